@@ -224,6 +224,10 @@ func (r *RedisCache) Flush(ctx context.Context) error {
 	return nil
 }
 
+func (r *RedisCache) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 func (r *RedisCache) Close() error {
 	return r.client.Close()
 }
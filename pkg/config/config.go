@@ -5,25 +5,35 @@ import (
 	"os"
 	"time"
 
+	"github.com/grigta/conveer/pkg/database"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	App          AppConfig
-	Database     DatabaseConfigNew
+	App      AppConfig
+	Database DatabaseConfigNew
 	// Legacy/top-level compatibility (config.yaml uses `redis.*` and `rabbitmq.*`)
-	Redis        RedisConfig
-	RabbitMQ     RabbitMQConfig
-	Cache        CacheConfig
-	MessageQueue MessageQueueConfig
-	Crypto       CryptoConfig
-	Services     ServicesConfig
-	Monitoring   MonitoringConfig
-	RateLimit    RateLimitConfig
-	Proxy        ProxyConfig
-	JWT          JWTConfig
-	Encryption   EncryptionConfig
-	SMS          SMSConfig
+	Redis         RedisConfig
+	RabbitMQ      RabbitMQConfig
+	Cache         CacheConfig
+	MessageQueue  MessageQueueConfig
+	Crypto        CryptoConfig
+	Services      ServicesConfig
+	Monitoring    MonitoringConfig
+	RateLimit     RateLimitConfig
+	Proxy         ProxyConfig
+	JWT           JWTConfig
+	Auth          AuthConfig
+	OIDC          OIDCConfig
+	ServiceAuth   ServiceAuthConfig
+	Encryption    EncryptionConfig
+	SMS           SMSConfig
+	APIVersioning APIVersioningConfig
+	ResponseCache ResponseCacheConfig
+	Shadow        ShadowConfig
+	GraphQL       GraphQLConfig
+	Secrets       SecretsConfig
+	ConfigWatch   ConfigWatchConfig
 }
 
 type AppConfig struct {
@@ -43,6 +53,8 @@ type DatabaseConfigNew struct {
 	URI    string
 	DBName string
 	MongoDB MongoDBConfig
+	// Пул соединений, read preference, write concern и таймауты клиента MongoDB
+	Options database.MongoOptions
 }
 
 type MongoDBConfig struct {
@@ -79,6 +91,58 @@ type JWTConfig struct {
 	ExpiresIn time.Duration
 }
 
+// AuthConfig политика блокировки при переборе паролей и хранение журнала аудита
+type AuthConfig struct {
+	LockoutThreshold int           // Неудачных входов подряд до блокировки, 0 отключает блокировку
+	LockoutWindow    time.Duration // Окно, в котором считаются неудачные входы
+	LockoutDuration  time.Duration // На сколько блокируется вход
+	AuditRetention   time.Duration // Сколько хранятся события аудита
+}
+
+// OIDCConfig включает режим OAuth2/OIDC-провайдера в auth-service для внутренних панелей.
+// Без ключа подписи он генерируется при старте, и токены перестают проверяться после перезапуска.
+type OIDCConfig struct {
+	Enabled        bool
+	Issuer         string        // Публичный URL auth-service, совпадает с iss в токенах
+	SigningKey     string        // RSA-ключ в PEM (PKCS#1 или PKCS#8) для подписи токенов
+	SigningKeyFile string        // Файл с ключом, если SigningKey не задан
+	AccessTokenTTL time.Duration // Срок жизни access token
+	IDTokenTTL     time.Duration // Срок жизни ID token
+	CodeTTL        time.Duration // Срок жизни кода авторизации
+	Clients        []OIDCClientConfig
+}
+
+// OIDCClientConfig панель, которой разрешён вход через auth-service.
+// Без секрета клиент считается публичным и защищается только PKCE.
+type OIDCClientConfig struct {
+	ID           string
+	Name         string
+	Secret       string
+	RedirectURIs []string // Допускается только точное совпадение redirect_uri
+}
+
+// ServiceAuthConfig выдача auth-service короткоживущих токенов сервисам для вызовов по gRPC.
+// Сервис получает токен, предъявив свой bootstrap-секрет; без перечисленных сервисов выдача выключена.
+type ServiceAuthConfig struct {
+	Issuer                 string        // Значение iss в токенах
+	SigningKey             string        // RSA-ключ в PEM (PKCS#1 или PKCS#8) для подписи токенов
+	SigningKeyFile         string        // Файл с ключом, если SigningKey не задан
+	PreviousSigningKey     string        // Прежний ключ при ротации: публикуется, пока не истекут подписанные им токены
+	PreviousSigningKeyFile string        // Файл с прежним ключом
+	TokenTTL               time.Duration // Срок жизни токена
+	Services               []ServiceIdentityConfig
+}
+
+// ServiceIdentityConfig сервис, которому выдаются токены, и сервисы, которые он может вызывать.
+// Скоуп — имя сервиса, имя с gRPC-методом ("proxy-service:/proxy.ProxyService/GetProxy")
+// или с префиксом метода ("proxy-service:/proxy.ProxyService/Get*").
+type ServiceIdentityConfig struct {
+	Name           string
+	Secret         string // Bootstrap-секрет сервиса
+	PreviousSecret string // Прежний секрет, принимается до конца ротации
+	Scopes         []string
+}
+
 type EncryptionConfig struct {
 	Key string
 }
@@ -97,6 +161,8 @@ type ServicesConfig struct {
 	MailServiceURL         string
 	MaxServiceURL          string
 	WarmingServiceURL      string
+	SchedulerServiceURL    string
+	InterventionServiceURL string
 }
 
 type SMSConfig struct {
@@ -112,6 +178,67 @@ type ProxyConfig struct {
 	MaxFailedChecks       int
 	IPQualityScoreAPIKey  string
 	ProviderConfigPath    string
+	// Проверка fraud-score: веса провайдеров вида "ipqs=0.5,scamalytics=0.3,heuristic=0.2"
+	ScamalyticsUser   string
+	ScamalyticsAPIKey string
+	FraudWeights      string
+	FraudCacheTTL     string
+	FraudHostingASNs  string // дополнительные ASN хостингов для эвристики, через запятую
+	// Проверка поддержки UDP/QUIC через выход прокси (нужна для MTProto)
+	CapabilityProbeInterval string
+	UDPProbeTarget          string // host:port DNS-сервера для проверки UDP ASSOCIATE
+	QUICProbeTarget         string // host:port QUIC-сервера для проверки version negotiation
+	// Отслеживание смены выходного IP мобильных прокси
+	ExitIPCheckInterval string
+	ExitIPCheckURL      string // сервис, возвращающий IP клиента (httpbin, ipify или plain text)
+	// Проверка задержки из ближайшего к прокси региона: эндпоинты вида "ru=http://...,eu=http://...",
+	// страны и платформы сопоставляются регионам парами "RU=ru,DE=eu" и "vk=ru,telegram=eu"
+	CheckEndpoints      string
+	CheckCountryRegions string
+	PlatformRegions     string
+	DefaultCheckRegion  string // регион для стран без сопоставления
+}
+
+// APIVersioningConfig управляет выводом из эксплуатации версий API в gateway.
+// Даты в формате YYYY-MM-DD.
+type APIVersioningConfig struct {
+	V1Deprecated   bool
+	V1DeprecatedAt string
+	V1Sunset       string
+	DocsURL        string
+}
+
+// ResponseCacheConfig включает кэширование ответов аналитики в gateway.
+// TTL задаётся для каждого маршрута, DefaultTTL используется, если маршрут его не указал.
+type ResponseCacheConfig struct {
+	Enabled    bool
+	DefaultTTL time.Duration
+}
+
+// ShadowConfig включает зеркалирование части GET-запросов gateway в staging.
+// Зеркальные запросы отправляются асинхронно и не влияют на ответ клиенту.
+type ShadowConfig struct {
+	Enabled     bool
+	TargetURL   string        // Базовый URL staging gateway
+	SampleRate  float64       // Доля зеркалируемых запросов, 0-1
+	Timeout     time.Duration // Таймаут зеркального запроса
+	MaxInFlight int           // Сверх этого числа одновременных запросов зеркалирование пропускается
+	Token       string        // Отдельный токен для staging, передаётся вместо учётных данных клиента
+}
+
+// GraphQLConfig включает эндпоинт /graphql в gateway.
+// Резолверы обращаются к сервисам по gRPC; адреса задаются в формате host:port.
+type GraphQLConfig struct {
+	Enabled              bool
+	VKServiceAddr        string
+	TelegramServiceAddr  string
+	MailServiceAddr      string
+	MaxServiceAddr       string
+	WarmingServiceAddr   string
+	AnalyticsServiceAddr string
+	ProxyServiceAddr     string
+	MaxDepth             int           // Максимальная вложенность полей запроса
+	ResolverTimeout      time.Duration // Таймаут одного вызова сервиса
 }
 
 type MonitoringConfig struct {
@@ -189,6 +316,7 @@ func getDefaultConfig() *Config {
 				URI:    "mongodb://localhost:27017",
 				DBName: "conveer",
 			},
+			Options: database.DefaultMongoOptions(),
 		},
 		Redis: RedisConfig{
 			Addr:     "localhost:6379",
@@ -218,11 +346,33 @@ func getDefaultConfig() *Config {
 			EncryptionKey: "",
 		},
 		Proxy: ProxyConfig{
-			HealthCheckInterval:   "15m",
-			RotationCheckInterval: "5m",
-			MaxFailedChecks:       3,
-			IPQualityScoreAPIKey:  "",
-			ProviderConfigPath:    "./configs/providers.yaml",
+			HealthCheckInterval:     "15m",
+			RotationCheckInterval:   "5m",
+			MaxFailedChecks:         3,
+			IPQualityScoreAPIKey:    "",
+			ProviderConfigPath:      "./configs/providers.yaml",
+			FraudCacheTTL:           "24h",
+			CapabilityProbeInterval: "24h",
+			UDPProbeTarget:          "8.8.8.8:53",
+			QUICProbeTarget:         "1.1.1.1:443",
+			ExitIPCheckInterval:     "5m",
+			ExitIPCheckURL:          "http://httpbin.org/ip",
+		},
+		Auth: AuthConfig{
+			LockoutThreshold: 5,
+			LockoutWindow:    15 * time.Minute,
+			LockoutDuration:  15 * time.Minute,
+			AuditRetention:   90 * 24 * time.Hour,
+		},
+		OIDC: OIDCConfig{
+			Issuer:         "http://localhost:8001",
+			AccessTokenTTL: time.Hour,
+			IDTokenTTL:     time.Hour,
+			CodeTTL:        time.Minute,
+		},
+		ServiceAuth: ServiceAuthConfig{
+			Issuer:   "http://localhost:8001",
+			TokenTTL: 15 * time.Minute,
 		},
 	}
 }
@@ -235,6 +385,10 @@ func setDefaults() {
 
 	viper.SetDefault("database.uri", "mongodb://localhost:27017")
 	viper.SetDefault("database.dbname", "conveer")
+	viper.SetDefault("database.options.maxpoolsize", 50)
+	viper.SetDefault("database.options.minpoolsize", 10)
+	viper.SetDefault("database.options.maxconnidletime", "5m")
+	viper.SetDefault("database.options.readpreference", "primary")
 
 	viper.SetDefault("redis.host", "localhost")
 	viper.SetDefault("redis.port", 6379)
@@ -244,6 +398,20 @@ func setDefaults() {
 
 	viper.SetDefault("jwt.expiresin", "24h")
 
+	viper.SetDefault("auth.lockoutthreshold", 5)
+	viper.SetDefault("auth.lockoutwindow", "15m")
+	viper.SetDefault("auth.lockoutduration", "15m")
+	viper.SetDefault("auth.auditretention", "2160h")
+
+	viper.SetDefault("oidc.enabled", false)
+	viper.SetDefault("oidc.issuer", "http://localhost:8001")
+	viper.SetDefault("oidc.accesstokenttl", "1h")
+	viper.SetDefault("oidc.idtokenttl", "1h")
+	viper.SetDefault("oidc.codettl", "1m")
+
+	viper.SetDefault("serviceauth.issuer", "http://localhost:8001")
+	viper.SetDefault("serviceauth.tokenttl", "15m")
+
 	viper.SetDefault("monitoring.prometheusport", 9090)
 	viper.SetDefault("monitoring.grafanaport", 3000)
 
@@ -255,6 +423,42 @@ func setDefaults() {
 	viper.SetDefault("sms.maxretryattempts", 3)
 	viper.SetDefault("sms.codewaittimeout", "5m")
 	viper.SetDefault("sms.activationexpiry", "30m")
+
+	viper.SetDefault("proxy.fraudcachettl", "24h")
+	viper.SetDefault("proxy.capabilityprobeinterval", "24h")
+	viper.SetDefault("proxy.udpprobetarget", "8.8.8.8:53")
+	viper.SetDefault("proxy.quicprobetarget", "1.1.1.1:443")
+	viper.SetDefault("proxy.exitipcheckinterval", "5m")
+	viper.SetDefault("proxy.exitipcheckurl", "http://httpbin.org/ip")
+
+	viper.SetDefault("apiversioning.v1deprecated", false)
+
+	viper.SetDefault("responsecache.enabled", false)
+	viper.SetDefault("responsecache.defaultttl", "60s")
+
+	viper.SetDefault("shadow.enabled", false)
+	viper.SetDefault("shadow.samplerate", 0.1)
+	viper.SetDefault("shadow.timeout", "10s")
+	viper.SetDefault("shadow.maxinflight", 50)
+
+	viper.SetDefault("graphql.enabled", false)
+	viper.SetDefault("graphql.vkserviceaddr", "vk-service:50059")
+	viper.SetDefault("graphql.telegramserviceaddr", "telegram-service:50060")
+	viper.SetDefault("graphql.mailserviceaddr", "mail-service:50061")
+	viper.SetDefault("graphql.maxserviceaddr", "max-service:50062")
+	viper.SetDefault("graphql.warmingserviceaddr", "warming-service:50063")
+	viper.SetDefault("graphql.analyticsserviceaddr", "analytics-service:50056")
+	viper.SetDefault("graphql.proxyserviceaddr", "proxy-service:50057")
+	viper.SetDefault("graphql.maxdepth", 6)
+	viper.SetDefault("graphql.resolvertimeout", "5s")
+
+	viper.SetDefault("secrets.watchinterval", "1m")
+	viper.SetDefault("secrets.vaultmount", "secret")
+
+	viper.SetDefault("configwatch.backend", ConfigBackendFile)
+	viper.SetDefault("configwatch.pollinterval", "30s")
+	viper.SetDefault("configwatch.consulprefix", "conveer")
+	viper.SetDefault("configwatch.etcdprefix", "conveer")
 }
 
 func bindEnvVariables() {
@@ -265,6 +469,15 @@ func bindEnvVariables() {
 
 	viper.BindEnv("database.uri", "MONGO_URI")
 	viper.BindEnv("database.dbname", "MONGO_DB_NAME")
+	viper.BindEnv("database.options.maxpoolsize", "MONGODB_MAX_POOL_SIZE")
+	viper.BindEnv("database.options.minpoolsize", "MONGODB_MIN_POOL_SIZE")
+	viper.BindEnv("database.options.maxconnidletime", "MONGODB_MAX_CONN_IDLE_TIME")
+	viper.BindEnv("database.options.readpreference", "MONGODB_READ_PREFERENCE")
+	viper.BindEnv("database.options.writeconcern", "MONGODB_WRITE_CONCERN")
+	viper.BindEnv("database.options.writetimeout", "MONGODB_WRITE_TIMEOUT")
+	viper.BindEnv("database.options.connecttimeout", "MONGODB_CONNECT_TIMEOUT")
+	viper.BindEnv("database.options.serverselectiontimeout", "MONGODB_SERVER_SELECTION_TIMEOUT")
+	viper.BindEnv("database.options.sockettimeout", "MONGODB_SOCKET_TIMEOUT")
 
 	viper.BindEnv("redis.host", "REDIS_HOST")
 	viper.BindEnv("redis.port", "REDIS_PORT")
@@ -276,6 +489,26 @@ func bindEnvVariables() {
 	viper.BindEnv("jwt.secret", "JWT_SECRET")
 	viper.BindEnv("jwt.expiresin", "JWT_EXPIRES_IN")
 
+	viper.BindEnv("auth.lockoutthreshold", "AUTH_LOCKOUT_THRESHOLD")
+	viper.BindEnv("auth.lockoutwindow", "AUTH_LOCKOUT_WINDOW")
+	viper.BindEnv("auth.lockoutduration", "AUTH_LOCKOUT_DURATION")
+	viper.BindEnv("auth.auditretention", "AUTH_AUDIT_RETENTION")
+
+	viper.BindEnv("oidc.enabled", "OIDC_ENABLED")
+	viper.BindEnv("oidc.issuer", "OIDC_ISSUER")
+	viper.BindEnv("oidc.signingkey", "OIDC_SIGNING_KEY")
+	viper.BindEnv("oidc.signingkeyfile", "OIDC_SIGNING_KEY_FILE")
+	viper.BindEnv("oidc.accesstokenttl", "OIDC_ACCESS_TOKEN_TTL")
+	viper.BindEnv("oidc.idtokenttl", "OIDC_ID_TOKEN_TTL")
+	viper.BindEnv("oidc.codettl", "OIDC_CODE_TTL")
+
+	viper.BindEnv("serviceauth.issuer", "SERVICE_AUTH_ISSUER")
+	viper.BindEnv("serviceauth.signingkey", "SERVICE_AUTH_SIGNING_KEY")
+	viper.BindEnv("serviceauth.signingkeyfile", "SERVICE_AUTH_SIGNING_KEY_FILE")
+	viper.BindEnv("serviceauth.previoussigningkey", "SERVICE_AUTH_PREVIOUS_SIGNING_KEY")
+	viper.BindEnv("serviceauth.previoussigningkeyfile", "SERVICE_AUTH_PREVIOUS_SIGNING_KEY_FILE")
+	viper.BindEnv("serviceauth.tokenttl", "SERVICE_AUTH_TOKEN_TTL")
+
 	viper.BindEnv("encryption.key", "ENCRYPTION_KEY")
 
 	viper.BindEnv("services.authserviceurl", "AUTH_SERVICE_URL")
@@ -289,6 +522,8 @@ func bindEnvVariables() {
 	viper.BindEnv("services.vkserviceurl", "VK_SERVICE_HTTP_URL")
 	viper.BindEnv("services.telegramserviceurl", "TELEGRAM_SERVICE_HTTP_URL")
 	viper.BindEnv("services.warmingserviceurl", "WARMING_SERVICE_HTTP_URL")
+	viper.BindEnv("services.schedulerserviceurl", "SCHEDULER_SERVICE_HTTP_URL")
+	viper.BindEnv("services.interventionserviceurl", "INTERVENTION_SERVICE_HTTP_URL")
 
 	viper.BindEnv("sms.providerconfigpath", "SMS_PROVIDER_CONFIG_PATH")
 	viper.BindEnv("sms.maxretryattempts", "SMS_MAX_RETRY_ATTEMPTS")
@@ -300,6 +535,20 @@ func bindEnvVariables() {
 	viper.BindEnv("proxy.maxfailedchecks", "PROXY_MAX_FAILED_CHECKS")
 	viper.BindEnv("proxy.ipqualityscoreapikey", "IPQS_API_KEY")
 	viper.BindEnv("proxy.providerconfigpath", "PROXY_PROVIDER_CONFIG_PATH")
+	viper.BindEnv("proxy.scamalyticsuser", "SCAMALYTICS_USER")
+	viper.BindEnv("proxy.scamalyticsapikey", "SCAMALYTICS_API_KEY")
+	viper.BindEnv("proxy.fraudweights", "PROXY_FRAUD_WEIGHTS")
+	viper.BindEnv("proxy.fraudcachettl", "PROXY_FRAUD_CACHE_TTL")
+	viper.BindEnv("proxy.fraudhostingasns", "PROXY_FRAUD_HOSTING_ASNS")
+	viper.BindEnv("proxy.capabilityprobeinterval", "PROXY_CAPABILITY_PROBE_INTERVAL")
+	viper.BindEnv("proxy.udpprobetarget", "PROXY_UDP_PROBE_TARGET")
+	viper.BindEnv("proxy.quicprobetarget", "PROXY_QUIC_PROBE_TARGET")
+	viper.BindEnv("proxy.exitipcheckinterval", "PROXY_EXIT_IP_CHECK_INTERVAL")
+	viper.BindEnv("proxy.exitipcheckurl", "PROXY_EXIT_IP_CHECK_URL")
+	viper.BindEnv("proxy.checkendpoints", "PROXY_CHECK_ENDPOINTS")
+	viper.BindEnv("proxy.checkcountryregions", "PROXY_CHECK_COUNTRY_REGIONS")
+	viper.BindEnv("proxy.platformregions", "PROXY_PLATFORM_REGIONS")
+	viper.BindEnv("proxy.defaultcheckregion", "PROXY_DEFAULT_CHECK_REGION")
 
 	viper.BindEnv("monitoring.prometheusport", "PROMETHEUS_PORT")
 	viper.BindEnv("monitoring.grafanaport", "GRAFANA_PORT")
@@ -307,6 +556,49 @@ func bindEnvVariables() {
 	viper.BindEnv("ratelimit.enabled", "RATE_LIMIT_ENABLED")
 	viper.BindEnv("ratelimit.requests", "RATE_LIMIT_REQUESTS")
 	viper.BindEnv("ratelimit.window", "RATE_LIMIT_WINDOW")
+
+	viper.BindEnv("apiversioning.v1deprecated", "API_V1_DEPRECATED")
+	viper.BindEnv("apiversioning.v1deprecatedat", "API_V1_DEPRECATED_AT")
+	viper.BindEnv("apiversioning.v1sunset", "API_V1_SUNSET")
+	viper.BindEnv("apiversioning.docsurl", "API_VERSIONING_DOCS_URL")
+
+	viper.BindEnv("responsecache.enabled", "GATEWAY_CACHE_ENABLED")
+	viper.BindEnv("responsecache.defaultttl", "GATEWAY_CACHE_DEFAULT_TTL")
+
+	viper.BindEnv("shadow.enabled", "GATEWAY_SHADOW_ENABLED")
+	viper.BindEnv("shadow.targeturl", "GATEWAY_SHADOW_URL")
+	viper.BindEnv("shadow.samplerate", "GATEWAY_SHADOW_SAMPLE_RATE")
+	viper.BindEnv("shadow.timeout", "GATEWAY_SHADOW_TIMEOUT")
+	viper.BindEnv("shadow.maxinflight", "GATEWAY_SHADOW_MAX_IN_FLIGHT")
+	viper.BindEnv("shadow.token", "GATEWAY_SHADOW_TOKEN")
+
+	viper.BindEnv("graphql.enabled", "GATEWAY_GRAPHQL_ENABLED")
+	viper.BindEnv("graphql.vkserviceaddr", "VK_SERVICE_GRPC_URL")
+	viper.BindEnv("graphql.telegramserviceaddr", "TELEGRAM_SERVICE_GRPC_URL")
+	viper.BindEnv("graphql.mailserviceaddr", "MAIL_SERVICE_GRPC_URL")
+	viper.BindEnv("graphql.maxserviceaddr", "MAX_SERVICE_GRPC_URL")
+	viper.BindEnv("graphql.warmingserviceaddr", "WARMING_SERVICE_GRPC_URL")
+	viper.BindEnv("graphql.analyticsserviceaddr", "ANALYTICS_SERVICE_GRPC_URL")
+	viper.BindEnv("graphql.proxyserviceaddr", "PROXY_SERVICE_GRPC_URL")
+	viper.BindEnv("graphql.maxdepth", "GATEWAY_GRAPHQL_MAX_DEPTH")
+	viper.BindEnv("graphql.resolvertimeout", "GATEWAY_GRAPHQL_RESOLVER_TIMEOUT")
+
+	viper.BindEnv("secrets.watchinterval", "SECRETS_WATCH_INTERVAL")
+	viper.BindEnv("secrets.file", "SECRETS_FILE")
+	viper.BindEnv("secrets.envkeys", "SECRETS_ENV_KEYS")
+	viper.BindEnv("secrets.vaultaddr", "VAULT_ADDR")
+	viper.BindEnv("secrets.vaulttoken", "VAULT_TOKEN")
+	viper.BindEnv("secrets.vaultmount", "VAULT_MOUNT")
+	viper.BindEnv("secrets.vaultpath", "VAULT_SECRET_PATH")
+	viper.BindEnv("secrets.exportenv", "SECRETS_EXPORT_ENV")
+
+	viper.BindEnv("configwatch.backend", "CONFIG_BACKEND")
+	viper.BindEnv("configwatch.pollinterval", "CONFIG_POLL_INTERVAL")
+	viper.BindEnv("configwatch.consuladdr", "CONSUL_HTTP_ADDR")
+	viper.BindEnv("configwatch.consultoken", "CONSUL_HTTP_TOKEN")
+	viper.BindEnv("configwatch.consulprefix", "CONFIG_CONSUL_PREFIX")
+	viper.BindEnv("configwatch.etcdendpoint", "ETCD_ENDPOINT")
+	viper.BindEnv("configwatch.etcdprefix", "CONFIG_ETCD_PREFIX")
 }
 
 func GetEnv(key, defaultValue string) string {
@@ -0,0 +1,518 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+)
+
+var (
+	ErrSecretsRolledBack = errors.New("secrets re-initialization failed, previous secrets restored")
+)
+
+// SecretsConfig настройки наблюдения за секретами.
+// Пустые источники не используются.
+type SecretsConfig struct {
+	WatchInterval time.Duration
+	File          string
+	EnvKeys       []string
+	VaultAddr     string
+	VaultToken    string
+	VaultMount    string
+	VaultPath     string
+	ExportEnv     bool
+}
+
+// Enabled сообщает, настроен ли хотя бы один источник секретов
+func (c SecretsConfig) Enabled() bool {
+	return c.File != "" || len(c.EnvKeys) > 0 || (c.VaultAddr != "" && c.VaultPath != "")
+}
+
+// SecretSource источник секретов (файл, окружение, Vault)
+type SecretSource interface {
+	Name() string
+	Load(ctx context.Context) (map[string]string, error)
+}
+
+// ReloadHook пересоздает зависимость (подключение к Mongo, адаптеры провайдеров) под новые секреты.
+// При ошибке хук должен оставить прежнее состояние; успешно примененные хуки
+// при откате вызываются повторно с предыдущими значениями.
+type ReloadHook func(ctx context.Context, secrets map[string]string) error
+
+type secretHook struct {
+	name string
+	keys []string
+	fn   ReloadHook
+}
+
+// SecretsWatcher периодически перечитывает источники секретов и при изменении
+// вызывает зарегистрированные хуки без перезапуска сервиса. Если хук возвращает
+// ошибку, уже примененные хуки откатываются к предыдущим значениям.
+type SecretsWatcher struct {
+	sources   []SecretSource
+	interval  time.Duration
+	exportEnv bool
+
+	mu       sync.RWMutex
+	current  map[string]string
+	rejected string
+	hooks    []secretHook
+
+	applyMu  sync.Mutex
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewSecretsWatcher создает наблюдателя. Источники объединяются по порядку,
+// значения из последующих источников перекрывают предыдущие.
+func NewSecretsWatcher(interval time.Duration, sources ...SecretSource) *SecretsWatcher {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &SecretsWatcher{
+		sources:  sources,
+		interval: interval,
+		current:  make(map[string]string),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// NewSecretsWatcherFromConfig создает наблюдателя по настройкам из конфига
+func NewSecretsWatcherFromConfig(cfg SecretsConfig) *SecretsWatcher {
+	var sources []SecretSource
+
+	if len(cfg.EnvKeys) > 0 {
+		sources = append(sources, &EnvSecretSource{Keys: cfg.EnvKeys})
+	}
+	if cfg.File != "" {
+		sources = append(sources, &FileSecretSource{Path: cfg.File})
+	}
+	if cfg.VaultAddr != "" && cfg.VaultPath != "" {
+		vault := NewVaultSecretSource(cfg.VaultAddr, cfg.VaultToken, cfg.VaultPath)
+		if cfg.VaultMount != "" {
+			vault.Mount = cfg.VaultMount
+		}
+		sources = append(sources, vault)
+	}
+
+	watcher := NewSecretsWatcher(cfg.WatchInterval, sources...)
+	watcher.SetExportEnv(cfg.ExportEnv)
+	return watcher
+}
+
+// SetExportEnv включает запись секретов в окружение процесса.
+// Нужно для конфигов, которые подставляют ${VAR} через os.ExpandEnv.
+func (w *SecretsWatcher) SetExportEnv(enabled bool) {
+	w.exportEnv = enabled
+}
+
+// OnChange регистрирует хук, вызываемый при изменении любого из ключей
+func (w *SecretsWatcher) OnChange(name string, keys []string, hook ReloadHook) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.hooks = append(w.hooks, secretHook{name: name, keys: keys, fn: hook})
+}
+
+// Load выполняет первичную загрузку секретов без вызова хуков
+func (w *SecretsWatcher) Load(ctx context.Context) error {
+	secrets, err := w.loadSources(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.applyMu.Lock()
+	defer w.applyMu.Unlock()
+
+	if w.exportEnv {
+		exportEnv(nil, secrets)
+	}
+
+	w.mu.Lock()
+	w.current = secrets
+	w.mu.Unlock()
+
+	return nil
+}
+
+// Get возвращает текущее значение секрета
+func (w *SecretsWatcher) Get(key string) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.current[key]
+}
+
+// Snapshot возвращает копию текущих секретов
+func (w *SecretsWatcher) Snapshot() map[string]string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return copySecrets(w.current)
+}
+
+// Start запускает периодическую проверку источников
+func (w *SecretsWatcher) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.stopChan:
+				return
+			case <-ticker.C:
+				if err := w.Check(ctx); err != nil {
+					logger.Error("Secrets check failed", logger.Field{Key: "error", Value: err.Error()})
+				}
+			}
+		}
+	}()
+}
+
+// Stop останавливает наблюдение
+func (w *SecretsWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopChan)
+	})
+	w.wg.Wait()
+}
+
+// Check перечитывает источники и применяет изменения.
+// Набор значений, на котором re-init уже падал, повторно не применяется до следующего изменения.
+func (w *SecretsWatcher) Check(ctx context.Context) error {
+	next, err := w.loadSources(ctx)
+	if err != nil {
+		// Недоступный источник не означает, что секреты удалены
+		return err
+	}
+
+	w.applyMu.Lock()
+	defer w.applyMu.Unlock()
+
+	w.mu.RLock()
+	previous := copySecrets(w.current)
+	rejected := w.rejected
+	hooks := append([]secretHook(nil), w.hooks...)
+	w.mu.RUnlock()
+
+	changed := changedKeys(previous, next)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	fingerprint := secretsFingerprint(next)
+	if fingerprint == rejected {
+		return nil
+	}
+
+	logger.Info("Secrets changed", logger.Field{Key: "keys", Value: strings.Join(changed, ",")})
+
+	if w.exportEnv {
+		exportEnv(previous, next)
+	}
+
+	var applied []secretHook
+	for _, hook := range hooks {
+		if !hookAffected(hook, changed) {
+			continue
+		}
+
+		if err := hook.fn(ctx, copySecrets(next)); err != nil {
+			logger.Error("Secrets re-init hook failed, rolling back",
+				logger.Field{Key: "hook", Value: hook.name},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+			w.rollback(ctx, applied, previous, next)
+
+			w.mu.Lock()
+			w.rejected = fingerprint
+			w.mu.Unlock()
+
+			return fmt.Errorf("%w: hook %s: %v", ErrSecretsRolledBack, hook.name, err)
+		}
+		applied = append(applied, hook)
+	}
+
+	w.mu.Lock()
+	w.current = next
+	w.rejected = ""
+	w.mu.Unlock()
+
+	return nil
+}
+
+// rollback возвращает окружение и уже примененные хуки к предыдущим секретам в обратном порядке
+func (w *SecretsWatcher) rollback(ctx context.Context, applied []secretHook, previous, next map[string]string) {
+	if w.exportEnv {
+		exportEnv(next, previous)
+	}
+
+	for i := len(applied) - 1; i >= 0; i-- {
+		hook := applied[i]
+		if err := hook.fn(ctx, copySecrets(previous)); err != nil {
+			logger.Error("Secrets rollback hook failed",
+				logger.Field{Key: "hook", Value: hook.name},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+		}
+	}
+}
+
+func (w *SecretsWatcher) loadSources(ctx context.Context) (map[string]string, error) {
+	secrets := make(map[string]string)
+	for _, source := range w.sources {
+		values, err := source.Load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load secrets from %s: %w", source.Name(), err)
+		}
+		for k, v := range values {
+			secrets[k] = v
+		}
+	}
+	return secrets, nil
+}
+
+func hookAffected(hook secretHook, changed []string) bool {
+	if len(hook.keys) == 0 {
+		return true
+	}
+	for _, key := range hook.keys {
+		for _, c := range changed {
+			if key == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func changedKeys(previous, next map[string]string) []string {
+	var changed []string
+	for k, v := range next {
+		if old, ok := previous[k]; !ok || old != v {
+			changed = append(changed, k)
+		}
+	}
+	for k := range previous {
+		if _, ok := next[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func secretsFingerprint(secrets map[string]string) string {
+	keys := make([]string, 0, len(secrets))
+	for k := range secrets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(secrets[k])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func exportEnv(previous, next map[string]string) {
+	for k, v := range next {
+		os.Setenv(k, v)
+	}
+	for k := range previous {
+		if _, ok := next[k]; !ok {
+			os.Unsetenv(k)
+		}
+	}
+}
+
+func copySecrets(secrets map[string]string) map[string]string {
+	result := make(map[string]string, len(secrets))
+	for k, v := range secrets {
+		result[k] = v
+	}
+	return result
+}
+
+// FileSecretSource читает секреты из файла KEY=VALUE или из каталога,
+// где имя файла — ключ, а содержимое — значение (Docker/Kubernetes secrets)
+type FileSecretSource struct {
+	Path string
+}
+
+func (s *FileSecretSource) Name() string {
+	return "file:" + s.Path
+}
+
+func (s *FileSecretSource) Load(ctx context.Context) (map[string]string, error) {
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return s.loadDir()
+	}
+	return s.loadFile()
+}
+
+func (s *FileSecretSource) loadDir() (map[string]string, error) {
+	entries, err := os.ReadDir(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string]string)
+	for _, entry := range entries {
+		// Kubernetes монтирует секреты через скрытые ..data симлинки
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.Path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		secrets[entry.Name()] = strings.TrimRight(string(data), "\r\n")
+	}
+	return secrets, nil
+}
+
+func (s *FileSecretSource) loadFile() (map[string]string, error) {
+	file, err := os.Open(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	secrets := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(strings.TrimPrefix(key, "export "))
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		secrets[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+// EnvSecretSource читает перечисленные ключи из окружения процесса.
+// Отсутствующие переменные пропускаются.
+type EnvSecretSource struct {
+	Keys []string
+}
+
+func (s *EnvSecretSource) Name() string {
+	return "env"
+}
+
+func (s *EnvSecretSource) Load(ctx context.Context) (map[string]string, error) {
+	secrets := make(map[string]string)
+	for _, key := range s.Keys {
+		if value, ok := os.LookupEnv(key); ok {
+			secrets[key] = value
+		}
+	}
+	return secrets, nil
+}
+
+// VaultSecretSource читает секреты из HashiCorp Vault KV v2
+type VaultSecretSource struct {
+	Address string
+	Token   string
+	Mount   string
+	Path    string
+	Client  *http.Client
+}
+
+func NewVaultSecretSource(address, token, path string) *VaultSecretSource {
+	return &VaultSecretSource{
+		Address: strings.TrimRight(address, "/"),
+		Token:   token,
+		Mount:   "secret",
+		Path:    strings.Trim(path, "/"),
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *VaultSecretSource) Name() string {
+	return "vault:" + s.Mount + "/" + s.Path
+}
+
+func (s *VaultSecretSource) Load(ctx context.Context) (map[string]string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", s.Address, s.Mount, s.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", s.Token)
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	secrets := make(map[string]string, len(body.Data.Data))
+	for k, v := range body.Data.Data {
+		if str, ok := v.(string); ok {
+			secrets[k] = str
+		} else {
+			secrets[k] = fmt.Sprint(v)
+		}
+	}
+	return secrets, nil
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticSource struct {
+	values map[string]string
+	err    error
+}
+
+func (s *staticSource) Name() string {
+	return "static"
+}
+
+func (s *staticSource) Load(ctx context.Context) (map[string]string, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return copySecrets(s.values), nil
+}
+
+func TestSecretsWatcherInvokesAffectedHooks(t *testing.T) {
+	ctx := context.Background()
+	source := &staticSource{values: map[string]string{"MONGO_URI": "mongodb://a", "API_KEY": "k1"}}
+
+	watcher := NewSecretsWatcher(0, source)
+	require.NoError(t, watcher.Load(ctx))
+
+	var mongoCalls, apiCalls []string
+	watcher.OnChange("mongo", []string{"MONGO_URI"}, func(ctx context.Context, secrets map[string]string) error {
+		mongoCalls = append(mongoCalls, secrets["MONGO_URI"])
+		return nil
+	})
+	watcher.OnChange("api", []string{"API_KEY"}, func(ctx context.Context, secrets map[string]string) error {
+		apiCalls = append(apiCalls, secrets["API_KEY"])
+		return nil
+	})
+
+	require.NoError(t, watcher.Check(ctx))
+	assert.Empty(t, mongoCalls)
+	assert.Empty(t, apiCalls)
+
+	source.values["API_KEY"] = "k2"
+	require.NoError(t, watcher.Check(ctx))
+	assert.Empty(t, mongoCalls)
+	assert.Equal(t, []string{"k2"}, apiCalls)
+	assert.Equal(t, "k2", watcher.Get("API_KEY"))
+}
+
+func TestSecretsWatcherRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	source := &staticSource{values: map[string]string{"MONGO_URI": "mongodb://a", "API_KEY": "k1"}}
+
+	watcher := NewSecretsWatcher(0, source)
+	require.NoError(t, watcher.Load(ctx))
+
+	var mongoCalls []string
+	failures := 0
+	watcher.OnChange("mongo", []string{"MONGO_URI"}, func(ctx context.Context, secrets map[string]string) error {
+		mongoCalls = append(mongoCalls, secrets["MONGO_URI"])
+		return nil
+	})
+	watcher.OnChange("api", []string{"API_KEY"}, func(ctx context.Context, secrets map[string]string) error {
+		if secrets["API_KEY"] == "broken" {
+			failures++
+			return errors.New("invalid key")
+		}
+		return nil
+	})
+
+	source.values["MONGO_URI"] = "mongodb://b"
+	source.values["API_KEY"] = "broken"
+
+	err := watcher.Check(ctx)
+	assert.ErrorIs(t, err, ErrSecretsRolledBack)
+	assert.Equal(t, []string{"mongodb://b", "mongodb://a"}, mongoCalls)
+	assert.Equal(t, "mongodb://a", watcher.Get("MONGO_URI"))
+	assert.Equal(t, "k1", watcher.Get("API_KEY"))
+
+	// The rejected set is not retried until the secrets change again
+	require.NoError(t, watcher.Check(ctx))
+	assert.Equal(t, 1, failures)
+
+	source.values["API_KEY"] = "k2"
+	require.NoError(t, watcher.Check(ctx))
+	assert.Equal(t, "mongodb://b", watcher.Get("MONGO_URI"))
+	assert.Equal(t, "k2", watcher.Get("API_KEY"))
+}
+
+func TestSecretsWatcherKeepsSecretsWhenSourceFails(t *testing.T) {
+	ctx := context.Background()
+	source := &staticSource{values: map[string]string{"API_KEY": "k1"}}
+
+	watcher := NewSecretsWatcher(0, source)
+	require.NoError(t, watcher.Load(ctx))
+
+	called := false
+	watcher.OnChange("api", nil, func(ctx context.Context, secrets map[string]string) error {
+		called = true
+		return nil
+	})
+
+	source.err = errors.New("unavailable")
+	assert.Error(t, watcher.Check(ctx))
+	assert.False(t, called)
+	assert.Equal(t, "k1", watcher.Get("API_KEY"))
+}
+
+func TestSecretsWatcherExportsEnv(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("CONVEER_TEST_SECRET", "")
+	source := &staticSource{values: map[string]string{"CONVEER_TEST_SECRET": "v1"}}
+
+	watcher := NewSecretsWatcher(0, source)
+	watcher.SetExportEnv(true)
+	require.NoError(t, watcher.Load(ctx))
+	assert.Equal(t, "v1", os.Getenv("CONVEER_TEST_SECRET"))
+
+	watcher.OnChange("fail", nil, func(ctx context.Context, secrets map[string]string) error {
+		assert.Equal(t, "v2", os.Getenv("CONVEER_TEST_SECRET"))
+		return errors.New("fail")
+	})
+
+	source.values["CONVEER_TEST_SECRET"] = "v2"
+	assert.Error(t, watcher.Check(ctx))
+	assert.Equal(t, "v1", os.Getenv("CONVEER_TEST_SECRET"))
+}
+
+func TestFileSecretSource(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	envFile := filepath.Join(dir, "secrets.env")
+	require.NoError(t, os.WriteFile(envFile, []byte("# comment\nMONGO_URI=mongodb://a\nexport API_KEY=\"k1\"\n"), 0600))
+
+	values, err := (&FileSecretSource{Path: envFile}).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"MONGO_URI": "mongodb://a", "API_KEY": "k1"}, values)
+
+	secretsDir := filepath.Join(dir, "run")
+	require.NoError(t, os.Mkdir(secretsDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(secretsDir, "JWT_SECRET"), []byte("s3cret\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(secretsDir, ".hidden"), []byte("x"), 0600))
+
+	values, err = (&FileSecretSource{Path: secretsDir}).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"JWT_SECRET": "s3cret"}, values)
+}
+
+func TestVaultSecretSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		assert.Equal(t, "/v1/secret/data/conveer/proxy", r.URL.Path)
+		w.Write([]byte(`{"data":{"data":{"IPQS_API_KEY":"abc","PORT":5432}}}`))
+	}))
+	defer server.Close()
+
+	values, err := NewVaultSecretSource(server.URL, "token", "/conveer/proxy/").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"IPQS_API_KEY": "abc", "PORT": "5432"}, values)
+
+	_, err = NewVaultSecretSource(server.URL, "wrong", "conveer/proxy").Load(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,369 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeType тип изменения ключа конфигурации
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeUpdated ChangeType = "updated"
+	ChangeRemoved ChangeType = "removed"
+)
+
+// ChangeEvent изменение одного ключа. Key — путь через точку,
+// например warming.scheduler.max_concurrent_tasks. Списки сравниваются целиком.
+type ChangeEvent struct {
+	Source   string
+	Key      string
+	Type     ChangeType
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// Int возвращает новое значение как целое число
+func (e ChangeEvent) Int() (int, bool) {
+	switch v := e.NewValue.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), v == float64(int(v))
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// Float возвращает новое значение как число с плавающей точкой
+func (e ChangeEvent) Float() (float64, bool) {
+	switch v := e.NewValue.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// Bool возвращает новое значение как bool
+func (e ChangeEvent) Bool() (bool, bool) {
+	switch v := e.NewValue.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+// String возвращает новое значение как строку, пустую для удаленного ключа
+func (e ChangeEvent) String() string {
+	if e.NewValue == nil {
+		return ""
+	}
+	if s, ok := e.NewValue.(string); ok {
+		return s
+	}
+	return fmt.Sprint(e.NewValue)
+}
+
+// Duration возвращает новое значение как time.Duration ("30s", "5m")
+func (e ChangeEvent) Duration() (time.Duration, bool) {
+	d, err := time.ParseDuration(e.String())
+	return d, err == nil
+}
+
+// ChangeSet изменения одной перезагрузки, отфильтрованные по префиксам подписчика.
+// Data — полный новый документ, чтобы сервис мог заново разобрать свою структуру.
+type ChangeSet struct {
+	Source string
+	Events []ChangeEvent
+	Data   []byte
+}
+
+// Has сообщает, изменился ли ключ или что-либо под ним
+func (c ChangeSet) Has(prefix string) bool {
+	for _, event := range c.Events {
+		if matchesPrefix(event.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get возвращает изменение конкретного ключа
+func (c ChangeSet) Get(key string) (ChangeEvent, bool) {
+	for _, event := range c.Events {
+		if event.Key == key {
+			return event, true
+		}
+	}
+	return ChangeEvent{}, false
+}
+
+// Decode разбирает новый документ в структуру с yaml-тегами
+func (c ChangeSet) Decode(out interface{}) error {
+	return yaml.Unmarshal(c.Data, out)
+}
+
+// ChangeHandler применяет изменения конфигурации.
+// Ошибка логируется, остальные подписчики все равно получают изменения.
+type ChangeHandler func(ctx context.Context, changes ChangeSet) error
+
+// ConfigSource источник документа конфигурации (файл, Consul, etcd).
+// Watch блокируется до отмены ctx и вызывает notify, когда документ мог измениться.
+type ConfigSource interface {
+	Name() string
+	Read(ctx context.Context) ([]byte, error)
+	Watch(ctx context.Context, notify func()) error
+}
+
+type configSubscriber struct {
+	name     string
+	prefixes []string
+	fn       ChangeHandler
+}
+
+// ConfigWatcher следит за YAML-документом конфигурации и рассылает подписчикам
+// типизированные события изменения ключей без перезапуска сервиса.
+// Если новый документ не читается или не разбирается, остается предыдущий.
+type ConfigWatcher struct {
+	source ConfigSource
+
+	mu          sync.RWMutex
+	data        []byte
+	values      map[string]interface{}
+	subscribers []configSubscriber
+
+	reloadMu sync.Mutex
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewConfigWatcher(source ConfigSource) *ConfigWatcher {
+	return &ConfigWatcher{
+		source: source,
+		values: make(map[string]interface{}),
+	}
+}
+
+// Subscribe регистрирует обработчик изменений ключей с указанными префиксами.
+// Пустой список префиксов означает любые изменения.
+func (w *ConfigWatcher) Subscribe(name string, prefixes []string, handler ChangeHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, configSubscriber{name: name, prefixes: prefixes, fn: handler})
+}
+
+// Load выполняет первичную загрузку без вызова подписчиков
+func (w *ConfigWatcher) Load(ctx context.Context) error {
+	data, values, err := w.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.data = data
+	w.values = values
+	w.mu.Unlock()
+
+	return nil
+}
+
+// Data возвращает текущий документ
+func (w *ConfigWatcher) Data() []byte {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return append([]byte(nil), w.data...)
+}
+
+// Decode разбирает текущий документ в структуру с yaml-тегами
+func (w *ConfigWatcher) Decode(out interface{}) error {
+	return yaml.Unmarshal(w.Data(), out)
+}
+
+// Start запускает наблюдение за источником
+func (w *ConfigWatcher) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		notify := func() {
+			if err := w.Reload(ctx); err != nil {
+				logger.Error("Config reload failed",
+					logger.Field{Key: "source", Value: w.source.Name()},
+					logger.Field{Key: "error", Value: err.Error()},
+				)
+			}
+		}
+
+		if err := w.source.Watch(ctx, notify); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error("Config watch stopped",
+				logger.Field{Key: "source", Value: w.source.Name()},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+		}
+	}()
+}
+
+// Stop останавливает наблюдение
+func (w *ConfigWatcher) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+}
+
+// Reload перечитывает источник и рассылает изменения подписчикам
+func (w *ConfigWatcher) Reload(ctx context.Context) error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	data, values, err := w.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	events := diffValues(w.source.Name(), w.values, values)
+	w.data = data
+	w.values = values
+	subscribers := append([]configSubscriber(nil), w.subscribers...)
+	w.mu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	logger.Info("Configuration changed",
+		logger.Field{Key: "source", Value: w.source.Name()},
+		logger.Field{Key: "keys", Value: len(events)},
+	)
+
+	var failed []string
+	for _, subscriber := range subscribers {
+		matched := filterEvents(events, subscriber.prefixes)
+		if len(matched) == 0 {
+			continue
+		}
+
+		changes := ChangeSet{Source: w.source.Name(), Events: matched, Data: data}
+		if err := subscriber.fn(ctx, changes); err != nil {
+			logger.Error("Config subscriber failed",
+				logger.Field{Key: "subscriber", Value: subscriber.name},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+			failed = append(failed, subscriber.name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to apply config changes in %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (w *ConfigWatcher) read(ctx context.Context) ([]byte, map[string]interface{}, error) {
+	data, err := w.source.Read(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config from %s: %w", w.source.Name(), err)
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config from %s: %w", w.source.Name(), err)
+	}
+
+	values := make(map[string]interface{})
+	flattenValues("", document, values)
+	return data, values, nil
+}
+
+// flattenValues раскладывает вложенные map в ключи через точку
+func flattenValues(prefix string, value interface{}, out map[string]interface{}) {
+	node, ok := value.(map[string]interface{})
+	if !ok {
+		if prefix != "" {
+			out[prefix] = value
+		}
+		return
+	}
+
+	for key, child := range node {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		flattenValues(key, child, out)
+	}
+}
+
+func diffValues(source string, oldValues, newValues map[string]interface{}) []ChangeEvent {
+	var events []ChangeEvent
+
+	for key, newValue := range newValues {
+		oldValue, ok := oldValues[key]
+		switch {
+		case !ok:
+			events = append(events, ChangeEvent{Source: source, Key: key, Type: ChangeAdded, NewValue: newValue})
+		case !reflect.DeepEqual(oldValue, newValue):
+			events = append(events, ChangeEvent{Source: source, Key: key, Type: ChangeUpdated, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	for key, oldValue := range oldValues {
+		if _, ok := newValues[key]; !ok {
+			events = append(events, ChangeEvent{Source: source, Key: key, Type: ChangeRemoved, OldValue: oldValue})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Key < events[j].Key
+	})
+	return events
+}
+
+func filterEvents(events []ChangeEvent, prefixes []string) []ChangeEvent {
+	if len(prefixes) == 0 {
+		return events
+	}
+
+	var matched []ChangeEvent
+	for _, event := range events {
+		for _, prefix := range prefixes {
+			if matchesPrefix(event.Key, prefix) {
+				matched = append(matched, event)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func matchesPrefix(key, prefix string) bool {
+	return key == prefix || strings.HasPrefix(key, prefix+".")
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/grigta/conveer/pkg/logger"
+)
+
+// fileChangeDebounce склеивает серию событий от одного сохранения файла
+const fileChangeDebounce = 300 * time.Millisecond
+
+// FileConfigSource читает YAML-файл и следит за ним через fsnotify.
+// Наблюдается каталог, чтобы пережить атомарную замену файла редактором
+// и обновление ConfigMap в Kubernetes (симлинк ..data).
+type FileConfigSource struct {
+	Path string
+}
+
+func NewFileConfigSource(path string) *FileConfigSource {
+	return &FileConfigSource{Path: path}
+}
+
+func (s *FileConfigSource) Name() string {
+	return "file:" + s.Path
+}
+
+func (s *FileConfigSource) Read(ctx context.Context) ([]byte, error) {
+	return os.ReadFile(s.Path)
+}
+
+func (s *FileConfigSource) Watch(ctx context.Context, notify func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	dir := filepath.Dir(s.Path)
+	if err := watcher.Add(dir); err != nil {
+		return err
+	}
+
+	name := filepath.Base(s.Path)
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			base := filepath.Base(event.Name)
+			if base != name && base != "..data" {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) == 0 {
+				continue
+			}
+			debounce = time.After(fileChangeDebounce)
+		case <-debounce:
+			debounce = nil
+			notify()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error("Config file watch error",
+				logger.Field{Key: "path", Value: s.Path},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	ConfigBackendFile   = "file"
+	ConfigBackendConsul = "consul"
+	ConfigBackendEtcd   = "etcd"
+)
+
+// ConfigWatchConfig настройки горячей перезагрузки конфигурации.
+// Backend: file (по умолчанию), consul или etcd. Для Consul и etcd
+// документ сервиса хранится под ключом <prefix>/<имя конфига>.
+type ConfigWatchConfig struct {
+	Backend      string
+	PollInterval time.Duration
+	ConsulAddr   string
+	ConsulToken  string
+	ConsulPrefix string
+	EtcdEndpoint string
+	EtcdPrefix   string
+}
+
+// NewConfigSource создает источник документа name. Для файлового бэкенда используется path.
+func NewConfigSource(cfg ConfigWatchConfig, name, path string) (ConfigSource, error) {
+	switch cfg.Backend {
+	case "", ConfigBackendFile:
+		return NewFileConfigSource(path), nil
+	case ConfigBackendConsul:
+		if cfg.ConsulAddr == "" {
+			return nil, fmt.Errorf("consul address is required for config backend %s", cfg.Backend)
+		}
+		return NewConsulConfigSource(cfg.ConsulAddr, cfg.ConsulToken, joinConfigKey(cfg.ConsulPrefix, name)), nil
+	case ConfigBackendEtcd:
+		if cfg.EtcdEndpoint == "" {
+			return nil, fmt.Errorf("etcd endpoint is required for config backend %s", cfg.Backend)
+		}
+		return NewEtcdConfigSource(cfg.EtcdEndpoint, joinConfigKey(cfg.EtcdPrefix, name), cfg.PollInterval), nil
+	default:
+		return nil, fmt.Errorf("unknown config backend: %s", cfg.Backend)
+	}
+}
+
+// ConfigWatchFromEnv читает настройки для сервисов со своей загрузкой конфига,
+// используя те же переменные окружения, что и LoadConfig
+func ConfigWatchFromEnv() ConfigWatchConfig {
+	pollInterval, err := time.ParseDuration(GetEnv("CONFIG_POLL_INTERVAL", "30s"))
+	if err != nil {
+		pollInterval = 30 * time.Second
+	}
+
+	return ConfigWatchConfig{
+		Backend:      GetEnv("CONFIG_BACKEND", ConfigBackendFile),
+		PollInterval: pollInterval,
+		ConsulAddr:   os.Getenv("CONSUL_HTTP_ADDR"),
+		ConsulToken:  os.Getenv("CONSUL_HTTP_TOKEN"),
+		ConsulPrefix: GetEnv("CONFIG_CONSUL_PREFIX", "conveer"),
+		EtcdEndpoint: os.Getenv("ETCD_ENDPOINT"),
+		EtcdPrefix:   GetEnv("CONFIG_ETCD_PREFIX", "conveer"),
+	}
+}
+
+func joinConfigKey(prefix, name string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return name
+	}
+	return prefix + "/" + name
+}
+
+// waitRetry ждет перед повторным запросом к недоступному бэкенду
+func waitRetry(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ConsulConfigSource читает документ из Consul KV и следит за ним через blocking queries
+type ConsulConfigSource struct {
+	Address string
+	Token   string
+	Key     string
+	Wait    time.Duration
+	Client  *http.Client
+}
+
+func NewConsulConfigSource(address, token, key string) *ConsulConfigSource {
+	return &ConsulConfigSource{
+		Address: strings.TrimRight(address, "/"),
+		Token:   token,
+		Key:     strings.Trim(key, "/"),
+		Wait:    time.Minute,
+		Client:  &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+func (s *ConsulConfigSource) Name() string {
+	return "consul:" + s.Key
+}
+
+func (s *ConsulConfigSource) Read(ctx context.Context) ([]byte, error) {
+	data, _, err := s.get(ctx, "")
+	return data, err
+}
+
+func (s *ConsulConfigSource) Watch(ctx context.Context, notify func()) error {
+	var index string
+	for {
+		_, next, err := s.get(ctx, index)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := waitRetry(ctx, 5*time.Second); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if index != "" && next != index {
+			notify()
+		}
+		index = next
+	}
+}
+
+// get читает ключ; с непустым index запрос блокируется до изменения или истечения Wait
+func (s *ConsulConfigSource) get(ctx context.Context, index string) ([]byte, string, error) {
+	query := url.Values{"raw": {""}}
+	if index != "" {
+		query.Set("index", index)
+		query.Set("wait", s.Wait.String())
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/kv/%s?%s", s.Address, s.Key, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if s.Token != "" {
+		req.Header.Set("X-Consul-Token", s.Token)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("consul returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("X-Consul-Index"), nil
+}
+
+// EtcdConfigSource читает документ из etcd v3 через JSON gateway и опрашивает ревизию ключа
+type EtcdConfigSource struct {
+	Endpoint     string
+	Key          string
+	PollInterval time.Duration
+	Client       *http.Client
+}
+
+func NewEtcdConfigSource(endpoint, key string, pollInterval time.Duration) *EtcdConfigSource {
+	if pollInterval <= 0 {
+		pollInterval = 30 * time.Second
+	}
+
+	return &EtcdConfigSource{
+		Endpoint:     strings.TrimRight(endpoint, "/"),
+		Key:          key,
+		PollInterval: pollInterval,
+		Client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *EtcdConfigSource) Name() string {
+	return "etcd:" + s.Key
+}
+
+func (s *EtcdConfigSource) Read(ctx context.Context) ([]byte, error) {
+	data, _, err := s.get(ctx)
+	return data, err
+}
+
+func (s *EtcdConfigSource) Watch(ctx context.Context, notify func()) error {
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+
+	_, revision, _ := s.get(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_, next, err := s.get(ctx)
+			if err != nil {
+				continue
+			}
+			if next != revision {
+				revision = next
+				notify()
+			}
+		}
+	}
+}
+
+// get возвращает значение ключа и его mod_revision
+func (s *EtcdConfigSource) get(ctx context.Context) ([]byte, string, error) {
+	payload, err := json.Marshal(map[string]string{
+		"key": base64.StdEncoding.EncodeToString([]byte(s.Key)),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint+"/v3/kv/range", bytes.NewReader(payload))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("etcd returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Kvs []struct {
+			Value       string `json:"value"`
+			ModRevision string `json:"mod_revision"`
+		} `json:"kvs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, "", fmt.Errorf("failed to decode etcd response: %w", err)
+	}
+	if len(body.Kvs) == 0 {
+		return nil, "", fmt.Errorf("etcd key %s not found", s.Key)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(body.Kvs[0].Value)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode etcd value: %w", err)
+	}
+	return data, body.Kvs[0].ModRevision, nil
+}
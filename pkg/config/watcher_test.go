@@ -0,0 +1,212 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticConfigSource struct {
+	data string
+	err  error
+}
+
+func (s *staticConfigSource) Name() string {
+	return "static"
+}
+
+func (s *staticConfigSource) Read(ctx context.Context) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []byte(s.data), nil
+}
+
+func (s *staticConfigSource) Watch(ctx context.Context, notify func()) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+const testWarmingConfig = `
+warming:
+  max_concurrent_tasks: 50
+  scheduler:
+    check_interval: 1m
+  scenarios:
+    basic:
+      vk:
+        actions_per_day: "5-10"
+`
+
+func TestConfigWatcherEmitsTypedEvents(t *testing.T) {
+	ctx := context.Background()
+	source := &staticConfigSource{data: testWarmingConfig}
+
+	watcher := NewConfigWatcher(source)
+	require.NoError(t, watcher.Load(ctx))
+
+	var limits, scheduler []ChangeSet
+	watcher.Subscribe("limits", []string{"warming.max_concurrent_tasks", "warming.scenarios"}, func(ctx context.Context, changes ChangeSet) error {
+		limits = append(limits, changes)
+		return nil
+	})
+	watcher.Subscribe("scheduler", []string{"warming.scheduler"}, func(ctx context.Context, changes ChangeSet) error {
+		scheduler = append(scheduler, changes)
+		return nil
+	})
+
+	require.NoError(t, watcher.Reload(ctx))
+	assert.Empty(t, limits)
+
+	source.data = `
+warming:
+  max_concurrent_tasks: 80
+  scheduler:
+    check_interval: 1m
+  scenarios:
+    basic:
+      vk:
+        actions_per_day: "10-20"
+      telegram:
+        actions_per_day: "3-5"
+`
+	require.NoError(t, watcher.Reload(ctx))
+	assert.Empty(t, scheduler)
+	require.Len(t, limits, 1)
+
+	changes := limits[0]
+	require.Len(t, changes.Events, 3)
+	assert.True(t, changes.Has("warming.scenarios.basic"))
+	assert.False(t, changes.Has("warming.scheduler"))
+
+	maxTasks, ok := changes.Get("warming.max_concurrent_tasks")
+	require.True(t, ok)
+	assert.Equal(t, ChangeUpdated, maxTasks.Type)
+	n, ok := maxTasks.Int()
+	assert.True(t, ok)
+	assert.Equal(t, 80, n)
+
+	added, ok := changes.Get("warming.scenarios.basic.telegram.actions_per_day")
+	require.True(t, ok)
+	assert.Equal(t, ChangeAdded, added.Type)
+	assert.Equal(t, "3-5", added.String())
+
+	var decoded struct {
+		Warming struct {
+			MaxConcurrentTasks int `yaml:"max_concurrent_tasks"`
+		} `yaml:"warming"`
+	}
+	require.NoError(t, changes.Decode(&decoded))
+	assert.Equal(t, 80, decoded.Warming.MaxConcurrentTasks)
+}
+
+func TestConfigWatcherKeepsConfigOnInvalidDocument(t *testing.T) {
+	ctx := context.Background()
+	source := &staticConfigSource{data: testWarmingConfig}
+
+	watcher := NewConfigWatcher(source)
+	require.NoError(t, watcher.Load(ctx))
+
+	called := false
+	watcher.Subscribe("all", nil, func(ctx context.Context, changes ChangeSet) error {
+		called = true
+		return nil
+	})
+
+	source.data = "warming: [broken"
+	assert.Error(t, watcher.Reload(ctx))
+
+	source.err = errors.New("unavailable")
+	assert.Error(t, watcher.Reload(ctx))
+
+	assert.False(t, called)
+	assert.Equal(t, testWarmingConfig, string(watcher.Data()))
+}
+
+func TestConfigWatcherReportsRemovedKeysAndSubscriberErrors(t *testing.T) {
+	ctx := context.Background()
+	source := &staticConfigSource{data: "proxy:\n  min_pool_size: 10\n  rotation: 5m\n"}
+
+	watcher := NewConfigWatcher(source)
+	require.NoError(t, watcher.Load(ctx))
+
+	var events []ChangeEvent
+	watcher.Subscribe("failing", nil, func(ctx context.Context, changes ChangeSet) error {
+		return errors.New("boom")
+	})
+	watcher.Subscribe("proxy", []string{"proxy"}, func(ctx context.Context, changes ChangeSet) error {
+		events = changes.Events
+		return nil
+	})
+
+	source.data = "proxy:\n  min_pool_size: 10\n"
+	assert.Error(t, watcher.Reload(ctx))
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "proxy.rotation", events[0].Key)
+	assert.Equal(t, ChangeRemoved, events[0].Type)
+	assert.Equal(t, "", events[0].String())
+}
+
+func TestChangeEventConversions(t *testing.T) {
+	d, ok := ChangeEvent{NewValue: "90s"}.Duration()
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, d)
+
+	f, ok := ChangeEvent{NewValue: 3}.Float()
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, f)
+
+	_, ok = ChangeEvent{NewValue: 2.5}.Int()
+	assert.False(t, ok)
+
+	b, ok := ChangeEvent{NewValue: "true"}.Bool()
+	assert.True(t, ok)
+	assert.True(t, b)
+}
+
+func TestConsulConfigSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/kv/conveer/warming", r.URL.Path)
+		assert.Equal(t, "token", r.Header.Get("X-Consul-Token"))
+		w.Header().Set("X-Consul-Index", "42")
+		w.Write([]byte("warming:\n  max_concurrent_tasks: 10\n"))
+	}))
+	defer server.Close()
+
+	source, err := NewConfigSource(ConfigWatchConfig{
+		Backend:      ConfigBackendConsul,
+		ConsulAddr:   server.URL,
+		ConsulToken:  "token",
+		ConsulPrefix: "conveer",
+	}, "warming", "")
+	require.NoError(t, err)
+
+	data, err := source.Read(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "warming:\n  max_concurrent_tasks: 10\n", string(data))
+}
+
+func TestEtcdConfigSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/kv/range", r.URL.Path)
+		value := base64.StdEncoding.EncodeToString([]byte("proxy:\n  min_pool_size: 20\n"))
+		w.Write([]byte(`{"kvs":[{"value":"` + value + `","mod_revision":"7"}]}`))
+	}))
+	defer server.Close()
+
+	source := NewEtcdConfigSource(server.URL, "conveer/proxy", time.Second)
+	data, err := source.Read(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "proxy:\n  min_pool_size: 20\n", string(data))
+
+	_, err = NewConfigSource(ConfigWatchConfig{Backend: "zookeeper"}, "proxy", "")
+	assert.Error(t, err)
+}
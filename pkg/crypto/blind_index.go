@@ -0,0 +1,91 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Fields with a built-in normalization. Other fields can be indexed with Index under their own name.
+const (
+	FieldPhone = "phone"
+	FieldEmail = "email"
+)
+
+// blindIndexContext separates the derived index key from the encryption key it comes from
+const blindIndexContext = "conveer/blind-index/v1"
+
+// BlindIndex computes deterministic keyed hashes of field values. The ciphertext of an encrypted
+// field is random, the index stored next to it is the same for equal values, so repositories
+// can put a regular index on it and find documents by exact match without decrypting them.
+//
+// The index reveals which documents share a value, so it is only meant for fields looked up by
+// exact value. Changing the key changes every index, documents must be reindexed.
+type BlindIndex struct {
+	key []byte
+}
+
+// NewBlindIndex derives the index key from a secret of at least 32 bytes
+func NewBlindIndex(secret []byte) (*BlindIndex, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("blind index secret must be at least 32 bytes")
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(blindIndexContext))
+	return &BlindIndex{key: mac.Sum(nil)}, nil
+}
+
+// BlindIndex returns the blind index keyed by the encryption key, the key itself is never used
+// directly so indexes reveal nothing about ciphertexts
+func (e *Encryptor) BlindIndex() *BlindIndex {
+	index, _ := NewBlindIndex(e.key)
+	return index
+}
+
+// Index returns the hex index of a value of the field. The field name is part of the hash,
+// equal values of different fields get different indexes.
+func (b *BlindIndex) Index(field, value string) string {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Phone indexes a phone number after NormalizePhone
+func (b *BlindIndex) Phone(phone string) string {
+	return b.Index(FieldPhone, NormalizePhone(phone))
+}
+
+// Email indexes an email address after NormalizeEmail
+func (b *BlindIndex) Email(email string) string {
+	return b.Index(FieldEmail, NormalizeEmail(email))
+}
+
+// EncryptIndexed encrypts a value and returns its index for the field,
+// the caller normalizes the value the way it is looked up
+func (e *Encryptor) EncryptIndexed(field, value string) (ciphertext, index string, err error) {
+	ciphertext, err = e.Encrypt(value)
+	if err != nil {
+		return "", "", err
+	}
+	return ciphertext, e.BlindIndex().Index(field, value), nil
+}
+
+// NormalizePhone keeps the digits only, "+7 (900) 123-45-67" and "79001234567" are the same number
+func NormalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// NormalizeEmail trims and lowercases the address
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
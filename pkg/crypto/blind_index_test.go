@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBlindIndexKey = "12345678901234567890123456789012"
+
+func TestBlindIndex_Deterministic(t *testing.T) {
+	index, err := NewBlindIndex([]byte(testBlindIndexKey))
+	require.NoError(t, err)
+
+	assert.Equal(t, index.Index("phone", "79001234567"), index.Index("phone", "79001234567"))
+	assert.NotEqual(t, index.Index("phone", "79001234567"), index.Index("phone", "79001234568"))
+	assert.Len(t, index.Index("phone", "79001234567"), 64)
+}
+
+func TestBlindIndex_FieldSeparation(t *testing.T) {
+	index, err := NewBlindIndex([]byte(testBlindIndexKey))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, index.Index("phone", "123"), index.Index("email", "123"))
+	// The separator keeps the field and value from running into each other
+	assert.NotEqual(t, index.Index("ab", "c"), index.Index("a", "bc"))
+}
+
+func TestBlindIndex_KeyMatters(t *testing.T) {
+	first, err := NewBlindIndex([]byte(testBlindIndexKey))
+	require.NoError(t, err)
+	second, err := NewBlindIndex([]byte("abcdefghijklmnopqrstuvwxyz012345"))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.Phone("79001234567"), second.Phone("79001234567"))
+}
+
+func TestNewBlindIndex_ShortSecret(t *testing.T) {
+	_, err := NewBlindIndex([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestBlindIndex_Normalization(t *testing.T) {
+	index, err := NewBlindIndex([]byte(testBlindIndexKey))
+	require.NoError(t, err)
+
+	assert.Equal(t, index.Phone("79001234567"), index.Phone("+7 (900) 123-45-67"))
+	assert.Equal(t, index.Email("user@mail.ru"), index.Email("  User@Mail.RU "))
+}
+
+func TestEncryptor_EncryptIndexed(t *testing.T) {
+	enc, err := NewEncryptor(testBlindIndexKey)
+	require.NoError(t, err)
+
+	first, firstIndex, err := enc.EncryptIndexed(FieldPhone, "79001234567")
+	require.NoError(t, err)
+	second, secondIndex, err := enc.EncryptIndexed(FieldPhone, "79001234567")
+	require.NoError(t, err)
+
+	// Ciphertexts stay random, the index is what lookups match on
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, firstIndex, secondIndex)
+	assert.Equal(t, enc.BlindIndex().Index(FieldPhone, "79001234567"), firstIndex)
+
+	plaintext, err := enc.Decrypt(first)
+	require.NoError(t, err)
+	assert.Equal(t, "79001234567", plaintext)
+
+	// The index key is derived, hashing with the encryption key itself gives a different value
+	direct := &BlindIndex{key: []byte(testBlindIndexKey)}
+	assert.NotEqual(t, direct.Index(FieldPhone, "79001234567"), firstIndex)
+}
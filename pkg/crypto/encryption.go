@@ -9,6 +9,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"math/big"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -40,22 +42,10 @@ func NewEncryptor(key string) (*Encryptor, error) {
 }
 
 func (e *Encryptor) Encrypt(plaintext string) (string, error) {
-	block, err := aes.NewCipher(e.key)
-	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
-	}
-
-	gcm, err := cipher.NewGCM(block)
+	ciphertext, err := e.EncryptBytes([]byte(plaintext))
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
-	}
-
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", fmt.Errorf("failed to generate nonce: %w", err)
+		return "", err
 	}
-
-	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
 	return base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
@@ -65,28 +55,60 @@ func (e *Encryptor) Decrypt(ciphertext string) (string, error) {
 		return "", fmt.Errorf("failed to decode ciphertext: %w", err)
 	}
 
-	block, err := aes.NewCipher(e.key)
+	plaintext, err := e.DecryptBytes(data)
 	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
+		return "", err
 	}
+	return string(plaintext), nil
+}
 
-	gcm, err := cipher.NewGCM(block)
+// EncryptBytes encrypts binary data such as cookie jars, the result is nonce followed by ciphertext
+func (e *Encryptor) EncryptBytes(plaintext []byte) ([]byte, error) {
+	gcm, err := e.gcm()
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// DecryptBytes decrypts data encrypted with EncryptBytes
+func (e *Encryptor) DecryptBytes(data []byte) ([]byte, error) {
+	gcm, err := e.gcm()
+	if err != nil {
+		return nil, err
 	}
 
 	nonceSize := gcm.NonceSize()
 	if len(data) < nonceSize {
-		return "", fmt.Errorf("ciphertext too short")
+		return nil, fmt.Errorf("ciphertext too short")
 	}
 
 	nonce, ciphertextBytes := data[:nonceSize], data[nonceSize:]
 	plaintext, err := gcm.Open(nil, nonce, ciphertextBytes, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to decrypt: %w", err)
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
 	}
 
-	return string(plaintext), nil
+	return plaintext, nil
+}
+
+func (e *Encryptor) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(e.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
 }
 
 func HashPassword(password string) (string, error) {
@@ -200,3 +222,56 @@ func GenerateSecureToken(length int) (string, error) {
 	gen := NewTokenGenerator(length)
 	return gen.Generate()
 }
+
+const (
+	passwordLower   = "abcdefghijkmnopqrstuvwxyz"
+	passwordUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordDigits  = "23456789"
+	passwordSymbols = "!@#$%^&*-_"
+)
+
+// PasswordGenerator generates account passwords
+type PasswordGenerator interface {
+	// GenerateSecure returns a random password of the given length (at least 4) with lower and upper
+	// case letters, digits and symbols
+	GenerateSecure(length int) string
+}
+
+type passwordGenerator struct{}
+
+func NewPasswordGenerator() PasswordGenerator {
+	return passwordGenerator{}
+}
+
+func (passwordGenerator) GenerateSecure(length int) string {
+	classes := []string{passwordLower, passwordUpper, passwordDigits, passwordSymbols}
+	if length < len(classes) {
+		length = len(classes)
+	}
+	all := strings.Join(classes, "")
+
+	password := make([]byte, length)
+	for i := range password {
+		// The first characters guarantee every class, the shuffle below moves them
+		charset := all
+		if i < len(classes) {
+			charset = classes[i]
+		}
+		password[i] = charset[randomIndex(len(charset))]
+	}
+
+	for i := len(password) - 1; i > 0; i-- {
+		j := randomIndex(i + 1)
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password)
+}
+
+func randomIndex(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return int(v.Int64())
+}
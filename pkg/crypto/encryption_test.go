@@ -164,6 +164,32 @@ func (suite *EncryptorTestSuite) TestDecrypt_WrongKey() {
 	suite.Error(err)
 }
 
+func (suite *EncryptorTestSuite) TestEncryptDecryptBytes() {
+	plaintext := []byte{0x00, 0xff, 0x10, 'c', 'o', 'o', 'k', 'i', 'e'}
+	ciphertext, err := suite.encryptor.EncryptBytes(plaintext)
+	suite.NoError(err)
+	suite.NotEqual(plaintext, ciphertext)
+
+	decrypted, err := suite.encryptor.DecryptBytes(ciphertext)
+	suite.NoError(err)
+	suite.Equal(plaintext, decrypted)
+}
+
+func (suite *EncryptorTestSuite) TestDecryptBytes_TooShort() {
+	_, err := suite.encryptor.DecryptBytes([]byte{1, 2, 3})
+	suite.Error(err)
+	suite.Contains(err.Error(), "too short")
+}
+
+func (suite *EncryptorTestSuite) TestDecryptBytes_Tampered() {
+	ciphertext, err := suite.encryptor.EncryptBytes([]byte("cookies"))
+	suite.Require().NoError(err)
+
+	ciphertext[len(ciphertext)-1] ^= 0xff
+	_, err = suite.encryptor.DecryptBytes(ciphertext)
+	suite.Error(err)
+}
+
 // Run the test suite
 func TestEncryptorTestSuite(t *testing.T) {
 	suite.Run(t, new(EncryptorTestSuite))
@@ -530,3 +556,32 @@ func BenchmarkEncryptWithSalt(b *testing.B) {
 	}
 }
 
+
+func TestPasswordGenerator_GenerateSecure(t *testing.T) {
+	gen := NewPasswordGenerator()
+
+	for _, length := range []int{1, 4, 16, 32} {
+		password := gen.GenerateSecure(length)
+
+		expected := length
+		if expected < 4 {
+			expected = 4
+		}
+		assert.Len(t, password, expected)
+		assert.True(t, strings.ContainsAny(password, passwordLower))
+		assert.True(t, strings.ContainsAny(password, passwordUpper))
+		assert.True(t, strings.ContainsAny(password, passwordDigits))
+		assert.True(t, strings.ContainsAny(password, passwordSymbols))
+	}
+}
+
+func TestPasswordGenerator_Uniqueness(t *testing.T) {
+	gen := NewPasswordGenerator()
+	passwords := make(map[string]bool)
+
+	for i := 0; i < 100; i++ {
+		passwords[gen.GenerateSecure(16)] = true
+	}
+
+	assert.Len(t, passwords, 100)
+}
@@ -0,0 +1,37 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 7636 appendix B example
+const (
+	rfcPKCEVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	rfcPKCEChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestPKCEChallenge_RFCVector(t *testing.T) {
+	assert.Equal(t, rfcPKCEChallenge, PKCEChallenge(rfcPKCEVerifier))
+}
+
+func TestVerifyPKCE(t *testing.T) {
+	assert.True(t, VerifyPKCE(rfcPKCEVerifier, rfcPKCEChallenge))
+
+	// Another verifier, a challenge sent as plain and a missing challenge
+	assert.False(t, VerifyPKCE(strings.Repeat("a", 43), rfcPKCEChallenge))
+	assert.False(t, VerifyPKCE(rfcPKCEVerifier, rfcPKCEVerifier))
+	assert.False(t, VerifyPKCE(rfcPKCEVerifier, ""))
+}
+
+func TestValidPKCEVerifier(t *testing.T) {
+	assert.True(t, ValidPKCEVerifier(rfcPKCEVerifier))
+	assert.True(t, ValidPKCEVerifier(strings.Repeat("a.~_-", 25)+"abc"))
+
+	assert.False(t, ValidPKCEVerifier(strings.Repeat("a", 42)))
+	assert.False(t, ValidPKCEVerifier(strings.Repeat("a", 129)))
+	assert.False(t, ValidPKCEVerifier(strings.Repeat("a", 42)+"+"))
+	assert.False(t, ValidPKCEVerifier(strings.Repeat("я", 43)))
+}
@@ -0,0 +1,141 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+)
+
+// minRecipientKeyBits is the smallest RSA key data is sealed for
+const minRecipientKeyBits = 2048
+
+// recipientKeyLabel binds the wrapped key to its purpose, OAEP rejects a key wrapped for another one
+var recipientKeyLabel = []byte("conveer/recipient/v1")
+
+// RecipientSealer encrypts data for the holder of an RSA private key without sharing a secret:
+// a random AES-256 key encrypts every sealed item with AES-GCM and is itself encrypted with
+// RSA-OAEP (SHA-256). The recipient unwraps the key once and opens every item sealed with it.
+type RecipientSealer struct {
+	gcm          cipher.AEAD
+	encryptedKey []byte
+}
+
+// NewRecipientSealer creates a sealer for a PEM encoded RSA public key ("PUBLIC KEY" or
+// "RSA PUBLIC KEY") of at least 2048 bits
+func NewRecipientSealer(publicKeyPEM []byte) (*RecipientSealer, error) {
+	publicKey, err := parseRecipientKey(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := GenerateRandomBytes(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, key, recipientKeyLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecipientSealer{gcm: gcm, encryptedKey: encryptedKey}, nil
+}
+
+// EncryptedKey returns the data key wrapped for the recipient, it is sent along with the sealed items
+func (s *RecipientSealer) EncryptedKey() []byte {
+	return s.encryptedKey
+}
+
+// Seal encrypts plaintext, the result is the nonce followed by the ciphertext
+func (s *RecipientSealer) Seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, s.gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return s.gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// OpenSealed decrypts an item sealed by RecipientSealer with the recipient's private key
+func OpenSealed(privateKey *rsa.PrivateKey, encryptedKey, sealed []byte) ([]byte, error) {
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privateKey, encryptedKey, recipientKeyLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func parseRecipientKey(publicKeyPEM []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("recipient key is not PEM encoded")
+	}
+
+	var publicKey *rsa.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient key: %w", err)
+		}
+		rsaKey, ok := parsed.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("recipient key must be an RSA key")
+		}
+		publicKey = rsaKey
+	case "RSA PUBLIC KEY":
+		parsed, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient key: %w", err)
+		}
+		publicKey = parsed
+	default:
+		return nil, fmt.Errorf("unsupported recipient key type %q", block.Type)
+	}
+
+	if publicKey.N.BitLen() < minRecipientKeyBits {
+		return nil, fmt.Errorf("recipient key must be at least %d bits", minRecipientKeyBits)
+	}
+
+	return publicKey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateRecipientKey(t *testing.T, bits int) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	return privateKey, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestRecipientSealer_RoundTrip(t *testing.T) {
+	privateKey, publicKeyPEM := generateRecipientKey(t, 2048)
+
+	sealer, err := NewRecipientSealer(publicKeyPEM)
+	require.NoError(t, err)
+
+	first, err := sealer.Seal([]byte(`{"login":"79001234567"}`))
+	require.NoError(t, err)
+	second, err := sealer.Seal([]byte("# Netscape HTTP Cookie File\n"))
+	require.NoError(t, err)
+
+	opened, err := OpenSealed(privateKey, sealer.EncryptedKey(), first)
+	require.NoError(t, err)
+	assert.Equal(t, `{"login":"79001234567"}`, string(opened))
+
+	opened, err = OpenSealed(privateKey, sealer.EncryptedKey(), second)
+	require.NoError(t, err)
+	assert.Equal(t, "# Netscape HTTP Cookie File\n", string(opened))
+}
+
+func TestRecipientSealer_PKCS1Key(t *testing.T) {
+	privateKey, _ := generateRecipientKey(t, 2048)
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(&privateKey.PublicKey),
+	})
+
+	sealer, err := NewRecipientSealer(publicKeyPEM)
+	require.NoError(t, err)
+
+	sealed, err := sealer.Seal([]byte("secret"))
+	require.NoError(t, err)
+
+	opened, err := OpenSealed(privateKey, sealer.EncryptedKey(), sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(opened))
+}
+
+func TestRecipientSealer_WrongKey(t *testing.T) {
+	_, publicKeyPEM := generateRecipientKey(t, 2048)
+	otherKey, _ := generateRecipientKey(t, 2048)
+
+	sealer, err := NewRecipientSealer(publicKeyPEM)
+	require.NoError(t, err)
+
+	sealed, err := sealer.Seal([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = OpenSealed(otherKey, sealer.EncryptedKey(), sealed)
+	assert.Error(t, err)
+}
+
+func TestRecipientSealer_TamperedData(t *testing.T) {
+	privateKey, publicKeyPEM := generateRecipientKey(t, 2048)
+
+	sealer, err := NewRecipientSealer(publicKeyPEM)
+	require.NoError(t, err)
+
+	sealed, err := sealer.Seal([]byte("secret"))
+	require.NoError(t, err)
+	sealed[len(sealed)-1] ^= 0xff
+
+	_, err = OpenSealed(privateKey, sealer.EncryptedKey(), sealed)
+	assert.Error(t, err)
+}
+
+func TestNewRecipientSealer_InvalidKeys(t *testing.T) {
+	_, err := NewRecipientSealer([]byte("not a key"))
+	assert.Error(t, err)
+
+	_, weakKeyPEM := generateRecipientKey(t, 1024)
+	_, err = NewRecipientSealer(weakKeyPEM)
+	assert.Error(t, err)
+
+	_, err = NewRecipientSealer(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1}}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package crypto
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 appendix B test vectors for the SHA1 key, truncated to 6 digits
+func TestGenerateTOTPCode_RFCVectors(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := GenerateTOTPCode(secret, TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code, err := GenerateTOTPCode(secret, TOTPStep(now))
+	require.NoError(t, err)
+
+	step, ok := ValidateTOTPCode(secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, TOTPStep(now), step)
+
+	// One step of clock drift is tolerated, two are not
+	_, ok = ValidateTOTPCode(secret, code, now.Add(TOTPPeriod), 1)
+	assert.True(t, ok)
+	_, ok = ValidateTOTPCode(secret, code, now.Add(2*TOTPPeriod), 1)
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTPCode(secret, "12345", now, 1)
+	assert.False(t, ok)
+	_, ok = ValidateTOTPCode("not base32!", code, now, 1)
+	assert.False(t, ok)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("Conveer", "operator@example.com", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Conveer:operator@example.com?"))
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "Conveer", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+}
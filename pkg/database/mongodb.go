@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,20 +14,72 @@ import (
 	"github.com/grigta/conveer/pkg/logger"
 )
 
+// defaultConnectTimeout bounds connecting when neither a timeout nor ConnectTimeout is given
+const defaultConnectTimeout = 10 * time.Second
+
 type MongoDB struct {
 	client   *mongo.Client
 	database *mongo.Database
+	dbName   string
 	timeout  time.Duration
+	options  MongoOptions
+	pool     *poolMonitor
+	mu       sync.RWMutex
+}
+
+// NewMongoDB connects with DefaultMongoOptions unless options are given
+func NewMongoDB(uri string, dbName string, timeout time.Duration, opts ...MongoOptions) (*MongoDB, error) {
+	tuning := mongoOptions(opts)
+
+	pool := &poolMonitor{}
+	client, err := connectMongo(uri, timeout, tuning, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Connected to MongoDB",
+		logger.Field{Key: "database", Value: dbName},
+		logger.Field{Key: "max_pool_size", Value: tuning.MaxPoolSize},
+		logger.Field{Key: "read_preference", Value: tuning.ReadPreference},
+	)
+
+	return &MongoDB{
+		client:   client,
+		database: client.Database(dbName),
+		dbName:   dbName,
+		timeout:  timeout,
+		options:  tuning,
+		pool:     pool,
+	}, nil
+}
+
+// NewMongoClient returns a bare driver client for services that manage databases themselves
+func NewMongoClient(uri string, opts ...MongoOptions) (*mongo.Client, error) {
+	tuning := mongoOptions(opts)
+
+	timeout := tuning.ConnectTimeout
+	if timeout <= 0 {
+		timeout = defaultConnectTimeout
+	}
+
+	return connectMongo(uri, timeout, tuning, nil)
+}
+
+func mongoOptions(opts []MongoOptions) MongoOptions {
+	if len(opts) == 0 {
+		return DefaultMongoOptions()
+	}
+	return opts[0].withDefaults()
 }
 
-func NewMongoDB(uri string, dbName string, timeout time.Duration) (*MongoDB, error) {
+func connectMongo(uri string, timeout time.Duration, opts MongoOptions, pool *poolMonitor) (*mongo.Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	clientOptions := options.Client().ApplyURI(uri)
-	clientOptions.SetMaxPoolSize(50)
-	clientOptions.SetMinPoolSize(10)
-	clientOptions.SetMaxConnIdleTime(5 * time.Minute)
+	clientOptions, err := opts.clientOptions(uri, pool)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MongoDB options: %w", err)
+	}
 
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
@@ -34,34 +87,80 @@ func NewMongoDB(uri string, dbName string, timeout time.Duration) (*MongoDB, err
 	}
 
 	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+		client.Disconnect(context.Background())
 		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 
-	logger.Info("Connected to MongoDB", logger.Field{Key: "database", Value: dbName})
+	return client, nil
+}
 
-	return &MongoDB{
-		client:   client,
-		database: client.Database(dbName),
-		timeout:  timeout,
-	}, nil
+// Reconnect switches to a new connection string (e.g. rotated credentials).
+// The current connection is kept if the new one cannot be established.
+func (m *MongoDB) Reconnect(uri string) error {
+	pool := &poolMonitor{}
+	client, err := connectMongo(uri, m.timeout, m.options, pool)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	old := m.client
+	m.client = client
+	m.pool = pool
+	m.database = client.Database(m.dbName)
+	m.mu.Unlock()
+
+	logger.Info("Reconnected to MongoDB", logger.Field{Key: "database", Value: m.dbName})
+
+	// Let in-flight operations on the old client finish before closing it
+	go func() {
+		time.Sleep(m.timeout)
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+		old.Disconnect(ctx)
+	}()
+
+	return nil
 }
 
 func (m *MongoDB) Close() error {
 	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
 	defer cancel()
-	return m.client.Disconnect(ctx)
+	return m.Client().Disconnect(ctx)
+}
+
+// Ping checks that the primary is reachable
+func (m *MongoDB) Ping(ctx context.Context) error {
+	return m.Client().Ping(ctx, readpref.Primary())
+}
+
+// PoolStats reports the connection pool usage of the current client
+func (m *MongoDB) PoolStats() PoolStats {
+	m.mu.RLock()
+	pool := m.pool
+	m.mu.RUnlock()
+	return pool.stats(m.options)
+}
+
+// HealthCheck pings the primary and reports the pool usage for /health
+func (m *MongoDB) HealthCheck(ctx context.Context) (PoolStats, error) {
+	return m.PoolStats(), m.Ping(ctx)
 }
 
 func (m *MongoDB) Client() *mongo.Client {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.client
 }
 
 func (m *MongoDB) GetDatabase() *mongo.Database {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.database
 }
 
 func (m *MongoDB) GetCollection(name string) *mongo.Collection {
-	return m.database.Collection(name)
+	return m.GetDatabase().Collection(name)
 }
 
 func (m *MongoDB) CreateIndexes(collection string, indexes []mongo.IndexModel) error {
@@ -134,7 +233,7 @@ func (m *MongoDB) Aggregate(ctx context.Context, collection string, pipeline int
 }
 
 func (m *MongoDB) WithTransaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) (interface{}, error)) (interface{}, error) {
-	session, err := m.client.StartSession()
+	session, err := m.Client().StartSession()
 	if err != nil {
 		return nil, fmt.Errorf("failed to start session: %w", err)
 	}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// MongoOptions tunes the MongoDB client. Zero values keep the defaults.
+type MongoOptions struct {
+	MaxPoolSize     uint64
+	MinPoolSize     uint64
+	MaxConnIdleTime time.Duration
+	// ReadPreference is primary, primaryPreferred, secondary, secondaryPreferred or nearest
+	ReadPreference string
+	// WriteConcern is majority or the number of members that must acknowledge a write,
+	// empty keeps the one from the connection string
+	WriteConcern           string
+	WriteTimeout           time.Duration
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+	SocketTimeout          time.Duration
+}
+
+func DefaultMongoOptions() MongoOptions {
+	return MongoOptions{
+		MaxPoolSize:     50,
+		MinPoolSize:     10,
+		MaxConnIdleTime: 5 * time.Minute,
+		ReadPreference:  "primary",
+	}
+}
+
+// withDefaults fills the fields left empty
+func (o MongoOptions) withDefaults() MongoOptions {
+	defaults := DefaultMongoOptions()
+	if o.MaxPoolSize == 0 {
+		o.MaxPoolSize = defaults.MaxPoolSize
+	}
+	if o.MinPoolSize == 0 {
+		o.MinPoolSize = defaults.MinPoolSize
+	}
+	if o.MinPoolSize > o.MaxPoolSize {
+		o.MinPoolSize = o.MaxPoolSize
+	}
+	if o.MaxConnIdleTime == 0 {
+		o.MaxConnIdleTime = defaults.MaxConnIdleTime
+	}
+	if o.ReadPreference == "" {
+		o.ReadPreference = defaults.ReadPreference
+	}
+	return o
+}
+
+// clientOptions builds the driver options, they take precedence over the connection string
+func (o MongoOptions) clientOptions(uri string, monitor *poolMonitor) (*options.ClientOptions, error) {
+	o = o.withDefaults()
+
+	mode, err := readpref.ModeFromString(o.ReadPreference)
+	if err != nil {
+		return nil, err
+	}
+	readPref, err := readpref.New(mode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid read preference %q: %w", o.ReadPreference, err)
+	}
+
+	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions.SetMaxPoolSize(o.MaxPoolSize)
+	clientOptions.SetMinPoolSize(o.MinPoolSize)
+	clientOptions.SetMaxConnIdleTime(o.MaxConnIdleTime)
+	clientOptions.SetReadPreference(readPref)
+
+	if o.WriteConcern != "" {
+		writeConcern, err := parseWriteConcern(o.WriteConcern, o.WriteTimeout)
+		if err != nil {
+			return nil, err
+		}
+		clientOptions.SetWriteConcern(writeConcern)
+	}
+	if o.ConnectTimeout > 0 {
+		clientOptions.SetConnectTimeout(o.ConnectTimeout)
+	}
+	if o.ServerSelectionTimeout > 0 {
+		clientOptions.SetServerSelectionTimeout(o.ServerSelectionTimeout)
+	}
+	if o.SocketTimeout > 0 {
+		clientOptions.SetSocketTimeout(o.SocketTimeout)
+	}
+	if monitor != nil {
+		clientOptions.SetPoolMonitor(&event.PoolMonitor{Event: monitor.handle})
+	}
+
+	return clientOptions, nil
+}
+
+func parseWriteConcern(value string, timeout time.Duration) (*writeconcern.WriteConcern, error) {
+	if strings.EqualFold(value, "majority") {
+		return &writeconcern.WriteConcern{W: "majority", WTimeout: timeout}, nil
+	}
+
+	w, err := strconv.Atoi(value)
+	if err != nil || w < 0 {
+		return nil, fmt.Errorf("invalid write concern %q: expected majority or a number of members", value)
+	}
+	return &writeconcern.WriteConcern{W: w, WTimeout: timeout}, nil
+}
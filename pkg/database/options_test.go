@@ -0,0 +1,82 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestClientOptionsApplyTuning(t *testing.T) {
+	opts, err := MongoOptions{
+		MaxPoolSize:            200,
+		ReadPreference:         "secondaryPreferred",
+		WriteConcern:           "majority",
+		WriteTimeout:           5 * time.Second,
+		ServerSelectionTimeout: 3 * time.Second,
+	}.clientOptions("mongodb://localhost:27017/?maxPoolSize=10", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(200), *opts.MaxPoolSize)
+	assert.Equal(t, uint64(10), *opts.MinPoolSize)
+	assert.Equal(t, 5*time.Minute, *opts.MaxConnIdleTime)
+	assert.Equal(t, readpref.SecondaryPreferredMode, opts.ReadPreference.Mode())
+	assert.Equal(t, "majority", opts.WriteConcern.W)
+	assert.Equal(t, 5*time.Second, opts.WriteConcern.WTimeout)
+	assert.Equal(t, 3*time.Second, *opts.ServerSelectionTimeout)
+	assert.Nil(t, opts.SocketTimeout)
+	assert.Nil(t, opts.PoolMonitor)
+}
+
+func TestClientOptionsDefaults(t *testing.T) {
+	opts, err := MongoOptions{MaxPoolSize: 5}.clientOptions("mongodb://localhost:27017", &poolMonitor{})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(5), *opts.MaxPoolSize)
+	assert.Equal(t, uint64(5), *opts.MinPoolSize, "min pool size is capped by the max")
+	assert.Equal(t, readpref.PrimaryMode, opts.ReadPreference.Mode())
+	assert.Nil(t, opts.WriteConcern)
+	assert.NotNil(t, opts.PoolMonitor)
+}
+
+func TestClientOptionsRejectInvalidValues(t *testing.T) {
+	_, err := MongoOptions{ReadPreference: "fastest"}.clientOptions("mongodb://localhost:27017", nil)
+	assert.Error(t, err)
+
+	_, err = MongoOptions{WriteConcern: "all"}.clientOptions("mongodb://localhost:27017", nil)
+	assert.Error(t, err)
+
+	opts, err := MongoOptions{WriteConcern: "2"}.clientOptions("mongodb://localhost:27017", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, opts.WriteConcern.W)
+}
+
+func TestPoolMonitorStats(t *testing.T) {
+	pool := &poolMonitor{}
+	for _, eventType := range []string{
+		event.ConnectionCreated, event.ConnectionCreated, event.ConnectionCreated,
+		event.GetStarted, event.GetSucceeded,
+		event.GetStarted, event.GetSucceeded,
+		event.GetStarted, event.GetFailed,
+		event.GetStarted,
+		event.ConnectionReturned,
+		event.ConnectionClosed,
+	} {
+		pool.handle(&event.PoolEvent{Type: eventType})
+	}
+
+	stats := pool.stats(DefaultMongoOptions())
+	assert.Equal(t, PoolStats{
+		MaxPoolSize:      50,
+		MinPoolSize:      10,
+		Open:             2,
+		InUse:            1,
+		Idle:             1,
+		WaitingCheckouts: 1,
+		FailedCheckouts:  1,
+		ReadPreference:   "primary",
+	}, stats)
+}
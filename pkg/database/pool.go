@@ -0,0 +1,62 @@
+package database
+
+import (
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// PoolStats is the connection pool usage reported by health checks
+type PoolStats struct {
+	MaxPoolSize      uint64 `json:"max_pool_size"`
+	MinPoolSize      uint64 `json:"min_pool_size"`
+	Open             int64  `json:"open"`
+	InUse            int64  `json:"in_use"`
+	Idle             int64  `json:"idle"`
+	WaitingCheckouts int64  `json:"waiting_checkouts"`
+	FailedCheckouts  int64  `json:"failed_checkouts"`
+	ReadPreference   string `json:"read_preference"`
+}
+
+// poolMonitor counts connections from the driver pool events, summed over all servers
+type poolMonitor struct {
+	open    atomic.Int64
+	inUse   atomic.Int64
+	waiting atomic.Int64
+	failed  atomic.Int64
+}
+
+func (p *poolMonitor) handle(e *event.PoolEvent) {
+	switch e.Type {
+	case event.ConnectionCreated:
+		p.open.Add(1)
+	case event.ConnectionClosed:
+		p.open.Add(-1)
+	case event.GetStarted:
+		p.waiting.Add(1)
+	case event.GetSucceeded:
+		p.waiting.Add(-1)
+		p.inUse.Add(1)
+	case event.GetFailed:
+		p.waiting.Add(-1)
+		p.failed.Add(1)
+	case event.ConnectionReturned:
+		p.inUse.Add(-1)
+	}
+}
+
+func (p *poolMonitor) stats(opts MongoOptions) PoolStats {
+	stats := PoolStats{
+		MaxPoolSize:      opts.MaxPoolSize,
+		MinPoolSize:      opts.MinPoolSize,
+		Open:             p.open.Load(),
+		InUse:            p.inUse.Load(),
+		WaitingCheckouts: p.waiting.Load(),
+		FailedCheckouts:  p.failed.Load(),
+		ReadPreference:   opts.ReadPreference,
+	}
+	if idle := stats.Open - stats.InUse; idle > 0 {
+		stats.Idle = idle
+	}
+	return stats
+}
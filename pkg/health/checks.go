@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/streadway/amqp"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// PingCheck checks a client that can ping its backend
+func PingCheck(p Pinger) CheckFunc {
+	return p.Ping
+}
+
+// MongoCheck pings the primary of a raw driver client
+func MongoCheck(client *mongo.Client) CheckFunc {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx, readpref.Primary())
+	}
+}
+
+// AMQPCheck checks a raw RabbitMQ connection opened without pkg/messaging
+func AMQPCheck(conn *amqp.Connection) CheckFunc {
+	return func(ctx context.Context) error {
+		if conn.IsClosed() {
+			return errors.New("rabbitmq connection is closed")
+		}
+		return nil
+	}
+}
+
+// GRPCCheck asks a downstream service for its grpc.health.v1 status.
+// Servers without the health service are considered up while the connection is ready.
+func GRPCCheck(conn *grpc.ClientConn, service string) CheckFunc {
+	client := healthpb.NewHealthClient(conn)
+
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			if status.Code(err) == codes.Unimplemented && conn.GetState() == connectivity.Ready {
+				return nil
+			}
+			return err
+		}
+
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("%s is %s", conn.Target(), resp.GetStatus())
+		}
+		return nil
+	}
+}
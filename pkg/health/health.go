@@ -0,0 +1,236 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Overall service statuses reported by /health
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"  // an optional dependency is down, the service still serves
+	StatusUnhealthy = "unhealthy" // a readiness gate is down
+)
+
+// Component statuses
+const (
+	ComponentUp   = "up"
+	ComponentDown = "down"
+)
+
+const (
+	defaultTimeout  = 3 * time.Second
+	defaultInterval = 10 * time.Second
+)
+
+// CheckFunc returns nil while the dependency is usable
+type CheckFunc func(ctx context.Context) error
+
+// DetailedCheckFunc also returns details shown in the report, such as connection pool usage
+type DetailedCheckFunc func(ctx context.Context) (interface{}, error)
+
+// Pinger is implemented by the shared Mongo, Redis and RabbitMQ clients
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type ComponentStatus struct {
+	Status    string      `json:"status"`
+	Critical  bool        `json:"critical"`
+	Error     string      `json:"error,omitempty"`
+	LatencyMs int64       `json:"latency_ms"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+type Report struct {
+	Service    string                     `json:"service"`
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components"`
+	CheckedAt  time.Time                  `json:"checked_at"`
+}
+
+type component struct {
+	name     string
+	check    DetailedCheckFunc
+	critical bool
+}
+
+// Checker runs dependency checks and serves them over grpc.health.v1 and Gin
+type Checker struct {
+	service    string
+	timeout    time.Duration
+	mu         sync.RWMutex
+	components []component
+	server     *grpchealth.Server
+}
+
+// NewChecker creates a checker that reports NOT_SERVING until the first check passes
+func NewChecker(service string) *Checker {
+	c := &Checker{
+		service: service,
+		timeout: defaultTimeout,
+		server:  grpchealth.NewServer(),
+	}
+	c.setServing(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// SetTimeout limits how long a single check may take
+func (c *Checker) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		c.timeout = timeout
+	}
+}
+
+// Add registers a readiness gate, the service is not serving while it fails
+func (c *Checker) Add(name string, check CheckFunc) {
+	c.add(name, check, true)
+}
+
+// AddOptional registers a dependency whose failure only degrades the service
+func (c *Checker) AddOptional(name string, check CheckFunc) {
+	c.add(name, check, false)
+}
+
+// AddDetailed registers a readiness gate that reports details along with its status
+func (c *Checker) AddDetailed(name string, check DetailedCheckFunc) {
+	c.addDetailed(name, check, true)
+}
+
+func (c *Checker) add(name string, check CheckFunc, critical bool) {
+	c.addDetailed(name, func(ctx context.Context) (interface{}, error) {
+		return nil, check(ctx)
+	}, critical)
+}
+
+func (c *Checker) addDetailed(name string, check DetailedCheckFunc, critical bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.components = append(c.components, component{name: name, check: check, critical: critical})
+}
+
+// Check runs all checks concurrently
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	components := make([]component, len(c.components))
+	copy(components, c.components)
+	c.mu.RUnlock()
+
+	report := Report{
+		Service:    c.service,
+		Status:     StatusHealthy,
+		Components: make(map[string]ComponentStatus, len(components)),
+		CheckedAt:  time.Now(),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, comp := range components {
+		wg.Add(1)
+		go func(comp component) {
+			defer wg.Done()
+			status := c.run(ctx, comp)
+
+			mu.Lock()
+			report.Components[comp.name] = status
+			mu.Unlock()
+		}(comp)
+	}
+	wg.Wait()
+
+	for _, status := range report.Components {
+		if status.Status == ComponentUp {
+			continue
+		}
+		if status.Critical {
+			report.Status = StatusUnhealthy
+			break
+		}
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, comp component) ComponentStatus {
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	details, err := comp.check(checkCtx)
+
+	status := ComponentStatus{
+		Status:    ComponentUp,
+		Critical:  comp.critical,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Details:   details,
+	}
+	if err != nil {
+		status.Status = ComponentDown
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// Register exposes grpc.health.v1 on the server
+func (c *Checker) Register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, c.server)
+}
+
+// Run refreshes the gRPC serving status until ctx is done, then marks the service as not serving
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	c.refresh(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.server.Shutdown()
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+func (c *Checker) refresh(ctx context.Context) {
+	if c.Check(ctx).Status == StatusUnhealthy {
+		c.setServing(healthpb.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+	c.setServing(healthpb.HealthCheckResponse_SERVING)
+}
+
+// setServing updates both the overall status and the named service status
+func (c *Checker) setServing(status healthpb.HealthCheckResponse_ServingStatus) {
+	c.server.SetServingStatus("", status)
+	c.server.SetServingStatus(c.service, status)
+}
+
+// Handler serves the report, readiness gate failures answer 503
+func (c *Checker) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		report := c.Check(ctx.Request.Context())
+
+		code := http.StatusOK
+		if report.Status == StatusUnhealthy {
+			code = http.StatusServiceUnavailable
+		}
+		ctx.JSON(code, report)
+	}
+}
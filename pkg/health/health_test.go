@@ -0,0 +1,112 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func up(ctx context.Context) error   { return nil }
+func down(ctx context.Context) error { return errors.New("connection refused") }
+
+func TestCheckStatuses(t *testing.T) {
+	checker := NewChecker("test-service")
+	checker.Add("mongodb", up)
+	assert.Equal(t, StatusHealthy, checker.Check(context.Background()).Status)
+
+	checker.AddOptional("sms-service", down)
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, ComponentDown, report.Components["sms-service"].Status)
+	assert.Equal(t, "connection refused", report.Components["sms-service"].Error)
+	assert.False(t, report.Components["sms-service"].Critical)
+
+	checker.Add("redis", down)
+	report = checker.Check(context.Background())
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Equal(t, ComponentUp, report.Components["mongodb"].Status)
+	assert.True(t, report.Components["redis"].Critical)
+}
+
+func TestCheckTimeout(t *testing.T) {
+	checker := NewChecker("test-service")
+	checker.SetTimeout(10 * time.Millisecond)
+	checker.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components["slow"].Error)
+}
+
+func TestCheckDetails(t *testing.T) {
+	checker := NewChecker("test-service")
+	checker.AddDetailed("mongodb", func(ctx context.Context) (interface{}, error) {
+		return map[string]int{"in_use": 3}, errors.New("ping failed")
+	})
+	checker.Add("redis", up)
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.True(t, report.Components["mongodb"].Critical)
+	assert.Equal(t, map[string]int{"in_use": 3}, report.Components["mongodb"].Details)
+	assert.Equal(t, "ping failed", report.Components["mongodb"].Error)
+	assert.Nil(t, report.Components["redis"].Details)
+}
+
+func TestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	checker := NewChecker("test-service")
+	checker.Add("mongodb", down)
+
+	router := gin.New()
+	router.GET("/health", checker.Handler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, "test-service", report.Service)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Equal(t, ComponentDown, report.Components["mongodb"].Status)
+}
+
+func TestServingStatus(t *testing.T) {
+	healthy := true
+	checker := NewChecker("test-service")
+	checker.Add("mongodb", func(ctx context.Context) error {
+		if healthy {
+			return nil
+		}
+		return errors.New("down")
+	})
+
+	servingStatus := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := checker.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "test-service"})
+		require.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus())
+
+	checker.refresh(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus())
+
+	healthy = false
+	checker.refresh(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus())
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+type contextKey int
+
+const (
+	traceIDKey contextKey = iota
+	requestIDKey
+	accountIDKey
+)
+
+// Attribute names of the context values in log records
+const (
+	TraceIDKey   = "trace_id"
+	RequestIDKey = "request_id"
+	AccountIDKey = "account_id"
+)
+
+// ContextWithTraceID returns a context whose log records carry the trace ID. The trace ID
+// follows a request across services, see UnaryServerInterceptor.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// ContextWithRequestID returns a context whose log records carry the request ID of one call
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// ContextWithAccountID returns a context whose log records carry the account being worked on
+func ContextWithAccountID(ctx context.Context, accountID string) context.Context {
+	return context.WithValue(ctx, accountIDKey, accountID)
+}
+
+// TraceIDFromContext returns the trace ID of the context, empty when there is none
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey).(string)
+	return id
+}
+
+// RequestIDFromContext returns the request ID of the context, empty when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// AccountIDFromContext returns the account ID of the context, empty when there is none
+func AccountIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(accountIDKey).(string)
+	return id
+}
+
+// NewID returns a random 16 byte hex ID for traces and requests
+func NewID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// contextHandler adds the IDs of the record's context, so slog.InfoContext and
+// Logger.WithContext log them alike
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := TraceIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String(TraceIDKey, id))
+	}
+	if id := RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	if id := AccountIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String(AccountIDKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
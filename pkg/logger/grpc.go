@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceMetadataKey carries the trace ID between services in gRPC metadata
+const TraceMetadataKey = "x-trace-id"
+
+// ServerOptions installs the trace interceptors on a gRPC server
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor()),
+	}
+}
+
+// DialOptions installs the trace interceptors on a gRPC client
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+	}
+}
+
+// UnaryServerInterceptor puts the caller's trace ID into the context of the call, or starts a
+// new trace when the caller sent none
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(incomingTrace(ctx), req)
+	}
+}
+
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &traceStream{ServerStream: ss, ctx: incomingTrace(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor passes the trace ID of the context on to the called service
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingTrace(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingTrace(ctx), desc, cc, method, opts...)
+	}
+}
+
+func incomingTrace(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TraceMetadataKey); len(values) > 0 && values[0] != "" {
+			return ContextWithTraceID(ctx, values[0])
+		}
+	}
+	return ContextWithTraceID(ctx, NewID())
+}
+
+func outgoingTrace(ctx context.Context) context.Context {
+	traceID := TraceIDFromContext(ctx)
+	if traceID == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, TraceMetadataKey, traceID)
+}
+
+// traceStream exposes the context with the trace ID to stream handlers
+type traceStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *traceStream) Context() context.Context {
+	return s.ctx
+}
@@ -3,21 +3,42 @@ package logger
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"sort"
+	"strings"
 	"time"
-
-	"github.com/sirupsen/logrus"
 )
 
+// Logger is the logger every service uses. It is backed by log/slog and accepts the call
+// styles found across the services, so none of them needs its own adapter:
+//
+//	log.Info("Account created", "account_id", id)            // key-value pairs
+//	log.Info("Account created", logger.Field{Key: "account_id", Value: id})
+//	log.WithError(err).Error("Failed to create account")      // logrus style
+//	log.Errorf("Failed to create account %s: %v", id, err)    // printf style
+//
+// Arguments may mix key-value pairs, Field, Fields, slog.Attr and a bare error, which is
+// logged under "error". Trace, request and account IDs of the context passed to WithContext
+// are added to every record.
 type Logger interface {
-	Debug(msg string, fields ...Field)
-	Info(msg string, fields ...Field)
-	Warn(msg string, fields ...Field)
-	Error(msg string, fields ...Field)
-	Fatal(msg string, fields ...Field)
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+	Fatal(msg string, args ...any)
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
 	WithContext(ctx context.Context) Logger
 	WithField(key string, value interface{}) Logger
 	WithFields(fields Fields) Logger
+	WithError(err error) Logger
+	// Slog returns the underlying slog logger for code written against log/slog
+	Slog() *slog.Logger
 }
 
 type Field struct {
@@ -27,129 +48,294 @@ type Field struct {
 
 type Fields map[string]interface{}
 
-type logrusLogger struct {
-	logger *logrus.Logger
-	entry  *logrus.Entry
+// LevelFatal is logged by Fatal before the process exits
+const LevelFatal = slog.Level(12)
+
+// Config describes a logger. Empty fields fall back to LOG_LEVEL and LOG_FORMAT, then to
+// info and json.
+type Config struct {
+	Service string // Added to every record as "service" when set
+	Level   string // debug, info, warn, error
+	Format  string // json or text
+	Output  io.Writer
+}
+
+// exit is replaced in tests
+var exit = os.Exit
+
+type slogLogger struct {
+	logger *slog.Logger
+	ctx    context.Context
 }
 
+// New creates a logger with the given level and format
 func New(level string, format string) Logger {
-	log := logrus.New()
+	return NewFromConfig(Config{Level: level, Format: format})
+}
+
+// FromEnv creates the logger of a service from LOG_LEVEL and LOG_FORMAT
+func FromEnv(service string) Logger {
+	return NewFromConfig(Config{Service: service})
+}
+
+// NewFromConfig creates a logger from the config
+func NewFromConfig(cfg Config) Logger {
+	if cfg.Level == "" {
+		cfg.Level = os.Getenv("LOG_LEVEL")
+	}
+	if cfg.Format == "" {
+		cfg.Format = os.Getenv("LOG_FORMAT")
+	}
+	if cfg.Output == nil {
+		cfg.Output = os.Stdout
+	}
+
+	opts := &slog.HandlerOptions{
+		Level:       ParseLevel(cfg.Level),
+		ReplaceAttr: replaceAttr,
+	}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "text") {
+		handler = slog.NewTextHandler(cfg.Output, opts)
+	} else {
+		handler = slog.NewJSONHandler(cfg.Output, opts)
+	}
 
-	parsedLevel, err := logrus.ParseLevel(level)
-	if err != nil {
-		parsedLevel = logrus.InfoLevel
+	log := slog.New(&contextHandler{Handler: handler})
+	if cfg.Service != "" {
+		log = log.With(slog.String("service", cfg.Service))
 	}
-	log.SetLevel(parsedLevel)
 
-	switch format {
-	case "json":
-		log.SetFormatter(&logrus.JSONFormatter{
-			TimestampFormat: time.RFC3339Nano,
-		})
+	return &slogLogger{logger: log}
+}
+
+// ParseLevel parses a level name, unknown names are info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug", "trace":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	case "fatal", "panic":
+		return LevelFatal
 	default:
-		log.SetFormatter(&logrus.TextFormatter{
-			TimestampFormat: time.RFC3339Nano,
-			FullTimestamp:   true,
-		})
+		return slog.LevelInfo
 	}
+}
 
-	log.SetOutput(os.Stdout)
+// replaceAttr names the fatal level and keeps timestamps in the RFC 3339 format the logs had before
+func replaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
 
-	return &logrusLogger{
-		logger: log,
-		entry:  log.WithFields(logrus.Fields{}),
+	switch a.Key {
+	case slog.LevelKey:
+		if level, ok := a.Value.Any().(slog.Level); ok && level >= LevelFatal {
+			return slog.String(slog.LevelKey, "FATAL")
+		}
+	case slog.TimeKey:
+		if t, ok := a.Value.Any().(time.Time); ok {
+			return slog.String(slog.TimeKey, t.Format(time.RFC3339Nano))
+		}
 	}
+	return a
+}
+
+func (l *slogLogger) Debug(msg string, args ...any) {
+	l.log(slog.LevelDebug, msg, args)
 }
 
-func (l *logrusLogger) Debug(msg string, fields ...Field) {
-	l.withFields(fields).Debug(msg)
+func (l *slogLogger) Info(msg string, args ...any) {
+	l.log(slog.LevelInfo, msg, args)
 }
 
-func (l *logrusLogger) Info(msg string, fields ...Field) {
-	l.withFields(fields).Info(msg)
+func (l *slogLogger) Warn(msg string, args ...any) {
+	l.log(slog.LevelWarn, msg, args)
 }
 
-func (l *logrusLogger) Warn(msg string, fields ...Field) {
-	l.withFields(fields).Warning(msg)
+func (l *slogLogger) Error(msg string, args ...any) {
+	l.log(slog.LevelError, msg, args)
 }
 
-func (l *logrusLogger) Error(msg string, fields ...Field) {
-	l.withFields(fields).Error(msg)
+func (l *slogLogger) Fatal(msg string, args ...any) {
+	l.log(LevelFatal, msg, args)
+	exit(1)
 }
 
-func (l *logrusLogger) Fatal(msg string, fields ...Field) {
-	l.withFields(fields).Fatal(msg)
+func (l *slogLogger) Debugf(format string, args ...any) {
+	l.logf(slog.LevelDebug, format, args)
 }
 
-func (l *logrusLogger) WithContext(ctx context.Context) Logger {
-	return &logrusLogger{
-		logger: l.logger,
-		entry:  l.entry.WithContext(ctx),
+func (l *slogLogger) Infof(format string, args ...any) {
+	l.logf(slog.LevelInfo, format, args)
+}
+
+func (l *slogLogger) Warnf(format string, args ...any) {
+	l.logf(slog.LevelWarn, format, args)
+}
+
+func (l *slogLogger) Errorf(format string, args ...any) {
+	l.logf(slog.LevelError, format, args)
+}
+
+func (l *slogLogger) Fatalf(format string, args ...any) {
+	l.logf(LevelFatal, format, args)
+	exit(1)
+}
+
+func (l *slogLogger) WithContext(ctx context.Context) Logger {
+	return &slogLogger{logger: l.logger, ctx: ctx}
+}
+
+func (l *slogLogger) WithField(key string, value interface{}) Logger {
+	return &slogLogger{logger: l.logger.With(slog.Any(key, value)), ctx: l.ctx}
+}
+
+func (l *slogLogger) WithFields(fields Fields) Logger {
+	return &slogLogger{logger: l.logger.With(fieldsAttrs(fields)...), ctx: l.ctx}
+}
+
+func (l *slogLogger) WithError(err error) Logger {
+	if err == nil {
+		return l
 	}
+	return &slogLogger{logger: l.logger.With(slog.Any("error", err)), ctx: l.ctx}
 }
 
-func (l *logrusLogger) WithField(key string, value interface{}) Logger {
-	return &logrusLogger{
-		logger: l.logger,
-		entry:  l.entry.WithField(key, value),
+func (l *slogLogger) Slog() *slog.Logger {
+	return l.logger
+}
+
+func (l *slogLogger) context() context.Context {
+	if l.ctx == nil {
+		return context.Background()
+	}
+	return l.ctx
+}
+
+func (l *slogLogger) log(level slog.Level, msg string, args []any) {
+	ctx := l.context()
+	if !l.logger.Enabled(ctx, level) {
+		return
 	}
+	l.logger.Log(ctx, level, msg, Args(args...)...)
 }
 
-func (l *logrusLogger) WithFields(fields Fields) Logger {
-	logrusFields := logrus.Fields{}
-	for k, v := range fields {
-		logrusFields[k] = v
+func (l *slogLogger) logf(level slog.Level, format string, args []any) {
+	ctx := l.context()
+	if !l.logger.Enabled(ctx, level) {
+		return
 	}
-	return &logrusLogger{
-		logger: l.logger,
-		entry:  l.entry.WithFields(logrusFields),
+	l.logger.Log(ctx, level, fmt.Sprintf(format, args...))
+}
+
+// Args converts the arguments of the call styles Logger accepts into slog attributes
+func Args(args ...any) []any {
+	attrs := make([]any, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case Field:
+			attrs = append(attrs, slog.Any(arg.Key, arg.Value))
+		case []Field:
+			for _, f := range arg {
+				attrs = append(attrs, slog.Any(f.Key, f.Value))
+			}
+		case Fields:
+			attrs = append(attrs, fieldsAttrs(arg)...)
+		case map[string]interface{}:
+			attrs = append(attrs, fieldsAttrs(arg)...)
+		case slog.Attr:
+			attrs = append(attrs, arg)
+		case error:
+			attrs = append(attrs, slog.Any("error", arg))
+		case string:
+			if i+1 < len(args) {
+				attrs = append(attrs, slog.Any(arg, args[i+1]))
+				i++
+			} else {
+				attrs = append(attrs, slog.String("!BADKEY", arg))
+			}
+		default:
+			attrs = append(attrs, slog.Any("!BADKEY", arg))
+		}
 	}
+	return attrs
 }
 
-func (l *logrusLogger) withFields(fields []Field) *logrus.Entry {
-	if len(fields) == 0 {
-		return l.entry
+// fieldsAttrs converts fields sorted by key, so records are stable
+func fieldsAttrs(fields map[string]interface{}) []any {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
-	logrusFields := logrus.Fields{}
-	for _, f := range fields {
-		logrusFields[f.Key] = f.Value
+	attrs := make([]any, 0, len(fields))
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, fields[k]))
 	}
-	return l.entry.WithFields(logrusFields)
+	return attrs
 }
 
 var defaultLogger Logger
 
 func init() {
-	defaultLogger = New("info", "json")
+	defaultLogger = NewFromConfig(Config{})
 }
 
+// SetDefault replaces the package logger and makes it the slog default, so slog.Info and the
+// standard log package write through it as well
 func SetDefault(l Logger) {
 	defaultLogger = l
+	slog.SetDefault(l.Slog())
 }
 
 func Default() Logger {
 	return defaultLogger
 }
 
-func Debug(msg string, fields ...Field) {
-	defaultLogger.Debug(msg, fields...)
+func Debug(msg string, args ...any) {
+	defaultLogger.Debug(msg, args...)
+}
+
+func Info(msg string, args ...any) {
+	defaultLogger.Info(msg, args...)
+}
+
+func Warn(msg string, args ...any) {
+	defaultLogger.Warn(msg, args...)
 }
 
-func Info(msg string, fields ...Field) {
-	defaultLogger.Info(msg, fields...)
+func Error(msg string, args ...any) {
+	defaultLogger.Error(msg, args...)
 }
 
-func Warn(msg string, fields ...Field) {
-	defaultLogger.Warn(msg, fields...)
+func Fatal(msg string, args ...any) {
+	defaultLogger.Fatal(msg, args...)
 }
 
-func Error(msg string, fields ...Field) {
-	defaultLogger.Error(msg, fields...)
+func Debugf(format string, args ...any) {
+	defaultLogger.Debugf(format, args...)
 }
 
-func Fatal(msg string, fields ...Field) {
-	defaultLogger.Fatal(msg, fields...)
+func Infof(format string, args ...any) {
+	defaultLogger.Infof(format, args...)
+}
+
+func Warnf(format string, args ...any) {
+	defaultLogger.Warnf(format, args...)
+}
+
+func Errorf(format string, args ...any) {
+	defaultLogger.Errorf(format, args...)
+}
+
+func Fatalf(format string, args ...any) {
+	defaultLogger.Fatalf(format, args...)
 }
 
 func WithContext(ctx context.Context) Logger {
@@ -164,37 +350,28 @@ func WithFields(fields Fields) Logger {
 	return defaultLogger.WithFields(fields)
 }
 
+func WithError(err error) Logger {
+	return defaultLogger.WithError(err)
+}
+
 func LogMiddleware(serviceName string) func(next func(ctx context.Context, req interface{}) (interface{}, error)) func(ctx context.Context, req interface{}) (interface{}, error) {
 	return func(next func(ctx context.Context, req interface{}) (interface{}, error)) func(ctx context.Context, req interface{}) (interface{}, error) {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
 			start := time.Now()
 
-			requestID := fmt.Sprintf("%d", time.Now().UnixNano())
-			ctx = context.WithValue(ctx, "request_id", requestID)
+			ctx = ContextWithRequestID(ctx, NewID())
+			log := WithContext(ctx)
 
-			Info("Request started",
-				Field{Key: "service", Value: serviceName},
-				Field{Key: "request_id", Value: requestID},
-				Field{Key: "request", Value: fmt.Sprintf("%T", req)},
-			)
+			log.Info("Request started", "service", serviceName, "request", fmt.Sprintf("%T", req))
 
 			resp, err := next(ctx, req)
 
 			duration := time.Since(start)
 
 			if err != nil {
-				Error("Request failed",
-					Field{Key: "service", Value: serviceName},
-					Field{Key: "request_id", Value: requestID},
-					Field{Key: "duration", Value: duration.Seconds()},
-					Field{Key: "error", Value: err.Error()},
-				)
+				log.Error("Request failed", "service", serviceName, "duration", duration.Seconds(), "error", err.Error())
 			} else {
-				Info("Request completed",
-					Field{Key: "service", Value: serviceName},
-					Field{Key: "request_id", Value: requestID},
-					Field{Key: "duration", Value: duration.Seconds()},
-				)
+				log.Info("Request completed", "service", serviceName, "duration", duration.Seconds())
 			}
 
 			return resp, err
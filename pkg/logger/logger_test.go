@@ -0,0 +1,230 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func newTestLogger(level string) (Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return NewFromConfig(Config{Service: "test-service", Level: level, Format: "json", Output: &buf}), &buf
+}
+
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func lastRecord(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+
+	records := decodeRecords(t, buf)
+	require.NotEmpty(t, records)
+	return records[len(records)-1]
+}
+
+func TestLogger_CallStyles(t *testing.T) {
+	log, buf := newTestLogger("debug")
+	err := errors.New("boom")
+
+	log.Info("key-value", "account_id", "a1", "attempt", 2)
+	record := lastRecord(t, buf)
+	assert.Equal(t, "key-value", record["msg"])
+	assert.Equal(t, "a1", record["account_id"])
+	assert.Equal(t, float64(2), record["attempt"])
+	assert.Equal(t, "test-service", record["service"])
+
+	log.Info("fields", Field{Key: "account_id", Value: "a2"}, []Field{{Key: "phone", Value: "+1"}}, Fields{"platform": "vk"})
+	record = lastRecord(t, buf)
+	assert.Equal(t, "a2", record["account_id"])
+	assert.Equal(t, "+1", record["phone"])
+	assert.Equal(t, "vk", record["platform"])
+
+	log.Error("bare error", err)
+	record = lastRecord(t, buf)
+	assert.Equal(t, "boom", record["error"])
+	assert.Equal(t, "ERROR", record["level"])
+
+	log.WithError(err).WithField("step", "sms").Warn("with error")
+	record = lastRecord(t, buf)
+	assert.Equal(t, "boom", record["error"])
+	assert.Equal(t, "sms", record["step"])
+
+	log.Infof("account %s created in %d attempts", "a3", 3)
+	record = lastRecord(t, buf)
+	assert.Equal(t, "account a3 created in 3 attempts", record["msg"])
+
+	log.Info("dangling", "orphan")
+	record = lastRecord(t, buf)
+	assert.Equal(t, "orphan", record["!BADKEY"])
+}
+
+func TestDefaultLogger_Printf(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+
+	log, buf := newTestLogger("info")
+	SetDefault(log)
+
+	Errorf("Failed to send alert to %d: %v", 42, errors.New("boom"))
+	record := lastRecord(t, buf)
+	assert.Equal(t, "Failed to send alert to 42: boom", record["msg"])
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "test-service", record["service"])
+
+	Warnf("Skipping digest %s", "daily")
+	assert.Equal(t, "WARN", lastRecord(t, buf)["level"])
+
+	Debugf("not logged at info")
+	assert.Len(t, decodeRecords(t, buf), 2)
+}
+
+func TestLogger_WithErrorNil(t *testing.T) {
+	log, buf := newTestLogger("info")
+
+	log.WithError(nil).Info("no error")
+	record := lastRecord(t, buf)
+	_, ok := record["error"]
+	assert.False(t, ok)
+}
+
+func TestLogger_Level(t *testing.T) {
+	log, buf := newTestLogger("warn")
+
+	log.Debug("debug")
+	log.Info("info")
+	log.Warn("warn")
+	log.Errorf("error %d", 1)
+
+	records := decodeRecords(t, buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "warn", records[0]["msg"])
+	assert.Equal(t, "error 1", records[1]["msg"])
+}
+
+func TestLogger_LevelFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("LOG_FORMAT", "text")
+
+	var buf bytes.Buffer
+	log := NewFromConfig(Config{Output: &buf})
+
+	log.Warn("hidden")
+	log.Error("shown", "account_id", "a1")
+
+	out := buf.String()
+	assert.NotContains(t, out, "hidden")
+	assert.Contains(t, out, "msg=shown")
+	assert.Contains(t, out, "account_id=a1")
+}
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, ParseLevel("DEBUG"))
+	assert.Equal(t, slog.LevelInfo, ParseLevel(""))
+	assert.Equal(t, slog.LevelInfo, ParseLevel("verbose"))
+	assert.Equal(t, slog.LevelWarn, ParseLevel("warning"))
+	assert.Equal(t, slog.LevelError, ParseLevel(" error "))
+	assert.Equal(t, LevelFatal, ParseLevel("fatal"))
+}
+
+func TestLogger_Fatal(t *testing.T) {
+	log, buf := newTestLogger("info")
+
+	code := -1
+	original := exit
+	exit = func(c int) { code = c }
+	defer func() { exit = original }()
+
+	log.Fatal("cannot start", "port", 8080)
+	assert.Equal(t, 1, code)
+
+	record := lastRecord(t, buf)
+	assert.Equal(t, "FATAL", record["level"])
+	assert.Equal(t, float64(8080), record["port"])
+}
+
+func TestLogger_ContextIDs(t *testing.T) {
+	log, buf := newTestLogger("info")
+
+	ctx := ContextWithTraceID(context.Background(), "trace-1")
+	ctx = ContextWithRequestID(ctx, "request-1")
+	ctx = ContextWithAccountID(ctx, "account-1")
+
+	log.WithContext(ctx).WithField("step", "login").Info("with context")
+	record := lastRecord(t, buf)
+	assert.Equal(t, "trace-1", record[TraceIDKey])
+	assert.Equal(t, "request-1", record[RequestIDKey])
+	assert.Equal(t, "account-1", record[AccountIDKey])
+	assert.Equal(t, "login", record["step"])
+
+	log.Slog().InfoContext(ctx, "slog call")
+	record = lastRecord(t, buf)
+	assert.Equal(t, "trace-1", record[TraceIDKey])
+
+	log.Info("no context")
+	record = lastRecord(t, buf)
+	_, ok := record[TraceIDKey]
+	assert.False(t, ok)
+}
+
+func TestNewID(t *testing.T) {
+	a, b := NewID(), NewID()
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+}
+
+func TestGRPCTracePropagation(t *testing.T) {
+	ctx := ContextWithTraceID(context.Background(), "trace-1")
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	require.NoError(t, UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"trace-1"}, outgoing.Get(TraceMetadataKey))
+
+	var traceID string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		traceID = TraceIDFromContext(ctx)
+		return nil, nil
+	}
+
+	incoming := metadata.NewIncomingContext(context.Background(), outgoing)
+	_, err := UnaryServerInterceptor()(incoming, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "trace-1", traceID)
+
+	_, err = UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Len(t, traceID, 32)
+}
+
+func TestGRPCClientWithoutTrace(t *testing.T) {
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		_, ok := metadata.FromOutgoingContext(ctx)
+		assert.False(t, ok)
+		return nil
+	}
+	require.NoError(t, UnaryClientInterceptor()(context.Background(), "/svc/Method", nil, nil, nil, invoker))
+}
@@ -14,6 +14,7 @@ type Client interface {
 	PublishToQueue(queueName string, message interface{}) error
 	PublishEvent(exchange, routingKey string, message interface{}) error
 	ConsumeQueue(ctx context.Context, queueName string, handler func([]byte) error) error
+	Ping(ctx context.Context) error
 	Close() error
 }
 
@@ -61,6 +62,10 @@ func (c *client) ConsumeQueue(ctx context.Context, queueName string, handler fun
 	return c.rabbit.ConsumeWithHandler(ctx, queueName, consumerName, handler)
 }
 
+func (c *client) Ping(ctx context.Context) error {
+	return c.rabbit.Ping(ctx)
+}
+
 func (c *client) Close() error {
 	return c.rabbit.Close()
 }
@@ -0,0 +1,171 @@
+package messaging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// DelayMode selects how delayed messages are held back until they are due
+type DelayMode string
+
+const (
+	// DelayModeTTL parks messages in TTL queues that dead-letter into the target exchange.
+	// It needs no broker plugins.
+	DelayModeTTL DelayMode = "ttl"
+	// DelayModePlugin routes messages through an exchange of the rabbitmq_delayed_message_exchange plugin
+	DelayModePlugin DelayMode = "plugin"
+)
+
+// MaxPriority is the highest priority declared on priority queues
+const MaxPriority uint8 = 10
+
+const (
+	delayedExchangeType = "x-delayed-message"
+	delayHeader         = "x-delay"
+	// delayQueueIdle keeps a TTL queue around after its last message is dead-lettered
+	delayQueueIdle = time.Minute
+)
+
+// DelayModeFromEnv reads RABBITMQ_DELAY_MODE, falling back to TTL queues
+func DelayModeFromEnv() DelayMode {
+	if DelayMode(os.Getenv("RABBITMQ_DELAY_MODE")) == DelayModePlugin {
+		return DelayModePlugin
+	}
+	return DelayModeTTL
+}
+
+// PublishOptions are the delivery options of PublishWithOptions
+type PublishOptions struct {
+	// Priority only takes effect on queues declared with DeclarePriorityQueue
+	Priority uint8
+	// Delay postpones delivery to the target exchange
+	Delay   time.Duration
+	Headers map[string]interface{}
+}
+
+// delayChannel is the part of amqp.Channel used by DelayedPublisher
+type delayChannel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	ExchangeBind(destination, key, source string, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+// DelayedPublisher publishes messages that reach their exchange only after a delay.
+// Existing exchanges are left untouched: the plugin mode binds a companion delayed exchange
+// to them, the TTL mode declares a queue per exchange, routing key and rounded delay.
+type DelayedPublisher struct {
+	channel delayChannel
+	mode    DelayMode
+
+	mu       sync.Mutex
+	prepared map[string]bool
+}
+
+func NewDelayedPublisher(channel *amqp.Channel, mode DelayMode) *DelayedPublisher {
+	return newDelayedPublisher(channel, mode)
+}
+
+func newDelayedPublisher(channel delayChannel, mode DelayMode) *DelayedPublisher {
+	return &DelayedPublisher{
+		channel:  channel,
+		mode:     mode,
+		prepared: make(map[string]bool),
+	}
+}
+
+// Publish delivers msg to exchange with routingKey once delay has passed
+func (p *DelayedPublisher) Publish(exchange, routingKey string, msg amqp.Publishing, delay time.Duration) error {
+	if delay <= 0 {
+		return p.channel.Publish(exchange, routingKey, false, false, msg)
+	}
+
+	if p.mode == DelayModePlugin {
+		delayed, err := p.delayedExchange(exchange)
+		if err != nil {
+			return err
+		}
+
+		headers := amqp.Table{}
+		for key, value := range msg.Headers {
+			headers[key] = value
+		}
+		headers[delayHeader] = delay.Milliseconds()
+		msg.Headers = headers
+
+		return p.channel.Publish(delayed, routingKey, false, false, msg)
+	}
+
+	queue, err := p.declareDelayQueue(exchange, routingKey, roundDelay(delay))
+	if err != nil {
+		return err
+	}
+
+	return p.channel.Publish("", queue, false, false, msg)
+}
+
+// delayedExchange declares the plugin exchange that forwards due messages to exchange
+func (p *DelayedPublisher) delayedExchange(exchange string) (string, error) {
+	delayed := exchange + ".delayed"
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.prepared[delayed] {
+		return delayed, nil
+	}
+
+	if err := p.channel.ExchangeDeclare(delayed, delayedExchangeType, true, false, false, false, amqp.Table{
+		"x-delayed-type": "topic",
+	}); err != nil {
+		return "", fmt.Errorf("failed to declare delayed exchange %s: %w", delayed, err)
+	}
+
+	if err := p.channel.ExchangeBind(exchange, "#", delayed, false, nil); err != nil {
+		return "", fmt.Errorf("failed to bind delayed exchange %s: %w", delayed, err)
+	}
+
+	p.prepared[delayed] = true
+	return delayed, nil
+}
+
+// declareDelayQueue declares the TTL queue on every publish, which also restarts its idle timer
+func (p *DelayedPublisher) declareDelayQueue(exchange, routingKey string, delay time.Duration) (string, error) {
+	name := delayQueueName(exchange, routingKey, delay)
+
+	_, err := p.channel.QueueDeclare(name, true, false, false, false, amqp.Table{
+		"x-message-ttl":             delay.Milliseconds(),
+		"x-expires":                 (delay + delayQueueIdle).Milliseconds(),
+		"x-dead-letter-exchange":    exchange,
+		"x-dead-letter-routing-key": routingKey,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to declare delay queue %s: %w", name, err)
+	}
+
+	return name, nil
+}
+
+func delayQueueName(exchange, routingKey string, delay time.Duration) string {
+	if exchange == "" {
+		return fmt.Sprintf("%s.delay.%d", routingKey, delay.Milliseconds())
+	}
+	return fmt.Sprintf("%s.%s.delay.%d", exchange, routingKey, delay.Milliseconds())
+}
+
+// roundDelay rounds up to a step that grows with the delay, bounding the number of TTL queues.
+// A queue-wide TTL keeps messages expiring in order, unlike per-message expiration.
+func roundDelay(delay time.Duration) time.Duration {
+	step := time.Second
+	switch {
+	case delay >= time.Hour:
+		step = 5 * time.Minute
+	case delay >= time.Minute:
+		step = 10 * time.Second
+	}
+	return (delay + step - 1) / step * step
+}
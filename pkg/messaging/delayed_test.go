@@ -0,0 +1,145 @@
+package messaging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type declaredExchange struct {
+	name string
+	kind string
+	args amqp.Table
+}
+
+type publishedMessage struct {
+	exchange string
+	key      string
+	msg      amqp.Publishing
+}
+
+// fakeDelayChannel records topology declarations and publishes
+type fakeDelayChannel struct {
+	exchanges []declaredExchange
+	bindings  [][3]string
+	queues    map[string]amqp.Table
+	published []publishedMessage
+}
+
+func newFakeDelayChannel() *fakeDelayChannel {
+	return &fakeDelayChannel{queues: make(map[string]amqp.Table)}
+}
+
+func (f *fakeDelayChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	f.exchanges = append(f.exchanges, declaredExchange{name, kind, args})
+	return nil
+}
+
+func (f *fakeDelayChannel) ExchangeBind(destination, key, source string, noWait bool, args amqp.Table) error {
+	f.bindings = append(f.bindings, [3]string{destination, key, source})
+	return nil
+}
+
+func (f *fakeDelayChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	f.queues[name] = args
+	return amqp.Queue{Name: name}, nil
+}
+
+func (f *fakeDelayChannel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	f.published = append(f.published, publishedMessage{exchange, key, msg})
+	return nil
+}
+
+// Test publishing without delay goes straight to the exchange
+func TestDelayedPublisher_NoDelay(t *testing.T) {
+	channel := newFakeDelayChannel()
+	publisher := newDelayedPublisher(channel, DelayModeTTL)
+
+	require.NoError(t, publisher.Publish("sms.commands", "retry", amqp.Publishing{Priority: 5}, 0))
+
+	require.Len(t, channel.published, 1)
+	assert.Equal(t, "sms.commands", channel.published[0].exchange)
+	assert.Equal(t, "retry", channel.published[0].key)
+	assert.Equal(t, uint8(5), channel.published[0].msg.Priority)
+	assert.Empty(t, channel.queues)
+}
+
+// Test TTL mode parks the message in a queue dead-lettering into the target
+func TestDelayedPublisher_TTL(t *testing.T) {
+	channel := newFakeDelayChannel()
+	publisher := newDelayedPublisher(channel, DelayModeTTL)
+
+	require.NoError(t, publisher.Publish("sms.commands", "retry", amqp.Publishing{Priority: 10}, time.Minute))
+
+	queue := "sms.commands.retry.delay.60000"
+	require.Contains(t, channel.queues, queue)
+	args := channel.queues[queue]
+	assert.Equal(t, int64(60000), args["x-message-ttl"])
+	assert.Equal(t, int64(120000), args["x-expires"])
+	assert.Equal(t, "sms.commands", args["x-dead-letter-exchange"])
+	assert.Equal(t, "retry", args["x-dead-letter-routing-key"])
+
+	require.Len(t, channel.published, 1)
+	assert.Equal(t, "", channel.published[0].exchange)
+	assert.Equal(t, queue, channel.published[0].key)
+	assert.Equal(t, uint8(10), channel.published[0].msg.Priority)
+}
+
+// Test plugin mode sets the delay header on a companion exchange
+func TestDelayedPublisher_Plugin(t *testing.T) {
+	channel := newFakeDelayChannel()
+	publisher := newDelayedPublisher(channel, DelayModePlugin)
+
+	headers := amqp.Table{"source": "test"}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, publisher.Publish("warming.commands", "execute_action", amqp.Publishing{Headers: headers}, 90*time.Second))
+	}
+
+	// The companion exchange is declared and bound once
+	require.Len(t, channel.exchanges, 1)
+	assert.Equal(t, "warming.commands.delayed", channel.exchanges[0].name)
+	assert.Equal(t, delayedExchangeType, channel.exchanges[0].kind)
+	assert.Equal(t, [][3]string{{"warming.commands", "#", "warming.commands.delayed"}}, channel.bindings)
+
+	require.Len(t, channel.published, 2)
+	msg := channel.published[0].msg
+	assert.Equal(t, "warming.commands.delayed", channel.published[0].exchange)
+	assert.Equal(t, "execute_action", channel.published[0].key)
+	assert.Equal(t, int64(90000), msg.Headers[delayHeader])
+	assert.Equal(t, "test", msg.Headers["source"])
+	assert.NotContains(t, headers, delayHeader, "caller headers must not be modified")
+	assert.Empty(t, channel.queues)
+}
+
+func TestRoundDelay(t *testing.T) {
+	tests := []struct {
+		delay    time.Duration
+		expected time.Duration
+	}{
+		{1500 * time.Millisecond, 2 * time.Second},
+		{30 * time.Second, 30 * time.Second},
+		{61 * time.Second, 70 * time.Second},
+		{47*time.Minute + 3*time.Second, 47*time.Minute + 10*time.Second},
+		{time.Hour + time.Minute, time.Hour + 5*time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, roundDelay(tt.delay), tt.delay.String())
+	}
+}
+
+func TestDelayQueueName(t *testing.T) {
+	assert.Equal(t, "sms.commands.retry.delay.60000", delayQueueName("sms.commands", "retry", time.Minute))
+	assert.Equal(t, "sms.retry.delay.5000", delayQueueName("", "sms.retry", 5*time.Second))
+}
+
+func TestDelayModeFromEnv(t *testing.T) {
+	t.Setenv("RABBITMQ_DELAY_MODE", "plugin")
+	assert.Equal(t, DelayModePlugin, DelayModeFromEnv())
+
+	t.Setenv("RABBITMQ_DELAY_MODE", "")
+	assert.Equal(t, DelayModeTTL, DelayModeFromEnv())
+}
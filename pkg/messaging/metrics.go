@@ -0,0 +1,29 @@
+package messaging
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	connectionsUp = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rabbitmq_connections_up",
+		Help: "Number of open RabbitMQ connections of the process",
+	})
+	reconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_reconnects_total",
+		Help: "Total number of RabbitMQ reconnection attempts by result",
+	}, []string{"result"})
+	publishConfirms = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_publish_confirms_total",
+		Help: "Total number of published messages by broker confirmation: ack, nack, timeout or lost",
+	}, []string{"result"})
+	republishBuffered = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rabbitmq_republish_buffer_size",
+		Help: "Number of unconfirmed messages waiting to be published again",
+	})
+	republished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_republished_total",
+		Help: "Total number of buffered messages by outcome: confirmed, or dropped after too many attempts or on a full buffer",
+	}, []string{"result"})
+)
@@ -3,19 +3,37 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/streadway/amqp"
 	"github.com/grigta/conveer/pkg/logger"
 )
 
+// ErrConnectionClosed is returned by Ping while the broker connection is down
+var ErrConnectionClosed = errors.New("rabbitmq connection is closed")
+
+// RabbitMQ is a broker client that survives connection loss: it reconnects with backoff,
+// declares the recorded topology on the new channel, restarts registered consumers and
+// republishes messages the broker did not confirm. Delivery is at-least-once and the order of
+// messages buffered across a reconnect is not kept.
 type RabbitMQ struct {
-	conn      *amqp.Connection
-	channel   *amqp.Channel
-	url       string
+	url            string
+	delayMode      DelayMode
+	confirmTimeout time.Duration
+
+	mu        sync.RWMutex
+	current   *connection
 	consumers []ConsumerRegistration
-	stopCh    chan struct{}
+
+	topology *topologyLog
+	pending  *republishBuffer
+	flushMu  sync.Mutex
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
 }
 
 type ConsumerRegistration struct {
@@ -25,8 +43,62 @@ type ConsumerRegistration struct {
 	Context      context.Context
 }
 
+// connection is one broker connection with its channel in confirm mode
+type connection struct {
+	conn       *amqp.Connection
+	channel    *amqp.Channel
+	delayed    *DelayedPublisher
+	connClosed chan *amqp.Error
+	chanClosed chan *amqp.Error
+	downOnce   sync.Once
+}
+
+// down marks the connection as no longer up, once
+func (c *connection) down() {
+	c.downOnce.Do(connectionsUp.Dec)
+}
+
+func (c *connection) close() error {
+	defer c.down()
+
+	if err := c.channel.Close(); err != nil && err != amqp.ErrClosed {
+		c.conn.Close()
+		return fmt.Errorf("failed to close channel: %w", err)
+	}
+	if err := c.conn.Close(); err != nil && err != amqp.ErrClosed {
+		return fmt.Errorf("failed to close connection: %w", err)
+	}
+	return nil
+}
+
 func NewRabbitMQ(url string) (*RabbitMQ, error) {
-	conn, err := amqp.Dial(url)
+	rabbitmq := &RabbitMQ{
+		url:            url,
+		delayMode:      DelayModeFromEnv(),
+		confirmTimeout: ConfirmTimeoutFromEnv(),
+		consumers:      make([]ConsumerRegistration, 0),
+		topology:       newTopologyLog(),
+		pending:        newRepublishBuffer(RepublishBufferFromEnv()),
+		stopCh:         make(chan struct{}),
+	}
+
+	current, err := rabbitmq.open()
+	if err != nil {
+		return nil, err
+	}
+	rabbitmq.current = current
+
+	logger.Info("Connected to RabbitMQ")
+
+	// Start connection monitor
+	go rabbitmq.monitorConnection()
+
+	return rabbitmq, nil
+}
+
+// open dials the broker and opens a channel in confirm mode
+func (r *RabbitMQ) open() (*connection, error) {
+	conn, err := amqp.Dial(r.url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
@@ -37,49 +109,93 @@ func NewRabbitMQ(url string) (*RabbitMQ, error) {
 		return nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
-	logger.Info("Connected to RabbitMQ")
-
-	rabbitmq := &RabbitMQ{
-		conn:      conn,
-		channel:   ch,
-		url:       url,
-		consumers: make([]ConsumerRegistration, 0),
-		stopCh:    make(chan struct{}),
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
 	}
+	confirms := newConfirmTracker(ch.NotifyPublish(make(chan amqp.Confirmation, confirmBufferSize)))
+
+	connectionsUp.Inc()
+
+	return &connection{
+		conn:    conn,
+		channel: ch,
+		delayed: newDelayedPublisher(&confirmChannel{
+			Channel:  ch,
+			confirms: confirms,
+			timeout:  r.confirmTimeout,
+		}, r.delayMode),
+		connClosed: conn.NotifyClose(make(chan *amqp.Error, 1)),
+		chanClosed: ch.NotifyClose(make(chan *amqp.Error, 1)),
+	}, nil
+}
 
-	// Start connection monitor
-	go rabbitmq.monitorConnection()
+func (r *RabbitMQ) active() *connection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
 
-	return rabbitmq, nil
+func (r *RabbitMQ) stopped() bool {
+	select {
+	case <-r.stopCh:
+		return true
+	default:
+		return false
+	}
 }
 
 func (r *RabbitMQ) Close() error {
 	// Stop monitoring
-	close(r.stopCh)
+	r.stopOnce.Do(func() { close(r.stopCh) })
 
-	if err := r.channel.Close(); err != nil {
-		return fmt.Errorf("failed to close channel: %w", err)
+	if lost := r.pending.len(); lost > 0 {
+		logger.Warn("Closing RabbitMQ with unconfirmed messages", logger.Field{Key: "count", Value: lost})
 	}
-	if err := r.conn.Close(); err != nil {
-		return fmt.Errorf("failed to close connection: %w", err)
+
+	return r.active().close()
+}
+
+// Ping reports whether the broker connection and channel are open
+func (r *RabbitMQ) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	current := r.active()
+	if current == nil || current.conn.IsClosed() {
+		return ErrConnectionClosed
+	}
+	if current.channel == nil {
+		return ErrConnectionClosed
 	}
 	return nil
 }
 
+// declare runs a topology step on the current channel and records it for reconnects
+func (r *RabbitMQ) declare(key string, step func(topologyChannel) error) error {
+	if err := step(r.active().channel); err != nil {
+		return err
+	}
+	r.topology.record(key, step)
+	return nil
+}
+
 func (r *RabbitMQ) DeclareExchange(name, kind string, durable, autoDelete bool) error {
-	return r.channel.ExchangeDeclare(
-		name,
-		kind,
-		durable,
-		autoDelete,
-		false,
-		false,
-		nil,
-	)
+	return r.declare("exchange:"+name, func(ch topologyChannel) error {
+		return ch.ExchangeDeclare(
+			name,
+			kind,
+			durable,
+			autoDelete,
+			false,
+			false,
+			nil,
+		)
+	})
 }
 
 func (r *RabbitMQ) DeclareQueue(name string, durable, autoDelete, exclusive bool) (amqp.Queue, error) {
-	return r.channel.QueueDeclare(
+	queue, err := r.active().channel.QueueDeclare(
 		name,
 		durable,
 		autoDelete,
@@ -87,16 +203,47 @@ func (r *RabbitMQ) DeclareQueue(name string, durable, autoDelete, exclusive bool
 		false,
 		nil,
 	)
+	// Server-named queues get a new name on every declaration and cannot be replayed
+	if err == nil && name != "" {
+		r.topology.record("queue:"+name, func(ch topologyChannel) error {
+			_, err := ch.QueueDeclare(name, durable, autoDelete, exclusive, false, nil)
+			return err
+		})
+	}
+	return queue, err
 }
 
-func (r *RabbitMQ) BindQueue(queueName, routingKey, exchangeName string) error {
-	return r.channel.QueueBind(
-		queueName,
-		routingKey,
-		exchangeName,
+// DeclarePriorityQueue declares a durable queue that delivers higher priority messages first
+func (r *RabbitMQ) DeclarePriorityQueue(name string, maxPriority uint8) (amqp.Queue, error) {
+	args := amqp.Table{"x-max-priority": int32(maxPriority)}
+
+	queue, err := r.active().channel.QueueDeclare(
+		name,
+		true,
 		false,
-		nil,
+		false,
+		false,
+		args,
 	)
+	if err == nil {
+		r.topology.record("queue:"+name, func(ch topologyChannel) error {
+			_, err := ch.QueueDeclare(name, true, false, false, false, args)
+			return err
+		})
+	}
+	return queue, err
+}
+
+func (r *RabbitMQ) BindQueue(queueName, routingKey, exchangeName string) error {
+	return r.declare("binding:"+queueName+":"+exchangeName+":"+routingKey, func(ch topologyChannel) error {
+		return ch.QueueBind(
+			queueName,
+			routingKey,
+			exchangeName,
+			false,
+			nil,
+		)
+	})
 }
 
 func (r *RabbitMQ) Publish(exchange, routingKey string, message interface{}) error {
@@ -105,17 +252,15 @@ func (r *RabbitMQ) Publish(exchange, routingKey string, message interface{}) err
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	return r.channel.Publish(
-		exchange,
-		routingKey,
-		false,
-		false,
-		amqp.Publishing{
+	return r.send(pendingPublish{
+		exchange:   exchange,
+		routingKey: routingKey,
+		msg: amqp.Publishing{
 			ContentType: "application/json",
 			Body:        body,
 			Timestamp:   time.Now(),
 		},
-	)
+	})
 }
 
 func (r *RabbitMQ) PublishWithHeaders(exchange, routingKey string, message interface{}, headers map[string]interface{}) error {
@@ -124,22 +269,105 @@ func (r *RabbitMQ) PublishWithHeaders(exchange, routingKey string, message inter
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	return r.channel.Publish(
-		exchange,
-		routingKey,
-		false,
-		false,
-		amqp.Publishing{
+	return r.send(pendingPublish{
+		exchange:   exchange,
+		routingKey: routingKey,
+		msg: amqp.Publishing{
 			Headers:     amqp.Table(headers),
 			ContentType: "application/json",
 			Body:        body,
 			Timestamp:   time.Now(),
 		},
+	})
+}
+
+// PublishWithOptions publishes a persistent message with a priority and an optional delivery delay
+func (r *RabbitMQ) PublishWithOptions(exchange, routingKey string, message interface{}, opts PublishOptions) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return r.send(pendingPublish{
+		exchange:   exchange,
+		routingKey: routingKey,
+		msg: amqp.Publishing{
+			Headers:      amqp.Table(opts.Headers),
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Priority:     opts.Priority,
+			Body:         body,
+			Timestamp:    time.Now(),
+		},
+		delay: opts.Delay,
+	})
+}
+
+// send publishes and waits for the broker confirmation. An unconfirmed message is kept for
+// republishing and the publish succeeds; it fails only when the republish buffer is full.
+func (r *RabbitMQ) send(p pendingPublish) error {
+	err := r.deliver(p)
+	if err == nil {
+		return nil
+	}
+
+	if !r.pending.add(p) {
+		republished.WithLabelValues("dropped").Inc()
+		return fmt.Errorf("failed to publish message and republish buffer is full: %w", err)
+	}
+
+	logger.Warn("Message not confirmed, buffered for republishing",
+		logger.Field{Key: "exchange", Value: p.exchange},
+		logger.Field{Key: "routing_key", Value: p.routingKey},
+		logger.Field{Key: "error", Value: err.Error()},
 	)
+	return nil
+}
+
+func (r *RabbitMQ) deliver(p pendingPublish) error {
+	return r.active().delayed.Publish(p.exchange, p.routingKey, p.msg, p.delay)
+}
+
+// flushPending republishes buffered messages until one fails again. A message is dropped after
+// maxRepublishAttempts failed republishes.
+func (r *RabbitMQ) flushPending() {
+	if !r.flushMu.TryLock() {
+		return
+	}
+	defer r.flushMu.Unlock()
+
+	items := r.pending.drain()
+	for i, p := range items {
+		err := r.deliver(p)
+		if err == nil {
+			republished.WithLabelValues("confirmed").Inc()
+			continue
+		}
+
+		p.attempts++
+		rest := items[i+1:]
+		if p.attempts < maxRepublishAttempts {
+			rest = append([]pendingPublish{p}, rest...)
+		} else {
+			republished.WithLabelValues("dropped").Inc()
+			logger.Error("Dropping message after failed republish attempts",
+				logger.Field{Key: "exchange", Value: p.exchange},
+				logger.Field{Key: "routing_key", Value: p.routingKey},
+				logger.Field{Key: "attempts", Value: p.attempts},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+		}
+		r.pending.restore(rest)
+		return
+	}
+
+	if len(items) > 0 {
+		logger.Info("Republished buffered messages", logger.Field{Key: "count", Value: len(items)})
+	}
 }
 
 func (r *RabbitMQ) Consume(queueName, consumerName string, autoAck bool) (<-chan amqp.Delivery, error) {
-	return r.channel.Consume(
+	return r.active().channel.Consume(
 		queueName,
 		consumerName,
 		autoAck,
@@ -152,12 +380,14 @@ func (r *RabbitMQ) Consume(queueName, consumerName string, autoAck bool) (<-chan
 
 func (r *RabbitMQ) ConsumeWithHandler(ctx context.Context, queueName, consumerName string, handler func([]byte) error) error {
 	// Register consumer for auto-recovery
+	r.mu.Lock()
 	r.consumers = append(r.consumers, ConsumerRegistration{
 		QueueName:    queueName,
 		ConsumerName: consumerName,
 		Handler:      handler,
 		Context:      ctx,
 	})
+	r.mu.Unlock()
 
 	// Start consuming
 	return r.startConsumer(ctx, queueName, consumerName, handler)
@@ -199,37 +429,44 @@ func (r *RabbitMQ) startConsumer(ctx context.Context, queueName, consumerName st
 }
 
 func (r *RabbitMQ) SetQos(prefetchCount int) error {
-	return r.channel.Qos(prefetchCount, 0, false)
+	return r.declare("qos", func(ch topologyChannel) error {
+		return ch.Qos(prefetchCount, 0, false)
+	})
 }
 
+// Reconnect replaces the broker connection, declares the recorded topology on the new channel,
+// restarts registered consumers and republishes buffered messages
 func (r *RabbitMQ) Reconnect() error {
-	if r.conn != nil && !r.conn.IsClosed() {
-		r.conn.Close()
-	}
-
-	conn, err := amqp.Dial(r.url)
+	next, err := r.open()
 	if err != nil {
+		reconnects.WithLabelValues("failed").Inc()
 		return fmt.Errorf("failed to reconnect to RabbitMQ: %w", err)
 	}
 
-	ch, err := conn.Channel()
-	if err != nil {
-		conn.Close()
-		return fmt.Errorf("failed to reopen channel: %w", err)
+	if err := r.topology.replay(next.channel); err != nil {
+		next.close()
+		reconnects.WithLabelValues("failed").Inc()
+		return fmt.Errorf("failed to restore topology after reconnect: %w", err)
 	}
 
-	r.conn = conn
-	r.channel = ch
-
-	logger.Info("Reconnected to RabbitMQ")
+	r.mu.Lock()
+	previous := r.current
+	r.current = next
+	consumers := append([]ConsumerRegistration(nil), r.consumers...)
+	r.mu.Unlock()
 
-	// Re-setup topology
-	if err := r.SetupTopology(); err != nil {
-		logger.Error("Failed to setup topology after reconnect", logger.Field{Key: "error", Value: err.Error()})
+	if previous != nil {
+		previous.close()
 	}
 
+	reconnects.WithLabelValues("success").Inc()
+	logger.Info("Reconnected to RabbitMQ")
+
 	// Restart all registered consumers
-	for _, consumer := range r.consumers {
+	for _, consumer := range consumers {
+		if consumer.Context.Err() != nil {
+			continue
+		}
 		if err := r.startConsumer(consumer.Context, consumer.QueueName, consumer.ConsumerName, consumer.Handler); err != nil {
 			logger.Error("Failed to restart consumer after reconnect",
 				logger.Field{Key: "queue", Value: consumer.QueueName},
@@ -242,31 +479,56 @@ func (r *RabbitMQ) Reconnect() error {
 		}
 	}
 
+	r.flushPending()
 	return nil
 }
 
+// monitorConnection reconnects when the connection or its channel closes and periodically
+// retries buffered messages
 func (r *RabbitMQ) monitorConnection() {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
 	for {
+		current := r.active()
+
+		var reason *amqp.Error
 		select {
 		case <-r.stopCh:
 			return
 		case <-ticker.C:
-			if r.conn != nil && r.conn.IsClosed() {
-				logger.Warn("RabbitMQ connection lost, attempting to reconnect...")
-				for i := 0; i < 5; i++ {
-					if err := r.Reconnect(); err != nil {
-						logger.Error("Failed to reconnect to RabbitMQ",
-							logger.Field{Key: "attempt", Value: i + 1},
-							logger.Field{Key: "error", Value: err.Error()},
-						)
-						time.Sleep(time.Duration(i+1) * time.Second)
-					} else {
-						break
-					}
-				}
+			r.flushPending()
+			continue
+		case reason = <-current.connClosed:
+		case reason = <-current.chanClosed:
+		}
+
+		current.down()
+		// Closed by Close, or replaced by an explicit Reconnect
+		if r.stopped() || r.active() != current {
+			continue
+		}
+
+		fields := []logger.Field{}
+		if reason != nil {
+			fields = append(fields, logger.Field{Key: "reason", Value: reason.Error()})
+		}
+		logger.Warn("RabbitMQ connection lost, attempting to reconnect...", fields)
+
+		for attempt := 0; r.active() == current; attempt++ {
+			if err := r.Reconnect(); err == nil {
+				break
+			} else {
+				logger.Error("Failed to reconnect to RabbitMQ",
+					logger.Field{Key: "attempt", Value: attempt + 1},
+					logger.Field{Key: "error", Value: err.Error()},
+				)
+			}
+
+			select {
+			case <-r.stopCh:
+				return
+			case <-time.After(reconnectBackoff(attempt)):
 			}
 		}
 	}
@@ -350,13 +612,18 @@ func (r *RabbitMQ) SetupSMSTopology() error {
 	}
 
 	// Declare SMS queues
-	smsQueues := []string{"sms.purchase", "sms.get_code", "sms.cancel", "sms.retry"}
+	smsQueues := []string{"sms.purchase", "sms.get_code", "sms.cancel"}
 	for _, queueName := range smsQueues {
 		if _, err := r.DeclareQueue(queueName, true, false, false); err != nil {
 			return fmt.Errorf("failed to declare queue %s: %w", queueName, err)
 		}
 	}
 
+	// Retries of activations close to expiry jump the queue
+	if _, err := r.DeclarePriorityQueue("sms.retry", MaxPriority); err != nil {
+		return fmt.Errorf("failed to declare queue sms.retry: %w", err)
+	}
+
 	// Bind queues to exchanges
 	bindings := []struct {
 		queue    string
@@ -383,16 +650,19 @@ func (r *RabbitMQ) SetupSMSTopology() error {
 func (r *RabbitMQ) CreateDLQ(queueName string) error {
 	dlqName := fmt.Sprintf("%s.dlq", queueName)
 
-	_, err := r.channel.QueueDeclare(
-		dlqName,
-		true,
-		false,
-		false,
-		false,
-		amqp.Table{
-			"x-message-ttl": int32(86400000),
-		},
-	)
+	err := r.declare("queue:"+dlqName, func(ch topologyChannel) error {
+		_, err := ch.QueueDeclare(
+			dlqName,
+			true,
+			false,
+			false,
+			false,
+			amqp.Table{
+				"x-message-ttl": int32(86400000),
+			},
+		)
+		return err
+	})
 
 	if err != nil {
 		return fmt.Errorf("failed to declare DLQ: %w", err)
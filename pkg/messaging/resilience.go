@@ -0,0 +1,261 @@
+package messaging
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+const (
+	defaultConfirmTimeout   = 5 * time.Second
+	defaultRepublishBuffer  = 10000
+	maxRepublishAttempts    = 5
+	confirmBufferSize       = 1024
+	reconnectBackoffInitial = time.Second
+	reconnectBackoffMax     = 30 * time.Second
+)
+
+var (
+	errPublishNacked  = errors.New("broker rejected the message")
+	errConfirmTimeout = errors.New("publish was not confirmed in time")
+	errConfirmLost    = errors.New("channel closed before the publish was confirmed")
+)
+
+// ConfirmTimeoutFromEnv reads RABBITMQ_CONFIRM_TIMEOUT, how long a publish waits for the broker ack
+func ConfirmTimeoutFromEnv() time.Duration {
+	if timeout, err := time.ParseDuration(os.Getenv("RABBITMQ_CONFIRM_TIMEOUT")); err == nil && timeout > 0 {
+		return timeout
+	}
+	return defaultConfirmTimeout
+}
+
+// RepublishBufferFromEnv reads RABBITMQ_REPUBLISH_BUFFER, how many unconfirmed messages are kept
+// for republishing
+func RepublishBufferFromEnv() int {
+	if size, err := strconv.Atoi(os.Getenv("RABBITMQ_REPUBLISH_BUFFER")); err == nil && size >= 0 {
+		return size
+	}
+	return defaultRepublishBuffer
+}
+
+// publishFunc is amqp.Channel.Publish
+type publishFunc func(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+
+// confirmTracker matches broker confirmations of a channel in confirm mode to the publishes
+// waiting for them. Delivery tags are counted here in publish order, so every publish on the
+// channel has to go through the tracker.
+type confirmTracker struct {
+	mu      sync.Mutex
+	nextTag uint64
+	waiters map[uint64]chan bool
+	closed  bool
+}
+
+func newConfirmTracker(confirms <-chan amqp.Confirmation) *confirmTracker {
+	tracker := &confirmTracker{waiters: make(map[uint64]chan bool)}
+	go tracker.dispatch(confirms)
+	return tracker
+}
+
+func (t *confirmTracker) dispatch(confirms <-chan amqp.Confirmation) {
+	for confirm := range confirms {
+		t.mu.Lock()
+		waiter, ok := t.waiters[confirm.DeliveryTag]
+		delete(t.waiters, confirm.DeliveryTag)
+		t.mu.Unlock()
+
+		if ok {
+			waiter <- confirm.Ack
+		}
+	}
+
+	// The channel is closed, nothing still pending will be confirmed
+	t.mu.Lock()
+	t.closed = true
+	for tag, waiter := range t.waiters {
+		close(waiter)
+		delete(t.waiters, tag)
+	}
+	t.mu.Unlock()
+}
+
+// publish sends the message and waits up to timeout for the broker to confirm it
+func (t *confirmTracker) publish(send publishFunc, exchange, key string, msg amqp.Publishing, timeout time.Duration) error {
+	waiter := make(chan bool, 1)
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return errConfirmLost
+	}
+	if err := send(exchange, key, false, false, msg); err != nil {
+		t.mu.Unlock()
+		return err
+	}
+	t.nextTag++
+	tag := t.nextTag
+	t.waiters[tag] = waiter
+	t.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case ack, ok := <-waiter:
+		switch {
+		case !ok:
+			publishConfirms.WithLabelValues("lost").Inc()
+			return errConfirmLost
+		case !ack:
+			publishConfirms.WithLabelValues("nack").Inc()
+			return errPublishNacked
+		}
+		publishConfirms.WithLabelValues("ack").Inc()
+		return nil
+	case <-timer.C:
+		// A late confirmation finds no waiter and is ignored
+		t.mu.Lock()
+		delete(t.waiters, tag)
+		t.mu.Unlock()
+		publishConfirms.WithLabelValues("timeout").Inc()
+		return errConfirmTimeout
+	}
+}
+
+// confirmChannel is the channel handed to DelayedPublisher: declarations go straight to the
+// channel, publishes wait for the broker confirmation
+type confirmChannel struct {
+	*amqp.Channel
+	confirms *confirmTracker
+	timeout  time.Duration
+}
+
+func (c *confirmChannel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	return c.confirms.publish(c.Channel.Publish, exchange, key, msg, c.timeout)
+}
+
+// pendingPublish is a message kept for republishing with the options it was published with
+type pendingPublish struct {
+	exchange   string
+	routingKey string
+	msg        amqp.Publishing
+	delay      time.Duration
+	attempts   int
+}
+
+// republishBuffer holds messages the broker did not confirm until they can be published again
+type republishBuffer struct {
+	mu    sync.Mutex
+	limit int
+	items []pendingPublish
+}
+
+func newRepublishBuffer(limit int) *republishBuffer {
+	return &republishBuffer{limit: limit}
+}
+
+// add keeps the message, false when the buffer is full
+func (b *republishBuffer) add(p pendingPublish) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.items) >= b.limit {
+		return false
+	}
+	b.items = append(b.items, p)
+	republishBuffered.Inc()
+	return true
+}
+
+// drain empties the buffer, returning its messages in the order they were added
+func (b *republishBuffer) drain() []pendingPublish {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	items := b.items
+	b.items = nil
+	republishBuffered.Sub(float64(len(items)))
+	return items
+}
+
+// restore puts messages taken by drain back in front of the buffer
+func (b *republishBuffer) restore(items []pendingPublish) {
+	if len(items) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.items = append(append([]pendingPublish(nil), items...), b.items...)
+	republishBuffered.Add(float64(len(items)))
+}
+
+func (b *republishBuffer) len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.items)
+}
+
+// topologyChannel is the part of amqp.Channel used to declare topology
+type topologyChannel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Qos(prefetchCount, prefetchSize int, global bool) error
+}
+
+// topologyLog remembers exchanges, queues, bindings and QoS declared through RabbitMQ so a new
+// channel gets them again after a reconnect. Repeated declarations are kept once, in the place
+// of the first one.
+type topologyLog struct {
+	mu    sync.Mutex
+	index map[string]int
+	steps []func(topologyChannel) error
+}
+
+func newTopologyLog() *topologyLog {
+	return &topologyLog{index: make(map[string]int)}
+}
+
+func (l *topologyLog) record(key string, step func(topologyChannel) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if i, ok := l.index[key]; ok {
+		l.steps[i] = step
+		return
+	}
+	l.index[key] = len(l.steps)
+	l.steps = append(l.steps, step)
+}
+
+// replay declares everything recorded on ch, stopping at the first error
+func (l *topologyLog) replay(ch topologyChannel) error {
+	l.mu.Lock()
+	steps := append([]func(topologyChannel) error(nil), l.steps...)
+	l.mu.Unlock()
+
+	for _, step := range steps {
+		if err := step(ch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconnectBackoff doubles the wait after each failed attempt, up to reconnectBackoffMax
+func reconnectBackoff(attempt int) time.Duration {
+	backoff := reconnectBackoffInitial
+	for i := 0; i < attempt && backoff < reconnectBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > reconnectBackoffMax {
+		backoff = reconnectBackoffMax
+	}
+	return backoff
+}
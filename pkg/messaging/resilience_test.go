@@ -0,0 +1,172 @@
+package messaging
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendOK(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	return nil
+}
+
+func TestConfirmTrackerAck(t *testing.T) {
+	confirms := make(chan amqp.Confirmation, 2)
+	tracker := newConfirmTracker(confirms)
+
+	confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+	assert.NoError(t, tracker.publish(sendOK, "events", "a", amqp.Publishing{}, time.Second))
+
+	confirms <- amqp.Confirmation{DeliveryTag: 2, Ack: false}
+	assert.ErrorIs(t, tracker.publish(sendOK, "events", "b", amqp.Publishing{}, time.Second), errPublishNacked)
+}
+
+func TestConfirmTrackerTimeout(t *testing.T) {
+	confirms := make(chan amqp.Confirmation, 1)
+	tracker := newConfirmTracker(confirms)
+
+	err := tracker.publish(sendOK, "events", "a", amqp.Publishing{}, 10*time.Millisecond)
+	assert.ErrorIs(t, err, errConfirmTimeout)
+
+	// The late confirmation of the first publish does not ack the second one
+	confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+	err = tracker.publish(sendOK, "events", "b", amqp.Publishing{}, 10*time.Millisecond)
+	assert.ErrorIs(t, err, errConfirmTimeout)
+}
+
+func TestConfirmTrackerChannelClosed(t *testing.T) {
+	confirms := make(chan amqp.Confirmation)
+	tracker := newConfirmTracker(confirms)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- tracker.publish(sendOK, "events", "a", amqp.Publishing{}, time.Second)
+	}()
+
+	require.Eventually(t, func() bool {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		return len(tracker.waiters) == 1
+	}, time.Second, time.Millisecond)
+	close(confirms)
+
+	assert.ErrorIs(t, <-done, errConfirmLost)
+	assert.ErrorIs(t, tracker.publish(sendOK, "events", "b", amqp.Publishing{}, time.Second), errConfirmLost)
+}
+
+func TestConfirmTrackerSendError(t *testing.T) {
+	tracker := newConfirmTracker(make(chan amqp.Confirmation))
+
+	err := tracker.publish(func(string, string, bool, bool, amqp.Publishing) error {
+		return amqp.ErrClosed
+	}, "events", "a", amqp.Publishing{}, time.Second)
+	assert.ErrorIs(t, err, amqp.ErrClosed)
+
+	// A failed send takes no delivery tag
+	assert.Zero(t, tracker.nextTag)
+}
+
+func TestRepublishBuffer(t *testing.T) {
+	buffer := newRepublishBuffer(2)
+
+	assert.True(t, buffer.add(pendingPublish{routingKey: "a"}))
+	assert.True(t, buffer.add(pendingPublish{routingKey: "b"}))
+	assert.False(t, buffer.add(pendingPublish{routingKey: "c"}))
+
+	items := buffer.drain()
+	require.Len(t, items, 2)
+	assert.Equal(t, "a", items[0].routingKey)
+	assert.Zero(t, buffer.len())
+
+	assert.True(t, buffer.add(pendingPublish{routingKey: "c"}))
+	buffer.restore(items[1:])
+	items = buffer.drain()
+	require.Len(t, items, 2)
+	assert.Equal(t, "b", items[0].routingKey)
+	assert.Equal(t, "c", items[1].routingKey)
+}
+
+// fakeTopologyChannel records declarations in order
+type fakeTopologyChannel struct {
+	calls []string
+	fail  string
+}
+
+func (f *fakeTopologyChannel) call(name string) error {
+	f.calls = append(f.calls, name)
+	if name == f.fail {
+		return errors.New("precondition failed")
+	}
+	return nil
+}
+
+func (f *fakeTopologyChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	return f.call("exchange " + name + " " + kind)
+}
+
+func (f *fakeTopologyChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	return amqp.Queue{Name: name}, f.call("queue " + name)
+}
+
+func (f *fakeTopologyChannel) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	return f.call("bind " + name + " " + exchange + " " + key)
+}
+
+func (f *fakeTopologyChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	return f.call("qos")
+}
+
+func TestTopologyLogReplay(t *testing.T) {
+	log := newTopologyLog()
+	exchange := func(kind string) func(topologyChannel) error {
+		return func(ch topologyChannel) error {
+			return ch.ExchangeDeclare("sms.events", kind, true, false, false, false, nil)
+		}
+	}
+
+	log.record("exchange:sms.events", exchange("direct"))
+	log.record("queue:sms.purchase", func(ch topologyChannel) error {
+		_, err := ch.QueueDeclare("sms.purchase", true, false, false, false, nil)
+		return err
+	})
+	log.record("binding:sms.purchase:sms.events:purchase", func(ch topologyChannel) error {
+		return ch.QueueBind("sms.purchase", "purchase", "sms.events", false, nil)
+	})
+	// Declared again: the latest declaration is replayed in the place of the first
+	log.record("exchange:sms.events", exchange("topic"))
+
+	ch := &fakeTopologyChannel{}
+	require.NoError(t, log.replay(ch))
+	assert.Equal(t, []string{
+		"exchange sms.events topic",
+		"queue sms.purchase",
+		"bind sms.purchase sms.events purchase",
+	}, ch.calls)
+
+	ch = &fakeTopologyChannel{fail: "queue sms.purchase"}
+	assert.Error(t, log.replay(ch))
+	assert.Len(t, ch.calls, 2)
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, reconnectBackoff(0))
+	assert.Equal(t, 4*time.Second, reconnectBackoff(2))
+	assert.Equal(t, reconnectBackoffMax, reconnectBackoff(5))
+	assert.Equal(t, reconnectBackoffMax, reconnectBackoff(100))
+}
+
+func TestResilienceSettingsFromEnv(t *testing.T) {
+	t.Setenv("RABBITMQ_CONFIRM_TIMEOUT", "")
+	t.Setenv("RABBITMQ_REPUBLISH_BUFFER", "")
+	assert.Equal(t, defaultConfirmTimeout, ConfirmTimeoutFromEnv())
+	assert.Equal(t, defaultRepublishBuffer, RepublishBufferFromEnv())
+
+	t.Setenv("RABBITMQ_CONFIRM_TIMEOUT", "750ms")
+	t.Setenv("RABBITMQ_REPUBLISH_BUFFER", "50")
+	assert.Equal(t, 750*time.Millisecond, ConfirmTimeoutFromEnv())
+	assert.Equal(t, 50, RepublishBufferFromEnv())
+}
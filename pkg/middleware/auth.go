@@ -42,6 +42,21 @@ func (am *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthenticate sets the claims of a valid token but lets anonymous and
+// invalid-token requests through, for internal services that shape responses by role
+func (am *AuthMiddleware) OptionalAuthenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := extractToken(c); token != "" {
+			if claims, err := am.validateToken(token); err == nil {
+				c.Set("user_id", claims["user_id"])
+				c.Set("email", claims["email"])
+				c.Set("role", claims["role"])
+			}
+		}
+		c.Next()
+	}
+}
+
 func (am *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("role")
@@ -107,6 +122,11 @@ func (am *AuthMiddleware) validateToken(tokenString string) (jwt.MapClaims, erro
 	return nil, fmt.Errorf("invalid token claims")
 }
 
+// ValidateToken checks the signature and expiry of a token and returns its claims
+func (am *AuthMiddleware) ValidateToken(tokenString string) (jwt.MapClaims, error) {
+	return am.validateToken(tokenString)
+}
+
 func (am *AuthMiddleware) GenerateToken(userID, email, role string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
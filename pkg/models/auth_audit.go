@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Authentication audit event types
+const (
+	AuthEventLoginSucceeded         = "login_succeeded"
+	AuthEventLoginFailed            = "login_failed"
+	AuthEventLoginBlocked           = "login_blocked" // Attempt while the account is locked, not counted as a failure
+	AuthEventAccountLocked          = "account_locked"
+	AuthEventTokenRefreshed         = "token_refreshed"
+	AuthEventTokenRefreshFailed     = "token_refresh_failed"
+	AuthEventPasswordResetRequested = "password_reset_requested"
+	AuthEventPasswordReset          = "password_reset"
+	AuthEventPasswordResetFailed    = "password_reset_failed"
+	AuthEventOIDCTokenIssued        = "oidc_token_issued"
+)
+
+var AuthEventTypes = []string{
+	AuthEventLoginSucceeded,
+	AuthEventLoginFailed,
+	AuthEventLoginBlocked,
+	AuthEventAccountLocked,
+	AuthEventTokenRefreshed,
+	AuthEventTokenRefreshFailed,
+	AuthEventPasswordResetRequested,
+	AuthEventPasswordReset,
+	AuthEventPasswordResetFailed,
+	AuthEventOIDCTokenIssued,
+}
+
+// Reasons recorded with failed authentication events
+const (
+	AuthReasonUnknownEmail         = "unknown_email"
+	AuthReasonInvalidPassword      = "invalid_password"
+	AuthReasonInvalidTwoFactorCode = "invalid_two_factor_code"
+	AuthReasonAccountDisabled      = "account_disabled"
+	AuthReasonAccountLocked        = "account_locked"
+	AuthReasonInvalidToken         = "invalid_token"
+	AuthReasonTokenExpired         = "token_expired"
+	AuthReasonTokenReused          = "token_reused"
+	AuthReasonTokenUsed            = "token_used"
+)
+
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 500
+)
+
+// AuthAuditEvent is one authentication attempt or credential change. Attempts against unknown
+// emails are recorded too, without a user ID.
+type AuthAuditEvent struct {
+	ID        primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	Type      string                 `bson:"type" json:"type"`
+	UserID    primitive.ObjectID     `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	Email     string                 `bson:"email,omitempty" json:"email,omitempty"`
+	Success   bool                   `bson:"success" json:"success"`
+	Reason    string                 `bson:"reason,omitempty" json:"reason,omitempty"`
+	IPAddress string                 `bson:"ip_address,omitempty" json:"ip_address,omitempty"`
+	UserAgent string                 `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	Details   map[string]interface{} `bson:"details,omitempty" json:"details,omitempty"`
+	CreatedAt time.Time              `bson:"created_at" json:"created_at"`
+}
+
+// AuthAuditFilter selects audit events. Zero fields don't filter.
+type AuthAuditFilter struct {
+	UserID    string    `form:"user_id"`
+	Email     string    `form:"email"`
+	Type      string    `form:"type"`
+	IPAddress string    `form:"ip"`
+	Success   *bool     `form:"success"`
+	From      time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page      int       `form:"page"`
+	Limit     int       `form:"limit"`
+}
+
+// Normalize validates the filter and applies the paging defaults
+func (f *AuthAuditFilter) Normalize() error {
+	if f.Type != "" {
+		known := false
+		for _, t := range AuthEventTypes {
+			if t == f.Type {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return ErrInvalidAuditEventType
+		}
+	}
+	if f.UserID != "" && !primitive.IsValidObjectID(f.UserID) {
+		return ErrInvalidAuditFilter
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return ErrInvalidAuditFilter
+	}
+
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultAuditPageSize
+	}
+	if f.Limit > MaxAuditPageSize {
+		f.Limit = MaxAuditPageSize
+	}
+	return nil
+}
+
+type AuthAuditPage struct {
+	Events []*AuthAuditEvent `json:"events"`
+	Total  int64             `json:"total"`
+	Page   int               `json:"page"`
+	Limit  int               `json:"limit"`
+}
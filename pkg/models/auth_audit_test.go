@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthAuditFilterNormalize(t *testing.T) {
+	filter := &AuthAuditFilter{}
+	assert.NoError(t, filter.Normalize())
+	assert.Equal(t, 1, filter.Page)
+	assert.Equal(t, DefaultAuditPageSize, filter.Limit)
+
+	filter = &AuthAuditFilter{Type: AuthEventLoginFailed, Page: 3, Limit: 10000}
+	assert.NoError(t, filter.Normalize())
+	assert.Equal(t, 3, filter.Page)
+	assert.Equal(t, MaxAuditPageSize, filter.Limit)
+
+	filter = &AuthAuditFilter{Type: "login"}
+	assert.ErrorIs(t, filter.Normalize(), ErrInvalidAuditEventType)
+
+	filter = &AuthAuditFilter{UserID: "not-an-id"}
+	assert.ErrorIs(t, filter.Normalize(), ErrInvalidAuditFilter)
+
+	now := time.Now()
+	filter = &AuthAuditFilter{From: now, To: now.Add(-time.Hour)}
+	assert.ErrorIs(t, filter.Normalize(), ErrInvalidAuditFilter)
+}
+
+func TestUserIsLocked(t *testing.T) {
+	now := time.Now()
+	user := &User{}
+	assert.False(t, user.IsLocked(now))
+
+	until := now.Add(time.Minute)
+	user.LockedUntil = &until
+	assert.True(t, user.IsLocked(now))
+	assert.False(t, user.IsLocked(until))
+}
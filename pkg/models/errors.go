@@ -8,4 +8,15 @@ var (
 	ErrInvalidRole       = errors.New("invalid role")
 	ErrUserNotFound      = errors.New("user not found")
 	ErrAccessDenied      = errors.New("access denied")
+
+	ErrTwoFactorRequired       = errors.New("two-factor code required")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+	ErrRefreshTokenReused = errors.New("refresh token has already been used, all sessions of this login were revoked")
+
+	ErrAccountLocked         = errors.New("account is temporarily locked after too many failed login attempts")
+	ErrInvalidAuditEventType = errors.New("invalid audit event type")
+	ErrInvalidAuditFilter    = errors.New("invalid audit filter")
 )
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelTelegram NotificationChannel = "telegram"
+	ChannelEmail    NotificationChannel = "email"
+)
+
+type NotificationCategory string
+
+const (
+	CategoryAlert        NotificationCategory = "alert"
+	CategoryDigest       NotificationCategory = "digest"
+	CategoryIntervention NotificationCategory = "intervention"
+)
+
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+var (
+	ErrInvalidChannel  = errors.New("invalid notification channel")
+	ErrInvalidSeverity = errors.New("invalid alert severity")
+	ErrInvalidDigest   = errors.New("invalid digest frequency")
+	ErrInvalidCategory = errors.New("invalid notification category")
+)
+
+// NotificationPreferences describes which notifications a user receives and through which channels.
+// Users without a stored document get DefaultNotificationPreferences for their role.
+type NotificationPreferences struct {
+	ID                  primitive.ObjectID    `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID              primitive.ObjectID    `bson:"user_id" json:"user_id"`
+	Enabled             bool                  `bson:"enabled" json:"enabled"`
+	Channels            []NotificationChannel `bson:"channels" json:"channels"`
+	AlertSeverities     []string              `bson:"alert_severities" json:"alert_severities"`
+	Digests             []string              `bson:"digests" json:"digests"`
+	InterventionNotices bool                  `bson:"intervention_notices" json:"intervention_notices"`
+	Platforms           []string              `bson:"platforms,omitempty" json:"platforms,omitempty"`
+	IsDefault           bool                  `bson:"-" json:"is_default"`
+	CreatedAt           time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt           time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Enabled             *bool                 `json:"enabled"`
+	Channels            []NotificationChannel `json:"channels"`
+	AlertSeverities     []string              `json:"alert_severities"`
+	Digests             []string              `json:"digests"`
+	InterventionNotices *bool                 `json:"intervention_notices"`
+	Platforms           []string              `json:"platforms"`
+}
+
+type NotificationRecipient struct {
+	UserID     string              `json:"user_id"`
+	Channel    NotificationChannel `json:"channel"`
+	TelegramID int64               `json:"telegram_id,omitempty"`
+	Email      string              `json:"email,omitempty"`
+}
+
+// DefaultNotificationPreferences mirrors the routing used before preferences existed:
+// admins get every alert and intervention notice, operators only critical alerts.
+func DefaultNotificationPreferences(user *User) *NotificationPreferences {
+	prefs := &NotificationPreferences{
+		UserID:          user.ID,
+		Enabled:         true,
+		Channels:        []NotificationChannel{ChannelTelegram},
+		AlertSeverities: []string{},
+		Digests:         []string{},
+		IsDefault:       true,
+	}
+
+	role := user.TelegramRole
+	if role == "" {
+		role = user.Role
+	}
+
+	switch UserRole(role) {
+	case RoleAdmin:
+		prefs.AlertSeverities = []string{SeverityCritical, SeverityWarning, SeverityInfo}
+		prefs.Digests = []string{DigestDaily}
+		prefs.InterventionNotices = true
+	case RoleOperator:
+		prefs.AlertSeverities = []string{SeverityCritical}
+	default:
+		prefs.Enabled = false
+	}
+
+	return prefs
+}
+
+// NormalizeSeverity maps the severities used by different services onto critical/warning/info
+func NormalizeSeverity(severity string) string {
+	switch severity {
+	case SeverityCritical, "high":
+		return SeverityCritical
+	case SeverityWarning, "medium":
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// Wants reports whether a notification of the given category should be delivered through channel.
+// For alerts value is the severity, for digests the frequency; it is ignored for intervention notices.
+func (p *NotificationPreferences) Wants(category NotificationCategory, value, platform string, channel NotificationChannel) bool {
+	if !p.Enabled || !containsChannel(p.Channels, channel) {
+		return false
+	}
+
+	if platform != "" && len(p.Platforms) > 0 && !containsString(p.Platforms, platform) {
+		return false
+	}
+
+	switch category {
+	case CategoryAlert:
+		return containsString(p.AlertSeverities, NormalizeSeverity(value))
+	case CategoryDigest:
+		return containsString(p.Digests, value)
+	case CategoryIntervention:
+		return p.InterventionNotices
+	}
+
+	return false
+}
+
+func (p *NotificationPreferences) Validate() error {
+	for _, channel := range p.Channels {
+		if channel != ChannelTelegram && channel != ChannelEmail {
+			return ErrInvalidChannel
+		}
+	}
+	for _, severity := range p.AlertSeverities {
+		if severity != SeverityCritical && severity != SeverityWarning && severity != SeverityInfo {
+			return ErrInvalidSeverity
+		}
+	}
+	for _, digest := range p.Digests {
+		if digest != DigestDaily && digest != DigestWeekly {
+			return ErrInvalidDigest
+		}
+	}
+	return nil
+}
+
+func ValidNotificationCategory(category NotificationCategory) bool {
+	return category == CategoryAlert || category == CategoryDigest || category == CategoryIntervention
+}
+
+func containsChannel(channels []NotificationChannel, channel NotificationChannel) bool {
+	for _, c := range channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultNotificationPreferences(t *testing.T) {
+	admin := DefaultNotificationPreferences(&User{Role: string(RoleAdmin)})
+	assert.True(t, admin.IsDefault)
+	assert.True(t, admin.Wants(CategoryAlert, "info", "vk", ChannelTelegram))
+	assert.True(t, admin.Wants(CategoryIntervention, "", "", ChannelTelegram))
+
+	// Telegram role takes precedence over the web role
+	operator := DefaultNotificationPreferences(&User{Role: string(RoleUser), TelegramRole: string(RoleOperator)})
+	assert.True(t, operator.Wants(CategoryAlert, "critical", "", ChannelTelegram))
+	assert.False(t, operator.Wants(CategoryAlert, "warning", "", ChannelTelegram))
+	assert.False(t, operator.Wants(CategoryIntervention, "", "", ChannelTelegram))
+
+	viewer := DefaultNotificationPreferences(&User{Role: string(RoleViewer)})
+	assert.False(t, viewer.Wants(CategoryAlert, "critical", "", ChannelTelegram))
+}
+
+func TestNotificationPreferencesWants(t *testing.T) {
+	prefs := &NotificationPreferences{
+		Enabled:         true,
+		Channels:        []NotificationChannel{ChannelEmail},
+		AlertSeverities: []string{SeverityCritical},
+		Digests:         []string{DigestWeekly},
+		Platforms:       []string{"vk"},
+	}
+
+	tests := []struct {
+		name     string
+		category NotificationCategory
+		value    string
+		platform string
+		channel  NotificationChannel
+		want     bool
+	}{
+		{"critical by email", CategoryAlert, "critical", "vk", ChannelEmail, true},
+		{"high maps to critical", CategoryAlert, "high", "vk", ChannelEmail, true},
+		{"wrong channel", CategoryAlert, "critical", "vk", ChannelTelegram, false},
+		{"severity not selected", CategoryAlert, "warning", "vk", ChannelEmail, false},
+		{"other platform", CategoryAlert, "critical", "mail", ChannelEmail, false},
+		{"no platform", CategoryAlert, "critical", "", ChannelEmail, true},
+		{"weekly digest", CategoryDigest, "weekly", "", ChannelEmail, true},
+		{"daily digest", CategoryDigest, "daily", "", ChannelEmail, false},
+		{"interventions off", CategoryIntervention, "", "vk", ChannelEmail, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, prefs.Wants(tt.category, tt.value, tt.platform, tt.channel))
+		})
+	}
+
+	prefs.Enabled = false
+	assert.False(t, prefs.Wants(CategoryAlert, "critical", "vk", ChannelEmail))
+}
+
+func TestNotificationPreferencesValidate(t *testing.T) {
+	assert.NoError(t, (&NotificationPreferences{
+		Channels:        []NotificationChannel{ChannelTelegram, ChannelEmail},
+		AlertSeverities: []string{SeverityWarning},
+		Digests:         []string{DigestDaily},
+	}).Validate())
+
+	assert.Equal(t, ErrInvalidChannel, (&NotificationPreferences{Channels: []NotificationChannel{"sms"}}).Validate())
+	assert.Equal(t, ErrInvalidSeverity, (&NotificationPreferences{AlertSeverities: []string{"high"}}).Validate())
+	assert.Equal(t, ErrInvalidDigest, (&NotificationPreferences{Digests: []string{"hourly"}}).Validate())
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Scopes understood by the OIDC provider, openid is required in every authorization request
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+var SupportedScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail}
+
+// OAuth2 error codes (RFC 6749 section 4.1.2.1 and 5.2)
+const (
+	OAuthInvalidRequest          = "invalid_request"
+	OAuthInvalidClient           = "invalid_client"
+	OAuthInvalidGrant            = "invalid_grant"
+	OAuthInvalidScope            = "invalid_scope"
+	OAuthUnauthorizedClient      = "unauthorized_client"
+	OAuthUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthUnsupportedResponseType = "unsupported_response_type"
+	OAuthAccessDenied            = "access_denied"
+	OAuthInvalidToken            = "invalid_token"
+	OAuthServerError             = "server_error"
+)
+
+// OAuthError is rendered as the standard {"error", "error_description"} body or redirect parameters
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func NewOAuthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// OIDCClient is a dashboard allowed to sign users in through auth-service. Clients without
+// a secret are public (single-page apps) and rely on PKCE alone.
+type OIDCClient struct {
+	ID           string
+	Name         string
+	Secret       string
+	RedirectURIs []string
+}
+
+// Public reports whether the client cannot keep a secret
+func (c *OIDCClient) Public() bool {
+	return c.Secret == ""
+}
+
+// AllowsRedirectURI requires an exact match with a registered URI, prefixes are not enough
+// to stop codes from being sent to an attacker-controlled path
+func (c *OIDCClient) AllowsRedirectURI(uri string) bool {
+	for _, allowed := range c.RedirectURIs {
+		if allowed == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorizationRequest is the query of the authorization endpoint, or the hidden fields
+// of its login form
+type AuthorizationRequest struct {
+	ResponseType        string `form:"response_type"`
+	ClientID            string `form:"client_id"`
+	RedirectURI         string `form:"redirect_uri"`
+	Scope               string `form:"scope"`
+	State               string `form:"state"`
+	Nonce               string `form:"nonce"`
+	CodeChallenge       string `form:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method"`
+}
+
+// Scopes splits the space-delimited scope parameter
+func (r *AuthorizationRequest) Scopes() []string {
+	return strings.Fields(r.Scope)
+}
+
+// AuthorizationCode is issued once the user signs in and exchanged for tokens exactly once.
+// Only the hash of the code is stored.
+type AuthorizationCode struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	CodeHash      string             `bson:"code_hash"`
+	ClientID      string             `bson:"client_id"`
+	UserID        primitive.ObjectID `bson:"user_id"`
+	RedirectURI   string             `bson:"redirect_uri"`
+	Scope         string             `bson:"scope"`
+	Nonce         string             `bson:"nonce,omitempty"`
+	CodeChallenge string             `bson:"code_challenge"`
+	AuthTime      time.Time          `bson:"auth_time"`
+	ExpiresAt     time.Time          `bson:"expires_at"`
+}
+
+// OIDCTokenRequest is the form posted to the token endpoint. Confidential clients may send
+// their credentials with HTTP Basic instead of client_id and client_secret.
+type OIDCTokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	CodeVerifier string `form:"code_verifier"`
+}
+
+type OIDCTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+	Scope       string `json:"scope"`
+}
+
+// OIDCUserInfo is returned by the userinfo endpoint, claims outside the granted scopes are left empty
+type OIDCUserInfo struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Role              string `json:"role,omitempty"`
+}
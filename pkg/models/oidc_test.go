@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCClientAllowsRedirectURI(t *testing.T) {
+	client := &OIDCClient{
+		ID:           "grafana",
+		RedirectURIs: []string{"https://grafana.conveer.local/login/generic_oauth"},
+	}
+
+	assert.True(t, client.AllowsRedirectURI("https://grafana.conveer.local/login/generic_oauth"))
+	assert.False(t, client.AllowsRedirectURI("https://grafana.conveer.local/login/generic_oauth/../evil"))
+	assert.False(t, client.AllowsRedirectURI("https://grafana.conveer.local/login"))
+	assert.False(t, client.AllowsRedirectURI(""))
+
+	assert.True(t, client.Public())
+	client.Secret = "secret"
+	assert.False(t, client.Public())
+}
+
+func TestAuthorizationRequestScopes(t *testing.T) {
+	req := &AuthorizationRequest{Scope: " openid  email profile "}
+	assert.Equal(t, []string{ScopeOpenID, ScopeEmail, ScopeProfile}, req.Scopes())
+	assert.Empty(t, (&AuthorizationRequest{}).Scopes())
+}
+
+func TestOAuthErrorMessage(t *testing.T) {
+	assert.Equal(t, "invalid_grant", NewOAuthError(OAuthInvalidGrant, "").Error())
+	assert.Equal(t, "invalid_grant: code expired", NewOAuthError(OAuthInvalidGrant, "code expired").Error())
+}
@@ -0,0 +1,16 @@
+package models
+
+// ServiceTokenRequest is the client credentials form a service posts to get its token.
+// Credentials may also come with HTTP Basic.
+type ServiceTokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+type ServiceTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
@@ -24,6 +24,20 @@ type User struct {
 	CreatedAt        time.Time              `bson:"created_at" json:"created_at"`
 	UpdatedAt        time.Time              `bson:"updated_at" json:"updated_at"`
 	LastLoginAt      *time.Time             `bson:"last_login_at" json:"last_login_at"`
+
+	// Two-factor authentication. The secret is stored encrypted, recovery codes as SHA-256 hashes.
+	TwoFactorEnabled  bool     `bson:"two_factor_enabled" json:"two_factor_enabled"`
+	TwoFactorSecret   string   `bson:"two_factor_secret,omitempty" json:"-"`
+	TwoFactorLastStep int64    `bson:"two_factor_last_step,omitempty" json:"-"`
+	RecoveryCodes     []string `bson:"recovery_codes,omitempty" json:"-"`
+
+	// Set by the brute-force lockout after too many failed logins
+	LockedUntil *time.Time `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+}
+
+// IsLocked reports whether logins are refused because of the brute-force lockout
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
 
 type UserRole string
@@ -39,6 +53,7 @@ const (
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
+	OTPCode  string `json:"otp_code,omitempty"` // TOTP or recovery code, required when 2FA is enabled
 }
 
 type RegisterRequest struct {
@@ -49,6 +64,10 @@ type RegisterRequest struct {
 	LastName  string `json:"last_name"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
 type UpdateUserRequest struct {
 	FirstName    string                 `json:"first_name"`
 	LastName     string                 `json:"last_name"`
@@ -69,6 +88,19 @@ type TokenResponse struct {
 	User         *User     `json:"user"`
 }
 
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
 type Session struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
@@ -76,10 +108,45 @@ type Session struct {
 	RefreshToken string             `bson:"refresh_token" json:"refresh_token"`
 	UserAgent    string             `bson:"user_agent" json:"user_agent"`
 	IPAddress    string             `bson:"ip_address" json:"ip_address"`
+	FamilyID     primitive.ObjectID `bson:"family_id,omitempty" json:"family_id,omitempty"`
 	ExpiresAt    time.Time          `bson:"expires_at" json:"expires_at"`
 	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
 }
 
+// Family returns the ID shared by every refresh of the same login. Sessions created
+// before families were tracked form a family of their own.
+func (s *Session) Family() primitive.ObjectID {
+	if s.FamilyID.IsZero() {
+		return s.ID
+	}
+	return s.FamilyID
+}
+
+// RotatedRefreshToken remembers a refresh token that was already exchanged, so presenting
+// it again can be recognised as a replay
+type RotatedRefreshToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	FamilyID  primitive.ObjectID `bson:"family_id" json:"family_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	RotatedAt time.Time          `bson:"rotated_at" json:"rotated_at"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+}
+
+const (
+	SecurityEventRefreshTokenReuse = "refresh_token_reuse"
+	SecurityEventAccountLocked     = "account_locked"
+)
+
+type SecurityEvent struct {
+	ID        primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID     `bson:"user_id" json:"user_id"`
+	Type      string                 `bson:"type" json:"type"`
+	FamilyID  primitive.ObjectID     `bson:"family_id,omitempty" json:"family_id,omitempty"`
+	Details   map[string]interface{} `bson:"details,omitempty" json:"details,omitempty"`
+	CreatedAt time.Time              `bson:"created_at" json:"created_at"`
+}
+
 type PasswordReset struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
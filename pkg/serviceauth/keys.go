@@ -0,0 +1,180 @@
+package serviceauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keysMaxAge     = 10 * time.Minute // Key set is fetched again after this, to pick up rotations
+	keysMinRefresh = 30 * time.Second // Unknown key IDs do not trigger fetches more often than this
+	tokenLeeway    = 30 * time.Second // Clock skew between services
+)
+
+// KeySet verifies service tokens with the public keys auth-service publishes. During a key
+// rotation it publishes the previous key too, and a token signed with a key this set has not
+// seen yet makes it fetch the keys again.
+type KeySet struct {
+	url    string
+	client *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	fetchErr    error         // Error of the last fetch, nil once it succeeded
+	refreshing  chan struct{} // Closed when the running fetch finishes, nil when none runs
+}
+
+func NewKeySet(url string, timeout time.Duration) *KeySet {
+	return &KeySet{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		keys:   make(map[string]*rsa.PublicKey),
+	}
+}
+
+// Verify checks the signature, audience and lifetime of a token and returns its claims
+func (k *KeySet) Verify(ctx context.Context, token string) (*Claims, error) {
+	return ParseToken(token, func(kid string) (*rsa.PublicKey, error) {
+		return k.key(ctx, kid)
+	})
+}
+
+// ParseToken checks a token against the public key with its key ID. auth-service uses it with
+// its own signing keys, other services through a KeySet.
+func ParseToken(token string, key func(kid string) (*rsa.PublicKey, error)) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return key(kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(tokenLeeway),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("service token has no subject")
+	}
+	return claims, nil
+}
+
+// key returns the public key with the ID. The key set is fetched outside the lock: known keys
+// are served from the current set while it refreshes, callers needing an unknown key wait for
+// the fetch.
+func (k *KeySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	k.mu.Lock()
+	key, ok := k.keys[kid]
+	now := time.Now()
+	stale := now.Sub(k.fetchedAt) > keysMaxAge
+	if (!ok || stale) && k.refreshing == nil && now.Sub(k.attemptedAt) > keysMinRefresh {
+		k.attemptedAt = now
+		k.refreshing = make(chan struct{})
+		go k.refresh(k.refreshing)
+	}
+	done := k.refreshing
+	k.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	if done == nil {
+		return nil, fmt.Errorf("unknown service token key %q", kid)
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	k.mu.Lock()
+	key, ok = k.keys[kid]
+	fetchErr := k.fetchErr
+	k.mu.Unlock()
+
+	if !ok {
+		if fetchErr != nil {
+			return nil, fmt.Errorf("failed to fetch service token keys: %w", fetchErr)
+		}
+		return nil, fmt.Errorf("unknown service token key %q", kid)
+	}
+	return key, nil
+}
+
+// refresh fetches the key set and swaps it in. It does not use a caller's context, so a
+// cancelled call does not fail the fetch the other callers wait for; the client timeout
+// bounds it.
+func (k *KeySet) refresh(done chan struct{}) {
+	keys, err := k.fetch(context.Background())
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.fetchErr = err
+	if err == nil {
+		k.keys = keys
+		k.fetchedAt = time.Now()
+	}
+	k.refreshing = nil
+	close(done)
+}
+
+func (k *KeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("key set responded with status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			KeyType  string `json:"kty"`
+			KeyID    string `json:"kid"`
+			Modulus  string `json:"n"`
+			Exponent string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode key set: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.KeyType != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.Modulus)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %q: %w", jwk.KeyID, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.Exponent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %q: %w", jwk.KeyID, err)
+		}
+		keys[jwk.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
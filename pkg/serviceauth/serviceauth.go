@@ -0,0 +1,261 @@
+// Package serviceauth authenticates gRPC calls between Conveer services.
+//
+// auth-service issues short-lived RS256 tokens to services that present their bootstrap
+// secret (OAuth2 client credentials). Callers attach the token to every outgoing call in
+// the x-service-token metadata, servers check its signature against auth-service's key set
+// and that one of its scopes covers the called method. HTTP calls carry the token in the
+// X-Service-Token header.
+package serviceauth
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey carries the service token. The authorization key stays free for user tokens
+// that services forward on behalf of users.
+const MetadataKey = "x-service-token"
+
+// HeaderName carries the service token in HTTP requests
+const HeaderName = "X-Service-Token"
+
+// Modes of checking incoming calls
+const (
+	ModeOff     = "off"     // Tokens are not checked
+	ModeAudit   = "audit"   // Calls without a valid token are logged and served, for the rollout
+	ModeEnforce = "enforce" // Calls without a valid token are rejected
+)
+
+const defaultAuthURL = "http://auth-service:8001"
+
+var calls = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "service_auth_calls_total",
+	Help: "Total number of incoming gRPC calls by service token check result: ok, missing, invalid or forbidden",
+}, []string{"service", "result"})
+
+// Config of one service, both as a caller and as a server
+type Config struct {
+	Service string        // Name of this service: the subject of its tokens and the scope it accepts
+	Mode    string        // How incoming calls are checked
+	AuthURL string        // HTTP address of auth-service
+	Secret  string        // Bootstrap secret of this service, without it outgoing calls carry no token
+	Timeout time.Duration // Timeout of calls to auth-service
+}
+
+// ConfigFromEnv reads SERVICE_AUTH_MODE, SERVICE_AUTH_URL and SERVICE_AUTH_SECRET, so every
+// service is configured the same way whatever its own config format is
+func ConfigFromEnv(service string) Config {
+	cfg := Config{
+		Service: service,
+		Mode:    strings.ToLower(os.Getenv("SERVICE_AUTH_MODE")),
+		AuthURL: os.Getenv("SERVICE_AUTH_URL"),
+		Secret:  os.Getenv("SERVICE_AUTH_SECRET"),
+		Timeout: 5 * time.Second,
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = ModeOff
+	}
+	if cfg.AuthURL == "" {
+		cfg.AuthURL = defaultAuthURL
+	}
+	return cfg
+}
+
+// Auth attaches this service's token to outgoing calls and checks the tokens of incoming ones
+type Auth struct {
+	cfg    Config
+	tokens *TokenSource
+	keys   *KeySet
+}
+
+func New(cfg Config) *Auth {
+	a := &Auth{cfg: cfg}
+	if cfg.Secret != "" {
+		a.tokens = NewTokenSource(cfg.AuthURL, cfg.Service, cfg.Secret, cfg.Timeout)
+	}
+	if cfg.Mode == ModeAudit || cfg.Mode == ModeEnforce {
+		a.keys = NewKeySet(strings.TrimSuffix(cfg.AuthURL, "/")+"/.well-known/service-jwks.json", cfg.Timeout)
+	}
+	return a
+}
+
+// FromEnv is New with ConfigFromEnv
+func FromEnv(service string) *Auth {
+	return New(ConfigFromEnv(service))
+}
+
+// ServerOptions installs the token check, nothing in mode off
+func (a *Auth) ServerOptions() []grpc.ServerOption {
+	if a.keys == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(a.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(a.StreamServerInterceptor()),
+	}
+}
+
+// DialOptions attaches the service token to calls, nothing without a bootstrap secret
+func (a *Auth) DialOptions() []grpc.DialOption {
+	if a.tokens == nil {
+		return nil
+	}
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(a.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(a.StreamClientInterceptor()),
+	}
+}
+
+func (a *Auth) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Auth) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &callerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (a *Auth) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(a.withToken(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func (a *Auth) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(a.withToken(ctx), desc, cc, method, opts...)
+	}
+}
+
+// withToken attaches the token. When auth-service cannot be reached the call goes without
+// one: servers in audit mode still serve it and servers in enforce mode reject it clearly.
+func (a *Auth) withToken(ctx context.Context) context.Context {
+	token, ok := a.token(ctx)
+	if !ok {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, token)
+}
+
+func (a *Auth) token(ctx context.Context) (string, bool) {
+	token, err := a.tokens.Token(ctx)
+	if err != nil {
+		logger.Warn("Failed to get service token", logger.Field{Key: "service", Value: a.cfg.Service}, logger.Field{Key: "error", Value: err.Error()})
+		return "", false
+	}
+	return token, true
+}
+
+// Transport attaches the service token to HTTP requests made through base (the default
+// transport when nil). Without a bootstrap secret requests go without a token.
+func (a *Auth) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if a.tokens == nil {
+		return base
+	}
+	return &tokenTransport{auth: a, base: base}
+}
+
+type tokenTransport struct {
+	auth *Auth
+	base http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, ok := t.auth.token(req.Context())
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(HeaderName, token)
+	return t.base.RoundTrip(req)
+}
+
+// authorize checks the token of an incoming call and puts the calling service into the context.
+// Health checks are always served so orchestrators need no token.
+func (a *Auth) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	if strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") {
+		return ctx, nil
+	}
+
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			token = values[0]
+		}
+	}
+
+	result, code, err := "ok", codes.OK, error(nil)
+	var claims *Claims
+	if token == "" {
+		result, code, err = "missing", codes.Unauthenticated, ErrMissingToken
+	} else if claims, err = a.keys.Verify(ctx, token); err != nil {
+		result, code = "invalid", codes.Unauthenticated
+	} else if !claims.Allows(a.cfg.Service, fullMethod) {
+		result, code, err = "forbidden", codes.PermissionDenied, ErrScopeDenied
+	}
+	calls.WithLabelValues(a.cfg.Service, result).Inc()
+
+	if err == nil {
+		return context.WithValue(ctx, callerKey{}, claims.Subject), nil
+	}
+
+	caller := "unknown"
+	if claims != nil {
+		caller = claims.Subject
+	}
+	fields := []logger.Field{
+		{Key: "method", Value: fullMethod},
+		{Key: "caller", Value: caller},
+		{Key: "result", Value: result},
+		{Key: "error", Value: err.Error()},
+	}
+	if a.cfg.Mode != ModeEnforce {
+		logger.Warn("Service call is not authorized, serving it in audit mode", fields)
+		return ctx, nil
+	}
+	logger.Warn("Rejected unauthorized service call", fields)
+	return nil, status.Error(code, err.Error())
+}
+
+type callerKey struct{}
+
+// CallerFromContext returns the service that made the incoming call, when its token was valid
+func CallerFromContext(ctx context.Context) (string, bool) {
+	caller, ok := ctx.Value(callerKey{}).(string)
+	return caller, ok
+}
+
+// callerStream exposes the context with the caller to stream handlers
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
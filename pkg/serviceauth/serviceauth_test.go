@@ -0,0 +1,272 @@
+package serviceauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testMethod = "/proxy.ProxyService/GetProxy"
+
+// fakeAuthService signs tokens like auth-service and serves its key set and token endpoint
+type fakeAuthService struct {
+	key         *rsa.PrivateKey
+	server      *httptest.Server
+	tokenCalls  atomic.Int32
+	failTokens  atomic.Bool
+	tokenScopes []string
+	keyCalls    atomic.Int32
+	keysGate    chan struct{} // When set, the key set is served once it is closed
+}
+
+func newFakeAuthService(t *testing.T) *fakeAuthService {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	f := &fakeAuthService{key: key, tokenScopes: []string{"proxy-service"}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/service-jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		f.keyCalls.Add(1)
+		if f.keysGate != nil {
+			<-f.keysGate
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"keys":[{"kty":"RSA","kid":"k1","alg":"RS256","use":"sig","n":"` +
+			base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()) + `","e":"` +
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()) + `"}]}`))
+	})
+	mux.HandleFunc("/oauth2/service-token", func(w http.ResponseWriter, r *http.Request) {
+		f.tokenCalls.Add(1)
+		if f.failTokens.Load() || r.FormValue("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		token := f.sign(t, NewClaims("test", r.FormValue("client_id"), f.tokenScopes, time.Now(), time.Minute), "k1")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"` + token + `","token_type":"Bearer","expires_in":60}`))
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeAuthService) sign(t *testing.T, claims *Claims, kid string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(f.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func callServer(a *Auth, token, method string) (string, error) {
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataKey, token))
+	}
+
+	var caller string
+	_, err := a.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller, _ = CallerFromContext(ctx)
+		return nil, nil
+	})
+	return caller, err
+}
+
+func TestClaimsAllows(t *testing.T) {
+	claims := &Claims{Scopes: []string{"sms-service", "proxy-service:/proxy.ProxyService/Get*", "persona-service:/persona.PersonaService/GeneratePersona"}}
+
+	assert.True(t, claims.Allows("sms-service", "/sms.SMSService/PurchaseNumber"))
+	assert.True(t, claims.Allows("proxy-service", "/proxy.ProxyService/GetProxy"))
+	assert.False(t, claims.Allows("proxy-service", "/proxy.ProxyService/AllocateProxy"))
+	assert.True(t, claims.Allows("persona-service", "/persona.PersonaService/GeneratePersona"))
+	assert.False(t, claims.Allows("persona-service", "/persona.PersonaService/DeletePersona"))
+	assert.False(t, claims.Allows("vk-service", "/vk.VKService/CreateAccount"))
+}
+
+func TestEnforceMode(t *testing.T) {
+	auth := newFakeAuthService(t)
+	server := New(Config{Service: "proxy-service", Mode: ModeEnforce, AuthURL: auth.server.URL, Timeout: time.Second})
+
+	token := auth.sign(t, NewClaims("test", "vk-service", []string{"proxy-service"}, time.Now(), time.Minute), "k1")
+	caller, err := callServer(server, token, testMethod)
+	require.NoError(t, err)
+	assert.Equal(t, "vk-service", caller)
+
+	_, err = callServer(server, "", testMethod)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	expired := auth.sign(t, NewClaims("test", "vk-service", []string{"proxy-service"}, time.Now().Add(-time.Hour), time.Minute), "k1")
+	_, err = callServer(server, expired, testMethod)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	wrongKey := auth.sign(t, NewClaims("test", "vk-service", []string{"proxy-service"}, time.Now(), time.Minute), "k2")
+	_, err = callServer(server, wrongKey, testMethod)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	otherService := auth.sign(t, NewClaims("test", "vk-service", []string{"sms-service"}, time.Now(), time.Minute), "k1")
+	_, err = callServer(server, otherService, testMethod)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = callServer(server, "", "/grpc.health.v1.Health/Check")
+	assert.NoError(t, err)
+}
+
+func TestAuditModeServesUnauthorizedCalls(t *testing.T) {
+	auth := newFakeAuthService(t)
+	server := New(Config{Service: "proxy-service", Mode: ModeAudit, AuthURL: auth.server.URL, Timeout: time.Second})
+
+	caller, err := callServer(server, "", testMethod)
+	assert.NoError(t, err)
+	assert.Empty(t, caller)
+
+	assert.Nil(t, New(Config{Service: "proxy-service", Mode: ModeOff}).ServerOptions())
+}
+
+func TestClientAttachesRenewedToken(t *testing.T) {
+	auth := newFakeAuthService(t)
+	client := New(Config{Service: "vk-service", Mode: ModeOff, AuthURL: auth.server.URL, Secret: "secret", Timeout: time.Second})
+	server := New(Config{Service: "proxy-service", Mode: ModeEnforce, AuthURL: auth.server.URL, Timeout: time.Second})
+
+	var sent string
+	invoke := func() error {
+		return client.UnaryClientInterceptor()(context.Background(), testMethod, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				sent = ""
+				if values := md.Get(MetadataKey); len(values) > 0 {
+					sent = values[0]
+				}
+				return nil
+			})
+	}
+
+	require.NoError(t, invoke())
+	require.NotEmpty(t, sent)
+	caller, err := callServer(server, sent, testMethod)
+	require.NoError(t, err)
+	assert.Equal(t, "vk-service", caller)
+
+	require.NoError(t, invoke())
+	assert.Equal(t, int32(1), auth.tokenCalls.Load(), "token is cached until renewal")
+
+	// A failed renewal keeps the current token while it is valid
+	auth.failTokens.Store(true)
+	client.tokens.renewAt = time.Now().Add(-time.Second)
+	require.NoError(t, invoke())
+	assert.NotEmpty(t, sent)
+	assert.Equal(t, int32(2), auth.tokenCalls.Load())
+
+	require.NoError(t, invoke())
+	assert.Equal(t, int32(2), auth.tokenCalls.Load(), "renewal is retried after a pause")
+
+	client.tokens.renewAt = time.Now().Add(-time.Second)
+	client.tokens.expiresAt = time.Now().Add(-time.Second)
+	require.NoError(t, invoke())
+	assert.Empty(t, sent)
+}
+
+func TestTransportAttachesToken(t *testing.T) {
+	auth := newFakeAuthService(t)
+	var sent string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = r.Header.Get(HeaderName)
+	}))
+	t.Cleanup(target.Close)
+
+	client := New(Config{Service: "analytics-service", Mode: ModeOff, AuthURL: auth.server.URL, Secret: "secret", Timeout: time.Second})
+	resp, err := (&http.Client{Transport: client.Transport(nil)}).Get(target.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	claims, err := ParseToken(sent, func(kid string) (*rsa.PublicKey, error) {
+		return &auth.key.PublicKey, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "analytics-service", claims.Subject)
+
+	withoutSecret := New(Config{Service: "analytics-service", Mode: ModeOff})
+	assert.Equal(t, http.DefaultTransport, withoutSecret.Transport(nil))
+}
+
+func TestKeySetFetchesOnceForConcurrentCallers(t *testing.T) {
+	auth := newFakeAuthService(t)
+	auth.keysGate = make(chan struct{})
+	keys := NewKeySet(auth.server.URL+"/.well-known/service-jwks.json", time.Second)
+
+	const callers = 10
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			_, err := keys.key(context.Background(), "k1")
+			errs <- err
+		}()
+	}
+
+	require.Eventually(t, func() bool { return auth.keyCalls.Load() == 1 }, time.Second, 5*time.Millisecond)
+	close(auth.keysGate)
+	for i := 0; i < callers; i++ {
+		require.NoError(t, <-errs)
+	}
+	assert.Equal(t, int32(1), auth.keyCalls.Load())
+}
+
+func TestKeySetServesKnownKeysWhileRefreshing(t *testing.T) {
+	auth := newFakeAuthService(t)
+	keys := NewKeySet(auth.server.URL+"/.well-known/service-jwks.json", 5*time.Second)
+	_, err := keys.key(context.Background(), "k1")
+	require.NoError(t, err)
+
+	// The key set is due for a refresh and auth-service hangs
+	auth.keysGate = make(chan struct{})
+	defer close(auth.keysGate)
+	keys.mu.Lock()
+	keys.fetchedAt = time.Now().Add(-2 * keysMaxAge)
+	keys.attemptedAt = time.Time{}
+	keys.mu.Unlock()
+
+	start := time.Now()
+	key, err := keys.key(context.Background(), "k1")
+	require.NoError(t, err)
+	assert.Equal(t, &auth.key.PublicKey, key)
+	assert.Less(t, time.Since(start), time.Second)
+	require.Eventually(t, func() bool { return auth.keyCalls.Load() == 2 }, time.Second, 5*time.Millisecond)
+
+	// Callers needing an unknown key wait for the fetch only as long as their context allows
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = keys.key(ctx, "k2")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(2), auth.keyCalls.Load())
+}
+
+func TestKeySetReportsFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	keys := NewKeySet(server.URL, time.Second)
+
+	_, err := keys.key(context.Background(), "k1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch service token keys")
+
+	// Another fetch waits for keysMinRefresh
+	_, err = keys.key(context.Background(), "k1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown service token key")
+}
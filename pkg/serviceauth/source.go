@@ -0,0 +1,102 @@
+package serviceauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// renewRetry is the pause between renewal attempts while auth-service is unavailable
+const renewRetry = 10 * time.Second
+
+// TokenSource gets this service's token from auth-service and renews it after two thirds of
+// its lifetime. If renewal fails, the current token is used until it expires.
+type TokenSource struct {
+	url     string
+	service string
+	secret  string
+	client  *http.Client
+
+	mu        sync.Mutex
+	token     string
+	renewAt   time.Time
+	expiresAt time.Time
+}
+
+func NewTokenSource(authURL, service, secret string, timeout time.Duration) *TokenSource {
+	return &TokenSource{
+		url:     strings.TrimSuffix(authURL, "/") + "/oauth2/service-token",
+		service: service,
+		secret:  secret,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.token != "" && now.Before(s.renewAt) {
+		return s.token, nil
+	}
+
+	token, ttl, err := s.fetch(ctx)
+	if err != nil {
+		if s.token != "" && now.Before(s.expiresAt) {
+			s.renewAt = now.Add(renewRetry)
+			if s.renewAt.After(s.expiresAt) {
+				s.renewAt = s.expiresAt
+			}
+			return s.token, nil
+		}
+		return "", err
+	}
+
+	s.token = token
+	s.renewAt = now.Add(ttl * 2 / 3)
+	s.expiresAt = now.Add(ttl)
+	return s.token, nil
+}
+
+// fetch exchanges the bootstrap secret for a token with the client credentials grant
+func (s *TokenSource) fetch(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.service},
+		"client_secret": {s.secret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("service token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("auth-service responded with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", 0, fmt.Errorf("failed to decode service token: %w", err)
+	}
+	if result.AccessToken == "" || result.ExpiresIn <= 0 {
+		return "", 0, fmt.Errorf("auth-service returned no service token")
+	}
+
+	return result.AccessToken, time.Duration(result.ExpiresIn) * time.Second, nil
+}
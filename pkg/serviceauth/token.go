@@ -0,0 +1,58 @@
+package serviceauth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Audience of service tokens, so user and OIDC tokens are never accepted in their place
+const Audience = "conveer-services"
+
+var (
+	ErrMissingToken = errors.New("service token is missing")
+	ErrScopeDenied  = errors.New("service token does not allow this method")
+)
+
+// Claims of a service token. The subject is the calling service, every scope names a service
+// it may call: "proxy-service" allows all methods, "proxy-service:/proxy.ProxyService/GetProxy"
+// one method and "proxy-service:/proxy.ProxyService/Get*" the methods with that prefix.
+type Claims struct {
+	Scopes []string `json:"scopes"`
+	jwt.RegisteredClaims
+}
+
+func NewClaims(issuer, service string, scopes []string, now time.Time, ttl time.Duration) *Claims {
+	return &Claims{
+		Scopes: scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    issuer,
+			Subject:   service,
+			Audience:  jwt.ClaimStrings{Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+}
+
+// Allows reports whether a scope of the token covers the method of the given service
+func (c *Claims) Allows(service, fullMethod string) bool {
+	for _, scope := range c.Scopes {
+		target, method, hasMethod := strings.Cut(scope, ":")
+		if target != service {
+			continue
+		}
+		if !hasMethod || method == fullMethod {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(method, "*"); ok && strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
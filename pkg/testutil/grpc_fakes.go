@@ -0,0 +1,132 @@
+package testutil
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Behavior scripts how a fake answers calls of a method
+type Behavior struct {
+	// Delay is waited before answering, or until the caller's context is done
+	Delay time.Duration
+	// Err is returned instead of the answer
+	Err error
+	// Times is how many calls the behavior applies to; 0 applies it to every following call
+	Times int
+}
+
+// Fail returns a behavior answering the next times calls with a gRPC status error
+func Fail(code codes.Code, msg string, times int) Behavior {
+	return Behavior{Err: status.Error(code, msg), Times: times}
+}
+
+// Delay returns a behavior delaying every following call
+func Delay(d time.Duration) Behavior {
+	return Behavior{Delay: d}
+}
+
+// fakeScript holds the scripted behaviors of a fake per method and counts its calls
+type fakeScript struct {
+	mu        sync.Mutex
+	behaviors map[string][]Behavior
+	calls     map[string]int
+}
+
+func newFakeScript() *fakeScript {
+	return &fakeScript{
+		behaviors: make(map[string][]Behavior),
+		calls:     make(map[string]int),
+	}
+}
+
+// Script queues behaviors for a method. They apply in order, each to its Times calls;
+// once they are used up the method answers normally.
+func (s *fakeScript) Script(method string, behaviors ...Behavior) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.behaviors[method] = append(s.behaviors[method], behaviors...)
+}
+
+// Reset drops the scripted behaviors and the call counts
+func (s *fakeScript) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.behaviors = make(map[string][]Behavior)
+	s.calls = make(map[string]int)
+}
+
+// Calls returns how many times the method was called
+func (s *fakeScript) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// apply counts the call and plays the current behavior of the method: it waits the delay
+// and returns the scripted error
+func (s *fakeScript) apply(ctx context.Context, method string) error {
+	s.mu.Lock()
+	s.calls[method]++
+	var behavior Behavior
+	if queue := s.behaviors[method]; len(queue) > 0 {
+		behavior = queue[0]
+		if behavior.Times > 0 {
+			queue[0].Times--
+			if queue[0].Times == 0 {
+				s.behaviors[method] = queue[1:]
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if behavior.Delay > 0 {
+		timer := time.NewTimer(behavior.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+		}
+	}
+
+	return behavior.Err
+}
+
+// ServeFakes serves the fakes registered by register on an in-memory listener and returns
+// a client connection to them. The server and the connection are closed when the test ends.
+//
+//	sms := testutil.NewFakeSMSService()
+//	proxy := testutil.NewFakeProxyService()
+//	conn := testutil.ServeFakes(t, sms.Register, proxy.Register)
+//	client := smspb.NewSMSServiceClient(conn)
+func ServeFakes(t testing.TB, register ...func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	for _, r := range register {
+		r(server)
+	}
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial fakes: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
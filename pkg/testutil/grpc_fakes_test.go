@@ -0,0 +1,155 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	smspb "github.com/grigta/conveer/services/sms-service/proto"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFakeSMSServiceDeliversCannedCode(t *testing.T) {
+	sms := NewFakeSMSService()
+	sms.SetCode("telegram", "777000")
+	sms.CodeAfter = 50 * time.Millisecond
+	client := smspb.NewSMSServiceClient(ServeFakes(t, sms.Register))
+	ctx := context.Background()
+
+	purchase, err := client.PurchaseNumber(ctx, &smspb.PurchaseNumberRequest{UserId: "u1", Service: "telegram", Country: "RU"})
+	require.NoError(t, err)
+	assert.Equal(t, "fake-activation-1", purchase.ActivationId)
+	assert.Equal(t, float32(10), purchase.Price)
+
+	code, err := client.GetSMSCode(ctx, &smspb.GetSMSCodeRequest{ActivationId: purchase.ActivationId})
+	require.NoError(t, err)
+	assert.Empty(t, code.Code)
+
+	require.Eventually(t, func() bool {
+		code, err = client.GetSMSCode(ctx, &smspb.GetSMSCodeRequest{ActivationId: purchase.ActivationId})
+		return err == nil && code.Code != ""
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "777000", code.Code)
+	assert.Equal(t, "received", sms.Activation(purchase.ActivationId).Status)
+
+	cancel, err := client.CancelActivation(ctx, &smspb.CancelActivationRequest{ActivationId: purchase.ActivationId})
+	require.NoError(t, err)
+	assert.False(t, cancel.Refunded)
+}
+
+func TestFakeSMSServiceCancelRefundsWaitingNumber(t *testing.T) {
+	sms := NewFakeSMSService()
+	sms.CodeAfter = time.Hour
+	client := smspb.NewSMSServiceClient(ServeFakes(t, sms.Register))
+	ctx := context.Background()
+
+	purchase, err := client.PurchaseNumber(ctx, &smspb.PurchaseNumberRequest{Service: "vk"})
+	require.NoError(t, err)
+
+	cancel, err := client.CancelActivation(ctx, &smspb.CancelActivationRequest{ActivationId: purchase.ActivationId})
+	require.NoError(t, err)
+	assert.True(t, cancel.Refunded)
+	assert.Equal(t, float32(10), cancel.RefundAmount)
+
+	_, err = client.GetSMSCode(ctx, &smspb.GetSMSCodeRequest{ActivationId: purchase.ActivationId})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.NoError(t, sms.DeliverCode(purchase.ActivationId, "1"))
+	require.Error(t, sms.DeliverCode("missing", "1"))
+}
+
+func TestFakeScriptFailsThenRecovers(t *testing.T) {
+	sms := NewFakeSMSService()
+	sms.Script("PurchaseNumber", Fail(codes.Unavailable, "provider down", 2))
+	client := smspb.NewSMSServiceClient(ServeFakes(t, sms.Register))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.PurchaseNumber(ctx, &smspb.PurchaseNumberRequest{Service: "vk"})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	}
+
+	_, err := client.PurchaseNumber(ctx, &smspb.PurchaseNumberRequest{Service: "vk"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, sms.Calls("PurchaseNumber"))
+
+	sms.Reset()
+	assert.Equal(t, 0, sms.Calls("PurchaseNumber"))
+}
+
+func TestFakeScriptDelayRespectsDeadline(t *testing.T) {
+	sms := NewFakeSMSService()
+	sms.Script("GetProviderBalance", Delay(time.Second))
+	client := smspb.NewSMSServiceClient(ServeFakes(t, sms.Register))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetProviderBalance(ctx, &smspb.GetProviderBalanceRequest{Provider: "fake"})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestFakeProxyServiceBindsAndRotates(t *testing.T) {
+	proxy := NewFakeProxyService()
+	client := proxypb.NewProxyServiceClient(ServeFakes(t, proxy.Register))
+	ctx := context.Background()
+
+	_, err := client.GetProxyForAccount(ctx, &proxypb.GetProxyRequest{AccountId: "acc1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	allocated, err := client.AllocateProxy(ctx, &proxypb.AllocateProxyRequest{AccountId: "acc1"})
+	require.NoError(t, err)
+	assert.Equal(t, "RU", allocated.Country)
+
+	again, err := client.AllocateProxy(ctx, &proxypb.AllocateProxyRequest{AccountId: "acc1"})
+	require.NoError(t, err)
+	assert.Equal(t, allocated.Id, again.Id)
+
+	rotated, err := client.RotateProxy(ctx, &proxypb.RotateProxyRequest{AccountId: "acc1"})
+	require.NoError(t, err)
+	assert.NotEqual(t, allocated.Ip, rotated.Ip)
+	assert.Equal(t, rotated.Id, proxy.Proxy("acc1").Id)
+
+	released, err := client.ReleaseProxy(ctx, &proxypb.ReleaseProxyRequest{AccountId: "acc1"})
+	require.NoError(t, err)
+	assert.True(t, released.Success)
+	assert.Nil(t, proxy.Proxy("acc1"))
+}
+
+func TestFakeActionExecutorResults(t *testing.T) {
+	platform := NewFakeActionExecutor()
+	platform.Unsupported("story")
+	platform.SetDetails("like", map[string]string{"post_id": "42"})
+	platform.Script("comment", ActionFailure("rate_limit", "too many comments", true, 1))
+	platform.Script("join", Fail(codes.Unavailable, "platform down", 1))
+	client := warmingpb.NewActionExecutorClient(ServeFakes(t, platform.Register))
+
+	stream, err := client.ExecuteActions(context.Background())
+	require.NoError(t, err)
+
+	for _, actionType := range []string{"like", "story", "comment", "join"} {
+		require.NoError(t, stream.Send(&warmingpb.ActionRequest{RequestId: actionType, AccountId: "acc1", ActionType: actionType}))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	results := make(map[string]*warmingpb.ActionResult)
+	for i := 0; i < 4; i++ {
+		result, err := stream.Recv()
+		require.NoError(t, err)
+		results[result.RequestId] = result
+	}
+
+	assert.True(t, results["like"].Success)
+	assert.Equal(t, "42", results["like"].Details["post_id"])
+	assert.Equal(t, "unsupported", results["story"].ErrorType)
+	assert.Equal(t, "rate_limit", results["comment"].ErrorType)
+	assert.True(t, results["comment"].Retryable)
+	assert.Equal(t, "unknown", results["join"].ErrorType)
+	assert.True(t, results["join"].Retryable)
+	assert.Len(t, platform.Requests(), 4)
+}
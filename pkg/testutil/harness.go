@@ -0,0 +1,478 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// HarnessConfig describes the environment a test needs
+type HarnessConfig struct {
+	// Containers configures the infrastructure containers
+	Containers ContainerConfig
+	// Dependencies lists the containers to start, all of them when empty
+	Dependencies []string
+	// Services are started in order after the containers are up
+	Services []ServiceSpec
+	// StartupTimeout bounds how long a service may take to become healthy
+	StartupTimeout time.Duration
+	// ShutdownTimeout bounds how long a service may take to exit after SIGTERM
+	ShutdownTimeout time.Duration
+}
+
+// ServiceSpec describes a service to run either as a binary or in-process
+type ServiceSpec struct {
+	Name string
+	// Package is a Go package path built once per test binary, e.g. "./services/sms-service/cmd"
+	Package string
+	// Binary is a prebuilt executable, used when Package is empty
+	Binary string
+	// Args are passed to the binary, ${VAR} references are expanded
+	Args []string
+	// Dir is the working directory of the binary
+	Dir string
+	// Env is added to the environment of the binary, ${VAR} references are expanded
+	Env map[string]string
+	// Ports names the ports to allocate; they are available as ${<NAME>_<PORT>_PORT}
+	Ports []string
+	// HealthPath is polled on the "http" port until it answers 200
+	HealthPath string
+	// Run starts the service in-process instead of as a binary. It must return once ctx is done.
+	Run func(ctx context.Context, svc *Service) error
+}
+
+// Service is a running service of the harness
+type Service struct {
+	Name  string
+	Ports map[string]int
+	Env   map[string]string
+
+	cmd    *exec.Cmd
+	cancel context.CancelFunc
+	done   chan error
+	logs   *syncBuffer
+}
+
+// Addr returns the localhost address of a named port
+func (s *Service) Addr(port string) string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(s.Ports[port]))
+}
+
+// URL returns the http URL of a named port
+func (s *Service) URL(port string) string {
+	return "http://" + s.Addr(port)
+}
+
+// Logs returns what the service has written to stdout and stderr so far
+func (s *Service) Logs() string {
+	return s.logs.String()
+}
+
+// Harness runs the infrastructure containers and services of an integration test
+type Harness struct {
+	Infra *TestInfrastructure
+
+	config   HarnessConfig
+	services []*Service
+	byName   map[string]*Service
+}
+
+// NewHarness starts the environment for t and tears it down when the test ends.
+// Service logs are dumped if the test fails.
+func NewHarness(t testing.TB, config HarnessConfig) *Harness {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping integration harness in short mode")
+	}
+
+	ctx := context.Background()
+	h, err := StartHarness(ctx, config)
+	if err != nil {
+		t.Fatalf("failed to start test harness: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			for _, svc := range h.services {
+				t.Logf("=== %s logs ===\n%s", svc.Name, svc.Logs())
+			}
+		}
+		if err := h.Close(ctx); err != nil {
+			t.Logf("failed to stop test harness: %v", err)
+		}
+	})
+
+	return h
+}
+
+// StartHarness starts the containers and then the services. The caller must call Close.
+func StartHarness(ctx context.Context, config HarnessConfig) (*Harness, error) {
+	if config.StartupTimeout == 0 {
+		config.StartupTimeout = 60 * time.Second
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = 10 * time.Second
+	}
+	if config.Containers == (ContainerConfig{}) {
+		config.Containers = DefaultContainerConfig()
+	}
+
+	dependencies := config.Dependencies
+	if len(dependencies) == 0 {
+		dependencies = AllDependencies
+	}
+
+	infra, err := StartTestInfrastructureFor(ctx, config.Containers, dependencies...)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Harness{
+		Infra:  infra,
+		config: config,
+		byName: make(map[string]*Service),
+	}
+
+	// Ports are allocated up front so services can be wired to each other regardless of order
+	for _, spec := range config.Services {
+		if _, exists := h.byName[spec.Name]; exists {
+			h.Close(ctx)
+			return nil, fmt.Errorf("duplicate service %q", spec.Name)
+		}
+
+		svc := &Service{
+			Name:  spec.Name,
+			Ports: make(map[string]int),
+			logs:  &syncBuffer{},
+		}
+		for _, port := range spec.Ports {
+			p, err := freePort()
+			if err != nil {
+				h.Close(ctx)
+				return nil, fmt.Errorf("failed to allocate port %s for %s: %w", port, spec.Name, err)
+			}
+			svc.Ports[port] = p
+		}
+
+		h.services = append(h.services, svc)
+		h.byName[spec.Name] = svc
+	}
+
+	for i, spec := range config.Services {
+		if err := h.startService(ctx, spec, h.services[i]); err != nil {
+			h.Close(ctx)
+			return nil, fmt.Errorf("failed to start %s: %w", spec.Name, err)
+		}
+	}
+
+	return h, nil
+}
+
+// Service returns a service by name
+func (h *Harness) Service(name string) *Service {
+	return h.byName[name]
+}
+
+// Database returns a database of the MongoDB container
+func (h *Harness) Database(name string) *mongo.Database {
+	return h.Infra.MongoDB.Database(name)
+}
+
+// Redis returns the client of the Redis container
+func (h *Harness) Redis() *redis.Client {
+	return h.Infra.Redis.GetClient()
+}
+
+// RabbitMQ connects a test client to the RabbitMQ container
+func (h *Harness) RabbitMQ() (*TestRabbitMQ, error) {
+	return NewTestRabbitMQ(h.Infra.RabbitMQ.GetURL())
+}
+
+// Expand replaces ${VAR} references with harness variables:
+// MONGODB_URI, MONGODB_DATABASE, REDIS_ADDR, REDIS_URL, RABBITMQ_URL and
+// <SERVICE>_<PORT>_PORT / <SERVICE>_<PORT>_ADDR for every service port.
+// Unknown variables fall back to the process environment.
+func (h *Harness) Expand(s string) string {
+	vars := h.variables()
+	return os.Expand(s, func(key string) string {
+		if v, ok := vars[key]; ok {
+			return v
+		}
+		return os.Getenv(key)
+	})
+}
+
+func (h *Harness) variables() map[string]string {
+	vars := make(map[string]string)
+
+	if mongoDB := h.Infra.MongoDB; mongoDB != nil {
+		vars["MONGODB_URI"] = mongoDB.URI
+		vars["MONGODB_DATABASE"] = mongoDB.DatabaseName
+	}
+	if r := h.Infra.Redis; r != nil {
+		vars["REDIS_ADDR"] = r.Addr()
+		vars["REDIS_URL"] = r.URI
+	}
+	if rabbit := h.Infra.RabbitMQ; rabbit != nil {
+		vars["RABBITMQ_URL"] = rabbit.URI
+	}
+
+	for _, svc := range h.services {
+		for port, p := range svc.Ports {
+			prefix := envName(svc.Name) + "_" + envName(port)
+			vars[prefix+"_PORT"] = strconv.Itoa(p)
+			vars[prefix+"_ADDR"] = svc.Addr(port)
+		}
+	}
+
+	return vars
+}
+
+func (h *Harness) startService(ctx context.Context, spec ServiceSpec, svc *Service) error {
+	svc.Env = make(map[string]string, len(spec.Env))
+	for k, v := range spec.Env {
+		svc.Env[k] = h.Expand(v)
+	}
+
+	switch {
+	case spec.Run != nil:
+		runCtx, cancel := context.WithCancel(context.Background())
+		svc.cancel = cancel
+		svc.done = make(chan error, 1)
+		go func() {
+			svc.done <- spec.Run(runCtx, svc)
+		}()
+
+	case spec.Package != "" || spec.Binary != "":
+		binary := spec.Binary
+		if spec.Package != "" {
+			var err error
+			binary, err = buildPackage(spec.Package)
+			if err != nil {
+				return err
+			}
+		}
+
+		args := make([]string, len(spec.Args))
+		for i, arg := range spec.Args {
+			args[i] = h.Expand(arg)
+		}
+
+		cmd := exec.Command(binary, args...)
+		cmd.Dir = spec.Dir
+		cmd.Env = os.Environ()
+		for k, v := range svc.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+		cmd.Stdout = svc.logs
+		cmd.Stderr = svc.logs
+
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start binary: %w", err)
+		}
+		svc.cmd = cmd
+		svc.cancel = func() {}
+		svc.done = make(chan error, 1)
+		go func() {
+			svc.done <- cmd.Wait()
+		}()
+
+	default:
+		return fmt.Errorf("service has neither Run, Package nor Binary")
+	}
+
+	return h.waitHealthy(ctx, spec, svc)
+}
+
+// waitHealthy waits for the health endpoint, or for the ports to accept connections when there is none
+func (h *Harness) waitHealthy(ctx context.Context, spec ServiceSpec, svc *Service) error {
+	ctx, cancel := context.WithTimeout(ctx, h.config.StartupTimeout)
+	defer cancel()
+
+	client := &http.Client{Timeout: time.Second}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if ready(client, spec, svc) {
+			return nil
+		}
+
+		select {
+		case err := <-svc.done:
+			svc.done <- err
+			return fmt.Errorf("service exited during startup: %v\n%s", err, svc.Logs())
+		case <-ctx.Done():
+			return fmt.Errorf("service not ready within %s\n%s", h.config.StartupTimeout, svc.Logs())
+		case <-ticker.C:
+		}
+	}
+}
+
+func ready(client *http.Client, spec ServiceSpec, svc *Service) bool {
+	if spec.HealthPath != "" {
+		if _, ok := svc.Ports["http"]; !ok {
+			return false
+		}
+		resp, err := client.Get(svc.URL("http") + spec.HealthPath)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}
+
+	for port := range svc.Ports {
+		conn, err := net.DialTimeout("tcp", svc.Addr(port), time.Second)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+	}
+	return true
+}
+
+// Close stops the services in reverse order and then terminates the containers
+func (h *Harness) Close(ctx context.Context) error {
+	var errs []string
+
+	for i := len(h.services) - 1; i >= 0; i-- {
+		if err := h.stopService(h.services[i]); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", h.services[i].Name, err))
+		}
+	}
+
+	if err := h.Infra.Close(ctx); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("teardown errors: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (h *Harness) stopService(svc *Service) error {
+	if svc.done == nil {
+		// Never started
+		return nil
+	}
+
+	if svc.cmd != nil && svc.cmd.Process != nil {
+		svc.cmd.Process.Signal(syscall.SIGTERM)
+	}
+	svc.cancel()
+
+	select {
+	case err := <-svc.done:
+		svc.done <- err
+		if svc.cmd != nil {
+			// A process stopped by our SIGTERM reports it as an error
+			return nil
+		}
+		return err
+	case <-time.After(h.config.ShutdownTimeout):
+		if svc.cmd != nil && svc.cmd.Process != nil {
+			svc.cmd.Process.Kill()
+		}
+		return fmt.Errorf("service did not stop within %s", h.config.ShutdownTimeout)
+	}
+}
+
+var (
+	buildMu     sync.Mutex
+	buildDir    string
+	builtBinary = make(map[string]string)
+)
+
+// buildPackage compiles a main package once per test binary
+func buildPackage(pkg string) (string, error) {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+
+	if binary, ok := builtBinary[pkg]; ok {
+		return binary, nil
+	}
+
+	if buildDir == "" {
+		dir, err := os.MkdirTemp("", "conveer-harness-")
+		if err != nil {
+			return "", fmt.Errorf("failed to create build directory: %w", err)
+		}
+		buildDir = dir
+	}
+
+	binary := filepath.Join(buildDir, fmt.Sprintf("svc%d", len(builtBinary)))
+	cmd := exec.Command("go", "build", "-o", binary, pkg)
+	cmd.Dir = moduleRoot()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to build %s: %w\n%s", pkg, err, out)
+	}
+
+	builtBinary[pkg] = binary
+	return binary, nil
+}
+
+// moduleRoot finds the directory holding go.mod so packages resolve the same from any test
+func moduleRoot() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func envName(s string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(s))
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes from stdout and stderr
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
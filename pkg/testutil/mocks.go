@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"time"
 )
@@ -445,12 +446,13 @@ func (m *MockIPQSServer) handleRequest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Extract IP from path: /api/json/ip/{api_key}/{ip}
-	parts := r.URL.Path
-	// For simplicity, just return mock data
+	ip := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
 	
 	m.mu.RLock()
-	score := m.DefaultScore
-	// In a real implementation, parse IP from path
+	score, ok := m.IPScores[ip]
+	if !ok {
+		score = m.DefaultScore
+	}
 	m.mu.RUnlock()
 
 	response := map[string]interface{}{
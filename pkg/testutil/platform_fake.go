@@ -0,0 +1,154 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FakeActionExecutor is the action stream of an in-process platform service. Every action
+// succeeds unless scripted: Script with the action type delays or fails single actions, see
+// ActionFailure for failures of a given error type. Script with "ExecuteActions" fails the
+// stream itself.
+type FakeActionExecutor struct {
+	warmingpb.UnimplementedActionExecutorServer
+	*fakeScript
+
+	mu          sync.Mutex
+	unsupported map[string]bool
+	details     map[string]map[string]string
+	requests    []*warmingpb.ActionRequest
+}
+
+// actionFailure is a scripted action failure reported with its error type
+type actionFailure struct {
+	errorType string
+	message   string
+	retryable bool
+}
+
+func (e *actionFailure) Error() string {
+	return e.message
+}
+
+// ActionFailure returns a behavior failing the next times actions with the error type of the
+// warming executor, e.g. "ban", "captcha" or "rate_limit". Other scripted errors are reported
+// as "unknown", retryable when their gRPC code is Unavailable.
+func ActionFailure(errorType, message string, retryable bool, times int) Behavior {
+	return Behavior{Err: &actionFailure{errorType: errorType, message: message, retryable: retryable}, Times: times}
+}
+
+// NewFakeActionExecutor creates a fake platform that executes every action
+func NewFakeActionExecutor() *FakeActionExecutor {
+	return &FakeActionExecutor{
+		fakeScript:  newFakeScript(),
+		unsupported: make(map[string]bool),
+		details:     make(map[string]map[string]string),
+	}
+}
+
+// Register registers the fake on a gRPC server, see ServeFakes
+func (f *FakeActionExecutor) Register(server *grpc.Server) {
+	warmingpb.RegisterActionExecutorServer(server, f)
+}
+
+// Unsupported makes the platform reject the action types as unknown
+func (f *FakeActionExecutor) Unsupported(actionTypes ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, actionType := range actionTypes {
+		f.unsupported[actionType] = true
+	}
+}
+
+// SetDetails sets the details returned with successful actions of the type
+func (f *FakeActionExecutor) SetDetails(actionType string, details map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.details[actionType] = details
+}
+
+// Requests returns the actions received so far
+func (f *FakeActionExecutor) Requests() []*warmingpb.ActionRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*warmingpb.ActionRequest(nil), f.requests...)
+}
+
+func (f *FakeActionExecutor) ExecuteActions(stream grpc.BidiStreamingServer[warmingpb.ActionRequest, warmingpb.ActionResult]) error {
+	if err := f.apply(stream.Context(), "ExecuteActions"); err != nil {
+		return err
+	}
+
+	// Actions run concurrently like on a real platform, results go back as they finish
+	var sendMu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		f.mu.Lock()
+		f.requests = append(f.requests, req)
+		f.mu.Unlock()
+
+		wg.Add(1)
+		go func(req *warmingpb.ActionRequest) {
+			defer wg.Done()
+
+			result := f.execute(stream.Context(), req)
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			stream.Send(result)
+		}(req)
+	}
+}
+
+func (f *FakeActionExecutor) execute(ctx context.Context, req *warmingpb.ActionRequest) *warmingpb.ActionResult {
+	start := time.Now()
+	result := &warmingpb.ActionResult{RequestId: req.RequestId}
+
+	f.mu.Lock()
+	unsupported := f.unsupported[req.ActionType]
+	details := f.details[req.ActionType]
+	f.mu.Unlock()
+
+	if unsupported {
+		result.ErrorType = "unsupported"
+		result.Error = "unsupported action: " + req.ActionType
+		return result
+	}
+
+	if err := f.apply(ctx, req.ActionType); err != nil {
+		result.ErrorType = "unknown"
+		result.Error = err.Error()
+		result.Retryable = status.Code(err) == codes.Unavailable
+
+		var failure *actionFailure
+		if errors.As(err, &failure) {
+			result.ErrorType = failure.errorType
+			result.Retryable = failure.retryable
+		}
+
+		result.LatencyMs = time.Since(start).Milliseconds()
+		return result
+	}
+
+	result.Success = true
+	result.Details = details
+	result.LatencyMs = time.Since(start).Milliseconds()
+	return result
+}
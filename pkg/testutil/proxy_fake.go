@@ -0,0 +1,171 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FakeProxyService is an in-process proxy-service that binds one proxy per account. Every
+// allocation and rotation hands out a new address. Methods can be scripted to fail or stall with Script.
+type FakeProxyService struct {
+	proxypb.UnimplementedProxyServiceServer
+	*fakeScript
+
+	// Country is used when the allocation does not ask for one
+	Country string
+	// Provider is reported for every proxy
+	Provider string
+
+	mu       sync.Mutex
+	bindings map[string]*proxypb.ProxyResponse
+	seq      int
+}
+
+// NewFakeProxyService creates a fake handing out residential RU proxies
+func NewFakeProxyService() *FakeProxyService {
+	return &FakeProxyService{
+		fakeScript: newFakeScript(),
+		Country:    "RU",
+		Provider:   "fake",
+		bindings:   make(map[string]*proxypb.ProxyResponse),
+	}
+}
+
+// Register registers the fake on a gRPC server, see ServeFakes
+func (f *FakeProxyService) Register(server *grpc.Server) {
+	proxypb.RegisterProxyServiceServer(server, f)
+}
+
+// Bind binds a proxy to the account, replacing its current one
+func (f *FakeProxyService) Bind(accountID string, proxy *proxypb.ProxyResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bindings[accountID] = proxy
+}
+
+// Proxy returns the proxy bound to the account, nil if it has none
+func (f *FakeProxyService) Proxy(accountID string) *proxypb.ProxyResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.bindings[accountID]
+}
+
+func (f *FakeProxyService) AllocateProxy(ctx context.Context, req *proxypb.AllocateProxyRequest) (*proxypb.ProxyResponse, error) {
+	if err := f.apply(ctx, "AllocateProxy"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if proxy, ok := f.bindings[req.AccountId]; ok {
+		return proxy, nil
+	}
+
+	proxy := f.newProxy(req.Type, req.Country, req.Protocol)
+	f.bindings[req.AccountId] = proxy
+	return proxy, nil
+}
+
+func (f *FakeProxyService) ReleaseProxy(ctx context.Context, req *proxypb.ReleaseProxyRequest) (*proxypb.ReleaseProxyResponse, error) {
+	if err := f.apply(ctx, "ReleaseProxy"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.bindings[req.AccountId]; !ok {
+		return &proxypb.ReleaseProxyResponse{Success: false, Message: "no proxy bound to account"}, nil
+	}
+	delete(f.bindings, req.AccountId)
+	return &proxypb.ReleaseProxyResponse{Success: true, Message: "Proxy released successfully"}, nil
+}
+
+func (f *FakeProxyService) GetProxyForAccount(ctx context.Context, req *proxypb.GetProxyRequest) (*proxypb.ProxyResponse, error) {
+	if err := f.apply(ctx, "GetProxyForAccount"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	proxy, ok := f.bindings[req.AccountId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no proxy bound to account")
+	}
+	return proxy, nil
+}
+
+func (f *FakeProxyService) RotateProxy(ctx context.Context, req *proxypb.RotateProxyRequest) (*proxypb.ProxyResponse, error) {
+	if err := f.apply(ctx, "RotateProxy"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, ok := f.bindings[req.AccountId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no proxy bound to account")
+	}
+
+	proxy := f.newProxy(current.Type, current.Country, current.Protocol)
+	f.bindings[req.AccountId] = proxy
+	return proxy, nil
+}
+
+func (f *FakeProxyService) GetExitIP(ctx context.Context, req *proxypb.GetExitIPRequest) (*proxypb.ExitIPResponse, error) {
+	if err := f.apply(ctx, "GetExitIP"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	proxy, ok := f.bindings[req.AccountId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no proxy bound to account")
+	}
+	return &proxypb.ExitIPResponse{
+		ProxyId:   proxy.Id,
+		AccountId: req.AccountId,
+		Ip:        proxy.Ip,
+		CheckedAt: time.Now().Unix(),
+	}, nil
+}
+
+// newProxy hands out the next address from the documentation range 198.51.100.0/24
+func (f *FakeProxyService) newProxy(proxyType, country, protocol string) *proxypb.ProxyResponse {
+	f.seq++
+	if proxyType == "" {
+		proxyType = "residential"
+	}
+	if country == "" {
+		country = f.Country
+	}
+	if protocol == "" {
+		protocol = "http"
+	}
+
+	return &proxypb.ProxyResponse{
+		Id:        fmt.Sprintf("fake-proxy-%d", f.seq),
+		Ip:        fmt.Sprintf("198.51.100.%d", f.seq%254+1),
+		Port:      int32(8000 + f.seq),
+		Username:  "user",
+		Password:  "pass",
+		Protocol:  protocol,
+		Type:      proxyType,
+		Country:   country,
+		Status:    "active",
+		ExpiresAt: time.Now().Add(24 * time.Hour).Unix(),
+		Provider:  f.Provider,
+	}
+}
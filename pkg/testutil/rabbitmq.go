@@ -0,0 +1,97 @@
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// TestRabbitMQ is a thin AMQP client for asserting on events in tests
+type TestRabbitMQ struct {
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+// NewTestRabbitMQ connects to the broker at url
+func NewTestRabbitMQ(url string) (*TestRabbitMQ, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	return &TestRabbitMQ{conn: conn, channel: channel}, nil
+}
+
+// DeclareExchange declares a durable exchange of the given kind
+func (r *TestRabbitMQ) DeclareExchange(name, kind string) error {
+	return r.channel.ExchangeDeclare(name, kind, true, false, false, false, nil)
+}
+
+// DeclareQueue declares a queue that is deleted once the test disconnects
+func (r *TestRabbitMQ) DeclareQueue(name string) error {
+	_, err := r.channel.QueueDeclare(name, false, true, false, false, nil)
+	return err
+}
+
+// BindQueue binds a queue to an exchange by routing key
+func (r *TestRabbitMQ) BindQueue(queue, exchange, routingKey string) error {
+	return r.channel.QueueBind(queue, routingKey, exchange, false, nil)
+}
+
+// Publish publishes msg as JSON
+func (r *TestRabbitMQ) Publish(exchange, routingKey string, msg interface{}) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return r.channel.Publish(exchange, routingKey, false, false, amqp.Publishing{
+		ContentType: "application/json",
+		Body:        body,
+		Timestamp:   time.Now(),
+	})
+}
+
+// ConsumeOne waits for the next message on the queue and decodes it as a JSON object
+func (r *TestRabbitMQ) ConsumeOne(ctx context.Context, queue string, timeout time.Duration) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		delivery, ok, err := r.channel.Get(queue, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get message: %w", err)
+		}
+		if ok {
+			var msg map[string]interface{}
+			if err := json.Unmarshal(delivery.Body, &msg); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+			}
+			return msg, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no message on %s within %s", queue, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close closes the channel and connection
+func (r *TestRabbitMQ) Close() error {
+	r.channel.Close()
+	return r.conn.Close()
+}
@@ -0,0 +1,230 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	smspb "github.com/grigta/conveer/services/sms-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FakeSMSService is an in-process sms-service. Purchased numbers receive a canned code per
+// service, delivered CodeAfter the purchase or set by DeliverCode. Methods can be scripted
+// to fail or stall with Script.
+type FakeSMSService struct {
+	smspb.UnimplementedSMSServiceServer
+	*fakeScript
+
+	// CodeAfter is how long after the purchase the canned code arrives
+	CodeAfter time.Duration
+	// Price is charged for every number
+	Price float32
+	// Balance is reported by GetProviderBalance
+	Balance float32
+
+	mu          sync.Mutex
+	codes       map[string]string
+	activations map[string]*FakeActivation
+	seq         int
+}
+
+// FakeActivation is a number bought from the fake
+type FakeActivation struct {
+	ID          string
+	UserID      string
+	Service     string
+	Country     string
+	PhoneNumber string
+	Status      string
+	Code        string
+	CodeAt      time.Time
+	CreatedAt   time.Time
+}
+
+// NewFakeSMSService creates a fake that answers every service with the code "12345"
+func NewFakeSMSService() *FakeSMSService {
+	return &FakeSMSService{
+		fakeScript:  newFakeScript(),
+		Price:       10,
+		Balance:     1000,
+		codes:       make(map[string]string),
+		activations: make(map[string]*FakeActivation),
+	}
+}
+
+// Register registers the fake on a gRPC server, see ServeFakes
+func (f *FakeSMSService) Register(server *grpc.Server) {
+	smspb.RegisterSMSServiceServer(server, f)
+}
+
+// SetCode sets the code numbers bought for the service will receive. An empty service sets the default.
+func (f *FakeSMSService) SetCode(service, code string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.codes[service] = code
+}
+
+// DeliverCode makes the code arrive on the activation now
+func (f *FakeSMSService) DeliverCode(activationID, code string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	activation, ok := f.activations[activationID]
+	if !ok {
+		return fmt.Errorf("activation %s not found", activationID)
+	}
+	activation.Code = code
+	activation.CodeAt = time.Now()
+	return nil
+}
+
+// Activation returns a copy of an activation, nil if it does not exist
+func (f *FakeSMSService) Activation(activationID string) *FakeActivation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	activation, ok := f.activations[activationID]
+	if !ok {
+		return nil
+	}
+	copied := *activation
+	return &copied
+}
+
+func (f *FakeSMSService) PurchaseNumber(ctx context.Context, req *smspb.PurchaseNumberRequest) (*smspb.PurchaseNumberResponse, error) {
+	if err := f.apply(ctx, "PurchaseNumber"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	now := time.Now()
+	code, ok := f.codes[req.Service]
+	if !ok {
+		code, ok = f.codes[""]
+	}
+	if !ok {
+		code = "12345"
+	}
+
+	activation := &FakeActivation{
+		ID:          fmt.Sprintf("fake-activation-%d", f.seq),
+		UserID:      req.UserId,
+		Service:     req.Service,
+		Country:     req.Country,
+		PhoneNumber: fmt.Sprintf("7999%07d", f.seq),
+		Status:      "waiting",
+		Code:        code,
+		CodeAt:      now.Add(f.CodeAfter),
+		CreatedAt:   now,
+	}
+	f.activations[activation.ID] = activation
+
+	return &smspb.PurchaseNumberResponse{
+		ActivationId: activation.ID,
+		PhoneNumber:  activation.PhoneNumber,
+		CountryCode:  req.Country,
+		Price:        f.Price,
+		Provider:     "fake",
+		ExpiresAt:    now.Add(20 * time.Minute).Unix(),
+	}, nil
+}
+
+func (f *FakeSMSService) GetSMSCode(ctx context.Context, req *smspb.GetSMSCodeRequest) (*smspb.GetSMSCodeResponse, error) {
+	if err := f.apply(ctx, "GetSMSCode"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	activation, ok := f.activations[req.ActivationId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "activation not found")
+	}
+	if activation.Status == "cancelled" {
+		return nil, status.Error(codes.FailedPrecondition, "activation is cancelled")
+	}
+
+	// No code yet is an empty answer, callers poll again
+	if activation.Code == "" || time.Now().Before(activation.CodeAt) {
+		return &smspb.GetSMSCodeResponse{}, nil
+	}
+
+	activation.Status = "received"
+	return &smspb.GetSMSCodeResponse{
+		Code:       activation.Code,
+		FullSms:    fmt.Sprintf("Your %s code: %s", activation.Service, activation.Code),
+		ReceivedAt: activation.CodeAt.Unix(),
+	}, nil
+}
+
+func (f *FakeSMSService) CancelActivation(ctx context.Context, req *smspb.CancelActivationRequest) (*smspb.CancelActivationResponse, error) {
+	if err := f.apply(ctx, "CancelActivation"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	activation, ok := f.activations[req.ActivationId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "activation not found")
+	}
+
+	// As with real providers, a number that got its code is not refunded
+	refunded := activation.Status == "waiting"
+	activation.Status = "cancelled"
+
+	resp := &smspb.CancelActivationResponse{Success: true, Refunded: refunded}
+	if refunded {
+		resp.RefundAmount = f.Price
+	}
+	return resp, nil
+}
+
+func (f *FakeSMSService) GetActivationStatus(ctx context.Context, req *smspb.GetActivationStatusRequest) (*smspb.GetActivationStatusResponse, error) {
+	if err := f.apply(ctx, "GetActivationStatus"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	activation, ok := f.activations[req.ActivationId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "activation not found")
+	}
+
+	resp := &smspb.GetActivationStatusResponse{
+		ActivationId: activation.ID,
+		Status:       activation.Status,
+		PhoneNumber:  activation.PhoneNumber,
+		Service:      activation.Service,
+		CreatedAt:    activation.CreatedAt.Unix(),
+		ExpiresAt:    activation.CreatedAt.Add(20 * time.Minute).Unix(),
+	}
+	if activation.Status == "received" {
+		resp.Code = activation.Code
+	}
+	return resp, nil
+}
+
+func (f *FakeSMSService) GetProviderBalance(ctx context.Context, req *smspb.GetProviderBalanceRequest) (*smspb.GetProviderBalanceResponse, error) {
+	if err := f.apply(ctx, "GetProviderBalance"); err != nil {
+		return nil, err
+	}
+
+	return &smspb.GetProviderBalanceResponse{
+		Provider:  req.Provider,
+		Balance:   f.Balance,
+		Currency:  "RUB",
+		UpdatedAt: time.Now().Unix(),
+	}, nil
+}
@@ -3,12 +3,26 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"net"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// Infrastructure dependencies that can be started for a test
+const (
+	DependencyMongoDB  = "mongodb"
+	DependencyRedis    = "redis"
+	DependencyRabbitMQ = "rabbitmq"
+)
+
+// AllDependencies lists every infrastructure dependency
+var AllDependencies = []string{DependencyMongoDB, DependencyRedis, DependencyRabbitMQ}
+
 // ContainerConfig holds configuration for test containers
 type ContainerConfig struct {
 	MongoDBVersion  string
@@ -27,13 +41,22 @@ func DefaultContainerConfig() ContainerConfig {
 
 // MongoDBContainer represents a MongoDB test container
 type MongoDBContainer struct {
-	Container   testcontainers.Container
-	URI         string
-	Host        string
-	Port        string
+	Container    testcontainers.Container
+	Client       *mongo.Client
+	URI          string
+	Host         string
+	Port         string
 	DatabaseName string
 }
 
+// MongoContainer is the name the integration suites use for MongoDBContainer
+type MongoContainer = MongoDBContainer
+
+// NewMongoContainer starts a MongoDB container with a connected client
+func NewMongoContainer(ctx context.Context) (*MongoContainer, error) {
+	return StartMongoContainer(ctx)
+}
+
 // StartMongoContainer starts a MongoDB container for testing
 func StartMongoContainer(ctx context.Context) (*MongoDBContainer, error) {
 	return StartMongoContainerWithConfig(ctx, DefaultContainerConfig())
@@ -77,8 +100,20 @@ func StartMongoContainerWithConfig(ctx context.Context, config ContainerConfig)
 
 	uri := fmt.Sprintf("mongodb://test:test@%s:%s/testdb?authSource=admin", host, port.Port())
 
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		container.Terminate(ctx)
+		return nil, fmt.Errorf("failed to connect to MongoDB container: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(ctx)
+		container.Terminate(ctx)
+		return nil, fmt.Errorf("failed to ping MongoDB container: %w", err)
+	}
+
 	return &MongoDBContainer{
 		Container:    container,
+		Client:       client,
 		URI:          uri,
 		Host:         host,
 		Port:         port.Port(),
@@ -86,22 +121,46 @@ func StartMongoContainerWithConfig(ctx context.Context, config ContainerConfig)
 	}, nil
 }
 
+// GetClient returns the client connected to the container
+func (m *MongoDBContainer) GetClient() *mongo.Client {
+	return m.Client
+}
+
+// Database returns a database of the container, use a unique name per test to keep tests isolated
+func (m *MongoDBContainer) Database(name string) *mongo.Database {
+	return m.Client.Database(name)
+}
+
 // Close terminates the MongoDB container
 func (m *MongoDBContainer) Close(ctx context.Context) error {
+	if m.Client != nil {
+		m.Client.Disconnect(ctx)
+	}
 	if m.Container != nil {
 		return m.Container.Terminate(ctx)
 	}
 	return nil
 }
 
+// Terminate terminates the MongoDB container
+func (m *MongoDBContainer) Terminate(ctx context.Context) error {
+	return m.Close(ctx)
+}
+
 // RedisContainer represents a Redis test container
 type RedisContainer struct {
 	Container testcontainers.Container
+	Client    *redis.Client
 	URI       string
 	Host      string
 	Port      string
 }
 
+// NewRedisContainer starts a Redis container with a connected client
+func NewRedisContainer(ctx context.Context) (*RedisContainer, error) {
+	return StartRedisContainer(ctx)
+}
+
 // StartRedisContainer starts a Redis container for testing
 func StartRedisContainer(ctx context.Context) (*RedisContainer, error) {
 	return StartRedisContainerWithConfig(ctx, DefaultContainerConfig())
@@ -142,20 +201,39 @@ func StartRedisContainerWithConfig(ctx context.Context, config ContainerConfig)
 
 	return &RedisContainer{
 		Container: container,
+		Client:    redis.NewClient(&redis.Options{Addr: net.JoinHostPort(host, port.Port())}),
 		URI:       uri,
 		Host:      host,
 		Port:      port.Port(),
 	}, nil
 }
 
+// GetClient returns the client connected to the container
+func (r *RedisContainer) GetClient() *redis.Client {
+	return r.Client
+}
+
+// Addr returns the host:port address of the container
+func (r *RedisContainer) Addr() string {
+	return net.JoinHostPort(r.Host, r.Port)
+}
+
 // Close terminates the Redis container
 func (r *RedisContainer) Close(ctx context.Context) error {
+	if r.Client != nil {
+		r.Client.Close()
+	}
 	if r.Container != nil {
 		return r.Container.Terminate(ctx)
 	}
 	return nil
 }
 
+// Terminate terminates the Redis container
+func (r *RedisContainer) Terminate(ctx context.Context) error {
+	return r.Close(ctx)
+}
+
 // RabbitMQContainer represents a RabbitMQ test container
 type RabbitMQContainer struct {
 	Container      testcontainers.Container
@@ -165,6 +243,11 @@ type RabbitMQContainer struct {
 	ManagementPort string
 }
 
+// NewRabbitMQContainer starts a RabbitMQ container
+func NewRabbitMQContainer(ctx context.Context) (*RabbitMQContainer, error) {
+	return StartRabbitMQContainer(ctx)
+}
+
 // StartRabbitMQContainer starts a RabbitMQ container for testing
 func StartRabbitMQContainer(ctx context.Context) (*RabbitMQContainer, error) {
 	return StartRabbitMQContainerWithConfig(ctx, DefaultContainerConfig())
@@ -222,6 +305,11 @@ func StartRabbitMQContainerWithConfig(ctx context.Context, config ContainerConfi
 	}, nil
 }
 
+// GetURL returns the AMQP URL of the container
+func (r *RabbitMQContainer) GetURL() string {
+	return r.URI
+}
+
 // Close terminates the RabbitMQ container
 func (r *RabbitMQContainer) Close(ctx context.Context) error {
 	if r.Container != nil {
@@ -230,6 +318,11 @@ func (r *RabbitMQContainer) Close(ctx context.Context) error {
 	return nil
 }
 
+// Terminate terminates the RabbitMQ container
+func (r *RabbitMQContainer) Terminate(ctx context.Context) error {
+	return r.Close(ctx)
+}
+
 // TestInfrastructure holds all test containers
 type TestInfrastructure struct {
 	MongoDB  *MongoDBContainer
@@ -244,29 +337,30 @@ func StartTestInfrastructure(ctx context.Context) (*TestInfrastructure, error) {
 
 // StartTestInfrastructureWithConfig starts all test containers with custom config
 func StartTestInfrastructureWithConfig(ctx context.Context, config ContainerConfig) (*TestInfrastructure, error) {
+	return StartTestInfrastructureFor(ctx, config, AllDependencies...)
+}
+
+// StartTestInfrastructureFor starts only the containers of the given dependencies
+func StartTestInfrastructureFor(ctx context.Context, config ContainerConfig, dependencies ...string) (*TestInfrastructure, error) {
 	infra := &TestInfrastructure{}
 
 	var err error
+	for _, dependency := range dependencies {
+		switch dependency {
+		case DependencyMongoDB:
+			infra.MongoDB, err = StartMongoContainerWithConfig(ctx, config)
+		case DependencyRedis:
+			infra.Redis, err = StartRedisContainerWithConfig(ctx, config)
+		case DependencyRabbitMQ:
+			infra.RabbitMQ, err = StartRabbitMQContainerWithConfig(ctx, config)
+		default:
+			err = fmt.Errorf("unknown dependency %q", dependency)
+		}
 
-	// Start MongoDB
-	infra.MongoDB, err = StartMongoContainerWithConfig(ctx, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to start MongoDB: %w", err)
-	}
-
-	// Start Redis
-	infra.Redis, err = StartRedisContainerWithConfig(ctx, config)
-	if err != nil {
-		infra.MongoDB.Close(ctx)
-		return nil, fmt.Errorf("failed to start Redis: %w", err)
-	}
-
-	// Start RabbitMQ
-	infra.RabbitMQ, err = StartRabbitMQContainerWithConfig(ctx, config)
-	if err != nil {
-		infra.MongoDB.Close(ctx)
-		infra.Redis.Close(ctx)
-		return nil, fmt.Errorf("failed to start RabbitMQ: %w", err)
+		if err != nil {
+			infra.Close(ctx)
+			return nil, fmt.Errorf("failed to start %s: %w", dependency, err)
+		}
 	}
 
 	return infra, nil
@@ -301,12 +395,18 @@ func (t *TestInfrastructure) Close(ctx context.Context) error {
 	return nil
 }
 
-// GetConnectionStrings returns all connection strings
+// GetConnectionStrings returns the connection strings of the started containers
 func (t *TestInfrastructure) GetConnectionStrings() map[string]string {
-	return map[string]string{
-		"mongodb":  t.MongoDB.URI,
-		"redis":    t.Redis.URI,
-		"rabbitmq": t.RabbitMQ.URI,
+	strings := make(map[string]string)
+	if t.MongoDB != nil {
+		strings[DependencyMongoDB] = t.MongoDB.URI
+	}
+	if t.Redis != nil {
+		strings[DependencyRedis] = t.Redis.URI
+	}
+	if t.RabbitMQ != nil {
+		strings[DependencyRabbitMQ] = t.RabbitMQ.URI
 	}
+	return strings
 }
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,8 +11,10 @@ import (
 
 	"github.com/grigta/conveer/pkg/cache"
 	"github.com/grigta/conveer/pkg/database"
+	"github.com/grigta/conveer/pkg/health"
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/serviceauth"
 	"github.com/grigta/conveer/services/analytics-service/internal/config"
 	"github.com/grigta/conveer/services/analytics-service/internal/handlers"
 	"github.com/grigta/conveer/services/analytics-service/internal/models"
@@ -31,7 +32,7 @@ import (
 
 func main() {
 	// Инициализация логгера
-	log := logger.NewLogger("analytics-service")
+	log := logger.FromEnv("analytics-service")
 
 	// Загрузка конфигурации
 	configPath := os.Getenv("CONFIG_PATH")
@@ -80,6 +81,7 @@ func main() {
 	forecastRepo := repository.NewForecastRepository(db)
 	recommendationRepo := repository.NewRecommendationRepository(db)
 	alertRepo := repository.NewAlertRepository(db)
+	lifecycleRepo := repository.NewLifecycleRepository(db)
 
 	// Инициализация Prometheus клиента
 	promClient, err := service.NewPrometheusClient(cfg.Prometheus.URL, log)
@@ -87,18 +89,40 @@ func main() {
 		log.WithError(err).Fatal("Failed to create Prometheus client")
 	}
 
-	// Инициализация gRPC клиентов к другим сервисам
-	grpcClients := initializeGRPCClients(cfg.GRPCServices, log)
+	// Инициализация gRPC клиентов к другим сервисам, токены сервиса проверяются по SERVICE_AUTH_MODE
+	serviceAuth := serviceauth.FromEnv("analytics-service")
+	grpcClients := initializeGRPCClients(cfg.GRPCServices, serviceAuth, log)
+
+	// Исходящие вебхуки с рекомендациями и алертами
+	var webhookSink *service.WebhookSink
+	if len(cfg.Webhooks.Endpoints) > 0 {
+		webhookSink = service.NewWebhookSink(webhookEndpoints(cfg.Webhooks.Endpoints), cfg.Webhooks.Timeout, cfg.Webhooks.MaxAttempts, cfg.Webhooks.RetryBackoff, log)
+	}
 
 	// Инициализация сервисов
-	aggregator := service.NewAggregator(promClient, metricsRepo, grpcClients, log)
+	lifecycleTracker := service.NewLifecycleTracker(lifecycleRepo, rabbitmq, log)
+	aggregator := service.NewAggregator(promClient, metricsRepo, grpcClients, rabbitmq, lifecycleTracker, log)
 	forecaster := service.NewForecaster(metricsRepo, forecastRepo, redisClient, log)
-	recommender := service.NewRecommender(metricsRepo, recommendationRepo, grpcClients, redisClient, log)
-	alertManager := service.NewAlertManager(alertRepo, metricsRepo, rabbitmq, log, cfg.Alerts.MonthlyBudget, cfg.Alerts.BudgetPeriod)
+	recommender := service.NewRecommender(metricsRepo, recommendationRepo, grpcClients, redisClient, lifecycleTracker, webhookSink, log)
+	preferencesClient := service.NewPreferencesClient(cfg.Alerts.AuthServiceURL, serviceAuth)
+	alertManager := service.NewAlertManager(alertRepo, metricsRepo, rabbitmq, preferencesClient, forecaster, webhookSink, log, cfg.Alerts.MonthlyBudget, cfg.Alerts.BudgetPeriod)
+	kpiExporter := service.NewKPIExporter(metricsRepo, log, cfg.KPI.Interval)
+
+	// Ежедневная текстовая сводка, LLM-генератор необязателен
+	reportsLocation, err := time.LoadLocation(cfg.Reports.Timezone)
+	if err != nil {
+		log.WithError(err).Warn("Unknown reports timezone, using UTC")
+		reportsLocation = time.UTC
+	}
+	var summaryGenerator service.SummaryGenerator
+	if cfg.Reports.LLM.Enabled && cfg.Reports.LLM.Endpoint != "" {
+		summaryGenerator = service.NewHTTPSummaryGenerator(cfg.Reports.LLM.Endpoint, cfg.Reports.LLM.APIKey, cfg.Reports.LLM.Model, cfg.Reports.LLM.Timeout)
+	}
+	reportComposer := service.NewReportComposer(metricsRepo, summaryGenerator, reportsLocation, cfg.Reports.Hour, log)
 
 	analyticsService := service.NewAnalyticsService(
 		metricsRepo, forecastRepo, recommendationRepo, alertRepo,
-		aggregator, forecaster, recommender, alertManager, log,
+		aggregator, forecaster, recommender, alertManager, lifecycleTracker, reportComposer, log,
 	)
 
 	// Инициализация предустановленных правил алертов
@@ -107,19 +131,43 @@ func main() {
 	}
 
 	// Запуск фоновых воркеров
+	if webhookSink != nil {
+		go webhookSink.Run(ctx)
+	}
 	go aggregator.Run(ctx)
 	go forecaster.Run(ctx)
 	go recommender.Run(ctx)
 	go alertManager.Run(ctx)
+	go alertManager.ConsumeSMSAnomalies(ctx)
+	go alertManager.ConsumeSMSRentals(ctx)
+	go lifecycleTracker.Run(ctx)
+	go kpiExporter.Run(ctx)
+	if cfg.Reports.Enabled {
+		go reportComposer.Run(ctx)
+	}
 
 	// Инициализация обработчиков
-	handler := handlers.NewAnalyticsHandler(analyticsService, log)
+	redactor := handlers.NewRedactor(cfg.Redaction.JWTSecret, cfg.Redaction.HiddenRoles)
+	if redactor.HidesAll() {
+		log.Warn("JWT_SECRET is not set, money amounts are hidden from every caller")
+	}
+	handler := handlers.NewAnalyticsHandler(analyticsService, redactor, log)
+
+	// Проверки готовности для gRPC health и /health, недоступность других сервисов только деградирует аналитику
+	healthChecker := health.NewChecker("analytics-service")
+	healthChecker.Add("mongodb", health.MongoCheck(mongoClient))
+	healthChecker.Add("redis", health.PingCheck(redisClient))
+	healthChecker.Add("rabbitmq", health.PingCheck(rabbitmq))
+	for name, conn := range grpcClients {
+		healthChecker.AddOptional(name, health.GRPCCheck(conn, ""))
+	}
+	go healthChecker.Run(ctx, 10*time.Second)
 
 	// Запуск gRPC сервера
-	go startGRPCServer(cfg.Service.GRPCPort, handler, log)
+	go startGRPCServer(cfg.Service.GRPCPort, handler, redactor, serviceAuth, healthChecker, log)
 
 	// Запуск HTTP сервера
-	go startHTTPServer(cfg.Service.HTTPPort, handler, log)
+	go startHTTPServer(cfg.Service.HTTPPort, handler, redactor, healthChecker, log)
 
 	// Ожидание сигнала завершения
 	sigChan := make(chan os.Signal, 1)
@@ -131,14 +179,33 @@ func main() {
 	time.Sleep(2 * time.Second)
 }
 
-func startGRPCServer(port int, handler *handlers.AnalyticsHandler, log *logger.Logger) {
+// webhookEndpoints переводит получателей вебхуков из конфигурации
+func webhookEndpoints(configs []config.WebhookConfig) []service.WebhookEndpoint {
+	endpoints := make([]service.WebhookEndpoint, 0, len(configs))
+	for _, c := range configs {
+		endpoints = append(endpoints, service.WebhookEndpoint{
+			Name:       c.Name,
+			URL:        c.URL,
+			Secret:     c.Secret,
+			Events:     c.Events,
+			Severities: c.Severities,
+			Platforms:  c.Platforms,
+		})
+	}
+	return endpoints
+}
+
+func startGRPCServer(port int, handler *handlers.AnalyticsHandler, redactor *handlers.Redactor, serviceAuth *serviceauth.Auth, healthChecker *health.Checker, log logger.Logger) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.WithError(err).Fatal("Failed to listen on gRPC port")
 	}
 
-	grpcServer := grpc.NewServer()
+	serverOpts := append([]grpc.ServerOption{grpc.UnaryInterceptor(redactor.UnaryInterceptor())}, logger.ServerOptions()...)
+	serverOpts = append(serverOpts, serviceAuth.ServerOptions()...)
+	grpcServer := grpc.NewServer(serverOpts...)
 	pb.RegisterAnalyticsServiceServer(grpcServer, handler)
+	healthChecker.Register(grpcServer)
 
 	log.WithField("port", port).Info("Starting gRPC server")
 	if err := grpcServer.Serve(lis); err != nil {
@@ -146,14 +213,16 @@ func startGRPCServer(port int, handler *handlers.AnalyticsHandler, log *logger.L
 	}
 }
 
-func startHTTPServer(port int, handler *handlers.AnalyticsHandler, log *logger.Logger) {
+func startHTTPServer(port int, handler *handlers.AnalyticsHandler, redactor *handlers.Redactor, healthChecker *health.Checker, log logger.Logger) {
 	router := gin.Default()
 
 	// API routes
 	v1 := router.Group("/api/v1/analytics")
+	v1.Use(redactor.Middleware())
 	{
 		v1.GET("/overall", handler.GetOverallAnalyticsHTTP)
 		v1.GET("/platform/:platform", handler.GetPlatformAnalyticsHTTP)
+		v1.GET("/compare", handler.ComparePlatformsHTTP)
 		v1.GET("/forecast/expenses", handler.GetExpenseForecastHTTP)
 		v1.GET("/forecast/readiness/:account_id", handler.GetReadinessForecastHTTP)
 		v1.GET("/forecast/optimal-time", handler.GetOptimalTimeHTTP)
@@ -164,14 +233,31 @@ func startHTTPServer(port int, handler *handlers.AnalyticsHandler, log *logger.L
 		v1.POST("/alerts/:id/acknowledge", handler.AcknowledgeAlertHTTP)
 		v1.GET("/rules", handler.ListAlertRulesHTTP)
 		v1.POST("/rules", handler.CreateAlertRuleHTTP)
+		v1.POST("/rules/validate", handler.ValidateAlertExpressionHTTP)
 		v1.PUT("/rules/:id", handler.UpdateAlertRuleHTTP)
 		v1.DELETE("/rules/:id", handler.DeleteAlertRuleHTTP)
+		v1.GET("/lifecycle", handler.GetLifecycleReportHTTP)
+		v1.GET("/funnel", handler.GetFunnelHTTP)
+		v1.GET("/survival", handler.GetSurvivalHTTP)
+		v1.GET("/providers/efficiency", handler.GetProviderEfficiencyHTTP)
+		v1.GET("/cleanup", handler.GetResourceCleanupHTTP)
+		v1.GET("/registrations/latency", handler.GetRegistrationLatencyHTTP)
+		v1.GET("/summary/daily", handler.GetDailySummaryHTTP)
+	}
+
+	// Grafana JSON datasource
+	grafana := router.Group("/grafana")
+	{
+		grafana.GET("/", handler.GrafanaHealthHTTP)
+		grafana.POST("/search", handler.GrafanaSearchHTTP)
+		grafana.POST("/query", handler.GrafanaQueryHTTP)
+		grafana.POST("/annotations", handler.GrafanaAnnotationsHTTP)
+		grafana.POST("/tag-keys", handler.GrafanaTagKeysHTTP)
+		grafana.POST("/tag-values", handler.GrafanaTagValuesHTTP)
 	}
 
 	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
-	})
+	router.GET("/health", healthChecker.Handler())
 
 	// Prometheus metrics
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -261,6 +347,127 @@ func setupIndexes(ctx context.Context, db *mongo.Database) error {
 		return err
 	}
 
+	// account_lifecycles indexes
+	lifecycleIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "platform", Value: 1},
+				{Key: "account_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "state", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "registered_at", Value: -1}},
+		},
+		{
+			// Журнал расходов сопоставляется с аккаунтами без платформы
+			Keys: bson.D{{Key: "account_id", Value: 1}},
+		},
+	}
+	if _, err := db.Collection("account_lifecycles").Indexes().CreateMany(ctx, lifecycleIndexes); err != nil {
+		return err
+	}
+
+	// lifecycle_transitions indexes
+	transitionsIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "from_state", Value: 1},
+				{Key: "left_at", Value: -1},
+			},
+		},
+		{
+			// Воронка собирает переходы каждого аккаунта
+			Keys: bson.D{
+				{Key: "account_id", Value: 1},
+				{Key: "platform", Value: 1},
+			},
+		},
+	}
+	if _, err := db.Collection("lifecycle_transitions").Indexes().CreateMany(ctx, transitionsIndexes); err != nil {
+		return err
+	}
+
+	// account_proxy_providers index
+	proxyProvidersIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "account_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := db.Collection("account_proxy_providers").Indexes().CreateOne(ctx, proxyProvidersIndex); err != nil {
+		return err
+	}
+
+	// account_warming_scenarios index
+	warmingScenariosIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "account_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := db.Collection("account_warming_scenarios").Indexes().CreateOne(ctx, warmingScenariosIndex); err != nil {
+		return err
+	}
+
+	// daily_summaries index
+	summariesIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "date", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := db.Collection("daily_summaries").Indexes().CreateOne(ctx, summariesIndex); err != nil {
+		return err
+	}
+
+	// cost_ledger indexes
+	costLedgerIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "category", Value: 1},
+				{Key: "recorded_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{{Key: "account_id", Value: 1}},
+		},
+	}
+	if _, err := db.Collection("cost_ledger").Indexes().CreateMany(ctx, costLedgerIndexes); err != nil {
+		return err
+	}
+
+	// resource_cleanups index
+	cleanupIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "platform", Value: 1},
+			{Key: "period_start", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := db.Collection("resource_cleanups").Indexes().CreateOne(ctx, cleanupIndex); err != nil {
+		return err
+	}
+
+	// registration_steps index
+	registrationStepsIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "platform", Value: 1},
+			{Key: "recorded_at", Value: -1},
+		},
+	}
+	if _, err := db.Collection("registration_steps").Indexes().CreateOne(ctx, registrationStepsIndex); err != nil {
+		return err
+	}
+
+	// account_appeal_outcomes index
+	appealsIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "platform", Value: 1},
+			{Key: "resolved_at", Value: -1},
+		},
+	}
+	if _, err := db.Collection("account_appeal_outcomes").Indexes().CreateOne(ctx, appealsIndex); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -277,8 +484,12 @@ func initializeAlertRules(ctx context.Context, repo *repository.AlertRepository,
 					Operator: ruleConfig.Threshold.Operator,
 					Value:    ruleConfig.Threshold.Value,
 				},
-				Severity: ruleConfig.Severity,
-				Cooldown: ruleConfig.Cooldown,
+				Expression: ruleConfig.Expression,
+				Severity:   ruleConfig.Severity,
+				Cooldown:   ruleConfig.Cooldown,
+			}
+			if err := service.ValidateAlertRule(rule); err != nil {
+				return fmt.Errorf("alert rule %q: %w", rule.Name, err)
 			}
 			if err := repo.CreateRule(ctx, rule); err != nil {
 				return err
@@ -288,11 +499,13 @@ func initializeAlertRules(ctx context.Context, repo *repository.AlertRepository,
 	return nil
 }
 
-func initializeGRPCClients(services map[string]string, log *logger.Logger) map[string]*grpc.ClientConn {
+func initializeGRPCClients(services map[string]string, serviceAuth *serviceauth.Auth, log logger.Logger) map[string]*grpc.ClientConn {
 	clients := make(map[string]*grpc.ClientConn)
+	dialOpts := append([]grpc.DialOption{grpc.WithInsecure()}, logger.DialOptions()...)
+	dialOpts = append(dialOpts, serviceAuth.DialOptions()...)
 
 	for service, address := range services {
-		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		conn, err := grpc.Dial(address, dialOpts...)
 		if err != nil {
 			log.WithError(err).WithField("service", service).Error("Failed to connect to service")
 			continue
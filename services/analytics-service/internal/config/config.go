@@ -19,8 +19,12 @@ type Config struct {
 	Aggregation   AggregationConfig   `yaml:"aggregation"`
 	Forecasting   ForecastingConfig   `yaml:"forecasting"`
 	Recommendations RecommendationConfig `yaml:"recommendations"`
+	KPI           KPIConfig           `yaml:"kpi"`
 	Alerts        AlertsConfig        `yaml:"alerts"`
 	Cache         CacheConfig         `yaml:"cache"`
+	Redaction     RedactionConfig     `yaml:"redaction"`
+	Webhooks      WebhooksConfig      `yaml:"webhooks"`
+	Reports       ReportsConfig       `yaml:"reports"`
 	GRPCServices  map[string]string   `yaml:"grpc_services"`
 }
 
@@ -69,6 +73,11 @@ type AggregationConfig struct {
 	Interval time.Duration `yaml:"interval"`
 }
 
+// KPIConfig конфигурация экспорта бизнес-KPI в Prometheus
+type KPIConfig struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
 // ForecastingConfig конфигурация прогнозирования
 type ForecastingConfig struct {
 	Interval         time.Duration `yaml:"interval"`
@@ -96,6 +105,7 @@ type AlertsConfig struct {
 	DefaultCooldown time.Duration      `yaml:"default_cooldown"`
 	MonthlyBudget   float64            `yaml:"monthly_budget"`
 	BudgetPeriod    time.Duration      `yaml:"budget_period"`
+	AuthServiceURL  string             `yaml:"auth_service_url"` // Источник настроек уведомлений
 	Rules           []AlertRuleConfig  `yaml:"rules"`
 }
 
@@ -105,6 +115,8 @@ type AlertRuleConfig struct {
 	Type      string                 `yaml:"type"`
 	Platform  string                 `yaml:"platform"`
 	Threshold AlertThresholdConfig   `yaml:"threshold"`
+	// Expression составное условие вместо type и threshold, см. ParseAlertExpression
+	Expression string                `yaml:"expression"`
 	Severity  string                 `yaml:"severity"`
 	Cooldown  int                    `yaml:"cooldown"`
 }
@@ -121,6 +133,47 @@ type CacheConfig struct {
 	RecommendationsTTL  time.Duration `yaml:"recommendations_ttl"`
 }
 
+// RedactionConfig скрытие денежных сумм в ответах API по роли вызывающего
+type RedactionConfig struct {
+	JWTSecret   string   `yaml:"jwt_secret"`   // Секрет для чтения роли из JWT, без него ответы не скрываются
+	HiddenRoles []string `yaml:"hidden_roles"` // Роли, которым не показываются абсолютные суммы
+}
+
+// WebhooksConfig исходящие вебхуки с рекомендациями и алертами
+type WebhooksConfig struct {
+	Timeout      time.Duration   `yaml:"timeout"`       // Таймаут одного запроса
+	MaxAttempts  int             `yaml:"max_attempts"`  // Попыток доставки до dead-letter
+	RetryBackoff time.Duration   `yaml:"retry_backoff"` // Пауза перед второй попыткой, дальше удваивается
+	Endpoints    []WebhookConfig `yaml:"endpoints"`
+}
+
+// WebhookConfig получатель вебхуков, пустой фильтр пропускает все значения
+type WebhookConfig struct {
+	Name       string   `yaml:"name"`
+	URL        string   `yaml:"url"`
+	Secret     string   `yaml:"secret"`     // Ключ HMAC-SHA256 подписи тела запроса
+	Events     []string `yaml:"events"`     // alert.fired, recommendation.generated
+	Severities []string `yaml:"severities"` // Severity алерта или приоритет рекомендации
+	Platforms  []string `yaml:"platforms"`
+}
+
+// ReportsConfig ежедневная текстовая сводка по метрикам
+type ReportsConfig struct {
+	Enabled  bool      `yaml:"enabled"`
+	Hour     int       `yaml:"hour"`     // Час, после которого составляется сводка за прошедшие сутки
+	Timezone string    `yaml:"timezone"` // Часовой пояс границ суток
+	LLM      LLMConfig `yaml:"llm"`
+}
+
+// LLMConfig необязательный генератор текста с OpenAI-совместимым API, при ошибке используется шаблон
+type LLMConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Endpoint string        `yaml:"endpoint"` // Полный URL chat completions
+	APIKey   string        `yaml:"api_key"`
+	Model    string        `yaml:"model"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
 // Load загружает конфигурацию
 func Load(configPath string) (*Config, error) {
 	config := &Config{}
@@ -188,6 +241,24 @@ func loadFromEnv(config *Config) {
 		config.RabbitMQ.URL = val
 	}
 
+	if val := os.Getenv("AUTH_SERVICE_URL"); val != "" {
+		config.Alerts.AuthServiceURL = val
+	}
+
+	if val := os.Getenv("JWT_SECRET"); val != "" {
+		config.Redaction.JWTSecret = val
+	}
+
+	if val := os.Getenv("REPORTS_LLM_API_KEY"); val != "" {
+		config.Reports.LLM.APIKey = val
+	}
+
+	// URL и секреты вебхуков обычно задаются ссылками на переменные окружения
+	for i := range config.Webhooks.Endpoints {
+		config.Webhooks.Endpoints[i].URL = os.ExpandEnv(config.Webhooks.Endpoints[i].URL)
+		config.Webhooks.Endpoints[i].Secret = os.ExpandEnv(config.Webhooks.Endpoints[i].Secret)
+	}
+
 	// Загрузка gRPC сервисов из переменных окружения
 	config.GRPCServices = make(map[string]string)
 	for _, env := range os.Environ() {
@@ -244,6 +315,10 @@ func setDefaults(config *Config) {
 		config.Aggregation.Interval = 5 * time.Minute
 	}
 
+	if config.KPI.Interval == 0 {
+		config.KPI.Interval = 1 * time.Minute
+	}
+
 	if config.Forecasting.Interval == 0 {
 		config.Forecasting.Interval = 1 * time.Hour
 	}
@@ -276,6 +351,10 @@ func setDefaults(config *Config) {
 		config.Alerts.BudgetPeriod = 30 * 24 * time.Hour // Default to 30 days
 	}
 
+	if config.Alerts.AuthServiceURL == "" {
+		config.Alerts.AuthServiceURL = "http://auth-service:8001"
+	}
+
 	if config.Cache.ForecastTTL == 0 {
 		config.Cache.ForecastTTL = 1 * time.Hour
 	}
@@ -284,6 +363,34 @@ func setDefaults(config *Config) {
 		config.Cache.RecommendationsTTL = 6 * time.Hour
 	}
 
+	if len(config.Redaction.HiddenRoles) == 0 {
+		config.Redaction.HiddenRoles = []string{"viewer"}
+	}
+
+	if config.Webhooks.Timeout == 0 {
+		config.Webhooks.Timeout = 10 * time.Second
+	}
+
+	if config.Webhooks.MaxAttempts == 0 {
+		config.Webhooks.MaxAttempts = 5
+	}
+
+	if config.Webhooks.RetryBackoff == 0 {
+		config.Webhooks.RetryBackoff = 2 * time.Second
+	}
+
+	if config.Reports.Hour <= 0 || config.Reports.Hour > 23 {
+		config.Reports.Hour = 6
+	}
+
+	if config.Reports.Timezone == "" {
+		config.Reports.Timezone = "Europe/Moscow"
+	}
+
+	if config.Reports.LLM.Timeout == 0 {
+		config.Reports.LLM.Timeout = 30 * time.Second
+	}
+
 	// Установка gRPC сервисов по умолчанию
 	if config.GRPCServices == nil {
 		config.GRPCServices = make(map[string]string)
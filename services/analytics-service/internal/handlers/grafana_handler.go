@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+	"github.com/grigta/conveer/services/analytics-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Обработчики реализуют протокол Grafana JSON datasource (simpod-json-datasource),
+// чтобы дашборды строились по aggregated_metrics без прямого доступа к MongoDB.
+//
+// Формат target: "<metric>" — сводные метрики (platform=all),
+// "<platform>.<metric>" — метрики платформы, "*.<metric>" — по ряду на каждую платформу.
+
+const (
+	grafanaMinInterval   = time.Minute
+	grafanaDefaultPoints = 1000
+)
+
+// grafanaRange диапазон времени запроса Grafana
+type grafanaRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// grafanaAdhocFilter ad-hoc фильтр дашборда
+type grafanaAdhocFilter struct {
+	Key      string `json:"key"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// grafanaQueryRequest тело запроса /query
+type grafanaQueryRequest struct {
+	Range         grafanaRange `json:"range"`
+	IntervalMs    int64        `json:"intervalMs"`
+	MaxDataPoints int64        `json:"maxDataPoints"`
+	Targets       []struct {
+		Target string `json:"target"`
+		RefID  string `json:"refId"`
+		Type   string `json:"type"`
+		Hide   bool   `json:"hide"`
+	} `json:"targets"`
+	AdhocFilters []grafanaAdhocFilter `json:"adhocFilters"`
+}
+
+// grafanaTimeSeries ответ /query в формате timeserie
+type grafanaTimeSeries struct {
+	Target     string       `json:"target"`
+	RefID      string       `json:"refId,omitempty"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// grafanaTable ответ /query в формате table
+type grafanaTable struct {
+	Type    string              `json:"type"`
+	RefID   string              `json:"refId,omitempty"`
+	Columns []map[string]string `json:"columns"`
+	Rows    [][]interface{}     `json:"rows"`
+}
+
+// GrafanaHealthHTTP проверка доступности datasource
+func (h *AnalyticsHandler) GrafanaHealthHTTP(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// GrafanaSearchHTTP возвращает список доступных метрик
+func (h *AnalyticsHandler) GrafanaSearchHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("POST", "/grafana/search", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	var req struct {
+		Target string `json:"target"`
+	}
+	// Тело может отсутствовать
+	_ = c.ShouldBindJSON(&req)
+
+	platforms, err := h.analyticsService.GetMetricPlatforms(c)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get metric platforms")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search metrics"})
+		return
+	}
+
+	targets := make([]string, 0, len(models.SeriesMetrics)*(len(platforms)+1))
+	for _, metric := range models.SeriesMetrics {
+		targets = append(targets, metric, "*."+metric)
+		for _, platform := range platforms {
+			if platform != "all" {
+				targets = append(targets, platform+"."+metric)
+			}
+		}
+	}
+
+	if req.Target != "" {
+		filtered := targets[:0]
+		for _, target := range targets {
+			if strings.Contains(target, req.Target) {
+				filtered = append(filtered, target)
+			}
+		}
+		targets = filtered
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+// GrafanaQueryHTTP возвращает временные ряды метрик за диапазон дашборда
+func (h *AnalyticsHandler) GrafanaQueryHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("POST", "/grafana/query", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	var req grafanaQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if req.Range.To.IsZero() {
+		req.Range.To = time.Now()
+	}
+	if req.Range.From.IsZero() || !req.Range.From.Before(req.Range.To) {
+		req.Range.From = req.Range.To.Add(-24 * time.Hour)
+	}
+
+	interval := grafanaInterval(req)
+	adhocPlatform := ""
+	for _, filter := range req.AdhocFilters {
+		if filter.Key == "platform" && filter.Operator == "=" {
+			adhocPlatform = filter.Value
+		}
+	}
+
+	response := make([]interface{}, 0, len(req.Targets))
+	for _, target := range req.Targets {
+		if target.Hide || target.Target == "" {
+			continue
+		}
+
+		metric, platform := parseGrafanaTarget(target.Target)
+		if !models.IsSeriesMetric(metric) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown metric: " + metric})
+			return
+		}
+		if adhocPlatform != "" && (platform == "" || platform == "all") {
+			platform = adhocPlatform
+		}
+
+		data, err := h.analyticsService.GetMetricSeries(c, metric, platform, req.Range.From, req.Range.To, interval)
+		if err != nil {
+			h.logger.WithError(err).Error("Failed to get metric series")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query metrics"})
+			return
+		}
+
+		if target.Type == "table" {
+			response = append(response, grafanaTableFrom(target.RefID, metric, data))
+			continue
+		}
+
+		for _, series := range grafanaSeriesFrom(target.RefID, metric, platform, data) {
+			response = append(response, series)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GrafanaAnnotationsHTTP возвращает сработавшие алерты как аннотации
+func (h *AnalyticsHandler) GrafanaAnnotationsHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("POST", "/grafana/annotations", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	var req struct {
+		Range      grafanaRange `json:"range"`
+		Annotation struct {
+			Name  string `json:"name"`
+			Query string `json:"query"`
+		} `json:"annotation"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	// В query можно передать severity для фильтрации
+	alerts, err := h.analyticsService.GetActiveAlerts(c, false, strings.TrimSpace(req.Annotation.Query))
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get alerts for annotations")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get annotations"})
+		return
+	}
+
+	annotations := make([]gin.H, 0, len(alerts))
+	for _, alert := range alerts {
+		if !req.Range.From.IsZero() && alert.FiredAt.Before(req.Range.From) {
+			continue
+		}
+		if !req.Range.To.IsZero() && alert.FiredAt.After(req.Range.To) {
+			continue
+		}
+
+		tags := []string{alert.Severity}
+		if alert.Platform != "" {
+			tags = append(tags, alert.Platform)
+		}
+
+		annotations = append(annotations, gin.H{
+			"annotation": req.Annotation,
+			"time":       alert.FiredAt.UnixMilli(),
+			"title":      alert.RuleName,
+			"text":       alert.Message,
+			"tags":       tags,
+		})
+	}
+
+	c.JSON(http.StatusOK, annotations)
+}
+
+// GrafanaTagKeysHTTP возвращает ключи ad-hoc фильтров
+func (h *AnalyticsHandler) GrafanaTagKeysHTTP(c *gin.Context) {
+	c.JSON(http.StatusOK, []gin.H{{"type": "string", "text": "platform"}})
+}
+
+// GrafanaTagValuesHTTP возвращает значения ad-hoc фильтра platform
+func (h *AnalyticsHandler) GrafanaTagValuesHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("POST", "/grafana/tag-values", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	var req struct {
+		Key string `json:"key"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Key != "platform" {
+		c.JSON(http.StatusOK, []gin.H{})
+		return
+	}
+
+	platforms, err := h.analyticsService.GetMetricPlatforms(c)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get metric platforms")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tag values"})
+		return
+	}
+
+	values := make([]gin.H, 0, len(platforms))
+	for _, platform := range platforms {
+		values = append(values, gin.H{"text": platform})
+	}
+
+	c.JSON(http.StatusOK, values)
+}
+
+// parseGrafanaTarget разбирает target на метрику и платформу.
+// Пустая платформа означает все платформы
+func parseGrafanaTarget(target string) (string, string) {
+	target = strings.TrimSpace(target)
+	idx := strings.Index(target, ".")
+	if idx < 0 {
+		return target, "all"
+	}
+
+	platform, metric := target[:idx], target[idx+1:]
+	if platform == "*" {
+		platform = ""
+	}
+	return metric, platform
+}
+
+// grafanaInterval рассчитывает шаг усреднения по intervalMs и maxDataPoints
+func grafanaInterval(req grafanaQueryRequest) time.Duration {
+	interval := time.Duration(req.IntervalMs) * time.Millisecond
+
+	maxPoints := req.MaxDataPoints
+	if maxPoints <= 0 {
+		maxPoints = grafanaDefaultPoints
+	}
+	if byPoints := req.Range.To.Sub(req.Range.From) / time.Duration(maxPoints); byPoints > interval {
+		interval = byPoints
+	}
+
+	if interval < grafanaMinInterval {
+		interval = grafanaMinInterval
+	}
+	return interval
+}
+
+// grafanaSeriesFrom группирует точки по платформам в ряды Grafana
+func grafanaSeriesFrom(refID, metric, platform string, data []models.TimeSeriesData) []grafanaTimeSeries {
+	var series []grafanaTimeSeries
+	index := make(map[string]int)
+
+	for _, point := range data {
+		name := metric
+		if platform != "all" {
+			name = point.Platform + "." + metric
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(series)
+			index[name] = i
+			series = append(series, grafanaTimeSeries{Target: name, RefID: refID, Datapoints: [][2]float64{}})
+		}
+		series[i].Datapoints = append(series[i].Datapoints, [2]float64{point.Value, float64(point.Timestamp.UnixMilli())})
+	}
+
+	if len(series) == 0 {
+		// Пустой ряд, чтобы панель показала "No data" вместо ошибки
+		name := metric
+		if platform != "all" && platform != "" {
+			name = platform + "." + metric
+		}
+		series = append(series, grafanaTimeSeries{Target: name, RefID: refID, Datapoints: [][2]float64{}})
+	}
+
+	return series
+}
+
+// grafanaTableFrom формирует таблицу Grafana из точек ряда
+func grafanaTableFrom(refID, metric string, data []models.TimeSeriesData) grafanaTable {
+	table := grafanaTable{
+		Type:  "table",
+		RefID: refID,
+		Columns: []map[string]string{
+			{"text": "Time", "type": "time"},
+			{"text": "Platform", "type": "string"},
+			{"text": metric, "type": "number"},
+		},
+		Rows: make([][]interface{}, 0, len(data)),
+	}
+
+	for _, point := range data {
+		table.Rows = append(table.Rows, []interface{}{point.Timestamp.UnixMilli(), point.Platform, point.Value})
+	}
+
+	return table
+}
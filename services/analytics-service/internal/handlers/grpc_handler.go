@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
@@ -15,17 +16,22 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxAccountCostsBatch ограничивает число аккаунтов в одном запросе расходов
+const maxAccountCostsBatch = 500
+
 // AnalyticsHandler обработчик gRPC запросов
 type AnalyticsHandler struct {
 	pb.UnimplementedAnalyticsServiceServer
 	analyticsService *service.AnalyticsService
-	logger           *logger.Logger
+	redactor         *Redactor
+	logger           logger.Logger
 }
 
 // NewAnalyticsHandler создает новый обработчик
-func NewAnalyticsHandler(analyticsService *service.AnalyticsService, logger *logger.Logger) *AnalyticsHandler {
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService, redactor *Redactor, logger logger.Logger) *AnalyticsHandler {
 	return &AnalyticsHandler{
 		analyticsService: analyticsService,
+		redactor:         redactor,
 		logger:           logger,
 	}
 }
@@ -55,6 +61,10 @@ func (h *AnalyticsHandler) GetOverallAnalytics(ctx context.Context, req *pb.Anal
 		return nil, status.Error(codes.Internal, "Failed to get analytics")
 	}
 
+	if h.redactor.hidesMoneyGRPC(ctx) {
+		redactOverall(analytics)
+	}
+
 	// Конвертируем в protobuf
 	response := &pb.OverallAnalytics{
 		TotalAccounts:      analytics.TotalAccounts,
@@ -75,6 +85,8 @@ func (h *AnalyticsHandler) GetOverallAnalytics(ctx context.Context, req *pb.Anal
 			BannedProxies:      analytics.Resources.BannedProxies,
 			SmsBalance:         analytics.Resources.SMSBalance,
 			WarmingTasksActive: analytics.Resources.WarmingTasksActive,
+			SmsDeferredToday:   analytics.Resources.SMSDeferredToday,
+			SmsDeferralRate:    analytics.Resources.SMSDeferralRate,
 		},
 		Performance: &pb.PerformanceSummary{
 			AvgWarmingDays:       analytics.Performance.AvgWarmingDays,
@@ -106,6 +118,10 @@ func (h *AnalyticsHandler) GetPlatformAnalytics(ctx context.Context, req *pb.Pla
 		return nil, status.Error(codes.Internal, "Failed to get platform analytics")
 	}
 
+	if h.redactor.hidesMoneyGRPC(ctx) {
+		redactPlatform(analytics)
+	}
+
 	return &pb.PlatformAnalytics{
 		Platform:        analytics.Platform,
 		TotalAccounts:   analytics.TotalAccounts,
@@ -138,6 +154,10 @@ func (h *AnalyticsHandler) GetExpenseForecast(ctx context.Context, req *pb.Forec
 		return nil, status.Error(codes.NotFound, "No forecast available")
 	}
 
+	if h.redactor.hidesMoneyGRPC(ctx) {
+		redactExpenseForecast(forecast.ExpenseForecast)
+	}
+
 	return &pb.ExpenseForecastResponse{
 		Period:        forecast.ExpenseForecast.Period,
 		PredictedCost: forecast.ExpenseForecast.PredictedCost,
@@ -214,6 +234,10 @@ func (h *AnalyticsHandler) GetProxyProviderRankings(ctx context.Context, req *em
 		return nil, status.Error(codes.Internal, "Failed to get proxy rankings")
 	}
 
+	if h.redactor.hidesMoneyGRPC(ctx) {
+		redactProxyRankings(rankings)
+	}
+
 	var pbRankings []*pb.ProviderRanking
 	for _, rank := range rankings.Rankings {
 		pbRankings = append(pbRankings, &pb.ProviderRanking{
@@ -344,24 +368,33 @@ func (h *AnalyticsHandler) CreateAlertRule(ctx context.Context, req *pb.CreateRu
 		service.RecordGRPCRequest("CreateAlertRule", time.Since(start).Seconds())
 	}()
 
-	if req.Name == "" || req.Type == "" {
-		return nil, status.Error(codes.InvalidArgument, "Name and type are required")
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Name is required")
+	}
+	if req.Expression == "" && (req.Type == "" || req.Threshold == nil) {
+		return nil, status.Error(codes.InvalidArgument, "Type and threshold or expression are required")
 	}
 
 	rule := &models.AlertRule{
-		Name:     req.Name,
-		Type:     req.Type,
-		Platform: req.Platform,
-		Enabled:  true,
-		Threshold: models.AlertThreshold{
+		Name:       req.Name,
+		Type:       req.Type,
+		Platform:   req.Platform,
+		Enabled:    true,
+		Expression: req.Expression,
+		Severity:   req.Severity,
+		Cooldown:   int(req.Cooldown),
+	}
+	if req.Threshold != nil {
+		rule.Threshold = models.AlertThreshold{
 			Operator: req.Threshold.Operator,
 			Value:    req.Threshold.Value,
-		},
-		Severity: req.Severity,
-		Cooldown: int(req.Cooldown),
+		}
 	}
 
 	if err := h.analyticsService.CreateAlertRule(ctx, rule); err != nil {
+		if errors.Is(err, service.ErrInvalidAlertRule) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, "Failed to create alert rule")
 	}
 
@@ -375,8 +408,9 @@ func (h *AnalyticsHandler) CreateAlertRule(ctx context.Context, req *pb.CreateRu
 			Operator: rule.Threshold.Operator,
 			Value:    rule.Threshold.Value,
 		},
-		Severity: rule.Severity,
-		Cooldown: int32(rule.Cooldown),
+		Expression: rule.Expression,
+		Severity:   rule.Severity,
+		Cooldown:   int32(rule.Cooldown),
 	}, nil
 }
 
@@ -399,7 +433,15 @@ func (h *AnalyticsHandler) UpdateAlertRule(ctx context.Context, req *pb.UpdateRu
 		}
 	}
 
-	if err := h.analyticsService.UpdateAlertRule(ctx, req.RuleId, req.Enabled, threshold, int(req.Cooldown)); err != nil {
+	var expression *string
+	if req.Expression != "" || req.ClearExpression {
+		expression = &req.Expression
+	}
+
+	if err := h.analyticsService.UpdateAlertRule(ctx, req.RuleId, req.Enabled, threshold, expression, int(req.Cooldown)); err != nil {
+		if errors.Is(err, service.ErrInvalidAlertRule) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, "Failed to update alert rule")
 	}
 
@@ -421,8 +463,9 @@ func (h *AnalyticsHandler) UpdateAlertRule(ctx context.Context, req *pb.UpdateRu
 					Operator: rule.Threshold.Operator,
 					Value:    rule.Threshold.Value,
 				},
-				Severity: rule.Severity,
-				Cooldown: int32(rule.Cooldown),
+				Expression: rule.Expression,
+				Severity:   rule.Severity,
+				Cooldown:   int32(rule.Cooldown),
 			}, nil
 		}
 	}
@@ -430,6 +473,28 @@ func (h *AnalyticsHandler) UpdateAlertRule(ctx context.Context, req *pb.UpdateRu
 	return nil, status.Error(codes.NotFound, "Rule not found")
 }
 
+// ValidateAlertExpression проверяет выражение правила без сохранения
+func (h *AnalyticsHandler) ValidateAlertExpression(ctx context.Context, req *pb.ValidateExpressionRequest) (*pb.ValidateExpressionResponse, error) {
+	start := time.Now()
+	defer func() {
+		service.RecordGRPCRequest("ValidateAlertExpression", time.Since(start).Seconds())
+	}()
+
+	if req.Expression == "" {
+		return nil, status.Error(codes.InvalidArgument, "Expression is required")
+	}
+
+	info := h.analyticsService.ValidateAlertExpression(req.Expression)
+	return &pb.ValidateExpressionResponse{
+		Valid:      info.Valid,
+		Error:      info.Error,
+		Position:   int32(info.Position),
+		Normalized: info.Normalized,
+		Metrics:    info.Metrics,
+		For:        info.For,
+	}, nil
+}
+
 // DeleteAlertRule удаляет правило алерта
 func (h *AnalyticsHandler) DeleteAlertRule(ctx context.Context, req *pb.DeleteRuleRequest) (*emptypb.Empty, error) {
 	start := time.Now()
@@ -472,8 +537,9 @@ func (h *AnalyticsHandler) ListAlertRules(ctx context.Context, req *emptypb.Empt
 				Operator: rule.Threshold.Operator,
 				Value:    rule.Threshold.Value,
 			},
-			Severity: rule.Severity,
-			Cooldown: int32(rule.Cooldown),
+			Expression: rule.Expression,
+			Severity:   rule.Severity,
+			Cooldown:   int32(rule.Cooldown),
 		})
 	}
 
@@ -482,6 +548,222 @@ func (h *AnalyticsHandler) ListAlertRules(ctx context.Context, req *emptypb.Empt
 	}, nil
 }
 
+// GetAccountLifecycleReport получает отчет по жизненному циклу аккаунтов
+func (h *AnalyticsHandler) GetAccountLifecycleReport(ctx context.Context, req *pb.LifecycleRequest) (*pb.LifecycleReportResponse, error) {
+	start := time.Now()
+	defer func() {
+		service.RecordGRPCRequest("GetAccountLifecycleReport", time.Since(start).Seconds())
+	}()
+
+	report, err := h.analyticsService.GetLifecycleReport(ctx, req.Platform, int(req.Days))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to get lifecycle report")
+	}
+
+	var durations []*pb.StateDuration
+	for _, d := range report.Durations {
+		durations = append(durations, &pb.StateDuration{
+			State:    string(d.State),
+			Samples:  d.Samples,
+			AvgHours: d.AvgHours,
+			P50Hours: d.P50Hours,
+			P90Hours: d.P90Hours,
+			MaxHours: d.MaxHours,
+		})
+	}
+
+	return &pb.LifecycleReportResponse{
+		Platform:    report.Platform,
+		StateCounts: report.StateCounts,
+		Durations:   durations,
+		PeriodStart: timestamppb.New(report.PeriodStart),
+		GeneratedAt: timestamppb.New(report.GeneratedAt),
+	}, nil
+}
+
+// GetAccountFunnel получает воронку аккаунтов по когортам
+func (h *AnalyticsHandler) GetAccountFunnel(ctx context.Context, req *pb.FunnelRequest) (*pb.FunnelResponse, error) {
+	start := time.Now()
+	defer func() {
+		service.RecordGRPCRequest("GetAccountFunnel", time.Since(start).Seconds())
+	}()
+
+	groupBy, ok := funnelGroupBy(req.GroupBy)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "Unknown group_by, expected: week, platform, proxy_provider")
+	}
+
+	weeks := int(req.Weeks)
+	if weeks <= 0 {
+		weeks = 8
+	}
+
+	filter := models.FunnelFilter{
+		Platform:      req.Platform,
+		ProxyProvider: req.ProxyProvider,
+		Since:         time.Now().AddDate(0, 0, -7*weeks),
+		GroupBy:       groupBy,
+	}
+
+	report, err := h.analyticsService.GetFunnel(ctx, filter)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to get funnel")
+	}
+
+	cohorts := make([]*pb.FunnelCohort, 0, len(report.Cohorts))
+	for _, cohort := range report.Cohorts {
+		cohorts = append(cohorts, convertFunnelCohort(cohort))
+	}
+
+	return &pb.FunnelResponse{
+		Platform:    report.Platform,
+		GroupBy:     report.GroupBy,
+		Total:       convertFunnelCohort(report.Total),
+		Cohorts:     cohorts,
+		PeriodStart: timestamppb.New(report.PeriodStart),
+		GeneratedAt: timestamppb.New(report.GeneratedAt),
+	}, nil
+}
+
+// GetAccountSurvival получает кривые выживаемости аккаунтов по когортам
+func (h *AnalyticsHandler) GetAccountSurvival(ctx context.Context, req *pb.SurvivalRequest) (*pb.SurvivalResponse, error) {
+	start := time.Now()
+	defer func() {
+		service.RecordGRPCRequest("GetAccountSurvival", time.Since(start).Seconds())
+	}()
+
+	groupBy, ok := survivalGroupBy(req.GroupBy)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "Unknown group_by, expected: platform, scenario, proxy_provider")
+	}
+
+	days := int(req.Days)
+	if days <= 0 {
+		days = 90
+	}
+
+	filter := models.SurvivalFilter{
+		Platform:      req.Platform,
+		Scenario:      req.Scenario,
+		ProxyProvider: req.ProxyProvider,
+		Since:         time.Now().AddDate(0, 0, -days),
+		GroupBy:       groupBy,
+	}
+
+	report, err := h.analyticsService.GetSurvival(ctx, filter)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to get survival curves")
+	}
+
+	curves := make([]*pb.SurvivalCurve, 0, len(report.Curves))
+	for _, curve := range report.Curves {
+		curves = append(curves, convertSurvivalCurve(curve))
+	}
+
+	return &pb.SurvivalResponse{
+		Platform:    report.Platform,
+		GroupBy:     report.GroupBy,
+		Total:       convertSurvivalCurve(report.Total),
+		Curves:      curves,
+		PeriodStart: timestamppb.New(report.PeriodStart),
+		GeneratedAt: timestamppb.New(report.GeneratedAt),
+	}, nil
+}
+
+// GetRegistrationLatency получает перцентили длительности регистраций по платформам и шагам
+func (h *AnalyticsHandler) GetRegistrationLatency(ctx context.Context, req *pb.RegistrationLatencyRequest) (*pb.RegistrationLatencyResponse, error) {
+	start := time.Now()
+	defer func() {
+		service.RecordGRPCRequest("GetRegistrationLatency", time.Since(start).Seconds())
+	}()
+
+	report, err := h.analyticsService.GetRegistrationLatency(ctx, req.Platform, int(req.Days))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to get registration latency")
+	}
+
+	platforms := make([]*pb.PlatformRegistrationLatency, 0, len(report.Platforms))
+	for _, p := range report.Platforms {
+		steps := make([]*pb.LatencyPercentiles, 0, len(p.Steps))
+		for _, step := range p.Steps {
+			steps = append(steps, convertLatencyPercentiles(step))
+		}
+		platforms = append(platforms, &pb.PlatformRegistrationLatency{
+			Platform: p.Platform,
+			EndToEnd: convertLatencyPercentiles(p.EndToEnd),
+			Steps:    steps,
+		})
+	}
+
+	return &pb.RegistrationLatencyResponse{
+		Platforms:   platforms,
+		PeriodStart: timestamppb.New(report.PeriodStart),
+		GeneratedAt: timestamppb.New(report.GeneratedAt),
+	}, nil
+}
+
+// GetAccountCosts получает расходы, отнесенные к аккаунтам
+func (h *AnalyticsHandler) GetAccountCosts(ctx context.Context, req *pb.AccountCostsRequest) (*pb.AccountCostsResponse, error) {
+	start := time.Now()
+	defer func() {
+		service.RecordGRPCRequest("GetAccountCosts", time.Since(start).Seconds())
+	}()
+
+	if len(req.AccountIds) > maxAccountCostsBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "At most %d accounts per request", maxAccountCostsBatch)
+	}
+
+	costs, err := h.analyticsService.GetAccountCosts(ctx, req.AccountIds)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to get account costs")
+	}
+
+	if h.redactor.hidesMoneyGRPC(ctx) {
+		redactAccountCosts(costs)
+	}
+
+	pbCosts := make([]*pb.AccountCost, 0, len(costs))
+	for _, cost := range costs {
+		pbCosts = append(pbCosts, &pb.AccountCost{
+			AccountId:  cost.AccountID,
+			Total:      cost.Total,
+			Currency:   cost.Currency,
+			ByCategory: cost.ByCategory,
+		})
+	}
+
+	return &pb.AccountCostsResponse{Costs: pbCosts}, nil
+}
+
+// GetDailySummary получает текстовую сводку за сутки
+func (h *AnalyticsHandler) GetDailySummary(ctx context.Context, req *pb.DailySummaryRequest) (*pb.DailySummaryResponse, error) {
+	start := time.Now()
+	defer func() {
+		service.RecordGRPCRequest("GetDailySummary", time.Since(start).Seconds())
+	}()
+
+	summary, err := h.analyticsService.GetDailySummary(ctx, req.Date)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSummaryDate) || errors.Is(err, service.ErrDayNotFinished) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "Failed to get daily summary")
+	}
+
+	if h.redactor.hidesMoneyGRPC(ctx) {
+		redactDailySummary(summary)
+	}
+
+	return &pb.DailySummaryResponse{
+		Date:        summary.Date,
+		Text:        summary.Text,
+		Generator:   summary.Generator,
+		PeriodStart: timestamppb.New(summary.PeriodStart),
+		PeriodEnd:   timestamppb.New(summary.PeriodEnd),
+		GeneratedAt: timestamppb.New(summary.GeneratedAt),
+	}, nil
+}
+
 // Helper функции
 
 func convertErrorStats(errors []models.ErrorStat) []*pb.ErrorStat {
@@ -507,3 +789,65 @@ func convertTrends(trends []service.TrendData) []*pb.TrendData {
 	}
 	return result
 }
+
+func convertFunnelCohort(cohort models.FunnelCohort) *pb.FunnelCohort {
+	var stages []*pb.FunnelStage
+	for _, stage := range cohort.Stages {
+		stages = append(stages, &pb.FunnelStage{
+			Stage:          string(stage.Stage),
+			Accounts:       stage.Accounts,
+			ConversionRate: stage.ConversionRate,
+			OverallRate:    stage.OverallRate,
+			MedianHours:    stage.MedianHours,
+			Samples:        stage.Samples,
+		})
+	}
+
+	return &pb.FunnelCohort{
+		Week:              cohort.Week,
+		Platform:          cohort.Platform,
+		ProxyProvider:     cohort.ProxyProvider,
+		Stages:            stages,
+		BannedBeforeReady: cohort.BannedBeforeReady,
+	}
+}
+
+func convertSurvivalCurve(curve models.SurvivalCurve) *pb.SurvivalCurve {
+	points := make([]*pb.SurvivalPoint, 0, len(curve.Points))
+	for _, point := range curve.Points {
+		points = append(points, &pb.SurvivalPoint{
+			Day:      int32(point.Day),
+			AtRisk:   point.AtRisk,
+			Banned:   point.Banned,
+			Censored: point.Censored,
+			Survival: point.Survival,
+		})
+	}
+
+	medianDays := int32(-1)
+	if curve.MedianDays != nil {
+		medianDays = int32(*curve.MedianDays)
+	}
+
+	return &pb.SurvivalCurve{
+		Platform:      curve.Platform,
+		Scenario:      curve.Scenario,
+		ProxyProvider: curve.ProxyProvider,
+		Accounts:      curve.Accounts,
+		Banned:        curve.Banned,
+		MedianDays:    medianDays,
+		Survival_7D:   curve.Survival7d,
+		Survival_30D:  curve.Survival30d,
+		Points:        points,
+	}
+}
+
+func convertLatencyPercentiles(latency models.LatencyPercentiles) *pb.LatencyPercentiles {
+	return &pb.LatencyPercentiles{
+		Step:    latency.Step,
+		Samples: latency.Samples,
+		P50Sec:  latency.P50Sec,
+		P90Sec:  latency.P90Sec,
+		P99Sec:  latency.P99Sec,
+	}
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grigta/conveer/services/analytics-service/internal/models"
@@ -43,6 +48,10 @@ func (h *AnalyticsHandler) GetOverallAnalyticsHTTP(c *gin.Context) {
 		return
 	}
 
+	if h.redactor.hidesMoneyHTTP(c) {
+		redactOverall(analytics)
+	}
+
 	c.JSON(http.StatusOK, analytics)
 }
 
@@ -66,6 +75,10 @@ func (h *AnalyticsHandler) GetPlatformAnalyticsHTTP(c *gin.Context) {
 		return
 	}
 
+	if h.redactor.hidesMoneyHTTP(c) {
+		redactPlatform(analytics)
+	}
+
 	c.JSON(http.StatusOK, analytics)
 }
 
@@ -93,12 +106,27 @@ func (h *AnalyticsHandler) GetExpenseForecastHTTP(c *gin.Context) {
 		return
 	}
 
+	// Сгорание бюджета не обязательно: без monthly_budget его нет
+	budget, err := h.analyticsService.GetBudgetBurnDown(c)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to get budget burn-down")
+	}
+
+	if h.redactor.hidesMoneyHTTP(c) {
+		redactExpenseForecast(forecast.ExpenseForecast)
+		if budget != nil {
+			redactBurnDown(budget)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"period":         forecast.ExpenseForecast.Period,
 		"predicted_cost": forecast.ExpenseForecast.PredictedCost,
 		"upper_bound":    forecast.ExpenseForecast.UpperBound,
 		"lower_bound":    forecast.ExpenseForecast.LowerBound,
 		"breakdown":      forecast.ExpenseForecast.Breakdown,
+		"daily_rate":     forecast.ExpenseForecast.DailyRate,
+		"budget":         budget,
 		"confidence":     forecast.Confidence,
 		"generated_at":   forecast.GeneratedAt,
 	})
@@ -180,6 +208,10 @@ func (h *AnalyticsHandler) GetProxyRankingsHTTP(c *gin.Context) {
 		return
 	}
 
+	if h.redactor.hidesMoneyHTTP(c) {
+		redactProxyRankings(rankings)
+	}
+
 	c.JSON(http.StatusOK, rankings)
 }
 
@@ -310,34 +342,43 @@ func (h *AnalyticsHandler) CreateAlertRuleHTTP(c *gin.Context) {
 		service.RecordHTTPRequest("POST", "/rules", time.Since(start).Seconds(), c.Writer.Status())
 	}()
 
+	// Правило задается либо типом и порогом, либо выражением (тогда тип и порог необязательны)
 	var req struct {
-		Name      string  `json:"name" binding:"required"`
-		Type      string  `json:"type" binding:"required"`
-		Platform  string  `json:"platform"`
-		Threshold struct {
+		Name      string `json:"name" binding:"required"`
+		Type      string `json:"type"`
+		Platform  string `json:"platform"`
+		Threshold *struct {
 			Operator string  `json:"operator" binding:"required"`
-			Value    float64 `json:"value" binding:"required"`
-		} `json:"threshold" binding:"required"`
-		Severity string `json:"severity" binding:"required"`
-		Cooldown int    `json:"cooldown"`
+			Value    float64 `json:"value"`
+		} `json:"threshold"`
+		Expression string `json:"expression"`
+		Severity   string `json:"severity" binding:"required"`
+		Cooldown   int    `json:"cooldown"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if req.Expression == "" && (req.Type == "" || req.Threshold == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type and threshold or expression are required"})
+		return
+	}
 
 	rule := &models.AlertRule{
-		Name:     req.Name,
-		Type:     req.Type,
-		Platform: req.Platform,
-		Enabled:  true,
-		Threshold: models.AlertThreshold{
+		Name:       req.Name,
+		Type:       req.Type,
+		Platform:   req.Platform,
+		Enabled:    true,
+		Expression: req.Expression,
+		Severity:   req.Severity,
+		Cooldown:   req.Cooldown,
+	}
+	if req.Threshold != nil {
+		rule.Threshold = models.AlertThreshold{
 			Operator: req.Threshold.Operator,
 			Value:    req.Threshold.Value,
-		},
-		Severity: req.Severity,
-		Cooldown: req.Cooldown,
+		}
 	}
 
 	if rule.Cooldown == 0 {
@@ -345,6 +386,10 @@ func (h *AnalyticsHandler) CreateAlertRuleHTTP(c *gin.Context) {
 	}
 
 	if err := h.analyticsService.CreateAlertRule(c, rule); err != nil {
+		if errors.Is(err, service.ErrInvalidAlertRule) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.WithError(err).Error("Failed to create alert rule")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create alert rule"})
 		return
@@ -360,8 +405,9 @@ func (h *AnalyticsHandler) CreateAlertRuleHTTP(c *gin.Context) {
 			"operator": rule.Threshold.Operator,
 			"value":    rule.Threshold.Value,
 		},
-		"severity": rule.Severity,
-		"cooldown": rule.Cooldown,
+		"expression": rule.Expression,
+		"severity":   rule.Severity,
+		"cooldown":   rule.Cooldown,
 	})
 }
 
@@ -384,7 +430,8 @@ func (h *AnalyticsHandler) UpdateAlertRuleHTTP(c *gin.Context) {
 			Operator string  `json:"operator"`
 			Value    float64 `json:"value"`
 		} `json:"threshold"`
-		Cooldown *int `json:"cooldown"`
+		Expression *string `json:"expression"`
+		Cooldown   *int    `json:"cooldown"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -410,7 +457,11 @@ func (h *AnalyticsHandler) UpdateAlertRuleHTTP(c *gin.Context) {
 		cooldown = *req.Cooldown
 	}
 
-	if err := h.analyticsService.UpdateAlertRule(c, ruleID, enabled, threshold, cooldown); err != nil {
+	if err := h.analyticsService.UpdateAlertRule(c, ruleID, enabled, threshold, req.Expression, cooldown); err != nil {
+		if errors.Is(err, service.ErrInvalidAlertRule) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.WithError(err).Error("Failed to update alert rule")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update alert rule"})
 		return
@@ -419,6 +470,25 @@ func (h *AnalyticsHandler) UpdateAlertRuleHTTP(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"success": true})
 }
 
+// ValidateAlertExpressionHTTP проверяет выражение правила без сохранения
+func (h *AnalyticsHandler) ValidateAlertExpressionHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("POST", "/rules/validate", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	var req struct {
+		Expression string `json:"expression" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.analyticsService.ValidateAlertExpression(req.Expression))
+}
+
 // DeleteAlertRuleHTTP удаляет правило алерта через HTTP
 func (h *AnalyticsHandler) DeleteAlertRuleHTTP(c *gin.Context) {
 	start := time.Now()
@@ -440,3 +510,326 @@ func (h *AnalyticsHandler) DeleteAlertRuleHTTP(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"success": true})
 }
+
+// GetLifecycleReportHTTP получает отчет по жизненному циклу аккаунтов через HTTP
+func (h *AnalyticsHandler) GetLifecycleReportHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("GET", "/lifecycle", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	platform := c.DefaultQuery("platform", "all")
+
+	days := 30
+	if daysStr := c.Query("days"); daysStr != "" {
+		if parsed, err := strconv.Atoi(daysStr); err == nil {
+			days = parsed
+		}
+	}
+
+	report, err := h.analyticsService.GetLifecycleReport(c, platform, days)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get lifecycle report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get lifecycle report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetFunnelHTTP получает воронку аккаунтов по когортам через HTTP
+func (h *AnalyticsHandler) GetFunnelHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("GET", "/funnel", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	weeks := 8
+	if weeksStr := c.Query("weeks"); weeksStr != "" {
+		if parsed, err := strconv.Atoi(weeksStr); err == nil && parsed > 0 {
+			weeks = parsed
+		}
+	}
+
+	var groups []string
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		groups = strings.Split(groupBy, ",")
+	}
+	groupBy, ok := funnelGroupBy(groups)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown group_by, expected: week, platform, proxy_provider"})
+		return
+	}
+
+	filter := models.FunnelFilter{
+		Platform:      c.DefaultQuery("platform", "all"),
+		ProxyProvider: c.Query("proxy_provider"),
+		Since:         time.Now().AddDate(0, 0, -7*weeks),
+		GroupBy:       groupBy,
+	}
+
+	report, err := h.analyticsService.GetFunnel(c, filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get funnel")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get funnel"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetSurvivalHTTP получает кривые выживаемости аккаунтов по когортам через HTTP.
+// С format=csv отдает точки всех кривых файлом для выгрузки.
+func (h *AnalyticsHandler) GetSurvivalHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("GET", "/survival", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	days := 90
+	if daysStr := c.Query("days"); daysStr != "" {
+		if parsed, err := strconv.Atoi(daysStr); err == nil && parsed > 0 {
+			days = parsed
+		}
+	}
+
+	var groups []string
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		groups = strings.Split(groupBy, ",")
+	}
+	groupBy, ok := survivalGroupBy(groups)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown group_by, expected: platform, scenario, proxy_provider"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown format, expected: json, csv"})
+		return
+	}
+
+	filter := models.SurvivalFilter{
+		Platform:      c.DefaultQuery("platform", "all"),
+		Scenario:      c.Query("scenario"),
+		ProxyProvider: c.Query("proxy_provider"),
+		Since:         time.Now().AddDate(0, 0, -days),
+		GroupBy:       groupBy,
+	}
+
+	report, err := h.analyticsService.GetSurvival(c, filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get survival curves")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get survival curves"})
+		return
+	}
+
+	if format == "csv" {
+		data, err := survivalCSV(report)
+		if err != nil {
+			h.logger.WithError(err).Error("Failed to export survival curves")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export survival curves"})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=survival_%s_%s.csv", report.Platform, report.GeneratedAt.Format("20060102")))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetProviderEfficiencyHTTP получает стоимость выжившего аккаунта по прокси-провайдерам через HTTP
+func (h *AnalyticsHandler) GetProviderEfficiencyHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("GET", "/providers/efficiency", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	days := 30
+	if daysStr := c.Query("days"); daysStr != "" {
+		if parsed, err := strconv.Atoi(daysStr); err == nil {
+			days = parsed
+		}
+	}
+
+	report, err := h.analyticsService.GetProviderEfficiency(c, days)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get provider efficiency")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get provider efficiency"})
+		return
+	}
+
+	if h.redactor.hidesMoneyHTTP(c) {
+		redactProviderEfficiency(report)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetResourceCleanupHTTP получает ресурсы, освобожденные при компенсации брошенных регистраций, через HTTP
+func (h *AnalyticsHandler) GetResourceCleanupHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("GET", "/cleanup", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	days := 7
+	if daysStr := c.Query("days"); daysStr != "" {
+		if parsed, err := strconv.Atoi(daysStr); err == nil {
+			days = parsed
+		}
+	}
+
+	summary, err := h.analyticsService.GetResourceCleanup(c, days)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get resource cleanup")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resource cleanup"})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetRegistrationLatencyHTTP получает перцентили длительности регистраций через HTTP
+func (h *AnalyticsHandler) GetRegistrationLatencyHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("GET", "/registrations/latency", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	platform := c.DefaultQuery("platform", "all")
+
+	days := 7
+	if daysStr := c.Query("days"); daysStr != "" {
+		if parsed, err := strconv.Atoi(daysStr); err == nil {
+			days = parsed
+		}
+	}
+
+	report, err := h.analyticsService.GetRegistrationLatency(c, platform, days)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get registration latency")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get registration latency"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetDailySummaryHTTP получает текстовую сводку за сутки через HTTP
+func (h *AnalyticsHandler) GetDailySummaryHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("GET", "/summary/daily", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	summary, err := h.analyticsService.GetDailySummary(c, c.Query("date"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSummaryDate) || errors.Is(err, service.ErrDayNotFinished) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to get daily summary")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get daily summary"})
+		return
+	}
+
+	if h.redactor.hidesMoneyHTTP(c) {
+		redactDailySummary(summary)
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// ComparePlatformsHTTP сравнивает ключевые показатели платформ с предыдущим периодом через HTTP
+func (h *AnalyticsHandler) ComparePlatformsHTTP(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		service.RecordHTTPRequest("GET", "/compare", time.Since(start).Seconds(), c.Writer.Status())
+	}()
+
+	days := 7
+	if daysStr := c.Query("days"); daysStr != "" {
+		if parsed, err := strconv.Atoi(daysStr); err == nil && parsed > 0 {
+			days = parsed
+		}
+	}
+
+	report, err := h.analyticsService.ComparePlatforms(c, days)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to compare platforms")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare platforms"})
+		return
+	}
+
+	if h.redactor.hidesMoneyHTTP(c) {
+		redactPlatformComparison(report)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// funnelGroupBy проверяет измерения группировки воронки
+func funnelGroupBy(groups []string) ([]string, bool) {
+	var result []string
+	for _, group := range groups {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			continue
+		}
+		if !models.IsFunnelGrouping(group) {
+			return nil, false
+		}
+		result = append(result, group)
+	}
+	return result, true
+}
+
+// survivalGroupBy проверяет измерения группировки кривых выживаемости
+func survivalGroupBy(groups []string) ([]string, bool) {
+	var result []string
+	for _, group := range groups {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			continue
+		}
+		if !models.IsSurvivalGrouping(group) {
+			return nil, false
+		}
+		result = append(result, group)
+	}
+	return result, true
+}
+
+// survivalCSV выгружает точки кривых: строка на день когорты, общая кривая идет первой
+func survivalCSV(report *models.SurvivalReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"platform", "scenario", "proxy_provider", "day", "at_risk", "banned", "censored", "survival"}); err != nil {
+		return nil, err
+	}
+
+	curves := append([]models.SurvivalCurve{report.Total}, report.Curves...)
+	for _, curve := range curves {
+		for _, point := range curve.Points {
+			record := []string{
+				curve.Platform,
+				curve.Scenario,
+				curve.ProxyProvider,
+				strconv.Itoa(point.Day),
+				strconv.FormatInt(point.AtRisk, 10),
+				strconv.FormatInt(point.Banned, 10),
+				strconv.FormatInt(point.Censored, 10),
+				strconv.FormatFloat(point.Survival, 'f', 4, 64),
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grigta/conveer/pkg/middleware"
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+	"github.com/grigta/conveer/services/analytics-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RedactedHeader помечает ответы, из которых убраны абсолютные суммы
+const RedactedHeader = "X-Analytics-Redacted"
+
+// Redactor убирает абсолютные денежные суммы из ответов для ролей без доступа к финансам.
+// Динамика остаётся: доли, проценты от бюджета и индексы вместо сумм.
+type Redactor struct {
+	auth        *middleware.AuthMiddleware
+	hiddenRoles map[string]bool
+	hideAll     bool
+}
+
+// NewRedactor создает redactor. Без секрета JWT роль не прочитать, поэтому при заданных скрытых
+// ролях суммы скрываются от всех вызывающих
+func NewRedactor(jwtSecret string, hiddenRoles []string) *Redactor {
+	r := &Redactor{hiddenRoles: make(map[string]bool)}
+	if jwtSecret != "" {
+		r.auth = middleware.NewAuthMiddleware(jwtSecret)
+	}
+	for _, role := range hiddenRoles {
+		r.hiddenRoles[strings.ToLower(role)] = true
+	}
+	r.hideAll = r.auth == nil && len(r.hiddenRoles) > 0
+	return r
+}
+
+// HidesAll сообщает, что суммы скрываются от всех вызывающих
+func (r *Redactor) HidesAll() bool {
+	return r.hideAll
+}
+
+// Middleware читает роль из JWT HTTP запроса; запросы без токена (внутренние сервисы) проходят как есть
+func (r *Redactor) Middleware() gin.HandlerFunc {
+	if r.auth == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return r.auth.OptionalAuthenticate()
+}
+
+// UnaryInterceptor читает роль из метаданных authorization gRPC запроса
+func (r *Redactor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if r.auth == nil {
+			return handler(ctx, req)
+		}
+
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		for _, value := range md.Get("authorization") {
+			token := strings.TrimSpace(strings.TrimPrefix(value, "Bearer "))
+			claims, err := r.auth.ValidateToken(token)
+			if err != nil {
+				continue
+			}
+
+			userID, _ := claims["user_id"].(string)
+			email, _ := claims["email"].(string)
+			role, _ := claims["role"].(string)
+			ctx = middleware.SetUserContext(ctx, userID, email, role)
+			break
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// hidesMoneyHTTP проверяет роль HTTP запроса и помечает ответ как скрытый
+func (r *Redactor) hidesMoneyHTTP(c *gin.Context) bool {
+	if !r.hideAll && !r.hiddenRoles[strings.ToLower(c.GetString("role"))] {
+		return false
+	}
+	c.Header(RedactedHeader, "true")
+	return true
+}
+
+// hidesMoneyGRPC проверяет роль gRPC запроса и помечает ответ как скрытый
+func (r *Redactor) hidesMoneyGRPC(ctx context.Context) bool {
+	role, _ := middleware.GetRoleFromContext(ctx)
+	if !r.hideAll && !r.hiddenRoles[strings.ToLower(role)] {
+		return false
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(RedactedHeader), "true"))
+	return true
+}
+
+// redactOverall убирает суммы расходов; тренд расходов становится индексом к среднему за период (100 = среднее)
+func redactOverall(analytics *service.OverallAnalytics) {
+	analytics.Expenses = service.ExpensesSummary{}
+	analytics.Resources.SMSBalance = 0
+
+	var total float64
+	for _, trend := range analytics.Trends {
+		total += trend.Expenses
+	}
+	for i := range analytics.Trends {
+		analytics.Trends[i].Expenses = percentOf(analytics.Trends[i].Expenses, total/float64(len(analytics.Trends)))
+	}
+}
+
+func redactPlatform(analytics *service.PlatformAnalytics) {
+	analytics.TotalSpent = 0
+}
+
+// redactExpenseForecast оставляет разбивку прогноза долями в процентах
+func redactExpenseForecast(forecast *models.ExpenseForecast) {
+	breakdown := make(map[string]float64, len(forecast.Breakdown))
+	for category, cost := range forecast.Breakdown {
+		breakdown[category] = percentOf(cost, forecast.PredictedCost)
+	}
+
+	forecast.Breakdown = breakdown
+	forecast.PredictedCost = 0
+	forecast.UpperBound = 0
+	forecast.LowerBound = 0
+	forecast.DailyRate = 0
+}
+
+// redactBurnDown выражает суммы сгорания в процентах месячного бюджета; даты исчерпания не меняются
+func redactBurnDown(burnDown *models.BudgetBurnDown) {
+	budget := burnDown.MonthlyBudget
+
+	burnDown.MonthlyBudget = 100
+	burnDown.Spent = percentOf(burnDown.Spent, budget)
+	burnDown.Remaining = percentOf(burnDown.Remaining, budget)
+	burnDown.ActualDailyRate = percentOf(burnDown.ActualDailyRate, budget)
+	burnDown.ProjectedDailyRate = percentOf(burnDown.ProjectedDailyRate, budget)
+	burnDown.ProjectedMonthEnd = percentOf(burnDown.ProjectedMonthEnd, budget)
+	for i := range burnDown.Days {
+		burnDown.Days[i].Spent = percentOf(burnDown.Days[i].Spent, budget)
+		burnDown.Days[i].Remaining = percentOf(burnDown.Days[i].Remaining, budget)
+	}
+}
+
+// redactProxyRankings убирает стоимость аккаунта; итоговый рейтинг её уже учитывает
+func redactProxyRankings(rankings *models.ProxyProviderRating) {
+	for i := range rankings.Rankings {
+		rankings.Rankings[i].CostPerAccount = 0
+		rankings.Rankings[i].CostPerSurvivingAccount = 0
+	}
+}
+
+// redactProviderEfficiency убирает суммы расходов; сравнение провайдеров остаётся через индекс эффективности
+func redactProviderEfficiency(report *models.ProviderEfficiencyReport) {
+	for i := range report.Providers {
+		report.Providers[i].TotalCost = 0
+		report.Providers[i].CostPerAccount = 0
+		report.Providers[i].CostPerSurvivingAccount = 0
+	}
+}
+
+// redactPlatformComparison убирает суммы расходов; доли, дни прогрева и прирост аккаунтов остаются
+func redactPlatformComparison(report *models.PlatformComparisonReport) {
+	for i := range report.Platforms {
+		platform := &report.Platforms[i]
+		platform.Current.CostPerAccount = 0
+		platform.Current.TotalSpent = 0
+		platform.Previous.CostPerAccount = 0
+		platform.Previous.TotalSpent = 0
+		if platform.Delta != nil {
+			platform.Delta.CostPerAccount = 0
+		}
+	}
+}
+
+// redactAccountCosts оставляет разбивку расходов аккаунта долями в процентах
+func redactAccountCosts(costs []models.AccountCost) {
+	for i := range costs {
+		breakdown := make(map[string]float64, len(costs[i].ByCategory))
+		for category, amount := range costs[i].ByCategory {
+			breakdown[category] = percentOf(amount, costs[i].Total)
+		}
+		costs[i].ByCategory = breakdown
+		costs[i].Total = 0
+	}
+}
+
+func percentOf(value, base float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return value / base * 100
+}
+
+// redactDailySummary убирает суммы расходов и пересобирает текст по шаблону: текст LLM может их содержать
+func redactDailySummary(summary *models.DailySummary) {
+	summary.Text = service.RenderDailySummary(summary, true)
+	summary.Generator = models.SummaryGeneratorTemplate
+	for _, metrics := range []*models.DailySummaryMetrics{&summary.Metrics, summary.Previous} {
+		if metrics == nil {
+			continue
+		}
+		metrics.TotalSpent = 0
+		metrics.SMSSpent = 0
+		metrics.ProxySpent = 0
+	}
+}
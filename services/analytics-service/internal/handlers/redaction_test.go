@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/pkg/middleware"
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+	"github.com/grigta/conveer/services/analytics-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactOverall(t *testing.T) {
+	analytics := &service.OverallAnalytics{
+		TotalAccounts:  120,
+		OverallBanRate: 4.5,
+		Expenses: service.ExpensesSummary{
+			TotalSpentToday:   150,
+			TotalSpentWeek:    900,
+			TotalSpentMonth:   3600,
+			SMSSpent:          2000,
+			ProxySpent:        1600,
+			AvgCostPerAccount: 30,
+		},
+		Resources: service.ResourcesSummary{ActiveProxies: 40, SMSBalance: 500},
+		Trends: []service.TrendData{
+			{AccountsCreated: 10, Expenses: 100},
+			{AccountsCreated: 12, Expenses: 200},
+			{AccountsCreated: 8, Expenses: 300},
+		},
+	}
+
+	redactOverall(analytics)
+
+	assert.Equal(t, service.ExpensesSummary{}, analytics.Expenses)
+	assert.Zero(t, analytics.Resources.SMSBalance)
+	assert.Equal(t, int64(40), analytics.Resources.ActiveProxies)
+	assert.Equal(t, int64(120), analytics.TotalAccounts)
+	assert.Equal(t, 4.5, analytics.OverallBanRate)
+
+	// Расходы по дням становятся индексом к среднему дню (200)
+	assert.InDelta(t, 50, analytics.Trends[0].Expenses, 1e-9)
+	assert.InDelta(t, 100, analytics.Trends[1].Expenses, 1e-9)
+	assert.InDelta(t, 150, analytics.Trends[2].Expenses, 1e-9)
+	assert.Equal(t, int64(12), analytics.Trends[1].AccountsCreated)
+}
+
+func TestRedactOverall_WithoutExpenses(t *testing.T) {
+	tests := []struct {
+		name   string
+		trends []service.TrendData
+	}{
+		{"no trends", nil},
+		{"zero expenses", []service.TrendData{{Expenses: 0}, {Expenses: 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analytics := &service.OverallAnalytics{Trends: tt.trends}
+			redactOverall(analytics)
+			for _, trend := range analytics.Trends {
+				assert.Zero(t, trend.Expenses)
+			}
+		})
+	}
+}
+
+func TestRedactBurnDown(t *testing.T) {
+	exhaustion := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+	burnDown := &models.BudgetBurnDown{
+		MonthlyBudget:      2000,
+		Spent:              1500,
+		Remaining:          500,
+		ActualDailyRate:    100,
+		ProjectedDailyRate: 120,
+		ProjectedMonthEnd:  2400,
+		ExhaustionDate:     &exhaustion,
+		OverrunDays:        6,
+		Days: []models.BurnDownDay{
+			{Spent: 100, Remaining: 1900},
+			{Spent: 1500, Remaining: 500, Projected: true},
+		},
+	}
+
+	redactBurnDown(burnDown)
+
+	assert.Equal(t, 100.0, burnDown.MonthlyBudget)
+	assert.InDelta(t, 75, burnDown.Spent, 1e-9)
+	assert.InDelta(t, 25, burnDown.Remaining, 1e-9)
+	assert.InDelta(t, 5, burnDown.ActualDailyRate, 1e-9)
+	assert.InDelta(t, 6, burnDown.ProjectedDailyRate, 1e-9)
+	assert.InDelta(t, 120, burnDown.ProjectedMonthEnd, 1e-9)
+	assert.InDelta(t, 5, burnDown.Days[0].Spent, 1e-9)
+	assert.InDelta(t, 95, burnDown.Days[0].Remaining, 1e-9)
+	assert.InDelta(t, 75, burnDown.Days[1].Spent, 1e-9)
+	assert.True(t, burnDown.Days[1].Projected)
+
+	// Даты и сроки исчерпания не скрываются
+	assert.Equal(t, &exhaustion, burnDown.ExhaustionDate)
+	assert.Equal(t, 6.0, burnDown.OverrunDays)
+}
+
+func TestRedactBurnDown_WithoutBudget(t *testing.T) {
+	burnDown := &models.BudgetBurnDown{Spent: 1500, Days: []models.BurnDownDay{{Spent: 100}}}
+
+	redactBurnDown(burnDown)
+
+	assert.Equal(t, 100.0, burnDown.MonthlyBudget)
+	assert.Zero(t, burnDown.Spent)
+	assert.Zero(t, burnDown.Days[0].Spent)
+}
+
+func TestRedactor_HidesMoney(t *testing.T) {
+	tests := []struct {
+		name        string
+		jwtSecret   string
+		hiddenRoles []string
+		role        string
+		want        bool
+	}{
+		{"hidden role", "secret", []string{"viewer"}, "viewer", true},
+		{"hidden role in other case", "secret", []string{"Viewer"}, "viewer", true},
+		{"visible role", "secret", []string{"viewer"}, "admin", false},
+		{"internal call without token", "secret", []string{"viewer"}, "", false},
+		{"no secret hides from everyone", "", []string{"viewer"}, "", true},
+		{"no secret hides from admins", "", []string{"viewer"}, "admin", true},
+		{"no hidden roles", "", nil, "viewer", false},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redactor := NewRedactor(tt.jwtSecret, tt.hiddenRoles)
+
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			if tt.role != "" {
+				c.Set("role", tt.role)
+			}
+			assert.Equal(t, tt.want, redactor.hidesMoneyHTTP(c))
+			if tt.want {
+				assert.Equal(t, "true", rec.Header().Get(RedactedHeader))
+			}
+
+			ctx := context.Background()
+			if tt.role != "" {
+				ctx = middleware.SetUserContext(ctx, "u1", "u1@example.com", tt.role)
+			}
+			assert.Equal(t, tt.want, redactor.hidesMoneyGRPC(ctx))
+		})
+	}
+}
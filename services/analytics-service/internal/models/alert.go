@@ -14,11 +14,18 @@ type AlertRule struct {
 	Platform    string             `bson:"platform,omitempty"` // или "all"
 	Enabled     bool               `bson:"enabled"`
 	Threshold   AlertThreshold     `bson:"threshold"`
+	// Expression составное условие (AND/OR, rate(), delta(), "for 15m"); если задано, Threshold не используется
+	Expression  string             `bson:"expression,omitempty"`
 	Severity    string             `bson:"severity"` // critical/warning/info
 	Cooldown    int                `bson:"cooldown"` // Минуты между алертами
 	LastFired   *time.Time         `bson:"last_fired,omitempty"`
+	// PendingSince с какого момента выражение выполняется непрерывно (для условия "for")
+	PendingSince *time.Time        `bson:"pending_since,omitempty"`
 }
 
+// AlertRuleTypeExpression тип правила с составным выражением
+const AlertRuleTypeExpression = "expression"
+
 // AlertThreshold порог для алерта
 type AlertThreshold struct {
 	Operator string  `bson:"operator"` // >, <, >=, <=
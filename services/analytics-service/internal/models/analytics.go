@@ -32,6 +32,13 @@ type AggregatedMetrics struct {
 	ActiveProxies    int64              `bson:"active_proxies"`
 	BannedProxies    int64              `bson:"banned_proxies"`
 	SMSBalance       float64            `bson:"sms_balance"`
+	SMSDeferred      int64              `bson:"sms_deferred"` // Покупки, отложенные до пополнения за 24ч
+	SMSDeferralRate  float64            `bson:"sms_deferral_rate"` // % отложенных покупок
+
+	// Длительность успешной регистрации за последние 24ч, сек
+	RegistrationP50Sec float64            `bson:"registration_p50_sec"`
+	RegistrationP90Sec float64            `bson:"registration_p90_sec"`
+	RegistrationP99Sec float64            `bson:"registration_p99_sec"`
 
 	// Ошибки
 	ErrorCount       int64              `bson:"error_count"`
@@ -49,6 +56,39 @@ type ErrorStat struct {
 	Count int64  `bson:"count"`
 }
 
+// SeriesMetrics числовые поля aggregated_metrics, доступные как временные ряды
+var SeriesMetrics = []string{
+	"total_accounts",
+	"ban_rate",
+	"success_rate",
+	"warming_active",
+	"warming_completed",
+	"avg_warming_days",
+	"sms_spent",
+	"proxy_spent",
+	"total_spent",
+	"active_proxies",
+	"banned_proxies",
+	"sms_balance",
+	"sms_deferred",
+	"sms_deferral_rate",
+	"registration_p50_sec",
+	"registration_p90_sec",
+	"registration_p99_sec",
+	"error_count",
+	"error_rate",
+}
+
+// IsSeriesMetric проверяет, что поле можно запрашивать как временной ряд
+func IsSeriesMetric(name string) bool {
+	for _, m := range SeriesMetrics {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
 // TimeSeriesData представляет данные временного ряда
 type TimeSeriesData struct {
 	Timestamp time.Time `bson:"timestamp"`
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ResourceCleanupReport суточный отчет сервиса о ресурсах, освобожденных при компенсации брошенных регистраций
+type ResourceCleanupReport struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Platform    string             `bson:"platform"`
+	Sessions    int64              `bson:"sessions"`  // Регистрации, прерванные по истечении SLA
+	Reclaimed   map[string]int64   `bson:"reclaimed"` // Ресурс (activation, proxy, account) -> освобождено
+	Failed      map[string]int64   `bson:"failed"`    // Ресурс -> шаги компенсации, завершившиеся ошибкой
+	PeriodStart time.Time          `bson:"period_start"`
+	PeriodEnd   time.Time          `bson:"period_end"`
+	ReceivedAt  time.Time          `bson:"received_at"`
+}
+
+// PlatformCleanup освобожденные ресурсы платформы за период
+type PlatformCleanup struct {
+	Platform  string           `json:"platform"`
+	Sessions  int64            `json:"sessions"`
+	Reclaimed map[string]int64 `json:"reclaimed"`
+	Failed    map[string]int64 `json:"failed"`
+}
+
+// ResourceCleanupSummary сводка освобожденных ресурсов по платформам
+type ResourceCleanupSummary struct {
+	Platforms   []PlatformCleanup `json:"platforms"`
+	Sessions    int64             `json:"sessions"`
+	Reclaimed   map[string]int64  `json:"reclaimed"`
+	Failed      map[string]int64  `json:"failed"`
+	PeriodStart time.Time         `json:"period_start"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
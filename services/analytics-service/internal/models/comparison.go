@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ComparedPlatforms платформы, которые сравниваются на дашборде
+var ComparedPlatforms = []string{"vk", "telegram", "mail", "max"}
+
+// PlatformKPIs ключевые показатели платформы за период
+type PlatformKPIs struct {
+	SuccessRate     float64 `json:"success_rate"`     // Средний за период, %
+	BanRate         float64 `json:"ban_rate"`         // Средний за период, %
+	CostPerAccount  float64 `json:"cost_per_account"` // Расходы за период на созданный за период аккаунт
+	AvgWarmingDays  float64 `json:"avg_warming_days"` // Средний за период
+	AccountsCreated int64   `json:"accounts_created"` // Прирост аккаунтов за период
+	TotalSpent      float64 `json:"total_spent"`      // Прирост расходов за период
+	Samples         int     `json:"samples"`          // Снимков агрегированных метрик в периоде
+}
+
+// PlatformKPIDelta изменение показателей относительно предыдущего периода той же длины.
+// Для долей в процентах — в процентных пунктах.
+type PlatformKPIDelta struct {
+	SuccessRate     float64 `json:"success_rate"`
+	BanRate         float64 `json:"ban_rate"`
+	CostPerAccount  float64 `json:"cost_per_account"`
+	AvgWarmingDays  float64 `json:"avg_warming_days"`
+	AccountsCreated int64   `json:"accounts_created"`
+}
+
+// PlatformComparison показатели платформы за период и предыдущий период.
+// Delta отсутствует, если за один из периодов нет снимков.
+type PlatformComparison struct {
+	Platform string            `json:"platform"`
+	Current  PlatformKPIs      `json:"current"`
+	Previous PlatformKPIs      `json:"previous"`
+	Delta    *PlatformKPIDelta `json:"delta,omitempty"`
+}
+
+// PlatformComparisonReport сравнение платформ за период
+type PlatformComparisonReport struct {
+	Platforms     []PlatformComparison `json:"platforms"`
+	PeriodStart   time.Time            `json:"period_start"`
+	PeriodEnd     time.Time            `json:"period_end"`
+	PreviousStart time.Time            `json:"previous_start"`
+	GeneratedAt   time.Time            `json:"generated_at"`
+}
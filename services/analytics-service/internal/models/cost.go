@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Категории записей журнала расходов
+const (
+	CostCategoryProxy         = "proxy"
+	CostCategoryProxyPurchase = "proxy_purchase" // Оплата провайдеру при покупке, без привязки к аккаунту
+)
+
+// CostEntry запись журнала расходов, отнесенная к аккаунту
+type CostEntry struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	AccountID  string             `bson:"account_id"`
+	Category   string             `bson:"category"`
+	Provider   string             `bson:"provider"`
+	Amount     float64            `bson:"amount"`
+	Currency   string             `bson:"currency,omitempty"`
+	SourceID   string             `bson:"source_id,omitempty"` // Прокси или активация, за которую списаны деньги
+	RecordedAt time.Time          `bson:"recorded_at"`
+}
+
+// ProviderCostOutcome расходы провайдера и исходы аккаунтов, на которые они пришлись
+type ProviderCostOutcome struct {
+	Provider  string  `bson:"_id"`
+	Accounts  int64   `bson:"accounts"`
+	Surviving int64   `bson:"surviving"`
+	Banned    int64   `bson:"banned"`
+	TotalCost float64 `bson:"total_cost"`
+}
+
+// ProviderEfficiency эффективность расходов на провайдера
+type ProviderEfficiency struct {
+	Provider                string  `json:"provider"`
+	Accounts                int64   `json:"accounts"`  // Аккаунты, получившие прокси провайдера
+	Surviving               int64   `json:"surviving"` // Из них не заблокированы и не выведены из работы
+	Banned                  int64   `json:"banned"`
+	SurvivalRate            float64 `json:"survival_rate"` // %
+	TotalCost               float64 `json:"total_cost"`
+	CostPerAccount          float64 `json:"cost_per_account"`
+	CostPerSurvivingAccount float64 `json:"cost_per_surviving_account"` // 0, если выживших нет
+	EfficiencyIndex         float64 `json:"efficiency_index"`           // 100 — самая дешевая стоимость выжившего аккаунта среди провайдеров
+}
+
+// ProviderEfficiencyReport отчет об эффективности расходов по прокси-провайдерам
+type ProviderEfficiencyReport struct {
+	Providers   []ProviderEfficiency `json:"providers"`
+	PeriodStart time.Time            `json:"period_start"`
+	GeneratedAt time.Time            `json:"generated_at"`
+}
+
+// AccountCost расходы, отнесенные к аккаунту, за все время
+type AccountCost struct {
+	AccountID  string             `json:"account_id"`
+	Total      float64            `json:"total"`
+	Currency   string             `json:"currency,omitempty"`
+	ByCategory map[string]float64 `json:"by_category"`
+}
@@ -34,6 +34,29 @@ type ExpenseForecast struct {
 	UpperBound    float64            `bson:"upper_bound"` // 95% CI
 	LowerBound    float64            `bson:"lower_bound"`
 	Breakdown     map[string]float64 `bson:"breakdown"` // sms/proxy
+	DailyRate     float64            `bson:"daily_rate"` // Прогнозируемый расход в сутки
+}
+
+// BudgetBurnDown сгорание месячного бюджета с прогнозом даты исчерпания
+type BudgetBurnDown struct {
+	MonthlyBudget      float64       `json:"monthly_budget"`
+	Spent              float64       `json:"spent"`
+	Remaining          float64       `json:"remaining"`
+	ActualDailyRate    float64       `json:"actual_daily_rate"`    // Средний расход в сутки с начала месяца
+	ProjectedDailyRate float64       `json:"projected_daily_rate"` // Из прогноза расходов
+	ProjectedMonthEnd  float64       `json:"projected_month_end"`  // Ожидаемые расходы за месяц
+	ExhaustionDate     *time.Time    `json:"exhaustion_date,omitempty"`
+	MonthEnd           time.Time     `json:"month_end"`
+	OverrunDays        float64       `json:"overrun_days"` // На сколько дней раньше конца месяца закончится бюджет
+	Days               []BurnDownDay `json:"days"`
+}
+
+// BurnDownDay точка графика сгорания бюджета
+type BurnDownDay struct {
+	Date      time.Time `json:"date"`
+	Spent     float64   `json:"spent"`
+	Remaining float64   `json:"remaining"`
+	Projected bool      `json:"projected"`
 }
 
 // ReadinessForecast прогноз готовности аккаунтов
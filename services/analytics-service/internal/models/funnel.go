@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// FunnelStage этап воронки аккаунтов
+type FunnelStage string
+
+const (
+	FunnelCreated FunnelStage = "created"
+	FunnelWarmed  FunnelStage = "warmed"
+	FunnelReady   FunnelStage = "ready"
+	FunnelBanned  FunnelStage = "banned"
+)
+
+// FunnelStages этапы воронки по порядку
+var FunnelStages = []FunnelStage{FunnelCreated, FunnelWarmed, FunnelReady, FunnelBanned}
+
+// Измерения, по которым группируются когорты воронки
+const (
+	FunnelGroupWeek          = "week"
+	FunnelGroupPlatform      = "platform"
+	FunnelGroupProxyProvider = "proxy_provider"
+)
+
+// FunnelGroupings допустимые измерения группировки когорт
+var FunnelGroupings = []string{FunnelGroupWeek, FunnelGroupPlatform, FunnelGroupProxyProvider}
+
+// IsFunnelGrouping проверяет, что по измерению можно группировать когорты
+func IsFunnelGrouping(group string) bool {
+	for _, g := range FunnelGroupings {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountProxyProvider провайдер первого прокси, выданного аккаунту
+type AccountProxyProvider struct {
+	AccountID   string    `bson:"account_id"`
+	Provider    string    `bson:"provider"`
+	AllocatedAt time.Time `bson:"allocated_at"`
+}
+
+// FunnelAccount моменты прохождения этапов воронки одним аккаунтом
+type FunnelAccount struct {
+	AccountID     string     `bson:"account_id"`
+	Platform      string     `bson:"platform"`
+	ProxyProvider string     `bson:"proxy_provider"`
+	CohortWeek    time.Time  `bson:"cohort_week"`
+	CreatedAt     time.Time  `bson:"registered_at"`
+	WarmedAt      *time.Time `bson:"warmed_at,omitempty"`
+	ReadyAt       *time.Time `bson:"ready_at,omitempty"`
+	BannedAt      *time.Time `bson:"banned_at,omitempty"`
+}
+
+// FunnelFilter параметры построения воронки
+type FunnelFilter struct {
+	Platform      string
+	ProxyProvider string
+	Since         time.Time
+	GroupBy       []string
+}
+
+// FunnelStageStats показатели этапа воронки в когорте
+type FunnelStageStats struct {
+	Stage          FunnelStage `json:"stage"`
+	Accounts       int64       `json:"accounts"`
+	ConversionRate float64     `json:"conversion_rate"` // Доля от предыдущего этапа, %
+	OverallRate    float64     `json:"overall_rate"`    // Доля от созданных, %
+	MedianHours    float64     `json:"median_hours"`    // Медиана времени на этапе до перехода на следующий
+	Samples        int64       `json:"samples"`         // Переходов, по которым посчитана медиана
+}
+
+// FunnelCohort воронка одной когорты. Незадействованные в группировке измерения равны "all".
+type FunnelCohort struct {
+	Week              string             `json:"week"` // Понедельник недели создания, YYYY-MM-DD
+	Platform          string             `json:"platform"`
+	ProxyProvider     string             `json:"proxy_provider"`
+	Stages            []FunnelStageStats `json:"stages"`
+	BannedBeforeReady int64              `json:"banned_before_ready"`
+}
+
+// FunnelReport отчет по воронке аккаунтов created → warmed → ready → banned
+type FunnelReport struct {
+	Platform    string         `json:"platform"`
+	GroupBy     []string       `json:"group_by"`
+	Total       FunnelCohort   `json:"total"`
+	Cohorts     []FunnelCohort `json:"cohorts"`
+	PeriodStart time.Time      `json:"period_start"`
+	GeneratedAt time.Time      `json:"generated_at"`
+}
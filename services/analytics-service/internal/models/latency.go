@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RegistrationStepTotal шаг, которым платформы публикуют полное время регистрации
+const RegistrationStepTotal = "total"
+
+// RegistrationStepSample длительность одного шага регистрации из события registration.step_completed
+type RegistrationStepSample struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Platform    string             `bson:"platform"`
+	AccountID   string             `bson:"account_id"`
+	Step        string             `bson:"step"`
+	DurationSec float64            `bson:"duration_sec"`
+	RecordedAt  time.Time          `bson:"recorded_at"`
+}
+
+// LatencyPercentiles распределение длительности шага (в секундах)
+type LatencyPercentiles struct {
+	Step    string  `json:"step"`
+	Samples int64   `json:"samples"`
+	P50Sec  float64 `json:"p50_sec"`
+	P90Sec  float64 `json:"p90_sec"`
+	P99Sec  float64 `json:"p99_sec"`
+}
+
+// PlatformRegistrationLatency перцентили длительности регистрации платформы
+type PlatformRegistrationLatency struct {
+	Platform string               `json:"platform"`
+	EndToEnd LatencyPercentiles   `json:"end_to_end"` // Только успешные регистрации
+	Steps    []LatencyPercentiles `json:"steps"`      // VK публикует только полное время
+}
+
+// RegistrationLatencyReport перцентили длительности регистраций по платформам
+type RegistrationLatencyReport struct {
+	Platforms   []PlatformRegistrationLatency `json:"platforms"`
+	PeriodStart time.Time                     `json:"period_start"`
+	GeneratedAt time.Time                     `json:"generated_at"`
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LifecycleState каноническое состояние жизненного цикла аккаунта
+type LifecycleState string
+
+const (
+	LifecycleRegistered LifecycleState = "registered"
+	LifecycleEnriched   LifecycleState = "enriched"
+	LifecycleWarming    LifecycleState = "warming"
+	LifecycleReady      LifecycleState = "ready"
+	LifecycleInUse      LifecycleState = "in_use"
+	LifecycleRestricted LifecycleState = "restricted"
+	LifecycleBanned     LifecycleState = "banned"
+	LifecycleRetired    LifecycleState = "retired"
+)
+
+// LifecycleStates все состояния в каноническом порядке
+var LifecycleStates = []LifecycleState{
+	LifecycleRegistered,
+	LifecycleEnriched,
+	LifecycleWarming,
+	LifecycleReady,
+	LifecycleInUse,
+	LifecycleRestricted,
+	LifecycleBanned,
+	LifecycleRetired,
+}
+
+// IsTerminal возвращает true для конечных состояний
+func (s LifecycleState) IsTerminal() bool {
+	return s == LifecycleBanned || s == LifecycleRetired
+}
+
+// AccountLifecycle текущее состояние аккаунта в жизненном цикле
+type AccountLifecycle struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	AccountID      string             `bson:"account_id"`
+	Platform       string             `bson:"platform"`
+	State          LifecycleState     `bson:"state"`
+	StateEnteredAt time.Time          `bson:"state_entered_at"`
+	RegisteredAt   time.Time          `bson:"registered_at"`
+	LastEvent      string             `bson:"last_event"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+// LifecycleTransition переход между состояниями с длительностью пребывания в исходном
+type LifecycleTransition struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	AccountID   string             `bson:"account_id"`
+	Platform    string             `bson:"platform"`
+	FromState   LifecycleState     `bson:"from_state"`
+	ToState     LifecycleState     `bson:"to_state"`
+	EnteredAt   time.Time          `bson:"entered_at"` // Когда аккаунт вошел в FromState
+	LeftAt      time.Time          `bson:"left_at"`
+	DurationSec float64            `bson:"duration_sec"`
+	Event       string             `bson:"event"`
+}
+
+// StateDurationStats распределение длительности пребывания в состоянии
+type StateDurationStats struct {
+	State    LifecycleState `json:"state"`
+	Samples  int64          `json:"samples"`
+	AvgHours float64        `json:"avg_hours"`
+	P50Hours float64        `json:"p50_hours"`
+	P90Hours float64        `json:"p90_hours"`
+	MaxHours float64        `json:"max_hours"`
+}
+
+// AppealOutcome итог апелляции на блокировку аккаунта
+type AppealOutcome struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	AccountID     string             `bson:"account_id"`
+	Platform      string             `bson:"platform"`
+	Outcome       string             `bson:"outcome"`
+	PhoneSource   string             `bson:"phone_source,omitempty"`
+	DurationHours float64            `bson:"duration_hours"`
+	ResolvedAt    time.Time          `bson:"resolved_at"`
+}
+
+// LifecycleReport отчет по жизненному циклу аккаунтов
+type LifecycleReport struct {
+	Platform          string               `json:"platform"`
+	StateCounts       map[string]int64     `json:"state_counts"`
+	Durations         []StateDurationStats `json:"durations"`
+	AppealOutcomes    map[string]int64     `json:"appeal_outcomes,omitempty"`
+	AppealRestoreRate float64              `json:"appeal_restore_rate"` // Доля одобренных апелляций, %
+	PeriodStart       time.Time            `json:"period_start"`
+	GeneratedAt       time.Time            `json:"generated_at"`
+}
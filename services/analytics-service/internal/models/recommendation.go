@@ -39,6 +39,9 @@ type ProviderRank struct {
 	AvgLatency   float64 `bson:"avg_latency"`
 	BanRate      float64 `bson:"ban_rate"`
 	CostPerAccount float64 `bson:"cost_per_account"`
+	SurvivalRate float64 `bson:"survival_rate,omitempty"` // По журналу расходов, 0 при малой выборке
+	CostPerSurvivingAccount float64 `bson:"cost_per_surviving_account,omitempty"`
+	EfficiencyIndex float64 `bson:"efficiency_index,omitempty"` // 0-100, 100 = самый дешевый выживший аккаунт
 	Recommendation string `bson:"recommendation"` // use/avoid/monitor
 }
 
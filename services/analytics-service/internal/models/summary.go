@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Генераторы текста сводки
+const (
+	SummaryGeneratorTemplate = "template"
+	SummaryGeneratorLLM      = "llm"
+)
+
+// DailySummary текстовая сводка за сутки вместе с метриками, по которым она составлена
+type DailySummary struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Date        string               `bson:"date" json:"date"` // YYYY-MM-DD в часовом поясе отчетов
+	PeriodStart time.Time            `bson:"period_start" json:"period_start"`
+	PeriodEnd   time.Time            `bson:"period_end" json:"period_end"`
+	Text        string               `bson:"text" json:"text"`
+	Generator   string               `bson:"generator" json:"generator"` // template или llm
+	Metrics     DailySummaryMetrics  `bson:"metrics" json:"metrics"`
+	Previous    *DailySummaryMetrics `bson:"previous,omitempty" json:"previous,omitempty"` // Предыдущие сутки для сравнения
+	GeneratedAt time.Time            `bson:"generated_at" json:"generated_at"`
+}
+
+// DailySummaryMetrics метрики суток по снимкам aggregated_metrics платформы all
+type DailySummaryMetrics struct {
+	Snapshots       int         `bson:"snapshots" json:"snapshots"`
+	TotalAccounts   int64       `bson:"total_accounts" json:"total_accounts"`
+	AccountsCreated int64       `bson:"accounts_created" json:"accounts_created"`
+	AccountsReady   int64       `bson:"accounts_ready" json:"accounts_ready"`
+	AccountsBanned  int64       `bson:"accounts_banned" json:"accounts_banned"`
+	BanRate         float64     `bson:"ban_rate" json:"ban_rate"`         // %
+	SuccessRate     float64     `bson:"success_rate" json:"success_rate"` // %
+	ErrorRate       float64     `bson:"error_rate" json:"error_rate"`     // %
+	TopErrors       []ErrorStat `bson:"top_errors,omitempty" json:"top_errors,omitempty"`
+	WarmingActive   int64       `bson:"warming_active" json:"warming_active"`
+	ActiveProxies   int64       `bson:"active_proxies" json:"active_proxies"`
+	BannedProxies   int64       `bson:"banned_proxies" json:"banned_proxies"`
+	TotalSpent      float64     `bson:"total_spent" json:"total_spent"`
+	SMSSpent        float64     `bson:"sms_spent" json:"sms_spent"`
+	ProxySpent      float64     `bson:"proxy_spent" json:"proxy_spent"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// Измерения, по которым группируются кривые выживаемости
+const (
+	SurvivalGroupPlatform      = "platform"
+	SurvivalGroupScenario      = "scenario"
+	SurvivalGroupProxyProvider = "proxy_provider"
+)
+
+// SurvivalGroupings допустимые измерения группировки кривых выживаемости
+var SurvivalGroupings = []string{SurvivalGroupPlatform, SurvivalGroupScenario, SurvivalGroupProxyProvider}
+
+// IsSurvivalGrouping проверяет, что по измерению можно группировать кривые
+func IsSurvivalGrouping(group string) bool {
+	for _, g := range SurvivalGroupings {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountWarmingScenario сценарий первого прогрева аккаунта
+type AccountWarmingScenario struct {
+	AccountID string    `bson:"account_id"`
+	Platform  string    `bson:"platform"`
+	Scenario  string    `bson:"scenario"`
+	StartedAt time.Time `bson:"started_at"`
+}
+
+// SurvivalAccount срок жизни аккаунта от регистрации до блокировки.
+// Аккаунт без блокировки цензурируется моментом вывода из оборота или текущим временем.
+type SurvivalAccount struct {
+	AccountID     string     `bson:"account_id"`
+	Platform      string     `bson:"platform"`
+	Scenario      string     `bson:"scenario"`
+	ProxyProvider string     `bson:"proxy_provider"`
+	RegisteredAt  time.Time  `bson:"registered_at"`
+	BannedAt      *time.Time `bson:"banned_at,omitempty"`
+	RetiredAt     *time.Time `bson:"retired_at,omitempty"`
+}
+
+// SurvivalFilter параметры построения кривых выживаемости
+type SurvivalFilter struct {
+	Platform      string
+	Scenario      string
+	ProxyProvider string
+	Since         time.Time
+	GroupBy       []string
+}
+
+// SurvivalPoint точка кривой Каплана-Мейера: день жизни, в который были блокировки или выбывания
+type SurvivalPoint struct {
+	Day      int     `json:"day"`
+	AtRisk   int64   `json:"at_risk"`  // Аккаунтов, доживших до начала дня
+	Banned   int64   `json:"banned"`   // Заблокировано в этот день
+	Censored int64   `json:"censored"` // Выбыло без блокировки: выведено из оборота или наблюдение закончилось
+	Survival float64 `json:"survival"` // Доля не заблокированных к концу дня, 0-1
+}
+
+// SurvivalCurve кривая выживаемости одной когорты. Незадействованные в группировке измерения равны "all".
+type SurvivalCurve struct {
+	Platform      string          `json:"platform"`
+	Scenario      string          `json:"scenario"`
+	ProxyProvider string          `json:"proxy_provider"`
+	Accounts      int64           `json:"accounts"`
+	Banned        int64           `json:"banned"`
+	MedianDays    *int            `json:"median_days"` // День, к концу которого заблокирована половина; null, если не достигнут
+	Survival7d    float64         `json:"survival_7d"`
+	Survival30d   float64         `json:"survival_30d"`
+	Points        []SurvivalPoint `json:"points"`
+}
+
+// SurvivalReport кривые выживаемости аккаунтов по когортам
+type SurvivalReport struct {
+	Platform    string          `json:"platform"`
+	GroupBy     []string        `json:"group_by"`
+	Total       SurvivalCurve   `json:"total"`
+	Curves      []SurvivalCurve `json:"curves"`
+	PeriodStart time.Time       `json:"period_start"`
+	GeneratedAt time.Time       `json:"generated_at"`
+}
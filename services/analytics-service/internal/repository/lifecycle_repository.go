@@ -0,0 +1,556 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LifecycleRepository репозиторий состояний жизненного цикла аккаунтов
+type LifecycleRepository struct {
+	statesCollection      *mongo.Collection
+	transitionsCollection *mongo.Collection
+	appealsCollection     *mongo.Collection
+	proxyCollection       *mongo.Collection
+	costCollection        *mongo.Collection
+	cleanupCollection     *mongo.Collection
+	stepsCollection       *mongo.Collection
+	scenarioCollection    *mongo.Collection
+}
+
+// NewLifecycleRepository создает новый репозиторий жизненного цикла
+func NewLifecycleRepository(db *mongo.Database) *LifecycleRepository {
+	return &LifecycleRepository{
+		statesCollection:      db.Collection("account_lifecycles"),
+		transitionsCollection: db.Collection("lifecycle_transitions"),
+		appealsCollection:     db.Collection("account_appeal_outcomes"),
+		proxyCollection:       db.Collection("account_proxy_providers"),
+		costCollection:        db.Collection("cost_ledger"),
+		cleanupCollection:     db.Collection("resource_cleanups"),
+		stepsCollection:       db.Collection("registration_steps"),
+		scenarioCollection:    db.Collection("account_warming_scenarios"),
+	}
+}
+
+// Get получает текущее состояние аккаунта.
+// Пустая платформа означает поиск только по account_id (события прокси и прогрева).
+func (r *LifecycleRepository) Get(ctx context.Context, platform, accountID string) (*models.AccountLifecycle, error) {
+	filter := bson.M{"account_id": accountID}
+	if platform != "" {
+		filter["platform"] = platform
+	}
+
+	var lifecycle models.AccountLifecycle
+	err := r.statesCollection.FindOne(ctx, filter).Decode(&lifecycle)
+	if err != nil {
+		return nil, err
+	}
+	return &lifecycle, nil
+}
+
+// Upsert сохраняет текущее состояние аккаунта
+func (r *LifecycleRepository) Upsert(ctx context.Context, lifecycle *models.AccountLifecycle) error {
+	lifecycle.UpdatedAt = time.Now()
+
+	filter := bson.M{
+		"platform":   lifecycle.Platform,
+		"account_id": lifecycle.AccountID,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"state":            lifecycle.State,
+			"state_entered_at": lifecycle.StateEnteredAt,
+			"last_event":       lifecycle.LastEvent,
+			"updated_at":       lifecycle.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"registered_at": lifecycle.RegisteredAt,
+		},
+	}
+
+	_, err := r.statesCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// SaveTransition сохраняет переход между состояниями
+func (r *LifecycleRepository) SaveTransition(ctx context.Context, transition *models.LifecycleTransition) error {
+	transition.ID = primitive.NewObjectID()
+	_, err := r.transitionsCollection.InsertOne(ctx, transition)
+	return err
+}
+
+// CountByState подсчитывает аккаунты по текущему состоянию
+func (r *LifecycleRepository) CountByState(ctx context.Context, platform string) (map[string]int64, error) {
+	matchStage := bson.M{}
+	if platform != "" && platform != "all" {
+		matchStage["platform"] = platform
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: matchStage}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$state",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.statesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		State string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, result := range results {
+		counts[result.State] = result.Count
+	}
+
+	return counts, nil
+}
+
+// GetDurations получает длительности пребывания в состоянии за период (в секундах)
+func (r *LifecycleRepository) GetDurations(ctx context.Context, platform string, state models.LifecycleState, since time.Time) ([]float64, error) {
+	filter := bson.M{
+		"from_state": state,
+		"left_at":    bson.M{"$gte": since},
+	}
+	if platform != "" && platform != "all" {
+		filter["platform"] = platform
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{"duration_sec": 1}).
+		SetSort(bson.D{{Key: "duration_sec", Value: 1}})
+
+	cursor, err := r.transitionsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		DurationSec float64 `bson:"duration_sec"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	durations := make([]float64, 0, len(results))
+	for _, result := range results {
+		durations = append(durations, result.DurationSec)
+	}
+
+	return durations, nil
+}
+
+// SaveAppealOutcome сохраняет итог апелляции
+func (r *LifecycleRepository) SaveAppealOutcome(ctx context.Context, outcome *models.AppealOutcome) error {
+	outcome.ID = primitive.NewObjectID()
+	_, err := r.appealsCollection.InsertOne(ctx, outcome)
+	return err
+}
+
+// CountAppealOutcomes подсчитывает итоги апелляций за период
+func (r *LifecycleRepository) CountAppealOutcomes(ctx context.Context, platform string, since time.Time) (map[string]int64, error) {
+	matchStage := bson.M{"resolved_at": bson.M{"$gte": since}}
+	if platform != "" && platform != "all" {
+		matchStage["platform"] = platform
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: matchStage}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$outcome",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.appealsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Outcome string `bson:"_id"`
+		Count   int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, result := range results {
+		counts[result.Outcome] = result.Count
+	}
+
+	return counts, nil
+}
+
+// SaveProxyProvider запоминает провайдера прокси аккаунта. Учитывается первая выдача,
+// чтобы ротация не переносила аккаунт в другую когорту воронки.
+func (r *LifecycleRepository) SaveProxyProvider(ctx context.Context, record *models.AccountProxyProvider) error {
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"provider":     record.Provider,
+			"allocated_at": record.AllocatedAt,
+		},
+	}
+
+	_, err := r.proxyCollection.UpdateOne(ctx, bson.M{"account_id": record.AccountID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// SaveCostEntry добавляет запись в журнал расходов
+func (r *LifecycleRepository) SaveCostEntry(ctx context.Context, entry *models.CostEntry) error {
+	entry.ID = primitive.NewObjectID()
+	_, err := r.costCollection.InsertOne(ctx, entry)
+	return err
+}
+
+// GetAccountCosts суммирует журнал расходов по аккаунтам и категориям.
+// Аккаунты без расходов в результат не попадают.
+func (r *LifecycleRepository) GetAccountCosts(ctx context.Context, accountIDs []string) ([]models.AccountCost, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"account_id": bson.M{"$in": accountIDs}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"account_id": "$account_id", "category": "$category"},
+			"amount":   bson.M{"$sum": "$amount"},
+			"currency": bson.M{"$first": "$currency"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.account_id", Value: 1}, {Key: "_id.category", Value: 1}}}},
+	}
+
+	cursor, err := r.costCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID struct {
+			AccountID string `bson:"account_id"`
+			Category  string `bson:"category"`
+		} `bson:"_id"`
+		Amount   float64 `bson:"amount"`
+		Currency string  `bson:"currency"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	var costs []models.AccountCost
+	for _, row := range rows {
+		if len(costs) == 0 || costs[len(costs)-1].AccountID != row.ID.AccountID {
+			costs = append(costs, models.AccountCost{
+				AccountID:  row.ID.AccountID,
+				ByCategory: make(map[string]float64),
+			})
+		}
+		cost := &costs[len(costs)-1]
+		cost.Total += row.Amount
+		cost.ByCategory[row.ID.Category] += row.Amount
+		if cost.Currency == "" {
+			cost.Currency = row.Currency
+		}
+	}
+
+	return costs, nil
+}
+
+// GetProviderCostOutcomes сопоставляет расходы с начала периода с текущими состояниями аккаунтов.
+// Выжившим считается аккаунт, который не заблокирован и не выведен из работы.
+func (r *LifecycleRepository) GetProviderCostOutcomes(ctx context.Context, category string, since time.Time) ([]models.ProviderCostOutcome, error) {
+	lost := bson.A{models.LifecycleBanned, models.LifecycleRetired}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"category":    category,
+			"recorded_at": bson.M{"$gte": since},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":  bson.M{"provider": "$provider", "account_id": "$account_id"},
+			"cost": bson.M{"$sum": "$amount"},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.statesCollection.Name(),
+			"localField":   "_id.account_id",
+			"foreignField": "account_id",
+			"as":           "lifecycle",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"cost":  1,
+			"state": bson.M{"$first": "$lifecycle.state"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$_id.provider",
+			"accounts":   bson.M{"$sum": 1},
+			"total_cost": bson.M{"$sum": "$cost"},
+			"surviving": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$and": bson.A{
+					bson.M{"$ne": bson.A{bson.M{"$ifNull": bson.A{"$state", nil}}, nil}},
+					bson.M{"$not": bson.A{bson.M{"$in": bson.A{"$state", lost}}}},
+				}}, 1, 0,
+			}}},
+			"banned": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$state", models.LifecycleBanned}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.costCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var outcomes []models.ProviderCostOutcome
+	if err := cursor.All(ctx, &outcomes); err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// SaveCleanupReport сохраняет отчет об освобожденных ресурсах.
+// Повторно доставленный отчет за тот же период платформы заменяет прежний.
+func (r *LifecycleRepository) SaveCleanupReport(ctx context.Context, report *models.ResourceCleanupReport) error {
+	filter := bson.M{
+		"platform":     report.Platform,
+		"period_start": report.PeriodStart,
+	}
+	_, err := r.cleanupCollection.ReplaceOne(ctx, filter, report, options.Replace().SetUpsert(true))
+	return err
+}
+
+// GetCleanupReports получает отчеты об освобожденных ресурсах, закрытые после since
+func (r *LifecycleRepository) GetCleanupReports(ctx context.Context, since time.Time) ([]models.ResourceCleanupReport, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "period_end", Value: 1}})
+	cursor, err := r.cleanupCollection.Find(ctx, bson.M{"period_end": bson.M{"$gt": since}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reports []models.ResourceCleanupReport
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// GetFunnelAccounts получает моменты прохождения этапов воронки аккаунтами, созданными с filter.Since.
+// Этап считается пройденным в момент первого входа в соответствующее состояние.
+func (r *LifecycleRepository) GetFunnelAccounts(ctx context.Context, filter models.FunnelFilter) ([]models.FunnelAccount, error) {
+	matchStage := bson.M{"registered_at": bson.M{"$gte": filter.Since}}
+	if filter.Platform != "" && filter.Platform != "all" {
+		matchStage["platform"] = filter.Platform
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: matchStage}},
+		r.stageEntriesLookup(),
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.proxyCollection.Name(),
+			"localField":   "account_id",
+			"foreignField": "account_id",
+			"as":           "proxy",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"account_id":    1,
+			"platform":      1,
+			"registered_at": 1,
+			"cohort_week": bson.M{"$dateTrunc": bson.M{
+				"date":        "$registered_at",
+				"unit":        "week",
+				"startOfWeek": "monday",
+			}},
+			"proxy_provider": bson.M{"$ifNull": bson.A{bson.M{"$first": "$proxy.provider"}, "unknown"}},
+			"warmed_at":      stageEntryExpr(models.LifecycleWarming),
+			"ready_at":       stageEntryExpr(models.LifecycleReady, models.LifecycleInUse),
+			"banned_at":      stageEntryExpr(models.LifecycleBanned),
+		}}},
+	}
+	if filter.ProxyProvider != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"proxy_provider": filter.ProxyProvider}}})
+	}
+
+	cursor, err := r.statesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var accounts []models.FunnelAccount
+	if err := cursor.All(ctx, &accounts); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// GetSurvivalAccounts получает сроки жизни аккаунтов, зарегистрированных с filter.Since,
+// с провайдером первого прокси и сценарием первого прогрева
+func (r *LifecycleRepository) GetSurvivalAccounts(ctx context.Context, filter models.SurvivalFilter) ([]models.SurvivalAccount, error) {
+	matchStage := bson.M{"registered_at": bson.M{"$gte": filter.Since}}
+	if filter.Platform != "" && filter.Platform != "all" {
+		matchStage["platform"] = filter.Platform
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: matchStage}},
+		r.stageEntriesLookup(),
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.proxyCollection.Name(),
+			"localField":   "account_id",
+			"foreignField": "account_id",
+			"as":           "proxy",
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.scenarioCollection.Name(),
+			"localField":   "account_id",
+			"foreignField": "account_id",
+			"as":           "warming",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"account_id":     1,
+			"platform":       1,
+			"registered_at":  1,
+			"proxy_provider": bson.M{"$ifNull": bson.A{bson.M{"$first": "$proxy.provider"}, "unknown"}},
+			"scenario":       bson.M{"$ifNull": bson.A{bson.M{"$first": "$warming.scenario"}, "none"}},
+			"banned_at":      stageEntryExpr(models.LifecycleBanned),
+			"retired_at":     stageEntryExpr(models.LifecycleRetired),
+		}}},
+	}
+
+	postMatch := bson.M{}
+	if filter.ProxyProvider != "" {
+		postMatch["proxy_provider"] = filter.ProxyProvider
+	}
+	if filter.Scenario != "" {
+		postMatch["scenario"] = filter.Scenario
+	}
+	if len(postMatch) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: postMatch}})
+	}
+
+	cursor, err := r.statesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var accounts []models.SurvivalAccount
+	if err := cursor.All(ctx, &accounts); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// SaveWarmingScenario запоминает сценарий прогрева аккаунта. Учитывается первый прогрев,
+// чтобы воскрешение после блокировки не переносило аккаунт в другую когорту.
+func (r *LifecycleRepository) SaveWarmingScenario(ctx context.Context, record *models.AccountWarmingScenario) error {
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"platform":   record.Platform,
+			"scenario":   record.Scenario,
+			"started_at": record.StartedAt,
+		},
+	}
+
+	_, err := r.scenarioCollection.UpdateOne(ctx, bson.M{"account_id": record.AccountID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// stageEntriesLookup добавляет аккаунту первые входы в каждое состояние (поле entries)
+func (r *LifecycleRepository) stageEntriesLookup() bson.D {
+	return bson.D{{Key: "$lookup", Value: bson.M{
+		"from": r.transitionsCollection.Name(),
+		"let":  bson.M{"account_id": "$account_id", "platform": "$platform"},
+		"pipeline": bson.A{
+			bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+				bson.M{"$eq": bson.A{"$account_id", "$$account_id"}},
+				bson.M{"$eq": bson.A{"$platform", "$$platform"}},
+			}}}},
+			bson.M{"$group": bson.M{
+				"_id":        "$to_state",
+				"entered_at": bson.M{"$min": "$left_at"},
+			}},
+		},
+		"as": "entries",
+	}}}
+}
+
+// stageEntryExpr выражение первого входа аккаунта в одно из состояний.
+// Переход в начальное состояние не сохраняется, поэтому для текущего состояния берется state_entered_at.
+func stageEntryExpr(states ...models.LifecycleState) bson.M {
+	return bson.M{"$min": bson.A{
+		bson.M{"$min": bson.M{"$map": bson.M{
+			"input": bson.M{"$filter": bson.M{
+				"input": "$entries",
+				"cond":  bson.M{"$in": bson.A{"$$this._id", states}},
+			}},
+			"in": "$$this.entered_at",
+		}}},
+		bson.M{"$cond": bson.A{bson.M{"$in": bson.A{"$state", states}}, "$state_entered_at", nil}},
+	}}
+}
+
+// SaveRegistrationStep сохраняет длительность шага регистрации
+func (r *LifecycleRepository) SaveRegistrationStep(ctx context.Context, sample *models.RegistrationStepSample) error {
+	_, err := r.stepsCollection.InsertOne(ctx, sample)
+	return err
+}
+
+// GetRegistrationStepDurations получает длительности шагов регистрации за период (в секундах),
+// сгруппированные по платформе и шагу и отсортированные по возрастанию
+func (r *LifecycleRepository) GetRegistrationStepDurations(ctx context.Context, platform string, since time.Time) (map[string]map[string][]float64, error) {
+	filter := bson.M{"recorded_at": bson.M{"$gte": since}}
+	if platform != "" && platform != "all" {
+		filter["platform"] = platform
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{"platform": 1, "step": 1, "duration_sec": 1}).
+		SetSort(bson.D{{Key: "duration_sec", Value: 1}})
+
+	cursor, err := r.stepsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var samples []models.RegistrationStepSample
+	if err := cursor.All(ctx, &samples); err != nil {
+		return nil, err
+	}
+
+	durations := make(map[string]map[string][]float64)
+	for _, sample := range samples {
+		steps, ok := durations[sample.Platform]
+		if !ok {
+			steps = make(map[string][]float64)
+			durations[sample.Platform] = steps
+		}
+		steps[sample.Step] = append(steps[sample.Step], sample.DurationSec)
+	}
+
+	return durations, nil
+}
@@ -15,12 +15,14 @@ import (
 // MetricsRepository репозиторий для работы с метриками
 type MetricsRepository struct {
 	collection *mongo.Collection
+	summaries  *mongo.Collection
 }
 
 // NewMetricsRepository создает новый репозиторий метрик
 func NewMetricsRepository(db *mongo.Database) *MetricsRepository {
 	return &MetricsRepository{
 		collection: db.Collection("aggregated_metrics"),
+		summaries:  db.Collection("daily_summaries"),
 	}
 }
 
@@ -108,6 +110,77 @@ func (r *MetricsRepository) GetTimeSeriesData(ctx context.Context, metricName st
 	return data, nil
 }
 
+// GetBucketedSeries получает временной ряд метрики за период, усредненный по интервалам.
+// Пустая платформа возвращает отдельный ряд для каждой платформы
+func (r *MetricsRepository) GetBucketedSeries(ctx context.Context, metricName, platform string, start, end time.Time, bucket time.Duration) ([]models.TimeSeriesData, error) {
+	matchStage := bson.M{
+		"timestamp": bson.M{
+			"$gte": start,
+			"$lte": end,
+		},
+	}
+	if platform != "" {
+		matchStage["platform"] = platform
+	}
+
+	bucketMs := bucket.Milliseconds()
+	if bucketMs <= 0 {
+		bucketMs = time.Minute.Milliseconds()
+	}
+
+	// Округляем timestamp вниз до начала интервала
+	tsMs := bson.M{"$toLong": "$timestamp"}
+	bucketStart := bson.M{"$subtract": bson.A{tsMs, bson.M{"$mod": bson.A{tsMs, bucketMs}}}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: matchStage}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"platform": "$platform",
+				"bucket":   bucketStart,
+			},
+			"value": bson.M{"$avg": "$" + metricName},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       0,
+			"platform":  "$_id.platform",
+			"timestamp": bson.M{"$toDate": "$_id.bucket"},
+			"value":     bson.M{"$ifNull": bson.A{"$value", 0}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "platform", Value: 1}, {Key: "timestamp", Value: 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var data []models.TimeSeriesData
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// GetPlatforms получает список платформ, для которых есть метрики
+func (r *MetricsRepository) GetPlatforms(ctx context.Context) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "platform", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	platforms := make([]string, 0, len(values))
+	for _, v := range values {
+		if p, ok := v.(string); ok && p != "" {
+			platforms = append(platforms, p)
+		}
+	}
+
+	return platforms, nil
+}
+
 // GetAggregatedStats получает агрегированную статистику
 func (r *MetricsRepository) GetAggregatedStats(ctx context.Context, platform string, period time.Duration) (map[string]interface{}, error) {
 	startTime := time.Now().Add(-period)
@@ -165,3 +238,39 @@ func (r *MetricsRepository) DeleteOldMetrics(ctx context.Context, olderThan time
 	})
 	return err
 }
+
+// SaveDailySummary сохраняет сводку, повторная сводка за те же сутки заменяет прежнюю
+func (r *MetricsRepository) SaveDailySummary(ctx context.Context, summary *models.DailySummary) error {
+	if summary.ID.IsZero() {
+		summary.ID = primitive.NewObjectID()
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"period_start": summary.PeriodStart,
+			"period_end":   summary.PeriodEnd,
+			"text":         summary.Text,
+			"generator":    summary.Generator,
+			"metrics":      summary.Metrics,
+			"previous":     summary.Previous,
+			"generated_at": summary.GeneratedAt,
+		},
+		"$setOnInsert": bson.M{"_id": summary.ID},
+	}
+	_, err := r.summaries.UpdateOne(ctx, bson.M{"date": summary.Date}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetDailySummary получает сводку за сутки, nil если ее еще нет
+func (r *MetricsRepository) GetDailySummary(ctx context.Context, date string) (*models.DailySummary, error) {
+	var summary models.DailySummary
+	err := r.summaries.FindOne(ctx, bson.M{"date": date}).Decode(&summary)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"math"
 	"sync"
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
 	"github.com/grigta/conveer/services/analytics-service/internal/models"
 	"github.com/grigta/conveer/services/analytics-service/internal/repository"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
@@ -16,12 +18,17 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// analyticsEventsExchange exchange событий об обновлении данных аналитики (сбрасывает кэш gateway)
+const analyticsEventsExchange = "analytics.events"
+
 // Aggregator сервис для агрегации метрик
 type Aggregator struct {
 	promClient  *PrometheusClient
 	metricsRepo *repository.MetricsRepository
 	grpcClients map[string]*grpc.ClientConn
-	logger      *logger.Logger
+	rabbitmq    *messaging.RabbitMQ
+	lifecycle   *LifecycleTracker
+	logger      logger.Logger
 	interval    time.Duration
 }
 
@@ -30,12 +37,16 @@ func NewAggregator(
 	promClient *PrometheusClient,
 	metricsRepo *repository.MetricsRepository,
 	grpcClients map[string]*grpc.ClientConn,
-	logger *logger.Logger,
+	rabbitmq *messaging.RabbitMQ,
+	lifecycle *LifecycleTracker,
+	logger logger.Logger,
 ) *Aggregator {
 	return &Aggregator{
 		promClient:  promClient,
 		metricsRepo: metricsRepo,
 		grpcClients: grpcClients,
+		rabbitmq:    rabbitmq,
+		lifecycle:   lifecycle,
 		logger:      logger,
 		interval:    5 * time.Minute,
 	}
@@ -46,6 +57,10 @@ func (a *Aggregator) Run(ctx context.Context) {
 	ticker := time.NewTicker(a.interval)
 	defer ticker.Stop()
 
+	if err := a.rabbitmq.DeclareExchange(analyticsEventsExchange, "topic", true, false); err != nil {
+		a.logger.WithError(err).Error("Failed to declare analytics events exchange")
+	}
+
 	// Первоначальная агрегация
 	if err := a.aggregateMetrics(ctx); err != nil {
 		a.logger.WithError(err).Error("Failed initial aggregation")
@@ -109,9 +124,24 @@ func (a *Aggregator) aggregateMetrics(ctx context.Context) error {
 	}
 
 	a.logger.Info("Metrics aggregation completed successfully")
+	a.publishAggregated()
 	return nil
 }
 
+// publishAggregated сообщает подписчикам, что агрегированные метрики обновились
+func (a *Aggregator) publishAggregated() {
+	event := &models.Event{
+		Type:      "analytics.metrics.aggregated",
+		Platform:  "all",
+		Message:   "Агрегированные метрики обновлены",
+		Timestamp: time.Now(),
+	}
+
+	if err := a.rabbitmq.Publish(analyticsEventsExchange, event.Type, event); err != nil {
+		a.logger.WithError(err).Warn("Failed to publish aggregation event")
+	}
+}
+
 // aggregatePlatformMetrics агрегирует метрики для конкретной платформы
 func (a *Aggregator) aggregatePlatformMetrics(ctx context.Context, platform string) error {
 	metrics := &models.AggregatedMetrics{
@@ -204,6 +234,25 @@ func (a *Aggregator) aggregatePlatformMetrics(ctx context.Context, platform stri
 		}
 	}
 
+	// Исходы регистраций по провайдерам, которые публикует сама платформа,
+	// точнее общей статистики proxy-service для рейтинга в разрезе платформы
+	platformProviderStats, err := a.promClient.GetProviderRegistrationStats(ctx, platform)
+	if err != nil {
+		a.logger.WithError(err).WithField("platform", platform).Warn("Failed to get provider registration stats")
+	} else if len(platformProviderStats) > 0 {
+		if metrics.ProxyProviderStats == nil {
+			metrics.ProxyProviderStats = make(map[string]*models.ProxyProviderStat)
+		}
+		for provider, stat := range platformProviderStats {
+			if existing, ok := metrics.ProxyProviderStats[provider]; ok {
+				existing.SuccessRate = stat.SuccessRate
+				existing.BanRate = stat.BanRate
+			} else {
+				metrics.ProxyProviderStats[provider] = stat
+			}
+		}
+	}
+
 	// Получаем статистику сценариев прогрева из warming-service
 	if warmingClient := a.grpcClients["warming"]; warmingClient != nil {
 		client := warmingpb.NewWarmingServiceClient(warmingClient)
@@ -239,6 +288,13 @@ func (a *Aggregator) aggregatePlatformMetrics(ctx context.Context, platform stri
 		if balance, ok := smsMetrics["balance"].(float64); ok {
 			metrics.SMSBalance = balance
 		}
+		if deferred, ok := smsMetrics["deferred"].(int64); ok {
+			metrics.SMSDeferred = deferred
+		}
+		// Без запланированных покупок доля не определена (NaN)
+		if rate, ok := smsMetrics["deferral_rate"].(float64); ok && !math.IsNaN(rate) {
+			metrics.SMSDeferralRate = rate * 100
+		}
 	}
 
 	// Получаем метрики ошибок
@@ -258,6 +314,20 @@ func (a *Aggregator) aggregatePlatformMetrics(ctx context.Context, platform stri
 		}
 	}
 
+	// Перцентили полного времени регистрации по событиям шагов за последние сутки
+	latency, err := a.lifecycle.GetRegistrationLatency(ctx, platform, 1)
+	if err != nil {
+		a.logger.WithError(err).WithField("platform", platform).Warn("Failed to get registration latency")
+	} else {
+		for _, p := range latency.Platforms {
+			if p.Platform == platform {
+				metrics.RegistrationP50Sec = p.EndToEnd.P50Sec
+				metrics.RegistrationP90Sec = p.EndToEnd.P90Sec
+				metrics.RegistrationP99Sec = p.EndToEnd.P99Sec
+			}
+		}
+	}
+
 	// Сохраняем метрики в MongoDB
 	if err := a.metricsRepo.Save(ctx, metrics); err != nil {
 		return err
@@ -388,6 +458,13 @@ func (a *Aggregator) aggregateOverallMetrics(ctx context.Context) error {
 		if balance, ok := smsMetrics["balance"].(float64); ok {
 			metrics.SMSBalance = balance
 		}
+		if deferred, ok := smsMetrics["deferred"].(int64); ok {
+			metrics.SMSDeferred = deferred
+		}
+		// Без запланированных покупок доля не определена (NaN)
+		if rate, ok := smsMetrics["deferral_rate"].(float64); ok && !math.IsNaN(rate) {
+			metrics.SMSDeferralRate = rate * 100
+		}
 	}
 
 	// Получаем общие метрики ошибок
@@ -0,0 +1,503 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// alertMetrics метрики, доступные в выражениях правил (совпадают с типами простых правил)
+var alertMetrics = map[string]bool{
+	"ban_rate":          true,
+	"error_rate":        true,
+	"success_rate":      true,
+	"balance":           true,
+	"warming_duration":  true,
+	"budget":            true,
+	"budget_projection": true,
+}
+
+// alertSeriesMetrics метрики, для которых есть временной ряд в aggregated_metrics, — только для них
+// считаются rate() и delta(); budget и budget_projection вычисляются на лету
+var alertSeriesMetrics = map[string]bool{
+	"ban_rate":         true,
+	"error_rate":       true,
+	"success_rate":     true,
+	"balance":          true,
+	"warming_duration": true,
+}
+
+// AlertExpression разобранное выражение составного правила, например
+// `ban_rate > 5 AND (error_rate >= 10 OR rate(success_rate, 1h) < -20) for 15m`
+type AlertExpression struct {
+	root alertNode
+	// For сколько условие должно выполняться непрерывно, прежде чем алерт сработает
+	For time.Duration
+}
+
+// AlertOperand значение в сравнении: метрика, её изменение в процентах за окно (rate)
+// или абсолютное изменение за окно (delta)
+type AlertOperand struct {
+	Func   string // пусто, rate или delta
+	Metric string
+	Window time.Duration
+}
+
+// AlertComparison сравнение операнда с порогом
+type AlertComparison struct {
+	Operand  AlertOperand
+	Operator string
+	Value    float64
+}
+
+// AlertExpressionError ошибка разбора выражения с позицией (в символах от начала)
+type AlertExpressionError struct {
+	Pos     int
+	Message string
+}
+
+func (e *AlertExpressionError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Message)
+}
+
+type alertNode interface {
+	eval(values map[string]float64) bool
+	comparisons() []*AlertComparison
+	String() string
+}
+
+type alertBinary struct {
+	op          string // AND/OR
+	left, right alertNode
+}
+
+type alertNot struct {
+	node alertNode
+}
+
+func (n *alertBinary) eval(values map[string]float64) bool {
+	if n.op == "AND" {
+		return n.left.eval(values) && n.right.eval(values)
+	}
+	return n.left.eval(values) || n.right.eval(values)
+}
+
+func (n *alertBinary) comparisons() []*AlertComparison {
+	return append(n.left.comparisons(), n.right.comparisons()...)
+}
+
+func (n *alertBinary) String() string {
+	return "(" + n.left.String() + " " + n.op + " " + n.right.String() + ")"
+}
+
+func (n *alertNot) eval(values map[string]float64) bool {
+	return !n.node.eval(values)
+}
+
+func (n *alertNot) comparisons() []*AlertComparison {
+	return n.node.comparisons()
+}
+
+func (n *alertNot) String() string {
+	if _, ok := n.node.(*AlertComparison); ok {
+		return "NOT (" + n.node.String() + ")"
+	}
+	return "NOT " + n.node.String()
+}
+
+func (c *AlertComparison) eval(values map[string]float64) bool {
+	return compareAlertValue(values[c.Operand.Key()], c.Operator, c.Value)
+}
+
+func (c *AlertComparison) comparisons() []*AlertComparison {
+	return []*AlertComparison{c}
+}
+
+func (c *AlertComparison) String() string {
+	return c.Operand.Key() + " " + c.Operator + " " + strconv.FormatFloat(c.Value, 'f', -1, 64)
+}
+
+// Holds проверяет сравнение на значении операнда
+func (c *AlertComparison) Holds(value float64) bool {
+	return compareAlertValue(value, c.Operator, c.Value)
+}
+
+// Key каноническая запись операнда, по ней же хранятся вычисленные значения
+func (o AlertOperand) Key() string {
+	if o.Func == "" {
+		return o.Metric
+	}
+	return o.Func + "(" + o.Metric + ", " + formatAlertWindow(o.Window) + ")"
+}
+
+// Eval вычисляет выражение по значениям операндов (ключ — AlertOperand.Key)
+func (e *AlertExpression) Eval(values map[string]float64) bool {
+	return e.root.eval(values)
+}
+
+// Comparisons возвращает все сравнения выражения в порядке записи
+func (e *AlertExpression) Comparisons() []*AlertComparison {
+	return e.root.comparisons()
+}
+
+// Operands возвращает различные операнды выражения в порядке записи
+func (e *AlertExpression) Operands() []AlertOperand {
+	var operands []AlertOperand
+	seen := make(map[string]bool)
+	for _, c := range e.Comparisons() {
+		if key := c.Operand.Key(); !seen[key] {
+			seen[key] = true
+			operands = append(operands, c.Operand)
+		}
+	}
+	return operands
+}
+
+// Metrics возвращает отсортированный список метрик, на которые ссылается выражение
+func (e *AlertExpression) Metrics() []string {
+	var metrics []string
+	seen := make(map[string]bool)
+	for _, o := range e.Operands() {
+		if !seen[o.Metric] {
+			seen[o.Metric] = true
+			metrics = append(metrics, o.Metric)
+		}
+	}
+	sort.Strings(metrics)
+	return metrics
+}
+
+// String нормализованная запись выражения с явными скобками
+func (e *AlertExpression) String() string {
+	s := e.root.String()
+	if _, ok := e.root.(*alertBinary); ok {
+		s = s[1 : len(s)-1]
+	}
+	if e.For > 0 {
+		s += " for " + formatAlertWindow(e.For)
+	}
+	return s
+}
+
+func compareAlertValue(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case "==":
+		return value == threshold
+	case "!=":
+		return value != threshold
+	default:
+		return false
+	}
+}
+
+// ParseAlertExpression разбирает выражение правила. Грамматика:
+//
+//	expr       = or [ "for" duration ]
+//	or         = and { ("OR" | "||") and }
+//	and        = unary { ("AND" | "&&") unary }
+//	unary      = ("NOT" | "!") unary | "(" or ")" | comparison
+//	comparison = operand (">" | ">=" | "<" | "<=" | "==" | "!=") number
+//	operand    = metric | ("rate" | "delta") "(" metric "," duration ")"
+//
+// Ключевые слова не зависят от регистра, длительности записываются как 30s, 15m, 1h, 1d.
+func ParseAlertExpression(source string) (*AlertExpression, error) {
+	tokens, err := lexAlertExpression(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &alertParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	expr := &AlertExpression{root: root}
+	if p.peek().isKeyword("FOR") {
+		p.next()
+		duration, err := p.parseDuration()
+		if err != nil {
+			return nil, err
+		}
+		expr.For = duration
+	}
+
+	if tok := p.peek(); tok.kind != alertTokenEOF {
+		return nil, &AlertExpressionError{Pos: tok.pos, Message: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+
+	return expr, nil
+}
+
+type alertTokenKind int
+
+const (
+	alertTokenEOF alertTokenKind = iota
+	alertTokenIdent
+	alertTokenNumber
+	alertTokenOperator
+	alertTokenPunct
+)
+
+type alertToken struct {
+	kind alertTokenKind
+	text string
+	pos  int
+}
+
+func (t alertToken) isKeyword(keyword string) bool {
+	return t.kind == alertTokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func (t alertToken) is(kind alertTokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+func lexAlertExpression(source string) ([]alertToken, error) {
+	var tokens []alertToken
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, alertToken{kind: alertTokenIdent, text: string(runes[start:i]), pos: start})
+		case unicode.IsDigit(r) || r == '.' || (r == '-' && i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.')):
+			// Число может нести единицу длительности (15m, 1h), она разбирается там, где ожидается длительность
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || unicode.IsLetter(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, alertToken{kind: alertTokenNumber, text: string(runes[start:i]), pos: start})
+		case strings.ContainsRune("<>=!", r):
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "=" {
+				return nil, &AlertExpressionError{Pos: start, Message: `use "==" for equality`}
+			}
+			tokens = append(tokens, alertToken{kind: alertTokenOperator, text: op, pos: start})
+		case r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, &AlertExpressionError{Pos: i, Message: fmt.Sprintf("unexpected %q", string(r))}
+			}
+			op := "AND"
+			if r == '|' {
+				op = "OR"
+			}
+			tokens = append(tokens, alertToken{kind: alertTokenIdent, text: op, pos: i})
+			i += 2
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, alertToken{kind: alertTokenPunct, text: string(r), pos: i})
+			i++
+		default:
+			return nil, &AlertExpressionError{Pos: i, Message: fmt.Sprintf("unexpected %q", string(r))}
+		}
+	}
+
+	return append(tokens, alertToken{kind: alertTokenEOF, text: "end of expression", pos: len(runes)}), nil
+}
+
+type alertParser struct {
+	tokens []alertToken
+	pos    int
+}
+
+func (p *alertParser) peek() alertToken {
+	return p.tokens[p.pos]
+}
+
+func (p *alertParser) next() alertToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != alertTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *alertParser) expect(kind alertTokenKind, text string) error {
+	if tok := p.next(); !tok.is(kind, text) {
+		return &AlertExpressionError{Pos: tok.pos, Message: fmt.Sprintf("expected %q, got %q", text, tok.text)}
+	}
+	return nil
+}
+
+func (p *alertParser) parseOr() (alertNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &alertBinary{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *alertParser) parseAnd() (alertNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("AND") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &alertBinary{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *alertParser) parseUnary() (alertNode, error) {
+	tok := p.peek()
+	switch {
+	case tok.isKeyword("NOT") || tok.is(alertTokenOperator, "!"):
+		p.next()
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &alertNot{node: node}, nil
+	case tok.is(alertTokenPunct, "("):
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(alertTokenPunct, ")"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *alertParser) parseComparison() (alertNode, error) {
+	operand, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.next()
+	if tok.kind != alertTokenOperator || tok.text == "!" {
+		return nil, &AlertExpressionError{Pos: tok.pos, Message: fmt.Sprintf("expected comparison operator, got %q", tok.text)}
+	}
+
+	valueTok := p.next()
+	value, err := strconv.ParseFloat(valueTok.text, 64)
+	if valueTok.kind != alertTokenNumber || err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, &AlertExpressionError{Pos: valueTok.pos, Message: fmt.Sprintf("expected number, got %q", valueTok.text)}
+	}
+
+	return &AlertComparison{Operand: operand, Operator: tok.text, Value: value}, nil
+}
+
+func (p *alertParser) parseOperand() (AlertOperand, error) {
+	tok := p.next()
+	if tok.kind != alertTokenIdent {
+		return AlertOperand{}, &AlertExpressionError{Pos: tok.pos, Message: fmt.Sprintf("expected metric, got %q", tok.text)}
+	}
+
+	name := strings.ToLower(tok.text)
+	if (name == "rate" || name == "delta") && p.peek().is(alertTokenPunct, "(") {
+		p.next()
+		metricTok := p.next()
+		metric := strings.ToLower(metricTok.text)
+		if metricTok.kind != alertTokenIdent || !alertMetrics[metric] {
+			return AlertOperand{}, &AlertExpressionError{Pos: metricTok.pos, Message: fmt.Sprintf("unknown metric %q", metricTok.text)}
+		}
+		if !alertSeriesMetrics[metric] {
+			return AlertOperand{}, &AlertExpressionError{Pos: metricTok.pos, Message: fmt.Sprintf("%s() is not available for %s", name, metric)}
+		}
+		if err := p.expect(alertTokenPunct, ","); err != nil {
+			return AlertOperand{}, err
+		}
+		window, err := p.parseDuration()
+		if err != nil {
+			return AlertOperand{}, err
+		}
+		if err := p.expect(alertTokenPunct, ")"); err != nil {
+			return AlertOperand{}, err
+		}
+		return AlertOperand{Func: name, Metric: metric, Window: window}, nil
+	}
+
+	if !alertMetrics[name] {
+		return AlertOperand{}, &AlertExpressionError{Pos: tok.pos, Message: fmt.Sprintf("unknown metric %q", tok.text)}
+	}
+	return AlertOperand{Metric: name}, nil
+}
+
+func (p *alertParser) parseDuration() (time.Duration, error) {
+	tok := p.next()
+	if tok.kind != alertTokenNumber {
+		return 0, &AlertExpressionError{Pos: tok.pos, Message: fmt.Sprintf("expected duration, got %q", tok.text)}
+	}
+	duration, err := parseAlertWindow(tok.text)
+	if err != nil {
+		return 0, &AlertExpressionError{Pos: tok.pos, Message: err.Error()}
+	}
+	return duration, nil
+}
+
+// parseAlertWindow разбирает длительность; кроме единиц time.ParseDuration понимает дни (1d)
+func parseAlertWindow(text string) (time.Duration, error) {
+	var (
+		duration time.Duration
+		err      error
+	)
+	if days := strings.TrimSuffix(text, "d"); days != text {
+		var n float64
+		n, err = strconv.ParseFloat(days, 64)
+		duration = time.Duration(n * float64(24*time.Hour))
+	} else {
+		duration, err = time.ParseDuration(text)
+	}
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", text)
+	}
+	return duration, nil
+}
+
+// formatAlertWindow записывает длительность в том же виде, в каком она задается в выражении
+func formatAlertWindow(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return strconv.FormatInt(int64(d/(24*time.Hour)), 10) + "d"
+	case d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d%time.Minute == 0:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	default:
+		return d.String()
+	}
+}
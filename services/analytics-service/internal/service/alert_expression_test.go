@@ -0,0 +1,243 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlertExpression_Precedence(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"ban_rate > 5", "ban_rate > 5"},
+		{"ban_rate > 5 OR error_rate > 10 AND success_rate < 50", "ban_rate > 5 OR (error_rate > 10 AND success_rate < 50)"},
+		{"ban_rate > 5 AND error_rate > 10 OR success_rate < 50", "(ban_rate > 5 AND error_rate > 10) OR success_rate < 50"},
+		{"(ban_rate > 5 OR error_rate > 10) AND success_rate < 50", "(ban_rate > 5 OR error_rate > 10) AND success_rate < 50"},
+		{"ban_rate > 1 OR error_rate > 2 OR balance < 3", "(ban_rate > 1 OR error_rate > 2) OR balance < 3"},
+		{"NOT ban_rate > 5 AND error_rate > 10", "NOT (ban_rate > 5) AND error_rate > 10"},
+		{"NOT (ban_rate > 5 OR error_rate > 10)", "NOT (ban_rate > 5 OR error_rate > 10)"},
+		{"NOT NOT ban_rate > 5", "NOT NOT (ban_rate > 5)"},
+		{"!ban_rate > 5 || error_rate > 10 && balance <= 100", "NOT (ban_rate > 5) OR (error_rate > 10 AND balance <= 100)"},
+		{"((ban_rate > 5))", "ban_rate > 5"},
+		{"BAN_RATE > 5 and Error_Rate > 10 Or not balance < 1", "(ban_rate > 5 AND error_rate > 10) OR NOT (balance < 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expr, err := ParseAlertExpression(tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.String())
+
+			// Нормализованная запись разбирается в то же выражение
+			again, err := ParseAlertExpression(expr.String())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, again.String())
+		})
+	}
+}
+
+func TestAlertExpression_Eval(t *testing.T) {
+	tests := []struct {
+		source string
+		values map[string]float64
+		want   bool
+	}{
+		{"ban_rate > 5 OR error_rate > 10 AND success_rate < 50", map[string]float64{"ban_rate": 6}, true},
+		{"ban_rate > 5 OR error_rate > 10 AND success_rate < 50", map[string]float64{"error_rate": 20, "success_rate": 60}, false},
+		{"(ban_rate > 5 OR error_rate > 10) AND success_rate < 50", map[string]float64{"ban_rate": 6, "success_rate": 60}, false},
+		{"(ban_rate > 5 OR error_rate > 10) AND success_rate < 50", map[string]float64{"error_rate": 20, "success_rate": 40}, true},
+		{"NOT ban_rate > 5 AND error_rate > 10", map[string]float64{"ban_rate": 1, "error_rate": 20}, true},
+		{"NOT (ban_rate > 5 AND error_rate > 10)", map[string]float64{"ban_rate": 1, "error_rate": 20}, true},
+		{"NOT (ban_rate > 5 OR error_rate > 10)", map[string]float64{"ban_rate": 1, "error_rate": 20}, false},
+		{"rate(success_rate, 1h) < -20 AND delta(balance, 1d) < -100", map[string]float64{"rate(success_rate, 1h)": -25, "delta(balance, 1d)": -150}, true},
+		{"rate(success_rate, 1h) < -20 AND delta(balance, 1d) < -100", map[string]float64{"rate(success_rate, 1h)": -25, "delta(balance, 1d)": -50}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expr, err := ParseAlertExpression(tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.Eval(tt.values))
+		})
+	}
+}
+
+func TestParseAlertExpression_Comparisons(t *testing.T) {
+	tests := []struct {
+		source    string
+		operator  string
+		threshold float64
+		value     float64
+		want      bool
+	}{
+		{"ban_rate > 5", ">", 5, 5, false},
+		{"ban_rate > 5", ">", 5, 5.1, true},
+		{"ban_rate >= 5", ">=", 5, 5, true},
+		{"ban_rate >= 5", ">=", 5, 4.9, false},
+		{"ban_rate < 5", "<", 5, 5, false},
+		{"ban_rate < 5", "<", 5, 4.9, true},
+		{"ban_rate <= 5", "<=", 5, 5, true},
+		{"ban_rate <= 5", "<=", 5, 5.1, false},
+		{"balance == 100", "==", 100, 100, true},
+		{"balance == 100", "==", 100, 99, false},
+		{"balance != 100", "!=", 100, 99, true},
+		{"balance != 100", "!=", 100, 100, false},
+		{"rate(success_rate, 1h) < -20", "<", -20, -25, true},
+		{"error_rate>.5", ">", 0.5, 0.6, true},
+		{"error_rate > 1e2", ">", 100, 101, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expr, err := ParseAlertExpression(tt.source)
+			require.NoError(t, err)
+
+			comparisons := expr.Comparisons()
+			require.Len(t, comparisons, 1)
+			assert.Equal(t, tt.operator, comparisons[0].Operator)
+			assert.Equal(t, tt.threshold, comparisons[0].Value)
+			assert.Equal(t, tt.want, comparisons[0].Holds(tt.value))
+		})
+	}
+}
+
+func TestParseAlertExpression_Durations(t *testing.T) {
+	tests := []struct {
+		source  string
+		holdFor time.Duration
+		window  time.Duration
+		want    string
+	}{
+		{"ban_rate > 5", 0, 0, "ban_rate > 5"},
+		{"ban_rate > 5 for 30s", 30 * time.Second, 0, "ban_rate > 5 for 30s"},
+		{"ban_rate > 5 for 15m", 15 * time.Minute, 0, "ban_rate > 5 for 15m"},
+		{"ban_rate > 5 FOR 2h", 2 * time.Hour, 0, "ban_rate > 5 for 2h"},
+		{"ban_rate > 5 for 1h30m", 90 * time.Minute, 0, "ban_rate > 5 for 90m"},
+		{"ban_rate > 5 for 1d", 24 * time.Hour, 0, "ban_rate > 5 for 1d"},
+		{"ban_rate > 5 for 1.5d", 36 * time.Hour, 0, "ban_rate > 5 for 36h"},
+		{"ban_rate > 5 for 24h", 24 * time.Hour, 0, "ban_rate > 5 for 1d"},
+		{"rate(ban_rate, 1h) > 5", 0, time.Hour, "rate(ban_rate, 1h) > 5"},
+		{"delta(balance, 1d) < -100 for 1h", time.Hour, 24 * time.Hour, "delta(balance, 1d) < -100 for 1h"},
+		{"RATE(Ban_Rate, 7d) > 50", 0, 7 * 24 * time.Hour, "rate(ban_rate, 7d) > 50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expr, err := ParseAlertExpression(tt.source)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.holdFor, expr.For)
+			assert.Equal(t, tt.window, expr.Operands()[0].Window)
+			assert.Equal(t, tt.want, expr.String())
+		})
+	}
+}
+
+func TestAlertExpression_Operands(t *testing.T) {
+	expr, err := ParseAlertExpression("ban_rate > 5 AND (rate(ban_rate, 1h) > 50 OR ban_rate > 10) AND delta(balance, 1d) < 0")
+	require.NoError(t, err)
+
+	assert.Equal(t, []AlertOperand{
+		{Metric: "ban_rate"},
+		{Func: "rate", Metric: "ban_rate", Window: time.Hour},
+		{Func: "delta", Metric: "balance", Window: 24 * time.Hour},
+	}, expr.Operands())
+	assert.Equal(t, []string{"balance", "ban_rate"}, expr.Metrics())
+	assert.Len(t, expr.Comparisons(), 4)
+}
+
+func TestParseAlertExpression_Errors(t *testing.T) {
+	tests := []struct {
+		source  string
+		pos     int
+		message string
+	}{
+		{"", 0, `expected metric, got "end of expression"`},
+		{"ban_rate >", 10, `expected number, got "end of expression"`},
+		{"ban_rate > abc", 11, `expected number, got "abc"`},
+		{"ban_rate > 5m", 11, `expected number, got "5m"`},
+		{"ban_rate 5", 9, `expected comparison operator, got "5"`},
+		{"ban_rate = 5", 9, `use "==" for equality`},
+		{"ban_rate > 5 AND", 16, `expected metric, got "end of expression"`},
+		{"ban_rate > 5 & error_rate > 1", 13, `unexpected "&"`},
+		{"ban_rate > 5 # note", 13, `unexpected "#"`},
+		{"ban_rate > 5 error_rate > 1", 13, `unexpected "error_rate"`},
+		{"(ban_rate > 5", 13, `expected ")", got "end of expression"`},
+		{"ban_rate > 5)", 12, `unexpected ")"`},
+		{"cpu > 5", 0, `unknown metric "cpu"`},
+		{"ban_rate > 5 AND NOT ошибки > 1", 21, `unknown metric "ошибки"`},
+		{"rate(cpu, 1h) > 5", 5, `unknown metric "cpu"`},
+		{"rate(budget, 1h) > 5", 5, `rate() is not available for budget`},
+		{"delta(budget_projection, 1h) > 5", 6, `delta() is not available for budget_projection`},
+		{"rate(ban_rate 1h) > 5", 14, `expected ",", got "1h"`},
+		{"rate(ban_rate, 1h > 5", 18, `expected ")", got ">"`},
+		{"rate(ban_rate, 0m) > 5", 15, `invalid duration "0m"`},
+		{"rate(ban_rate, hour) > 5", 15, `expected duration, got "hour"`},
+		{"ban_rate > 5 for 15", 17, `invalid duration "15"`},
+		{"ban_rate > 5 for -1h", 17, `invalid duration "-1h"`},
+		{"ban_rate > 5 for soon", 17, `expected duration, got "soon"`},
+		{"ban_rate > 5 for", 16, `expected duration, got "end of expression"`},
+		{"ban_rate > 5 for 1h AND error_rate > 1", 20, `unexpected "AND"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := ParseAlertExpression(tt.source)
+			require.Error(t, err)
+
+			var exprErr *AlertExpressionError
+			require.True(t, errors.As(err, &exprErr), err)
+			assert.Equal(t, tt.pos, exprErr.Pos)
+			assert.Equal(t, tt.message, exprErr.Message)
+		})
+	}
+}
+
+func TestWindowOperandValue(t *testing.T) {
+	point := func(platform string, banRate, balance float64) models.AggregatedMetrics {
+		return models.AggregatedMetrics{Platform: platform, BanRate: banRate, SMSBalance: balance}
+	}
+	rate := AlertOperand{Func: "rate", Metric: "ban_rate", Window: time.Hour}
+	delta := AlertOperand{Func: "delta", Metric: "balance", Window: 24 * time.Hour}
+
+	tests := []struct {
+		name     string
+		operand  AlertOperand
+		platform string
+		series   []models.AggregatedMetrics
+		want     float64
+		err      string
+	}{
+		{"rate without series", rate, "vk", nil, 0, "not enough ban_rate metrics of vk over 1h"},
+		{"rate over one point", rate, "vk", []models.AggregatedMetrics{point("vk", 10, 0)}, 0, "not enough ban_rate metrics of vk over 1h"},
+		{"rate over other platforms", rate, "vk", []models.AggregatedMetrics{point("all", 10, 0), point("mail", 15, 0)}, 0, "not enough ban_rate metrics of vk over 1h"},
+		{"delta over one point", delta, "", []models.AggregatedMetrics{point("all", 0, 500)}, 0, "not enough balance metrics of all over 1d"},
+		{"rate growth", rate, "vk", []models.AggregatedMetrics{point("vk", 10, 0), point("vk", 12, 0), point("vk", 15, 0)}, 50, ""},
+		{"rate of all platforms", rate, "", []models.AggregatedMetrics{point("all", 20, 0), point("vk", 1, 0), point("all", 10, 0)}, -50, ""},
+		{"rate from negative", AlertOperand{Func: "rate", Metric: "balance", Window: time.Hour}, "vk", []models.AggregatedMetrics{point("vk", 0, -10), point("vk", 0, -5)}, 50, ""},
+		{"rate from zero to zero", rate, "vk", []models.AggregatedMetrics{point("vk", 0, 0), point("vk", 0, 0)}, 0, ""},
+		{"rate from zero", rate, "vk", []models.AggregatedMetrics{point("vk", 0, 0), point("vk", 5, 0)}, 0, "rate(ban_rate, 1h) is undefined: ban_rate was 0 at the start of the window"},
+		{"delta from zero", delta, "vk", []models.AggregatedMetrics{point("vk", 0, 0), point("vk", 0, 250)}, 250, ""},
+		{"delta uses window ends", delta, "vk", []models.AggregatedMetrics{point("vk", 0, 1000), point("vk", 0, 100), point("vk", 0, 700)}, -300, ""},
+		{"unknown series metric", AlertOperand{Func: "delta", Metric: "budget", Window: time.Hour}, "vk", []models.AggregatedMetrics{point("vk", 0, 0)}, 0, "unknown metric type: budget"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := windowOperandValue(tt.operand, tt.platform, tt.series)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, value, 1e-9)
+		})
+	}
+}
@@ -3,23 +3,32 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
+	sharedmodels "github.com/grigta/conveer/pkg/models"
 	"github.com/grigta/conveer/services/analytics-service/internal/models"
 	"github.com/grigta/conveer/services/analytics-service/internal/repository"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ErrInvalidAlertRule правило алерта с неизвестной метрикой, оператором или ошибкой в выражении
+var ErrInvalidAlertRule = errors.New("invalid alert rule")
+
 // AlertManager менеджер алертов
 type AlertManager struct {
 	alertRepo      *repository.AlertRepository
 	metricsRepo    *repository.MetricsRepository
 	rabbitmq       *messaging.RabbitMQ
-	logger         *logger.Logger
+	preferences    *PreferencesClient
+	forecaster     *Forecaster
+	webhooks       *WebhookSink
+	logger         logger.Logger
 	interval       time.Duration
 	monthlyBudget  float64
 	budgetPeriod   time.Duration
@@ -30,7 +39,10 @@ func NewAlertManager(
 	alertRepo *repository.AlertRepository,
 	metricsRepo *repository.MetricsRepository,
 	rabbitmq *messaging.RabbitMQ,
-	logger *logger.Logger,
+	preferences *PreferencesClient,
+	forecaster *Forecaster,
+	webhooks *WebhookSink,
+	logger logger.Logger,
 	monthlyBudget float64,
 	budgetPeriod time.Duration,
 ) *AlertManager {
@@ -38,6 +50,9 @@ func NewAlertManager(
 		alertRepo:     alertRepo,
 		metricsRepo:   metricsRepo,
 		rabbitmq:      rabbitmq,
+		preferences:   preferences,
+		forecaster:    forecaster,
+		webhooks:      webhooks,
 		logger:        logger,
 		interval:      1 * time.Minute,
 		monthlyBudget: monthlyBudget,
@@ -90,24 +105,24 @@ func (a *AlertManager) checkAlerts(ctx context.Context) error {
 			continue
 		}
 
-		// Получаем текущее значение метрики
-		currentValue, err := a.getCurrentMetricValue(ctx, rule)
+		// Проверяем условие: простой порог или составное выражение
+		match, err := a.evaluateRule(ctx, &rule)
 		if err != nil {
 			a.logger.WithError(err).WithField("rule", rule.Name).Error("Failed to get metric value")
 			continue
 		}
 
-		// Проверяем условие
-		if a.evaluateCondition(currentValue, rule.Threshold) {
+		if match != nil {
+			currentValue := match.value
 			// Создаем событие алерта
 			alert := &models.AlertEvent{
 				RuleID:       rule.ID,
 				RuleName:     rule.Name,
 				Severity:     rule.Severity,
 				Platform:     rule.Platform,
-				Message:      a.generateAlertMessage(rule, currentValue),
+				Message:      match.message,
 				CurrentValue: currentValue,
-				Threshold:    rule.Threshold.Value,
+				Threshold:    match.threshold,
 				FiredAt:      time.Now(),
 				Acknowledged: false,
 			}
@@ -123,6 +138,13 @@ func (a *AlertManager) checkAlerts(ctx context.Context) error {
 				a.logger.WithError(err).Error("Failed to publish alert event")
 			}
 
+			// Email получают только подписанные на эту severity пользователи
+			a.notifyByEmail(ctx, alert)
+
+			if a.webhooks != nil {
+				a.webhooks.SendAlert(alert)
+			}
+
 			// Обновляем LastFired
 			now := time.Now()
 			rule.LastFired = &now
@@ -138,7 +160,7 @@ func (a *AlertManager) checkAlerts(ctx context.Context) error {
 				"rule":     rule.Name,
 				"severity": rule.Severity,
 				"value":    currentValue,
-				"threshold": rule.Threshold.Value,
+				"threshold": match.threshold,
 			}).Warn("Alert fired")
 		}
 	}
@@ -151,15 +173,48 @@ func (a *AlertManager) checkAlerts(ctx context.Context) error {
 	return nil
 }
 
+// alertMatch сработавшее правило: значение и порог для события и текст алерта
+type alertMatch struct {
+	value     float64
+	threshold float64
+	message   string
+}
+
+// evaluateRule проверяет правило и возвращает nil, если алерт не должен срабатывать
+func (a *AlertManager) evaluateRule(ctx context.Context, rule *models.AlertRule) (*alertMatch, error) {
+	if rule.Expression != "" {
+		return a.evaluateExpressionRule(ctx, rule)
+	}
+
+	currentValue, err := a.getCurrentMetricValue(ctx, *rule)
+	if err != nil {
+		return nil, err
+	}
+	if !a.evaluateCondition(currentValue, rule.Threshold) {
+		return nil, nil
+	}
+
+	return &alertMatch{
+		value:     currentValue,
+		threshold: rule.Threshold.Value,
+		message:   a.generateAlertMessage(*rule, currentValue),
+	}, nil
+}
+
 // getCurrentMetricValue получает текущее значение метрики для правила
 func (a *AlertManager) getCurrentMetricValue(ctx context.Context, rule models.AlertRule) (float64, error) {
+	return a.getMetricValue(ctx, rule.Type, rule.Platform)
+}
+
+// getMetricValue получает текущее значение метрики платформы
+func (a *AlertManager) getMetricValue(ctx context.Context, metric, platform string) (float64, error) {
 	// Получаем последние метрики
-	metrics, err := a.metricsRepo.GetLatest(ctx, rule.Platform)
+	metrics, err := a.metricsRepo.GetLatest(ctx, platform)
 	if err != nil {
 		return 0, err
 	}
 
-	switch rule.Type {
+	switch metric {
 	case "ban_rate":
 		return metrics.BanRate, nil
 	case "error_rate":
@@ -169,7 +224,7 @@ func (a *AlertManager) getCurrentMetricValue(ctx context.Context, rule models.Al
 		if a.monthlyBudget > 0 {
 			// Получаем расходы за период бюджета
 			startTime := time.Now().Add(-a.budgetPeriod)
-			periodMetrics, err := a.metricsRepo.GetByTimeRange(ctx, rule.Platform, startTime, time.Now())
+			periodMetrics, err := a.metricsRepo.GetByTimeRange(ctx, platform, startTime, time.Now())
 			if err != nil {
 				return 0, err
 			}
@@ -182,6 +237,13 @@ func (a *AlertManager) getCurrentMetricValue(ctx context.Context, rule models.Al
 			return (totalSpent / a.monthlyBudget) * 100, nil
 		}
 		return 0, nil
+	case "budget_projection":
+		// Сколько дней до конца месяца останется после прогнозного исчерпания бюджета
+		burnDown, err := a.GetBudgetBurnDown(ctx)
+		if err != nil || burnDown == nil {
+			return 0, err
+		}
+		return burnDown.OverrunDays, nil
 	case "balance":
 		return metrics.SMSBalance, nil
 	case "success_rate":
@@ -189,27 +251,167 @@ func (a *AlertManager) getCurrentMetricValue(ctx context.Context, rule models.Al
 	case "warming_duration":
 		return metrics.AvgWarmingDays, nil
 	default:
-		return 0, fmt.Errorf("unknown metric type: %s", rule.Type)
+		return 0, fmt.Errorf("unknown metric type: %s", metric)
 	}
 }
 
 // evaluateCondition проверяет условие алерта
 func (a *AlertManager) evaluateCondition(value float64, threshold models.AlertThreshold) bool {
-	switch threshold.Operator {
-	case ">":
-		return value > threshold.Value
-	case ">=":
-		return value >= threshold.Value
-	case "<":
-		return value < threshold.Value
-	case "<=":
-		return value <= threshold.Value
-	case "==":
-		return value == threshold.Value
-	case "!=":
-		return value != threshold.Value
+	return compareAlertValue(value, threshold.Operator, threshold.Value)
+}
+
+// evaluateExpressionRule проверяет правило с составным выражением. Условие "for" выдерживается
+// через pending_since: впервые выполненное выражение только запоминается, алерт срабатывает,
+// когда оно держится не меньше заданного срока; невыполненное выражение сбрасывает отсчет
+func (a *AlertManager) evaluateExpressionRule(ctx context.Context, rule *models.AlertRule) (*alertMatch, error) {
+	expr, err := ParseAlertExpression(rule.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+
+	values := make(map[string]float64)
+	for _, operand := range expr.Operands() {
+		value, err := a.getOperandValue(ctx, operand, rule.Platform)
+		if err != nil {
+			return nil, err
+		}
+		values[operand.Key()] = value
+	}
+
+	if !expr.Eval(values) {
+		if rule.PendingSince != nil {
+			rule.PendingSince = nil
+			if err := a.alertRepo.UpdateRuleField(ctx, rule.ID, "pending_since", nil); err != nil {
+				a.logger.WithError(err).Error("Failed to reset rule pending_since")
+			}
+		}
+		return nil, nil
+	}
+
+	if expr.For > 0 {
+		now := time.Now()
+		if rule.PendingSince == nil {
+			rule.PendingSince = &now
+			if err := a.alertRepo.UpdateRuleField(ctx, rule.ID, "pending_since", now); err != nil {
+				a.logger.WithError(err).Error("Failed to update rule pending_since")
+			}
+			return nil, nil
+		}
+		if now.Sub(*rule.PendingSince) < expr.For {
+			return nil, nil
+		}
+	}
+
+	// В событие попадает первое выполненное сравнение, в текст — все выполненные
+	match := &alertMatch{}
+	var held []string
+	for _, c := range expr.Comparisons() {
+		value := values[c.Operand.Key()]
+		if !c.Holds(value) {
+			continue
+		}
+		if len(held) == 0 {
+			match.value = value
+			match.threshold = c.Value
+		}
+		held = append(held, fmt.Sprintf("%s = %.2f (%s %g)", c.Operand.Key(), value, c.Operator, c.Value))
+	}
+	match.message = a.generateExpressionMessage(*rule, held, expr.For)
+
+	return match, nil
+}
+
+// getOperandValue вычисляет операнд выражения: текущее значение метрики, её изменение в процентах
+// (rate) или абсолютное изменение (delta) за окно
+func (a *AlertManager) getOperandValue(ctx context.Context, operand AlertOperand, platform string) (float64, error) {
+	if operand.Func == "" {
+		return a.getMetricValue(ctx, operand.Metric, platform)
+	}
+
+	now := time.Now()
+	series, err := a.metricsRepo.GetByTimeRange(ctx, platform, now.Add(-operand.Window), now)
+	if err != nil {
+		return 0, err
+	}
+	return windowOperandValue(operand, platform, series)
+}
+
+// windowOperandValue считает rate или delta по ряду за окно операнда. Берется ряд самой
+// платформы, для правил без платформы — сводный ряд "all"; нужно хотя бы два среза
+func windowOperandValue(operand AlertOperand, platform string, series []models.AggregatedMetrics) (float64, error) {
+	seriesPlatform := platform
+	if seriesPlatform == "" {
+		seriesPlatform = "all"
+	}
+
+	var values []float64
+	for i := range series {
+		if series[i].Platform != seriesPlatform {
+			continue
+		}
+		value, ok := seriesMetricValue(&series[i], operand.Metric)
+		if !ok {
+			return 0, fmt.Errorf("unknown metric type: %s", operand.Metric)
+		}
+		values = append(values, value)
+	}
+
+	if len(values) < 2 {
+		return 0, fmt.Errorf("not enough %s metrics of %s over %s", operand.Metric, seriesPlatform, formatAlertWindow(operand.Window))
+	}
+	first, last := values[0], values[len(values)-1]
+
+	if operand.Func == "delta" {
+		return last - first, nil
+	}
+	if first == 0 {
+		if last == 0 {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("%s is undefined: %s was 0 at the start of the window", operand.Key(), operand.Metric)
+	}
+	return (last - first) / math.Abs(first) * 100, nil
+}
+
+// seriesMetricValue значение метрики из сохраненного среза aggregated_metrics
+func seriesMetricValue(metrics *models.AggregatedMetrics, metric string) (float64, bool) {
+	switch metric {
+	case "ban_rate":
+		return metrics.BanRate, true
+	case "error_rate":
+		return metrics.ErrorRate, true
+	case "success_rate":
+		return metrics.SuccessRate, true
+	case "balance":
+		return metrics.SMSBalance, true
+	case "warming_duration":
+		return metrics.AvgWarmingDays, true
+	default:
+		return 0, false
+	}
+}
+
+// ValidateAlertRule проверяет правило перед сохранением: тип и оператор простого правила
+// или синтаксис и метрики выражения. Правилу с выражением без типа присваивается тип expression
+func ValidateAlertRule(rule *models.AlertRule) error {
+	if rule.Expression != "" {
+		if _, err := ParseAlertExpression(rule.Expression); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidAlertRule, err)
+		}
+		if rule.Type == "" {
+			rule.Type = models.AlertRuleTypeExpression
+		}
+		return nil
+	}
+
+	if !alertMetrics[rule.Type] {
+		return fmt.Errorf("%w: unknown metric type %q", ErrInvalidAlertRule, rule.Type)
+	}
+	switch rule.Threshold.Operator {
+	case ">", ">=", "<", "<=", "==", "!=":
+		return nil
 	default:
-		return false
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidAlertRule, rule.Threshold.Operator)
 	}
 }
 
@@ -230,6 +432,9 @@ func (a *AlertManager) generateAlertMessage(rule models.AlertRule, currentValue
 	case "budget":
 		return fmt.Sprintf("💰 Превышение бюджета: использовано %.1f%% (порог: %.1f%%)",
 			currentValue, rule.Threshold.Value)
+	case "budget_projection":
+		return fmt.Sprintf("💸 Прогноз: бюджет закончится на %.1f дн. раньше конца месяца",
+			currentValue)
 	case "balance":
 		return fmt.Sprintf("📱 Низкий баланс SMS: %.0f (порог: %.0f)",
 			currentValue, rule.Threshold.Value)
@@ -242,6 +447,23 @@ func (a *AlertManager) generateAlertMessage(rule models.AlertRule, currentValue
 	}
 }
 
+// generateExpressionMessage генерирует сообщение алерта для правила с выражением
+func (a *AlertManager) generateExpressionMessage(rule models.AlertRule, held []string, holdFor time.Duration) string {
+	platform := rule.Platform
+	if platform == "" || platform == "all" {
+		platform = "все платформы"
+	}
+
+	message := fmt.Sprintf("🔔 %s (%s)", rule.Name, platform)
+	if len(held) > 0 {
+		message += ": " + strings.Join(held, ", ")
+	}
+	if holdFor > 0 {
+		message += fmt.Sprintf(", держится %s", formatAlertWindow(holdFor))
+	}
+	return message
+}
+
 // publishAlertEvent публикует событие алерта в RabbitMQ
 func (a *AlertManager) publishAlertEvent(ctx context.Context, alert *models.AlertEvent) error {
 	// Определяем правильный routing key в зависимости от severity/типа алерта
@@ -291,14 +513,58 @@ func (a *AlertManager) publishAlertEvent(ctx context.Context, alert *models.Aler
 	return a.rabbitmq.Publish("bot.events", routingKey, data)
 }
 
+// notifyByEmail отправляет алерт на email пользователям согласно их настройкам уведомлений
+func (a *AlertManager) notifyByEmail(ctx context.Context, alert *models.AlertEvent) {
+	if a.preferences == nil {
+		return
+	}
+
+	platform := alert.Platform
+	if platform == "all" {
+		platform = ""
+	}
+
+	recipients, err := a.preferences.Recipients(ctx, sharedmodels.CategoryAlert, alert.Severity, platform, sharedmodels.ChannelEmail)
+	if err != nil {
+		a.logger.WithError(err).Warn("Failed to get email recipients from notification preferences")
+		return
+	}
+
+	for _, recipient := range recipients {
+		message := map[string]interface{}{
+			"type":      "alert_notification",
+			"recipient": recipient.Email,
+			"data": map[string]interface{}{
+				"rule_name":     alert.RuleName,
+				"severity":      alert.Severity,
+				"platform":      alert.Platform,
+				"message":       alert.Message,
+				"current_value": alert.CurrentValue,
+				"threshold":     alert.Threshold,
+				"fired_at":      alert.FiredAt,
+			},
+		}
+
+		if err := a.rabbitmq.PublishEvent("notification.email.send", message); err != nil {
+			a.logger.WithError(err).WithField("user_id", recipient.UserID).Error("Failed to publish alert email")
+		}
+	}
+}
+
 // CreateAlertRule создает новое правило алерта
 func (a *AlertManager) CreateAlertRule(ctx context.Context, rule *models.AlertRule) error {
+	if err := ValidateAlertRule(rule); err != nil {
+		return err
+	}
 	rule.Enabled = true
 	return a.alertRepo.CreateRule(ctx, rule)
 }
 
 // UpdateAlertRule обновляет правило алерта
 func (a *AlertManager) UpdateAlertRule(ctx context.Context, rule *models.AlertRule) error {
+	if err := ValidateAlertRule(rule); err != nil {
+		return err
+	}
 	return a.alertRepo.UpdateRule(ctx, rule)
 }
 
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
@@ -21,8 +23,10 @@ type AnalyticsService struct {
 	forecaster   *Forecaster
 	recommender  *Recommender
 	alertManager *AlertManager
+	lifecycle    *LifecycleTracker
+	reports      *ReportComposer
 
-	logger *logger.Logger
+	logger logger.Logger
 }
 
 // NewAnalyticsService создает новый сервис аналитики
@@ -35,7 +39,9 @@ func NewAnalyticsService(
 	forecaster *Forecaster,
 	recommender *Recommender,
 	alertManager *AlertManager,
-	logger *logger.Logger,
+	lifecycle *LifecycleTracker,
+	reports *ReportComposer,
+	logger logger.Logger,
 ) *AnalyticsService {
 	return &AnalyticsService{
 		metricsRepo:        metricsRepo,
@@ -46,6 +52,8 @@ func NewAnalyticsService(
 		forecaster:         forecaster,
 		recommender:        recommender,
 		alertManager:       alertManager,
+		lifecycle:          lifecycle,
+		reports:            reports,
 		logger:             logger,
 	}
 }
@@ -75,12 +83,6 @@ func (s *AnalyticsService) GetOverallAnalytics(ctx context.Context, startDate, e
 		})
 	}
 
-	// Получаем агрегированную статистику
-	stats, err := s.metricsRepo.GetAggregatedStats(ctx, "all", 24*time.Hour)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to get aggregated stats")
-	}
-
 	// Собираем общую аналитику
 	analytics := &OverallAnalytics{
 		TotalAccounts:       latestMetrics.TotalAccounts,
@@ -100,6 +102,8 @@ func (s *AnalyticsService) GetOverallAnalytics(ctx context.Context, startDate, e
 			BannedProxies:      latestMetrics.BannedProxies,
 			SMSBalance:         latestMetrics.SMSBalance,
 			WarmingTasksActive: latestMetrics.WarmingActive,
+			SMSDeferredToday:   latestMetrics.SMSDeferred,
+			SMSDeferralRate:    latestMetrics.SMSDeferralRate,
 		},
 		Performance: PerformanceSummary{
 			AvgWarmingDays:       latestMetrics.AvgWarmingDays,
@@ -152,6 +156,11 @@ func (s *AnalyticsService) GetExpenseForecast(ctx context.Context, period string
 	return s.forecaster.GetExpenseForecast(ctx, period)
 }
 
+// GetBudgetBurnDown получает сгорание месячного бюджета
+func (s *AnalyticsService) GetBudgetBurnDown(ctx context.Context) (*models.BudgetBurnDown, error) {
+	return s.alertManager.GetBudgetBurnDown(ctx)
+}
+
 // GetAccountReadinessForecast получает прогноз готовности аккаунта
 func (s *AnalyticsService) GetAccountReadinessForecast(ctx context.Context, accountID, platform string) (*models.ForecastResult, error) {
 	return s.forecaster.GetReadinessForecast(ctx, accountID)
@@ -193,8 +202,8 @@ func (s *AnalyticsService) CreateAlertRule(ctx context.Context, rule *models.Ale
 	return s.alertManager.CreateAlertRule(ctx, rule)
 }
 
-// UpdateAlertRule обновляет правило алерта
-func (s *AnalyticsService) UpdateAlertRule(ctx context.Context, ruleID string, enabled bool, threshold *models.AlertThreshold, cooldown int) error {
+// UpdateAlertRule обновляет правило алерта. Пустое expression переводит правило обратно на порог
+func (s *AnalyticsService) UpdateAlertRule(ctx context.Context, ruleID string, enabled bool, threshold *models.AlertThreshold, expression *string, cooldown int) error {
 	id, err := primitive.ObjectIDFromHex(ruleID)
 	if err != nil {
 		return err
@@ -209,6 +218,13 @@ func (s *AnalyticsService) UpdateAlertRule(ctx context.Context, ruleID string, e
 	if threshold != nil {
 		rule.Threshold = *threshold
 	}
+	if expression != nil && *expression != rule.Expression {
+		rule.Expression = *expression
+		rule.PendingSince = nil
+		if rule.Expression == "" && rule.Type == models.AlertRuleTypeExpression {
+			rule.Type = ""
+		}
+	}
 	if cooldown > 0 {
 		rule.Cooldown = cooldown
 	}
@@ -216,6 +232,40 @@ func (s *AnalyticsService) UpdateAlertRule(ctx context.Context, ruleID string, e
 	return s.alertManager.UpdateAlertRule(ctx, rule)
 }
 
+// AlertExpressionInfo результат проверки выражения правила
+type AlertExpressionInfo struct {
+	Valid      bool     `json:"valid"`
+	Error      string   `json:"error,omitempty"`
+	Position   int      `json:"position"`
+	Normalized string   `json:"normalized,omitempty"`
+	Metrics    []string `json:"metrics,omitempty"`
+	For        string   `json:"for,omitempty"`
+}
+
+// ValidateAlertExpression проверяет выражение правила без сохранения
+func (s *AnalyticsService) ValidateAlertExpression(expression string) *AlertExpressionInfo {
+	expr, err := ParseAlertExpression(expression)
+	if err != nil {
+		info := &AlertExpressionInfo{Error: err.Error()}
+		var exprErr *AlertExpressionError
+		if errors.As(err, &exprErr) {
+			info.Error = exprErr.Message
+			info.Position = exprErr.Pos
+		}
+		return info
+	}
+
+	info := &AlertExpressionInfo{
+		Valid:      true,
+		Normalized: expr.String(),
+		Metrics:    expr.Metrics(),
+	}
+	if expr.For > 0 {
+		info.For = formatAlertWindow(expr.For)
+	}
+	return info
+}
+
 // DeleteAlertRule удаляет правило алерта
 func (s *AnalyticsService) DeleteAlertRule(ctx context.Context, ruleID string) error {
 	return s.alertManager.DeleteAlertRule(ctx, ruleID)
@@ -231,6 +281,59 @@ func (s *AnalyticsService) ForceAggregation(ctx context.Context) error {
 	return s.aggregator.ForceAggregate(ctx)
 }
 
+// GetLifecycleReport получает отчет по жизненному циклу аккаунтов
+func (s *AnalyticsService) GetLifecycleReport(ctx context.Context, platform string, days int) (*models.LifecycleReport, error) {
+	return s.lifecycle.GetReport(ctx, platform, days)
+}
+
+// GetFunnel получает воронку аккаунтов по когортам
+func (s *AnalyticsService) GetFunnel(ctx context.Context, filter models.FunnelFilter) (*models.FunnelReport, error) {
+	return s.lifecycle.GetFunnel(ctx, filter)
+}
+
+// GetSurvival получает кривые выживаемости аккаунтов по когортам
+func (s *AnalyticsService) GetSurvival(ctx context.Context, filter models.SurvivalFilter) (*models.SurvivalReport, error) {
+	return s.lifecycle.GetSurvival(ctx, filter)
+}
+
+// GetProviderEfficiency получает отчет об эффективности расходов на прокси-провайдеров
+func (s *AnalyticsService) GetProviderEfficiency(ctx context.Context, days int) (*models.ProviderEfficiencyReport, error) {
+	return s.lifecycle.GetProviderEfficiency(ctx, days)
+}
+
+// GetAccountCosts получает расходы, отнесенные к аккаунтам
+func (s *AnalyticsService) GetAccountCosts(ctx context.Context, accountIDs []string) ([]models.AccountCost, error) {
+	return s.lifecycle.GetAccountCosts(ctx, accountIDs)
+}
+
+// GetRegistrationLatency получает перцентили длительности регистраций по платформам и шагам
+func (s *AnalyticsService) GetRegistrationLatency(ctx context.Context, platform string, days int) (*models.RegistrationLatencyReport, error) {
+	return s.lifecycle.GetRegistrationLatency(ctx, platform, days)
+}
+
+// GetResourceCleanup получает сводку ресурсов, освобожденных при компенсации брошенных регистраций
+func (s *AnalyticsService) GetResourceCleanup(ctx context.Context, days int) (*models.ResourceCleanupSummary, error) {
+	return s.lifecycle.GetResourceCleanup(ctx, days)
+}
+
+// GetDailySummary получает текстовую сводку за сутки date (YYYY-MM-DD, по умолчанию вчерашние)
+func (s *AnalyticsService) GetDailySummary(ctx context.Context, date string) (*models.DailySummary, error) {
+	return s.reports.GetDailySummary(ctx, date)
+}
+
+// GetMetricSeries получает временной ряд агрегированной метрики для внешних дашбордов
+func (s *AnalyticsService) GetMetricSeries(ctx context.Context, metric, platform string, start, end time.Time, interval time.Duration) ([]models.TimeSeriesData, error) {
+	if !models.IsSeriesMetric(metric) {
+		return nil, fmt.Errorf("unknown metric: %s", metric)
+	}
+	return s.metricsRepo.GetBucketedSeries(ctx, metric, platform, start, end, interval)
+}
+
+// GetMetricPlatforms получает список платформ с сохраненными метриками
+func (s *AnalyticsService) GetMetricPlatforms(ctx context.Context) ([]string, error) {
+	return s.metricsRepo.GetPlatforms(ctx)
+}
+
 // Helper методы
 
 func (s *AnalyticsService) getAccountsByPlatform(ctx context.Context) map[string]int64 {
@@ -313,6 +416,8 @@ type ResourcesSummary struct {
 	BannedProxies      int64   `json:"banned_proxies"`
 	SMSBalance         float64 `json:"sms_balance"`
 	WarmingTasksActive int64   `json:"warming_tasks_active"`
+	SMSDeferredToday   int64   `json:"sms_deferred_today"`
+	SMSDeferralRate    float64 `json:"sms_deferral_rate"`
 }
 
 type PerformanceSummary struct {
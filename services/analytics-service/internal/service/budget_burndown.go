@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+// budgetForecastPeriod период прогноза расходов, из которого берется суточный темп
+const budgetForecastPeriod = "7d"
+
+// GetBudgetBurnDown строит сгорание месячного бюджета за текущий календарный месяц
+func (a *AlertManager) GetBudgetBurnDown(ctx context.Context) (*models.BudgetBurnDown, error) {
+	if a.monthlyBudget <= 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	metrics, err := a.metricsRepo.GetByTimeRange(ctx, "all", monthStart, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get month expenses: %w", err)
+	}
+
+	daily := make(map[int]float64)
+	for _, m := range metrics {
+		daily[m.Timestamp.In(now.Location()).Day()] += m.TotalSpent
+	}
+
+	// Темп из прогноза расходов, при его отсутствии — фактический темп месяца
+	var projectedRate float64
+	if a.forecaster != nil {
+		forecast, err := a.forecaster.GetExpenseForecast(ctx, budgetForecastPeriod)
+		if err != nil {
+			a.logger.WithError(err).Warn("Failed to get expense forecast for budget burn-down")
+		} else if forecast != nil && forecast.ExpenseForecast != nil {
+			projectedRate = forecast.ExpenseForecast.DailyRate
+		}
+	}
+
+	return buildBurnDown(a.monthlyBudget, daily, projectedRate, now), nil
+}
+
+// buildBurnDown считает фактическое сгорание по дням и продлевает его прогнозным темпом до конца месяца
+func buildBurnDown(budget float64, daily map[int]float64, projectedRate float64, now time.Time) *models.BudgetBurnDown {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	monthEnd := monthStart.AddDate(0, 1, 0)
+
+	burnDown := &models.BudgetBurnDown{
+		MonthlyBudget: budget,
+		MonthEnd:      monthEnd,
+	}
+
+	remaining := budget
+	for day := monthStart; day.Before(now); day = day.AddDate(0, 0, 1) {
+		spent := daily[day.Day()]
+		remaining -= spent
+		burnDown.Spent += spent
+		burnDown.Days = append(burnDown.Days, models.BurnDownDay{
+			Date:      day,
+			Spent:     spent,
+			Remaining: remaining,
+		})
+	}
+	burnDown.Remaining = remaining
+
+	elapsedDays := now.Sub(monthStart).Hours() / 24
+	if elapsedDays > 0 {
+		burnDown.ActualDailyRate = burnDown.Spent / elapsedDays
+	}
+
+	rate := projectedRate
+	if rate <= 0 {
+		rate = burnDown.ActualDailyRate
+	}
+	burnDown.ProjectedDailyRate = rate
+
+	daysLeft := monthEnd.Sub(now).Hours() / 24
+	burnDown.ProjectedMonthEnd = burnDown.Spent + rate*daysLeft
+
+	// Прогнозные точки начинаются с завтрашнего дня, сегодняшний уже учтен фактом
+	projected := remaining
+	for day := monthStart.AddDate(0, 0, len(burnDown.Days)); day.Before(monthEnd); day = day.AddDate(0, 0, 1) {
+		projected -= rate
+		burnDown.Days = append(burnDown.Days, models.BurnDownDay{
+			Date:      day,
+			Spent:     rate,
+			Remaining: projected,
+			Projected: true,
+		})
+	}
+
+	switch {
+	case remaining <= 0:
+		exhaustion := now
+		burnDown.ExhaustionDate = &exhaustion
+	case rate > 0:
+		exhaustion := now.Add(time.Duration(remaining / rate * float64(24*time.Hour)))
+		burnDown.ExhaustionDate = &exhaustion
+	}
+
+	if burnDown.ExhaustionDate != nil && burnDown.ExhaustionDate.Before(monthEnd) {
+		burnDown.OverrunDays = math.Round(monthEnd.Sub(*burnDown.ExhaustionDate).Hours()/24*10) / 10
+	}
+
+	return burnDown
+}
@@ -20,8 +20,8 @@ import (
 type Forecaster struct {
 	metricsRepo  *repository.MetricsRepository
 	forecastRepo *repository.ForecastRepository
-	cache        *cache.RedisClient
-	logger       *logger.Logger
+	cache        *cache.RedisCache
+	logger       logger.Logger
 	interval     time.Duration
 }
 
@@ -29,8 +29,8 @@ type Forecaster struct {
 func NewForecaster(
 	metricsRepo *repository.MetricsRepository,
 	forecastRepo *repository.ForecastRepository,
-	cache *cache.RedisClient,
-	logger *logger.Logger,
+	cache *cache.RedisCache,
+	logger logger.Logger,
 ) *Forecaster {
 	return &Forecaster{
 		metricsRepo:  metricsRepo,
@@ -156,6 +156,14 @@ func (f *Forecaster) forecastExpenses(ctx context.Context, period string) error
 	}
 	r2 := 1 - (ssRes / ssTot)
 
+	// Суточный расход: значение линии регрессии в середине периода, умноженное на число записей в сутки
+	var dailyRate float64
+	if span := endTime.Sub(metrics[0].Timestamp).Hours(); span > 0 {
+		samplesPerDay := float64(len(metrics)) / span * 24
+		midTimestamp := float64(time.Now().Add(time.Duration(days) * 12 * time.Hour).Unix())
+		dailyRate = math.Max(0, (alpha+beta*midTimestamp)*samplesPerDay)
+	}
+
 	// Разбивка по типам расходов
 	breakdown := make(map[string]float64)
 	if len(metrics) > 0 {
@@ -179,6 +187,7 @@ func (f *Forecaster) forecastExpenses(ctx context.Context, period string) error
 			UpperBound:    upperBound,
 			LowerBound:    lowerBound,
 			Breakdown:     breakdown,
+			DailyRate:     dailyRate,
 		},
 		Confidence: r2,
 		Model:      "linear_regression",
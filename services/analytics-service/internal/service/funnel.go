@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+// funnelAll значение измерения, не участвующего в группировке
+const funnelAll = "all"
+
+// GetFunnel строит воронку created → warmed → ready → banned по когортам.
+// Этапы накопительные: аккаунт, ставший ready без прогрева, считается прогретым,
+// а banned учитывает только блокировки после ready — более ранние идут в banned_before_ready.
+func (t *LifecycleTracker) GetFunnel(ctx context.Context, filter models.FunnelFilter) (*models.FunnelReport, error) {
+	if filter.Since.IsZero() {
+		filter.Since = time.Now().Add(-t.reportWindow)
+	}
+	if len(filter.GroupBy) == 0 {
+		filter.GroupBy = models.FunnelGroupings
+	}
+
+	accounts, err := t.lifecycleRepo.GetFunnelAccounts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total := newFunnelAccumulator(models.FunnelCohort{Week: funnelAll, Platform: funnelAll, ProxyProvider: funnelAll})
+	cohorts := make(map[string]*funnelAccumulator)
+
+	for _, account := range accounts {
+		key := funnelCohortKey(account, filter.GroupBy)
+		id := key.Week + "|" + key.Platform + "|" + key.ProxyProvider
+		cohort, ok := cohorts[id]
+		if !ok {
+			cohort = newFunnelAccumulator(key)
+			cohorts[id] = cohort
+		}
+		cohort.add(account)
+		total.add(account)
+	}
+
+	platform := filter.Platform
+	if platform == "" {
+		platform = funnelAll
+	}
+
+	report := &models.FunnelReport{
+		Platform:    platform,
+		GroupBy:     filter.GroupBy,
+		Total:       total.result(),
+		Cohorts:     make([]models.FunnelCohort, 0, len(cohorts)),
+		PeriodStart: filter.Since,
+		GeneratedAt: time.Now(),
+	}
+	for _, cohort := range cohorts {
+		report.Cohorts = append(report.Cohorts, cohort.result())
+	}
+
+	// Свежие недели первыми
+	sort.Slice(report.Cohorts, func(i, j int) bool {
+		a, b := report.Cohorts[i], report.Cohorts[j]
+		if a.Week != b.Week {
+			return a.Week > b.Week
+		}
+		if a.Platform != b.Platform {
+			return a.Platform < b.Platform
+		}
+		return a.ProxyProvider < b.ProxyProvider
+	})
+
+	return report, nil
+}
+
+// funnelCohortKey определяет когорту аккаунта по выбранным измерениям
+func funnelCohortKey(account models.FunnelAccount, groupBy []string) models.FunnelCohort {
+	key := models.FunnelCohort{Week: funnelAll, Platform: funnelAll, ProxyProvider: funnelAll}
+	for _, group := range groupBy {
+		switch group {
+		case models.FunnelGroupWeek:
+			key.Week = account.CohortWeek.UTC().Format("2006-01-02")
+		case models.FunnelGroupPlatform:
+			key.Platform = account.Platform
+		case models.FunnelGroupProxyProvider:
+			key.ProxyProvider = account.ProxyProvider
+		}
+	}
+	return key
+}
+
+// funnelAccumulator накапливает прохождение этапов аккаунтами когорты
+type funnelAccumulator struct {
+	cohort            models.FunnelCohort
+	counts            map[models.FunnelStage]int64
+	hoursInStage      map[models.FunnelStage][]float64
+	bannedBeforeReady int64
+}
+
+func newFunnelAccumulator(cohort models.FunnelCohort) *funnelAccumulator {
+	return &funnelAccumulator{
+		cohort:       cohort,
+		counts:       make(map[models.FunnelStage]int64),
+		hoursInStage: make(map[models.FunnelStage][]float64),
+	}
+}
+
+func (a *funnelAccumulator) add(account models.FunnelAccount) {
+	a.counts[models.FunnelCreated]++
+
+	warmedAt := account.WarmedAt
+	if warmedAt == nil {
+		warmedAt = account.ReadyAt
+	}
+	if warmedAt == nil {
+		a.countBannedBeforeReady(account)
+		return
+	}
+	a.counts[models.FunnelWarmed]++
+	a.addStageTime(models.FunnelCreated, account.CreatedAt, *warmedAt)
+
+	if account.ReadyAt == nil {
+		a.countBannedBeforeReady(account)
+		return
+	}
+	a.counts[models.FunnelReady]++
+	if account.WarmedAt != nil {
+		a.addStageTime(models.FunnelWarmed, *account.WarmedAt, *account.ReadyAt)
+	}
+
+	if account.BannedAt == nil {
+		return
+	}
+	if account.BannedAt.Before(*account.ReadyAt) {
+		a.bannedBeforeReady++
+		return
+	}
+	a.counts[models.FunnelBanned]++
+	a.addStageTime(models.FunnelReady, *account.ReadyAt, *account.BannedAt)
+}
+
+func (a *funnelAccumulator) countBannedBeforeReady(account models.FunnelAccount) {
+	if account.BannedAt != nil {
+		a.bannedBeforeReady++
+	}
+}
+
+// addStageTime учитывает время на этапе; отрицательные интервалы из-за рассинхрона событий отбрасываются
+func (a *funnelAccumulator) addStageTime(stage models.FunnelStage, entered, left time.Time) {
+	if left.Before(entered) {
+		return
+	}
+	a.hoursInStage[stage] = append(a.hoursInStage[stage], left.Sub(entered).Hours())
+}
+
+func (a *funnelAccumulator) result() models.FunnelCohort {
+	cohort := a.cohort
+	cohort.BannedBeforeReady = a.bannedBeforeReady
+
+	created := a.counts[models.FunnelCreated]
+	var previous int64
+	for i, stage := range models.FunnelStages {
+		count := a.counts[stage]
+		stats := models.FunnelStageStats{
+			Stage:    stage,
+			Accounts: count,
+		}
+
+		if i == 0 {
+			if count > 0 {
+				stats.ConversionRate = 100
+			}
+		} else if previous > 0 {
+			stats.ConversionRate = float64(count) / float64(previous) * 100
+		}
+		if created > 0 {
+			stats.OverallRate = float64(count) / float64(created) * 100
+		}
+
+		hours := a.hoursInStage[stage]
+		if len(hours) > 0 {
+			sort.Float64s(hours)
+			stats.MedianHours = percentile(hours, 0.5)
+			stats.Samples = int64(len(hours))
+		}
+
+		cohort.Stages = append(cohort.Stages, stats)
+		previous = count
+	}
+
+	return cohort
+}
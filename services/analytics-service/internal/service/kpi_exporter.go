@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+	"github.com/grigta/conveer/services/analytics-service/internal/repository"
+)
+
+// KPIExporter публикует бизнес-KPI как Prometheus gauges на /metrics,
+// чтобы внешние Grafana/Alertmanager читали их без обращения к gRPC API
+type KPIExporter struct {
+	metricsRepo *repository.MetricsRepository
+	logger      logger.Logger
+	interval    time.Duration
+}
+
+// NewKPIExporter создает экспортер бизнес-KPI
+func NewKPIExporter(
+	metricsRepo *repository.MetricsRepository,
+	logger logger.Logger,
+	interval time.Duration,
+) *KPIExporter {
+	return &KPIExporter{
+		metricsRepo: metricsRepo,
+		logger:      logger,
+		interval:    interval,
+	}
+}
+
+// Run запускает фоновое обновление KPI
+func (e *KPIExporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	// Первоначальное обновление, чтобы KPI были доступны сразу после старта
+	if err := e.export(ctx); err != nil {
+		e.logger.WithError(err).Error("Failed initial KPI export")
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			RecordWorkerRun("kpi_exporter")
+			if err := e.export(ctx); err != nil {
+				e.logger.WithError(err).Error("Failed to export KPIs")
+				RecordWorkerError("kpi_exporter")
+			}
+		case <-ctx.Done():
+			e.logger.Info("Stopping KPI exporter")
+			return
+		}
+	}
+}
+
+// export обновляет gauges по последним агрегированным метрикам
+func (e *KPIExporter) export(ctx context.Context) error {
+	platforms := []string{"vk", "telegram", "mail", "max", "all"}
+
+	exported := 0
+	for _, platform := range platforms {
+		metrics, err := e.metricsRepo.GetLatest(ctx, platform)
+		if err != nil || metrics == nil {
+			// Нет данных по платформе — оставляем прежние значения
+			continue
+		}
+
+		kpiAccountsReady.WithLabelValues(platform).Set(float64(metrics.AccountsByStatus["ready"]))
+		kpiBanRate.WithLabelValues(platform).Set(metrics.BanRate)
+		kpiWeatherIndex.WithLabelValues(platform).Set(weatherIndex(metrics))
+		exported++
+	}
+
+	if exported == 0 {
+		return fmt.Errorf("no aggregated metrics available")
+	}
+
+	if err := e.exportSpendToday(ctx); err != nil {
+		return err
+	}
+
+	kpiLastUpdate.SetToCurrentTime()
+	return nil
+}
+
+// exportSpendToday обновляет расходы за последние 24 часа
+func (e *KPIExporter) exportSpendToday(ctx context.Context) error {
+	now := time.Now()
+	metrics, err := e.metricsRepo.GetByTimeRange(ctx, "all", now.Add(-24*time.Hour), now)
+	if err != nil {
+		return fmt.Errorf("failed to get spend for today: %w", err)
+	}
+
+	// Суммируем так же, как ExpensesSummary.TotalSpentToday в обзоре аналитики
+	var sms, proxy, total float64
+	for _, m := range metrics {
+		sms += m.SMSSpent
+		proxy += m.ProxySpent
+		total += m.TotalSpent
+	}
+
+	kpiSpendToday.WithLabelValues("sms").Set(sms)
+	kpiSpendToday.WithLabelValues("proxy").Set(proxy)
+	kpiSpendToday.WithLabelValues("total").Set(total)
+	return nil
+}
+
+// weatherIndex сводный индекс «погоды» платформы от 0 (шторм) до 100 (ясно).
+// Баны весят больше всего, затем неуспешные регистрации и ошибки.
+func weatherIndex(metrics *models.AggregatedMetrics) float64 {
+	if metrics == nil {
+		return 0
+	}
+
+	penalty := 0.5*metrics.BanRate +
+		0.3*(100-metrics.SuccessRate) +
+		0.2*math.Min(metrics.ErrorRate, 100)
+
+	index := 100 - penalty
+	if math.IsNaN(index) {
+		return 0
+	}
+	return math.Max(0, math.Min(100, index))
+}
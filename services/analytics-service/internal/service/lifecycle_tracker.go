@@ -0,0 +1,437 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+	"github.com/grigta/conveer/services/analytics-service/internal/repository"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// lifecycleSources exchange'и платформ, из событий которых строится жизненный цикл
+var lifecycleSources = []string{"vk", "telegram", "mail", "max", "warming", "proxy"}
+
+// appealEventPrefix префикс событий апелляций на блокировку (appeal_approved, appeal_rejected, ...)
+const appealEventPrefix = "appeal_"
+
+// lifecycleTransitions допустимые переходы канонической машины состояний.
+// Пустое исходное состояние означает, что аккаунт еще не известен аналитике.
+// Из banned аккаунт может вернуться в restricted, пока рассматривается апелляция.
+var lifecycleTransitions = map[models.LifecycleState][]models.LifecycleState{
+	"":                         {models.LifecycleRegistered, models.LifecycleEnriched, models.LifecycleWarming, models.LifecycleReady, models.LifecycleInUse, models.LifecycleRestricted, models.LifecycleBanned, models.LifecycleRetired},
+	models.LifecycleRegistered: {models.LifecycleEnriched, models.LifecycleWarming, models.LifecycleRestricted, models.LifecycleBanned, models.LifecycleRetired},
+	models.LifecycleEnriched:   {models.LifecycleWarming, models.LifecycleReady, models.LifecycleRestricted, models.LifecycleBanned, models.LifecycleRetired},
+	models.LifecycleWarming:    {models.LifecycleReady, models.LifecycleRestricted, models.LifecycleBanned, models.LifecycleRetired},
+	models.LifecycleReady:      {models.LifecycleInUse, models.LifecycleWarming, models.LifecycleRestricted, models.LifecycleBanned, models.LifecycleRetired},
+	models.LifecycleInUse:      {models.LifecycleReady, models.LifecycleRestricted, models.LifecycleBanned, models.LifecycleRetired},
+	models.LifecycleRestricted: {models.LifecycleWarming, models.LifecycleReady, models.LifecycleBanned, models.LifecycleRetired},
+	models.LifecycleBanned:     {models.LifecycleRestricted, models.LifecycleRetired},
+	models.LifecycleRetired:    {},
+}
+
+// statusToLifecycle соответствие статусов платформенных сервисов каноническим состояниям
+var statusToLifecycle = map[string]models.LifecycleState{
+	"created":    models.LifecycleRegistered,
+	"registered": models.LifecycleRegistered,
+	"warming":    models.LifecycleWarming,
+	"ready":      models.LifecycleReady,
+	"in_use":     models.LifecycleInUse,
+	"exported":   models.LifecycleInUse,
+	"suspended":  models.LifecycleRestricted,
+	"appealing":  models.LifecycleRestricted,
+	"restricted": models.LifecycleRestricted,
+	"banned":     models.LifecycleBanned,
+	"deleted":    models.LifecycleRetired,
+	"retired":    models.LifecycleRetired,
+}
+
+// LifecycleTracker строит жизненный цикл аккаунтов по событиям сервисов
+type LifecycleTracker struct {
+	lifecycleRepo *repository.LifecycleRepository
+	rabbitmq      *messaging.RabbitMQ
+	logger        logger.Logger
+	reportWindow  time.Duration
+}
+
+// NewLifecycleTracker создает новый трекер жизненного цикла
+func NewLifecycleTracker(
+	lifecycleRepo *repository.LifecycleRepository,
+	rabbitmq *messaging.RabbitMQ,
+	logger logger.Logger,
+) *LifecycleTracker {
+	return &LifecycleTracker{
+		lifecycleRepo: lifecycleRepo,
+		rabbitmq:      rabbitmq,
+		logger:        logger,
+		reportWindow:  30 * 24 * time.Hour,
+	}
+}
+
+// lifecycleEvent общие поля событий платформенных сервисов
+type lifecycleEvent struct {
+	Type      string `json:"type"`
+	AccountID string `json:"account_id"`
+	Platform  string `json:"platform"`
+	Status    string `json:"status"`
+	NewStatus string `json:"new_status"`
+
+	PhoneSource   string  `json:"phone_source"`
+	DurationHours float64 `json:"duration_hours"`
+
+	Step        string  `json:"step"`
+	DurationSec float64 `json:"duration_sec"`
+
+	Provider string  `json:"provider"`
+	ProxyID  string  `json:"proxy_id"`
+	Cost     float64 `json:"cost"`
+	Currency string  `json:"currency"`
+
+	ScenarioType string `json:"scenario_type"`
+}
+
+// Run подписывается на события сервисов и обновляет состояния аккаунтов
+func (t *LifecycleTracker) Run(ctx context.Context) {
+	for _, source := range lifecycleSources {
+		exchange := fmt.Sprintf("%s.events", source)
+		queue := fmt.Sprintf("analytics.lifecycle.%s", source)
+
+		if err := t.rabbitmq.DeclareExchange(exchange, "topic", true, false); err != nil {
+			t.logger.WithError(err).WithField("exchange", exchange).Error("Failed to declare exchange")
+			continue
+		}
+		if _, err := t.rabbitmq.DeclareQueue(queue, true, false, false); err != nil {
+			t.logger.WithError(err).WithField("queue", queue).Error("Failed to declare lifecycle queue")
+			continue
+		}
+		if err := t.rabbitmq.BindQueue(queue, "#", exchange); err != nil {
+			t.logger.WithError(err).WithField("queue", queue).Error("Failed to bind lifecycle queue")
+			continue
+		}
+
+		source := source
+		handler := func(body []byte) error {
+			return t.handleEvent(ctx, source, body)
+		}
+		if err := t.rabbitmq.ConsumeWithHandler(ctx, queue, "analytics-lifecycle-"+source, handler); err != nil {
+			t.logger.WithError(err).WithField("queue", queue).Error("Failed to consume lifecycle events")
+		}
+	}
+
+	<-ctx.Done()
+	t.logger.Info("Stopping lifecycle tracker")
+}
+
+// handleEvent разбирает событие и применяет переход
+func (t *LifecycleTracker) handleEvent(ctx context.Context, source string, body []byte) error {
+	var event lifecycleEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		// Некоторые сервисы публикуют уже сериализованный JSON как строку
+		var raw string
+		if json.Unmarshal(body, &raw) != nil || json.Unmarshal([]byte(raw), &event) != nil {
+			return nil // Не переотправляем битые сообщения
+		}
+	}
+
+	if event.Type == cleanupReportEvent {
+		t.recordCleanupReport(ctx, source, body)
+		return nil
+	}
+
+	if event.Type == registrationStepEvent {
+		t.recordRegistrationStep(ctx, source, &event)
+		return nil
+	}
+
+	if source == "proxy" && event.Type == proxyPurchasedEvent {
+		t.recordProxyPurchase(ctx, &event)
+		return nil
+	}
+
+	if event.AccountID == "" {
+		return nil
+	}
+
+	if source == "proxy" && event.Provider != "" {
+		t.recordProxyProvider(ctx, &event)
+		if event.Cost > 0 {
+			t.recordProxyCost(ctx, &event)
+		}
+	}
+
+	if source == "warming" && event.ScenarioType != "" {
+		t.recordWarmingScenario(ctx, &event)
+	}
+
+	state, ok := resolveLifecycleState(source, &event)
+	if !ok {
+		return nil
+	}
+
+	platform := event.Platform
+	if platform == "" && source != "warming" && source != "proxy" {
+		platform = source
+	}
+
+	if strings.HasPrefix(event.Type, appealEventPrefix) {
+		t.recordAppealOutcome(ctx, platform, &event)
+		return nil
+	}
+
+	return t.Apply(ctx, platform, event.AccountID, state, event.Type)
+}
+
+// resolveLifecycleState определяет целевое состояние по событию
+func resolveLifecycleState(source string, event *lifecycleEvent) (models.LifecycleState, bool) {
+	if event.NewStatus != "" {
+		state, ok := statusToLifecycle[event.NewStatus]
+		return state, ok
+	}
+
+	switch {
+	case source == "proxy" && strings.HasSuffix(event.Type, "allocated"):
+		return models.LifecycleEnriched, true
+	case source == "warming" && strings.Contains(event.Type, "task.started"):
+		return models.LifecycleWarming, true
+	case source == "warming" && strings.Contains(event.Type, "account.ready"):
+		return models.LifecycleReady, true
+	case strings.HasSuffix(event.Type, "created"):
+		return models.LifecycleRegistered, true
+	case strings.HasSuffix(event.Type, "deleted"):
+		return models.LifecycleRetired, true
+	case strings.HasSuffix(event.Type, "banned"):
+		return models.LifecycleBanned, true
+	}
+
+	if event.Status != "" && strings.Contains(event.Type, "status") {
+		state, ok := statusToLifecycle[event.Status]
+		return state, ok
+	}
+
+	return "", false
+}
+
+// Apply применяет переход аккаунта в новое состояние
+func (t *LifecycleTracker) Apply(ctx context.Context, platform, accountID string, state models.LifecycleState, eventType string) error {
+	now := time.Now()
+
+	current, err := t.lifecycleRepo.Get(ctx, platform, accountID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	var from models.LifecycleState
+	if current != nil {
+		from = current.State
+		if from == state {
+			return nil
+		}
+		if platform == "" {
+			platform = current.Platform
+		}
+	} else if platform == "" {
+		// Без платформы не можем завести аккаунт, ждем событие платформенного сервиса
+		return nil
+	}
+
+	if !isAllowedTransition(from, state) {
+		lifecycleRejectedTransitions.WithLabelValues(string(from), string(state)).Inc()
+		t.logger.WithField("account_id", accountID).
+			WithField("from", from).
+			WithField("to", state).
+			Debug("Ignoring invalid lifecycle transition")
+		return nil
+	}
+
+	lifecycle := &models.AccountLifecycle{
+		AccountID:      accountID,
+		Platform:       platform,
+		State:          state,
+		StateEnteredAt: now,
+		RegisteredAt:   now,
+		LastEvent:      eventType,
+	}
+	if err := t.lifecycleRepo.Upsert(ctx, lifecycle); err != nil {
+		return err
+	}
+
+	if current != nil {
+		transition := &models.LifecycleTransition{
+			AccountID:   accountID,
+			Platform:    platform,
+			FromState:   from,
+			ToState:     state,
+			EnteredAt:   current.StateEnteredAt,
+			LeftAt:      now,
+			DurationSec: now.Sub(current.StateEnteredAt).Seconds(),
+			Event:       eventType,
+		}
+		if err := t.lifecycleRepo.SaveTransition(ctx, transition); err != nil {
+			t.logger.WithError(err).WithField("account_id", accountID).Error("Failed to save lifecycle transition")
+		}
+		lifecycleStateDuration.WithLabelValues(platform, string(from)).Observe(transition.DurationSec / 3600)
+	}
+
+	lifecycleTransitionsTotal.WithLabelValues(platform, string(from), string(state)).Inc()
+	return nil
+}
+
+// GetReport формирует отчет по текущим состояниям и длительностям
+func (t *LifecycleTracker) GetReport(ctx context.Context, platform string, days int) (*models.LifecycleReport, error) {
+	window := t.reportWindow
+	if days > 0 {
+		window = time.Duration(days) * 24 * time.Hour
+	}
+	since := time.Now().Add(-window)
+
+	counts, err := t.lifecycleRepo.CountByState(ctx, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.LifecycleReport{
+		Platform:    platform,
+		StateCounts: make(map[string]int64),
+		PeriodStart: since,
+		GeneratedAt: time.Now(),
+	}
+
+	for _, state := range models.LifecycleStates {
+		report.StateCounts[string(state)] = counts[string(state)]
+		if platform != "" && platform != "all" {
+			lifecycleStateAccounts.WithLabelValues(platform, string(state)).Set(float64(counts[string(state)]))
+		}
+
+		if state.IsTerminal() {
+			continue
+		}
+
+		durations, err := t.lifecycleRepo.GetDurations(ctx, platform, state, since)
+		if err != nil {
+			return nil, err
+		}
+		report.Durations = append(report.Durations, summarizeDurations(state, durations))
+	}
+
+	appeals, err := t.lifecycleRepo.CountAppealOutcomes(ctx, platform, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(appeals) > 0 {
+		report.AppealOutcomes = appeals
+		var resolved int64
+		for _, count := range appeals {
+			resolved += count
+		}
+		report.AppealRestoreRate = float64(appeals["approved"]) / float64(resolved) * 100
+	}
+
+	return report, nil
+}
+
+// recordAppealOutcome сохраняет итог апелляции. Смену состояния аккаунта
+// приносит отдельное событие смены статуса, здесь учитывается только исход.
+func (t *LifecycleTracker) recordAppealOutcome(ctx context.Context, platform string, event *lifecycleEvent) {
+	outcome := strings.TrimPrefix(event.Type, appealEventPrefix)
+	if outcome == "submitted" || platform == "" {
+		return
+	}
+
+	appealOutcomesTotal.WithLabelValues(platform, outcome, event.PhoneSource).Inc()
+
+	record := &models.AppealOutcome{
+		AccountID:     event.AccountID,
+		Platform:      platform,
+		Outcome:       outcome,
+		PhoneSource:   event.PhoneSource,
+		DurationHours: event.DurationHours,
+		ResolvedAt:    time.Now(),
+	}
+	if err := t.lifecycleRepo.SaveAppealOutcome(ctx, record); err != nil {
+		t.logger.WithError(err).WithField("account_id", event.AccountID).Error("Failed to save appeal outcome")
+	}
+}
+
+// recordProxyProvider запоминает провайдера прокси для когорт воронки
+func (t *LifecycleTracker) recordProxyProvider(ctx context.Context, event *lifecycleEvent) {
+	record := &models.AccountProxyProvider{
+		AccountID:   event.AccountID,
+		Provider:    event.Provider,
+		AllocatedAt: time.Now(),
+	}
+	if err := t.lifecycleRepo.SaveProxyProvider(ctx, record); err != nil {
+		t.logger.WithError(err).WithField("account_id", event.AccountID).Error("Failed to save proxy provider")
+	}
+}
+
+// recordWarmingScenario запоминает сценарий прогрева для когорт кривых выживаемости
+func (t *LifecycleTracker) recordWarmingScenario(ctx context.Context, event *lifecycleEvent) {
+	record := &models.AccountWarmingScenario{
+		AccountID: event.AccountID,
+		Platform:  event.Platform,
+		Scenario:  event.ScenarioType,
+		StartedAt: time.Now(),
+	}
+	if err := t.lifecycleRepo.SaveWarmingScenario(ctx, record); err != nil {
+		t.logger.WithError(err).WithField("account_id", event.AccountID).Error("Failed to save warming scenario")
+	}
+}
+
+// isAllowedTransition проверяет допустимость перехода
+func isAllowedTransition(from, to models.LifecycleState) bool {
+	for _, allowed := range lifecycleTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeDurations считает распределение длительностей (в часах)
+func summarizeDurations(state models.LifecycleState, durations []float64) models.StateDurationStats {
+	stats := models.StateDurationStats{
+		State:   state,
+		Samples: int64(len(durations)),
+	}
+	if len(durations) == 0 {
+		return stats
+	}
+
+	hours := make([]float64, len(durations))
+	var sum float64
+	for i, d := range durations {
+		hours[i] = d / 3600
+		sum += hours[i]
+	}
+	sort.Float64s(hours)
+
+	stats.AvgHours = sum / float64(len(hours))
+	stats.P50Hours = percentile(hours, 0.5)
+	stats.P90Hours = percentile(hours, 0.9)
+	stats.MaxHours = hours[len(hours)-1]
+
+	return stats
+}
+
+// percentile возвращает перцентиль отсортированной выборки
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAllowedTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from models.LifecycleState
+		to   models.LifecycleState
+		want bool
+	}{
+		{"new account registered", "", models.LifecycleRegistered, true},
+		{"new account seen banned", "", models.LifecycleBanned, true},
+		{"registered to enriched", models.LifecycleRegistered, models.LifecycleEnriched, true},
+		{"registered skips to warming", models.LifecycleRegistered, models.LifecycleWarming, true},
+		{"registered cannot be ready", models.LifecycleRegistered, models.LifecycleReady, false},
+		{"enriched to ready", models.LifecycleEnriched, models.LifecycleReady, true},
+		{"warming to ready", models.LifecycleWarming, models.LifecycleReady, true},
+		{"warming cannot be in use", models.LifecycleWarming, models.LifecycleInUse, false},
+		{"ready to in use", models.LifecycleReady, models.LifecycleInUse, true},
+		{"ready back to warming", models.LifecycleReady, models.LifecycleWarming, true},
+		{"in use back to ready", models.LifecycleInUse, models.LifecycleReady, true},
+		{"restricted restored", models.LifecycleRestricted, models.LifecycleReady, true},
+		{"banned under appeal", models.LifecycleBanned, models.LifecycleRestricted, true},
+		{"banned cannot be ready", models.LifecycleBanned, models.LifecycleReady, false},
+		{"banned retired", models.LifecycleBanned, models.LifecycleRetired, true},
+		{"retired is final", models.LifecycleRetired, models.LifecycleRegistered, false},
+		{"no going back to registered", models.LifecycleWarming, models.LifecycleRegistered, false},
+		{"unknown state", "unknown", models.LifecycleReady, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isAllowedTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestLifecycleTransitions_CoverAllStates(t *testing.T) {
+	for _, state := range models.LifecycleStates {
+		_, ok := lifecycleTransitions[state]
+		assert.True(t, ok, "state %q has no transitions entry", state)
+	}
+
+	for from, targets := range lifecycleTransitions {
+		for _, to := range targets {
+			assert.NotEqual(t, from, to, "self transition of %q", from)
+			assert.Contains(t, models.LifecycleStates, to)
+		}
+	}
+
+	// Из любого нетерминального состояния аккаунт можно заблокировать и вывести из оборота
+	for _, state := range models.LifecycleStates {
+		if state.IsTerminal() {
+			continue
+		}
+		assert.True(t, isAllowedTransition(state, models.LifecycleBanned), "%q cannot be banned", state)
+		assert.True(t, isAllowedTransition(state, models.LifecycleRetired), "%q cannot be retired", state)
+	}
+}
+
+func TestResolveLifecycleState(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		event  lifecycleEvent
+		want   models.LifecycleState
+		ok     bool
+	}{
+		{"new status wins", "vk", lifecycleEvent{Type: "account.created", NewStatus: "banned"}, models.LifecycleBanned, true},
+		{"unknown new status", "vk", lifecycleEvent{Type: "account.created", NewStatus: "frozen"}, "", false},
+		{"exported is in use", "telegram", lifecycleEvent{Type: "account.status_changed", NewStatus: "exported"}, models.LifecycleInUse, true},
+		{"appealing is restricted", "vk", lifecycleEvent{Type: "account.status_changed", NewStatus: "appealing"}, models.LifecycleRestricted, true},
+		{"proxy allocated", "proxy", lifecycleEvent{Type: "proxy.allocated"}, models.LifecycleEnriched, true},
+		{"allocated outside proxy", "vk", lifecycleEvent{Type: "proxy.allocated"}, "", false},
+		{"warming started", "warming", lifecycleEvent{Type: "warming.task.started"}, models.LifecycleWarming, true},
+		{"warming finished", "warming", lifecycleEvent{Type: "warming.account.ready"}, models.LifecycleReady, true},
+		{"task started outside warming", "vk", lifecycleEvent{Type: "warming.task.started"}, "", false},
+		{"created", "vk", lifecycleEvent{Type: "created"}, models.LifecycleRegistered, true},
+		{"deleted", "mail", lifecycleEvent{Type: "account.deleted"}, models.LifecycleRetired, true},
+		{"banned", "max", lifecycleEvent{Type: "account.banned"}, models.LifecycleBanned, true},
+		{"status event", "telegram", lifecycleEvent{Type: "account.status_changed", Status: "suspended"}, models.LifecycleRestricted, true},
+		{"status ignored without status event", "telegram", lifecycleEvent{Type: "account.updated", Status: "ready"}, "", false},
+		{"unknown status", "telegram", lifecycleEvent{Type: "status", Status: "creating"}, "", false},
+		{"unrelated event", "vk", lifecycleEvent{Type: "error"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, ok := resolveLifecycleState(tt.source, &tt.event)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, state)
+		})
+	}
+}
+
+func TestSummarizeDurations(t *testing.T) {
+	stats := summarizeDurations(models.LifecycleWarming, []float64{7200, 3600, 36000, 10800})
+
+	assert.Equal(t, models.LifecycleWarming, stats.State)
+	assert.Equal(t, int64(4), stats.Samples)
+	assert.InDelta(t, 4, stats.AvgHours, 1e-9)
+	assert.InDelta(t, 2, stats.P50Hours, 1e-9)
+	assert.InDelta(t, 10, stats.P90Hours, 1e-9)
+	assert.InDelta(t, 10, stats.MaxHours, 1e-9)
+
+	empty := summarizeDurations(models.LifecycleReady, nil)
+	assert.Zero(t, empty.Samples)
+	assert.Zero(t, empty.AvgHours)
+}
@@ -75,6 +75,12 @@ var (
 		Buckets: prometheus.DefBuckets,
 	})
 
+	// Метрики вебхуков
+	webhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "analytics_webhook_deliveries_total",
+		Help: "Total number of webhook delivery outcomes (delivered, retried, dead_letter)",
+	}, []string{"webhook", "event", "result"})
+
 	// Метрики gRPC
 	grpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name: "analytics_grpc_request_duration_seconds",
@@ -168,6 +174,65 @@ var (
 		Name: "analytics_worker_errors_total",
 		Help: "Total number of worker errors",
 	}, []string{"worker"})
+
+	// Метрики жизненного цикла аккаунтов
+	lifecycleStateAccounts = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "analytics_account_lifecycle_state",
+		Help: "Number of accounts in each lifecycle state",
+	}, []string{"platform", "state"})
+
+	lifecycleTransitionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "analytics_lifecycle_transitions_total",
+		Help: "Total number of account lifecycle transitions",
+	}, []string{"platform", "from", "to"})
+
+	lifecycleRejectedTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "analytics_lifecycle_rejected_transitions_total",
+		Help: "Total number of invalid lifecycle transitions ignored",
+	}, []string{"from", "to"})
+
+	lifecycleStateDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "analytics_lifecycle_state_duration_hours",
+		Help: "Time spent by accounts in a lifecycle state in hours",
+		Buckets: []float64{1, 6, 24, 72, 168, 336, 720},
+	}, []string{"platform", "state"})
+
+	registrationStepDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "analytics_registration_step_duration_seconds",
+		Help: "Registration step duration reported by platform services, step \"total\" is the end-to-end time",
+		Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800},
+	}, []string{"platform", "step"})
+
+	appealOutcomesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "analytics_appeal_outcomes_total",
+		Help: "Total number of ban appeal outcomes",
+	}, []string{"platform", "outcome", "phone_source"})
+
+	// Публичные бизнес-KPI. Имена стабильны: на них завязаны внешние дашборды и алерты
+	kpiAccountsReady = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "conveer_accounts_ready",
+		Help: "Number of accounts ready for use",
+	}, []string{"platform"})
+
+	kpiBanRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "conveer_ban_rate_percent",
+		Help: "Share of banned accounts in percent",
+	}, []string{"platform"})
+
+	kpiSpendToday = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "conveer_spend_today",
+		Help: "Amount spent over the last 24 hours",
+	}, []string{"type"}) // type: sms, proxy, total
+
+	kpiWeatherIndex = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "conveer_platform_weather_index",
+		Help: "Platform health index from 0 (storm) to 100 (clear)",
+	}, []string{"platform"})
+
+	kpiLastUpdate = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "conveer_kpi_last_update_timestamp_seconds",
+		Help: "Unix timestamp of the last successful KPI update",
+	})
 )
 
 // UpdateBusinessMetrics обновляет бизнес-метрики на основе агрегированных данных
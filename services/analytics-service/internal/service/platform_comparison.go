@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+// ComparePlatforms сравнивает ключевые показатели платформ за последние days дней
+// с предыдущим периодом той же длины
+func (s *AnalyticsService) ComparePlatforms(ctx context.Context, days int) (*models.PlatformComparisonReport, error) {
+	if days <= 0 {
+		days = 7
+	}
+
+	now := time.Now()
+	periodStart := now.AddDate(0, 0, -days)
+	previousStart := periodStart.AddDate(0, 0, -days)
+
+	report := &models.PlatformComparisonReport{
+		Platforms:     make([]models.PlatformComparison, 0, len(models.ComparedPlatforms)),
+		PeriodStart:   periodStart,
+		PeriodEnd:     now,
+		PreviousStart: previousStart,
+		GeneratedAt:   now,
+	}
+
+	for _, platform := range models.ComparedPlatforms {
+		snapshots, err := s.metricsRepo.GetByTimeRange(ctx, platform, previousStart, now)
+		if err != nil {
+			return nil, err
+		}
+
+		// Снимки отсортированы по времени
+		split := len(snapshots)
+		for i, snapshot := range snapshots {
+			if !snapshot.Timestamp.Before(periodStart) {
+				split = i
+				break
+			}
+		}
+
+		comparison := models.PlatformComparison{
+			Platform: platform,
+			Current:  platformKPIs(snapshots[split:]),
+			Previous: platformKPIs(snapshots[:split]),
+		}
+		if comparison.Current.Samples > 0 && comparison.Previous.Samples > 0 {
+			comparison.Delta = &models.PlatformKPIDelta{
+				SuccessRate:     comparison.Current.SuccessRate - comparison.Previous.SuccessRate,
+				BanRate:         comparison.Current.BanRate - comparison.Previous.BanRate,
+				CostPerAccount:  comparison.Current.CostPerAccount - comparison.Previous.CostPerAccount,
+				AvgWarmingDays:  comparison.Current.AvgWarmingDays - comparison.Previous.AvgWarmingDays,
+				AccountsCreated: comparison.Current.AccountsCreated - comparison.Previous.AccountsCreated,
+			}
+		}
+
+		report.Platforms = append(report.Platforms, comparison)
+	}
+
+	return report, nil
+}
+
+// platformKPIs сводит снимки периода: доли и дни прогрева усредняются, а прирост аккаунтов
+// и расходов считается как разница накопительных значений последнего и первого снимка
+func platformKPIs(snapshots []models.AggregatedMetrics) models.PlatformKPIs {
+	kpis := models.PlatformKPIs{Samples: len(snapshots)}
+	if len(snapshots) == 0 {
+		return kpis
+	}
+
+	for _, snapshot := range snapshots {
+		kpis.SuccessRate += snapshot.SuccessRate
+		kpis.BanRate += snapshot.BanRate
+		kpis.AvgWarmingDays += snapshot.AvgWarmingDays
+	}
+	count := float64(len(snapshots))
+	kpis.SuccessRate /= count
+	kpis.BanRate /= count
+	kpis.AvgWarmingDays /= count
+
+	first, last := snapshots[0], snapshots[len(snapshots)-1]
+	// Сброс счетчиков в Prometheus дает отрицательный прирост, такой период не учитываем
+	if created := last.TotalAccounts - first.TotalAccounts; created > 0 {
+		kpis.AccountsCreated = created
+	}
+	if spent := last.TotalSpent - first.TotalSpent; spent > 0 {
+		kpis.TotalSpent = spent
+	}
+	if kpis.AccountsCreated > 0 {
+		kpis.CostPerAccount = kpis.TotalSpent / float64(kpis.AccountsCreated)
+	}
+
+	return kpis
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	sharedmodels "github.com/grigta/conveer/pkg/models"
+	"github.com/grigta/conveer/pkg/serviceauth"
+)
+
+// PreferencesClient клиент настроек уведомлений auth-service
+type PreferencesClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewPreferencesClient создает новый клиент настроек уведомлений. Получателей auth-service отдает
+// только по токену сервиса со скоупом auth-service
+func NewPreferencesClient(baseURL string, serviceAuth *serviceauth.Auth) *PreferencesClient {
+	return &PreferencesClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second, Transport: serviceAuth.Transport(nil)},
+	}
+}
+
+// Recipients возвращает получателей уведомления в указанном канале
+func (c *PreferencesClient) Recipients(ctx context.Context, category sharedmodels.NotificationCategory, value, platform string, channel sharedmodels.NotificationChannel) ([]sharedmodels.NotificationRecipient, error) {
+	query := url.Values{}
+	query.Set("category", string(category))
+	query.Set("value", value)
+	query.Set("platform", platform)
+	query.Set("channel", string(channel))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/notification-preferences/recipients?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth service returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Recipients []sharedmodels.NotificationRecipient `json:"recipients"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	return body.Recipients, nil
+}
@@ -16,11 +16,11 @@ import (
 // PrometheusClient клиент для работы с Prometheus
 type PrometheusClient struct {
 	api    v1.API
-	logger *logger.Logger
+	logger logger.Logger
 }
 
 // NewPrometheusClient создает новый клиент Prometheus
-func NewPrometheusClient(url string, logger *logger.Logger) (*PrometheusClient, error) {
+func NewPrometheusClient(url string, logger logger.Logger) (*PrometheusClient, error) {
 	client, err := api.NewClient(api.Config{
 		Address: url,
 	})
@@ -244,6 +244,42 @@ func (c *PrometheusClient) GetProxyMetrics(ctx context.Context) (map[string]inte
 	return metrics, nil
 }
 
+// GetProviderRegistrationStats получает исходы регистраций платформы по прокси-провайдерам.
+// Возвращает пустую карту, если платформа не публикует <platform>_proxy_provider_registrations_total
+func (c *PrometheusClient) GetProviderRegistrationStats(ctx context.Context, platform string) (map[string]*models.ProxyProviderStat, error) {
+	query := fmt.Sprintf(`sum by (provider, result) (increase(%s_proxy_provider_registrations_total[24h]))`, platform)
+	results, err := c.queryInstantVector(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make(map[string]map[string]float64)
+	for _, sample := range results {
+		provider := string(sample.Metric["provider"])
+		if provider == "" {
+			continue
+		}
+		if outcomes[provider] == nil {
+			outcomes[provider] = make(map[string]float64)
+		}
+		outcomes[provider][string(sample.Metric["result"])] = float64(sample.Value)
+	}
+
+	stats := make(map[string]*models.ProxyProviderStat)
+	for provider, counts := range outcomes {
+		attempts := counts["success"] + counts["failed"]
+		if attempts == 0 {
+			continue
+		}
+		stats[provider] = &models.ProxyProviderStat{
+			SuccessRate: counts["success"] / attempts * 100,
+			BanRate:     counts["banned"] / attempts * 100,
+		}
+	}
+
+	return stats, nil
+}
+
 // GetSMSMetrics получает метрики SMS
 func (c *PrometheusClient) GetSMSMetrics(ctx context.Context) (map[string]interface{}, error) {
 	metrics := make(map[string]interface{})
@@ -269,6 +305,19 @@ func (c *PrometheusClient) GetSMSMetrics(ctx context.Context) (map[string]interf
 		metrics["avg_price"] = float64(avgPriceResult.Value)
 	}
 
+	// Покупки, отложенные до пополнения номеров у провайдера
+	deferredQuery := `sum(increase(sms_purchases_deferred_total[24h]))`
+	deferredResult, err := c.queryInstant(ctx, deferredQuery)
+	if err == nil && deferredResult != nil {
+		metrics["deferred"] = int64(deferredResult.Value)
+	}
+
+	deferralRateQuery := `sum(increase(sms_scheduled_purchases_total{deferred="true"}[24h])) / sum(increase(sms_scheduled_purchases_total[24h]))`
+	deferralRateResult, err := c.queryInstant(ctx, deferralRateQuery)
+	if err == nil && deferralRateResult != nil {
+		metrics["deferral_rate"] = float64(deferralRateResult.Value)
+	}
+
 	return metrics, nil
 }
 
@@ -319,10 +368,10 @@ func (c *PrometheusClient) queryInstant(ctx context.Context, query string) (*mod
 
 	switch v := result.(type) {
 	case *model.Scalar:
-		return (*model.Sample)(v), nil
+		return &model.Sample{Value: v.Value, Timestamp: v.Timestamp}, nil
 	case model.Vector:
 		if len(v) > 0 {
-			return (*model.Sample)(&v[0]), nil
+			return v[0], nil
 		}
 	}
 
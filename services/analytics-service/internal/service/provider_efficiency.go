@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+// GetProviderEfficiency сопоставляет журнал расходов на прокси с исходами аккаунтов
+// и считает стоимость выжившего аккаунта по провайдерам.
+// Индекс эффективности 100 получает провайдер с самой дешевой стоимостью выжившего аккаунта,
+// остальные — пропорционально дороже; провайдер без выживших получает 0.
+func (t *LifecycleTracker) GetProviderEfficiency(ctx context.Context, days int) (*models.ProviderEfficiencyReport, error) {
+	window := t.reportWindow
+	if days > 0 {
+		window = time.Duration(days) * 24 * time.Hour
+	}
+	since := time.Now().Add(-window)
+
+	outcomes, err := t.lifecycleRepo.GetProviderCostOutcomes(ctx, models.CostCategoryProxy, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ProviderEfficiencyReport{
+		Providers:   make([]models.ProviderEfficiency, 0, len(outcomes)),
+		PeriodStart: since,
+		GeneratedAt: time.Now(),
+	}
+
+	var bestCost float64
+	for _, outcome := range outcomes {
+		efficiency := models.ProviderEfficiency{
+			Provider:  outcome.Provider,
+			Accounts:  outcome.Accounts,
+			Surviving: outcome.Surviving,
+			Banned:    outcome.Banned,
+			TotalCost: outcome.TotalCost,
+		}
+		if outcome.Accounts > 0 {
+			efficiency.SurvivalRate = float64(outcome.Surviving) / float64(outcome.Accounts) * 100
+			efficiency.CostPerAccount = outcome.TotalCost / float64(outcome.Accounts)
+		}
+		if outcome.Surviving > 0 {
+			efficiency.CostPerSurvivingAccount = outcome.TotalCost / float64(outcome.Surviving)
+			if bestCost == 0 || efficiency.CostPerSurvivingAccount < bestCost {
+				bestCost = efficiency.CostPerSurvivingAccount
+			}
+		}
+		report.Providers = append(report.Providers, efficiency)
+	}
+
+	for i := range report.Providers {
+		if cost := report.Providers[i].CostPerSurvivingAccount; cost > 0 {
+			report.Providers[i].EfficiencyIndex = bestCost / cost * 100
+		}
+	}
+
+	sort.Slice(report.Providers, func(i, j int) bool {
+		return report.Providers[i].EfficiencyIndex > report.Providers[j].EfficiencyIndex
+	})
+
+	return report, nil
+}
+
+// recordProxyCost заносит стоимость выданного прокси в журнал расходов
+func (t *LifecycleTracker) recordProxyCost(ctx context.Context, event *lifecycleEvent) {
+	entry := &models.CostEntry{
+		AccountID:  event.AccountID,
+		Category:   models.CostCategoryProxy,
+		Provider:   event.Provider,
+		Amount:     event.Cost,
+		Currency:   event.Currency,
+		SourceID:   event.ProxyID,
+		RecordedAt: time.Now(),
+	}
+	if err := t.lifecycleRepo.SaveCostEntry(ctx, entry); err != nil {
+		t.logger.WithError(err).WithField("account_id", event.AccountID).Error("Failed to save proxy cost")
+	}
+}
+
+// proxyPurchasedEvent событие proxy-service об оплате прокси провайдеру
+const proxyPurchasedEvent = "proxy.purchased"
+
+// recordProxyPurchase заносит оплату провайдеру в журнал расходов.
+// Запись не относится к аккаунту, поэтому не попадает в расходы аккаунтов и отчет об эффективности.
+func (t *LifecycleTracker) recordProxyPurchase(ctx context.Context, event *lifecycleEvent) {
+	if event.Provider == "" || event.Cost <= 0 {
+		return
+	}
+
+	entry := &models.CostEntry{
+		Category:   models.CostCategoryProxyPurchase,
+		Provider:   event.Provider,
+		Amount:     event.Cost,
+		Currency:   event.Currency,
+		RecordedAt: time.Now(),
+	}
+	if err := t.lifecycleRepo.SaveCostEntry(ctx, entry); err != nil {
+		t.logger.WithError(err).WithField("provider", event.Provider).Error("Failed to save proxy purchase cost")
+	}
+}
+
+// GetAccountCosts получает расходы аккаунтов; аккаунты без расходов получают нулевую сумму
+func (t *LifecycleTracker) GetAccountCosts(ctx context.Context, accountIDs []string) ([]models.AccountCost, error) {
+	costs, err := t.lifecycleRepo.GetAccountCosts(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byAccount := make(map[string]models.AccountCost, len(costs))
+	for _, cost := range costs {
+		byAccount[cost.AccountID] = cost
+	}
+
+	result := make([]models.AccountCost, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		cost, ok := byAccount[accountID]
+		if !ok {
+			cost = models.AccountCost{AccountID: accountID, ByCategory: map[string]float64{}}
+		}
+		result = append(result, cost)
+	}
+
+	return result, nil
+}
@@ -25,23 +25,33 @@ type Recommender struct {
 	recommendationRepo *repository.RecommendationRepository
 	grpcClients        map[string]*grpc.ClientConn
 	redisCache         *cache.RedisCache
-	logger             *logger.Logger
+	lifecycle          *LifecycleTracker
+	webhooks           *WebhookSink
+	logger             logger.Logger
 	interval           time.Duration
 }
 
+// minEfficiencyAccounts минимум аккаунтов с расходами, при котором эффективность
+// провайдера заменяет в балле фиксированные веса банов и стоимости
+const minEfficiencyAccounts = 10
+
 // NewRecommender создает новый сервис рекомендаций
 func NewRecommender(
 	metricsRepo *repository.MetricsRepository,
 	recommendationRepo *repository.RecommendationRepository,
 	grpcClients map[string]*grpc.ClientConn,
 	redisCache *cache.RedisCache,
-	logger *logger.Logger,
+	lifecycle *LifecycleTracker,
+	webhooks *WebhookSink,
+	logger logger.Logger,
 ) *Recommender {
 	return &Recommender{
 		metricsRepo:        metricsRepo,
 		recommendationRepo: recommendationRepo,
 		grpcClients:        grpcClients,
 		redisCache:         redisCache,
+		lifecycle:          lifecycle,
+		webhooks:           webhooks,
 		logger:             logger,
 		interval:           6 * time.Hour,
 	}
@@ -107,6 +117,18 @@ func (r *Recommender) generateRecommendations(ctx context.Context) error {
 	return nil
 }
 
+// saveRecommendation сохраняет рекомендацию и отправляет ее на вебхуки
+func (r *Recommender) saveRecommendation(ctx context.Context, recommendation *models.Recommendation) error {
+	if err := r.recommendationRepo.Save(ctx, recommendation); err != nil {
+		return err
+	}
+
+	if r.webhooks != nil {
+		r.webhooks.SendRecommendation(recommendation)
+	}
+	return nil
+}
+
 // rankProxyProviders создает рейтинг прокси-провайдеров
 func (r *Recommender) rankProxyProviders(ctx context.Context) error {
 	// Проверяем кэш
@@ -125,7 +147,7 @@ func (r *Recommender) rankProxyProviders(ctx context.Context) error {
 				ProxyRating: &cachedRanking,
 				ActionItems: r.generateProxyActionItems(cachedRanking.Rankings),
 			}
-			return r.recommendationRepo.Save(ctx, recommendation)
+			return r.saveRecommendation(ctx, recommendation)
 		}
 	}
 
@@ -142,6 +164,9 @@ func (r *Recommender) rankProxyProviders(ctx context.Context) error {
 		return nil
 	}
 
+	// Стоимость выжившего аккаунта по журналу расходов
+	efficiency := r.getProviderEfficiency(ctx)
+
 	// Получаем реальные данные прокси-провайдеров
 	var rankings []models.ProviderRank
 
@@ -163,22 +188,7 @@ func (r *Recommender) rankProxyProviders(ctx context.Context) error {
 					CostPerAccount: providerStat.CostPerProxy,
 				}
 
-				// Рассчитываем взвешенный балл
-				// Веса: success=0.4, ban_inv=0.3, latency_norm=0.2, cost_norm=0.1
-				rank.Score = 0.4*rank.SuccessRate +
-					0.3*(100-rank.BanRate) +
-					0.2*(100-math.Min(rank.AvgLatency*100, 100)) +
-					0.1*(100-math.Min(rank.CostPerAccount*5, 100))
-
-				// Определяем рекомендацию
-				if rank.Score > 80 {
-					rank.Recommendation = "use"
-				} else if rank.Score > 60 {
-					rank.Recommendation = "monitor"
-				} else {
-					rank.Recommendation = "avoid"
-				}
-
+				r.scoreProvider(&rank, efficiency[rank.Provider])
 				rankings = append(rankings, rank)
 			}
 		} else {
@@ -218,18 +228,7 @@ func (r *Recommender) rankProxyProviders(ctx context.Context) error {
 			stats.AvgLatency /= metricsCount
 			stats.CostPerAccount /= metricsCount
 
-			// Рассчитываем балл
-			stats.Score = r.calculateProviderScore(stats)
-
-			// Определяем рекомендацию
-			if stats.Score > 80 {
-				stats.Recommendation = "use"
-			} else if stats.Score > 60 {
-				stats.Recommendation = "monitor"
-			} else {
-				stats.Recommendation = "avoid"
-			}
-
+			r.scoreProvider(stats, efficiency[provider])
 			rankings = append(rankings, *stats)
 		}
 	}
@@ -257,7 +256,7 @@ func (r *Recommender) rankProxyProviders(ctx context.Context) error {
 		ActionItems: r.generateProxyActionItems(rankings),
 	}
 
-	if err := r.recommendationRepo.Save(ctx, recommendation); err != nil {
+	if err := r.saveRecommendation(ctx, recommendation); err != nil {
 		return err
 	}
 
@@ -307,7 +306,7 @@ func (r *Recommender) recommendWarmingScenarioForPlatform(ctx context.Context, p
 					"Мониторировать успешность и корректировать при необходимости",
 				},
 			}
-			return r.recommendationRepo.Save(ctx, recommendation)
+			return r.saveRecommendation(ctx, recommendation)
 		}
 	}
 
@@ -325,36 +324,35 @@ func (r *Recommender) recommendWarmingScenarioForPlatform(ctx context.Context, p
 	}
 
 	// Получаем реальные данные о сценариях из warming-service
-	var scenarios []struct {
-		Type        string
-		SuccessRate float64
-		AvgDays     float64
-	}
+	var scenarios []scenarioOutcome
+	experimentName := ""
 
 	if warmingClient := r.grpcClients["warming"]; warmingClient != nil {
-		// Вызываем gRPC метод GetScenarioStatistics
 		client := warmingpb.NewWarmingServiceClient(warmingClient)
-		resp, err := client.GetScenarioStatistics(ctx, &warmingpb.ScenarioStatisticsRequest{
-			Platform: platform,
-			Days:     7,
-		})
 
-		if err == nil && resp != nil && len(resp.ScenarioStats) > 0 {
-			// Используем реальные данные от warming-service
-			for _, scenarioStat := range resp.ScenarioStats {
-				scenarios = append(scenarios, struct {
-					Type        string
-					SuccessRate float64
-					AvgDays     float64
-				}{
-					Type:        scenarioStat.ScenarioType,
-					SuccessRate: scenarioStat.SuccessRate,
-					AvgDays:     scenarioStat.AvgDurationDays,
-				})
-			}
-		} else {
-			if err != nil && status.Code(err) != codes.Unimplemented {
-				r.logger.WithError(err).Warn("Failed to get scenario statistics from warming-service")
+		// Результаты A/B эксперимента измерены на одинаковых аккаунтах и важнее общей статистики
+		scenarios, experimentName = r.experimentScenarios(ctx, client, platform)
+
+		if len(scenarios) == 0 {
+			// Вызываем gRPC метод GetScenarioStatistics
+			resp, err := client.GetScenarioStatistics(ctx, &warmingpb.ScenarioStatisticsRequest{
+				Platform: platform,
+				Days:     7,
+			})
+
+			if err == nil && resp != nil && len(resp.ScenarioStats) > 0 {
+				// Используем реальные данные от warming-service
+				for _, scenarioStat := range resp.ScenarioStats {
+					scenarios = append(scenarios, scenarioOutcome{
+						Type:        scenarioStat.ScenarioType,
+						SuccessRate: scenarioStat.SuccessRate,
+						AvgDays:     scenarioStat.AvgDurationDays,
+					})
+				}
+			} else {
+				if err != nil && status.Code(err) != codes.Unimplemented {
+					r.logger.WithError(err).Warn("Failed to get scenario statistics from warming-service")
+				}
 			}
 		}
 	}
@@ -374,11 +372,7 @@ func (r *Recommender) recommendWarmingScenarioForPlatform(ctx context.Context, p
 			customSuccess *= successModifier
 		}
 
-		scenarios = []struct {
-			Type        string
-			SuccessRate float64
-			AvgDays     float64
-		}{
+		scenarios = []scenarioOutcome{
 			{"basic", basicSuccess, 14.0},
 			{"advanced", advancedSuccess, 21.0},
 			{"custom", customSuccess, 28.0},
@@ -390,12 +384,12 @@ func (r *Recommender) recommendWarmingScenarioForPlatform(ctx context.Context, p
 		return nil
 	}
 
+	// Успешность прогрева не видит блокировок после него, поэтому балл сценария
+	// с достаточной выборкой умножается на долю аккаунтов, доживших до 30-го дня
+	survival := r.getScenarioSurvival(ctx, platform)
+
 	// Выбираем лучший сценарий по соотношению успешности и времени
-	var bestScenario struct {
-		Type        string
-		SuccessRate float64
-		AvgDays     float64
-	}
+	var bestScenario scenarioOutcome
 	bestScore := 0.0
 
 	for _, scenario := range scenarios {
@@ -408,6 +402,9 @@ func (r *Recommender) recommendWarmingScenarioForPlatform(ctx context.Context, p
 		}
 
 		score := scenario.SuccessRate / timePenalty
+		if curve, ok := survival[scenario.Type]; ok {
+			score *= curve.Survival30d
+		}
 		if score > bestScore {
 			bestScore = score
 			bestScenario = scenario
@@ -432,6 +429,12 @@ func (r *Recommender) recommendWarmingScenarioForPlatform(ctx context.Context, p
 	case "custom":
 		reasoning = fmt.Sprintf("Кастомный сценарий максимизирует успешность для %s аккаунтов", platform)
 	}
+	if experimentName != "" {
+		reasoning += fmt.Sprintf(" (успешность измерена в эксперименте %s)", experimentName)
+	}
+	if curve, ok := survival[bestScenario.Type]; ok {
+		reasoning += fmt.Sprintf("; выживаемость 30 дней: %.0f%% по %d аккаунтам", curve.Survival30d*100, curve.Accounts)
+	}
 
 	// Создаем рекомендацию
 	recommendation := &models.Recommendation{
@@ -453,7 +456,7 @@ func (r *Recommender) recommendWarmingScenarioForPlatform(ctx context.Context, p
 		},
 	}
 
-	if err := r.recommendationRepo.Save(ctx, recommendation); err != nil {
+	if err := r.saveRecommendation(ctx, recommendation); err != nil {
 		return err
 	}
 
@@ -472,6 +475,57 @@ func (r *Recommender) recommendWarmingScenarioForPlatform(ctx context.Context, p
 	return nil
 }
 
+// scenarioOutcome успешность и длительность сценария прогрева
+type scenarioOutcome struct {
+	Type        string
+	SuccessRate float64
+	AvgDays     float64
+}
+
+// maxExperimentsChecked ограничивает число последних экспериментов, результаты которых запрашиваются
+const maxExperimentsChecked = 5
+
+// experimentScenarios возвращает успешность вариантов последнего эксперимента платформы
+// со значимым результатом; пустой результат, если такого эксперимента нет
+func (r *Recommender) experimentScenarios(ctx context.Context, client warmingpb.WarmingServiceClient, platform string) ([]scenarioOutcome, string) {
+	resp, err := client.ListExperiments(ctx, &warmingpb.ListExperimentsRequest{Platform: platform})
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			r.logger.WithError(err).Warn("Failed to list warming experiments")
+		}
+		return nil, ""
+	}
+
+	for i, experiment := range resp.Experiments {
+		if i >= maxExperimentsChecked {
+			break
+		}
+
+		results, err := client.GetExperimentResults(ctx, &warmingpb.ExperimentRequest{ExperimentId: experiment.Id})
+		if err != nil {
+			r.logger.WithError(err).WithField("experiment", experiment.Name).Warn("Failed to get warming experiment results")
+			continue
+		}
+
+		// Без победителя разница между вариантами не значима
+		if results.Winner == "" {
+			continue
+		}
+
+		scenarios := make([]scenarioOutcome, 0, len(results.Variants))
+		for _, variant := range results.Variants {
+			scenarios = append(scenarios, scenarioOutcome{
+				Type:        variant.ScenarioType,
+				SuccessRate: variant.ReadyRate,
+				AvgDays:     float64(variant.DurationDays),
+			})
+		}
+		return scenarios, results.Name
+	}
+
+	return nil, ""
+}
+
 // analyzeErrorPatterns анализирует паттерны ошибок
 func (r *Recommender) analyzeErrorPatterns(ctx context.Context) error {
 	// Проверяем кэш
@@ -496,7 +550,7 @@ func (r *Recommender) analyzeErrorPatterns(ctx context.Context) error {
 				ErrorPattern: &cachedAnalysis,
 				ActionItems:  r.generateErrorActionItems(cachedAnalysis.Clusters),
 			}
-			return r.recommendationRepo.Save(ctx, recommendation)
+			return r.saveRecommendation(ctx, recommendation)
 		}
 	}
 
@@ -580,7 +634,7 @@ func (r *Recommender) analyzeErrorPatterns(ctx context.Context) error {
 		ActionItems: r.generateErrorActionItems(clusters),
 	}
 
-	if err := r.recommendationRepo.Save(ctx, recommendation); err != nil {
+	if err := r.saveRecommendation(ctx, recommendation); err != nil {
 		return err
 	}
 
@@ -595,12 +649,89 @@ func (r *Recommender) analyzeErrorPatterns(ctx context.Context) error {
 	return nil
 }
 
-// calculateProviderScore рассчитывает общий балл провайдера
-func (r *Recommender) calculateProviderScore(rank *models.ProviderRank) float64 {
+// getProviderEfficiency получает эффективность расходов провайдеров с достаточной выборкой
+func (r *Recommender) getProviderEfficiency(ctx context.Context) map[string]*models.ProviderEfficiency {
+	result := make(map[string]*models.ProviderEfficiency)
+	if r.lifecycle == nil {
+		return result
+	}
+
+	report, err := r.lifecycle.GetProviderEfficiency(ctx, 0)
+	if err != nil {
+		r.logger.WithError(err).Warn("Failed to get provider efficiency, using fixed score weights")
+		return result
+	}
+
+	for i := range report.Providers {
+		if report.Providers[i].Accounts >= minEfficiencyAccounts {
+			result[report.Providers[i].Provider] = &report.Providers[i]
+		}
+	}
+	return result
+}
+
+// minSurvivalAccounts минимум аккаунтов в когорте сценария, при котором выживаемость влияет на выбор
+const minSurvivalAccounts = 20
+
+// getScenarioSurvival получает кривые выживаемости сценариев прогрева платформы с достаточной выборкой
+func (r *Recommender) getScenarioSurvival(ctx context.Context, platform string) map[string]*models.SurvivalCurve {
+	result := make(map[string]*models.SurvivalCurve)
+	if r.lifecycle == nil {
+		return result
+	}
+
+	report, err := r.lifecycle.GetSurvival(ctx, models.SurvivalFilter{
+		Platform: platform,
+		GroupBy:  []string{models.SurvivalGroupScenario},
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("platform", platform).Warn("Failed to get scenario survival, ranking scenarios without it")
+		return result
+	}
+
+	for i := range report.Curves {
+		if report.Curves[i].Accounts >= minSurvivalAccounts {
+			result[report.Curves[i].Scenario] = &report.Curves[i]
+		}
+	}
+	return result
+}
+
+// scoreProvider рассчитывает балл провайдера и рекомендацию
+func (r *Recommender) scoreProvider(rank *models.ProviderRank, efficiency *models.ProviderEfficiency) {
+	if efficiency != nil {
+		rank.SurvivalRate = efficiency.SurvivalRate
+		rank.CostPerSurvivingAccount = efficiency.CostPerSurvivingAccount
+		rank.EfficiencyIndex = efficiency.EfficiencyIndex
+	}
+	rank.Score = r.calculateProviderScore(rank, efficiency)
+
+	if rank.Score > 80 {
+		rank.Recommendation = "use"
+	} else if rank.Score > 60 {
+		rank.Recommendation = "monitor"
+	} else {
+		rank.Recommendation = "avoid"
+	}
+}
+
+// calculateProviderScore рассчитывает общий балл провайдера.
+// Если известна стоимость выжившего аккаунта, она заменяет отдельные веса банов и стоимости:
+// индекс эффективности уже учитывает и цену прокси, и долю потерянных аккаунтов.
+func (r *Recommender) calculateProviderScore(rank *models.ProviderRank, efficiency *models.ProviderEfficiency) float64 {
+	latencyScore := 100 - math.Min(rank.AvgLatency*100, 100)
+
+	if efficiency != nil {
+		// Веса: success_rate=0.4, latency=0.2, efficiency=0.4
+		return 0.4*rank.SuccessRate +
+			0.2*latencyScore +
+			0.4*efficiency.EfficiencyIndex
+	}
+
 	// Веса: success_rate=0.4, ban_rate=0.3, latency=0.2, cost=0.1
 	return 0.4*rank.SuccessRate +
 		0.3*(100-rank.BanRate) +
-		0.2*(100-math.Min(rank.AvgLatency*100, 100)) +
+		0.2*latencyScore +
 		0.1*(100-math.Min(rank.CostPerAccount*5, 100))
 }
 
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+// registrationStepEvent событие платформенного сервиса о завершении шага регистрации.
+// Шаг models.RegistrationStepTotal несет полное время успешной регистрации.
+const registrationStepEvent = "registration.step_completed"
+
+// recordRegistrationStep сохраняет длительность шага регистрации
+func (t *LifecycleTracker) recordRegistrationStep(ctx context.Context, source string, event *lifecycleEvent) {
+	if event.Step == "" || event.DurationSec <= 0 {
+		return
+	}
+
+	platform := event.Platform
+	if platform == "" {
+		platform = source
+	}
+
+	registrationStepDuration.WithLabelValues(platform, event.Step).Observe(event.DurationSec)
+
+	sample := &models.RegistrationStepSample{
+		Platform:    platform,
+		AccountID:   event.AccountID,
+		Step:        event.Step,
+		DurationSec: event.DurationSec,
+		RecordedAt:  time.Now(),
+	}
+	if err := t.lifecycleRepo.SaveRegistrationStep(ctx, sample); err != nil {
+		t.logger.WithError(err).WithField("account_id", event.AccountID).Error("Failed to save registration step")
+	}
+}
+
+// GetRegistrationLatency считает перцентили p50/p90/p99 полного времени регистрации
+// и каждого шага по платформам. Пустая платформа или "all" — все платформы.
+func (t *LifecycleTracker) GetRegistrationLatency(ctx context.Context, platform string, days int) (*models.RegistrationLatencyReport, error) {
+	window := t.reportWindow
+	if days > 0 {
+		window = time.Duration(days) * 24 * time.Hour
+	}
+	since := time.Now().Add(-window)
+
+	durations, err := t.lifecycleRepo.GetRegistrationStepDurations(ctx, platform, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.RegistrationLatencyReport{
+		Platforms:   make([]models.PlatformRegistrationLatency, 0, len(durations)),
+		PeriodStart: since,
+		GeneratedAt: time.Now(),
+	}
+
+	for name, steps := range durations {
+		latency := models.PlatformRegistrationLatency{
+			Platform: name,
+			EndToEnd: summarizeLatency(models.RegistrationStepTotal, steps[models.RegistrationStepTotal]),
+			Steps:    make([]models.LatencyPercentiles, 0, len(steps)),
+		}
+		for step, samples := range steps {
+			if step == models.RegistrationStepTotal {
+				continue
+			}
+			latency.Steps = append(latency.Steps, summarizeLatency(step, samples))
+		}
+		sort.Slice(latency.Steps, func(i, j int) bool {
+			return latency.Steps[i].Step < latency.Steps[j].Step
+		})
+		report.Platforms = append(report.Platforms, latency)
+	}
+
+	sort.Slice(report.Platforms, func(i, j int) bool {
+		return report.Platforms[i].Platform < report.Platforms[j].Platform
+	})
+
+	return report, nil
+}
+
+// summarizeLatency считает перцентили отсортированной выборки длительностей
+func summarizeLatency(step string, sorted []float64) models.LatencyPercentiles {
+	return models.LatencyPercentiles{
+		Step:    step,
+		Samples: int64(len(sorted)),
+		P50Sec:  percentile(sorted, 0.5),
+		P90Sec:  percentile(sorted, 0.9),
+		P99Sec:  percentile(sorted, 0.99),
+	}
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+	"github.com/grigta/conveer/services/analytics-service/internal/repository"
+)
+
+const summaryDateLayout = "2006-01-02"
+
+var (
+	// ErrInvalidSummaryDate дата сводки не в формате YYYY-MM-DD
+	ErrInvalidSummaryDate = errors.New("invalid summary date, expected YYYY-MM-DD")
+	// ErrDayNotFinished сводка составляется только за закончившиеся сутки
+	ErrDayNotFinished = errors.New("summary is available after the day is over")
+)
+
+// SummaryGenerator превращает метрики суток в текст сводки
+type SummaryGenerator interface {
+	Name() string
+	Generate(ctx context.Context, summary *models.DailySummary) (string, error)
+}
+
+// TemplateSummaryGenerator составляет сводку по шаблону, работает всегда
+type TemplateSummaryGenerator struct{}
+
+// Name возвращает имя генератора
+func (TemplateSummaryGenerator) Name() string {
+	return models.SummaryGeneratorTemplate
+}
+
+// Generate составляет текст сводки
+func (TemplateSummaryGenerator) Generate(ctx context.Context, summary *models.DailySummary) (string, error) {
+	return RenderDailySummary(summary, false), nil
+}
+
+// HTTPSummaryGenerator пересказывает метрики через OpenAI-совместимый chat completions API
+type HTTPSummaryGenerator struct {
+	endpoint string
+	apiKey   string
+	model    string
+	client   *http.Client
+}
+
+// NewHTTPSummaryGenerator создает LLM-генератор сводки
+func NewHTTPSummaryGenerator(endpoint, apiKey, model string, timeout time.Duration) *HTTPSummaryGenerator {
+	return &HTTPSummaryGenerator{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		model:    model,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Name возвращает имя генератора
+func (g *HTTPSummaryGenerator) Name() string {
+	return models.SummaryGeneratorLLM
+}
+
+// Generate просит модель пересказать метрики, цифры берутся только из переданных данных
+func (g *HTTPSummaryGenerator) Generate(ctx context.Context, summary *models.DailySummary) (string, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"date":     summary.Date,
+		"metrics":  summary.Metrics,
+		"previous": summary.Previous,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	prompt := "Составь короткую сводку (3-5 предложений, обычный текст без разметки) о работе фермы аккаунтов за сутки " +
+		"по метрикам в JSON. previous — предыдущие сутки для сравнения, суммы в рублях, rate — проценты. " +
+		"Используй только числа из данных, выдели заметные изменения. Метрики:\n" + string(data)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model": g.model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"temperature": 0.2,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.apiKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("llm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("llm returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode llm response: %w", err)
+	}
+	if len(result.Choices) == 0 || strings.TrimSpace(result.Choices[0].Message.Content) == "" {
+		return "", fmt.Errorf("llm returned no text")
+	}
+
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
+
+// ReportComposer составляет ежедневные сводки по агрегированным метрикам и хранит их рядом с метриками
+type ReportComposer struct {
+	metricsRepo *repository.MetricsRepository
+	generator   SummaryGenerator // LLM-генератор, nil — только шаблон
+	location    *time.Location
+	hour        int
+	logger      logger.Logger
+}
+
+// NewReportComposer создает составителя сводок; сводка за сутки составляется после hour часов следующего дня
+func NewReportComposer(
+	metricsRepo *repository.MetricsRepository,
+	generator SummaryGenerator,
+	location *time.Location,
+	hour int,
+	logger logger.Logger,
+) *ReportComposer {
+	return &ReportComposer{
+		metricsRepo: metricsRepo,
+		generator:   generator,
+		location:    location,
+		hour:        hour,
+		logger:      logger,
+	}
+}
+
+// Run составляет сводку за прошедшие сутки, как только наступает заданный час
+func (c *ReportComposer) Run(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	c.composeDue(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			c.composeDue(ctx)
+		case <-ctx.Done():
+			c.logger.Info("Stopping report composer")
+			return
+		}
+	}
+}
+
+func (c *ReportComposer) composeDue(ctx context.Context) {
+	now := time.Now().In(c.location)
+	if now.Hour() < c.hour {
+		return
+	}
+
+	yesterday := now.AddDate(0, 0, -1).Format(summaryDateLayout)
+	existing, err := c.metricsRepo.GetDailySummary(ctx, yesterday)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to check daily summary")
+		return
+	}
+	if existing != nil {
+		return
+	}
+
+	RecordWorkerRun("report_composer")
+	if _, err := c.Compose(ctx, yesterday); err != nil {
+		c.logger.WithError(err).WithField("date", yesterday).Error("Failed to compose daily summary")
+		RecordWorkerError("report_composer")
+	}
+}
+
+// GetDailySummary получает сводку за сутки date (по умолчанию вчерашние), составляя ее, если ее еще нет
+func (c *ReportComposer) GetDailySummary(ctx context.Context, date string) (*models.DailySummary, error) {
+	if date == "" {
+		date = time.Now().In(c.location).AddDate(0, 0, -1).Format(summaryDateLayout)
+	}
+
+	summary, err := c.metricsRepo.GetDailySummary(ctx, date)
+	if err != nil || summary != nil {
+		return summary, err
+	}
+
+	return c.Compose(ctx, date)
+}
+
+// Compose составляет и сохраняет сводку за сутки date. LLM-генератор используется, если задан,
+// при его ошибке текст составляется по шаблону.
+func (c *ReportComposer) Compose(ctx context.Context, date string) (*models.DailySummary, error) {
+	start, err := time.ParseInLocation(summaryDateLayout, date, c.location)
+	if err != nil {
+		return nil, ErrInvalidSummaryDate
+	}
+	end := start.AddDate(0, 0, 1)
+	if end.After(time.Now()) {
+		return nil, ErrDayNotFinished
+	}
+
+	current, err := c.dayMetrics(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.DailySummary{
+		Date:        date,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Metrics:     current,
+		GeneratedAt: time.Now(),
+	}
+
+	previous, err := c.dayMetrics(ctx, start.AddDate(0, 0, -1), start)
+	if err != nil {
+		c.logger.WithError(err).Warn("Failed to get previous day metrics for summary")
+	} else if previous.Snapshots > 0 {
+		summary.Previous = &previous
+	}
+
+	summary.Generator = models.SummaryGeneratorTemplate
+	summary.Text = RenderDailySummary(summary, false)
+	if c.generator != nil && current.Snapshots > 0 {
+		if text, err := c.generator.Generate(ctx, summary); err != nil {
+			c.logger.WithError(err).Warn("Summary generator failed, using template")
+		} else {
+			summary.Text = text
+			summary.Generator = c.generator.Name()
+		}
+	}
+
+	if err := c.metricsRepo.SaveDailySummary(ctx, summary); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (c *ReportComposer) dayMetrics(ctx context.Context, start, end time.Time) (models.DailySummaryMetrics, error) {
+	snapshots, err := c.metricsRepo.GetByTimeRange(ctx, "all", start, end)
+	if err != nil {
+		return models.DailySummaryMetrics{}, err
+	}
+	return summarizeSnapshots(snapshots), nil
+}
+
+// summarizeSnapshots сводит снимки платформы all за сутки: приросты считаются между первым
+// и последним снимком, расходы суммируются, текущие значения берутся из последнего снимка
+func summarizeSnapshots(snapshots []models.AggregatedMetrics) models.DailySummaryMetrics {
+	var overall []models.AggregatedMetrics
+	for _, snapshot := range snapshots {
+		if snapshot.Platform == "all" {
+			overall = append(overall, snapshot)
+		}
+	}
+
+	var result models.DailySummaryMetrics
+	if len(overall) == 0 {
+		return result
+	}
+
+	first, last := overall[0], overall[len(overall)-1]
+	result.Snapshots = len(overall)
+	result.TotalAccounts = last.TotalAccounts
+	result.AccountsCreated = nonNegative(last.TotalAccounts - first.TotalAccounts)
+	result.AccountsReady = nonNegative(last.AccountsByStatus["ready"] - first.AccountsByStatus["ready"])
+	result.AccountsBanned = nonNegative(last.AccountsByStatus["banned"] - first.AccountsByStatus["banned"])
+	result.BanRate = last.BanRate
+	result.SuccessRate = last.SuccessRate
+	result.ErrorRate = last.ErrorRate
+	result.TopErrors = last.TopErrors
+	result.WarmingActive = last.WarmingActive
+	result.ActiveProxies = last.ActiveProxies
+	result.BannedProxies = last.BannedProxies
+
+	for _, snapshot := range overall {
+		result.TotalSpent += snapshot.TotalSpent
+		result.SMSSpent += snapshot.SMSSpent
+		result.ProxySpent += snapshot.ProxySpent
+	}
+
+	return result
+}
+
+func nonNegative(value int64) int64 {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
+
+// RenderDailySummary составляет текст сводки по шаблону. С hideMoney суммы не выводятся,
+// остается только их изменение к предыдущим суткам в процентах.
+func RenderDailySummary(summary *models.DailySummary, hideMoney bool) string {
+	m, prev := summary.Metrics, summary.Previous
+
+	day := summary.Date
+	if parsed, err := time.Parse(summaryDateLayout, summary.Date); err == nil {
+		day = parsed.Format("02.01.2006")
+	}
+
+	if m.Snapshots == 0 {
+		return fmt.Sprintf("За %s нет данных агрегации.", day)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Сводка за %s.\n", day)
+
+	fmt.Fprintf(&b, "Аккаунты: создано %d", m.AccountsCreated)
+	if prev != nil {
+		b.WriteString(" " + countChange(m.AccountsCreated, prev.AccountsCreated))
+	}
+	fmt.Fprintf(&b, ", готово к работе %d, заблокировано %d", m.AccountsReady, m.AccountsBanned)
+	if prev != nil {
+		b.WriteString(" " + countChange(m.AccountsBanned, prev.AccountsBanned))
+	}
+	fmt.Fprintf(&b, ". Всего %d, ban rate %.1f%%, успешность %.1f%%.\n", m.TotalAccounts, m.BanRate, m.SuccessRate)
+
+	switch {
+	case !hideMoney:
+		fmt.Fprintf(&b, "Расходы: %.2f ₽ (SMS %.2f ₽, прокси %.2f ₽)", m.TotalSpent, m.SMSSpent, m.ProxySpent)
+		if change := spendChange(m.TotalSpent, prev); change != "" {
+			b.WriteString(", " + change)
+		}
+		b.WriteString(".\n")
+	case spendChange(m.TotalSpent, prev) != "":
+		fmt.Fprintf(&b, "Расходы: %s.\n", spendChange(m.TotalSpent, prev))
+	}
+
+	fmt.Fprintf(&b, "Ресурсы: задач прогрева %d, активных прокси %d, заблокированных прокси %d.\n",
+		m.WarmingActive, m.ActiveProxies, m.BannedProxies)
+
+	fmt.Fprintf(&b, "Ошибки: error rate %.1f%%", m.ErrorRate)
+	if len(m.TopErrors) > 0 {
+		top := m.TopErrors
+		if len(top) > 3 {
+			top = top[:3]
+		}
+		parts := make([]string, 0, len(top))
+		for _, e := range top {
+			parts = append(parts, fmt.Sprintf("%s (%d)", e.Type, e.Count))
+		}
+		b.WriteString(", чаще всего " + strings.Join(parts, ", "))
+	}
+	b.WriteString(".")
+
+	return b.String()
+}
+
+// countChange описывает изменение счетчика к предыдущим суткам
+func countChange(current, previous int64) string {
+	if current == previous {
+		return "(без изменений)"
+	}
+	return fmt.Sprintf("(%+d к предыдущим суткам)", current-previous)
+}
+
+// spendChange описывает изменение расходов к предыдущим суткам в процентах
+func spendChange(current float64, prev *models.DailySummaryMetrics) string {
+	if prev == nil || prev.TotalSpent <= 0 {
+		return ""
+	}
+
+	change := (current - prev.TotalSpent) / prev.TotalSpent * 100
+	switch {
+	case change >= 0.5:
+		return fmt.Sprintf("на %.0f%% больше, чем накануне", change)
+	case change <= -0.5:
+		return fmt.Sprintf("на %.0f%% меньше, чем накануне", -change)
+	default:
+		return "на уровне предыдущих суток"
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+// cleanupReportEvent суточный отчет платформенного сервиса о компенсации брошенных регистраций
+const cleanupReportEvent = "registration.cleanup_report"
+
+// cleanupReportPayload тело события registration.cleanup_report
+type cleanupReportPayload struct {
+	Platform    string           `json:"platform"`
+	Sessions    int64            `json:"sessions"`
+	Reclaimed   map[string]int64 `json:"reclaimed"`
+	Failed      map[string]int64 `json:"failed"`
+	PeriodStart int64            `json:"period_start"`
+	PeriodEnd   int64            `json:"period_end"`
+}
+
+// recordCleanupReport сохраняет суточный отчет об освобожденных ресурсах
+func (t *LifecycleTracker) recordCleanupReport(ctx context.Context, source string, body []byte) {
+	var payload cleanupReportPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		var raw string
+		if json.Unmarshal(body, &raw) != nil || json.Unmarshal([]byte(raw), &payload) != nil {
+			return
+		}
+	}
+
+	platform := payload.Platform
+	if platform == "" {
+		platform = source
+	}
+
+	report := &models.ResourceCleanupReport{
+		Platform:    platform,
+		Sessions:    payload.Sessions,
+		Reclaimed:   payload.Reclaimed,
+		Failed:      payload.Failed,
+		PeriodStart: time.Unix(payload.PeriodStart, 0),
+		PeriodEnd:   time.Unix(payload.PeriodEnd, 0),
+		ReceivedAt:  time.Now(),
+	}
+	if err := t.lifecycleRepo.SaveCleanupReport(ctx, report); err != nil {
+		t.logger.WithError(err).WithField("platform", platform).Error("Failed to save cleanup report")
+	}
+}
+
+// GetResourceCleanup суммирует суточные отчеты платформ об освобожденных ресурсах за период
+func (t *LifecycleTracker) GetResourceCleanup(ctx context.Context, days int) (*models.ResourceCleanupSummary, error) {
+	window := t.reportWindow
+	if days > 0 {
+		window = time.Duration(days) * 24 * time.Hour
+	}
+	since := time.Now().Add(-window)
+
+	reports, err := t.lifecycleRepo.GetCleanupReports(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.ResourceCleanupSummary{
+		Platforms:   []models.PlatformCleanup{},
+		Reclaimed:   make(map[string]int64),
+		Failed:      make(map[string]int64),
+		PeriodStart: since,
+		GeneratedAt: time.Now(),
+	}
+
+	byPlatform := make(map[string]*models.PlatformCleanup)
+	for _, report := range reports {
+		platform, ok := byPlatform[report.Platform]
+		if !ok {
+			platform = &models.PlatformCleanup{
+				Platform:  report.Platform,
+				Reclaimed: make(map[string]int64),
+				Failed:    make(map[string]int64),
+			}
+			byPlatform[report.Platform] = platform
+		}
+
+		platform.Sessions += report.Sessions
+		summary.Sessions += report.Sessions
+		for resource, count := range report.Reclaimed {
+			platform.Reclaimed[resource] += count
+			summary.Reclaimed[resource] += count
+		}
+		for resource, count := range report.Failed {
+			platform.Failed[resource] += count
+			summary.Failed[resource] += count
+		}
+	}
+
+	for _, platform := range byPlatform {
+		summary.Platforms = append(summary.Platforms, *platform)
+	}
+	sort.Slice(summary.Platforms, func(i, j int) bool {
+		return summary.Platforms[i].Platform < summary.Platforms[j].Platform
+	})
+
+	return summary, nil
+}
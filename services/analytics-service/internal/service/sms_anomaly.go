@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+const (
+	smsAnomalyQueue      = "analytics.sms.anomalies"
+	smsAnomalyRoutingKey = "sms.code.anomaly"
+	smsAnomalyRuleName   = "sms_code_anomaly"
+)
+
+// smsAnomalyEvent событие sms-service о полученном SMS, не прошедшем проверку
+type smsAnomalyEvent struct {
+	ActivationID string    `json:"activation_id"`
+	Service      string    `json:"service"`
+	Country      string    `json:"country"`
+	Provider     string    `json:"provider"`
+	PhoneNumber  string    `json:"phone_number"`
+	Anomalies    []string  `json:"anomalies"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// ConsumeSMSAnomalies сохраняет аномалии входящих SMS как события алертов
+func (a *AlertManager) ConsumeSMSAnomalies(ctx context.Context) {
+	if err := a.rabbitmq.DeclareExchange("sms.events", "topic", true, false); err != nil {
+		a.logger.WithError(err).Error("Failed to declare sms.events exchange")
+		return
+	}
+	if _, err := a.rabbitmq.DeclareQueue(smsAnomalyQueue, true, false, false); err != nil {
+		a.logger.WithError(err).Error("Failed to declare SMS anomaly queue")
+		return
+	}
+	if err := a.rabbitmq.BindQueue(smsAnomalyQueue, smsAnomalyRoutingKey, "sms.events"); err != nil {
+		a.logger.WithError(err).Error("Failed to bind SMS anomaly queue")
+		return
+	}
+
+	handler := func(body []byte) error {
+		return a.handleSMSAnomaly(ctx, body)
+	}
+	if err := a.rabbitmq.ConsumeWithHandler(ctx, smsAnomalyQueue, "analytics-sms-anomalies", handler); err != nil {
+		a.logger.WithError(err).Error("Failed to consume SMS anomalies")
+	}
+}
+
+func (a *AlertManager) handleSMSAnomaly(ctx context.Context, body []byte) error {
+	var event smsAnomalyEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil // Не переотправляем битые сообщения
+	}
+
+	// Фишинг на купленный номер требует внимания оператора, остальные аномалии только учитываются
+	severity := "medium"
+	for _, anomaly := range event.Anomalies {
+		if anomaly == "phishing" {
+			severity = "high"
+		}
+	}
+
+	firedAt := event.Timestamp
+	if firedAt.IsZero() {
+		firedAt = time.Now()
+	}
+
+	alert := &models.AlertEvent{
+		RuleName:     smsAnomalyRuleName,
+		Severity:     severity,
+		Platform:     smsServicePlatform(event.Service),
+		Message:      fmt.Sprintf("Подозрительное SMS для активации %s (%s, %s): %s", event.ActivationID, event.Provider, event.Country, strings.Join(event.Anomalies, ", ")),
+		CurrentValue: float64(len(event.Anomalies)),
+		FiredAt:      firedAt,
+	}
+
+	if err := a.alertRepo.SaveAlertEvent(ctx, alert); err != nil {
+		return err
+	}
+
+	alertsFired.WithLabelValues(severity, smsAnomalyRuleName, alert.Platform).Inc()
+
+	if severity == "high" {
+		if err := a.publishAlertEvent(ctx, alert); err != nil {
+			a.logger.WithError(err).Error("Failed to publish SMS anomaly alert")
+		}
+	}
+
+	if a.webhooks != nil {
+		a.webhooks.SendAlert(alert)
+	}
+
+	return nil
+}
+
+// smsServicePlatform приводит название сервиса в sms-service к платформе аналитики
+func smsServicePlatform(service string) string {
+	switch strings.ToLower(service) {
+	case "mail.ru":
+		return "mail"
+	case "vk", "telegram", "max":
+		return strings.ToLower(service)
+	default:
+		return "all"
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+const (
+	smsRentalQueue      = "analytics.sms.rentals"
+	smsRentalRoutingKey = "sms.rental.expiring"
+	smsRentalRuleName   = "sms_rental_expiring"
+)
+
+// smsRentalEvent событие sms-service об аренде номера, срок которой скоро истекает
+type smsRentalEvent struct {
+	RentalID    string    `json:"rental_id"`
+	AccountID   string    `json:"account_id"`
+	Service     string    `json:"service"`
+	Country     string    `json:"country"`
+	Provider    string    `json:"provider"`
+	PhoneNumber string    `json:"phone_number"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	HoursLeft   float64   `json:"hours_left"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// ConsumeSMSRentals сохраняет предупреждения об истечении аренды номеров как события алертов
+func (a *AlertManager) ConsumeSMSRentals(ctx context.Context) {
+	if err := a.rabbitmq.DeclareExchange("sms.events", "topic", true, false); err != nil {
+		a.logger.WithError(err).Error("Failed to declare sms.events exchange")
+		return
+	}
+	if _, err := a.rabbitmq.DeclareQueue(smsRentalQueue, true, false, false); err != nil {
+		a.logger.WithError(err).Error("Failed to declare SMS rental queue")
+		return
+	}
+	if err := a.rabbitmq.BindQueue(smsRentalQueue, smsRentalRoutingKey, "sms.events"); err != nil {
+		a.logger.WithError(err).Error("Failed to bind SMS rental queue")
+		return
+	}
+
+	handler := func(body []byte) error {
+		return a.handleSMSRental(ctx, body)
+	}
+	if err := a.rabbitmq.ConsumeWithHandler(ctx, smsRentalQueue, "analytics-sms-rentals", handler); err != nil {
+		a.logger.WithError(err).Error("Failed to consume SMS rentals")
+	}
+}
+
+func (a *AlertManager) handleSMSRental(ctx context.Context, body []byte) error {
+	var event smsRentalEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil // Не переотправляем битые сообщения
+	}
+
+	// Без номера привязанный аккаунт не пройдет повторную проверку, такую аренду нужно продлить
+	severity := "medium"
+	owner := "без аккаунта"
+	if event.AccountID != "" {
+		severity = "high"
+		owner = "аккаунта " + event.AccountID
+	}
+
+	firedAt := event.Timestamp
+	if firedAt.IsZero() {
+		firedAt = time.Now()
+	}
+
+	alert := &models.AlertEvent{
+		RuleName:     smsRentalRuleName,
+		Severity:     severity,
+		Platform:     smsServicePlatform(event.Service),
+		Message:      fmt.Sprintf("Аренда номера %s (%s) %s истекает %s, осталось %.0f ч", event.PhoneNumber, event.RentalID, owner, event.ExpiresAt.Format("02.01.2006 15:04"), event.HoursLeft),
+		CurrentValue: event.HoursLeft,
+		FiredAt:      firedAt,
+	}
+
+	if err := a.alertRepo.SaveAlertEvent(ctx, alert); err != nil {
+		return err
+	}
+
+	alertsFired.WithLabelValues(severity, smsRentalRuleName, alert.Platform).Inc()
+
+	if severity == "high" {
+		if err := a.publishAlertEvent(ctx, alert); err != nil {
+			a.logger.WithError(err).Error("Failed to publish SMS rental alert")
+		}
+	}
+
+	if a.webhooks != nil {
+		a.webhooks.SendAlert(alert)
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+// survivalAll значение измерения, не участвующего в группировке
+const survivalAll = "all"
+
+// GetSurvival строит кривые выживаемости Каплана-Мейера: доля аккаунтов, не заблокированных
+// к N-му дню после регистрации. Аккаунты без блокировки учитываются до вывода из оборота
+// или до текущего момента, поэтому свежие когорты не занижают выживаемость.
+func (t *LifecycleTracker) GetSurvival(ctx context.Context, filter models.SurvivalFilter) (*models.SurvivalReport, error) {
+	if filter.Since.IsZero() {
+		filter.Since = time.Now().Add(-3 * t.reportWindow)
+	}
+	if len(filter.GroupBy) == 0 {
+		filter.GroupBy = models.SurvivalGroupings
+	}
+
+	accounts, err := t.lifecycleRepo.GetSurvivalAccounts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	total := newSurvivalAccumulator(models.SurvivalCurve{Platform: survivalAll, Scenario: survivalAll, ProxyProvider: survivalAll})
+	curves := make(map[string]*survivalAccumulator)
+
+	for _, account := range accounts {
+		key := survivalCurveKey(account, filter.GroupBy)
+		id := key.Platform + "|" + key.Scenario + "|" + key.ProxyProvider
+		curve, ok := curves[id]
+		if !ok {
+			curve = newSurvivalAccumulator(key)
+			curves[id] = curve
+		}
+		curve.add(account, now)
+		total.add(account, now)
+	}
+
+	platform := filter.Platform
+	if platform == "" {
+		platform = survivalAll
+	}
+
+	report := &models.SurvivalReport{
+		Platform:    platform,
+		GroupBy:     filter.GroupBy,
+		Total:       total.result(),
+		Curves:      make([]models.SurvivalCurve, 0, len(curves)),
+		PeriodStart: filter.Since,
+		GeneratedAt: now,
+	}
+	for _, curve := range curves {
+		report.Curves = append(report.Curves, curve.result())
+	}
+
+	// Крупные когорты первыми
+	sort.Slice(report.Curves, func(i, j int) bool {
+		a, b := report.Curves[i], report.Curves[j]
+		if a.Accounts != b.Accounts {
+			return a.Accounts > b.Accounts
+		}
+		if a.Platform != b.Platform {
+			return a.Platform < b.Platform
+		}
+		if a.Scenario != b.Scenario {
+			return a.Scenario < b.Scenario
+		}
+		return a.ProxyProvider < b.ProxyProvider
+	})
+
+	return report, nil
+}
+
+// survivalCurveKey определяет когорту аккаунта по выбранным измерениям
+func survivalCurveKey(account models.SurvivalAccount, groupBy []string) models.SurvivalCurve {
+	key := models.SurvivalCurve{Platform: survivalAll, Scenario: survivalAll, ProxyProvider: survivalAll}
+	for _, group := range groupBy {
+		switch group {
+		case models.SurvivalGroupPlatform:
+			key.Platform = account.Platform
+		case models.SurvivalGroupScenario:
+			key.Scenario = account.Scenario
+		case models.SurvivalGroupProxyProvider:
+			key.ProxyProvider = account.ProxyProvider
+		}
+	}
+	return key
+}
+
+// survivalObservation срок наблюдения аккаунта в днях и закончился ли он блокировкой
+type survivalObservation struct {
+	days   int
+	banned bool
+}
+
+// survivalAccumulator накапливает сроки жизни аккаунтов когорты
+type survivalAccumulator struct {
+	curve        models.SurvivalCurve
+	observations []survivalObservation
+}
+
+func newSurvivalAccumulator(curve models.SurvivalCurve) *survivalAccumulator {
+	return &survivalAccumulator{curve: curve}
+}
+
+func (a *survivalAccumulator) add(account models.SurvivalAccount, now time.Time) {
+	end, banned := now, false
+	switch {
+	case account.BannedAt != nil:
+		end, banned = *account.BannedAt, true
+	case account.RetiredAt != nil:
+		end = *account.RetiredAt
+	}
+
+	// Отрицательные сроки из-за рассинхрона событий считаются днем регистрации
+	days := 0
+	if end.After(account.RegisteredAt) {
+		days = int(end.Sub(account.RegisteredAt).Hours() / 24)
+	}
+
+	a.observations = append(a.observations, survivalObservation{days: days, banned: banned})
+}
+
+func (a *survivalAccumulator) result() models.SurvivalCurve {
+	curve := a.curve
+	curve.Accounts = int64(len(a.observations))
+	curve.Points = kaplanMeier(a.observations)
+
+	for _, point := range curve.Points {
+		curve.Banned += point.Banned
+		if curve.MedianDays == nil && point.Survival <= 0.5 {
+			day := point.Day
+			curve.MedianDays = &day
+		}
+	}
+	curve.Survival7d = survivalAt(curve.Points, 7)
+	curve.Survival30d = survivalAt(curve.Points, 30)
+
+	return curve
+}
+
+// kaplanMeier считает оценку Каплана-Мейера по дням. Выбывшие без блокировки в день d
+// остаются под риском в этот день: их блокировка в тот же день не наблюдалась бы.
+func kaplanMeier(observations []survivalObservation) []models.SurvivalPoint {
+	if len(observations) == 0 {
+		return []models.SurvivalPoint{}
+	}
+
+	sorted := make([]survivalObservation, len(observations))
+	copy(sorted, observations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].days < sorted[j].days })
+
+	points := make([]models.SurvivalPoint, 0)
+	atRisk := int64(len(sorted))
+	survival := 1.0
+
+	for i := 0; i < len(sorted); {
+		point := models.SurvivalPoint{Day: sorted[i].days, AtRisk: atRisk}
+		for ; i < len(sorted) && sorted[i].days == point.Day; i++ {
+			if sorted[i].banned {
+				point.Banned++
+			} else {
+				point.Censored++
+			}
+		}
+
+		survival *= 1 - float64(point.Banned)/float64(point.AtRisk)
+		point.Survival = survival
+		points = append(points, point)
+
+		atRisk -= point.Banned + point.Censored
+	}
+
+	return points
+}
+
+// survivalAt возвращает выживаемость к концу дня по последней точке не позже него
+func survivalAt(points []models.SurvivalPoint, day int) float64 {
+	survival := 1.0
+	for _, point := range points {
+		if point.Day > day {
+			break
+		}
+		survival = point.Survival
+	}
+	return survival
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/analytics-service/internal/models"
+)
+
+// События, отправляемые во внешние системы
+const (
+	WebhookEventAlertFired              = "alert.fired"
+	WebhookEventRecommendationGenerated = "recommendation.generated"
+)
+
+// webhookQueueSize размер очереди доставок одного получателя
+const webhookQueueSize = 256
+
+// WebhookEndpoint получатель вебхуков, пустой фильтр пропускает все значения
+type WebhookEndpoint struct {
+	Name       string
+	URL        string
+	Secret     string
+	Events     []string
+	Severities []string
+	Platforms  []string
+}
+
+// WebhookPayload тело запроса вебхука
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	Severity  string      `json:"severity,omitempty"`
+	Platform  string      `json:"platform,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+type webhookDelivery struct {
+	payload *WebhookPayload
+	body    []byte
+}
+
+type webhookTarget struct {
+	endpoint WebhookEndpoint
+	queue    chan webhookDelivery
+}
+
+// WebhookSink доставляет алерты и рекомендации на внешние вебхуки.
+// У каждого получателя своя очередь, поэтому недоступный получатель не задерживает остальных.
+type WebhookSink struct {
+	targets      []*webhookTarget
+	client       *http.Client
+	maxAttempts  int
+	retryBackoff time.Duration
+	logger       logger.Logger
+}
+
+// NewWebhookSink создает отправителя вебхуков
+func NewWebhookSink(
+	endpoints []WebhookEndpoint,
+	timeout time.Duration,
+	maxAttempts int,
+	retryBackoff time.Duration,
+	logger logger.Logger,
+) *WebhookSink {
+	targets := make([]*webhookTarget, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		targets = append(targets, &webhookTarget{
+			endpoint: endpoint,
+			queue:    make(chan webhookDelivery, webhookQueueSize),
+		})
+	}
+
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return &WebhookSink{
+		targets:      targets,
+		client:       &http.Client{Timeout: timeout},
+		maxAttempts:  maxAttempts,
+		retryBackoff: retryBackoff,
+		logger:       logger,
+	}
+}
+
+// Run запускает доставку по всем получателям до отмены контекста
+func (s *WebhookSink) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, target := range s.targets {
+		wg.Add(1)
+		go func(target *webhookTarget) {
+			defer wg.Done()
+			for {
+				select {
+				case delivery := <-target.queue:
+					s.deliver(ctx, target.endpoint, delivery)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(target)
+	}
+	wg.Wait()
+	s.logger.Info("Stopping webhook sink")
+}
+
+// SendAlert ставит в очередь сработавший алерт
+func (s *WebhookSink) SendAlert(alert *models.AlertEvent) {
+	s.enqueue(&WebhookPayload{
+		Event:     WebhookEventAlertFired,
+		Severity:  alert.Severity,
+		Platform:  alert.Platform,
+		Timestamp: alert.FiredAt,
+		Data: map[string]interface{}{
+			"alert_id":      alert.ID.Hex(),
+			"rule_id":       alert.RuleID.Hex(),
+			"rule_name":     alert.RuleName,
+			"message":       alert.Message,
+			"current_value": alert.CurrentValue,
+			"threshold":     alert.Threshold,
+			"fired_at":      alert.FiredAt,
+		},
+	})
+}
+
+// SendRecommendation ставит в очередь сгенерированную рекомендацию.
+// Severity рекомендации — её приоритет, детали сериализуются так же, как в ответах API.
+func (s *WebhookSink) SendRecommendation(recommendation *models.Recommendation) {
+	data := map[string]interface{}{
+		"recommendation_id": recommendation.ID.Hex(),
+		"type":              recommendation.Type,
+		"priority":          recommendation.Priority,
+		"generated_at":      recommendation.GeneratedAt,
+		"valid_until":       recommendation.ValidUntil,
+		"action_items":      recommendation.ActionItems,
+	}
+
+	var platform string
+	switch {
+	case recommendation.ProxyRating != nil:
+		data["details"] = recommendation.ProxyRating
+	case recommendation.WarmingScenario != nil:
+		data["details"] = recommendation.WarmingScenario
+		platform = recommendation.WarmingScenario.Platform
+	case recommendation.ErrorPattern != nil:
+		data["details"] = recommendation.ErrorPattern
+	}
+
+	s.enqueue(&WebhookPayload{
+		Event:     WebhookEventRecommendationGenerated,
+		Severity:  recommendation.Priority,
+		Platform:  platform,
+		Timestamp: recommendation.GeneratedAt,
+		Data:      data,
+	})
+}
+
+// enqueue раскладывает событие по получателям, чьи фильтры его пропускают
+func (s *WebhookSink) enqueue(payload *WebhookPayload) {
+	var body []byte
+	for _, target := range s.targets {
+		if !target.endpoint.matches(payload) {
+			continue
+		}
+
+		if body == nil {
+			payload.ID = uuid.New().String()
+			var err error
+			if body, err = json.Marshal(payload); err != nil {
+				s.logger.WithError(err).WithField("event", payload.Event).Error("Failed to marshal webhook payload")
+				return
+			}
+		}
+
+		select {
+		case target.queue <- webhookDelivery{payload: payload, body: body}:
+		default:
+			s.deadLetter(target.endpoint, webhookDelivery{payload: payload, body: body}, 0, fmt.Errorf("delivery queue is full"))
+		}
+	}
+}
+
+// deliver отправляет событие с повторами: сетевые ошибки, 429 и 5xx повторяются
+// с удваивающейся паузой, остальные ответы 4xx сразу уходят в dead-letter
+func (s *WebhookSink) deliver(ctx context.Context, endpoint WebhookEndpoint, delivery webhookDelivery) {
+	backoff := s.retryBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		retryable, err := s.post(ctx, endpoint, delivery)
+		if err == nil {
+			webhookDeliveries.WithLabelValues(endpoint.Name, delivery.payload.Event, "delivered").Inc()
+			return
+		}
+		lastErr = err
+
+		if !retryable || attempt == s.maxAttempts {
+			s.deadLetter(endpoint, delivery, attempt, lastErr)
+			return
+		}
+
+		webhookDeliveries.WithLabelValues(endpoint.Name, delivery.payload.Event, "retried").Inc()
+		s.logger.WithError(err).WithFields(map[string]interface{}{
+			"webhook":     endpoint.Name,
+			"delivery_id": delivery.payload.ID,
+			"attempt":     attempt,
+		}).Warn("Webhook delivery failed, retrying")
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			s.deadLetter(endpoint, delivery, attempt, ctx.Err())
+			return
+		}
+	}
+}
+
+// post выполняет одну попытку доставки и сообщает, имеет ли смысл повторять
+func (s *WebhookSink) post(ctx context.Context, endpoint WebhookEndpoint, delivery webhookDelivery) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.body))
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "conveer-analytics-webhooks")
+	req.Header.Set("X-Conveer-Event", delivery.payload.Event)
+	req.Header.Set("X-Conveer-Delivery", delivery.payload.ID)
+	req.Header.Set("X-Conveer-Timestamp", timestamp)
+	if endpoint.Secret != "" {
+		req.Header.Set("X-Conveer-Signature", "sha256="+signWebhook(endpoint.Secret, timestamp, delivery.body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+}
+
+// deadLetter логирует недоставленное событие вместе с телом, чтобы его можно было отправить повторно
+func (s *WebhookSink) deadLetter(endpoint WebhookEndpoint, delivery webhookDelivery, attempts int, err error) {
+	webhookDeliveries.WithLabelValues(endpoint.Name, delivery.payload.Event, "dead_letter").Inc()
+	s.logger.WithError(err).WithFields(map[string]interface{}{
+		"webhook":     endpoint.Name,
+		"url":         endpoint.URL,
+		"event":       delivery.payload.Event,
+		"delivery_id": delivery.payload.ID,
+		"attempts":    attempts,
+		"payload":     string(delivery.body),
+	}).Error("Webhook delivery dead-lettered")
+}
+
+// signWebhook подписывает "<timestamp>.<тело>" ключом получателя
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// matches проверяет фильтры получателя. Событие без платформы или с платформой all
+// относится ко всем платформам и проходит любой фильтр платформ.
+func (e WebhookEndpoint) matches(payload *WebhookPayload) bool {
+	if !matchesFilter(e.Events, payload.Event) || !matchesFilter(e.Severities, payload.Severity) {
+		return false
+	}
+	if payload.Platform == "" || payload.Platform == "all" {
+		return true
+	}
+	return matchesFilter(e.Platforms, payload.Platform)
+}
+
+func matchesFilter(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, allowed := range filter {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
@@ -273,6 +273,8 @@ type ResourcesSummary struct {
 	BannedProxies      int64                  `protobuf:"varint,2,opt,name=banned_proxies,json=bannedProxies,proto3" json:"banned_proxies,omitempty"`
 	SmsBalance         float64                `protobuf:"fixed64,3,opt,name=sms_balance,json=smsBalance,proto3" json:"sms_balance,omitempty"`
 	WarmingTasksActive int64                  `protobuf:"varint,4,opt,name=warming_tasks_active,json=warmingTasksActive,proto3" json:"warming_tasks_active,omitempty"`
+	SmsDeferredToday   int64                  `protobuf:"varint,5,opt,name=sms_deferred_today,json=smsDeferredToday,proto3" json:"sms_deferred_today,omitempty"`
+	SmsDeferralRate    float64                `protobuf:"fixed64,6,opt,name=sms_deferral_rate,json=smsDeferralRate,proto3" json:"sms_deferral_rate,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -335,6 +337,20 @@ func (x *ResourcesSummary) GetWarmingTasksActive() int64 {
 	return 0
 }
 
+func (x *ResourcesSummary) GetSmsDeferredToday() int64 {
+	if x != nil {
+		return x.SmsDeferredToday
+	}
+	return 0
+}
+
+func (x *ResourcesSummary) GetSmsDeferralRate() float64 {
+	if x != nil {
+		return x.SmsDeferralRate
+	}
+	return 0
+}
+
 type PerformanceSummary struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
 	AvgWarmingDays       float64                `protobuf:"fixed64,1,opt,name=avg_warming_days,json=avgWarmingDays,proto3" json:"avg_warming_days,omitempty"`
@@ -1624,13 +1640,15 @@ func (x *AcknowledgeRequest) GetAlertId() string {
 }
 
 type CreateRuleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Platform      string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
-	Threshold     *AlertThreshold        `protobuf:"bytes,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	Severity      string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
-	Cooldown      int32                  `protobuf:"varint,6,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Platform  string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	Threshold *AlertThreshold        `protobuf:"bytes,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Severity  string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
+	Cooldown  int32                  `protobuf:"varint,6,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	// Составное условие вместо type и threshold
+	Expression    string `protobuf:"bytes,7,opt,name=expression,proto3" json:"expression,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1707,14 +1725,24 @@ func (x *CreateRuleRequest) GetCooldown() int32 {
 	return 0
 }
 
+func (x *CreateRuleRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
 type UpdateRuleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
-	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Threshold     *AlertThreshold        `protobuf:"bytes,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	Cooldown      int32                  `protobuf:"varint,4,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RuleId    string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Enabled   bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Threshold *AlertThreshold        `protobuf:"bytes,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Cooldown  int32                  `protobuf:"varint,4,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	// Пустое expression оставляет выражение как есть, clear_expression возвращает правило на порог
+	Expression      string `protobuf:"bytes,5,opt,name=expression,proto3" json:"expression,omitempty"`
+	ClearExpression bool   `protobuf:"varint,6,opt,name=clear_expression,json=clearExpression,proto3" json:"clear_expression,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateRuleRequest) Reset() {
@@ -1775,6 +1803,20 @@ func (x *UpdateRuleRequest) GetCooldown() int32 {
 	return 0
 }
 
+func (x *UpdateRuleRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *UpdateRuleRequest) GetClearExpression() bool {
+	if x != nil {
+		return x.ClearExpression
+	}
+	return false
+}
+
 type DeleteRuleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
@@ -1829,6 +1871,7 @@ type AlertRuleResponse struct {
 	Threshold     *AlertThreshold        `protobuf:"bytes,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
 	Severity      string                 `protobuf:"bytes,7,opt,name=severity,proto3" json:"severity,omitempty"`
 	Cooldown      int32                  `protobuf:"varint,8,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	Expression    string                 `protobuf:"bytes,9,opt,name=expression,proto3" json:"expression,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1919,6 +1962,13 @@ func (x *AlertRuleResponse) GetCooldown() int32 {
 	return 0
 }
 
+func (x *AlertRuleResponse) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
 type AlertRulesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Rules         []*AlertRuleResponse   `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
@@ -2015,6 +2065,134 @@ func (x *AlertThreshold) GetValue() float64 {
 	return 0
 }
 
+type ValidateExpressionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expression    string                 `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateExpressionRequest) Reset() {
+	*x = ValidateExpressionRequest{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateExpressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateExpressionRequest) ProtoMessage() {}
+
+func (x *ValidateExpressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateExpressionRequest.ProtoReflect.Descriptor instead.
+func (*ValidateExpressionRequest) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ValidateExpressionRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+type ValidateExpressionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	Normalized    string                 `protobuf:"bytes,4,opt,name=normalized,proto3" json:"normalized,omitempty"`
+	Metrics       []string               `protobuf:"bytes,5,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	For           string                 `protobuf:"bytes,6,opt,name=for,proto3" json:"for,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateExpressionResponse) Reset() {
+	*x = ValidateExpressionResponse{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateExpressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateExpressionResponse) ProtoMessage() {}
+
+func (x *ValidateExpressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateExpressionResponse.ProtoReflect.Descriptor instead.
+func (*ValidateExpressionResponse) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ValidateExpressionResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateExpressionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ValidateExpressionResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ValidateExpressionResponse) GetNormalized() string {
+	if x != nil {
+		return x.Normalized
+	}
+	return ""
+}
+
+func (x *ValidateExpressionResponse) GetMetrics() []string {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *ValidateExpressionResponse) GetFor() string {
+	if x != nil {
+		return x.For
+	}
+	return ""
+}
+
 type ErrorStat struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -2025,7 +2203,7 @@ type ErrorStat struct {
 
 func (x *ErrorStat) Reset() {
 	*x = ErrorStat{}
-	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[30]
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2037,7 +2215,7 @@ func (x *ErrorStat) String() string {
 func (*ErrorStat) ProtoMessage() {}
 
 func (x *ErrorStat) ProtoReflect() protoreflect.Message {
-	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[30]
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2050,7 +2228,7 @@ func (x *ErrorStat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorStat.ProtoReflect.Descriptor instead.
 func (*ErrorStat) Descriptor() ([]byte, []int) {
-	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{30}
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ErrorStat) GetType() string {
@@ -2067,132 +2245,1534 @@ func (x *ErrorStat) GetCount() int64 {
 	return 0
 }
 
-var File_services_analytics_service_proto_analytics_proto protoreflect.FileDescriptor
+type LifecycleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_services_analytics_service_proto_analytics_proto_rawDesc = "" +
-	"\n" +
-	"0services/analytics-service/proto/analytics.proto\x12\tanalytics\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x84\x01\n" +
-	"\x10AnalyticsRequest\x129\n" +
-	"\n" +
-	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xcb\x05\n" +
-	"\x10OverallAnalytics\x12%\n" +
-	"\x0etotal_accounts\x18\x01 \x01(\x03R\rtotalAccounts\x12e\n" +
-	"\x14accounts_by_platform\x18\x02 \x03(\v23.analytics.OverallAnalytics.AccountsByPlatformEntryR\x12accountsByPlatform\x12_\n" +
-	"\x12accounts_by_status\x18\x03 \x03(\v21.analytics.OverallAnalytics.AccountsByStatusEntryR\x10accountsByStatus\x120\n" +
-	"\x14overall_success_rate\x18\x04 \x01(\x01R\x12overallSuccessRate\x12(\n" +
-	"\x10overall_ban_rate\x18\x05 \x01(\x01R\x0eoverallBanRate\x126\n" +
-	"\bexpenses\x18\x06 \x01(\v2\x1a.analytics.ExpensesSummaryR\bexpenses\x129\n" +
-	"\tresources\x18\a \x01(\v2\x1b.analytics.ResourcesSummaryR\tresources\x12?\n" +
-	"\vperformance\x18\b \x01(\v2\x1d.analytics.PerformanceSummaryR\vperformance\x12,\n" +
-	"\x06trends\x18\t \x03(\v2\x14.analytics.TrendDataR\x06trends\x1aE\n" +
-	"\x17AccountsByPlatformEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aC\n" +
-	"\x15AccountsByStatusEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x82\x02\n" +
-	"\x0fExpensesSummary\x12*\n" +
-	"\x11total_spent_today\x18\x01 \x01(\x01R\x0ftotalSpentToday\x12(\n" +
-	"\x10total_spent_week\x18\x02 \x01(\x01R\x0etotalSpentWeek\x12*\n" +
-	"\x11total_spent_month\x18\x03 \x01(\x01R\x0ftotalSpentMonth\x12\x1b\n" +
-	"\tsms_spent\x18\x04 \x01(\x01R\bsmsSpent\x12\x1f\n" +
-	"\vproxy_spent\x18\x05 \x01(\x01R\n" +
-	"proxySpent\x12/\n" +
-	"\x14avg_cost_per_account\x18\x06 \x01(\x01R\x11avgCostPerAccount\"\xb3\x01\n" +
-	"\x10ResourcesSummary\x12%\n" +
-	"\x0eactive_proxies\x18\x01 \x01(\x03R\ractiveProxies\x12%\n" +
-	"\x0ebanned_proxies\x18\x02 \x01(\x03R\rbannedProxies\x12\x1f\n" +
-	"\vsms_balance\x18\x03 \x01(\x01R\n" +
-	"smsBalance\x120\n" +
-	"\x14warming_tasks_active\x18\x04 \x01(\x03R\x12warmingTasksActive\"\xfa\x01\n" +
-	"\x12PerformanceSummary\x12(\n" +
-	"\x10avg_warming_days\x18\x01 \x01(\x01R\x0eavgWarmingDays\x124\n" +
-	"\x16accounts_created_today\x18\x02 \x01(\x03R\x14accountsCreatedToday\x120\n" +
-	"\x14accounts_ready_today\x18\x03 \x01(\x03R\x12accountsReadyToday\x12\x1d\n" +
-	"\n" +
-	"error_rate\x18\x04 \x01(\x01R\terrorRate\x123\n" +
-	"\n" +
-	"top_errors\x18\x05 \x03(\v2\x14.analytics.ErrorStatR\ttopErrors\"\xab\x01\n" +
-	"\tTrendData\x12.\n" +
-	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12)\n" +
-	"\x10accounts_created\x18\x02 \x01(\x03R\x0faccountsCreated\x12'\n" +
-	"\x0faccounts_banned\x18\x03 \x01(\x03R\x0eaccountsBanned\x12\x1a\n" +
-	"\bexpenses\x18\x04 \x01(\x01R\bexpenses\"-\n" +
-	"\x0fPlatformRequest\x12\x1a\n" +
-	"\bplatform\x18\x01 \x01(\tR\bplatform\"\x8f\x03\n" +
-	"\x11PlatformAnalytics\x12\x1a\n" +
-	"\bplatform\x18\x01 \x01(\tR\bplatform\x12%\n" +
-	"\x0etotal_accounts\x18\x02 \x01(\x03R\rtotalAccounts\x12G\n" +
-	"\tby_status\x18\x03 \x03(\v2*.analytics.PlatformAnalytics.ByStatusEntryR\bbyStatus\x12!\n" +
-	"\fsuccess_rate\x18\x04 \x01(\x01R\vsuccessRate\x12\x19\n" +
-	"\bban_rate\x18\x05 \x01(\x01R\abanRate\x12(\n" +
-	"\x10avg_warming_days\x18\x06 \x01(\x01R\x0eavgWarmingDays\x12\x1f\n" +
-	"\vtotal_spent\x18\a \x01(\x01R\n" +
-	"totalSpent\x12(\n" +
-	"\x0frecommendations\x18\b \x03(\tR\x0frecommendations\x1a;\n" +
-	"\rByStatusEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\")\n" +
-	"\x0fForecastRequest\x12\x16\n" +
-	"\x06period\x18\x01 \x01(\tR\x06period\"\x88\x03\n" +
-	"\x17ExpenseForecastResponse\x12\x16\n" +
-	"\x06period\x18\x01 \x01(\tR\x06period\x12%\n" +
-	"\x0epredicted_cost\x18\x02 \x01(\x01R\rpredictedCost\x12\x1f\n" +
-	"\vupper_bound\x18\x03 \x01(\x01R\n" +
-	"upperBound\x12\x1f\n" +
-	"\vlower_bound\x18\x04 \x01(\x01R\n" +
-	"lowerBound\x12O\n" +
-	"\tbreakdown\x18\x05 \x03(\v21.analytics.ExpenseForecastResponse.BreakdownEntryR\tbreakdown\x12\x1e\n" +
-	"\n" +
-	"confidence\x18\x06 \x01(\x01R\n" +
-	"confidence\x12=\n" +
-	"\fgenerated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x1a<\n" +
-	"\x0eBreakdownEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"M\n" +
-	"\x10ReadinessRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
-	"\bplatform\x18\x02 \x01(\tR\bplatform\"\xd1\x01\n" +
-	"\x19ReadinessForecastResponse\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
-	"\x0eestimated_days\x18\x02 \x01(\x05R\restimatedDays\x12C\n" +
-	"\x0fcompletion_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0ecompletionDate\x12)\n" +
-	"\x10current_progress\x18\x04 \x01(\x01R\x0fcurrentProgress\"0\n" +
-	"\x12OptimalTimeRequest\x12\x1a\n" +
-	"\bplatform\x18\x01 \x01(\tR\bplatform\"\x95\x01\n" +
-	"\x13OptimalTimeResponse\x12\x1d\n" +
-	"\n" +
-	"best_hours\x18\x01 \x03(\x05R\tbestHours\x12\x1b\n" +
-	"\tbest_days\x18\x02 \x03(\tR\bbestDays\x12!\n" +
-	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12\x1f\n" +
-	"\vsample_size\x18\x04 \x01(\x03R\n" +
-	"sampleSize\"\x8e\x01\n" +
-	"\x15ProxyRankingsResponse\x126\n" +
-	"\brankings\x18\x01 \x03(\v2\x1a.analytics.ProviderRankingR\brankings\x12=\n" +
-	"\fgenerated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xf4\x01\n" +
-	"\x0fProviderRanking\x12\x1a\n" +
-	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
-	"\x05score\x18\x02 \x01(\x01R\x05score\x12!\n" +
-	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12\x1f\n" +
-	"\vavg_latency\x18\x04 \x01(\x01R\n" +
-	"avgLatency\x12\x19\n" +
-	"\bban_rate\x18\x05 \x01(\x01R\abanRate\x12(\n" +
-	"\x10cost_per_account\x18\x06 \x01(\x01R\x0ecostPerAccount\x12&\n" +
-	"\x0erecommendation\x18\a \x01(\tR\x0erecommendation\"\xd3\x01\n" +
-	"\x1eWarmingRecommendationsResponse\x12\x1a\n" +
-	"\bplatform\x18\x01 \x01(\tR\bplatform\x12)\n" +
-	"\x10recommended_type\x18\x02 \x01(\tR\x0frecommendedType\x12)\n" +
-	"\x10recommended_days\x18\x03 \x01(\x05R\x0frecommendedDays\x12!\n" +
-	"\fsuccess_rate\x18\x04 \x01(\x01R\vsuccessRate\x12\x1c\n" +
-	"\treasoning\x18\x05 \x01(\tR\treasoning\"%\n" +
-	"\x0fAnalysisRequest\x12\x12\n" +
-	"\x04days\x18\x01 \x01(\x05R\x04days\"K\n" +
-	"\x14ErrorPatternResponse\x123\n" +
-	"\bclusters\x18\x01 \x03(\v2\x17.analytics.ErrorClusterR\bclusters\"\xb4\x01\n" +
-	"\fErrorCluster\x12\x18\n" +
+func (x *LifecycleRequest) Reset() {
+	*x = LifecycleRequest{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifecycleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifecycleRequest) ProtoMessage() {}
+
+func (x *LifecycleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LifecycleRequest.ProtoReflect.Descriptor instead.
+func (*LifecycleRequest) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LifecycleRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *LifecycleRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type LifecycleReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	StateCounts   map[string]int64       `protobuf:"bytes,2,rep,name=state_counts,json=stateCounts,proto3" json:"state_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Durations     []*StateDuration       `protobuf:"bytes,3,rep,name=durations,proto3" json:"durations,omitempty"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LifecycleReportResponse) Reset() {
+	*x = LifecycleReportResponse{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifecycleReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifecycleReportResponse) ProtoMessage() {}
+
+func (x *LifecycleReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LifecycleReportResponse.ProtoReflect.Descriptor instead.
+func (*LifecycleReportResponse) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LifecycleReportResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *LifecycleReportResponse) GetStateCounts() map[string]int64 {
+	if x != nil {
+		return x.StateCounts
+	}
+	return nil
+}
+
+func (x *LifecycleReportResponse) GetDurations() []*StateDuration {
+	if x != nil {
+		return x.Durations
+	}
+	return nil
+}
+
+func (x *LifecycleReportResponse) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *LifecycleReportResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+type StateDuration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Samples       int64                  `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`
+	AvgHours      float64                `protobuf:"fixed64,3,opt,name=avg_hours,json=avgHours,proto3" json:"avg_hours,omitempty"`
+	P50Hours      float64                `protobuf:"fixed64,4,opt,name=p50_hours,json=p50Hours,proto3" json:"p50_hours,omitempty"`
+	P90Hours      float64                `protobuf:"fixed64,5,opt,name=p90_hours,json=p90Hours,proto3" json:"p90_hours,omitempty"`
+	MaxHours      float64                `protobuf:"fixed64,6,opt,name=max_hours,json=maxHours,proto3" json:"max_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateDuration) Reset() {
+	*x = StateDuration{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateDuration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateDuration) ProtoMessage() {}
+
+func (x *StateDuration) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateDuration.ProtoReflect.Descriptor instead.
+func (*StateDuration) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StateDuration) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *StateDuration) GetSamples() int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *StateDuration) GetAvgHours() float64 {
+	if x != nil {
+		return x.AvgHours
+	}
+	return 0
+}
+
+func (x *StateDuration) GetP50Hours() float64 {
+	if x != nil {
+		return x.P50Hours
+	}
+	return 0
+}
+
+func (x *StateDuration) GetP90Hours() float64 {
+	if x != nil {
+		return x.P90Hours
+	}
+	return 0
+}
+
+func (x *StateDuration) GetMaxHours() float64 {
+	if x != nil {
+		return x.MaxHours
+	}
+	return 0
+}
+
+type FunnelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	ProxyProvider string                 `protobuf:"bytes,2,opt,name=proxy_provider,json=proxyProvider,proto3" json:"proxy_provider,omitempty"`
+	Weeks         int32                  `protobuf:"varint,3,opt,name=weeks,proto3" json:"weeks,omitempty"`
+	GroupBy       []string               `protobuf:"bytes,4,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunnelRequest) Reset() {
+	*x = FunnelRequest{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunnelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunnelRequest) ProtoMessage() {}
+
+func (x *FunnelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunnelRequest.ProtoReflect.Descriptor instead.
+func (*FunnelRequest) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *FunnelRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *FunnelRequest) GetProxyProvider() string {
+	if x != nil {
+		return x.ProxyProvider
+	}
+	return ""
+}
+
+func (x *FunnelRequest) GetWeeks() int32 {
+	if x != nil {
+		return x.Weeks
+	}
+	return 0
+}
+
+func (x *FunnelRequest) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+type FunnelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	GroupBy       []string               `protobuf:"bytes,2,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	Total         *FunnelCohort          `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`
+	Cohorts       []*FunnelCohort        `protobuf:"bytes,4,rep,name=cohorts,proto3" json:"cohorts,omitempty"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunnelResponse) Reset() {
+	*x = FunnelResponse{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunnelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunnelResponse) ProtoMessage() {}
+
+func (x *FunnelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunnelResponse.ProtoReflect.Descriptor instead.
+func (*FunnelResponse) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *FunnelResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *FunnelResponse) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *FunnelResponse) GetTotal() *FunnelCohort {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *FunnelResponse) GetCohorts() []*FunnelCohort {
+	if x != nil {
+		return x.Cohorts
+	}
+	return nil
+}
+
+func (x *FunnelResponse) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *FunnelResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+type FunnelCohort struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Week              string                 `protobuf:"bytes,1,opt,name=week,proto3" json:"week,omitempty"`
+	Platform          string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	ProxyProvider     string                 `protobuf:"bytes,3,opt,name=proxy_provider,json=proxyProvider,proto3" json:"proxy_provider,omitempty"`
+	Stages            []*FunnelStage         `protobuf:"bytes,4,rep,name=stages,proto3" json:"stages,omitempty"`
+	BannedBeforeReady int64                  `protobuf:"varint,5,opt,name=banned_before_ready,json=bannedBeforeReady,proto3" json:"banned_before_ready,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FunnelCohort) Reset() {
+	*x = FunnelCohort{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunnelCohort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunnelCohort) ProtoMessage() {}
+
+func (x *FunnelCohort) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunnelCohort.ProtoReflect.Descriptor instead.
+func (*FunnelCohort) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *FunnelCohort) GetWeek() string {
+	if x != nil {
+		return x.Week
+	}
+	return ""
+}
+
+func (x *FunnelCohort) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *FunnelCohort) GetProxyProvider() string {
+	if x != nil {
+		return x.ProxyProvider
+	}
+	return ""
+}
+
+func (x *FunnelCohort) GetStages() []*FunnelStage {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *FunnelCohort) GetBannedBeforeReady() int64 {
+	if x != nil {
+		return x.BannedBeforeReady
+	}
+	return 0
+}
+
+type FunnelStage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Stage          string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	Accounts       int64                  `protobuf:"varint,2,opt,name=accounts,proto3" json:"accounts,omitempty"`
+	ConversionRate float64                `protobuf:"fixed64,3,opt,name=conversion_rate,json=conversionRate,proto3" json:"conversion_rate,omitempty"`
+	OverallRate    float64                `protobuf:"fixed64,4,opt,name=overall_rate,json=overallRate,proto3" json:"overall_rate,omitempty"`
+	MedianHours    float64                `protobuf:"fixed64,5,opt,name=median_hours,json=medianHours,proto3" json:"median_hours,omitempty"`
+	Samples        int64                  `protobuf:"varint,6,opt,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FunnelStage) Reset() {
+	*x = FunnelStage{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunnelStage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunnelStage) ProtoMessage() {}
+
+func (x *FunnelStage) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunnelStage.ProtoReflect.Descriptor instead.
+func (*FunnelStage) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *FunnelStage) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *FunnelStage) GetAccounts() int64 {
+	if x != nil {
+		return x.Accounts
+	}
+	return 0
+}
+
+func (x *FunnelStage) GetConversionRate() float64 {
+	if x != nil {
+		return x.ConversionRate
+	}
+	return 0
+}
+
+func (x *FunnelStage) GetOverallRate() float64 {
+	if x != nil {
+		return x.OverallRate
+	}
+	return 0
+}
+
+func (x *FunnelStage) GetMedianHours() float64 {
+	if x != nil {
+		return x.MedianHours
+	}
+	return 0
+}
+
+func (x *FunnelStage) GetSamples() int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+type SurvivalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Scenario      string                 `protobuf:"bytes,2,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	ProxyProvider string                 `protobuf:"bytes,3,opt,name=proxy_provider,json=proxyProvider,proto3" json:"proxy_provider,omitempty"`
+	Days          int32                  `protobuf:"varint,4,opt,name=days,proto3" json:"days,omitempty"` // Глубина когорт по дате регистрации
+	GroupBy       []string               `protobuf:"bytes,5,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SurvivalRequest) Reset() {
+	*x = SurvivalRequest{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurvivalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurvivalRequest) ProtoMessage() {}
+
+func (x *SurvivalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurvivalRequest.ProtoReflect.Descriptor instead.
+func (*SurvivalRequest) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SurvivalRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *SurvivalRequest) GetScenario() string {
+	if x != nil {
+		return x.Scenario
+	}
+	return ""
+}
+
+func (x *SurvivalRequest) GetProxyProvider() string {
+	if x != nil {
+		return x.ProxyProvider
+	}
+	return ""
+}
+
+func (x *SurvivalRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *SurvivalRequest) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+type SurvivalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	GroupBy       []string               `protobuf:"bytes,2,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	Total         *SurvivalCurve         `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`
+	Curves        []*SurvivalCurve       `protobuf:"bytes,4,rep,name=curves,proto3" json:"curves,omitempty"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SurvivalResponse) Reset() {
+	*x = SurvivalResponse{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurvivalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurvivalResponse) ProtoMessage() {}
+
+func (x *SurvivalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurvivalResponse.ProtoReflect.Descriptor instead.
+func (*SurvivalResponse) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SurvivalResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *SurvivalResponse) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *SurvivalResponse) GetTotal() *SurvivalCurve {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *SurvivalResponse) GetCurves() []*SurvivalCurve {
+	if x != nil {
+		return x.Curves
+	}
+	return nil
+}
+
+func (x *SurvivalResponse) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *SurvivalResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+type SurvivalCurve struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Scenario      string                 `protobuf:"bytes,2,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	ProxyProvider string                 `protobuf:"bytes,3,opt,name=proxy_provider,json=proxyProvider,proto3" json:"proxy_provider,omitempty"`
+	Accounts      int64                  `protobuf:"varint,4,opt,name=accounts,proto3" json:"accounts,omitempty"`
+	Banned        int64                  `protobuf:"varint,5,opt,name=banned,proto3" json:"banned,omitempty"`
+	MedianDays    int32                  `protobuf:"varint,6,opt,name=median_days,json=medianDays,proto3" json:"median_days,omitempty"` // -1, если половина когорты еще не заблокирована
+	Survival_7D   float64                `protobuf:"fixed64,7,opt,name=survival_7d,json=survival7d,proto3" json:"survival_7d,omitempty"`
+	Survival_30D  float64                `protobuf:"fixed64,8,opt,name=survival_30d,json=survival30d,proto3" json:"survival_30d,omitempty"`
+	Points        []*SurvivalPoint       `protobuf:"bytes,9,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SurvivalCurve) Reset() {
+	*x = SurvivalCurve{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurvivalCurve) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurvivalCurve) ProtoMessage() {}
+
+func (x *SurvivalCurve) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurvivalCurve.ProtoReflect.Descriptor instead.
+func (*SurvivalCurve) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SurvivalCurve) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *SurvivalCurve) GetScenario() string {
+	if x != nil {
+		return x.Scenario
+	}
+	return ""
+}
+
+func (x *SurvivalCurve) GetProxyProvider() string {
+	if x != nil {
+		return x.ProxyProvider
+	}
+	return ""
+}
+
+func (x *SurvivalCurve) GetAccounts() int64 {
+	if x != nil {
+		return x.Accounts
+	}
+	return 0
+}
+
+func (x *SurvivalCurve) GetBanned() int64 {
+	if x != nil {
+		return x.Banned
+	}
+	return 0
+}
+
+func (x *SurvivalCurve) GetMedianDays() int32 {
+	if x != nil {
+		return x.MedianDays
+	}
+	return 0
+}
+
+func (x *SurvivalCurve) GetSurvival_7D() float64 {
+	if x != nil {
+		return x.Survival_7D
+	}
+	return 0
+}
+
+func (x *SurvivalCurve) GetSurvival_30D() float64 {
+	if x != nil {
+		return x.Survival_30D
+	}
+	return 0
+}
+
+func (x *SurvivalCurve) GetPoints() []*SurvivalPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type SurvivalPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           int32                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	AtRisk        int64                  `protobuf:"varint,2,opt,name=at_risk,json=atRisk,proto3" json:"at_risk,omitempty"`
+	Banned        int64                  `protobuf:"varint,3,opt,name=banned,proto3" json:"banned,omitempty"`
+	Censored      int64                  `protobuf:"varint,4,opt,name=censored,proto3" json:"censored,omitempty"`
+	Survival      float64                `protobuf:"fixed64,5,opt,name=survival,proto3" json:"survival,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SurvivalPoint) Reset() {
+	*x = SurvivalPoint{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurvivalPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurvivalPoint) ProtoMessage() {}
+
+func (x *SurvivalPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurvivalPoint.ProtoReflect.Descriptor instead.
+func (*SurvivalPoint) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SurvivalPoint) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *SurvivalPoint) GetAtRisk() int64 {
+	if x != nil {
+		return x.AtRisk
+	}
+	return 0
+}
+
+func (x *SurvivalPoint) GetBanned() int64 {
+	if x != nil {
+		return x.Banned
+	}
+	return 0
+}
+
+func (x *SurvivalPoint) GetCensored() int64 {
+	if x != nil {
+		return x.Censored
+	}
+	return 0
+}
+
+func (x *SurvivalPoint) GetSurvival() float64 {
+	if x != nil {
+		return x.Survival
+	}
+	return 0
+}
+
+type RegistrationLatencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationLatencyRequest) Reset() {
+	*x = RegistrationLatencyRequest{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationLatencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationLatencyRequest) ProtoMessage() {}
+
+func (x *RegistrationLatencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationLatencyRequest.ProtoReflect.Descriptor instead.
+func (*RegistrationLatencyRequest) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RegistrationLatencyRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *RegistrationLatencyRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type RegistrationLatencyResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Platforms     []*PlatformRegistrationLatency `protobuf:"bytes,1,rep,name=platforms,proto3" json:"platforms,omitempty"`
+	PeriodStart   *timestamppb.Timestamp         `protobuf:"bytes,2,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp         `protobuf:"bytes,3,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationLatencyResponse) Reset() {
+	*x = RegistrationLatencyResponse{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationLatencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationLatencyResponse) ProtoMessage() {}
+
+func (x *RegistrationLatencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationLatencyResponse.ProtoReflect.Descriptor instead.
+func (*RegistrationLatencyResponse) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RegistrationLatencyResponse) GetPlatforms() []*PlatformRegistrationLatency {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+func (x *RegistrationLatencyResponse) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *RegistrationLatencyResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+type PlatformRegistrationLatency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	EndToEnd      *LatencyPercentiles    `protobuf:"bytes,2,opt,name=end_to_end,json=endToEnd,proto3" json:"end_to_end,omitempty"`
+	Steps         []*LatencyPercentiles  `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlatformRegistrationLatency) Reset() {
+	*x = PlatformRegistrationLatency{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlatformRegistrationLatency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlatformRegistrationLatency) ProtoMessage() {}
+
+func (x *PlatformRegistrationLatency) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlatformRegistrationLatency.ProtoReflect.Descriptor instead.
+func (*PlatformRegistrationLatency) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PlatformRegistrationLatency) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *PlatformRegistrationLatency) GetEndToEnd() *LatencyPercentiles {
+	if x != nil {
+		return x.EndToEnd
+	}
+	return nil
+}
+
+func (x *PlatformRegistrationLatency) GetSteps() []*LatencyPercentiles {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type LatencyPercentiles struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Step          string                 `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"`
+	Samples       int64                  `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`
+	P50Sec        float64                `protobuf:"fixed64,3,opt,name=p50_sec,json=p50Sec,proto3" json:"p50_sec,omitempty"`
+	P90Sec        float64                `protobuf:"fixed64,4,opt,name=p90_sec,json=p90Sec,proto3" json:"p90_sec,omitempty"`
+	P99Sec        float64                `protobuf:"fixed64,5,opt,name=p99_sec,json=p99Sec,proto3" json:"p99_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyPercentiles) Reset() {
+	*x = LatencyPercentiles{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyPercentiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyPercentiles) ProtoMessage() {}
+
+func (x *LatencyPercentiles) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyPercentiles.ProtoReflect.Descriptor instead.
+func (*LatencyPercentiles) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *LatencyPercentiles) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *LatencyPercentiles) GetSamples() int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *LatencyPercentiles) GetP50Sec() float64 {
+	if x != nil {
+		return x.P50Sec
+	}
+	return 0
+}
+
+func (x *LatencyPercentiles) GetP90Sec() float64 {
+	if x != nil {
+		return x.P90Sec
+	}
+	return 0
+}
+
+func (x *LatencyPercentiles) GetP99Sec() float64 {
+	if x != nil {
+		return x.P99Sec
+	}
+	return 0
+}
+
+type AccountCostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountIds    []string               `protobuf:"bytes,1,rep,name=account_ids,json=accountIds,proto3" json:"account_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountCostsRequest) Reset() {
+	*x = AccountCostsRequest{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountCostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountCostsRequest) ProtoMessage() {}
+
+func (x *AccountCostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountCostsRequest.ProtoReflect.Descriptor instead.
+func (*AccountCostsRequest) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AccountCostsRequest) GetAccountIds() []string {
+	if x != nil {
+		return x.AccountIds
+	}
+	return nil
+}
+
+type AccountCostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Costs         []*AccountCost         `protobuf:"bytes,1,rep,name=costs,proto3" json:"costs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountCostsResponse) Reset() {
+	*x = AccountCostsResponse{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountCostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountCostsResponse) ProtoMessage() {}
+
+func (x *AccountCostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountCostsResponse.ProtoReflect.Descriptor instead.
+func (*AccountCostsResponse) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *AccountCostsResponse) GetCosts() []*AccountCost {
+	if x != nil {
+		return x.Costs
+	}
+	return nil
+}
+
+type AccountCost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Total         float64                `protobuf:"fixed64,2,opt,name=total,proto3" json:"total,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	ByCategory    map[string]float64     `protobuf:"bytes,4,rep,name=by_category,json=byCategory,proto3" json:"by_category,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountCost) Reset() {
+	*x = AccountCost{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountCost) ProtoMessage() {}
+
+func (x *AccountCost) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountCost.ProtoReflect.Descriptor instead.
+func (*AccountCost) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AccountCost) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountCost) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AccountCost) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AccountCost) GetByCategory() map[string]float64 {
+	if x != nil {
+		return x.ByCategory
+	}
+	return nil
+}
+
+type DailySummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD, по умолчанию вчерашние сутки
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailySummaryRequest) Reset() {
+	*x = DailySummaryRequest{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailySummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailySummaryRequest) ProtoMessage() {}
+
+func (x *DailySummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailySummaryRequest.ProtoReflect.Descriptor instead.
+func (*DailySummaryRequest) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DailySummaryRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type DailySummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Generator     string                 `protobuf:"bytes,3,opt,name=generator,proto3" json:"generator,omitempty"` // template или llm
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailySummaryResponse) Reset() {
+	*x = DailySummaryResponse{}
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailySummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailySummaryResponse) ProtoMessage() {}
+
+func (x *DailySummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_analytics_service_proto_analytics_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailySummaryResponse.ProtoReflect.Descriptor instead.
+func (*DailySummaryResponse) Descriptor() ([]byte, []int) {
+	return file_services_analytics_service_proto_analytics_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DailySummaryResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailySummaryResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DailySummaryResponse) GetGenerator() string {
+	if x != nil {
+		return x.Generator
+	}
+	return ""
+}
+
+func (x *DailySummaryResponse) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *DailySummaryResponse) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *DailySummaryResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+var File_services_analytics_service_proto_analytics_proto protoreflect.FileDescriptor
+
+const file_services_analytics_service_proto_analytics_proto_rawDesc = "" +
+	"\n" +
+	"0services/analytics-service/proto/analytics.proto\x12\tanalytics\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x84\x01\n" +
+	"\x10AnalyticsRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xcb\x05\n" +
+	"\x10OverallAnalytics\x12%\n" +
+	"\x0etotal_accounts\x18\x01 \x01(\x03R\rtotalAccounts\x12e\n" +
+	"\x14accounts_by_platform\x18\x02 \x03(\v23.analytics.OverallAnalytics.AccountsByPlatformEntryR\x12accountsByPlatform\x12_\n" +
+	"\x12accounts_by_status\x18\x03 \x03(\v21.analytics.OverallAnalytics.AccountsByStatusEntryR\x10accountsByStatus\x120\n" +
+	"\x14overall_success_rate\x18\x04 \x01(\x01R\x12overallSuccessRate\x12(\n" +
+	"\x10overall_ban_rate\x18\x05 \x01(\x01R\x0eoverallBanRate\x126\n" +
+	"\bexpenses\x18\x06 \x01(\v2\x1a.analytics.ExpensesSummaryR\bexpenses\x129\n" +
+	"\tresources\x18\a \x01(\v2\x1b.analytics.ResourcesSummaryR\tresources\x12?\n" +
+	"\vperformance\x18\b \x01(\v2\x1d.analytics.PerformanceSummaryR\vperformance\x12,\n" +
+	"\x06trends\x18\t \x03(\v2\x14.analytics.TrendDataR\x06trends\x1aE\n" +
+	"\x17AccountsByPlatformEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aC\n" +
+	"\x15AccountsByStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x82\x02\n" +
+	"\x0fExpensesSummary\x12*\n" +
+	"\x11total_spent_today\x18\x01 \x01(\x01R\x0ftotalSpentToday\x12(\n" +
+	"\x10total_spent_week\x18\x02 \x01(\x01R\x0etotalSpentWeek\x12*\n" +
+	"\x11total_spent_month\x18\x03 \x01(\x01R\x0ftotalSpentMonth\x12\x1b\n" +
+	"\tsms_spent\x18\x04 \x01(\x01R\bsmsSpent\x12\x1f\n" +
+	"\vproxy_spent\x18\x05 \x01(\x01R\n" +
+	"proxySpent\x12/\n" +
+	"\x14avg_cost_per_account\x18\x06 \x01(\x01R\x11avgCostPerAccount\"\x8d\x02\n" +
+	"\x10ResourcesSummary\x12%\n" +
+	"\x0eactive_proxies\x18\x01 \x01(\x03R\ractiveProxies\x12%\n" +
+	"\x0ebanned_proxies\x18\x02 \x01(\x03R\rbannedProxies\x12\x1f\n" +
+	"\vsms_balance\x18\x03 \x01(\x01R\n" +
+	"smsBalance\x120\n" +
+	"\x14warming_tasks_active\x18\x04 \x01(\x03R\x12warmingTasksActive\x12,\n" +
+	"\x12sms_deferred_today\x18\x05 \x01(\x03R\x10smsDeferredToday\x12*\n" +
+	"\x11sms_deferral_rate\x18\x06 \x01(\x01R\x0fsmsDeferralRate\"\xfa\x01\n" +
+	"\x12PerformanceSummary\x12(\n" +
+	"\x10avg_warming_days\x18\x01 \x01(\x01R\x0eavgWarmingDays\x124\n" +
+	"\x16accounts_created_today\x18\x02 \x01(\x03R\x14accountsCreatedToday\x120\n" +
+	"\x14accounts_ready_today\x18\x03 \x01(\x03R\x12accountsReadyToday\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x04 \x01(\x01R\terrorRate\x123\n" +
+	"\n" +
+	"top_errors\x18\x05 \x03(\v2\x14.analytics.ErrorStatR\ttopErrors\"\xab\x01\n" +
+	"\tTrendData\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12)\n" +
+	"\x10accounts_created\x18\x02 \x01(\x03R\x0faccountsCreated\x12'\n" +
+	"\x0faccounts_banned\x18\x03 \x01(\x03R\x0eaccountsBanned\x12\x1a\n" +
+	"\bexpenses\x18\x04 \x01(\x01R\bexpenses\"-\n" +
+	"\x0fPlatformRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\"\x8f\x03\n" +
+	"\x11PlatformAnalytics\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12%\n" +
+	"\x0etotal_accounts\x18\x02 \x01(\x03R\rtotalAccounts\x12G\n" +
+	"\tby_status\x18\x03 \x03(\v2*.analytics.PlatformAnalytics.ByStatusEntryR\bbyStatus\x12!\n" +
+	"\fsuccess_rate\x18\x04 \x01(\x01R\vsuccessRate\x12\x19\n" +
+	"\bban_rate\x18\x05 \x01(\x01R\abanRate\x12(\n" +
+	"\x10avg_warming_days\x18\x06 \x01(\x01R\x0eavgWarmingDays\x12\x1f\n" +
+	"\vtotal_spent\x18\a \x01(\x01R\n" +
+	"totalSpent\x12(\n" +
+	"\x0frecommendations\x18\b \x03(\tR\x0frecommendations\x1a;\n" +
+	"\rByStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\")\n" +
+	"\x0fForecastRequest\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\"\x88\x03\n" +
+	"\x17ExpenseForecastResponse\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12%\n" +
+	"\x0epredicted_cost\x18\x02 \x01(\x01R\rpredictedCost\x12\x1f\n" +
+	"\vupper_bound\x18\x03 \x01(\x01R\n" +
+	"upperBound\x12\x1f\n" +
+	"\vlower_bound\x18\x04 \x01(\x01R\n" +
+	"lowerBound\x12O\n" +
+	"\tbreakdown\x18\x05 \x03(\v21.analytics.ExpenseForecastResponse.BreakdownEntryR\tbreakdown\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x06 \x01(\x01R\n" +
+	"confidence\x12=\n" +
+	"\fgenerated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x1a<\n" +
+	"\x0eBreakdownEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"M\n" +
+	"\x10ReadinessRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\"\xd1\x01\n" +
+	"\x19ReadinessForecastResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
+	"\x0eestimated_days\x18\x02 \x01(\x05R\restimatedDays\x12C\n" +
+	"\x0fcompletion_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0ecompletionDate\x12)\n" +
+	"\x10current_progress\x18\x04 \x01(\x01R\x0fcurrentProgress\"0\n" +
+	"\x12OptimalTimeRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\"\x95\x01\n" +
+	"\x13OptimalTimeResponse\x12\x1d\n" +
+	"\n" +
+	"best_hours\x18\x01 \x03(\x05R\tbestHours\x12\x1b\n" +
+	"\tbest_days\x18\x02 \x03(\tR\bbestDays\x12!\n" +
+	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12\x1f\n" +
+	"\vsample_size\x18\x04 \x01(\x03R\n" +
+	"sampleSize\"\x8e\x01\n" +
+	"\x15ProxyRankingsResponse\x126\n" +
+	"\brankings\x18\x01 \x03(\v2\x1a.analytics.ProviderRankingR\brankings\x12=\n" +
+	"\fgenerated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xf4\x01\n" +
+	"\x0fProviderRanking\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12!\n" +
+	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12\x1f\n" +
+	"\vavg_latency\x18\x04 \x01(\x01R\n" +
+	"avgLatency\x12\x19\n" +
+	"\bban_rate\x18\x05 \x01(\x01R\abanRate\x12(\n" +
+	"\x10cost_per_account\x18\x06 \x01(\x01R\x0ecostPerAccount\x12&\n" +
+	"\x0erecommendation\x18\a \x01(\tR\x0erecommendation\"\xd3\x01\n" +
+	"\x1eWarmingRecommendationsResponse\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12)\n" +
+	"\x10recommended_type\x18\x02 \x01(\tR\x0frecommendedType\x12)\n" +
+	"\x10recommended_days\x18\x03 \x01(\x05R\x0frecommendedDays\x12!\n" +
+	"\fsuccess_rate\x18\x04 \x01(\x01R\vsuccessRate\x12\x1c\n" +
+	"\treasoning\x18\x05 \x01(\tR\treasoning\"%\n" +
+	"\x0fAnalysisRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\"K\n" +
+	"\x14ErrorPatternResponse\x123\n" +
+	"\bclusters\x18\x01 \x03(\v2\x17.analytics.ErrorClusterR\bclusters\"\xb4\x01\n" +
+	"\fErrorCluster\x12\x18\n" +
 	"\apattern\x18\x01 \x01(\tR\apattern\x12\x1c\n" +
 	"\tfrequency\x18\x02 \x01(\x03R\tfrequency\x12-\n" +
 	"\x12affected_platforms\x18\x03 \x03(\tR\x11affectedPlatforms\x12\x1d\n" +
@@ -2218,21 +3798,28 @@ const file_services_analytics_service_proto_analytics_proto_rawDesc = "" +
 	"\bfired_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\afiredAt\x12\"\n" +
 	"\facknowledged\x18\t \x01(\bR\facknowledged\"/\n" +
 	"\x12AcknowledgeRequest\x12\x19\n" +
-	"\balert_id\x18\x01 \x01(\tR\aalertId\"\xc8\x01\n" +
+	"\balert_id\x18\x01 \x01(\tR\aalertId\"\xe8\x01\n" +
 	"\x11CreateRuleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
 	"\bplatform\x18\x03 \x01(\tR\bplatform\x127\n" +
 	"\tthreshold\x18\x04 \x01(\v2\x19.analytics.AlertThresholdR\tthreshold\x12\x1a\n" +
 	"\bseverity\x18\x05 \x01(\tR\bseverity\x12\x1a\n" +
-	"\bcooldown\x18\x06 \x01(\x05R\bcooldown\"\x9b\x01\n" +
+	"\bcooldown\x18\x06 \x01(\x05R\bcooldown\x12\x1e\n" +
+	"\n" +
+	"expression\x18\a \x01(\tR\n" +
+	"expression\"\xe6\x01\n" +
 	"\x11UpdateRuleRequest\x12\x17\n" +
 	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x18\n" +
 	"\aenabled\x18\x02 \x01(\bR\aenabled\x127\n" +
 	"\tthreshold\x18\x03 \x01(\v2\x19.analytics.AlertThresholdR\tthreshold\x12\x1a\n" +
-	"\bcooldown\x18\x04 \x01(\x05R\bcooldown\",\n" +
+	"\bcooldown\x18\x04 \x01(\x05R\bcooldown\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x05 \x01(\tR\n" +
+	"expression\x12)\n" +
+	"\x10clear_expression\x18\x06 \x01(\bR\x0fclearExpression\",\n" +
 	"\x11DeleteRuleRequest\x12\x17\n" +
-	"\arule_id\x18\x01 \x01(\tR\x06ruleId\"\xf2\x01\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\"\x92\x02\n" +
 	"\x11AlertRuleResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -2241,15 +3828,149 @@ const file_services_analytics_service_proto_analytics_proto_rawDesc = "" +
 	"\aenabled\x18\x05 \x01(\bR\aenabled\x127\n" +
 	"\tthreshold\x18\x06 \x01(\v2\x19.analytics.AlertThresholdR\tthreshold\x12\x1a\n" +
 	"\bseverity\x18\a \x01(\tR\bseverity\x12\x1a\n" +
-	"\bcooldown\x18\b \x01(\x05R\bcooldown\"H\n" +
+	"\bcooldown\x18\b \x01(\x05R\bcooldown\x12\x1e\n" +
+	"\n" +
+	"expression\x18\t \x01(\tR\n" +
+	"expression\"H\n" +
 	"\x12AlertRulesResponse\x122\n" +
 	"\x05rules\x18\x01 \x03(\v2\x1c.analytics.AlertRuleResponseR\x05rules\"B\n" +
 	"\x0eAlertThreshold\x12\x1a\n" +
 	"\boperator\x18\x01 \x01(\tR\boperator\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value\"5\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\";\n" +
+	"\x19ValidateExpressionRequest\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x01 \x01(\tR\n" +
+	"expression\"\xb0\x01\n" +
+	"\x1aValidateExpressionResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\x12\x1e\n" +
+	"\n" +
+	"normalized\x18\x04 \x01(\tR\n" +
+	"normalized\x12\x18\n" +
+	"\ametrics\x18\x05 \x03(\tR\ametrics\x12\x10\n" +
+	"\x03for\x18\x06 \x01(\tR\x03for\"5\n" +
 	"\tErrorStat\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x03R\x05count2\xa7\t\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"B\n" +
+	"\x10LifecycleRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"\x83\x03\n" +
+	"\x17LifecycleReportResponse\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12V\n" +
+	"\fstate_counts\x18\x02 \x03(\v23.analytics.LifecycleReportResponse.StateCountsEntryR\vstateCounts\x126\n" +
+	"\tdurations\x18\x03 \x03(\v2\x18.analytics.StateDurationR\tdurations\x12=\n" +
+	"\fperiod_start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12=\n" +
+	"\fgenerated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x1a>\n" +
+	"\x10StateCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xb3\x01\n" +
+	"\rStateDuration\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x18\n" +
+	"\asamples\x18\x02 \x01(\x03R\asamples\x12\x1b\n" +
+	"\tavg_hours\x18\x03 \x01(\x01R\bavgHours\x12\x1b\n" +
+	"\tp50_hours\x18\x04 \x01(\x01R\bp50Hours\x12\x1b\n" +
+	"\tp90_hours\x18\x05 \x01(\x01R\bp90Hours\x12\x1b\n" +
+	"\tmax_hours\x18\x06 \x01(\x01R\bmaxHours\"\x83\x01\n" +
+	"\rFunnelRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12%\n" +
+	"\x0eproxy_provider\x18\x02 \x01(\tR\rproxyProvider\x12\x14\n" +
+	"\x05weeks\x18\x03 \x01(\x05R\x05weeks\x12\x19\n" +
+	"\bgroup_by\x18\x04 \x03(\tR\agroupBy\"\xa7\x02\n" +
+	"\x0eFunnelResponse\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x19\n" +
+	"\bgroup_by\x18\x02 \x03(\tR\agroupBy\x12-\n" +
+	"\x05total\x18\x03 \x01(\v2\x17.analytics.FunnelCohortR\x05total\x121\n" +
+	"\acohorts\x18\x04 \x03(\v2\x17.analytics.FunnelCohortR\acohorts\x12=\n" +
+	"\fperiod_start\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12=\n" +
+	"\fgenerated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xc5\x01\n" +
+	"\fFunnelCohort\x12\x12\n" +
+	"\x04week\x18\x01 \x01(\tR\x04week\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12%\n" +
+	"\x0eproxy_provider\x18\x03 \x01(\tR\rproxyProvider\x12.\n" +
+	"\x06stages\x18\x04 \x03(\v2\x16.analytics.FunnelStageR\x06stages\x12.\n" +
+	"\x13banned_before_ready\x18\x05 \x01(\x03R\x11bannedBeforeReady\"\xc8\x01\n" +
+	"\vFunnelStage\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x1a\n" +
+	"\baccounts\x18\x02 \x01(\x03R\baccounts\x12'\n" +
+	"\x0fconversion_rate\x18\x03 \x01(\x01R\x0econversionRate\x12!\n" +
+	"\foverall_rate\x18\x04 \x01(\x01R\voverallRate\x12!\n" +
+	"\fmedian_hours\x18\x05 \x01(\x01R\vmedianHours\x12\x18\n" +
+	"\asamples\x18\x06 \x01(\x03R\asamples\"\x9f\x01\n" +
+	"\x0fSurvivalRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x1a\n" +
+	"\bscenario\x18\x02 \x01(\tR\bscenario\x12%\n" +
+	"\x0eproxy_provider\x18\x03 \x01(\tR\rproxyProvider\x12\x12\n" +
+	"\x04days\x18\x04 \x01(\x05R\x04days\x12\x19\n" +
+	"\bgroup_by\x18\x05 \x03(\tR\agroupBy\"\xa9\x02\n" +
+	"\x10SurvivalResponse\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x19\n" +
+	"\bgroup_by\x18\x02 \x03(\tR\agroupBy\x12.\n" +
+	"\x05total\x18\x03 \x01(\v2\x18.analytics.SurvivalCurveR\x05total\x120\n" +
+	"\x06curves\x18\x04 \x03(\v2\x18.analytics.SurvivalCurveR\x06curves\x12=\n" +
+	"\fperiod_start\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12=\n" +
+	"\fgenerated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xb9\x02\n" +
+	"\rSurvivalCurve\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x1a\n" +
+	"\bscenario\x18\x02 \x01(\tR\bscenario\x12%\n" +
+	"\x0eproxy_provider\x18\x03 \x01(\tR\rproxyProvider\x12\x1a\n" +
+	"\baccounts\x18\x04 \x01(\x03R\baccounts\x12\x16\n" +
+	"\x06banned\x18\x05 \x01(\x03R\x06banned\x12\x1f\n" +
+	"\vmedian_days\x18\x06 \x01(\x05R\n" +
+	"medianDays\x12\x1f\n" +
+	"\vsurvival_7d\x18\a \x01(\x01R\n" +
+	"survival7d\x12!\n" +
+	"\fsurvival_30d\x18\b \x01(\x01R\vsurvival30d\x120\n" +
+	"\x06points\x18\t \x03(\v2\x18.analytics.SurvivalPointR\x06points\"\x8a\x01\n" +
+	"\rSurvivalPoint\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x05R\x03day\x12\x17\n" +
+	"\aat_risk\x18\x02 \x01(\x03R\x06atRisk\x12\x16\n" +
+	"\x06banned\x18\x03 \x01(\x03R\x06banned\x12\x1a\n" +
+	"\bcensored\x18\x04 \x01(\x03R\bcensored\x12\x1a\n" +
+	"\bsurvival\x18\x05 \x01(\x01R\bsurvival\"L\n" +
+	"\x1aRegistrationLatencyRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"\xe1\x01\n" +
+	"\x1bRegistrationLatencyResponse\x12D\n" +
+	"\tplatforms\x18\x01 \x03(\v2&.analytics.PlatformRegistrationLatencyR\tplatforms\x12=\n" +
+	"\fperiod_start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12=\n" +
+	"\fgenerated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xab\x01\n" +
+	"\x1bPlatformRegistrationLatency\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12;\n" +
+	"\n" +
+	"end_to_end\x18\x02 \x01(\v2\x1d.analytics.LatencyPercentilesR\bendToEnd\x123\n" +
+	"\x05steps\x18\x03 \x03(\v2\x1d.analytics.LatencyPercentilesR\x05steps\"\x8d\x01\n" +
+	"\x12LatencyPercentiles\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\tR\x04step\x12\x18\n" +
+	"\asamples\x18\x02 \x01(\x03R\asamples\x12\x17\n" +
+	"\ap50_sec\x18\x03 \x01(\x01R\x06p50Sec\x12\x17\n" +
+	"\ap90_sec\x18\x04 \x01(\x01R\x06p90Sec\x12\x17\n" +
+	"\ap99_sec\x18\x05 \x01(\x01R\x06p99Sec\"6\n" +
+	"\x13AccountCostsRequest\x12\x1f\n" +
+	"\vaccount_ids\x18\x01 \x03(\tR\n" +
+	"accountIds\"D\n" +
+	"\x14AccountCostsResponse\x12,\n" +
+	"\x05costs\x18\x01 \x03(\v2\x16.analytics.AccountCostR\x05costs\"\xe6\x01\n" +
+	"\vAccountCost\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x01R\x05total\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12G\n" +
+	"\vby_category\x18\x04 \x03(\v2&.analytics.AccountCost.ByCategoryEntryR\n" +
+	"byCategory\x1a=\n" +
+	"\x0fByCategoryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\")\n" +
+	"\x13DailySummaryRequest\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\"\x95\x02\n" +
+	"\x14DailySummaryResponse\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1c\n" +
+	"\tgenerator\x18\x03 \x01(\tR\tgenerator\x12=\n" +
+	"\fperiod_start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x129\n" +
+	"\n" +
+	"period_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd\x12=\n" +
+	"\fgenerated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt2\x96\x0e\n" +
 	"\x10AnalyticsService\x12O\n" +
 	"\x13GetOverallAnalytics\x12\x1b.analytics.AnalyticsRequest\x1a\x1b.analytics.OverallAnalytics\x12P\n" +
 	"\x14GetPlatformAnalytics\x12\x1a.analytics.PlatformRequest\x1a\x1c.analytics.PlatformAnalytics\x12T\n" +
@@ -2264,7 +3985,14 @@ const file_services_analytics_service_proto_analytics_proto_rawDesc = "" +
 	"\x0fCreateAlertRule\x12\x1c.analytics.CreateRuleRequest\x1a\x1c.analytics.AlertRuleResponse\x12M\n" +
 	"\x0fUpdateAlertRule\x12\x1c.analytics.UpdateRuleRequest\x1a\x1c.analytics.AlertRuleResponse\x12G\n" +
 	"\x0fDeleteAlertRule\x12\x1c.analytics.DeleteRuleRequest\x1a\x16.google.protobuf.Empty\x12G\n" +
-	"\x0eListAlertRules\x12\x16.google.protobuf.Empty\x1a\x1d.analytics.AlertRulesResponseB<Z:github.com/grigta/conveer/services/analytics-service/protob\x06proto3"
+	"\x0eListAlertRules\x12\x16.google.protobuf.Empty\x1a\x1d.analytics.AlertRulesResponse\x12f\n" +
+	"\x17ValidateAlertExpression\x12$.analytics.ValidateExpressionRequest\x1a%.analytics.ValidateExpressionResponse\x12\\\n" +
+	"\x19GetAccountLifecycleReport\x12\x1b.analytics.LifecycleRequest\x1a\".analytics.LifecycleReportResponse\x12G\n" +
+	"\x10GetAccountFunnel\x12\x18.analytics.FunnelRequest\x1a\x19.analytics.FunnelResponse\x12M\n" +
+	"\x12GetAccountSurvival\x12\x1a.analytics.SurvivalRequest\x1a\x1b.analytics.SurvivalResponse\x12g\n" +
+	"\x16GetRegistrationLatency\x12%.analytics.RegistrationLatencyRequest\x1a&.analytics.RegistrationLatencyResponse\x12R\n" +
+	"\x0fGetAccountCosts\x12\x1e.analytics.AccountCostsRequest\x1a\x1f.analytics.AccountCostsResponse\x12R\n" +
+	"\x0fGetDailySummary\x12\x1e.analytics.DailySummaryRequest\x1a\x1f.analytics.DailySummaryResponseB<Z:github.com/grigta/conveer/services/analytics-service/protob\x06proto3"
 
 var (
 	file_services_analytics_service_proto_analytics_proto_rawDescOnce sync.Once
@@ -2278,7 +4006,7 @@ func file_services_analytics_service_proto_analytics_proto_rawDescGZIP() []byte
 	return file_services_analytics_service_proto_analytics_proto_rawDescData
 }
 
-var file_services_analytics_service_proto_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_services_analytics_service_proto_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
 var file_services_analytics_service_proto_analytics_proto_goTypes = []any{
 	(*AnalyticsRequest)(nil),               // 0: analytics.AnalyticsRequest
 	(*OverallAnalytics)(nil),               // 1: analytics.OverallAnalytics
@@ -2310,71 +4038,133 @@ var file_services_analytics_service_proto_analytics_proto_goTypes = []any{
 	(*AlertRuleResponse)(nil),              // 27: analytics.AlertRuleResponse
 	(*AlertRulesResponse)(nil),             // 28: analytics.AlertRulesResponse
 	(*AlertThreshold)(nil),                 // 29: analytics.AlertThreshold
-	(*ErrorStat)(nil),                      // 30: analytics.ErrorStat
-	nil,                                    // 31: analytics.OverallAnalytics.AccountsByPlatformEntry
-	nil,                                    // 32: analytics.OverallAnalytics.AccountsByStatusEntry
-	nil,                                    // 33: analytics.PlatformAnalytics.ByStatusEntry
-	nil,                                    // 34: analytics.ExpenseForecastResponse.BreakdownEntry
-	(*timestamppb.Timestamp)(nil),          // 35: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                  // 36: google.protobuf.Empty
+	(*ValidateExpressionRequest)(nil),      // 30: analytics.ValidateExpressionRequest
+	(*ValidateExpressionResponse)(nil),     // 31: analytics.ValidateExpressionResponse
+	(*ErrorStat)(nil),                      // 32: analytics.ErrorStat
+	(*LifecycleRequest)(nil),               // 33: analytics.LifecycleRequest
+	(*LifecycleReportResponse)(nil),        // 34: analytics.LifecycleReportResponse
+	(*StateDuration)(nil),                  // 35: analytics.StateDuration
+	(*FunnelRequest)(nil),                  // 36: analytics.FunnelRequest
+	(*FunnelResponse)(nil),                 // 37: analytics.FunnelResponse
+	(*FunnelCohort)(nil),                   // 38: analytics.FunnelCohort
+	(*FunnelStage)(nil),                    // 39: analytics.FunnelStage
+	(*SurvivalRequest)(nil),                // 40: analytics.SurvivalRequest
+	(*SurvivalResponse)(nil),               // 41: analytics.SurvivalResponse
+	(*SurvivalCurve)(nil),                  // 42: analytics.SurvivalCurve
+	(*SurvivalPoint)(nil),                  // 43: analytics.SurvivalPoint
+	(*RegistrationLatencyRequest)(nil),     // 44: analytics.RegistrationLatencyRequest
+	(*RegistrationLatencyResponse)(nil),    // 45: analytics.RegistrationLatencyResponse
+	(*PlatformRegistrationLatency)(nil),    // 46: analytics.PlatformRegistrationLatency
+	(*LatencyPercentiles)(nil),             // 47: analytics.LatencyPercentiles
+	(*AccountCostsRequest)(nil),            // 48: analytics.AccountCostsRequest
+	(*AccountCostsResponse)(nil),           // 49: analytics.AccountCostsResponse
+	(*AccountCost)(nil),                    // 50: analytics.AccountCost
+	(*DailySummaryRequest)(nil),            // 51: analytics.DailySummaryRequest
+	(*DailySummaryResponse)(nil),           // 52: analytics.DailySummaryResponse
+	nil,                                    // 53: analytics.OverallAnalytics.AccountsByPlatformEntry
+	nil,                                    // 54: analytics.OverallAnalytics.AccountsByStatusEntry
+	nil,                                    // 55: analytics.PlatformAnalytics.ByStatusEntry
+	nil,                                    // 56: analytics.ExpenseForecastResponse.BreakdownEntry
+	nil,                                    // 57: analytics.LifecycleReportResponse.StateCountsEntry
+	nil,                                    // 58: analytics.AccountCost.ByCategoryEntry
+	(*timestamppb.Timestamp)(nil),          // 59: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                  // 60: google.protobuf.Empty
 }
 var file_services_analytics_service_proto_analytics_proto_depIdxs = []int32{
-	35, // 0: analytics.AnalyticsRequest.start_date:type_name -> google.protobuf.Timestamp
-	35, // 1: analytics.AnalyticsRequest.end_date:type_name -> google.protobuf.Timestamp
-	31, // 2: analytics.OverallAnalytics.accounts_by_platform:type_name -> analytics.OverallAnalytics.AccountsByPlatformEntry
-	32, // 3: analytics.OverallAnalytics.accounts_by_status:type_name -> analytics.OverallAnalytics.AccountsByStatusEntry
+	59, // 0: analytics.AnalyticsRequest.start_date:type_name -> google.protobuf.Timestamp
+	59, // 1: analytics.AnalyticsRequest.end_date:type_name -> google.protobuf.Timestamp
+	53, // 2: analytics.OverallAnalytics.accounts_by_platform:type_name -> analytics.OverallAnalytics.AccountsByPlatformEntry
+	54, // 3: analytics.OverallAnalytics.accounts_by_status:type_name -> analytics.OverallAnalytics.AccountsByStatusEntry
 	2,  // 4: analytics.OverallAnalytics.expenses:type_name -> analytics.ExpensesSummary
 	3,  // 5: analytics.OverallAnalytics.resources:type_name -> analytics.ResourcesSummary
 	4,  // 6: analytics.OverallAnalytics.performance:type_name -> analytics.PerformanceSummary
 	5,  // 7: analytics.OverallAnalytics.trends:type_name -> analytics.TrendData
-	30, // 8: analytics.PerformanceSummary.top_errors:type_name -> analytics.ErrorStat
-	35, // 9: analytics.TrendData.date:type_name -> google.protobuf.Timestamp
-	33, // 10: analytics.PlatformAnalytics.by_status:type_name -> analytics.PlatformAnalytics.ByStatusEntry
-	34, // 11: analytics.ExpenseForecastResponse.breakdown:type_name -> analytics.ExpenseForecastResponse.BreakdownEntry
-	35, // 12: analytics.ExpenseForecastResponse.generated_at:type_name -> google.protobuf.Timestamp
-	35, // 13: analytics.ReadinessForecastResponse.completion_date:type_name -> google.protobuf.Timestamp
+	32, // 8: analytics.PerformanceSummary.top_errors:type_name -> analytics.ErrorStat
+	59, // 9: analytics.TrendData.date:type_name -> google.protobuf.Timestamp
+	55, // 10: analytics.PlatformAnalytics.by_status:type_name -> analytics.PlatformAnalytics.ByStatusEntry
+	56, // 11: analytics.ExpenseForecastResponse.breakdown:type_name -> analytics.ExpenseForecastResponse.BreakdownEntry
+	59, // 12: analytics.ExpenseForecastResponse.generated_at:type_name -> google.protobuf.Timestamp
+	59, // 13: analytics.ReadinessForecastResponse.completion_date:type_name -> google.protobuf.Timestamp
 	15, // 14: analytics.ProxyRankingsResponse.rankings:type_name -> analytics.ProviderRanking
-	35, // 15: analytics.ProxyRankingsResponse.generated_at:type_name -> google.protobuf.Timestamp
+	59, // 15: analytics.ProxyRankingsResponse.generated_at:type_name -> google.protobuf.Timestamp
 	19, // 16: analytics.ErrorPatternResponse.clusters:type_name -> analytics.ErrorCluster
 	22, // 17: analytics.AlertsResponse.alerts:type_name -> analytics.AlertEvent
-	35, // 18: analytics.AlertEvent.fired_at:type_name -> google.protobuf.Timestamp
+	59, // 18: analytics.AlertEvent.fired_at:type_name -> google.protobuf.Timestamp
 	29, // 19: analytics.CreateRuleRequest.threshold:type_name -> analytics.AlertThreshold
 	29, // 20: analytics.UpdateRuleRequest.threshold:type_name -> analytics.AlertThreshold
 	29, // 21: analytics.AlertRuleResponse.threshold:type_name -> analytics.AlertThreshold
 	27, // 22: analytics.AlertRulesResponse.rules:type_name -> analytics.AlertRuleResponse
-	0,  // 23: analytics.AnalyticsService.GetOverallAnalytics:input_type -> analytics.AnalyticsRequest
-	6,  // 24: analytics.AnalyticsService.GetPlatformAnalytics:input_type -> analytics.PlatformRequest
-	8,  // 25: analytics.AnalyticsService.GetExpenseForecast:input_type -> analytics.ForecastRequest
-	10, // 26: analytics.AnalyticsService.GetAccountReadinessForecast:input_type -> analytics.ReadinessRequest
-	12, // 27: analytics.AnalyticsService.GetOptimalRegistrationTime:input_type -> analytics.OptimalTimeRequest
-	36, // 28: analytics.AnalyticsService.GetProxyProviderRankings:input_type -> google.protobuf.Empty
-	6,  // 29: analytics.AnalyticsService.GetWarmingScenarioRecommendations:input_type -> analytics.PlatformRequest
-	17, // 30: analytics.AnalyticsService.GetErrorPatternAnalysis:input_type -> analytics.AnalysisRequest
-	20, // 31: analytics.AnalyticsService.GetActiveAlerts:input_type -> analytics.AlertsRequest
-	23, // 32: analytics.AnalyticsService.AcknowledgeAlert:input_type -> analytics.AcknowledgeRequest
-	24, // 33: analytics.AnalyticsService.CreateAlertRule:input_type -> analytics.CreateRuleRequest
-	25, // 34: analytics.AnalyticsService.UpdateAlertRule:input_type -> analytics.UpdateRuleRequest
-	26, // 35: analytics.AnalyticsService.DeleteAlertRule:input_type -> analytics.DeleteRuleRequest
-	36, // 36: analytics.AnalyticsService.ListAlertRules:input_type -> google.protobuf.Empty
-	1,  // 37: analytics.AnalyticsService.GetOverallAnalytics:output_type -> analytics.OverallAnalytics
-	7,  // 38: analytics.AnalyticsService.GetPlatformAnalytics:output_type -> analytics.PlatformAnalytics
-	9,  // 39: analytics.AnalyticsService.GetExpenseForecast:output_type -> analytics.ExpenseForecastResponse
-	11, // 40: analytics.AnalyticsService.GetAccountReadinessForecast:output_type -> analytics.ReadinessForecastResponse
-	13, // 41: analytics.AnalyticsService.GetOptimalRegistrationTime:output_type -> analytics.OptimalTimeResponse
-	14, // 42: analytics.AnalyticsService.GetProxyProviderRankings:output_type -> analytics.ProxyRankingsResponse
-	16, // 43: analytics.AnalyticsService.GetWarmingScenarioRecommendations:output_type -> analytics.WarmingRecommendationsResponse
-	18, // 44: analytics.AnalyticsService.GetErrorPatternAnalysis:output_type -> analytics.ErrorPatternResponse
-	21, // 45: analytics.AnalyticsService.GetActiveAlerts:output_type -> analytics.AlertsResponse
-	36, // 46: analytics.AnalyticsService.AcknowledgeAlert:output_type -> google.protobuf.Empty
-	27, // 47: analytics.AnalyticsService.CreateAlertRule:output_type -> analytics.AlertRuleResponse
-	27, // 48: analytics.AnalyticsService.UpdateAlertRule:output_type -> analytics.AlertRuleResponse
-	36, // 49: analytics.AnalyticsService.DeleteAlertRule:output_type -> google.protobuf.Empty
-	28, // 50: analytics.AnalyticsService.ListAlertRules:output_type -> analytics.AlertRulesResponse
-	37, // [37:51] is the sub-list for method output_type
-	23, // [23:37] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	57, // 23: analytics.LifecycleReportResponse.state_counts:type_name -> analytics.LifecycleReportResponse.StateCountsEntry
+	35, // 24: analytics.LifecycleReportResponse.durations:type_name -> analytics.StateDuration
+	59, // 25: analytics.LifecycleReportResponse.period_start:type_name -> google.protobuf.Timestamp
+	59, // 26: analytics.LifecycleReportResponse.generated_at:type_name -> google.protobuf.Timestamp
+	38, // 27: analytics.FunnelResponse.total:type_name -> analytics.FunnelCohort
+	38, // 28: analytics.FunnelResponse.cohorts:type_name -> analytics.FunnelCohort
+	59, // 29: analytics.FunnelResponse.period_start:type_name -> google.protobuf.Timestamp
+	59, // 30: analytics.FunnelResponse.generated_at:type_name -> google.protobuf.Timestamp
+	39, // 31: analytics.FunnelCohort.stages:type_name -> analytics.FunnelStage
+	42, // 32: analytics.SurvivalResponse.total:type_name -> analytics.SurvivalCurve
+	42, // 33: analytics.SurvivalResponse.curves:type_name -> analytics.SurvivalCurve
+	59, // 34: analytics.SurvivalResponse.period_start:type_name -> google.protobuf.Timestamp
+	59, // 35: analytics.SurvivalResponse.generated_at:type_name -> google.protobuf.Timestamp
+	43, // 36: analytics.SurvivalCurve.points:type_name -> analytics.SurvivalPoint
+	46, // 37: analytics.RegistrationLatencyResponse.platforms:type_name -> analytics.PlatformRegistrationLatency
+	59, // 38: analytics.RegistrationLatencyResponse.period_start:type_name -> google.protobuf.Timestamp
+	59, // 39: analytics.RegistrationLatencyResponse.generated_at:type_name -> google.protobuf.Timestamp
+	47, // 40: analytics.PlatformRegistrationLatency.end_to_end:type_name -> analytics.LatencyPercentiles
+	47, // 41: analytics.PlatformRegistrationLatency.steps:type_name -> analytics.LatencyPercentiles
+	50, // 42: analytics.AccountCostsResponse.costs:type_name -> analytics.AccountCost
+	58, // 43: analytics.AccountCost.by_category:type_name -> analytics.AccountCost.ByCategoryEntry
+	59, // 44: analytics.DailySummaryResponse.period_start:type_name -> google.protobuf.Timestamp
+	59, // 45: analytics.DailySummaryResponse.period_end:type_name -> google.protobuf.Timestamp
+	59, // 46: analytics.DailySummaryResponse.generated_at:type_name -> google.protobuf.Timestamp
+	0,  // 47: analytics.AnalyticsService.GetOverallAnalytics:input_type -> analytics.AnalyticsRequest
+	6,  // 48: analytics.AnalyticsService.GetPlatformAnalytics:input_type -> analytics.PlatformRequest
+	8,  // 49: analytics.AnalyticsService.GetExpenseForecast:input_type -> analytics.ForecastRequest
+	10, // 50: analytics.AnalyticsService.GetAccountReadinessForecast:input_type -> analytics.ReadinessRequest
+	12, // 51: analytics.AnalyticsService.GetOptimalRegistrationTime:input_type -> analytics.OptimalTimeRequest
+	60, // 52: analytics.AnalyticsService.GetProxyProviderRankings:input_type -> google.protobuf.Empty
+	6,  // 53: analytics.AnalyticsService.GetWarmingScenarioRecommendations:input_type -> analytics.PlatformRequest
+	17, // 54: analytics.AnalyticsService.GetErrorPatternAnalysis:input_type -> analytics.AnalysisRequest
+	20, // 55: analytics.AnalyticsService.GetActiveAlerts:input_type -> analytics.AlertsRequest
+	23, // 56: analytics.AnalyticsService.AcknowledgeAlert:input_type -> analytics.AcknowledgeRequest
+	24, // 57: analytics.AnalyticsService.CreateAlertRule:input_type -> analytics.CreateRuleRequest
+	25, // 58: analytics.AnalyticsService.UpdateAlertRule:input_type -> analytics.UpdateRuleRequest
+	26, // 59: analytics.AnalyticsService.DeleteAlertRule:input_type -> analytics.DeleteRuleRequest
+	60, // 60: analytics.AnalyticsService.ListAlertRules:input_type -> google.protobuf.Empty
+	30, // 61: analytics.AnalyticsService.ValidateAlertExpression:input_type -> analytics.ValidateExpressionRequest
+	33, // 62: analytics.AnalyticsService.GetAccountLifecycleReport:input_type -> analytics.LifecycleRequest
+	36, // 63: analytics.AnalyticsService.GetAccountFunnel:input_type -> analytics.FunnelRequest
+	40, // 64: analytics.AnalyticsService.GetAccountSurvival:input_type -> analytics.SurvivalRequest
+	44, // 65: analytics.AnalyticsService.GetRegistrationLatency:input_type -> analytics.RegistrationLatencyRequest
+	48, // 66: analytics.AnalyticsService.GetAccountCosts:input_type -> analytics.AccountCostsRequest
+	51, // 67: analytics.AnalyticsService.GetDailySummary:input_type -> analytics.DailySummaryRequest
+	1,  // 68: analytics.AnalyticsService.GetOverallAnalytics:output_type -> analytics.OverallAnalytics
+	7,  // 69: analytics.AnalyticsService.GetPlatformAnalytics:output_type -> analytics.PlatformAnalytics
+	9,  // 70: analytics.AnalyticsService.GetExpenseForecast:output_type -> analytics.ExpenseForecastResponse
+	11, // 71: analytics.AnalyticsService.GetAccountReadinessForecast:output_type -> analytics.ReadinessForecastResponse
+	13, // 72: analytics.AnalyticsService.GetOptimalRegistrationTime:output_type -> analytics.OptimalTimeResponse
+	14, // 73: analytics.AnalyticsService.GetProxyProviderRankings:output_type -> analytics.ProxyRankingsResponse
+	16, // 74: analytics.AnalyticsService.GetWarmingScenarioRecommendations:output_type -> analytics.WarmingRecommendationsResponse
+	18, // 75: analytics.AnalyticsService.GetErrorPatternAnalysis:output_type -> analytics.ErrorPatternResponse
+	21, // 76: analytics.AnalyticsService.GetActiveAlerts:output_type -> analytics.AlertsResponse
+	60, // 77: analytics.AnalyticsService.AcknowledgeAlert:output_type -> google.protobuf.Empty
+	27, // 78: analytics.AnalyticsService.CreateAlertRule:output_type -> analytics.AlertRuleResponse
+	27, // 79: analytics.AnalyticsService.UpdateAlertRule:output_type -> analytics.AlertRuleResponse
+	60, // 80: analytics.AnalyticsService.DeleteAlertRule:output_type -> google.protobuf.Empty
+	28, // 81: analytics.AnalyticsService.ListAlertRules:output_type -> analytics.AlertRulesResponse
+	31, // 82: analytics.AnalyticsService.ValidateAlertExpression:output_type -> analytics.ValidateExpressionResponse
+	34, // 83: analytics.AnalyticsService.GetAccountLifecycleReport:output_type -> analytics.LifecycleReportResponse
+	37, // 84: analytics.AnalyticsService.GetAccountFunnel:output_type -> analytics.FunnelResponse
+	41, // 85: analytics.AnalyticsService.GetAccountSurvival:output_type -> analytics.SurvivalResponse
+	45, // 86: analytics.AnalyticsService.GetRegistrationLatency:output_type -> analytics.RegistrationLatencyResponse
+	49, // 87: analytics.AnalyticsService.GetAccountCosts:output_type -> analytics.AccountCostsResponse
+	52, // 88: analytics.AnalyticsService.GetDailySummary:output_type -> analytics.DailySummaryResponse
+	68, // [68:89] is the sub-list for method output_type
+	47, // [47:68] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_services_analytics_service_proto_analytics_proto_init() }
@@ -2388,7 +4178,7 @@ func file_services_analytics_service_proto_analytics_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_analytics_service_proto_analytics_proto_rawDesc), len(file_services_analytics_service_proto_analytics_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   35,
+			NumMessages:   59,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -34,6 +34,13 @@ const (
 	AnalyticsService_UpdateAlertRule_FullMethodName                   = "/analytics.AnalyticsService/UpdateAlertRule"
 	AnalyticsService_DeleteAlertRule_FullMethodName                   = "/analytics.AnalyticsService/DeleteAlertRule"
 	AnalyticsService_ListAlertRules_FullMethodName                    = "/analytics.AnalyticsService/ListAlertRules"
+	AnalyticsService_ValidateAlertExpression_FullMethodName           = "/analytics.AnalyticsService/ValidateAlertExpression"
+	AnalyticsService_GetAccountLifecycleReport_FullMethodName         = "/analytics.AnalyticsService/GetAccountLifecycleReport"
+	AnalyticsService_GetAccountFunnel_FullMethodName                  = "/analytics.AnalyticsService/GetAccountFunnel"
+	AnalyticsService_GetAccountSurvival_FullMethodName                = "/analytics.AnalyticsService/GetAccountSurvival"
+	AnalyticsService_GetRegistrationLatency_FullMethodName            = "/analytics.AnalyticsService/GetRegistrationLatency"
+	AnalyticsService_GetAccountCosts_FullMethodName                   = "/analytics.AnalyticsService/GetAccountCosts"
+	AnalyticsService_GetDailySummary_FullMethodName                   = "/analytics.AnalyticsService/GetDailySummary"
 )
 
 // AnalyticsServiceClient is the client API for AnalyticsService service.
@@ -59,6 +66,17 @@ type AnalyticsServiceClient interface {
 	UpdateAlertRule(ctx context.Context, in *UpdateRuleRequest, opts ...grpc.CallOption) (*AlertRuleResponse, error)
 	DeleteAlertRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListAlertRules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AlertRulesResponse, error)
+	ValidateAlertExpression(ctx context.Context, in *ValidateExpressionRequest, opts ...grpc.CallOption) (*ValidateExpressionResponse, error)
+	// Жизненный цикл аккаунтов
+	GetAccountLifecycleReport(ctx context.Context, in *LifecycleRequest, opts ...grpc.CallOption) (*LifecycleReportResponse, error)
+	GetAccountFunnel(ctx context.Context, in *FunnelRequest, opts ...grpc.CallOption) (*FunnelResponse, error)
+	GetAccountSurvival(ctx context.Context, in *SurvivalRequest, opts ...grpc.CallOption) (*SurvivalResponse, error)
+	// Длительность регистраций
+	GetRegistrationLatency(ctx context.Context, in *RegistrationLatencyRequest, opts ...grpc.CallOption) (*RegistrationLatencyResponse, error)
+	// Расходы по аккаунтам
+	GetAccountCosts(ctx context.Context, in *AccountCostsRequest, opts ...grpc.CallOption) (*AccountCostsResponse, error)
+	// Ежедневная текстовая сводка
+	GetDailySummary(ctx context.Context, in *DailySummaryRequest, opts ...grpc.CallOption) (*DailySummaryResponse, error)
 }
 
 type analyticsServiceClient struct {
@@ -209,6 +227,76 @@ func (c *analyticsServiceClient) ListAlertRules(ctx context.Context, in *emptypb
 	return out, nil
 }
 
+func (c *analyticsServiceClient) ValidateAlertExpression(ctx context.Context, in *ValidateExpressionRequest, opts ...grpc.CallOption) (*ValidateExpressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateExpressionResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_ValidateAlertExpression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetAccountLifecycleReport(ctx context.Context, in *LifecycleRequest, opts ...grpc.CallOption) (*LifecycleReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LifecycleReportResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetAccountLifecycleReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetAccountFunnel(ctx context.Context, in *FunnelRequest, opts ...grpc.CallOption) (*FunnelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FunnelResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetAccountFunnel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetAccountSurvival(ctx context.Context, in *SurvivalRequest, opts ...grpc.CallOption) (*SurvivalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SurvivalResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetAccountSurvival_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetRegistrationLatency(ctx context.Context, in *RegistrationLatencyRequest, opts ...grpc.CallOption) (*RegistrationLatencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationLatencyResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetRegistrationLatency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetAccountCosts(ctx context.Context, in *AccountCostsRequest, opts ...grpc.CallOption) (*AccountCostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountCostsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetAccountCosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetDailySummary(ctx context.Context, in *DailySummaryRequest, opts ...grpc.CallOption) (*DailySummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DailySummaryResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetDailySummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AnalyticsServiceServer is the server API for AnalyticsService service.
 // All implementations must embed UnimplementedAnalyticsServiceServer
 // for forward compatibility.
@@ -232,6 +320,17 @@ type AnalyticsServiceServer interface {
 	UpdateAlertRule(context.Context, *UpdateRuleRequest) (*AlertRuleResponse, error)
 	DeleteAlertRule(context.Context, *DeleteRuleRequest) (*emptypb.Empty, error)
 	ListAlertRules(context.Context, *emptypb.Empty) (*AlertRulesResponse, error)
+	ValidateAlertExpression(context.Context, *ValidateExpressionRequest) (*ValidateExpressionResponse, error)
+	// Жизненный цикл аккаунтов
+	GetAccountLifecycleReport(context.Context, *LifecycleRequest) (*LifecycleReportResponse, error)
+	GetAccountFunnel(context.Context, *FunnelRequest) (*FunnelResponse, error)
+	GetAccountSurvival(context.Context, *SurvivalRequest) (*SurvivalResponse, error)
+	// Длительность регистраций
+	GetRegistrationLatency(context.Context, *RegistrationLatencyRequest) (*RegistrationLatencyResponse, error)
+	// Расходы по аккаунтам
+	GetAccountCosts(context.Context, *AccountCostsRequest) (*AccountCostsResponse, error)
+	// Ежедневная текстовая сводка
+	GetDailySummary(context.Context, *DailySummaryRequest) (*DailySummaryResponse, error)
 	mustEmbedUnimplementedAnalyticsServiceServer()
 }
 
@@ -284,6 +383,27 @@ func (UnimplementedAnalyticsServiceServer) DeleteAlertRule(context.Context, *Del
 func (UnimplementedAnalyticsServiceServer) ListAlertRules(context.Context, *emptypb.Empty) (*AlertRulesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListAlertRules not implemented")
 }
+func (UnimplementedAnalyticsServiceServer) ValidateAlertExpression(context.Context, *ValidateExpressionRequest) (*ValidateExpressionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateAlertExpression not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetAccountLifecycleReport(context.Context, *LifecycleRequest) (*LifecycleReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountLifecycleReport not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetAccountFunnel(context.Context, *FunnelRequest) (*FunnelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountFunnel not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetAccountSurvival(context.Context, *SurvivalRequest) (*SurvivalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountSurvival not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetRegistrationLatency(context.Context, *RegistrationLatencyRequest) (*RegistrationLatencyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRegistrationLatency not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetAccountCosts(context.Context, *AccountCostsRequest) (*AccountCostsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountCosts not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetDailySummary(context.Context, *DailySummaryRequest) (*DailySummaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDailySummary not implemented")
+}
 func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
 func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
 
@@ -557,6 +677,132 @@ func _AnalyticsService_ListAlertRules_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnalyticsService_ValidateAlertExpression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateExpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ValidateAlertExpression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ValidateAlertExpression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ValidateAlertExpression(ctx, req.(*ValidateExpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetAccountLifecycleReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LifecycleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetAccountLifecycleReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetAccountLifecycleReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetAccountLifecycleReport(ctx, req.(*LifecycleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetAccountFunnel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FunnelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetAccountFunnel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetAccountFunnel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetAccountFunnel(ctx, req.(*FunnelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetAccountSurvival_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SurvivalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetAccountSurvival(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetAccountSurvival_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetAccountSurvival(ctx, req.(*SurvivalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetRegistrationLatency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationLatencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetRegistrationLatency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetRegistrationLatency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetRegistrationLatency(ctx, req.(*RegistrationLatencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetAccountCosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountCostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetAccountCosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetAccountCosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetAccountCosts(ctx, req.(*AccountCostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetDailySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DailySummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetDailySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetDailySummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetDailySummary(ctx, req.(*DailySummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -620,6 +866,34 @@ var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAlertRules",
 			Handler:    _AnalyticsService_ListAlertRules_Handler,
 		},
+		{
+			MethodName: "ValidateAlertExpression",
+			Handler:    _AnalyticsService_ValidateAlertExpression_Handler,
+		},
+		{
+			MethodName: "GetAccountLifecycleReport",
+			Handler:    _AnalyticsService_GetAccountLifecycleReport_Handler,
+		},
+		{
+			MethodName: "GetAccountFunnel",
+			Handler:    _AnalyticsService_GetAccountFunnel_Handler,
+		},
+		{
+			MethodName: "GetAccountSurvival",
+			Handler:    _AnalyticsService_GetAccountSurvival_Handler,
+		},
+		{
+			MethodName: "GetRegistrationLatency",
+			Handler:    _AnalyticsService_GetRegistrationLatency_Handler,
+		},
+		{
+			MethodName: "GetAccountCosts",
+			Handler:    _AnalyticsService_GetAccountCosts_Handler,
+		},
+		{
+			MethodName: "GetDailySummary",
+			Handler:    _AnalyticsService_GetDailySummary_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/analytics-service/proto/analytics.proto",
@@ -9,11 +9,17 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/grigta/conveer/pkg/cache"
 	"github.com/grigta/conveer/pkg/config"
 	"github.com/grigta/conveer/pkg/crypto"
 	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/serviceauth"
+	"github.com/grigta/conveer/services/api-gateway/internal/caching"
+	"github.com/grigta/conveer/services/api-gateway/internal/graphql"
 	"github.com/grigta/conveer/services/api-gateway/internal/handlers"
 	"github.com/grigta/conveer/services/api-gateway/internal/routes"
+	"github.com/grigta/conveer/services/api-gateway/internal/shadowing"
 	"github.com/gin-gonic/gin"
 )
 
@@ -37,11 +43,59 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	appCtx, stopApp := context.WithCancel(context.Background())
+	defer stopApp()
+
+	var responseCache *caching.ResponseCache
+	if cfg.ResponseCache.Enabled {
+		redisCache, err := cache.NewRedisCache(cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.Password, cfg.Redis.DB)
+		if err != nil {
+			logger.Warn("Response cache disabled, Redis is unavailable", logger.Field{Key: "error", Value: err.Error()})
+		} else {
+			defer redisCache.Close()
+			responseCache = caching.NewResponseCache(redisCache, cfg.ResponseCache.DefaultTTL)
+
+			// Without the broker entries still expire by TTL, they just aren't dropped early
+			broker, err := messaging.NewRabbitMQ(cfg.RabbitMQ.URL)
+			if err != nil {
+				logger.Warn("Response cache invalidation disabled", logger.Field{Key: "error", Value: err.Error()})
+			} else {
+				defer broker.Close()
+				if err := responseCache.ListenForInvalidations(appCtx, broker); err != nil {
+					logger.Warn("Failed to subscribe to analytics events", logger.Field{Key: "error", Value: err.Error()})
+				}
+			}
+		}
+	}
+
+	var shadower *shadowing.Shadower
+	if cfg.Shadow.Enabled && cfg.Shadow.TargetURL != "" {
+		shadower, err = shadowing.NewShadower(cfg.Shadow)
+		if err != nil {
+			logger.Warn("Request shadowing disabled", logger.Field{Key: "error", Value: err.Error()})
+		}
+	}
+
+	var (
+		graphqlSchema *graphql.Schema
+		accountDetail gin.HandlerFunc
+	)
+	if cfg.GraphQL.Enabled {
+		clients, err := graphql.DialClients(cfg.GraphQL, serviceauth.FromEnv("api-gateway"))
+		if err != nil {
+			logger.Warn("GraphQL endpoint disabled", logger.Field{Key: "error", Value: err.Error()})
+		} else {
+			defer clients.Close()
+			graphqlSchema = graphql.NewGatewaySchema(clients, cfg.GraphQL.MaxDepth, cfg.GraphQL.ResolverTimeout)
+			accountDetail = graphql.AccountDetailHandler(clients, cfg.GraphQL.ResolverTimeout)
+		}
+	}
+
 	router := gin.New()
 	router.Use(gin.Recovery())
 
 	h := handlers.NewHandlers(cfg)
-	routes.SetupRoutes(router, h, cfg)
+	routes.SetupRoutes(router, h, cfg, responseCache, shadower, graphqlSchema, accountDetail)
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.App.Port),
@@ -0,0 +1,230 @@
+package caching
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/cache"
+	"github.com/grigta/conveer/pkg/logger"
+)
+
+// Cache tags group routes that are invalidated together
+const (
+	TagAnalyticsDashboard = "analytics:dashboard"
+	TagAnalyticsReports   = "analytics:reports"
+)
+
+const (
+	keyPrefix = "gateway:response:"
+
+	// tagIndexTTL outlives every route TTL so a tag always knows its live entries
+	tagIndexTTL = 24 * time.Hour
+)
+
+// ResponseCache stores upstream GET responses in Redis. Routes opt in through Middleware.
+type ResponseCache struct {
+	store      *cache.RedisCache
+	defaultTTL time.Duration
+}
+
+type entry struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+	Body        []byte `json:"body"`
+}
+
+func NewResponseCache(store *cache.RedisCache, defaultTTL time.Duration) *ResponseCache {
+	if defaultTTL <= 0 {
+		defaultTTL = time.Minute
+	}
+
+	return &ResponseCache{
+		store:      store,
+		defaultTTL: defaultTTL,
+	}
+}
+
+// Middleware serves successful GET responses from the cache for ttl, or the default TTL when ttl is zero.
+// A nil cache passes requests through, so routes can be wired the same way whether caching is enabled or not.
+func (rc *ResponseCache) Middleware(tag string, ttl time.Duration) gin.HandlerFunc {
+	if rc == nil {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	if ttl <= 0 {
+		ttl = rc.defaultTTL
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet || c.GetHeader("Cache-Control") == "no-cache" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key := rc.key(tag, c)
+
+		var cached entry
+		err := rc.store.GetJSON(ctx, key, &cached)
+		if err == nil {
+			cacheRequests.WithLabelValues(tag, "hit").Inc()
+			serve(c, &cached, "HIT")
+			c.Abort()
+			return
+		}
+		if !errors.Is(err, cache.ErrCacheMiss) {
+			logger.Warn("Failed to read response cache",
+				logger.Field{Key: "key", Value: key},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+		}
+		cacheRequests.WithLabelValues(tag, "miss").Inc()
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		e := &entry{
+			Status:      writer.status,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+
+		if e.Status != http.StatusOK {
+			c.Writer.WriteHeader(e.Status)
+			c.Writer.Write(e.Body)
+			return
+		}
+
+		e.ETag = etag(e.Body)
+		if err := rc.store.Set(ctx, key, e, ttl); err != nil {
+			logger.Warn("Failed to store response in cache",
+				logger.Field{Key: "key", Value: key},
+				logger.Field{Key: "error", Value: err.Error()},
+			)
+		} else {
+			rc.index(ctx, tag, key)
+		}
+
+		serve(c, e, "MISS")
+	}
+}
+
+// Invalidate drops every cached response stored under the given tags
+func (rc *ResponseCache) Invalidate(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		tagKey := tagIndexKey(tag)
+
+		keys, err := rc.store.SMembers(ctx, tagKey)
+		if err != nil {
+			return fmt.Errorf("failed to list cached responses for %s: %w", tag, err)
+		}
+
+		if err := rc.store.Delete(ctx, append(keys, tagKey)...); err != nil {
+			return fmt.Errorf("failed to invalidate %s: %w", tag, err)
+		}
+
+		cacheInvalidations.WithLabelValues(tag).Inc()
+		logger.Info("Response cache invalidated",
+			logger.Field{Key: "tag", Value: tag},
+			logger.Field{Key: "entries", Value: len(keys)},
+		)
+	}
+
+	return nil
+}
+
+// key derives the cache key from the path, sorted query params and the caller's role,
+// since responses for admins and moderators may differ
+func (rc *ResponseCache) key(tag string, c *gin.Context) string {
+	query := c.Request.URL.Query()
+	// The token param carries the JWT and would give every session its own entry
+	query.Del("token")
+
+	role, _ := c.Get("role")
+	roleName, _ := role.(string)
+
+	sum := sha256.Sum256([]byte(c.Request.URL.Path + "?" + query.Encode() + "|" + roleName))
+	return keyPrefix + tag + ":" + hex.EncodeToString(sum[:16])
+}
+
+func (rc *ResponseCache) index(ctx context.Context, tag, key string) {
+	tagKey := tagIndexKey(tag)
+	if err := rc.store.SAdd(ctx, tagKey, key); err != nil {
+		logger.Warn("Failed to index cached response",
+			logger.Field{Key: "tag", Value: tag},
+			logger.Field{Key: "error", Value: err.Error()},
+		)
+		return
+	}
+	rc.store.Expire(ctx, tagKey, tagIndexTTL)
+}
+
+func tagIndexKey(tag string) string {
+	return keyPrefix + "tag:" + tag
+}
+
+// serve writes a cached response, answering 304 when the client already has this version
+func serve(c *gin.Context, e *entry, source string) {
+	header := c.Writer.Header()
+	header.Set("ETag", e.ETag)
+	header.Set("X-Cache", source)
+
+	if match := c.GetHeader("If-None-Match"); match != "" && match == e.ETag {
+		header.Del("Content-Length")
+		c.Writer.WriteHeader(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	c.Data(e.Status, e.ContentType, e.Body)
+}
+
+func etag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// bufferedWriter holds the upstream response back so it can be cached and tagged with an ETag before it is sent
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return false
+}
@@ -0,0 +1,63 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
+)
+
+// AnalyticsEventsExchange carries events analytics-service publishes when its data changes
+const AnalyticsEventsExchange = "analytics.events"
+
+// invalidationRules maps analytics event types to the cache tags they make stale
+var invalidationRules = map[string][]string{
+	"analytics.metrics.aggregated": {TagAnalyticsDashboard, TagAnalyticsReports},
+}
+
+// ListenForInvalidations subscribes to analytics events and drops the affected cache tags.
+// Every gateway instance gets its own queue so all of them see each event.
+func (rc *ResponseCache) ListenForInvalidations(ctx context.Context, broker *messaging.RabbitMQ) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	queue := "gateway.cache." + hostname
+
+	if err := broker.DeclareExchange(AnalyticsEventsExchange, "topic", true, false); err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+	if _, err := broker.DeclareQueue(queue, false, true, false); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+	if err := broker.BindQueue(queue, "analytics.#", AnalyticsEventsExchange); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	return broker.ConsumeWithHandler(ctx, queue, "api-gateway-cache", rc.handleEvent)
+}
+
+func (rc *ResponseCache) handleEvent(body []byte) error {
+	var event struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		// Requeueing a malformed event would only loop it forever
+		logger.Warn("Skipping malformed analytics event", logger.Field{Key: "error", Value: err.Error()})
+		return nil
+	}
+
+	tags := invalidationRules[event.Type]
+	if len(tags) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return rc.Invalidate(ctx, tags...)
+}
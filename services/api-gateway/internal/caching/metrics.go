@@ -0,0 +1,18 @@
+package caching
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_response_cache_requests_total",
+		Help: "Total number of cacheable requests by tag and result",
+	}, []string{"tag", "result"})
+
+	cacheInvalidations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_response_cache_invalidations_total",
+		Help: "Total number of response cache invalidations by tag",
+	}, []string{"tag"})
+)
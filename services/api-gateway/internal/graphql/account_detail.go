@@ -0,0 +1,202 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Sections of the account detail document
+const (
+	sectionAccount = "account"
+	sectionProxy   = "proxy"
+	sectionWarming = "warming"
+	sectionCost    = "cost"
+
+	resultError = "error"
+)
+
+var errAccountNotFound = errors.New("account not found")
+
+// accountSection is one part of the account detail document. A section whose service failed
+// carries the error instead of the data, the other sections are still returned.
+type accountSection struct {
+	Data  interface{} `json:"data"`
+	Error string      `json:"error,omitempty"`
+}
+
+// AccountDetailHandler serves GET /api/v1/accounts/:platform/:id/full, the document behind
+// the account page: the account from its platform service, the bound proxy, the warming
+// progress and the costs, fetched concurrently with the same gRPC clients and per-call
+// timeout as the GraphQL resolvers.
+func AccountDetailHandler(clients *Clients, timeout time.Duration) gin.HandlerFunc {
+	r := &resolvers{clients: clients, timeout: timeout}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		defer func() {
+			accountDetailDuration.Observe(time.Since(start).Seconds())
+		}()
+
+		platform := c.Param("platform")
+		accountID := c.Param("id")
+
+		source, err := clients.accountSource(platform)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// analytics-service redacts money amounts by the caller's role, as on its REST API
+		ctx := c.Request.Context()
+		if authorization := c.GetHeader("Authorization"); authorization != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+		}
+
+		fetchers := map[string]func(context.Context) (interface{}, error){
+			sectionAccount: func(ctx context.Context) (interface{}, error) {
+				callCtx, cancel := r.callContext(ctx)
+				defer cancel()
+
+				a, err := source.get(callCtx, accountID)
+				if status.Code(err) == codes.NotFound {
+					return nil, errAccountNotFound
+				}
+				if err != nil {
+					return nil, serviceError(platform, err)
+				}
+				return accountFields(platform, *a), nil
+			},
+			sectionProxy: func(ctx context.Context) (interface{}, error) {
+				return r.accountProxy(ctx, accountID)
+			},
+			sectionWarming: func(ctx context.Context) (interface{}, error) {
+				return r.warming(ctx, platform, accountID)
+			},
+			sectionCost: func(ctx context.Context) (interface{}, error) {
+				return (&costLoader{resolvers: r, accountIDs: []string{accountID}}).load(ctx, accountID)
+			},
+		}
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			sections = make(map[string]accountSection, len(fetchers))
+			errs     = make(map[string]error)
+		)
+		for name, fetch := range fetchers {
+			wg.Add(1)
+			go func(name string, fetch func(context.Context) (interface{}, error)) {
+				defer wg.Done()
+
+				data, err := fetch(ctx)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs[name] = err
+					sections[name] = accountSection{Error: err.Error()}
+					return
+				}
+				sections[name] = accountSection{Data: withoutTypename(data)}
+			}(name, fetch)
+		}
+		wg.Wait()
+
+		for name := range fetchers {
+			result := resultOK
+			if errs[name] != nil {
+				result = resultError
+			}
+			accountDetailSections.WithLabelValues(name, result).Inc()
+		}
+
+		if errors.Is(errs[sectionAccount], errAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+			return
+		}
+
+		if len(errs) > 0 {
+			logger.Warn("Account detail assembled with errors",
+				logger.Field{Key: "platform", Value: platform},
+				logger.Field{Key: "account_id", Value: accountID},
+				logger.Field{Key: "errors", Value: len(errs)},
+			)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"platform":   platform,
+			"account_id": accountID,
+			"partial":    len(errs) > 0,
+			"account":    sections[sectionAccount],
+			"proxy":      sections[sectionProxy],
+			"warming":    sections[sectionWarming],
+			"cost":       sections[sectionCost],
+		})
+	}
+}
+
+// accountProxy resolves the proxy bound to the account, null if it has none. Credentials are
+// left out, the page only shows where the account goes out from.
+func (r *resolvers) accountProxy(ctx context.Context, accountID string) (interface{}, error) {
+	callCtx, cancel := r.callContext(ctx)
+	defer cancel()
+
+	proxy, err := r.clients.Proxy.GetProxyForAccount(callCtx, &proxypb.GetProxyRequest{AccountId: accountID})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, serviceError("proxy", err)
+	}
+
+	var expiresAt interface{}
+	if proxy.ExpiresAt > 0 {
+		expiresAt = optionalTime(time.Unix(proxy.ExpiresAt, 0))
+	}
+
+	return Object{
+		"id":            proxy.Id,
+		"ip":            proxy.Ip,
+		"port":          proxy.Port,
+		"protocol":      proxy.Protocol,
+		"type":          proxy.Type,
+		"country":       optionalString(proxy.Country),
+		"city":          optionalString(proxy.City),
+		"status":        proxy.Status,
+		"provider":      optionalString(proxy.Provider),
+		"fallbackLevel": proxy.FallbackLevel,
+		"capabilities":  proxy.Capabilities,
+		"expiresAt":     expiresAt,
+	}, nil
+}
+
+// withoutTypename drops the GraphQL type markers from resolved objects, the REST document has no use for them
+func withoutTypename(v interface{}) interface{} {
+	switch v := v.(type) {
+	case Object:
+		result := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if key != "__typename" {
+				result[key] = withoutTypename(value)
+			}
+		}
+		return result
+	case []Object:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, withoutTypename(item))
+		}
+		return result
+	}
+	return v
+}
@@ -0,0 +1,298 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/config"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/serviceauth"
+	analyticspb "github.com/grigta/conveer/services/analytics-service/proto"
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
+	maxpb "github.com/grigta/conveer/services/max-service/proto"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	telegrampb "github.com/grigta/conveer/services/telegram-service/proto"
+	vkpb "github.com/grigta/conveer/services/vk-service/proto"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Clients are the gRPC clients the resolvers and the account detail endpoint fan out to
+type Clients struct {
+	VK        vkpb.VKServiceClient
+	Telegram  telegrampb.TelegramServiceClient
+	Mail      mailpb.MailServiceClient
+	Max       maxpb.MaxServiceClient
+	Warming   warmingpb.WarmingServiceClient
+	Analytics analyticspb.AnalyticsServiceClient
+	Proxy     proxypb.ProxyServiceClient
+
+	conns []*grpc.ClientConn
+}
+
+// DialClients creates the clients. Connections are established lazily, so the gateway
+// starts while a service is down and only the fields backed by it fail.
+func DialClients(cfg config.GraphQLConfig, serviceAuth *serviceauth.Auth) (*Clients, error) {
+	c := &Clients{}
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, logger.DialOptions()...)
+	dialOpts = append(dialOpts, serviceAuth.DialOptions()...)
+
+	dial := func(service, addr string) (*grpc.ClientConn, error) {
+		conn, err := grpc.Dial(addr, dialOpts...)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("failed to dial %s service at %s: %w", service, addr, err)
+		}
+		c.conns = append(c.conns, conn)
+		return conn, nil
+	}
+
+	conn, err := dial("vk", cfg.VKServiceAddr)
+	if err != nil {
+		return nil, err
+	}
+	c.VK = vkpb.NewVKServiceClient(conn)
+
+	if conn, err = dial("telegram", cfg.TelegramServiceAddr); err != nil {
+		return nil, err
+	}
+	c.Telegram = telegrampb.NewTelegramServiceClient(conn)
+
+	if conn, err = dial("mail", cfg.MailServiceAddr); err != nil {
+		return nil, err
+	}
+	c.Mail = mailpb.NewMailServiceClient(conn)
+
+	if conn, err = dial("max", cfg.MaxServiceAddr); err != nil {
+		return nil, err
+	}
+	c.Max = maxpb.NewMaxServiceClient(conn)
+
+	if conn, err = dial("warming", cfg.WarmingServiceAddr); err != nil {
+		return nil, err
+	}
+	c.Warming = warmingpb.NewWarmingServiceClient(conn)
+
+	if conn, err = dial("analytics", cfg.AnalyticsServiceAddr); err != nil {
+		return nil, err
+	}
+	c.Analytics = analyticspb.NewAnalyticsServiceClient(conn)
+
+	if conn, err = dial("proxy", cfg.ProxyServiceAddr); err != nil {
+		return nil, err
+	}
+	c.Proxy = proxypb.NewProxyServiceClient(conn)
+
+	return c, nil
+}
+
+// Close closes all connections
+func (c *Clients) Close() {
+	for _, conn := range c.conns {
+		conn.Close()
+	}
+}
+
+// account is the platform independent view of an account
+type account struct {
+	ID           string
+	Status       string
+	Phone        string
+	Email        string
+	FirstName    string
+	LastName     string
+	Username     string
+	PersonaID    string
+	ErrorMessage string
+	RetryCount   int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// accountSource lists and gets the accounts of one platform
+type accountSource interface {
+	list(ctx context.Context, status string, limit, offset int) ([]account, int64, error)
+	get(ctx context.Context, id string) (*account, error)
+}
+
+func (c *Clients) accountSource(platform string) (accountSource, error) {
+	switch platform {
+	case "vk":
+		return vkAccounts{c.VK}, nil
+	case "telegram":
+		return telegramAccounts{c.Telegram}, nil
+	case "mail":
+		return mailAccounts{c.Mail}, nil
+	case "max":
+		return maxAccounts{c.Max}, nil
+	}
+	return nil, fmt.Errorf("unknown platform %q, expected one of: vk, telegram, mail, max", platform)
+}
+
+type vkAccounts struct {
+	client vkpb.VKServiceClient
+}
+
+func (s vkAccounts) list(ctx context.Context, status string, limit, offset int) ([]account, int64, error) {
+	resp, err := s.client.ListAccounts(ctx, &vkpb.ListAccountsRequest{Status: status, Limit: int32(limit), Offset: int32(offset)})
+	if err != nil {
+		return nil, 0, err
+	}
+	accounts := make([]account, 0, len(resp.Accounts))
+	for _, a := range resp.Accounts {
+		accounts = append(accounts, fromVK(a))
+	}
+	return accounts, int64(resp.Total), nil
+}
+
+func (s vkAccounts) get(ctx context.Context, id string) (*account, error) {
+	a, err := s.client.GetAccount(ctx, &vkpb.GetAccountRequest{AccountId: id})
+	if err != nil {
+		return nil, err
+	}
+	result := fromVK(a)
+	return &result, nil
+}
+
+func fromVK(a *vkpb.Account) account {
+	return account{
+		ID:           a.Id,
+		Status:       a.Status,
+		Phone:        a.Phone,
+		Email:        a.Email,
+		FirstName:    a.FirstName,
+		LastName:     a.LastName,
+		Username:     a.Username,
+		PersonaID:    a.PersonaId,
+		ErrorMessage: a.ErrorMessage,
+		RetryCount:   a.RetryCount,
+		CreatedAt:    a.CreatedAt.AsTime(),
+		UpdatedAt:    a.UpdatedAt.AsTime(),
+	}
+}
+
+type telegramAccounts struct {
+	client telegrampb.TelegramServiceClient
+}
+
+func (s telegramAccounts) list(ctx context.Context, status string, limit, offset int) ([]account, int64, error) {
+	resp, err := s.client.ListAccounts(ctx, &telegrampb.ListAccountsRequest{Status: status, Limit: int32(limit), Offset: int32(offset)})
+	if err != nil {
+		return nil, 0, err
+	}
+	accounts := make([]account, 0, len(resp.Accounts))
+	for _, a := range resp.Accounts {
+		accounts = append(accounts, fromTelegram(a))
+	}
+	return accounts, int64(resp.Total), nil
+}
+
+func (s telegramAccounts) get(ctx context.Context, id string) (*account, error) {
+	a, err := s.client.GetAccount(ctx, &telegrampb.GetAccountRequest{AccountId: id})
+	if err != nil {
+		return nil, err
+	}
+	result := fromTelegram(a)
+	return &result, nil
+}
+
+func fromTelegram(a *telegrampb.Account) account {
+	return account{
+		ID:           a.Id,
+		Status:       a.Status,
+		Phone:        a.Phone,
+		FirstName:    a.FirstName,
+		LastName:     a.LastName,
+		Username:     a.Username,
+		PersonaID:    a.PersonaId,
+		ErrorMessage: a.ErrorMessage,
+		RetryCount:   a.RetryCount,
+		CreatedAt:    a.CreatedAt.AsTime(),
+		UpdatedAt:    a.UpdatedAt.AsTime(),
+	}
+}
+
+type mailAccounts struct {
+	client mailpb.MailServiceClient
+}
+
+func (s mailAccounts) list(ctx context.Context, status string, limit, offset int) ([]account, int64, error) {
+	resp, err := s.client.ListAccounts(ctx, &mailpb.ListAccountsRequest{Status: status, Limit: int32(limit), Offset: int32(offset)})
+	if err != nil {
+		return nil, 0, err
+	}
+	accounts := make([]account, 0, len(resp.Accounts))
+	for _, a := range resp.Accounts {
+		accounts = append(accounts, fromMail(a))
+	}
+	return accounts, resp.Total, nil
+}
+
+func (s mailAccounts) get(ctx context.Context, id string) (*account, error) {
+	a, err := s.client.GetAccount(ctx, &mailpb.GetAccountRequest{AccountId: id})
+	if err != nil {
+		return nil, err
+	}
+	result := fromMail(a)
+	return &result, nil
+}
+
+func fromMail(a *mailpb.Account) account {
+	return account{
+		ID:           a.Id,
+		Status:       a.Status,
+		Phone:        a.Phone,
+		Email:        a.Email,
+		FirstName:    a.FirstName,
+		LastName:     a.LastName,
+		PersonaID:    a.PersonaId,
+		ErrorMessage: a.ErrorMessage,
+		RetryCount:   a.RetryCount,
+		CreatedAt:    time.Unix(a.CreatedAt, 0),
+		UpdatedAt:    time.Unix(a.UpdatedAt, 0),
+	}
+}
+
+type maxAccounts struct {
+	client maxpb.MaxServiceClient
+}
+
+func (s maxAccounts) list(ctx context.Context, status string, limit, offset int) ([]account, int64, error) {
+	resp, err := s.client.ListAccounts(ctx, &maxpb.ListAccountsRequest{Status: status, Limit: int32(limit), Offset: int32(offset)})
+	if err != nil {
+		return nil, 0, err
+	}
+	accounts := make([]account, 0, len(resp.Accounts))
+	for _, a := range resp.Accounts {
+		accounts = append(accounts, fromMax(a))
+	}
+	return accounts, resp.Total, nil
+}
+
+func (s maxAccounts) get(ctx context.Context, id string) (*account, error) {
+	a, err := s.client.GetAccount(ctx, &maxpb.GetAccountRequest{AccountId: id})
+	if err != nil {
+		return nil, err
+	}
+	result := fromMax(a)
+	return &result, nil
+}
+
+func fromMax(a *maxpb.Account) account {
+	return account{
+		ID:           a.Id,
+		Status:       a.Status,
+		Phone:        a.Phone,
+		FirstName:    a.FirstName,
+		LastName:     a.LastName,
+		Username:     a.Username,
+		PersonaID:    a.PersonaId,
+		ErrorMessage: a.ErrorMessage,
+		RetryCount:   a.RetryCount,
+		CreatedAt:    time.Unix(a.CreatedAt, 0),
+		UpdatedAt:    time.Unix(a.UpdatedAt, 0),
+	}
+}
@@ -0,0 +1,383 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Resolver resolves a root query field from its arguments
+type Resolver func(ctx context.Context, args Arguments) (interface{}, error)
+
+// Lazy is an object field resolved only when the query selects it, so a query that does not
+// ask for warming or cost data does not call warming-service or analytics-service
+type Lazy func(ctx context.Context, args Arguments) (interface{}, error)
+
+// Object is a resolved object. Values are scalars, nested objects, lists or Lazy.
+// Every field of the type must be present, fields without a value hold nil.
+type Object map[string]interface{}
+
+// Arguments are field arguments with variables substituted
+type Arguments map[string]interface{}
+
+// String returns a string argument or "" when it is missing
+func (a Arguments) String(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+// Int returns an integer argument or def when it is missing
+func (a Arguments) Int(name string, def int) int {
+	switch v := a[name].(type) {
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// Request is a GraphQL request as sent by clients over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is a GraphQL response. Data is omitted when the request failed before execution.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []Error     `json:"errors,omitempty"`
+}
+
+// Error is a request or field error. Path points to the field that was resolved to null.
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Schema executes queries against a set of root fields
+type Schema struct {
+	query       map[string]Resolver
+	maxDepth    int
+	concurrency int
+}
+
+// NewSchema creates a schema. maxDepth limits selection nesting, concurrency limits
+// lazy fields resolved in parallel by one request.
+func NewSchema(query map[string]Resolver, maxDepth, concurrency int) *Schema {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &Schema{
+		query:       query,
+		maxDepth:    maxDepth,
+		concurrency: concurrency,
+	}
+}
+
+// Execute parses and runs a query. Field errors are reported next to the partial data.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return requestError(err)
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return requestError(err)
+	}
+
+	variables, err := coerceVariables(op.variables, req.Variables)
+	if err != nil {
+		return requestError(err)
+	}
+
+	if s.maxDepth > 0 {
+		if depth := selectionDepth(op.selections); depth > s.maxDepth {
+			return requestError(fmt.Errorf("query depth %d exceeds the limit of %d", depth, s.maxDepth))
+		}
+	}
+
+	for _, f := range op.selections {
+		if _, ok := s.query[f.name]; !ok && f.name != "__typename" {
+			return requestError(fmt.Errorf("cannot query field %q on type \"Query\"", f.name))
+		}
+	}
+
+	e := &execution{
+		variables: variables,
+		slots:     make(chan struct{}, s.concurrency),
+	}
+	root := Object{"__typename": "Query"}
+	for name, resolver := range s.query {
+		resolver := resolver
+		root[name] = Lazy(func(ctx context.Context, args Arguments) (interface{}, error) {
+			return resolver(ctx, args)
+		})
+	}
+
+	data := e.completeObject(ctx, op.selections, root, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func requestError(err error) *Response {
+	return &Response{Errors: []Error{{Message: err.Error()}}}
+}
+
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, fmt.Errorf("operationName is required for a document with several operations")
+		}
+		return doc.operations[0], nil
+	}
+
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+func coerceVariables(definitions []variableDefinition, provided map[string]interface{}) (map[string]interface{}, error) {
+	variables := make(map[string]interface{}, len(definitions))
+	for _, definition := range definitions {
+		v, ok := provided[definition.name]
+		if !ok {
+			v = definition.defaultValue
+		}
+		if v == nil && definition.nonNull {
+			return nil, fmt.Errorf("variable $%s of non-null type was not provided", definition.name)
+		}
+		variables[definition.name] = normalizeJSON(v)
+	}
+	return variables, nil
+}
+
+// normalizeJSON converts whole JSON numbers to int64 so variables and literals look the same to resolvers
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeJSON(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = normalizeJSON(v[k])
+		}
+	}
+	return v
+}
+
+func selectionDepth(selections []*field) int {
+	if len(selections) == 0 {
+		return 0
+	}
+
+	depth := 0
+	for _, f := range selections {
+		if d := selectionDepth(f.selections); d > depth {
+			depth = d
+		}
+	}
+	return depth + 1
+}
+
+type execution struct {
+	variables map[string]interface{}
+	slots     chan struct{}
+
+	mu     sync.Mutex
+	errors []Error
+}
+
+func (e *execution) fieldError(path []interface{}, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errors = append(e.errors, Error{Message: err.Error(), Path: path})
+}
+
+func (e *execution) arguments(f *field) (Arguments, error) {
+	args := make(Arguments, len(f.arguments))
+	for name, v := range f.arguments {
+		resolved, err := e.resolveValue(v)
+		if err != nil {
+			return nil, err
+		}
+		args[name] = resolved
+	}
+	return args, nil
+}
+
+func (e *execution) resolveValue(v value) (interface{}, error) {
+	switch v := v.(type) {
+	case variableRef:
+		resolved, ok := e.variables[string(v)]
+		if !ok {
+			return nil, fmt.Errorf("variable $%s is not defined by the operation", v)
+		}
+		return resolved, nil
+	case listValue:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := e.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = resolved
+		}
+		return list, nil
+	case objectValue:
+		object := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			resolved, err := e.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			object[k] = resolved
+		}
+		return object, nil
+	}
+	return v, nil
+}
+
+// completeObject resolves the selected fields of an object. Lazy fields run concurrently,
+// a failed field becomes null and the rest of the object is still returned.
+func (e *execution) completeObject(ctx context.Context, selections []*field, object Object, path []interface{}) *orderedObject {
+	result := &orderedObject{}
+	seen := make(map[string]bool, len(selections))
+
+	var fields []*field
+	for _, f := range selections {
+		if key := f.responseKey(); !seen[key] {
+			seen[key] = true
+			result.keys = append(result.keys, key)
+			fields = append(fields, f)
+		}
+	}
+	result.values = make([]interface{}, len(fields))
+
+	var wg sync.WaitGroup
+	for i, f := range fields {
+		fieldPath := appendPath(path, result.keys[i])
+		v, ok := object[f.name]
+		if !ok {
+			e.fieldError(fieldPath, fmt.Errorf("cannot query field %q on type %q", f.name, object["__typename"]))
+			continue
+		}
+
+		lazy, isLazy := v.(Lazy)
+		if !isLazy {
+			result.values[i] = e.completeValue(ctx, f, v, fieldPath)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, f *field, fieldPath []interface{}) {
+			defer wg.Done()
+
+			resolved, err := e.resolveLazy(ctx, f, lazy)
+			if err != nil {
+				e.fieldError(fieldPath, err)
+				return
+			}
+			result.values[i] = e.completeValue(ctx, f, resolved, fieldPath)
+		}(i, f, fieldPath)
+	}
+	wg.Wait()
+
+	return result
+}
+
+func (e *execution) resolveLazy(ctx context.Context, f *field, lazy Lazy) (interface{}, error) {
+	args, err := e.arguments(f)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case e.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-e.slots }()
+
+	return lazy(ctx, args)
+}
+
+func (e *execution) completeValue(ctx context.Context, f *field, v interface{}, path []interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case Object:
+		if len(f.selections) == 0 {
+			e.fieldError(path, fmt.Errorf("field %q of type %q must have a selection of subfields", f.name, v["__typename"]))
+			return nil
+		}
+		return e.completeObject(ctx, f.selections, v, path)
+	case []Object:
+		// Items are completed in parallel so lazy fields of a page do not run one item at a time
+		list := make([]interface{}, len(v))
+		var wg sync.WaitGroup
+		for i, item := range v {
+			wg.Add(1)
+			go func(i int, item Object) {
+				defer wg.Done()
+				list[i] = e.completeValue(ctx, f, item, appendPath(path, i))
+			}(i, item)
+		}
+		wg.Wait()
+		return list
+	}
+
+	if len(f.selections) > 0 {
+		e.fieldError(path, fmt.Errorf("field %q is a scalar and has no subfields", f.name))
+		return nil
+	}
+	return v
+}
+
+func appendPath(path []interface{}, element interface{}) []interface{} {
+	extended := make([]interface{}, len(path)+1)
+	copy(extended, path)
+	extended[len(path)] = element
+	return extended
+}
+
+// orderedObject keeps response fields in the order of the selection set
+type orderedObject struct {
+	keys   []string
+	values []interface{}
+}
+
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
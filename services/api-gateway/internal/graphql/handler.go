@@ -0,0 +1,73 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/logger"
+	"google.golang.org/grpc/metadata"
+)
+
+// Results of a GraphQL request
+const (
+	resultOK      = "ok"
+	resultPartial = "partial"
+	resultInvalid = "invalid"
+)
+
+// Handler serves GraphQL over HTTP: POST with a JSON body or GET with query parameters.
+// The caller's Authorization header is forwarded to the services, so analytics-service
+// redacts money amounts for the same roles as on its REST API.
+func Handler(schema *Schema) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		defer func() {
+			graphqlDuration.Observe(time.Since(start).Seconds())
+		}()
+
+		var req Request
+		switch c.Request.Method {
+		case http.MethodPost:
+			if err := c.ShouldBindJSON(&req); err != nil {
+				graphqlRequests.WithLabelValues(resultInvalid).Inc()
+				c.JSON(http.StatusBadRequest, requestError(err))
+				return
+			}
+		default:
+			req.Query = c.Query("query")
+			req.OperationName = c.Query("operationName")
+			if variables := c.Query("variables"); variables != "" {
+				if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+					graphqlRequests.WithLabelValues(resultInvalid).Inc()
+					c.JSON(http.StatusBadRequest, requestError(err))
+					return
+				}
+			}
+		}
+
+		ctx := c.Request.Context()
+		if authorization := c.GetHeader("Authorization"); authorization != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+		}
+
+		resp := schema.Execute(ctx, req)
+		switch {
+		case resp.Data == nil:
+			graphqlRequests.WithLabelValues(resultInvalid).Inc()
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		case len(resp.Errors) > 0:
+			graphqlRequests.WithLabelValues(resultPartial).Inc()
+			logger.Warn("GraphQL query resolved with errors",
+				logger.Field{Key: "operation", Value: req.OperationName},
+				logger.Field{Key: "errors", Value: len(resp.Errors)},
+			)
+		default:
+			graphqlRequests.WithLabelValues(resultOK).Inc()
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
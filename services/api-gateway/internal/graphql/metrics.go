@@ -0,0 +1,32 @@
+package graphql
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	graphqlRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_graphql_requests_total",
+		Help: "Total number of GraphQL requests by result",
+	}, []string{"result"})
+
+	graphqlDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "gateway_graphql_request_duration_seconds",
+		Help:    "GraphQL request duration in seconds including all resolver calls",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+var (
+	accountDetailSections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_account_detail_sections_total",
+		Help: "Total number of account detail sections fetched by section and result",
+	}, []string{"section", "result"})
+
+	accountDetailDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "gateway_account_detail_duration_seconds",
+		Help:    "Account detail request duration in seconds including all service calls",
+		Buckets: prometheus.DefBuckets,
+	})
+)
@@ -0,0 +1,347 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	analyticspb "github.com/grigta/conveer/services/analytics-service/proto"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// maxConcurrentCalls limits the service calls of lazy fields made by one request
+	maxConcurrentCalls = 16
+)
+
+// NewGatewaySchema builds the schema served at /graphql:
+//
+//	accounts(platform: String!, status: String, limit: Int = 20, offset: Int = 0): AccountPage
+//	account(platform: String!, id: ID!): Account
+//	platformAnalytics(platform: String!): PlatformAnalytics
+//	warmingStatistics(platform: String): WarmingStatistics
+//
+// Account.warming is resolved by warming-service and Account.cost by analytics-service,
+// costs of a page are fetched with a single call.
+func NewGatewaySchema(clients *Clients, maxDepth int, timeout time.Duration) *Schema {
+	r := &resolvers{clients: clients, timeout: timeout}
+	return NewSchema(map[string]Resolver{
+		"accounts":          r.accounts,
+		"account":           r.account,
+		"platformAnalytics": r.platformAnalytics,
+		"warmingStatistics": r.warmingStatistics,
+	}, maxDepth, maxConcurrentCalls)
+}
+
+type resolvers struct {
+	clients *Clients
+	timeout time.Duration
+}
+
+// callContext bounds a single service call, the request context bounds the whole query
+func (r *resolvers) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+func (r *resolvers) accounts(ctx context.Context, args Arguments) (interface{}, error) {
+	platform := args.String("platform")
+	source, err := r.clients.accountSource(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := args.Int("limit", defaultPageSize)
+	if limit <= 0 || limit > maxPageSize {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+	}
+	offset := args.Int("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	callCtx, cancel := r.callContext(ctx)
+	defer cancel()
+
+	accounts, total, err := source.list(callCtx, args.String("status"), limit, offset)
+	if err != nil {
+		return nil, serviceError(platform, err)
+	}
+
+	ids := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		ids = append(ids, a.ID)
+	}
+	costs := &costLoader{resolvers: r, accountIDs: ids}
+
+	items := make([]Object, 0, len(accounts))
+	for _, a := range accounts {
+		items = append(items, r.accountObject(platform, a, costs))
+	}
+
+	return Object{
+		"__typename": "AccountPage",
+		"total":      total,
+		"items":      items,
+	}, nil
+}
+
+func (r *resolvers) account(ctx context.Context, args Arguments) (interface{}, error) {
+	platform := args.String("platform")
+	source, err := r.clients.accountSource(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	id := args.String("id")
+	if id == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	callCtx, cancel := r.callContext(ctx)
+	defer cancel()
+
+	a, err := source.get(callCtx, id)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, serviceError(platform, err)
+	}
+
+	return r.accountObject(platform, *a, &costLoader{resolvers: r, accountIDs: []string{a.ID}}), nil
+}
+
+func (r *resolvers) accountObject(platform string, a account, costs *costLoader) Object {
+	object := accountFields(platform, a)
+	object["warming"] = Lazy(func(ctx context.Context, _ Arguments) (interface{}, error) {
+		return r.warming(ctx, platform, a.ID)
+	})
+	object["cost"] = Lazy(func(ctx context.Context, _ Arguments) (interface{}, error) {
+		return costs.load(ctx, a.ID)
+	})
+	return object
+}
+
+// accountFields are the fields of an account that come with the account itself
+func accountFields(platform string, a account) Object {
+	return Object{
+		"__typename":   "Account",
+		"id":           a.ID,
+		"platform":     platform,
+		"status":       a.Status,
+		"phone":        optionalString(a.Phone),
+		"email":        optionalString(a.Email),
+		"firstName":    optionalString(a.FirstName),
+		"lastName":     optionalString(a.LastName),
+		"username":     optionalString(a.Username),
+		"personaId":    optionalString(a.PersonaID),
+		"errorMessage": optionalString(a.ErrorMessage),
+		"retryCount":   a.RetryCount,
+		"createdAt":    optionalTime(a.CreatedAt),
+		"updatedAt":    optionalTime(a.UpdatedAt),
+	}
+}
+
+// warming resolves the progress of the latest warming task of the account, null if it was never warmed
+func (r *resolvers) warming(ctx context.Context, platform, accountID string) (interface{}, error) {
+	callCtx, cancel := r.callContext(ctx)
+	defer cancel()
+
+	resp, err := r.clients.Warming.ListTasks(callCtx, &warmingpb.ListTasksRequest{
+		Platform:  platform,
+		AccountId: accountID,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, serviceError("warming", err)
+	}
+	if len(resp.Tasks) == 0 {
+		return nil, nil
+	}
+
+	task := resp.Tasks[0]
+	var progress float64
+	if task.DurationDays > 0 {
+		progress = float64(task.CurrentDay) / float64(task.DurationDays) * 100
+		if progress > 100 {
+			progress = 100
+		}
+	}
+
+	return Object{
+		"__typename":       "WarmingProgress",
+		"taskId":           task.Id,
+		"status":           task.Status,
+		"scenarioType":     task.ScenarioType,
+		"currentDay":       task.CurrentDay,
+		"durationDays":     task.DurationDays,
+		"progress":         progress,
+		"actionsCompleted": task.ActionsCompleted,
+		"actionsFailed":    task.ActionsFailed,
+		"lastError":        optionalString(task.LastError),
+		"nextActionAt":     optionalTimestamp(task.NextActionAt),
+		"completedAt":      optionalTimestamp(task.CompletedAt),
+	}, nil
+}
+
+func (r *resolvers) platformAnalytics(ctx context.Context, args Arguments) (interface{}, error) {
+	platform := args.String("platform")
+	if platform == "" {
+		return nil, fmt.Errorf("platform is required")
+	}
+
+	callCtx, cancel := r.callContext(ctx)
+	defer cancel()
+
+	analytics, err := r.clients.Analytics.GetPlatformAnalytics(callCtx, &analyticspb.PlatformRequest{Platform: platform})
+	if err != nil {
+		return nil, serviceError("analytics", err)
+	}
+
+	return Object{
+		"__typename":      "PlatformAnalytics",
+		"platform":        analytics.Platform,
+		"totalAccounts":   analytics.TotalAccounts,
+		"byStatus":        statusCounts(analytics.ByStatus),
+		"successRate":     analytics.SuccessRate,
+		"banRate":         analytics.BanRate,
+		"avgWarmingDays":  analytics.AvgWarmingDays,
+		"totalSpent":      analytics.TotalSpent,
+		"recommendations": analytics.Recommendations,
+	}, nil
+}
+
+func (r *resolvers) warmingStatistics(ctx context.Context, args Arguments) (interface{}, error) {
+	callCtx, cancel := r.callContext(ctx)
+	defer cancel()
+
+	stats, err := r.clients.Warming.GetWarmingStatistics(callCtx, &warmingpb.StatisticsRequest{Platform: args.String("platform")})
+	if err != nil {
+		return nil, serviceError("warming", err)
+	}
+
+	return Object{
+		"__typename":      "WarmingStatistics",
+		"totalTasks":      stats.TotalTasks,
+		"completedTasks":  stats.CompletedTasks,
+		"failedTasks":     stats.FailedTasks,
+		"inProgressTasks": stats.InProgressTasks,
+		"successRate":     stats.SuccessRate,
+		"avgDurationDays": stats.AvgDurationDays,
+		"byPlatform":      statusCounts(stats.ByPlatform),
+	}, nil
+}
+
+// costLoader fetches the costs of all accounts of a page with one analytics call,
+// made when the first account of the page resolves its cost
+type costLoader struct {
+	resolvers  *resolvers
+	accountIDs []string
+
+	once  sync.Once
+	costs map[string]*analyticspb.AccountCost
+	err   error
+}
+
+func (l *costLoader) load(ctx context.Context, accountID string) (interface{}, error) {
+	l.once.Do(func() {
+		callCtx, cancel := l.resolvers.callContext(ctx)
+		defer cancel()
+
+		resp, err := l.resolvers.clients.Analytics.GetAccountCosts(callCtx, &analyticspb.AccountCostsRequest{AccountIds: l.accountIDs})
+		if err != nil {
+			l.err = serviceError("analytics", err)
+			return
+		}
+
+		l.costs = make(map[string]*analyticspb.AccountCost, len(resp.Costs))
+		for _, cost := range resp.Costs {
+			l.costs[cost.AccountId] = cost
+		}
+	})
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	cost, ok := l.costs[accountID]
+	if !ok {
+		return nil, nil
+	}
+
+	categories := make([]string, 0, len(cost.ByCategory))
+	for category := range cost.ByCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	byCategory := make([]Object, 0, len(categories))
+	for _, category := range categories {
+		byCategory = append(byCategory, Object{
+			"__typename": "CategoryCost",
+			"category":   category,
+			"amount":     cost.ByCategory[category],
+		})
+	}
+
+	return Object{
+		"__typename": "AccountCost",
+		"total":      cost.Total,
+		"currency":   optionalString(cost.Currency),
+		"byCategory": byCategory,
+	}, nil
+}
+
+// statusCounts turns a count map into a list sorted by key, GraphQL has no map type
+func statusCounts(counts map[string]int64) []Object {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]Object, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, Object{
+			"__typename": "Count",
+			"key":        key,
+			"count":      counts[key],
+		})
+	}
+	return result
+}
+
+func serviceError(service string, err error) error {
+	return fmt.Errorf("%s service: %s", service, status.Convert(err).Message())
+}
+
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func optionalTime(t time.Time) interface{} {
+	if t.IsZero() || t.Unix() == 0 {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func optionalTimestamp(ts *timestamppb.Timestamp) interface{} {
+	if ts == nil {
+		return nil
+	}
+	return optionalTime(ts.AsTime())
+}
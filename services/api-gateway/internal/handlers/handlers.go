@@ -7,6 +7,7 @@ import (
 	"github.com/grigta/conveer/pkg/config"
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/api-gateway/internal/proxy"
+	"github.com/grigta/conveer/services/api-gateway/internal/versioning"
 	"github.com/gin-gonic/gin"
 )
 
@@ -147,6 +148,37 @@ func (h *Handlers) WarmingProxy(c *gin.Context) {
 	h.proxyClient.ProxyToService(c, h.config.Services.WarmingServiceURL, c.Request.URL.Path)
 }
 
+func (h *Handlers) SchedulerProxy(c *gin.Context) {
+	logger.Info("Proxying request to Scheduler service",
+		logger.Field{Key: "method", Value: c.Request.Method},
+		logger.Field{Key: "path", Value: c.Request.URL.Path},
+	)
+
+	h.proxyClient.ProxyToService(c, h.config.Services.SchedulerServiceURL, c.Request.URL.Path)
+}
+
+func (h *Handlers) InterventionProxy(c *gin.Context) {
+	logger.Info("Proxying request to Intervention service",
+		logger.Field{Key: "method", Value: c.Request.Method},
+		logger.Field{Key: "path", Value: c.Request.URL.Path},
+	)
+
+	h.proxyClient.ProxyToService(c, h.config.Services.InterventionServiceURL, c.Request.URL.Path)
+}
+
+// VersionedProxy proxies a v2 request to the v1 upstream and adapts the response with shim
+func (h *Handlers) VersionedProxy(serviceURL string, shim versioning.Shim) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger.Info("Proxying versioned request",
+			logger.Field{Key: "method", Value: c.Request.Method},
+			logger.Field{Key: "path", Value: c.Request.URL.Path},
+			logger.Field{Key: "version", Value: versioning.FromContext(c)},
+		)
+
+		h.proxyClient.ProxyToServiceWithTransform(c, serviceURL, c.Request.URL.Path, shim.Transformer(c))
+	}
+}
+
 func (h *Handlers) NotFound(c *gin.Context) {
 	c.JSON(http.StatusNotFound, gin.H{
 		"error":   "Route not found",
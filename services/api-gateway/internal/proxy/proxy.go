@@ -34,6 +34,48 @@ func NewProxyClient(cfg *config.Config) *ProxyClient {
 }
 
 func (p *ProxyClient) ProxyToService(c *gin.Context, serviceURL, path string) {
+	resp, ok := p.forward(c, serviceURL, path)
+	if !ok {
+		return
+	}
+	defer resp.Body.Close()
+
+	p.copyResponseHeaders(resp.Header, c.Writer.Header())
+	c.Status(resp.StatusCode)
+
+	if _, err := io.Copy(c.Writer, resp.Body); err != nil {
+		logger.Error("Failed to copy response body",
+			logger.Field{Key: "error", Value: err.Error()},
+		)
+	}
+}
+
+// ProxyToServiceWithTransform buffers the upstream response and passes it
+// through transform before replying. Used by the API versioning shims.
+func (p *ProxyClient) ProxyToServiceWithTransform(c *gin.Context, serviceURL, path string, transform func(status int, body []byte) []byte) {
+	resp, ok := p.forward(c, serviceURL, path)
+	if !ok {
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Error("Failed to read response body",
+			logger.Field{Key: "error", Value: err.Error()},
+		)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Service unavailable"})
+		return
+	}
+
+	body = transform(resp.StatusCode, body)
+
+	p.copyResponseHeaders(resp.Header, c.Writer.Header())
+	c.Writer.Header().Del("Content-Length")
+	c.Data(resp.StatusCode, "application/json; charset=utf-8", body)
+}
+
+func (p *ProxyClient) forward(c *gin.Context, serviceURL, path string) (*http.Response, bool) {
 	targetURL, err := p.buildTargetURL(serviceURL, path, c.Request.URL.RawQuery)
 	if err != nil {
 		logger.Error("Failed to build target URL",
@@ -41,7 +83,7 @@ func (p *ProxyClient) ProxyToService(c *gin.Context, serviceURL, path string) {
 			logger.Field{Key: "service", Value: serviceURL},
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		return
+		return nil, false
 	}
 
 	proxyReq, err := p.createProxyRequest(c, targetURL)
@@ -51,7 +93,7 @@ func (p *ProxyClient) ProxyToService(c *gin.Context, serviceURL, path string) {
 			logger.Field{Key: "url", Value: targetURL},
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		return
+		return nil, false
 	}
 
 	p.copyHeaders(c.Request.Header, proxyReq.Header)
@@ -68,18 +110,10 @@ func (p *ProxyClient) ProxyToService(c *gin.Context, serviceURL, path string) {
 			logger.Field{Key: "url", Value: targetURL},
 		)
 		c.JSON(http.StatusBadGateway, gin.H{"error": "Service unavailable"})
-		return
+		return nil, false
 	}
-	defer resp.Body.Close()
-
-	p.copyResponseHeaders(resp.Header, c.Writer.Header())
-	c.Status(resp.StatusCode)
 
-	if _, err := io.Copy(c.Writer, resp.Body); err != nil {
-		logger.Error("Failed to copy response body",
-			logger.Field{Key: "error", Value: err.Error()},
-		)
-	}
+	return resp, true
 }
 
 func (p *ProxyClient) buildTargetURL(serviceURL, path, query string) (string, error) {
@@ -88,7 +122,9 @@ func (p *ProxyClient) buildTargetURL(serviceURL, path, query string) (string, er
 		return "", fmt.Errorf("invalid service URL: %w", err)
 	}
 
+	// v1 and v2 map to the same upstream routes; response shape is adapted by the gateway
 	targetPath := strings.TrimPrefix(path, "/api/v1")
+	targetPath = strings.TrimPrefix(targetPath, "/api/v2")
 	targetPath = strings.TrimPrefix(targetPath, "/auth")
 	targetPath = strings.TrimPrefix(targetPath, "/users")
 	targetPath = strings.TrimPrefix(targetPath, "/products")
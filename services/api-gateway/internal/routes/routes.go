@@ -6,12 +6,18 @@ import (
 
 	"github.com/grigta/conveer/pkg/config"
 	"github.com/grigta/conveer/pkg/middleware"
+	"github.com/grigta/conveer/services/api-gateway/internal/caching"
+	"github.com/grigta/conveer/services/api-gateway/internal/graphql"
 	"github.com/grigta/conveer/services/api-gateway/internal/handlers"
+	"github.com/grigta/conveer/services/api-gateway/internal/shadowing"
+	"github.com/grigta/conveer/services/api-gateway/internal/versioning"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
+// SetupRoutes registers all gateway routes. responseCache, shadower, graphqlSchema and accountDetail
+// may be nil when caching, shadowing or GraphQL is disabled.
+func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config, responseCache *caching.ResponseCache, shadower *shadowing.Shadower, graphqlSchema *graphql.Schema, accountDetail gin.HandlerFunc) {
 	corsConfig := middleware.DefaultCORSConfig()
 	router.Use(middleware.CORS(corsConfig))
 
@@ -26,10 +32,17 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 
 	router.Use(requestTimeout(30 * time.Second))
 
+	// Only read-only routes are shadowed; cache hits never reach upstream and are not mirrored
+	shadow := shadower.Middleware()
+
 	router.GET("/health", h.HealthCheck)
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	versions := versioning.Versions(cfg.APIVersioning)
+	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.Secret)
+
 	api := router.Group("/api/v1")
+	api.Use(versioning.Middleware(versions[versioning.V1]))
 	{
 		auth := api.Group("/auth")
 		{
@@ -40,9 +53,23 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 			auth.POST("/forgot-password", h.AuthProxy)
 			auth.POST("/reset-password", h.AuthProxy)
 			auth.POST("/verify-email", h.AuthProxy)
-		}
 
-		authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.Secret)
+			twoFactor := auth.Group("/2fa")
+			twoFactor.Use(authMiddleware.Authenticate())
+			{
+				twoFactor.POST("/enroll", h.AuthProxy)
+				twoFactor.POST("/confirm", h.AuthProxy)
+				twoFactor.POST("/disable", h.AuthProxy)
+				twoFactor.POST("/recovery-codes", h.AuthProxy)
+			}
+
+			audit := auth.Group("/audit")
+			audit.Use(authMiddleware.Authenticate(), authMiddleware.RequireRole("admin"))
+			{
+				audit.GET("/events", h.AuthProxy)
+				audit.GET("/events/export", h.AuthProxy)
+			}
+		}
 
 		users := api.Group("/users")
 		users.Use(authMiddleware.Authenticate())
@@ -100,13 +127,17 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 
 		analytics := api.Group("/analytics")
 		analytics.Use(authMiddleware.Authenticate())
-		analytics.Use(authMiddleware.RequireRole("admin", "moderator"))
+		// Viewers get analytics with money amounts redacted by analytics-service
+		analytics.Use(authMiddleware.RequireRole("admin", "moderator", "viewer"))
 		{
-			analytics.GET("/dashboard", h.AnalyticsProxy)
-			analytics.GET("/reports/sales", h.AnalyticsProxy)
-			analytics.GET("/reports/users", h.AnalyticsProxy)
-			analytics.GET("/reports/products", h.AnalyticsProxy)
-			analytics.GET("/reports/revenue", h.AnalyticsProxy)
+			dashboardCache := responseCache.Middleware(caching.TagAnalyticsDashboard, time.Minute)
+			reportsCache := responseCache.Middleware(caching.TagAnalyticsReports, 5*time.Minute)
+
+			analytics.GET("/dashboard", dashboardCache, shadow, h.AnalyticsProxy)
+			analytics.GET("/reports/sales", reportsCache, shadow, h.AnalyticsProxy)
+			analytics.GET("/reports/users", reportsCache, shadow, h.AnalyticsProxy)
+			analytics.GET("/reports/products", reportsCache, shadow, h.AnalyticsProxy)
+			analytics.GET("/reports/revenue", reportsCache, shadow, h.AnalyticsProxy)
 			analytics.POST("/reports/custom", h.AnalyticsProxy)
 		}
 
@@ -119,7 +150,7 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 			proxies.GET("/account/:account_id", h.ProxyProxy)
 			proxies.GET("/health/:id", h.ProxyProxy)
 			proxies.POST("/:id/rotate", h.ProxyProxy)
-			proxies.GET("/statistics", h.ProxyProxy)
+			proxies.GET("/statistics", shadow, h.ProxyProxy)
 		}
 
 		providers := api.Group("/providers")
@@ -136,7 +167,7 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 			sms.GET("/code/:activation_id", h.SMSProxy)
 			sms.POST("/cancel/:activation_id", h.SMSProxy)
 			sms.GET("/status/:activation_id", h.SMSProxy)
-			sms.GET("/statistics", h.SMSProxy)
+			sms.GET("/statistics", shadow, h.SMSProxy)
 			sms.GET("/balance", h.SMSProxy)
 		}
 
@@ -148,8 +179,10 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 			vk.GET("/accounts/:id", h.VKProxy)
 			vk.PUT("/accounts/:id/status", h.VKProxy)
 			vk.POST("/accounts/:id/retry", h.VKProxy)
+			vk.POST("/accounts/:id/populate-profile", h.VKProxy)
+			vk.POST("/accounts/:id/bind-email", h.VKProxy)
 			vk.DELETE("/accounts/:id", h.VKProxy)
-			vk.GET("/statistics", h.VKProxy)
+			vk.GET("/statistics", shadow, h.VKProxy)
 		}
 
 		telegram := api.Group("/telegram")
@@ -161,7 +194,7 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 			telegram.PUT("/accounts/:id/status", h.TelegramProxy)
 			telegram.POST("/accounts/:id/retry", h.TelegramProxy)
 			telegram.DELETE("/accounts/:id", h.TelegramProxy)
-			telegram.GET("/statistics", h.TelegramProxy)
+			telegram.GET("/statistics", shadow, h.TelegramProxy)
 		}
 
 		mail := api.Group("/mail")
@@ -173,7 +206,7 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 			mail.PUT("/accounts/:id/status", h.MailProxy)
 			mail.POST("/accounts/:id/retry", h.MailProxy)
 			mail.DELETE("/accounts/:id", h.MailProxy)
-			mail.GET("/statistics", h.MailProxy)
+			mail.GET("/statistics", shadow, h.MailProxy)
 		}
 
 		max := api.Group("/max")
@@ -186,7 +219,7 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 			max.POST("/accounts/:id/retry", h.MaxProxy)
 			max.POST("/accounts/:id/link-vk", h.MaxProxy)
 			max.DELETE("/accounts/:id", h.MaxProxy)
-			max.GET("/statistics", h.MaxProxy)
+			max.GET("/statistics", shadow, h.MaxProxy)
 		}
 
 		warming := api.Group("/warming")
@@ -197,11 +230,52 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 			warming.POST("/:taskId/resume", h.WarmingProxy)
 			warming.POST("/:taskId/stop", h.WarmingProxy)
 			warming.GET("/:taskId", h.WarmingProxy)
-			warming.GET("/statistics", h.WarmingProxy)
+			warming.GET("/statistics", shadow, h.WarmingProxy)
 			warming.POST("/scenarios", h.WarmingProxy)
 			warming.PUT("/scenarios/:scenarioId", h.WarmingProxy)
+			warming.POST("/scenarios/simulate", h.WarmingProxy)
 			warming.GET("/scenarios", h.WarmingProxy)
 			warming.GET("/tasks", h.WarmingProxy)
+			warming.GET("/experiments", h.WarmingProxy)
+			warming.GET("/experiments/:experimentId/results", h.WarmingProxy)
+			warming.POST("/experiments", authMiddleware.RequireRole("admin"), h.WarmingProxy)
+			warming.POST("/experiments/:experimentId/stop", authMiddleware.RequireRole("admin"), h.WarmingProxy)
+		}
+
+		scheduler := api.Group("/scheduler")
+		scheduler.Use(authMiddleware.Authenticate())
+		scheduler.Use(authMiddleware.RequireRole("admin"))
+		{
+			scheduler.POST("/jobs", h.SchedulerProxy)
+			scheduler.GET("/jobs", h.SchedulerProxy)
+			scheduler.GET("/jobs/:id", h.SchedulerProxy)
+			scheduler.PUT("/jobs/:id", h.SchedulerProxy)
+			scheduler.DELETE("/jobs/:id", h.SchedulerProxy)
+			scheduler.POST("/jobs/:id/pause", h.SchedulerProxy)
+			scheduler.POST("/jobs/:id/resume", h.SchedulerProxy)
+			scheduler.POST("/jobs/:id/trigger", h.SchedulerProxy)
+			scheduler.GET("/jobs/:id/runs", h.SchedulerProxy)
+		}
+
+		interventions := api.Group("/interventions")
+		interventions.Use(authMiddleware.Authenticate())
+		interventions.Use(authMiddleware.RequireRole("admin", "moderator"))
+		{
+			interventions.GET("", h.InterventionProxy)
+			interventions.GET("/:id", h.InterventionProxy)
+			interventions.POST("/:id/claim", h.InterventionProxy)
+			interventions.POST("/:id/resolve", h.InterventionProxy)
+			interventions.POST("/:id/requeue", h.InterventionProxy)
+		}
+
+		if accountDetail != nil {
+			// The document includes costs, so it is limited to the roles of /analytics
+			accounts := api.Group("/accounts")
+			accounts.Use(authMiddleware.Authenticate())
+			accounts.Use(authMiddleware.RequireRole("admin", "moderator", "viewer"))
+			{
+				accounts.GET("/:platform/:id/full", accountDetail)
+			}
 		}
 
 		admin := api.Group("/admin")
@@ -217,6 +291,19 @@ func SetupRoutes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config) {
 		}
 	}
 
+	setupV2Routes(router, h, cfg, authMiddleware, versions[versioning.V2])
+
+	if graphqlSchema != nil {
+		// Queries can read analytics, so the endpoint is limited to the roles of /analytics
+		gql := router.Group("/graphql")
+		gql.Use(authMiddleware.Authenticate())
+		gql.Use(authMiddleware.RequireRole("admin", "moderator", "viewer"))
+		{
+			gql.POST("", graphql.Handler(graphqlSchema))
+			gql.GET("", graphql.Handler(graphqlSchema))
+		}
+	}
+
 	router.NoRoute(h.NotFound)
 	router.NoMethod(h.MethodNotAllowed)
 }
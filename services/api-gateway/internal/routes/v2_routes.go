@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/config"
+	"github.com/grigta/conveer/pkg/middleware"
+	"github.com/grigta/conveer/services/api-gateway/internal/handlers"
+	"github.com/grigta/conveer/services/api-gateway/internal/versioning"
+)
+
+// setupV2Routes registers /api/v2. Upstream services still serve the v1 shape,
+// so every v2 route goes through a compatibility shim. Only the most-used
+// endpoints are exposed here; the rest stay on v1 until they need a breaking change.
+func setupV2Routes(router *gin.Engine, h *handlers.Handlers, cfg *config.Config, authMiddleware *middleware.AuthMiddleware, version versioning.Version) {
+	api := router.Group("/api/v2")
+	api.Use(versioning.Middleware(version))
+	api.Use(authMiddleware.Authenticate())
+
+	platforms := map[string]string{
+		"vk":       cfg.Services.VKServiceURL,
+		"telegram": cfg.Services.TelegramServiceURL,
+		"mail":     cfg.Services.MailServiceURL,
+		"max":      cfg.Services.MaxServiceURL,
+	}
+	for platform, serviceURL := range platforms {
+		group := api.Group("/" + platform)
+		{
+			group.GET("/accounts", h.VersionedProxy(serviceURL, versioning.List("accounts")))
+			group.POST("/accounts", h.VersionedProxy(serviceURL, versioning.Envelope))
+			group.GET("/accounts/:id", h.VersionedProxy(serviceURL, versioning.Envelope))
+			group.GET("/statistics", h.VersionedProxy(serviceURL, versioning.Envelope))
+		}
+	}
+
+	warming := api.Group("/warming")
+	{
+		warming.POST("/start", h.VersionedProxy(cfg.Services.WarmingServiceURL, versioning.Envelope))
+		warming.GET("/tasks", h.VersionedProxy(cfg.Services.WarmingServiceURL, versioning.List("tasks")))
+		warming.GET("/statistics", h.VersionedProxy(cfg.Services.WarmingServiceURL, versioning.Envelope))
+		warming.GET("/:taskId", h.VersionedProxy(cfg.Services.WarmingServiceURL, versioning.Envelope))
+	}
+
+	proxies := api.Group("/proxies")
+	{
+		proxies.POST("/allocate", h.VersionedProxy(cfg.Services.ProxyServiceURL, versioning.Envelope))
+		proxies.GET("/account/:account_id", h.VersionedProxy(cfg.Services.ProxyServiceURL, versioning.Envelope))
+		proxies.GET("/statistics", h.VersionedProxy(cfg.Services.ProxyServiceURL, versioning.Envelope))
+	}
+
+	sms := api.Group("/sms")
+	{
+		sms.GET("/balance", h.VersionedProxy(cfg.Services.SMSServiceURL, versioning.Envelope))
+		sms.GET("/statistics", h.VersionedProxy(cfg.Services.SMSServiceURL, versioning.Envelope))
+	}
+}
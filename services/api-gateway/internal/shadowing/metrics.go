@@ -0,0 +1,30 @@
+package shadowing
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	shadowRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_shadow_requests_total",
+		Help: "Total number of shadowed requests by route and comparison result",
+	}, []string{"route", "result"})
+
+	shadowStatusMismatches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_shadow_status_mismatches_total",
+		Help: "Total number of shadowed requests whose status differs from the primary by route and status pair",
+	}, []string{"route", "primary_status", "shadow_status"})
+
+	shadowLatencyRatio = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gateway_shadow_latency_ratio",
+		Help:    "Shadow latency divided by primary latency",
+		Buckets: prometheus.ExponentialBuckets(0.125, 2, 8),
+	}, []string{"route"})
+
+	shadowLatencyDelta = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gateway_shadow_latency_delta_seconds",
+		Help:    "Shadow latency minus primary latency in seconds",
+		Buckets: []float64{-1, -0.5, -0.25, -0.1, -0.05, 0, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"route"})
+)
@@ -0,0 +1,226 @@
+package shadowing
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/config"
+	"github.com/grigta/conveer/pkg/logger"
+)
+
+// Header marks mirrored requests so the staging side can tell them apart from real traffic
+const Header = "X-Shadow-Request"
+
+// sensitiveHeaders carry credentials of the client or of a service. Staging is less trusted than
+// production, so they are never mirrored; staging gets the dedicated shadow token instead.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+	"X-Csrf-Token":        true,
+}
+
+// sensitiveHeaderParts catch the remaining API key and token headers by name
+var sensitiveHeaderParts = []string{"token", "secret", "api-key", "apikey", "session", "password"}
+
+func sensitiveHeader(name string) bool {
+	if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+		return true
+	}
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveHeaderParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveParams are query parameters that carry credentials, extractToken accepts the JWT as ?token=
+var sensitiveParams = map[string]bool{
+	"key":           true,
+	"auth":          true,
+	"authorization": true,
+	"sig":           true,
+	"signature":     true,
+}
+
+// sensitiveParamParts catch access_token, api_key, client_secret and the like
+var sensitiveParamParts = []string{"token", "secret", "password", "session", "apikey", "api_key", "api-key", "credential"}
+
+func sensitiveParam(name string) bool {
+	lower := strings.ToLower(name)
+	if sensitiveParams[lower] {
+		return true
+	}
+	for _, part := range sensitiveParamParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// Comparison results of a shadowed request
+const (
+	resultMatch          = "match"
+	resultStatusMismatch = "status_mismatch"
+	resultError          = "error"
+	resultDropped        = "dropped"
+)
+
+// Shadower mirrors a sample of read-only requests to a staging gateway after the primary
+// response is written. Routes opt in through Middleware.
+type Shadower struct {
+	target     *url.URL
+	sampleRate float64
+	timeout    time.Duration
+	client     *http.Client
+	inFlight   chan struct{}
+	token      string
+}
+
+func NewShadower(cfg config.ShadowConfig) (*Shadower, error) {
+	target, err := url.Parse(strings.TrimRight(cfg.TargetURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	maxInFlight := cfg.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = 50
+	}
+
+	return &Shadower{
+		target:     target,
+		sampleRate: cfg.SampleRate,
+		timeout:    timeout,
+		client:     &http.Client{Timeout: timeout},
+		inFlight:   make(chan struct{}, maxInFlight),
+		token:      cfg.Token,
+	}, nil
+}
+
+// Middleware mirrors sampled GET requests of the route. A nil shadower passes requests through,
+// so routes can be wired the same way whether shadowing is enabled or not.
+func (s *Shadower) Middleware() gin.HandlerFunc {
+	if s == nil {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet || c.GetHeader(Header) != "" || rand.Float64() >= s.sampleRate {
+			c.Next()
+			return
+		}
+
+		// The request is copied up front, handlers may change it while serving
+		route := c.FullPath()
+		shadowReq := s.newRequest(c)
+
+		start := time.Now()
+		c.Next()
+		primaryLatency := time.Since(start)
+		primaryStatus := c.Writer.Status()
+
+		if shadowReq == nil {
+			return
+		}
+
+		select {
+		case s.inFlight <- struct{}{}:
+		default:
+			shadowRequests.WithLabelValues(route, resultDropped).Inc()
+			return
+		}
+
+		go func() {
+			defer func() { <-s.inFlight }()
+			s.send(shadowReq, route, primaryStatus, primaryLatency)
+		}()
+	}
+}
+
+func (s *Shadower) newRequest(c *gin.Context) *http.Request {
+	target := *s.target
+	target.Path += c.Request.URL.Path
+	query := c.Request.URL.Query()
+	for name := range query {
+		if sensitiveParam(name) {
+			query.Del(name)
+		}
+	}
+	target.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, target.String(), nil)
+	if err != nil {
+		logger.Warn("Failed to build shadow request",
+			logger.Field{Key: "url", Value: target.String()},
+			logger.Field{Key: "error", Value: err.Error()},
+		)
+		return nil
+	}
+
+	req.Header = c.Request.Header.Clone()
+	for name := range req.Header {
+		if sensitiveHeader(name) {
+			req.Header.Del(name)
+		}
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	// Revalidation would turn shadow responses into 304s that can't be compared
+	req.Header.Del("If-None-Match")
+	req.Header.Set(Header, "true")
+	req.Header.Set("X-Forwarded-For", c.ClientIP())
+	return req
+}
+
+// send replays the request on the staging gateway and compares the outcome with the primary response
+func (s *Shadower) send(req *http.Request, route string, primaryStatus int, primaryLatency time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		shadowRequests.WithLabelValues(route, resultError).Inc()
+		logger.Debug("Shadow request failed",
+			logger.Field{Key: "route", Value: route},
+			logger.Field{Key: "error", Value: err.Error()},
+		)
+		return
+	}
+	// Latency includes the body, as it does for the primary response
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	shadowLatency := time.Since(start)
+
+	shadowLatencyDelta.WithLabelValues(route).Observe((shadowLatency - primaryLatency).Seconds())
+	if primaryLatency > 0 {
+		shadowLatencyRatio.WithLabelValues(route).Observe(float64(shadowLatency) / float64(primaryLatency))
+	}
+
+	if resp.StatusCode != primaryStatus {
+		shadowRequests.WithLabelValues(route, resultStatusMismatch).Inc()
+		shadowStatusMismatches.WithLabelValues(route, strconv.Itoa(primaryStatus), strconv.Itoa(resp.StatusCode)).Inc()
+		return
+	}
+
+	shadowRequests.WithLabelValues(route, resultMatch).Inc()
+}
@@ -0,0 +1,212 @@
+package shadowing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staging records the requests mirrored to it
+type staging struct {
+	server   *httptest.Server
+	status   int
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newStaging(t *testing.T, status int) *staging {
+	st := &staging{status: status}
+	st.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st.mu.Lock()
+		st.requests = append(st.requests, r)
+		st.mu.Unlock()
+		w.WriteHeader(st.status)
+	}))
+	t.Cleanup(st.server.Close)
+	return st
+}
+
+func (st *staging) received() []*http.Request {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return append([]*http.Request(nil), st.requests...)
+}
+
+func newTestRouter(t *testing.T, cfg config.ShadowConfig) (*gin.Engine, *Shadower) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	shadower, err := NewShadower(cfg)
+	require.NoError(t, err)
+
+	router := gin.New()
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	}
+	router.GET("/api/v1/analytics/:kind", shadower.Middleware(), handler)
+	router.POST("/api/v1/analytics/:kind", shadower.Middleware(), handler)
+	return router, shadower
+}
+
+func serve(router *gin.Engine, method, path string, header http.Header) int {
+	req := httptest.NewRequest(method, path, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func resultCount(route, result string) float64 {
+	return testutil.ToFloat64(shadowRequests.WithLabelValues(route, result))
+}
+
+const testRoute = "/api/v1/analytics/:kind"
+
+func TestShadower_SampleRateZeroNeverMirrors(t *testing.T) {
+	st := newStaging(t, http.StatusOK)
+	router, _ := newTestRouter(t, config.ShadowConfig{TargetURL: st.server.URL, SampleRate: 0})
+
+	match := resultCount(testRoute, resultMatch)
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/api/v1/analytics/kpi", nil))
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, st.received())
+	assert.Equal(t, match, resultCount(testRoute, resultMatch))
+}
+
+func TestShadower_SampleRateOneMirrorsEveryRequest(t *testing.T) {
+	st := newStaging(t, http.StatusOK)
+	router, _ := newTestRouter(t, config.ShadowConfig{TargetURL: st.server.URL + "/staging", SampleRate: 1})
+
+	match := resultCount(testRoute, resultMatch)
+	for i := 0; i < 5; i++ {
+		serve(router, http.MethodGet, "/api/v1/analytics/kpi?period=day", nil)
+	}
+
+	require.Eventually(t, func() bool { return resultCount(testRoute, resultMatch) == match+5 }, 2*time.Second, 10*time.Millisecond)
+	requests := st.received()
+	require.Len(t, requests, 5)
+	assert.Equal(t, "/staging/api/v1/analytics/kpi", requests[0].URL.Path)
+	assert.Equal(t, "period=day", requests[0].URL.RawQuery)
+	assert.Equal(t, "true", requests[0].Header.Get(Header))
+}
+
+func TestShadower_SkipsNonGETAndShadowRequests(t *testing.T) {
+	st := newStaging(t, http.StatusOK)
+	router, _ := newTestRouter(t, config.ShadowConfig{TargetURL: st.server.URL, SampleRate: 1})
+
+	serve(router, http.MethodPost, "/api/v1/analytics/kpi", nil)
+	serve(router, http.MethodGet, "/api/v1/analytics/kpi", http.Header{Header: {"true"}})
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, st.received())
+}
+
+func TestShadower_DropsOverInFlightCap(t *testing.T) {
+	st := newStaging(t, http.StatusOK)
+	router, shadower := newTestRouter(t, config.ShadowConfig{TargetURL: st.server.URL, SampleRate: 1, MaxInFlight: 1})
+
+	// One shadow request is already running
+	shadower.inFlight <- struct{}{}
+	defer func() { <-shadower.inFlight }()
+
+	dropped := resultCount(testRoute, resultDropped)
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/api/v1/analytics/kpi", nil))
+
+	assert.Equal(t, dropped+1, resultCount(testRoute, resultDropped))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, st.received())
+}
+
+func TestShadower_RecordsStatusMismatch(t *testing.T) {
+	st := newStaging(t, http.StatusInternalServerError)
+	router, _ := newTestRouter(t, config.ShadowConfig{TargetURL: st.server.URL, SampleRate: 1})
+
+	mismatch := resultCount(testRoute, resultStatusMismatch)
+	pair := testutil.ToFloat64(shadowStatusMismatches.WithLabelValues(testRoute, "200", "500"))
+
+	serve(router, http.MethodGet, "/api/v1/analytics/kpi", nil)
+
+	require.Eventually(t, func() bool { return resultCount(testRoute, resultStatusMismatch) == mismatch+1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, pair+1, testutil.ToFloat64(shadowStatusMismatches.WithLabelValues(testRoute, "200", "500")))
+}
+
+func TestShadower_StripsCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+	}{
+		{"without shadow token", "", ""},
+		{"with shadow token", "shadow-secret", "Bearer shadow-secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newStaging(t, http.StatusOK)
+			router, _ := newTestRouter(t, config.ShadowConfig{TargetURL: st.server.URL, SampleRate: 1, Token: tt.token})
+
+			serve(router, http.MethodGet, "/api/v1/analytics/kpi", http.Header{
+				"Authorization":       {"Bearer user-jwt"},
+				"Proxy-Authorization": {"Basic cHJveHk="},
+				"Cookie":              {"session=abc"},
+				"X-Api-Key":           {"key"},
+				"X-Service-Token":     {"service-jwt"},
+				"X-Refresh-Token":     {"refresh"},
+				"X-Client-Secret":     {"secret"},
+				"If-None-Match":       {`"etag"`},
+				"Accept-Language":     {"ru"},
+			})
+
+			require.Eventually(t, func() bool { return len(st.received()) == 1 }, 2*time.Second, 10*time.Millisecond)
+			header := st.received()[0].Header
+
+			assert.Equal(t, tt.authorization, header.Get("Authorization"))
+			for _, name := range []string{"Proxy-Authorization", "Cookie", "X-Api-Key", "X-Service-Token", "X-Refresh-Token", "X-Client-Secret", "If-None-Match"} {
+				assert.Empty(t, header.Get(name), name)
+			}
+			assert.Equal(t, "ru", header.Get("Accept-Language"))
+			assert.Equal(t, "true", header.Get(Header))
+		})
+	}
+}
+
+func TestShadower_StripsCredentialParams(t *testing.T) {
+	st := newStaging(t, http.StatusOK)
+	router, _ := newTestRouter(t, config.ShadowConfig{TargetURL: st.server.URL, SampleRate: 1})
+
+	serve(router, http.MethodGet, "/api/v1/analytics/kpi?period=day&token=user-jwt&access_token=a&api_key=k&Client_Secret=s&platform=vk", nil)
+
+	require.Eventually(t, func() bool { return len(st.received()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "period=day&platform=vk", st.received()[0].URL.RawQuery)
+}
+
+func TestSensitiveParam(t *testing.T) {
+	for _, name := range []string{"token", "access_token", "refresh_token", "api_key", "apikey", "key", "Auth", "client_secret", "password", "session_id", "signature"} {
+		assert.True(t, sensitiveParam(name), name)
+	}
+	for _, name := range []string{"period", "platform", "from", "to", "page", "limit", "format"} {
+		assert.False(t, sensitiveParam(name), name)
+	}
+}
+
+func TestSensitiveHeader(t *testing.T) {
+	for _, name := range []string{"authorization", "Cookie", "X-API-Key", "X-Auth-Token", "X-Session-Id", "X-Apikey"} {
+		assert.True(t, sensitiveHeader(name), name)
+	}
+	for _, name := range []string{"Accept", "User-Agent", "X-Request-Id", "X-Forwarded-For"} {
+		assert.False(t, sensitiveHeader(name), name)
+	}
+}
@@ -0,0 +1,29 @@
+package versioning
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_api_requests_total",
+		Help: "Total number of API requests by version",
+	}, []string{"version", "method", "route", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gateway_api_request_duration_seconds",
+		Help:    "Duration of API requests by version",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"version", "route"})
+
+	deprecatedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_api_deprecated_requests_total",
+		Help: "Total number of requests served by a deprecated API version",
+	}, []string{"version", "route"})
+
+	shimErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_api_shim_errors_total",
+		Help: "Total number of responses the compatibility shim failed to convert",
+	}, []string{"version", "route"})
+)
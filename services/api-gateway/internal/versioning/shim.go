@@ -0,0 +1,106 @@
+package versioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/logger"
+)
+
+// Shim converts a v1-shaped upstream response into the v2 response shape.
+// Upstream services keep speaking v1; only the gateway knows about v2.
+type Shim func(status int, body []byte) ([]byte, error)
+
+// v2 responses always use the same envelope:
+//
+//	{"data": ..., "meta": {...}}        on success
+//	{"error": {"code": ..., "message": ...}} on failure
+type envelope struct {
+	Data  interface{}            `json:"data,omitempty"`
+	Meta  map[string]interface{} `json:"meta,omitempty"`
+	Error *errorBody             `json:"error,omitempty"`
+}
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Envelope wraps a single-object response into {"data": ...}
+func Envelope(status int, body []byte) ([]byte, error) {
+	if status >= http.StatusBadRequest {
+		return convertError(status, body)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(envelope{Data: data})
+}
+
+// List converts {"<itemsKey>": [...], "total": n, ...} into {"data": [...], "meta": {"total": n, ...}}.
+// Every field other than itemsKey is moved to meta.
+func List(itemsKey string) Shim {
+	return func(status int, body []byte) ([]byte, error) {
+		if status >= http.StatusBadRequest {
+			return convertError(status, body)
+		}
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, err
+		}
+
+		items, ok := fields[itemsKey]
+		if !ok {
+			return nil, fmt.Errorf("response has no %q field", itemsKey)
+		}
+		if items == nil {
+			items = []interface{}{}
+		}
+		delete(fields, itemsKey)
+
+		return json.Marshal(envelope{Data: items, Meta: fields})
+	}
+}
+
+func convertError(status int, body []byte) ([]byte, error) {
+	var v1 struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &v1)
+
+	message := v1.Error
+	if v1.Message != "" {
+		message = v1.Message
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	code := strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+	return json.Marshal(envelope{Error: &errorBody{Code: code, Message: message}})
+}
+
+// Transformer binds the shim to a request. When conversion fails the original
+// body is returned unchanged so clients still get a response.
+func (s Shim) Transformer(c *gin.Context) func(status int, body []byte) []byte {
+	return func(status int, body []byte) []byte {
+		converted, err := s(status, body)
+		if err != nil {
+			shimErrors.WithLabelValues(FromContext(c), c.FullPath()).Inc()
+			logger.Warn("Failed to convert response to v2 shape",
+				logger.Field{Key: "error", Value: err.Error()},
+				logger.Field{Key: "path", Value: c.Request.URL.Path},
+			)
+			return body
+		}
+		return converted
+	}
+}
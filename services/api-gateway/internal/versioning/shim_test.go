@@ -0,0 +1,69 @@
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	body, err := Envelope(http.StatusOK, []byte(`{"id":"a1","status":"active"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"id":"a1","status":"active"}}`, string(body))
+
+	_, err = Envelope(http.StatusOK, []byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestList(t *testing.T) {
+	shim := List("accounts")
+
+	body, err := shim(http.StatusOK, []byte(`{"accounts":[{"id":"a1"}],"total":1,"page":2}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":[{"id":"a1"}],"meta":{"total":1,"page":2}}`, string(body))
+
+	body, err = shim(http.StatusOK, []byte(`{"accounts":null,"total":0}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":[],"meta":{"total":0}}`, string(body))
+
+	_, err = shim(http.StatusOK, []byte(`{"items":[]}`))
+	assert.Error(t, err)
+}
+
+func TestShim_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"error field", http.StatusNotFound, `{"error":"account not found"}`, `{"error":{"code":"not_found","message":"account not found"}}`},
+		{"message wins over error", http.StatusBadRequest, `{"error":"bad","message":"limit must be positive"}`, `{"error":{"code":"bad_request","message":"limit must be positive"}}`},
+		{"empty body", http.StatusBadGateway, ``, `{"error":{"code":"bad_gateway","message":"Bad Gateway"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, shim := range []Shim{Envelope, List("accounts")} {
+				body, err := shim(tt.status, []byte(tt.body))
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.want, string(body))
+			}
+		})
+	}
+}
+
+func TestShim_TransformerKeepsBodyOnFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v2/accounts", nil)
+
+	transform := List("accounts").Transformer(c)
+
+	assert.Equal(t, []byte(`{"items":[]}`), transform(http.StatusOK, []byte(`{"items":[]}`)))
+	assert.JSONEq(t, `{"data":[]}`, string(transform(http.StatusOK, []byte(`{"accounts":[]}`))))
+}
@@ -0,0 +1,98 @@
+package versioning
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/config"
+)
+
+const (
+	V1 = "v1"
+	V2 = "v2"
+
+	// ContextKey holds the API version of the current request in gin.Context
+	ContextKey = "api_version"
+
+	dateLayout = "2006-01-02"
+)
+
+type Version struct {
+	Name         string
+	Deprecated   bool
+	DeprecatedAt time.Time
+	Sunset       time.Time
+	DocsURL      string
+}
+
+// Versions builds the version table from gateway configuration.
+// v2 is always current; v1 becomes deprecated once configured so.
+func Versions(cfg config.APIVersioningConfig) map[string]Version {
+	v1 := Version{
+		Name:       V1,
+		Deprecated: cfg.V1Deprecated,
+		DocsURL:    cfg.DocsURL,
+	}
+	if t, err := time.Parse(dateLayout, cfg.V1DeprecatedAt); err == nil {
+		v1.DeprecatedAt = t
+	}
+	if t, err := time.Parse(dateLayout, cfg.V1Sunset); err == nil {
+		v1.Sunset = t
+	}
+
+	return map[string]Version{
+		V1: v1,
+		V2: {Name: V2, DocsURL: cfg.DocsURL},
+	}
+}
+
+// Middleware tags the request with its API version, sets deprecation headers
+// and records per-version metrics.
+func Middleware(v Version) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Set(ContextKey, v.Name)
+
+		header := c.Writer.Header()
+		header.Set("API-Version", v.Name)
+		if v.Deprecated {
+			// RFC 9745 only allows a date, so without one the header is left out
+			if !v.DeprecatedAt.IsZero() {
+				header.Set("Deprecation", fmt.Sprintf("@%d", v.DeprecatedAt.Unix()))
+			}
+			if !v.Sunset.IsZero() {
+				// RFC 8594
+				header.Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if v.DocsURL != "" {
+				header.Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", v.DocsURL))
+			}
+		}
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		requestsTotal.WithLabelValues(v.Name, c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		requestDuration.WithLabelValues(v.Name, route).Observe(time.Since(start).Seconds())
+		if v.Deprecated {
+			deprecatedRequests.WithLabelValues(v.Name, route).Inc()
+		}
+	}
+}
+
+// FromContext returns the API version of the request, defaulting to v1
+func FromContext(c *gin.Context) string {
+	if v, ok := c.Get(ContextKey); ok {
+		if name, ok := v.(string); ok {
+			return name
+		}
+	}
+	return V1
+}
@@ -0,0 +1,108 @@
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveVersion(v Version) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+
+	var seen string
+	router := gin.New()
+	router.GET("/api/accounts", Middleware(v), func(c *gin.Context) {
+		seen = FromContext(c)
+		c.Status(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/accounts", nil))
+	return rec, seen
+}
+
+func TestVersions(t *testing.T) {
+	versions := Versions(config.APIVersioningConfig{
+		V1Deprecated:   true,
+		V1DeprecatedAt: "2026-09-01",
+		V1Sunset:       "2027-03-01",
+		DocsURL:        "https://docs.example.com/api/v2",
+	})
+
+	v1 := versions[V1]
+	assert.True(t, v1.Deprecated)
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), v1.DeprecatedAt)
+	assert.Equal(t, time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC), v1.Sunset)
+
+	v2 := versions[V2]
+	assert.False(t, v2.Deprecated)
+	assert.Equal(t, "https://docs.example.com/api/v2", v2.DocsURL)
+}
+
+func TestVersions_InvalidDates(t *testing.T) {
+	versions := Versions(config.APIVersioningConfig{
+		V1Deprecated:   true,
+		V1DeprecatedAt: "01.09.2026",
+		V1Sunset:       "soon",
+	})
+
+	assert.True(t, versions[V1].DeprecatedAt.IsZero())
+	assert.True(t, versions[V1].Sunset.IsZero())
+}
+
+func TestMiddleware_Headers(t *testing.T) {
+	deprecatedAt := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		version     Version
+		deprecation string
+		sunset      string
+		link        string
+	}{
+		{
+			name:    "current version",
+			version: Version{Name: V2, DocsURL: "https://docs.example.com"},
+		},
+		{
+			name:        "deprecated with date",
+			version:     Version{Name: V1, Deprecated: true, DeprecatedAt: deprecatedAt, Sunset: sunset, DocsURL: "https://docs.example.com"},
+			deprecation: "@1788220800",
+			sunset:      "Mon, 01 Mar 2027 00:00:00 GMT",
+			link:        `<https://docs.example.com>; rel="deprecation"`,
+		},
+		{
+			name:    "deprecated without date",
+			version: Version{Name: V1, Deprecated: true, DocsURL: "https://docs.example.com"},
+			link:    `<https://docs.example.com>; rel="deprecation"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, seen := serveVersion(tt.version)
+
+			assert.Equal(t, tt.version.Name, rec.Header().Get("API-Version"))
+			assert.Equal(t, tt.version.Name, seen)
+			assert.Equal(t, tt.deprecation, rec.Header().Get("Deprecation"))
+			assert.Equal(t, tt.sunset, rec.Header().Get("Sunset"))
+			assert.Equal(t, tt.link, rec.Header().Get("Link"))
+		})
+	}
+}
+
+func TestFromContext_DefaultsToV1(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	assert.Equal(t, V1, FromContext(c))
+
+	c.Set(ContextKey, V2)
+	assert.Equal(t, V2, FromContext(c))
+}
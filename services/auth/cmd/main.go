@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -14,14 +18,23 @@ import (
 	"github.com/grigta/conveer/pkg/config"
 	"github.com/grigta/conveer/pkg/crypto"
 	"github.com/grigta/conveer/pkg/database"
+	"github.com/grigta/conveer/pkg/health"
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/middleware"
+	"github.com/grigta/conveer/pkg/models"
+	"github.com/grigta/conveer/pkg/serviceauth"
+	"github.com/grigta/conveer/services/auth/internal/handlers"
 	"github.com/grigta/conveer/services/auth/internal/repository"
 	"github.com/grigta/conveer/services/auth/internal/service"
+	pb "github.com/grigta/conveer/services/auth/proto"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 )
 
+// serviceName is the scope other services need to call auth-service with service tokens
+const serviceName = "auth-service"
+
 func main() {
 	cfg, err := config.LoadConfig("./config")
 	if err != nil {
@@ -39,12 +52,11 @@ func main() {
 	if err != nil {
 		logger.Fatal("Failed to initialize encryptor", logger.Field{Key: "error", Value: err.Error()})
 	}
-	_ = encryptor // Store for later use if needed
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	db, err := database.NewMongoDB(cfg.Database.URI, cfg.Database.DBName, 10*time.Second)
+	db, err := database.NewMongoDB(cfg.Database.URI, cfg.Database.DBName, 10*time.Second, cfg.Database.Options)
 	if err != nil {
 		logger.Fatal("Failed to connect to database", logger.Field{Key: "error", Value: err.Error()})
 	}
@@ -66,8 +78,27 @@ func main() {
 		logger.Fatal("Failed to setup RabbitMQ topology", logger.Field{Key: "error", Value: err.Error()})
 	}
 
+	// Security alerts for the Telegram bot go through its exchange
+	if err := rabbitmq.DeclareExchange("bot.events", "topic", true, false); err != nil {
+		logger.Fatal("Failed to declare bot.events exchange", logger.Field{Key: "error", Value: err.Error()})
+	}
+
 	authRepo := repository.NewAuthRepository(db, redisCache)
-	authService := service.NewAuthService(authRepo, cfg, rabbitmq)
+	if err := authRepo.CreateIndexes(cfg.Auth.AuditRetention); err != nil {
+		logger.Warn("Failed to create indexes", logger.Field{Key: "error", Value: err.Error()})
+	}
+	authService := service.NewAuthService(authRepo, cfg, rabbitmq, encryptor)
+
+	// Readiness gates for gRPC health and /health
+	healthChecker := health.NewChecker("auth-service")
+	healthChecker.AddDetailed("mongodb", func(ctx context.Context) (interface{}, error) {
+		return db.HealthCheck(ctx)
+	})
+	healthChecker.Add("redis", health.PingCheck(redisCache))
+	healthChecker.Add("rabbitmq", health.PingCheck(rabbitmq))
+
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	go healthChecker.Run(healthCtx, 10*time.Second)
 
 	// Start gRPC server
 	lis, err := net.Listen("tcp", ":50051")
@@ -75,7 +106,13 @@ func main() {
 		logger.Fatal("Failed to listen", logger.Field{Key: "error", Value: err.Error()})
 	}
 
-	grpcServer := grpc.NewServer()
+	// Health checks are exempt from service auth, the JWKS and token endpoints are served over HTTP
+	serviceAuth := serviceauth.FromEnv(serviceName)
+	grpcOptions := append([]grpc.ServerOption{grpc.UnaryInterceptor(handlers.ClientInfoInterceptor)}, logger.ServerOptions()...)
+	grpcServer := grpc.NewServer(append(grpcOptions, serviceAuth.ServerOptions()...)...)
+	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.Secret)
+	pb.RegisterAuthServiceServer(grpcServer, handlers.NewGRPCHandler(authService, authMiddleware))
+	healthChecker.Register(grpcServer)
 
 	go func() {
 		logger.Info("Starting Auth gRPC Service", logger.Field{Key: "port", Value: 50051})
@@ -88,9 +125,29 @@ func main() {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(clientInfo())
+
+	// Short-lived tokens for calls between internal services
+	var serviceTokens *service.ServiceTokenIssuer
+	if len(cfg.ServiceAuth.Services) > 0 {
+		serviceTokens, err = service.NewServiceTokenIssuer(cfg.ServiceAuth)
+		if err != nil {
+			logger.Fatal("Failed to initialize service token issuer", logger.Field{Key: "error", Value: err.Error()})
+		}
+		setupServiceTokenHandlers(router, serviceTokens)
+	}
 
 	// Setup HTTP handlers that wrap the service
-	setupHTTPHandlers(router, authService)
+	setupHTTPHandlers(router, authService, healthChecker, authMiddleware, serviceTokens)
+
+	// OAuth2/OIDC provider for internal dashboards and the api-gateway
+	if cfg.OIDC.Enabled {
+		oidcProvider, err := service.NewOIDCProvider(authService, cfg.OIDC)
+		if err != nil {
+			logger.Fatal("Failed to initialize OIDC provider", logger.Field{Key: "error", Value: err.Error()})
+		}
+		setupOIDCHandlers(router, oidcProvider, authService, authMiddleware, strings.HasPrefix(cfg.OIDC.Issuer, "https://"))
+	}
 
 	httpServer := &http.Server{
 		Addr:    ":8001",
@@ -110,6 +167,9 @@ func main() {
 
 	logger.Info("Shutting down Auth Service...")
 
+	// Report NOT_SERVING so callers stop routing here before the servers stop
+	stopHealth()
+
 	// Graceful shutdown of HTTP server
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutdownCancel()
@@ -121,14 +181,9 @@ func main() {
 	logger.Info("Auth Service exited")
 }
 
-func setupHTTPHandlers(router *gin.Engine, authService *service.AuthService) {
+func setupHTTPHandlers(router *gin.Engine, authService *service.AuthService, healthChecker *health.Checker, authMiddleware *middleware.AuthMiddleware, serviceTokens *service.ServiceTokenIssuer) {
 	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status": "healthy",
-			"service": "auth-service",
-		})
-	})
+	router.GET("/health", healthChecker.Handler())
 
 	// Auth endpoints
 	router.POST("/register", func(c *gin.Context) {
@@ -142,13 +197,21 @@ func setupHTTPHandlers(router *gin.Engine, authService *service.AuthService) {
 	})
 
 	router.POST("/login", func(c *gin.Context) {
-		var req map[string]interface{}
+		var req models.LoginRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		// TODO: Implement proper handler that calls authService methods
-		c.JSON(http.StatusOK, gin.H{"message": "Login endpoint"})
+		result, err := authService.Login(c.Request.Context(), &req)
+		if err == models.ErrTwoFactorRequired {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error(), "two_factor_required": true})
+			return
+		}
+		if err != nil {
+			c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, result)
 	})
 
 	router.POST("/logout", func(c *gin.Context) {
@@ -157,13 +220,17 @@ func setupHTTPHandlers(router *gin.Engine, authService *service.AuthService) {
 	})
 
 	router.POST("/refresh", func(c *gin.Context) {
-		var req map[string]interface{}
+		var req models.RefreshTokenRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		// TODO: Implement proper handler that calls authService methods
-		c.JSON(http.StatusOK, gin.H{"message": "Refresh endpoint"})
+		result, err := authService.RefreshToken(c.Request.Context(), req.RefreshToken)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, result)
 	})
 
 	router.POST("/forgot-password", func(c *gin.Context) {
@@ -195,4 +262,251 @@ func setupHTTPHandlers(router *gin.Engine, authService *service.AuthService) {
 		// TODO: Implement proper handler that calls authService methods
 		c.JSON(http.StatusOK, gin.H{"message": "Verify email endpoint"})
 	})
+
+	// Two-factor authentication of the logged in user
+	twoFactor := router.Group("/2fa")
+	twoFactor.Use(authMiddleware.Authenticate())
+	{
+		twoFactor.POST("/enroll", func(c *gin.Context) {
+			enrollment, err := authService.EnrollTwoFactor(c.Request.Context(), c.GetString("user_id"))
+			if err != nil {
+				c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, enrollment)
+		})
+
+		twoFactor.POST("/confirm", func(c *gin.Context) {
+			var req models.TwoFactorCodeRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			codes, err := authService.ConfirmTwoFactor(c.Request.Context(), c.GetString("user_id"), req.Code)
+			if err != nil {
+				c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes})
+		})
+
+		twoFactor.POST("/disable", func(c *gin.Context) {
+			var req models.TwoFactorCodeRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if err := authService.DisableTwoFactor(c.Request.Context(), c.GetString("user_id"), req.Code); err != nil {
+				c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+		})
+
+		twoFactor.POST("/recovery-codes", func(c *gin.Context) {
+			var req models.TwoFactorCodeRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			codes, err := authService.RegenerateRecoveryCodes(c.Request.Context(), c.GetString("user_id"), req.Code)
+			if err != nil {
+				c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes})
+		})
+	}
+
+	// Authentication audit log, admins only
+	audit := router.Group("/audit")
+	audit.Use(authMiddleware.Authenticate(), authMiddleware.RequireRole(string(models.RoleAdmin)))
+	{
+		audit.GET("/events", func(c *gin.Context) {
+			var filter models.AuthAuditFilter
+			if err := c.ShouldBindQuery(&filter); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			page, err := authService.ListAuthAuditEvents(c.Request.Context(), &filter)
+			if err != nil {
+				c.JSON(auditErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, page)
+		})
+
+		// Streams every matching event as CSV (default) or newline-delimited JSON
+		audit.GET("/events/export", func(c *gin.Context) {
+			var filter models.AuthAuditFilter
+			if err := c.ShouldBindQuery(&filter); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if err := filter.Normalize(); err != nil {
+				c.JSON(auditErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			exportAuditEvents(c, authService, &filter, c.DefaultQuery("format", "csv"))
+		})
+	}
+
+	// Notification preferences: users manage their own, admins anyone's. Recipients are resolved
+	// only for the analytics notifier and the telegram bot, which call with service tokens.
+	prefs := router.Group("/notification-preferences")
+	{
+		prefs.GET("/recipients", requireServiceCaller(serviceTokens), func(c *gin.Context) {
+			recipients, err := authService.ListNotificationRecipients(
+				c.Request.Context(),
+				models.NotificationCategory(c.Query("category")),
+				c.Query("value"),
+				c.Query("platform"),
+				models.NotificationChannel(c.DefaultQuery("channel", string(models.ChannelTelegram))),
+			)
+			if err != nil {
+				c.JSON(preferencesErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"recipients": recipients})
+		})
+
+		prefs.GET("/:user_id", authMiddleware.Authenticate(), selfOrAdmin(), func(c *gin.Context) {
+			result, err := authService.GetNotificationPreferences(c.Request.Context(), c.Param("user_id"))
+			if err != nil {
+				c.JSON(preferencesErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, result)
+		})
+
+		prefs.PUT("/:user_id", authMiddleware.Authenticate(), selfOrAdmin(), func(c *gin.Context) {
+			var req models.UpdateNotificationPreferencesRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			result, err := authService.UpdateNotificationPreferences(c.Request.Context(), c.Param("user_id"), &req)
+			if err != nil {
+				c.JSON(preferencesErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, result)
+		})
+
+		prefs.DELETE("/:user_id", authMiddleware.Authenticate(), selfOrAdmin(), func(c *gin.Context) {
+			result, err := authService.ResetNotificationPreferences(c.Request.Context(), c.Param("user_id"))
+			if err != nil {
+				c.JSON(preferencesErrorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, result)
+		})
+	}
+}
+
+// clientInfo passes the caller's address and user agent to the service for auditing
+func clientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := service.WithClientInfo(c.Request.Context(), service.ClientInfo{
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func exportAuditEvents(c *gin.Context, authService *service.AuthService, filter *models.AuthAuditFilter, format string) {
+	filename := fmt.Sprintf("auth-audit-%s", time.Now().Format("20060102-150405"))
+
+	switch format {
+	case "csv":
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", filename))
+
+		w := csv.NewWriter(c.Writer)
+		w.Write([]string{"created_at", "type", "success", "reason", "user_id", "email", "ip_address", "user_agent"})
+		err := authService.ExportAuthAuditEvents(c.Request.Context(), filter, func(event *models.AuthAuditEvent) error {
+			userID := ""
+			if !event.UserID.IsZero() {
+				userID = event.UserID.Hex()
+			}
+			return w.Write([]string{
+				event.CreatedAt.UTC().Format(time.RFC3339),
+				event.Type,
+				strconv.FormatBool(event.Success),
+				event.Reason,
+				userID,
+				event.Email,
+				event.IPAddress,
+				event.UserAgent,
+			})
+		})
+		w.Flush()
+		if err != nil {
+			// Headers are already sent, the truncated export is all that can be reported
+			logger.Error("Failed to export audit events", logger.Field{Key: "error", Value: err.Error()})
+		}
+
+	case "ndjson":
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.ndjson", filename))
+
+		encoder := json.NewEncoder(c.Writer)
+		err := authService.ExportAuthAuditEvents(c.Request.Context(), filter, func(event *models.AuthAuditEvent) error {
+			return encoder.Encode(event)
+		})
+		if err != nil {
+			logger.Error("Failed to export audit events", logger.Field{Key: "error", Value: err.Error()})
+		}
+
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or ndjson"})
+	}
+}
+
+func auditErrorStatus(err error) int {
+	switch err {
+	case models.ErrInvalidAuditEventType, models.ErrInvalidAuditFilter:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func preferencesErrorStatus(err error) int {
+	switch err {
+	case models.ErrUserNotFound:
+		return http.StatusNotFound
+	case models.ErrInvalidChannel, models.ErrInvalidSeverity, models.ErrInvalidDigest, models.ErrInvalidCategory:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func twoFactorErrorStatus(err error) int {
+	switch err {
+	case models.ErrUserNotFound:
+		return http.StatusNotFound
+	case models.ErrInvalidTwoFactorCode, models.ErrTwoFactorRequired, service.ErrInvalidCredentials:
+		return http.StatusUnauthorized
+	case service.ErrAccountDisabled:
+		return http.StatusForbidden
+	case models.ErrAccountLocked:
+		return http.StatusLocked
+	case models.ErrTwoFactorNotEnrolled, models.ErrTwoFactorAlreadyEnabled:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// selfOrAdmin lets users reach only their own :user_id routes, admins reach everyone's
+func selfOrAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("role") != string(models.RoleAdmin) && c.GetString("user_id") != c.Param("user_id") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
 }
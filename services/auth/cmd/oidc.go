@@ -0,0 +1,273 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/middleware"
+	"github.com/grigta/conveer/pkg/models"
+	"github.com/grigta/conveer/services/auth/internal/service"
+)
+
+// sessionCookieMaxAge matches the lifetime of Conveer access tokens
+const sessionCookieMaxAge = 24 * 60 * 60
+
+const (
+	// loginCSRFCookie carries the token of the last rendered login form
+	loginCSRFCookie = "oauth_csrf"
+	// loginCSRFMaxAge is how long a rendered login form can be submitted
+	loginCSRFMaxAge = 30 * 60
+)
+
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Вход в Conveer</title>
+</head>
+<body>
+<h1>Вход в Conveer</h1>
+{{if .Client}}<p>Приложение «{{.Client}}» запрашивает вход.</p>{{end}}
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+<form method="post" action="/oauth2/authorize">
+<input type="hidden" name="response_type" value="{{.Request.ResponseType}}">
+<input type="hidden" name="client_id" value="{{.Request.ClientID}}">
+<input type="hidden" name="redirect_uri" value="{{.Request.RedirectURI}}">
+<input type="hidden" name="scope" value="{{.Request.Scope}}">
+<input type="hidden" name="state" value="{{.Request.State}}">
+<input type="hidden" name="nonce" value="{{.Request.Nonce}}">
+<input type="hidden" name="code_challenge" value="{{.Request.CodeChallenge}}">
+<input type="hidden" name="code_challenge_method" value="{{.Request.CodeChallengeMethod}}">
+<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+<label>Email <input type="email" name="email" value="{{.Email}}" required autofocus></label>
+<label>Пароль <input type="password" name="password" required></label>
+{{if .TwoFactor}}<label>Код 2FA <input type="text" name="otp_code" autocomplete="one-time-code" required></label>{{end}}
+<button type="submit">Войти</button>
+</form>
+</body>
+</html>`))
+
+type loginPageData struct {
+	Request   *models.AuthorizationRequest
+	Client    string
+	Email     string
+	Error     string
+	TwoFactor bool
+	CSRFToken string
+}
+
+func setupOIDCHandlers(router *gin.Engine, provider *service.OIDCProvider, authService *service.AuthService, authMiddleware *middleware.AuthMiddleware, secureCookies bool) {
+	router.GET("/.well-known/openid-configuration", func(c *gin.Context) {
+		c.JSON(http.StatusOK, provider.Discovery())
+	})
+
+	router.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, provider.JWKS())
+	})
+
+	// A user already signed in to Conveer gets a code right away, anyone else sees the login form
+	router.GET("/oauth2/authorize", authMiddleware.OptionalAuthenticate(), func(c *gin.Context) {
+		var req models.AuthorizationRequest
+		if err := c.ShouldBindQuery(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.NewOAuthError(models.OAuthInvalidRequest, err.Error()))
+			return
+		}
+		if !validateAuthorizationRequest(c, provider, &req) {
+			return
+		}
+
+		if userID := c.GetString("user_id"); userID != "" {
+			if user, err := provider.UserFromSession(c.Request.Context(), userID); err == nil {
+				issueAuthorizationCode(c, provider, &req, user)
+				return
+			}
+		}
+
+		renderLoginPage(c, http.StatusOK, provider, &loginPageData{Request: &req}, secureCookies)
+	})
+
+	router.POST("/oauth2/authorize", func(c *gin.Context) {
+		var req models.AuthorizationRequest
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.NewOAuthError(models.OAuthInvalidRequest, err.Error()))
+			return
+		}
+		if !validateAuthorizationRequest(c, provider, &req) {
+			return
+		}
+
+		// A form posted from another site has no token of ours, so nobody can be signed in behind their back
+		if !validLoginCSRFToken(c) {
+			data := &loginPageData{Request: &req, Error: "Форма входа устарела, попробуйте ещё раз"}
+			renderLoginPage(c, http.StatusForbidden, provider, data, secureCookies)
+			return
+		}
+
+		login := &models.LoginRequest{
+			Email:    strings.TrimSpace(c.PostForm("email")),
+			Password: c.PostForm("password"),
+			OTPCode:  strings.TrimSpace(c.PostForm("otp_code")),
+		}
+		result, err := authService.Login(c.Request.Context(), login)
+		if err != nil {
+			data := &loginPageData{Request: &req, Email: login.Email, Error: loginErrorMessage(err)}
+			data.TwoFactor = errors.Is(err, models.ErrTwoFactorRequired) || login.OTPCode != ""
+			renderLoginPage(c, twoFactorErrorStatus(err), provider, data, secureCookies)
+			return
+		}
+
+		// The Conveer session cookie lets the next dashboard sign in without the form
+		c.SetSameSite(http.SameSiteStrictMode)
+		c.SetCookie(loginCSRFCookie, "", -1, "/oauth2/authorize", "", secureCookies, true)
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie("token", result.AccessToken, sessionCookieMaxAge, "/", "", secureCookies, true)
+
+		issueAuthorizationCode(c, provider, &req, result.User)
+	})
+
+	router.POST("/oauth2/token", func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.Header("Pragma", "no-cache")
+
+		var req models.OIDCTokenRequest
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.NewOAuthError(models.OAuthInvalidRequest, err.Error()))
+			return
+		}
+
+		basicAuth := false
+		if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+			req.ClientID, req.ClientSecret, basicAuth = clientID, clientSecret, true
+		}
+
+		result, err := provider.Exchange(c.Request.Context(), &req)
+		if err != nil {
+			oauthErr := oauthError(err)
+			status := http.StatusBadRequest
+			if oauthErr.Code == models.OAuthInvalidClient {
+				status = http.StatusUnauthorized
+				if basicAuth {
+					c.Header("WWW-Authenticate", `Basic realm="conveer"`)
+				}
+			} else if oauthErr.Code == models.OAuthServerError {
+				status = http.StatusInternalServerError
+			}
+			c.JSON(status, oauthErr)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	})
+
+	userInfo := func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, "Bearer ") {
+			c.Header("WWW-Authenticate", `Bearer realm="conveer"`)
+			c.JSON(http.StatusUnauthorized, models.NewOAuthError(models.OAuthInvalidRequest, "bearer token is required"))
+			return
+		}
+
+		info, err := provider.UserInfo(c.Request.Context(), strings.TrimPrefix(header, "Bearer "))
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="conveer", error="invalid_token"`)
+			c.JSON(http.StatusUnauthorized, oauthError(err))
+			return
+		}
+		c.JSON(http.StatusOK, info)
+	}
+	router.GET("/oauth2/userinfo", userInfo)
+	router.POST("/oauth2/userinfo", userInfo)
+}
+
+// validateAuthorizationRequest answers an untrusted client or redirect URI directly and
+// sends any other error back to the client. It reports whether the request may go on.
+func validateAuthorizationRequest(c *gin.Context, provider *service.OIDCProvider, req *models.AuthorizationRequest) bool {
+	if err := provider.CheckRedirect(req); err != nil {
+		c.JSON(http.StatusBadRequest, oauthError(err))
+		return false
+	}
+	if err := provider.ValidateAuthorizationRequest(req); err != nil {
+		c.Redirect(http.StatusFound, provider.ErrorRedirect(req, err))
+		return false
+	}
+	return true
+}
+
+func issueAuthorizationCode(c *gin.Context, provider *service.OIDCProvider, req *models.AuthorizationRequest, user *models.User) {
+	redirect, err := provider.Authorize(c.Request.Context(), req, user)
+	if err != nil {
+		redirect = provider.ErrorRedirect(req, err)
+	}
+	c.Redirect(http.StatusFound, redirect)
+}
+
+// renderLoginPage issues a new CSRF token with every form, a failed attempt included
+func renderLoginPage(c *gin.Context, status int, provider *service.OIDCProvider, data *loginPageData, secureCookies bool) {
+	data.Client = provider.ClientName(data.Request.ClientID)
+
+	token, err := newLoginCSRFToken()
+	if err != nil {
+		logger.Error("Failed to generate login CSRF token", logger.Field{Key: "error", Value: err.Error()})
+		c.JSON(http.StatusInternalServerError, models.NewOAuthError(models.OAuthServerError, ""))
+		return
+	}
+	data.CSRFToken = token
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(loginCSRFCookie, token, loginCSRFMaxAge, "/oauth2/authorize", "", secureCookies, true)
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Frame-Options", "DENY")
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(status)
+	if err := loginPage.Execute(c.Writer, data); err != nil {
+		logger.Error("Failed to render login page", logger.Field{Key: "error", Value: err.Error()})
+	}
+}
+
+func newLoginCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// validLoginCSRFToken reports whether the posted form carries the token from the CSRF cookie
+func validLoginCSRFToken(c *gin.Context) bool {
+	cookie, err := c.Cookie(loginCSRFCookie)
+	if err != nil || cookie == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie), []byte(c.PostForm("csrf_token"))) == 1
+}
+
+func loginErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, models.ErrTwoFactorRequired):
+		return "Введите код двухфакторной аутентификации"
+	case errors.Is(err, models.ErrInvalidTwoFactorCode):
+		return "Неверный код двухфакторной аутентификации"
+	case errors.Is(err, models.ErrAccountLocked):
+		return "Слишком много неудачных попыток, вход временно заблокирован"
+	case errors.Is(err, service.ErrAccountDisabled):
+		return "Учётная запись отключена"
+	case errors.Is(err, service.ErrInvalidCredentials):
+		return "Неверный email или пароль"
+	}
+	return "Не удалось войти, попробуйте позже"
+}
+
+func oauthError(err error) *models.OAuthError {
+	var oauthErr *models.OAuthError
+	if errors.As(err, &oauthErr) {
+		return oauthErr
+	}
+	return models.NewOAuthError(models.OAuthServerError, "")
+}
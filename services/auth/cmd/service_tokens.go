@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/models"
+	"github.com/grigta/conveer/pkg/serviceauth"
+	"github.com/grigta/conveer/services/auth/internal/service"
+)
+
+func setupServiceTokenHandlers(router *gin.Engine, issuer *service.ServiceTokenIssuer) {
+	router.GET("/.well-known/service-jwks.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, issuer.JWKS())
+	})
+
+	router.POST("/oauth2/service-token", func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.Header("Pragma", "no-cache")
+
+		var req models.ServiceTokenRequest
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.NewOAuthError(models.OAuthInvalidRequest, err.Error()))
+			return
+		}
+		if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+			req.ClientID, req.ClientSecret = clientID, clientSecret
+		}
+
+		result, err := issuer.Issue(&req)
+		if err != nil {
+			oauthErr := oauthError(err)
+			status := http.StatusBadRequest
+			switch oauthErr.Code {
+			case models.OAuthInvalidClient:
+				status = http.StatusUnauthorized
+				logger.Warn("Service token denied",
+					logger.Field{Key: "service", Value: req.ClientID},
+					logger.Field{Key: "ip", Value: c.ClientIP()})
+			case models.OAuthServerError:
+				status = http.StatusInternalServerError
+			}
+			c.JSON(status, oauthErr)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// requireServiceCaller serves only internal services whose token has a scope covering the route,
+// e.g. "auth-service:/notification-preferences/recipients". Without an issuer no service can get
+// a token, so every request is refused.
+func requireServiceCaller(issuer *service.ServiceTokenIssuer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(serviceauth.HeaderName)
+		if token == "" || issuer == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Service token required"})
+			c.Abort()
+			return
+		}
+
+		claims, err := issuer.Verify(token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid service token"})
+			c.Abort()
+			return
+		}
+		if !claims.Allows(serviceName, c.FullPath()) {
+			logger.Warn("Service is not allowed to call route",
+				logger.Field{Key: "service", Value: claims.Subject},
+				logger.Field{Key: "route", Value: c.FullPath()})
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		c.Set("service", claims.Subject)
+		c.Next()
+	}
+}
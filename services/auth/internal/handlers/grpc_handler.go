@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/middleware"
+	"github.com/grigta/conveer/pkg/models"
+	"github.com/grigta/conveer/pkg/serviceauth"
+	"github.com/grigta/conveer/services/auth/internal/service"
+	pb "github.com/grigta/conveer/services/auth/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type GRPCHandler struct {
+	pb.UnimplementedAuthServiceServer
+	authService *service.AuthService
+	auth        *middleware.AuthMiddleware
+}
+
+func NewGRPCHandler(authService *service.AuthService, auth *middleware.AuthMiddleware) *GRPCHandler {
+	return &GRPCHandler{authService: authService, auth: auth}
+}
+
+func (h *GRPCHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	result, err := h.authService.Login(ctx, &models.LoginRequest{
+		Email:    req.Email,
+		Password: req.Password,
+		OTPCode:  req.OtpCode,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.LoginResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		ExpiresIn:    int32(result.ExpiresIn),
+		TokenType:    result.TokenType,
+		UserId:       result.User.ID.Hex(),
+		Role:         result.User.Role,
+	}, nil
+}
+
+func (h *GRPCHandler) EnrollTwoFactor(ctx context.Context, req *pb.EnrollTwoFactorRequest) (*pb.EnrollTwoFactorResponse, error) {
+	userID, err := h.twoFactorUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := h.authService.EnrollTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.EnrollTwoFactorResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningUri: enrollment.ProvisioningURI,
+	}, nil
+}
+
+func (h *GRPCHandler) ConfirmTwoFactor(ctx context.Context, req *pb.TwoFactorCodeRequest) (*pb.RecoveryCodesResponse, error) {
+	userID, err := h.twoFactorUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := h.authService.ConfirmTwoFactor(ctx, userID, req.Code)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (h *GRPCHandler) VerifyTwoFactor(ctx context.Context, req *pb.TwoFactorCodeRequest) (*pb.VerifyTwoFactorResponse, error) {
+	userID, err := h.twoFactorUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.authService.VerifyTwoFactor(ctx, userID, req.Code)
+	if errors.Is(err, models.ErrInvalidTwoFactorCode) {
+		return &pb.VerifyTwoFactorResponse{Valid: false}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.VerifyTwoFactorResponse{Valid: true}, nil
+}
+
+func (h *GRPCHandler) DisableTwoFactor(ctx context.Context, req *pb.TwoFactorCodeRequest) (*pb.DisableTwoFactorResponse, error) {
+	userID, err := h.twoFactorUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.authService.DisableTwoFactor(ctx, userID, req.Code); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.DisableTwoFactorResponse{Success: true}, nil
+}
+
+func (h *GRPCHandler) RegenerateRecoveryCodes(ctx context.Context, req *pb.TwoFactorCodeRequest) (*pb.RecoveryCodesResponse, error) {
+	userID, err := h.twoFactorUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := h.authService.RegenerateRecoveryCodes(ctx, userID, req.Code)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// twoFactorUser resolves whose second factor a call manages. A user token forwarded in the
+// authorization metadata limits the call to that user. Without one the user ID of the request
+// is taken only from a service that presented a valid service token.
+func (h *GRPCHandler) twoFactorUser(ctx context.Context, requested string) (string, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && h.auth != nil {
+		for _, value := range md.Get("authorization") {
+			claims, err := h.auth.ValidateToken(strings.TrimSpace(strings.TrimPrefix(value, "Bearer ")))
+			if err != nil {
+				return "", status.Error(codes.Unauthenticated, "invalid user token")
+			}
+			userID, _ := claims["user_id"].(string)
+			if userID == "" {
+				return "", status.Error(codes.Unauthenticated, "user token has no user")
+			}
+			if requested != "" && requested != userID {
+				return "", status.Error(codes.PermissionDenied, "two-factor settings of another user")
+			}
+			return userID, nil
+		}
+	}
+
+	if _, ok := serviceauth.CallerFromContext(ctx); !ok {
+		return "", status.Error(codes.Unauthenticated, "user or service token required")
+	}
+	if requested == "" {
+		return "", status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	return requested, nil
+}
+
+func (h *GRPCHandler) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	filter := &models.AuthAuditFilter{
+		UserID:    req.UserId,
+		Email:     req.Email,
+		Type:      req.Type,
+		IPAddress: req.IpAddress,
+		Page:      int(req.Page),
+		Limit:     int(req.Limit),
+	}
+	if req.From > 0 {
+		filter.From = time.Unix(req.From, 0)
+	}
+	if req.To > 0 {
+		filter.To = time.Unix(req.To, 0)
+	}
+
+	page, err := h.authService.ListAuthAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListAuditEventsResponse{
+		Events: make([]*pb.AuditEvent, 0, len(page.Events)),
+		Total:  page.Total,
+		Page:   int32(page.Page),
+		Limit:  int32(page.Limit),
+	}
+	for _, event := range page.Events {
+		pbEvent := &pb.AuditEvent{
+			Id:        event.ID.Hex(),
+			Type:      event.Type,
+			Email:     event.Email,
+			Success:   event.Success,
+			Reason:    event.Reason,
+			IpAddress: event.IPAddress,
+			UserAgent: event.UserAgent,
+			CreatedAt: event.CreatedAt.Unix(),
+		}
+		if !event.UserID.IsZero() {
+			pbEvent.UserId = event.UserID.Hex()
+		}
+		resp.Events = append(resp.Events, pbEvent)
+	}
+
+	return resp, nil
+}
+
+// ClientInfoInterceptor passes the caller's address and user agent to the service for auditing.
+// Gateways forwarding requests are expected to set x-forwarded-for.
+func ClientInfoInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var client service.ClientInfo
+
+	if p, ok := peer.FromContext(ctx); ok {
+		client.IPAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(client.IPAddress); err == nil {
+			client.IPAddress = host
+		}
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+			client.IPAddress = strings.TrimSpace(strings.Split(forwarded[0], ",")[0])
+		}
+		if userAgent := md.Get("user-agent"); len(userAgent) > 0 {
+			client.UserAgent = userAgent[0]
+		}
+	}
+
+	return handler(service.WithClientInfo(ctx, client), req)
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrTwoFactorRequired), errors.Is(err, models.ErrInvalidTwoFactorCode):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrTwoFactorNotEnrolled), errors.Is(err, models.ErrTwoFactorAlreadyEnabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidCredentials), errors.Is(err, service.ErrAccountDisabled):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrAccountLocked):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, models.ErrInvalidAuditEventType), errors.Is(err, models.ErrInvalidAuditFilter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	logger.Error("Auth request failed", logger.Field{Key: "error", Value: err.Error()})
+	return status.Error(codes.Internal, err.Error())
+}
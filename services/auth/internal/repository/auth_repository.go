@@ -11,6 +11,8 @@ import (
 	"github.com/grigta/conveer/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type AuthRepository struct {
@@ -53,6 +55,22 @@ func (r *AuthRepository) FindUserByID(ctx context.Context, id string) (*models.U
 	return &user, nil
 }
 
+// FindUserCredentials reads the user bypassing the cache, which does not keep
+// password and two-factor fields
+func (r *AuthRepository) FindUserCredentials(ctx context.Context, id string) (*models.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	filter := bson.M{"_id": objectID}
+	if err := r.db.FindOne(ctx, "users", filter, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
 	filter := bson.M{"email": email}
@@ -211,6 +229,255 @@ func (r *AuthRepository) UpdateSession(ctx context.Context, session *models.Sess
 	return err
 }
 
+// RotateSession stores the session's new tokens. The update only applies while the session still
+// holds oldRefreshToken, so when two requests race with the same token only one of them rotates it.
+func (r *AuthRepository) RotateSession(ctx context.Context, session *models.Session, oldToken, oldRefreshToken string) (bool, error) {
+	filter := bson.M{"_id": session.ID, "refresh_token": oldRefreshToken}
+	update := bson.M{
+		"$set": bson.M{
+			"token":         session.Token,
+			"refresh_token": session.RefreshToken,
+			"family_id":     session.FamilyID,
+			"expires_at":    session.ExpiresAt,
+		},
+	}
+
+	result, err := r.db.UpdateOne(ctx, "sessions", filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	// The exchanged tokens must stop resolving from the cache right away
+	r.cache.Delete(ctx, fmt.Sprintf("session:token:%s", oldToken))
+	r.cache.Delete(ctx, fmt.Sprintf("session:refresh:%s", oldRefreshToken))
+
+	return result.MatchedCount > 0, nil
+}
+
+// RevokeSessionFamily deletes every session of the family and returns how many were removed
+func (r *AuthRepository) RevokeSessionFamily(ctx context.Context, familyID primitive.ObjectID) (int64, error) {
+	filter := bson.M{"$or": []bson.M{{"family_id": familyID}, {"_id": familyID}}}
+
+	cursor, err := r.db.Find(ctx, "sessions", filter)
+	if err != nil {
+		return 0, err
+	}
+	var sessions []models.Session
+	err = cursor.All(ctx, &sessions)
+	cursor.Close(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.DeleteMany(ctx, "sessions", filter)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, session := range sessions {
+		r.cache.Delete(ctx, fmt.Sprintf("session:token:%s", session.Token))
+		r.cache.Delete(ctx, fmt.Sprintf("session:refresh:%s", session.RefreshToken))
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (r *AuthRepository) CreateRotatedRefreshToken(ctx context.Context, rotated *models.RotatedRefreshToken) error {
+	_, err := r.db.InsertOne(ctx, "rotated_refresh_tokens", rotated)
+	return err
+}
+
+func (r *AuthRepository) FindRotatedRefreshToken(ctx context.Context, tokenHash string) (*models.RotatedRefreshToken, error) {
+	var rotated models.RotatedRefreshToken
+	filter := bson.M{"token_hash": tokenHash, "expires_at": bson.M{"$gt": time.Now()}}
+	if err := r.db.FindOne(ctx, "rotated_refresh_tokens", filter, &rotated); err != nil {
+		return nil, err
+	}
+	return &rotated, nil
+}
+
+func (r *AuthRepository) CreateAuthorizationCode(ctx context.Context, code *models.AuthorizationCode) error {
+	_, err := r.db.InsertOne(ctx, "oidc_authorization_codes", code)
+	return err
+}
+
+// ConsumeAuthorizationCode removes an unexpired code and returns it, so a code can be exchanged only once
+func (r *AuthRepository) ConsumeAuthorizationCode(ctx context.Context, codeHash string) (*models.AuthorizationCode, error) {
+	var code models.AuthorizationCode
+	filter := bson.M{"code_hash": codeHash, "expires_at": bson.M{"$gt": time.Now()}}
+	if err := r.db.GetCollection("oidc_authorization_codes").FindOneAndDelete(ctx, filter).Decode(&code); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *AuthRepository) CreateSecurityEvent(ctx context.Context, event *models.SecurityEvent) error {
+	_, err := r.db.InsertOne(ctx, "security_events", event)
+	return err
+}
+
+// LockUser refuses logins until the given time
+func (r *AuthRepository) LockUser(ctx context.Context, id string, until time.Time) error {
+	return r.updateUser(ctx, id, bson.M{
+		"$set": bson.M{
+			"locked_until": until,
+			"updated_at":   time.Now(),
+		},
+	})
+}
+
+func (r *AuthRepository) CreateAuthAuditEvent(ctx context.Context, event *models.AuthAuditEvent) error {
+	_, err := r.db.InsertOne(ctx, "auth_audit_events", event)
+	return err
+}
+
+// CountAuthAuditEvents counts the user's events of a type recorded after since
+func (r *AuthRepository) CountAuthAuditEvents(ctx context.Context, userID primitive.ObjectID, eventType string, since time.Time) (int64, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"type":       eventType,
+		"created_at": bson.M{"$gt": since},
+	}
+	return r.db.CountDocuments(ctx, "auth_audit_events", filter)
+}
+
+// FindAuthAuditEvents returns a page of events, newest first, and the total matching the filter
+func (r *AuthRepository) FindAuthAuditEvents(ctx context.Context, filter *models.AuthAuditFilter) ([]*models.AuthAuditEvent, int64, error) {
+	query := authAuditQuery(filter)
+
+	total, err := r.db.CountDocuments(ctx, "auth_audit_events", query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((filter.Page - 1) * filter.Limit)).
+		SetLimit(int64(filter.Limit))
+
+	cursor, err := r.db.Find(ctx, "auth_audit_events", query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	events := make([]*models.AuthAuditEvent, 0)
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// StreamAuthAuditEvents calls fn for every event matching the filter, oldest first, ignoring paging
+func (r *AuthRepository) StreamAuthAuditEvents(ctx context.Context, filter *models.AuthAuditFilter, fn func(*models.AuthAuditEvent) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.db.Find(ctx, "auth_audit_events", authAuditQuery(filter), opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var event models.AuthAuditEvent
+		if err := cursor.Decode(&event); err != nil {
+			return err
+		}
+		if err := fn(&event); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func authAuditQuery(filter *models.AuthAuditFilter) bson.M {
+	query := bson.M{}
+	if filter.UserID != "" {
+		if objectID, err := primitive.ObjectIDFromHex(filter.UserID); err == nil {
+			query["user_id"] = objectID
+		}
+	}
+	if filter.Email != "" {
+		query["email"] = filter.Email
+	}
+	if filter.Type != "" {
+		query["type"] = filter.Type
+	}
+	if filter.IPAddress != "" {
+		query["ip_address"] = filter.IPAddress
+	}
+	if filter.Success != nil {
+		query["success"] = *filter.Success
+	}
+
+	createdAt := bson.M{}
+	if !filter.From.IsZero() {
+		createdAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		createdAt["$lte"] = filter.To
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	return query
+}
+
+func (r *AuthRepository) CreateIndexes(auditRetention time.Duration) error {
+	if err := r.db.CreateIndexes("rotated_refresh_tokens", []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := r.db.CreateIndexes("oidc_authorization_codes", []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := r.db.CreateIndexes("sessions", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "family_id", Value: 1}}},
+	}); err != nil {
+		return err
+	}
+
+	if err := r.db.CreateIndexes("security_events", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}); err != nil {
+		return err
+	}
+
+	auditIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "type", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "email", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "ip_address", Value: 1}, {Key: "created_at", Value: -1}}},
+	}
+	createdAt := mongo.IndexModel{Keys: bson.D{{Key: "created_at", Value: -1}}}
+	if auditRetention > 0 {
+		// Without a retention the audit log is kept forever
+		createdAt.Options = options.Index().SetExpireAfterSeconds(int32(auditRetention.Seconds()))
+	}
+	auditIndexes = append(auditIndexes, createdAt)
+
+	return r.db.CreateIndexes("auth_audit_events", auditIndexes)
+}
+
 func (r *AuthRepository) DeleteSession(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -312,3 +579,209 @@ func (r *AuthRepository) UpdateEmailVerification(ctx context.Context, verificati
 	_, err := r.db.UpdateOne(ctx, "email_verifications", filter, update)
 	return err
 }
+
+func (r *AuthRepository) FindActiveUsers(ctx context.Context) ([]*models.User, error) {
+	cursor, err := r.db.Find(ctx, "users", bson.M{"is_active": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *AuthRepository) FindNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	cacheKey := fmt.Sprintf("notification_prefs:%s", userID)
+
+	var prefs models.NotificationPreferences
+	if err := r.cache.GetJSON(ctx, cacheKey, &prefs); err == nil {
+		return &prefs, nil
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"user_id": objectID}
+	if err := r.db.FindOne(ctx, "notification_preferences", filter, &prefs); err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(ctx, cacheKey, prefs, 5*time.Minute)
+
+	return &prefs, nil
+}
+
+func (r *AuthRepository) FindAllNotificationPreferences(ctx context.Context) ([]*models.NotificationPreferences, error) {
+	cursor, err := r.db.Find(ctx, "notification_preferences", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var prefs []*models.NotificationPreferences
+	if err := cursor.All(ctx, &prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+func (r *AuthRepository) UpsertNotificationPreferences(ctx context.Context, prefs *models.NotificationPreferences) error {
+	filter := bson.M{"user_id": prefs.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"enabled":              prefs.Enabled,
+			"channels":             prefs.Channels,
+			"alert_severities":     prefs.AlertSeverities,
+			"digests":              prefs.Digests,
+			"intervention_notices": prefs.InterventionNotices,
+			"platforms":            prefs.Platforms,
+			"updated_at":           prefs.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": prefs.CreatedAt,
+		},
+	}
+
+	_, err := r.db.UpdateOne(ctx, "notification_preferences", filter, update, options.Update().SetUpsert(true))
+
+	if err == nil {
+		r.cache.Delete(ctx, fmt.Sprintf("notification_prefs:%s", prefs.UserID.Hex()))
+	}
+
+	return err
+}
+
+func (r *AuthRepository) DeleteNotificationPreferences(ctx context.Context, userID string) error {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"user_id": objectID}
+	_, err = r.db.DeleteOne(ctx, "notification_preferences", filter)
+
+	if err == nil {
+		r.cache.Delete(ctx, fmt.Sprintf("notification_prefs:%s", userID))
+	}
+
+	return err
+}
+
+func (r *AuthRepository) SetTwoFactorSecret(ctx context.Context, id, encryptedSecret string) error {
+	return r.updateUser(ctx, id, bson.M{
+		"$set": bson.M{
+			"two_factor_secret":  encryptedSecret,
+			"two_factor_enabled": false,
+			"updated_at":         time.Now(),
+		},
+		"$unset": bson.M{
+			"two_factor_last_step": "",
+			"recovery_codes":       "",
+		},
+	})
+}
+
+func (r *AuthRepository) EnableTwoFactor(ctx context.Context, id string, recoveryCodeHashes []string, step int64) error {
+	return r.updateUser(ctx, id, bson.M{
+		"$set": bson.M{
+			"two_factor_enabled":   true,
+			"two_factor_last_step": step,
+			"recovery_codes":       recoveryCodeHashes,
+			"updated_at":           time.Now(),
+		},
+	})
+}
+
+func (r *AuthRepository) DisableTwoFactor(ctx context.Context, id string) error {
+	return r.updateUser(ctx, id, bson.M{
+		"$set": bson.M{
+			"two_factor_enabled": false,
+			"updated_at":         time.Now(),
+		},
+		"$unset": bson.M{
+			"two_factor_secret":    "",
+			"two_factor_last_step": "",
+			"recovery_codes":       "",
+		},
+	})
+}
+
+func (r *AuthRepository) UpdateRecoveryCodes(ctx context.Context, id string, recoveryCodeHashes []string) error {
+	return r.updateUser(ctx, id, bson.M{
+		"$set": bson.M{
+			"recovery_codes": recoveryCodeHashes,
+			"updated_at":     time.Now(),
+		},
+	})
+}
+
+// AdvanceTwoFactorStep records the last accepted TOTP step. It only succeeds for a step
+// newer than the stored one, so a code cannot be replayed within its validity window.
+func (r *AuthRepository) AdvanceTwoFactorStep(ctx context.Context, id string, step int64) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id": objectID,
+		"$or": []bson.M{
+			{"two_factor_last_step": bson.M{"$exists": false}},
+			{"two_factor_last_step": bson.M{"$lt": step}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"two_factor_last_step": step}}
+
+	result, err := r.db.UpdateOne(ctx, "users", filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	r.cache.Delete(ctx, fmt.Sprintf("user:%s", id))
+
+	return result.ModifiedCount > 0, nil
+}
+
+// ConsumeRecoveryCode removes a recovery code hash, reporting whether it was present
+func (r *AuthRepository) ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": objectID, "recovery_codes": codeHash}
+	update := bson.M{
+		"$pull": bson.M{"recovery_codes": codeHash},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.db.UpdateOne(ctx, "users", filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	r.cache.Delete(ctx, fmt.Sprintf("user:%s", id))
+
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *AuthRepository) updateUser(ctx context.Context, id string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.UpdateOne(ctx, "users", bson.M{"_id": objectID}, update)
+
+	if err == nil {
+		r.cache.Delete(ctx, fmt.Sprintf("user:%s", id))
+	}
+
+	return err
+}
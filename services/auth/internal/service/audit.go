@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ClientInfo identifies where an authentication request came from
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo attaches the caller's address and user agent to ctx so audit events can record them
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+func clientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+// ListAuthAuditEvents returns a page of the authentication audit log, newest first
+func (s *AuthService) ListAuthAuditEvents(ctx context.Context, filter *models.AuthAuditFilter) (*models.AuthAuditPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	events, total, err := s.repo.FindAuthAuditEvents(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to query audit events", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to query audit events")
+	}
+
+	return &models.AuthAuditPage{
+		Events: events,
+		Total:  total,
+		Page:   filter.Page,
+		Limit:  filter.Limit,
+	}, nil
+}
+
+// ExportAuthAuditEvents streams every event matching the filter to fn, oldest first
+func (s *AuthService) ExportAuthAuditEvents(ctx context.Context, filter *models.AuthAuditFilter, fn func(*models.AuthAuditEvent) error) error {
+	if err := filter.Normalize(); err != nil {
+		return err
+	}
+
+	return s.repo.StreamAuthAuditEvents(ctx, filter, fn)
+}
+
+// recordAuthEvent stores an audit event with the caller's client info. Failures are only
+// logged, auditing must not break authentication.
+func (s *AuthService) recordAuthEvent(ctx context.Context, event *models.AuthAuditEvent) {
+	client := clientInfoFromContext(ctx)
+
+	event.ID = primitive.NewObjectID()
+	event.IPAddress = client.IPAddress
+	event.UserAgent = client.UserAgent
+	event.CreatedAt = time.Now()
+
+	if err := s.repo.CreateAuthAuditEvent(ctx, event); err != nil {
+		logger.Error("Failed to save audit event",
+			logger.Field{Key: "type", Value: event.Type},
+			logger.Field{Key: "error", Value: err.Error()},
+		)
+	}
+}
+
+func userAuthEvent(eventType string, user *models.User, success bool, reason string) *models.AuthAuditEvent {
+	return &models.AuthAuditEvent{
+		Type:    eventType,
+		UserID:  user.ID,
+		Email:   user.Email,
+		Success: success,
+		Reason:  reason,
+	}
+}
+
+// recordLoginFailure audits a failed login and locks the account once the failures since the
+// last successful login, lock or window start reach the threshold
+func (s *AuthService) recordLoginFailure(ctx context.Context, user *models.User, reason string) {
+	s.recordAuthEvent(ctx, userAuthEvent(models.AuthEventLoginFailed, user, false, reason))
+
+	policy := s.config.Auth
+	if policy.LockoutThreshold <= 0 {
+		return
+	}
+
+	now := time.Now()
+	since := now.Add(-policy.LockoutWindow)
+	if user.LockedUntil != nil && user.LockedUntil.After(since) {
+		since = *user.LockedUntil
+	}
+	if user.LastLoginAt != nil && user.LastLoginAt.After(since) {
+		since = *user.LastLoginAt
+	}
+
+	failures, err := s.repo.CountAuthAuditEvents(ctx, user.ID, models.AuthEventLoginFailed, since)
+	if err != nil {
+		logger.Error("Failed to count failed logins", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+	if failures < int64(policy.LockoutThreshold) {
+		return
+	}
+
+	s.lockAccount(ctx, user, failures, now.Add(policy.LockoutDuration))
+}
+
+func (s *AuthService) lockAccount(ctx context.Context, user *models.User, failures int64, until time.Time) {
+	if err := s.repo.LockUser(ctx, user.ID.Hex(), until); err != nil {
+		logger.Error("Failed to lock account", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+
+	client := clientInfoFromContext(ctx)
+
+	logger.Warn("Account locked after failed logins",
+		logger.Field{Key: "user_id", Value: user.ID.Hex()},
+		logger.Field{Key: "failed_attempts", Value: failures},
+		logger.Field{Key: "ip_address", Value: client.IPAddress},
+		logger.Field{Key: "locked_until", Value: until},
+	)
+
+	locked := userAuthEvent(models.AuthEventAccountLocked, user, true, "")
+	locked.Details = map[string]interface{}{
+		"failed_attempts": failures,
+		"locked_until":    until,
+	}
+	s.recordAuthEvent(ctx, locked)
+
+	event := &models.SecurityEvent{
+		ID:     primitive.NewObjectID(),
+		UserID: user.ID,
+		Type:   models.SecurityEventAccountLocked,
+		Details: map[string]interface{}{
+			"failed_attempts": failures,
+			"locked_until":    until,
+			"ip_address":      client.IPAddress,
+		},
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.CreateSecurityEvent(ctx, event); err != nil {
+		logger.Error("Failed to save security event", logger.Field{Key: "error", Value: err.Error()})
+	}
+
+	if err := s.rabbitmq.PublishEvent("auth.account_locked", map[string]interface{}{
+		"user_id":         user.ID.Hex(),
+		"email":           user.Email,
+		"failed_attempts": failures,
+		"locked_until":    until,
+		"ip_address":      client.IPAddress,
+		"user_agent":      client.UserAgent,
+	}); err != nil {
+		logger.Error("Failed to publish account locked event", logger.Field{Key: "error", Value: err.Error()})
+	}
+
+	if err := s.sendSecurityAlertEmail(user, event); err != nil {
+		logger.Error("Failed to send security alert email", logger.Field{Key: "error", Value: err.Error()})
+	}
+	if err := s.sendSecurityAlertToBot(user, event); err != nil {
+		logger.Error("Failed to send security alert to bot", logger.Field{Key: "error", Value: err.Error()})
+	}
+}
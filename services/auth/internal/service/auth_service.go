@@ -17,19 +17,27 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrAccountDisabled     = errors.New("account is disabled")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)
+
 type AuthService struct {
 	repo         *repository.AuthRepository
 	config       *config.Config
 	rabbitmq     *messaging.RabbitMQ
 	authMiddleware *middleware.AuthMiddleware
+	encryptor    *crypto.Encryptor
 }
 
-func NewAuthService(repo *repository.AuthRepository, cfg *config.Config, mq *messaging.RabbitMQ) *AuthService {
+func NewAuthService(repo *repository.AuthRepository, cfg *config.Config, mq *messaging.RabbitMQ, encryptor *crypto.Encryptor) *AuthService {
 	return &AuthService{
 		repo:           repo,
 		config:         cfg,
 		rabbitmq:       mq,
 		authMiddleware: middleware.NewAuthMiddleware(cfg.JWT.Secret),
+		encryptor:      encryptor,
 	}
 }
 
@@ -86,6 +94,7 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 		ExpiresAt:    time.Now().Add(24 * time.Hour),
 		CreatedAt:    time.Now(),
 	}
+	session.FamilyID = session.ID
 
 	if err := s.repo.CreateSession(ctx, session); err != nil {
 		logger.Error("Failed to create session", logger.Field{Key: "error", Value: err.Error()})
@@ -123,15 +132,40 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.TokenResponse, error) {
 	user, err := s.repo.FindUserByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		s.recordAuthEvent(ctx, &models.AuthAuditEvent{
+			Type:   models.AuthEventLoginFailed,
+			Email:  req.Email,
+			Reason: models.AuthReasonUnknownEmail,
+		})
+		return nil, ErrInvalidCredentials
+	}
+
+	// Checked before the password so a locked account can't keep being guessed
+	if user.IsLocked(time.Now()) {
+		s.recordAuthEvent(ctx, userAuthEvent(models.AuthEventLoginBlocked, user, false, models.AuthReasonAccountLocked))
+		return nil, models.ErrAccountLocked
 	}
 
 	if !crypto.CheckPassword(req.Password, user.Password) {
-		return nil, errors.New("invalid credentials")
+		s.recordLoginFailure(ctx, user, models.AuthReasonInvalidPassword)
+		return nil, ErrInvalidCredentials
 	}
 
 	if !user.IsActive {
-		return nil, errors.New("account is disabled")
+		s.recordAuthEvent(ctx, userAuthEvent(models.AuthEventLoginFailed, user, false, models.AuthReasonAccountDisabled))
+		return nil, ErrAccountDisabled
+	}
+
+	if user.TwoFactorEnabled {
+		if req.OTPCode == "" {
+			return nil, models.ErrTwoFactorRequired
+		}
+		if err := s.verifySecondFactor(ctx, user, req.OTPCode); err != nil {
+			if errors.Is(err, models.ErrInvalidTwoFactorCode) {
+				s.recordLoginFailure(ctx, user, models.AuthReasonInvalidTwoFactorCode)
+			}
+			return nil, err
+		}
 	}
 
 	token, err := s.authMiddleware.GenerateToken(user.ID.Hex(), user.Email, user.Role)
@@ -141,20 +175,26 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	}
 
 	refreshToken := uuid.New().String()
+	client := clientInfoFromContext(ctx)
 
 	session := &models.Session{
 		ID:           primitive.NewObjectID(),
 		UserID:       user.ID,
 		Token:        token,
 		RefreshToken: refreshToken,
+		UserAgent:    client.UserAgent,
+		IPAddress:    client.IPAddress,
 		ExpiresAt:    time.Now().Add(24 * time.Hour),
 		CreatedAt:    time.Now(),
 	}
+	session.FamilyID = session.ID
 
 	if err := s.repo.CreateSession(ctx, session); err != nil {
 		logger.Error("Failed to create session", logger.Field{Key: "error", Value: err.Error()})
 	}
 
+	s.recordAuthEvent(ctx, userAuthEvent(models.AuthEventLoginSucceeded, user, true, ""))
+
 	now := time.Now()
 	user.LastLoginAt = &now
 	if err := s.repo.UpdateUserLastLogin(ctx, user.ID.Hex()); err != nil {
@@ -179,11 +219,28 @@ func (s *AuthService) Logout(ctx context.Context, token string) error {
 func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.TokenResponse, error) {
 	session, err := s.repo.FindSessionByRefreshToken(ctx, refreshToken)
 	if err != nil {
-		return nil, errors.New("invalid refresh token")
+		if userID, reused := s.detectRefreshTokenReuse(ctx, refreshToken); reused {
+			s.recordAuthEvent(ctx, &models.AuthAuditEvent{
+				Type:   models.AuthEventTokenRefreshFailed,
+				UserID: userID,
+				Reason: models.AuthReasonTokenReused,
+			})
+			return nil, models.ErrRefreshTokenReused
+		}
+		s.recordAuthEvent(ctx, &models.AuthAuditEvent{
+			Type:   models.AuthEventTokenRefreshFailed,
+			Reason: models.AuthReasonInvalidToken,
+		})
+		return nil, ErrInvalidRefreshToken
 	}
 
 	if time.Now().After(session.ExpiresAt) {
 		s.repo.DeleteSession(ctx, session.ID.Hex())
+		s.recordAuthEvent(ctx, &models.AuthAuditEvent{
+			Type:   models.AuthEventTokenRefreshFailed,
+			UserID: session.UserID,
+			Reason: models.AuthReasonTokenExpired,
+		})
 		return nil, errors.New("refresh token expired")
 	}
 
@@ -198,15 +255,27 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*m
 	}
 
 	newRefreshToken := uuid.New().String()
+	oldToken := session.Token
 
+	session.FamilyID = session.Family()
 	session.Token = newToken
 	session.RefreshToken = newRefreshToken
 	session.ExpiresAt = time.Now().Add(24 * time.Hour)
 
-	if err := s.repo.UpdateSession(ctx, session); err != nil {
+	rotated, err := s.repo.RotateSession(ctx, session, oldToken, refreshToken)
+	if err != nil {
 		logger.Error("Failed to update session", logger.Field{Key: "error", Value: err.Error()})
 		return nil, errors.New("failed to update session")
 	}
+	if !rotated {
+		// Another request exchanged the same token first, so it was presented twice
+		s.revokeSessionFamily(ctx, session.UserID, session.FamilyID, "concurrent_refresh")
+		s.recordAuthEvent(ctx, userAuthEvent(models.AuthEventTokenRefreshFailed, user, false, models.AuthReasonTokenReused))
+		return nil, models.ErrRefreshTokenReused
+	}
+
+	s.rememberRotatedRefreshToken(ctx, session, refreshToken)
+	s.recordAuthEvent(ctx, userAuthEvent(models.AuthEventTokenRefreshed, user, true, ""))
 
 	user.Password = ""
 
@@ -248,6 +317,11 @@ func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
 func (s *AuthService) ForgotPassword(ctx context.Context, email string) error {
 	user, err := s.repo.FindUserByEmail(ctx, email)
 	if err != nil {
+		s.recordAuthEvent(ctx, &models.AuthAuditEvent{
+			Type:   models.AuthEventPasswordResetRequested,
+			Email:  email,
+			Reason: models.AuthReasonUnknownEmail,
+		})
 		return nil
 	}
 
@@ -271,20 +345,28 @@ func (s *AuthService) ForgotPassword(ctx context.Context, email string) error {
 		logger.Error("Failed to send password reset email", logger.Field{Key: "error", Value: err.Error()})
 	}
 
+	s.recordAuthEvent(ctx, userAuthEvent(models.AuthEventPasswordResetRequested, user, true, ""))
+
 	return nil
 }
 
 func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
 	passwordReset, err := s.repo.FindPasswordResetByToken(ctx, token)
 	if err != nil {
+		s.recordAuthEvent(ctx, &models.AuthAuditEvent{
+			Type:   models.AuthEventPasswordResetFailed,
+			Reason: models.AuthReasonInvalidToken,
+		})
 		return errors.New("invalid reset token")
 	}
 
 	if passwordReset.Used {
+		s.recordPasswordResetFailure(ctx, passwordReset, models.AuthReasonTokenUsed)
 		return errors.New("reset token already used")
 	}
 
 	if time.Now().After(passwordReset.ExpiresAt) {
+		s.recordPasswordResetFailure(ctx, passwordReset, models.AuthReasonTokenExpired)
 		return errors.New("reset token expired")
 	}
 
@@ -306,9 +388,23 @@ func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword stri
 		logger.Error("Failed to delete user sessions", logger.Field{Key: "error", Value: err.Error()})
 	}
 
+	s.recordAuthEvent(ctx, &models.AuthAuditEvent{
+		Type:    models.AuthEventPasswordReset,
+		UserID:  passwordReset.UserID,
+		Success: true,
+	})
+
 	return nil
 }
 
+func (s *AuthService) recordPasswordResetFailure(ctx context.Context, passwordReset *models.PasswordReset, reason string) {
+	s.recordAuthEvent(ctx, &models.AuthAuditEvent{
+		Type:   models.AuthEventPasswordResetFailed,
+		UserID: passwordReset.UserID,
+		Reason: reason,
+	})
+}
+
 func (s *AuthService) sendWelcomeEmail(user *models.User, verificationToken string) error {
 	message := map[string]interface{}{
 		"type":      "welcome_email",
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/models"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (s *AuthService) GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	user, err := s.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, models.ErrUserNotFound
+	}
+
+	prefs, err := s.repo.FindNotificationPreferences(ctx, userID)
+	if err == mongo.ErrNoDocuments {
+		return models.DefaultNotificationPreferences(user), nil
+	}
+	if err != nil {
+		logger.Error("Failed to load notification preferences", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to load notification preferences")
+	}
+
+	return prefs, nil
+}
+
+func (s *AuthService) UpdateNotificationPreferences(ctx context.Context, userID string, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	prefs, err := s.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Enabled != nil {
+		prefs.Enabled = *req.Enabled
+	}
+	if req.Channels != nil {
+		prefs.Channels = req.Channels
+	}
+	if req.AlertSeverities != nil {
+		prefs.AlertSeverities = req.AlertSeverities
+	}
+	if req.Digests != nil {
+		prefs.Digests = req.Digests
+	}
+	if req.InterventionNotices != nil {
+		prefs.InterventionNotices = *req.InterventionNotices
+	}
+	if req.Platforms != nil {
+		prefs.Platforms = req.Platforms
+	}
+
+	if err := prefs.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if prefs.IsDefault {
+		prefs.CreatedAt = now
+		prefs.IsDefault = false
+	}
+	prefs.UpdatedAt = now
+
+	if err := s.repo.UpsertNotificationPreferences(ctx, prefs); err != nil {
+		logger.Error("Failed to save notification preferences", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to save notification preferences")
+	}
+
+	return prefs, nil
+}
+
+// ResetNotificationPreferences drops stored preferences so the role defaults apply again
+func (s *AuthService) ResetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	user, err := s.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, models.ErrUserNotFound
+	}
+
+	if err := s.repo.DeleteNotificationPreferences(ctx, userID); err != nil {
+		logger.Error("Failed to delete notification preferences", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to reset notification preferences")
+	}
+
+	return models.DefaultNotificationPreferences(user), nil
+}
+
+// ListNotificationRecipients resolves who should receive a notification through the given channel
+func (s *AuthService) ListNotificationRecipients(ctx context.Context, category models.NotificationCategory, value, platform string, channel models.NotificationChannel) ([]models.NotificationRecipient, error) {
+	if !models.ValidNotificationCategory(category) {
+		return nil, models.ErrInvalidCategory
+	}
+
+	users, err := s.repo.FindActiveUsers(ctx)
+	if err != nil {
+		logger.Error("Failed to load users", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to load users")
+	}
+
+	stored, err := s.repo.FindAllNotificationPreferences(ctx)
+	if err != nil {
+		logger.Error("Failed to load notification preferences", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to load notification preferences")
+	}
+
+	byUser := make(map[string]*models.NotificationPreferences, len(stored))
+	for _, prefs := range stored {
+		byUser[prefs.UserID.Hex()] = prefs
+	}
+
+	recipients := make([]models.NotificationRecipient, 0)
+	for _, user := range users {
+		prefs, ok := byUser[user.ID.Hex()]
+		if !ok {
+			prefs = models.DefaultNotificationPreferences(user)
+		}
+
+		if !prefs.Wants(category, value, platform, channel) {
+			continue
+		}
+
+		recipient := models.NotificationRecipient{
+			UserID:  user.ID.Hex(),
+			Channel: channel,
+		}
+		switch channel {
+		case models.ChannelTelegram:
+			if user.TelegramID == 0 {
+				continue
+			}
+			recipient.TelegramID = user.TelegramID
+		case models.ChannelEmail:
+			if user.Email == "" {
+				continue
+			}
+			recipient.Email = user.Email
+		}
+
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, nil
+}
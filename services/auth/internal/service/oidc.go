@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/grigta/conveer/pkg/config"
+	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	oidcCodeSize         = 32 // Random bytes per authorization code
+	oidcGeneratedKeyBits = 2048
+)
+
+// DiscoveryDocument is served at /.well-known/openid-configuration
+type DiscoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// JSONWebKey is the public part of the signing key (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// OIDCProvider lets internal dashboards and the api-gateway sign users in with the
+// authorization code flow. Every request must use PKCE with S256, confidential clients
+// authenticate at the token endpoint on top of it.
+type OIDCProvider struct {
+	auth    *AuthService
+	config  config.OIDCConfig
+	issuer  string
+	clients map[string]*models.OIDCClient
+	key     *rsa.PrivateKey
+	keyID   string
+}
+
+func NewOIDCProvider(auth *AuthService, cfg config.OIDCConfig) (*OIDCProvider, error) {
+	key, err := loadOIDCSigningKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID, err := oidcKeyID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make(map[string]*models.OIDCClient, len(cfg.Clients))
+	for _, client := range cfg.Clients {
+		if client.ID == "" || len(client.RedirectURIs) == 0 {
+			return nil, fmt.Errorf("OIDC client %q needs an id and at least one redirect URI", client.ID)
+		}
+		clients[client.ID] = &models.OIDCClient{
+			ID:           client.ID,
+			Name:         client.Name,
+			Secret:       os.ExpandEnv(client.Secret),
+			RedirectURIs: client.RedirectURIs,
+		}
+	}
+
+	return &OIDCProvider{
+		auth:    auth,
+		config:  cfg,
+		issuer:  strings.TrimSuffix(cfg.Issuer, "/"),
+		clients: clients,
+		key:     key,
+		keyID:   keyID,
+	}, nil
+}
+
+func (p *OIDCProvider) Discovery() *DiscoveryDocument {
+	return &DiscoveryDocument{
+		Issuer:                            p.issuer,
+		AuthorizationEndpoint:             p.issuer + "/oauth2/authorize",
+		TokenEndpoint:                     p.issuer + "/oauth2/token",
+		UserInfoEndpoint:                  p.issuer + "/oauth2/userinfo",
+		JWKSURI:                           p.issuer + "/.well-known/jwks.json",
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{jwt.SigningMethodRS256.Alg()},
+		ScopesSupported:                   models.SupportedScopes,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{crypto.PKCEMethodS256},
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce",
+			"email", "email_verified", "name", "preferred_username", "role",
+		},
+	}
+}
+
+func (p *OIDCProvider) JWKS() *JSONWebKeySet {
+	return &JSONWebKeySet{Keys: []JSONWebKey{{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: jwt.SigningMethodRS256.Alg(),
+		KeyID:     p.keyID,
+		Modulus:   base64.RawURLEncoding.EncodeToString(p.key.PublicKey.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.PublicKey.E)).Bytes()),
+	}}}
+}
+
+// ClientName is shown on the login form so users know which dashboard asks them to sign in
+func (p *OIDCProvider) ClientName(clientID string) string {
+	client, ok := p.clients[clientID]
+	if !ok {
+		return ""
+	}
+	if client.Name != "" {
+		return client.Name
+	}
+	return client.ID
+}
+
+// CheckRedirect validates the client and its redirect URI. Until both are known to be good
+// errors are shown to the user instead of being redirected.
+func (p *OIDCProvider) CheckRedirect(req *models.AuthorizationRequest) error {
+	client, ok := p.clients[req.ClientID]
+	if !ok {
+		return models.NewOAuthError(models.OAuthInvalidClient, "unknown client_id")
+	}
+	if !client.AllowsRedirectURI(req.RedirectURI) {
+		return models.NewOAuthError(models.OAuthInvalidRequest, "redirect_uri is not registered for the client")
+	}
+	return nil
+}
+
+// ValidateAuthorizationRequest checks the rest of the request, its errors are redirected back to the client
+func (p *OIDCProvider) ValidateAuthorizationRequest(req *models.AuthorizationRequest) error {
+	if req.ResponseType != "code" {
+		return models.NewOAuthError(models.OAuthUnsupportedResponseType, "only the authorization code flow is supported")
+	}
+
+	if !hasScope(req.Scopes(), models.ScopeOpenID) {
+		return models.NewOAuthError(models.OAuthInvalidScope, "the openid scope is required")
+	}
+
+	if req.CodeChallenge == "" {
+		return models.NewOAuthError(models.OAuthInvalidRequest, "code_challenge is required")
+	}
+	if req.CodeChallengeMethod != crypto.PKCEMethodS256 {
+		return models.NewOAuthError(models.OAuthInvalidRequest, "code_challenge_method must be S256")
+	}
+
+	return nil
+}
+
+// UserFromSession returns the user of a Conveer access token, so a signed-in user is not asked
+// for the password again
+func (p *OIDCProvider) UserFromSession(ctx context.Context, userID string) (*models.User, error) {
+	user, err := p.auth.repo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, models.ErrUserNotFound
+	}
+	if !user.IsActive || user.IsLocked(time.Now()) {
+		return nil, ErrAccountDisabled
+	}
+	return user, nil
+}
+
+// Authorize issues an authorization code for the signed-in user and returns the client
+// redirect carrying it
+func (p *OIDCProvider) Authorize(ctx context.Context, req *models.AuthorizationRequest, user *models.User) (string, error) {
+	raw, err := crypto.GenerateRandomBytes(oidcCodeSize)
+	if err != nil {
+		return "", err
+	}
+	code := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now()
+	authCode := &models.AuthorizationCode{
+		ID:            primitive.NewObjectID(),
+		CodeHash:      crypto.SHA256Hash(code),
+		ClientID:      req.ClientID,
+		UserID:        user.ID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         grantedScopes(req.Scopes()),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      now,
+		ExpiresAt:     now.Add(p.config.CodeTTL),
+	}
+
+	if err := p.auth.repo.CreateAuthorizationCode(ctx, authCode); err != nil {
+		logger.Error("Failed to save authorization code", logger.Field{Key: "error", Value: err.Error()})
+		return "", models.NewOAuthError(models.OAuthServerError, "")
+	}
+
+	params := url.Values{}
+	params.Set("code", code)
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return withQuery(req.RedirectURI, params), nil
+}
+
+// ErrorRedirect sends an authorization error back to a client whose redirect URI was validated
+func (p *OIDCProvider) ErrorRedirect(req *models.AuthorizationRequest, err error) string {
+	oauthErr := &models.OAuthError{}
+	if !errors.As(err, &oauthErr) {
+		oauthErr = models.NewOAuthError(models.OAuthServerError, "")
+	}
+
+	params := url.Values{}
+	params.Set("error", oauthErr.Code)
+	if oauthErr.Description != "" {
+		params.Set("error_description", oauthErr.Description)
+	}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return withQuery(req.RedirectURI, params)
+}
+
+// Exchange redeems an authorization code for an access token and an ID token. The client
+// credentials come from HTTP Basic when present, otherwise from the form.
+func (p *OIDCProvider) Exchange(ctx context.Context, req *models.OIDCTokenRequest) (*models.OIDCTokenResponse, error) {
+	if req.GrantType != "authorization_code" {
+		return nil, models.NewOAuthError(models.OAuthUnsupportedGrantType, "only authorization_code is supported")
+	}
+
+	client, ok := p.clients[req.ClientID]
+	if !ok {
+		return nil, models.NewOAuthError(models.OAuthInvalidClient, "unknown client")
+	}
+	if !client.Public() && subtle.ConstantTimeCompare([]byte(client.Secret), []byte(req.ClientSecret)) != 1 {
+		return nil, models.NewOAuthError(models.OAuthInvalidClient, "client authentication failed")
+	}
+
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, models.NewOAuthError(models.OAuthInvalidRequest, "code and code_verifier are required")
+	}
+
+	// The code is consumed before any other check, a failed exchange burns it
+	code, err := p.auth.repo.ConsumeAuthorizationCode(ctx, crypto.SHA256Hash(req.Code))
+	if err != nil {
+		return nil, models.NewOAuthError(models.OAuthInvalidGrant, "authorization code is invalid or expired")
+	}
+	if code.ClientID != client.ID || code.RedirectURI != req.RedirectURI {
+		return nil, models.NewOAuthError(models.OAuthInvalidGrant, "authorization code was issued to another client or redirect_uri")
+	}
+	if !crypto.VerifyPKCE(req.CodeVerifier, code.CodeChallenge) {
+		return nil, models.NewOAuthError(models.OAuthInvalidGrant, "code_verifier does not match the code_challenge")
+	}
+
+	user, err := p.UserFromSession(ctx, code.UserID.Hex())
+	if err != nil {
+		return nil, models.NewOAuthError(models.OAuthInvalidGrant, "user is no longer allowed to sign in")
+	}
+
+	now := time.Now()
+	accessToken, err := p.sign(p.accessTokenClaims(user, code, now))
+	if err != nil {
+		logger.Error("Failed to sign access token", logger.Field{Key: "error", Value: err.Error()})
+		return nil, models.NewOAuthError(models.OAuthServerError, "")
+	}
+	idToken, err := p.sign(p.idTokenClaims(user, code, now))
+	if err != nil {
+		logger.Error("Failed to sign ID token", logger.Field{Key: "error", Value: err.Error()})
+		return nil, models.NewOAuthError(models.OAuthServerError, "")
+	}
+
+	event := userAuthEvent(models.AuthEventOIDCTokenIssued, user, true, "")
+	event.Details = map[string]interface{}{"client_id": client.ID, "scope": code.Scope}
+	p.auth.recordAuthEvent(ctx, event)
+
+	return &models.OIDCTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(p.config.AccessTokenTTL.Seconds()),
+		IDToken:     idToken,
+		Scope:       code.Scope,
+	}, nil
+}
+
+// UserInfo returns the claims of the access token's user allowed by the granted scopes
+func (p *OIDCProvider) UserInfo(ctx context.Context, accessToken string) (*models.OIDCUserInfo, error) {
+	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
+		return &p.key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}), jwt.WithIssuer(p.issuer), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, models.NewOAuthError(models.OAuthInvalidToken, "access token is invalid or expired")
+	}
+
+	claims, _ := token.Claims.(jwt.MapClaims)
+	subject, _ := claims.GetSubject()
+	scope, _ := claims["scope"].(string)
+
+	user, err := p.UserFromSession(ctx, subject)
+	if err != nil {
+		return nil, models.NewOAuthError(models.OAuthInvalidToken, "user is no longer allowed to sign in")
+	}
+
+	info := &models.OIDCUserInfo{Subject: subject}
+	scopes := strings.Fields(scope)
+	if hasScope(scopes, models.ScopeEmail) {
+		verified := user.IsVerified
+		info.Email = user.Email
+		info.EmailVerified = &verified
+	}
+	if hasScope(scopes, models.ScopeProfile) {
+		info.Name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		info.PreferredUsername = user.Username
+		info.Role = user.Role
+	}
+	return info, nil
+}
+
+// accessTokenClaims keep user_id, email and role of the Conveer tokens, so services that
+// read them only need to switch to verifying RS256 with the JWKS
+func (p *OIDCProvider) accessTokenClaims(user *models.User, code *models.AuthorizationCode, now time.Time) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":       p.issuer,
+		"sub":       user.ID.Hex(),
+		"aud":       code.ClientID,
+		"exp":       jwt.NewNumericDate(now.Add(p.config.AccessTokenTTL)),
+		"iat":       jwt.NewNumericDate(now),
+		"jti":       uuid.New().String(),
+		"client_id": code.ClientID,
+		"scope":     code.Scope,
+		"user_id":   user.ID.Hex(),
+		"email":     user.Email,
+		"role":      user.Role,
+	}
+}
+
+func (p *OIDCProvider) idTokenClaims(user *models.User, code *models.AuthorizationCode, now time.Time) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss":       p.issuer,
+		"sub":       user.ID.Hex(),
+		"aud":       code.ClientID,
+		"exp":       jwt.NewNumericDate(now.Add(p.config.IDTokenTTL)),
+		"iat":       jwt.NewNumericDate(now),
+		"auth_time": jwt.NewNumericDate(code.AuthTime),
+	}
+	if code.Nonce != "" {
+		claims["nonce"] = code.Nonce
+	}
+
+	scopes := strings.Fields(code.Scope)
+	if hasScope(scopes, models.ScopeEmail) {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.IsVerified
+	}
+	if hasScope(scopes, models.ScopeProfile) {
+		claims["name"] = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		claims["preferred_username"] = user.Username
+		claims["role"] = user.Role
+	}
+	return claims
+}
+
+func (p *OIDCProvider) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = p.keyID
+	return token.SignedString(p.key)
+}
+
+// loadOIDCSigningKey reads the RSA key from the config or its file. Without one a key is
+// generated, which is enough for development but invalidates issued tokens on restart.
+func loadOIDCSigningKey(cfg config.OIDCConfig) (*rsa.PrivateKey, error) {
+	data := []byte(cfg.SigningKey)
+	if len(data) == 0 && cfg.SigningKeyFile != "" {
+		var err error
+		if data, err = os.ReadFile(cfg.SigningKeyFile); err != nil {
+			return nil, fmt.Errorf("failed to read OIDC signing key: %w", err)
+		}
+	}
+
+	if len(data) == 0 {
+		logger.Warn("OIDC signing key is not configured, generating a temporary one")
+		return rsa.GenerateKey(rand.Reader, oidcGeneratedKeyBits)
+	}
+
+	return parseRSASigningKey(data, "OIDC")
+}
+
+// parseRSASigningKey decodes a PEM RSA private key in PKCS#1 or PKCS#8
+func parseRSASigningKey(data []byte, name string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s signing key is not PEM encoded", name)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s signing key: %w", name, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s signing key must be an RSA key", name)
+	}
+	return key, nil
+}
+
+// oidcKeyID derives a stable kid from the public key, so rotating the key changes it
+func oidcKeyID(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(hash[:12]), nil
+}
+
+// grantedScopes keeps the supported scopes of the request, unknown ones are ignored
+func grantedScopes(requested []string) string {
+	var granted []string
+	for _, scope := range models.SupportedScopes {
+		if hasScope(requested, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	return strings.Join(granted, " ")
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func withQuery(rawURL string, params url.Values) string {
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + params.Encode()
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Exchanged refresh tokens are remembered well past their own expiry, a leaked export
+// may be replayed long after the login it came from
+const rotatedRefreshTokenRetention = 30 * 24 * time.Hour
+
+func (s *AuthService) rememberRotatedRefreshToken(ctx context.Context, session *models.Session, refreshToken string) {
+	now := time.Now()
+	rotated := &models.RotatedRefreshToken{
+		ID:        primitive.NewObjectID(),
+		TokenHash: crypto.SHA256Hash(refreshToken),
+		FamilyID:  session.FamilyID,
+		UserID:    session.UserID,
+		RotatedAt: now,
+		ExpiresAt: now.Add(rotatedRefreshTokenRetention),
+	}
+
+	if err := s.repo.CreateRotatedRefreshToken(ctx, rotated); err != nil {
+		logger.Error("Failed to remember rotated refresh token", logger.Field{Key: "error", Value: err.Error()})
+	}
+}
+
+// detectRefreshTokenReuse reports whether an unknown refresh token is one that was already
+// exchanged, and whose it was. A replayed token means it leaked, so the whole family it belongs to is revoked.
+func (s *AuthService) detectRefreshTokenReuse(ctx context.Context, refreshToken string) (primitive.ObjectID, bool) {
+	rotated, err := s.repo.FindRotatedRefreshToken(ctx, crypto.SHA256Hash(refreshToken))
+	if err != nil {
+		return primitive.NilObjectID, false
+	}
+
+	s.revokeSessionFamily(ctx, rotated.UserID, rotated.FamilyID, "rotated_token_presented")
+	return rotated.UserID, true
+}
+
+func (s *AuthService) revokeSessionFamily(ctx context.Context, userID, familyID primitive.ObjectID, reason string) {
+	revoked, err := s.repo.RevokeSessionFamily(ctx, familyID)
+	if err != nil {
+		logger.Error("Failed to revoke session family",
+			logger.Field{Key: "family_id", Value: familyID.Hex()},
+			logger.Field{Key: "error", Value: err.Error()},
+		)
+	}
+
+	event := &models.SecurityEvent{
+		ID:       primitive.NewObjectID(),
+		UserID:   userID,
+		Type:     models.SecurityEventRefreshTokenReuse,
+		FamilyID: familyID,
+		Details: map[string]interface{}{
+			"reason":           reason,
+			"revoked_sessions": revoked,
+		},
+		CreatedAt: time.Now(),
+	}
+
+	logger.Warn("Refresh token reuse detected, session family revoked",
+		logger.Field{Key: "user_id", Value: userID.Hex()},
+		logger.Field{Key: "family_id", Value: familyID.Hex()},
+		logger.Field{Key: "reason", Value: reason},
+		logger.Field{Key: "revoked_sessions", Value: revoked},
+	)
+
+	if err := s.repo.CreateSecurityEvent(ctx, event); err != nil {
+		logger.Error("Failed to save security event", logger.Field{Key: "error", Value: err.Error()})
+	}
+
+	user, err := s.repo.FindUserByID(ctx, userID.Hex())
+	if err != nil {
+		logger.Error("Failed to load user for security alert", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+
+	if err := s.sendSecurityAlertEmail(user, event); err != nil {
+		logger.Error("Failed to send security alert email", logger.Field{Key: "error", Value: err.Error()})
+	}
+	if err := s.sendSecurityAlertToBot(user, event); err != nil {
+		logger.Error("Failed to send security alert to bot", logger.Field{Key: "error", Value: err.Error()})
+	}
+}
+
+func (s *AuthService) sendSecurityAlertEmail(user *models.User, event *models.SecurityEvent) error {
+	message := map[string]interface{}{
+		"type":      "security_alert",
+		"recipient": user.Email,
+		"data": map[string]interface{}{
+			"username":    user.Username,
+			"event":       event.Type,
+			"detected_at": event.CreatedAt,
+			"details":     event.Details,
+		},
+	}
+
+	return s.rabbitmq.PublishEvent("notification.email.send", message)
+}
+
+// sendSecurityAlertToBot asks the Telegram bot to message the affected user directly
+func (s *AuthService) sendSecurityAlertToBot(user *models.User, event *models.SecurityEvent) error {
+	if user.TelegramID == 0 {
+		return nil
+	}
+
+	metadata := map[string]interface{}{
+		"user_id":     user.ID.Hex(),
+		"telegram_id": user.TelegramID,
+	}
+	if !event.FamilyID.IsZero() {
+		metadata["family_id"] = event.FamilyID.Hex()
+	}
+
+	message := map[string]interface{}{
+		"type":      "auth.security." + event.Type,
+		"message":   securityAlertText(user, event),
+		"metadata":  metadata,
+		"timestamp": event.CreatedAt,
+	}
+
+	return s.rabbitmq.Publish("bot.events", "auth.security."+event.Type, message)
+}
+
+func securityAlertText(user *models.User, event *models.SecurityEvent) string {
+	switch event.Type {
+	case models.SecurityEventAccountLocked:
+		until, _ := event.Details["locked_until"].(time.Time)
+		return fmt.Sprintf("Sign-in to %s was locked until %s after %v failed attempts. Change your password if it wasn't you.",
+			user.Username, until.Format(time.RFC1123), event.Details["failed_attempts"])
+	}
+	return fmt.Sprintf("A refresh token of %s was used twice. All sessions of that login were signed out, sign in again and change your password if it wasn't you.", user.Username)
+}
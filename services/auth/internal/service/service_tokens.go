@@ -0,0 +1,183 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/grigta/conveer/pkg/config"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/models"
+	"github.com/grigta/conveer/pkg/serviceauth"
+)
+
+type serviceIdentity struct {
+	name           string
+	secret         string
+	previousSecret string
+	scopes         []string
+}
+
+type signingKey struct {
+	key *rsa.PrivateKey
+	id  string
+}
+
+// ServiceTokenIssuer gives internal services short-lived tokens for calling each other over gRPC.
+// A service authenticates with its bootstrap secret; both secrets and signing keys rotate without
+// downtime: the previous secret is accepted and the previous key is published during a rotation.
+type ServiceTokenIssuer struct {
+	issuer   string
+	ttl      time.Duration
+	current  signingKey
+	previous *signingKey
+	services map[string]*serviceIdentity
+}
+
+func NewServiceTokenIssuer(cfg config.ServiceAuthConfig) (*ServiceTokenIssuer, error) {
+	services := make(map[string]*serviceIdentity, len(cfg.Services))
+	for _, svc := range cfg.Services {
+		secret := os.ExpandEnv(svc.Secret)
+		if svc.Name == "" || secret == "" {
+			return nil, fmt.Errorf("service %q needs a name and a secret to get tokens", svc.Name)
+		}
+		services[svc.Name] = &serviceIdentity{
+			name:           svc.Name,
+			secret:         secret,
+			previousSecret: os.ExpandEnv(svc.PreviousSecret),
+			scopes:         svc.Scopes,
+		}
+	}
+
+	current, err := loadServiceSigningKey(cfg.SigningKey, cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		logger.Warn("Service token signing key is not configured, generating a temporary one")
+		key, err := rsa.GenerateKey(rand.Reader, oidcGeneratedKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		if current, err = newSigningKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	previous, err := loadServiceSigningKey(cfg.PreviousSigningKey, cfg.PreviousSigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceTokenIssuer{
+		issuer:   strings.TrimSuffix(cfg.Issuer, "/"),
+		ttl:      cfg.TokenTTL,
+		current:  *current,
+		previous: previous,
+		services: services,
+	}, nil
+}
+
+// Issue exchanges the service's bootstrap secret for a token with its configured scopes
+func (i *ServiceTokenIssuer) Issue(req *models.ServiceTokenRequest) (*models.ServiceTokenResponse, error) {
+	if req.GrantType != "client_credentials" {
+		return nil, models.NewOAuthError(models.OAuthUnsupportedGrantType, "only client_credentials is supported")
+	}
+
+	svc, ok := i.services[req.ClientID]
+	if !ok || !svc.matches(req.ClientSecret) {
+		return nil, models.NewOAuthError(models.OAuthInvalidClient, "service authentication failed")
+	}
+
+	claims := serviceauth.NewClaims(i.issuer, svc.name, svc.scopes, time.Now(), i.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = i.current.id
+	signed, err := token.SignedString(i.current.key)
+	if err != nil {
+		logger.Error("Failed to sign service token", logger.Field{Key: "error", Value: err.Error()})
+		return nil, models.NewOAuthError(models.OAuthServerError, "")
+	}
+
+	return &models.ServiceTokenResponse{
+		AccessToken: signed,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(i.ttl.Seconds()),
+		Scope:       strings.Join(svc.scopes, " "),
+	}, nil
+}
+
+// Verify checks a service token against the current and, during a rotation, the previous key
+func (i *ServiceTokenIssuer) Verify(token string) (*serviceauth.Claims, error) {
+	return serviceauth.ParseToken(token, func(kid string) (*rsa.PublicKey, error) {
+		switch {
+		case kid == i.current.id:
+			return &i.current.key.PublicKey, nil
+		case i.previous != nil && kid == i.previous.id:
+			return &i.previous.key.PublicKey, nil
+		default:
+			return nil, fmt.Errorf("unknown service token key %q", kid)
+		}
+	})
+}
+
+// JWKS publishes the current key and, during a rotation, the previous one
+func (i *ServiceTokenIssuer) JWKS() *JSONWebKeySet {
+	keys := []JSONWebKey{i.current.jwk()}
+	if i.previous != nil {
+		keys = append(keys, i.previous.jwk())
+	}
+	return &JSONWebKeySet{Keys: keys}
+}
+
+// matches compares with both secrets in constant time, an empty previous secret never matches
+func (s *serviceIdentity) matches(secret string) bool {
+	current := subtle.ConstantTimeCompare([]byte(s.secret), []byte(secret)) == 1
+	previous := s.previousSecret != "" && subtle.ConstantTimeCompare([]byte(s.previousSecret), []byte(secret)) == 1
+	return current || previous
+}
+
+func (k *signingKey) jwk() JSONWebKey {
+	return JSONWebKey{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: jwt.SigningMethodRS256.Alg(),
+		KeyID:     k.id,
+		Modulus:   base64.RawURLEncoding.EncodeToString(k.key.PublicKey.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.key.PublicKey.E)).Bytes()),
+	}
+}
+
+func newSigningKey(key *rsa.PrivateKey) (*signingKey, error) {
+	id, err := oidcKeyID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{key: key, id: id}, nil
+}
+
+// loadServiceSigningKey reads a key from the config value or its file, nil when neither is set
+func loadServiceSigningKey(value, file string) (*signingKey, error) {
+	data := []byte(value)
+	if len(data) == 0 && file != "" {
+		var err error
+		if data, err = os.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("failed to read service token signing key: %w", err)
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	key, err := parseRSASigningKey(data, "service token")
+	if err != nil {
+		return nil, err
+	}
+	return newSigningKey(key)
+}
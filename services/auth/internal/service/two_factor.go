@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/models"
+)
+
+const (
+	twoFactorIssuer    = "Conveer"
+	twoFactorSkew      = 1 // Accept codes one period before or after the current one
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 5 // Random bytes per code, rendered as 10 hex characters
+)
+
+// EnrollTwoFactor generates a new TOTP secret for the user. Two-factor authentication
+// stays disabled until the first code is confirmed with ConfirmTwoFactor.
+func (s *AuthService) EnrollTwoFactor(ctx context.Context, userID string) (*models.TwoFactorEnrollment, error) {
+	user, err := s.repo.FindUserCredentials(ctx, userID)
+	if err != nil {
+		return nil, models.ErrUserNotFound
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, models.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := crypto.GenerateTOTPSecret()
+	if err != nil {
+		logger.Error("Failed to generate TOTP secret", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to enroll two-factor authentication")
+	}
+
+	encrypted, err := s.encryptor.Encrypt(secret)
+	if err != nil {
+		logger.Error("Failed to encrypt TOTP secret", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to enroll two-factor authentication")
+	}
+
+	if err := s.repo.SetTwoFactorSecret(ctx, userID, encrypted); err != nil {
+		logger.Error("Failed to save TOTP secret", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to enroll two-factor authentication")
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: crypto.TOTPProvisioningURI(twoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication once the user proves the authenticator
+// is set up. The returned recovery codes are shown only once.
+func (s *AuthService) ConfirmTwoFactor(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := s.repo.FindUserCredentials(ctx, userID)
+	if err != nil {
+		return nil, models.ErrUserNotFound
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, models.ErrTwoFactorAlreadyEnabled
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, models.ErrTwoFactorNotEnrolled
+	}
+
+	secret, err := s.encryptor.Decrypt(user.TwoFactorSecret)
+	if err != nil {
+		logger.Error("Failed to decrypt TOTP secret", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to confirm two-factor authentication")
+	}
+
+	step, ok := crypto.ValidateTOTPCode(secret, code, time.Now(), twoFactorSkew)
+	if !ok {
+		return nil, models.ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		logger.Error("Failed to generate recovery codes", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to confirm two-factor authentication")
+	}
+
+	if err := s.repo.EnableTwoFactor(ctx, userID, hashes, step); err != nil {
+		logger.Error("Failed to enable two-factor authentication", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to confirm two-factor authentication")
+	}
+
+	logger.Info("Two-factor authentication enabled", logger.Field{Key: "user_id", Value: userID})
+
+	return codes, nil
+}
+
+// VerifyTwoFactor checks a TOTP or recovery code for the user, e.g. before sensitive operations
+func (s *AuthService) VerifyTwoFactor(ctx context.Context, userID, code string) error {
+	user, err := s.repo.FindUserCredentials(ctx, userID)
+	if err != nil {
+		return models.ErrUserNotFound
+	}
+
+	if !user.TwoFactorEnabled {
+		return models.ErrTwoFactorNotEnrolled
+	}
+
+	return s.verifySecondFactor(ctx, user, code)
+}
+
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID, code string) error {
+	if err := s.VerifyTwoFactor(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.DisableTwoFactor(ctx, userID); err != nil {
+		logger.Error("Failed to disable two-factor authentication", logger.Field{Key: "error", Value: err.Error()})
+		return errors.New("failed to disable two-factor authentication")
+	}
+
+	logger.Info("Two-factor authentication disabled", logger.Field{Key: "user_id", Value: userID})
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes, invalidating the previous ones
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if err := s.VerifyTwoFactor(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		logger.Error("Failed to generate recovery codes", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to regenerate recovery codes")
+	}
+
+	if err := s.repo.UpdateRecoveryCodes(ctx, userID, hashes); err != nil {
+		logger.Error("Failed to save recovery codes", logger.Field{Key: "error", Value: err.Error()})
+		return nil, errors.New("failed to regenerate recovery codes")
+	}
+
+	return codes, nil
+}
+
+// verifySecondFactor accepts either a current TOTP code, which cannot be reused,
+// or an unused recovery code, which is consumed
+func (s *AuthService) verifySecondFactor(ctx context.Context, user *models.User, code string) error {
+	userID := user.ID.Hex()
+
+	secret, err := s.encryptor.Decrypt(user.TwoFactorSecret)
+	if err != nil {
+		logger.Error("Failed to decrypt TOTP secret", logger.Field{Key: "error", Value: err.Error()})
+		return errors.New("failed to verify two-factor code")
+	}
+
+	if step, ok := crypto.ValidateTOTPCode(secret, code, time.Now(), twoFactorSkew); ok {
+		advanced, err := s.repo.AdvanceTwoFactorStep(ctx, userID, step)
+		if err != nil {
+			logger.Error("Failed to record TOTP step", logger.Field{Key: "error", Value: err.Error()})
+			return errors.New("failed to verify two-factor code")
+		}
+		if !advanced {
+			return models.ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	consumed, err := s.repo.ConsumeRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		logger.Error("Failed to consume recovery code", logger.Field{Key: "error", Value: err.Error()})
+		return errors.New("failed to verify two-factor code")
+	}
+	if !consumed {
+		return models.ErrInvalidTwoFactorCode
+	}
+
+	logger.Info("Recovery code used",
+		logger.Field{Key: "user_id", Value: userID},
+		logger.Field{Key: "remaining", Value: len(user.RecoveryCodes) - 1},
+	)
+
+	return nil
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := crypto.GenerateRandomKey(recoveryCodeLength)
+		if err != nil {
+			return nil, nil, err
+		}
+		code := raw[:len(raw)/2] + "-" + raw[len(raw)/2:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(code)
+	normalized = strings.NewReplacer("-", "", " ", "").Replace(normalized)
+	return crypto.SHA256Hash(normalized)
+}
@@ -0,0 +1,104 @@
+package clients
+
+import (
+	"fmt"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/serviceauth"
+	analyticspb "github.com/grigta/conveer/services/analytics-service/proto"
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
+	maxpb "github.com/grigta/conveer/services/max-service/proto"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	telegrampb "github.com/grigta/conveer/services/telegram-service/proto"
+	vkpb "github.com/grigta/conveer/services/vk-service/proto"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Addresses are the gRPC addresses of the services the dashboard reads from
+type Addresses struct {
+	VK        string
+	Telegram  string
+	Mail      string
+	Max       string
+	Warming   string
+	Analytics string
+	Proxy     string
+}
+
+type Clients struct {
+	VK        vkpb.VKServiceClient
+	Telegram  telegrampb.TelegramServiceClient
+	Mail      mailpb.MailServiceClient
+	Max       maxpb.MaxServiceClient
+	Warming   warmingpb.WarmingServiceClient
+	Analytics analyticspb.AnalyticsServiceClient
+	Proxy     proxypb.ProxyServiceClient
+
+	conns []*grpc.ClientConn
+}
+
+// Dial creates the clients. Connections are established lazily, so the dashboard starts
+// while a service is down and only the blocks backed by it report an error.
+func Dial(addrs Addresses, serviceAuth *serviceauth.Auth) (*Clients, error) {
+	c := &Clients{}
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, logger.DialOptions()...)
+	dialOpts = append(dialOpts, serviceAuth.DialOptions()...)
+
+	dial := func(service, addr string) (*grpc.ClientConn, error) {
+		conn, err := grpc.Dial(addr, dialOpts...)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("failed to dial %s service at %s: %w", service, addr, err)
+		}
+		c.conns = append(c.conns, conn)
+		return conn, nil
+	}
+
+	conn, err := dial("vk", addrs.VK)
+	if err != nil {
+		return nil, err
+	}
+	c.VK = vkpb.NewVKServiceClient(conn)
+
+	if conn, err = dial("telegram", addrs.Telegram); err != nil {
+		return nil, err
+	}
+	c.Telegram = telegrampb.NewTelegramServiceClient(conn)
+
+	if conn, err = dial("mail", addrs.Mail); err != nil {
+		return nil, err
+	}
+	c.Mail = mailpb.NewMailServiceClient(conn)
+
+	if conn, err = dial("max", addrs.Max); err != nil {
+		return nil, err
+	}
+	c.Max = maxpb.NewMaxServiceClient(conn)
+
+	if conn, err = dial("warming", addrs.Warming); err != nil {
+		return nil, err
+	}
+	c.Warming = warmingpb.NewWarmingServiceClient(conn)
+
+	if conn, err = dial("analytics", addrs.Analytics); err != nil {
+		return nil, err
+	}
+	c.Analytics = analyticspb.NewAnalyticsServiceClient(conn)
+
+	if conn, err = dial("proxy", addrs.Proxy); err != nil {
+		return nil, err
+	}
+	c.Proxy = proxypb.NewProxyServiceClient(conn)
+
+	return c, nil
+}
+
+// Close closes all connections
+func (c *Clients) Close() {
+	for _, conn := range c.conns {
+		conn.Close()
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/dashboard-service/internal/models"
+	"github.com/grigta/conveer/services/dashboard-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type HTTPHandler struct {
+	dashboardService *service.DashboardService
+	logger           logger.Logger
+}
+
+func NewHTTPHandler(dashboardService *service.DashboardService, logger logger.Logger) *HTTPHandler {
+	return &HTTPHandler{
+		dashboardService: dashboardService,
+		logger:           logger,
+	}
+}
+
+func (h *HTTPHandler) GetOverview(c *gin.Context) {
+	overview, err := h.dashboardService.Overview(c.Request.Context())
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+func (h *HTTPHandler) ListAccounts(c *gin.Context) {
+	var platforms []string
+	if value := c.Query("platform"); value != "" {
+		platforms = strings.Split(value, ",")
+	}
+
+	page, err := h.dashboardService.ListAccounts(c.Request.Context(), models.AccountFilter{
+		Platforms: platforms,
+		Status:    c.Query("status"),
+	}, pageQuery(c))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *HTTPHandler) ListWarmingTasks(c *gin.Context) {
+	page, err := h.dashboardService.ListWarmingTasks(c.Request.Context(), models.WarmingTaskFilter{
+		Platform:  c.Query("platform"),
+		Status:    c.Query("status"),
+		AccountID: c.Query("account_id"),
+	}, pageQuery(c))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *HTTPHandler) GetProxies(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "7"))
+
+	summary, err := h.dashboardService.ProxySummary(c.Request.Context(), days)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (h *HTTPHandler) ListAlerts(c *gin.Context) {
+	unacknowledged, _ := strconv.ParseBool(c.Query("unacknowledged_only"))
+
+	page, err := h.dashboardService.ListAlerts(c.Request.Context(), models.AlertFilter{
+		Severity:           c.Query("severity"),
+		UnacknowledgedOnly: unacknowledged,
+	}, pageQuery(c))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *HTTPHandler) AcknowledgeAlert(c *gin.Context) {
+	if err := h.dashboardService.AcknowledgeAlert(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"acknowledged": true})
+}
+
+func (h *HTTPHandler) GetExpenses(c *gin.Context) {
+	expenses, err := h.dashboardService.Expenses(c.Request.Context(), c.Query("period"))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, expenses)
+}
+
+func pageQuery(c *gin.Context) models.PageQuery {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	return models.PageQuery{
+		Sort:     c.Query("sort"),
+		Order:    c.Query("order"),
+		Page:     page,
+		PageSize: pageSize,
+	}
+}
+
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidQuery):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrUnavailable):
+		return http.StatusBadGateway
+	}
+
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
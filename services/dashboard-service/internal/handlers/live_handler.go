@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/dashboard-service/internal/live"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type LiveHandler struct {
+	hub      *live.Hub
+	upgrader websocket.Upgrader
+	logger   logger.Logger
+}
+
+// NewLiveHandler accepts WebSocket connections from the allowed origins, "*" or an empty list allows any
+func NewLiveHandler(hub *live.Hub, allowedOrigins []string, logger logger.Logger) *LiveHandler {
+	return &LiveHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			CheckOrigin: func(r *http.Request) bool {
+				return originAllowed(r.Header.Get("Origin"), allowedOrigins)
+			},
+		},
+		logger: logger,
+	}
+}
+
+// Live upgrades the request and streams events of the topics given in ?topics=, all topics by default.
+// Browsers cannot set headers on WebSocket requests, the token is passed in ?token= or a cookie.
+func (h *LiveHandler) Live(c *gin.Context) {
+	topics := live.Topics
+	if value := c.Query("topics"); value != "" {
+		topics = strings.Split(value, ",")
+		for _, topic := range topics {
+			if !contains(live.Topics, topic) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":  "unknown topic " + topic,
+					"topics": live.Topics,
+				})
+				return
+			}
+		}
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already replied
+		h.logger.WithError(err).Debug("WebSocket upgrade failed")
+		return
+	}
+
+	h.hub.Serve(conn, topics)
+}
+
+func originAllowed(origin string, allowed []string) bool {
+	if origin == "" || len(allowed) == 0 {
+		return true
+	}
+	for _, o := range allowed {
+		if o == "*" || o == origin {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
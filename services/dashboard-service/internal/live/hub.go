@@ -0,0 +1,244 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/services/dashboard-service/internal/models"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	TopicAccounts = "accounts"
+	TopicWarming  = "warming"
+	TopicProxies  = "proxies"
+	TopicAlerts   = "alerts"
+	TopicExpenses = "expenses"
+)
+
+// Topics are the live topics a client can subscribe to
+var Topics = []string{TopicAccounts, TopicWarming, TopicProxies, TopicAlerts, TopicExpenses}
+
+type binding struct {
+	exchange   string
+	routingKey string
+}
+
+// topicBindings lists the events forwarded on each topic. Every topic has its own queue,
+// the consumer handler only sees the message body.
+var topicBindings = map[string][]binding{
+	TopicAccounts: {
+		{"vk.events", "#"},
+		{"telegram.events", "#"},
+		{"mail.events", "#"},
+		{"max.events", "#"},
+	},
+	TopicWarming: {
+		{"warming.events", "#"},
+	},
+	TopicProxies: {
+		{"proxy.events", "#"},
+	},
+	TopicAlerts: {
+		// analytics-service publishes alerts under these keys, the alert type is in the body
+		{"bot.events", "analytics.#"},
+		{"bot.events", "sms.balance.low"},
+	},
+	TopicExpenses: {
+		{"sms.events", "sms.number.purchased"},
+		{"sms.events", "sms.rental.#"},
+	},
+}
+
+const writeTimeout = 10 * time.Second
+
+// Hub forwards events of the other services to the WebSocket clients subscribed to their topic
+type Hub struct {
+	rabbit *messaging.RabbitMQ
+	config *models.DashboardConfig
+	logger logger.Logger
+
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+}
+
+type client struct {
+	conn   *websocket.Conn
+	topics map[string]bool
+	send   chan []byte
+	once   sync.Once
+}
+
+func NewHub(rabbit *messaging.RabbitMQ, config *models.DashboardConfig, logger logger.Logger) *Hub {
+	return &Hub{
+		rabbit:  rabbit,
+		config:  config,
+		logger:  logger,
+		clients: make(map[*client]struct{}),
+	}
+}
+
+// Start declares the per-instance queues and consumes them until ctx is done.
+// The queues are deleted by the broker when the instance goes away.
+func (h *Hub) Start(ctx context.Context) error {
+	for topic, bindings := range topicBindings {
+		queue := fmt.Sprintf("%s.%s", h.config.LiveQueue, topic)
+		if _, err := h.rabbit.DeclareQueue(queue, false, true, false); err != nil {
+			return fmt.Errorf("failed to declare queue %s: %w", queue, err)
+		}
+
+		for _, b := range bindings {
+			if err := h.rabbit.DeclareExchange(b.exchange, "topic", true, false); err != nil {
+				return fmt.Errorf("failed to declare exchange %s: %w", b.exchange, err)
+			}
+			if err := h.rabbit.BindQueue(queue, b.routingKey, b.exchange); err != nil {
+				return fmt.Errorf("failed to bind queue %s to %s: %w", queue, b.exchange, err)
+			}
+		}
+
+		topic := topic
+		if err := h.rabbit.ConsumeWithHandler(ctx, queue, "dashboard-service-"+topic, func(body []byte) error {
+			h.broadcast(topic, body)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to consume %s: %w", queue, err)
+		}
+	}
+
+	return nil
+}
+
+// broadcast wraps an event and queues it for the subscribed clients. Clients that do not keep up
+// are disconnected instead of slowing the consumer down, they reload the screen on reconnect.
+func (h *Hub) broadcast(topic string, body []byte) {
+	message, err := json.Marshal(newEvent(topic, body))
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to encode live event")
+		return
+	}
+
+	h.mu.RLock()
+	var slow []*client
+	for c := range h.clients {
+		if !c.topics[topic] {
+			continue
+		}
+		select {
+		case c.send <- message:
+		default:
+			slow = append(slow, c)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, c := range slow {
+		h.logger.Warn("Dropping slow dashboard client")
+		h.remove(c)
+	}
+}
+
+// newEvent wraps a message body, the type is taken from the body when the publisher sets one
+func newEvent(topic string, body []byte) models.LiveEvent {
+	event := models.LiveEvent{
+		Topic:     topic,
+		Data:      json.RawMessage(body),
+		Timestamp: time.Now(),
+	}
+
+	var fields struct {
+		Type      string `json:"type"`
+		EventType string `json:"event_type"`
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		// Not a JSON object, forward it as a string
+		event.Data = string(body)
+		return event
+	}
+	event.Type = fields.Type
+	if event.Type == "" {
+		event.Type = fields.EventType
+	}
+	return event
+}
+
+// Serve streams the events of the topics to the connection until it is closed
+func (h *Hub) Serve(conn *websocket.Conn, topics []string) {
+	c := &client{
+		conn:   conn,
+		topics: make(map[string]bool, len(topics)),
+		send:   make(chan []byte, h.config.LiveSendBuffer),
+	}
+	for _, topic := range topics {
+		c.topics[topic] = true
+	}
+
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+
+	go h.readLoop(c)
+	h.writeLoop(c)
+}
+
+// readLoop discards client messages and notices when the connection goes away
+func (h *Hub) readLoop(c *client) {
+	defer h.remove(c)
+
+	pongWait := 2 * h.config.LivePingInterval
+	c.conn.SetReadLimit(1024)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *Hub) writeLoop(c *client) {
+	ticker := time.NewTicker(h.config.LivePingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				h.remove(c)
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				h.remove(c)
+				return
+			}
+		}
+	}
+}
+
+// remove unregisters the client and closes its send channel, which ends its write loop
+func (h *Hub) remove(c *client) {
+	c.once.Do(func() {
+		h.mu.Lock()
+		delete(h.clients, c)
+		h.mu.Unlock()
+		close(c.send)
+	})
+}
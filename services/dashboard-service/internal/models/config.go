@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Platforms are the account platforms shown in the registry
+var Platforms = []string{"vk", "telegram", "mail", "max"}
+
+type DashboardConfig struct {
+	// ScanLimit is the number of rows read from each source to sort a list
+	ScanLimit   int
+	CallTimeout time.Duration
+	// LiveQueue receives the events forwarded to WebSocket clients, one queue per instance
+	LiveQueue        string
+	LiveSendBuffer   int
+	LivePingInterval time.Duration
+	AllowedOrigins   []string
+}
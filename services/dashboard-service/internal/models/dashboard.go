@@ -0,0 +1,167 @@
+package models
+
+import "time"
+
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// PageQuery selects one page of a list sorted by a single field
+type PageQuery struct {
+	Sort     string
+	Order    string
+	Page     int
+	PageSize int
+}
+
+// Page is a sorted page of a list merged from one or more services
+type Page struct {
+	Items    interface{} `json:"items"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+	Total    int64       `json:"total"`
+	Sort     string      `json:"sort"`
+	Order    string      `json:"order"`
+	// Truncated is set when more rows matched than were read for sorting, later pages may miss rows
+	Truncated bool `json:"truncated,omitempty"`
+	// Errors lists the services that failed, the page is built from the others
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// AccountFilter selects accounts of the registry, empty Platforms means all platforms
+type AccountFilter struct {
+	Platforms []string
+	Status    string
+}
+
+// Account is the platform independent registry entry
+type Account struct {
+	Platform     string    `json:"platform"`
+	ID           string    `json:"id"`
+	Status       string    `json:"status"`
+	Phone        string    `json:"phone,omitempty"`
+	Email        string    `json:"email,omitempty"`
+	FirstName    string    `json:"first_name,omitempty"`
+	LastName     string    `json:"last_name,omitempty"`
+	Username     string    `json:"username,omitempty"`
+	PersonaID    string    `json:"persona_id,omitempty"`
+	ErrorMessage string    `json:"error_message,omitempty"`
+	RetryCount   int32     `json:"retry_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type WarmingTaskFilter struct {
+	Platform  string
+	Status    string
+	AccountID string
+}
+
+type WarmingTask struct {
+	ID               string     `json:"id"`
+	AccountID        string     `json:"account_id"`
+	Platform         string     `json:"platform"`
+	ScenarioType     string     `json:"scenario_type"`
+	Status           string     `json:"status"`
+	CurrentDay       int32      `json:"current_day"`
+	DurationDays     int32      `json:"duration_days"`
+	Progress         float64    `json:"progress"` // share of the scenario days passed, 0-1
+	ActionsCompleted int32      `json:"actions_completed"`
+	ActionsFailed    int32      `json:"actions_failed"`
+	ReadinessScore   float64    `json:"readiness_score"`
+	LastError        string     `json:"last_error,omitempty"`
+	NextActionAt     *time.Time `json:"next_action_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+}
+
+type AlertFilter struct {
+	Severity           string
+	UnacknowledgedOnly bool
+}
+
+type Alert struct {
+	ID           string    `json:"id"`
+	RuleName     string    `json:"rule_name"`
+	Severity     string    `json:"severity"`
+	Platform     string    `json:"platform,omitempty"`
+	Message      string    `json:"message"`
+	CurrentValue float64   `json:"current_value"`
+	Threshold    float64   `json:"threshold"`
+	FiredAt      time.Time `json:"fired_at"`
+	Acknowledged bool      `json:"acknowledged"`
+}
+
+type ProxyProvider struct {
+	Provider      string  `json:"provider"`
+	TotalProxies  int64   `json:"total_proxies"`
+	ActiveProxies int64   `json:"active_proxies"`
+	BannedProxies int64   `json:"banned_proxies"`
+	AvgLatency    float64 `json:"avg_latency"`
+	CostPerProxy  float64 `json:"cost_per_proxy"`
+	SuccessRate   float64 `json:"success_rate"`
+	BanRate       float64 `json:"ban_rate"`
+}
+
+type ProxySummary struct {
+	TotalProxies     int64             `json:"total_proxies"`
+	ActiveProxies    int64             `json:"active_proxies"`
+	ExpiredProxies   int64             `json:"expired_proxies"`
+	BannedProxies    int64             `json:"banned_proxies"`
+	TotalBindings    int64             `json:"total_bindings"`
+	ProxiesByType    map[string]int64  `json:"proxies_by_type,omitempty"`
+	ProxiesByCountry map[string]int64  `json:"proxies_by_country,omitempty"`
+	AvgFraudScore    float64           `json:"avg_fraud_score"`
+	AvgLatency       float64           `json:"avg_latency"`
+	Providers        []ProxyProvider   `json:"providers"`
+	Errors           map[string]string `json:"errors,omitempty"`
+}
+
+type ExpenseForecast struct {
+	Period        string             `json:"period"`
+	PredictedCost float64            `json:"predicted_cost"`
+	UpperBound    float64            `json:"upper_bound"`
+	LowerBound    float64            `json:"lower_bound"`
+	Breakdown     map[string]float64 `json:"breakdown,omitempty"`
+	Confidence    float64            `json:"confidence"`
+}
+
+type ExpenseSummary struct {
+	SpentToday        float64           `json:"spent_today"`
+	SpentWeek         float64           `json:"spent_week"`
+	SpentMonth        float64           `json:"spent_month"`
+	SMSSpent          float64           `json:"sms_spent"`
+	ProxySpent        float64           `json:"proxy_spent"`
+	AvgCostPerAccount float64           `json:"avg_cost_per_account"`
+	Forecast          *ExpenseForecast  `json:"forecast,omitempty"`
+	Errors            map[string]string `json:"errors,omitempty"`
+}
+
+type PlatformAccounts struct {
+	Total    int64            `json:"total"`
+	ByStatus map[string]int64 `json:"by_status"`
+}
+
+// Overview is the landing screen of the dashboard, every block is filled independently
+type Overview struct {
+	Accounts       map[string]PlatformAccounts `json:"accounts"`
+	WarmingActive  int64                       `json:"warming_active"`
+	ActiveProxies  int64                       `json:"active_proxies"`
+	BannedProxies  int64                       `json:"banned_proxies"`
+	ActiveAlerts   int                         `json:"active_alerts"`
+	CriticalAlerts int                         `json:"critical_alerts"`
+	SpentToday     float64                     `json:"spent_today"`
+	SpentMonth     float64                     `json:"spent_month"`
+	GeneratedAt    time.Time                   `json:"generated_at"`
+	Errors         map[string]string           `json:"errors,omitempty"`
+}
+
+// LiveEvent is an event of another service forwarded to WebSocket clients
+type LiveEvent struct {
+	Topic     string      `json:"topic"`
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
@@ -0,0 +1,504 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	analyticspb "github.com/grigta/conveer/services/analytics-service/proto"
+	"github.com/grigta/conveer/services/dashboard-service/internal/clients"
+	"github.com/grigta/conveer/services/dashboard-service/internal/models"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrUnavailable = errors.New("backing services unavailable")
+
+// DashboardService reads the dashboard screens from the platform, warming, proxy and analytics services.
+// Lists are read up to ScanLimit rows per source and sorted and paged here, the services
+// have no common sort order and not all of them page by offset.
+type DashboardService struct {
+	clients *clients.Clients
+	config  *models.DashboardConfig
+	logger  logger.Logger
+}
+
+func NewDashboardService(clients *clients.Clients, config *models.DashboardConfig, logger logger.Logger) *DashboardService {
+	return &DashboardService{
+		clients: clients,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// partial collects the errors of the sources a response is built from
+type partial struct {
+	mu     sync.Mutex
+	errors map[string]string
+}
+
+func (p *partial) fail(source string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.errors == nil {
+		p.errors = make(map[string]string)
+	}
+	p.errors[source] = err.Error()
+}
+
+// failedAll reports whether no source of the response answered
+func (p *partial) failedAll(sources int) error {
+	if len(p.errors) < sources {
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrUnavailable, p.errors)
+}
+
+func (s *DashboardService) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, s.config.CallTimeout)
+}
+
+// ListAccounts returns a page of the account registry merged across platforms
+func (s *DashboardService) ListAccounts(ctx context.Context, filter models.AccountFilter, q models.PageQuery) (*models.Page, error) {
+	q, err := normalize(q, accountSortFields, "created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	platforms := filter.Platforms
+	if len(platforms) == 0 {
+		platforms = models.Platforms
+	}
+	for _, platform := range platforms {
+		if _, ok := s.accountSources()[platform]; !ok {
+			return nil, fmt.Errorf("%w: unknown platform %q", ErrInvalidQuery, platform)
+		}
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		accounts  []models.Account
+		total     int64
+		truncated bool
+		failures  partial
+	)
+	for _, platform := range platforms {
+		list := s.accountSources()[platform]
+		wg.Add(1)
+		go func(platform string) {
+			defer wg.Done()
+			callCtx, cancel := s.callContext(ctx)
+			defer cancel()
+
+			items, count, err := list(callCtx, filter.Status, s.config.ScanLimit)
+			if err != nil {
+				s.logger.WithError(err).WithField("platform", platform).Warn("Failed to list accounts")
+				failures.fail(platform, err)
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			accounts = append(accounts, items...)
+			if count < int64(len(items)) {
+				count = int64(len(items))
+			}
+			total += count
+			if len(items) >= s.config.ScanLimit || count > int64(len(items)) {
+				truncated = true
+			}
+		}(platform)
+	}
+	wg.Wait()
+
+	if err := failures.failedAll(len(platforms)); err != nil {
+		return nil, err
+	}
+
+	return &models.Page{
+		Items:     sortAndPage(accounts, q, accountSortFields),
+		Page:      q.Page,
+		PageSize:  q.PageSize,
+		Total:     total,
+		Sort:      q.Sort,
+		Order:     q.Order,
+		Truncated: truncated,
+		Errors:    failures.errors,
+	}, nil
+}
+
+// ListWarmingTasks returns a page of warming tasks
+func (s *DashboardService) ListWarmingTasks(ctx context.Context, filter models.WarmingTaskFilter, q models.PageQuery) (*models.Page, error) {
+	q, err := normalize(q, warmingTaskSortFields, "created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	callCtx, cancel := s.callContext(ctx)
+	defer cancel()
+
+	resp, err := s.clients.Warming.ListTasks(callCtx, &warmingpb.ListTasksRequest{
+		Platform:  filter.Platform,
+		Status:    filter.Status,
+		AccountId: filter.AccountID,
+		Limit:     int32(s.config.ScanLimit),
+	})
+	if err != nil {
+		return nil, upstreamError("warming", err)
+	}
+
+	tasks := make([]models.WarmingTask, 0, len(resp.Tasks))
+	for _, task := range resp.Tasks {
+		tasks = append(tasks, warmingTaskFromProto(task))
+	}
+
+	total := resp.TotalCount
+	if total < int64(len(tasks)) {
+		total = int64(len(tasks))
+	}
+
+	return &models.Page{
+		Items:     sortAndPage(tasks, q, warmingTaskSortFields),
+		Page:      q.Page,
+		PageSize:  q.PageSize,
+		Total:     total,
+		Sort:      q.Sort,
+		Order:     q.Order,
+		Truncated: len(tasks) >= s.config.ScanLimit,
+	}, nil
+}
+
+// ListAlerts returns a page of the alerts currently firing
+func (s *DashboardService) ListAlerts(ctx context.Context, filter models.AlertFilter, q models.PageQuery) (*models.Page, error) {
+	q, err := normalize(q, alertSortFields, "fired_at")
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.activeAlerts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page{
+		Items:    sortAndPage(alerts, q, alertSortFields),
+		Page:     q.Page,
+		PageSize: q.PageSize,
+		Total:    int64(len(alerts)),
+		Sort:     q.Sort,
+		Order:    q.Order,
+	}, nil
+}
+
+func (s *DashboardService) AcknowledgeAlert(ctx context.Context, id string) error {
+	callCtx, cancel := s.callContext(ctx)
+	defer cancel()
+
+	if _, err := s.clients.Analytics.AcknowledgeAlert(callCtx, &analyticspb.AcknowledgeRequest{AlertId: id}); err != nil {
+		return upstreamError("analytics", err)
+	}
+	return nil
+}
+
+func (s *DashboardService) activeAlerts(ctx context.Context, filter models.AlertFilter) ([]models.Alert, error) {
+	callCtx, cancel := s.callContext(ctx)
+	defer cancel()
+
+	resp, err := s.clients.Analytics.GetActiveAlerts(callCtx, &analyticspb.AlertsRequest{
+		UnacknowledgedOnly: filter.UnacknowledgedOnly,
+		Severity:           filter.Severity,
+	})
+	if err != nil {
+		return nil, upstreamError("analytics", err)
+	}
+
+	alerts := make([]models.Alert, 0, len(resp.Alerts))
+	for _, alert := range resp.Alerts {
+		alerts = append(alerts, models.Alert{
+			ID:           alert.Id,
+			RuleName:     alert.RuleName,
+			Severity:     alert.Severity,
+			Platform:     alert.Platform,
+			Message:      alert.Message,
+			CurrentValue: alert.CurrentValue,
+			Threshold:    alert.Threshold,
+			FiredAt:      alert.FiredAt.AsTime(),
+			Acknowledged: alert.Acknowledged,
+		})
+	}
+	return alerts, nil
+}
+
+// ProxySummary returns the proxy pool state with per-provider statistics for the last days
+func (s *DashboardService) ProxySummary(ctx context.Context, days int) (*models.ProxySummary, error) {
+	summary := &models.ProxySummary{Providers: []models.ProxyProvider{}}
+	var (
+		wg       sync.WaitGroup
+		failures partial
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		callCtx, cancel := s.callContext(ctx)
+		defer cancel()
+
+		stats, err := s.clients.Proxy.GetProxyStatistics(callCtx, &proxypb.GetStatisticsRequest{})
+		if err != nil {
+			failures.fail("proxy_statistics", err)
+			return
+		}
+		summary.TotalProxies = stats.TotalProxies
+		summary.ActiveProxies = stats.ActiveProxies
+		summary.ExpiredProxies = stats.ExpiredProxies
+		summary.BannedProxies = stats.BannedProxies
+		summary.TotalBindings = stats.TotalBindings
+		summary.ProxiesByType = stats.ProxiesByType
+		summary.ProxiesByCountry = stats.ProxiesByCountry
+		summary.AvgFraudScore = stats.AvgFraudScore
+		summary.AvgLatency = stats.AvgLatency
+	}()
+	go func() {
+		defer wg.Done()
+		callCtx, cancel := s.callContext(ctx)
+		defer cancel()
+
+		resp, err := s.clients.Proxy.GetProviderStatistics(callCtx, &proxypb.GetProviderStatisticsRequest{Days: int32(days)})
+		if err != nil {
+			failures.fail("provider_statistics", err)
+			return
+		}
+		for _, p := range resp.ProviderStats {
+			summary.Providers = append(summary.Providers, models.ProxyProvider{
+				Provider:      p.Provider,
+				TotalProxies:  p.TotalProxies,
+				ActiveProxies: p.ActiveProxies,
+				BannedProxies: p.BannedProxies,
+				AvgLatency:    p.AvgLatency,
+				CostPerProxy:  p.CostPerProxy,
+				SuccessRate:   p.SuccessRate,
+				BanRate:       p.BanRate,
+			})
+		}
+	}()
+	wg.Wait()
+
+	if err := failures.failedAll(2); err != nil {
+		return nil, err
+	}
+	summary.Errors = failures.errors
+	return summary, nil
+}
+
+// Expenses returns spending so far with the forecast for the period, 7d or 30d
+func (s *DashboardService) Expenses(ctx context.Context, period string) (*models.ExpenseSummary, error) {
+	if period == "" {
+		period = "7d"
+	}
+	if period != "7d" && period != "30d" {
+		return nil, fmt.Errorf("%w: period must be 7d or 30d", ErrInvalidQuery)
+	}
+
+	summary := &models.ExpenseSummary{}
+	var (
+		wg       sync.WaitGroup
+		failures partial
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		expenses, err := s.expenses(ctx)
+		if err != nil {
+			failures.fail("expenses", err)
+			return
+		}
+		summary.SpentToday = expenses.TotalSpentToday
+		summary.SpentWeek = expenses.TotalSpentWeek
+		summary.SpentMonth = expenses.TotalSpentMonth
+		summary.SMSSpent = expenses.SmsSpent
+		summary.ProxySpent = expenses.ProxySpent
+		summary.AvgCostPerAccount = expenses.AvgCostPerAccount
+	}()
+	go func() {
+		defer wg.Done()
+		callCtx, cancel := s.callContext(ctx)
+		defer cancel()
+
+		forecast, err := s.clients.Analytics.GetExpenseForecast(callCtx, &analyticspb.ForecastRequest{Period: period})
+		if err != nil {
+			failures.fail("forecast", err)
+			return
+		}
+		summary.Forecast = &models.ExpenseForecast{
+			Period:        forecast.Period,
+			PredictedCost: forecast.PredictedCost,
+			UpperBound:    forecast.UpperBound,
+			LowerBound:    forecast.LowerBound,
+			Breakdown:     forecast.Breakdown,
+			Confidence:    forecast.Confidence,
+		}
+	}()
+	wg.Wait()
+
+	if err := failures.failedAll(2); err != nil {
+		return nil, err
+	}
+	summary.Errors = failures.errors
+	return summary, nil
+}
+
+func (s *DashboardService) expenses(ctx context.Context) (*analyticspb.ExpensesSummary, error) {
+	callCtx, cancel := s.callContext(ctx)
+	defer cancel()
+
+	analytics, err := s.clients.Analytics.GetOverallAnalytics(callCtx, &analyticspb.AnalyticsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if analytics.Expenses == nil {
+		return &analyticspb.ExpensesSummary{}, nil
+	}
+	return analytics.Expenses, nil
+}
+
+// Overview collects the headline numbers of every screen. A failed source leaves its
+// numbers at zero and is reported in Errors.
+func (s *DashboardService) Overview(ctx context.Context) (*models.Overview, error) {
+	overview := &models.Overview{
+		Accounts:    make(map[string]models.PlatformAccounts),
+		GeneratedAt: time.Now(),
+	}
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures partial
+	)
+
+	for platform, stats := range s.statisticsSources() {
+		wg.Add(1)
+		go func(platform string, stats statisticsFunc) {
+			defer wg.Done()
+			callCtx, cancel := s.callContext(ctx)
+			defer cancel()
+
+			accounts, err := stats(callCtx)
+			if err != nil {
+				failures.fail(platform, err)
+				return
+			}
+			mu.Lock()
+			overview.Accounts[platform] = accounts
+			mu.Unlock()
+		}(platform, stats)
+	}
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		callCtx, cancel := s.callContext(ctx)
+		defer cancel()
+
+		stats, err := s.clients.Warming.GetWarmingStatistics(callCtx, &warmingpb.StatisticsRequest{})
+		if err != nil {
+			failures.fail("warming", err)
+			return
+		}
+		overview.WarmingActive = stats.InProgressTasks
+	}()
+	go func() {
+		defer wg.Done()
+		callCtx, cancel := s.callContext(ctx)
+		defer cancel()
+
+		stats, err := s.clients.Proxy.GetProxyStatistics(callCtx, &proxypb.GetStatisticsRequest{})
+		if err != nil {
+			failures.fail("proxy", err)
+			return
+		}
+		overview.ActiveProxies = stats.ActiveProxies
+		overview.BannedProxies = stats.BannedProxies
+	}()
+	go func() {
+		defer wg.Done()
+		alerts, err := s.activeAlerts(ctx, models.AlertFilter{UnacknowledgedOnly: true})
+		if err != nil {
+			failures.fail("alerts", err)
+			return
+		}
+		overview.ActiveAlerts = len(alerts)
+		for _, alert := range alerts {
+			if alert.Severity == "critical" {
+				overview.CriticalAlerts++
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		expenses, err := s.expenses(ctx)
+		if err != nil {
+			failures.fail("expenses", err)
+			return
+		}
+		overview.SpentToday = expenses.TotalSpentToday
+		overview.SpentMonth = expenses.TotalSpentMonth
+	}()
+	wg.Wait()
+
+	if err := failures.failedAll(len(models.Platforms) + 4); err != nil {
+		return nil, err
+	}
+	overview.Errors = failures.errors
+	return overview, nil
+}
+
+func warmingTaskFromProto(task *warmingpb.WarmingTask) models.WarmingTask {
+	result := models.WarmingTask{
+		ID:               task.Id,
+		AccountID:        task.AccountId,
+		Platform:         task.Platform,
+		ScenarioType:     task.ScenarioType,
+		Status:           task.Status,
+		CurrentDay:       task.CurrentDay,
+		DurationDays:     task.DurationDays,
+		ActionsCompleted: task.ActionsCompleted,
+		ActionsFailed:    task.ActionsFailed,
+		ReadinessScore:   task.ReadinessScore,
+		LastError:        task.LastError,
+		CreatedAt:        task.CreatedAt.AsTime(),
+		UpdatedAt:        task.UpdatedAt.AsTime(),
+	}
+	if task.DurationDays > 0 {
+		result.Progress = float64(task.CurrentDay) / float64(task.DurationDays)
+		if result.Progress > 1 {
+			result.Progress = 1
+		}
+	}
+	if task.NextActionAt != nil {
+		next := task.NextActionAt.AsTime()
+		result.NextActionAt = &next
+	}
+	if task.CompletedAt != nil {
+		completed := task.CompletedAt.AsTime()
+		result.CompletedAt = &completed
+	}
+	return result
+}
+
+// upstreamError keeps the gRPC code of a failed call so the handler can map it to an HTTP status
+func upstreamError(source string, err error) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.NotFound, codes.InvalidArgument:
+			return status.Errorf(st.Code(), "%s: %s", source, st.Message())
+		}
+	}
+	return fmt.Errorf("%w: %s: %v", ErrUnavailable, source, err)
+}
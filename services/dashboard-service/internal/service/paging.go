@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grigta/conveer/services/dashboard-service/internal/models"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// sortFields maps the sort names accepted by a list to the comparisons used for them
+type sortFields[T any] map[string]func(a, b T) bool
+
+// normalize fills the defaults of a page query and rejects unknown sort fields and orders
+func normalize[T any](q models.PageQuery, fields sortFields[T], defaultSort string) (models.PageQuery, error) {
+	if q.Sort == "" {
+		q.Sort = defaultSort
+	}
+	if _, ok := fields[q.Sort]; !ok {
+		return q, fmt.Errorf("%w: unknown sort field %q, allowed: %s", ErrInvalidQuery, q.Sort, strings.Join(fields.names(), ", "))
+	}
+
+	switch q.Order {
+	case "":
+		q.Order = models.OrderDesc
+	case models.OrderAsc, models.OrderDesc:
+	default:
+		return q, fmt.Errorf("%w: order must be %s or %s", ErrInvalidQuery, models.OrderAsc, models.OrderDesc)
+	}
+
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultPageSize
+	}
+	if q.PageSize > MaxPageSize {
+		q.PageSize = MaxPageSize
+	}
+
+	return q, nil
+}
+
+// sortAndPage sorts items in place by the query and returns the requested page of them
+func sortAndPage[T any](items []T, q models.PageQuery, fields sortFields[T]) []T {
+	less := fields[q.Sort]
+	sort.SliceStable(items, func(i, j int) bool {
+		if q.Order == models.OrderAsc {
+			return less(items[i], items[j])
+		}
+		return less(items[j], items[i])
+	})
+
+	start := (q.Page - 1) * q.PageSize
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + q.PageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func (f sortFields[T]) names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var accountSortFields = sortFields[models.Account]{
+	"created_at":  func(a, b models.Account) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	"updated_at":  func(a, b models.Account) bool { return a.UpdatedAt.Before(b.UpdatedAt) },
+	"status":      func(a, b models.Account) bool { return a.Status < b.Status },
+	"platform":    func(a, b models.Account) bool { return a.Platform < b.Platform },
+	"retry_count": func(a, b models.Account) bool { return a.RetryCount < b.RetryCount },
+}
+
+var warmingTaskSortFields = sortFields[models.WarmingTask]{
+	"created_at":      func(a, b models.WarmingTask) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	"updated_at":      func(a, b models.WarmingTask) bool { return a.UpdatedAt.Before(b.UpdatedAt) },
+	"status":          func(a, b models.WarmingTask) bool { return a.Status < b.Status },
+	"platform":        func(a, b models.WarmingTask) bool { return a.Platform < b.Platform },
+	"progress":        func(a, b models.WarmingTask) bool { return a.Progress < b.Progress },
+	"readiness_score": func(a, b models.WarmingTask) bool { return a.ReadinessScore < b.ReadinessScore },
+	"actions_failed":  func(a, b models.WarmingTask) bool { return a.ActionsFailed < b.ActionsFailed },
+}
+
+var alertSortFields = sortFields[models.Alert]{
+	"fired_at": func(a, b models.Alert) bool { return a.FiredAt.Before(b.FiredAt) },
+	"severity": func(a, b models.Alert) bool { return severityRank(a.Severity) < severityRank(b.Severity) },
+	"platform": func(a, b models.Alert) bool { return a.Platform < b.Platform },
+}
+
+// severityRank orders alerts from info to critical, unknown severities come first
+func severityRank(severity string) int {
+	switch severity {
+	case "info":
+		return 1
+	case "warning":
+		return 2
+	case "high":
+		return 3
+	case "critical":
+		return 4
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/dashboard-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAccounts() []models.Account {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	return []models.Account{
+		{Platform: "vk", ID: "a", Status: "ready", CreatedAt: base.Add(2 * time.Hour)},
+		{Platform: "mail", ID: "b", Status: "error", CreatedAt: base},
+		{Platform: "telegram", ID: "c", Status: "warming", CreatedAt: base.Add(3 * time.Hour)},
+		{Platform: "max", ID: "d", Status: "ready", CreatedAt: base.Add(time.Hour)},
+	}
+}
+
+func ids(accounts []models.Account) []string {
+	result := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		result = append(result, a.ID)
+	}
+	return result
+}
+
+func TestNormalizeDefaults(t *testing.T) {
+	q, err := normalize(models.PageQuery{}, accountSortFields, "created_at")
+	require.NoError(t, err)
+
+	assert.Equal(t, "created_at", q.Sort)
+	assert.Equal(t, models.OrderDesc, q.Order)
+	assert.Equal(t, 1, q.Page)
+	assert.Equal(t, DefaultPageSize, q.PageSize)
+}
+
+func TestNormalizeCapsPageSize(t *testing.T) {
+	q, err := normalize(models.PageQuery{PageSize: 10000}, accountSortFields, "created_at")
+	require.NoError(t, err)
+
+	assert.Equal(t, MaxPageSize, q.PageSize)
+}
+
+func TestNormalizeRejectsUnknownSort(t *testing.T) {
+	_, err := normalize(models.PageQuery{Sort: "password"}, accountSortFields, "created_at")
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = normalize(models.PageQuery{Order: "sideways"}, accountSortFields, "created_at")
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestSortAndPageNewestFirst(t *testing.T) {
+	q := models.PageQuery{Sort: "created_at", Order: models.OrderDesc, Page: 1, PageSize: 3}
+
+	page := sortAndPage(testAccounts(), q, accountSortFields)
+	assert.Equal(t, []string{"c", "a", "d"}, ids(page))
+
+	q.Page = 2
+	page = sortAndPage(testAccounts(), q, accountSortFields)
+	assert.Equal(t, []string{"b"}, ids(page))
+}
+
+func TestSortAndPageAscendingIsStable(t *testing.T) {
+	q := models.PageQuery{Sort: "status", Order: models.OrderAsc, Page: 1, PageSize: 10}
+
+	page := sortAndPage(testAccounts(), q, accountSortFields)
+	// Equal statuses keep the order they were merged in
+	assert.Equal(t, []string{"b", "a", "d", "c"}, ids(page))
+}
+
+func TestSortAndPagePastTheEnd(t *testing.T) {
+	q := models.PageQuery{Sort: "created_at", Order: models.OrderDesc, Page: 5, PageSize: 10}
+
+	page := sortAndPage(testAccounts(), q, accountSortFields)
+	assert.NotNil(t, page)
+	assert.Empty(t, page)
+}
+
+func TestAlertSeverityOrder(t *testing.T) {
+	alerts := []models.Alert{
+		{ID: "1", Severity: "warning"},
+		{ID: "2", Severity: "critical"},
+		{ID: "3", Severity: "info"},
+	}
+	q := models.PageQuery{Sort: "severity", Order: models.OrderDesc, Page: 1, PageSize: 10}
+
+	page := sortAndPage(alerts, q, alertSortFields)
+	require.Len(t, page, 3)
+	assert.Equal(t, "2", page[0].ID)
+	assert.Equal(t, "1", page[1].ID)
+	assert.Equal(t, "3", page[2].ID)
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/grigta/conveer/services/dashboard-service/internal/models"
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
+	maxpb "github.com/grigta/conveer/services/max-service/proto"
+	telegrampb "github.com/grigta/conveer/services/telegram-service/proto"
+	vkpb "github.com/grigta/conveer/services/vk-service/proto"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// listFunc reads up to limit accounts of a platform and the number of matching accounts it reports
+type listFunc func(ctx context.Context, status string, limit int) ([]models.Account, int64, error)
+
+type statisticsFunc func(ctx context.Context) (models.PlatformAccounts, error)
+
+// accountSources maps each platform to the service holding its accounts
+func (s *DashboardService) accountSources() map[string]listFunc {
+	return map[string]listFunc{
+		"vk": func(ctx context.Context, status string, limit int) ([]models.Account, int64, error) {
+			resp, err := s.clients.VK.ListAccounts(ctx, &vkpb.ListAccountsRequest{Status: status, Limit: int32(limit)})
+			if err != nil {
+				return nil, 0, err
+			}
+			accounts := make([]models.Account, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, models.Account{
+					Platform:     "vk",
+					ID:           a.Id,
+					Status:       a.Status,
+					Phone:        a.Phone,
+					Email:        a.Email,
+					FirstName:    a.FirstName,
+					LastName:     a.LastName,
+					Username:     a.Username,
+					PersonaID:    a.PersonaId,
+					ErrorMessage: a.ErrorMessage,
+					RetryCount:   a.RetryCount,
+					CreatedAt:    a.CreatedAt.AsTime(),
+					UpdatedAt:    a.UpdatedAt.AsTime(),
+				})
+			}
+			return accounts, int64(resp.Total), nil
+		},
+		"telegram": func(ctx context.Context, status string, limit int) ([]models.Account, int64, error) {
+			resp, err := s.clients.Telegram.ListAccounts(ctx, &telegrampb.ListAccountsRequest{Status: status, Limit: int32(limit)})
+			if err != nil {
+				return nil, 0, err
+			}
+			accounts := make([]models.Account, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, models.Account{
+					Platform:     "telegram",
+					ID:           a.Id,
+					Status:       a.Status,
+					Phone:        a.Phone,
+					FirstName:    a.FirstName,
+					LastName:     a.LastName,
+					Username:     a.Username,
+					PersonaID:    a.PersonaId,
+					ErrorMessage: a.ErrorMessage,
+					RetryCount:   a.RetryCount,
+					CreatedAt:    a.CreatedAt.AsTime(),
+					UpdatedAt:    a.UpdatedAt.AsTime(),
+				})
+			}
+			return accounts, int64(resp.Total), nil
+		},
+		"mail": func(ctx context.Context, status string, limit int) ([]models.Account, int64, error) {
+			resp, err := s.clients.Mail.ListAccounts(ctx, &mailpb.ListAccountsRequest{Status: status, Limit: int32(limit)})
+			if err != nil {
+				return nil, 0, err
+			}
+			accounts := make([]models.Account, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, models.Account{
+					Platform:     "mail",
+					ID:           a.Id,
+					Status:       a.Status,
+					Phone:        a.Phone,
+					Email:        a.Email,
+					FirstName:    a.FirstName,
+					LastName:     a.LastName,
+					PersonaID:    a.PersonaId,
+					ErrorMessage: a.ErrorMessage,
+					RetryCount:   a.RetryCount,
+					CreatedAt:    time.Unix(a.CreatedAt, 0),
+					UpdatedAt:    time.Unix(a.UpdatedAt, 0),
+				})
+			}
+			return accounts, resp.Total, nil
+		},
+		"max": func(ctx context.Context, status string, limit int) ([]models.Account, int64, error) {
+			resp, err := s.clients.Max.ListAccounts(ctx, &maxpb.ListAccountsRequest{Status: status, Limit: int32(limit)})
+			if err != nil {
+				return nil, 0, err
+			}
+			accounts := make([]models.Account, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, models.Account{
+					Platform:     "max",
+					ID:           a.Id,
+					Status:       a.Status,
+					Phone:        a.Phone,
+					FirstName:    a.FirstName,
+					LastName:     a.LastName,
+					Username:     a.Username,
+					PersonaID:    a.PersonaId,
+					ErrorMessage: a.ErrorMessage,
+					RetryCount:   a.RetryCount,
+					CreatedAt:    time.Unix(a.CreatedAt, 0),
+					UpdatedAt:    time.Unix(a.UpdatedAt, 0),
+				})
+			}
+			return accounts, resp.Total, nil
+		},
+	}
+}
+
+// statisticsSources maps each platform to its account counters
+func (s *DashboardService) statisticsSources() map[string]statisticsFunc {
+	return map[string]statisticsFunc{
+		"vk": func(ctx context.Context) (models.PlatformAccounts, error) {
+			stats, err := s.clients.VK.GetStatistics(ctx, &emptypb.Empty{})
+			if err != nil {
+				return models.PlatformAccounts{}, err
+			}
+			return models.PlatformAccounts{Total: stats.Total, ByStatus: stats.ByStatus}, nil
+		},
+		"telegram": func(ctx context.Context) (models.PlatformAccounts, error) {
+			stats, err := s.clients.Telegram.GetStatistics(ctx, &emptypb.Empty{})
+			if err != nil {
+				return models.PlatformAccounts{}, err
+			}
+			return models.PlatformAccounts{Total: stats.Total, ByStatus: stats.ByStatus}, nil
+		},
+		"mail": func(ctx context.Context) (models.PlatformAccounts, error) {
+			stats, err := s.clients.Mail.GetStatistics(ctx, &mailpb.GetStatisticsRequest{})
+			if err != nil {
+				return models.PlatformAccounts{}, err
+			}
+			return models.PlatformAccounts{Total: stats.TotalAccounts, ByStatus: stats.AccountsByStatus}, nil
+		},
+		"max": func(ctx context.Context) (models.PlatformAccounts, error) {
+			stats, err := s.clients.Max.GetStatistics(ctx, &maxpb.GetStatisticsRequest{})
+			if err != nil {
+				return models.PlatformAccounts{}, err
+			}
+			return models.PlatformAccounts{Total: stats.TotalAccounts, ByStatus: stats.AccountsByStatus}, nil
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/intervention-service/internal/models"
+	"github.com/grigta/conveer/services/intervention-service/internal/service"
+	pb "github.com/grigta/conveer/services/intervention-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GRPCHandler struct {
+	pb.UnimplementedInterventionServiceServer
+	interventionService *service.InterventionService
+	logger              logger.Logger
+}
+
+func NewGRPCHandler(interventionService *service.InterventionService, logger logger.Logger) *GRPCHandler {
+	return &GRPCHandler{
+		interventionService: interventionService,
+		logger:              logger,
+	}
+}
+
+func (h *GRPCHandler) ListInterventions(ctx context.Context, req *pb.ListInterventionsRequest) (*pb.ListInterventionsResponse, error) {
+	items, err := h.interventionService.ListInterventions(ctx, models.InterventionFilter{
+		Status:      req.Status,
+		Platform:    req.Platform,
+		Operator:    req.Operator,
+		SLABreached: req.SlaBreached,
+		Limit:       int(req.Limit),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListInterventionsResponse{Interventions: make([]*pb.Intervention, 0, len(items))}
+	for _, item := range items {
+		resp.Interventions = append(resp.Interventions, toProtoIntervention(item))
+	}
+	return resp, nil
+}
+
+func (h *GRPCHandler) GetIntervention(ctx context.Context, req *pb.GetInterventionRequest) (*pb.Intervention, error) {
+	item, err := h.interventionService.GetIntervention(ctx, req.InterventionId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoIntervention(item), nil
+}
+
+func (h *GRPCHandler) ClaimIntervention(ctx context.Context, req *pb.ClaimInterventionRequest) (*pb.Intervention, error) {
+	item, err := h.interventionService.Claim(ctx, req.InterventionId, req.Operator)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoIntervention(item), nil
+}
+
+func (h *GRPCHandler) ResolveIntervention(ctx context.Context, req *pb.ResolveInterventionRequest) (*pb.Intervention, error) {
+	item, err := h.interventionService.Resolve(ctx, req.InterventionId, &models.ResolveRequest{
+		Operator:   req.Operator,
+		Resolution: req.Resolution,
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.logger.Errorf("Failed to resolve intervention %s: %v", req.InterventionId, err)
+		return nil, toStatus(err)
+	}
+
+	return toProtoIntervention(item), nil
+}
+
+func (h *GRPCHandler) RequeueIntervention(ctx context.Context, req *pb.RequeueInterventionRequest) (*pb.Intervention, error) {
+	item, err := h.interventionService.Requeue(ctx, req.InterventionId, &models.OperatorRequest{
+		Operator: req.Operator,
+		Note:     req.Note,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoIntervention(item), nil
+}
+
+func toProtoIntervention(item *models.Intervention) *pb.Intervention {
+	return &pb.Intervention{
+		Id:             item.ID.Hex(),
+		Platform:       item.Platform,
+		AccountId:      item.AccountID,
+		TaskId:         item.TaskID,
+		Reason:         item.Reason,
+		Step:           item.Step,
+		Error:          item.Error,
+		RetryCount:     int32(item.RetryCount),
+		Status:         item.Status,
+		Occurrences:    int32(item.Occurrences),
+		Operator:       item.Operator,
+		AssignedAt:     unixOrZero(item.AssignedAt),
+		Requeues:       int32(item.Requeues),
+		DueAt:          item.DueAt.Unix(),
+		SlaBreachedAt:  unixOrZero(item.SLABreachedAt),
+		Resolution:     item.Resolution,
+		ResolutionNote: item.ResolutionNote,
+		ResolvedBy:     item.ResolvedBy,
+		ResolvedAt:     unixOrZero(item.ResolvedAt),
+		FirstSeenAt:    item.FirstSeenAt.Unix(),
+		LastSeenAt:     item.LastSeenAt.Unix(),
+	}
+}
+
+func unixOrZero(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, models.ErrInterventionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrOperatorRequired),
+		errors.Is(err, models.ErrInvalidResolution),
+		errors.Is(err, models.ErrRetryNotSupported):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrAssignedToOther),
+		errors.Is(err, models.ErrNotAssigned),
+		errors.Is(err, models.ErrAlreadyResolved):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "intervention service error: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/intervention-service/internal/models"
+	"github.com/grigta/conveer/services/intervention-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HTTPHandler struct {
+	interventionService *service.InterventionService
+	logger              logger.Logger
+}
+
+func NewHTTPHandler(interventionService *service.InterventionService, logger logger.Logger) *HTTPHandler {
+	return &HTTPHandler{
+		interventionService: interventionService,
+		logger:              logger,
+	}
+}
+
+func (h *HTTPHandler) ListInterventions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	breached, _ := strconv.ParseBool(c.Query("sla_breached"))
+
+	items, err := h.interventionService.ListInterventions(c.Request.Context(), models.InterventionFilter{
+		Status:      c.Query("status"),
+		Platform:    c.Query("platform"),
+		Operator:    c.Query("operator"),
+		SLABreached: breached,
+		Limit:       limit,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"interventions": items})
+}
+
+func (h *HTTPHandler) GetIntervention(c *gin.Context) {
+	item, err := h.interventionService.GetIntervention(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"intervention": item})
+}
+
+func (h *HTTPHandler) ClaimIntervention(c *gin.Context) {
+	var req models.OperatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.interventionService.Claim(c.Request.Context(), c.Param("id"), req.Operator)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"intervention": item})
+}
+
+func (h *HTTPHandler) ResolveIntervention(c *gin.Context) {
+	var req models.ResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.interventionService.Resolve(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		h.logger.Errorf("Failed to resolve intervention %s: %v", c.Param("id"), err)
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"intervention": item})
+}
+
+func (h *HTTPHandler) RequeueIntervention(c *gin.Context) {
+	var req models.OperatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.interventionService.Requeue(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"intervention": item})
+}
+
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrInterventionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrOperatorRequired),
+		errors.Is(err, models.ErrInvalidResolution),
+		errors.Is(err, models.ErrRetryNotSupported):
+		return http.StatusBadRequest
+	case errors.Is(err, models.ErrAssignedToOther),
+		errors.Is(err, models.ErrNotAssigned),
+		errors.Is(err, models.ErrAlreadyResolved):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
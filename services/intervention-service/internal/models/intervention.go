@@ -0,0 +1,110 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// StatusPending items wait for an operator to claim them
+	StatusPending = "pending"
+	// StatusAssigned items are being worked on by their operator
+	StatusAssigned = "assigned"
+	StatusResolved = "resolved"
+)
+
+const (
+	// ResolutionRetry sends the platform's retry command for the account
+	ResolutionRetry = "retry"
+	// ResolutionDismiss closes the item without a command, e.g. the operator fixed the account by hand
+	ResolutionDismiss = "dismiss"
+)
+
+const (
+	// EventsExchange receives intervention lifecycle events
+	EventsExchange   = "intervention.events"
+	EventResolved    = "intervention.resolved"
+	EventSLABreached = "intervention.sla_breached"
+)
+
+var (
+	ErrInterventionNotFound = errors.New("intervention not found")
+	ErrOperatorRequired     = errors.New("operator is required")
+	ErrAssignedToOther      = errors.New("intervention is assigned to another operator")
+	ErrNotAssigned          = errors.New("intervention is not assigned")
+	ErrAlreadyResolved      = errors.New("intervention is already resolved")
+	ErrInvalidResolution    = errors.New("invalid resolution")
+	ErrRetryNotSupported    = errors.New("platform has no retry command")
+	ErrInvalidMessage       = errors.New("invalid manual intervention message")
+)
+
+// Intervention is a manual_intervention request of a platform service. Repeated requests for the
+// same account fold into one open item until it is resolved.
+type Intervention struct {
+	ID          primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	Platform    string                 `bson:"platform" json:"platform"`
+	AccountID   string                 `bson:"account_id,omitempty" json:"account_id,omitempty"`
+	TaskID      string                 `bson:"task_id,omitempty" json:"task_id,omitempty"` // warming task, warming-service reports tasks
+	Reason      string                 `bson:"reason" json:"reason"`
+	Step        string                 `bson:"step,omitempty" json:"step,omitempty"`
+	Error       string                 `bson:"error,omitempty" json:"error,omitempty"`
+	RetryCount  int                    `bson:"retry_count" json:"retry_count"`
+	Details     map[string]interface{} `bson:"details,omitempty" json:"details,omitempty"` // the rest of the latest message
+	Status      string                 `bson:"status" json:"status"`
+	Occurrences int                    `bson:"occurrences" json:"occurrences"`
+
+	Operator   string     `bson:"operator,omitempty" json:"operator,omitempty"`
+	AssignedAt *time.Time `bson:"assigned_at,omitempty" json:"assigned_at,omitempty"`
+	Requeues   int        `bson:"requeues" json:"requeues"`
+
+	// DueAt is the SLA deadline, counted from the first request
+	DueAt         time.Time  `bson:"due_at" json:"due_at"`
+	SLABreachedAt *time.Time `bson:"sla_breached_at,omitempty" json:"sla_breached_at,omitempty"`
+
+	Resolution     string     `bson:"resolution,omitempty" json:"resolution,omitempty"`
+	ResolutionNote string     `bson:"resolution_note,omitempty" json:"resolution_note,omitempty"`
+	ResolvedBy     string     `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+
+	FirstSeenAt time.Time `bson:"first_seen_at" json:"first_seen_at"`
+	LastSeenAt  time.Time `bson:"last_seen_at" json:"last_seen_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Open reports whether the item still needs an operator
+func (i *Intervention) Open() bool {
+	return i.Status != StatusResolved
+}
+
+// InterventionFilter narrows ListInterventions, empty fields match everything
+type InterventionFilter struct {
+	Status      string
+	Platform    string
+	Operator    string
+	SLABreached bool
+	Limit       int
+}
+
+// OperatorRequest identifies the operator acting on an item
+type OperatorRequest struct {
+	Operator string `json:"operator" binding:"required"`
+	Note     string `json:"note"`
+}
+
+type ResolveRequest struct {
+	Operator   string `json:"operator" binding:"required"`
+	Resolution string `json:"resolution" binding:"required"`
+	Note       string `json:"note"`
+}
+
+type InterventionConfig struct {
+	// Platforms whose manual_intervention queues are consumed
+	Platforms []string
+	SLA       time.Duration
+	// SLACheckInterval is how often open items are checked against their deadline
+	SLACheckInterval time.Duration
+	// New items go to the least loaded of Operators, with none configured they wait to be claimed
+	Operators []string
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/intervention-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultListLimit = 100
+
+var openStatuses = bson.M{"$in": []string{models.StatusPending, models.StatusAssigned}}
+
+type InterventionRepository struct {
+	collection *mongo.Collection
+	logger     logger.Logger
+}
+
+func NewInterventionRepository(db *mongo.Database, logger logger.Logger) *InterventionRepository {
+	return &InterventionRepository{
+		collection: db.Collection("interventions"),
+		logger:     logger,
+	}
+}
+
+func (r *InterventionRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "platform", Value: 1},
+				{Key: "account_id", Value: 1},
+				{Key: "task_id", Value: 1},
+				{Key: "status", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "due_at", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "operator", Value: 1},
+				{Key: "status", Value: 1},
+			},
+		},
+	}
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create intervention indexes: %w", err)
+	}
+	return nil
+}
+
+// Record folds the request into the open item of the same account, or opens a new one due in sla.
+// created is true when a new item was opened.
+func (r *InterventionRepository) Record(ctx context.Context, item *models.Intervention, sla time.Duration) (*models.Intervention, bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"platform":   item.Platform,
+		"account_id": item.AccountID,
+		"task_id":    item.TaskID,
+		"status":     openStatuses,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"reason":       item.Reason,
+			"step":         item.Step,
+			"error":        item.Error,
+			"retry_count":  item.RetryCount,
+			"details":      item.Details,
+			"last_seen_at": now,
+			"updated_at":   now,
+		},
+		"$inc": bson.M{"occurrences": 1},
+		"$setOnInsert": bson.M{
+			"status":        models.StatusPending,
+			"requeues":      0,
+			"first_seen_at": now,
+			"due_at":        now.Add(sla),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var recorded models.Intervention
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&recorded); err != nil {
+		return nil, false, fmt.Errorf("failed to record intervention: %w", err)
+	}
+	return &recorded, recorded.Occurrences == 1, nil
+}
+
+func (r *InterventionRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Intervention, error) {
+	var item models.Intervention
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find intervention: %w", err)
+	}
+	return &item, nil
+}
+
+// List returns items matching the filter, the closest deadlines first
+func (r *InterventionRepository) List(ctx context.Context, filter models.InterventionFilter) ([]*models.Intervention, error) {
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Platform != "" {
+		query["platform"] = filter.Platform
+	}
+	if filter.Operator != "" {
+		query["operator"] = filter.Operator
+	}
+	if filter.SLABreached {
+		query["sla_breached_at"] = bson.M{"$exists": true}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "due_at", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interventions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	items := []*models.Intervention{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode interventions: %w", err)
+	}
+	return items, nil
+}
+
+// CountAssigned returns the number of items each operator is working on
+func (r *InterventionRepository) CountAssigned(ctx context.Context) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": models.StatusAssigned}}},
+		{{Key: "$group", Value: bson.M{"_id": "$operator", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count assigned interventions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Operator string `bson:"_id"`
+		Count    int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode assigned counts: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Operator] = row.Count
+	}
+	return counts, nil
+}
+
+// Assign gives a pending item, or one the operator already holds, to the operator. It returns
+// false when the item is resolved or held by someone else.
+func (r *InterventionRepository) Assign(ctx context.Context, id primitive.ObjectID, operator string) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": id,
+		"$or": []bson.M{
+			{"status": models.StatusPending},
+			{"status": models.StatusAssigned, "operator": operator},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      models.StatusAssigned,
+			"operator":    operator,
+			"assigned_at": now,
+			"updated_at":  now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to assign intervention: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Unassign puts an assigned item back to pending. It returns false when the item is not assigned.
+func (r *InterventionRepository) Unassign(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	filter := bson.M{
+		"_id":    id,
+		"status": models.StatusAssigned,
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": models.StatusPending, "updated_at": time.Now()},
+		"$unset": bson.M{"operator": "", "assigned_at": ""},
+		"$inc":   bson.M{"requeues": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to requeue intervention: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Resolve closes a pending item or one the operator holds. It returns false when the item is
+// already resolved or held by someone else.
+func (r *InterventionRepository) Resolve(ctx context.Context, id primitive.ObjectID, operator, resolution, note string) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": id,
+		"$or": []bson.M{
+			{"status": models.StatusPending},
+			{"status": models.StatusAssigned, "operator": operator},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":          models.StatusResolved,
+			"operator":        operator,
+			"resolution":      resolution,
+			"resolution_note": note,
+			"resolved_by":     operator,
+			"resolved_at":     now,
+			"updated_at":      now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve intervention: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Reopen hands a resolved item back to the operator who resolved it, used when the resolution
+// command could not be sent
+func (r *InterventionRepository) Reopen(ctx context.Context, id primitive.ObjectID, operator string) error {
+	filter := bson.M{
+		"_id":         id,
+		"status":      models.StatusResolved,
+		"resolved_by": operator,
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": models.StatusAssigned, "updated_at": time.Now()},
+		"$unset": bson.M{"resolution": "", "resolution_note": "", "resolved_by": "", "resolved_at": ""},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to reopen intervention: %w", err)
+	}
+	return nil
+}
+
+// ListOverdue returns open items past their deadline that are not marked as breached yet
+func (r *InterventionRepository) ListOverdue(ctx context.Context, now time.Time) ([]*models.Intervention, error) {
+	filter := bson.M{
+		"status":          openStatuses,
+		"due_at":          bson.M{"$lte": now},
+		"sla_breached_at": bson.M{"$exists": false},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue interventions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var items []*models.Intervention
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode overdue interventions: %w", err)
+	}
+	return items, nil
+}
+
+// MarkBreached records the SLA breach once. Only one replica succeeds, the others see it marked.
+func (r *InterventionRepository) MarkBreached(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error) {
+	filter := bson.M{
+		"_id":             id,
+		"sla_breached_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"sla_breached_at": at}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark SLA breach: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
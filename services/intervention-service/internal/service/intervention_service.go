@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/services/intervention-service/internal/models"
+	"github.com/grigta/conveer/services/intervention-service/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Publisher sends resolution commands and lifecycle events, satisfied by *messaging.RabbitMQ
+type Publisher interface {
+	Publish(exchange, routingKey string, message interface{}) error
+}
+
+type InterventionService struct {
+	repo      *repository.InterventionRepository
+	publisher Publisher
+	config    *models.InterventionConfig
+	metrics   *MetricsCollector
+	logger    logger.Logger
+}
+
+func NewInterventionService(
+	repo *repository.InterventionRepository,
+	publisher Publisher,
+	config *models.InterventionConfig,
+	metrics *MetricsCollector,
+	logger logger.Logger,
+) *InterventionService {
+	return &InterventionService{
+		repo:      repo,
+		publisher: publisher,
+		config:    config,
+		metrics:   metrics,
+		logger:    logger,
+	}
+}
+
+// Consume binds the manual_intervention queue of every configured platform to the platform's
+// events exchange and records what arrives
+func (s *InterventionService) Consume(ctx context.Context, rabbit *messaging.RabbitMQ) error {
+	for _, platform := range s.config.Platforms {
+		route, ok := platformRoutes[platform]
+		if !ok {
+			return fmt.Errorf("unknown platform %q", platform)
+		}
+		queue := interventionQueue(platform)
+
+		// The platform service declares these as well, declaring here lets this service start first
+		if err := rabbit.DeclareExchange(route.EventsExchange, "topic", true, false); err != nil {
+			return fmt.Errorf("failed to declare exchange %s: %w", route.EventsExchange, err)
+		}
+		if _, err := rabbit.DeclareQueue(queue, true, false, false); err != nil {
+			return fmt.Errorf("failed to declare queue %s: %w", queue, err)
+		}
+		if err := rabbit.BindQueue(queue, route.EventsKey, route.EventsExchange); err != nil {
+			return fmt.Errorf("failed to bind queue %s: %w", queue, err)
+		}
+
+		handler := func(body []byte) error {
+			return s.HandleMessage(ctx, platform, body)
+		}
+		if err := rabbit.ConsumeWithHandler(ctx, queue, "intervention-service-"+platform, handler); err != nil {
+			return fmt.Errorf("failed to consume %s: %w", queue, err)
+		}
+	}
+	return rabbit.DeclareExchange(models.EventsExchange, "topic", true, false)
+}
+
+// HandleMessage records a manual_intervention message. Malformed messages are dropped, storage
+// errors are returned so the message is redelivered.
+func (s *InterventionService) HandleMessage(ctx context.Context, platform string, body []byte) error {
+	item, err := parseMessage(platform, body)
+	if err != nil {
+		s.logger.Warnf("Dropping %s manual intervention message: %v", platform, err)
+		return nil
+	}
+
+	recorded, created, err := s.repo.Record(ctx, item, s.config.SLA)
+	if err != nil {
+		return err
+	}
+	s.metrics.IncrementReceived(platform, created)
+
+	if !created {
+		return nil
+	}
+	s.logger.Infof("New %s intervention %s: %s", platform, recorded.ID.Hex(), recorded.Reason)
+
+	if len(s.config.Operators) > 0 {
+		s.autoAssign(ctx, recorded)
+	}
+	return nil
+}
+
+// autoAssign hands a new item to the least loaded configured operator. Replicas may pick the
+// same operator at once, which only skews the balance slightly.
+func (s *InterventionService) autoAssign(ctx context.Context, item *models.Intervention) {
+	load, err := s.repo.CountAssigned(ctx)
+	if err != nil {
+		s.logger.Errorf("Failed to load operator workload: %v", err)
+		return
+	}
+
+	operator := leastLoadedOperator(s.config.Operators, load)
+	assigned, err := s.repo.Assign(ctx, item.ID, operator)
+	if err != nil {
+		s.logger.Errorf("Failed to assign intervention %s: %v", item.ID.Hex(), err)
+		return
+	}
+	if assigned {
+		s.logger.Infof("Intervention %s assigned to %s", item.ID.Hex(), operator)
+	}
+}
+
+func (s *InterventionService) GetIntervention(ctx context.Context, id string) (*models.Intervention, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInterventionNotFound
+	}
+
+	item, err := s.repo.FindByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, models.ErrInterventionNotFound
+	}
+	return item, nil
+}
+
+func (s *InterventionService) ListInterventions(ctx context.Context, filter models.InterventionFilter) ([]*models.Intervention, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Claim assigns a pending item to the operator. Claiming an item the operator already holds is a no-op.
+func (s *InterventionService) Claim(ctx context.Context, id, operator string) (*models.Intervention, error) {
+	if operator == "" {
+		return nil, models.ErrOperatorRequired
+	}
+	item, err := s.GetIntervention(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned, err := s.repo.Assign(ctx, item.ID, operator)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		return nil, s.conflict(ctx, item.ID)
+	}
+
+	s.logger.Infof("Intervention %s claimed by %s", id, operator)
+	return s.GetIntervention(ctx, id)
+}
+
+// Resolve closes an item the operator holds, or a pending one, and sends the resolution to the
+// originating service. A retry the platform can't take is rejected before anything changes.
+func (s *InterventionService) Resolve(ctx context.Context, id string, req *models.ResolveRequest) (*models.Intervention, error) {
+	if req.Operator == "" {
+		return nil, models.ErrOperatorRequired
+	}
+	if req.Resolution != models.ResolutionRetry && req.Resolution != models.ResolutionDismiss {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidResolution, req.Resolution)
+	}
+
+	item, err := s.GetIntervention(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	route := platformRoutes[item.Platform]
+	if req.Resolution == models.ResolutionRetry && (route.retryCommand == nil || item.AccountID == "") {
+		return nil, fmt.Errorf("%w: %s", models.ErrRetryNotSupported, item.Platform)
+	}
+
+	// Closing first keeps two operators from sending the same retry
+	resolved, err := s.repo.Resolve(ctx, item.ID, req.Operator, req.Resolution, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	if !resolved {
+		return nil, s.conflict(ctx, item.ID)
+	}
+
+	if req.Resolution == models.ResolutionRetry {
+		if err := s.publisher.Publish(route.CommandExchange, route.CommandKey, route.retryCommand(item)); err != nil {
+			if reopenErr := s.repo.Reopen(ctx, item.ID, req.Operator); reopenErr != nil {
+				s.logger.Errorf("Failed to reopen intervention %s: %v", id, reopenErr)
+			}
+			return nil, fmt.Errorf("failed to send retry command: %w", err)
+		}
+	}
+
+	s.metrics.IncrementResolved(item.Platform, req.Resolution)
+	s.metrics.ObserveTimeToResolve(item.Platform, time.Since(item.FirstSeenAt).Seconds())
+	s.logger.Infof("Intervention %s resolved by %s with %s", id, req.Operator, req.Resolution)
+
+	resolvedItem, err := s.GetIntervention(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvent(models.EventResolved, resolvedItem)
+	return resolvedItem, nil
+}
+
+// Requeue puts an assigned item back to pending for another operator to claim
+func (s *InterventionService) Requeue(ctx context.Context, id string, req *models.OperatorRequest) (*models.Intervention, error) {
+	if req.Operator == "" {
+		return nil, models.ErrOperatorRequired
+	}
+	item, err := s.GetIntervention(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	requeued, err := s.repo.Unassign(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !requeued {
+		if !item.Open() {
+			return nil, models.ErrAlreadyResolved
+		}
+		return nil, models.ErrNotAssigned
+	}
+
+	s.metrics.IncrementRequeued(item.Platform)
+	if req.Note != "" {
+		s.logger.Infof("Intervention %s requeued by %s (held by %s): %s", id, req.Operator, item.Operator, req.Note)
+	} else {
+		s.logger.Infof("Intervention %s requeued by %s (held by %s)", id, req.Operator, item.Operator)
+	}
+	return s.GetIntervention(ctx, id)
+}
+
+// WatchSLA marks open items past their deadline every check interval until ctx is done
+func (s *InterventionService) WatchSLA(ctx context.Context) {
+	ticker := time.NewTicker(s.config.SLACheckInterval)
+	defer ticker.Stop()
+
+	s.logger.Infof("SLA watcher started, SLA %s", s.config.SLA)
+
+	for {
+		s.checkSLA(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *InterventionService) checkSLA(ctx context.Context, now time.Time) {
+	items, err := s.repo.ListOverdue(ctx, now)
+	if err != nil {
+		s.logger.Errorf("Failed to load overdue interventions: %v", err)
+		return
+	}
+
+	for _, item := range items {
+		marked, err := s.repo.MarkBreached(ctx, item.ID, now)
+		if err != nil {
+			s.logger.Errorf("Failed to mark SLA breach of intervention %s: %v", item.ID.Hex(), err)
+			continue
+		}
+		if !marked {
+			continue
+		}
+
+		item.SLABreachedAt = &now
+		s.metrics.IncrementSLABreaches(item.Platform)
+		s.logger.Warnf("Intervention %s (%s) breached its SLA, status %s", item.ID.Hex(), item.Platform, item.Status)
+		s.publishEvent(models.EventSLABreached, item)
+	}
+}
+
+// conflict explains why an item could not be assigned or resolved by the operator
+func (s *InterventionService) conflict(ctx context.Context, id primitive.ObjectID) error {
+	item, err := s.repo.FindByID(ctx, id)
+	switch {
+	case err != nil:
+		return err
+	case item == nil:
+		return models.ErrInterventionNotFound
+	case !item.Open():
+		return models.ErrAlreadyResolved
+	default:
+		return fmt.Errorf("%w: %s", models.ErrAssignedToOther, item.Operator)
+	}
+}
+
+func (s *InterventionService) publishEvent(eventType string, item *models.Intervention) {
+	event := map[string]interface{}{
+		"type":            eventType,
+		"intervention_id": item.ID.Hex(),
+		"platform":        item.Platform,
+		"account_id":      item.AccountID,
+		"task_id":         item.TaskID,
+		"reason":          item.Reason,
+		"status":          item.Status,
+		"operator":        item.Operator,
+		"resolution":      item.Resolution,
+		"due_at":          item.DueAt,
+		"timestamp":       time.Now(),
+	}
+
+	if err := s.publisher.Publish(models.EventsExchange, eventType, event); err != nil {
+		s.logger.Errorf("Failed to publish %s for intervention %s: %v", eventType, item.ID.Hex(), err)
+	}
+}
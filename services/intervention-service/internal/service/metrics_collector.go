@@ -0,0 +1,77 @@
+package service
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type MetricsCollector struct {
+	received      *prometheus.CounterVec
+	resolved      *prometheus.CounterVec
+	requeued      *prometheus.CounterVec
+	slaBreaches   *prometheus.CounterVec
+	timeToResolve *prometheus.HistogramVec
+}
+
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{
+		received: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "intervention_requests_total",
+				Help: "Total number of manual intervention requests by platform and whether they opened a new item",
+			},
+			[]string{"platform", "new"},
+		),
+		resolved: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "intervention_resolved_total",
+				Help: "Total number of resolved interventions by platform and resolution",
+			},
+			[]string{"platform", "resolution"},
+		),
+		requeued: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "intervention_requeued_total",
+				Help: "Total number of interventions put back to pending by their operator",
+			},
+			[]string{"platform"},
+		),
+		slaBreaches: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "intervention_sla_breaches_total",
+				Help: "Total number of interventions not resolved within the SLA",
+			},
+			[]string{"platform"},
+		),
+		timeToResolve: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "intervention_time_to_resolve_seconds",
+				Help:    "Time from the first request to the resolution",
+				Buckets: []float64{60, 300, 900, 1800, 3600, 7200, 14400, 43200, 86400},
+			},
+			[]string{"platform"},
+		),
+	}
+}
+
+func (m *MetricsCollector) IncrementReceived(platform string, created bool) {
+	m.received.WithLabelValues(platform, strconv.FormatBool(created)).Inc()
+}
+
+func (m *MetricsCollector) IncrementResolved(platform, resolution string) {
+	m.resolved.WithLabelValues(platform, resolution).Inc()
+}
+
+func (m *MetricsCollector) IncrementRequeued(platform string) {
+	m.requeued.WithLabelValues(platform).Inc()
+}
+
+func (m *MetricsCollector) IncrementSLABreaches(platform string) {
+	m.slaBreaches.WithLabelValues(platform).Inc()
+}
+
+func (m *MetricsCollector) ObserveTimeToResolve(platform string, seconds float64) {
+	m.timeToResolve.WithLabelValues(platform).Observe(seconds)
+}
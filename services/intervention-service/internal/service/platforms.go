@@ -0,0 +1,166 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/grigta/conveer/services/intervention-service/internal/models"
+)
+
+// platformRoute is how a platform service reports manual interventions and takes retry commands
+type platformRoute struct {
+	// Exchange and routing key the platform publishes manual_intervention messages with
+	EventsExchange string
+	EventsKey      string
+	// Exchange and routing key of the registration retry command, empty when the platform has none
+	CommandExchange string
+	CommandKey      string
+	// retryCommand builds the retry command in the format of the platform's consumer
+	retryCommand func(item *models.Intervention) interface{}
+}
+
+var platformRoutes = map[string]platformRoute{
+	"vk": {
+		EventsExchange:  "vk.events",
+		EventsKey:       "vk.manual_intervention",
+		CommandExchange: "vk.commands",
+		CommandKey:      "vk.retry",
+		retryCommand: func(item *models.Intervention) interface{} {
+			return map[string]interface{}{
+				"account_id":  item.AccountID,
+				"retry_count": item.RetryCount + 1,
+				"timestamp":   time.Now(),
+			}
+		},
+	},
+	"mail": {
+		EventsExchange:  "mail.events",
+		EventsKey:       "mail.manual_intervention",
+		CommandExchange: "mail.commands",
+		CommandKey:      "mail.retry",
+		retryCommand:    registrationRetryTask,
+	},
+	"max": {
+		EventsExchange:  "max.events",
+		EventsKey:       "max.manual_intervention",
+		CommandExchange: "max.commands",
+		CommandKey:      "max.retry",
+		retryCommand:    registrationRetryTask,
+	},
+	// warming-service appends the task platform to the routing key and has no retry command,
+	// a paused task is resumed through its API
+	"warming": {
+		EventsExchange: "warming.events",
+		EventsKey:      "warming.manual_intervention.#",
+	},
+}
+
+// registrationRetryTask is the RetryTaskPayload of mail-service and max-service
+func registrationRetryTask(item *models.Intervention) interface{} {
+	return map[string]interface{}{
+		"accountID":  item.AccountID,
+		"retryCount": item.RetryCount + 1,
+	}
+}
+
+// interventionQueue is the queue the platform's manual_intervention messages collect in
+func interventionQueue(platform string) string {
+	return platform + ".manual_intervention"
+}
+
+// knownMessageFields are read into Intervention fields, the rest of the message goes to Details
+var knownMessageFields = map[string]bool{
+	"type":        true,
+	"platform":    true,
+	"service":     true,
+	"timestamp":   true,
+	"account_id":  true,
+	"task_id":     true,
+	"reason":      true,
+	"step":        true,
+	"error":       true,
+	"retry_count": true,
+}
+
+// parseMessage turns a manual_intervention message of the platform into an item. The platforms
+// share account_id, reason, step and error; warming-service reports task_id instead of an account.
+func parseMessage(platform string, body []byte) (*models.Intervention, error) {
+	// warming-service publishes already marshalled JSON, which arrives as a base64 string
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '"' {
+		var raw []byte
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidMessage, err)
+		}
+		body = raw
+	}
+
+	var message map[string]interface{}
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidMessage, err)
+	}
+
+	item := &models.Intervention{
+		Platform:   platform,
+		AccountID:  stringField(message, "account_id"),
+		TaskID:     stringField(message, "task_id"),
+		Reason:     stringField(message, "reason"),
+		Step:       stringField(message, "step"),
+		Error:      stringField(message, "error"),
+		RetryCount: intField(message, "retry_count"),
+	}
+	if item.AccountID == "" && item.TaskID == "" {
+		return nil, fmt.Errorf("%w: no account_id or task_id", models.ErrInvalidMessage)
+	}
+	if item.Reason == "" {
+		item.Reason = item.Error
+	}
+
+	for key, value := range message {
+		if knownMessageFields[key] {
+			continue
+		}
+		if item.Details == nil {
+			item.Details = make(map[string]interface{})
+		}
+		item.Details[key] = value
+	}
+
+	return item, nil
+}
+
+func stringField(message map[string]interface{}, key string) string {
+	switch value := message[key].(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func intField(message map[string]interface{}, key string) int {
+	switch value := message[key].(type) {
+	case float64:
+		return int(value)
+	case string:
+		n, _ := strconv.Atoi(value)
+		return n
+	default:
+		return 0
+	}
+}
+
+// leastLoadedOperator picks the operator with the fewest assigned items, the first listed on a tie
+func leastLoadedOperator(operators []string, load map[string]int) string {
+	best := ""
+	for _, operator := range operators {
+		if best == "" || load[operator] < load[best] {
+			best = operator
+		}
+	}
+	return best
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/grigta/conveer/services/intervention-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessageVK(t *testing.T) {
+	body := []byte(`{
+		"type": "manual_intervention",
+		"platform": "vk",
+		"account_id": "65f0c0ffee0000000000abcd",
+		"reason": "SMS verification failed after multiple attempts",
+		"step": "sms_verification",
+		"error": "code not received",
+		"session_id": "65f0c0ffee0000000000dcba",
+		"retry_count": 2,
+		"timestamp": "2026-03-02T10:00:00Z"
+	}`)
+
+	item, err := parseMessage("vk", body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "vk", item.Platform)
+	assert.Equal(t, "65f0c0ffee0000000000abcd", item.AccountID)
+	assert.Equal(t, "SMS verification failed after multiple attempts", item.Reason)
+	assert.Equal(t, "sms_verification", item.Step)
+	assert.Equal(t, "code not received", item.Error)
+	assert.Equal(t, 2, item.RetryCount)
+	assert.Equal(t, map[string]interface{}{"session_id": "65f0c0ffee0000000000dcba"}, item.Details)
+}
+
+func TestParseMessageWarming(t *testing.T) {
+	// warming-service marshals the event itself, the broker client marshals the bytes again
+	inner, err := json.Marshal(map[string]interface{}{"task_id": "65f0c0ffee0000000000aaaa", "reason": "account banned"})
+	require.NoError(t, err)
+	body, err := json.Marshal(inner)
+	require.NoError(t, err)
+
+	item, err := parseMessage("warming", body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "65f0c0ffee0000000000aaaa", item.TaskID)
+	assert.Empty(t, item.AccountID)
+	assert.Equal(t, "account banned", item.Reason)
+	assert.Nil(t, item.Details)
+}
+
+func TestParseMessageRejectsInvalid(t *testing.T) {
+	for _, body := range []string{
+		`not json`,
+		`{"reason": "no subject"}`,
+		`"bm90IGpzb24="`,
+	} {
+		_, err := parseMessage("mail", []byte(body))
+		assert.True(t, errors.Is(err, models.ErrInvalidMessage), "message %s should be invalid", body)
+	}
+}
+
+func TestParseMessageFallsBackToError(t *testing.T) {
+	item, err := parseMessage("max", []byte(`{"account_id": "a1", "error": "captcha"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "captcha", item.Reason)
+}
+
+func TestRetryCommands(t *testing.T) {
+	item := &models.Intervention{AccountID: "a1", RetryCount: 1}
+
+	vk := platformRoutes["vk"].retryCommand(item).(map[string]interface{})
+	assert.Equal(t, "a1", vk["account_id"])
+	assert.Equal(t, 2, vk["retry_count"])
+
+	for _, platform := range []string{"mail", "max"} {
+		route := platformRoutes[platform]
+		assert.Equal(t, platform+".commands", route.CommandExchange)
+		assert.Equal(t, platform+".retry", route.CommandKey)
+		assert.Equal(t, map[string]interface{}{"accountID": "a1", "retryCount": 2}, route.retryCommand(item))
+	}
+
+	assert.Nil(t, platformRoutes["warming"].retryCommand)
+}
+
+func TestLeastLoadedOperator(t *testing.T) {
+	operators := []string{"anna", "boris", "vera"}
+
+	assert.Equal(t, "anna", leastLoadedOperator(operators, map[string]int{}))
+	assert.Equal(t, "vera", leastLoadedOperator(operators, map[string]int{"anna": 2, "boris": 1}))
+	assert.Equal(t, "boris", leastLoadedOperator(operators, map[string]int{"anna": 3, "boris": 1, "vera": 1, "gleb": 0}))
+	assert.Empty(t, leastLoadedOperator(nil, map[string]int{"anna": 0}))
+}
@@ -0,0 +1,703 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.2
+// source: services/intervention-service/proto/intervention.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListInterventionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	Operator      string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	SlaBreached   bool                   `protobuf:"varint,4,opt,name=sla_breached,json=slaBreached,proto3" json:"sla_breached,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInterventionsRequest) Reset() {
+	*x = ListInterventionsRequest{}
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInterventionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterventionsRequest) ProtoMessage() {}
+
+func (x *ListInterventionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterventionsRequest.ProtoReflect.Descriptor instead.
+func (*ListInterventionsRequest) Descriptor() ([]byte, []int) {
+	return file_services_intervention_service_proto_intervention_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListInterventionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListInterventionsRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ListInterventionsRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ListInterventionsRequest) GetSlaBreached() bool {
+	if x != nil {
+		return x.SlaBreached
+	}
+	return false
+}
+
+func (x *ListInterventionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListInterventionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interventions []*Intervention        `protobuf:"bytes,1,rep,name=interventions,proto3" json:"interventions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInterventionsResponse) Reset() {
+	*x = ListInterventionsResponse{}
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInterventionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterventionsResponse) ProtoMessage() {}
+
+func (x *ListInterventionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterventionsResponse.ProtoReflect.Descriptor instead.
+func (*ListInterventionsResponse) Descriptor() ([]byte, []int) {
+	return file_services_intervention_service_proto_intervention_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListInterventionsResponse) GetInterventions() []*Intervention {
+	if x != nil {
+		return x.Interventions
+	}
+	return nil
+}
+
+type GetInterventionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	InterventionId string                 `protobuf:"bytes,1,opt,name=intervention_id,json=interventionId,proto3" json:"intervention_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetInterventionRequest) Reset() {
+	*x = GetInterventionRequest{}
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInterventionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInterventionRequest) ProtoMessage() {}
+
+func (x *GetInterventionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInterventionRequest.ProtoReflect.Descriptor instead.
+func (*GetInterventionRequest) Descriptor() ([]byte, []int) {
+	return file_services_intervention_service_proto_intervention_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetInterventionRequest) GetInterventionId() string {
+	if x != nil {
+		return x.InterventionId
+	}
+	return ""
+}
+
+type ClaimInterventionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	InterventionId string                 `protobuf:"bytes,1,opt,name=intervention_id,json=interventionId,proto3" json:"intervention_id,omitempty"`
+	Operator       string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClaimInterventionRequest) Reset() {
+	*x = ClaimInterventionRequest{}
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimInterventionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimInterventionRequest) ProtoMessage() {}
+
+func (x *ClaimInterventionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimInterventionRequest.ProtoReflect.Descriptor instead.
+func (*ClaimInterventionRequest) Descriptor() ([]byte, []int) {
+	return file_services_intervention_service_proto_intervention_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClaimInterventionRequest) GetInterventionId() string {
+	if x != nil {
+		return x.InterventionId
+	}
+	return ""
+}
+
+func (x *ClaimInterventionRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+type ResolveInterventionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	InterventionId string                 `protobuf:"bytes,1,opt,name=intervention_id,json=interventionId,proto3" json:"intervention_id,omitempty"`
+	Operator       string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	// retry or dismiss
+	Resolution    string `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	Note          string `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveInterventionRequest) Reset() {
+	*x = ResolveInterventionRequest{}
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveInterventionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveInterventionRequest) ProtoMessage() {}
+
+func (x *ResolveInterventionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveInterventionRequest.ProtoReflect.Descriptor instead.
+func (*ResolveInterventionRequest) Descriptor() ([]byte, []int) {
+	return file_services_intervention_service_proto_intervention_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ResolveInterventionRequest) GetInterventionId() string {
+	if x != nil {
+		return x.InterventionId
+	}
+	return ""
+}
+
+func (x *ResolveInterventionRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ResolveInterventionRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *ResolveInterventionRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type RequeueInterventionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	InterventionId string                 `protobuf:"bytes,1,opt,name=intervention_id,json=interventionId,proto3" json:"intervention_id,omitempty"`
+	Operator       string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Note           string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RequeueInterventionRequest) Reset() {
+	*x = RequeueInterventionRequest{}
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueInterventionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueInterventionRequest) ProtoMessage() {}
+
+func (x *RequeueInterventionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueInterventionRequest.ProtoReflect.Descriptor instead.
+func (*RequeueInterventionRequest) Descriptor() ([]byte, []int) {
+	return file_services_intervention_service_proto_intervention_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RequeueInterventionRequest) GetInterventionId() string {
+	if x != nil {
+		return x.InterventionId
+	}
+	return ""
+}
+
+func (x *RequeueInterventionRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *RequeueInterventionRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type Intervention struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Platform       string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	AccountId      string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TaskId         string                 `protobuf:"bytes,4,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Step           string                 `protobuf:"bytes,6,opt,name=step,proto3" json:"step,omitempty"`
+	Error          string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	RetryCount     int32                  `protobuf:"varint,8,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	Status         string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	Occurrences    int32                  `protobuf:"varint,10,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	Operator       string                 `protobuf:"bytes,11,opt,name=operator,proto3" json:"operator,omitempty"`
+	AssignedAt     int64                  `protobuf:"varint,12,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	Requeues       int32                  `protobuf:"varint,13,opt,name=requeues,proto3" json:"requeues,omitempty"`
+	DueAt          int64                  `protobuf:"varint,14,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	SlaBreachedAt  int64                  `protobuf:"varint,15,opt,name=sla_breached_at,json=slaBreachedAt,proto3" json:"sla_breached_at,omitempty"`
+	Resolution     string                 `protobuf:"bytes,16,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	ResolutionNote string                 `protobuf:"bytes,17,opt,name=resolution_note,json=resolutionNote,proto3" json:"resolution_note,omitempty"`
+	ResolvedBy     string                 `protobuf:"bytes,18,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	ResolvedAt     int64                  `protobuf:"varint,19,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	FirstSeenAt    int64                  `protobuf:"varint,20,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	LastSeenAt     int64                  `protobuf:"varint,21,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Intervention) Reset() {
+	*x = Intervention{}
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Intervention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Intervention) ProtoMessage() {}
+
+func (x *Intervention) ProtoReflect() protoreflect.Message {
+	mi := &file_services_intervention_service_proto_intervention_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Intervention.ProtoReflect.Descriptor instead.
+func (*Intervention) Descriptor() ([]byte, []int) {
+	return file_services_intervention_service_proto_intervention_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Intervention) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Intervention) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Intervention) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Intervention) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *Intervention) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Intervention) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *Intervention) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Intervention) GetRetryCount() int32 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *Intervention) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Intervention) GetOccurrences() int32 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return 0
+}
+
+func (x *Intervention) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *Intervention) GetAssignedAt() int64 {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return 0
+}
+
+func (x *Intervention) GetRequeues() int32 {
+	if x != nil {
+		return x.Requeues
+	}
+	return 0
+}
+
+func (x *Intervention) GetDueAt() int64 {
+	if x != nil {
+		return x.DueAt
+	}
+	return 0
+}
+
+func (x *Intervention) GetSlaBreachedAt() int64 {
+	if x != nil {
+		return x.SlaBreachedAt
+	}
+	return 0
+}
+
+func (x *Intervention) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *Intervention) GetResolutionNote() string {
+	if x != nil {
+		return x.ResolutionNote
+	}
+	return ""
+}
+
+func (x *Intervention) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Intervention) GetResolvedAt() int64 {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return 0
+}
+
+func (x *Intervention) GetFirstSeenAt() int64 {
+	if x != nil {
+		return x.FirstSeenAt
+	}
+	return 0
+}
+
+func (x *Intervention) GetLastSeenAt() int64 {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return 0
+}
+
+var File_services_intervention_service_proto_intervention_proto protoreflect.FileDescriptor
+
+const file_services_intervention_service_proto_intervention_proto_rawDesc = "" +
+	"\n" +
+	"6services/intervention-service/proto/intervention.proto\x12\fintervention\"\xa3\x01\n" +
+	"\x18ListInterventionsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12\x1a\n" +
+	"\boperator\x18\x03 \x01(\tR\boperator\x12!\n" +
+	"\fsla_breached\x18\x04 \x01(\bR\vslaBreached\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"]\n" +
+	"\x19ListInterventionsResponse\x12@\n" +
+	"\rinterventions\x18\x01 \x03(\v2\x1a.intervention.InterventionR\rinterventions\"A\n" +
+	"\x16GetInterventionRequest\x12'\n" +
+	"\x0fintervention_id\x18\x01 \x01(\tR\x0einterventionId\"_\n" +
+	"\x18ClaimInterventionRequest\x12'\n" +
+	"\x0fintervention_id\x18\x01 \x01(\tR\x0einterventionId\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\"\x95\x01\n" +
+	"\x1aResolveInterventionRequest\x12'\n" +
+	"\x0fintervention_id\x18\x01 \x01(\tR\x0einterventionId\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\tR\n" +
+	"resolution\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"u\n" +
+	"\x1aRequeueInterventionRequest\x12'\n" +
+	"\x0fintervention_id\x18\x01 \x01(\tR\x0einterventionId\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"\xf8\x04\n" +
+	"\fIntervention\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x17\n" +
+	"\atask_id\x18\x04 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x12\n" +
+	"\x04step\x18\x06 \x01(\tR\x04step\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1f\n" +
+	"\vretry_count\x18\b \x01(\x05R\n" +
+	"retryCount\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12 \n" +
+	"\voccurrences\x18\n" +
+	" \x01(\x05R\voccurrences\x12\x1a\n" +
+	"\boperator\x18\v \x01(\tR\boperator\x12\x1f\n" +
+	"\vassigned_at\x18\f \x01(\x03R\n" +
+	"assignedAt\x12\x1a\n" +
+	"\brequeues\x18\r \x01(\x05R\brequeues\x12\x15\n" +
+	"\x06due_at\x18\x0e \x01(\x03R\x05dueAt\x12&\n" +
+	"\x0fsla_breached_at\x18\x0f \x01(\x03R\rslaBreachedAt\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x10 \x01(\tR\n" +
+	"resolution\x12'\n" +
+	"\x0fresolution_note\x18\x11 \x01(\tR\x0eresolutionNote\x12\x1f\n" +
+	"\vresolved_by\x18\x12 \x01(\tR\n" +
+	"resolvedBy\x12\x1f\n" +
+	"\vresolved_at\x18\x13 \x01(\x03R\n" +
+	"resolvedAt\x12\"\n" +
+	"\rfirst_seen_at\x18\x14 \x01(\x03R\vfirstSeenAt\x12 \n" +
+	"\flast_seen_at\x18\x15 \x01(\x03R\n" +
+	"lastSeenAt2\xe3\x03\n" +
+	"\x13InterventionService\x12d\n" +
+	"\x11ListInterventions\x12&.intervention.ListInterventionsRequest\x1a'.intervention.ListInterventionsResponse\x12S\n" +
+	"\x0fGetIntervention\x12$.intervention.GetInterventionRequest\x1a\x1a.intervention.Intervention\x12W\n" +
+	"\x11ClaimIntervention\x12&.intervention.ClaimInterventionRequest\x1a\x1a.intervention.Intervention\x12[\n" +
+	"\x13ResolveIntervention\x12(.intervention.ResolveInterventionRequest\x1a\x1a.intervention.Intervention\x12[\n" +
+	"\x13RequeueIntervention\x12(.intervention.RequeueInterventionRequest\x1a\x1a.intervention.InterventionB?Z=github.com/grigta/conveer/services/intervention-service/protob\x06proto3"
+
+var (
+	file_services_intervention_service_proto_intervention_proto_rawDescOnce sync.Once
+	file_services_intervention_service_proto_intervention_proto_rawDescData []byte
+)
+
+func file_services_intervention_service_proto_intervention_proto_rawDescGZIP() []byte {
+	file_services_intervention_service_proto_intervention_proto_rawDescOnce.Do(func() {
+		file_services_intervention_service_proto_intervention_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_services_intervention_service_proto_intervention_proto_rawDesc), len(file_services_intervention_service_proto_intervention_proto_rawDesc)))
+	})
+	return file_services_intervention_service_proto_intervention_proto_rawDescData
+}
+
+var file_services_intervention_service_proto_intervention_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_services_intervention_service_proto_intervention_proto_goTypes = []any{
+	(*ListInterventionsRequest)(nil),   // 0: intervention.ListInterventionsRequest
+	(*ListInterventionsResponse)(nil),  // 1: intervention.ListInterventionsResponse
+	(*GetInterventionRequest)(nil),     // 2: intervention.GetInterventionRequest
+	(*ClaimInterventionRequest)(nil),   // 3: intervention.ClaimInterventionRequest
+	(*ResolveInterventionRequest)(nil), // 4: intervention.ResolveInterventionRequest
+	(*RequeueInterventionRequest)(nil), // 5: intervention.RequeueInterventionRequest
+	(*Intervention)(nil),               // 6: intervention.Intervention
+}
+var file_services_intervention_service_proto_intervention_proto_depIdxs = []int32{
+	6, // 0: intervention.ListInterventionsResponse.interventions:type_name -> intervention.Intervention
+	0, // 1: intervention.InterventionService.ListInterventions:input_type -> intervention.ListInterventionsRequest
+	2, // 2: intervention.InterventionService.GetIntervention:input_type -> intervention.GetInterventionRequest
+	3, // 3: intervention.InterventionService.ClaimIntervention:input_type -> intervention.ClaimInterventionRequest
+	4, // 4: intervention.InterventionService.ResolveIntervention:input_type -> intervention.ResolveInterventionRequest
+	5, // 5: intervention.InterventionService.RequeueIntervention:input_type -> intervention.RequeueInterventionRequest
+	1, // 6: intervention.InterventionService.ListInterventions:output_type -> intervention.ListInterventionsResponse
+	6, // 7: intervention.InterventionService.GetIntervention:output_type -> intervention.Intervention
+	6, // 8: intervention.InterventionService.ClaimIntervention:output_type -> intervention.Intervention
+	6, // 9: intervention.InterventionService.ResolveIntervention:output_type -> intervention.Intervention
+	6, // 10: intervention.InterventionService.RequeueIntervention:output_type -> intervention.Intervention
+	6, // [6:11] is the sub-list for method output_type
+	1, // [1:6] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_services_intervention_service_proto_intervention_proto_init() }
+func file_services_intervention_service_proto_intervention_proto_init() {
+	if File_services_intervention_service_proto_intervention_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_intervention_service_proto_intervention_proto_rawDesc), len(file_services_intervention_service_proto_intervention_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_services_intervention_service_proto_intervention_proto_goTypes,
+		DependencyIndexes: file_services_intervention_service_proto_intervention_proto_depIdxs,
+		MessageInfos:      file_services_intervention_service_proto_intervention_proto_msgTypes,
+	}.Build()
+	File_services_intervention_service_proto_intervention_proto = out.File
+	file_services_intervention_service_proto_intervention_proto_goTypes = nil
+	file_services_intervention_service_proto_intervention_proto_depIdxs = nil
+}
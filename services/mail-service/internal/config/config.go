@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/grigta/conveer/services/mail-service/internal/models"
@@ -17,7 +18,10 @@ type Config struct {
 	RabbitMQ     RabbitMQConfig     `yaml:"rabbitmq"`
 	ProxyService ProxyServiceConfig `yaml:"proxy_service"`
 	SMSService   SMSServiceConfig   `yaml:"sms_service"`
+	PersonaService PersonaServiceConfig `yaml:"persona_service"`
 	Registration models.RegistrationConfig `yaml:"registration"`
+	MailboxCheck models.MailboxCheckConfig `yaml:"mailbox_check"`
+	MailboxHealth models.MailboxHealthConfig `yaml:"mailbox_health"`
 	Browser      BrowserConfig      `yaml:"browser"`
 	Encryption   EncryptionConfig   `yaml:"encryption"`
 }
@@ -59,6 +63,12 @@ type SMSServiceConfig struct {
 	Timeout time.Duration `yaml:"timeout"`
 }
 
+// PersonaServiceConfig represents persona service configuration
+type PersonaServiceConfig struct {
+	Address string        `yaml:"address"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
 // BrowserConfig represents browser configuration
 type BrowserConfig struct {
 	PoolSize       int           `yaml:"pool_size"`
@@ -102,6 +112,10 @@ func LoadConfig(path string) (*Config, error) {
 			Address: "sms-service:50058",
 			Timeout: 30 * time.Second,
 		},
+		PersonaService: PersonaServiceConfig{
+			Address: "persona-service:50064",
+			Timeout: 10 * time.Second,
+		},
 		Registration: models.RegistrationConfig{
 			MaxRetryAttempts:      3,
 			RetryBackoffBase:      5 * time.Minute,
@@ -113,6 +127,29 @@ func LoadConfig(path string) (*Config, error) {
 			MaxSMSPolls:           30,
 			EnablePhoneVerification: true,
 			CaptchaTimeout:        10 * time.Minute,
+			Workers:               4,
+			Prefetch:              4,
+			ProxyMinInterval:      2 * time.Minute,
+			ProxyMaxConcurrent:    1,
+			ShutdownTimeout:       5 * time.Minute,
+			DefaultProvider:       models.MailProviderMailRu,
+		},
+		MailboxCheck: models.MailboxCheckConfig{
+			Enabled:      false,
+			SMTPPort:     587,
+			IMAPHost:     "imap.mail.ru",
+			IMAPPort:     993,
+			Timeout:      3 * time.Minute,
+			PollInterval: 15 * time.Second,
+		},
+		MailboxHealth: models.MailboxHealthConfig{
+			Enabled:          false,
+			Interval:         10 * time.Minute,
+			CheckEvery:       24 * time.Hour,
+			ProbeEvery:       7 * 24 * time.Hour,
+			BatchSize:        50,
+			QuotaThreshold:   0.95,
+			MaxLoginFailures: 3,
 		},
 		Browser: BrowserConfig{
 			PoolSize:       10,
@@ -146,5 +183,77 @@ func LoadConfig(path string) (*Config, error) {
 		config.Service.HTTPPort = httpPort
 	}
 	
+	if personaServiceURL := os.Getenv("PERSONA_SERVICE_GRPC_URL"); personaServiceURL != "" {
+		config.PersonaService.Address = personaServiceURL
+	}
+	
+	if workers := os.Getenv("MAIL_REGISTRATION_WORKERS"); workers != "" {
+		if n, err := strconv.Atoi(workers); err == nil && n > 0 {
+			config.Registration.Workers = n
+		}
+	}
+	if prefetch := os.Getenv("MAIL_REGISTRATION_PREFETCH"); prefetch != "" {
+		if n, err := strconv.Atoi(prefetch); err == nil && n >= 0 {
+			config.Registration.Prefetch = n
+		}
+	}
+	if interval := os.Getenv("MAIL_PROXY_MIN_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil {
+			config.Registration.ProxyMinInterval = d
+		}
+	}
+	if concurrent := os.Getenv("MAIL_PROXY_MAX_CONCURRENT"); concurrent != "" {
+		if n, err := strconv.Atoi(concurrent); err == nil && n > 0 {
+			config.Registration.ProxyMaxConcurrent = n
+		}
+	}
+	if timeout := os.Getenv("MAIL_SHUTDOWN_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			config.Registration.ShutdownTimeout = d
+		}
+	}
+	if provider := os.Getenv("MAIL_DEFAULT_PROVIDER"); provider != "" {
+		config.Registration.DefaultProvider = provider
+	}
+	
+	if enabled := os.Getenv("MAIL_MAILBOX_CHECK_ENABLED"); enabled != "" {
+		if b, err := strconv.ParseBool(enabled); err == nil {
+			config.MailboxCheck.Enabled = b
+		}
+	}
+	if host := os.Getenv("MAIL_PROBE_SMTP_HOST"); host != "" {
+		config.MailboxCheck.SMTPHost = host
+	}
+	if port := os.Getenv("MAIL_PROBE_SMTP_PORT"); port != "" {
+		if n, err := strconv.Atoi(port); err == nil && n > 0 {
+			config.MailboxCheck.SMTPPort = n
+		}
+	}
+	if username := os.Getenv("MAIL_PROBE_SMTP_USERNAME"); username != "" {
+		config.MailboxCheck.SMTPUsername = username
+	}
+	if password := os.Getenv("MAIL_PROBE_SMTP_PASSWORD"); password != "" {
+		config.MailboxCheck.SMTPPassword = password
+	}
+	if from := os.Getenv("MAIL_PROBE_FROM"); from != "" {
+		config.MailboxCheck.From = from
+	}
+	
+	if enabled := os.Getenv("MAIL_MAILBOX_HEALTH_ENABLED"); enabled != "" {
+		if b, err := strconv.ParseBool(enabled); err == nil {
+			config.MailboxHealth.Enabled = b
+		}
+	}
+	if every := os.Getenv("MAIL_MAILBOX_HEALTH_CHECK_EVERY"); every != "" {
+		if d, err := time.ParseDuration(every); err == nil && d > 0 {
+			config.MailboxHealth.CheckEvery = d
+		}
+	}
+	if every := os.Getenv("MAIL_MAILBOX_HEALTH_PROBE_EVERY"); every != "" {
+		if d, err := time.ParseDuration(every); err == nil && d >= 0 {
+			config.MailboxHealth.ProbeEvery = d
+		}
+	}
+	
 	return config, nil
 }
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/grigta/conveer/services/mail-service/internal/models"
 	"github.com/grigta/conveer/services/mail-service/internal/service"
@@ -33,10 +35,16 @@ func (h *GRPCHandler) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 		PreferredCountry:     req.PreferredCountry,
 		UsePhoneVerification: req.UsePhoneVerification,
 		CustomEmailPrefix:    req.CustomEmailPrefix,
+		UseRandomProfile:     req.UseRandomProfile,
+		PersonaID:            req.PersonaId,
+		Provider:             req.Provider,
 	}
 	
 	result, err := h.service.CreateAccount(ctx, registrationReq)
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownMailProvider) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	
@@ -55,18 +63,21 @@ func (h *GRPCHandler) GetAccount(ctx context.Context, req *pb.GetAccountRequest)
 	}
 	
 	return &pb.Account{
-		Id:           account.ID.Hex(),
-		Email:        account.Email,
-		FirstName:    account.FirstName,
-		LastName:     account.LastName,
-		BirthDate:    account.BirthDate,
-		Gender:       account.Gender,
-		Status:       string(account.Status),
-		Phone:        account.Phone,
-		CreatedAt:    account.CreatedAt.Unix(),
-		UpdatedAt:    account.UpdatedAt.Unix(),
-		ErrorMessage: account.ErrorMessage,
-		RetryCount:   int32(account.RetryCount),
+		Id:                 account.ID.Hex(),
+		Email:              account.Email,
+		FirstName:          account.FirstName,
+		LastName:           account.LastName,
+		BirthDate:          account.BirthDate,
+		Gender:             account.Gender,
+		Status:             string(account.Status),
+		Phone:              account.Phone,
+		CreatedAt:          account.CreatedAt.Unix(),
+		UpdatedAt:          account.UpdatedAt.Unix(),
+		ErrorMessage:       account.ErrorMessage,
+		RetryCount:         int32(account.RetryCount),
+		PersonaId:          account.PersonaID,
+		MailboxCheckStatus: mailboxCheckStatus(account),
+		Provider:           accountProvider(account),
 	}, nil
 }
 
@@ -76,6 +87,9 @@ func (h *GRPCHandler) ListAccounts(ctx context.Context, req *pb.ListAccountsRequ
 	if req.Status != "" {
 		filter["status"] = req.Status
 	}
+	if req.Provider != "" {
+		filter["provider"] = service.AccountProviderFilter(req.Provider)
+	}
 	filter["deleted_at"] = nil
 	
 	accounts, total, err := h.service.ListAccounts(ctx, filter, int(req.Limit), int(req.Offset))
@@ -86,18 +100,21 @@ func (h *GRPCHandler) ListAccounts(ctx context.Context, req *pb.ListAccountsRequ
 	pbAccounts := make([]*pb.Account, len(accounts))
 	for i, account := range accounts {
 		pbAccounts[i] = &pb.Account{
-			Id:           account.ID.Hex(),
-			Email:        account.Email,
-			FirstName:    account.FirstName,
-			LastName:     account.LastName,
-			BirthDate:    account.BirthDate,
-			Gender:       account.Gender,
-			Status:       string(account.Status),
-			Phone:        account.Phone,
-			CreatedAt:    account.CreatedAt.Unix(),
-			UpdatedAt:    account.UpdatedAt.Unix(),
-			ErrorMessage: account.ErrorMessage,
-			RetryCount:   int32(account.RetryCount),
+			Id:                 account.ID.Hex(),
+			Email:              account.Email,
+			FirstName:          account.FirstName,
+			LastName:           account.LastName,
+			BirthDate:          account.BirthDate,
+			Gender:             account.Gender,
+			Status:             string(account.Status),
+			Phone:              account.Phone,
+			CreatedAt:          account.CreatedAt.Unix(),
+			UpdatedAt:          account.UpdatedAt.Unix(),
+			ErrorMessage:       account.ErrorMessage,
+			RetryCount:         int32(account.RetryCount),
+			PersonaId:          account.PersonaID,
+			MailboxCheckStatus: mailboxCheckStatus(account),
+			Provider:           accountProvider(account),
 		}
 	}
 	
@@ -170,3 +187,96 @@ func (h *GRPCHandler) GetStatistics(ctx context.Context, req *pb.GetStatisticsRe
 		Last_24Hours:     stats.Last24Hours,
 	}, nil
 }
+
+// VerifyMailbox sends a probe email to the account and reports where it was delivered
+func (h *GRPCHandler) VerifyMailbox(ctx context.Context, req *pb.VerifyMailboxRequest) (*pb.MailboxCheck, error) {
+	check, err := h.service.VerifyMailbox(ctx, req.AccountId)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	
+	return &pb.MailboxCheck{
+		AccountId: req.AccountId,
+		Status:    string(check.Status),
+		Delivered: check.Delivered,
+		Folder:    check.Folder,
+		LatencyMs: check.LatencyMs,
+		Error:     check.Error,
+		CheckedAt: check.CheckedAt.Unix(),
+	}, nil
+}
+
+// ReserveRecoveryMailbox binds a verified mailbox to an account on another platform
+func (h *GRPCHandler) ReserveRecoveryMailbox(ctx context.Context, req *pb.ReserveRecoveryMailboxRequest) (*pb.RecoveryMailbox, error) {
+	if req.Platform == "" || req.ConsumerAccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "platform and consumer_account_id are required")
+	}
+
+	account, err := h.service.ReserveRecoveryMailbox(ctx, req.Platform, req.ConsumerAccountId)
+	if err != nil {
+		if errors.Is(err, service.ErrNoRecoveryMailbox) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	mailbox := &pb.RecoveryMailbox{
+		AccountId: account.ID.Hex(),
+		Email:     account.Email,
+	}
+	if account.BoundTo != nil {
+		mailbox.BoundAt = account.BoundTo.BoundAt.Unix()
+	}
+	return mailbox, nil
+}
+
+// ReleaseRecoveryMailbox frees the mailbox bound to an account on another platform
+func (h *GRPCHandler) ReleaseRecoveryMailbox(ctx context.Context, req *pb.ReleaseRecoveryMailboxRequest) (*pb.ReleaseRecoveryMailboxResponse, error) {
+	if req.Platform == "" || req.ConsumerAccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "platform and consumer_account_id are required")
+	}
+
+	if err := h.service.ReleaseRecoveryMailbox(ctx, req.Platform, req.ConsumerAccountId); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ReleaseRecoveryMailboxResponse{Success: true}, nil
+}
+
+// ReadVerificationMail looks up the latest email from a sender in the mailbox
+func (h *GRPCHandler) ReadVerificationMail(ctx context.Context, req *pb.ReadVerificationMailRequest) (*pb.VerificationMail, error) {
+	if req.From == "" {
+		return nil, status.Error(codes.InvalidArgument, "from is required")
+	}
+
+	found, err := h.service.ReadVerificationMail(ctx, req.AccountId, req.From, time.Unix(req.Since, 0))
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if found == nil {
+		return &pb.VerificationMail{}, nil
+	}
+
+	return &pb.VerificationMail{
+		Found:      true,
+		Subject:    found.Subject,
+		Links:      found.Links,
+		Code:       found.Code,
+		ReceivedAt: found.ReceivedAt.Unix(),
+	}, nil
+}
+
+func mailboxCheckStatus(account *models.MailAccount) string {
+	if account.MailboxCheck == nil {
+		return ""
+	}
+	return string(account.MailboxCheck.Status)
+}
+
+// accountProvider reports accounts created before provider selection as mail.ru ones
+func accountProvider(account *models.MailAccount) string {
+	if account.Provider == "" {
+		return models.MailProviderMailRu
+	}
+	return account.Provider
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/grigta/conveer/pkg/health"
 	"github.com/grigta/conveer/services/mail-service/internal/models"
 	"github.com/grigta/conveer/services/mail-service/internal/service"
 	"github.com/gin-gonic/gin"
@@ -12,13 +14,17 @@ import (
 
 // HTTPHandler handles HTTP requests
 type HTTPHandler struct {
-	service *service.MailService
+	service        *service.MailService
+	browserManager *service.BrowserManager
+	healthChecker  *health.Checker
 }
 
 // NewHTTPHandler creates a new HTTP handler
-func NewHTTPHandler(service *service.MailService) *HTTPHandler {
+func NewHTTPHandler(service *service.MailService, browserManager *service.BrowserManager, healthChecker *health.Checker) *HTTPHandler {
 	return &HTTPHandler{
-		service: service,
+		service:        service,
+		browserManager: browserManager,
+		healthChecker:  healthChecker,
 	}
 }
 
@@ -31,12 +37,16 @@ func (h *HTTPHandler) RegisterRoutes(router *gin.Engine) {
 		api.GET("/accounts/:id", h.GetAccount)
 		api.PUT("/accounts/:id/status", h.UpdateAccountStatus)
 		api.POST("/accounts/:id/retry", h.RetryRegistration)
+		api.POST("/accounts/:id/verify-mailbox", h.VerifyMailbox)
+		api.POST("/accounts/:id/check-health", h.CheckMailboxHealth)
 		api.DELETE("/accounts/:id", h.DeleteAccount)
 		api.GET("/statistics", h.GetStatistics)
+		api.GET("/browser-pool", h.GetBrowserPool)
+		api.PUT("/browser-pool", h.ResizeBrowserPool)
 	}
 	
 	// Health check
-	router.GET("/health", h.HealthCheck)
+	router.GET("/health", h.healthChecker.Handler())
 	
 	// Metrics
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -52,6 +62,10 @@ func (h *HTTPHandler) CreateAccount(c *gin.Context) {
 	
 	result, err := h.service.CreateAccount(c.Request.Context(), &req)
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownMailProvider) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -78,11 +92,23 @@ func (h *HTTPHandler) ListAccounts(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 	status := c.Query("status")
+	mailboxCheck := c.Query("mailbox_check")
+	mailboxHealth := c.Query("mailbox_health")
+	provider := c.Query("provider")
 	
 	filter := make(map[string]interface{})
 	if status != "" {
 		filter["status"] = status
 	}
+	if mailboxCheck != "" {
+		filter["mailbox_check.status"] = mailboxCheck
+	}
+	if mailboxHealth != "" {
+		filter["mailbox_health.status"] = mailboxHealth
+	}
+	if provider != "" {
+		filter["provider"] = service.AccountProviderFilter(provider)
+	}
 	filter["deleted_at"] = nil
 	
 	accounts, total, err := h.service.ListAccounts(c.Request.Context(), filter, limit, offset)
@@ -141,6 +167,32 @@ func (h *HTTPHandler) RetryRegistration(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"success": true})
 }
 
+// VerifyMailbox checks that the account's mailbox receives mail
+func (h *HTTPHandler) VerifyMailbox(c *gin.Context) {
+	id := c.Param("id")
+	
+	check, err := h.service.VerifyMailbox(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	c.JSON(http.StatusOK, check)
+}
+
+// CheckMailboxHealth runs the periodic mailbox health check for the account right away
+func (h *HTTPHandler) CheckMailboxHealth(c *gin.Context) {
+	id := c.Param("id")
+	
+	health, err := h.service.CheckMailboxHealth(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	c.JSON(http.StatusOK, health)
+}
+
 // DeleteAccount deletes an account
 func (h *HTTPHandler) DeleteAccount(c *gin.Context) {
 	id := c.Param("id")
@@ -165,10 +217,30 @@ func (h *HTTPHandler) GetStatistics(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
-// HealthCheck returns service health
-func (h *HTTPHandler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "mail-service",
-	})
+// GetBrowserPool returns browser pool state
+func (h *HTTPHandler) GetBrowserPool(c *gin.Context) {
+	c.JSON(http.StatusOK, h.browserManager.Stats())
+}
+
+// ResizeBrowserPool grows or shrinks the browser pool without a restart
+func (h *HTTPHandler) ResizeBrowserPool(c *gin.Context) {
+	var req struct {
+		Size int `json:"size" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats, err := h.browserManager.Resize(req.Size)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPoolSize) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
 }
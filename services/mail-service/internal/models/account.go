@@ -6,9 +6,10 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// MailAccount represents a Mail.ru account
+// MailAccount represents a mailbox account at one of the mail providers
 type MailAccount struct {
 	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Provider          string             `bson:"provider,omitempty" json:"provider,omitempty"`
 	Email             string             `bson:"email,encrypted" json:"email"`
 	Password          string             `bson:"password,encrypted" json:"password"`
 	RecoveryEmail     string             `bson:"recovery_email" json:"recovery_email,omitempty"`
@@ -29,6 +30,10 @@ type MailAccount struct {
 	LastLoginAt       *time.Time         `bson:"last_login_at,omitempty" json:"last_login_at,omitempty"`
 	ErrorMessage      string             `bson:"error_message,omitempty" json:"error_message,omitempty"`
 	RetryCount        int                `bson:"retry_count" json:"retry_count"`
+	PersonaID         string             `bson:"persona_id,omitempty" json:"persona_id,omitempty"`
+	MailboxCheck      *MailboxCheck      `bson:"mailbox_check,omitempty" json:"mailbox_check,omitempty"`
+	MailboxHealth     *MailboxHealth     `bson:"mailbox_health,omitempty" json:"mailbox_health,omitempty"`
+	BoundTo           *MailboxBinding    `bson:"bound_to,omitempty" json:"bound_to,omitempty"`
 }
 
 // AccountStatus represents the status of an account
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// MailboxCheckStatus represents the outcome of a mailbox delivery check
+type MailboxCheckStatus string
+
+const (
+	MailboxCheckPassed MailboxCheckStatus = "passed"
+	MailboxCheckSpam   MailboxCheckStatus = "spam"
+	MailboxCheckFailed MailboxCheckStatus = "failed"
+)
+
+// MailboxCheck represents the result of sending a probe email to an account and looking it up over IMAP
+type MailboxCheck struct {
+	ProbeID   string             `bson:"probe_id" json:"probe_id"`
+	Status    MailboxCheckStatus `bson:"status" json:"status"`
+	Delivered bool               `bson:"delivered" json:"delivered"`
+	Folder    string             `bson:"folder,omitempty" json:"folder,omitempty"`
+	LatencyMs int64              `bson:"latency_ms,omitempty" json:"latency_ms,omitempty"`
+	Error     string             `bson:"error,omitempty" json:"error,omitempty"`
+	CheckedAt time.Time          `bson:"checked_at" json:"checked_at"`
+}
+
+// Passed reports whether the probe landed in the inbox
+func (c *MailboxCheck) Passed() bool {
+	return c != nil && c.Status == MailboxCheckPassed
+}
+
+// MailboxCheckConfig represents configuration for mailbox delivery checks
+type MailboxCheckConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	SMTPHost     string        `yaml:"smtp_host"`
+	SMTPPort     int           `yaml:"smtp_port"`
+	SMTPUsername string        `yaml:"smtp_username"`
+	SMTPPassword string        `yaml:"smtp_password"`
+	From         string        `yaml:"from"`
+	IMAPHost     string        `yaml:"imap_host"`
+	IMAPPort     int           `yaml:"imap_port"`
+	Timeout      time.Duration `yaml:"timeout"`
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
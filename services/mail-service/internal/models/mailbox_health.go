@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// MailboxHealthStatus represents the outcome of a periodic mailbox health check
+type MailboxHealthStatus string
+
+const (
+	MailboxHealthy     MailboxHealthStatus = "healthy"
+	MailboxLocked      MailboxHealthStatus = "locked"       // the provider blocked or suspended the mailbox
+	MailboxLoginFailed MailboxHealthStatus = "login_failed" // the credentials were rejected without a reason
+	MailboxQuotaFull   MailboxHealthStatus = "quota_full"
+	MailboxSpamPlaced  MailboxHealthStatus = "spam"        // the latest probe landed in the spam folder
+	MailboxUnreachable MailboxHealthStatus = "unreachable" // the IMAP server could not be reached, nothing is concluded
+)
+
+// MailboxHealth represents the latest periodic check of a mailbox: IMAP login, storage quota
+// and, every few checks, spam placement of a probe email (its result is in MailboxCheck)
+type MailboxHealth struct {
+	Status           MailboxHealthStatus `bson:"status" json:"status"`
+	LoginOK          bool                `bson:"login_ok" json:"login_ok"`
+	Error            string              `bson:"error,omitempty" json:"error,omitempty"`
+	QuotaUsedKB      int64               `bson:"quota_used_kb,omitempty" json:"quota_used_kb,omitempty"`
+	QuotaLimitKB     int64               `bson:"quota_limit_kb,omitempty" json:"quota_limit_kb,omitempty"`
+	LoginFailures    int                 `bson:"login_failures,omitempty" json:"login_failures,omitempty"` // rejected logins in a row
+	SuspendedByCheck bool                `bson:"suspended_by_check,omitempty" json:"suspended_by_check,omitempty"`
+	PreviousStatus   AccountStatus       `bson:"previous_status,omitempty" json:"previous_status,omitempty"` // restored when the mailbox recovers
+	CheckedAt        time.Time           `bson:"checked_at" json:"checked_at"`
+	ProbedAt         *time.Time          `bson:"probed_at,omitempty" json:"probed_at,omitempty"`
+}
+
+// QuotaUsage returns the used share of the storage quota, 0 when the server reports none
+func (h *MailboxHealth) QuotaUsage() float64 {
+	if h == nil || h.QuotaLimitKB <= 0 {
+		return 0
+	}
+	return float64(h.QuotaUsedKB) / float64(h.QuotaLimitKB)
+}
+
+// MailboxHealthConfig represents configuration for periodic mailbox health checks
+type MailboxHealthConfig struct {
+	Enabled          bool          `yaml:"enabled"`
+	Interval         time.Duration `yaml:"interval"`           // how often mailboxes due for a check are looked up
+	CheckEvery       time.Duration `yaml:"check_every"`        // how often each mailbox is checked
+	ProbeEvery       time.Duration `yaml:"probe_every"`        // how often a probe measures spam placement, 0 disables probes
+	BatchSize        int           `yaml:"batch_size"`         // mailboxes checked per run
+	QuotaThreshold   float64       `yaml:"quota_threshold"`    // used share of the quota reported as quota_full
+	MaxLoginFailures int           `yaml:"max_login_failures"` // rejected logins in a row before the account is suspended
+}
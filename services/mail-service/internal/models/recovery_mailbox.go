@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// MailboxBinding records the account on another platform that uses the mailbox as its recovery email
+type MailboxBinding struct {
+	Platform  string    `bson:"platform" json:"platform"`
+	AccountID string    `bson:"account_id" json:"account_id"`
+	BoundAt   time.Time `bson:"bound_at" json:"bound_at"`
+}
+
+// VerificationMail represents the parts of a confirmation email other services act on
+type VerificationMail struct {
+	From       string    `json:"from"`
+	Subject    string    `json:"subject"`
+	Links      []string  `json:"links,omitempty"`
+	Code       string    `json:"code,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
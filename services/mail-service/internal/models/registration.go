@@ -20,15 +20,26 @@ const (
 	StepComplete          RegistrationStep = "complete"
 )
 
+// Mail providers a registration can target
+const (
+	MailProviderMailRu  = "mailru"
+	MailProviderYandex  = "yandex"
+	MailProviderOutlook = "outlook"
+)
+
 // RegistrationRequest represents a request to register a new account
 type RegistrationRequest struct {
-	FirstName              string `json:"first_name" validate:"required"`
-	LastName               string `json:"last_name" validate:"required"`
-	BirthDate              string `json:"birth_date" validate:"required"`
-	Gender                 string `json:"gender" validate:"required,oneof=male female"`
+	FirstName              string `json:"first_name" validate:"required_without=UseRandomProfile"`
+	LastName               string `json:"last_name" validate:"required_without=UseRandomProfile"`
+	BirthDate              string `json:"birth_date" validate:"required_without=UseRandomProfile"`
+	Gender                 string `json:"gender" validate:"omitempty,oneof=male female"`
 	PreferredCountry       string `json:"preferred_country,omitempty"`
 	UsePhoneVerification   bool   `json:"use_phone_verification"`
 	CustomEmailPrefix      string `json:"custom_email_prefix,omitempty"`
+	UseRandomProfile       bool   `json:"use_random_profile,omitempty"`
+	PersonaID              string `json:"persona_id,omitempty"`
+	PersonaReservationID   string `json:"persona_reservation_id,omitempty"`
+	Provider               string `json:"provider,omitempty" validate:"omitempty,oneof=mailru yandex outlook"`
 }
 
 // RegistrationSession represents an active registration session
@@ -75,4 +86,10 @@ type RegistrationConfig struct {
 	MaxSMSPolls           int           `yaml:"max_sms_polls"`
 	EnablePhoneVerification bool        `yaml:"enable_phone_verification"`
 	CaptchaTimeout        time.Duration `yaml:"captcha_timeout"`
+	Workers               int           `yaml:"workers"`
+	Prefetch              int           `yaml:"prefetch"`
+	ProxyMinInterval      time.Duration `yaml:"proxy_min_interval"`
+	ProxyMaxConcurrent    int           `yaml:"proxy_max_concurrent"`
+	ShutdownTimeout       time.Duration `yaml:"shutdown_timeout"`
+	DefaultProvider       string        `yaml:"default_provider"`
 }
@@ -216,6 +216,143 @@ func (r *AccountRepository) UpdateAccountFullCredentials(ctx context.Context, id
 	return err
 }
 
+// UpdateMailboxCheck stores the result of the latest mailbox delivery check
+func (r *AccountRepository) UpdateMailboxCheck(ctx context.Context, id primitive.ObjectID, check *models.MailboxCheck) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{
+				"mailbox_check": check,
+				"updated_at":    time.Now(),
+			},
+		},
+	)
+	return err
+}
+
+// GetDueForHealthCheck returns mailboxes in the statuses that were never health checked or
+// last checked before the given time, least recently checked first, with decrypted credentials
+func (r *AccountRepository) GetDueForHealthCheck(ctx context.Context, statuses []models.AccountStatus, checkedBefore time.Time, limit int) ([]*models.MailAccount, error) {
+	filter := bson.M{
+		"status":     bson.M{"$in": statuses},
+		"deleted_at": nil,
+		"$or": []bson.M{
+			{"mailbox_health.checked_at": bson.M{"$lt": checkedBefore}},
+			{"mailbox_health": nil},
+		},
+	}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"mailbox_health.checked_at": 1}).
+		SetProjection(bson.M{"_id": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var due []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &due); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*models.MailAccount, 0, len(due))
+	for _, d := range due {
+		account, err := r.GetByID(ctx, d.ID)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// UpdateMailboxHealth stores the result of the latest mailbox health check. A non-empty
+// status replaces the account status together with the error message.
+func (r *AccountRepository) UpdateMailboxHealth(ctx context.Context, id primitive.ObjectID, health *models.MailboxHealth, status models.AccountStatus, errorMsg string) error {
+	set := bson.M{
+		"mailbox_health": health,
+		"updated_at":     time.Now(),
+	}
+	if health.LoginOK {
+		set["last_login_at"] = health.CheckedAt
+	}
+	if status != "" {
+		set["status"] = status
+		set["error_message"] = errorMsg
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	return err
+}
+
+// FindByBinding returns the mailbox bound to an account on another platform
+func (r *AccountRepository) FindByBinding(ctx context.Context, platform, consumerID string) (*models.MailAccount, error) {
+	var account models.MailAccount
+	err := r.collection.FindOne(ctx, bson.M{
+		"bound_to.platform":   platform,
+		"bound_to.account_id": consumerID,
+		"deleted_at":          nil,
+	}).Decode(&account)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, account.ID)
+}
+
+// BindFreeMailbox atomically binds the oldest unbound mailbox that passed the delivery check
+// and returns it, mongo.ErrNoDocuments means every such mailbox is already taken
+func (r *AccountRepository) BindFreeMailbox(ctx context.Context, binding *models.MailboxBinding) (*models.MailAccount, error) {
+	filter := bson.M{
+		"status": bson.M{"$in": []models.AccountStatus{
+			models.AccountStatusCreated,
+			models.AccountStatusWarming,
+			models.AccountStatusReady,
+		}},
+		"mailbox_check.status": models.MailboxCheckPassed,
+		"bound_to":             nil,
+		"deleted_at":           nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"bound_to":   binding,
+			"updated_at": time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetSort(bson.M{"created_at": 1})
+
+	var account models.MailAccount
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&account); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, account.ID)
+}
+
+// Unbind frees the mailbox bound to an account on another platform
+func (r *AccountRepository) Unbind(ctx context.Context, platform, consumerID string) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"bound_to.platform":   platform,
+			"bound_to.account_id": consumerID,
+		},
+		bson.M{
+			"$unset": bson.M{"bound_to": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
 // IncrementRetryCount increments retry count
 func (r *AccountRepository) IncrementRetryCount(ctx context.Context, id primitive.ObjectID) error {
 	_, err := r.collection.UpdateOne(
@@ -339,6 +476,13 @@ func (r *AccountRepository) CreateIndexes(ctx context.Context) error {
 		{
 			Keys: bson.M{"deleted_at": 1},
 		},
+		{
+			Keys:    bson.D{{Key: "bound_to.platform", Value: 1}, {Key: "bound_to.account_id", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "mailbox_health.checked_at", Value: 1}},
+		},
 	}
 	
 	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
+	"github.com/grigta/conveer/pkg/logger"
+
 	"github.com/playwright-community/playwright-go"
 )
 
@@ -15,13 +18,28 @@ type BrowserConfig struct {
 	Headless    bool
 }
 
+// maxBrowserPoolSize caps runtime resizing, each browser holds a few hundred MB
+const maxBrowserPoolSize = 100
+
+var ErrInvalidPoolSize = errors.New("invalid browser pool size")
+
+// PoolStats represents browser pool state
+type PoolStats struct {
+	AvailableBrowsers int `json:"available_browsers"`
+	InUseBrowsers     int `json:"in_use_browsers"`
+	TargetSize        int `json:"target_size"`
+}
+
 // BrowserManager manages a pool of browser instances
 type BrowserManager struct {
 	pw        *playwright.Playwright
-	pool      []playwright.Browser
+	pool      []playwright.Browser // idle browsers
 	poolMutex sync.Mutex
 	poolSize  int
+	inUse     int
 	headless  bool
+	closed    bool
+	metrics   *MetricsCollector
 }
 
 // NewBrowserManager creates a new browser manager
@@ -63,6 +81,18 @@ func (m *BrowserManager) AcquireBrowser(ctx context.Context, config *BrowserConf
 	m.poolMutex.Lock()
 	defer m.poolMutex.Unlock()
 
+	browser, err := m.takeBrowser(config)
+	if err != nil {
+		return nil, err
+	}
+
+	m.inUse++
+	m.recordPoolMetrics(m.statsLocked())
+	return browser, nil
+}
+
+// takeBrowser expects poolMutex to be held
+func (m *BrowserManager) takeBrowser(config *BrowserConfig) (playwright.Browser, error) {
 	// Try to get from pool
 	if len(m.pool) > 0 {
 		browser := m.pool[len(m.pool)-1]
@@ -87,10 +117,18 @@ func (m *BrowserManager) ReleaseBrowser(browser playwright.Browser) {
 	m.poolMutex.Lock()
 	defer m.poolMutex.Unlock()
 
-	// Check pool size
-	if len(m.pool) >= m.poolSize {
+	if m.inUse > 0 {
+		m.inUse--
+	}
+	defer func() {
+		m.recordPoolMetrics(m.statsLocked())
+	}()
+
+	// Busy browsers count towards the pool size, so browsers over it are
+	// closed here, also after the pool was shrunk while they were in use
+	if len(m.pool)+m.inUse >= m.poolSize {
 		// Close excess browser
-		browser.Close()
+		closeBrowser(browser)
 		return
 	}
 
@@ -108,6 +146,7 @@ func (m *BrowserManager) Shutdown() error {
 	m.poolMutex.Lock()
 	defer m.poolMutex.Unlock()
 
+	m.closed = true
 	for _, browser := range m.pool {
 		browser.Close()
 	}
@@ -135,7 +174,7 @@ func (m *BrowserManager) createBrowser(config *BrowserConfig) (playwright.Browse
 
 	if config != nil && config.ProxyURL != "" {
 		opts.Proxy = &playwright.Proxy{
-			Server: playwright.String(config.ProxyURL),
+			Server: config.ProxyURL,
 		}
 	}
 
@@ -154,7 +193,7 @@ func (m *BrowserManager) createBrowser(config *BrowserConfig) (playwright.Browse
 			},
 			Locale:       playwright.String(config.Fingerprint.Locale),
 			TimezoneId:   playwright.String(config.Fingerprint.Timezone),
-			ExtraHTTPHeaders: map[string]string{
+			ExtraHttpHeaders: map[string]string{
 				"Accept-Language": config.Fingerprint.Locale,
 			},
 		}
@@ -168,3 +207,99 @@ func (m *BrowserManager) createBrowser(config *BrowserConfig) (playwright.Browse
 
 	return browser, nil
 }
+
+// Stats returns the current browser pool state
+func (m *BrowserManager) Stats() PoolStats {
+	m.poolMutex.Lock()
+	defer m.poolMutex.Unlock()
+
+	return m.statsLocked()
+}
+
+// statsLocked expects poolMutex to be held
+func (m *BrowserManager) statsLocked() PoolStats {
+	return PoolStats{
+		AvailableBrowsers: len(m.pool),
+		InUseBrowsers:     m.inUse,
+		TargetSize:        m.poolSize,
+	}
+}
+
+// Resize changes the pool size at runtime. Growing launches the new browsers in the
+// background. Shrinking closes idle browsers right away, busy ones finish their job
+// and are closed on release.
+func (m *BrowserManager) Resize(size int) (PoolStats, error) {
+	if size < 1 || size > maxBrowserPoolSize {
+		return PoolStats{}, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPoolSize, maxBrowserPoolSize)
+	}
+
+	m.poolMutex.Lock()
+	previous := m.poolSize
+	m.poolSize = size
+
+	var toClose []playwright.Browser
+	for len(m.pool) > 0 && len(m.pool)+m.inUse > size {
+		toClose = append(toClose, m.pool[len(m.pool)-1])
+		m.pool = m.pool[:len(m.pool)-1]
+	}
+	missing := size - len(m.pool) - m.inUse
+
+	stats := m.statsLocked()
+	m.recordPoolMetrics(stats)
+	m.poolMutex.Unlock()
+
+	for _, browser := range toClose {
+		closeBrowser(browser)
+	}
+	if missing > 0 {
+		go m.warmBrowsers(missing)
+	}
+
+	logger.Infof("Browser pool resized from %d to %d (closed %d, warming %d)", previous, size, len(toClose), max(missing, 0))
+	return stats, nil
+}
+
+// warmBrowsers launches browsers one by one until the pool reaches its size again.
+// It stops early when the pool is shrunk meanwhile or the manager shuts down.
+func (m *BrowserManager) warmBrowsers(count int) {
+	for i := 0; i < count; i++ {
+		m.poolMutex.Lock()
+		full := m.closed || len(m.pool)+m.inUse >= m.poolSize
+		m.poolMutex.Unlock()
+		if full {
+			return
+		}
+
+		browser, err := m.createBrowser(nil)
+		if err != nil {
+			logger.Errorf("Failed to warm browser for resized pool: %v", err)
+			return
+		}
+
+		m.poolMutex.Lock()
+		if m.closed || len(m.pool)+m.inUse >= m.poolSize {
+			m.poolMutex.Unlock()
+			closeBrowser(browser)
+			return
+		}
+		m.pool = append(m.pool, browser)
+		m.recordPoolMetrics(m.statsLocked())
+		m.poolMutex.Unlock()
+	}
+}
+
+// closeBrowser closes the browser's contexts before the browser itself, so pages get
+// their unload handlers and cookies are flushed
+func closeBrowser(browser playwright.Browser) {
+	for _, browserContext := range browser.Contexts() {
+		browserContext.Close()
+	}
+	browser.Close()
+}
+
+func (m *BrowserManager) recordPoolMetrics(stats PoolStats) {
+	if m.metrics == nil {
+		return
+	}
+	m.metrics.UpdateBrowserPool(stats.AvailableBrowsers, stats.InUseBrowsers, stats.TargetSize)
+}
@@ -164,3 +164,41 @@ func ApplyFingerprint(page playwright.Page, fingerprint Fingerprint) error {
 
 	return nil
 }
+
+// RandomProfile is a locally generated identity, used when the persona service is unavailable
+type RandomProfile struct {
+	FirstName string
+	LastName  string
+	BirthDate string // YYYY-MM-DD
+	Gender    string
+}
+
+// GenerateRandomProfile creates a random russian identity of the given gender, any gender when empty
+func GenerateRandomProfile(gender string) RandomProfile {
+	firstNamesMale := []string{"Александр", "Дмитрий", "Максим", "Сергей", "Андрей", "Алексей", "Артём", "Иван", "Кирилл", "Михаил", "Никита", "Матвей", "Роман", "Егор", "Арсений", "Илья", "Денис", "Евгений", "Даниил", "Тимофей"}
+	firstNamesFemale := []string{"Анна", "Мария", "Елена", "Наталья", "Ольга", "Екатерина", "Анастасия", "Дарья", "Юлия", "Ирина", "Татьяна", "Светлана", "Ксения", "Полина", "Алиса", "Виктория", "Александра", "Вероника", "Арина", "Валерия"}
+	lastNames := []string{"Иванов", "Смирнов", "Кузнецов", "Попов", "Васильев", "Петров", "Соколов", "Михайлов", "Новиков", "Федоров", "Морозов", "Волков", "Алексеев", "Лебедев", "Семенов", "Егоров", "Павлов", "Козлов", "Степанов", "Николаев"}
+
+	if gender != "male" && gender != "female" {
+		gender = "male"
+		if rand.Intn(2) == 0 {
+			gender = "female"
+		}
+	}
+
+	profile := RandomProfile{
+		FirstName: firstNamesMale[rand.Intn(len(firstNamesMale))],
+		LastName:  lastNames[rand.Intn(len(lastNames))],
+		Gender:    gender,
+	}
+	if gender == "female" {
+		profile.FirstName = firstNamesFemale[rand.Intn(len(firstNamesFemale))]
+		profile.LastName += "а"
+	}
+
+	// Birth date between 18 and 35 years ago
+	ageInDays := 18*365 + rand.Intn(17*365)
+	profile.BirthDate = time.Now().AddDate(0, 0, -ageInDays).Format("2006-01-02")
+
+	return profile
+}
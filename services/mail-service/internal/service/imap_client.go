@@ -0,0 +1,287 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errIMAPAuth is returned when the server rejects the mailbox credentials
+var errIMAPAuth = errors.New("imap authentication failed")
+
+var (
+	imapLiteralPattern = regexp.MustCompile(`\{(\d+)\}$`)
+	imapListPattern    = regexp.MustCompile(`^\* LIST \(([^)]*)\) (?:"[^"]*"|NIL) (.+)$`)
+	imapQuotaPattern   = regexp.MustCompile(`(?i)^\* QUOTA .*\(.*\bSTORAGE (\d+) (\d+)`)
+)
+
+// imapClient is a minimal IMAP4rev1 client covering what the mailbox checks and
+// verification mail lookups need: logging in, finding the spam folder, searching
+// a folder, fetching a message and reading the storage quota
+type imapClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	tag    int
+}
+
+func dialIMAP(ctx context.Context, host string, port int, timeout time.Duration) (*imapClient, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config:    &tls.Config{ServerName: host},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to imap server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	c := &imapClient{conn: conn, reader: bufio.NewReader(conn)}
+
+	greeting, err := c.readLine()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read imap greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting, "* OK") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected imap greeting: %s", greeting)
+	}
+
+	return c, nil
+}
+
+// Login authenticates with the mailbox credentials
+func (c *imapClient) Login(username, password string) error {
+	if _, err := c.command("LOGIN " + imapQuote(username) + " " + imapQuote(password)); err != nil {
+		var cmdErr *imapCommandError
+		if errors.As(err, &cmdErr) {
+			return fmt.Errorf("%w: %s", errIMAPAuth, cmdErr.status)
+		}
+		return err
+	}
+	return nil
+}
+
+// JunkFolder returns the folder the server marks with the \Junk attribute,
+// falling back to the usual Mail.ru name when none is marked
+func (c *imapClient) JunkFolder() (string, error) {
+	lines, err := c.command(`LIST "" "*"`)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range lines {
+		match := imapListPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		for _, attr := range strings.Fields(match[1]) {
+			if strings.EqualFold(attr, `\Junk`) || strings.EqualFold(attr, `\Spam`) {
+				return imapUnquote(match[2]), nil
+			}
+		}
+	}
+
+	return "Spam", nil
+}
+
+// SearchHeader reports whether the folder holds a message with the given header value
+func (c *imapClient) SearchHeader(folder, header, value string) (bool, error) {
+	if _, err := c.command("EXAMINE " + imapQuote(folder)); err != nil {
+		return false, err
+	}
+
+	lines, err := c.command("UID SEARCH HEADER " + imapQuote(header) + " " + imapQuote(value))
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "* SEARCH") && len(strings.Fields(line)) > 2 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SearchFrom returns the UIDs of messages in the folder from the sender received on or after since, oldest first
+func (c *imapClient) SearchFrom(folder, from string, since time.Time) ([]uint32, error) {
+	if _, err := c.command("EXAMINE " + imapQuote(folder)); err != nil {
+		return nil, err
+	}
+
+	// SINCE only compares dates, the caller filters by the Date header
+	lines, err := c.command("UID SEARCH FROM " + imapQuote(from) + " SINCE " + since.Format("2-Jan-2006"))
+	if err != nil {
+		return nil, err
+	}
+
+	var uids []uint32
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "* SEARCH") {
+			continue
+		}
+		for _, field := range strings.Fields(line)[2:] {
+			if uid, err := strconv.ParseUint(field, 10, 32); err == nil {
+				uids = append(uids, uint32(uid))
+			}
+		}
+	}
+	return uids, nil
+}
+
+// FetchMessage returns the raw RFC 822 message without marking it as seen
+func (c *imapClient) FetchMessage(uid uint32) ([]byte, error) {
+	lines, err := c.command(fmt.Sprintf("UID FETCH %d BODY.PEEK[]", uid))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		i := strings.Index(line, "BODY[] \"")
+		if !strings.HasPrefix(line, "* ") || i < 0 {
+			continue
+		}
+		if body, ok := imapQuotedAt(line, i+len("BODY[] ")); ok {
+			return []byte(body), nil
+		}
+	}
+	return nil, fmt.Errorf("message %d not found", uid)
+}
+
+// StorageQuota returns the storage used and allowed under the INBOX quota root in kilobytes
+// (RFC 2087). ok is false when the server has no QUOTA extension or reports no storage limit.
+func (c *imapClient) StorageQuota() (used, limit int64, ok bool, err error) {
+	lines, err := c.command(`GETQUOTAROOT "INBOX"`)
+	if err != nil {
+		var cmdErr *imapCommandError
+		if errors.As(err, &cmdErr) {
+			return 0, 0, false, nil
+		}
+		return 0, 0, false, err
+	}
+
+	for _, line := range lines {
+		match := imapQuotaPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		used, _ = strconv.ParseInt(match[1], 10, 64)
+		limit, _ = strconv.ParseInt(match[2], 10, 64)
+		return used, limit, limit > 0, nil
+	}
+	return 0, 0, false, nil
+}
+
+// Close logs out and closes the connection
+func (c *imapClient) Close() error {
+	c.command("LOGOUT")
+	return c.conn.Close()
+}
+
+// imapCommandError is a NO or BAD completion of a tagged command
+type imapCommandError struct {
+	command string
+	status  string
+}
+
+func (e *imapCommandError) Error() string {
+	return fmt.Sprintf("imap %s failed: %s", e.command, e.status)
+}
+
+// command sends a tagged command and returns the untagged responses preceding its completion
+func (c *imapClient) command(cmd string) ([]string, error) {
+	c.tag++
+	tag := fmt.Sprintf("A%03d", c.tag)
+
+	if _, err := fmt.Fprintf(c.conn, "%s %s\r\n", tag, cmd); err != nil {
+		return nil, fmt.Errorf("failed to send imap command: %w", err)
+	}
+
+	var untagged []string
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read imap response: %w", err)
+		}
+
+		if !strings.HasPrefix(line, tag+" ") {
+			untagged = append(untagged, line)
+			continue
+		}
+
+		status := strings.TrimPrefix(line, tag+" ")
+		if strings.HasPrefix(status, "OK") {
+			return untagged, nil
+		}
+		return nil, &imapCommandError{command: strings.Fields(cmd)[0], status: status}
+	}
+}
+
+// readLine reads one response line, inlining any literals it carries as quoted strings
+func (c *imapClient) readLine() (string, error) {
+	var sb strings.Builder
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		match := imapLiteralPattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			sb.WriteString(line)
+			return sb.String(), nil
+		}
+
+		size, _ := strconv.Atoi(line[match[2]:match[3]])
+		literal := make([]byte, size)
+		if _, err := io.ReadFull(c.reader, literal); err != nil {
+			return "", err
+		}
+
+		sb.WriteString(line[:match[0]])
+		sb.WriteString(imapQuote(string(literal)))
+	}
+}
+
+func imapQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+func imapUnquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+	s = strings.ReplaceAll(s, `\"`, `"`)
+	return strings.ReplaceAll(s, `\\`, `\`)
+}
+
+// imapQuotedAt unquotes the quoted string starting at index start of the line
+func imapQuotedAt(line string, start int) (string, bool) {
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return imapUnquote(line[start : i+1]), true
+		}
+	}
+	return "", false
+}
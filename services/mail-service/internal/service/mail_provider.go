@@ -0,0 +1,284 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grigta/conveer/services/mail-service/internal/models"
+	"github.com/playwright-community/playwright-go"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrUnknownMailProvider is returned when a registration asks for a provider that is not supported
+var ErrUnknownMailProvider = errors.New("unknown mail provider")
+
+// MailProvider describes the signup of one mailbox provider.
+// The registration flow owns the session, proxy, browser and checkpoints; the provider
+// supplies where to go, what to fill in and the provider specific parts of the form.
+type MailProvider interface {
+	Name() string
+	// Domain is appended to the login to build the address
+	Domain() string
+	SignupURL() string
+	// InboxURL is opened to look for the confirmation email, empty when none is sent
+	InboxURL() string
+	// SMSService is the service numbers are purchased for
+	SMSService() string
+	Selectors() ProviderSelectors
+
+	// NextPage moves multi-page signup forms on to the next group of fields
+	NextPage(page playwright.Page) error
+	SetBirthDate(page playwright.Page, birthDate string) error
+	SelectGender(page playwright.Page, gender string) error
+}
+
+// ProviderSelectors are the elements of a signup form, empty selectors are not on the form
+type ProviderSelectors struct {
+	Form            string
+	Login           string
+	Password        string
+	PasswordConfirm string
+	FirstName       string
+	LastName        string
+	Submit          string
+	Phone           string
+	SendCode        string
+	Code            string
+	CodeSubmit      string
+	ConfirmLink     string
+	Captcha         []string
+}
+
+// providerBase implements the static parts of MailProvider, form hooks default to no-ops
+type providerBase struct {
+	name       string
+	domain     string
+	signupURL  string
+	inboxURL   string
+	smsService string
+	selectors  ProviderSelectors
+}
+
+func (p *providerBase) Name() string                 { return p.name }
+func (p *providerBase) Domain() string               { return p.domain }
+func (p *providerBase) SignupURL() string            { return p.signupURL }
+func (p *providerBase) InboxURL() string             { return p.inboxURL }
+func (p *providerBase) SMSService() string           { return p.smsService }
+func (p *providerBase) Selectors() ProviderSelectors { return p.selectors }
+
+func (p *providerBase) NextPage(page playwright.Page) error {
+	return nil
+}
+
+func (p *providerBase) SetBirthDate(page playwright.Page, birthDate string) error {
+	return nil
+}
+
+func (p *providerBase) SelectGender(page playwright.Page, gender string) error {
+	return nil
+}
+
+// mailRuProvider registers accounts at mail.ru, the signup is a single page
+type mailRuProvider struct {
+	providerBase
+}
+
+func newMailRuProvider() *mailRuProvider {
+	return &mailRuProvider{providerBase{
+		name:       models.MailProviderMailRu,
+		domain:     "mail.ru",
+		signupURL:  "https://account.mail.ru/signup",
+		inboxURL:   "https://e.mail.ru/inbox",
+		smsService: "mail.ru",
+		selectors: ProviderSelectors{
+			Form:        "form",
+			Login:       "input[name='email']",
+			Password:    "input[name='password']",
+			FirstName:   "input[name='firstname']",
+			LastName:    "input[name='lastname']",
+			Submit:      "button[type='submit']",
+			Phone:       "input[name='phone']",
+			SendCode:    "button[data-test-id='send-code-button']",
+			Code:        "input[name='code']",
+			CodeSubmit:  "button[type='submit']",
+			ConfirmLink: "a[href*='confirm']",
+			Captcha: []string{
+				".captcha-image",
+				".g-recaptcha",
+				"iframe[src*='captcha']",
+				"div[class*='captcha']",
+			},
+		},
+	}}
+}
+
+func (p *mailRuProvider) SetBirthDate(page playwright.Page, birthDate string) error {
+	year, month, day, err := splitBirthDate(birthDate)
+	if err != nil {
+		return err
+	}
+
+	if _, err := page.SelectOption("select[name='birth_day']", playwright.SelectOptionValues{
+		Values: &[]string{day},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := page.SelectOption("select[name='birth_month']", playwright.SelectOptionValues{
+		Values: &[]string{month},
+	}); err != nil {
+		return err
+	}
+
+	_, err = page.SelectOption("select[name='birth_year']", playwright.SelectOptionValues{
+		Values: &[]string{year},
+	})
+	return err
+}
+
+func (p *mailRuProvider) SelectGender(page playwright.Page, gender string) error {
+	if gender == "male" {
+		return page.Click("input[value='male']")
+	}
+	return page.Click("input[value='female']")
+}
+
+// yandexProvider registers accounts at Yandex, which asks for neither birth date nor gender
+type yandexProvider struct {
+	providerBase
+}
+
+func newYandexProvider() *yandexProvider {
+	return &yandexProvider{providerBase{
+		name:       models.MailProviderYandex,
+		domain:     "yandex.ru",
+		signupURL:  "https://passport.yandex.ru/registration",
+		inboxURL:   "https://mail.yandex.ru/",
+		smsService: "yandex",
+		selectors: ProviderSelectors{
+			Form:            "form",
+			Login:           "input[name='login']",
+			Password:        "input[name='password']",
+			PasswordConfirm: "input[name='password_confirm']",
+			FirstName:       "input[name='firstname']",
+			LastName:        "input[name='lastname']",
+			Submit:          "button[type='submit']",
+			Phone:           "input[name='phone']",
+			SendCode:        "button[data-t='button:action:phone-confirm']",
+			Code:            "input[name='phoneCode']",
+			CodeSubmit:      "button[type='submit']",
+			ConfirmLink:     "a[href*='confirm']",
+			Captcha: []string{
+				".captcha__image",
+				"img[src*='captcha']",
+				"iframe[src*='captcha']",
+				"div[class*='Captcha']",
+			},
+		},
+	}}
+}
+
+// outlookProvider registers accounts at Outlook, whose signup asks for one group of fields per page
+type outlookProvider struct {
+	providerBase
+}
+
+func newOutlookProvider() *outlookProvider {
+	return &outlookProvider{providerBase{
+		name:       models.MailProviderOutlook,
+		domain:     "outlook.com",
+		signupURL:  "https://signup.live.com/signup",
+		smsService: "microsoft",
+		selectors: ProviderSelectors{
+			Form:       "form",
+			Login:      "input[name='MemberName']",
+			Password:   "input[name='Password']",
+			FirstName:  "input[name='FirstName']",
+			LastName:   "input[name='LastName']",
+			Submit:     "#iSignupAction",
+			Phone:      "input[name='PhoneNumber']",
+			SendCode:   "#wlspispHipSendCode",
+			Code:       "input[name='SolutionElement']",
+			CodeSubmit: "#iSignupAction",
+			Captcha: []string{
+				"#enforcementFrame",
+				"iframe[src*='arkoselabs']",
+				"iframe[src*='captcha']",
+			},
+		},
+	}}
+}
+
+func (p *outlookProvider) NextPage(page playwright.Page) error {
+	if err := page.Click(p.selectors.Submit); err != nil {
+		return err
+	}
+	_, err := page.WaitForSelector(p.selectors.Form, playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(10000),
+	})
+	return err
+}
+
+func (p *outlookProvider) SetBirthDate(page playwright.Page, birthDate string) error {
+	year, month, day, err := splitBirthDate(birthDate)
+	if err != nil {
+		return err
+	}
+
+	// Month and day options have no leading zeros
+	if _, err := page.SelectOption("select[name='BirthMonth']", playwright.SelectOptionValues{
+		Values: &[]string{trimLeadingZeros(month)},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := page.SelectOption("select[name='BirthDay']", playwright.SelectOptionValues{
+		Values: &[]string{trimLeadingZeros(day)},
+	}); err != nil {
+		return err
+	}
+
+	return TypeWithHumanSpeed(page, "input[name='BirthYear']", year)
+}
+
+var mailProviders = map[string]MailProvider{
+	models.MailProviderMailRu:  newMailRuProvider(),
+	models.MailProviderYandex:  newYandexProvider(),
+	models.MailProviderOutlook: newOutlookProvider(),
+}
+
+// LookupMailProvider returns the provider registered under name
+func LookupMailProvider(name string) (MailProvider, error) {
+	provider, ok := mailProviders[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMailProvider, name)
+	}
+	return provider, nil
+}
+
+// AccountProviderFilter matches the accounts of a provider in account list filters.
+// Accounts created before provider selection have no provider and are mail.ru ones.
+func AccountProviderFilter(name string) interface{} {
+	if name == models.MailProviderMailRu {
+		return bson.M{"$in": []interface{}{name, nil}}
+	}
+	return name
+}
+
+// splitBirthDate splits a YYYY-MM-DD birth date
+func splitBirthDate(birthDate string) (year, month, day string, err error) {
+	parts := strings.Split(birthDate, "-")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid birth date format")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func trimLeadingZeros(value string) string {
+	if n, err := strconv.Atoi(value); err == nil {
+		return strconv.Itoa(n)
+	}
+	return value
+}
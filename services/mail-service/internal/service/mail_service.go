@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"sync"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/mail-service/internal/models"
 	"github.com/grigta/conveer/services/mail-service/internal/repository"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
 	smspb "github.com/grigta/conveer/services/sms-service/proto"
 	"github.com/streadway/amqp"
@@ -22,6 +24,9 @@ type RegistrationTaskPayload struct {
 	RegistrationRequest  *models.RegistrationRequest  `json:"registrationRequest"`
 }
 
+// registrationConsumerTag identifies the mail.register consumer so it can be cancelled on shutdown
+const registrationConsumerTag = "mail-service.register"
+
 // RetryTaskPayload represents the payload for retry tasks
 type RetryTaskPayload struct {
 	AccountID   string `json:"accountID"`
@@ -34,10 +39,18 @@ type MailService struct {
 	sessionRepo      *repository.SessionRepository
 	proxyClient      proxypb.ProxyServiceClient
 	smsClient        smspb.SMSServiceClient
+	personaClient    personapb.PersonaServiceClient
 	rabbitmqChannel  *amqp.Channel
 	browserManager   *BrowserManager
 	config           *models.RegistrationConfig
 	metrics          *MetricsCollector
+	proxyPacer       *ProxyPacer
+	mailboxVerifier  *MailboxVerifier
+	mailboxCheck     *models.MailboxCheckConfig
+	mailboxHealth    *models.MailboxHealthConfig
+	inFlight         sync.WaitGroup
+	workCtx          context.Context
+	cancelWork       context.CancelFunc
 }
 
 // NewMailService creates a new mail service instance
@@ -46,40 +59,72 @@ func NewMailService(
 	sessionRepo *repository.SessionRepository,
 	proxyConn *grpc.ClientConn,
 	smsConn *grpc.ClientConn,
+	personaConn *grpc.ClientConn,
 	rabbitmqChannel *amqp.Channel,
 	browserManager *BrowserManager,
 	config *models.RegistrationConfig,
+	mailboxCheck *models.MailboxCheckConfig,
+	mailboxHealth *models.MailboxHealthConfig,
 ) *MailService {
+	metrics := NewMetricsCollector()
+	if browserManager != nil {
+		browserManager.metrics = metrics
+	}
+
 	return &MailService{
 		accountRepo:      accountRepo,
 		sessionRepo:      sessionRepo,
 		proxyClient:      proxypb.NewProxyServiceClient(proxyConn),
 		smsClient:        smspb.NewSMSServiceClient(smsConn),
+		personaClient:    personapb.NewPersonaServiceClient(personaConn),
 		rabbitmqChannel:  rabbitmqChannel,
 		browserManager:   browserManager,
 		config:           config,
-		metrics:          NewMetricsCollector(),
+		metrics:          metrics,
+		proxyPacer:       NewProxyPacer(config.ProxyMinInterval, config.ProxyMaxConcurrent),
+		mailboxVerifier:  NewMailboxVerifier(mailboxCheck),
+		mailboxCheck:     mailboxCheck,
+		mailboxHealth:    mailboxHealth,
 	}
 }
 
 // CreateAccount creates a new mail account
 func (s *MailService) CreateAccount(ctx context.Context, req *models.RegistrationRequest) (*models.RegistrationResult, error) {
 	s.metrics.IncrementRegistrationAttempts()
+
+	providerName := req.Provider
+	if providerName == "" {
+		providerName = s.config.DefaultProvider
+	}
+	provider, err := LookupMailProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take the profile from the persona service if requested
+	if req.UseRandomProfile || req.PersonaID != "" {
+		if err := s.applyPersona(ctx, req); err != nil {
+			return nil, err
+		}
+	}
 	
 	// Create account document
 	account := &models.MailAccount{
 		ID:        primitive.NewObjectID(),
+		Provider:  provider.Name(),
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		BirthDate: req.BirthDate,
 		Gender:    req.Gender,
 		Status:    models.AccountStatusCreating,
+		PersonaID: req.PersonaID,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 	
 	// Save account to database
 	if err := s.accountRepo.Create(ctx, account); err != nil {
+		s.cancelPersona(ctx, req)
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
 	
@@ -93,11 +138,17 @@ func (s *MailService) CreateAccount(ctx context.Context, req *models.Registratio
 		StartedAt:            time.Now(),
 		LastActivityAt:       time.Now(),
 	}
+	if req.CustomEmailPrefix != "" {
+		session.StepCheckpoints["email_prefix"] = req.CustomEmailPrefix
+	}
 	
 	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		s.cancelPersona(ctx, req)
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 	
+	s.confirmPersona(ctx, account, req.PersonaReservationID)
+	
 	// Publish to registration queue
 	if err := s.publishRegistrationTask(account.ID.Hex(), req); err != nil {
 		return nil, fmt.Errorf("failed to publish registration task: %w", err)
@@ -203,17 +254,63 @@ func (s *MailService) GetStatistics(ctx context.Context) (*models.AccountStatist
 
 // StartWorkers starts background workers
 func (s *MailService) StartWorkers(ctx context.Context) {
-	go s.registrationWorker(ctx)
+	// In-flight signups run detached from ctx so shutdown lets them finish instead of abandoning a half-filled form
+	s.workCtx, s.cancelWork = context.WithCancel(context.WithoutCancel(ctx))
+	
+	s.startRegistrationWorkers(ctx)
 	go s.retryWorker(ctx)
 	go s.cleanupWorker(ctx)
 	go s.stuckSessionMonitor(ctx)
+	if s.mailboxHealth.Enabled {
+		go s.mailboxHealthWorker(ctx)
+	}
 }
 
-// registrationWorker processes registration tasks
-func (s *MailService) registrationWorker(ctx context.Context) {
+// Shutdown stops consuming registrations and waits for in-flight signups to complete
+func (s *MailService) Shutdown() {
+	if err := s.rabbitmqChannel.Cancel(registrationConsumerTag, false); err != nil {
+		logger.Errorf("Failed to cancel registration consumer: %v", err)
+	}
+	
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+	
+	select {
+	case <-done:
+		logger.Info("All in-flight registrations completed")
+	case <-time.After(s.config.ShutdownTimeout):
+		// Unacked deliveries are requeued when the channel closes and resume from their checkpoint
+		logger.Warnf("Shutdown timeout reached, aborting in-flight registrations")
+	}
+	
+	if s.cancelWork != nil {
+		s.cancelWork()
+	}
+}
+
+// startRegistrationWorkers starts a pool of workers sharing one mail.register consumer
+func (s *MailService) startRegistrationWorkers(ctx context.Context) {
+	workers := s.config.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	
+	prefetch := s.config.Prefetch
+	if prefetch <= 0 {
+		prefetch = workers
+	}
+	
+	// Prefetch caps unacked deliveries so idle instances can pick up the rest of the queue
+	if err := s.rabbitmqChannel.Qos(prefetch, 0, false); err != nil {
+		logger.Errorf("Failed to set registration prefetch: %v", err)
+	}
+	
 	msgs, err := s.rabbitmqChannel.Consume(
 		"mail.register",
-		"",
+		registrationConsumerTag,
 		false,
 		false,
 		false,
@@ -221,26 +318,54 @@ func (s *MailService) registrationWorker(ctx context.Context) {
 		nil,
 	)
 	if err != nil {
-		log.Printf("Failed to register consumer: %v", err)
+		logger.Errorf("Failed to register consumer: %v", err)
 		return
 	}
 	
+	logger.Infof("Starting %d registration workers (prefetch %d)", workers, prefetch)
+	for i := 0; i < workers; i++ {
+		go s.registrationWorker(ctx, msgs)
+	}
+}
+
+// registrationWorker processes registration tasks
+func (s *MailService) registrationWorker(ctx context.Context, msgs <-chan amqp.Delivery) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case msg := <-msgs:
-			// Process registration
-			if err := s.processRegistration(ctx, msg.Body); err != nil {
-				log.Printf("Registration failed: %v", err)
-				msg.Nack(false, true)
-			} else {
-				msg.Ack(false)
+		case msg, ok := <-msgs:
+			if !ok {
+				return
 			}
+			s.handleDelivery(ctx, msg, s.processRegistration, "Registration")
 		}
 	}
 }
 
+// handleDelivery runs a task outside the shutdown context and tracks it until it is acked
+func (s *MailService) handleDelivery(ctx context.Context, msg amqp.Delivery, process func(context.Context, []byte) error, kind string) {
+	// Hand deliveries received during shutdown back to the queue
+	if ctx.Err() != nil {
+		msg.Nack(false, true)
+		return
+	}
+	
+	s.inFlight.Add(1)
+	s.metrics.IncrementRegistrationsInFlight()
+	defer func() {
+		s.metrics.DecrementRegistrationsInFlight()
+		s.inFlight.Done()
+	}()
+	
+	if err := process(s.workCtx, msg.Body); err != nil {
+		logger.Errorf("%s failed: %v", kind, err)
+		msg.Nack(false, true)
+	} else {
+		msg.Ack(false)
+	}
+}
+
 // retryWorker processes retry tasks
 func (s *MailService) retryWorker(ctx context.Context) {
 	msgs, err := s.rabbitmqChannel.Consume(
@@ -253,7 +378,7 @@ func (s *MailService) retryWorker(ctx context.Context) {
 		nil,
 	)
 	if err != nil {
-		log.Printf("Failed to register retry consumer: %v", err)
+		logger.Errorf("Failed to register retry consumer: %v", err)
 		return
 	}
 	
@@ -261,14 +386,11 @@ func (s *MailService) retryWorker(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case msg := <-msgs:
-			// Process retry
-			if err := s.processRetry(ctx, msg.Body); err != nil {
-				log.Printf("Retry failed: %v", err)
-				msg.Nack(false, true)
-			} else {
-				msg.Ack(false)
+		case msg, ok := <-msgs:
+			if !ok {
+				return
 			}
+			s.handleDelivery(ctx, msg, s.processRetry, "Retry")
 		}
 	}
 }
@@ -285,7 +407,7 @@ func (s *MailService) cleanupWorker(ctx context.Context) {
 		case <-ticker.C:
 			// Clean up sessions older than 1 hour
 			if err := s.sessionRepo.CleanupStuckSessions(ctx, 1*time.Hour); err != nil {
-				log.Printf("Failed to cleanup stuck sessions: %v", err)
+				logger.Errorf("Failed to cleanup stuck sessions: %v", err)
 			}
 		}
 	}
@@ -304,7 +426,7 @@ func (s *MailService) stuckSessionMonitor(ctx context.Context) {
 			// Find sessions stuck in same step for >30 minutes
 			sessions, err := s.sessionRepo.GetStuckSessions(ctx, 30*time.Minute)
 			if err != nil {
-				log.Printf("Failed to get stuck sessions: %v", err)
+				logger.Errorf("Failed to get stuck sessions: %v", err)
 				continue
 			}
 			
@@ -379,15 +501,48 @@ func (s *MailService) publishRetryTask(accountID string) error {
 	)
 }
 
+// registrationStepTotal is the step name of the end-to-end registration time
+const registrationStepTotal = "total"
+
+func (s *MailService) publishRegistrationStep(accountID, step string, duration time.Duration) error {
+	payload := map[string]interface{}{
+		"type":         "registration.step_completed",
+		"platform":     "mail",
+		"account_id":   accountID,
+		"step":         step,
+		"duration_sec": duration.Seconds(),
+		"service":      "mail-service",
+		"timestamp":    time.Now().Unix(),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal registration step payload: %w", err)
+	}
+
+	return s.rabbitmqChannel.Publish(
+		"mail.events",                 // exchange
+		"registration.step_completed", // routing key
+		false,                         // mandatory
+		false,                         // immediate
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        data,
+		},
+	)
+}
+
 func (s *MailService) publishManualIntervention(accountID string, reason string) error {
 	s.metrics.IncrementManualIntervention(reason)
 
 	// Create payload
 	payload := map[string]interface{}{
+		"type":       "manual_intervention",
+		"platform":   "mail",
 		"account_id": accountID,
 		"reason":     reason,
 		"service":    "mail-service",
-		"timestamp":  time.Now().Unix(),
+		"timestamp":  time.Now(),
 	}
 
 	data, err := json.Marshal(payload)
@@ -436,6 +591,7 @@ func (s *MailService) processRegistration(ctx context.Context, data []byte) erro
 	}
 
 	s.metrics.IncrementRegistrationSuccess()
+	s.scheduleMailboxCheck(accountID)
 	return nil
 }
 
@@ -476,5 +632,6 @@ func (s *MailService) processRetry(ctx context.Context, data []byte) error {
 	}
 
 	s.metrics.IncrementRegistrationSuccess()
+	s.scheduleMailboxCheck(accountID)
 	return nil
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/mail-service/internal/models"
+	"github.com/streadway/amqp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VerifyMailbox sends a probe email to the account and confirms it arrives in the inbox.
+// Flows that hand out the mailbox as a recovery email should only use accounts that passed.
+func (s *MailService) VerifyMailbox(ctx context.Context, accountID string) (*models.MailboxCheck, error) {
+	if !s.mailboxVerifier.Configured() {
+		return nil, fmt.Errorf("mailbox checks are not configured")
+	}
+
+	id, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID: %w", err)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if account.Email == "" || account.Password == "" {
+		return nil, fmt.Errorf("account has no mailbox credentials yet")
+	}
+
+	check := s.mailboxVerifier.Verify(ctx, account.Email, account.Password)
+	s.metrics.RecordMailboxCheck(check)
+
+	if err := s.accountRepo.UpdateMailboxCheck(ctx, id, check); err != nil {
+		return nil, fmt.Errorf("failed to save mailbox check: %w", err)
+	}
+
+	if !check.Passed() {
+		if err := s.publishMailboxCheckFailed(account, check); err != nil {
+			logger.Errorf("Failed to publish mailbox check result for %s: %v", accountID, err)
+		}
+	}
+
+	return check, nil
+}
+
+// scheduleMailboxCheck verifies a freshly registered mailbox in the background
+func (s *MailService) scheduleMailboxCheck(accountID primitive.ObjectID) {
+	if !s.mailboxCheck.Enabled || !s.mailboxVerifier.Configured() {
+		return
+	}
+
+	// Tracked with the registrations so shutdown doesn't close the database under it
+	s.inFlight.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+
+		check, err := s.VerifyMailbox(s.workCtx, accountID.Hex())
+		if err != nil {
+			logger.Errorf("Mailbox check for %s failed: %v", accountID.Hex(), err)
+			return
+		}
+		logger.Infof("Mailbox check for %s: %s", accountID.Hex(), check.Status)
+	}()
+}
+
+func (s *MailService) publishMailboxCheckFailed(account *models.MailAccount, check *models.MailboxCheck) error {
+	payload := map[string]interface{}{
+		"account_id": account.ID.Hex(),
+		"status":     check.Status,
+		"delivered":  check.Delivered,
+		"folder":     check.Folder,
+		"error":      check.Error,
+		"service":    "mail-service",
+		"timestamp":  time.Now().Unix(),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mailbox check payload: %w", err)
+	}
+
+	return s.rabbitmqChannel.Publish(
+		"mail.events",               // exchange
+		"mail.mailbox_check.failed", // routing key
+		false,                       // mandatory
+		false,                       // immediate
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        data,
+		},
+	)
+}
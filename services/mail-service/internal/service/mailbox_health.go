@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/mail-service/internal/models"
+	"github.com/streadway/amqp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// mailboxHealthStatuses are the account statuses whose mailboxes are monitored. Suspended
+// accounts stay monitored so the ones suspended by a check are restored once the mailbox recovers.
+var mailboxHealthStatuses = []models.AccountStatus{
+	models.AccountStatusCreated,
+	models.AccountStatusWarming,
+	models.AccountStatusReady,
+	models.AccountStatusSuspended,
+}
+
+// lockedMailboxMarkers are parts of IMAP login rejections that mean the provider blocked the
+// mailbox rather than the password being wrong
+var lockedMailboxMarkers = []string{"block", "locked", "suspend", "disabled", "frozen", "заблок"}
+
+// CheckMailboxHealth logs into the mailbox over IMAP, reads its storage quota and, when a probe
+// is due, measures spam placement. Locked mailboxes and repeatedly rejected logins suspend the
+// account; a mailbox suspended this way is restored to its previous status once it recovers.
+func (s *MailService) CheckMailboxHealth(ctx context.Context, accountID string) (*models.MailboxHealth, error) {
+	id, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID: %w", err)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	return s.checkMailboxHealth(ctx, account)
+}
+
+// mailboxHealthWorker checks the mailboxes that are due every interval
+func (s *MailService) mailboxHealthWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.mailboxHealth.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkDueMailboxes(ctx)
+		}
+	}
+}
+
+func (s *MailService) checkDueMailboxes(ctx context.Context) {
+	accounts, err := s.accountRepo.GetDueForHealthCheck(ctx, mailboxHealthStatuses, time.Now().Add(-s.mailboxHealth.CheckEvery), s.mailboxHealth.BatchSize)
+	if err != nil {
+		logger.Errorf("Failed to get mailboxes due for health check: %v", err)
+		return
+	}
+
+	for _, account := range accounts {
+		if ctx.Err() != nil {
+			return
+		}
+
+		health, err := s.checkMailboxHealth(ctx, account)
+		if err != nil {
+			logger.Errorf("Mailbox health check for %s failed: %v", account.ID.Hex(), err)
+			continue
+		}
+		if health.Status != models.MailboxHealthy {
+			logger.Infof("Mailbox health check for %s: %s %s", account.ID.Hex(), health.Status, health.Error)
+		}
+	}
+}
+
+func (s *MailService) checkMailboxHealth(ctx context.Context, account *models.MailAccount) (*models.MailboxHealth, error) {
+	if account.Email == "" || account.Password == "" {
+		return nil, fmt.Errorf("account has no mailbox credentials yet")
+	}
+
+	health := s.inspectMailbox(ctx, account.Email, account.Password)
+
+	previous := account.MailboxHealth
+	if previous != nil {
+		health.ProbedAt = previous.ProbedAt
+		health.SuspendedByCheck = previous.SuspendedByCheck
+		health.PreviousStatus = previous.PreviousStatus
+	}
+	switch health.Status {
+	case models.MailboxLoginFailed:
+		health.LoginFailures = 1
+		if previous != nil {
+			health.LoginFailures = previous.LoginFailures + 1
+		}
+	case models.MailboxUnreachable:
+		// Nothing was learned about the mailbox, the streak of rejections goes on
+		if previous != nil {
+			health.LoginFailures = previous.LoginFailures
+		}
+	}
+
+	var probe *models.MailboxCheck
+	if health.LoginOK && s.probeDue(health) {
+		probe = s.mailboxVerifier.Verify(ctx, account.Email, account.Password)
+		s.metrics.RecordMailboxCheck(probe)
+		if err := s.accountRepo.UpdateMailboxCheck(ctx, account.ID, probe); err != nil {
+			logger.Errorf("Failed to save mailbox check for %s: %v", account.ID.Hex(), err)
+		}
+
+		probedAt := probe.CheckedAt
+		health.ProbedAt = &probedAt
+		if probe.Status == models.MailboxCheckSpam && health.Status == models.MailboxHealthy {
+			health.Status = models.MailboxSpamPlaced
+		}
+	}
+
+	status, reason := s.mailboxHealthTransition(account, health)
+	if err := s.accountRepo.UpdateMailboxHealth(ctx, account.ID, health, status, reason); err != nil {
+		return nil, fmt.Errorf("failed to save mailbox health: %w", err)
+	}
+	s.metrics.RecordMailboxHealth(health.Status)
+
+	if err := s.publishMailboxHealth(account, health, probe, status); err != nil {
+		logger.Errorf("Failed to publish mailbox health for %s: %v", account.ID.Hex(), err)
+	}
+	if status != "" {
+		if err := s.publishStatusChanged(account, status, reason); err != nil {
+			logger.Errorf("Failed to publish status change for %s: %v", account.ID.Hex(), err)
+		}
+	}
+
+	return health, nil
+}
+
+// inspectMailbox logs into the mailbox and reads its storage quota
+func (s *MailService) inspectMailbox(ctx context.Context, email, password string) *models.MailboxHealth {
+	health := &models.MailboxHealth{CheckedAt: time.Now()}
+
+	client, err := dialIMAP(ctx, s.mailboxCheck.IMAPHost, s.mailboxCheck.IMAPPort, s.mailboxCheck.PollInterval)
+	if err != nil {
+		health.Status = models.MailboxUnreachable
+		health.Error = err.Error()
+		return health
+	}
+	defer client.Close()
+
+	if err := client.Login(email, password); err != nil {
+		health.Error = err.Error()
+		switch {
+		case !errors.Is(err, errIMAPAuth):
+			health.Status = models.MailboxUnreachable
+		case mailboxLocked(err):
+			health.Status = models.MailboxLocked
+		default:
+			health.Status = models.MailboxLoginFailed
+		}
+		return health
+	}
+
+	health.LoginOK = true
+	health.Status = models.MailboxHealthy
+
+	used, limit, ok, err := client.StorageQuota()
+	if err != nil {
+		// The login already proved the mailbox works, a quota read failure is not a finding
+		logger.Errorf("Failed to read mailbox quota: %v", err)
+		return health
+	}
+	if ok {
+		health.QuotaUsedKB = used
+		health.QuotaLimitKB = limit
+		if health.QuotaUsage() >= s.mailboxHealth.QuotaThreshold {
+			health.Status = models.MailboxQuotaFull
+		}
+	}
+
+	return health
+}
+
+// probeDue reports whether spam placement should be measured in this check
+func (s *MailService) probeDue(health *models.MailboxHealth) bool {
+	if s.mailboxHealth.ProbeEvery <= 0 || !s.mailboxVerifier.Configured() {
+		return false
+	}
+	return health.ProbedAt == nil || time.Since(*health.ProbedAt) >= s.mailboxHealth.ProbeEvery
+}
+
+// mailboxHealthTransition returns the account status the check leads to and its reason,
+// an empty status when the account keeps its current one
+func (s *MailService) mailboxHealthTransition(account *models.MailAccount, health *models.MailboxHealth) (models.AccountStatus, string) {
+	suspend := health.Status == models.MailboxLocked ||
+		(health.Status == models.MailboxLoginFailed && health.LoginFailures >= s.mailboxHealth.MaxLoginFailures)
+
+	switch {
+	case suspend && account.Status != models.AccountStatusSuspended:
+		health.SuspendedByCheck = true
+		health.PreviousStatus = account.Status
+		return models.AccountStatusSuspended, health.Error
+	case health.LoginOK && account.Status == models.AccountStatusSuspended && health.SuspendedByCheck:
+		restored := health.PreviousStatus
+		if restored == "" {
+			restored = models.AccountStatusCreated
+		}
+		health.SuspendedByCheck = false
+		health.PreviousStatus = ""
+		return restored, ""
+	}
+	return "", ""
+}
+
+// mailboxLocked reports whether the login rejection says the mailbox is blocked
+func mailboxLocked(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, marker := range lockedMailboxMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MailService) publishMailboxHealth(account *models.MailAccount, health *models.MailboxHealth, probe *models.MailboxCheck, status models.AccountStatus) error {
+	accountStatus := account.Status
+	if status != "" {
+		accountStatus = status
+	}
+
+	payload := map[string]interface{}{
+		"account_id":     account.ID.Hex(),
+		"provider":       account.Provider,
+		"status":         health.Status,
+		"login_ok":       health.LoginOK,
+		"login_failures": health.LoginFailures,
+		"quota_used_kb":  health.QuotaUsedKB,
+		"quota_limit_kb": health.QuotaLimitKB,
+		"account_status": accountStatus,
+		"error":          health.Error,
+		"service":        "mail-service",
+		"timestamp":      time.Now().Unix(),
+	}
+	if probe != nil {
+		payload["probe_status"] = probe.Status
+		payload["probe_folder"] = probe.Folder
+		payload["probe_latency_ms"] = probe.LatencyMs
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mailbox health payload: %w", err)
+	}
+
+	return s.rabbitmqChannel.Publish(
+		"mail.events",                 // exchange
+		"mail.mailbox_health.checked", // routing key
+		false,                         // mandatory
+		false,                         // immediate
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        data,
+		},
+	)
+}
+
+func (s *MailService) publishStatusChanged(account *models.MailAccount, status models.AccountStatus, reason string) error {
+	payload := map[string]interface{}{
+		"account_id": account.ID.Hex(),
+		"old_status": account.Status,
+		"new_status": status,
+		"reason":     reason,
+		"timestamp":  time.Now(),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status change payload: %w", err)
+	}
+
+	return s.rabbitmqChannel.Publish(
+		"mail.events", // exchange
+		fmt.Sprintf("mail.account.status_changed.%s", status), // routing key
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        data,
+		},
+	)
+}
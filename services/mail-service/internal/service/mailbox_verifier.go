@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/services/mail-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// probeHeader marks probe emails so they can be found over IMAP regardless of how the subject is rewritten
+const probeHeader = "X-Conveer-Probe"
+
+// MailboxVerifier checks that a registered mailbox actually receives mail: it sends a probe
+// from a separate sender account and looks the probe up in the inbox and spam folder over IMAP
+type MailboxVerifier struct {
+	config *models.MailboxCheckConfig
+}
+
+// NewMailboxVerifier creates a new mailbox verifier
+func NewMailboxVerifier(config *models.MailboxCheckConfig) *MailboxVerifier {
+	return &MailboxVerifier{config: config}
+}
+
+// Configured reports whether a probe sender is set up
+func (v *MailboxVerifier) Configured() bool {
+	return v.config.SMTPHost != "" && v.config.From != ""
+}
+
+// Verify sends a probe to the mailbox and waits for it to arrive. Failures are reported in the result.
+func (v *MailboxVerifier) Verify(ctx context.Context, email, password string) *models.MailboxCheck {
+	check := &models.MailboxCheck{
+		ProbeID: primitive.NewObjectID().Hex(),
+	}
+	fail := func(err error) *models.MailboxCheck {
+		check.Status = models.MailboxCheckFailed
+		check.Error = err.Error()
+		check.CheckedAt = time.Now()
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.config.Timeout)
+	defer cancel()
+
+	sentAt := time.Now()
+	if err := v.sendProbe(email, check.ProbeID); err != nil {
+		return fail(fmt.Errorf("failed to send probe: %w", err))
+	}
+
+	ticker := time.NewTicker(v.config.PollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		folder, spam, err := v.findProbe(ctx, email, password, check.ProbeID)
+		switch {
+		case errors.Is(err, errIMAPAuth):
+			// The mailbox can't be logged into, waiting won't change that
+			return fail(err)
+		case err != nil:
+			lastErr = err
+		case folder != "":
+			check.Delivered = true
+			check.Folder = folder
+			check.LatencyMs = time.Since(sentAt).Milliseconds()
+			check.CheckedAt = time.Now()
+			check.Status = models.MailboxCheckPassed
+			if spam {
+				check.Status = models.MailboxCheckSpam
+			}
+			return check
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fail(fmt.Errorf("probe not found within %s: %w", v.config.Timeout, lastErr))
+			}
+			return fail(fmt.Errorf("probe not delivered within %s", v.config.Timeout))
+		case <-ticker.C:
+		}
+	}
+}
+
+func (v *MailboxVerifier) sendProbe(to, probeID string) error {
+	addr := net.JoinHostPort(v.config.SMTPHost, strconv.Itoa(v.config.SMTPPort))
+	tlsConfig := &tls.Config{ServerName: v.config.SMTPHost}
+
+	var client *smtp.Client
+	if v.config.SMTPPort == 465 {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 30 * time.Second}, "tcp", addr, tlsConfig)
+		if err != nil {
+			return err
+		}
+		client, err = smtp.NewClient(conn, v.config.SMTPHost)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+	} else {
+		var err error
+		client, err = smtp.Dial(addr)
+		if err != nil {
+			return err
+		}
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				client.Close()
+				return err
+			}
+		}
+	}
+	defer client.Close()
+
+	if v.config.SMTPUsername != "" {
+		auth := smtp.PlainAuth("", v.config.SMTPUsername, v.config.SMTPPassword, v.config.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(v.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildProbeMessage(v.config.From, to, probeID)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// findProbe returns the folder holding the probe and whether it is the spam folder
+func (v *MailboxVerifier) findProbe(ctx context.Context, email, password, probeID string) (string, bool, error) {
+	client, err := dialIMAP(ctx, v.config.IMAPHost, v.config.IMAPPort, v.config.PollInterval)
+	if err != nil {
+		return "", false, err
+	}
+	defer client.Close()
+
+	if err := client.Login(email, password); err != nil {
+		return "", false, err
+	}
+
+	found, err := client.SearchHeader("INBOX", probeHeader, probeID)
+	if err != nil {
+		return "", false, err
+	}
+	if found {
+		return "INBOX", false, nil
+	}
+
+	junk, err := client.JunkFolder()
+	if err != nil {
+		return "", false, err
+	}
+	found, err = client.SearchHeader(junk, probeHeader, probeID)
+	if err != nil {
+		return "", false, err
+	}
+	if found {
+		return junk, true, nil
+	}
+
+	return "", false, nil
+}
+
+func buildProbeMessage(from, to, probeID string) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + to + "\r\n")
+	sb.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", "Проверка почтового ящика") + "\r\n")
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("Message-ID: <" + probeID + "@" + messageIDDomain(from) + ">\r\n")
+	sb.WriteString(probeHeader + ": " + probeID + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString("Здравствуйте! Это письмо отправлено автоматически, отвечать на него не нужно.\r\n")
+	return []byte(sb.String())
+}
+
+func messageIDDomain(from string) string {
+	if i := strings.LastIndex(from, "@"); i >= 0 {
+		return strings.TrimSuffix(from[i+1:], ">")
+	}
+	return "localhost"
+}
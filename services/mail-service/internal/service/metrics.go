@@ -3,6 +3,7 @@ package service
 import (
 	"time"
 
+	"github.com/grigta/conveer/services/mail-service/internal/models"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -20,6 +21,16 @@ type MetricsCollector struct {
 	manualInterventions   *prometheus.CounterVec
 	sessionsActive        prometheus.Gauge
 	sessionsDuration      prometheus.Histogram
+	registrationsInFlight prometheus.Gauge
+	proxyPacingWait       prometheus.Histogram
+	mailboxChecks         *prometheus.CounterVec
+	probeLatency          prometheus.Histogram
+	mailboxHealth         *prometheus.CounterVec
+	recoveryMailboxes     *prometheus.CounterVec
+	browserPoolIdle       prometheus.Gauge
+	browserPoolInUse      prometheus.Gauge
+	browserPoolTarget     prometheus.Gauge
+	browserPoolUsage      prometheus.Gauge
 }
 
 // NewMetricsCollector creates a new metrics collector
@@ -90,6 +101,57 @@ func NewMetricsCollector() *MetricsCollector {
 			Help:    "Duration of registration sessions",
 			Buckets: prometheus.ExponentialBuckets(30, 2, 10),
 		}),
+		registrationsInFlight: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "mail_service_registrations_in_flight",
+			Help: "Number of registrations currently processed by workers",
+		}),
+		proxyPacingWait: promauto.NewHistogram(prometheus.HistogramOpts{
+			Name:    "mail_service_proxy_pacing_wait_seconds",
+			Help:    "Time a registration waited for its proxy to become available",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+		}),
+		mailboxChecks: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "mail_service_mailbox_checks_total",
+				Help: "Mailbox delivery checks by result",
+			},
+			[]string{"status"},
+		),
+		probeLatency: promauto.NewHistogram(prometheus.HistogramOpts{
+			Name:    "mail_service_probe_delivery_seconds",
+			Help:    "Time for a probe email to show up in the checked mailbox",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 9),
+		}),
+		mailboxHealth: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "mail_service_mailbox_health_checks_total",
+				Help: "Periodic mailbox health checks by result",
+			},
+			[]string{"status"},
+		),
+		recoveryMailboxes: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "mail_service_recovery_mailboxes_total",
+				Help: "Recovery mailbox reservations and releases by platform and result",
+			},
+			[]string{"platform", "result"},
+		),
+		browserPoolIdle: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "mail_service_browser_pool_idle",
+			Help: "Number of idle browsers in the pool",
+		}),
+		browserPoolInUse: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "mail_service_browser_pool_in_use",
+			Help: "Number of browsers currently running a registration",
+		}),
+		browserPoolTarget: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "mail_service_browser_pool_target_size",
+			Help: "Target size of the browser pool, changed at runtime by resizing",
+		}),
+		browserPoolUsage: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "mail_service_browser_pool_utilization",
+			Help: "Share of the target browser pool size currently in use",
+		}),
 	}
 }
 
@@ -147,3 +209,46 @@ func (m *MetricsCollector) SetActiveSessions(count float64) {
 func (m *MetricsCollector) RecordSessionDuration(duration time.Duration) {
 	m.sessionsDuration.Observe(duration.Seconds())
 }
+
+// IncrementRegistrationsInFlight marks a registration picked up by a worker
+func (m *MetricsCollector) IncrementRegistrationsInFlight() {
+	m.registrationsInFlight.Inc()
+}
+
+// DecrementRegistrationsInFlight marks a registration finished by a worker
+func (m *MetricsCollector) DecrementRegistrationsInFlight() {
+	m.registrationsInFlight.Dec()
+}
+
+// RecordProxyPacingWait records how long a registration waited for its proxy
+func (m *MetricsCollector) RecordProxyPacingWait(duration time.Duration) {
+	m.proxyPacingWait.Observe(duration.Seconds())
+}
+
+// RecordMailboxCheck records the result of a mailbox delivery check
+func (m *MetricsCollector) RecordMailboxCheck(check *models.MailboxCheck) {
+	m.mailboxChecks.WithLabelValues(string(check.Status)).Inc()
+	if check.Delivered {
+		m.probeLatency.Observe((time.Duration(check.LatencyMs) * time.Millisecond).Seconds())
+	}
+}
+
+// RecordMailboxHealth records the result of a periodic mailbox health check
+func (m *MetricsCollector) RecordMailboxHealth(status models.MailboxHealthStatus) {
+	m.mailboxHealth.WithLabelValues(string(status)).Inc()
+}
+
+// RecordRecoveryMailbox records a recovery mailbox reservation or release
+func (m *MetricsCollector) RecordRecoveryMailbox(platform, result string) {
+	m.recoveryMailboxes.WithLabelValues(platform, result).Inc()
+}
+
+// UpdateBrowserPool records the browser pool state
+func (m *MetricsCollector) UpdateBrowserPool(idle, inUse, target int) {
+	m.browserPoolIdle.Set(float64(idle))
+	m.browserPoolInUse.Set(float64(inUse))
+	m.browserPoolTarget.Set(float64(target))
+	if target > 0 {
+		m.browserPoolUsage.Set(float64(inUse) / float64(target))
+	}
+}
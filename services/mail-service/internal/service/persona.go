@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/mail-service/internal/models"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
+)
+
+const personaPlatform = "mail"
+
+// applyPersona reserves an existing persona, or one reused from other platforms or newly generated.
+// The persona email prefix is used unless the caller asked for a custom one. Random profiles are
+// generated locally so registrations keep working while the persona service is down.
+func (s *MailService) applyPersona(ctx context.Context, req *models.RegistrationRequest) error {
+	persona, err := s.personaClient.AllocatePersona(ctx, &personapb.AllocatePersonaRequest{
+		Platform:      personaPlatform,
+		PersonaId:     req.PersonaID,
+		Gender:        req.Gender,
+		ReuseExisting: req.PersonaID == "",
+	})
+	if err != nil {
+		if req.PersonaID != "" {
+			return fmt.Errorf("failed to reserve persona %s: %w", req.PersonaID, err)
+		}
+		logger.Warnf("Persona service unavailable, generating profile locally: %v", err)
+
+		profile := GenerateRandomProfile(req.Gender)
+		req.FirstName = profile.FirstName
+		req.LastName = profile.LastName
+		req.BirthDate = profile.BirthDate
+		req.Gender = profile.Gender
+		return nil
+	}
+
+	req.PersonaID = persona.Id
+	req.PersonaReservationID = persona.ReservationId
+	req.FirstName = persona.FirstName
+	req.LastName = persona.LastName
+	req.BirthDate = persona.BirthDate
+	req.Gender = persona.Gender
+	if req.CustomEmailPrefix == "" {
+		req.CustomEmailPrefix = persona.EmailPrefix
+	}
+
+	return nil
+}
+
+// confirmPersona turns the reservation into an assignment so the identity is not reused on mail.ru
+func (s *MailService) confirmPersona(ctx context.Context, account *models.MailAccount, reservationID string) {
+	if account.PersonaID == "" || reservationID == "" {
+		return
+	}
+
+	if _, err := s.personaClient.ConfirmReservation(ctx, &personapb.ConfirmReservationRequest{
+		PersonaId:     account.PersonaID,
+		ReservationId: reservationID,
+		AccountId:     account.ID.Hex(),
+	}); err != nil {
+		logger.Errorf("Failed to confirm persona %s reservation for account %s: %v", account.PersonaID, account.ID.Hex(), err)
+	}
+}
+
+// cancelPersona frees the persona of a registration that did not produce an account
+func (s *MailService) cancelPersona(ctx context.Context, req *models.RegistrationRequest) {
+	if req.PersonaID == "" || req.PersonaReservationID == "" {
+		return
+	}
+
+	if _, err := s.personaClient.CancelReservation(ctx, &personapb.CancelReservationRequest{
+		PersonaId:     req.PersonaID,
+		ReservationId: req.PersonaReservationID,
+	}); err != nil {
+		logger.Errorf("Failed to cancel persona %s reservation: %v", req.PersonaID, err)
+	}
+}
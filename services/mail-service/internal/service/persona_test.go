@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grigta/conveer/services/mail-service/internal/models"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakePersonaClient answers AllocatePersona; other methods are not used by applyPersona
+type fakePersonaClient struct {
+	personapb.PersonaServiceClient
+
+	persona   *personapb.Persona
+	err       error
+	allocated *personapb.AllocatePersonaRequest
+}
+
+func (c *fakePersonaClient) AllocatePersona(ctx context.Context, in *personapb.AllocatePersonaRequest, opts ...grpc.CallOption) (*personapb.Persona, error) {
+	c.allocated = in
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.persona, nil
+}
+
+func TestApplyPersona_FillsFromPersona(t *testing.T) {
+	client := &fakePersonaClient{persona: &personapb.Persona{
+		Id:            "p1",
+		ReservationId: "r1",
+		FirstName:     "Анна",
+		LastName:      "Смирнова",
+		BirthDate:     "1995-04-12",
+		Gender:        "female",
+		EmailPrefix:   "anna.smirnova",
+	}}
+	s := &MailService{personaClient: client}
+	req := &models.RegistrationRequest{UseRandomProfile: true, Gender: "female"}
+
+	require.NoError(t, s.applyPersona(context.Background(), req))
+
+	assert.True(t, client.allocated.ReuseExisting)
+	assert.Equal(t, "female", client.allocated.Gender)
+	assert.Equal(t, "p1", req.PersonaID)
+	assert.Equal(t, "r1", req.PersonaReservationID)
+	assert.Equal(t, "Анна", req.FirstName)
+	assert.Equal(t, "1995-04-12", req.BirthDate)
+	assert.Equal(t, "anna.smirnova", req.CustomEmailPrefix)
+}
+
+func TestApplyPersona_GeneratesLocallyWhenUnavailable(t *testing.T) {
+	s := &MailService{personaClient: &fakePersonaClient{err: status.Error(codes.Unavailable, "connection refused")}}
+	req := &models.RegistrationRequest{UseRandomProfile: true, Gender: "female"}
+
+	require.NoError(t, s.applyPersona(context.Background(), req))
+
+	assert.Empty(t, req.PersonaID)
+	assert.Empty(t, req.PersonaReservationID)
+	assert.NotEmpty(t, req.FirstName)
+	assert.NotEmpty(t, req.LastName)
+	assert.Equal(t, "female", req.Gender)
+
+	_, _, _, err := splitBirthDate(req.BirthDate)
+	assert.NoError(t, err)
+}
+
+func TestApplyPersona_RequestedPersonaUnavailable(t *testing.T) {
+	s := &MailService{personaClient: &fakePersonaClient{err: status.Error(codes.Unavailable, "connection refused")}}
+	req := &models.RegistrationRequest{PersonaID: "p1"}
+
+	err := s.applyPersona(context.Background(), req)
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Empty(t, req.FirstName)
+}
+
+func TestGenerateRandomProfile(t *testing.T) {
+	for _, gender := range []string{"male", "female"} {
+		profile := GenerateRandomProfile(gender)
+		assert.Equal(t, gender, profile.Gender)
+		assert.NotEmpty(t, profile.FirstName)
+		assert.Equal(t, gender == "female", []rune(profile.LastName)[len([]rune(profile.LastName))-1] == 'а')
+	}
+
+	assert.Contains(t, []string{"male", "female"}, GenerateRandomProfile("").Gender)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pacerPollInterval is how often a signup re-checks a proxy that is at its concurrency limit
+const pacerPollInterval = time.Second
+
+// ProxyPacer spaces out signups that share a proxy so mail.ru doesn't see bursts from one IP
+type ProxyPacer struct {
+	mu            sync.Mutex
+	minInterval   time.Duration
+	maxConcurrent int
+	slots         map[string]*proxySlot
+}
+
+type proxySlot struct {
+	active    int
+	lastStart time.Time
+}
+
+// NewProxyPacer creates a pacer allowing maxConcurrent signups per proxy, started at least minInterval apart
+func NewProxyPacer(minInterval time.Duration, maxConcurrent int) *ProxyPacer {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	return &ProxyPacer{
+		minInterval:   minInterval,
+		maxConcurrent: maxConcurrent,
+		slots:         make(map[string]*proxySlot),
+	}
+}
+
+// Acquire blocks until the proxy may start another signup and returns the function releasing the slot
+func (p *ProxyPacer) Acquire(ctx context.Context, proxyID string) (func(), error) {
+	for {
+		wait := p.reserve(proxyID)
+		if wait == 0 {
+			var once sync.Once
+			return func() {
+				once.Do(func() { p.release(proxyID) })
+			}, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a slot if the proxy is ready, otherwise returns how long to wait before asking again
+func (p *ProxyPacer) reserve(proxyID string) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	p.prune(now)
+
+	slot, ok := p.slots[proxyID]
+	if !ok {
+		slot = &proxySlot{}
+		p.slots[proxyID] = slot
+	}
+
+	if slot.active >= p.maxConcurrent {
+		return pacerPollInterval
+	}
+
+	if !slot.lastStart.IsZero() {
+		if elapsed := now.Sub(slot.lastStart); elapsed < p.minInterval {
+			return p.minInterval - elapsed
+		}
+	}
+
+	slot.active++
+	slot.lastStart = now
+	return 0
+}
+
+func (p *ProxyPacer) release(proxyID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if slot, ok := p.slots[proxyID]; ok && slot.active > 0 {
+		slot.active--
+	}
+}
+
+// prune drops idle proxies whose interval has passed, they have nothing left to enforce
+func (p *ProxyPacer) prune(now time.Time) {
+	for id, slot := range p.slots {
+		if slot.active == 0 && now.Sub(slot.lastStart) >= p.minInterval {
+			delete(p.slots, id)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/mail-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrNoRecoveryMailbox is returned when every mailbox that passed the delivery check is already bound
+var ErrNoRecoveryMailbox = errors.New("no verified mailbox is free")
+
+// verificationMailLookback is how many of the sender's latest messages are checked per folder
+const verificationMailLookback = 5
+
+// maxMailPartSize caps how much of a single MIME part is read
+const maxMailPartSize = 1 << 20
+
+var (
+	mailLinkPattern = regexp.MustCompile(`https?://[^\s"'<>()]+`)
+	mailTagPattern  = regexp.MustCompile(`<[^>]*>`)
+	mailCodePattern = regexp.MustCompile(`\b\d{4,8}\b`)
+)
+
+// ReserveRecoveryMailbox binds a mailbox that passed the delivery check to an account on another
+// platform. Calling it again for the same account returns the mailbox already bound to it.
+func (s *MailService) ReserveRecoveryMailbox(ctx context.Context, platform, consumerID string) (*models.MailAccount, error) {
+	account, err := s.accountRepo.FindByBinding(ctx, platform, consumerID)
+	if err == nil {
+		return account, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("failed to look up bound mailbox: %w", err)
+	}
+
+	account, err = s.accountRepo.BindFreeMailbox(ctx, &models.MailboxBinding{
+		Platform:  platform,
+		AccountID: consumerID,
+		BoundAt:   time.Now(),
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		s.metrics.RecordRecoveryMailbox(platform, "exhausted")
+		return nil, ErrNoRecoveryMailbox
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind mailbox: %w", err)
+	}
+
+	s.metrics.RecordRecoveryMailbox(platform, "reserved")
+	logger.Infof("Mailbox %s bound to %s account %s", account.ID.Hex(), platform, consumerID)
+	return account, nil
+}
+
+// ReleaseRecoveryMailbox frees the mailbox bound to an account, e.g. when binding it on the platform failed
+func (s *MailService) ReleaseRecoveryMailbox(ctx context.Context, platform, consumerID string) error {
+	released, err := s.accountRepo.Unbind(ctx, platform, consumerID)
+	if err != nil {
+		return fmt.Errorf("failed to release mailbox: %w", err)
+	}
+	if released {
+		s.metrics.RecordRecoveryMailbox(platform, "released")
+		logger.Infof("Mailbox released by %s account %s", platform, consumerID)
+	}
+	return nil
+}
+
+// ReadVerificationMail returns the latest email from the sender received after since,
+// nil when none has arrived yet. The inbox is checked first, then the spam folder.
+func (s *MailService) ReadVerificationMail(ctx context.Context, accountID, from string, since time.Time) (*models.VerificationMail, error) {
+	id, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID: %w", err)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if account.Email == "" || account.Password == "" {
+		return nil, fmt.Errorf("account has no mailbox credentials yet")
+	}
+
+	client, err := dialIMAP(ctx, s.mailboxCheck.IMAPHost, s.mailboxCheck.IMAPPort, s.mailboxCheck.PollInterval)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	if err := client.Login(account.Email, account.Password); err != nil {
+		return nil, err
+	}
+
+	junk, err := client.JunkFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, folder := range []string{"INBOX", junk} {
+		found, err := findVerificationMail(client, folder, from, since)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findVerificationMail returns the newest message in the folder from the sender dated after since
+func findVerificationMail(client *imapClient, folder, from string, since time.Time) (*models.VerificationMail, error) {
+	uids, err := client.SearchFrom(folder, from, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(uids) - 1; i >= 0 && i >= len(uids)-verificationMailLookback; i-- {
+		raw, err := client.FetchMessage(uids[i])
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := parseVerificationMail(raw)
+		if err != nil {
+			logger.Warnf("Skipping unreadable message %d in %s: %v", uids[i], folder, err)
+			continue
+		}
+		if found.ReceivedAt.IsZero() || !found.ReceivedAt.Before(since) {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// parseVerificationMail extracts the subject, links and numeric code of a confirmation email.
+// Bodies are expected in UTF-8, which is what the platforms we register on send.
+func parseVerificationMail(raw []byte) (*models.VerificationMail, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+
+	found := &models.VerificationMail{
+		From:    msg.Header.Get("From"),
+		Subject: msg.Header.Get("Subject"),
+	}
+	if subject, err := new(mime.WordDecoder).DecodeHeader(found.Subject); err == nil {
+		found.Subject = subject
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		found.ReceivedAt = date
+	}
+
+	body := readMailText(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body, 0)
+
+	seen := make(map[string]bool)
+	for _, link := range mailLinkPattern.FindAllString(body, -1) {
+		link = strings.TrimRight(html.UnescapeString(link), ".,;")
+		if !seen[link] {
+			seen[link] = true
+			found.Links = append(found.Links, link)
+		}
+	}
+
+	// Links carry digits of their own, so they are dropped before looking for the code
+	text := mailTagPattern.ReplaceAllString(mailLinkPattern.ReplaceAllString(body, " "), " ")
+	if code := mailCodePattern.FindString(found.Subject); code != "" {
+		found.Code = code
+	} else {
+		found.Code = mailCodePattern.FindString(html.UnescapeString(text))
+	}
+
+	return found, nil
+}
+
+// readMailText returns the decoded text and HTML parts of a message body
+func readMailText(contentType, encoding string, body io.Reader, depth int) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth > 3 || params["boundary"] == "" {
+			return ""
+		}
+
+		var parts []string
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err != nil {
+				break
+			}
+			if text := readMailText(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part, depth+1); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return ""
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxMailPartSize))
+	if err != nil && len(data) == 0 {
+		return ""
+	}
+	return string(data)
+}
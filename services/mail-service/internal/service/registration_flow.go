@@ -4,11 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"math/rand"
 	"strings"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/mail-service/internal/models"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
 	smspb "github.com/grigta/conveer/services/sms-service/proto"
@@ -16,14 +16,24 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// RegistrationFlow handles the mail.ru registration process
+// proxyCountryChain lets registration fall back to neighbouring countries when the RU pool is empty
+var proxyCountryChain = []*proxypb.CountryPreference{
+	{Country: "RU"},
+	{Country: "KZ"},
+	{Country: "BY"},
+}
+
+// RegistrationFlow handles the registration process, the account provider supplies the signup form
 type RegistrationFlow struct {
-	service *MailService
-	ctx     context.Context
-	account *models.MailAccount
-	session *models.RegistrationSession
-	browser playwright.Browser
-	page    playwright.Page
+	service  *MailService
+	ctx      context.Context
+	account  *models.MailAccount
+	session  *models.RegistrationSession
+	provider MailProvider
+	browser  playwright.Browser
+	page     playwright.Page
+
+	releaseProxy func()
 }
 
 // NewRegistrationFlow creates a new registration flow
@@ -38,11 +48,22 @@ func (s *MailService) NewRegistrationFlow(ctx context.Context, accountID primiti
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 	
+	// Accounts created before provider selection are mail.ru ones
+	providerName := account.Provider
+	if providerName == "" {
+		providerName = models.MailProviderMailRu
+	}
+	provider, err := LookupMailProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	
 	return &RegistrationFlow{
-		service: s,
-		ctx:     ctx,
-		account: account,
-		session: session,
+		service:  s,
+		ctx:      ctx,
+		account:  account,
+		session:  session,
+		provider: provider,
 	}, nil
 }
 
@@ -50,7 +71,10 @@ func (s *MailService) NewRegistrationFlow(ctx context.Context, accountID primiti
 func (f *RegistrationFlow) Execute() error {
 	start := time.Now()
 	defer func() {
-		f.service.metrics.RecordStepDuration("total", time.Since(start))
+		f.service.metrics.RecordStepDuration(registrationStepTotal, time.Since(start))
+		if f.releaseProxy != nil {
+			f.releaseProxy()
+		}
 		// Release browser if it was allocated
 		if f.browser != nil {
 			f.service.browserManager.ReleaseBrowser(f.browser)
@@ -81,9 +105,16 @@ func (f *RegistrationFlow) Execute() error {
 	}
 	
 	for i := startIdx; i < len(steps); i++ {
+		// Once the proxy is known, wait for its turn before opening the signup form
+		if steps[i].step != models.StepProxyAllocation && f.releaseProxy == nil && f.session.ProxyID != "" {
+			if err := f.waitForProxy(); err != nil {
+				return err
+			}
+		}
+		
 		stepStart := time.Now()
 		
-		log.Printf("Executing step: %s", steps[i].step)
+		logger.Infof("Executing step: %s", steps[i].step)
 		f.session.CurrentStep = steps[i].step
 		f.service.sessionRepo.UpdateStep(f.ctx, f.session.ID, steps[i].step, nil)
 		
@@ -92,7 +123,7 @@ func (f *RegistrationFlow) Execute() error {
 			return fmt.Errorf("step %s failed: %w", steps[i].step, err)
 		}
 		
-		f.service.metrics.RecordStepDuration(string(steps[i].step), time.Since(stepStart))
+		f.recordStep(string(steps[i].step), time.Since(stepStart))
 		f.session.LastActivityAt = time.Now()
 	}
 	
@@ -105,6 +136,7 @@ func (f *RegistrationFlow) Execute() error {
 	// Update account status
 	f.service.accountRepo.UpdateAccountStatus(f.ctx, f.account.ID, models.AccountStatusCreated, "")
 	f.service.metrics.IncrementRegistrationSuccess()
+	f.publishStepCompleted(registrationStepTotal, time.Since(start))
 	
 	return nil
 }
@@ -112,33 +144,48 @@ func (f *RegistrationFlow) Execute() error {
 // Step 1: Allocate proxy
 func (f *RegistrationFlow) allocateProxy() error {
 	resp, err := f.service.proxyClient.AllocateProxy(f.ctx, &proxypb.AllocateProxyRequest{
-		Type:    "mobile",
-		Country: "RU",
+		Type:         "mobile",
+		Country:      "RU",
+		CountryChain: proxyCountryChain,
+		Platform:     "mail",
 	})
 	if err != nil {
 		return fmt.Errorf("failed to allocate proxy: %w", err)
 	}
 	
-	f.session.ProxyID = resp.ProxyId
-	f.session.ProxyURL = resp.ProxyUrl
-	f.account.ProxyID = resp.ProxyId
-	f.account.RegistrationIP = resp.IpAddress
+	f.session.ProxyID = resp.Id
+	f.session.ProxyURL = proxyURL(resp)
+	f.account.ProxyID = resp.Id
+	f.account.RegistrationIP = resp.Ip
 	
 	// Save checkpoint
 	f.session.StepCheckpoints["proxy"] = map[string]string{
-		"proxy_id":  resp.ProxyId,
-		"proxy_url": resp.ProxyUrl,
-		"ip":        resp.IpAddress,
+		"proxy_id":  resp.Id,
+		"proxy_url": f.session.ProxyURL,
+		"ip":        resp.Ip,
 	}
 	
 	f.service.sessionRepo.UpdateSession(f.ctx, f.account.ID, map[string]interface{}{
-		"proxy_id":  resp.ProxyId,
-		"proxy_url": resp.ProxyUrl,
+		"proxy_id":  resp.Id,
+		"proxy_url": f.session.ProxyURL,
 	})
 	
 	return nil
 }
 
+// waitForProxy takes a pacing slot on the session proxy
+func (f *RegistrationFlow) waitForProxy() error {
+	waitStart := time.Now()
+	release, err := f.service.proxyPacer.Acquire(f.ctx, f.session.ProxyID)
+	if err != nil {
+		return fmt.Errorf("failed to wait for proxy %s: %w", f.session.ProxyID, err)
+	}
+	f.service.metrics.RecordProxyPacingWait(time.Since(waitStart))
+	f.releaseProxy = release
+	
+	return nil
+}
+
 // Step 2: Generate email
 func (f *RegistrationFlow) generateEmail() error {
 	// Generate random email or use custom prefix
@@ -154,7 +201,7 @@ func (f *RegistrationFlow) generateEmail() error {
 		f.session.StepCheckpoints["email_prefix"] = prefixStr
 	}
 
-	f.session.Email = fmt.Sprintf("%s@mail.ru", prefixStr)
+	f.session.Email = fmt.Sprintf("%s@%s", prefixStr, f.provider.Domain())
 	f.account.Email = f.session.Email
 
 	// Generate password
@@ -180,7 +227,7 @@ func (f *RegistrationFlow) generateEmail() error {
 func (f *RegistrationFlow) fillRegistrationForm() error {
 	// Setup browser with proxy
 	fingerprint := GenerateFingerprint()
-	f.account.Fingerprint = fingerprint
+	f.account.Fingerprint = models.Fingerprint(fingerprint)
 	f.account.UserAgent = fingerprint.UserAgent
 	
 	browser, err := f.service.browserManager.AcquireBrowser(f.ctx, &BrowserConfig{
@@ -204,8 +251,10 @@ func (f *RegistrationFlow) fillRegistrationForm() error {
 		return fmt.Errorf("failed to inject stealth: %w", err)
 	}
 	
+	selectors := f.provider.Selectors()
+	
 	// Navigate to signup page
-	if _, err := page.Goto("https://account.mail.ru/signup", playwright.PageGotoOptions{
+	if _, err := page.Goto(f.provider.SignupURL(), playwright.PageGotoOptions{
 		WaitUntil: playwright.WaitUntilStateNetworkidle,
 		Timeout:   playwright.Float(30000),
 	}); err != nil {
@@ -213,39 +262,51 @@ func (f *RegistrationFlow) fillRegistrationForm() error {
 	}
 	
 	// Wait for form
-	if err := page.WaitForSelector("form", playwright.PageWaitForSelectorOptions{
+	if _, err := page.WaitForSelector(selectors.Form, playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(10000),
 	}); err != nil {
 		return fmt.Errorf("registration form not found: %w", err)
 	}
 	
 	// Fill email
-	if err := f.typeWithHumanSpeed(page, "input[name='email']", strings.Split(f.session.Email, "@")[0]); err != nil {
+	if err := f.fillField(page, selectors.Login, strings.Split(f.session.Email, "@")[0]); err != nil {
 		return fmt.Errorf("failed to fill email: %w", err)
 	}
+	if err := f.provider.NextPage(page); err != nil {
+		return fmt.Errorf("failed to submit email: %w", err)
+	}
 	
 	// Fill password
-	if err := f.typeWithHumanSpeed(page, "input[name='password']", f.session.Password); err != nil {
+	if err := f.fillField(page, selectors.Password, f.session.Password); err != nil {
 		return fmt.Errorf("failed to fill password: %w", err)
 	}
+	if err := f.fillField(page, selectors.PasswordConfirm, f.session.Password); err != nil {
+		return fmt.Errorf("failed to confirm password: %w", err)
+	}
+	if err := f.provider.NextPage(page); err != nil {
+		return fmt.Errorf("failed to submit password: %w", err)
+	}
 	
 	// Fill first name
-	if err := f.typeWithHumanSpeed(page, "input[name='firstname']", f.account.FirstName); err != nil {
+	if err := f.fillField(page, selectors.FirstName, f.account.FirstName); err != nil {
 		return fmt.Errorf("failed to fill first name: %w", err)
 	}
 	
 	// Fill last name
-	if err := f.typeWithHumanSpeed(page, "input[name='lastname']", f.account.LastName); err != nil {
+	if err := f.fillField(page, selectors.LastName, f.account.LastName); err != nil {
 		return fmt.Errorf("failed to fill last name: %w", err)
 	}
+	if err := f.provider.NextPage(page); err != nil {
+		return fmt.Errorf("failed to submit name: %w", err)
+	}
 	
 	// Set birth date
-	if err := f.setBirthDate(page, f.account.BirthDate); err != nil {
+	if err := f.provider.SetBirthDate(page, f.account.BirthDate); err != nil {
 		return fmt.Errorf("failed to set birth date: %w", err)
 	}
 	
 	// Select gender
-	if err := f.selectGender(page, f.account.Gender); err != nil {
+	if err := f.provider.SelectGender(page, f.account.Gender); err != nil {
 		return fmt.Errorf("failed to select gender: %w", err)
 	}
 	
@@ -253,7 +314,7 @@ func (f *RegistrationFlow) fillRegistrationForm() error {
 	time.Sleep(time.Duration(rand.Intn(2000)+1000) * time.Millisecond)
 	
 	// Click submit
-	if err := page.Click("button[type='submit']"); err != nil {
+	if err := page.Click(selectors.Submit); err != nil {
 		return fmt.Errorf("failed to submit form: %w", err)
 	}
 	
@@ -270,8 +331,13 @@ func (f *RegistrationFlow) verifyPhone() error {
 		return nil
 	}
 
+	selectors := f.provider.Selectors()
+	if selectors.Phone == "" {
+		return nil
+	}
+	
 	// Check if phone field exists
-	phoneExists, err := f.page.Locator("input[name='phone']").Count()
+	phoneExists, err := f.page.Locator(selectors.Phone).Count()
 	if err != nil || phoneExists == 0 {
 		// Phone verification not required by the form
 		return nil
@@ -279,8 +345,9 @@ func (f *RegistrationFlow) verifyPhone() error {
 	
 	// Purchase phone number
 	resp, err := f.service.smsClient.PurchaseNumber(f.ctx, &smspb.PurchaseNumberRequest{
-		Service: "mail.ru",
-		Country: "RU",
+		Service:        f.provider.SMSService(),
+		Country:        "RU",
+		IdempotencyKey: "mail:registration:" + f.account.ID.Hex(),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to purchase phone: %w", err)
@@ -292,12 +359,12 @@ func (f *RegistrationFlow) verifyPhone() error {
 	f.account.ActivationID = resp.ActivationId
 	
 	// Enter phone number
-	if err := f.typeWithHumanSpeed(f.page, "input[name='phone']", resp.PhoneNumber); err != nil {
+	if err := f.typeWithHumanSpeed(f.page, selectors.Phone, resp.PhoneNumber); err != nil {
 		return fmt.Errorf("failed to enter phone: %w", err)
 	}
 	
 	// Click send SMS
-	if err := f.page.Click("button[data-test-id='send-code-button']"); err != nil {
+	if err := f.page.Click(selectors.SendCode); err != nil {
 		return fmt.Errorf("failed to send SMS: %w", err)
 	}
 	
@@ -320,12 +387,12 @@ func (f *RegistrationFlow) verifyPhone() error {
 	}
 	
 	// Enter SMS code
-	if err := f.typeWithHumanSpeed(f.page, "input[name='code']", smsCode); err != nil {
+	if err := f.typeWithHumanSpeed(f.page, selectors.Code, smsCode); err != nil {
 		return fmt.Errorf("failed to enter SMS code: %w", err)
 	}
 	
 	// Submit code
-	if err := f.page.Click("button[type='submit']"); err != nil {
+	if err := f.page.Click(selectors.CodeSubmit); err != nil {
 		return fmt.Errorf("failed to submit SMS code: %w", err)
 	}
 	
@@ -338,22 +405,14 @@ func (f *RegistrationFlow) verifyPhone() error {
 // Step 5: Handle CAPTCHA
 func (f *RegistrationFlow) handleCaptcha() error {
 	// Check for CAPTCHA
-	captchaSelectors := []string{
-		".captcha-image",
-		".g-recaptcha",
-		"iframe[src*='captcha']",
-		"div[class*='captcha']",
-	}
-	
-	for _, selector := range captchaSelectors {
+	for _, selector := range f.provider.Selectors().Captcha {
 		count, _ := f.page.Locator(selector).Count()
 		if count > 0 {
 			f.session.CaptchaDetected = true
-			f.service.metrics.IncrementCaptchaDetected()
 			
 			// Publish to manual intervention queue
 			if err := f.service.publishManualIntervention(f.account.ID.Hex(), "CAPTCHA detected"); err != nil {
-				log.Printf("Failed to publish manual intervention: %v", err)
+				logger.Errorf("Failed to publish manual intervention: %v", err)
 			}
 			
 			// Update account status
@@ -368,8 +427,14 @@ func (f *RegistrationFlow) handleCaptcha() error {
 
 // Step 6: Confirm email
 func (f *RegistrationFlow) confirmEmail() error {
+	inboxURL := f.provider.InboxURL()
+	if inboxURL == "" {
+		// The provider sends no confirmation email
+		return nil
+	}
+	
 	// Check if email confirmation is required
-	if _, err := f.page.Goto("https://e.mail.ru/inbox", playwright.PageGotoOptions{
+	if _, err := f.page.Goto(inboxURL, playwright.PageGotoOptions{
 		WaitUntil: playwright.WaitUntilStateNetworkidle,
 		Timeout:   playwright.Float(30000),
 	}); err != nil {
@@ -381,7 +446,7 @@ func (f *RegistrationFlow) confirmEmail() error {
 	time.Sleep(5 * time.Second)
 	
 	// Look for confirmation email
-	confirmationLink, err := f.page.Locator("a[href*='confirm']").First().GetAttribute("href")
+	confirmationLink, err := f.page.Locator(f.provider.Selectors().ConfirmLink).First().GetAttribute("href")
 	if err != nil || confirmationLink == "" {
 		// No confirmation required
 		return nil
@@ -431,8 +496,16 @@ func (f *RegistrationFlow) setupProfile() error {
 
 // Helper methods
 
+// proxyURL builds the browser proxy address of an allocated proxy
+func proxyURL(p *proxypb.ProxyResponse) string {
+	if p.Username != "" {
+		return fmt.Sprintf("%s://%s:%s@%s:%d", p.Protocol, p.Username, p.Password, p.Ip, p.Port)
+	}
+	return fmt.Sprintf("%s://%s:%d", p.Protocol, p.Ip, p.Port)
+}
+
 func (f *RegistrationFlow) handleStepError(step models.RegistrationStep, err error) {
-	f.service.metrics.IncrementRegistrationFailure(string(step))
+	f.service.metrics.IncrementRegistrationFailures(string(step))
 	
 	// Check for specific errors
 	errorMsg := err.Error()
@@ -456,7 +529,7 @@ func (f *RegistrationFlow) handleStepError(step models.RegistrationStep, err err
 	
 	if f.session.ProxyID != "" {
 		f.service.proxyClient.ReleaseProxy(f.ctx, &proxypb.ReleaseProxyRequest{
-			ProxyId: f.session.ProxyID,
+			AccountId: f.account.ID.Hex(),
 		})
 	}
 	
@@ -471,46 +544,12 @@ func (f *RegistrationFlow) typeWithHumanSpeed(page playwright.Page, selector str
 	return TypeWithHumanSpeed(page, selector, text)
 }
 
-func (f *RegistrationFlow) setBirthDate(page playwright.Page, birthDate string) error {
-	// Parse birth date (format: YYYY-MM-DD)
-	parts := strings.Split(birthDate, "-")
-	if len(parts) != 3 {
-		return fmt.Errorf("invalid birth date format")
-	}
-	
-	// Fill day
-	if err := page.SelectOption("select[name='birth_day']", playwright.SelectOptionValues{
-		Values: &[]string{parts[2]},
-	}); err != nil {
-		return err
-	}
-	
-	// Fill month
-	if err := page.SelectOption("select[name='birth_month']", playwright.SelectOptionValues{
-		Values: &[]string{parts[1]},
-	}); err != nil {
-		return err
-	}
-	
-	// Fill year
-	if err := page.SelectOption("select[name='birth_year']", playwright.SelectOptionValues{
-		Values: &[]string{parts[0]},
-	}); err != nil {
-		return err
+// fillField types into a form field, fields the provider's form lacks are skipped
+func (f *RegistrationFlow) fillField(page playwright.Page, selector string, text string) error {
+	if selector == "" {
+		return nil
 	}
-	
-	return nil
-}
-
-func (f *RegistrationFlow) selectGender(page playwright.Page, gender string) error {
-	var selector string
-	if gender == "male" {
-		selector = "input[value='male']"
-	} else {
-		selector = "input[value='female']"
-	}
-	
-	return page.Click(selector)
+	return f.typeWithHumanSpeed(page, selector, text)
 }
 
 func (f *RegistrationFlow) generateRandomString(length int) string {
@@ -549,3 +588,16 @@ func (f *RegistrationFlow) generateSecurePassword() string {
 	
 	return string(runes)
 }
+
+// recordStep records a successful step in metrics and reports it to analytics
+func (f *RegistrationFlow) recordStep(step string, duration time.Duration) {
+	f.service.metrics.RecordStepDuration(step, duration)
+	f.publishStepCompleted(step, duration)
+}
+
+// publishStepCompleted reports a step duration, registrationStepTotal carries the end-to-end time
+func (f *RegistrationFlow) publishStepCompleted(step string, duration time.Duration) {
+	if err := f.service.publishRegistrationStep(f.account.ID.Hex(), step, duration); err != nil {
+		logger.Errorf("Failed to publish registration step %s for %s: %v", step, f.account.ID.Hex(), err)
+	}
+}
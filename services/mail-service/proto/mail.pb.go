@@ -31,6 +31,9 @@ type CreateAccountRequest struct {
 	PreferredCountry     string                 `protobuf:"bytes,5,opt,name=preferred_country,json=preferredCountry,proto3" json:"preferred_country,omitempty"`
 	UsePhoneVerification bool                   `protobuf:"varint,6,opt,name=use_phone_verification,json=usePhoneVerification,proto3" json:"use_phone_verification,omitempty"`
 	CustomEmailPrefix    string                 `protobuf:"bytes,7,opt,name=custom_email_prefix,json=customEmailPrefix,proto3" json:"custom_email_prefix,omitempty"`
+	UseRandomProfile     bool                   `protobuf:"varint,8,opt,name=use_random_profile,json=useRandomProfile,proto3" json:"use_random_profile,omitempty"`
+	PersonaId            string                 `protobuf:"bytes,9,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	Provider             string                 `protobuf:"bytes,10,opt,name=provider,proto3" json:"provider,omitempty"`
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -114,6 +117,27 @@ func (x *CreateAccountRequest) GetCustomEmailPrefix() string {
 	return ""
 }
 
+func (x *CreateAccountRequest) GetUseRandomProfile() bool {
+	if x != nil {
+		return x.UseRandomProfile
+	}
+	return false
+}
+
+func (x *CreateAccountRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
 // CreateAccountResponse represents the response to account creation
 type CreateAccountResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -230,21 +254,24 @@ func (x *GetAccountRequest) GetAccountId() string {
 
 // Account represents a mail account
 type Account struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	FirstName     string                 `protobuf:"bytes,3,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName      string                 `protobuf:"bytes,4,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	BirthDate     string                 `protobuf:"bytes,5,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
-	Gender        string                 `protobuf:"bytes,6,opt,name=gender,proto3" json:"gender,omitempty"`
-	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
-	Phone         string                 `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	RetryCount    int32                  `protobuf:"varint,12,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email              string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	FirstName          string                 `protobuf:"bytes,3,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName           string                 `protobuf:"bytes,4,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	BirthDate          string                 `protobuf:"bytes,5,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
+	Gender             string                 `protobuf:"bytes,6,opt,name=gender,proto3" json:"gender,omitempty"`
+	Status             string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Phone              string                 `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
+	CreatedAt          int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          int64                  `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ErrorMessage       string                 `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	RetryCount         int32                  `protobuf:"varint,12,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	PersonaId          string                 `protobuf:"bytes,13,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	MailboxCheckStatus string                 `protobuf:"bytes,14,opt,name=mailbox_check_status,json=mailboxCheckStatus,proto3" json:"mailbox_check_status,omitempty"`
+	Provider           string                 `protobuf:"bytes,15,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Account) Reset() {
@@ -361,12 +388,34 @@ func (x *Account) GetRetryCount() int32 {
 	return 0
 }
 
+func (x *Account) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+func (x *Account) GetMailboxCheckStatus() string {
+	if x != nil {
+		return x.MailboxCheckStatus
+	}
+	return ""
+}
+
+func (x *Account) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
 // ListAccountsRequest represents a request to list accounts
 type ListAccountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Provider      string                 `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -422,6 +471,13 @@ func (x *ListAccountsRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *ListAccountsRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
 // AccountList represents a list of accounts
 type AccountList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -883,11 +939,499 @@ func (x *Statistics) GetLast_24Hours() int64 {
 	return 0
 }
 
+// VerifyMailboxRequest represents a request to check that a mailbox receives mail
+type VerifyMailboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMailboxRequest) Reset() {
+	*x = VerifyMailboxRequest{}
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMailboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMailboxRequest) ProtoMessage() {}
+
+func (x *VerifyMailboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMailboxRequest.ProtoReflect.Descriptor instead.
+func (*VerifyMailboxRequest) Descriptor() ([]byte, []int) {
+	return file_services_mail_service_proto_mail_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VerifyMailboxRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+// MailboxCheck represents the result of a probe email delivery check
+type MailboxCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Delivered     bool                   `protobuf:"varint,3,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	Folder        string                 `protobuf:"bytes,4,opt,name=folder,proto3" json:"folder,omitempty"`
+	LatencyMs     int64                  `protobuf:"varint,5,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	CheckedAt     int64                  `protobuf:"varint,7,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailboxCheck) Reset() {
+	*x = MailboxCheck{}
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailboxCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailboxCheck) ProtoMessage() {}
+
+func (x *MailboxCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailboxCheck.ProtoReflect.Descriptor instead.
+func (*MailboxCheck) Descriptor() ([]byte, []int) {
+	return file_services_mail_service_proto_mail_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MailboxCheck) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *MailboxCheck) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MailboxCheck) GetDelivered() bool {
+	if x != nil {
+		return x.Delivered
+	}
+	return false
+}
+
+func (x *MailboxCheck) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *MailboxCheck) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *MailboxCheck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MailboxCheck) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+// ReserveRecoveryMailbox represents a request to bind a verified mailbox to an account on another platform
+type ReserveRecoveryMailboxRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Platform          string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	ConsumerAccountId string                 `protobuf:"bytes,2,opt,name=consumer_account_id,json=consumerAccountId,proto3" json:"consumer_account_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReserveRecoveryMailboxRequest) Reset() {
+	*x = ReserveRecoveryMailboxRequest{}
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveRecoveryMailboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveRecoveryMailboxRequest) ProtoMessage() {}
+
+func (x *ReserveRecoveryMailboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveRecoveryMailboxRequest.ProtoReflect.Descriptor instead.
+func (*ReserveRecoveryMailboxRequest) Descriptor() ([]byte, []int) {
+	return file_services_mail_service_proto_mail_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReserveRecoveryMailboxRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ReserveRecoveryMailboxRequest) GetConsumerAccountId() string {
+	if x != nil {
+		return x.ConsumerAccountId
+	}
+	return ""
+}
+
+// RecoveryMailbox represents a mailbox bound to an account on another platform
+type RecoveryMailbox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	BoundAt       int64                  `protobuf:"varint,3,opt,name=bound_at,json=boundAt,proto3" json:"bound_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryMailbox) Reset() {
+	*x = RecoveryMailbox{}
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryMailbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryMailbox) ProtoMessage() {}
+
+func (x *RecoveryMailbox) ProtoReflect() protoreflect.Message {
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryMailbox.ProtoReflect.Descriptor instead.
+func (*RecoveryMailbox) Descriptor() ([]byte, []int) {
+	return file_services_mail_service_proto_mail_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecoveryMailbox) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RecoveryMailbox) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RecoveryMailbox) GetBoundAt() int64 {
+	if x != nil {
+		return x.BoundAt
+	}
+	return 0
+}
+
+// ReleaseRecoveryMailboxRequest represents a request to free a mailbox bound to an account
+type ReleaseRecoveryMailboxRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Platform          string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	ConsumerAccountId string                 `protobuf:"bytes,2,opt,name=consumer_account_id,json=consumerAccountId,proto3" json:"consumer_account_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReleaseRecoveryMailboxRequest) Reset() {
+	*x = ReleaseRecoveryMailboxRequest{}
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRecoveryMailboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRecoveryMailboxRequest) ProtoMessage() {}
+
+func (x *ReleaseRecoveryMailboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRecoveryMailboxRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRecoveryMailboxRequest) Descriptor() ([]byte, []int) {
+	return file_services_mail_service_proto_mail_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReleaseRecoveryMailboxRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ReleaseRecoveryMailboxRequest) GetConsumerAccountId() string {
+	if x != nil {
+		return x.ConsumerAccountId
+	}
+	return ""
+}
+
+// ReleaseRecoveryMailboxResponse represents the response to release request
+type ReleaseRecoveryMailboxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRecoveryMailboxResponse) Reset() {
+	*x = ReleaseRecoveryMailboxResponse{}
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRecoveryMailboxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRecoveryMailboxResponse) ProtoMessage() {}
+
+func (x *ReleaseRecoveryMailboxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRecoveryMailboxResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseRecoveryMailboxResponse) Descriptor() ([]byte, []int) {
+	return file_services_mail_service_proto_mail_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReleaseRecoveryMailboxResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// ReadVerificationMailRequest represents a request to look up the latest email from a sender
+type ReadVerificationMailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	Since         int64                  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadVerificationMailRequest) Reset() {
+	*x = ReadVerificationMailRequest{}
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadVerificationMailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadVerificationMailRequest) ProtoMessage() {}
+
+func (x *ReadVerificationMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadVerificationMailRequest.ProtoReflect.Descriptor instead.
+func (*ReadVerificationMailRequest) Descriptor() ([]byte, []int) {
+	return file_services_mail_service_proto_mail_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReadVerificationMailRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ReadVerificationMailRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ReadVerificationMailRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+// VerificationMail represents the links and code found in a verification email
+type VerificationMail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Links         []string               `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`
+	Code          string                 `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	ReceivedAt    int64                  `protobuf:"varint,5,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerificationMail) Reset() {
+	*x = VerificationMail{}
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerificationMail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerificationMail) ProtoMessage() {}
+
+func (x *VerificationMail) ProtoReflect() protoreflect.Message {
+	mi := &file_services_mail_service_proto_mail_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerificationMail.ProtoReflect.Descriptor instead.
+func (*VerificationMail) Descriptor() ([]byte, []int) {
+	return file_services_mail_service_proto_mail_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VerificationMail) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *VerificationMail) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *VerificationMail) GetLinks() []string {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *VerificationMail) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *VerificationMail) GetReceivedAt() int64 {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return 0
+}
+
 var File_services_mail_service_proto_mail_proto protoreflect.FileDescriptor
 
 const file_services_mail_service_proto_mail_proto_rawDesc = "" +
 	"\n" +
-	"&services/mail-service/proto/mail.proto\x12\x04mail\"\x9c\x02\n" +
+	"&services/mail-service/proto/mail.proto\x12\x04mail\"\x85\x03\n" +
 	"\x14CreateAccountRequest\x12\x1d\n" +
 	"\n" +
 	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
@@ -897,7 +1441,12 @@ const file_services_mail_service_proto_mail_proto_rawDesc = "" +
 	"\x06gender\x18\x04 \x01(\tR\x06gender\x12+\n" +
 	"\x11preferred_country\x18\x05 \x01(\tR\x10preferredCountry\x124\n" +
 	"\x16use_phone_verification\x18\x06 \x01(\bR\x14usePhoneVerification\x12.\n" +
-	"\x13custom_email_prefix\x18\a \x01(\tR\x11customEmailPrefix\"\x8d\x01\n" +
+	"\x13custom_email_prefix\x18\a \x01(\tR\x11customEmailPrefix\x12,\n" +
+	"\x12use_random_profile\x18\b \x01(\bR\x10useRandomProfile\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\t \x01(\tR\tpersonaId\x12\x1a\n" +
+	"\bprovider\x18\n" +
+	" \x01(\tR\bprovider\"\x8d\x01\n" +
 	"\x15CreateAccountResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
 	"\n" +
@@ -906,7 +1455,7 @@ const file_services_mail_service_proto_mail_proto_rawDesc = "" +
 	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"2\n" +
 	"\x11GetAccountRequest\x12\x1d\n" +
 	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"\xd4\x02\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xc1\x03\n" +
 	"\aAccount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1d\n" +
@@ -925,11 +1474,16 @@ const file_services_mail_service_proto_mail_proto_rawDesc = "" +
 	" \x01(\x03R\tupdatedAt\x12#\n" +
 	"\rerror_message\x18\v \x01(\tR\ferrorMessage\x12\x1f\n" +
 	"\vretry_count\x18\f \x01(\x05R\n" +
-	"retryCount\"[\n" +
+	"retryCount\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\r \x01(\tR\tpersonaId\x120\n" +
+	"\x14mailbox_check_status\x18\x0e \x01(\tR\x12mailboxCheckStatus\x12\x1a\n" +
+	"\bprovider\x18\x0f \x01(\tR\bprovider\"w\n" +
 	"\x13ListAccountsRequest\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"N\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\"N\n" +
 	"\vAccountList\x12)\n" +
 	"\baccounts\x18\x01 \x03(\v2\r.mail.AccountR\baccounts\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\"x\n" +
@@ -961,7 +1515,46 @@ const file_services_mail_service_proto_mail_proto_rawDesc = "" +
 	"\rlast_24_hours\x18\x06 \x01(\x03R\vlast24Hours\x1aC\n" +
 	"\x15AccountsByStatusEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x012\x86\x04\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"5\n" +
+	"\x14VerifyMailboxRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xcf\x01\n" +
+	"\fMailboxCheck\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
+	"\tdelivered\x18\x03 \x01(\bR\tdelivered\x12\x16\n" +
+	"\x06folder\x18\x04 \x01(\tR\x06folder\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x05 \x01(\x03R\tlatencyMs\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\a \x01(\x03R\tcheckedAt\"k\n" +
+	"\x1dReserveRecoveryMailboxRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12.\n" +
+	"\x13consumer_account_id\x18\x02 \x01(\tR\x11consumerAccountId\"a\n" +
+	"\x0fRecoveryMailbox\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x19\n" +
+	"\bbound_at\x18\x03 \x01(\x03R\aboundAt\"k\n" +
+	"\x1dReleaseRecoveryMailboxRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12.\n" +
+	"\x13consumer_account_id\x18\x02 \x01(\tR\x11consumerAccountId\":\n" +
+	"\x1eReleaseRecoveryMailboxResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"f\n" +
+	"\x1bReadVerificationMailRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\x03R\x05since\"\x8d\x01\n" +
+	"\x10VerificationMail\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x14\n" +
+	"\x05links\x18\x03 \x03(\tR\x05links\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\tR\x04code\x12\x1f\n" +
+	"\vreceived_at\x18\x05 \x01(\x03R\n" +
+	"receivedAt2\xd5\x06\n" +
 	"\vMailService\x12H\n" +
 	"\rCreateAccount\x12\x1a.mail.CreateAccountRequest\x1a\x1b.mail.CreateAccountResponse\x124\n" +
 	"\n" +
@@ -970,7 +1563,11 @@ const file_services_mail_service_proto_mail_proto_rawDesc = "" +
 	"\x13UpdateAccountStatus\x12 .mail.UpdateAccountStatusRequest\x1a!.mail.UpdateAccountStatusResponse\x12T\n" +
 	"\x11RetryRegistration\x12\x1e.mail.RetryRegistrationRequest\x1a\x1f.mail.RetryRegistrationResponse\x12H\n" +
 	"\rDeleteAccount\x12\x1a.mail.DeleteAccountRequest\x1a\x1b.mail.DeleteAccountResponse\x12=\n" +
-	"\rGetStatistics\x12\x1a.mail.GetStatisticsRequest\x1a\x10.mail.StatisticsB7Z5github.com/grigta/conveer/services/mail-service/protob\x06proto3"
+	"\rGetStatistics\x12\x1a.mail.GetStatisticsRequest\x1a\x10.mail.Statistics\x12?\n" +
+	"\rVerifyMailbox\x12\x1a.mail.VerifyMailboxRequest\x1a\x12.mail.MailboxCheck\x12T\n" +
+	"\x16ReserveRecoveryMailbox\x12#.mail.ReserveRecoveryMailboxRequest\x1a\x15.mail.RecoveryMailbox\x12c\n" +
+	"\x16ReleaseRecoveryMailbox\x12#.mail.ReleaseRecoveryMailboxRequest\x1a$.mail.ReleaseRecoveryMailboxResponse\x12Q\n" +
+	"\x14ReadVerificationMail\x12!.mail.ReadVerificationMailRequest\x1a\x16.mail.VerificationMailB7Z5github.com/grigta/conveer/services/mail-service/protob\x06proto3"
 
 var (
 	file_services_mail_service_proto_mail_proto_rawDescOnce sync.Once
@@ -984,27 +1581,35 @@ func file_services_mail_service_proto_mail_proto_rawDescGZIP() []byte {
 	return file_services_mail_service_proto_mail_proto_rawDescData
 }
 
-var file_services_mail_service_proto_mail_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_services_mail_service_proto_mail_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_services_mail_service_proto_mail_proto_goTypes = []any{
-	(*CreateAccountRequest)(nil),        // 0: mail.CreateAccountRequest
-	(*CreateAccountResponse)(nil),       // 1: mail.CreateAccountResponse
-	(*GetAccountRequest)(nil),           // 2: mail.GetAccountRequest
-	(*Account)(nil),                     // 3: mail.Account
-	(*ListAccountsRequest)(nil),         // 4: mail.ListAccountsRequest
-	(*AccountList)(nil),                 // 5: mail.AccountList
-	(*UpdateAccountStatusRequest)(nil),  // 6: mail.UpdateAccountStatusRequest
-	(*UpdateAccountStatusResponse)(nil), // 7: mail.UpdateAccountStatusResponse
-	(*RetryRegistrationRequest)(nil),    // 8: mail.RetryRegistrationRequest
-	(*RetryRegistrationResponse)(nil),   // 9: mail.RetryRegistrationResponse
-	(*DeleteAccountRequest)(nil),        // 10: mail.DeleteAccountRequest
-	(*DeleteAccountResponse)(nil),       // 11: mail.DeleteAccountResponse
-	(*GetStatisticsRequest)(nil),        // 12: mail.GetStatisticsRequest
-	(*Statistics)(nil),                  // 13: mail.Statistics
-	nil,                                 // 14: mail.Statistics.AccountsByStatusEntry
+	(*CreateAccountRequest)(nil),           // 0: mail.CreateAccountRequest
+	(*CreateAccountResponse)(nil),          // 1: mail.CreateAccountResponse
+	(*GetAccountRequest)(nil),              // 2: mail.GetAccountRequest
+	(*Account)(nil),                        // 3: mail.Account
+	(*ListAccountsRequest)(nil),            // 4: mail.ListAccountsRequest
+	(*AccountList)(nil),                    // 5: mail.AccountList
+	(*UpdateAccountStatusRequest)(nil),     // 6: mail.UpdateAccountStatusRequest
+	(*UpdateAccountStatusResponse)(nil),    // 7: mail.UpdateAccountStatusResponse
+	(*RetryRegistrationRequest)(nil),       // 8: mail.RetryRegistrationRequest
+	(*RetryRegistrationResponse)(nil),      // 9: mail.RetryRegistrationResponse
+	(*DeleteAccountRequest)(nil),           // 10: mail.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),          // 11: mail.DeleteAccountResponse
+	(*GetStatisticsRequest)(nil),           // 12: mail.GetStatisticsRequest
+	(*Statistics)(nil),                     // 13: mail.Statistics
+	(*VerifyMailboxRequest)(nil),           // 14: mail.VerifyMailboxRequest
+	(*MailboxCheck)(nil),                   // 15: mail.MailboxCheck
+	(*ReserveRecoveryMailboxRequest)(nil),  // 16: mail.ReserveRecoveryMailboxRequest
+	(*RecoveryMailbox)(nil),                // 17: mail.RecoveryMailbox
+	(*ReleaseRecoveryMailboxRequest)(nil),  // 18: mail.ReleaseRecoveryMailboxRequest
+	(*ReleaseRecoveryMailboxResponse)(nil), // 19: mail.ReleaseRecoveryMailboxResponse
+	(*ReadVerificationMailRequest)(nil),    // 20: mail.ReadVerificationMailRequest
+	(*VerificationMail)(nil),               // 21: mail.VerificationMail
+	nil,                                    // 22: mail.Statistics.AccountsByStatusEntry
 }
 var file_services_mail_service_proto_mail_proto_depIdxs = []int32{
 	3,  // 0: mail.AccountList.accounts:type_name -> mail.Account
-	14, // 1: mail.Statistics.accounts_by_status:type_name -> mail.Statistics.AccountsByStatusEntry
+	22, // 1: mail.Statistics.accounts_by_status:type_name -> mail.Statistics.AccountsByStatusEntry
 	0,  // 2: mail.MailService.CreateAccount:input_type -> mail.CreateAccountRequest
 	2,  // 3: mail.MailService.GetAccount:input_type -> mail.GetAccountRequest
 	4,  // 4: mail.MailService.ListAccounts:input_type -> mail.ListAccountsRequest
@@ -1012,15 +1617,23 @@ var file_services_mail_service_proto_mail_proto_depIdxs = []int32{
 	8,  // 6: mail.MailService.RetryRegistration:input_type -> mail.RetryRegistrationRequest
 	10, // 7: mail.MailService.DeleteAccount:input_type -> mail.DeleteAccountRequest
 	12, // 8: mail.MailService.GetStatistics:input_type -> mail.GetStatisticsRequest
-	1,  // 9: mail.MailService.CreateAccount:output_type -> mail.CreateAccountResponse
-	3,  // 10: mail.MailService.GetAccount:output_type -> mail.Account
-	5,  // 11: mail.MailService.ListAccounts:output_type -> mail.AccountList
-	7,  // 12: mail.MailService.UpdateAccountStatus:output_type -> mail.UpdateAccountStatusResponse
-	9,  // 13: mail.MailService.RetryRegistration:output_type -> mail.RetryRegistrationResponse
-	11, // 14: mail.MailService.DeleteAccount:output_type -> mail.DeleteAccountResponse
-	13, // 15: mail.MailService.GetStatistics:output_type -> mail.Statistics
-	9,  // [9:16] is the sub-list for method output_type
-	2,  // [2:9] is the sub-list for method input_type
+	14, // 9: mail.MailService.VerifyMailbox:input_type -> mail.VerifyMailboxRequest
+	16, // 10: mail.MailService.ReserveRecoveryMailbox:input_type -> mail.ReserveRecoveryMailboxRequest
+	18, // 11: mail.MailService.ReleaseRecoveryMailbox:input_type -> mail.ReleaseRecoveryMailboxRequest
+	20, // 12: mail.MailService.ReadVerificationMail:input_type -> mail.ReadVerificationMailRequest
+	1,  // 13: mail.MailService.CreateAccount:output_type -> mail.CreateAccountResponse
+	3,  // 14: mail.MailService.GetAccount:output_type -> mail.Account
+	5,  // 15: mail.MailService.ListAccounts:output_type -> mail.AccountList
+	7,  // 16: mail.MailService.UpdateAccountStatus:output_type -> mail.UpdateAccountStatusResponse
+	9,  // 17: mail.MailService.RetryRegistration:output_type -> mail.RetryRegistrationResponse
+	11, // 18: mail.MailService.DeleteAccount:output_type -> mail.DeleteAccountResponse
+	13, // 19: mail.MailService.GetStatistics:output_type -> mail.Statistics
+	15, // 20: mail.MailService.VerifyMailbox:output_type -> mail.MailboxCheck
+	17, // 21: mail.MailService.ReserveRecoveryMailbox:output_type -> mail.RecoveryMailbox
+	19, // 22: mail.MailService.ReleaseRecoveryMailbox:output_type -> mail.ReleaseRecoveryMailboxResponse
+	21, // 23: mail.MailService.ReadVerificationMail:output_type -> mail.VerificationMail
+	13, // [13:24] is the sub-list for method output_type
+	2,  // [2:13] is the sub-list for method input_type
 	2,  // [2:2] is the sub-list for extension type_name
 	2,  // [2:2] is the sub-list for extension extendee
 	0,  // [0:2] is the sub-list for field type_name
@@ -1037,7 +1650,7 @@ func file_services_mail_service_proto_mail_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_mail_service_proto_mail_proto_rawDesc), len(file_services_mail_service_proto_mail_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
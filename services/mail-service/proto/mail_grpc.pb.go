@@ -19,13 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MailService_CreateAccount_FullMethodName       = "/mail.MailService/CreateAccount"
-	MailService_GetAccount_FullMethodName          = "/mail.MailService/GetAccount"
-	MailService_ListAccounts_FullMethodName        = "/mail.MailService/ListAccounts"
-	MailService_UpdateAccountStatus_FullMethodName = "/mail.MailService/UpdateAccountStatus"
-	MailService_RetryRegistration_FullMethodName   = "/mail.MailService/RetryRegistration"
-	MailService_DeleteAccount_FullMethodName       = "/mail.MailService/DeleteAccount"
-	MailService_GetStatistics_FullMethodName       = "/mail.MailService/GetStatistics"
+	MailService_CreateAccount_FullMethodName          = "/mail.MailService/CreateAccount"
+	MailService_GetAccount_FullMethodName             = "/mail.MailService/GetAccount"
+	MailService_ListAccounts_FullMethodName           = "/mail.MailService/ListAccounts"
+	MailService_UpdateAccountStatus_FullMethodName    = "/mail.MailService/UpdateAccountStatus"
+	MailService_RetryRegistration_FullMethodName      = "/mail.MailService/RetryRegistration"
+	MailService_DeleteAccount_FullMethodName          = "/mail.MailService/DeleteAccount"
+	MailService_GetStatistics_FullMethodName          = "/mail.MailService/GetStatistics"
+	MailService_VerifyMailbox_FullMethodName          = "/mail.MailService/VerifyMailbox"
+	MailService_ReserveRecoveryMailbox_FullMethodName = "/mail.MailService/ReserveRecoveryMailbox"
+	MailService_ReleaseRecoveryMailbox_FullMethodName = "/mail.MailService/ReleaseRecoveryMailbox"
+	MailService_ReadVerificationMail_FullMethodName   = "/mail.MailService/ReadVerificationMail"
 )
 
 // MailServiceClient is the client API for MailService service.
@@ -41,6 +45,10 @@ type MailServiceClient interface {
 	RetryRegistration(ctx context.Context, in *RetryRegistrationRequest, opts ...grpc.CallOption) (*RetryRegistrationResponse, error)
 	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
 	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*Statistics, error)
+	VerifyMailbox(ctx context.Context, in *VerifyMailboxRequest, opts ...grpc.CallOption) (*MailboxCheck, error)
+	ReserveRecoveryMailbox(ctx context.Context, in *ReserveRecoveryMailboxRequest, opts ...grpc.CallOption) (*RecoveryMailbox, error)
+	ReleaseRecoveryMailbox(ctx context.Context, in *ReleaseRecoveryMailboxRequest, opts ...grpc.CallOption) (*ReleaseRecoveryMailboxResponse, error)
+	ReadVerificationMail(ctx context.Context, in *ReadVerificationMailRequest, opts ...grpc.CallOption) (*VerificationMail, error)
 }
 
 type mailServiceClient struct {
@@ -121,6 +129,46 @@ func (c *mailServiceClient) GetStatistics(ctx context.Context, in *GetStatistics
 	return out, nil
 }
 
+func (c *mailServiceClient) VerifyMailbox(ctx context.Context, in *VerifyMailboxRequest, opts ...grpc.CallOption) (*MailboxCheck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MailboxCheck)
+	err := c.cc.Invoke(ctx, MailService_VerifyMailbox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) ReserveRecoveryMailbox(ctx context.Context, in *ReserveRecoveryMailboxRequest, opts ...grpc.CallOption) (*RecoveryMailbox, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryMailbox)
+	err := c.cc.Invoke(ctx, MailService_ReserveRecoveryMailbox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) ReleaseRecoveryMailbox(ctx context.Context, in *ReleaseRecoveryMailboxRequest, opts ...grpc.CallOption) (*ReleaseRecoveryMailboxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseRecoveryMailboxResponse)
+	err := c.cc.Invoke(ctx, MailService_ReleaseRecoveryMailbox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) ReadVerificationMail(ctx context.Context, in *ReadVerificationMailRequest, opts ...grpc.CallOption) (*VerificationMail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerificationMail)
+	err := c.cc.Invoke(ctx, MailService_ReadVerificationMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MailServiceServer is the server API for MailService service.
 // All implementations must embed UnimplementedMailServiceServer
 // for forward compatibility.
@@ -134,6 +182,10 @@ type MailServiceServer interface {
 	RetryRegistration(context.Context, *RetryRegistrationRequest) (*RetryRegistrationResponse, error)
 	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
 	GetStatistics(context.Context, *GetStatisticsRequest) (*Statistics, error)
+	VerifyMailbox(context.Context, *VerifyMailboxRequest) (*MailboxCheck, error)
+	ReserveRecoveryMailbox(context.Context, *ReserveRecoveryMailboxRequest) (*RecoveryMailbox, error)
+	ReleaseRecoveryMailbox(context.Context, *ReleaseRecoveryMailboxRequest) (*ReleaseRecoveryMailboxResponse, error)
+	ReadVerificationMail(context.Context, *ReadVerificationMailRequest) (*VerificationMail, error)
 	mustEmbedUnimplementedMailServiceServer()
 }
 
@@ -165,6 +217,18 @@ func (UnimplementedMailServiceServer) DeleteAccount(context.Context, *DeleteAcco
 func (UnimplementedMailServiceServer) GetStatistics(context.Context, *GetStatisticsRequest) (*Statistics, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStatistics not implemented")
 }
+func (UnimplementedMailServiceServer) VerifyMailbox(context.Context, *VerifyMailboxRequest) (*MailboxCheck, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyMailbox not implemented")
+}
+func (UnimplementedMailServiceServer) ReserveRecoveryMailbox(context.Context, *ReserveRecoveryMailboxRequest) (*RecoveryMailbox, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReserveRecoveryMailbox not implemented")
+}
+func (UnimplementedMailServiceServer) ReleaseRecoveryMailbox(context.Context, *ReleaseRecoveryMailboxRequest) (*ReleaseRecoveryMailboxResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseRecoveryMailbox not implemented")
+}
+func (UnimplementedMailServiceServer) ReadVerificationMail(context.Context, *ReadVerificationMailRequest) (*VerificationMail, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadVerificationMail not implemented")
+}
 func (UnimplementedMailServiceServer) mustEmbedUnimplementedMailServiceServer() {}
 func (UnimplementedMailServiceServer) testEmbeddedByValue()                     {}
 
@@ -312,6 +376,78 @@ func _MailService_GetStatistics_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MailService_VerifyMailbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMailboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).VerifyMailbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_VerifyMailbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).VerifyMailbox(ctx, req.(*VerifyMailboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_ReserveRecoveryMailbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveRecoveryMailboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).ReserveRecoveryMailbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_ReserveRecoveryMailbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).ReserveRecoveryMailbox(ctx, req.(*ReserveRecoveryMailboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_ReleaseRecoveryMailbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRecoveryMailboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).ReleaseRecoveryMailbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_ReleaseRecoveryMailbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).ReleaseRecoveryMailbox(ctx, req.(*ReleaseRecoveryMailboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_ReadVerificationMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadVerificationMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).ReadVerificationMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_ReadVerificationMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).ReadVerificationMail(ctx, req.(*ReadVerificationMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MailService_ServiceDesc is the grpc.ServiceDesc for MailService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -347,6 +483,22 @@ var MailService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatistics",
 			Handler:    _MailService_GetStatistics_Handler,
 		},
+		{
+			MethodName: "VerifyMailbox",
+			Handler:    _MailService_VerifyMailbox_Handler,
+		},
+		{
+			MethodName: "ReserveRecoveryMailbox",
+			Handler:    _MailService_ReserveRecoveryMailbox_Handler,
+		},
+		{
+			MethodName: "ReleaseRecoveryMailbox",
+			Handler:    _MailService_ReleaseRecoveryMailbox_Handler,
+		},
+		{
+			MethodName: "ReadVerificationMail",
+			Handler:    _MailService_ReadVerificationMail_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/mail-service/proto/mail.proto",
@@ -3,11 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/grigta/conveer/services/max-service/internal/config"
 	"github.com/grigta/conveer/services/max-service/internal/handlers"
@@ -15,6 +15,9 @@ import (
 	"github.com/grigta/conveer/services/max-service/internal/service"
 	pb "github.com/grigta/conveer/services/max-service/proto"
 	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/health"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/serviceauth"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/streadway/amqp"
@@ -24,6 +27,9 @@ import (
 )
 
 func main() {
+	log := logger.FromEnv("max-service")
+	logger.SetDefault(log)
+
 	// Load configuration
 	configPath := os.Getenv("MAX_CONFIG_PATH")
 	if configPath == "" {
@@ -85,33 +91,57 @@ func main() {
 	
 	// Create indexes
 	if err := accountRepo.CreateIndexes(ctx); err != nil {
-		log.Printf("Failed to create account indexes: %v", err)
+		log.Errorf("Failed to create account indexes: %v", err)
 	}
 	if err := sessionRepo.CreateIndexes(ctx); err != nil {
-		log.Printf("Failed to create session indexes: %v", err)
+		log.Errorf("Failed to create session indexes: %v", err)
 	}
 	
+	serviceAuth := serviceauth.FromEnv("max-service")
+	dialOpts := append([]grpc.DialOption{grpc.WithInsecure()}, logger.DialOptions()...)
+	dialOpts = append(dialOpts, serviceAuth.DialOptions()...)
+
 	// Connect to proxy service
-	proxyConn, err := grpc.Dial(cfg.ProxyService.Address, grpc.WithInsecure())
+	proxyConn, err := grpc.Dial(cfg.ProxyService.Address, dialOpts...)
 	if err != nil {
 		log.Fatalf("Failed to connect to proxy service: %v", err)
 	}
 	defer proxyConn.Close()
 	
 	// Connect to SMS service
-	smsConn, err := grpc.Dial(cfg.SMSService.Address, grpc.WithInsecure())
+	smsConn, err := grpc.Dial(cfg.SMSService.Address, dialOpts...)
 	if err != nil {
 		log.Fatalf("Failed to connect to SMS service: %v", err)
 	}
 	defer smsConn.Close()
+	
+	// Connect to persona service
+	personaConn, err := grpc.Dial(cfg.PersonaService.Address, dialOpts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to persona service: %v", err)
+	}
+	defer personaConn.Close()
 
 	// Connect to VK service
-	vkConn, err := grpc.Dial(cfg.VKService.Address, grpc.WithInsecure())
+	vkConn, err := grpc.Dial(cfg.VKService.Address, dialOpts...)
 	if err != nil {
 		log.Fatalf("Failed to connect to VK service: %v", err)
 	}
 	defer vkConn.Close()
 
+	// Readiness gates for gRPC health and /health, downstream services only degrade registration
+	healthChecker := health.NewChecker("max-service")
+	healthChecker.Add("mongodb", health.MongoCheck(mongoClient))
+	healthChecker.Add("redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
+	healthChecker.Add("rabbitmq", health.AMQPCheck(rabbitmqConn))
+	healthChecker.AddOptional("proxy-service", health.GRPCCheck(proxyConn, ""))
+	healthChecker.AddOptional("sms-service", health.GRPCCheck(smsConn, ""))
+	healthChecker.AddOptional("persona-service", health.GRPCCheck(personaConn, ""))
+	healthCtx, stopHealth := context.WithCancel(ctx)
+	go healthChecker.Run(healthCtx, 10*time.Second)
+	
 	// Initialize browser manager
 	browserManager, err := service.NewBrowserManager(cfg.Browser.PoolSize, cfg.Browser.Headless)
 	if err != nil {
@@ -125,6 +155,7 @@ func main() {
 		sessionRepo,
 		proxyConn,
 		smsConn,
+		personaConn,
 		vkConn,
 		rabbitmqChannel,
 		browserManager,
@@ -135,9 +166,10 @@ func main() {
 	maxService.StartWorkers(ctx)
 
 	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(append(logger.ServerOptions(), serviceAuth.ServerOptions()...)...)
 	grpcHandler := handlers.NewGRPCHandler(maxService)
 	pb.RegisterMaxServiceServer(grpcServer, grpcHandler)
+	healthChecker.Register(grpcServer)
 
 	// Start gRPC server
 	grpcListener, err := net.Listen("tcp", ":"+cfg.Service.GRPCPort)
@@ -146,7 +178,7 @@ func main() {
 	}
 
 	go func() {
-		log.Printf("Starting gRPC server on port %s", cfg.Service.GRPCPort)
+		log.Infof("Starting gRPC server on port %s", cfg.Service.GRPCPort)
 		if err := grpcServer.Serve(grpcListener); err != nil {
 			log.Fatalf("Failed to serve gRPC: %v", err)
 		}
@@ -155,12 +187,12 @@ func main() {
 	// Create HTTP server
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
-	httpHandler := handlers.NewHTTPHandler(maxService)
+	httpHandler := handlers.NewHTTPHandler(maxService, browserManager, healthChecker)
 	httpHandler.RegisterRoutes(router)
 	
 	// Start HTTP server
 	go func() {
-		log.Printf("Starting HTTP server on port %s", cfg.Service.HTTPPort)
+		log.Infof("Starting HTTP server on port %s", cfg.Service.HTTPPort)
 		if err := router.Run(":" + cfg.Service.HTTPPort); err != nil {
 			log.Fatalf("Failed to start HTTP server: %v", err)
 		}
@@ -171,7 +203,10 @@ func main() {
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 	
-	log.Println("Shutting down...")
+	log.Info("Shutting down...")
+	
+	// Report NOT_SERVING so callers stop routing here before the servers stop
+	stopHealth()
 	grpcServer.GracefulStop()
 	cancel()
 }
@@ -17,6 +17,7 @@ type Config struct {
 	RabbitMQ     RabbitMQConfig     `yaml:"rabbitmq"`
 	ProxyService ProxyServiceConfig `yaml:"proxy_service"`
 	SMSService   SMSServiceConfig   `yaml:"sms_service"`
+	PersonaService PersonaServiceConfig `yaml:"persona_service"`
 	VKService    VKServiceConfig    `yaml:"vk_service"`
 	Registration models.RegistrationConfig `yaml:"registration"`
 	Browser      BrowserConfig      `yaml:"browser"`
@@ -66,6 +67,12 @@ type SMSServiceConfig struct {
 	Timeout time.Duration `yaml:"timeout"`
 }
 
+// PersonaServiceConfig represents persona service configuration
+type PersonaServiceConfig struct {
+	Address string        `yaml:"address"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
 // BrowserConfig represents browser configuration
 type BrowserConfig struct {
 	PoolSize       int           `yaml:"pool_size"`
@@ -109,6 +116,10 @@ func LoadConfig(path string) (*Config, error) {
 			Address: "sms-service:50058",
 			Timeout: 30 * time.Second,
 		},
+		PersonaService: PersonaServiceConfig{
+			Address: "persona-service:50064",
+			Timeout: 10 * time.Second,
+		},
 		VKService: VKServiceConfig{
 			Address: "vk-service:50059",
 			Timeout: 30 * time.Second,
@@ -158,5 +169,9 @@ func LoadConfig(path string) (*Config, error) {
 		config.VKService.Address = vkServiceURL
 	}
 	
+	if personaServiceURL := os.Getenv("PERSONA_SERVICE_GRPC_URL"); personaServiceURL != "" {
+		config.PersonaService.Address = personaServiceURL
+	}
+	
 	return config, nil
 }
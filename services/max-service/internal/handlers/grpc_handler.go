@@ -33,6 +33,8 @@ func (h *GRPCHandler) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 		AvatarURL:          req.AvatarUrl,
 		PreferredCountry:   req.PreferredCountry,
 		CreateNewVKAccount: req.CreateNewVkAccount,
+		UseRandomProfile:   req.UseRandomProfile,
+		PersonaID:          req.PersonaId,
 	}
 	
 	result, err := h.service.CreateAccount(ctx, registrationReq)
@@ -69,6 +71,7 @@ func (h *GRPCHandler) GetAccount(ctx context.Context, req *pb.GetAccountRequest)
 		UpdatedAt:    account.UpdatedAt.Unix(),
 		ErrorMessage: account.ErrorMessage,
 		RetryCount:   int32(account.RetryCount),
+		PersonaId:    account.PersonaID,
 	}, nil
 }
 
@@ -102,6 +105,7 @@ func (h *GRPCHandler) ListAccounts(ctx context.Context, req *pb.ListAccountsRequ
 			UpdatedAt:    account.UpdatedAt.Unix(),
 			ErrorMessage: account.ErrorMessage,
 			RetryCount:   int32(account.RetryCount),
+			PersonaId:    account.PersonaID,
 		}
 	}
 	
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/grigta/conveer/pkg/health"
 	"github.com/grigta/conveer/services/max-service/internal/models"
 	"github.com/grigta/conveer/services/max-service/internal/service"
 	"github.com/gin-gonic/gin"
@@ -12,13 +14,17 @@ import (
 
 // HTTPHandler handles HTTP requests
 type HTTPHandler struct {
-	service *service.MaxService
+	service        *service.MaxService
+	browserManager *service.BrowserManager
+	healthChecker  *health.Checker
 }
 
 // NewHTTPHandler creates a new HTTP handler
-func NewHTTPHandler(service *service.MaxService) *HTTPHandler {
+func NewHTTPHandler(service *service.MaxService, browserManager *service.BrowserManager, healthChecker *health.Checker) *HTTPHandler {
 	return &HTTPHandler{
-		service: service,
+		service:        service,
+		browserManager: browserManager,
+		healthChecker:  healthChecker,
 	}
 }
 
@@ -34,10 +40,12 @@ func (h *HTTPHandler) RegisterRoutes(router *gin.Engine) {
 		api.POST("/accounts/:id/link-vk", h.LinkVKAccount)
 		api.DELETE("/accounts/:id", h.DeleteAccount)
 		api.GET("/statistics", h.GetStatistics)
+		api.GET("/browser-pool", h.GetBrowserPool)
+		api.PUT("/browser-pool", h.ResizeBrowserPool)
 	}
 	
 	// Health check
-	router.GET("/health", h.HealthCheck)
+	router.GET("/health", h.healthChecker.Handler())
 	
 	// Metrics
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -188,10 +196,30 @@ func (h *HTTPHandler) GetStatistics(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
-// HealthCheck returns service health
-func (h *HTTPHandler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "max-service",
-	})
+// GetBrowserPool returns browser pool state
+func (h *HTTPHandler) GetBrowserPool(c *gin.Context) {
+	c.JSON(http.StatusOK, h.browserManager.Stats())
+}
+
+// ResizeBrowserPool grows or shrinks the browser pool without a restart
+func (h *HTTPHandler) ResizeBrowserPool(c *gin.Context) {
+	var req struct {
+		Size int `json:"size" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats, err := h.browserManager.Resize(req.Size)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPoolSize) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
 }
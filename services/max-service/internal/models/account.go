@@ -21,6 +21,7 @@ type MaxAccount struct {
 	AvatarURL       string             `bson:"avatar_url,omitempty" json:"avatar_url,omitempty"`
 	Status          AccountStatus      `bson:"status" json:"status"`
 	ProxyID         string             `bson:"proxy_id,omitempty" json:"proxy_id,omitempty"`
+	ProxyProvider   string             `bson:"proxy_provider,omitempty" json:"proxy_provider,omitempty"`
 	ActivationID    string             `bson:"activation_id,omitempty" json:"activation_id,omitempty"`
 	Cookies         string             `bson:"cookies,encrypted" json:"cookies,omitempty"`
 	UserAgent       string             `bson:"user_agent" json:"user_agent"`
@@ -32,6 +33,7 @@ type MaxAccount struct {
 	ErrorMessage    string             `bson:"error_message,omitempty" json:"error_message,omitempty"`
 	RetryCount      int                `bson:"retry_count" json:"retry_count"`
 	IsVKLinked      bool               `bson:"is_vk_linked" json:"is_vk_linked"`
+	PersonaID       string             `bson:"persona_id,omitempty" json:"persona_id,omitempty"`
 }
 
 // AccountStatus represents the status of an account
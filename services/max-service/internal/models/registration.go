@@ -22,12 +22,15 @@ const (
 // RegistrationRequest represents a request to register a new account
 type RegistrationRequest struct {
 	VKAccountID         string `json:"vk_account_id,omitempty"`
-	FirstName           string `json:"first_name" validate:"required"`
-	LastName            string `json:"last_name" validate:"required"`
+	FirstName           string `json:"first_name" validate:"required_without=UseRandomProfile"`
+	LastName            string `json:"last_name" validate:"required_without=UseRandomProfile"`
 	Username            string `json:"username,omitempty"`
 	AvatarURL           string `json:"avatar_url,omitempty"`
 	PreferredCountry    string `json:"preferred_country,omitempty"`
 	CreateNewVKAccount  bool   `json:"create_new_vk_account"`
+	UseRandomProfile    bool   `json:"use_random_profile,omitempty"`
+	PersonaID           string `json:"persona_id,omitempty"`
+	PersonaReservationID string `json:"persona_reservation_id,omitempty"`
 }
 
 // RegistrationSession represents an active registration session
@@ -39,6 +42,7 @@ type RegistrationSession struct {
 	CreateNewVKAccount bool                   `bson:"create_new_vk_account" json:"create_new_vk_account"`
 	ProxyID            string                 `bson:"proxy_id,omitempty" json:"proxy_id"`
 	ProxyURL           string                 `bson:"proxy_url,omitempty" json:"proxy_url"`
+	ProxyProvider      string                 `bson:"proxy_provider,omitempty" json:"proxy_provider"`
 	Phone              string                 `bson:"phone,omitempty" json:"phone"`
 	ActivationID       string                 `bson:"activation_id,omitempty" json:"activation_id"`
 	VKUserID           string                 `bson:"vk_user_id,omitempty" json:"vk_user_id"`
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/grigta/conveer/services/max-service/internal/models"
+)
+
+// Error types reported in max_errors_total. Analytics clusters errors by this label,
+// so the set is kept small and stable instead of using raw error messages.
+const (
+	errorTypeProxy        = "proxy_error"
+	errorTypeVK           = "vk_error"
+	errorTypeCaptcha      = "captcha"
+	errorTypeRateLimited  = "rate_limited"
+	errorTypeBanned       = "banned"
+	errorTypeBrowser      = "browser_error"
+	errorTypeTimeout      = "timeout"
+	errorTypeActivation   = "activation_error"
+	errorTypeProfile      = "profile_error"
+	errorTypeRegistration = "registration_error"
+	errorTypeRetry        = "retry_error"
+)
+
+// Proxy provider outcomes reported in max_proxy_provider_registrations_total
+const (
+	providerResultSuccess = "success"
+	providerResultFailed  = "failed"
+	providerResultBanned  = "banned"
+)
+
+// classifyStepError maps a failed registration step to an error type
+func classifyStepError(step models.RegistrationStep, err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errorTypeTimeout
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "captcha"):
+		return errorTypeCaptcha
+	case strings.Contains(msg, "rate limit"), strings.Contains(msg, "too many requests"):
+		return errorTypeRateLimited
+	case strings.Contains(msg, "vk account"):
+		return errorTypeVK
+	case strings.Contains(msg, "banned"), strings.Contains(msg, "blocked"):
+		return errorTypeBanned
+	case strings.Contains(msg, "timeout"):
+		return errorTypeTimeout
+	case strings.Contains(msg, "browser"):
+		return errorTypeBrowser
+	}
+
+	switch step {
+	case models.StepProxyAllocation:
+		return errorTypeProxy
+	case models.StepVKAccountCheck, models.StepVKRegistration, models.StepVKLogin:
+		return errorTypeVK
+	case models.StepMaxActivation:
+		return errorTypeActivation
+	case models.StepMaxProfileSetup:
+		return errorTypeProfile
+	default:
+		return errorTypeRegistration
+	}
+}
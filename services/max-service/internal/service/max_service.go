@@ -4,11 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/max-service/internal/models"
 	"github.com/grigta/conveer/services/max-service/internal/repository"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
 	smspb "github.com/grigta/conveer/services/sms-service/proto"
 	vkpb "github.com/grigta/conveer/services/vk-service/proto"
@@ -36,6 +37,7 @@ type MaxService struct {
 	proxyClient      proxypb.ProxyServiceClient
 	smsClient        smspb.SMSServiceClient
 	vkClient         vkpb.VKServiceClient
+	personaClient    personapb.PersonaServiceClient
 	rabbitmqChannel  *amqp.Channel
 	browserManager   *BrowserManager
 	config           *models.RegistrationConfig
@@ -49,12 +51,17 @@ func NewMaxService(
 	sessionRepo *repository.SessionRepository,
 	proxyConn *grpc.ClientConn,
 	smsConn *grpc.ClientConn,
+	personaConn *grpc.ClientConn,
 	vkConn *grpc.ClientConn,
 	rabbitmqChannel *amqp.Channel,
 	browserManager *BrowserManager,
 	config *models.RegistrationConfig,
 ) *MaxService {
 	vkClient := vkpb.NewVKServiceClient(vkConn)
+	metrics := NewMetricsCollector()
+	if browserManager != nil {
+		browserManager.metrics = metrics
+	}
 	
 	return &MaxService{
 		accountRepo:      accountRepo,
@@ -62,10 +69,11 @@ func NewMaxService(
 		proxyClient:      proxypb.NewProxyServiceClient(proxyConn),
 		smsClient:        smspb.NewSMSServiceClient(smsConn),
 		vkClient:         vkClient,
+		personaClient:    personapb.NewPersonaServiceClient(personaConn),
 		rabbitmqChannel:  rabbitmqChannel,
 		browserManager:   browserManager,
 		config:           config,
-		metrics:          NewMetricsCollector(),
+		metrics:          metrics,
 		vkIntegration:    NewVKIntegration(vkClient),
 	}
 }
@@ -74,6 +82,13 @@ func NewMaxService(
 func (s *MaxService) CreateAccount(ctx context.Context, req *models.RegistrationRequest) (*models.RegistrationResult, error) {
 	s.metrics.IncrementRegistrationAttempts()
 	
+	// Take the profile from the persona service if requested
+	if req.UseRandomProfile || req.PersonaID != "" {
+		if err := s.applyPersona(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	
 	// Create account document
 	account := &models.MaxAccount{
 		ID:          primitive.NewObjectID(),
@@ -84,12 +99,14 @@ func (s *MaxService) CreateAccount(ctx context.Context, req *models.Registration
 		AvatarURL:   req.AvatarURL,
 		Status:      models.AccountStatusCreating,
 		IsVKLinked:  req.VKAccountID != "",
+		PersonaID:   req.PersonaID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 	
 	// Save account to database
 	if err := s.accountRepo.Create(ctx, account); err != nil {
+		s.cancelPersona(ctx, req)
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
 	
@@ -106,9 +123,12 @@ func (s *MaxService) CreateAccount(ctx context.Context, req *models.Registration
 	}
 	
 	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		s.cancelPersona(ctx, req)
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 	
+	s.confirmPersona(ctx, account, req.PersonaReservationID)
+	
 	// Publish to registration queue
 	if err := s.publishRegistrationTask(account.ID.Hex(), req); err != nil {
 		return nil, fmt.Errorf("failed to publish registration task: %w", err)
@@ -144,7 +164,18 @@ func (s *MaxService) UpdateAccountStatus(ctx context.Context, accountID string,
 		return fmt.Errorf("invalid account ID: %w", err)
 	}
 	
-	return s.accountRepo.UpdateAccountStatus(ctx, id, status, errorMsg)
+	if err := s.accountRepo.UpdateAccountStatus(ctx, id, status, errorMsg); err != nil {
+		return err
+	}
+	
+	// Bans after registration still count against the provider the account was registered through
+	if status == models.AccountStatusBanned {
+		if account, err := s.accountRepo.GetByID(ctx, id); err == nil {
+			s.metrics.IncrementProviderRegistrations(account.ProxyProvider, providerResultBanned)
+		}
+	}
+	
+	return nil
 }
 
 // LinkVKAccount links a VK account to Max account
@@ -245,6 +276,7 @@ func (s *MaxService) StartWorkers(ctx context.Context) {
 	go s.retryWorker(ctx)
 	go s.cleanupWorker(ctx)
 	go s.stuckSessionMonitor(ctx)
+	go s.accountMetricsWorker(ctx)
 }
 
 // registrationWorker processes registration tasks
@@ -259,7 +291,7 @@ func (s *MaxService) registrationWorker(ctx context.Context) {
 		nil,
 	)
 	if err != nil {
-		log.Printf("Failed to register consumer: %v", err)
+		logger.Errorf("Failed to register consumer: %v", err)
 		return
 	}
 	
@@ -270,7 +302,7 @@ func (s *MaxService) registrationWorker(ctx context.Context) {
 		case msg := <-msgs:
 			// Process registration
 			if err := s.processRegistration(ctx, msg.Body); err != nil {
-				log.Printf("Registration failed: %v", err)
+				logger.Errorf("Registration failed: %v", err)
 				msg.Nack(false, true)
 			} else {
 				msg.Ack(false)
@@ -291,7 +323,7 @@ func (s *MaxService) retryWorker(ctx context.Context) {
 		nil,
 	)
 	if err != nil {
-		log.Printf("Failed to register retry consumer: %v", err)
+		logger.Errorf("Failed to register retry consumer: %v", err)
 		return
 	}
 	
@@ -302,7 +334,7 @@ func (s *MaxService) retryWorker(ctx context.Context) {
 		case msg := <-msgs:
 			// Process retry
 			if err := s.processRetry(ctx, msg.Body); err != nil {
-				log.Printf("Retry failed: %v", err)
+				logger.Errorf("Retry failed: %v", err)
 				msg.Nack(false, true)
 			} else {
 				msg.Ack(false)
@@ -323,7 +355,7 @@ func (s *MaxService) cleanupWorker(ctx context.Context) {
 		case <-ticker.C:
 			// Clean up sessions older than 1 hour
 			if err := s.sessionRepo.CleanupStuckSessions(ctx, 1*time.Hour); err != nil {
-				log.Printf("Failed to cleanup stuck sessions: %v", err)
+				logger.Errorf("Failed to cleanup stuck sessions: %v", err)
 			}
 		}
 	}
@@ -342,7 +374,7 @@ func (s *MaxService) stuckSessionMonitor(ctx context.Context) {
 			// Find sessions stuck in same step for >30 minutes
 			sessions, err := s.sessionRepo.GetStuckSessions(ctx, 30*time.Minute)
 			if err != nil {
-				log.Printf("Failed to get stuck sessions: %v", err)
+				logger.Errorf("Failed to get stuck sessions: %v", err)
 				continue
 			}
 			
@@ -358,6 +390,36 @@ func (s *MaxService) stuckSessionMonitor(ctx context.Context) {
 	}
 }
 
+// accountMetricsWorker keeps the account gauges in sync with the database
+func (s *MaxService) accountMetricsWorker(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	
+	for {
+		s.reportAccountMetrics(ctx)
+		
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *MaxService) reportAccountMetrics(ctx context.Context) {
+	stats, err := s.accountRepo.GetStatistics(ctx)
+	if err != nil {
+		logger.Errorf("Failed to get account statistics: %v", err)
+		return
+	}
+	
+	// GetStatistics reports every status, so emptied statuses drop to zero
+	for status, count := range stats.AccountsByStatus {
+		s.metrics.SetAccountsTotal(status, float64(count))
+		s.metrics.UpdateAccountsByStatus(status, float64(count))
+	}
+}
+
 // Helper methods
 
 func (s *MaxService) publishRegistrationTask(accountID string, req *models.RegistrationRequest) error {
@@ -417,15 +479,48 @@ func (s *MaxService) publishRetryTask(accountID string) error {
 	)
 }
 
+// registrationStepTotal is the step name of the end-to-end registration time
+const registrationStepTotal = "total"
+
+func (s *MaxService) publishRegistrationStep(accountID, step string, duration time.Duration) error {
+	payload := map[string]interface{}{
+		"type":         "registration.step_completed",
+		"platform":     "max",
+		"account_id":   accountID,
+		"step":         step,
+		"duration_sec": duration.Seconds(),
+		"service":      "max-service",
+		"timestamp":    time.Now().Unix(),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal registration step payload: %w", err)
+	}
+
+	return s.rabbitmqChannel.Publish(
+		"max.events",                 // exchange
+		"registration.step_completed", // routing key
+		false,                         // mandatory
+		false,                         // immediate
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        data,
+		},
+	)
+}
+
 func (s *MaxService) publishManualIntervention(accountID string, reason string) error {
 	s.metrics.IncrementManualIntervention(reason)
 
 	// Create payload
 	payload := map[string]interface{}{
+		"type":       "manual_intervention",
+		"platform":   "max",
 		"account_id": accountID,
 		"reason":     reason,
 		"service":    "max-service",
-		"timestamp":  time.Now().Unix(),
+		"timestamp":  time.Now(),
 	}
 
 	data, err := json.Marshal(payload)
@@ -461,13 +556,12 @@ func (s *MaxService) processRegistration(ctx context.Context, data []byte) error
 	// Create registration flow
 	flow, err := s.NewRegistrationFlow(ctx, accountID)
 	if err != nil {
-		s.metrics.IncrementRegistrationFailures(err.Error())
+		s.metrics.IncrementErrorsTotal(errorTypeRegistration)
 		return fmt.Errorf("failed to create registration flow: %w", err)
 	}
 
 	// Execute registration
 	if err := flow.Execute(); err != nil {
-		s.metrics.IncrementRegistrationFailures(err.Error())
 		// Update account status to failed
 		s.accountRepo.UpdateAccountStatus(ctx, accountID, models.AccountStatusFailed, err.Error())
 		return fmt.Errorf("registration execution failed: %w", err)
@@ -498,13 +592,12 @@ func (s *MaxService) processRetry(ctx context.Context, data []byte) error {
 	// Create registration flow for retry (it will fetch session internally)
 	flow, err := s.NewRegistrationFlow(ctx, accountID)
 	if err != nil {
-		s.metrics.IncrementRegistrationFailures(err.Error())
+		s.metrics.IncrementErrorsTotal(errorTypeRetry)
 		return fmt.Errorf("failed to create registration flow: %w", err)
 	}
 
 	// Execute retry from current step
 	if err := flow.Execute(); err != nil {
-		s.metrics.IncrementRegistrationFailures(err.Error())
 		// Update account status
 		if account.RetryCount >= s.config.MaxRetryAttempts {
 			s.accountRepo.UpdateAccountStatus(ctx, accountID, models.AccountStatusFailed,
@@ -18,6 +18,16 @@ type MetricsCollector struct {
 	smsRequests          prometheus.Counter
 	captchaDetected      prometheus.Counter
 	manualIntervention   *prometheus.CounterVec
+
+	// Platform-level series consumed by analytics-service
+	accountsTotal         *prometheus.GaugeVec
+	errorsTotal           *prometheus.CounterVec
+	providerRegistrations *prometheus.CounterVec
+
+	browserPoolIdle   prometheus.Gauge
+	browserPoolInUse  prometheus.Gauge
+	browserPoolTarget prometheus.Gauge
+	browserPoolUsage  prometheus.Gauge
 }
 
 // NewMetricsCollector creates a new metrics collector
@@ -81,6 +91,47 @@ func NewMetricsCollector() *MetricsCollector {
 			},
 			[]string{"reason"},
 		),
+		accountsTotal: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "max_accounts_total",
+				Help: "Total number of Max accounts by status",
+			},
+			[]string{"status"},
+		),
+		errorsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "max_errors_total",
+				Help: "Total number of errors by type",
+			},
+			[]string{"type"},
+		),
+		providerRegistrations: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "max_proxy_provider_registrations_total",
+				Help: "Registration outcomes and bans by proxy provider",
+			},
+			[]string{"provider", "result"},
+		),
+		browserPoolIdle: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: "max_service",
+			Name:      "browser_pool_idle",
+			Help:      "Number of idle browsers in the pool",
+		}),
+		browserPoolInUse: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: "max_service",
+			Name:      "browser_pool_in_use",
+			Help:      "Number of browsers currently running a registration",
+		}),
+		browserPoolTarget: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: "max_service",
+			Name:      "browser_pool_target_size",
+			Help:      "Target size of the browser pool, changed at runtime by resizing",
+		}),
+		browserPoolUsage: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: "max_service",
+			Name:      "browser_pool_utilization",
+			Help:      "Share of the target browser pool size currently in use",
+		}),
 	}
 }
 
@@ -128,3 +179,31 @@ func (m *MetricsCollector) IncrementCaptchaDetected() {
 func (m *MetricsCollector) IncrementManualIntervention(reason string) {
 	m.manualIntervention.WithLabelValues(reason).Inc()
 }
+
+// SetAccountsTotal sets the number of accounts in a status
+func (m *MetricsCollector) SetAccountsTotal(status string, count float64) {
+	m.accountsTotal.WithLabelValues(status).Set(count)
+}
+
+// IncrementErrorsTotal increments errors counter by error type
+func (m *MetricsCollector) IncrementErrorsTotal(errorType string) {
+	m.errorsTotal.WithLabelValues(errorType).Inc()
+}
+
+// IncrementProviderRegistrations records a registration outcome for a proxy provider
+func (m *MetricsCollector) IncrementProviderRegistrations(provider, result string) {
+	if provider == "" {
+		provider = "unknown"
+	}
+	m.providerRegistrations.WithLabelValues(provider, result).Inc()
+}
+
+// UpdateBrowserPool records the browser pool state
+func (m *MetricsCollector) UpdateBrowserPool(idle, inUse, target int) {
+	m.browserPoolIdle.Set(float64(idle))
+	m.browserPoolInUse.Set(float64(inUse))
+	m.browserPoolTarget.Set(float64(target))
+	if target > 0 {
+		m.browserPoolUsage.Set(float64(inUse) / float64(target))
+	}
+}
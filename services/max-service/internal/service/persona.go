@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/max-service/internal/models"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
+)
+
+const personaPlatform = "max"
+
+// applyPersona reserves an existing persona, or one reused from other platforms or newly generated.
+// A VK account created for this account later reuses the same persona. Random profiles are
+// generated locally so registrations keep working while the persona service is down.
+func (s *MaxService) applyPersona(ctx context.Context, req *models.RegistrationRequest) error {
+	persona, err := s.personaClient.AllocatePersona(ctx, &personapb.AllocatePersonaRequest{
+		Platform:      personaPlatform,
+		PersonaId:     req.PersonaID,
+		ReuseExisting: req.PersonaID == "",
+	})
+	if err != nil {
+		if req.PersonaID != "" {
+			return fmt.Errorf("failed to reserve persona %s: %w", req.PersonaID, err)
+		}
+		logger.Warnf("Persona service unavailable, generating profile locally: %v", err)
+
+		profile := GenerateRandomProfile("")
+		req.FirstName = profile.FirstName
+		req.LastName = profile.LastName
+		return nil
+	}
+
+	req.PersonaID = persona.Id
+	req.PersonaReservationID = persona.ReservationId
+	req.FirstName = persona.FirstName
+	req.LastName = persona.LastName
+	if req.Username == "" {
+		req.Username = persona.Username
+	}
+	// Avatars are only downloadable when the persona service is configured with a public base URL
+	if req.AvatarURL == "" && len(persona.Avatars) > 0 && strings.Contains(persona.Avatars[0], "://") {
+		req.AvatarURL = persona.Avatars[0]
+	}
+
+	return nil
+}
+
+// confirmPersona turns the reservation into an assignment so the identity is not reused on Max
+func (s *MaxService) confirmPersona(ctx context.Context, account *models.MaxAccount, reservationID string) {
+	if account.PersonaID == "" || reservationID == "" {
+		return
+	}
+
+	if _, err := s.personaClient.ConfirmReservation(ctx, &personapb.ConfirmReservationRequest{
+		PersonaId:     account.PersonaID,
+		ReservationId: reservationID,
+		AccountId:     account.ID.Hex(),
+	}); err != nil {
+		logger.Errorf("Failed to confirm persona %s reservation for account %s: %v", account.PersonaID, account.ID.Hex(), err)
+	}
+}
+
+// cancelPersona frees the persona of a registration that did not produce an account
+func (s *MaxService) cancelPersona(ctx context.Context, req *models.RegistrationRequest) {
+	if req.PersonaID == "" || req.PersonaReservationID == "" {
+		return
+	}
+
+	if _, err := s.personaClient.CancelReservation(ctx, &personapb.CancelReservationRequest{
+		PersonaId:     req.PersonaID,
+		ReservationId: req.PersonaReservationID,
+	}); err != nil {
+		logger.Errorf("Failed to cancel persona %s reservation: %v", req.PersonaID, err)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grigta/conveer/services/max-service/internal/models"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakePersonaClient answers AllocatePersona; other methods are not used by applyPersona
+type fakePersonaClient struct {
+	personapb.PersonaServiceClient
+
+	persona   *personapb.Persona
+	err       error
+	allocated *personapb.AllocatePersonaRequest
+}
+
+func (c *fakePersonaClient) AllocatePersona(ctx context.Context, in *personapb.AllocatePersonaRequest, opts ...grpc.CallOption) (*personapb.Persona, error) {
+	c.allocated = in
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.persona, nil
+}
+
+func TestApplyPersona_FillsFromPersona(t *testing.T) {
+	client := &fakePersonaClient{persona: &personapb.Persona{
+		Id:            "p1",
+		ReservationId: "r1",
+		FirstName:     "Иван",
+		LastName:      "Петров",
+		Username:      "ivan_petrov",
+		Avatars:       []string{"https://cdn.example.com/a1.jpg"},
+	}}
+	s := &MaxService{personaClient: client}
+	req := &models.RegistrationRequest{UseRandomProfile: true}
+
+	require.NoError(t, s.applyPersona(context.Background(), req))
+
+	assert.True(t, client.allocated.ReuseExisting)
+	assert.Equal(t, "p1", req.PersonaID)
+	assert.Equal(t, "r1", req.PersonaReservationID)
+	assert.Equal(t, "Иван", req.FirstName)
+	assert.Equal(t, "ivan_petrov", req.Username)
+	assert.Equal(t, "https://cdn.example.com/a1.jpg", req.AvatarURL)
+}
+
+func TestApplyPersona_GeneratesLocallyWhenUnavailable(t *testing.T) {
+	s := &MaxService{personaClient: &fakePersonaClient{err: status.Error(codes.Unavailable, "connection refused")}}
+	req := &models.RegistrationRequest{UseRandomProfile: true}
+
+	require.NoError(t, s.applyPersona(context.Background(), req))
+
+	assert.Empty(t, req.PersonaID)
+	assert.Empty(t, req.PersonaReservationID)
+	assert.NotEmpty(t, req.FirstName)
+	assert.NotEmpty(t, req.LastName)
+}
+
+func TestApplyPersona_RequestedPersonaUnavailable(t *testing.T) {
+	s := &MaxService{personaClient: &fakePersonaClient{err: status.Error(codes.Unavailable, "connection refused")}}
+	req := &models.RegistrationRequest{PersonaID: "p1"}
+
+	err := s.applyPersona(context.Background(), req)
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Empty(t, req.FirstName)
+}
@@ -4,11 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"math/rand"
 	"strings"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/max-service/internal/models"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
 	"github.com/playwright-community/playwright-go"
@@ -49,7 +49,7 @@ func (s *MaxService) NewRegistrationFlow(ctx context.Context, accountID primitiv
 func (f *RegistrationFlow) Execute() error {
 	start := time.Now()
 	defer func() {
-		f.service.metrics.RecordStepDuration("total", time.Since(start))
+		f.service.metrics.RecordStepDuration(registrationStepTotal, time.Since(start))
 		// Release browser if it was allocated
 		if f.browser != nil {
 			f.service.browserManager.ReleaseBrowser(f.browser)
@@ -81,7 +81,7 @@ func (f *RegistrationFlow) Execute() error {
 	for i := startIdx; i < len(steps); i++ {
 		stepStart := time.Now()
 		
-		log.Printf("Executing step: %s", steps[i].step)
+		logger.Infof("Executing step: %s", steps[i].step)
 		f.session.CurrentStep = steps[i].step
 		f.service.sessionRepo.UpdateStep(f.ctx, f.session.ID, steps[i].step, nil)
 		
@@ -90,7 +90,7 @@ func (f *RegistrationFlow) Execute() error {
 			return fmt.Errorf("step %s failed: %w", steps[i].step, err)
 		}
 		
-		f.service.metrics.RecordStepDuration(string(steps[i].step), time.Since(stepStart))
+		f.recordStep(string(steps[i].step), time.Since(stepStart))
 		f.session.LastActivityAt = time.Now()
 	}
 	
@@ -103,6 +103,8 @@ func (f *RegistrationFlow) Execute() error {
 	// Update account status
 	f.service.accountRepo.UpdateAccountStatus(f.ctx, f.account.ID, models.AccountStatusCreated, "")
 	f.service.metrics.IncrementRegistrationSuccess()
+	f.publishStepCompleted(registrationStepTotal, time.Since(start))
+	f.service.metrics.IncrementProviderRegistrations(f.session.ProxyProvider, providerResultSuccess)
 	
 	return nil
 }
@@ -116,28 +118,32 @@ func (f *RegistrationFlow) allocateProxy() error {
 	}
 	
 	resp, err := f.service.proxyClient.AllocateProxy(f.ctx, &proxypb.AllocateProxyRequest{
-		Type:    "residential",
-		Country: country,
+		Type:     "residential",
+		Country:  country,
+		Platform: "max",
 	})
 	if err != nil {
 		return fmt.Errorf("failed to allocate proxy: %w", err)
 	}
 	
-	f.session.ProxyID = resp.ProxyId
-	f.session.ProxyURL = resp.ProxyUrl
-	f.account.ProxyID = resp.ProxyId
-	f.account.RegistrationIP = resp.IpAddress
+	f.session.ProxyID = resp.Id
+	f.session.ProxyURL = proxyURL(resp)
+	f.session.ProxyProvider = resp.Provider
+	f.account.ProxyID = resp.Id
+	f.account.ProxyProvider = resp.Provider
+	f.account.RegistrationIP = resp.Ip
 	
 	// Save checkpoint
 	f.session.StepCheckpoints["proxy"] = map[string]string{
-		"proxy_id":  resp.ProxyId,
-		"proxy_url": resp.ProxyUrl,
-		"ip":        resp.IpAddress,
+		"proxy_id":  resp.Id,
+		"proxy_url": f.session.ProxyURL,
+		"ip":        resp.Ip,
 	}
 	
 	f.service.sessionRepo.UpdateSession(f.ctx, f.account.ID, map[string]interface{}{
-		"proxy_id":  resp.ProxyId,
-		"proxy_url": resp.ProxyUrl,
+		"proxy_id":       resp.Id,
+		"proxy_url":      f.session.ProxyURL,
+		"proxy_provider": resp.Provider,
 	})
 	
 	return nil
@@ -196,13 +202,14 @@ func (f *RegistrationFlow) registerVKAccount() error {
 		return nil
 	}
 	
-	// Create new VK account
+	// Create new VK account, sharing the persona so both profiles present the same identity
 	req := &VKAccountRequest{
 		FirstName:        f.account.FirstName,
 		LastName:         f.account.LastName,
 		BirthDate:        "1990-01-01", // Default birth date
 		Gender:           "male",
 		PreferredCountry: "RU",
+		PersonaID:        f.account.PersonaID,
 	}
 	
 	result, err := f.service.vkIntegration.CreateVKAccount(f.ctx, req)
@@ -238,7 +245,7 @@ func (f *RegistrationFlow) registerVKAccount() error {
 func (f *RegistrationFlow) loginToVK() error {
 	// Setup browser with proxy
 	fingerprint := GenerateFingerprint()
-	f.account.Fingerprint = fingerprint
+	f.account.Fingerprint = models.Fingerprint(fingerprint)
 	f.account.UserAgent = fingerprint.UserAgent
 	
 	browser, err := f.service.browserManager.AcquireBrowser(f.ctx, &BrowserConfig{
@@ -335,7 +342,7 @@ func (f *RegistrationFlow) activateMax() error {
 	
 	if !activated {
 		// Max might already be activated
-		log.Printf("Max activation button not found, might be already activated")
+		logger.Warnf("Max activation button not found, might be already activated")
 	}
 	
 	// Wait for activation
@@ -388,7 +395,7 @@ func (f *RegistrationFlow) setupMaxProfile() error {
 	// Set avatar if provided
 	if f.account.AvatarURL != "" {
 		// Implementation for avatar upload would go here
-		log.Printf("Avatar upload not implemented yet")
+		logger.Warnf("Avatar upload not implemented yet")
 	}
 	
 	// Extract all cookies including Max-specific ones
@@ -441,9 +448,26 @@ func (f *RegistrationFlow) setupMaxProfile() error {
 
 // Helper methods
 
+// proxyURL builds the browser proxy address of an allocated proxy
+func proxyURL(p *proxypb.ProxyResponse) string {
+	if p.Username != "" {
+		return fmt.Sprintf("%s://%s:%s@%s:%d", p.Protocol, p.Username, p.Password, p.Ip, p.Port)
+	}
+	return fmt.Sprintf("%s://%s:%d", p.Protocol, p.Ip, p.Port)
+}
+
 func (f *RegistrationFlow) handleStepError(step models.RegistrationStep, err error) {
 	f.service.metrics.IncrementRegistrationFailure(string(step))
 	
+	errorType := classifyStepError(step, err)
+	f.service.metrics.IncrementErrorsTotal(errorType)
+	if step != models.StepProxyAllocation {
+		f.service.metrics.IncrementProviderRegistrations(f.session.ProxyProvider, providerResultFailed)
+		if errorType == errorTypeBanned {
+			f.service.metrics.IncrementProviderRegistrations(f.session.ProxyProvider, providerResultBanned)
+		}
+	}
+	
 	// Check for specific errors
 	errorMsg := err.Error()
 	
@@ -468,7 +492,20 @@ func (f *RegistrationFlow) handleStepError(step models.RegistrationStep, err err
 	
 	if f.session.ProxyID != "" {
 		f.service.proxyClient.ReleaseProxy(f.ctx, &proxypb.ReleaseProxyRequest{
-			ProxyId: f.session.ProxyID,
+			AccountId: f.account.ID.Hex(),
 		})
 	}
 }
+
+// recordStep records a successful step in metrics and reports it to analytics
+func (f *RegistrationFlow) recordStep(step string, duration time.Duration) {
+	f.service.metrics.RecordStepDuration(step, duration)
+	f.publishStepCompleted(step, duration)
+}
+
+// publishStepCompleted reports a step duration, registrationStepTotal carries the end-to-end time
+func (f *RegistrationFlow) publishStepCompleted(step string, duration time.Duration) {
+	if err := f.service.publishRegistrationStep(f.account.ID.Hex(), step, duration); err != nil {
+		logger.Errorf("Failed to publish registration step %s for %s: %v", step, f.account.ID.Hex(), err)
+	}
+}
@@ -48,6 +48,7 @@ func (v *VKIntegration) CreateVKAccount(ctx context.Context, request *VKAccountR
 		// BirthDate:        request.BirthDate, // TODO: Convert string to timestamp
 		Gender:           request.Gender,
 		PreferredCountry: request.PreferredCountry,
+		PersonaId:        request.PersonaID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create VK account: %w", err)
@@ -89,10 +90,10 @@ func (v *VKIntegration) GetVKCredentials(ctx context.Context, vkAccountID string
 func (v *VKIntegration) LoginToVK(ctx context.Context, page playwright.Page, vkAccount *VKCredentials) error {
 	// Load cookies if available - try session restore first
 	if vkAccount.Cookies != "" {
-		var cookies []playwright.Cookie
+		var cookies []playwright.OptionalCookie
 		if err := json.Unmarshal([]byte(vkAccount.Cookies), &cookies); err == nil {
 			// Add cookies to browser context
-			if err := page.Context().AddCookies(cookies...); err != nil {
+			if err := page.Context().AddCookies(cookies); err != nil {
 				return fmt.Errorf("failed to add cookies: %w", err)
 			}
 		}
@@ -136,7 +137,7 @@ func (v *VKIntegration) LoginToVK(ctx context.Context, page playwright.Page, vkA
 	}
 	
 	// Wait for password field
-	if err := page.WaitForSelector("input[name='password']", playwright.PageWaitForSelectorOptions{
+	if _, err := page.WaitForSelector("input[name='password']", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(10000),
 	}); err != nil {
 		return fmt.Errorf("password field not found: %w", err)
@@ -174,6 +175,7 @@ type VKAccountRequest struct {
 	BirthDate        string
 	Gender           string
 	PreferredCountry string
+	PersonaID        string
 }
 
 // VKAccountResult represents VK account creation result
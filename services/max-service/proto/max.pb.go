@@ -31,6 +31,8 @@ type CreateAccountRequest struct {
 	AvatarUrl          string                 `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
 	PreferredCountry   string                 `protobuf:"bytes,6,opt,name=preferred_country,json=preferredCountry,proto3" json:"preferred_country,omitempty"`
 	CreateNewVkAccount bool                   `protobuf:"varint,7,opt,name=create_new_vk_account,json=createNewVkAccount,proto3" json:"create_new_vk_account,omitempty"`
+	UseRandomProfile   bool                   `protobuf:"varint,8,opt,name=use_random_profile,json=useRandomProfile,proto3" json:"use_random_profile,omitempty"`
+	PersonaId          string                 `protobuf:"bytes,9,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -114,6 +116,20 @@ func (x *CreateAccountRequest) GetCreateNewVkAccount() bool {
 	return false
 }
 
+func (x *CreateAccountRequest) GetUseRandomProfile() bool {
+	if x != nil {
+		return x.UseRandomProfile
+	}
+	return false
+}
+
+func (x *CreateAccountRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
 // CreateAccountResponse represents the response to account creation
 type CreateAccountResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -245,6 +261,7 @@ type Account struct {
 	UpdatedAt     int64                  `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	ErrorMessage  string                 `protobuf:"bytes,13,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	RetryCount    int32                  `protobuf:"varint,14,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	PersonaId     string                 `protobuf:"bytes,15,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -377,6 +394,13 @@ func (x *Account) GetRetryCount() int32 {
 	return 0
 }
 
+func (x *Account) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
 // ListAccountsRequest represents a request to list accounts
 type ListAccountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1017,7 +1041,7 @@ var File_services_max_service_proto_max_proto protoreflect.FileDescriptor
 
 const file_services_max_service_proto_max_proto_rawDesc = "" +
 	"\n" +
-	"$services/max-service/proto/max.proto\x12\x03max\"\x91\x02\n" +
+	"$services/max-service/proto/max.proto\x12\x03max\"\xde\x02\n" +
 	"\x14CreateAccountRequest\x12\"\n" +
 	"\rvk_account_id\x18\x01 \x01(\tR\vvkAccountId\x12\x1d\n" +
 	"\n" +
@@ -1027,7 +1051,10 @@ const file_services_max_service_proto_max_proto_rawDesc = "" +
 	"\n" +
 	"avatar_url\x18\x05 \x01(\tR\tavatarUrl\x12+\n" +
 	"\x11preferred_country\x18\x06 \x01(\tR\x10preferredCountry\x121\n" +
-	"\x15create_new_vk_account\x18\a \x01(\bR\x12createNewVkAccount\"\x8d\x01\n" +
+	"\x15create_new_vk_account\x18\a \x01(\bR\x12createNewVkAccount\x12,\n" +
+	"\x12use_random_profile\x18\b \x01(\bR\x10useRandomProfile\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\t \x01(\tR\tpersonaId\"\x8d\x01\n" +
 	"\x15CreateAccountResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
 	"\n" +
@@ -1036,7 +1063,7 @@ const file_services_max_service_proto_max_proto_rawDesc = "" +
 	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"2\n" +
 	"\x11GetAccountRequest\x12\x1d\n" +
 	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"\xa6\x03\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xc5\x03\n" +
 	"\aAccount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
 	"\rvk_account_id\x18\x02 \x01(\tR\vvkAccountId\x12\x1c\n" +
@@ -1059,7 +1086,9 @@ const file_services_max_service_proto_max_proto_rawDesc = "" +
 	"updated_at\x18\f \x01(\x03R\tupdatedAt\x12#\n" +
 	"\rerror_message\x18\r \x01(\tR\ferrorMessage\x12\x1f\n" +
 	"\vretry_count\x18\x0e \x01(\x05R\n" +
-	"retryCount\"\x81\x01\n" +
+	"retryCount\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x0f \x01(\tR\tpersonaId\"\x81\x01\n" +
 	"\x13ListAccountsRequest\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12$\n" +
 	"\x0evk_linked_only\x18\x02 \x01(\bR\fvkLinkedOnly\x12\x14\n" +
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/grigta/conveer/pkg/health"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/serviceauth"
+	"github.com/grigta/conveer/services/persona-service/internal/handlers"
+	"github.com/grigta/conveer/services/persona-service/internal/models"
+	"github.com/grigta/conveer/services/persona-service/internal/repository"
+	"github.com/grigta/conveer/services/persona-service/internal/service"
+	pb "github.com/grigta/conveer/services/persona-service/proto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	log := logger.FromEnv("persona-service")
+
+	// Load configuration
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("/app/config")
+	viper.AddConfigPath("/app/configs")
+	viper.AddConfigPath("./configs")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	// Support common env names used in our .env / docker-compose
+	_ = viper.BindEnv("mongodb.uri", "MONGODB_URI", "MONGO_URI")
+	_ = viper.BindEnv("mongodb.database", "MONGO_DB_NAME")
+
+	if err := viper.ReadInConfig(); err != nil {
+		log.Warnf("Config file not found, using env variables: %v", err)
+	}
+
+	// Set defaults
+	viper.SetDefault("service.name", "persona-service")
+	viper.SetDefault("grpc.port", "50064")
+	viper.SetDefault("http.port", "8015")
+	viper.SetDefault("mongodb.uri", "mongodb://mongodb:27017")
+	viper.SetDefault("mongodb.database", "persona_service")
+	viper.SetDefault("persona.default_locale", models.LocaleRU)
+	viper.SetDefault("persona.min_age", 18)
+	viper.SetDefault("persona.max_age", 35)
+	viper.SetDefault("persona.max_attempts", 10)
+	viper.SetDefault("persona.avatar_pool_size", 50)
+	viper.SetDefault("persona.avatar_set_size", 4)
+	viper.SetDefault("persona.avatar_base_url", "")
+	viper.SetDefault("persona.reservation_ttl", "1h")
+	viper.SetDefault("persona.reservation_cleanup_interval", "10m")
+
+	// Initialize MongoDB
+	ctx := context.Background()
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(viper.GetString("mongodb.uri")))
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer mongoClient.Disconnect(ctx)
+
+	database := mongoClient.Database(viper.GetString("mongodb.database"))
+
+	// Initialize repositories
+	personaRepo := repository.NewPersonaRepository(database, log)
+	if err := personaRepo.CreateIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create persona indexes: %v", err)
+	}
+
+	// Initialize services
+	personaConfig := &models.PersonaConfig{
+		DefaultLocale:  viper.GetString("persona.default_locale"),
+		MinAge:         viper.GetInt("persona.min_age"),
+		MaxAge:         viper.GetInt("persona.max_age"),
+		MaxAttempts:    viper.GetInt("persona.max_attempts"),
+		AvatarPoolSize: viper.GetInt("persona.avatar_pool_size"),
+		AvatarSetSize:  viper.GetInt("persona.avatar_set_size"),
+		AvatarBaseURL:  viper.GetString("persona.avatar_base_url"),
+		ReservationTTL: viper.GetDuration("persona.reservation_ttl"),
+	}
+
+	generator := service.NewPersonaGenerator(
+		personaConfig.AvatarPoolSize,
+		personaConfig.AvatarSetSize,
+		personaConfig.AvatarBaseURL,
+		time.Now().UnixNano(),
+	)
+	metricsCollector := service.NewMetricsCollector()
+
+	personaService := service.NewPersonaService(
+		personaRepo,
+		generator,
+		personaConfig,
+		metricsCollector,
+		log,
+	)
+
+	// Expired reservations are ignored by allocation, the cleanup only trims them from documents
+	cleanupCtx, stopCleanup := context.WithCancel(context.Background())
+	defer stopCleanup()
+	go personaService.RunReservationCleanup(cleanupCtx, viper.GetDuration("persona.reservation_cleanup_interval"))
+
+	// Initialize handlers
+	grpcHandler := handlers.NewGRPCHandler(personaService, log)
+	httpHandler := handlers.NewHTTPHandler(personaService, log)
+
+	// Readiness gates for gRPC health and /health
+	healthChecker := health.NewChecker("persona-service")
+	healthChecker.Add("mongodb", health.MongoCheck(mongoClient))
+
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	go healthChecker.Run(healthCtx, 10*time.Second)
+
+	// Start gRPC server
+	grpcPort := viper.GetString("grpc.port")
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+	}
+
+	serviceAuth := serviceauth.FromEnv("persona-service")
+	grpcServer := grpc.NewServer(append(logger.ServerOptions(), serviceAuth.ServerOptions()...)...)
+	pb.RegisterPersonaServiceServer(grpcServer, grpcHandler)
+	healthChecker.Register(grpcServer)
+	reflection.Register(grpcServer)
+
+	go func() {
+		log.Infof("Starting gRPC server on port %s", grpcPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Failed to serve gRPC: %v", err)
+		}
+	}()
+
+	// Start HTTP server
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(gin.LoggerWithConfig(gin.LoggerConfig{
+		SkipPaths: []string{"/health", "/metrics"},
+	}))
+
+	// Register HTTP routes
+	router.GET("/health", healthChecker.Handler())
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	api := router.Group("/api/v1")
+	{
+		api.POST("/personas", httpHandler.GeneratePersona)
+		api.GET("/personas/:id", httpHandler.GetPersona)
+		api.POST("/personas/:id/assign", httpHandler.AssignPersona)
+		api.DELETE("/personas/:id", httpHandler.ReleasePersona)
+		api.POST("/personas/allocate", httpHandler.AllocatePersona)
+		api.POST("/personas/:id/reservations/:reservation_id/confirm", httpHandler.ConfirmReservation)
+		api.DELETE("/personas/:id/reservations/:reservation_id", httpHandler.CancelReservation)
+	}
+
+	httpPort := viper.GetString("http.port")
+	httpServer := &http.Server{
+		Addr:    ":" + httpPort,
+		Handler: router,
+	}
+
+	go func() {
+		log.Infof("Starting HTTP server on port %s", httpPort)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start HTTP server: %v", err)
+		}
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Info("Shutting down servers...")
+
+	// Report NOT_SERVING so callers stop routing here before the servers stop
+	stopHealth()
+
+	// Graceful shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	grpcServer.GracefulStop()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Errorf("HTTP server forced to shutdown: %v", err)
+	}
+
+	log.Info("Servers exited")
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/persona-service/internal/models"
+	"github.com/grigta/conveer/services/persona-service/internal/service"
+	pb "github.com/grigta/conveer/services/persona-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GRPCHandler struct {
+	pb.UnimplementedPersonaServiceServer
+	personaService *service.PersonaService
+	logger         logger.Logger
+}
+
+func NewGRPCHandler(personaService *service.PersonaService, logger logger.Logger) *GRPCHandler {
+	return &GRPCHandler{
+		personaService: personaService,
+		logger:         logger,
+	}
+}
+
+func (h *GRPCHandler) GeneratePersona(ctx context.Context, req *pb.GeneratePersonaRequest) (*pb.Persona, error) {
+	persona, err := h.personaService.GeneratePersona(ctx, &models.GenerateRequest{
+		Platform:  req.Platform,
+		Gender:    req.Gender,
+		Locale:    req.Locale,
+		Timezone:  req.Timezone,
+		MinAge:    int(req.MinAge),
+		MaxAge:    int(req.MaxAge),
+		AccountID: req.AccountId,
+	})
+	if err != nil {
+		h.logger.Errorf("Failed to generate persona: %v", err)
+		return nil, toStatus(err)
+	}
+
+	return h.toProto(persona, req.Platform)
+}
+
+func (h *GRPCHandler) GetPersona(ctx context.Context, req *pb.GetPersonaRequest) (*pb.Persona, error) {
+	persona, err := h.personaService.GetPersona(ctx, req.PersonaId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return h.toProto(persona, req.Platform)
+}
+
+func (h *GRPCHandler) AssignPersona(ctx context.Context, req *pb.AssignPersonaRequest) (*pb.Persona, error) {
+	persona, err := h.personaService.AssignPersona(ctx, req.PersonaId, req.Platform, req.AccountId)
+	if err != nil {
+		h.logger.Errorf("Failed to assign persona %s: %v", req.PersonaId, err)
+		return nil, toStatus(err)
+	}
+
+	return h.toProto(persona, req.Platform)
+}
+
+func (h *GRPCHandler) ReleasePersona(ctx context.Context, req *pb.ReleasePersonaRequest) (*pb.ReleasePersonaResponse, error) {
+	if err := h.personaService.ReleasePersona(ctx, req.PersonaId); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ReleasePersonaResponse{Success: true}, nil
+}
+
+func (h *GRPCHandler) AllocatePersona(ctx context.Context, req *pb.AllocatePersonaRequest) (*pb.Persona, error) {
+	persona, reservation, err := h.personaService.AllocatePersona(ctx, &models.AllocateRequest{
+		Platform:      req.Platform,
+		PersonaID:     req.PersonaId,
+		Gender:        req.Gender,
+		Locale:        req.Locale,
+		Timezone:      req.Timezone,
+		MinAge:        int(req.MinAge),
+		MaxAge:        int(req.MaxAge),
+		ReuseExisting: req.ReuseExisting,
+	})
+	if err != nil {
+		h.logger.Errorf("Failed to allocate persona for %s: %v", req.Platform, err)
+		return nil, toStatus(err)
+	}
+
+	resp, err := h.toProto(persona, req.Platform)
+	if err != nil {
+		return nil, err
+	}
+	resp.ReservationId = reservation.ID
+	resp.ReservedUntil = reservation.ExpiresAt.Unix()
+	return resp, nil
+}
+
+func (h *GRPCHandler) ConfirmReservation(ctx context.Context, req *pb.ConfirmReservationRequest) (*pb.Persona, error) {
+	persona, err := h.personaService.ConfirmReservation(ctx, req.PersonaId, req.ReservationId, req.AccountId)
+	if err != nil {
+		h.logger.Errorf("Failed to confirm reservation %s of persona %s: %v", req.ReservationId, req.PersonaId, err)
+		return nil, toStatus(err)
+	}
+
+	platform := ""
+	for _, assignment := range persona.Assignments {
+		if assignment.AccountID == req.AccountId {
+			platform = assignment.Platform
+		}
+	}
+	return h.toProto(persona, platform)
+}
+
+func (h *GRPCHandler) CancelReservation(ctx context.Context, req *pb.CancelReservationRequest) (*pb.CancelReservationResponse, error) {
+	if err := h.personaService.CancelReservation(ctx, req.PersonaId, req.ReservationId); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CancelReservationResponse{Success: true}, nil
+}
+
+// toProto converts a persona, applying platform formatting when a platform is given
+func (h *GRPCHandler) toProto(persona *models.Persona, platform string) (*pb.Persona, error) {
+	resp := &pb.Persona{
+		Id:          persona.ID.Hex(),
+		FirstName:   persona.FirstName,
+		LastName:    persona.LastName,
+		Gender:      persona.Gender,
+		Locale:      persona.Locale,
+		BirthDate:   persona.BirthDate.Format("2006-01-02"),
+		City:        persona.City,
+		Country:     persona.Country,
+		Avatar:      persona.Avatar,
+		Avatars:     persona.Avatars,
+		Bio:         persona.Bio,
+		Interests:   persona.Interests,
+		Timezone:    persona.Timezone,
+		EmailPrefix: persona.EmailPrefix,
+		Username:    persona.Username,
+		DisplayName: persona.FirstName + " " + persona.LastName,
+		CreatedAt:   persona.CreatedAt.Unix(),
+	}
+
+	if platform == "" {
+		return resp, nil
+	}
+
+	profile, err := h.personaService.FormatPersona(persona, platform)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp.Platform = platform
+	resp.FirstName = profile.FirstName
+	resp.LastName = profile.LastName
+	resp.DisplayName = profile.DisplayName
+	resp.BirthDate = profile.BirthDate
+	resp.Username = profile.Username
+	resp.EmailPrefix = profile.EmailPrefix
+	resp.Bio = profile.Bio
+	if assignment := persona.AssignmentFor(platform); assignment != nil {
+		resp.AccountId = assignment.AccountID
+	}
+
+	return resp, nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, models.ErrPersonaNotFound),
+		errors.Is(err, models.ErrReservationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrUnsupportedPlatform),
+		errors.Is(err, models.ErrUnsupportedLocale),
+		errors.Is(err, models.ErrInvalidGender),
+		errors.Is(err, models.ErrInvalidAgeRange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrAlreadyAssigned),
+		errors.Is(err, models.ErrReserved):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, models.ErrUniquenessExhausted):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "persona service error: %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/persona-service/internal/models"
+	"github.com/grigta/conveer/services/persona-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HTTPHandler struct {
+	personaService *service.PersonaService
+	logger         logger.Logger
+}
+
+func NewHTTPHandler(personaService *service.PersonaService, logger logger.Logger) *HTTPHandler {
+	return &HTTPHandler{
+		personaService: personaService,
+		logger:         logger,
+	}
+}
+
+func (h *HTTPHandler) GeneratePersona(c *gin.Context) {
+	var req models.GenerateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	persona, err := h.personaService.GeneratePersona(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Errorf("Failed to generate persona: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respond(c, persona, req.Platform, http.StatusCreated)
+}
+
+func (h *HTTPHandler) GetPersona(c *gin.Context) {
+	persona, err := h.personaService.GetPersona(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respond(c, persona, c.Query("platform"), http.StatusOK)
+}
+
+func (h *HTTPHandler) AssignPersona(c *gin.Context) {
+	var req struct {
+		Platform  string `json:"platform" binding:"required"`
+		AccountID string `json:"account_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	persona, err := h.personaService.AssignPersona(c.Request.Context(), c.Param("id"), req.Platform, req.AccountID)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respond(c, persona, req.Platform, http.StatusOK)
+}
+
+func (h *HTTPHandler) ReleasePersona(c *gin.Context) {
+	if err := h.personaService.ReleasePersona(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *HTTPHandler) AllocatePersona(c *gin.Context) {
+	var req models.AllocateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	persona, reservation, err := h.personaService.AllocatePersona(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Errorf("Failed to allocate persona for %s: %v", req.Platform, err)
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.personaService.FormatPersona(persona, req.Platform)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"persona":     persona,
+		"profile":     profile,
+		"reservation": reservation,
+	})
+}
+
+func (h *HTTPHandler) ConfirmReservation(c *gin.Context) {
+	var req struct {
+		AccountID string `json:"account_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	persona, err := h.personaService.ConfirmReservation(c.Request.Context(), c.Param("id"), c.Param("reservation_id"), req.AccountID)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"persona": persona})
+}
+
+func (h *HTTPHandler) CancelReservation(c *gin.Context) {
+	if err := h.personaService.CancelReservation(c.Request.Context(), c.Param("id"), c.Param("reservation_id")); err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *HTTPHandler) respond(c *gin.Context, persona *models.Persona, platform string, code int) {
+	if platform == "" {
+		c.JSON(code, gin.H{"persona": persona})
+		return
+	}
+
+	profile, err := h.personaService.FormatPersona(persona, platform)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(code, gin.H{
+		"persona": persona,
+		"profile": profile,
+	})
+}
+
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrPersonaNotFound),
+		errors.Is(err, models.ErrReservationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrUnsupportedPlatform),
+		errors.Is(err, models.ErrUnsupportedLocale),
+		errors.Is(err, models.ErrInvalidGender),
+		errors.Is(err, models.ErrInvalidAgeRange):
+		return http.StatusBadRequest
+	case errors.Is(err, models.ErrAlreadyAssigned),
+		errors.Is(err, models.ErrReserved):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrUniquenessExhausted):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+)
+
+const (
+	LocaleRU = "ru_RU"
+	LocaleEN = "en_US"
+)
+
+const (
+	PlatformVK       = "vk"
+	PlatformTelegram = "telegram"
+	PlatformMail     = "mail"
+	PlatformMax      = "max"
+)
+
+var (
+	ErrPersonaNotFound     = errors.New("persona not found")
+	ErrUnsupportedPlatform = errors.New("unsupported platform")
+	ErrUnsupportedLocale   = errors.New("unsupported locale")
+	ErrInvalidGender       = errors.New("invalid gender")
+	ErrInvalidAgeRange     = errors.New("invalid age range")
+	ErrAlreadyAssigned     = errors.New("persona already assigned to another account on this platform")
+	ErrUniquenessExhausted = errors.New("failed to generate unique persona")
+	ErrReserved            = errors.New("persona is reserved by another registration on this platform")
+	ErrReservationNotFound = errors.New("reservation not found or expired")
+)
+
+// Persona is a single coherent identity shared by every account created for it.
+// Name, email prefix and username are unique across all stored personas.
+type Persona struct {
+	ID           primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	FirstName    string               `bson:"first_name" json:"first_name"`
+	LastName     string               `bson:"last_name" json:"last_name"`
+	Gender       string               `bson:"gender" json:"gender"`
+	Locale       string               `bson:"locale" json:"locale"`
+	BirthDate    time.Time            `bson:"birth_date" json:"birth_date"`
+	City         string               `bson:"city" json:"city"`
+	Country      string               `bson:"country" json:"country"`
+	Timezone     string               `bson:"timezone" json:"timezone"`
+	Avatar       string               `bson:"avatar" json:"avatar"`
+	Avatars      []string             `bson:"avatars" json:"avatars"` // Photos of the same face, Avatar is the first one
+	Bio          string               `bson:"bio" json:"bio"`
+	Interests    []string             `bson:"interests" json:"interests"`
+	EmailPrefix  string               `bson:"email_prefix" json:"email_prefix"`
+	Username     string               `bson:"username" json:"username"`
+	Assignments  []PersonaAssignment  `bson:"assignments" json:"assignments"`
+	Reservations []PersonaReservation `bson:"reservations" json:"reservations,omitempty"` // At most one active per platform
+	CreatedAt    time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// PersonaAssignment links a persona to an account on one platform
+type PersonaAssignment struct {
+	Platform   string    `bson:"platform" json:"platform"`
+	AccountID  string    `bson:"account_id" json:"account_id"`
+	AssignedAt time.Time `bson:"assigned_at" json:"assigned_at"`
+}
+
+// PersonaReservation holds a persona for a registration that has not produced an account yet.
+// It is confirmed into an assignment once the account exists, or cancelled when registration fails.
+type PersonaReservation struct {
+	ID         string    `bson:"id" json:"id"`
+	Platform   string    `bson:"platform" json:"platform"`
+	ReservedAt time.Time `bson:"reserved_at" json:"reserved_at"`
+	ExpiresAt  time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+// ReservationByID returns the reservation with id if it has not expired at now
+func (p *Persona) ReservationByID(id string, now time.Time) *PersonaReservation {
+	for i := range p.Reservations {
+		if p.Reservations[i].ID == id && p.Reservations[i].ExpiresAt.After(now) {
+			return &p.Reservations[i]
+		}
+	}
+	return nil
+}
+
+// AssignmentFor returns the assignment for platform, if any
+func (p *Persona) AssignmentFor(platform string) *PersonaAssignment {
+	for i := range p.Assignments {
+		if p.Assignments[i].Platform == platform {
+			return &p.Assignments[i]
+		}
+	}
+	return nil
+}
+
+// PlatformProfile is a persona rendered with the limits and conventions of one platform
+type PlatformProfile struct {
+	Platform    string `json:"platform"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	DisplayName string `json:"display_name"`
+	Username    string `json:"username,omitempty"`
+	EmailPrefix string `json:"email_prefix,omitempty"`
+	BirthDate   string `json:"birth_date"`
+	Bio         string `json:"bio,omitempty"`
+}
+
+type GenerateRequest struct {
+	Platform  string `json:"platform"`
+	Gender    string `json:"gender"`
+	Locale    string `json:"locale"`
+	Timezone  string `json:"timezone"`
+	MinAge    int    `json:"min_age"`
+	MaxAge    int    `json:"max_age"`
+	AccountID string `json:"account_id"`
+}
+
+// AllocateRequest reserves a persona for a registration on Platform.
+// PersonaID pins a specific persona; otherwise ReuseExisting prefers a persona
+// already registered on other platforms before a new one is generated.
+type AllocateRequest struct {
+	Platform      string `json:"platform"`
+	PersonaID     string `json:"persona_id"`
+	Gender        string `json:"gender"`
+	Locale        string `json:"locale"`
+	Timezone      string `json:"timezone"`
+	MinAge        int    `json:"min_age"`
+	MaxAge        int    `json:"max_age"`
+	ReuseExisting bool   `json:"reuse_existing"`
+}
+
+// PersonaFilter selects reusable personas
+type PersonaFilter struct {
+	Gender         string
+	Locale         string
+	Timezone       string
+	BornAfter      time.Time
+	BornBefore     time.Time
+	FreeOnPlatform string
+}
+
+type PersonaConfig struct {
+	DefaultLocale  string
+	MinAge         int
+	MaxAge         int
+	MaxAttempts    int
+	AvatarPoolSize int
+	AvatarSetSize  int
+	AvatarBaseURL  string
+	ReservationTTL time.Duration
+}
+
+func ValidPlatform(platform string) bool {
+	switch platform {
+	case PlatformVK, PlatformTelegram, PlatformMail, PlatformMax:
+		return true
+	}
+	return false
+}
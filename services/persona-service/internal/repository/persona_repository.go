@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/persona-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PersonaRepository struct {
+	collection *mongo.Collection
+	logger     logger.Logger
+}
+
+func NewPersonaRepository(db *mongo.Database, logger logger.Logger) *PersonaRepository {
+	return &PersonaRepository{
+		collection: db.Collection("personas"),
+		logger:     logger,
+	}
+}
+
+// CreateIndexes creates the unique indexes that back persona uniqueness
+func (r *PersonaRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "first_name", Value: 1},
+				{Key: "last_name", Value: 1},
+				{Key: "birth_date", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "email_prefix", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "username", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "assignments.platform", Value: 1},
+				{Key: "assignments.account_id", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{{Key: "reservations.expires_at", Value: 1}},
+		},
+	}
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create persona indexes: %w", err)
+	}
+	return nil
+}
+
+// Create inserts a persona. Collisions on unique fields are returned as mongo duplicate key errors.
+func (r *PersonaRepository) Create(ctx context.Context, persona *models.Persona) error {
+	persona.CreatedAt = time.Now()
+	persona.UpdatedAt = time.Now()
+	if persona.Assignments == nil {
+		persona.Assignments = []models.PersonaAssignment{}
+	}
+	if persona.Reservations == nil {
+		persona.Reservations = []models.PersonaReservation{}
+	}
+
+	result, err := r.collection.InsertOne(ctx, persona)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to insert persona: %w", err)
+	}
+
+	persona.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *PersonaRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Persona, error) {
+	var persona models.Persona
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&persona)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find persona: %w", err)
+	}
+	return &persona, nil
+}
+
+func (r *PersonaRepository) FindByAccount(ctx context.Context, platform, accountID string) (*models.Persona, error) {
+	var persona models.Persona
+	filter := bson.M{"assignments": bson.M{"$elemMatch": bson.M{"platform": platform, "account_id": accountID}}}
+	err := r.collection.FindOne(ctx, filter).Decode(&persona)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find persona: %w", err)
+	}
+	return &persona, nil
+}
+
+// AddAssignment links the persona to an account unless the platform slot is already taken
+func (r *PersonaRepository) AddAssignment(ctx context.Context, id primitive.ObjectID, assignment models.PersonaAssignment) (bool, error) {
+	filter := bson.M{
+		"_id":                  id,
+		"assignments.platform": bson.M{"$ne": assignment.Platform},
+	}
+	update := bson.M{
+		"$push": bson.M{"assignments": assignment},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to assign persona: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// freeOn matches personas with neither an account nor an active reservation on platform
+func freeOn(platform string, now time.Time) bson.M {
+	return bson.M{
+		"assignments.platform": bson.M{"$ne": platform},
+		"reservations": bson.M{"$not": bson.M{"$elemMatch": bson.M{
+			"platform":   platform,
+			"expires_at": bson.M{"$gt": now},
+		}}},
+	}
+}
+
+// reserveUpdate replaces any expired reservation for the platform with the new one.
+// A pipeline update is used because $pull and $push cannot touch the same array in one update.
+func reserveUpdate(reservation models.PersonaReservation) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"reservations": bson.M{"$concatArrays": bson.A{
+				bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$reservations", bson.A{}}},
+					"cond":  bson.M{"$ne": bson.A{"$$this.platform", reservation.Platform}},
+				}},
+				bson.A{reservation},
+			}},
+			"updated_at": reservation.ReservedAt,
+		}}},
+	}
+}
+
+// ReserveByID reserves the persona unless the platform slot is taken. Returns nil if it is.
+func (r *PersonaRepository) ReserveByID(ctx context.Context, id primitive.ObjectID, reservation models.PersonaReservation) (*models.Persona, error) {
+	filter := freeOn(reservation.Platform, reservation.ReservedAt)
+	filter["_id"] = id
+
+	return r.reserve(ctx, filter, reservation, options.FindOneAndUpdate())
+}
+
+// ReserveAvailable reserves the oldest persona matching filter that is free on the platform.
+// Returns nil if there is none.
+func (r *PersonaRepository) ReserveAvailable(ctx context.Context, filter models.PersonaFilter, reservation models.PersonaReservation) (*models.Persona, error) {
+	query := freeOn(reservation.Platform, reservation.ReservedAt)
+	if filter.Gender != "" {
+		query["gender"] = filter.Gender
+	}
+	if filter.Locale != "" {
+		query["locale"] = filter.Locale
+	}
+	if filter.Timezone != "" {
+		query["timezone"] = filter.Timezone
+	}
+	if !filter.BornAfter.IsZero() || !filter.BornBefore.IsZero() {
+		birthDate := bson.M{}
+		if !filter.BornAfter.IsZero() {
+			birthDate["$gte"] = filter.BornAfter
+		}
+		if !filter.BornBefore.IsZero() {
+			birthDate["$lte"] = filter.BornBefore
+		}
+		query["birth_date"] = birthDate
+	}
+
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return r.reserve(ctx, query, reservation, opts)
+}
+
+func (r *PersonaRepository) reserve(ctx context.Context, filter bson.M, reservation models.PersonaReservation, opts *options.FindOneAndUpdateOptions) (*models.Persona, error) {
+	opts.SetReturnDocument(options.After)
+
+	var persona models.Persona
+	err := r.collection.FindOneAndUpdate(ctx, filter, reserveUpdate(reservation), opts).Decode(&persona)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to reserve persona: %w", err)
+	}
+	return &persona, nil
+}
+
+// ConfirmReservation turns an active reservation into an assignment. Returns nil if the
+// reservation expired or the platform slot was taken in the meantime.
+func (r *PersonaRepository) ConfirmReservation(ctx context.Context, id primitive.ObjectID, reservationID string, assignment models.PersonaAssignment) (*models.Persona, error) {
+	filter := bson.M{
+		"_id": id,
+		"reservations": bson.M{"$elemMatch": bson.M{
+			"id":         reservationID,
+			"expires_at": bson.M{"$gt": assignment.AssignedAt},
+		}},
+		"assignments.platform": bson.M{"$ne": assignment.Platform},
+	}
+	update := bson.M{
+		"$pull": bson.M{"reservations": bson.M{"id": reservationID}},
+		"$push": bson.M{"assignments": assignment},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var persona models.Persona
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&persona)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to confirm reservation: %w", err)
+	}
+	return &persona, nil
+}
+
+// CancelReservation drops the reservation, returns false if there was none
+func (r *PersonaRepository) CancelReservation(ctx context.Context, id primitive.ObjectID, reservationID string) (bool, error) {
+	update := bson.M{
+		"$pull": bson.M{"reservations": bson.M{"id": reservationID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "reservations.id": reservationID}, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel reservation: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// PurgeExpiredReservations removes reservations that expired before now
+func (r *PersonaRepository) PurgeExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	expired := bson.M{"expires_at": bson.M{"$lte": now}}
+	update := bson.M{"$pull": bson.M{"reservations": expired}}
+
+	result, err := r.collection.UpdateMany(ctx, bson.M{"reservations": bson.M{"$elemMatch": expired}}, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired reservations: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *PersonaRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete persona: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return models.ErrPersonaNotFound
+	}
+	return nil
+}
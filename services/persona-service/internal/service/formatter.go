@@ -0,0 +1,55 @@
+package service
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/grigta/conveer/services/persona-service/internal/models"
+)
+
+// Platform limits for profile fields
+const (
+	telegramBioLimit      = 70
+	telegramUsernameLimit = 32
+	maxUsernameLimit      = 30
+	vkStatusLimit         = 140
+	mailPrefixLimit       = 31
+)
+
+// FormatForPlatform renders a persona the way the given platform expects it
+func FormatForPlatform(persona *models.Persona, platform string) (*models.PlatformProfile, error) {
+	profile := &models.PlatformProfile{
+		Platform:    platform,
+		FirstName:   persona.FirstName,
+		LastName:    persona.LastName,
+		DisplayName: persona.FirstName + " " + persona.LastName,
+		BirthDate:   persona.BirthDate.Format("2006-01-02"),
+	}
+
+	switch platform {
+	case models.PlatformVK:
+		// VK assigns numeric ids, the bio is used as the profile status
+		profile.Bio = truncate(persona.Bio, vkStatusLimit)
+	case models.PlatformTelegram:
+		// Telegram does not ask for a birth date during sign up
+		profile.BirthDate = ""
+		profile.Username = truncate(persona.Username, telegramUsernameLimit)
+		profile.Bio = truncate(persona.Bio, telegramBioLimit)
+	case models.PlatformMail:
+		profile.EmailPrefix = truncate(persona.EmailPrefix, mailPrefixLimit)
+	case models.PlatformMax:
+		profile.Username = truncate(strings.ReplaceAll(persona.Username, "_", ""), maxUsernameLimit)
+		profile.Bio = persona.Bio
+	default:
+		return nil, models.ErrUnsupportedPlatform
+	}
+
+	return profile, nil
+}
+
+func truncate(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return strings.TrimSpace(string([]rune(value)[:limit]))
+}
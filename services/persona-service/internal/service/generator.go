@@ -0,0 +1,186 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/grigta/conveer/services/persona-service/internal/models"
+)
+
+// PersonaGenerator builds personas whose name, gender, age, city and bio agree with each other
+type PersonaGenerator struct {
+	mu             sync.Mutex
+	rand           *rand.Rand
+	now            func() time.Time
+	avatarPoolSize int
+	avatarSetSize  int
+	avatarBaseURL  string
+}
+
+// NewPersonaGenerator creates a generator. The avatar pool holds avatarPoolSize sets per gender,
+// each set being avatarSetSize photos of the same face: <gender>/<set>/<n>.jpg under avatarBaseURL.
+func NewPersonaGenerator(avatarPoolSize, avatarSetSize int, avatarBaseURL string, seed int64) *PersonaGenerator {
+	if avatarPoolSize <= 0 {
+		avatarPoolSize = 1
+	}
+	if avatarSetSize <= 0 {
+		avatarSetSize = 1
+	}
+	if avatarBaseURL != "" && !strings.HasSuffix(avatarBaseURL, "/") {
+		avatarBaseURL += "/"
+	}
+	return &PersonaGenerator{
+		rand:           rand.New(rand.NewSource(seed)),
+		now:            time.Now,
+		avatarPoolSize: avatarPoolSize,
+		avatarSetSize:  avatarSetSize,
+		avatarBaseURL:  avatarBaseURL,
+	}
+}
+
+// Generate creates a persona for the request. attempt is the number of previous
+// collisions and widens the suffix space of the email prefix and username.
+func (g *PersonaGenerator) Generate(req *models.GenerateRequest, attempt int) (*models.Persona, error) {
+	data, ok := locales[req.Locale]
+	if !ok {
+		return nil, models.ErrUnsupportedLocale
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gender := req.Gender
+	switch gender {
+	case "":
+		gender = models.GenderMale
+		if g.rand.Intn(2) == 0 {
+			gender = models.GenderFemale
+		}
+	case models.GenderMale, models.GenderFemale:
+	default:
+		return nil, models.ErrInvalidGender
+	}
+
+	var firstName string
+	lastName := g.pick(data.lastNames)
+	if gender == models.GenderMale {
+		firstName = g.pick(data.maleFirstNames)
+	} else {
+		firstName = g.pick(data.femaleFirstNames)
+		if req.Locale == models.LocaleRU {
+			lastName = feminizeLastName(lastName)
+		}
+	}
+
+	timezone := req.Timezone
+	cities, ok := data.citiesByTimezone[timezone]
+	if !ok {
+		timezone = data.defaultTimezone
+		cities = data.citiesByTimezone[timezone]
+	}
+	city := g.pick(cities)
+
+	birthDate := g.birthDate(req.MinAge, req.MaxAge)
+	interests := g.pickInterests(data.interests, 2+g.rand.Intn(2))
+	bio := fmt.Sprintf(g.pick(data.bioTemplates), city, strings.Join(interests, ", "))
+
+	avatars := g.avatarSet(gender)
+
+	latinFirst := transliterate(firstName)
+	latinLast := transliterate(lastName)
+
+	return &models.Persona{
+		FirstName:   firstName,
+		LastName:    lastName,
+		Gender:      gender,
+		Locale:      req.Locale,
+		BirthDate:   birthDate,
+		City:        city,
+		Country:     data.country,
+		Timezone:    timezone,
+		Avatar:      avatars[0],
+		Avatars:     avatars,
+		Bio:         bio,
+		Interests:   interests,
+		EmailPrefix: latinFirst + "." + latinLast + g.suffix(birthDate, attempt),
+		Username:    latinFirst + "_" + latinLast + g.suffix(birthDate, attempt),
+	}, nil
+}
+
+func (g *PersonaGenerator) pick(values []string) string {
+	return values[g.rand.Intn(len(values))]
+}
+
+func (g *PersonaGenerator) pickInterests(pool []string, n int) []string {
+	picked := make([]string, 0, n)
+	for _, i := range g.rand.Perm(len(pool))[:n] {
+		picked = append(picked, pool[i])
+	}
+	return picked
+}
+
+// avatarSet picks one face from the pool so every platform profile shows the same person
+func (g *PersonaGenerator) avatarSet(gender string) []string {
+	set := 1 + g.rand.Intn(g.avatarPoolSize)
+
+	avatars := make([]string, 0, g.avatarSetSize)
+	for n := 1; n <= g.avatarSetSize; n++ {
+		avatars = append(avatars, fmt.Sprintf("%s%s/%03d/%d.jpg", g.avatarBaseURL, gender, set, n))
+	}
+	return avatars
+}
+
+// birthDate returns a date that makes the persona between minAge and maxAge years old today
+func (g *PersonaGenerator) birthDate(minAge, maxAge int) time.Time {
+	now := g.now()
+	latest := now.AddDate(-minAge, 0, 0)
+	earliest := now.AddDate(-maxAge-1, 0, 1)
+	days := int(latest.Sub(earliest).Hours() / 24)
+
+	date := earliest.AddDate(0, 0, g.rand.Intn(days+1))
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// suffix keeps the first attempt human-looking (birth year) and falls back to random digits on collisions
+func (g *PersonaGenerator) suffix(birthDate time.Time, attempt int) string {
+	switch {
+	case attempt == 0:
+		return fmt.Sprintf("%d", birthDate.Year())
+	case attempt < 3:
+		return fmt.Sprintf("%02d%02d", birthDate.Year()%100, 10+g.rand.Intn(90))
+	default:
+		return fmt.Sprintf("%d", 1000+g.rand.Intn(9000*attempt))
+	}
+}
+
+// feminizeLastName derives the feminine form of a Russian surname
+func feminizeLastName(lastName string) string {
+	switch {
+	case strings.HasSuffix(lastName, "ский"):
+		return strings.TrimSuffix(lastName, "ий") + "ая"
+	case strings.HasSuffix(lastName, "ов"), strings.HasSuffix(lastName, "ев"),
+		strings.HasSuffix(lastName, "ин"), strings.HasSuffix(lastName, "ёв"):
+		return lastName + "а"
+	default:
+		return lastName
+	}
+}
+
+// transliterate converts a name into lowercase ASCII suitable for logins
+func transliterate(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if latin, ok := translit[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
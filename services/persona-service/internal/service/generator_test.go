@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/persona-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGenerator() *PersonaGenerator {
+	g := NewPersonaGenerator(10, 3, "", 42)
+	g.now = func() time.Time { return time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC) }
+	return g
+}
+
+func ageAt(birthDate, now time.Time) int {
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || (now.Month() == birthDate.Month() && now.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+func TestGenerateIsCoherent(t *testing.T) {
+	g := newTestGenerator()
+
+	for i := 0; i < 200; i++ {
+		persona, err := g.Generate(&models.GenerateRequest{
+			Gender:   models.GenderFemale,
+			Locale:   models.LocaleRU,
+			Timezone: "Asia/Novosibirsk",
+			MinAge:   20,
+			MaxAge:   25,
+		}, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.GenderFemale, persona.Gender)
+		assert.Contains(t, locales[models.LocaleRU].femaleFirstNames, persona.FirstName)
+		assert.True(t, strings.HasSuffix(persona.LastName, "а") || strings.HasSuffix(persona.LastName, "ая"), "last name %q is not feminine", persona.LastName)
+		assert.Contains(t, []string{"Новосибирск", "Барнаул", "Томск"}, persona.City)
+		assert.Equal(t, "RU", persona.Country)
+		assert.True(t, strings.HasPrefix(persona.Avatar, "female/"))
+		assert.NotEmpty(t, persona.Bio)
+
+		age := ageAt(persona.BirthDate, g.now())
+		assert.GreaterOrEqual(t, age, 20)
+		assert.LessOrEqual(t, age, 25)
+	}
+}
+
+func TestGenerateFallsBackToLocaleTimezone(t *testing.T) {
+	g := newTestGenerator()
+
+	persona, err := g.Generate(&models.GenerateRequest{
+		Locale:   models.LocaleEN,
+		Timezone: "Europe/Moscow",
+		MinAge:   18,
+		MaxAge:   35,
+	}, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, "America/New_York", persona.Timezone)
+	assert.Equal(t, "US", persona.Country)
+}
+
+func TestGenerateAvatarSetIsOneFace(t *testing.T) {
+	g := NewPersonaGenerator(10, 3, "https://cdn.example.com/avatars", 42)
+
+	persona, err := g.Generate(&models.GenerateRequest{Locale: models.LocaleRU, Gender: models.GenderMale, MinAge: 18, MaxAge: 35}, 0)
+	require.NoError(t, err)
+
+	require.Len(t, persona.Avatars, 3)
+	assert.Equal(t, persona.Avatars[0], persona.Avatar)
+
+	set := strings.TrimSuffix(persona.Avatars[0], "1.jpg")
+	assert.True(t, strings.HasPrefix(set, "https://cdn.example.com/avatars/male/"), "unexpected avatar %q", persona.Avatar)
+	for n, avatar := range persona.Avatars {
+		assert.Equal(t, fmt.Sprintf("%s%d.jpg", set, n+1), avatar)
+	}
+}
+
+func TestGenerateRejectsInvalidInput(t *testing.T) {
+	g := newTestGenerator()
+
+	_, err := g.Generate(&models.GenerateRequest{Locale: "de_DE", MinAge: 18, MaxAge: 35}, 0)
+	assert.Equal(t, models.ErrUnsupportedLocale, err)
+
+	_, err = g.Generate(&models.GenerateRequest{Locale: models.LocaleRU, Gender: "other", MinAge: 18, MaxAge: 35}, 0)
+	assert.Equal(t, models.ErrInvalidGender, err)
+}
+
+func TestGenerateLoginsAreASCII(t *testing.T) {
+	g := newTestGenerator()
+
+	for attempt := 0; attempt < 5; attempt++ {
+		persona, err := g.Generate(&models.GenerateRequest{Locale: models.LocaleRU, MinAge: 18, MaxAge: 35}, attempt)
+		require.NoError(t, err)
+
+		for _, login := range []string{persona.EmailPrefix, persona.Username} {
+			for _, r := range login {
+				assert.True(t, r < 128, "login %q contains non-ASCII characters", login)
+			}
+		}
+		if attempt == 0 {
+			assert.True(t, strings.HasSuffix(persona.EmailPrefix, persona.BirthDate.Format("2006")))
+		}
+	}
+}
+
+func TestFeminizeLastName(t *testing.T) {
+	assert.Equal(t, "Иванова", feminizeLastName("Иванов"))
+	assert.Equal(t, "Васильева", feminizeLastName("Васильев"))
+	assert.Equal(t, "Никитина", feminizeLastName("Никитин"))
+	assert.Equal(t, "Ковалевская", feminizeLastName("Ковалевский"))
+}
+
+func TestTransliterate(t *testing.T) {
+	assert.Equal(t, "artem", transliterate("Артём"))
+	assert.Equal(t, "shchukina", transliterate("Щукина"))
+	assert.Equal(t, "emily", transliterate("Emily"))
+}
+
+func TestFormatForPlatform(t *testing.T) {
+	persona := &models.Persona{
+		FirstName:   "Ирина",
+		LastName:    "Петрова",
+		BirthDate:   time.Date(1998, 3, 4, 0, 0, 0, 0, time.UTC),
+		Bio:         strings.Repeat("б", 100),
+		EmailPrefix: "irina.petrova1998",
+		Username:    "irina_petrova1998",
+	}
+
+	vk, err := FormatForPlatform(persona, models.PlatformVK)
+	require.NoError(t, err)
+	assert.Equal(t, "1998-03-04", vk.BirthDate)
+	assert.Equal(t, "Ирина Петрова", vk.DisplayName)
+	assert.Empty(t, vk.Username)
+
+	telegram, err := FormatForPlatform(persona, models.PlatformTelegram)
+	require.NoError(t, err)
+	assert.Empty(t, telegram.BirthDate)
+	assert.Equal(t, telegramBioLimit, len([]rune(telegram.Bio)))
+	assert.Equal(t, "irina_petrova1998", telegram.Username)
+
+	mail, err := FormatForPlatform(persona, models.PlatformMail)
+	require.NoError(t, err)
+	assert.Equal(t, "irina.petrova1998", mail.EmailPrefix)
+
+	max, err := FormatForPlatform(persona, models.PlatformMax)
+	require.NoError(t, err)
+	assert.Equal(t, "irinapetrova1998", max.Username)
+
+	_, err = FormatForPlatform(persona, "ok")
+	assert.Equal(t, models.ErrUnsupportedPlatform, err)
+}
@@ -0,0 +1,108 @@
+package service
+
+import "github.com/grigta/conveer/services/persona-service/internal/models"
+
+// localeData holds the name, city and bio pools for one locale
+type localeData struct {
+	country          string
+	defaultTimezone  string
+	maleFirstNames   []string
+	femaleFirstNames []string
+	lastNames        []string
+	citiesByTimezone map[string][]string
+	interests        []string
+	bioTemplates     []string
+}
+
+var locales = map[string]*localeData{
+	models.LocaleRU: {
+		country:         "RU",
+		defaultTimezone: "Europe/Moscow",
+		maleFirstNames: []string{
+			"Александр", "Дмитрий", "Максим", "Сергей", "Андрей", "Алексей", "Артём", "Иван", "Кирилл", "Михаил",
+			"Никита", "Матвей", "Роман", "Егор", "Арсений", "Илья", "Денис", "Евгений", "Даниил", "Тимофей",
+		},
+		femaleFirstNames: []string{
+			"Анна", "Мария", "Елена", "Наталья", "Ольга", "Екатерина", "Анастасия", "Дарья", "Юлия", "Ирина",
+			"Татьяна", "Светлана", "Ксения", "Полина", "Алиса", "Виктория", "Александра", "Вероника", "Арина", "Валерия",
+		},
+		// Stored in the masculine form, feminine forms are derived by feminizeLastName
+		lastNames: []string{
+			"Иванов", "Смирнов", "Кузнецов", "Попов", "Васильев", "Петров", "Соколов", "Михайлов", "Новиков", "Федоров",
+			"Морозов", "Волков", "Алексеев", "Лебедев", "Семенов", "Егоров", "Павлов", "Козлов", "Степанов", "Николаев",
+			"Орлов", "Андреев", "Макаров", "Никитин", "Захаров", "Зайцев", "Соловьев", "Борисов", "Яковлев", "Григорьев",
+			"Романов", "Воробьев", "Сергеев", "Кузьмин", "Фролов", "Александров", "Дмитриев", "Королев", "Гусев", "Киселев",
+			"Ильин", "Максимов", "Поляков", "Сорокин", "Виноградов", "Ковалев", "Белов", "Медведев", "Антонов", "Тарасов",
+			"Жуков", "Баранов", "Филиппов", "Комаров", "Давыдов", "Беляев", "Герасимов", "Богданов", "Осипов", "Сидоров",
+			"Матвеев", "Титов", "Марков", "Миронов", "Крылов", "Куликов", "Карпов", "Власов", "Мельников", "Денисов",
+			"Гаврилов", "Тихонов", "Казаков", "Афанасьев", "Данилов", "Савельев", "Тимофеев", "Фомин", "Чернов", "Абрамов",
+			"Маслов", "Ефимов", "Горбунов", "Ермаков", "Дорофеев", "Беликов", "Суханов", "Голубев", "Калинин", "Ковалевский",
+		},
+		citiesByTimezone: map[string][]string{
+			"Europe/Moscow":      {"Москва", "Санкт-Петербург", "Казань", "Нижний Новгород", "Воронеж", "Ярославль", "Ростов-на-Дону"},
+			"Europe/Samara":      {"Самара", "Ижевск", "Ульяновск"},
+			"Asia/Yekaterinburg": {"Екатеринбург", "Челябинск", "Пермь", "Уфа", "Тюмень"},
+			"Asia/Omsk":          {"Омск"},
+			"Asia/Novosibirsk":   {"Новосибирск", "Барнаул", "Томск"},
+			"Asia/Krasnoyarsk":   {"Красноярск", "Кемерово", "Новокузнецк"},
+			"Asia/Irkutsk":       {"Иркутск", "Улан-Удэ"},
+			"Asia/Vladivostok":   {"Владивосток", "Хабаровск"},
+			"Europe/Kaliningrad": {"Калининград"},
+			"Europe/Volgograd":   {"Волгоград"},
+		},
+		interests: []string{
+			"путешествия", "музыка", "фотография", "спорт", "кино", "книги", "кулинария",
+			"программирование", "бег", "йога", "рыбалка", "настольные игры", "велосипед",
+		},
+		bioTemplates: []string{
+			"%[1]s, люблю %[2]s",
+			"Живу в городе %[1]s, %[2]s — моё всё",
+			"Люблю %[2]s и хорошее настроение",
+			"Из города %[1]s. Увлекаюсь: %[2]s",
+		},
+	},
+	models.LocaleEN: {
+		country:         "US",
+		defaultTimezone: "America/New_York",
+		maleFirstNames: []string{
+			"James", "John", "Robert", "Michael", "William", "David", "Joseph", "Daniel", "Matthew", "Andrew",
+			"Ryan", "Tyler", "Nathan", "Brandon", "Kevin", "Jason", "Eric", "Adam", "Kyle", "Justin",
+		},
+		femaleFirstNames: []string{
+			"Mary", "Jennifer", "Linda", "Emily", "Jessica", "Sarah", "Ashley", "Megan", "Hannah", "Olivia",
+			"Emma", "Lauren", "Rachel", "Samantha", "Amanda", "Nicole", "Stephanie", "Rebecca", "Katherine", "Laura",
+		},
+		lastNames: []string{
+			"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez",
+			"Wilson", "Anderson", "Taylor", "Thomas", "Moore", "Jackson", "Martin", "Lee", "Thompson", "White",
+			"Harris", "Clark", "Lewis", "Robinson", "Walker", "Young", "Allen", "King", "Wright", "Scott",
+			"Green", "Baker", "Adams", "Nelson", "Hill", "Campbell", "Mitchell", "Roberts", "Carter", "Phillips",
+		},
+		citiesByTimezone: map[string][]string{
+			"America/New_York":    {"New York", "Boston", "Philadelphia", "Atlanta", "Miami"},
+			"America/Chicago":     {"Chicago", "Houston", "Dallas", "Austin", "Minneapolis"},
+			"America/Denver":      {"Denver", "Salt Lake City"},
+			"America/Phoenix":     {"Phoenix"},
+			"America/Los_Angeles": {"Los Angeles", "San Francisco", "Seattle", "Portland", "San Diego"},
+		},
+		interests: []string{
+			"travel", "music", "photography", "sports", "movies", "books", "cooking",
+			"coding", "running", "yoga", "fishing", "board games", "cycling",
+		},
+		bioTemplates: []string{
+			"%[1]s based, into %[2]s",
+			"Living in %[1]s, %[2]s all day",
+			"Into %[2]s and good vibes",
+			"From %[1]s. Big fan of %[2]s",
+		},
+	},
+}
+
+// translit maps Cyrillic letters onto the Latin spelling used in usernames and email prefixes
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type MetricsCollector struct {
+	generated    *prometheus.CounterVec
+	collisions   *prometheus.CounterVec
+	released     prometheus.Counter
+	reservations *prometheus.CounterVec
+}
+
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{
+		generated: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "persona_generated_total",
+				Help: "Total number of persona generation requests by result",
+			},
+			[]string{"locale", "result"},
+		),
+		collisions: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "persona_collisions_total",
+				Help: "Total number of generated personas rejected as duplicates",
+			},
+			[]string{"locale"},
+		),
+		released: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "persona_released_total",
+				Help: "Total number of released personas",
+			},
+		),
+		reservations: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "persona_reservations_total",
+				Help: "Total number of persona reservation operations by result",
+			},
+			[]string{"platform", "result"},
+		),
+	}
+}
+
+func (m *MetricsCollector) IncrementGenerated(locale, result string) {
+	m.generated.WithLabelValues(locale, result).Inc()
+}
+
+func (m *MetricsCollector) IncrementCollisions(locale string) {
+	m.collisions.WithLabelValues(locale).Inc()
+}
+
+func (m *MetricsCollector) IncrementReleased() {
+	m.released.Inc()
+}
+
+func (m *MetricsCollector) IncrementReservations(platform, result string) {
+	m.reservations.WithLabelValues(platform, result).Inc()
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/persona-service/internal/models"
+	"github.com/grigta/conveer/services/persona-service/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PersonaService struct {
+	repo      *repository.PersonaRepository
+	generator *PersonaGenerator
+	config    *models.PersonaConfig
+	metrics   *MetricsCollector
+	logger    logger.Logger
+}
+
+func NewPersonaService(
+	repo *repository.PersonaRepository,
+	generator *PersonaGenerator,
+	config *models.PersonaConfig,
+	metrics *MetricsCollector,
+	logger logger.Logger,
+) *PersonaService {
+	return &PersonaService{
+		repo:      repo,
+		generator: generator,
+		config:    config,
+		metrics:   metrics,
+		logger:    logger,
+	}
+}
+
+// GeneratePersona creates and stores a unique persona, optionally assigning it to an account right away
+func (s *PersonaService) GeneratePersona(ctx context.Context, req *models.GenerateRequest) (*models.Persona, error) {
+	if req.Platform != "" && !models.ValidPlatform(req.Platform) {
+		return nil, models.ErrUnsupportedPlatform
+	}
+	if err := s.applyDefaults(req); err != nil {
+		return nil, err
+	}
+
+	return s.generate(ctx, req, nil)
+}
+
+func (s *PersonaService) applyDefaults(req *models.GenerateRequest) error {
+	if req.Locale == "" {
+		req.Locale = s.config.DefaultLocale
+	}
+	if req.MinAge == 0 {
+		req.MinAge = s.config.MinAge
+	}
+	if req.MaxAge == 0 {
+		req.MaxAge = s.config.MaxAge
+	}
+	if req.MinAge < 14 || req.MinAge > req.MaxAge {
+		return models.ErrInvalidAgeRange
+	}
+	return nil
+}
+
+// generate stores a new unique persona, holding it with reservation if one is given
+func (s *PersonaService) generate(ctx context.Context, req *models.GenerateRequest, reservation *models.PersonaReservation) (*models.Persona, error) {
+	var persona *models.Persona
+	for attempt := 0; attempt < s.config.MaxAttempts; attempt++ {
+		candidate, err := s.generator.Generate(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Platform != "" && req.AccountID != "" {
+			candidate.Assignments = []models.PersonaAssignment{{
+				Platform:   req.Platform,
+				AccountID:  req.AccountID,
+				AssignedAt: time.Now(),
+			}}
+		}
+		if reservation != nil {
+			candidate.Reservations = []models.PersonaReservation{*reservation}
+		}
+
+		err = s.repo.Create(ctx, candidate)
+		if err == nil {
+			persona = candidate
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		s.metrics.IncrementCollisions(req.Locale)
+		s.logger.Debugf("Persona collision on attempt %d, regenerating", attempt+1)
+	}
+
+	if persona == nil {
+		s.metrics.IncrementGenerated(req.Locale, "exhausted")
+		return nil, models.ErrUniquenessExhausted
+	}
+
+	s.metrics.IncrementGenerated(req.Locale, "success")
+	s.logger.Infof("Generated persona %s (%s, %s)", persona.ID.Hex(), persona.Locale, persona.Gender)
+
+	return persona, nil
+}
+
+func (s *PersonaService) GetPersona(ctx context.Context, id string) (*models.Persona, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrPersonaNotFound
+	}
+
+	persona, err := s.repo.FindByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if persona == nil {
+		return nil, models.ErrPersonaNotFound
+	}
+	return persona, nil
+}
+
+// AssignPersona links an existing persona to an account so every platform presents the same identity.
+// Each persona can back at most one account per platform.
+func (s *PersonaService) AssignPersona(ctx context.Context, id, platform, accountID string) (*models.Persona, error) {
+	if !models.ValidPlatform(platform) {
+		return nil, models.ErrUnsupportedPlatform
+	}
+
+	persona, err := s.GetPersona(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing := persona.AssignmentFor(platform); existing != nil {
+		if existing.AccountID == accountID {
+			return persona, nil
+		}
+		return nil, models.ErrAlreadyAssigned
+	}
+
+	assignment := models.PersonaAssignment{
+		Platform:   platform,
+		AccountID:  accountID,
+		AssignedAt: time.Now(),
+	}
+	assigned, err := s.repo.AddAssignment(ctx, persona.ID, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		// Lost a race with another assignment for the same platform
+		return nil, models.ErrAlreadyAssigned
+	}
+
+	persona.Assignments = append(persona.Assignments, assignment)
+	return persona, nil
+}
+
+// ReleasePersona deletes the persona so its name and logins can be generated again
+func (s *PersonaService) ReleasePersona(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.ErrPersonaNotFound
+	}
+
+	if err := s.repo.Delete(ctx, objectID); err != nil {
+		return err
+	}
+
+	s.metrics.IncrementReleased()
+	return nil
+}
+
+// AllocatePersona reserves a persona for a registration on req.Platform. A pinned persona is
+// reserved as is; otherwise a persona already registered elsewhere is reused when allowed,
+// and a new one is generated as the last resort. The reservation must be confirmed with the
+// account id once the account exists, or cancelled if registration fails; otherwise it expires.
+func (s *PersonaService) AllocatePersona(ctx context.Context, req *models.AllocateRequest) (*models.Persona, *models.PersonaReservation, error) {
+	if !models.ValidPlatform(req.Platform) {
+		return nil, nil, models.ErrUnsupportedPlatform
+	}
+
+	now := time.Now()
+	reservation := models.PersonaReservation{
+		ID:         primitive.NewObjectID().Hex(),
+		Platform:   req.Platform,
+		ReservedAt: now,
+		ExpiresAt:  now.Add(s.config.ReservationTTL),
+	}
+
+	if req.PersonaID != "" {
+		persona, err := s.reservePinned(ctx, req.PersonaID, reservation)
+		if err != nil {
+			s.metrics.IncrementReservations(req.Platform, "conflict")
+			return nil, nil, err
+		}
+		s.metrics.IncrementReservations(req.Platform, "pinned")
+		return persona, &reservation, nil
+	}
+
+	generateReq := &models.GenerateRequest{
+		Platform: req.Platform,
+		Gender:   req.Gender,
+		Locale:   req.Locale,
+		Timezone: req.Timezone,
+		MinAge:   req.MinAge,
+		MaxAge:   req.MaxAge,
+	}
+	if err := s.applyDefaults(generateReq); err != nil {
+		return nil, nil, err
+	}
+
+	if req.ReuseExisting {
+		filter := models.PersonaFilter{
+			Gender:     generateReq.Gender,
+			Locale:     generateReq.Locale,
+			Timezone:   generateReq.Timezone,
+			BornAfter:  now.AddDate(-generateReq.MaxAge-1, 0, 1),
+			BornBefore: now.AddDate(-generateReq.MinAge, 0, 0),
+		}
+		persona, err := s.repo.ReserveAvailable(ctx, filter, reservation)
+		if err != nil {
+			return nil, nil, err
+		}
+		if persona != nil {
+			s.metrics.IncrementReservations(req.Platform, "reused")
+			s.logger.Infof("Reserved existing persona %s for %s", persona.ID.Hex(), req.Platform)
+			return persona, &reservation, nil
+		}
+	}
+
+	persona, err := s.generate(ctx, generateReq, &reservation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.metrics.IncrementReservations(req.Platform, "generated")
+	return persona, &reservation, nil
+}
+
+func (s *PersonaService) reservePinned(ctx context.Context, id string, reservation models.PersonaReservation) (*models.Persona, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrPersonaNotFound
+	}
+
+	persona, err := s.repo.ReserveByID(ctx, objectID, reservation)
+	if err != nil || persona != nil {
+		return persona, err
+	}
+
+	// Tell apart a missing persona from a taken platform slot
+	existing, err := s.GetPersona(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.AssignmentFor(reservation.Platform) != nil {
+		return nil, models.ErrAlreadyAssigned
+	}
+	return nil, models.ErrReserved
+}
+
+// ConfirmReservation assigns the reserved persona to the registered account.
+// Confirming again for the same account is a no-op.
+func (s *PersonaService) ConfirmReservation(ctx context.Context, id, reservationID, accountID string) (*models.Persona, error) {
+	persona, err := s.GetPersona(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reservation := persona.ReservationByID(reservationID, now)
+	if reservation == nil {
+		for _, assignment := range persona.Assignments {
+			if assignment.AccountID == accountID {
+				return persona, nil
+			}
+		}
+		s.metrics.IncrementReservations(reservationPlatform(persona, reservationID), "expired")
+		return nil, models.ErrReservationNotFound
+	}
+
+	assignment := models.PersonaAssignment{
+		Platform:   reservation.Platform,
+		AccountID:  accountID,
+		AssignedAt: now,
+	}
+	confirmed, err := s.repo.ConfirmReservation(ctx, persona.ID, reservationID, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if confirmed == nil {
+		// Expired between the read and the update, or the slot was assigned directly
+		if persona.AssignmentFor(reservation.Platform) != nil {
+			return nil, models.ErrAlreadyAssigned
+		}
+		return nil, models.ErrReservationNotFound
+	}
+
+	s.metrics.IncrementReservations(reservation.Platform, "confirmed")
+	return confirmed, nil
+}
+
+// CancelReservation frees the platform slot of a registration that did not produce an account
+func (s *PersonaService) CancelReservation(ctx context.Context, id, reservationID string) error {
+	persona, err := s.GetPersona(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	cancelled, err := s.repo.CancelReservation(ctx, persona.ID, reservationID)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return models.ErrReservationNotFound
+	}
+
+	s.metrics.IncrementReservations(reservationPlatform(persona, reservationID), "cancelled")
+	return nil
+}
+
+// reservationPlatform finds the platform of a reservation, including an expired one not purged yet
+func reservationPlatform(persona *models.Persona, reservationID string) string {
+	for _, reservation := range persona.Reservations {
+		if reservation.ID == reservationID {
+			return reservation.Platform
+		}
+	}
+	return "unknown"
+}
+
+// RunReservationCleanup purges expired reservations until ctx is done.
+// Expired reservations are already ignored by allocation, this only keeps documents small.
+func (s *PersonaService) RunReservationCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.repo.PurgeExpiredReservations(ctx, time.Now())
+			if err != nil {
+				s.logger.Errorf("Failed to purge expired reservations: %v", err)
+				continue
+			}
+			if purged > 0 {
+				s.logger.Infof("Purged expired reservations from %d personas", purged)
+			}
+		}
+	}
+}
+
+// FormatPersona renders the persona for platform
+func (s *PersonaService) FormatPersona(persona *models.Persona, platform string) (*models.PlatformProfile, error) {
+	profile, err := FormatForPlatform(persona, platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format persona: %w", err)
+	}
+	return profile, nil
+}
@@ -0,0 +1,933 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.2
+// source: services/persona-service/proto/persona.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GeneratePersonaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Gender        string                 `protobuf:"bytes,2,opt,name=gender,proto3" json:"gender,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	Timezone      string                 `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	MinAge        int32                  `protobuf:"varint,5,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxAge        int32                  `protobuf:"varint,6,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	AccountId     string                 `protobuf:"bytes,7,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratePersonaRequest) Reset() {
+	*x = GeneratePersonaRequest{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratePersonaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePersonaRequest) ProtoMessage() {}
+
+func (x *GeneratePersonaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePersonaRequest.ProtoReflect.Descriptor instead.
+func (*GeneratePersonaRequest) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GeneratePersonaRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *GeneratePersonaRequest) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *GeneratePersonaRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *GeneratePersonaRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *GeneratePersonaRequest) GetMinAge() int32 {
+	if x != nil {
+		return x.MinAge
+	}
+	return 0
+}
+
+func (x *GeneratePersonaRequest) GetMaxAge() int32 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *GeneratePersonaRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type GetPersonaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PersonaId     string                 `protobuf:"bytes,1,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPersonaRequest) Reset() {
+	*x = GetPersonaRequest{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPersonaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPersonaRequest) ProtoMessage() {}
+
+func (x *GetPersonaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPersonaRequest.ProtoReflect.Descriptor instead.
+func (*GetPersonaRequest) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPersonaRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+func (x *GetPersonaRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type AssignPersonaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PersonaId     string                 `protobuf:"bytes,1,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignPersonaRequest) Reset() {
+	*x = AssignPersonaRequest{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignPersonaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignPersonaRequest) ProtoMessage() {}
+
+func (x *AssignPersonaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignPersonaRequest.ProtoReflect.Descriptor instead.
+func (*AssignPersonaRequest) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AssignPersonaRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+func (x *AssignPersonaRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *AssignPersonaRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ReleasePersonaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PersonaId     string                 `protobuf:"bytes,1,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleasePersonaRequest) Reset() {
+	*x = ReleasePersonaRequest{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleasePersonaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleasePersonaRequest) ProtoMessage() {}
+
+func (x *ReleasePersonaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleasePersonaRequest.ProtoReflect.Descriptor instead.
+func (*ReleasePersonaRequest) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReleasePersonaRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+type ReleasePersonaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleasePersonaResponse) Reset() {
+	*x = ReleasePersonaResponse{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleasePersonaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleasePersonaResponse) ProtoMessage() {}
+
+func (x *ReleasePersonaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleasePersonaResponse.ProtoReflect.Descriptor instead.
+func (*ReleasePersonaResponse) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReleasePersonaResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AllocatePersonaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	PersonaId     string                 `protobuf:"bytes,2,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	Gender        string                 `protobuf:"bytes,3,opt,name=gender,proto3" json:"gender,omitempty"`
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	MinAge        int32                  `protobuf:"varint,6,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxAge        int32                  `protobuf:"varint,7,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	ReuseExisting bool                   `protobuf:"varint,8,opt,name=reuse_existing,json=reuseExisting,proto3" json:"reuse_existing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocatePersonaRequest) Reset() {
+	*x = AllocatePersonaRequest{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocatePersonaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocatePersonaRequest) ProtoMessage() {}
+
+func (x *AllocatePersonaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocatePersonaRequest.ProtoReflect.Descriptor instead.
+func (*AllocatePersonaRequest) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AllocatePersonaRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *AllocatePersonaRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+func (x *AllocatePersonaRequest) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *AllocatePersonaRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *AllocatePersonaRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *AllocatePersonaRequest) GetMinAge() int32 {
+	if x != nil {
+		return x.MinAge
+	}
+	return 0
+}
+
+func (x *AllocatePersonaRequest) GetMaxAge() int32 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *AllocatePersonaRequest) GetReuseExisting() bool {
+	if x != nil {
+		return x.ReuseExisting
+	}
+	return false
+}
+
+type ConfirmReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PersonaId     string                 `protobuf:"bytes,1,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationRequest) Reset() {
+	*x = ConfirmReservationRequest{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationRequest) ProtoMessage() {}
+
+func (x *ConfirmReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationRequest) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConfirmReservationRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+func (x *ConfirmReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ConfirmReservationRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type CancelReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PersonaId     string                 `protobuf:"bytes,1,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReservationRequest) Reset() {
+	*x = CancelReservationRequest{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationRequest) ProtoMessage() {}
+
+func (x *CancelReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationRequest.ProtoReflect.Descriptor instead.
+func (*CancelReservationRequest) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelReservationRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+func (x *CancelReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type CancelReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReservationResponse) Reset() {
+	*x = CancelReservationResponse{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationResponse) ProtoMessage() {}
+
+func (x *CancelReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationResponse.ProtoReflect.Descriptor instead.
+func (*CancelReservationResponse) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type Persona struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Gender        string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
+	Locale        string                 `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	BirthDate     string                 `protobuf:"bytes,6,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
+	City          string                 `protobuf:"bytes,7,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	Avatar        string                 `protobuf:"bytes,9,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Bio           string                 `protobuf:"bytes,10,opt,name=bio,proto3" json:"bio,omitempty"`
+	EmailPrefix   string                 `protobuf:"bytes,11,opt,name=email_prefix,json=emailPrefix,proto3" json:"email_prefix,omitempty"`
+	Username      string                 `protobuf:"bytes,12,opt,name=username,proto3" json:"username,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,13,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Platform      string                 `protobuf:"bytes,14,opt,name=platform,proto3" json:"platform,omitempty"`
+	AccountId     string                 `protobuf:"bytes,15,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Timezone      string                 `protobuf:"bytes,17,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Interests     []string               `protobuf:"bytes,18,rep,name=interests,proto3" json:"interests,omitempty"`
+	Avatars       []string               `protobuf:"bytes,19,rep,name=avatars,proto3" json:"avatars,omitempty"`
+	ReservationId string                 `protobuf:"bytes,20,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	ReservedUntil int64                  `protobuf:"varint,21,opt,name=reserved_until,json=reservedUntil,proto3" json:"reserved_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Persona) Reset() {
+	*x = Persona{}
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Persona) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Persona) ProtoMessage() {}
+
+func (x *Persona) ProtoReflect() protoreflect.Message {
+	mi := &file_services_persona_service_proto_persona_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Persona.ProtoReflect.Descriptor instead.
+func (*Persona) Descriptor() ([]byte, []int) {
+	return file_services_persona_service_proto_persona_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Persona) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Persona) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Persona) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Persona) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *Persona) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Persona) GetBirthDate() string {
+	if x != nil {
+		return x.BirthDate
+	}
+	return ""
+}
+
+func (x *Persona) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Persona) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Persona) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *Persona) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *Persona) GetEmailPrefix() string {
+	if x != nil {
+		return x.EmailPrefix
+	}
+	return ""
+}
+
+func (x *Persona) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Persona) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Persona) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Persona) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Persona) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Persona) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Persona) GetInterests() []string {
+	if x != nil {
+		return x.Interests
+	}
+	return nil
+}
+
+func (x *Persona) GetAvatars() []string {
+	if x != nil {
+		return x.Avatars
+	}
+	return nil
+}
+
+func (x *Persona) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *Persona) GetReservedUntil() int64 {
+	if x != nil {
+		return x.ReservedUntil
+	}
+	return 0
+}
+
+var File_services_persona_service_proto_persona_proto protoreflect.FileDescriptor
+
+const file_services_persona_service_proto_persona_proto_rawDesc = "" +
+	"\n" +
+	",services/persona-service/proto/persona.proto\x12\apersona\"\xd1\x01\n" +
+	"\x16GeneratePersonaRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x16\n" +
+	"\x06gender\x18\x02 \x01(\tR\x06gender\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12\x17\n" +
+	"\amin_age\x18\x05 \x01(\x05R\x06minAge\x12\x17\n" +
+	"\amax_age\x18\x06 \x01(\x05R\x06maxAge\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\a \x01(\tR\taccountId\"N\n" +
+	"\x11GetPersonaRequest\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x01 \x01(\tR\tpersonaId\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\"p\n" +
+	"\x14AssignPersonaRequest\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x01 \x01(\tR\tpersonaId\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\"6\n" +
+	"\x15ReleasePersonaRequest\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x01 \x01(\tR\tpersonaId\"2\n" +
+	"\x16ReleasePersonaResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xf8\x01\n" +
+	"\x16AllocatePersonaRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x02 \x01(\tR\tpersonaId\x12\x16\n" +
+	"\x06gender\x18\x03 \x01(\tR\x06gender\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12\x17\n" +
+	"\amin_age\x18\x06 \x01(\x05R\x06minAge\x12\x17\n" +
+	"\amax_age\x18\a \x01(\x05R\x06maxAge\x12%\n" +
+	"\x0ereuse_existing\x18\b \x01(\bR\rreuseExisting\"\x80\x01\n" +
+	"\x19ConfirmReservationRequest\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x01 \x01(\tR\tpersonaId\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\"`\n" +
+	"\x18CancelReservationRequest\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x01 \x01(\tR\tpersonaId\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\"5\n" +
+	"\x19CancelReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xda\x04\n" +
+	"\aPersona\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x16\n" +
+	"\x06gender\x18\x04 \x01(\tR\x06gender\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\x12\x1d\n" +
+	"\n" +
+	"birth_date\x18\x06 \x01(\tR\tbirthDate\x12\x12\n" +
+	"\x04city\x18\a \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\b \x01(\tR\acountry\x12\x16\n" +
+	"\x06avatar\x18\t \x01(\tR\x06avatar\x12\x10\n" +
+	"\x03bio\x18\n" +
+	" \x01(\tR\x03bio\x12!\n" +
+	"\femail_prefix\x18\v \x01(\tR\vemailPrefix\x12\x1a\n" +
+	"\busername\x18\f \x01(\tR\busername\x12!\n" +
+	"\fdisplay_name\x18\r \x01(\tR\vdisplayName\x12\x1a\n" +
+	"\bplatform\x18\x0e \x01(\tR\bplatform\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x0f \x01(\tR\taccountId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\x03R\tcreatedAt\x12\x1a\n" +
+	"\btimezone\x18\x11 \x01(\tR\btimezone\x12\x1c\n" +
+	"\tinterests\x18\x12 \x03(\tR\tinterests\x12\x18\n" +
+	"\aavatars\x18\x13 \x03(\tR\aavatars\x12%\n" +
+	"\x0ereservation_id\x18\x14 \x01(\tR\rreservationId\x12%\n" +
+	"\x0ereserved_until\x18\x15 \x01(\x03R\rreservedUntil2\x95\x04\n" +
+	"\x0ePersonaService\x12D\n" +
+	"\x0fGeneratePersona\x12\x1f.persona.GeneratePersonaRequest\x1a\x10.persona.Persona\x12:\n" +
+	"\n" +
+	"GetPersona\x12\x1a.persona.GetPersonaRequest\x1a\x10.persona.Persona\x12@\n" +
+	"\rAssignPersona\x12\x1d.persona.AssignPersonaRequest\x1a\x10.persona.Persona\x12Q\n" +
+	"\x0eReleasePersona\x12\x1e.persona.ReleasePersonaRequest\x1a\x1f.persona.ReleasePersonaResponse\x12D\n" +
+	"\x0fAllocatePersona\x12\x1f.persona.AllocatePersonaRequest\x1a\x10.persona.Persona\x12J\n" +
+	"\x12ConfirmReservation\x12\".persona.ConfirmReservationRequest\x1a\x10.persona.Persona\x12Z\n" +
+	"\x11CancelReservation\x12!.persona.CancelReservationRequest\x1a\".persona.CancelReservationResponseB:Z8github.com/grigta/conveer/services/persona-service/protob\x06proto3"
+
+var (
+	file_services_persona_service_proto_persona_proto_rawDescOnce sync.Once
+	file_services_persona_service_proto_persona_proto_rawDescData []byte
+)
+
+func file_services_persona_service_proto_persona_proto_rawDescGZIP() []byte {
+	file_services_persona_service_proto_persona_proto_rawDescOnce.Do(func() {
+		file_services_persona_service_proto_persona_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_services_persona_service_proto_persona_proto_rawDesc), len(file_services_persona_service_proto_persona_proto_rawDesc)))
+	})
+	return file_services_persona_service_proto_persona_proto_rawDescData
+}
+
+var file_services_persona_service_proto_persona_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_services_persona_service_proto_persona_proto_goTypes = []any{
+	(*GeneratePersonaRequest)(nil),    // 0: persona.GeneratePersonaRequest
+	(*GetPersonaRequest)(nil),         // 1: persona.GetPersonaRequest
+	(*AssignPersonaRequest)(nil),      // 2: persona.AssignPersonaRequest
+	(*ReleasePersonaRequest)(nil),     // 3: persona.ReleasePersonaRequest
+	(*ReleasePersonaResponse)(nil),    // 4: persona.ReleasePersonaResponse
+	(*AllocatePersonaRequest)(nil),    // 5: persona.AllocatePersonaRequest
+	(*ConfirmReservationRequest)(nil), // 6: persona.ConfirmReservationRequest
+	(*CancelReservationRequest)(nil),  // 7: persona.CancelReservationRequest
+	(*CancelReservationResponse)(nil), // 8: persona.CancelReservationResponse
+	(*Persona)(nil),                   // 9: persona.Persona
+}
+var file_services_persona_service_proto_persona_proto_depIdxs = []int32{
+	0, // 0: persona.PersonaService.GeneratePersona:input_type -> persona.GeneratePersonaRequest
+	1, // 1: persona.PersonaService.GetPersona:input_type -> persona.GetPersonaRequest
+	2, // 2: persona.PersonaService.AssignPersona:input_type -> persona.AssignPersonaRequest
+	3, // 3: persona.PersonaService.ReleasePersona:input_type -> persona.ReleasePersonaRequest
+	5, // 4: persona.PersonaService.AllocatePersona:input_type -> persona.AllocatePersonaRequest
+	6, // 5: persona.PersonaService.ConfirmReservation:input_type -> persona.ConfirmReservationRequest
+	7, // 6: persona.PersonaService.CancelReservation:input_type -> persona.CancelReservationRequest
+	9, // 7: persona.PersonaService.GeneratePersona:output_type -> persona.Persona
+	9, // 8: persona.PersonaService.GetPersona:output_type -> persona.Persona
+	9, // 9: persona.PersonaService.AssignPersona:output_type -> persona.Persona
+	4, // 10: persona.PersonaService.ReleasePersona:output_type -> persona.ReleasePersonaResponse
+	9, // 11: persona.PersonaService.AllocatePersona:output_type -> persona.Persona
+	9, // 12: persona.PersonaService.ConfirmReservation:output_type -> persona.Persona
+	8, // 13: persona.PersonaService.CancelReservation:output_type -> persona.CancelReservationResponse
+	7, // [7:14] is the sub-list for method output_type
+	0, // [0:7] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_services_persona_service_proto_persona_proto_init() }
+func file_services_persona_service_proto_persona_proto_init() {
+	if File_services_persona_service_proto_persona_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_persona_service_proto_persona_proto_rawDesc), len(file_services_persona_service_proto_persona_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_services_persona_service_proto_persona_proto_goTypes,
+		DependencyIndexes: file_services_persona_service_proto_persona_proto_depIdxs,
+		MessageInfos:      file_services_persona_service_proto_persona_proto_msgTypes,
+	}.Build()
+	File_services_persona_service_proto_persona_proto = out.File
+	file_services_persona_service_proto_persona_proto_goTypes = nil
+	file_services_persona_service_proto_persona_proto_depIdxs = nil
+}
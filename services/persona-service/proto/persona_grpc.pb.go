@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.2
+// source: services/persona-service/proto/persona.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PersonaService_GeneratePersona_FullMethodName    = "/persona.PersonaService/GeneratePersona"
+	PersonaService_GetPersona_FullMethodName         = "/persona.PersonaService/GetPersona"
+	PersonaService_AssignPersona_FullMethodName      = "/persona.PersonaService/AssignPersona"
+	PersonaService_ReleasePersona_FullMethodName     = "/persona.PersonaService/ReleasePersona"
+	PersonaService_AllocatePersona_FullMethodName    = "/persona.PersonaService/AllocatePersona"
+	PersonaService_ConfirmReservation_FullMethodName = "/persona.PersonaService/ConfirmReservation"
+	PersonaService_CancelReservation_FullMethodName  = "/persona.PersonaService/CancelReservation"
+)
+
+// PersonaServiceClient is the client API for PersonaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PersonaServiceClient interface {
+	GeneratePersona(ctx context.Context, in *GeneratePersonaRequest, opts ...grpc.CallOption) (*Persona, error)
+	GetPersona(ctx context.Context, in *GetPersonaRequest, opts ...grpc.CallOption) (*Persona, error)
+	AssignPersona(ctx context.Context, in *AssignPersonaRequest, opts ...grpc.CallOption) (*Persona, error)
+	ReleasePersona(ctx context.Context, in *ReleasePersonaRequest, opts ...grpc.CallOption) (*ReleasePersonaResponse, error)
+	AllocatePersona(ctx context.Context, in *AllocatePersonaRequest, opts ...grpc.CallOption) (*Persona, error)
+	ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*Persona, error)
+	CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationResponse, error)
+}
+
+type personaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPersonaServiceClient(cc grpc.ClientConnInterface) PersonaServiceClient {
+	return &personaServiceClient{cc}
+}
+
+func (c *personaServiceClient) GeneratePersona(ctx context.Context, in *GeneratePersonaRequest, opts ...grpc.CallOption) (*Persona, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Persona)
+	err := c.cc.Invoke(ctx, PersonaService_GeneratePersona_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personaServiceClient) GetPersona(ctx context.Context, in *GetPersonaRequest, opts ...grpc.CallOption) (*Persona, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Persona)
+	err := c.cc.Invoke(ctx, PersonaService_GetPersona_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personaServiceClient) AssignPersona(ctx context.Context, in *AssignPersonaRequest, opts ...grpc.CallOption) (*Persona, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Persona)
+	err := c.cc.Invoke(ctx, PersonaService_AssignPersona_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personaServiceClient) ReleasePersona(ctx context.Context, in *ReleasePersonaRequest, opts ...grpc.CallOption) (*ReleasePersonaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleasePersonaResponse)
+	err := c.cc.Invoke(ctx, PersonaService_ReleasePersona_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personaServiceClient) AllocatePersona(ctx context.Context, in *AllocatePersonaRequest, opts ...grpc.CallOption) (*Persona, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Persona)
+	err := c.cc.Invoke(ctx, PersonaService_AllocatePersona_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personaServiceClient) ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*Persona, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Persona)
+	err := c.cc.Invoke(ctx, PersonaService_ConfirmReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personaServiceClient) CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelReservationResponse)
+	err := c.cc.Invoke(ctx, PersonaService_CancelReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PersonaServiceServer is the server API for PersonaService service.
+// All implementations must embed UnimplementedPersonaServiceServer
+// for forward compatibility.
+type PersonaServiceServer interface {
+	GeneratePersona(context.Context, *GeneratePersonaRequest) (*Persona, error)
+	GetPersona(context.Context, *GetPersonaRequest) (*Persona, error)
+	AssignPersona(context.Context, *AssignPersonaRequest) (*Persona, error)
+	ReleasePersona(context.Context, *ReleasePersonaRequest) (*ReleasePersonaResponse, error)
+	AllocatePersona(context.Context, *AllocatePersonaRequest) (*Persona, error)
+	ConfirmReservation(context.Context, *ConfirmReservationRequest) (*Persona, error)
+	CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationResponse, error)
+	mustEmbedUnimplementedPersonaServiceServer()
+}
+
+// UnimplementedPersonaServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPersonaServiceServer struct{}
+
+func (UnimplementedPersonaServiceServer) GeneratePersona(context.Context, *GeneratePersonaRequest) (*Persona, error) {
+	return nil, status.Error(codes.Unimplemented, "method GeneratePersona not implemented")
+}
+func (UnimplementedPersonaServiceServer) GetPersona(context.Context, *GetPersonaRequest) (*Persona, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPersona not implemented")
+}
+func (UnimplementedPersonaServiceServer) AssignPersona(context.Context, *AssignPersonaRequest) (*Persona, error) {
+	return nil, status.Error(codes.Unimplemented, "method AssignPersona not implemented")
+}
+func (UnimplementedPersonaServiceServer) ReleasePersona(context.Context, *ReleasePersonaRequest) (*ReleasePersonaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleasePersona not implemented")
+}
+func (UnimplementedPersonaServiceServer) AllocatePersona(context.Context, *AllocatePersonaRequest) (*Persona, error) {
+	return nil, status.Error(codes.Unimplemented, "method AllocatePersona not implemented")
+}
+func (UnimplementedPersonaServiceServer) ConfirmReservation(context.Context, *ConfirmReservationRequest) (*Persona, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmReservation not implemented")
+}
+func (UnimplementedPersonaServiceServer) CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (UnimplementedPersonaServiceServer) mustEmbedUnimplementedPersonaServiceServer() {}
+func (UnimplementedPersonaServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePersonaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PersonaServiceServer will
+// result in compilation errors.
+type UnsafePersonaServiceServer interface {
+	mustEmbedUnimplementedPersonaServiceServer()
+}
+
+func RegisterPersonaServiceServer(s grpc.ServiceRegistrar, srv PersonaServiceServer) {
+	// If the following call panics, it indicates UnimplementedPersonaServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PersonaService_ServiceDesc, srv)
+}
+
+func _PersonaService_GeneratePersona_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeneratePersonaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonaServiceServer).GeneratePersona(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonaService_GeneratePersona_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonaServiceServer).GeneratePersona(ctx, req.(*GeneratePersonaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonaService_GetPersona_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPersonaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonaServiceServer).GetPersona(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonaService_GetPersona_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonaServiceServer).GetPersona(ctx, req.(*GetPersonaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonaService_AssignPersona_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignPersonaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonaServiceServer).AssignPersona(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonaService_AssignPersona_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonaServiceServer).AssignPersona(ctx, req.(*AssignPersonaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonaService_ReleasePersona_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleasePersonaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonaServiceServer).ReleasePersona(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonaService_ReleasePersona_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonaServiceServer).ReleasePersona(ctx, req.(*ReleasePersonaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonaService_AllocatePersona_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocatePersonaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonaServiceServer).AllocatePersona(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonaService_AllocatePersona_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonaServiceServer).AllocatePersona(ctx, req.(*AllocatePersonaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonaService_ConfirmReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonaServiceServer).ConfirmReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonaService_ConfirmReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonaServiceServer).ConfirmReservation(ctx, req.(*ConfirmReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonaService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonaServiceServer).CancelReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonaService_CancelReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonaServiceServer).CancelReservation(ctx, req.(*CancelReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PersonaService_ServiceDesc is the grpc.ServiceDesc for PersonaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PersonaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "persona.PersonaService",
+	HandlerType: (*PersonaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GeneratePersona",
+			Handler:    _PersonaService_GeneratePersona_Handler,
+		},
+		{
+			MethodName: "GetPersona",
+			Handler:    _PersonaService_GetPersona_Handler,
+		},
+		{
+			MethodName: "AssignPersona",
+			Handler:    _PersonaService_AssignPersona_Handler,
+		},
+		{
+			MethodName: "ReleasePersona",
+			Handler:    _PersonaService_ReleasePersona_Handler,
+		},
+		{
+			MethodName: "AllocatePersona",
+			Handler:    _PersonaService_AllocatePersona_Handler,
+		},
+		{
+			MethodName: "ConfirmReservation",
+			Handler:    _PersonaService_ConfirmReservation_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _PersonaService_CancelReservation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services/persona-service/proto/persona.proto",
+}
@@ -14,8 +14,11 @@ import (
 	"github.com/grigta/conveer/pkg/config"
 	"github.com/grigta/conveer/pkg/crypto"
 	"github.com/grigta/conveer/pkg/database"
+	"github.com/grigta/conveer/pkg/health"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
 	"github.com/grigta/conveer/pkg/middleware"
+	"github.com/grigta/conveer/pkg/serviceauth"
 	"github.com/grigta/conveer/services/proxy-service/internal/handlers"
 	"github.com/grigta/conveer/services/proxy-service/internal/repository"
 	"github.com/grigta/conveer/services/proxy-service/internal/service"
@@ -23,7 +26,6 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -33,16 +35,11 @@ func main() {
 	defer cancel()
 
 	cfg := config.LoadConfig()
-	log := logrus.New()
-	if lvl, err := logrus.ParseLevel(cfg.App.LogLevel); err == nil {
-		log.SetLevel(lvl)
-	}
-	log.SetOutput(os.Stdout)
-	log.SetFormatter(&logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano})
+	log := logger.NewFromConfig(logger.Config{Service: "proxy-service", Level: cfg.App.LogLevel})
 
 	encryptor, err := crypto.NewEncryptor(cfg.Encryption.Key)
 	if err != nil {
-		log.Fatal("Failed to create encryptor: ", err)
+		log.Fatal("Failed to create encryptor", "error", err)
 	}
 
 	mongoURI := cfg.Database.URI
@@ -54,9 +51,9 @@ func main() {
 		mongoDBName = cfg.Database.MongoDB.DBName
 	}
 
-	mongodb, err := database.NewMongoDB(mongoURI, mongoDBName, 10*time.Second)
+	mongodb, err := database.NewMongoDB(mongoURI, mongoDBName, 10*time.Second, cfg.Database.Options)
 	if err != nil {
-		log.Fatal("Failed to connect to MongoDB: ", err)
+		log.Fatal("Failed to connect to MongoDB", "error", err)
 	}
 	defer mongodb.Close()
 
@@ -73,7 +70,7 @@ func main() {
 
 	redis, err := cache.NewRedisCache(redisHost, redisPort, redisPassword, redisDB)
 	if err != nil {
-		log.Fatal("Failed to connect to Redis: ", err)
+		log.Fatal("Failed to connect to Redis", "error", err)
 	}
 	defer redis.Close()
 
@@ -84,12 +81,12 @@ func main() {
 
 	rabbitmq, err := messaging.NewRabbitMQ(rabbitURL)
 	if err != nil {
-		log.Fatal("Failed to connect to RabbitMQ: ", err)
+		log.Fatal("Failed to connect to RabbitMQ", "error", err)
 	}
 	defer rabbitmq.Close()
 
 	if err := setupRabbitMQ(rabbitmq, log); err != nil {
-		log.Fatal("Failed to setup RabbitMQ: ", err)
+		log.Fatal("Failed to setup RabbitMQ", "error", err)
 	}
 
 	proxyRepo := repository.NewProxyRepository(mongodb, encryptor, log)
@@ -110,10 +107,11 @@ func main() {
 
 	providerManager, err := service.NewProviderManager(providerConfigPath, log, encryptor)
 	if err != nil {
-		log.Fatal("Failed to create provider manager: ", err)
+		log.Fatal("Failed to create provider manager", "error", err)
 	}
 
 	healthChecker := service.NewHealthChecker(proxyRepo, rabbitmq, log, cfg)
+	healthChecker.SetFraudCache(redis)
 	rotationManager := service.NewRotationManager(proxyRepo, providerRepo, providerManager, rabbitmq, log, cfg)
 	proxyService := service.NewProxyService(
 		proxyRepo,
@@ -127,21 +125,43 @@ func main() {
 		cfg,
 	)
 
+	// Feeds WatchProxyEvents streams, RabbitMQ events are published as before
+	events := service.NewEventBus()
+	proxyService.SetEventBus(events)
+
 	proxyService.Start(ctx)
 	defer proxyService.Stop()
 
+	if cfg.Secrets.Enabled() {
+		secretsWatcher := setupSecretsWatcher(ctx, cfg, mongodb, providerManager, healthChecker, log)
+		defer secretsWatcher.Stop()
+	}
+
+	if configWatcher := setupConfigWatcher(ctx, cfg, providerConfigPath, providerManager, proxyService, log); configWatcher != nil {
+		defer configWatcher.Stop()
+	}
+
+	// Readiness gates for gRPC health and /health
+	serviceHealth := health.NewChecker("proxy-service")
+	serviceHealth.AddDetailed("mongodb", func(ctx context.Context) (interface{}, error) {
+		return mongodb.HealthCheck(ctx)
+	})
+	serviceHealth.Add("redis", health.PingCheck(redis))
+	serviceHealth.Add("rabbitmq", health.PingCheck(rabbitmq))
+	go serviceHealth.Run(ctx, 10*time.Second)
+
 	var wg sync.WaitGroup
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		startGRPCServer(proxyService, proxyRepo, log, cfg)
+		startGRPCServer(proxyService, proxyRepo, events, serviceHealth, log, cfg)
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		startHTTPServer(proxyService, proxyRepo, providerRepo, log, cfg)
+		startHTTPServer(proxyService, proxyRepo, providerRepo, serviceHealth, log, cfg)
 	}()
 
 	sigChan := make(chan os.Signal, 1)
@@ -169,7 +189,89 @@ func main() {
 	}
 }
 
-func setupRabbitMQ(rabbitmq *messaging.RabbitMQ, log *logrus.Logger) error {
+// setupSecretsWatcher re-initializes Mongo, provider adapters and the IPQS key when secrets rotate
+func setupSecretsWatcher(
+	ctx context.Context,
+	cfg *config.Config,
+	mongodb *database.MongoDB,
+	providerManager *service.ProviderManager,
+	healthChecker *service.HealthChecker,
+	log logger.Logger,
+) *config.SecretsWatcher {
+	watcher := config.NewSecretsWatcherFromConfig(cfg.Secrets)
+	// Provider configs reference secrets as ${VAR}
+	watcher.SetExportEnv(true)
+
+	if err := watcher.Load(ctx); err != nil {
+		log.WithError(err).Error("Failed to load secrets")
+	}
+
+	watcher.OnChange("mongodb", []string{"MONGO_URI"}, func(ctx context.Context, secrets map[string]string) error {
+		if secrets["MONGO_URI"] == "" {
+			return nil
+		}
+		return mongodb.Reconnect(secrets["MONGO_URI"])
+	})
+	watcher.OnChange("providers", nil, func(ctx context.Context, secrets map[string]string) error {
+		return providerManager.Reload()
+	})
+	watcher.OnChange("ipqs", []string{"IPQS_API_KEY"}, func(ctx context.Context, secrets map[string]string) error {
+		healthChecker.SetIPQSAPIKey(secrets["IPQS_API_KEY"])
+		return nil
+	})
+
+	watcher.Start(ctx)
+	log.Info("Secrets watcher started")
+
+	return watcher
+}
+
+// setupConfigWatcher rebuilds providers and resizes the proxy pool when the provider config changes
+func setupConfigWatcher(
+	ctx context.Context,
+	cfg *config.Config,
+	providerConfigPath string,
+	providerManager *service.ProviderManager,
+	proxyService *service.ProxyService,
+	log logger.Logger,
+) *config.ConfigWatcher {
+	source, err := config.NewConfigSource(cfg.ConfigWatch, "proxy/providers", providerConfigPath)
+	if err != nil {
+		log.WithError(err).Error("Config hot-reload disabled")
+		return nil
+	}
+
+	watcher := config.NewConfigWatcher(source)
+	if err := watcher.Load(ctx); err != nil {
+		log.WithError(err).Error("Failed to load provider config for hot-reload")
+	}
+
+	watcher.Subscribe("providers", []string{"providers"}, func(ctx context.Context, changes config.ChangeSet) error {
+		previousPoolSize := providerManager.MinPoolSize()
+		if err := providerManager.ApplyConfig(changes.Data); err != nil {
+			return err
+		}
+
+		poolSize := providerManager.MinPoolSize()
+		log.Infof("Provider config reloaded from %s, min pool size %d", changes.Source, poolSize)
+
+		if poolSize > previousPoolSize {
+			go func() {
+				if err := proxyService.RefreshProxyPool(ctx); err != nil {
+					log.WithError(err).Error("Failed to refresh proxy pool after config reload")
+				}
+			}()
+		}
+		return nil
+	})
+
+	watcher.Start(ctx)
+	log.Info("Config watcher started")
+
+	return watcher
+}
+
+func setupRabbitMQ(rabbitmq *messaging.RabbitMQ, log logger.Logger) error {
 	if err := rabbitmq.DeclareExchange("proxy.events", "topic", true, false); err != nil {
 		return fmt.Errorf("failed to declare events exchange: %w", err)
 	}
@@ -183,6 +285,7 @@ func setupRabbitMQ(rabbitmq *messaging.RabbitMQ, log *logrus.Logger) error {
 		"proxy.release",
 		"proxy.health_check",
 		"proxy.rotation",
+		"proxy.usage",
 	}
 
 	for _, queue := range queues {
@@ -199,7 +302,7 @@ func setupRabbitMQ(rabbitmq *messaging.RabbitMQ, log *logrus.Logger) error {
 	return nil
 }
 
-func startGRPCServer(proxyService *service.ProxyService, proxyRepo *repository.ProxyRepository, log *logrus.Logger, cfg *config.Config) {
+func startGRPCServer(proxyService *service.ProxyService, proxyRepo *repository.ProxyRepository, events *service.EventBus, serviceHealth *health.Checker, log logger.Logger, cfg *config.Config) {
 	port := 50057
 	if cfg.Services.ProxyServiceURL != "" {
 		// Parse port from URL if needed
@@ -207,32 +310,34 @@ func startGRPCServer(proxyService *service.ProxyService, proxyRepo *repository.P
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
-		log.Fatal("Failed to listen on gRPC port: ", err)
+		log.Fatal("Failed to listen on gRPC port", "error", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	grpcHandler := handlers.NewGRPCHandler(proxyService, proxyRepo, log)
+	serviceAuth := serviceauth.FromEnv("proxy-service")
+	grpcServer := grpc.NewServer(append(logger.ServerOptions(), serviceAuth.ServerOptions()...)...)
+	grpcHandler := handlers.NewGRPCHandler(proxyService, proxyRepo, events, log)
 	pb.RegisterProxyServiceServer(grpcServer, grpcHandler)
+	serviceHealth.Register(grpcServer)
 
 	reflection.Register(grpcServer)
 
 	log.Infof("Starting gRPC server on port %d", port)
 	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatal("Failed to serve gRPC: ", err)
+		log.Fatal("Failed to serve gRPC", "error", err)
 	}
 }
 
-func startHTTPServer(proxyService *service.ProxyService, proxyRepo *repository.ProxyRepository, providerRepo *repository.ProviderRepository, log *logrus.Logger, cfg *config.Config) {
+func startHTTPServer(proxyService *service.ProxyService, proxyRepo *repository.ProxyRepository, providerRepo *repository.ProviderRepository, serviceHealth *health.Checker, log logger.Logger, cfg *config.Config) {
 	port := 8007
 
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(gin.LoggerWithConfig(gin.LoggerConfig{
-		Output: log.Out,
+		Output: os.Stdout,
 	}))
 
 	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.Secret)
-	httpHandler := handlers.NewHTTPHandler(proxyService, proxyRepo, providerRepo, authMiddleware, log)
+	httpHandler := handlers.NewHTTPHandler(proxyService, proxyRepo, providerRepo, authMiddleware, serviceHealth, log)
 	httpHandler.SetupRoutes(router)
 
 	// Add Prometheus metrics endpoint
@@ -240,6 +345,6 @@ func startHTTPServer(proxyService *service.ProxyService, proxyRepo *repository.P
 
 	log.Infof("Starting HTTP server on port %d", port)
 	if err := router.Run(fmt.Sprintf(":%d", port)); err != nil {
-		log.Fatal("Failed to start HTTP server: ", err)
+		log.Fatal("Failed to start HTTP server", "error", err)
 	}
 }
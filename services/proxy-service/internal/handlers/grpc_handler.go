@@ -2,13 +2,14 @@ package handlers
 
 import (
 	"context"
+	"errors"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
-	pb "github.com/grigta/conveer/services/proxy-service/proto"
 	"github.com/grigta/conveer/services/proxy-service/internal/repository"
 	"github.com/grigta/conveer/services/proxy-service/internal/service"
+	pb "github.com/grigta/conveer/services/proxy-service/proto"
 
-	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -18,17 +19,20 @@ type GRPCHandler struct {
 	pb.UnimplementedProxyServiceServer
 	proxyService *service.ProxyService
 	proxyRepo    *repository.ProxyRepository
-	logger       *logrus.Logger
+	events       *service.EventBus
+	logger       logger.Logger
 }
 
 func NewGRPCHandler(
 	proxyService *service.ProxyService,
 	proxyRepo *repository.ProxyRepository,
-	logger *logrus.Logger,
+	events *service.EventBus,
+	logger logger.Logger,
 ) *GRPCHandler {
 	return &GRPCHandler{
 		proxyService: proxyService,
 		proxyRepo:    proxyRepo,
+		events:       events,
 		logger:       logger,
 	}
 }
@@ -39,27 +43,46 @@ func (h *GRPCHandler) AllocateProxy(ctx context.Context, req *pb.AllocateProxyRe
 		Type:      models.ProxyType(req.Type),
 		Country:   req.Country,
 		Protocol:  models.ProxyProtocol(req.Protocol),
+		Requires:  req.Requires,
+		Policy:    req.Policy,
+		Platform:  req.Platform,
 	}
 
-	proxy, err := h.proxyService.AllocateProxy(ctx, request)
+	for _, preference := range req.CountryChain {
+		request.CountryChain = append(request.CountryChain, models.CountryPreference{
+			Country:        preference.Country,
+			MaxAllocations: int(preference.MaxAllocations),
+		})
+	}
+
+	allocation, err := h.proxyService.AllocateProxyWithFallback(ctx, request)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to allocate proxy")
+		if errors.Is(err, service.ErrUnknownCapability) || errors.Is(err, service.ErrUnknownPolicy) {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to allocate proxy: %v", err)
+		}
+		if errors.Is(err, service.ErrCountryChainExhausted) || errors.Is(err, service.ErrCapabilityUnavailable) {
+			return nil, status.Errorf(codes.ResourceExhausted, "failed to allocate proxy: %v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to allocate proxy: %v", err)
 	}
 
+	proxy := allocation.Proxy
 	return &pb.ProxyResponse{
-		Id:        proxy.ID.Hex(),
-		Ip:        proxy.IP,
-		Port:      int32(proxy.Port),
-		Username:  proxy.Username,
-		Password:  proxy.Password,
-		Protocol:  string(proxy.Protocol),
-		Type:      string(proxy.Type),
-		Country:   proxy.Country,
-		City:      proxy.City,
-		Status:    string(proxy.Status),
-		ExpiresAt: proxy.ExpiresAt.Unix(),
-		Provider:  proxy.Provider,
+		Id:            proxy.ID.Hex(),
+		Ip:            proxy.IP,
+		Port:          int32(proxy.Port),
+		Username:      proxy.Username,
+		Password:      proxy.Password,
+		Protocol:      string(proxy.Protocol),
+		Type:          string(proxy.Type),
+		Country:       proxy.Country,
+		City:          proxy.City,
+		Status:        string(proxy.Status),
+		ExpiresAt:     proxy.ExpiresAt.Unix(),
+		Provider:      proxy.Provider,
+		FallbackLevel: int32(allocation.FallbackLevel),
+		Capabilities:  proxy.Capabilities.List(),
 	}, nil
 }
 
@@ -87,18 +110,19 @@ func (h *GRPCHandler) GetProxyForAccount(ctx context.Context, req *pb.GetProxyRe
 	}
 
 	return &pb.ProxyResponse{
-		Id:        proxy.ID.Hex(),
-		Ip:        proxy.IP,
-		Port:      int32(proxy.Port),
-		Username:  proxy.Username,
-		Password:  proxy.Password,
-		Protocol:  string(proxy.Protocol),
-		Type:      string(proxy.Type),
-		Country:   proxy.Country,
-		City:      proxy.City,
-		Status:    string(proxy.Status),
-		ExpiresAt: proxy.ExpiresAt.Unix(),
-		Provider:  proxy.Provider,
+		Id:           proxy.ID.Hex(),
+		Ip:           proxy.IP,
+		Port:         int32(proxy.Port),
+		Username:     proxy.Username,
+		Password:     proxy.Password,
+		Protocol:     string(proxy.Protocol),
+		Type:         string(proxy.Type),
+		Country:      proxy.Country,
+		City:         proxy.City,
+		Status:       string(proxy.Status),
+		ExpiresAt:    proxy.ExpiresAt.Unix(),
+		Provider:     proxy.Provider,
+		Capabilities: proxy.Capabilities.List(),
 	}, nil
 }
 
@@ -139,18 +163,19 @@ func (h *GRPCHandler) RotateProxy(ctx context.Context, req *pb.RotateProxyReques
 	}
 
 	return &pb.ProxyResponse{
-		Id:        newProxy.ID.Hex(),
-		Ip:        newProxy.IP,
-		Port:      int32(newProxy.Port),
-		Username:  newProxy.Username,
-		Password:  newProxy.Password,
-		Protocol:  string(newProxy.Protocol),
-		Type:      string(newProxy.Type),
-		Country:   newProxy.Country,
-		City:      newProxy.City,
-		Status:    string(newProxy.Status),
-		ExpiresAt: newProxy.ExpiresAt.Unix(),
-		Provider:  newProxy.Provider,
+		Id:           newProxy.ID.Hex(),
+		Ip:           newProxy.IP,
+		Port:         int32(newProxy.Port),
+		Username:     newProxy.Username,
+		Password:     newProxy.Password,
+		Protocol:     string(newProxy.Protocol),
+		Type:         string(newProxy.Type),
+		Country:      newProxy.Country,
+		City:         newProxy.City,
+		Status:       string(newProxy.Status),
+		ExpiresAt:    newProxy.ExpiresAt.Unix(),
+		Provider:     newProxy.Provider,
+		Capabilities: newProxy.Capabilities.List(),
 	}, nil
 }
 
@@ -162,15 +187,19 @@ func (h *GRPCHandler) GetProxyStatistics(ctx context.Context, req *pb.GetStatist
 	}
 
 	response := &pb.ProxyStatisticsResponse{
-		TotalProxies:     stats.TotalProxies,
-		ActiveProxies:    stats.ActiveProxies,
-		ExpiredProxies:   stats.ExpiredProxies,
-		BannedProxies:    stats.BannedProxies,
-		TotalBindings:    stats.TotalBindings,
-		ProxiesByType:    make(map[string]int64),
-		ProxiesByCountry: make(map[string]int64),
-		AvgFraudScore:    stats.AvgFraudScore,
-		AvgLatency:       stats.AvgLatency,
+		TotalProxies:       stats.TotalProxies,
+		ActiveProxies:      stats.ActiveProxies,
+		ExpiredProxies:     stats.ExpiredProxies,
+		BannedProxies:      stats.BannedProxies,
+		TotalBindings:      stats.TotalBindings,
+		ProxiesByType:      make(map[string]int64),
+		ProxiesByCountry:   make(map[string]int64),
+		AvgFraudScore:      stats.AvgFraudScore,
+		AvgLatency:         stats.AvgLatency,
+		TotalBytesSent:     stats.TotalBytesSent,
+		TotalBytesReceived: stats.TotalBytesReceived,
+		TotalRequests:      stats.TotalRequests,
+		BytesByProvider:    make(map[string]int64),
 	}
 
 	for k, v := range stats.ProxiesByType {
@@ -181,5 +210,106 @@ func (h *GRPCHandler) GetProxyStatistics(ctx context.Context, req *pb.GetStatist
 		response.ProxiesByCountry[k] = v
 	}
 
+	for k, v := range stats.BytesByProvider {
+		response.BytesByProvider[k] = v
+	}
+
+	return response, nil
+}
+
+func (h *GRPCHandler) GetAccountUsage(ctx context.Context, req *pb.GetAccountUsageRequest) (*pb.AccountUsageResponse, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	usage, err := h.proxyService.GetAccountUsage(ctx, req.AccountId)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get account usage")
+		return nil, status.Errorf(codes.Internal, "failed to get account usage: %v", err)
+	}
+
+	response := &pb.AccountUsageResponse{
+		AccountId:          usage.AccountID,
+		TotalBytesSent:     usage.TotalBytesSent,
+		TotalBytesReceived: usage.TotalBytesReceived,
+		TotalRequests:      usage.TotalRequests,
+	}
+
+	for _, binding := range usage.Bindings {
+		response.Bindings = append(response.Bindings, &pb.BindingUsage{
+			ProxyId:       binding.ProxyID.Hex(),
+			Status:        string(binding.Status),
+			BoundAt:       binding.BoundAt.Unix(),
+			LastUsedAt:    binding.LastUsedAt.Unix(),
+			BytesSent:     binding.BytesSent,
+			BytesReceived: binding.BytesReceived,
+			RequestCount:  binding.RequestCount,
+		})
+	}
+
 	return response, nil
 }
+
+func (h *GRPCHandler) GetExitIP(ctx context.Context, req *pb.GetExitIPRequest) (*pb.ExitIPResponse, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	exitIP, err := h.proxyService.GetExitIP(ctx, req.AccountId, req.Refresh)
+	if err != nil {
+		if errors.Is(err, service.ErrNoProxyForAccount) {
+			return nil, status.Error(codes.NotFound, "no proxy found for account")
+		}
+		h.logger.WithError(err).Error("Failed to get exit IP")
+		return nil, status.Errorf(codes.Unavailable, "failed to get exit IP: %v", err)
+	}
+
+	return &pb.ExitIPResponse{
+		ProxyId:    exitIP.ProxyID,
+		AccountId:  exitIP.AccountID,
+		Ip:         exitIP.IP,
+		PreviousIp: exitIP.PreviousIP,
+		Changed:    exitIP.Changed,
+		CheckedAt:  exitIP.CheckedAt.Unix(),
+	}, nil
+}
+
+// WatchProxyEvents streams proxy events matching the filter until the client disconnects
+func (h *GRPCHandler) WatchProxyEvents(req *pb.WatchProxyEventsRequest, stream pb.ProxyService_WatchProxyEventsServer) error {
+	filter := models.ProxyEventFilter{
+		ProxyID:   req.ProxyId,
+		AccountID: req.AccountId,
+		Provider:  req.Provider,
+		Country:   req.Country,
+	}
+	for _, t := range req.Types {
+		if !models.IsProxyEventType(t) {
+			return status.Errorf(codes.InvalidArgument, "unknown event type %q", t)
+		}
+		filter.Types = append(filter.Types, models.ProxyEventType(t))
+	}
+
+	events, cancel := h.events.Subscribe(filter)
+	defer cancel()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-events:
+			if err := stream.Send(&pb.ProxyEvent{
+				Type:      string(event.Type),
+				ProxyId:   event.ProxyID,
+				AccountId: event.AccountID,
+				Provider:  event.Provider,
+				Country:   event.Country,
+				ProxyType: event.ProxyType,
+				Details:   event.Details,
+				Timestamp: event.Timestamp.Unix(),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
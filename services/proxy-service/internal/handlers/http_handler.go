@@ -1,24 +1,30 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/grigta/conveer/pkg/health"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/middleware"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 	"github.com/grigta/conveer/services/proxy-service/internal/repository"
 	"github.com/grigta/conveer/services/proxy-service/internal/service"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type HTTPHandler struct {
-	proxyService  *service.ProxyService
-	proxyRepo     *repository.ProxyRepository
-	providerRepo  *repository.ProviderRepository
+	proxyService   *service.ProxyService
+	proxyRepo      *repository.ProxyRepository
+	providerRepo   *repository.ProviderRepository
 	authMiddleware *middleware.AuthMiddleware
-	logger        *logrus.Logger
+	serviceHealth  *health.Checker
+	logger         logger.Logger
 }
 
 func NewHTTPHandler(
@@ -26,14 +32,16 @@ func NewHTTPHandler(
 	proxyRepo *repository.ProxyRepository,
 	providerRepo *repository.ProviderRepository,
 	authMiddleware *middleware.AuthMiddleware,
-	logger *logrus.Logger,
+	serviceHealth *health.Checker,
+	logger logger.Logger,
 ) *HTTPHandler {
 	return &HTTPHandler{
-		proxyService:  proxyService,
-		proxyRepo:     proxyRepo,
-		providerRepo:  providerRepo,
+		proxyService:   proxyService,
+		proxyRepo:      proxyRepo,
+		providerRepo:   providerRepo,
 		authMiddleware: authMiddleware,
-		logger:        logger,
+		serviceHealth:  serviceHealth,
+		logger:         logger,
 	}
 }
 
@@ -49,14 +57,25 @@ func (h *HTTPHandler) SetupRoutes(router *gin.Engine) {
 		proxies.POST("/release", h.ReleaseProxy)
 		proxies.GET("/:id", h.GetProxyByID)
 		proxies.GET("/account/:account_id", h.GetProxyByAccount)
+		proxies.GET("/account/:account_id/exit-ip", h.GetExitIP)
 		proxies.GET("/health/:id", h.GetProxyHealth)
 		proxies.POST("/:id/rotate", h.RotateProxy)
 		proxies.GET("/statistics", h.GetStatistics)
+		proxies.GET("/usage/:account_id", h.GetAccountUsage)
+		proxies.GET("/history/:account_id", h.GetProxyHistory)
+
+		// Manually bought proxies are added by operators only
+		importHandlers := []gin.HandlerFunc{h.ImportProxies}
+		if h.authMiddleware != nil {
+			importHandlers = append([]gin.HandlerFunc{h.authMiddleware.RequireRole("admin", "operator")}, importHandlers...)
+		}
+		proxies.POST("/import", importHandlers...)
 	}
 
 	api.GET("/providers", h.GetProviders)
+	api.GET("/providers/spend", h.GetProviderSpend)
 
-	router.GET("/health", h.HealthCheck)
+	router.GET("/health", h.serviceHealth.Handler())
 }
 
 func (h *HTTPHandler) AllocateProxy(c *gin.Context) {
@@ -67,25 +86,36 @@ func (h *HTTPHandler) AllocateProxy(c *gin.Context) {
 		return
 	}
 
-	proxy, err := h.proxyService.AllocateProxy(c.Request.Context(), request)
+	allocation, err := h.proxyService.AllocateProxyWithFallback(c.Request.Context(), request)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to allocate proxy")
+		if errors.Is(err, service.ErrUnknownCapability) || errors.Is(err, service.ErrUnknownPolicy) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrCountryChainExhausted) || errors.Is(err, service.ErrCapabilityUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	proxy := allocation.Proxy
 	c.JSON(http.StatusOK, gin.H{
-		"id":        proxy.ID.Hex(),
-		"ip":        proxy.IP,
-		"port":      proxy.Port,
-		"username":  proxy.Username,
-		"password":  proxy.Password,
-		"protocol":  proxy.Protocol,
-		"type":      proxy.Type,
-		"country":   proxy.Country,
-		"city":      proxy.City,
-		"status":    proxy.Status,
-		"expiresAt": proxy.ExpiresAt,
+		"id":            proxy.ID.Hex(),
+		"ip":            proxy.IP,
+		"port":          proxy.Port,
+		"username":      proxy.Username,
+		"password":      proxy.Password,
+		"protocol":      proxy.Protocol,
+		"type":          proxy.Type,
+		"country":       proxy.Country,
+		"city":          proxy.City,
+		"status":        proxy.Status,
+		"expiresAt":     proxy.ExpiresAt,
+		"fallbackLevel": allocation.FallbackLevel,
+		"capabilities":  proxy.Capabilities,
 	})
 }
 
@@ -171,6 +201,25 @@ func (h *HTTPHandler) GetProxyByAccount(c *gin.Context) {
 	})
 }
 
+// GetExitIP returns the exit IP of the account's proxy, refresh=true checks it through the proxy now
+func (h *HTTPHandler) GetExitIP(c *gin.Context) {
+	accountID := c.Param("account_id")
+	refresh := c.Query("refresh") == "true"
+
+	exitIP, err := h.proxyService.GetExitIP(c.Request.Context(), accountID, refresh)
+	if err != nil {
+		if errors.Is(err, service.ErrNoProxyForAccount) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No proxy found for account"})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to get exit IP")
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, exitIP)
+}
+
 func (h *HTTPHandler) GetProxyHealth(c *gin.Context) {
 	idStr := c.Param("id")
 
@@ -203,6 +252,8 @@ func (h *HTTPHandler) GetProxyHealth(c *gin.Context) {
 		"blacklist_status": health.BlacklistStatus,
 		"last_check":       health.LastCheck.Unix(),
 		"failed_checks":    health.FailedChecks,
+		"check_region":     health.CheckRegion,
+		"region_latency":   health.RegionLatency,
 	})
 }
 
@@ -252,6 +303,86 @@ func (h *HTTPHandler) GetStatistics(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+func (h *HTTPHandler) GetAccountUsage(c *gin.Context) {
+	accountID := c.Param("account_id")
+
+	usage, err := h.proxyService.GetAccountUsage(c.Request.Context(), accountID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get account usage")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// GetProxyHistory returns every proxy the account was bound to, latest first
+func (h *HTTPHandler) GetProxyHistory(c *gin.Context) {
+	accountID := c.Param("account_id")
+
+	history, err := h.proxyService.GetProxyHistory(c.Request.Context(), accountID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get proxy history")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"account_id": accountID,
+		"history":    history,
+	})
+}
+
+// ImportProxies accepts a JSON list of proxies or a CSV upload in the "file" form field.
+// dry_run=true in the query (or JSON body) validates and health-checks without storing.
+func (h *HTTPHandler) ImportProxies(c *gin.Context) {
+	var request models.ProxyImportRequest
+
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file is required in the file field"})
+			return
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			h.logger.WithError(err).Error("Failed to open uploaded CSV")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+			return
+		}
+		defer reader.Close()
+
+		request.Proxies, err = service.ParseProxyImportCSV(reader)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		request.Provider = c.PostForm("provider")
+	} else if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.WithError(err).Error("Failed to bind request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if dryRun, err := strconv.ParseBool(c.Query("dry_run")); err == nil {
+		request.DryRun = dryRun
+	}
+
+	report, err := h.proxyService.ImportProxies(c.Request.Context(), request)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to import proxies")
+		if errors.Is(err, service.ErrImportEmpty) || errors.Is(err, service.ErrImportTooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import proxies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 func (h *HTTPHandler) GetProviders(c *gin.Context) {
 	stats, err := h.providerRepo.GetAllProviderStats(c.Request.Context())
 	if err != nil {
@@ -282,9 +413,25 @@ func (h *HTTPHandler) GetProviders(c *gin.Context) {
 	})
 }
 
-func (h *HTTPHandler) HealthCheck(c *gin.Context) {
+// GetProviderSpend reports spend against the monthly budgets, ?month=YYYY-MM defaults to the current month
+func (h *HTTPHandler) GetProviderSpend(c *gin.Context) {
+	month := c.Query("month")
+	if month == "" {
+		month = models.SpendMonth(time.Now())
+	} else if _, err := time.Parse("2006-01", month); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "month must be in YYYY-MM format"})
+		return
+	}
+
+	spend, err := h.proxyService.GetProviderSpend(c.Request.Context(), month)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get provider spend")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get provider spend"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "proxy-service",
+		"month":     month,
+		"providers": spend,
 	})
 }
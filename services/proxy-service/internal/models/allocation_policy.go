@@ -0,0 +1,161 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Scales used to bring scoring inputs to the 0..1 range before weighting
+const (
+	policyLatencyScaleMs = 1000.0 // latency at which the latency component saturates
+	policyFraudScale     = 100.0
+	policyPriorityScale  = 10.0
+	policyCostScale      = 10.0 // cost per proxy in the provider's currency
+)
+
+// AllocationPolicy is a named set of allocation defaults selected by consumer services,
+// e.g. mobile RU proxies for registration or sticky residential ones for warming
+type AllocationPolicy struct {
+	Type             ProxyType           `json:"type,omitempty" yaml:"type,omitempty"`
+	Country          string              `json:"country,omitempty" yaml:"country,omitempty"`
+	CountryChain     []CountryPreference `json:"country_chain,omitempty" yaml:"country_chain,omitempty"`
+	Protocol         ProxyProtocol       `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Requires         []string            `json:"requires,omitempty" yaml:"requires,omitempty"`
+	Providers        []string            `json:"providers,omitempty" yaml:"providers,omitempty"`             // Only these providers are used, empty means any
+	MaxFraudScore    float64             `json:"max_fraud_score,omitempty" yaml:"max_fraud_score,omitempty"` // Pooled proxies above it are skipped, 0 disables
+	MaxLatencyMs     int                 `json:"max_latency_ms,omitempty" yaml:"max_latency_ms,omitempty"`   // Pooled proxies above it are skipped, 0 disables
+	Weights          ScoringWeights      `json:"weights" yaml:"weights"`
+	RotationInterval string              `json:"rotation_interval,omitempty" yaml:"rotation_interval,omitempty"` // Empty keeps the proxy until it expires
+}
+
+// ScoringWeights rank pooled proxies, a higher weight makes the factor matter more.
+// All zero keeps the repository order.
+type ScoringWeights struct {
+	Latency    float64 `json:"latency" yaml:"latency"`
+	FraudScore float64 `json:"fraud_score" yaml:"fraud_score"`
+	Priority   float64 `json:"priority" yaml:"priority"` // Provider priority, 1 is the most preferred
+	Cost       float64 `json:"cost" yaml:"cost"`
+}
+
+// IsZero reports whether no factor is weighted
+func (w ScoringWeights) IsZero() bool {
+	return w.Latency == 0 && w.FraudScore == 0 && w.Priority == 0 && w.Cost == 0
+}
+
+// Validate checks the policy values that would otherwise only fail at allocation time
+func (p AllocationPolicy) Validate() error {
+	if p.Type != "" && p.Type != ProxyTypeMobile && p.Type != ProxyTypeResidential {
+		return fmt.Errorf("unknown proxy type %q", p.Type)
+	}
+	for _, capability := range p.Requires {
+		if !IsProxyCapability(capability) {
+			return fmt.Errorf("unknown capability %q", capability)
+		}
+	}
+	if p.Weights.Latency < 0 || p.Weights.FraudScore < 0 || p.Weights.Priority < 0 || p.Weights.Cost < 0 {
+		return fmt.Errorf("scoring weights must not be negative")
+	}
+	if _, err := p.RotationEvery(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RotationEvery returns the rotation cadence, 0 when proxies rotate only before they expire
+func (p AllocationPolicy) RotationEvery() (time.Duration, error) {
+	if p.RotationInterval == "" {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(p.RotationInterval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rotation_interval %q: %w", p.RotationInterval, err)
+	}
+	if interval < 0 {
+		return 0, fmt.Errorf("rotation_interval must not be negative")
+	}
+	return interval, nil
+}
+
+// Apply fills the fields the request leaves empty with the policy defaults.
+// Required capabilities of both are combined.
+func (p AllocationPolicy) Apply(request ProxyAllocationRequest) ProxyAllocationRequest {
+	if request.Type == "" {
+		request.Type = p.Type
+	}
+	if request.Country == "" && len(request.CountryChain) == 0 {
+		request.Country = p.Country
+		request.CountryChain = p.CountryChain
+	}
+	if request.Protocol == "" {
+		request.Protocol = p.Protocol
+	}
+
+	for _, capability := range p.Requires {
+		found := false
+		for _, required := range request.Requires {
+			if required == capability {
+				found = true
+				break
+			}
+		}
+		if !found {
+			request.Requires = append(request.Requires, capability)
+		}
+	}
+
+	return request
+}
+
+// AllowsProvider reports whether the policy lets the provider be used
+func (p AllocationPolicy) AllowsProvider(provider string) bool {
+	if len(p.Providers) == 0 {
+		return true
+	}
+	for _, allowed := range p.Providers {
+		if allowed == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// Admits reports whether a pooled proxy passes the health thresholds. Proxies never checked
+// only pass when no threshold is set.
+func (p AllocationPolicy) Admits(health *ProxyHealth) bool {
+	if p.MaxFraudScore <= 0 && p.MaxLatencyMs <= 0 {
+		return true
+	}
+	if health == nil {
+		return false
+	}
+	if p.MaxFraudScore > 0 && health.FraudScore > p.MaxFraudScore {
+		return false
+	}
+	if p.MaxLatencyMs > 0 && health.Latency > p.MaxLatencyMs {
+		return false
+	}
+	return true
+}
+
+// Score rates a pooled proxy for the policy, lower is better. Missing health data and
+// provider settings count as the worst value of their factor.
+func (p AllocationPolicy) Score(health *ProxyHealth, provider *ProxyProvider) float64 {
+	latency, fraud := 1.0, 1.0
+	if health != nil {
+		latency = math.Min(float64(health.Latency)/policyLatencyScaleMs, 1)
+		fraud = math.Min(health.FraudScore/policyFraudScale, 1)
+	}
+
+	priority, cost := 1.0, 1.0
+	if provider != nil {
+		priority = math.Min(float64(provider.Priority)/policyPriorityScale, 1)
+		cost = math.Min(provider.Pricing.CostPerProxy/policyCostScale, 1)
+	}
+
+	return p.Weights.Latency*latency +
+		p.Weights.FraudScore*fraud +
+		p.Weights.Priority*priority +
+		p.Weights.Cost*cost
+}
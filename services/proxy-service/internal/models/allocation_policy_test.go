@@ -0,0 +1,106 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationPolicyApply(t *testing.T) {
+	policy := AllocationPolicy{
+		Type:     ProxyTypeMobile,
+		Country:  "RU",
+		Protocol: ProtocolSOCKS5,
+		Requires: []string{CapabilityUDP},
+	}
+
+	request := policy.Apply(ProxyAllocationRequest{AccountID: "acc-1"})
+	assert.Equal(t, ProxyTypeMobile, request.Type)
+	assert.Equal(t, "RU", request.Country)
+	assert.Equal(t, ProtocolSOCKS5, request.Protocol)
+	assert.Equal(t, []string{CapabilityUDP}, request.Requires)
+
+	// Explicit request fields win over the policy
+	request = policy.Apply(ProxyAllocationRequest{
+		AccountID:    "acc-2",
+		Type:         ProxyTypeResidential,
+		CountryChain: []CountryPreference{{Country: "KZ"}},
+		Requires:     []string{CapabilityQUIC, CapabilityUDP},
+	})
+	assert.Equal(t, ProxyTypeResidential, request.Type)
+	assert.Empty(t, request.Country)
+	assert.Equal(t, "KZ", request.Chain()[0].Country)
+	assert.Equal(t, []string{CapabilityQUIC, CapabilityUDP}, request.Requires)
+}
+
+func TestAllocationPolicyValidate(t *testing.T) {
+	assert.NoError(t, AllocationPolicy{Type: ProxyTypeResidential, RotationInterval: "72h"}.Validate())
+	assert.Error(t, AllocationPolicy{Type: "datacenter"}.Validate())
+	assert.Error(t, AllocationPolicy{Requires: []string{"tcp"}}.Validate())
+	assert.Error(t, AllocationPolicy{RotationInterval: "daily"}.Validate())
+	assert.Error(t, AllocationPolicy{Weights: ScoringWeights{Latency: -1}}.Validate())
+}
+
+func TestAllocationPolicyRotationEvery(t *testing.T) {
+	interval, err := AllocationPolicy{}.RotationEvery()
+	assert.NoError(t, err)
+	assert.Zero(t, interval)
+
+	interval, err = AllocationPolicy{RotationInterval: "6h"}.RotationEvery()
+	assert.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, interval)
+}
+
+func TestAllocationPolicyAllowsProvider(t *testing.T) {
+	assert.True(t, AllocationPolicy{}.AllowsProvider("provider1"))
+
+	policy := AllocationPolicy{Providers: []string{"provider2"}}
+	assert.True(t, policy.AllowsProvider("provider2"))
+	assert.False(t, policy.AllowsProvider("provider1"))
+}
+
+func TestAllocationPolicyAdmits(t *testing.T) {
+	assert.True(t, AllocationPolicy{}.Admits(nil))
+
+	policy := AllocationPolicy{MaxFraudScore: 50, MaxLatencyMs: 800}
+	assert.False(t, policy.Admits(nil), "unchecked proxies don't pass thresholds")
+	assert.True(t, policy.Admits(&ProxyHealth{FraudScore: 20, Latency: 300}))
+	assert.False(t, policy.Admits(&ProxyHealth{FraudScore: 75, Latency: 300}))
+	assert.False(t, policy.Admits(&ProxyHealth{FraudScore: 20, Latency: 1200}))
+}
+
+func TestAllocationPolicyScore(t *testing.T) {
+	policy := AllocationPolicy{Weights: ScoringWeights{Latency: 1, FraudScore: 2, Priority: 0.5}}
+
+	fast := policy.Score(&ProxyHealth{Latency: 100, FraudScore: 10}, &ProxyProvider{Priority: 1})
+	slow := policy.Score(&ProxyHealth{Latency: 900, FraudScore: 10}, &ProxyProvider{Priority: 1})
+	risky := policy.Score(&ProxyHealth{Latency: 100, FraudScore: 90}, &ProxyProvider{Priority: 1})
+	unchecked := policy.Score(nil, &ProxyProvider{Priority: 1})
+
+	assert.Less(t, fast, slow)
+	assert.Less(t, slow, risky, "fraud score weighs more than latency")
+	assert.Less(t, risky, unchecked)
+	assert.InDelta(t, 0.1+0.2+0.05, fast, 1e-9)
+
+	assert.Zero(t, AllocationPolicy{}.Score(nil, nil))
+}
+
+func TestProxyHealthFromRegion(t *testing.T) {
+	// Checked from its own region in Asia, the proxy is also measured from the Russian checker
+	health := &ProxyHealth{Latency: 120, CheckRegion: "asia", RegionLatency: map[string]int{"asia": 120, "ru": 900}}
+
+	assert.Equal(t, 900, health.FromRegion("ru").Latency)
+	assert.Equal(t, "ru", health.FromRegion("ru").CheckRegion)
+	assert.Equal(t, 120, health.Latency, "the stored health is not changed")
+	assert.Same(t, health, health.FromRegion("asia"))
+	assert.Same(t, health, health.FromRegion("eu"), "unchecked regions keep the closest region latency")
+	assert.Same(t, health, health.FromRegion(""))
+
+	var unchecked *ProxyHealth
+	assert.Nil(t, unchecked.FromRegion("ru"))
+
+	policy := AllocationPolicy{MaxLatencyMs: 500}
+	assert.True(t, policy.Admits(health.FromRegion("eu")))
+	assert.False(t, policy.Admits(health.FromRegion("ru")))
+}
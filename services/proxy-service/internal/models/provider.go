@@ -1,6 +1,8 @@
 package models
 
 import (
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -52,15 +54,92 @@ type ProviderParameters struct {
 	RotationInterval  string          `json:"rotation_interval" yaml:"rotation_interval"`
 	MaxConcurrent     int             `json:"max_concurrent,omitempty" yaml:"max_concurrent,omitempty"`
 	MinPoolSize       int             `json:"min_pool_size,omitempty" yaml:"min_pool_size,omitempty"`
+	TrafficCapGB      float64         `json:"traffic_cap_gb,omitempty" yaml:"traffic_cap_gb,omitempty"`         // Per proxy, 0 means unlimited
+	TrafficAlertPct   int             `json:"traffic_alert_pct,omitempty" yaml:"traffic_alert_pct,omitempty"` // Warn when usage crosses this share of the cap
 }
 
 type ProviderPricing struct {
-	CostPerProxy float64 `json:"cost_per_proxy" yaml:"cost_per_proxy"`
-	Currency     string  `json:"currency" yaml:"currency"`
+	CostPerProxy  float64 `json:"cost_per_proxy" yaml:"cost_per_proxy"`
+	Currency      string  `json:"currency" yaml:"currency"`
+	MonthlyBudget float64 `json:"monthly_budget,omitempty" yaml:"monthly_budget,omitempty"` // Spend cap per calendar month (UTC) in Currency, 0 means unlimited
+}
+
+// Supports reports whether the provider sells proxies matching the purchase parameters.
+// A provider that lists no countries or protocols is not restricted by them.
+func (p *ProxyProvider) Supports(params ProxyPurchaseParams) bool {
+	if params.Type != "" && p.Type != "" && params.Type != p.Type {
+		return false
+	}
+
+	if params.Country != "" && len(p.Parameters.Countries) > 0 {
+		found := false
+		for _, country := range p.Parameters.Countries {
+			if strings.EqualFold(country, params.Country) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if params.Protocol != "" && len(p.Parameters.Protocols) > 0 {
+		found := false
+		for _, protocol := range p.Parameters.Protocols {
+			if protocol == params.Protocol {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PurchaseCost is what a purchase cost: the price reported by the provider, or the list price
+// when the purchase response doesn't carry one
+func (p *ProxyProvider) PurchaseCost(response *ProxyResponse) float64 {
+	if response != nil && response.Price > 0 {
+		return response.Price
+	}
+	return p.Pricing.CostPerProxy
+}
+
+// SortByPurchaseCost orders providers cheapest first, equal prices by priority
+func SortByPurchaseCost(providers []ProxyProvider) {
+	sort.SliceStable(providers, func(i, j int) bool {
+		if providers[i].Pricing.CostPerProxy != providers[j].Pricing.CostPerProxy {
+			return providers[i].Pricing.CostPerProxy < providers[j].Pricing.CostPerProxy
+		}
+		return providers[i].Priority < providers[j].Priority
+	})
+}
+
+// ProviderSpend is what was paid to a provider in a calendar month
+type ProviderSpend struct {
+	Provider  string    `bson:"provider" json:"provider"`
+	Month     string    `bson:"month" json:"month"` // YYYY-MM in UTC
+	Amount    float64   `bson:"amount" json:"amount"`
+	Purchases int64     `bson:"purchases" json:"purchases"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+
+	// Filled from the provider config when spend is reported
+	MonthlyBudget float64 `bson:"-" json:"monthly_budget,omitempty"`
+	Currency      string  `bson:"-" json:"currency,omitempty"`
+}
+
+// SpendMonth returns the budget month t falls into
+func SpendMonth(t time.Time) string {
+	return t.UTC().Format("2006-01")
 }
 
 type ProviderConfig struct {
-	Providers []ProxyProvider `json:"providers" yaml:"providers"`
+	Providers []ProxyProvider             `json:"providers" yaml:"providers"`
+	Policies  map[string]AllocationPolicy `json:"policies,omitempty" yaml:"policies,omitempty"` // Keyed by the name consumers pass in allocation requests
 }
 
 type ProviderStats struct {
@@ -95,4 +174,5 @@ type ProxyResponse struct {
 	Country  string        `json:"country,omitempty"`
 	City     string        `json:"city,omitempty"`
 	ExpireAt time.Time     `json:"expire_at,omitempty"`
+	Price    float64       `json:"price,omitempty"` // Charged for the purchase in the provider's currency, 0 when the API doesn't report it
 }
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyProviderSupports(t *testing.T) {
+	provider := &ProxyProvider{
+		Type: ProxyTypeMobile,
+		Parameters: ProviderParameters{
+			Countries: []string{"RU", "KZ"},
+			Protocols: []ProxyProtocol{ProtocolHTTP},
+		},
+	}
+
+	assert.True(t, provider.Supports(ProxyPurchaseParams{}))
+	assert.True(t, provider.Supports(ProxyPurchaseParams{Type: ProxyTypeMobile, Country: "ru", Protocol: ProtocolHTTP}))
+	assert.False(t, provider.Supports(ProxyPurchaseParams{Type: ProxyTypeResidential}))
+	assert.False(t, provider.Supports(ProxyPurchaseParams{Country: "US"}))
+	assert.False(t, provider.Supports(ProxyPurchaseParams{Protocol: ProtocolSOCKS5}))
+
+	// A provider without restrictions sells anything
+	assert.True(t, (&ProxyProvider{}).Supports(ProxyPurchaseParams{Type: ProxyTypeResidential, Country: "US", Protocol: ProtocolSOCKS5}))
+}
+
+func TestProxyProviderPurchaseCost(t *testing.T) {
+	provider := &ProxyProvider{Pricing: ProviderPricing{CostPerProxy: 5}}
+
+	assert.Equal(t, 4.2, provider.PurchaseCost(&ProxyResponse{Price: 4.2}))
+	assert.Equal(t, 5.0, provider.PurchaseCost(&ProxyResponse{}))
+	assert.Equal(t, 5.0, provider.PurchaseCost(nil))
+}
+
+func TestSortByPurchaseCost(t *testing.T) {
+	providers := []ProxyProvider{
+		{Name: "expensive", Priority: 1, Pricing: ProviderPricing{CostPerProxy: 7}},
+		{Name: "cheap-secondary", Priority: 3, Pricing: ProviderPricing{CostPerProxy: 3.5}},
+		{Name: "cheap-primary", Priority: 2, Pricing: ProviderPricing{CostPerProxy: 3.5}},
+	}
+
+	SortByPurchaseCost(providers)
+
+	names := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		names = append(names, provider.Name)
+	}
+	assert.Equal(t, []string{"cheap-primary", "cheap-secondary", "expensive"}, names)
+}
+
+func TestSpendMonth(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	assert.Equal(t, "2026-03", SpendMonth(time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)))
+	// Budgets roll over at midnight UTC
+	assert.Equal(t, "2026-03", SpendMonth(time.Date(2026, 4, 1, 2, 0, 0, 0, moscow)))
+}
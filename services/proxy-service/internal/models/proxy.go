@@ -32,20 +32,88 @@ const (
 )
 
 type Proxy struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Provider     string             `bson:"provider" json:"provider"`
-	IP           string             `bson:"ip" json:"ip"`
-	Port         int                `bson:"port" json:"port"`
-	Protocol     ProxyProtocol      `bson:"protocol" json:"protocol"`
-	Username     string             `bson:"username" json:"username"`
-	Password     string             `bson:"password" json:"password"` // Encrypted
-	Type         ProxyType          `bson:"type" json:"type"`
-	Country      string             `bson:"country" json:"country"`
-	City         string             `bson:"city" json:"city"`
-	Status       ProxyStatus        `bson:"status" json:"status"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	ExpiresAt    time.Time          `bson:"expires_at" json:"expires_at"`
-	LastChecked  time.Time          `bson:"last_checked" json:"last_checked"`
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Provider        string             `bson:"provider" json:"provider"`
+	IP              string             `bson:"ip" json:"ip"`
+	Port            int                `bson:"port" json:"port"`
+	Protocol        ProxyProtocol      `bson:"protocol" json:"protocol"`
+	Username        string             `bson:"username" json:"username"`
+	Password        string             `bson:"password" json:"password"` // Encrypted
+	Type            ProxyType          `bson:"type" json:"type"`
+	Country         string             `bson:"country" json:"country"`
+	City            string             `bson:"city" json:"city"`
+	Status          ProxyStatus        `bson:"status" json:"status"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	ExpiresAt       time.Time          `bson:"expires_at" json:"expires_at"`
+	LastChecked     time.Time          `bson:"last_checked" json:"last_checked"`
+	BytesUsed       int64              `bson:"bytes_used" json:"bytes_used"`
+	AlertLevel      int                `bson:"traffic_alert_level" json:"traffic_alert_level"` // Highest cap percentage already reported
+	Capabilities    ProxyCapabilities  `bson:"capabilities" json:"capabilities"`
+	ExitIP          string             `bson:"exit_ip,omitempty" json:"exit_ip,omitempty"` // Address the proxy was last seen exiting from
+	ExitIPCheckedAt time.Time          `bson:"exit_ip_checked_at,omitempty" json:"exit_ip_checked_at,omitempty"`
+	IPHistory       []ProxyIPChange    `bson:"ip_history,omitempty" json:"ip_history,omitempty"` // Most recent exit IP changes, oldest first
+	PurchaseCost    float64            `bson:"purchase_cost,omitempty" json:"purchase_cost,omitempty"` // Paid to the provider, 0 for imported proxies
+}
+
+// MaxIPHistory bounds the exit IP changes kept on a proxy document
+const MaxIPHistory = 50
+
+// ProxyIPChange is one exit IP change of a rotating proxy
+type ProxyIPChange struct {
+	PreviousIP string    `bson:"previous_ip" json:"previous_ip"`
+	IP         string    `bson:"ip" json:"ip"`
+	DetectedAt time.Time `bson:"detected_at" json:"detected_at"`
+}
+
+// ExitIPStatus is the exit IP of an account's proxy as returned to platform services
+type ExitIPStatus struct {
+	ProxyID    string    `json:"proxy_id"`
+	AccountID  string    `json:"account_id,omitempty"`
+	IP         string    `json:"ip"`
+	PreviousIP string    `json:"previous_ip,omitempty"` // Set when the check detected a change
+	Changed    bool      `json:"changed"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// Protocol capabilities a proxy exit may support besides plain TCP
+const (
+	CapabilityUDP  = "udp"
+	CapabilityQUIC = "quic"
+)
+
+// ProxyCapabilities holds the result of the last capability probe
+type ProxyCapabilities struct {
+	UDP       bool      `bson:"udp" json:"udp"`   // SOCKS5 UDP ASSOCIATE relays datagrams to the internet
+	QUIC      bool      `bson:"quic" json:"quic"` // QUIC endpoints answer through the UDP relay
+	CheckedAt time.Time `bson:"checked_at" json:"checked_at"`
+}
+
+// Has reports whether the named capability was confirmed by the last probe
+func (c ProxyCapabilities) Has(capability string) bool {
+	switch capability {
+	case CapabilityUDP:
+		return c.UDP
+	case CapabilityQUIC:
+		return c.QUIC
+	}
+	return false
+}
+
+// List returns the names of the supported capabilities
+func (c ProxyCapabilities) List() []string {
+	var capabilities []string
+	if c.UDP {
+		capabilities = append(capabilities, CapabilityUDP)
+	}
+	if c.QUIC {
+		capabilities = append(capabilities, CapabilityQUIC)
+	}
+	return capabilities
+}
+
+// IsProxyCapability checks that the capability name is known
+func IsProxyCapability(capability string) bool {
+	return capability == CapabilityUDP || capability == CapabilityQUIC
 }
 
 type ProxyHealth struct {
@@ -59,6 +127,26 @@ type ProxyHealth struct {
 	BlacklistStatus bool               `bson:"blacklist_status" json:"blacklist_status"`
 	LastCheck       time.Time          `bson:"last_check" json:"last_check"`
 	FailedChecks    int                `bson:"failed_checks" json:"failed_checks"`
+	CheckRegion     string             `bson:"check_region,omitempty" json:"check_region,omitempty"`     // Checker region Latency was measured from
+	RegionLatency   map[string]int     `bson:"region_latency,omitempty" json:"region_latency,omitempty"` // milliseconds per checker region
+}
+
+// FromRegion returns the health as seen from a checker region: the latency measured from
+// there, or the health itself when the proxy was not checked from the region
+func (h *ProxyHealth) FromRegion(region string) *ProxyHealth {
+	if h == nil || region == "" || region == h.CheckRegion {
+		return h
+	}
+
+	latency, ok := h.RegionLatency[region]
+	if !ok {
+		return h
+	}
+
+	regional := *h
+	regional.Latency = latency
+	regional.CheckRegion = region
+	return &regional
 }
 
 type BindingStatus string
@@ -70,12 +158,32 @@ const (
 )
 
 type ProxyBinding struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	ProxyID    primitive.ObjectID `bson:"proxy_id" json:"proxy_id"`
-	AccountID  string             `bson:"account_id" json:"account_id"`
-	BoundAt    time.Time          `bson:"bound_at" json:"bound_at"`
-	LastUsedAt time.Time          `bson:"last_used_at" json:"last_used_at"`
-	Status     BindingStatus      `bson:"status" json:"status"`
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProxyID       primitive.ObjectID `bson:"proxy_id" json:"proxy_id"`
+	AccountID     string             `bson:"account_id" json:"account_id"`
+	BoundAt       time.Time          `bson:"bound_at" json:"bound_at"`
+	LastUsedAt    time.Time          `bson:"last_used_at" json:"last_used_at"`
+	Status        BindingStatus      `bson:"status" json:"status"`
+	BytesSent     int64              `bson:"bytes_sent" json:"bytes_sent"`
+	BytesReceived int64              `bson:"bytes_received" json:"bytes_received"`
+	RequestCount  int64              `bson:"request_count" json:"request_count"`
+}
+
+// ProxyUsageReport is published by platform services after traffic went through an account's proxy
+type ProxyUsageReport struct {
+	AccountID     string    `json:"account_id"`
+	BytesSent     int64     `json:"bytes_sent"`
+	BytesReceived int64     `json:"bytes_received"`
+	Requests      int64     `json:"requests"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+type AccountUsage struct {
+	AccountID          string         `json:"account_id"`
+	TotalBytesSent     int64          `json:"total_bytes_sent"`
+	TotalBytesReceived int64          `json:"total_bytes_received"`
+	TotalRequests      int64          `json:"total_requests"`
+	Bindings           []ProxyBinding `json:"bindings"`
 }
 
 type ProxyFilters struct {
@@ -83,23 +191,54 @@ type ProxyFilters struct {
 	Country string      `json:"country,omitempty"`
 	Status  ProxyStatus `json:"status,omitempty"`
 	Provider string     `json:"provider,omitempty"`
+	Providers []string  `json:"providers,omitempty"` // Any of these providers
+	Requires []string   `json:"requires,omitempty"`
 }
 
 type ProxyAllocationRequest struct {
-	AccountID    string        `json:"account_id" binding:"required"`
-	Type         ProxyType     `json:"type,omitempty"`
-	Country      string        `json:"country,omitempty"`
-	Protocol     ProxyProtocol `json:"protocol,omitempty"`
+	AccountID    string              `json:"account_id" binding:"required"`
+	Type         ProxyType           `json:"type,omitempty"`
+	Country      string              `json:"country,omitempty"`
+	Protocol     ProxyProtocol       `json:"protocol,omitempty"`
+	CountryChain []CountryPreference `json:"country_chain,omitempty" binding:"omitempty,dive"` // Tried in order, overrides Country
+	Requires     []string            `json:"requires,omitempty"`                                // Capabilities the proxy must support, e.g. "udp"
+	Policy       string              `json:"policy,omitempty"`                                  // Named allocation policy supplying defaults for empty fields
+	Platform     string              `json:"platform,omitempty"`                                // Platform of the account, recorded in its proxy history
+}
+
+// CountryPreference is one level of a country failover chain
+type CountryPreference struct {
+	Country        string `json:"country" yaml:"country" binding:"required"`
+	MaxAllocations int    `json:"max_allocations,omitempty" yaml:"max_allocations,omitempty"` // Bound proxies allowed in this country, 0 means unlimited
+}
+
+// Chain returns the countries to try, a single level built from Country when no chain is given
+func (r ProxyAllocationRequest) Chain() []CountryPreference {
+	if len(r.CountryChain) > 0 {
+		return r.CountryChain
+	}
+	return []CountryPreference{{Country: r.Country}}
+}
+
+// ProxyAllocation is the outcome of walking a country failover chain
+type ProxyAllocation struct {
+	Proxy         *Proxy `json:"proxy"`
+	Country       string `json:"country"`
+	FallbackLevel int    `json:"fallback_level"` // Index of the chain level used, 0 is the preferred country
 }
 
 type ProxyStats struct {
-	TotalProxies     int64              `json:"total_proxies"`
-	ActiveProxies    int64              `json:"active_proxies"`
-	ExpiredProxies   int64              `json:"expired_proxies"`
-	BannedProxies    int64              `json:"banned_proxies"`
-	TotalBindings    int64              `json:"total_bindings"`
-	ProxiesByType    map[string]int64   `json:"proxies_by_type"`
-	ProxiesByCountry map[string]int64   `json:"proxies_by_country"`
-	AvgFraudScore    float64            `json:"avg_fraud_score"`
-	AvgLatency       float64            `json:"avg_latency"`
+	TotalProxies       int64            `json:"total_proxies"`
+	ActiveProxies      int64            `json:"active_proxies"`
+	ExpiredProxies     int64            `json:"expired_proxies"`
+	BannedProxies      int64            `json:"banned_proxies"`
+	TotalBindings      int64            `json:"total_bindings"`
+	ProxiesByType      map[string]int64 `json:"proxies_by_type"`
+	ProxiesByCountry   map[string]int64 `json:"proxies_by_country"`
+	AvgFraudScore      float64          `json:"avg_fraud_score"`
+	AvgLatency         float64          `json:"avg_latency"`
+	TotalBytesSent     int64            `json:"total_bytes_sent"`
+	TotalBytesReceived int64            `json:"total_bytes_received"`
+	TotalRequests      int64            `json:"total_requests"`
+	BytesByProvider    map[string]int64 `json:"bytes_by_provider"`
 }
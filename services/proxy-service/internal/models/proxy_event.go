@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+type ProxyEventType string
+
+const (
+	ProxyEventAllocated      ProxyEventType = "allocated"
+	ProxyEventReleased       ProxyEventType = "released"
+	ProxyEventRotated        ProxyEventType = "rotated"
+	ProxyEventHealthDegraded ProxyEventType = "health_degraded"
+	ProxyEventBlacklisted    ProxyEventType = "blacklisted"
+	ProxyEventBanned         ProxyEventType = "banned"
+	ProxyEventIPChanged      ProxyEventType = "ip_changed"
+)
+
+var proxyEventTypes = map[ProxyEventType]bool{
+	ProxyEventAllocated:      true,
+	ProxyEventReleased:       true,
+	ProxyEventRotated:        true,
+	ProxyEventHealthDegraded: true,
+	ProxyEventBlacklisted:    true,
+	ProxyEventBanned:         true,
+	ProxyEventIPChanged:      true,
+}
+
+// IsProxyEventType reports whether t is an event type pushed to watchers
+func IsProxyEventType(t string) bool {
+	return proxyEventTypes[ProxyEventType(t)]
+}
+
+// ProxyEvent is a change of a proxy pushed to WatchProxyEvents subscribers.
+// Details carry type specific values such as the new proxy of a rotation.
+type ProxyEvent struct {
+	Type      ProxyEventType    `json:"type"`
+	ProxyID   string            `json:"proxy_id"`
+	AccountID string            `json:"account_id,omitempty"`
+	Provider  string            `json:"provider,omitempty"`
+	Country   string            `json:"country,omitempty"`
+	ProxyType string            `json:"proxy_type,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// ProxyEventFilter selects events for a subscriber, empty fields match everything
+type ProxyEventFilter struct {
+	Types     []ProxyEventType
+	ProxyID   string
+	AccountID string
+	Provider  string
+	Country   string
+}
+
+func (f ProxyEventFilter) Matches(event ProxyEvent) bool {
+	if len(f.Types) > 0 {
+		matched := false
+		for _, t := range f.Types {
+			if t == event.Type {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if f.ProxyID != "" && f.ProxyID != event.ProxyID {
+		return false
+	}
+	if f.AccountID != "" && f.AccountID != event.AccountID {
+		return false
+	}
+	if f.Provider != "" && f.Provider != event.Provider {
+		return false
+	}
+	if f.Country != "" && f.Country != event.Country {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyEventFilterMatches(t *testing.T) {
+	event := ProxyEvent{
+		Type:      ProxyEventBlacklisted,
+		ProxyID:   "p1",
+		AccountID: "acc-1",
+		Provider:  "proxy6",
+		Country:   "RU",
+	}
+
+	assert.True(t, ProxyEventFilter{}.Matches(event))
+	assert.True(t, ProxyEventFilter{Types: []ProxyEventType{ProxyEventBanned, ProxyEventBlacklisted}}.Matches(event))
+	assert.True(t, ProxyEventFilter{Provider: "proxy6", Country: "RU"}.Matches(event))
+
+	assert.False(t, ProxyEventFilter{Types: []ProxyEventType{ProxyEventAllocated}}.Matches(event))
+	assert.False(t, ProxyEventFilter{ProxyID: "p2"}.Matches(event))
+	assert.False(t, ProxyEventFilter{AccountID: "acc-2"}.Matches(event))
+	assert.False(t, ProxyEventFilter{Country: "KZ"}.Matches(event))
+}
+
+func TestIsProxyEventType(t *testing.T) {
+	assert.True(t, IsProxyEventType("health_degraded"))
+	assert.False(t, IsProxyEventType("health_failed"))
+	assert.False(t, IsProxyEventType(""))
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProxyUsageRecord is one proxy an account was bound to. Unlike proxies, which are deleted
+// after they expire, records are kept so the proxies an account used stay known.
+type ProxyUsageRecord struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AccountID string             `bson:"account_id" json:"account_id"`
+	Platform  string             `bson:"platform,omitempty" json:"platform,omitempty"`
+	ProxyID   primitive.ObjectID `bson:"proxy_id" json:"proxy_id"`
+	Provider  string             `bson:"provider" json:"provider"`
+	Country   string             `bson:"country" json:"country"`
+	IP        string             `bson:"ip" json:"ip"`
+	ExitIP    string             `bson:"exit_ip,omitempty" json:"exit_ip,omitempty"`
+	BoundAt   time.Time          `bson:"bound_at" json:"bound_at"`
+}
+
+// NewProxyUsageRecord describes the binding of the proxy to the account
+func NewProxyUsageRecord(proxy *Proxy, accountID, platform string) ProxyUsageRecord {
+	return ProxyUsageRecord{
+		AccountID: accountID,
+		Platform:  platform,
+		ProxyID:   proxy.ID,
+		Provider:  proxy.Provider,
+		Country:   proxy.Country,
+		IP:        proxy.IP,
+		ExitIP:    proxy.ExitIP,
+		BoundAt:   time.Now(),
+	}
+}
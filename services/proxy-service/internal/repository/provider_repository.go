@@ -6,20 +6,20 @@ import (
 	"time"
 
 	"github.com/grigta/conveer/pkg/database"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"github.com/sirupsen/logrus"
 )
 
 type ProviderRepository struct {
 	db     *database.MongoDB
-	logger *logrus.Logger
+	logger logger.Logger
 }
 
-func NewProviderRepository(db *database.MongoDB, logger *logrus.Logger) *ProviderRepository {
+func NewProviderRepository(db *database.MongoDB, logger logger.Logger) *ProviderRepository {
 	return &ProviderRepository{
 		db:     db,
 		logger: logger,
@@ -102,7 +102,7 @@ func (r *ProviderRepository) IncrementProviderCounter(ctx context.Context, name
 			counterType: 1,
 		},
 		"$set": bson.M{
-			"provider_name": name,
+			"provider_name":     name,
 			"last_request_time": time.Now(),
 		},
 	}
@@ -131,7 +131,7 @@ func (r *ProviderRepository) UpdateProviderActiveProxies(ctx context.Context, na
 	update := bson.M{
 		"$set": bson.M{
 			"active_proxies": count,
-			"provider_name": name,
+			"provider_name":  name,
 		},
 	}
 
@@ -185,6 +185,84 @@ func (r *ProviderRepository) SaveProviderConfig(ctx context.Context, provider *m
 	return nil
 }
 
+// ReserveProviderSpend adds amount to the provider's spend for the month unless that would
+// take it over budget, a budget of 0 means unlimited. The check and the increment are one
+// update, so concurrent purchases cannot overshoot the budget together.
+func (r *ProviderRepository) ReserveProviderSpend(ctx context.Context, name, month string, amount, budget float64) (bool, error) {
+	filter := bson.M{"provider": name, "month": month}
+	if budget > 0 {
+		if amount > budget {
+			return false, nil
+		}
+		filter["amount"] = bson.M{"$lte": budget - amount}
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"amount": amount},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	_, err := r.db.GetCollection("provider_spend").UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		// The month's document exists but is over the limit, so the upsert tried to insert another one
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		r.logger.WithError(err).Error("Failed to reserve provider spend")
+		return false, err
+	}
+
+	return true, nil
+}
+
+// AddProviderSpend corrects the provider's spend for the month and counts purchases,
+// returning the updated spend
+func (r *ProviderRepository) AddProviderSpend(ctx context.Context, name, month string, amount float64, purchases int64) (*models.ProviderSpend, error) {
+	update := bson.M{
+		"$inc": bson.M{
+			"amount":    amount,
+			"purchases": purchases,
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var spend models.ProviderSpend
+	err := r.db.GetCollection("provider_spend").FindOneAndUpdate(
+		ctx,
+		bson.M{"provider": name, "month": month},
+		update,
+		opts,
+	).Decode(&spend)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to update provider spend")
+		return nil, err
+	}
+
+	return &spend, nil
+}
+
+// GetProviderSpend returns the spend of every provider in the month
+func (r *ProviderRepository) GetProviderSpend(ctx context.Context, month string) ([]models.ProviderSpend, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "provider", Value: 1}})
+
+	cursor, err := r.db.GetCollection("provider_spend").Find(ctx, bson.M{"month": month}, opts)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get provider spend")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var spend []models.ProviderSpend
+	if err := cursor.All(ctx, &spend); err != nil {
+		return nil, err
+	}
+
+	return spend, nil
+}
+
 func (r *ProviderRepository) CreateIndexes(ctx context.Context) error {
 	providerIndexes := []mongo.IndexModel{
 		{
@@ -215,5 +293,18 @@ func (r *ProviderRepository) CreateIndexes(ctx context.Context) error {
 		return err
 	}
 
+	spendIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "month", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, err = r.db.GetCollection("provider_spend").Indexes().CreateMany(ctx, spendIndexes)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to create provider_spend indexes")
+		return err
+	}
+
 	return nil
 }
@@ -3,26 +3,30 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/grigta/conveer/pkg/crypto"
 	"github.com/grigta/conveer/pkg/database"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"github.com/sirupsen/logrus"
 )
 
+// ErrCountryLimitReached is returned when a country already has its maximum of bound proxies
+var ErrCountryLimitReached = errors.New("country allocation limit reached")
+
 type ProxyRepository struct {
 	db        *database.MongoDB
 	encryptor *crypto.Encryptor
-	logger    *logrus.Logger
+	logger    logger.Logger
 }
 
-func NewProxyRepository(db *database.MongoDB, encryptor *crypto.Encryptor, logger *logrus.Logger) *ProxyRepository {
+func NewProxyRepository(db *database.MongoDB, encryptor *crypto.Encryptor, logger logger.Logger) *ProxyRepository {
 	return &ProxyRepository{
 		db:        db,
 		encryptor: encryptor,
@@ -99,6 +103,11 @@ func (r *ProxyRepository) GetAvailableProxies(ctx context.Context, filters model
 	}
 	if filters.Provider != "" {
 		filter["provider"] = filters.Provider
+	} else if len(filters.Providers) > 0 {
+		filter["provider"] = bson.M{"$in": filters.Providers}
+	}
+	for _, capability := range filters.Requires {
+		filter["capabilities."+capability] = true
 	}
 
 	cursor, err := r.db.GetCollection("proxies").Find(ctx, filter)
@@ -134,7 +143,7 @@ func (r *ProxyRepository) GetAvailableProxies(ctx context.Context, filters model
 func (r *ProxyRepository) UpdateProxyStatus(ctx context.Context, id primitive.ObjectID, status models.ProxyStatus) error {
 	update := bson.M{
 		"$set": bson.M{
-			"status": status,
+			"status":       status,
 			"last_checked": time.Now(),
 		},
 	}
@@ -152,6 +161,23 @@ func (r *ProxyRepository) UpdateProxyStatus(ctx context.Context, id primitive.Ob
 	return nil
 }
 
+// UpdateProxyCapabilities stores the result of a capability probe
+func (r *ProxyRepository) UpdateProxyCapabilities(ctx context.Context, id primitive.ObjectID, capabilities models.ProxyCapabilities) error {
+	update := bson.M{
+		"$set": bson.M{
+			"capabilities": capabilities,
+		},
+	}
+
+	_, err := r.db.GetCollection("proxies").UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to update proxy capabilities")
+		return err
+	}
+
+	return nil
+}
+
 func (r *ProxyRepository) UpdateProxyHealth(ctx context.Context, id primitive.ObjectID, health *models.ProxyHealth) error {
 	health.ProxyID = id
 	health.LastCheck = time.Now()
@@ -202,7 +228,7 @@ func (r *ProxyRepository) GetProxiesByStatus(ctx context.Context, status models.
 func (r *ProxyRepository) GetExpiredProxies(ctx context.Context) ([]models.Proxy, error) {
 	filter := bson.M{
 		"expires_at": bson.M{"$lte": time.Now()},
-		"status": bson.M{"$ne": models.ProxyStatusReleased},
+		"status":     bson.M{"$ne": models.ProxyStatusReleased},
 	}
 
 	cursor, err := r.db.GetCollection("proxies").Find(ctx, filter)
@@ -238,57 +264,98 @@ func (r *ProxyRepository) BindProxyToAccount(ctx context.Context, proxyID primit
 			return err
 		}
 
-		existingBinding := bson.M{
-			"account_id": accountID,
-			"status": bson.M{"$ne": models.BindingStatusReleased},
+		if err := r.bindProxy(sc, proxyID, accountID); err != nil {
+			return err
 		}
 
-		update := bson.M{
-			"$set": bson.M{
-				"status": models.BindingStatusReleased,
-			},
-		}
+		return session.CommitTransaction(sc)
+	})
 
-		_, err := r.db.GetCollection("proxy_bindings").UpdateMany(sc, existingBinding, update)
-		if err != nil {
-			return err
-		}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to bind proxy to account")
+		return err
+	}
 
-		binding := models.ProxyBinding{
-			ProxyID:    proxyID,
-			AccountID:  accountID,
-			BoundAt:    time.Now(),
-			LastUsedAt: time.Now(),
-			Status:     models.BindingStatusActive,
-		}
+	return nil
+}
 
-		_, err = r.db.GetCollection("proxy_bindings").InsertOne(sc, binding)
+// BindProxyToAccountWithinLimit binds the proxy unless limit proxies of the country are already
+// bound. The count, the country's allocation counter and the binding are written in one
+// transaction: concurrent binds in the country conflict on the counter and are retried against
+// the new count, so the limit cannot be overshot.
+func (r *ProxyRepository) BindProxyToAccountWithinLimit(ctx context.Context, proxyID primitive.ObjectID, accountID, country string, limit int) error {
+	_, err := r.db.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		bound, err := r.CountBoundProxiesByCountry(sc, country)
 		if err != nil {
-			return err
+			return nil, err
 		}
-
-		proxyUpdate := bson.M{
-			"$set": bson.M{
-				"status": models.ProxyStatusActive,
-			},
+		if bound >= int64(limit) {
+			return nil, fmt.Errorf("%w (%d/%d)", ErrCountryLimitReached, bound, limit)
 		}
 
-		_, err = r.db.GetCollection("proxies").UpdateOne(sc, bson.M{"_id": proxyID}, proxyUpdate)
+		_, err = r.db.GetCollection("proxy_country_allocations").UpdateOne(sc,
+			bson.M{"_id": country},
+			bson.M{"$set": bson.M{"bound": bound + 1, "updated_at": time.Now()}},
+			options.Update().SetUpsert(true),
+		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return session.CommitTransaction(sc)
+		return nil, r.bindProxy(sc, proxyID, accountID)
 	})
 
 	if err != nil {
-		r.logger.WithError(err).Error("Failed to bind proxy to account")
+		if !errors.Is(err, ErrCountryLimitReached) {
+			r.logger.WithError(err).Error("Failed to bind proxy to account")
+		}
 		return err
 	}
 
 	return nil
 }
 
+// bindProxy releases the account's current bindings and binds the proxy, within the caller's transaction
+func (r *ProxyRepository) bindProxy(sc mongo.SessionContext, proxyID primitive.ObjectID, accountID string) error {
+	existingBinding := bson.M{
+		"account_id": accountID,
+		"status":     bson.M{"$ne": models.BindingStatusReleased},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status": models.BindingStatusReleased,
+		},
+	}
+
+	_, err := r.db.GetCollection("proxy_bindings").UpdateMany(sc, existingBinding, update)
+	if err != nil {
+		return err
+	}
+
+	binding := models.ProxyBinding{
+		ProxyID:    proxyID,
+		AccountID:  accountID,
+		BoundAt:    time.Now(),
+		LastUsedAt: time.Now(),
+		Status:     models.BindingStatusActive,
+	}
+
+	_, err = r.db.GetCollection("proxy_bindings").InsertOne(sc, binding)
+	if err != nil {
+		return err
+	}
+
+	proxyUpdate := bson.M{
+		"$set": bson.M{
+			"status": models.ProxyStatusActive,
+		},
+	}
+
+	_, err = r.db.GetCollection("proxies").UpdateOne(sc, bson.M{"_id": proxyID}, proxyUpdate)
+	return err
+}
+
 func (r *ProxyRepository) ReleaseProxyBinding(ctx context.Context, proxyID primitive.ObjectID) error {
 	update := bson.M{
 		"$set": bson.M{
@@ -321,7 +388,7 @@ func (r *ProxyRepository) GetProxyByAccountID(ctx context.Context, accountID str
 	var binding models.ProxyBinding
 	err := r.db.GetCollection("proxy_bindings").FindOne(ctx, bson.M{
 		"account_id": accountID,
-		"status": models.BindingStatusActive,
+		"status":     models.BindingStatusActive,
 	}).Decode(&binding)
 
 	if err != nil {
@@ -335,6 +402,192 @@ func (r *ProxyRepository) GetProxyByAccountID(ctx context.Context, accountID str
 	return r.GetProxyByID(ctx, binding.ProxyID)
 }
 
+func (r *ProxyRepository) RecordBindingUsage(ctx context.Context, report models.ProxyUsageReport) (*models.Proxy, error) {
+	usedAt := report.Timestamp
+	if usedAt.IsZero() {
+		usedAt = time.Now()
+	}
+
+	var binding models.ProxyBinding
+	err := r.db.GetCollection("proxy_bindings").FindOneAndUpdate(ctx, bson.M{
+		"account_id": report.AccountID,
+		"status":     models.BindingStatusActive,
+	}, bson.M{
+		"$inc": bson.M{
+			"bytes_sent":     report.BytesSent,
+			"bytes_received": report.BytesReceived,
+			"request_count":  report.Requests,
+		},
+		"$set": bson.M{
+			"last_used_at": usedAt,
+		},
+	}).Decode(&binding)
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		r.logger.WithError(err).Error("Failed to record binding usage")
+		return nil, err
+	}
+
+	// Password is not needed by callers, so skip the decryption done in GetProxyByID
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"password": 0})
+
+	var proxy models.Proxy
+	err = r.db.GetCollection("proxies").FindOneAndUpdate(ctx, bson.M{"_id": binding.ProxyID}, bson.M{
+		"$inc": bson.M{"bytes_used": report.BytesSent + report.BytesReceived},
+	}, opts).Decode(&proxy)
+
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to update proxy traffic usage")
+		return nil, err
+	}
+
+	return &proxy, nil
+}
+
+// RaiseTrafficAlertLevel stores the new alert level and reports whether it was raised.
+// The conditional update makes sure concurrent consumers alert only once per level.
+func (r *ProxyRepository) RaiseTrafficAlertLevel(ctx context.Context, proxyID primitive.ObjectID, level int) (bool, error) {
+	result, err := r.db.GetCollection("proxies").UpdateOne(ctx, bson.M{
+		"_id":                 proxyID,
+		"traffic_alert_level": bson.M{"$not": bson.M{"$gte": level}},
+	}, bson.M{
+		"$set": bson.M{"traffic_alert_level": level},
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to update proxy traffic alert level")
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// RecordExitIP stores the exit IP seen by a check and reports whether it replaced previousIP.
+// The update is conditional on previousIP so a change seen by concurrent checks is recorded
+// once; the change is added to the history unless it is the first IP seen.
+func (r *ProxyRepository) RecordExitIP(ctx context.Context, proxyID primitive.ObjectID, previousIP, ip string, checkedAt time.Time) (bool, error) {
+	collection := r.db.GetCollection("proxies")
+
+	if previousIP == ip {
+		_, err := collection.UpdateOne(ctx, bson.M{"_id": proxyID}, bson.M{
+			"$set": bson.M{"exit_ip_checked_at": checkedAt},
+		})
+		if err != nil {
+			r.logger.WithError(err).Error("Failed to update proxy exit IP check time")
+			return false, err
+		}
+		return false, nil
+	}
+
+	filter := bson.M{"_id": proxyID, "exit_ip": previousIP}
+	if previousIP == "" {
+		// Proxies never checked have no exit_ip field, null matches it
+		filter["exit_ip"] = bson.M{"$in": bson.A{"", nil}}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"exit_ip":            ip,
+			"exit_ip_checked_at": checkedAt,
+		},
+	}
+	if previousIP != "" {
+		update["$push"] = bson.M{
+			"ip_history": bson.M{
+				"$each": bson.A{models.ProxyIPChange{
+					PreviousIP: previousIP,
+					IP:         ip,
+					DetectedAt: checkedAt,
+				}},
+				"$slice": -models.MaxIPHistory,
+			},
+		}
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to record proxy exit IP")
+		return false, err
+	}
+
+	return result.ModifiedCount > 0 && previousIP != "", nil
+}
+
+func (r *ProxyRepository) GetBindingsByAccountID(ctx context.Context, accountID string) ([]models.ProxyBinding, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "bound_at", Value: -1}})
+
+	cursor, err := r.db.GetCollection("proxy_bindings").Find(ctx, bson.M{"account_id": accountID}, opts)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get proxy bindings by account ID")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var bindings []models.ProxyBinding
+	if err := cursor.All(ctx, &bindings); err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+// RecordProxyUsage adds the binding to the account's proxy history. Rotations don't know the
+// platform, it is taken from the account's earlier records.
+func (r *ProxyRepository) RecordProxyUsage(ctx context.Context, record models.ProxyUsageRecord) error {
+	if record.Platform == "" {
+		platform, err := r.accountPlatform(ctx, record.AccountID)
+		if err != nil {
+			return err
+		}
+		record.Platform = platform
+	}
+
+	if _, err := r.db.GetCollection("proxy_usage_history").InsertOne(ctx, record); err != nil {
+		r.logger.WithError(err).Error("Failed to record proxy usage")
+		return err
+	}
+
+	return nil
+}
+
+// accountPlatform returns the platform of the account's latest proxy history record that has one
+func (r *ProxyRepository) accountPlatform(ctx context.Context, accountID string) (string, error) {
+	var record models.ProxyUsageRecord
+	opts := options.FindOne().SetSort(bson.D{{Key: "bound_at", Value: -1}})
+	err := r.db.GetCollection("proxy_usage_history").FindOne(ctx, bson.M{
+		"account_id": accountID,
+		"platform":   bson.M{"$nin": bson.A{"", nil}},
+	}, opts).Decode(&record)
+	if err != nil && err != mongo.ErrNoDocuments {
+		r.logger.WithError(err).Error("Failed to get account platform from proxy history")
+		return "", err
+	}
+	return record.Platform, nil
+}
+
+// GetProxyHistory returns the proxies the account was bound to, latest first
+func (r *ProxyRepository) GetProxyHistory(ctx context.Context, accountID string) ([]models.ProxyUsageRecord, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "bound_at", Value: -1}})
+
+	cursor, err := r.db.GetCollection("proxy_usage_history").Find(ctx, bson.M{"account_id": accountID}, opts)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get proxy history")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	records := []models.ProxyUsageRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
 func (r *ProxyRepository) CreateIndexes(ctx context.Context) error {
 	proxiesIndexes := []mongo.IndexModel{
 		{
@@ -391,7 +644,7 @@ func (r *ProxyRepository) CreateIndexes(ctx context.Context) error {
 		},
 		{
 			// Unique compound index to ensure only one active binding per proxy
-			Keys: bson.D{{Key: "proxy_id", Value: 1}, {Key: "status", Value: 1}},
+			Keys:    bson.D{{Key: "proxy_id", Value: 1}, {Key: "status", Value: 1}},
 			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"status": models.BindingStatusActive}),
 		},
 	}
@@ -402,6 +655,18 @@ func (r *ProxyRepository) CreateIndexes(ctx context.Context) error {
 		return err
 	}
 
+	historyIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "account_id", Value: 1}, {Key: "bound_at", Value: -1}},
+		},
+	}
+
+	_, err = r.db.GetCollection("proxy_usage_history").Indexes().CreateMany(ctx, historyIndexes)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to create proxy_usage_history indexes")
+		return err
+	}
+
 	return nil
 }
 
@@ -409,6 +674,7 @@ func (r *ProxyRepository) GetProxyStatistics(ctx context.Context) (*models.Proxy
 	stats := &models.ProxyStats{
 		ProxiesByType:    make(map[string]int64),
 		ProxiesByCountry: make(map[string]int64),
+		BytesByProvider:  make(map[string]int64),
 	}
 
 	total, err := r.db.GetCollection("proxies").CountDocuments(ctx, bson.M{})
@@ -443,7 +709,7 @@ func (r *ProxyRepository) GetProxyStatistics(ctx context.Context) (*models.Proxy
 
 	pipeline := []bson.M{
 		{"$group": bson.M{
-			"_id": "$type",
+			"_id":   "$type",
 			"count": bson.M{"$sum": 1},
 		}},
 	}
@@ -466,7 +732,7 @@ func (r *ProxyRepository) GetProxyStatistics(ctx context.Context) (*models.Proxy
 
 	pipeline = []bson.M{
 		{"$group": bson.M{
-			"_id": "$country",
+			"_id":   "$country",
 			"count": bson.M{"$sum": 1},
 		}},
 	}
@@ -489,9 +755,9 @@ func (r *ProxyRepository) GetProxyStatistics(ctx context.Context) (*models.Proxy
 
 	healthPipeline := []bson.M{
 		{"$group": bson.M{
-			"_id": nil,
+			"_id":             nil,
 			"avg_fraud_score": bson.M{"$avg": "$fraud_score"},
-			"avg_latency": bson.M{"$avg": "$latency"},
+			"avg_latency":     bson.M{"$avg": "$latency"},
 		}},
 	}
 
@@ -508,9 +774,92 @@ func (r *ProxyRepository) GetProxyStatistics(ctx context.Context) (*models.Proxy
 		cursor3.Close(ctx)
 	}
 
+	usagePipeline := []bson.M{
+		{"$group": bson.M{
+			"_id":            nil,
+			"bytes_sent":     bson.M{"$sum": "$bytes_sent"},
+			"bytes_received": bson.M{"$sum": "$bytes_received"},
+			"requests":       bson.M{"$sum": "$request_count"},
+		}},
+	}
+
+	cursor4, err := r.db.GetCollection("proxy_bindings").Aggregate(ctx, usagePipeline)
+	if err == nil && cursor4.Next(ctx) {
+		var result struct {
+			BytesSent     int64 `bson:"bytes_sent"`
+			BytesReceived int64 `bson:"bytes_received"`
+			Requests      int64 `bson:"requests"`
+		}
+		if err := cursor4.Decode(&result); err == nil {
+			stats.TotalBytesSent = result.BytesSent
+			stats.TotalBytesReceived = result.BytesReceived
+			stats.TotalRequests = result.Requests
+		}
+		cursor4.Close(ctx)
+	}
+
+	pipeline = []bson.M{
+		{"$group": bson.M{
+			"_id":   "$provider",
+			"bytes": bson.M{"$sum": "$bytes_used"},
+		}},
+	}
+
+	cursor5, err := r.db.GetCollection("proxies").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor5.Close(ctx)
+
+	for cursor5.Next(ctx) {
+		var result struct {
+			ID    string `bson:"_id"`
+			Bytes int64  `bson:"bytes"`
+		}
+		if err := cursor5.Decode(&result); err == nil {
+			stats.BytesByProvider[result.ID] = result.Bytes
+		}
+	}
+
 	return stats, nil
 }
 
+// ProxyExists checks whether a proxy with the address is already stored
+func (r *ProxyRepository) ProxyExists(ctx context.Context, ip string, port int) (bool, error) {
+	count, err := r.db.GetCollection("proxies").CountDocuments(ctx, bson.M{"ip": ip, "port": port})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to check proxy existence")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CountBoundProxiesByCountry returns how many proxies of a country are bound to accounts
+func (r *ProxyRepository) CountBoundProxiesByCountry(ctx context.Context, country string) (int64, error) {
+	occupiedIDs, err := r.getOccupiedProxyIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(occupiedIDs) == 0 {
+		return 0, nil
+	}
+
+	filter := bson.M{
+		"_id":     bson.M{"$in": occupiedIDs},
+		"country": country,
+	}
+
+	count, err := r.db.GetCollection("proxies").CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to count bound proxies by country")
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *ProxyRepository) getOccupiedProxyIDs(ctx context.Context) ([]primitive.ObjectID, error) {
 	filter := bson.M{"status": models.BindingStatusActive}
 
@@ -537,7 +886,7 @@ func (r *ProxyRepository) GetActiveBindingByProxyID(ctx context.Context, proxyID
 	var binding models.ProxyBinding
 	err := r.db.GetCollection("proxy_bindings").FindOne(ctx, bson.M{
 		"proxy_id": proxyID,
-		"status": models.BindingStatusActive,
+		"status":   models.BindingStatusActive,
 	}).Decode(&binding)
 
 	if err != nil {
@@ -567,3 +916,31 @@ func (r *ProxyRepository) GetProxyHealthByID(ctx context.Context, proxyID primit
 
 	return &health, nil
 }
+
+// GetProxyHealthByIDs returns the health record of each proxy that was checked, keyed by proxy ID
+func (r *ProxyRepository) GetProxyHealthByIDs(ctx context.Context, proxyIDs []primitive.ObjectID) (map[primitive.ObjectID]*models.ProxyHealth, error) {
+	health := make(map[primitive.ObjectID]*models.ProxyHealth, len(proxyIDs))
+	if len(proxyIDs) == 0 {
+		return health, nil
+	}
+
+	cursor, err := r.db.GetCollection("proxy_health").Find(ctx, bson.M{
+		"proxy_id": bson.M{"$in": proxyIDs},
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get proxy health")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var record models.ProxyHealth
+		if err := cursor.Decode(&record); err != nil {
+			r.logger.WithError(err).Error("Failed to decode proxy health")
+			continue
+		}
+		health[record.ProxyID] = &record
+	}
+
+	return health, nil
+}
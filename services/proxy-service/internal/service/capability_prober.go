@@ -0,0 +1,382 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+)
+
+const (
+	defaultUDPProbeTarget  = "8.8.8.8:53"
+	defaultQUICProbeTarget = "1.1.1.1:443"
+
+	socks5Version        = 0x05
+	socks5AuthNone       = 0x00
+	socks5AuthPassword   = 0x02
+	socks5CmdUDPAssoc    = 0x03
+	socks5AddrIPv4       = 0x01
+	socks5AddrDomain     = 0x03
+	socks5AddrIPv6       = 0x04
+	socks5ReplySucceeded = 0x00
+
+	// quicProbeVersion follows the 0x?a?a?a?a pattern reserved to force version negotiation (RFC 9000, 15)
+	quicProbeVersion = 0x1a2a3a4a
+	// quicMinDatagram is the size clients must pad their first datagram to, servers drop smaller ones
+	quicMinDatagram = 1200
+)
+
+var (
+	ErrUDPAssociateRejected = errors.New("proxy rejected UDP ASSOCIATE")
+	ErrUnexpectedReply      = errors.New("unexpected reply")
+)
+
+// CapabilityProber checks which protocols besides TCP a proxy exit can carry.
+// UDP support is verified with a SOCKS5 UDP ASSOCIATE and a DNS query through the relay,
+// QUIC with a version negotiation exchange over the same relay.
+type CapabilityProber struct {
+	udpTarget  string
+	quicTarget string
+	timeout    time.Duration
+}
+
+func NewCapabilityProber(udpTarget, quicTarget string, timeout time.Duration) *CapabilityProber {
+	if udpTarget == "" {
+		udpTarget = defaultUDPProbeTarget
+	}
+	if quicTarget == "" {
+		quicTarget = defaultQUICProbeTarget
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return &CapabilityProber{
+		udpTarget:  udpTarget,
+		quicTarget: quicTarget,
+		timeout:    timeout,
+	}
+}
+
+// Probe returns the capabilities of the proxy. HTTP proxies can only tunnel TCP,
+// so they are reported without probing. The error explains why UDP is unsupported.
+func (p *CapabilityProber) Probe(ctx context.Context, proxy *models.Proxy) (models.ProxyCapabilities, error) {
+	capabilities := models.ProxyCapabilities{CheckedAt: time.Now()}
+
+	if proxy.Protocol != models.ProtocolSOCKS5 {
+		return capabilities, nil
+	}
+
+	relay, err := p.associate(ctx, proxy)
+	if err != nil {
+		return capabilities, err
+	}
+	defer relay.Close()
+
+	query := newDNSProbe()
+	validDNSReply := func(reply []byte) error {
+		return parseDNSReply(reply, query)
+	}
+	if err := relay.exchange(p.udpTarget, query, p.timeout, validDNSReply); err != nil {
+		return capabilities, fmt.Errorf("udp relay to %s failed: %w", p.udpTarget, err)
+	}
+	capabilities.UDP = true
+
+	scid := make([]byte, 8)
+	rand.Read(scid)
+	validQUICReply := func(reply []byte) error {
+		return parseVersionNegotiation(reply, scid)
+	}
+	if err := relay.exchange(p.quicTarget, newQUICProbe(scid), p.timeout, validQUICReply); err != nil {
+		return capabilities, fmt.Errorf("quic probe to %s failed: %w", p.quicTarget, err)
+	}
+	capabilities.QUIC = true
+
+	return capabilities, nil
+}
+
+// socks5UDPRelay is an established UDP association. The control connection must stay
+// open for as long as the relay is used, the proxy drops the association when it closes.
+type socks5UDPRelay struct {
+	control net.Conn
+	conn    *net.UDPConn
+}
+
+// associate performs the SOCKS5 handshake and requests a UDP relay (RFC 1928, 1929)
+func (p *CapabilityProber) associate(ctx context.Context, proxy *models.Proxy) (*socks5UDPRelay, error) {
+	proxyAddr := net.JoinHostPort(proxy.IP, strconv.Itoa(proxy.Port))
+
+	dialer := &net.Dialer{Timeout: p.timeout}
+	control, err := dialer.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	control.SetDeadline(time.Now().Add(p.timeout))
+
+	relayAddr, err := socks5UDPAssociate(control, proxy.Username, proxy.Password)
+	if err != nil {
+		control.Close()
+		return nil, err
+	}
+
+	// Many proxies answer with an unspecified address meaning "the host you connected to"
+	if relayAddr.IP == nil || relayAddr.IP.IsUnspecified() {
+		relayAddr.IP = control.RemoteAddr().(*net.TCPAddr).IP
+	}
+
+	conn, err := net.DialUDP("udp", nil, relayAddr)
+	if err != nil {
+		control.Close()
+		return nil, fmt.Errorf("failed to open udp relay socket: %w", err)
+	}
+
+	return &socks5UDPRelay{control: control, conn: conn}, nil
+}
+
+func socks5UDPAssociate(conn net.Conn, username, password string) (*net.UDPAddr, error) {
+	method := byte(socks5AuthNone)
+	if username != "" {
+		method = socks5AuthPassword
+	}
+
+	if _, err := conn.Write([]byte{socks5Version, 1, method}); err != nil {
+		return nil, err
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return nil, fmt.Errorf("failed to read method selection: %w", err)
+	}
+	if reply[0] != socks5Version || reply[1] != method {
+		return nil, fmt.Errorf("%w: auth method %#x", ErrUnexpectedReply, reply[1])
+	}
+
+	if method == socks5AuthPassword {
+		auth := []byte{0x01, byte(len(username))}
+		auth = append(auth, username...)
+		auth = append(auth, byte(len(password)))
+		auth = append(auth, password...)
+		if _, err := conn.Write(auth); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(conn, reply); err != nil {
+			return nil, fmt.Errorf("failed to read auth status: %w", err)
+		}
+		if reply[1] != 0x00 {
+			return nil, errors.New("proxy authentication failed")
+		}
+	}
+
+	// DST.ADDR and DST.PORT are zero: the client doesn't know which address it will send from
+	request := []byte{socks5Version, socks5CmdUDPAssoc, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0}
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, fmt.Errorf("failed to read associate reply: %w", err)
+	}
+	if header[1] != socks5ReplySucceeded {
+		return nil, fmt.Errorf("%w: reply code %#x", ErrUDPAssociateRejected, header[1])
+	}
+
+	host, port, err := readSOCKS5Addr(conn, header[3])
+	if err != nil {
+		return nil, err
+	}
+
+	addr := &net.UDPAddr{Port: port}
+	if ip := net.ParseIP(host); ip != nil {
+		addr.IP = ip
+	} else if host != "" {
+		resolved, err := net.ResolveUDPAddr("udp", net.JoinHostPort(host, strconv.Itoa(port)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve relay address: %w", err)
+		}
+		addr = resolved
+	}
+
+	return addr, nil
+}
+
+func readSOCKS5Addr(r io.Reader, addrType byte) (string, int, error) {
+	var host string
+
+	switch addrType {
+	case socks5AddrIPv4, socks5AddrIPv6:
+		size := net.IPv4len
+		if addrType == socks5AddrIPv6 {
+			size = net.IPv6len
+		}
+		ip := make([]byte, size)
+		if _, err := io.ReadFull(r, ip); err != nil {
+			return "", 0, err
+		}
+		host = net.IP(ip).String()
+	case socks5AddrDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(r, length); err != nil {
+			return "", 0, err
+		}
+		domain := make([]byte, length[0])
+		if _, err := io.ReadFull(r, domain); err != nil {
+			return "", 0, err
+		}
+		host = string(domain)
+	default:
+		return "", 0, fmt.Errorf("%w: address type %#x", ErrUnexpectedReply, addrType)
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(r, port); err != nil {
+		return "", 0, err
+	}
+
+	return host, int(binary.BigEndian.Uint16(port)), nil
+}
+
+// exchange sends one datagram to target through the relay and waits for a reply accepted by validate
+func (r *socks5UDPRelay) exchange(target string, payload []byte, timeout time.Duration, validate func([]byte) error) error {
+	header, err := socks5UDPHeader(target)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+
+	r.conn.SetReadDeadline(time.Now().Add(timeout))
+
+	buf := make([]byte, 65535)
+	for {
+		n, err := r.conn.Read(buf)
+		if err != nil {
+			return err
+		}
+
+		data, err := stripSOCKS5UDPHeader(buf[:n])
+		if err != nil {
+			continue
+		}
+
+		// Late replies to an earlier probe may still arrive, keep reading until the deadline
+		if validate(data) == nil {
+			return nil
+		}
+	}
+}
+
+func (r *socks5UDPRelay) Close() {
+	r.conn.Close()
+	r.control.Close()
+}
+
+// socks5UDPHeader builds the RSV, FRAG, ATYP, DST.ADDR and DST.PORT prefix of a relayed datagram
+func socks5UDPHeader(target string) ([]byte, error) {
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port in %s", target)
+	}
+
+	header := []byte{0x00, 0x00, 0x00}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			header = append(header, socks5AddrIPv4)
+			header = append(header, ip4...)
+		} else {
+			header = append(header, socks5AddrIPv6)
+			header = append(header, ip.To16()...)
+		}
+	} else {
+		header = append(header, socks5AddrDomain, byte(len(host)))
+		header = append(header, host...)
+	}
+
+	return binary.BigEndian.AppendUint16(header, uint16(port)), nil
+}
+
+func stripSOCKS5UDPHeader(datagram []byte) ([]byte, error) {
+	if len(datagram) < 4 || datagram[2] != 0x00 {
+		return nil, ErrUnexpectedReply
+	}
+
+	r := bytes.NewReader(datagram[4:])
+	if _, _, err := readSOCKS5Addr(r, datagram[3]); err != nil {
+		return nil, err
+	}
+
+	return datagram[len(datagram)-r.Len():], nil
+}
+
+// newDNSProbe builds a recursive A query for example.com
+func newDNSProbe() []byte {
+	query := make([]byte, 12)
+	rand.Read(query[:2])
+	query[2] = 0x01 // RD
+	query[5] = 0x01 // QDCOUNT
+
+	for _, label := range []string{"example", "com"} {
+		query = append(query, byte(len(label)))
+		query = append(query, label...)
+	}
+
+	return append(query, 0x00, 0x00, 0x01, 0x00, 0x01)
+}
+
+// parseDNSReply checks that the reply is a response to the query
+func parseDNSReply(reply, query []byte) error {
+	if len(reply) < 12 || reply[2]&0x80 == 0 {
+		return fmt.Errorf("%w: not a DNS response", ErrUnexpectedReply)
+	}
+	if !bytes.Equal(reply[:2], query[:2]) {
+		return fmt.Errorf("%w: DNS id mismatch", ErrUnexpectedReply)
+	}
+	return nil
+}
+
+// newQUICProbe builds a padded long header Initial-sized packet with an unsupported version.
+// Any QUIC server has to answer it with Version Negotiation, no handshake keys are needed.
+func newQUICProbe(scid []byte) []byte {
+	dcid := make([]byte, 8)
+	rand.Read(dcid)
+
+	packet := []byte{0xc0}
+	packet = binary.BigEndian.AppendUint32(packet, quicProbeVersion)
+	packet = append(packet, byte(len(dcid)))
+	packet = append(packet, dcid...)
+	packet = append(packet, byte(len(scid)))
+	packet = append(packet, scid...)
+
+	return append(packet, make([]byte, quicMinDatagram-len(packet))...)
+}
+
+// parseVersionNegotiation checks that the reply is a Version Negotiation packet addressed to scid
+func parseVersionNegotiation(reply, scid []byte) error {
+	if len(reply) < 7 || reply[0]&0x80 == 0 {
+		return fmt.Errorf("%w: not a QUIC long header packet", ErrUnexpectedReply)
+	}
+	if binary.BigEndian.Uint32(reply[1:5]) != 0 {
+		return fmt.Errorf("%w: not a version negotiation packet", ErrUnexpectedReply)
+	}
+
+	dcidLen := int(reply[5])
+	if len(reply) < 6+dcidLen || !bytes.Equal(reply[6:6+dcidLen], scid) {
+		return fmt.Errorf("%w: connection id mismatch", ErrUnexpectedReply)
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSOCKS5Server accepts UDP ASSOCIATE requests and relays datagrams to their destination
+type fakeSOCKS5Server struct {
+	listener    net.Listener
+	rejectReply byte
+}
+
+func newFakeSOCKS5Server(t *testing.T, rejectReply byte) *fakeSOCKS5Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeSOCKS5Server{listener: listener, rejectReply: rejectReply}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+
+	return s
+}
+
+func (s *fakeSOCKS5Server) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSOCKS5Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSOCKS5Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	greeting := make([]byte, 3)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		return
+	}
+	conn.Write([]byte{socks5Version, greeting[2]})
+
+	if greeting[2] == socks5AuthPassword {
+		header := make([]byte, 2)
+		io.ReadFull(conn, header)
+		io.ReadFull(conn, make([]byte, header[1]))
+		passLen := make([]byte, 1)
+		io.ReadFull(conn, passLen)
+		io.ReadFull(conn, make([]byte, passLen[0]))
+		conn.Write([]byte{0x01, 0x00})
+	}
+
+	request := make([]byte, 10)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return
+	}
+
+	if s.rejectReply != socks5ReplySucceeded {
+		conn.Write([]byte{socks5Version, s.rejectReply, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+		return
+	}
+
+	relay, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		return
+	}
+	defer relay.Close()
+
+	// Reply with an unspecified address like most real proxies do
+	reply := []byte{socks5Version, socks5ReplySucceeded, 0x00, socks5AddrIPv4, 0, 0, 0, 0}
+	conn.Write(binary.BigEndian.AppendUint16(reply, uint16(relay.LocalAddr().(*net.UDPAddr).Port)))
+
+	go relayDatagrams(relay)
+	io.Copy(io.Discard, conn)
+}
+
+func relayDatagrams(relay *net.UDPConn) {
+	buf := make([]byte, 65535)
+	for {
+		n, client, err := relay.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		header := buf[:10]
+		target := &net.UDPAddr{IP: net.IP(header[4:8]), Port: int(binary.BigEndian.Uint16(header[8:10]))}
+
+		upstream, err := net.DialUDP("udp", nil, target)
+		if err != nil {
+			continue
+		}
+		upstream.Write(buf[10:n])
+		upstream.SetReadDeadline(time.Now().Add(time.Second))
+
+		response := make([]byte, 65535)
+		m, err := upstream.Read(response)
+		upstream.Close()
+		if err != nil {
+			continue
+		}
+
+		relay.WriteToUDP(append(append([]byte{}, header...), response[:m]...), client)
+	}
+}
+
+// startUDPServer answers every datagram with the result of respond
+func startUDPServer(t *testing.T, respond func([]byte) []byte) string {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, addr, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			if reply := respond(buf[:n]); reply != nil {
+				conn.WriteToUDP(reply, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func fakeDNSServer(query []byte) []byte {
+	reply := append([]byte{}, query...)
+	reply[2] |= 0x80
+	return reply
+}
+
+func fakeQUICServer(packet []byte) []byte {
+	if len(packet) < quicMinDatagram {
+		return nil
+	}
+
+	dcidLen := int(packet[5])
+	dcid := packet[6 : 6+dcidLen]
+	scidLen := int(packet[6+dcidLen])
+	scid := packet[7+dcidLen : 7+dcidLen+scidLen]
+
+	reply := []byte{0x80, 0, 0, 0, 0, byte(len(scid))}
+	reply = append(reply, scid...)
+	reply = append(reply, byte(len(dcid)))
+	reply = append(reply, dcid...)
+	return binary.BigEndian.AppendUint32(reply, 0x00000001)
+}
+
+func socks5Proxy(port int) *models.Proxy {
+	return &models.Proxy{
+		IP:       "127.0.0.1",
+		Port:     port,
+		Protocol: models.ProtocolSOCKS5,
+		Username: "user",
+		Password: "pass",
+	}
+}
+
+// Test Probe through a relay with UDP and QUIC reachable
+func TestCapabilityProber_UDPAndQUIC(t *testing.T) {
+	server := newFakeSOCKS5Server(t, socks5ReplySucceeded)
+	prober := NewCapabilityProber(startUDPServer(t, fakeDNSServer), startUDPServer(t, fakeQUICServer), time.Second)
+
+	capabilities, err := prober.Probe(context.Background(), socks5Proxy(server.port()))
+	require.NoError(t, err)
+	assert.True(t, capabilities.UDP)
+	assert.True(t, capabilities.QUIC)
+	assert.False(t, capabilities.CheckedAt.IsZero())
+}
+
+// Test Probe when the QUIC endpoint doesn't answer
+func TestCapabilityProber_UDPOnly(t *testing.T) {
+	server := newFakeSOCKS5Server(t, socks5ReplySucceeded)
+	silent := startUDPServer(t, func([]byte) []byte { return nil })
+	prober := NewCapabilityProber(startUDPServer(t, fakeDNSServer), silent, 200*time.Millisecond)
+
+	capabilities, err := prober.Probe(context.Background(), socks5Proxy(server.port()))
+	assert.Error(t, err)
+	assert.True(t, capabilities.UDP)
+	assert.False(t, capabilities.QUIC)
+}
+
+// Test Probe when the proxy doesn't support UDP ASSOCIATE
+func TestCapabilityProber_AssociateRejected(t *testing.T) {
+	server := newFakeSOCKS5Server(t, 0x07) // Command not supported
+	prober := NewCapabilityProber(startUDPServer(t, fakeDNSServer), startUDPServer(t, fakeQUICServer), time.Second)
+
+	capabilities, err := prober.Probe(context.Background(), socks5Proxy(server.port()))
+	assert.ErrorIs(t, err, ErrUDPAssociateRejected)
+	assert.False(t, capabilities.UDP)
+	assert.False(t, capabilities.QUIC)
+}
+
+// Test Probe skips HTTP proxies
+func TestCapabilityProber_HTTPProxy(t *testing.T) {
+	prober := NewCapabilityProber("", "", time.Second)
+
+	capabilities, err := prober.Probe(context.Background(), &models.Proxy{IP: "127.0.0.1", Port: 1, Protocol: models.ProtocolHTTP})
+	require.NoError(t, err)
+	assert.Empty(t, capabilities.List())
+	assert.False(t, capabilities.CheckedAt.IsZero())
+}
+
+func TestParseVersionNegotiation(t *testing.T) {
+	scid := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	packet := newQUICProbe(scid)
+	assert.Len(t, packet, quicMinDatagram)
+	assert.Equal(t, uint32(quicProbeVersion), binary.BigEndian.Uint32(packet[1:5]))
+
+	assert.NoError(t, parseVersionNegotiation(fakeQUICServer(packet), scid))
+	assert.Error(t, parseVersionNegotiation(fakeQUICServer(packet), []byte{8, 7, 6, 5, 4, 3, 2, 1}))
+	assert.Error(t, parseVersionNegotiation([]byte{0x40, 0, 0, 0, 1}, scid))
+}
+
+func TestSOCKS5UDPHeader(t *testing.T) {
+	header, err := socks5UDPHeader("8.8.8.8:53")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, socks5AddrIPv4, 8, 8, 8, 8, 0, 53}, header)
+
+	header, err = socks5UDPHeader("dns.google:53")
+	require.NoError(t, err)
+	assert.Equal(t, byte(socks5AddrDomain), header[3])
+
+	payload, err := stripSOCKS5UDPHeader(append(header, 0xaa))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xaa}, payload)
+}
+
+func TestProxyCapabilities(t *testing.T) {
+	capabilities := models.ProxyCapabilities{UDP: true}
+
+	assert.True(t, capabilities.Has(models.CapabilityUDP))
+	assert.False(t, capabilities.Has(models.CapabilityQUIC))
+	assert.Equal(t, []string{"udp"}, capabilities.List())
+	assert.Equal(t, []string{"quic"}, missingCapabilities(capabilities, []string{"udp", "quic"}))
+	assert.True(t, models.IsProxyCapability("quic"))
+	assert.False(t, models.IsProxyCapability("tcp"))
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// legacyCheckURL is measured against when no checker endpoints are configured
+const legacyCheckURL = "http://httpbin.org/ip"
+
+// DefaultCountryRegions maps proxy countries to checker regions, configured pairs are added on top
+var DefaultCountryRegions = map[string]string{
+	"RU": "ru", "BY": "ru", "KZ": "ru", "UZ": "ru", "KG": "ru", "AM": "ru", "AZ": "ru", "GE": "ru",
+	"UA": "eu", "PL": "eu", "DE": "eu", "NL": "eu", "FR": "eu", "GB": "eu", "FI": "eu", "LV": "eu",
+	"LT": "eu", "EE": "eu", "CZ": "eu", "IT": "eu", "ES": "eu", "SE": "eu", "TR": "eu",
+	"US": "us", "CA": "us", "MX": "us", "BR": "us",
+	"IN": "asia", "SG": "asia", "JP": "asia", "HK": "asia", "ID": "asia", "VN": "asia", "TH": "asia",
+}
+
+// DefaultPlatformRegions maps platforms to the region of their datacenters, configured pairs
+// are added on top
+var DefaultPlatformRegions = map[string]string{
+	"vk":       "ru",
+	"mail":     "ru",
+	"max":      "ru",
+	"telegram": "eu",
+}
+
+// CheckRegions routes latency checks to the checker endpoint closest to a proxy. Measured from
+// a single far away checker, a proxy looks slow for the distance to the checker rather than
+// for its own quality, so every proxy is checked from its own region and additionally from the
+// datacenter regions of the platforms it may be allocated for.
+type CheckRegions struct {
+	endpoints     map[string]string // region -> checker URL
+	countries     map[string]string // country -> region
+	platforms     map[string]string // platform -> region
+	defaultRegion string
+}
+
+// ParseCheckRegions parses "region=url" endpoints and "key=region" country and platform pairs.
+// Without endpoints every check goes to the legacy checker and no region is recorded.
+func ParseCheckRegions(endpoints, countries, platforms, defaultRegion string) (*CheckRegions, error) {
+	r := &CheckRegions{
+		endpoints:     make(map[string]string),
+		countries:     make(map[string]string, len(DefaultCountryRegions)),
+		platforms:     make(map[string]string, len(DefaultPlatformRegions)),
+		defaultRegion: strings.ToLower(strings.TrimSpace(defaultRegion)),
+	}
+
+	for country, region := range DefaultCountryRegions {
+		r.countries[country] = region
+	}
+	for platform, region := range DefaultPlatformRegions {
+		r.platforms[platform] = region
+	}
+
+	pairs, err := parseRegionPairs(endpoints, "checker endpoint")
+	if err != nil {
+		return nil, err
+	}
+	for region, endpoint := range pairs {
+		parsed, err := url.Parse(endpoint)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid checker endpoint %q of region %s", endpoint, region)
+		}
+		r.endpoints[strings.ToLower(region)] = endpoint
+	}
+
+	pairs, err = parseRegionPairs(countries, "country region")
+	if err != nil {
+		return nil, err
+	}
+	for country, region := range pairs {
+		r.countries[strings.ToUpper(country)] = strings.ToLower(region)
+	}
+
+	pairs, err = parseRegionPairs(platforms, "platform region")
+	if err != nil {
+		return nil, err
+	}
+	for platform, region := range pairs {
+		r.platforms[strings.ToLower(platform)] = strings.ToLower(region)
+	}
+
+	if r.defaultRegion != "" && len(r.endpoints) > 0 && r.endpoints[r.defaultRegion] == "" {
+		return nil, fmt.Errorf("default check region %q has no checker endpoint", r.defaultRegion)
+	}
+
+	return r, nil
+}
+
+func parseRegionPairs(value, kind string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return pairs, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid %s %q", kind, pair)
+		}
+		pairs[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return pairs, nil
+}
+
+// Closest returns the checker region for a proxy country: the country's own region when it
+// has an endpoint, otherwise the default region. Empty means the legacy checker.
+func (r *CheckRegions) Closest(country string) string {
+	if region := r.countries[strings.ToUpper(country)]; r.endpoints[region] != "" {
+		return region
+	}
+	return r.defaultRegion
+}
+
+// Plan returns the regions to check a proxy from, the closest one first followed by the
+// platform regions with an endpoint
+func (r *CheckRegions) Plan(country string) []string {
+	closest := r.Closest(country)
+	plan := []string{closest}
+	if len(r.endpoints) == 0 {
+		return plan
+	}
+
+	seen := map[string]bool{closest: true}
+	for _, region := range r.platformRegions() {
+		if !seen[region] {
+			seen[region] = true
+			plan = append(plan, region)
+		}
+	}
+	return plan
+}
+
+// platformRegions returns the distinct platform regions with an endpoint, sorted for a stable plan
+func (r *CheckRegions) platformRegions() []string {
+	var regions []string
+	seen := make(map[string]bool)
+	for _, region := range r.platforms {
+		if r.endpoints[region] != "" && !seen[region] {
+			seen[region] = true
+			regions = append(regions, region)
+		}
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// URL returns the checker endpoint of a region, the legacy checker for an empty region
+func (r *CheckRegions) URL(region string) string {
+	if endpoint := r.endpoints[region]; endpoint != "" {
+		return endpoint
+	}
+	return legacyCheckURL
+}
+
+// PlatformRegion returns the datacenter region of a platform, empty when it is unknown or no
+// checker runs there
+func (r *CheckRegions) PlatformRegion(platform string) string {
+	region := r.platforms[strings.ToLower(platform)]
+	if r.endpoints[region] == "" {
+		return ""
+	}
+	return region
+}
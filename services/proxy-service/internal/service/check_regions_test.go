@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCheckRegions(t *testing.T) {
+	regions, err := ParseCheckRegions(
+		"ru=http://checker-ru.test/ip, eu=http://checker-eu.test/ip",
+		"AE=eu",
+		"telegram=eu,ok=ru",
+		"eu",
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ru", regions.Closest("ru"))
+	assert.Equal(t, "eu", regions.Closest("AE"), "configured countries are added to the defaults")
+	assert.Equal(t, "eu", regions.Closest("US"), "regions without a checker fall back to the default")
+	assert.Equal(t, "eu", regions.Closest(""))
+	assert.Equal(t, "http://checker-ru.test/ip", regions.URL("ru"))
+	assert.Equal(t, legacyCheckURL, regions.URL("us"))
+
+	assert.Equal(t, "ru", regions.PlatformRegion("VK"))
+	assert.Equal(t, "ru", regions.PlatformRegion("ok"))
+	assert.Equal(t, "", regions.PlatformRegion("unknown"))
+
+	assert.Equal(t, []string{"ru", "eu"}, regions.Plan("RU"))
+	assert.Equal(t, []string{"eu", "ru"}, regions.Plan("DE"))
+}
+
+func TestParseCheckRegionsInvalid(t *testing.T) {
+	_, err := ParseCheckRegions("ru", "", "", "")
+	assert.Error(t, err)
+
+	_, err = ParseCheckRegions("ru=checker-ru", "", "", "")
+	assert.Error(t, err, "endpoints need a scheme and host")
+
+	_, err = ParseCheckRegions("ru=http://checker-ru.test/ip", "RU=", "", "")
+	assert.Error(t, err)
+
+	_, err = ParseCheckRegions("ru=http://checker-ru.test/ip", "", "", "us")
+	assert.Error(t, err, "the default region needs a checker")
+}
+
+func TestCheckRegionsWithoutEndpoints(t *testing.T) {
+	regions, err := ParseCheckRegions("", "", "", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{""}, regions.Plan("RU"))
+	assert.Equal(t, legacyCheckURL, regions.URL(regions.Closest("RU")))
+	assert.Equal(t, "", regions.PlatformRegion("vk"))
+}
+
+func TestHealthCheckerMeasureLatency(t *testing.T) {
+	// An HTTP proxy receives the absolute checker URL, the test server answers for every checker
+	var mu sync.Mutex
+	var requested []string
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Host)
+		mu.Unlock()
+		if r.URL.Host == "checker-eu.test" {
+			http.Error(w, "unreachable", http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"origin": "203.0.113.7"}`))
+	}))
+	defer proxyServer.Close()
+
+	host, portStr, err := net.SplitHostPort(proxyServer.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	regions, err := ParseCheckRegions("ru=http://checker-ru.test/ip,asia=http://checker-asia.test/ip", "", "", "")
+	require.NoError(t, err)
+	checker := &HealthChecker{logger: logger.NewFromConfig(logger.Config{Output: io.Discard}), regions: regions}
+
+	proxy := &models.Proxy{IP: host, Port: port, Protocol: models.ProtocolHTTP, Country: "JP"}
+	health := &models.ProxyHealth{}
+	checker.measureLatency(context.Background(), proxy, health)
+
+	assert.Equal(t, []string{"checker-asia.test", "checker-ru.test"}, requested)
+	assert.Equal(t, "asia", health.CheckRegion)
+	assert.GreaterOrEqual(t, health.Latency, 0)
+	assert.Contains(t, health.RegionLatency, "asia")
+	assert.Contains(t, health.RegionLatency, "ru")
+	assert.Equal(t, "ru", checker.PlatformRegion("vk"))
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+)
+
+// subscriberBuffer is how many events a subscriber may lag behind before events are dropped for it
+const subscriberBuffer = 64
+
+// EventBus fans proxy events out to in-process subscribers such as WatchProxyEvents streams.
+// Publishing never blocks: a subscriber that does not keep up loses events instead of
+// stalling allocation or health checks. A nil bus discards everything.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[uint64]*subscriber
+	nextID      uint64
+}
+
+type subscriber struct {
+	filter models.ProxyEventFilter
+	events chan models.ProxyEvent
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[uint64]*subscriber),
+	}
+}
+
+// Subscribe registers a subscriber. The returned cancel func unregisters it and closes the channel.
+func (b *EventBus) Subscribe(filter models.ProxyEventFilter) (<-chan models.ProxyEvent, func()) {
+	sub := &subscriber{
+		filter: filter,
+		events: make(chan models.ProxyEvent, subscriberBuffer),
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = sub
+	SetEventSubscribers(float64(len(b.subscribers)))
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, id)
+			SetEventSubscribers(float64(len(b.subscribers)))
+			b.mu.Unlock()
+			close(sub.events)
+		})
+	}
+
+	return sub.events, cancel
+}
+
+// Publish delivers the event to every matching subscriber
+func (b *EventBus) Publish(event models.ProxyEvent) {
+	if b == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			RecordDroppedProxyEvent(string(event.Type))
+		}
+	}
+}
+
+// proxyEvent fills the proxy fields shared by all event types
+func proxyEvent(eventType models.ProxyEventType, proxy *models.Proxy, accountID string) models.ProxyEvent {
+	return models.ProxyEvent{
+		Type:      eventType,
+		ProxyID:   proxy.ID.Hex(),
+		AccountID: accountID,
+		Provider:  proxy.Provider,
+		Country:   proxy.Country,
+		ProxyType: string(proxy.Type),
+		Timestamp: time.Now(),
+	}
+}
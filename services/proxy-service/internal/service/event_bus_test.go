@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBusDeliversMatchingEvents(t *testing.T) {
+	bus := NewEventBus()
+
+	banned, cancelBanned := bus.Subscribe(models.ProxyEventFilter{Types: []models.ProxyEventType{models.ProxyEventBanned}})
+	defer cancelBanned()
+	all, cancelAll := bus.Subscribe(models.ProxyEventFilter{})
+	defer cancelAll()
+
+	bus.Publish(models.ProxyEvent{Type: models.ProxyEventAllocated, ProxyID: "p1"})
+	bus.Publish(models.ProxyEvent{Type: models.ProxyEventBanned, ProxyID: "p2"})
+
+	event := <-banned
+	assert.Equal(t, "p2", event.ProxyID)
+	assert.False(t, event.Timestamp.IsZero())
+	assert.Empty(t, banned)
+
+	assert.Equal(t, "p1", (<-all).ProxyID)
+	assert.Equal(t, "p2", (<-all).ProxyID)
+}
+
+func TestEventBusDropsEventsForSlowSubscribers(t *testing.T) {
+	bus := NewEventBus()
+	events, cancel := bus.Subscribe(models.ProxyEventFilter{})
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		bus.Publish(models.ProxyEvent{Type: models.ProxyEventReleased})
+	}
+
+	assert.Len(t, events, subscriberBuffer)
+}
+
+func TestEventBusCancelClosesChannel(t *testing.T) {
+	bus := NewEventBus()
+	events, cancel := bus.Subscribe(models.ProxyEventFilter{})
+
+	cancel()
+	cancel()
+
+	_, ok := <-events
+	require.False(t, ok)
+	bus.Publish(models.ProxyEvent{Type: models.ProxyEventRotated})
+}
+
+func TestNilEventBusDiscardsEvents(t *testing.T) {
+	var bus *EventBus
+	assert.NotPanics(t, func() {
+		bus.Publish(models.ProxyEvent{Type: models.ProxyEventBanned})
+	})
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+)
+
+const defaultExitIPCheckURL = "http://httpbin.org/ip"
+
+var ErrInvalidExitIP = errors.New("exit IP service returned no valid address")
+
+// ExitIPChecker resolves the address a proxy exits from by asking an IP echo service
+// through it. Mobile proxies rotate that address on the carrier's schedule while the
+// proxy itself, and the account bound to it, stay the same.
+type ExitIPChecker struct {
+	checkURL string
+	timeout  time.Duration
+}
+
+func NewExitIPChecker(checkURL string, timeout time.Duration) *ExitIPChecker {
+	if checkURL == "" {
+		checkURL = defaultExitIPCheckURL
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &ExitIPChecker{
+		checkURL: checkURL,
+		timeout:  timeout,
+	}
+}
+
+// Check returns the current exit IP of the proxy
+func (c *ExitIPChecker) Check(ctx context.Context, proxy *models.Proxy) (string, error) {
+	proxyURL := &url.URL{
+		Scheme: string(proxy.Protocol),
+		Host:   net.JoinHostPort(proxy.IP, fmt.Sprint(proxy.Port)),
+	}
+	if proxy.Username != "" {
+		proxyURL.User = url.UserPassword(proxy.Username, proxy.Password)
+	}
+
+	client := &http.Client{
+		Timeout: c.timeout,
+		Transport: &http.Transport{
+			Proxy:             http.ProxyURL(proxyURL),
+			DisableKeepAlives: true, // A reused connection could hide a rotation
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.checkURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("exit IP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("exit IP service returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", err
+	}
+
+	return parseExitIP(body)
+}
+
+// parseExitIP accepts the httpbin {"origin": "..."} and ipify {"ip": "..."} formats as well
+// as a plain text address. httpbin lists forwarding hops after the client address.
+func parseExitIP(body []byte) (string, error) {
+	var result struct {
+		Origin string `json:"origin"`
+		IP     string `json:"ip"`
+	}
+
+	value := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &result); err == nil {
+		value = result.Origin
+		if value == "" {
+			value = result.IP
+		}
+	}
+
+	value = strings.TrimSpace(strings.Split(value, ",")[0])
+	if net.ParseIP(value) == nil {
+		return "", ErrInvalidExitIP
+	}
+	return value, nil
+}
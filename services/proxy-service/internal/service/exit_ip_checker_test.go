@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExitIP(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"httpbin", `{"origin": "203.0.113.7"}`, "203.0.113.7"},
+		{"httpbin with forwarding hops", `{"origin": "203.0.113.7, 10.0.0.1"}`, "203.0.113.7"},
+		{"ipify", `{"ip":"2001:db8::1"}`, "2001:db8::1"},
+		{"plain text", "198.51.100.4\n", "198.51.100.4"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ip, err := parseExitIP([]byte(tc.body))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ip)
+		})
+	}
+
+	_, err := parseExitIP([]byte(`{"origin": ""}`))
+	assert.ErrorIs(t, err, ErrInvalidExitIP)
+
+	_, err = parseExitIP([]byte("<html>blocked</html>"))
+	assert.ErrorIs(t, err, ErrInvalidExitIP)
+}
+
+func TestExitIPCheckerCheck(t *testing.T) {
+	// An HTTP proxy receives the absolute check URL, the test server answers for the echo service
+	var requested string
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+		assert.NotEmpty(t, r.Header.Get("Proxy-Authorization"))
+		w.Write([]byte(`{"origin": "203.0.113.7"}`))
+	}))
+	defer proxyServer.Close()
+
+	host, portStr, err := net.SplitHostPort(proxyServer.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	checker := NewExitIPChecker("http://ip.example.test/ip", 0)
+	ip, err := checker.Check(context.Background(), &models.Proxy{
+		IP:       host,
+		Port:     port,
+		Protocol: models.ProtocolHTTP,
+		Username: "user",
+		Password: "pass",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", ip)
+	assert.Equal(t, "http://ip.example.test/ip", requested)
+}
@@ -0,0 +1,529 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+)
+
+const (
+	fraudProviderIPQS        = "ipqs"
+	fraudProviderScamalytics = "scamalytics"
+	fraudProviderHeuristic   = "heuristic"
+
+	fraudCacheKeyPrefix    = "proxy:fraud:"
+	defaultFraudCacheTTL   = 24 * time.Hour
+	defaultFraudAPITimeout = 10 * time.Second
+)
+
+// ErrFraudCheckerNotConfigured is returned by checkers that lack credentials; such checkers are skipped
+var ErrFraudCheckerNotConfigured = errors.New("fraud checker not configured")
+
+// DefaultFraudWeights is used when no weights are configured
+var DefaultFraudWeights = map[string]float64{
+	fraudProviderIPQS:        0.5,
+	fraudProviderScamalytics: 0.3,
+	fraudProviderHeuristic:   0.2,
+}
+
+// FraudReport is the provider-neutral result of an IP reputation check
+type FraudReport struct {
+	Provider    string    `json:"provider"`
+	FraudScore  float64   `json:"fraud_score"` // 0-100
+	CountryCode string    `json:"country_code,omitempty"`
+	ISP         string    `json:"isp,omitempty"`
+	ASN         int       `json:"asn,omitempty"`
+	IsVPN       bool      `json:"is_vpn"`
+	IsProxy     bool      `json:"is_proxy"`
+	IsTor       bool      `json:"is_tor"`
+	IsHosting   bool      `json:"is_hosting"`
+	RecentAbuse bool      `json:"recent_abuse"`
+	Mobile      bool      `json:"mobile"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+type FraudChecker interface {
+	Name() string
+	Check(ctx context.Context, ip string) (*FraudReport, error)
+}
+
+// FraudCache is the subset of the Redis cache used to store fraud reports
+type FraudCache interface {
+	GetJSON(ctx context.Context, key string, dest interface{}) error
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+}
+
+// ParseFraudWeights parses "ipqs=0.5,scamalytics=0.3,heuristic=0.2"; an empty string yields the defaults
+func ParseFraudWeights(value string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	if strings.TrimSpace(value) == "" {
+		for name, weight := range DefaultFraudWeights {
+			weights[name] = weight
+		}
+		return weights, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid fraud weight %q", pair)
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid fraud weight %q", pair)
+		}
+		weights[strings.ToLower(strings.TrimSpace(parts[0]))] = weight
+	}
+
+	return weights, nil
+}
+
+type weightedFraudChecker struct {
+	checker FraudChecker
+	weight  float64
+}
+
+// CompositeFraudChecker queries every configured backend, blends their scores by weight
+// and caches the combined report per IP to keep paid API usage down
+type CompositeFraudChecker struct {
+	checkers []weightedFraudChecker
+	cache    FraudCache
+	cacheTTL time.Duration
+	logger   logger.Logger
+	mu       sync.RWMutex
+}
+
+func NewCompositeFraudChecker(cacheTTL time.Duration, logger logger.Logger) *CompositeFraudChecker {
+	if cacheTTL <= 0 {
+		cacheTTL = defaultFraudCacheTTL
+	}
+
+	return &CompositeFraudChecker{
+		cacheTTL: cacheTTL,
+		logger:   logger,
+	}
+}
+
+// AddChecker registers a backend; checkers with a zero weight are ignored
+func (c *CompositeFraudChecker) AddChecker(checker FraudChecker, weight float64) {
+	if weight <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checkers = append(c.checkers, weightedFraudChecker{checker: checker, weight: weight})
+	// Higher weights go first so their country and ISP data wins
+	sort.SliceStable(c.checkers, func(i, j int) bool {
+		return c.checkers[i].weight > c.checkers[j].weight
+	})
+}
+
+func (c *CompositeFraudChecker) SetCache(cache FraudCache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = cache
+}
+
+func (c *CompositeFraudChecker) Name() string {
+	return "composite"
+}
+
+func (c *CompositeFraudChecker) Check(ctx context.Context, ip string) (*FraudReport, error) {
+	c.mu.RLock()
+	checkers := c.checkers
+	cache := c.cache
+	c.mu.RUnlock()
+
+	cacheKey := fraudCacheKeyPrefix + ip
+	if cache != nil {
+		var cached FraudReport
+		if err := cache.GetJSON(ctx, cacheKey, &cached); err == nil {
+			RecordFraudCheck("cache", "hit")
+			return &cached, nil
+		}
+	}
+
+	var (
+		reports     []*FraudReport
+		weights     []float64
+		providers   []string
+		totalWeight float64
+	)
+
+	for _, wc := range checkers {
+		report, err := wc.checker.Check(ctx, ip)
+		if errors.Is(err, ErrFraudCheckerNotConfigured) {
+			continue
+		}
+		if err != nil {
+			RecordFraudCheck(wc.checker.Name(), "error")
+			c.logger.WithError(err).Warnf("Fraud check via %s failed for %s", wc.checker.Name(), ip)
+			continue
+		}
+
+		RecordFraudCheck(wc.checker.Name(), "success")
+		reports = append(reports, report)
+		weights = append(weights, wc.weight)
+		providers = append(providers, wc.checker.Name())
+		totalWeight += wc.weight
+	}
+
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no fraud checker available for %s", ip)
+	}
+
+	combined := &FraudReport{
+		Provider:  strings.Join(providers, ","),
+		CheckedAt: time.Now(),
+	}
+
+	for i, report := range reports {
+		combined.FraudScore += report.FraudScore * weights[i] / totalWeight
+
+		combined.IsVPN = combined.IsVPN || report.IsVPN
+		combined.IsProxy = combined.IsProxy || report.IsProxy
+		combined.IsTor = combined.IsTor || report.IsTor
+		combined.IsHosting = combined.IsHosting || report.IsHosting
+		combined.RecentAbuse = combined.RecentAbuse || report.RecentAbuse
+		combined.Mobile = combined.Mobile || report.Mobile
+
+		if combined.CountryCode == "" {
+			combined.CountryCode = report.CountryCode
+		}
+		if combined.ISP == "" {
+			combined.ISP = report.ISP
+		}
+		if combined.ASN == 0 {
+			combined.ASN = report.ASN
+		}
+	}
+
+	if cache != nil {
+		if err := cache.Set(ctx, cacheKey, combined, c.cacheTTL); err != nil {
+			c.logger.WithError(err).Warn("Failed to cache fraud report")
+		}
+	}
+
+	return combined, nil
+}
+
+// IPQSChecker queries IPQualityScore
+type IPQSChecker struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+	mu      sync.RWMutex
+}
+
+func NewIPQSChecker(apiKey string) *IPQSChecker {
+	return &IPQSChecker{
+		apiKey:  apiKey,
+		baseURL: "https://ipqualityscore.com/api/json/ip",
+		client:  &http.Client{Timeout: defaultFraudAPITimeout},
+	}
+}
+
+func (c *IPQSChecker) Name() string {
+	return fraudProviderIPQS
+}
+
+// SetAPIKey replaces the key used by subsequent checks
+func (c *IPQSChecker) SetAPIKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiKey = key
+}
+
+func (c *IPQSChecker) APIKey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.apiKey
+}
+
+func (c *IPQSChecker) Check(ctx context.Context, ip string) (*FraudReport, error) {
+	apiKey := c.APIKey()
+	if apiKey == "" {
+		return nil, ErrFraudCheckerNotConfigured
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s/%s", c.baseURL, apiKey, ip), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check fraud score: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var ipqsResp IPQSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ipqsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode IPQualityScore response: %w", err)
+	}
+
+	if !ipqsResp.Success {
+		return nil, fmt.Errorf("IPQualityScore API error: %s", ipqsResp.Message)
+	}
+
+	return &FraudReport{
+		Provider:    c.Name(),
+		FraudScore:  ipqsResp.FraudScore,
+		CountryCode: ipqsResp.CountryCode,
+		ISP:         ipqsResp.ISP,
+		ASN:         ipqsResp.ASN,
+		IsVPN:       ipqsResp.VPN,
+		IsProxy:     ipqsResp.Proxy,
+		IsTor:       ipqsResp.TOR,
+		IsHosting:   ipqsResp.ConnectionType == "Data Center",
+		RecentAbuse: ipqsResp.RecentAbuse,
+		Mobile:      ipqsResp.Mobile,
+		CheckedAt:   time.Now(),
+	}, nil
+}
+
+// ScamalyticsChecker queries the Scamalytics IP fraud API
+type ScamalyticsChecker struct {
+	user    string
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+type scamalyticsResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Score  string `json:"score"`
+	Risk   string `json:"risk"`
+}
+
+func NewScamalyticsChecker(user, apiKey string) *ScamalyticsChecker {
+	return &ScamalyticsChecker{
+		user:    user,
+		apiKey:  apiKey,
+		baseURL: "https://api11.scamalytics.com",
+		client:  &http.Client{Timeout: defaultFraudAPITimeout},
+	}
+}
+
+func (c *ScamalyticsChecker) Name() string {
+	return fraudProviderScamalytics
+}
+
+func (c *ScamalyticsChecker) Check(ctx context.Context, ip string) (*FraudReport, error) {
+	if c.user == "" || c.apiKey == "" {
+		return nil, ErrFraudCheckerNotConfigured
+	}
+
+	params := url.Values{}
+	params.Set("ip", ip)
+	params.Set("key", c.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s/?%s", c.baseURL, c.user, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Scamalytics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result scamalyticsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode Scamalytics response: %w", err)
+	}
+
+	if result.Status != "ok" {
+		return nil, fmt.Errorf("Scamalytics API error: %s", result.Error)
+	}
+
+	score, err := strconv.ParseFloat(result.Score, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Scamalytics score %q", result.Score)
+	}
+
+	return &FraudReport{
+		Provider:   c.Name(),
+		FraudScore: score,
+		IsProxy:    result.Risk == "high" || result.Risk == "very high",
+		CheckedAt:  time.Now(),
+	}, nil
+}
+
+// hostingASNs lists networks of large cloud and hosting providers; addresses from them
+// are almost never residential or mobile
+var hostingASNs = map[int]string{
+	13335:  "Cloudflare",
+	14061:  "DigitalOcean",
+	14618:  "Amazon",
+	15169:  "Google",
+	16276:  "OVH",
+	16509:  "Amazon",
+	20473:  "Vultr",
+	24940:  "Hetzner",
+	51167:  "Contabo",
+	49505:  "Selectel",
+	60068:  "Datacamp",
+	63949:  "Linode",
+	8075:   "Microsoft",
+	9009:   "M247",
+	200350: "Yandex Cloud",
+	396982: "Google Cloud",
+}
+
+var hostingHostnameMarkers = []string{
+	"amazonaws", "googleusercontent", "cloud", "hosting", "server", "vps", "dedicated",
+	"hetzner", "ovh", "linode", "digitalocean", "contabo", "vultr",
+}
+
+var residentialHostnameMarkers = []string{
+	"dynamic", "dyn", "dsl", "pool", "dhcp", "broadband", "cable", "ppp", "ftth", "mobile",
+}
+
+// HeuristicChecker estimates risk without paid APIs: the origin ASN and country come from
+// the Team Cymru DNS service and the reverse DNS name hints at hosting or residential use
+type HeuristicChecker struct {
+	extraHostingASNs map[int]bool
+	lookupTXT        func(ctx context.Context, name string) ([]string, error)
+	lookupAddr       func(ctx context.Context, addr string) ([]string, error)
+}
+
+func NewHeuristicChecker(extraHostingASNs []int) *HeuristicChecker {
+	extra := make(map[int]bool, len(extraHostingASNs))
+	for _, asn := range extraHostingASNs {
+		extra[asn] = true
+	}
+
+	return &HeuristicChecker{
+		extraHostingASNs: extra,
+		lookupTXT:        net.DefaultResolver.LookupTXT,
+		lookupAddr:       net.DefaultResolver.LookupAddr,
+	}
+}
+
+func (c *HeuristicChecker) Name() string {
+	return fraudProviderHeuristic
+}
+
+func (c *HeuristicChecker) Check(ctx context.Context, ip string) (*FraudReport, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	report := &FraudReport{
+		Provider:   c.Name(),
+		FraudScore: 20,
+		CheckedAt:  time.Now(),
+	}
+
+	if parsed.IsPrivate() || parsed.IsLoopback() || parsed.IsUnspecified() {
+		report.FraudScore = 100
+		return report, nil
+	}
+
+	if ipv4 := parsed.To4(); ipv4 != nil {
+		asn, country, err := c.lookupOrigin(ctx, ipv4)
+		if err == nil {
+			report.ASN = asn
+			report.CountryCode = country
+			if name, ok := hostingASNs[asn]; ok || c.extraHostingASNs[asn] {
+				report.ISP = name
+				report.IsHosting = true
+				report.FraudScore += 50
+			}
+		}
+	}
+
+	names, err := c.lookupAddr(ctx, ip)
+	if err != nil || len(names) == 0 {
+		// Residential ranges nearly always have reverse DNS
+		report.FraudScore += 10
+	} else {
+		hostname := strings.ToLower(names[0])
+		switch {
+		case containsAny(hostname, hostingHostnameMarkers):
+			report.IsHosting = true
+			report.FraudScore += 20
+		case containsAny(hostname, residentialHostnameMarkers):
+			report.FraudScore -= 10
+			report.Mobile = strings.Contains(hostname, "mobile")
+		}
+	}
+
+	report.IsProxy = report.IsHosting
+	if report.FraudScore > 100 {
+		report.FraudScore = 100
+	}
+	if report.FraudScore < 0 {
+		report.FraudScore = 0
+	}
+
+	return report, nil
+}
+
+// lookupOrigin resolves "ASN | prefix | CC | registry | date" from origin.asn.cymru.com
+func (c *HeuristicChecker) lookupOrigin(ctx context.Context, ip net.IP) (int, string, error) {
+	name := fmt.Sprintf("%d.%d.%d.%d.origin.asn.cymru.com", ip[3], ip[2], ip[1], ip[0])
+
+	records, err := c.lookupTXT(ctx, name)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(records) == 0 {
+		return 0, "", fmt.Errorf("no origin record for %s", ip)
+	}
+
+	fields := strings.Split(records[0], "|")
+	if len(fields) < 3 {
+		return 0, "", fmt.Errorf("malformed origin record %q", records[0])
+	}
+
+	// Multi-origin prefixes list several ASNs separated by spaces
+	origins := strings.Fields(fields[0])
+	if len(origins) == 0 {
+		return 0, "", fmt.Errorf("malformed origin record %q", records[0])
+	}
+	asn, err := strconv.Atoi(origins[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("malformed origin record %q", records[0])
+	}
+
+	return asn, strings.TrimSpace(fields[2]), nil
+}
+
+func parseASNList(value string) []int {
+	var asns []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(part)), "AS")
+		if asn, err := strconv.Atoi(part); err == nil {
+			asns = append(asns, asn)
+		}
+	}
+	return asns
+}
+
+func containsAny(value string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(value, marker) {
+			return true
+		}
+	}
+	return false
+}
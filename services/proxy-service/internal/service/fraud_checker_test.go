@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFraudChecker struct {
+	name   string
+	report *FraudReport
+	err    error
+	calls  int
+}
+
+func (c *stubFraudChecker) Name() string {
+	return c.name
+}
+
+func (c *stubFraudChecker) Check(ctx context.Context, ip string) (*FraudReport, error) {
+	c.calls++
+	return c.report, c.err
+}
+
+type memoryFraudCache struct {
+	values map[string][]byte
+	ttl    time.Duration
+}
+
+func (c *memoryFraudCache) GetJSON(ctx context.Context, key string, dest interface{}) error {
+	data, ok := c.values[key]
+	if !ok {
+		return errors.New("key not found")
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (c *memoryFraudCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.values[key] = data
+	c.ttl = expiration
+	return nil
+}
+
+func TestParseFraudWeights(t *testing.T) {
+	weights, err := ParseFraudWeights("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultFraudWeights, weights)
+
+	weights, err = ParseFraudWeights("IPQS=0.7, heuristic=0.3")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"ipqs": 0.7, "heuristic": 0.3}, weights)
+
+	_, err = ParseFraudWeights("ipqs")
+	assert.Error(t, err)
+	_, err = ParseFraudWeights("ipqs=-1")
+	assert.Error(t, err)
+}
+
+func TestCompositeFraudChecker_WeightsScores(t *testing.T) {
+	checker := NewCompositeFraudChecker(time.Hour, logger.NewFromConfig(logger.Config{Output: io.Discard}))
+	checker.AddChecker(&stubFraudChecker{name: "ipqs", report: &FraudReport{FraudScore: 80, CountryCode: "US", IsVPN: true}}, 0.75)
+	checker.AddChecker(&stubFraudChecker{name: "heuristic", report: &FraudReport{FraudScore: 40, CountryCode: "DE", IsHosting: true}}, 0.25)
+	checker.AddChecker(&stubFraudChecker{name: "scamalytics", err: ErrFraudCheckerNotConfigured}, 0.5)
+	checker.AddChecker(&stubFraudChecker{name: "disabled", report: &FraudReport{FraudScore: 100}}, 0)
+
+	report, err := checker.Check(context.Background(), "203.0.113.10")
+	require.NoError(t, err)
+
+	assert.InDelta(t, 70.0, report.FraudScore, 0.001)
+	assert.Equal(t, "US", report.CountryCode, "highest weight provides the country")
+	assert.True(t, report.IsVPN)
+	assert.True(t, report.IsHosting)
+	assert.Equal(t, "ipqs,heuristic", report.Provider)
+}
+
+func TestCompositeFraudChecker_SkipsFailingBackends(t *testing.T) {
+	checker := NewCompositeFraudChecker(time.Hour, logger.NewFromConfig(logger.Config{Output: io.Discard}))
+	checker.AddChecker(&stubFraudChecker{name: "ipqs", err: errors.New("quota exceeded")}, 0.5)
+	checker.AddChecker(&stubFraudChecker{name: "heuristic", report: &FraudReport{FraudScore: 30}}, 0.2)
+
+	report, err := checker.Check(context.Background(), "203.0.113.10")
+	require.NoError(t, err)
+	assert.Equal(t, 30.0, report.FraudScore)
+
+	failing := NewCompositeFraudChecker(time.Hour, logger.NewFromConfig(logger.Config{Output: io.Discard}))
+	failing.AddChecker(&stubFraudChecker{name: "ipqs", err: ErrFraudCheckerNotConfigured}, 1)
+	_, err = failing.Check(context.Background(), "203.0.113.10")
+	assert.Error(t, err)
+}
+
+func TestCompositeFraudChecker_CachesPerIP(t *testing.T) {
+	backend := &stubFraudChecker{name: "ipqs", report: &FraudReport{FraudScore: 55}}
+	cache := &memoryFraudCache{values: make(map[string][]byte)}
+
+	checker := NewCompositeFraudChecker(0, logger.NewFromConfig(logger.Config{Output: io.Discard}))
+	checker.AddChecker(backend, 1)
+	checker.SetCache(cache)
+
+	for i := 0; i < 3; i++ {
+		report, err := checker.Check(context.Background(), "203.0.113.10")
+		require.NoError(t, err)
+		assert.Equal(t, 55.0, report.FraudScore)
+	}
+
+	assert.Equal(t, 1, backend.calls)
+	assert.Equal(t, 24*time.Hour, cache.ttl)
+	assert.Contains(t, cache.values, "proxy:fraud:203.0.113.10")
+
+	_, err := checker.Check(context.Background(), "203.0.113.11")
+	require.NoError(t, err)
+	assert.Equal(t, 2, backend.calls)
+}
+
+func TestIPQSChecker_Check(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-key/203.0.113.10", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success": true, "fraud_score": 85, "country_code": "NL", "ISP": "Test ISP", "ASN": 60068, "vpn": true, "proxy": true, "recent_abuse": true, "connection_type": "Data Center"}`))
+	}))
+	defer server.Close()
+
+	checker := NewIPQSChecker("")
+	checker.baseURL = server.URL
+
+	_, err := checker.Check(context.Background(), "203.0.113.10")
+	assert.ErrorIs(t, err, ErrFraudCheckerNotConfigured)
+
+	checker.SetAPIKey("test-key")
+	report, err := checker.Check(context.Background(), "203.0.113.10")
+	require.NoError(t, err)
+	assert.Equal(t, 85.0, report.FraudScore)
+	assert.Equal(t, "NL", report.CountryCode)
+	assert.Equal(t, 60068, report.ASN)
+	assert.True(t, report.IsVPN)
+	assert.True(t, report.IsHosting)
+	assert.True(t, report.RecentAbuse)
+}
+
+func TestScamalyticsChecker_Check(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/conveer/", r.URL.Path)
+		assert.Equal(t, "203.0.113.10", r.URL.Query().Get("ip"))
+		assert.Equal(t, "secret", r.URL.Query().Get("key"))
+		w.Write([]byte(`{"status": "ok", "score": "78", "risk": "high"}`))
+	}))
+	defer server.Close()
+
+	checker := NewScamalyticsChecker("conveer", "secret")
+	checker.baseURL = server.URL
+
+	report, err := checker.Check(context.Background(), "203.0.113.10")
+	require.NoError(t, err)
+	assert.Equal(t, 78.0, report.FraudScore)
+	assert.True(t, report.IsProxy)
+
+	_, err = NewScamalyticsChecker("", "").Check(context.Background(), "203.0.113.10")
+	assert.ErrorIs(t, err, ErrFraudCheckerNotConfigured)
+}
+
+func TestHeuristicChecker_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		ip          string
+		origin      string
+		hostnames   []string
+		expectScore float64
+		expectASN   int
+		expectHost  bool
+	}{
+		{"hosting ASN with server hostname", "203.0.113.10", "24940 | 203.0.113.0/24 | DE | ripencc | 2009-01-01", []string{"static.10.113.0.203.clients.your-server.de."}, 90, 24940, true},
+		{"residential ISP", "198.51.100.7", "12389 | 198.51.100.0/24 | RU | ripencc | 2005-01-01", []string{"dsl-198-51-100-7.dynamic.example.ru."}, 10, 12389, false},
+		{"extra hosting ASN without reverse DNS", "192.0.2.44", "64500 | 192.0.2.0/24 | US | arin | 2010-01-01", nil, 80, 64500, true},
+		{"private address", "10.0.0.1", "", nil, 100, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewHeuristicChecker(parseASNList("AS64500, bogus"))
+			checker.lookupTXT = func(ctx context.Context, name string) ([]string, error) {
+				return []string{tt.origin}, nil
+			}
+			checker.lookupAddr = func(ctx context.Context, addr string) ([]string, error) {
+				if tt.hostnames == nil {
+					return nil, errors.New("no PTR record")
+				}
+				return tt.hostnames, nil
+			}
+
+			report, err := checker.Check(context.Background(), tt.ip)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectScore, report.FraudScore)
+			assert.Equal(t, tt.expectASN, report.ASN)
+			assert.Equal(t, tt.expectHost, report.IsHosting)
+		})
+	}
+
+	_, err := NewHeuristicChecker(nil).Check(context.Background(), "not-an-ip")
+	assert.Error(t, err)
+}
@@ -8,58 +8,64 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/grigta/conveer/pkg/config"
-	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
-	"github.com/grigta/conveer/services/proxy-service/internal/repository"
 
-	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type HealthChecker struct {
-	proxyRepo      *repository.ProxyRepository
-	rabbitmq       *messaging.RabbitMQ
-	logger         *logrus.Logger
-	config         *config.Config
-	checkInterval  time.Duration
+	proxyRepo       proxyStore
+	rabbitmq        broker
+	logger          logger.Logger
+	config          *config.Config
+	checkInterval   time.Duration
 	maxFailedChecks int
-	ipqsAPIKey     string
-	stopChan       chan struct{}
-	wg             sync.WaitGroup
+	ipqs            *IPQSChecker
+	fraudChecker    *CompositeFraudChecker
+	prober          *CapabilityProber
+	probeInterval   time.Duration
+	ipChecker       *ExitIPChecker
+	ipCheckInterval time.Duration
+	regions         *CheckRegions
+	events          *EventBus
+	stopChan        chan struct{}
+	wg              sync.WaitGroup
 }
 
 type IPQSResponse struct {
-	Success      bool    `json:"success"`
-	Message      string  `json:"message"`
-	FraudScore   float64 `json:"fraud_score"`
-	CountryCode  string  `json:"country_code"`
-	City         string  `json:"city"`
-	ISP          string  `json:"ISP"`
-	ASN          int     `json:"ASN"`
-	Organization string  `json:"organization"`
-	IsCrawler    bool    `json:"is_crawler"`
-	Timezone     string  `json:"timezone"`
-	Mobile       bool    `json:"mobile"`
-	Host         string  `json:"host"`
-	Proxy        bool    `json:"proxy"`
-	VPN          bool    `json:"vpn"`
-	TOR          bool    `json:"tor"`
-	ActiveVPN    bool    `json:"active_vpn"`
-	ActiveTOR    bool    `json:"active_tor"`
-	RecentAbuse  bool    `json:"recent_abuse"`
-	BotStatus    bool    `json:"bot_status"`
-	ConnectionType string `json:"connection_type"`
-	AbuseVelocity  string `json:"abuse_velocity"`
+	Success        bool    `json:"success"`
+	Message        string  `json:"message"`
+	FraudScore     float64 `json:"fraud_score"`
+	CountryCode    string  `json:"country_code"`
+	City           string  `json:"city"`
+	ISP            string  `json:"ISP"`
+	ASN            int     `json:"ASN"`
+	Organization   string  `json:"organization"`
+	IsCrawler      bool    `json:"is_crawler"`
+	Timezone       string  `json:"timezone"`
+	Mobile         bool    `json:"mobile"`
+	Host           string  `json:"host"`
+	Proxy          bool    `json:"proxy"`
+	VPN            bool    `json:"vpn"`
+	TOR            bool    `json:"tor"`
+	ActiveVPN      bool    `json:"active_vpn"`
+	ActiveTOR      bool    `json:"active_tor"`
+	RecentAbuse    bool    `json:"recent_abuse"`
+	BotStatus      bool    `json:"bot_status"`
+	ConnectionType string  `json:"connection_type"`
+	AbuseVelocity  string  `json:"abuse_velocity"`
 }
 
 func NewHealthChecker(
-	proxyRepo *repository.ProxyRepository,
-	rabbitmq *messaging.RabbitMQ,
-	logger *logrus.Logger,
+	proxyRepo proxyStore,
+	rabbitmq broker,
+	logger logger.Logger,
 	config *config.Config,
 ) *HealthChecker {
 	checkInterval := 15 * time.Minute
@@ -74,6 +80,45 @@ func NewHealthChecker(
 		maxFailedChecks = config.Proxy.MaxFailedChecks
 	}
 
+	fraudCacheTTL := 24 * time.Hour
+	if config.Proxy.FraudCacheTTL != "" {
+		if d, err := time.ParseDuration(config.Proxy.FraudCacheTTL); err == nil {
+			fraudCacheTTL = d
+		}
+	}
+
+	weights, err := ParseFraudWeights(config.Proxy.FraudWeights)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid fraud check weights, using defaults")
+		weights, _ = ParseFraudWeights("")
+	}
+
+	probeInterval := 24 * time.Hour
+	if config.Proxy.CapabilityProbeInterval != "" {
+		if d, err := time.ParseDuration(config.Proxy.CapabilityProbeInterval); err == nil {
+			probeInterval = d
+		}
+	}
+
+	ipCheckInterval := 5 * time.Minute
+	if config.Proxy.ExitIPCheckInterval != "" {
+		if d, err := time.ParseDuration(config.Proxy.ExitIPCheckInterval); err == nil {
+			ipCheckInterval = d
+		}
+	}
+
+	regions, err := ParseCheckRegions(config.Proxy.CheckEndpoints, config.Proxy.CheckCountryRegions, config.Proxy.PlatformRegions, config.Proxy.DefaultCheckRegion)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid check regions, checking every proxy from the default checker")
+		regions, _ = ParseCheckRegions("", "", "", "")
+	}
+
+	ipqs := NewIPQSChecker(config.Proxy.IPQualityScoreAPIKey)
+	fraudChecker := NewCompositeFraudChecker(fraudCacheTTL, logger)
+	fraudChecker.AddChecker(ipqs, weights[fraudProviderIPQS])
+	fraudChecker.AddChecker(NewScamalyticsChecker(config.Proxy.ScamalyticsUser, config.Proxy.ScamalyticsAPIKey), weights[fraudProviderScamalytics])
+	fraudChecker.AddChecker(NewHeuristicChecker(parseASNList(config.Proxy.FraudHostingASNs)), weights[fraudProviderHeuristic])
+
 	return &HealthChecker{
 		proxyRepo:       proxyRepo,
 		rabbitmq:        rabbitmq,
@@ -81,7 +126,13 @@ func NewHealthChecker(
 		config:          config,
 		checkInterval:   checkInterval,
 		maxFailedChecks: maxFailedChecks,
-		ipqsAPIKey:      config.Proxy.IPQualityScoreAPIKey,
+		ipqs:            ipqs,
+		fraudChecker:    fraudChecker,
+		prober:          NewCapabilityProber(config.Proxy.UDPProbeTarget, config.Proxy.QUICProbeTarget, 5*time.Second),
+		probeInterval:   probeInterval,
+		ipChecker:       NewExitIPChecker(config.Proxy.ExitIPCheckURL, 10*time.Second),
+		ipCheckInterval: ipCheckInterval,
+		regions:         regions,
 		stopChan:        make(chan struct{}),
 	}
 }
@@ -98,6 +149,14 @@ func (h *HealthChecker) Start(ctx context.Context) {
 		defer h.wg.Done()
 		h.consumeHealthCheckRequests(ctx)
 	}()
+
+	if h.ipCheckInterval > 0 {
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+			h.runExitIPChecks(ctx)
+		}()
+	}
 }
 
 func (h *HealthChecker) Stop() {
@@ -135,6 +194,17 @@ func (h *HealthChecker) performHealthChecks(ctx context.Context) {
 		return
 	}
 
+	// Previous results tell a new blacklisting apart from one already announced
+	ids := make([]primitive.ObjectID, 0, len(proxies))
+	for _, proxy := range proxies {
+		ids = append(ids, proxy.ID)
+	}
+	previous, err := h.proxyRepo.GetProxyHealthByIDs(ctx, ids)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to get previous proxy health")
+		previous = map[primitive.ObjectID]*models.ProxyHealth{}
+	}
+
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, 10) // Limit concurrent checks
 
@@ -152,8 +222,15 @@ func (h *HealthChecker) performHealthChecks(ctx context.Context) {
 				h.logger.WithError(err).Error("Failed to update proxy health")
 			}
 
+			h.publishHealthEvents(&p, previous[p.ID], health)
+
 			if health.FailedChecks >= h.maxFailedChecks {
 				h.HandleFailedCheck(ctx, &p)
+				return
+			}
+
+			if health.FailedChecks == 0 && h.capabilitiesStale(&p) {
+				h.ProbeCapabilities(ctx, &p)
 			}
 		}(proxy)
 	}
@@ -178,23 +255,24 @@ func (h *HealthChecker) CheckProxyHealth(ctx context.Context, proxy *models.Prox
 		LastCheck: time.Now(),
 	}
 
-	latency := h.testLatency(ctx, proxy)
-	health.Latency = latency
+	h.measureLatency(ctx, proxy, health)
 
-	if latency < 0 {
+	if health.Latency < 0 {
 		health.FailedChecks++
 		RecordHealthCheck("failed")
 		return health
 	}
 
-	RecordLatency(float64(latency))
+	RecordLatency(float64(health.Latency))
 
-	fraudData := h.checkFraudScore(ctx, proxy.IP)
-	if fraudData != nil {
+	fraudData, err := h.fraudChecker.Check(ctx, proxy.IP)
+	if err != nil {
+		h.logger.WithError(err).Warnf("Fraud check failed for proxy %s", proxy.ID.Hex())
+	} else {
 		health.FraudScore = fraudData.FraudScore
-		health.IsVPN = fraudData.VPN
-		health.IsProxy = fraudData.Proxy
-		health.IsTor = fraudData.TOR
+		health.IsVPN = fraudData.IsVPN
+		health.IsProxy = fraudData.IsProxy
+		health.IsTor = fraudData.IsTor
 		health.BlacklistStatus = fraudData.RecentAbuse
 		RecordFraudScore(fraudData.FraudScore)
 	}
@@ -209,7 +287,45 @@ func (h *HealthChecker) CheckProxyHealth(ctx context.Context, proxy *models.Prox
 	return health
 }
 
-func (h *HealthChecker) testLatency(ctx context.Context, proxy *models.Proxy) int {
+// measureLatency checks the proxy from the checker region closest to it and then from the
+// platform regions. Only the closest region decides whether the check failed.
+func (h *HealthChecker) measureLatency(ctx context.Context, proxy *models.Proxy, health *models.ProxyHealth) {
+	plan := h.regions.Plan(proxy.Country)
+
+	health.CheckRegion = plan[0]
+	health.Latency = h.testLatency(ctx, proxy, h.regions.URL(plan[0]))
+	if health.Latency < 0 {
+		return
+	}
+
+	for _, region := range plan {
+		if region == "" {
+			continue
+		}
+
+		latency := health.Latency
+		if region != health.CheckRegion {
+			latency = h.testLatency(ctx, proxy, h.regions.URL(region))
+			if latency < 0 {
+				continue
+			}
+		}
+
+		if health.RegionLatency == nil {
+			health.RegionLatency = make(map[string]int, len(plan))
+		}
+		health.RegionLatency[region] = latency
+		RecordRegionLatency(region, float64(latency))
+	}
+}
+
+// PlatformRegion returns the datacenter region of the platform latency is also measured from,
+// empty when there is none
+func (h *HealthChecker) PlatformRegion(platform string) string {
+	return h.regions.PlatformRegion(platform)
+}
+
+func (h *HealthChecker) testLatency(ctx context.Context, proxy *models.Proxy, checkURL string) int {
 	proxyURL := fmt.Sprintf("%s://%s:%s@%s:%d",
 		proxy.Protocol,
 		proxy.Username,
@@ -235,10 +351,16 @@ func (h *HealthChecker) testLatency(ctx context.Context, proxy *models.Proxy) in
 		},
 	}
 
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checkURL, nil)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to build latency check request")
+		return -1
+	}
+
 	start := time.Now()
-	resp, err := client.Get("http://httpbin.org/ip")
+	resp, err := client.Do(req)
 	if err != nil {
-		h.logger.WithError(err).Errorf("Failed to test proxy latency for %s", proxy.ID.Hex())
+		h.logger.WithError(err).Errorf("Failed to test proxy latency for %s via %s", proxy.ID.Hex(), checkURL)
 		return -1
 	}
 	defer resp.Body.Close()
@@ -264,37 +386,193 @@ func (h *HealthChecker) testLatency(ctx context.Context, proxy *models.Proxy) in
 	return latency
 }
 
-func (h *HealthChecker) checkFraudScore(ctx context.Context, ip string) *IPQSResponse {
-	if h.ipqsAPIKey == "" {
-		h.logger.Debug("IPQualityScore API key not configured, skipping fraud check")
-		return nil
+// ProbeCapabilities checks UDP and QUIC support of the proxy and stores the result
+func (h *HealthChecker) ProbeCapabilities(ctx context.Context, proxy *models.Proxy) models.ProxyCapabilities {
+	capabilities, err := h.prober.Probe(ctx, proxy)
+	if err != nil {
+		h.logger.WithError(err).Debugf("Capability probe incomplete for proxy %s", proxy.ID.Hex())
+	}
+
+	if proxy.Protocol == models.ProtocolSOCKS5 {
+		RecordCapabilityProbe(models.CapabilityUDP, capabilities.UDP)
+		RecordCapabilityProbe(models.CapabilityQUIC, capabilities.QUIC)
+	}
+
+	if err := h.proxyRepo.UpdateProxyCapabilities(ctx, proxy.ID, capabilities); err != nil {
+		h.logger.WithError(err).Warnf("Failed to save capabilities of proxy %s", proxy.ID.Hex())
+	}
+
+	proxy.Capabilities = capabilities
+	return capabilities
+}
+
+func (h *HealthChecker) capabilitiesStale(proxy *models.Proxy) bool {
+	return time.Since(proxy.Capabilities.CheckedAt) > h.probeInterval
+}
+
+func (h *HealthChecker) runExitIPChecks(ctx context.Context) {
+	ticker := time.NewTicker(h.ipCheckInterval)
+	defer ticker.Stop()
+
+	h.logger.Info("Starting periodic exit IP checks of mobile proxies")
+
+	for {
+		select {
+		case <-ticker.C:
+			h.performExitIPChecks(ctx)
+		case <-h.stopChan:
+			h.logger.Info("Stopping exit IP checks")
+			return
+		case <-ctx.Done():
+			h.logger.Info("Context cancelled, stopping exit IP checks")
+			return
+		}
 	}
+}
 
-	url := fmt.Sprintf("https://ipqualityscore.com/api/json/ip/%s/%s", h.ipqsAPIKey, ip)
+// performExitIPChecks looks for rotated exit IPs of active mobile proxies. Residential
+// proxies keep their address, so only mobile ones are polled.
+func (h *HealthChecker) performExitIPChecks(ctx context.Context) {
+	proxies, err := h.proxyRepo.GetProxiesByStatus(ctx, models.ProxyStatusActive)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get mobile proxies for exit IP check")
+		return
+	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(url)
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, 10) // Limit concurrent checks
+
+	for _, proxy := range proxies {
+		if proxy.Type != models.ProxyTypeMobile {
+			continue
+		}
+
+		wg.Add(1)
+		go func(p models.Proxy) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if _, err := h.CheckExitIP(ctx, &p); err != nil {
+				h.logger.WithError(err).Debugf("Exit IP check failed for proxy %s", p.ID.Hex())
+			}
+		}(proxy)
+	}
+
+	wg.Wait()
+}
+
+// CheckExitIP resolves the current exit IP of the proxy, records a change on the proxy
+// document and announces it with a proxy.ip_changed event. The account binding is kept:
+// sessions stay sticky to the proxy while its address rotates.
+func (h *HealthChecker) CheckExitIP(ctx context.Context, proxy *models.Proxy) (*models.ExitIPStatus, error) {
+	ip, err := h.ipChecker.Check(ctx, proxy)
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to check fraud score")
-		return nil
+		RecordExitIPCheck("failed")
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var ipqsResp IPQSResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ipqsResp); err != nil {
-		h.logger.WithError(err).Error("Failed to decode IPQualityScore response")
-		return nil
+	checkedAt := time.Now()
+	previousIP := proxy.ExitIP
+	changed, err := h.proxyRepo.RecordExitIP(ctx, proxy.ID, previousIP, ip, checkedAt)
+	if err != nil {
+		RecordExitIPCheck("failed")
+		return nil, err
 	}
 
-	if !ipqsResp.Success {
-		h.logger.Errorf("IPQualityScore API error: %s", ipqsResp.Message)
-		return nil
+	status := &models.ExitIPStatus{
+		ProxyID:   proxy.ID.Hex(),
+		IP:        ip,
+		Changed:   changed,
+		CheckedAt: checkedAt,
 	}
 
-	return &ipqsResp
+	binding, err := h.proxyRepo.GetActiveBindingByProxyID(ctx, proxy.ID)
+	if err != nil {
+		h.logger.WithError(err).Warnf("Failed to get binding of proxy %s", proxy.ID.Hex())
+	} else if binding != nil {
+		status.AccountID = binding.AccountID
+	}
+
+	// A change another check recorded first was already announced by it
+	if changed {
+		status.PreviousIP = previousIP
+		RecordExitIPCheck("changed")
+		RecordExitIPChange(proxy.Provider)
+		h.publishIPChanged(proxy, status)
+	} else {
+		RecordExitIPCheck("unchanged")
+	}
+
+	proxy.ExitIP = ip
+	proxy.ExitIPCheckedAt = checkedAt
+	return status, nil
 }
 
-func (h *HealthChecker) verifyGeoLocation(ctx context.Context, proxy *models.Proxy, fraudData *IPQSResponse) bool {
+func (h *HealthChecker) publishIPChanged(proxy *models.Proxy, status *models.ExitIPStatus) {
+	h.logger.Infof("Exit IP of proxy %s changed from %s to %s", proxy.ID.Hex(), status.PreviousIP, status.IP)
+
+	event := map[string]interface{}{
+		"proxy_id":    proxy.ID.Hex(),
+		"account_id":  status.AccountID,
+		"provider":    proxy.Provider,
+		"type":        string(proxy.Type),
+		"country":     proxy.Country,
+		"previous_ip": status.PreviousIP,
+		"ip":          status.IP,
+		"detected_at": status.CheckedAt,
+	}
+
+	if err := h.rabbitmq.Publish("proxy.events", "proxy.ip_changed", event); err != nil {
+		h.logger.WithError(err).Error("Failed to publish IP changed event")
+	}
+
+	changed := proxyEvent(models.ProxyEventIPChanged, proxy, status.AccountID)
+	changed.Details = map[string]string{
+		"previous_ip": status.PreviousIP,
+		"ip":          status.IP,
+	}
+	h.events.Publish(changed)
+}
+
+// publishHealthEvents pushes a failed check as degradation and a proxy newly reported for abuse as blacklisted
+func (h *HealthChecker) publishHealthEvents(proxy *models.Proxy, previous, health *models.ProxyHealth) {
+	if health.FailedChecks > 0 {
+		degraded := proxyEvent(models.ProxyEventHealthDegraded, proxy, "")
+		degraded.Details = map[string]string{
+			"reason":        "latency_check_failed",
+			"failed_checks": strconv.Itoa(health.FailedChecks),
+		}
+		h.events.Publish(degraded)
+		return
+	}
+
+	if health.BlacklistStatus && (previous == nil || !previous.BlacklistStatus) {
+		blacklisted := proxyEvent(models.ProxyEventBlacklisted, proxy, "")
+		blacklisted.Details = map[string]string{
+			"fraud_score": strconv.FormatFloat(health.FraudScore, 'f', 1, 64),
+		}
+		h.events.Publish(blacklisted)
+	}
+}
+
+// SetEventBus enables pushing health events to in-process subscribers
+func (h *HealthChecker) SetEventBus(events *EventBus) {
+	h.events = events
+}
+
+// SetIPQSAPIKey replaces the IPQualityScore key used by subsequent fraud checks
+func (h *HealthChecker) SetIPQSAPIKey(key string) {
+	h.ipqs.SetAPIKey(key)
+}
+
+// SetFraudCache enables caching of fraud reports per IP
+func (h *HealthChecker) SetFraudCache(cache FraudCache) {
+	h.fraudChecker.SetCache(cache)
+}
+
+func (h *HealthChecker) verifyGeoLocation(ctx context.Context, proxy *models.Proxy, fraudData *FraudReport) bool {
 	if fraudData == nil {
 		return true
 	}
@@ -324,6 +602,13 @@ func (h *HealthChecker) HandleFailedCheck(ctx context.Context, proxy *models.Pro
 	if err := h.rabbitmq.Publish("proxy.events", "proxy.health_failed", event); err != nil {
 		h.logger.WithError(err).Error("Failed to publish health failed event")
 	}
+
+	banned := proxyEvent(models.ProxyEventBanned, proxy, "")
+	banned.Details = map[string]string{
+		"reason":   "health_check_failed",
+		"failures": strconv.Itoa(h.maxFailedChecks),
+	}
+	h.events.Publish(banned)
 }
 
 func (h *HealthChecker) consumeHealthCheckRequests(ctx context.Context) {
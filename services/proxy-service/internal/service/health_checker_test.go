@@ -3,15 +3,17 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/grigta/conveer/pkg/config"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -25,7 +27,7 @@ type HealthCheckerTestSuite struct {
 	cancel    context.CancelFunc
 	proxyRepo *MockProxyRepository
 	rabbitmq  *MockRabbitMQ
-	logger    *logrus.Logger
+	logger    logger.Logger
 	config    *config.Config
 }
 
@@ -33,13 +35,12 @@ func (s *HealthCheckerTestSuite) SetupTest() {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 	s.proxyRepo = new(MockProxyRepository)
 	s.rabbitmq = NewMockRabbitMQ()
-	s.logger = logrus.New()
-	s.logger.SetLevel(logrus.DebugLevel)
+	s.logger = logger.NewFromConfig(logger.Config{Output: io.Discard})
 	s.config = &config.Config{
 		Proxy: config.ProxyConfig{
-			HealthCheckInterval:    "15m",
-			MaxFailedChecks:        3,
-			IPQualityScoreAPIKey:   "",
+			HealthCheckInterval:  "15m",
+			MaxFailedChecks:      3,
+			IPQualityScoreAPIKey: "",
 		},
 	}
 }
@@ -72,8 +73,8 @@ func (s *HealthCheckerTestSuite) TestNewHealthChecker_DefaultValues() {
 func (s *HealthCheckerTestSuite) TestNewHealthChecker_CustomValues() {
 	cfg := &config.Config{
 		Proxy: config.ProxyConfig{
-			HealthCheckInterval: "5m",
-			MaxFailedChecks:     5,
+			HealthCheckInterval:  "5m",
+			MaxFailedChecks:      5,
 			IPQualityScoreAPIKey: "test-api-key",
 		},
 	}
@@ -83,7 +84,7 @@ func (s *HealthCheckerTestSuite) TestNewHealthChecker_CustomValues() {
 	s.NotNil(hc)
 	s.Equal(5*time.Minute, hc.checkInterval)
 	s.Equal(5, hc.maxFailedChecks)
-	s.Equal("test-api-key", hc.ipqsAPIKey)
+	s.Equal("test-api-key", hc.ipqs.APIKey())
 }
 
 // Test CheckProxyHealth - successful check
@@ -150,26 +151,26 @@ func (s *HealthCheckerTestSuite) TestHandleFailedCheck_BanProxy() {
 	s.proxyRepo.On("UpdateProxyStatus", s.ctx, proxyID, models.ProxyStatusBanned).Return(nil)
 	s.rabbitmq.On("Publish", "proxy.events", "proxy.health_failed", mock.Anything).Return(nil)
 
-	// Verify expectations are set
-	s.proxyRepo.AssertExpectations(s.T())
-	
 	// The proxy should be banned after max failed checks
-	_ = proxy
+	NewHealthChecker(s.proxyRepo, s.rabbitmq, s.logger, s.config).HandleFailedCheck(s.ctx, proxy)
+
+	s.proxyRepo.AssertExpectations(s.T())
+	s.rabbitmq.AssertExpectations(s.T())
 }
 
 // Test IPQSResponse parsing
 func (s *HealthCheckerTestSuite) TestIPQSResponse_Parsing() {
 	response := IPQSResponse{
-		Success:      true,
-		FraudScore:   45.5,
-		CountryCode:  "US",
-		City:         "New York",
-		ISP:          "Test ISP",
-		Mobile:       true,
-		Proxy:        true,
-		VPN:          false,
-		TOR:          false,
-		RecentAbuse:  false,
+		Success:     true,
+		FraudScore:  45.5,
+		CountryCode: "US",
+		City:        "New York",
+		ISP:         "Test ISP",
+		Mobile:      true,
+		Proxy:       true,
+		VPN:         false,
+		TOR:         false,
+		RecentAbuse: false,
 	}
 
 	s.True(response.Success)
@@ -190,9 +191,9 @@ func (s *HealthCheckerTestSuite) TestCheckFraudScore_NoAPIKey() {
 	}
 
 	hc := NewHealthChecker(s.proxyRepo, nil, s.logger, cfg)
-	
+
 	// Without API key, fraud check should be skipped
-	s.Empty(hc.ipqsAPIKey)
+	s.Empty(hc.ipqs.APIKey())
 }
 
 // Test checkFraudScore with mock server
@@ -257,11 +258,17 @@ func (s *HealthCheckerTestSuite) TestVerifyGeoLocation_NilFraudData() {
 
 // Test performHealthChecks - with active proxies
 func (s *HealthCheckerTestSuite) TestPerformHealthChecks_WithActiveProxies() {
+	// Nothing listens on the port, so every check fails right away
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
 	proxies := []models.Proxy{
 		{
 			ID:       primitive.NewObjectID(),
-			IP:       "192.168.1.1",
-			Port:     8080,
+			IP:       "127.0.0.1",
+			Port:     port,
 			Protocol: models.ProtocolHTTP,
 			Username: "user1",
 			Password: "pass1",
@@ -269,8 +276,8 @@ func (s *HealthCheckerTestSuite) TestPerformHealthChecks_WithActiveProxies() {
 		},
 		{
 			ID:       primitive.NewObjectID(),
-			IP:       "192.168.1.2",
-			Port:     8080,
+			IP:       "127.0.0.1",
+			Port:     port,
 			Protocol: models.ProtocolHTTP,
 			Username: "user2",
 			Password: "pass2",
@@ -279,22 +286,33 @@ func (s *HealthCheckerTestSuite) TestPerformHealthChecks_WithActiveProxies() {
 	}
 
 	s.proxyRepo.On("GetProxiesByStatus", s.ctx, models.ProxyStatusActive).Return(proxies, nil)
-	s.proxyRepo.On("UpdateProxyHealth", s.ctx, mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("*models.ProxyHealth")).Return(nil)
+	s.proxyRepo.On("GetProxyHealthByIDs", s.ctx, []primitive.ObjectID{proxies[0].ID, proxies[1].ID}).Return(map[primitive.ObjectID]*models.ProxyHealth{}, nil)
+	s.proxyRepo.On("UpdateProxyHealth", s.ctx, mock.AnythingOfType("primitive.ObjectID"), mock.MatchedBy(func(health *models.ProxyHealth) bool {
+		return health.FailedChecks == 1 && health.Latency < 0
+	})).Return(nil).Times(2)
 	s.proxyRepo.On("GetProxyStatistics", s.ctx).Return(&models.ProxyStats{
 		ActiveProxies: 2,
 		TotalBindings: 1,
 	}, nil)
 
-	// Verify mock expectations are set
+	NewHealthChecker(s.proxyRepo, s.rabbitmq, s.logger, s.config).performHealthChecks(s.ctx)
+
+	// One failure is below the limit, nothing is banned yet
 	s.proxyRepo.AssertExpectations(s.T())
+	s.proxyRepo.AssertNotCalled(s.T(), "UpdateProxyStatus", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Test performHealthChecks - no active proxies
 func (s *HealthCheckerTestSuite) TestPerformHealthChecks_NoActiveProxies() {
 	s.proxyRepo.On("GetProxiesByStatus", s.ctx, models.ProxyStatusActive).Return([]models.Proxy{}, nil)
+	s.proxyRepo.On("GetProxyHealthByIDs", s.ctx, []primitive.ObjectID{}).Return(map[primitive.ObjectID]*models.ProxyHealth{}, nil)
+	s.proxyRepo.On("GetProxyStatistics", s.ctx).Return(&models.ProxyStats{}, nil)
 
 	// No proxies to check
+	NewHealthChecker(s.proxyRepo, s.rabbitmq, s.logger, s.config).performHealthChecks(s.ctx)
+
 	s.proxyRepo.AssertExpectations(s.T())
+	s.proxyRepo.AssertNotCalled(s.T(), "UpdateProxyHealth", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Test ScheduleHealthCheck - immediate check
@@ -308,6 +326,9 @@ func (s *HealthCheckerTestSuite) TestScheduleHealthCheck_Immediate() {
 
 	s.rabbitmq.On("Publish", "", "proxy.health_check", request).Return(nil)
 
+	hc := NewHealthChecker(s.proxyRepo, s.rabbitmq, s.logger, s.config)
+	s.Require().NoError(hc.ScheduleHealthCheck(s.ctx, proxyID, 0))
+
 	s.rabbitmq.AssertExpectations(s.T())
 }
 
@@ -410,5 +431,3 @@ func BenchmarkIPQSResponseParsing(b *testing.B) {
 		_ = json.Unmarshal(jsonData, &response)
 	}
 }
-
-
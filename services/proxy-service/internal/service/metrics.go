@@ -1,6 +1,8 @@
 package service
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -47,6 +49,14 @@ var (
 		},
 	)
 
+	proxyFraudChecksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_fraud_checks_total",
+			Help: "Total number of fraud checks by provider and result",
+		},
+		[]string{"provider", "result"},
+	)
+
 	proxyLatency = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "proxy_latency_milliseconds",
@@ -55,6 +65,15 @@ var (
 		},
 	)
 
+	proxyRegionLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "proxy_region_latency_milliseconds",
+			Help:    "Proxy latency in milliseconds by checker region",
+			Buckets: prometheus.ExponentialBuckets(10, 2, 10),
+		},
+		[]string{"region"},
+	)
+
 	activeProxiesCount = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "active_proxies_count",
@@ -89,6 +108,134 @@ var (
 			Help: "Total number of proxy rotation errors",
 		},
 	)
+
+	proxyTrafficBytesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_traffic_bytes_total",
+			Help: "Total bytes transferred through proxies",
+		},
+		[]string{"provider", "direction"},
+	)
+
+	proxyRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_requests_total",
+			Help: "Total number of requests sent through proxies",
+		},
+		[]string{"provider"},
+	)
+
+	proxyTrafficCapWarnings = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_traffic_cap_warnings_total",
+			Help: "Total number of provider traffic cap warnings",
+		},
+		[]string{"provider", "level"},
+	)
+
+	proxyAllocationFallbacks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_allocation_fallback_level_total",
+			Help: "Total number of chained allocations by preferred country and fallback level used",
+		},
+		[]string{"preferred_country", "level"},
+	)
+
+	proxyCapabilityProbes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_capability_probes_total",
+			Help: "Total number of proxy capability probes by capability and result",
+		},
+		[]string{"capability", "result"},
+	)
+
+	proxyPolicyAllocations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_policy_allocations_total",
+			Help: "Total number of allocations requested with a named policy by result",
+		},
+		[]string{"policy", "result"},
+	)
+
+	proxyPolicyAllocationDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "proxy_policy_allocation_duration_seconds",
+			Help:    "Duration of allocations requested with a named policy in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{"policy"},
+	)
+
+	proxyExitIPChecksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_exit_ip_checks_total",
+			Help: "Total number of proxy exit IP checks by result",
+		},
+		[]string{"result"},
+	)
+
+	proxyExitIPChangesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_exit_ip_changes_total",
+			Help: "Total number of detected proxy exit IP changes",
+		},
+		[]string{"provider"},
+	)
+
+	proxyImportsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_imports_total",
+			Help: "Total number of manually imported proxy entries by result",
+		},
+		[]string{"status"},
+	)
+
+	proxyEventSubscribers = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "proxy_event_subscribers",
+			Help: "Number of active proxy event stream subscribers",
+		},
+	)
+
+	proxyEventsDroppedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_events_dropped_total",
+			Help: "Total number of proxy events dropped for subscribers that fell behind",
+		},
+		[]string{"type"},
+	)
+
+	proxyPurchasesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_purchases_total",
+			Help: "Total number of proxies purchased by provider",
+		},
+		[]string{"provider"},
+	)
+
+	proxyPurchaseSpendTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_purchase_spend_total",
+			Help: "Total amount paid to proxy providers in the provider's currency",
+		},
+		[]string{"provider"},
+	)
+
+	proxyProviderMonthSpend = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "proxy_provider_month_spend",
+			Help: "Amount spent on the provider in the current budget month",
+		},
+		[]string{"provider"},
+	)
+
+	proxyBudgetSkipsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_budget_skips_total",
+			Help: "Total number of purchases skipped because the provider is over its monthly budget",
+		},
+		[]string{"provider"},
+	)
 )
 
 func RecordProxyAllocation(proxyType, country string) {
@@ -111,10 +258,18 @@ func RecordFraudScore(score float64) {
 	proxyFraudScore.Observe(score)
 }
 
+func RecordFraudCheck(provider, result string) {
+	proxyFraudChecksTotal.WithLabelValues(provider, result).Inc()
+}
+
 func RecordLatency(latency float64) {
 	proxyLatency.Observe(latency)
 }
 
+func RecordRegionLatency(region string, latency float64) {
+	proxyRegionLatency.WithLabelValues(region).Observe(latency)
+}
+
 func SetActiveProxies(count float64) {
 	activeProxiesCount.Set(count)
 }
@@ -134,3 +289,63 @@ func RecordAllocationError() {
 func RecordRotationError() {
 	proxyRotationErrors.Inc()
 }
+
+func RecordProxyTraffic(provider string, bytesSent, bytesReceived, requests int64) {
+	proxyTrafficBytesTotal.WithLabelValues(provider, "sent").Add(float64(bytesSent))
+	proxyTrafficBytesTotal.WithLabelValues(provider, "received").Add(float64(bytesReceived))
+	proxyRequestsTotal.WithLabelValues(provider).Add(float64(requests))
+}
+
+func RecordTrafficCapWarning(provider string, level int) {
+	proxyTrafficCapWarnings.WithLabelValues(provider, strconv.Itoa(level)).Inc()
+}
+
+func RecordAllocationFallbackLevel(preferredCountry string, level int) {
+	proxyAllocationFallbacks.WithLabelValues(preferredCountry, strconv.Itoa(level)).Inc()
+}
+
+func RecordCapabilityProbe(capability string, supported bool) {
+	result := "unsupported"
+	if supported {
+		result = "supported"
+	}
+	proxyCapabilityProbes.WithLabelValues(capability, result).Inc()
+}
+
+func RecordProxyImport(status string) {
+	proxyImportsTotal.WithLabelValues(status).Inc()
+}
+
+func RecordPolicyAllocation(policy, result string, duration float64) {
+	proxyPolicyAllocations.WithLabelValues(policy, result).Inc()
+	proxyPolicyAllocationDuration.WithLabelValues(policy).Observe(duration)
+}
+
+func RecordExitIPCheck(result string) {
+	proxyExitIPChecksTotal.WithLabelValues(result).Inc()
+}
+
+func RecordExitIPChange(provider string) {
+	proxyExitIPChangesTotal.WithLabelValues(provider).Inc()
+}
+
+func SetEventSubscribers(count float64) {
+	proxyEventSubscribers.Set(count)
+}
+
+func RecordDroppedProxyEvent(eventType string) {
+	proxyEventsDroppedTotal.WithLabelValues(eventType).Inc()
+}
+
+func RecordProxyPurchase(provider string, cost float64) {
+	proxyPurchasesTotal.WithLabelValues(provider).Inc()
+	proxyPurchaseSpendTotal.WithLabelValues(provider).Add(cost)
+}
+
+func SetProviderMonthSpend(provider string, amount float64) {
+	proxyProviderMonthSpend.WithLabelValues(provider).Set(amount)
+}
+
+func RecordBudgetSkip(provider string) {
+	proxyBudgetSkipsTotal.WithLabelValues(provider).Inc()
+}
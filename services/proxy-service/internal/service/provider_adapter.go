@@ -14,9 +14,9 @@ import (
 	"time"
 
 	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
 
@@ -32,42 +32,95 @@ type ProviderAdapter interface {
 type HTTPProviderAdapter struct {
 	provider  models.ProxyProvider
 	client    *http.Client
-	logger    *logrus.Logger
+	logger    logger.Logger
 	encryptor *crypto.Encryptor
 	mu        sync.RWMutex
 }
 
 type ProviderManager struct {
-	providers map[string]ProviderAdapter
-	config    *models.ProviderConfig
-	logger    *logrus.Logger
-	encryptor *crypto.Encryptor
-	mu        sync.RWMutex
+	providers  map[string]ProviderAdapter
+	config     *models.ProviderConfig
+	configPath string
+	logger     logger.Logger
+	encryptor  *crypto.Encryptor
+	mu         sync.RWMutex
 }
 
-func NewProviderManager(configPath string, logger *logrus.Logger, encryptor *crypto.Encryptor) (*ProviderManager, error) {
+func NewProviderManager(configPath string, logger logger.Logger, encryptor *crypto.Encryptor) (*ProviderManager, error) {
 	config, err := LoadProviderConfigs(configPath)
 	if err != nil {
 		return nil, err
 	}
 
 	manager := &ProviderManager{
-		providers: make(map[string]ProviderAdapter),
-		config:    config,
-		logger:    logger,
-		encryptor: encryptor,
+		config:     config,
+		configPath: configPath,
+		logger:     logger,
+		encryptor:  encryptor,
+	}
+	manager.providers = manager.buildAdapters(config)
+
+	return manager, nil
+}
+
+// Reload re-reads the provider config and rebuilds adapters, picking up rotated API keys.
+// The current adapters stay in place if the config cannot be loaded.
+func (m *ProviderManager) Reload() error {
+	config, err := LoadProviderConfigs(m.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to reload provider config: %w", err)
+	}
+
+	m.apply(config)
+	return nil
+}
+
+// ApplyConfig rebuilds adapters from a provider config document delivered by the config watcher
+func (m *ProviderManager) ApplyConfig(data []byte) error {
+	config, err := ParseProviderConfigs(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse provider config: %w", err)
 	}
 
+	m.apply(config)
+	return nil
+}
+
+func (m *ProviderManager) apply(config *models.ProviderConfig) {
+	providers := m.buildAdapters(config)
+
+	m.mu.Lock()
+	m.config = config
+	m.providers = providers
+	m.mu.Unlock()
+
+	m.logger.Infof("Reloaded %d proxy providers", len(providers))
+}
+
+// MinPoolSize returns the number of spare proxies the enabled providers ask to keep in the pool
+func (m *ProviderManager) MinPoolSize() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	total := 0
+	for _, provider := range m.config.Providers {
+		if provider.Enabled {
+			total += provider.Parameters.MinPoolSize
+		}
+	}
+	return total
+}
+
+func (m *ProviderManager) buildAdapters(config *models.ProviderConfig) map[string]ProviderAdapter {
+	providers := make(map[string]ProviderAdapter)
 	for _, providerConfig := range config.Providers {
 		if !providerConfig.Enabled {
 			continue
 		}
 
-		adapter := NewHTTPProviderAdapter(providerConfig, logger, encryptor)
-		manager.providers[providerConfig.Name] = adapter
+		providers[providerConfig.Name] = NewHTTPProviderAdapter(providerConfig, m.logger, m.encryptor)
 	}
-
-	return manager, nil
+	return providers
 }
 
 func LoadProviderConfigs(path string) (*models.ProviderConfig, error) {
@@ -76,6 +129,10 @@ func LoadProviderConfigs(path string) (*models.ProviderConfig, error) {
 		return nil, err
 	}
 
+	return ParseProviderConfigs(data)
+}
+
+func ParseProviderConfigs(data []byte) (*models.ProviderConfig, error) {
 	expandedData := os.ExpandEnv(string(data))
 
 	var config models.ProviderConfig
@@ -83,9 +140,27 @@ func LoadProviderConfigs(path string) (*models.ProviderConfig, error) {
 		return nil, err
 	}
 
+	for name, policy := range config.Policies {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("allocation policy %s: %w", name, err)
+		}
+	}
+
 	return &config, nil
 }
 
+// GetPolicy returns the named allocation policy from the current provider config
+func (m *ProviderManager) GetPolicy(name string) (*models.AllocationPolicy, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	policy, ok := m.config.Policies[name]
+	if !ok {
+		return nil, false
+	}
+	return &policy, true
+}
+
 func (m *ProviderManager) GetProviderByName(name string) (ProviderAdapter, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -110,7 +185,37 @@ func (m *ProviderManager) GetActiveProviders() []ProviderAdapter {
 	return providers
 }
 
-func NewHTTPProviderAdapter(provider models.ProxyProvider, logger *logrus.Logger, encryptor *crypto.Encryptor) *HTTPProviderAdapter {
+// PurchaseCandidates returns the enabled providers that sell proxies matching params, cheapest first
+func (m *ProviderManager) PurchaseCandidates(params models.ProxyPurchaseParams) []models.ProxyProvider {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var candidates []models.ProxyProvider
+	for _, provider := range m.config.Providers {
+		if _, ok := m.providers[provider.Name]; !ok || !provider.Supports(params) {
+			continue
+		}
+		candidates = append(candidates, provider)
+	}
+
+	models.SortByPurchaseCost(candidates)
+	return candidates
+}
+
+func (m *ProviderManager) GetProviderConfig(name string) (*models.ProxyProvider, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i := range m.config.Providers {
+		if m.config.Providers[i].Name == name {
+			return &m.config.Providers[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func NewHTTPProviderAdapter(provider models.ProxyProvider, logger logger.Logger, encryptor *crypto.Encryptor) *HTTPProviderAdapter {
 	client := &http.Client{
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
@@ -340,10 +445,10 @@ func (a *HTTPProviderAdapter) GetProviderConfig() models.ProxyProvider {
 
 type MockProviderAdapter struct {
 	name   string
-	logger *logrus.Logger
+	logger logger.Logger
 }
 
-func NewMockProviderAdapter(name string, logger *logrus.Logger) *MockProviderAdapter {
+func NewMockProviderAdapter(name string, logger logger.Logger) *MockProviderAdapter {
 	return &MockProviderAdapter{
 		name:   name,
 		logger: logger,
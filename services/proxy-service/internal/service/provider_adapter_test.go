@@ -3,17 +3,17 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -22,15 +22,14 @@ type ProviderAdapterTestSuite struct {
 	suite.Suite
 	ctx       context.Context
 	cancel    context.CancelFunc
-	logger    *logrus.Logger
+	logger    logger.Logger
 	encryptor *crypto.Encryptor
 }
 
 func (s *ProviderAdapterTestSuite) SetupTest() {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
-	s.logger = logrus.New()
-	s.logger.SetLevel(logrus.DebugLevel)
-	
+	s.logger = logger.NewFromConfig(logger.Config{Output: io.Discard})
+
 	var err error
 	s.encryptor, err = crypto.NewEncryptor("12345678901234567890123456789012")
 	s.Require().NoError(err)
@@ -550,6 +549,90 @@ func (s *ProviderAdapterTestSuite) TestProviderManager_GetProviderByName() {
 	// Note: This would require mocking the config file loading
 }
 
+// Test ProviderManager hot reload of pool sizes
+func (s *ProviderAdapterTestSuite) TestProviderManager_ApplyConfig() {
+	manager := &ProviderManager{
+		config:    &models.ProviderConfig{},
+		logger:    s.logger,
+		encryptor: s.encryptor,
+	}
+	s.Equal(0, manager.MinPoolSize())
+
+	err := manager.ApplyConfig([]byte(`
+providers:
+  - name: "provider1"
+    enabled: true
+    api:
+      base_url: "https://api.provider1.com"
+    parameters:
+      min_pool_size: 10
+  - name: "provider2"
+    enabled: true
+    api:
+      base_url: "https://api.provider2.com"
+    parameters:
+      min_pool_size: 20
+  - name: "provider3"
+    enabled: false
+    parameters:
+      min_pool_size: 5
+`))
+	s.Require().NoError(err)
+	s.Equal(30, manager.MinPoolSize())
+	s.Len(manager.GetActiveProviders(), 2)
+
+	// A broken document keeps the current providers
+	s.Error(manager.ApplyConfig([]byte("providers: [broken")))
+	s.Equal(30, manager.MinPoolSize())
+}
+
+func (s *ProviderAdapterTestSuite) TestProviderManager_Policies() {
+	manager := &ProviderManager{
+		config:    &models.ProviderConfig{},
+		logger:    s.logger,
+		encryptor: s.encryptor,
+	}
+
+	err := manager.ApplyConfig([]byte(`
+providers:
+  - name: "provider1"
+    enabled: true
+policies:
+  warming:
+    type: "residential"
+    country_chain:
+      - country: "RU"
+        max_allocations: 100
+      - country: "KZ"
+    providers: ["provider1"]
+    max_fraud_score: 40
+    weights:
+      fraud_score: 2
+      latency: 1
+    rotation_interval: "168h"
+`))
+	s.Require().NoError(err)
+
+	policy, ok := manager.GetPolicy("warming")
+	s.Require().True(ok)
+	s.Equal(models.ProxyTypeResidential, policy.Type)
+	s.Equal([]models.CountryPreference{{Country: "RU", MaxAllocations: 100}, {Country: "KZ"}}, policy.CountryChain)
+	s.Equal(40.0, policy.MaxFraudScore)
+	s.Equal(2.0, policy.Weights.FraudScore)
+
+	_, ok = manager.GetPolicy("registration")
+	s.False(ok)
+
+	// An invalid policy rejects the whole document
+	s.Error(manager.ApplyConfig([]byte(`
+policies:
+  registration:
+    rotation_interval: "daily"
+`)))
+	_, ok = manager.GetPolicy("warming")
+	s.True(ok)
+}
+
 // Table-driven tests for auth types
 func TestAuthTypes(t *testing.T) {
 	tests := []struct {
@@ -637,8 +720,7 @@ func BenchmarkPurchaseProxy(b *testing.B) {
 	}))
 	defer server.Close()
 
-	logger := logrus.New()
-	logger.SetLevel(logrus.ErrorLevel)
+	logger := logger.NewFromConfig(logger.Config{Output: io.Discard})
 
 	provider := models.ProxyProvider{
 		Name:    "bench-provider",
@@ -673,8 +755,7 @@ func BenchmarkListProxies(b *testing.B) {
 	}))
 	defer server.Close()
 
-	logger := logrus.New()
-	logger.SetLevel(logrus.ErrorLevel)
+	logger := logger.NewFromConfig(logger.Config{Output: io.Discard})
 
 	provider := models.ProxyProvider{
 		Name:    "bench-provider",
@@ -695,4 +776,3 @@ func BenchmarkListProxies(b *testing.B) {
 		_, _ = adapter.ListProxies(ctx)
 	}
 }
-
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+)
+
+// ErrProviderBudgetExhausted is returned when every provider able to sell the proxy has spent its monthly budget
+var ErrProviderBudgetExhausted = errors.New("provider monthly budgets exhausted")
+
+// purchasedEventType is the type of spend events, analytics keeps them in the cost ledger
+const purchasedEventType = "proxy.purchased"
+
+// PurchaseEvent reports money paid to a provider for a new proxy
+type PurchaseEvent struct {
+	Type          string    `json:"type"`
+	Provider      string    `json:"provider"`
+	IP            string    `json:"ip"`
+	Port          int       `json:"port"`
+	ProxyType     string    `json:"proxy_type,omitempty"`
+	Country       string    `json:"country,omitempty"`
+	Cost          float64   `json:"cost"`
+	Currency      string    `json:"currency,omitempty"`
+	Month         string    `json:"month"`
+	MonthSpend    float64   `json:"month_spend"`              // Spent on the provider this month including this purchase
+	MonthlyBudget float64   `json:"monthly_budget,omitempty"` // 0 means unlimited
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// buyProxy purchases a proxy from the cheapest provider that sells matching proxies, is allowed
+// by the policy and still has budget this month. The list price is reserved before the purchase
+// and corrected to the price the provider reports afterwards.
+func (s *ProxyService) buyProxy(ctx context.Context, params models.ProxyPurchaseParams, policy *models.AllocationPolicy) (*models.Proxy, error) {
+	candidates := s.providerManager.PurchaseCandidates(params)
+	if len(candidates) == 0 {
+		return nil, errors.New("no active providers available")
+	}
+
+	month := models.SpendMonth(time.Now())
+	overBudget := false
+	var lastError error
+
+	for i := range candidates {
+		provider := &candidates[i]
+		if policy != nil && !policy.AllowsProvider(provider.Name) {
+			continue
+		}
+
+		adapter, err := s.providerManager.GetProviderByName(provider.Name)
+		if err != nil {
+			continue
+		}
+
+		listPrice := provider.Pricing.CostPerProxy
+		reserved, err := s.providerRepo.ReserveProviderSpend(ctx, provider.Name, month, listPrice, provider.Pricing.MonthlyBudget)
+		if err != nil {
+			// Without the spend we cannot tell whether the budget allows another purchase
+			lastError = err
+			continue
+		}
+		if !reserved {
+			overBudget = true
+			RecordBudgetSkip(provider.Name)
+			s.logger.Warnf("Provider %s is over its monthly budget of %.2f %s, skipping", provider.Name, provider.Pricing.MonthlyBudget, provider.Pricing.Currency)
+			continue
+		}
+
+		params.Provider = provider.Name
+		proxyResp, err := adapter.PurchaseProxy(ctx, params)
+		if err != nil {
+			lastError = err
+			s.logger.WithError(err).Warnf("Failed to purchase proxy from %s", provider.Name)
+			if _, err := s.providerRepo.AddProviderSpend(ctx, provider.Name, month, -listPrice, 0); err != nil {
+				s.logger.WithError(err).Warn("Failed to release reserved provider spend")
+			}
+			continue
+		}
+
+		proxy := &models.Proxy{
+			Provider:     provider.Name,
+			IP:           proxyResp.IP,
+			Port:         proxyResp.Port,
+			Protocol:     proxyResp.Protocol,
+			Username:     proxyResp.Username,
+			Password:     proxyResp.Password,
+			Type:         params.Type,
+			Country:      proxyResp.Country,
+			City:         proxyResp.City,
+			Status:       models.ProxyStatusActive,
+			ExpiresAt:    proxyResp.ExpireAt,
+			PurchaseCost: provider.PurchaseCost(proxyResp),
+		}
+
+		s.recordPurchase(ctx, provider, proxy, month, proxy.PurchaseCost-listPrice)
+		return proxy, nil
+	}
+
+	if lastError != nil {
+		return nil, lastError
+	}
+	if overBudget {
+		return nil, ErrProviderBudgetExhausted
+	}
+
+	return nil, errors.New("failed to purchase proxy from any provider")
+}
+
+// recordPurchase settles the reserved spend and publishes the spend event
+func (s *ProxyService) recordPurchase(ctx context.Context, provider *models.ProxyProvider, proxy *models.Proxy, month string, correction float64) {
+	event := PurchaseEvent{
+		Type:          purchasedEventType,
+		Provider:      provider.Name,
+		IP:            proxy.IP,
+		Port:          proxy.Port,
+		ProxyType:     string(proxy.Type),
+		Country:       proxy.Country,
+		Cost:          proxy.PurchaseCost,
+		Currency:      provider.Pricing.Currency,
+		Month:         month,
+		MonthlyBudget: provider.Pricing.MonthlyBudget,
+		Timestamp:     time.Now(),
+	}
+
+	RecordProxyPurchase(provider.Name, proxy.PurchaseCost)
+
+	spend, err := s.providerRepo.AddProviderSpend(ctx, provider.Name, month, correction, 1)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to record provider spend")
+	} else {
+		event.MonthSpend = spend.Amount
+		SetProviderMonthSpend(provider.Name, spend.Amount)
+
+		if budget := provider.Pricing.MonthlyBudget; budget > 0 && spend.Amount+provider.Pricing.CostPerProxy > budget {
+			s.logger.Warnf("Provider %s has spent %.2f of its %.2f %s monthly budget, no more purchases this month",
+				provider.Name, spend.Amount, budget, provider.Pricing.Currency)
+		}
+	}
+
+	if err := s.rabbitmq.Publish("proxy.events", purchasedEventType, event); err != nil {
+		s.logger.WithError(err).Error("Failed to publish purchase event")
+	}
+}
+
+// GetProviderSpend reports the month's spend of every provider that was paid or has a budget
+func (s *ProxyService) GetProviderSpend(ctx context.Context, month string) ([]models.ProviderSpend, error) {
+	spend, err := s.providerRepo.GetProviderSpend(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	reported := make(map[string]bool, len(spend))
+	for i := range spend {
+		reported[spend[i].Provider] = true
+		if provider, ok := s.providerManager.GetProviderConfig(spend[i].Provider); ok {
+			spend[i].MonthlyBudget = provider.Pricing.MonthlyBudget
+			spend[i].Currency = provider.Pricing.Currency
+		}
+	}
+
+	for _, provider := range s.providerManager.PurchaseCandidates(models.ProxyPurchaseParams{}) {
+		if reported[provider.Name] || provider.Pricing.MonthlyBudget <= 0 {
+			continue
+		}
+		spend = append(spend, models.ProviderSpend{
+			Provider:      provider.Name,
+			Month:         month,
+			MonthlyBudget: provider.Pricing.MonthlyBudget,
+			Currency:      provider.Pricing.Currency,
+		})
+	}
+
+	return spend, nil
+}
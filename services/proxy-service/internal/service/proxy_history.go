@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+)
+
+// recordProxyUsage adds a new binding to the account's proxy history. A failure is only
+// logged, the binding itself already succeeded.
+func recordProxyUsage(ctx context.Context, proxyRepo proxyStore, logger logger.Logger, proxy *models.Proxy, accountID, platform string) {
+	if err := proxyRepo.RecordProxyUsage(ctx, models.NewProxyUsageRecord(proxy, accountID, platform)); err != nil {
+		logger.WithError(err).Warnf("Failed to record proxy %s in the history of account %s", proxy.ID.Hex(), accountID)
+	}
+}
+
+// GetProxyHistory returns every proxy the account was bound to, latest first
+func (s *ProxyService) GetProxyHistory(ctx context.Context, accountID string) ([]models.ProxyUsageRecord, error) {
+	return s.proxyRepo.GetProxyHistory(ctx, accountID)
+}
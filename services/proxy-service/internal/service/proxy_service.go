@@ -5,51 +5,71 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/grigta/conveer/pkg/cache"
 	"github.com/grigta/conveer/pkg/config"
-	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 	"github.com/grigta/conveer/services/proxy-service/internal/repository"
 
-	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ProxyService struct {
-	proxyRepo       *repository.ProxyRepository
-	providerRepo    *repository.ProviderRepository
+	proxyRepo       proxyStore
+	providerRepo    providerStore
 	providerManager *ProviderManager
 	healthChecker   *HealthChecker
 	rotationManager *RotationManager
-	rabbitmq        *messaging.RabbitMQ
-	redis           *cache.RedisCache
-	logger          *logrus.Logger
+	rabbitmq        broker
+	redis           accountCache
+	logger          logger.Logger
 	config          *config.Config
+	events          *EventBus
 	mu              sync.RWMutex
 }
 
 type AllocationEvent struct {
-	ProxyID   string    `json:"proxy_id"`
-	AccountID string    `json:"account_id"`
-	IP        string    `json:"ip"`
-	Port      int       `json:"port"`
-	Type      string    `json:"type"`
-	Country   string    `json:"country"`
-	Timestamp time.Time `json:"timestamp"`
+	ProxyID       string    `json:"proxy_id"`
+	AccountID     string    `json:"account_id"`
+	IP            string    `json:"ip"`
+	Port          int       `json:"port"`
+	Type          string    `json:"type"`
+	Country       string    `json:"country"`
+	Provider      string    `json:"provider"`
+	FallbackLevel int       `json:"fallback_level"`
+	Cost          float64   `json:"cost"` // Price paid for the proxy, or the provider list price, feeds the analytics cost ledger
+	Currency      string    `json:"currency,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
 }
 
+var (
+	ErrCountryLimitReached   = repository.ErrCountryLimitReached
+	ErrCountryChainExhausted = errors.New("no proxy available in any country of the chain")
+	ErrUnknownCapability     = errors.New("unknown proxy capability")
+	ErrCapabilityUnavailable = errors.New("proxy lacks required capability")
+	ErrUnknownPolicy         = errors.New("unknown allocation policy")
+	ErrNoProxyForAccount     = errors.New("no proxy found for account")
+)
+
+const defaultTrafficAlertPct = 80
+
+// defaultMinPoolSize is used when no enabled provider sets min_pool_size
+const defaultMinPoolSize = 10
+
 func NewProxyService(
-	proxyRepo *repository.ProxyRepository,
-	providerRepo *repository.ProviderRepository,
+	proxyRepo proxyStore,
+	providerRepo providerStore,
 	providerManager *ProviderManager,
 	healthChecker *HealthChecker,
 	rotationManager *RotationManager,
-	rabbitmq *messaging.RabbitMQ,
-	redis *cache.RedisCache,
-	logger *logrus.Logger,
+	rabbitmq broker,
+	redis accountCache,
+	logger logger.Logger,
 	config *config.Config,
 ) *ProxyService {
 	return &ProxyService{
@@ -65,12 +85,20 @@ func NewProxyService(
 	}
 }
 
+// SetEventBus enables pushing allocation, health and rotation events to in-process subscribers
+func (s *ProxyService) SetEventBus(events *EventBus) {
+	s.events = events
+	s.healthChecker.SetEventBus(events)
+	s.rotationManager.SetEventBus(events)
+}
+
 func (s *ProxyService) Start(ctx context.Context) {
 	s.healthChecker.Start(ctx)
 	s.rotationManager.Start(ctx)
 
 	go s.consumeAllocationRequests(ctx)
 	go s.consumeReleaseRequests(ctx)
+	go s.consumeUsageReports(ctx)
 	go s.RefreshProxyPoolPeriodically(ctx)
 }
 
@@ -80,8 +108,44 @@ func (s *ProxyService) Stop() {
 }
 
 func (s *ProxyService) AllocateProxy(ctx context.Context, request models.ProxyAllocationRequest) (*models.Proxy, error) {
+	allocation, err := s.AllocateProxyWithFallback(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return allocation.Proxy, nil
+}
+
+// AllocateProxyWithFallback walks the request's country chain and binds the first proxy
+// that fits, reporting which fallback level was used. A named policy fills in the fields
+// the request leaves empty and decides how pooled proxies are ranked.
+func (s *ProxyService) AllocateProxyWithFallback(ctx context.Context, request models.ProxyAllocationRequest) (*models.ProxyAllocation, error) {
+	if request.Policy == "" {
+		return s.allocateWithFallback(ctx, request, nil)
+	}
+
+	policy, ok := s.providerManager.GetPolicy(request.Policy)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPolicy, request.Policy)
+	}
+
+	start := time.Now()
+	allocation, err := s.allocateWithFallback(ctx, policy.Apply(request), policy)
+	RecordPolicyAllocation(request.Policy, policyAllocationResult(allocation, err), time.Since(start).Seconds())
+
+	return allocation, err
+}
+
+func (s *ProxyService) allocateWithFallback(ctx context.Context, request models.ProxyAllocationRequest, policy *models.AllocationPolicy) (*models.ProxyAllocation, error) {
 	s.logger.Infof("Allocating proxy for account %s", request.AccountID)
 
+	chain := request.Chain()
+
+	for _, capability := range request.Requires {
+		if !models.IsProxyCapability(capability) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCapability, capability)
+		}
+	}
+
 	existingProxy, err := s.proxyRepo.GetProxyByAccountID(ctx, request.AccountID)
 	if err != nil {
 		return nil, err
@@ -89,7 +153,11 @@ func (s *ProxyService) AllocateProxy(ctx context.Context, request models.ProxyAl
 
 	if existingProxy != nil {
 		s.logger.Infof("Account %s already has proxy %s", request.AccountID, existingProxy.ID.Hex())
-		return existingProxy, nil
+		// The binding is kept: changing the exit IP of a live account costs more than a missing capability
+		if missing := missingCapabilities(existingProxy.Capabilities, request.Requires); len(missing) > 0 {
+			s.logger.Warnf("Proxy %s of account %s lacks required capabilities %v", existingProxy.ID.Hex(), request.AccountID, missing)
+		}
+		return newProxyAllocation(existingProxy, chain), nil
 	}
 
 	cacheKey := fmt.Sprintf("proxy:account:%s", request.AccountID)
@@ -97,79 +165,288 @@ func (s *ProxyService) AllocateProxy(ctx context.Context, request models.ProxyAl
 		if proxyID, err := primitive.ObjectIDFromHex(cachedProxyID); err == nil {
 			if proxy, err := s.proxyRepo.GetProxyByID(ctx, proxyID); err == nil && proxy.Status == models.ProxyStatusActive {
 				s.logger.Infof("Found cached proxy %s for account %s", proxy.ID.Hex(), request.AccountID)
-				return proxy, nil
+				return newProxyAllocation(proxy, chain), nil
 			}
 		}
 	}
 
-	filters := models.ProxyFilters{
-		Type:    request.Type,
-		Country: request.Country,
-		Status:  models.ProxyStatusActive,
+	var proxy *models.Proxy
+	var levelErrors []string
+	level := 0
+
+	for i, preference := range chain {
+		proxy, err = s.allocateInCountry(ctx, request, preference, policy)
+		if err == nil {
+			level = i
+			break
+		}
+
+		s.logger.WithError(err).Warnf("Country %q unavailable for account %s", preference.Country, request.AccountID)
+		levelErrors = append(levelErrors, fmt.Sprintf("%s: %v", countryLabel(preference.Country), err))
 	}
 
-	availableProxies, err := s.proxyRepo.GetAvailableProxies(ctx, filters)
-	if err != nil {
-		return nil, err
+	if proxy == nil {
+		if len(chain) == 1 {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", ErrCountryChainExhausted, strings.Join(levelErrors, "; "))
 	}
 
-	var proxy *models.Proxy
+	recordProxyUsage(ctx, s.proxyRepo, s.logger, proxy, request.AccountID, request.Platform)
 
-	if len(availableProxies) > 0 {
-		for _, p := range availableProxies {
-			if err := s.proxyRepo.BindProxyToAccount(ctx, p.ID, request.AccountID); err == nil {
-				proxy = &p
-				break
-			}
+	if err := s.redis.Set(ctx, cacheKey, proxy.ID.Hex(), 1*time.Hour); err != nil {
+		s.logger.WithError(err).Warn("Failed to cache proxy allocation")
+	}
+
+	if err := s.rotationManager.ScheduleRotation(ctx, proxy.ID, request.AccountID, rotationDeadline(proxy.ExpiresAt, policy)); err != nil {
+		s.logger.WithError(err).Warn("Failed to schedule rotation")
+	}
+
+	event := AllocationEvent{
+		ProxyID:       proxy.ID.Hex(),
+		AccountID:     request.AccountID,
+		IP:            proxy.IP,
+		Port:          proxy.Port,
+		Type:          string(proxy.Type),
+		Country:       proxy.Country,
+		Provider:      proxy.Provider,
+		FallbackLevel: level,
+		Timestamp:     time.Now(),
+	}
+	if provider, ok := s.providerManager.GetProviderConfig(proxy.Provider); ok {
+		event.Cost = provider.Pricing.CostPerProxy
+		event.Currency = provider.Pricing.Currency
+	}
+	if proxy.PurchaseCost > 0 {
+		event.Cost = proxy.PurchaseCost
+	}
+
+	if err := s.rabbitmq.Publish("proxy.events", "proxy.allocated", event); err != nil {
+		s.logger.WithError(err).Error("Failed to publish allocation event")
+	}
+
+	allocated := proxyEvent(models.ProxyEventAllocated, proxy, request.AccountID)
+	allocated.Details = map[string]string{"fallback_level": strconv.Itoa(level)}
+	s.events.Publish(allocated)
+
+	RecordProxyAllocation(string(proxy.Type), proxy.Country)
+	if len(chain) > 1 {
+		RecordAllocationFallbackLevel(chain[0].Country, level)
+	}
+
+	s.logger.Infof("Successfully allocated proxy %s for account %s (country %s, fallback level %d)",
+		proxy.ID.Hex(), request.AccountID, proxy.Country, level)
+
+	return &models.ProxyAllocation{
+		Proxy:         proxy,
+		Country:       proxy.Country,
+		FallbackLevel: level,
+	}, nil
+}
+
+func policyAllocationResult(allocation *models.ProxyAllocation, err error) string {
+	switch {
+	case err == nil:
+		if allocation.FallbackLevel > 0 {
+			return "fallback"
 		}
+		return "allocated"
+	case errors.Is(err, ErrCountryChainExhausted), errors.Is(err, ErrCapabilityUnavailable), errors.Is(err, ErrCountryLimitReached):
+		return "unavailable"
+	default:
+		return "error"
 	}
+}
 
-	if proxy == nil {
-		s.logger.Info("No available proxies, purchasing new one")
+// rotationDeadline is when the proxy has to be rotated: before it expires, or earlier
+// when the policy sets a rotation cadence
+func rotationDeadline(expiresAt time.Time, policy *models.AllocationPolicy) time.Time {
+	if policy == nil {
+		return expiresAt
+	}
+
+	// Validated when the config was loaded
+	interval, _ := policy.RotationEvery()
+	if interval <= 0 {
+		return expiresAt
+	}
 
-		newProxy, err := s.purchaseNewProxy(ctx, request)
+	deadline := time.Now().Add(interval)
+	if !expiresAt.IsZero() && expiresAt.Before(deadline) {
+		return expiresAt
+	}
+	return deadline
+}
+
+// allocateInCountry binds a free pooled proxy of the country or purchases a new one,
+// unless the country already reached its allocation limit
+func (s *ProxyService) allocateInCountry(ctx context.Context, request models.ProxyAllocationRequest, preference models.CountryPreference, policy *models.AllocationPolicy) (*models.Proxy, error) {
+	// Only a shortcut to skip full countries, the limit itself is enforced by bindProxy
+	if preference.MaxAllocations > 0 && preference.Country != "" {
+		bound, err := s.proxyRepo.CountBoundProxiesByCountry(ctx, preference.Country)
 		if err != nil {
 			return nil, err
 		}
+		if bound >= int64(preference.MaxAllocations) {
+			return nil, fmt.Errorf("%w (%d/%d)", ErrCountryLimitReached, bound, preference.MaxAllocations)
+		}
+	}
 
-		if err := s.proxyRepo.CreateProxy(ctx, newProxy); err != nil {
+	filters := models.ProxyFilters{
+		Type:     request.Type,
+		Country:  preference.Country,
+		Status:   models.ProxyStatusActive,
+		Requires: request.Requires,
+	}
+	if policy != nil {
+		filters.Providers = policy.Providers
+	}
+
+	availableProxies, err := s.proxyRepo.GetAvailableProxies(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy != nil {
+		availableProxies, err = s.rankProxies(ctx, availableProxies, policy, s.platformRegion(request.Platform))
+		if err != nil {
 			return nil, err
 		}
+	}
 
-		if err := s.proxyRepo.BindProxyToAccount(ctx, newProxy.ID, request.AccountID); err != nil {
+	for _, p := range availableProxies {
+		err := s.bindProxy(ctx, p.ID, request.AccountID, preference)
+		if err == nil {
+			proxy := p
+			return &proxy, nil
+		}
+		if errors.Is(err, ErrCountryLimitReached) {
 			return nil, err
 		}
+	}
+
+	s.logger.Infof("No available proxies in %s, purchasing new one", countryLabel(preference.Country))
 
-		proxy = newProxy
+	purchaseRequest := request
+	purchaseRequest.Country = preference.Country
+	// Only SOCKS5 can relay UDP, HTTP proxies tunnel TCP alone
+	if len(request.Requires) > 0 && purchaseRequest.Protocol == "" {
+		purchaseRequest.Protocol = models.ProtocolSOCKS5
 	}
 
-	if err := s.redis.Set(ctx, cacheKey, proxy.ID.Hex(), 1*time.Hour); err != nil {
-		s.logger.WithError(err).Warn("Failed to cache proxy allocation")
+	newProxy, err := s.purchaseNewProxy(ctx, purchaseRequest, policy)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.rotationManager.ScheduleRotation(ctx, proxy.ID, request.AccountID, proxy.ExpiresAt); err != nil {
-		s.logger.WithError(err).Warn("Failed to schedule rotation")
+	if err := s.proxyRepo.CreateProxy(ctx, newProxy); err != nil {
+		return nil, err
 	}
 
-	event := AllocationEvent{
-		ProxyID:   proxy.ID.Hex(),
-		AccountID: request.AccountID,
-		IP:        proxy.IP,
-		Port:      proxy.Port,
-		Type:      string(proxy.Type),
-		Country:   proxy.Country,
-		Timestamp: time.Now(),
+	// A fresh proxy has never been probed, it stays in the pool if it can't serve this request
+	if len(request.Requires) > 0 {
+		capabilities := s.healthChecker.ProbeCapabilities(ctx, newProxy)
+		if missing := missingCapabilities(capabilities, request.Requires); len(missing) > 0 {
+			return nil, fmt.Errorf("%w: purchased proxy %s has no %s", ErrCapabilityUnavailable, newProxy.ID.Hex(), strings.Join(missing, ", "))
+		}
 	}
 
-	if err := s.rabbitmq.Publish("proxy.events", "proxy.allocated", event); err != nil {
-		s.logger.WithError(err).Error("Failed to publish allocation event")
+	// A purchased proxy that loses the race for the last slot stays in the pool
+	if err := s.bindProxy(ctx, newProxy.ID, request.AccountID, preference); err != nil {
+		return nil, err
 	}
 
-	RecordProxyAllocation(string(proxy.Type), proxy.Country)
+	return newProxy, nil
+}
 
-	s.logger.Infof("Successfully allocated proxy %s for account %s", proxy.ID.Hex(), request.AccountID)
+// bindProxy binds the proxy to the account. Under a country limit the count and the binding
+// happen in one transaction, so concurrent allocations cannot overshoot the limit.
+func (s *ProxyService) bindProxy(ctx context.Context, proxyID primitive.ObjectID, accountID string, preference models.CountryPreference) error {
+	if preference.MaxAllocations > 0 && preference.Country != "" {
+		return s.proxyRepo.BindProxyToAccountWithinLimit(ctx, proxyID, accountID, preference.Country, preference.MaxAllocations)
+	}
+	return s.proxyRepo.BindProxyToAccount(ctx, proxyID, accountID)
+}
 
-	return proxy, nil
+// rankProxies drops pooled proxies failing the policy's health thresholds and orders the
+// rest by the policy score, best first. With a region, latency measured from there is used
+// where the proxy was checked from it.
+func (s *ProxyService) rankProxies(ctx context.Context, proxies []models.Proxy, policy *models.AllocationPolicy, region string) ([]models.Proxy, error) {
+	if len(proxies) == 0 || (policy.Weights.IsZero() && policy.MaxFraudScore <= 0 && policy.MaxLatencyMs <= 0) {
+		return proxies, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(proxies))
+	for i, p := range proxies {
+		ids[i] = p.ID
+	}
+
+	health, err := s.proxyRepo.GetProxyHealthByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[primitive.ObjectID]float64, len(proxies))
+	ranked := make([]models.Proxy, 0, len(proxies))
+	for _, p := range proxies {
+		regional := health[p.ID].FromRegion(region)
+		if !policy.Admits(regional) {
+			continue
+		}
+
+		provider, _ := s.providerManager.GetProviderConfig(p.Provider)
+		scores[p.ID] = policy.Score(regional, provider)
+		ranked = append(ranked, p)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID] < scores[ranked[j].ID]
+	})
+
+	return ranked, nil
+}
+
+// platformRegion returns the datacenter region of the platform proxies are allocated for
+func (s *ProxyService) platformRegion(platform string) string {
+	if s.healthChecker == nil || platform == "" {
+		return ""
+	}
+	return s.healthChecker.PlatformRegion(platform)
+}
+
+// missingCapabilities returns the required capabilities the proxy doesn't support
+func missingCapabilities(capabilities models.ProxyCapabilities, required []string) []string {
+	var missing []string
+	for _, capability := range required {
+		if !capabilities.Has(capability) {
+			missing = append(missing, capability)
+		}
+	}
+	return missing
+}
+
+// newProxyAllocation describes an already bound proxy relative to the requested chain
+func newProxyAllocation(proxy *models.Proxy, chain []models.CountryPreference) *models.ProxyAllocation {
+	level := 0
+	for i, preference := range chain {
+		if preference.Country == proxy.Country {
+			level = i
+			break
+		}
+	}
+
+	return &models.ProxyAllocation{
+		Proxy:         proxy,
+		Country:       proxy.Country,
+		FallbackLevel: level,
+	}
+}
+
+func countryLabel(country string) string {
+	if country == "" {
+		return "any country"
+	}
+	return country
 }
 
 func (s *ProxyService) ReleaseProxy(ctx context.Context, accountID string) error {
@@ -214,6 +491,8 @@ func (s *ProxyService) ReleaseProxy(ctx context.Context, accountID string) error
 		s.logger.WithError(err).Error("Failed to publish release event")
 	}
 
+	s.events.Publish(proxyEvent(models.ProxyEventReleased, proxy, accountID))
+
 	if err := s.providerRepo.IncrementProviderCounter(ctx, proxy.Provider, "total_released"); err != nil {
 		s.logger.WithError(err).Warn("Failed to increment provider release counter")
 	}
@@ -247,6 +526,35 @@ func (s *ProxyService) GetProxyForAccount(ctx context.Context, accountID string)
 	return proxy, nil
 }
 
+// GetExitIP returns the exit IP of the account's proxy. Platform services call it with refresh
+// before sensitive steps such as login or payment, to notice a rotation right before them;
+// without refresh the last recorded IP is returned unless the proxy was never checked.
+func (s *ProxyService) GetExitIP(ctx context.Context, accountID string, refresh bool) (*models.ExitIPStatus, error) {
+	proxy, err := s.GetProxyForAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if proxy == nil {
+		return nil, ErrNoProxyForAccount
+	}
+
+	if !refresh && proxy.ExitIP != "" {
+		return &models.ExitIPStatus{
+			ProxyID:   proxy.ID.Hex(),
+			AccountID: accountID,
+			IP:        proxy.ExitIP,
+			CheckedAt: proxy.ExitIPCheckedAt,
+		}, nil
+	}
+
+	status, err := s.healthChecker.CheckExitIP(ctx, proxy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check exit IP: %w", err)
+	}
+	status.AccountID = accountID
+	return status, nil
+}
+
 func (s *ProxyService) RefreshProxyPool(ctx context.Context) error {
 	s.logger.Info("Refreshing proxy pool")
 
@@ -255,7 +563,10 @@ func (s *ProxyService) RefreshProxyPool(ctx context.Context) error {
 		return err
 	}
 
-	minPoolSize := 10
+	minPoolSize := s.providerManager.MinPoolSize()
+	if minPoolSize <= 0 {
+		minPoolSize = defaultMinPoolSize
+	}
 	targetPoolSize := int(stats.TotalBindings) + minPoolSize
 
 	if stats.ActiveProxies >= int64(targetPoolSize) {
@@ -266,55 +577,34 @@ func (s *ProxyService) RefreshProxyPool(ctx context.Context) error {
 	needed := targetPoolSize - int(stats.ActiveProxies)
 	s.logger.Infof("Need to purchase %d new proxies", needed)
 
-	providers := s.providerManager.GetActiveProviders()
-	if len(providers) == 0 {
-		return errors.New("no active providers available")
-	}
-
 	var wg sync.WaitGroup
 	errorsChan := make(chan error, needed)
 
 	for i := 0; i < needed; i++ {
 		wg.Add(1)
-		go func(index int) {
+		go func() {
 			defer wg.Done()
 
-			provider := providers[index%len(providers)]
 			params := models.ProxyPurchaseParams{
-				Provider: provider.GetProviderName(),
 				Type:     models.ProxyTypeMobile,
 				Protocol: models.ProtocolHTTP,
 				Duration: 24 * time.Hour,
 				Quantity: 1,
 			}
 
-			proxyResp, err := provider.PurchaseProxy(ctx, params)
+			proxy, err := s.buyProxy(ctx, params, nil)
 			if err != nil {
 				errorsChan <- err
 				return
 			}
 
-			proxy := &models.Proxy{
-				Provider:  provider.GetProviderName(),
-				IP:        proxyResp.IP,
-				Port:      proxyResp.Port,
-				Protocol:  proxyResp.Protocol,
-				Username:  proxyResp.Username,
-				Password:  proxyResp.Password,
-				Type:      params.Type,
-				Country:   proxyResp.Country,
-				City:      proxyResp.City,
-				Status:    models.ProxyStatusActive,
-				ExpiresAt: proxyResp.ExpireAt,
-			}
-
 			if err := s.proxyRepo.CreateProxy(ctx, proxy); err != nil {
 				errorsChan <- err
 				return
 			}
 
 			s.logger.Infof("Added new proxy %s to pool", proxy.ID.Hex())
-		}(i)
+		}()
 	}
 
 	wg.Wait()
@@ -353,57 +643,122 @@ func (s *ProxyService) GetProxyStatistics(ctx context.Context) (*models.ProxySta
 	return s.proxyRepo.GetProxyStatistics(ctx)
 }
 
-func (s *ProxyService) purchaseNewProxy(ctx context.Context, request models.ProxyAllocationRequest) (*models.Proxy, error) {
-	providers := s.providerManager.GetActiveProviders()
-	if len(providers) == 0 {
-		return nil, errors.New("no active providers available")
+func (s *ProxyService) RecordUsage(ctx context.Context, report models.ProxyUsageReport) error {
+	if report.AccountID == "" {
+		return errors.New("account_id is required")
+	}
+	if report.BytesSent < 0 || report.BytesReceived < 0 || report.Requests < 0 {
+		return errors.New("usage counters must not be negative")
 	}
 
-	var lastError error
+	proxy, err := s.proxyRepo.RecordBindingUsage(ctx, report)
+	if err != nil {
+		return err
+	}
 
-	for _, provider := range providers {
-		params := models.ProxyPurchaseParams{
-			Provider: provider.GetProviderName(),
-			Type:     request.Type,
-			Country:  request.Country,
-			Protocol: request.Protocol,
-			Duration: 24 * time.Hour,
-			Quantity: 1,
-		}
+	if proxy == nil {
+		s.logger.Warnf("No active proxy binding for account %s, dropping usage report", report.AccountID)
+		return nil
+	}
 
-		proxyResp, err := provider.PurchaseProxy(ctx, params)
-		if err != nil {
-			lastError = err
-			s.logger.WithError(err).Warnf("Failed to purchase proxy from %s", provider.GetProviderName())
-			continue
-		}
+	RecordProxyTraffic(proxy.Provider, report.BytesSent, report.BytesReceived, report.Requests)
+	s.checkTrafficCap(ctx, proxy)
 
-		proxy := &models.Proxy{
-			Provider:  provider.GetProviderName(),
-			IP:        proxyResp.IP,
-			Port:      proxyResp.Port,
-			Protocol:  proxyResp.Protocol,
-			Username:  proxyResp.Username,
-			Password:  proxyResp.Password,
-			Type:      request.Type,
-			Country:   proxyResp.Country,
-			City:      proxyResp.City,
-			Status:    models.ProxyStatusActive,
-			ExpiresAt: proxyResp.ExpireAt,
-		}
+	return nil
+}
 
-		if err := s.providerRepo.IncrementProviderCounter(ctx, provider.GetProviderName(), "total_allocated"); err != nil {
-			s.logger.WithError(err).Warn("Failed to increment provider allocation counter")
-		}
+func (s *ProxyService) GetAccountUsage(ctx context.Context, accountID string) (*models.AccountUsage, error) {
+	bindings, err := s.proxyRepo.GetBindingsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &models.AccountUsage{
+		AccountID: accountID,
+		Bindings:  bindings,
+	}
 
-		return proxy, nil
+	for _, binding := range bindings {
+		usage.TotalBytesSent += binding.BytesSent
+		usage.TotalBytesReceived += binding.BytesReceived
+		usage.TotalRequests += binding.RequestCount
+	}
+
+	return usage, nil
+}
+
+func (s *ProxyService) checkTrafficCap(ctx context.Context, proxy *models.Proxy) {
+	provider, ok := s.providerManager.GetProviderConfig(proxy.Provider)
+	if !ok || provider.Parameters.TrafficCapGB <= 0 {
+		return
+	}
+
+	capBytes := int64(provider.Parameters.TrafficCapGB * (1 << 30))
+	level := trafficCapLevel(proxy.BytesUsed, capBytes, provider.Parameters.TrafficAlertPct)
+	if level == 0 || level <= proxy.AlertLevel {
+		return
+	}
+
+	raised, err := s.proxyRepo.RaiseTrafficAlertLevel(ctx, proxy.ID, level)
+	if err != nil || !raised {
+		return
+	}
+
+	RecordTrafficCapWarning(proxy.Provider, level)
+	s.logger.Warnf("Proxy %s used %d%% of %s traffic cap", proxy.ID.Hex(), level, proxy.Provider)
+
+	event := map[string]interface{}{
+		"proxy_id":   proxy.ID.Hex(),
+		"provider":   proxy.Provider,
+		"bytes_used": proxy.BytesUsed,
+		"cap_bytes":  capBytes,
+		"level":      level,
+		"timestamp":  time.Now(),
+	}
+
+	if err := s.rabbitmq.Publish("proxy.events", "proxy.traffic_cap_warning", event); err != nil {
+		s.logger.WithError(err).Error("Failed to publish traffic cap warning")
+	}
+}
+
+// trafficCapLevel returns the cap percentage crossed by used: 0, the alert threshold or 100
+func trafficCapLevel(used, capBytes int64, alertPct int) int {
+	if capBytes <= 0 {
+		return 0
+	}
+	if alertPct <= 0 || alertPct >= 100 {
+		alertPct = defaultTrafficAlertPct
 	}
 
-	if lastError != nil {
-		return nil, lastError
+	switch {
+	case used >= capBytes:
+		return 100
+	case used*100 >= capBytes*int64(alertPct):
+		return alertPct
 	}
 
-	return nil, errors.New("failed to purchase proxy from any provider")
+	return 0
+}
+
+func (s *ProxyService) purchaseNewProxy(ctx context.Context, request models.ProxyAllocationRequest, policy *models.AllocationPolicy) (*models.Proxy, error) {
+	params := models.ProxyPurchaseParams{
+		Type:     request.Type,
+		Country:  request.Country,
+		Protocol: request.Protocol,
+		Duration: 24 * time.Hour,
+		Quantity: 1,
+	}
+
+	proxy, err := s.buyProxy(ctx, params, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.providerRepo.IncrementProviderCounter(ctx, proxy.Provider, "total_allocated"); err != nil {
+		s.logger.WithError(err).Warn("Failed to increment provider allocation counter")
+	}
+
+	return proxy, nil
 }
 
 func (s *ProxyService) consumeAllocationRequests(ctx context.Context) {
@@ -458,6 +813,30 @@ func (s *ProxyService) consumeReleaseRequests(ctx context.Context) {
 	}
 }
 
+func (s *ProxyService) consumeUsageReports(ctx context.Context) {
+	s.logger.Info("Starting usage report consumer")
+
+	handler := func(msg []byte) error {
+		var report models.ProxyUsageReport
+
+		if err := json.Unmarshal(msg, &report); err != nil {
+			s.logger.WithError(err).Error("Failed to unmarshal usage report")
+			return err
+		}
+
+		if err := s.RecordUsage(ctx, report); err != nil {
+			s.logger.WithError(err).Error("Failed to record proxy usage")
+			return err
+		}
+
+		return nil
+	}
+
+	if err := s.rabbitmq.ConsumeWithHandler(ctx, "proxy.usage", "proxy-usage-consumer", handler); err != nil {
+		s.logger.WithError(err).Error("Failed to start usage consumer")
+	}
+}
+
 func (s *ProxyService) ForceRotateProxy(ctx context.Context, accountID string) (*models.Proxy, error) {
 	s.logger.Infof("Force rotating proxy for account %s", accountID)
 
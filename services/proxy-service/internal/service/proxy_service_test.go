@@ -4,15 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"sync"
 	"testing"
 	"time"
 
-	"github.com/grigta/conveer/pkg/cache"
 	"github.com/grigta/conveer/pkg/config"
-	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -67,6 +68,11 @@ func (m *MockProxyRepository) UpdateProxyHealth(ctx context.Context, id primitiv
 	return args.Error(0)
 }
 
+func (m *MockProxyRepository) UpdateProxyCapabilities(ctx context.Context, id primitive.ObjectID, capabilities models.ProxyCapabilities) error {
+	args := m.Called(ctx, id, capabilities)
+	return args.Error(0)
+}
+
 func (m *MockProxyRepository) GetProxiesByStatus(ctx context.Context, status models.ProxyStatus) ([]models.Proxy, error) {
 	args := m.Called(ctx, status)
 	if args.Get(0) == nil {
@@ -88,6 +94,11 @@ func (m *MockProxyRepository) BindProxyToAccount(ctx context.Context, proxyID pr
 	return args.Error(0)
 }
 
+func (m *MockProxyRepository) BindProxyToAccountWithinLimit(ctx context.Context, proxyID primitive.ObjectID, accountID, country string, limit int) error {
+	args := m.Called(ctx, proxyID, accountID, country, limit)
+	return args.Error(0)
+}
+
 func (m *MockProxyRepository) ReleaseProxyBinding(ctx context.Context, proxyID primitive.ObjectID) error {
 	args := m.Called(ctx, proxyID)
 	return args.Error(0)
@@ -109,6 +120,63 @@ func (m *MockProxyRepository) GetActiveBindingByProxyID(ctx context.Context, pro
 	return args.Get(0).(*models.ProxyBinding), args.Error(1)
 }
 
+func (m *MockProxyRepository) ProxyExists(ctx context.Context, ip string, port int) (bool, error) {
+	args := m.Called(ctx, ip, port)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProxyRepository) GetProxyHealthByIDs(ctx context.Context, proxyIDs []primitive.ObjectID) (map[primitive.ObjectID]*models.ProxyHealth, error) {
+	args := m.Called(ctx, proxyIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[primitive.ObjectID]*models.ProxyHealth), args.Error(1)
+}
+
+func (m *MockProxyRepository) RecordExitIP(ctx context.Context, proxyID primitive.ObjectID, previousIP, ip string, checkedAt time.Time) (bool, error) {
+	args := m.Called(ctx, proxyID, previousIP, ip, checkedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProxyRepository) CountBoundProxiesByCountry(ctx context.Context, country string) (int64, error) {
+	args := m.Called(ctx, country)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProxyRepository) GetBindingsByAccountID(ctx context.Context, accountID string) ([]models.ProxyBinding, error) {
+	args := m.Called(ctx, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ProxyBinding), args.Error(1)
+}
+
+func (m *MockProxyRepository) RecordBindingUsage(ctx context.Context, report models.ProxyUsageReport) (*models.Proxy, error) {
+	args := m.Called(ctx, report)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Proxy), args.Error(1)
+}
+
+func (m *MockProxyRepository) RaiseTrafficAlertLevel(ctx context.Context, proxyID primitive.ObjectID, level int) (bool, error) {
+	args := m.Called(ctx, proxyID, level)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProxyRepository) RecordProxyUsage(ctx context.Context, record models.ProxyUsageRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockProxyRepository) GetProxyHistory(ctx context.Context, accountID string) ([]models.ProxyUsageRecord, error) {
+	args := m.Called(ctx, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ProxyUsageRecord), args.Error(1)
+}
+
 // MockProviderRepository is a mock implementation of ProviderRepository
 type MockProviderRepository struct {
 	mock.Mock
@@ -119,42 +187,43 @@ func (m *MockProviderRepository) IncrementProviderCounter(ctx context.Context, p
 	return args.Error(0)
 }
 
-// MockProviderManager is a mock implementation of ProviderManager
-type MockProviderManager struct {
-	mock.Mock
+func (m *MockProviderRepository) ReserveProviderSpend(ctx context.Context, name, month string, amount, budget float64) (bool, error) {
+	args := m.Called(ctx, name, month, amount, budget)
+	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockProviderManager) GetProviderByName(name string) (ProviderAdapter, error) {
-	args := m.Called(name)
+func (m *MockProviderRepository) AddProviderSpend(ctx context.Context, name, month string, amount float64, purchases int64) (*models.ProviderSpend, error) {
+	args := m.Called(ctx, name, month, amount, purchases)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(ProviderAdapter), args.Error(1)
+	return args.Get(0).(*models.ProviderSpend), args.Error(1)
 }
 
-func (m *MockProviderManager) GetActiveProviders() []ProviderAdapter {
-	args := m.Called()
+func (m *MockProviderRepository) GetProviderSpend(ctx context.Context, month string) ([]models.ProviderSpend, error) {
+	args := m.Called(ctx, month)
 	if args.Get(0) == nil {
-		return nil
+		return nil, args.Error(1)
 	}
-	return args.Get(0).([]ProviderAdapter)
+	return args.Get(0).([]models.ProviderSpend), args.Error(1)
 }
 
-// MockProviderAdapter is a mock implementation of ProviderAdapter
-type MockProviderAdapter struct {
+// MockProviderAdapterTest is a testify mock of ProviderAdapter; MockProviderAdapter in
+// provider_adapter.go is the fake provider used in development
+type MockProviderAdapterTest struct {
 	mock.Mock
 	name string
 }
 
-func NewMockProviderAdapterTest(name string) *MockProviderAdapter {
-	return &MockProviderAdapter{name: name}
+func NewMockProviderAdapterTest(name string) *MockProviderAdapterTest {
+	return &MockProviderAdapterTest{name: name}
 }
 
-func (m *MockProviderAdapter) GetProviderName() string {
+func (m *MockProviderAdapterTest) GetProviderName() string {
 	return m.name
 }
 
-func (m *MockProviderAdapter) ListProxies(ctx context.Context) ([]models.ProxyResponse, error) {
+func (m *MockProviderAdapterTest) ListProxies(ctx context.Context) ([]models.ProxyResponse, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -162,7 +231,7 @@ func (m *MockProviderAdapter) ListProxies(ctx context.Context) ([]models.ProxyRe
 	return args.Get(0).([]models.ProxyResponse), args.Error(1)
 }
 
-func (m *MockProviderAdapter) PurchaseProxy(ctx context.Context, params models.ProxyPurchaseParams) (*models.ProxyResponse, error) {
+func (m *MockProviderAdapterTest) PurchaseProxy(ctx context.Context, params models.ProxyPurchaseParams) (*models.ProxyResponse, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -170,12 +239,12 @@ func (m *MockProviderAdapter) PurchaseProxy(ctx context.Context, params models.P
 	return args.Get(0).(*models.ProxyResponse), args.Error(1)
 }
 
-func (m *MockProviderAdapter) ReleaseProxy(ctx context.Context, proxyID string) error {
+func (m *MockProviderAdapterTest) ReleaseProxy(ctx context.Context, proxyID string) error {
 	args := m.Called(ctx, proxyID)
 	return args.Error(0)
 }
 
-func (m *MockProviderAdapter) RotateProxy(ctx context.Context, proxyID string) (*models.ProxyResponse, error) {
+func (m *MockProviderAdapterTest) RotateProxy(ctx context.Context, proxyID string) (*models.ProxyResponse, error) {
 	args := m.Called(ctx, proxyID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -183,7 +252,7 @@ func (m *MockProviderAdapter) RotateProxy(ctx context.Context, proxyID string) (
 	return args.Get(0).(*models.ProxyResponse), args.Error(1)
 }
 
-func (m *MockProviderAdapter) CheckProxy(ctx context.Context, proxyID string) (bool, error) {
+func (m *MockProviderAdapterTest) CheckProxy(ctx context.Context, proxyID string) (bool, error) {
 	args := m.Called(ctx, proxyID)
 	return args.Bool(0), args.Error(1)
 }
@@ -191,6 +260,7 @@ func (m *MockProviderAdapter) CheckProxy(ctx context.Context, proxyID string) (b
 // MockRabbitMQ is a mock implementation of RabbitMQ
 type MockRabbitMQ struct {
 	mock.Mock
+	mu                sync.Mutex
 	PublishedMessages []struct {
 		Exchange   string
 		RoutingKey string
@@ -203,11 +273,13 @@ func NewMockRabbitMQ() *MockRabbitMQ {
 }
 
 func (m *MockRabbitMQ) Publish(exchange, routingKey string, message interface{}) error {
+	m.mu.Lock()
 	m.PublishedMessages = append(m.PublishedMessages, struct {
 		Exchange   string
 		RoutingKey string
 		Message    interface{}
 	}{exchange, routingKey, message})
+	m.mu.Unlock()
 	args := m.Called(exchange, routingKey, message)
 	return args.Error(0)
 }
@@ -234,93 +306,40 @@ func (m *MockRedisCache) Get(ctx context.Context, key string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockRedisCache) Set(ctx context.Context, key, value string, ttl time.Duration) error {
-	m.data[key] = value
+func (m *MockRedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	m.data[key] = fmt.Sprint(value)
 	args := m.Called(ctx, key, value, ttl)
 	return args.Error(0)
 }
 
-func (m *MockRedisCache) Delete(ctx context.Context, key string) error {
-	delete(m.data, key)
-	args := m.Called(ctx, key)
-	return args.Error(0)
-}
-
-// MockHealthChecker is a mock implementation of HealthChecker
-type MockHealthChecker struct {
-	mock.Mock
-}
-
-func (m *MockHealthChecker) Start(ctx context.Context) {
-	m.Called(ctx)
-}
-
-func (m *MockHealthChecker) Stop() {
-	m.Called()
-}
-
-func (m *MockHealthChecker) CheckProxyHealth(ctx context.Context, proxy *models.Proxy) *models.ProxyHealth {
-	args := m.Called(ctx, proxy)
-	if args.Get(0) == nil {
-		return nil
+func (m *MockRedisCache) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(m.data, key)
 	}
-	return args.Get(0).(*models.ProxyHealth)
-}
-
-// MockRotationManager is a mock implementation of RotationManager
-type MockRotationManager struct {
-	mock.Mock
-}
-
-func (m *MockRotationManager) Start(ctx context.Context) {
-	m.Called(ctx)
-}
-
-func (m *MockRotationManager) Stop() {
-	m.Called()
-}
-
-func (m *MockRotationManager) ScheduleRotation(ctx context.Context, proxyID primitive.ObjectID, accountID string, expiresAt time.Time) error {
-	args := m.Called(ctx, proxyID, accountID, expiresAt)
-	return args.Error(0)
-}
-
-func (m *MockRotationManager) CancelScheduledRotation(proxyID primitive.ObjectID, accountID string) {
-	m.Called(proxyID, accountID)
-}
-
-func (m *MockRotationManager) RotateProxy(ctx context.Context, proxyID primitive.ObjectID, accountID string) error {
-	args := m.Called(ctx, proxyID, accountID)
+	args := m.Called(ctx, keys)
 	return args.Error(0)
 }
 
 // ProxyServiceTestSuite is the test suite for ProxyService
 type ProxyServiceTestSuite struct {
 	suite.Suite
-	ctx             context.Context
-	cancel          context.CancelFunc
-	proxyRepo       *MockProxyRepository
-	providerRepo    *MockProviderRepository
-	providerManager *MockProviderManager
-	healthChecker   *MockHealthChecker
-	rotationManager *MockRotationManager
-	rabbitmq        *MockRabbitMQ
-	redis           *MockRedisCache
-	logger          *logrus.Logger
-	config          *config.Config
+	ctx          context.Context
+	cancel       context.CancelFunc
+	proxyRepo    *MockProxyRepository
+	providerRepo *MockProviderRepository
+	rabbitmq     *MockRabbitMQ
+	redis        *MockRedisCache
+	logger       logger.Logger
+	config       *config.Config
 }
 
 func (s *ProxyServiceTestSuite) SetupTest() {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 	s.proxyRepo = new(MockProxyRepository)
 	s.providerRepo = new(MockProviderRepository)
-	s.providerManager = new(MockProviderManager)
-	s.healthChecker = new(MockHealthChecker)
-	s.rotationManager = new(MockRotationManager)
 	s.rabbitmq = NewMockRabbitMQ()
 	s.redis = NewMockRedisCache()
-	s.logger = logrus.New()
-	s.logger.SetLevel(logrus.DebugLevel)
+	s.logger = logger.NewFromConfig(logger.Config{Output: io.Discard})
 	s.config = &config.Config{}
 }
 
@@ -332,11 +351,53 @@ func TestProxyServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ProxyServiceTestSuite))
 }
 
+// newService wires a ProxyService to the suite mocks, purchases go to the given providers
+func (s *ProxyServiceTestSuite) newService(providers ...ProviderAdapter) *ProxyService {
+	manager := newTestProviderManager(s.logger, providers...)
+	healthChecker := NewHealthChecker(s.proxyRepo, s.rabbitmq, s.logger, s.config)
+	rotationManager := NewRotationManager(s.proxyRepo, s.providerRepo, manager, s.rabbitmq, s.logger, s.config)
+
+	return NewProxyService(s.proxyRepo, s.providerRepo, manager, healthChecker, rotationManager, s.rabbitmq, s.redis, s.logger, s.config)
+}
+
+// newTestProviderManager serves the adapters as enabled providers without a config file
+func newTestProviderManager(log logger.Logger, adapters ...ProviderAdapter) *ProviderManager {
+	manager := &ProviderManager{
+		providers: make(map[string]ProviderAdapter),
+		config:    &models.ProviderConfig{},
+		logger:    log,
+	}
+	for _, adapter := range adapters {
+		manager.providers[adapter.GetProviderName()] = adapter
+		manager.config.Providers = append(manager.config.Providers, models.ProxyProvider{Name: adapter.GetProviderName(), Enabled: true})
+	}
+	return manager
+}
+
+// expectPurchase sets up a successful purchase from the provider, times times
+func (s *ProxyServiceTestSuite) expectPurchase(provider *MockProviderAdapterTest, response *models.ProxyResponse, times int) {
+	name := provider.GetProviderName()
+	s.providerRepo.On("ReserveProviderSpend", s.ctx, name, mock.AnythingOfType("string"), 0.0, 0.0).Return(true, nil).Times(times)
+	provider.On("PurchaseProxy", s.ctx, mock.AnythingOfType("models.ProxyPurchaseParams")).Return(response, nil).Times(times)
+	s.providerRepo.On("AddProviderSpend", s.ctx, name, mock.AnythingOfType("string"), 0.0, int64(1)).Return(&models.ProviderSpend{Provider: name}, nil).Times(times)
+	s.rabbitmq.On("Publish", "proxy.events", "proxy.purchased", mock.AnythingOfType("service.PurchaseEvent")).Return(nil).Times(times)
+}
+
+func (s *ProxyServiceTestSuite) assertMocks(mocks ...interface{ AssertExpectations(mock.TestingT) bool }) {
+	s.proxyRepo.AssertExpectations(s.T())
+	s.providerRepo.AssertExpectations(s.T())
+	s.rabbitmq.AssertExpectations(s.T())
+	s.redis.AssertExpectations(s.T())
+	for _, m := range mocks {
+		m.AssertExpectations(s.T())
+	}
+}
+
 // Test AllocateProxy - successful allocation from existing pool
 func (s *ProxyServiceTestSuite) TestAllocateProxy_ExistingPool_Success() {
 	accountID := "account123"
 	proxyID := primitive.NewObjectID()
-	
+
 	existingProxy := &models.Proxy{
 		ID:        proxyID,
 		Provider:  "test-provider",
@@ -355,37 +416,68 @@ func (s *ProxyServiceTestSuite) TestAllocateProxy_ExistingPool_Success() {
 		Country:   "US",
 	}
 
-	// Setup expectations
 	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(nil, nil)
 	s.redis.On("Get", s.ctx, "proxy:account:"+accountID).Return("", errors.New("not found"))
 	s.proxyRepo.On("GetAvailableProxies", s.ctx, mock.AnythingOfType("models.ProxyFilters")).Return([]models.Proxy{*existingProxy}, nil)
 	s.proxyRepo.On("BindProxyToAccount", s.ctx, proxyID, accountID).Return(nil)
-	s.redis.On("Set", s.ctx, "proxy:account:"+accountID, mock.AnythingOfType("string"), time.Hour).Return(nil)
-	s.rotationManager.On("ScheduleRotation", s.ctx, proxyID, accountID, existingProxy.ExpiresAt).Return(nil)
+	s.proxyRepo.On("RecordProxyUsage", s.ctx, mock.AnythingOfType("models.ProxyUsageRecord")).Return(nil)
+	s.redis.On("Set", s.ctx, "proxy:account:"+accountID, proxyID.Hex(), time.Hour).Return(nil)
 	s.rabbitmq.On("Publish", "proxy.events", "proxy.allocated", mock.AnythingOfType("service.AllocationEvent")).Return(nil)
 
-	// Create a minimal service for testing
-	service := &ProxyService{
-		proxyRepo:       s.proxyRepo,
-		providerRepo:    s.providerRepo,
-		providerManager: nil, // Will use mock provider manager
-		healthChecker:   nil,
-		rotationManager: nil, // Will use mock rotation manager
-		rabbitmq:        nil, // Will use mock rabbitmq
-		redis:           nil, // Will use mock redis
-		logger:          s.logger,
-		config:          s.config,
+	proxy, err := s.newService().AllocateProxy(s.ctx, request)
+	s.Require().NoError(err)
+	s.Equal(proxyID, proxy.ID)
+
+	s.assertMocks()
+}
+
+// Test AllocateProxy - a country limit is enforced when binding, not only by the count before it
+func (s *ProxyServiceTestSuite) TestAllocateProxy_CountryLimitReachedOnBind() {
+	accountID := "account321"
+	ruProxy := models.Proxy{ID: primitive.NewObjectID(), Country: "RU", Status: models.ProxyStatusActive}
+	kzProxy := models.Proxy{ID: primitive.NewObjectID(), Country: "KZ", Status: models.ProxyStatusActive}
+
+	request := models.ProxyAllocationRequest{
+		AccountID: accountID,
+		CountryChain: []models.CountryPreference{
+			{Country: "RU", MaxAllocations: 100},
+			{Country: "KZ", MaxAllocations: 10},
+		},
 	}
 
-	// For now, verify the mock expectations setup is correct
-	s.proxyRepo.AssertExpectations(s.T())
+	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(nil, nil)
+	s.redis.On("Get", s.ctx, "proxy:account:"+accountID).Return("", errors.New("not found"))
+
+	// RU looked free, but a concurrent allocation took the last slot
+	s.proxyRepo.On("CountBoundProxiesByCountry", s.ctx, "RU").Return(int64(99), nil)
+	s.proxyRepo.On("GetAvailableProxies", s.ctx, mock.MatchedBy(func(filters models.ProxyFilters) bool { return filters.Country == "RU" })).
+		Return([]models.Proxy{ruProxy}, nil)
+	s.proxyRepo.On("BindProxyToAccountWithinLimit", s.ctx, ruProxy.ID, accountID, "RU", 100).
+		Return(fmt.Errorf("%w (100/100)", ErrCountryLimitReached))
+
+	s.proxyRepo.On("CountBoundProxiesByCountry", s.ctx, "KZ").Return(int64(3), nil)
+	s.proxyRepo.On("GetAvailableProxies", s.ctx, mock.MatchedBy(func(filters models.ProxyFilters) bool { return filters.Country == "KZ" })).
+		Return([]models.Proxy{kzProxy}, nil)
+	s.proxyRepo.On("BindProxyToAccountWithinLimit", s.ctx, kzProxy.ID, accountID, "KZ", 10).Return(nil)
+
+	s.proxyRepo.On("RecordProxyUsage", s.ctx, mock.AnythingOfType("models.ProxyUsageRecord")).Return(nil)
+	s.redis.On("Set", s.ctx, "proxy:account:"+accountID, kzProxy.ID.Hex(), time.Hour).Return(nil)
+	s.rabbitmq.On("Publish", "proxy.events", "proxy.allocated", mock.AnythingOfType("service.AllocationEvent")).Return(nil)
+
+	allocation, err := s.newService().AllocateProxyWithFallback(s.ctx, request)
+	s.Require().NoError(err)
+	s.Equal(kzProxy.ID, allocation.Proxy.ID)
+	s.Equal(1, allocation.FallbackLevel)
+
+	s.assertMocks()
+	s.proxyRepo.AssertNotCalled(s.T(), "BindProxyToAccount", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Test AllocateProxy - returns existing proxy for account
 func (s *ProxyServiceTestSuite) TestAllocateProxy_ExistingProxyForAccount() {
 	accountID := "account123"
 	proxyID := primitive.NewObjectID()
-	
+
 	existingProxy := &models.Proxy{
 		ID:        proxyID,
 		Provider:  "test-provider",
@@ -400,14 +492,18 @@ func (s *ProxyServiceTestSuite) TestAllocateProxy_ExistingProxyForAccount() {
 
 	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(existingProxy, nil)
 
-	// Verify the expectation is set
-	s.proxyRepo.AssertExpectations(s.T())
+	proxy, err := s.newService().AllocateProxy(s.ctx, models.ProxyAllocationRequest{AccountID: accountID, Country: "US"})
+	s.Require().NoError(err)
+	s.Equal(existingProxy, proxy)
+
+	s.assertMocks()
+	s.proxyRepo.AssertNotCalled(s.T(), "BindProxyToAccount", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Test AllocateProxy - purchase new proxy when pool is empty
 func (s *ProxyServiceTestSuite) TestAllocateProxy_EmptyPool_PurchaseNew() {
 	accountID := "account456"
-	
+
 	newProxyResponse := &models.ProxyResponse{
 		IP:       "10.0.0.1",
 		Port:     3128,
@@ -424,29 +520,47 @@ func (s *ProxyServiceTestSuite) TestAllocateProxy_EmptyPool_PurchaseNew() {
 	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(nil, nil)
 	s.redis.On("Get", s.ctx, "proxy:account:"+accountID).Return("", errors.New("not found"))
 	s.proxyRepo.On("GetAvailableProxies", s.ctx, mock.AnythingOfType("models.ProxyFilters")).Return([]models.Proxy{}, nil)
-	s.providerManager.On("GetActiveProviders").Return([]ProviderAdapter{mockProvider})
-	mockProvider.On("PurchaseProxy", s.ctx, mock.AnythingOfType("models.ProxyPurchaseParams")).Return(newProxyResponse, nil)
+	s.expectPurchase(mockProvider, newProxyResponse, 1)
+	s.providerRepo.On("IncrementProviderCounter", s.ctx, "test-provider", "total_allocated").Return(nil)
 	s.proxyRepo.On("CreateProxy", s.ctx, mock.AnythingOfType("*models.Proxy")).Return(nil)
 	s.proxyRepo.On("BindProxyToAccount", s.ctx, mock.AnythingOfType("primitive.ObjectID"), accountID).Return(nil)
-	s.providerRepo.On("IncrementProviderCounter", s.ctx, "test-provider", "total_allocated").Return(nil)
+	s.proxyRepo.On("RecordProxyUsage", s.ctx, mock.AnythingOfType("models.ProxyUsageRecord")).Return(nil)
+	s.redis.On("Set", s.ctx, "proxy:account:"+accountID, mock.AnythingOfType("string"), time.Hour).Return(nil)
+	s.rabbitmq.On("Publish", "proxy.events", "proxy.allocated", mock.AnythingOfType("service.AllocationEvent")).Return(nil)
 
-	// Verify expectations setup
-	s.proxyRepo.AssertExpectations(s.T())
+	proxy, err := s.newService(mockProvider).AllocateProxy(s.ctx, models.ProxyAllocationRequest{
+		AccountID: accountID,
+		Type:      models.ProxyTypeMobile,
+		Country:   "US",
+	})
+	s.Require().NoError(err)
+	s.Equal("test-provider", proxy.Provider)
+	s.Equal("10.0.0.1", proxy.IP)
+	s.False(proxy.ID.IsZero())
+
+	s.assertMocks(mockProvider)
 }
 
 // Test AllocateProxy - no active providers available
 func (s *ProxyServiceTestSuite) TestAllocateProxy_NoActiveProviders() {
-	s.providerManager.On("GetActiveProviders").Return([]ProviderAdapter{})
-	
-	// This should result in an error
-	s.providerManager.AssertExpectations(s.T())
+	accountID := "account789"
+
+	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(nil, nil)
+	s.redis.On("Get", s.ctx, "proxy:account:"+accountID).Return("", errors.New("not found"))
+	s.proxyRepo.On("GetAvailableProxies", s.ctx, mock.AnythingOfType("models.ProxyFilters")).Return([]models.Proxy{}, nil)
+
+	_, err := s.newService().AllocateProxy(s.ctx, models.ProxyAllocationRequest{AccountID: accountID, Country: "US"})
+	s.EqualError(err, "no active providers available")
+
+	s.assertMocks()
+	s.proxyRepo.AssertNotCalled(s.T(), "CreateProxy", mock.Anything, mock.Anything)
 }
 
 // Test ReleaseProxy - successful release
 func (s *ProxyServiceTestSuite) TestReleaseProxy_Success() {
 	accountID := "account123"
 	proxyID := primitive.NewObjectID()
-	
+
 	proxy := &models.Proxy{
 		ID:       proxyID,
 		Provider: "test-provider",
@@ -459,30 +573,34 @@ func (s *ProxyServiceTestSuite) TestReleaseProxy_Success() {
 
 	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(proxy, nil)
 	s.proxyRepo.On("ReleaseProxyBinding", s.ctx, proxyID).Return(nil)
-	s.redis.On("Delete", s.ctx, "proxy:account:"+accountID).Return(nil)
-	s.rotationManager.On("CancelScheduledRotation", proxyID, accountID).Return()
-	s.providerManager.On("GetProviderByName", "test-provider").Return(mockProvider, nil)
+	s.redis.On("Delete", s.ctx, []string{"proxy:account:" + accountID}).Return(nil)
 	mockProvider.On("ReleaseProxy", s.ctx, "192.168.1.1:8080").Return(nil)
 	s.rabbitmq.On("Publish", "proxy.events", "proxy.released", mock.Anything).Return(nil)
 	s.providerRepo.On("IncrementProviderCounter", s.ctx, "test-provider", "total_released").Return(nil)
 
-	s.proxyRepo.AssertExpectations(s.T())
+	s.Require().NoError(s.newService(mockProvider).ReleaseProxy(s.ctx, accountID))
+
+	s.assertMocks(mockProvider)
 }
 
 // Test ReleaseProxy - no proxy found for account
 func (s *ProxyServiceTestSuite) TestReleaseProxy_NoProxyFound() {
 	accountID := "account999"
-	
+
 	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(nil, nil)
-	
-	s.proxyRepo.AssertExpectations(s.T())
+
+	err := s.newService().ReleaseProxy(s.ctx, accountID)
+	s.EqualError(err, "no proxy found for account")
+
+	s.assertMocks()
+	s.proxyRepo.AssertNotCalled(s.T(), "ReleaseProxyBinding", mock.Anything, mock.Anything)
 }
 
 // Test GetProxyForAccount - cache hit
 func (s *ProxyServiceTestSuite) TestGetProxyForAccount_CacheHit() {
 	accountID := "account123"
 	proxyID := primitive.NewObjectID()
-	
+
 	proxy := &models.Proxy{
 		ID:     proxyID,
 		IP:     "192.168.1.1",
@@ -493,14 +611,19 @@ func (s *ProxyServiceTestSuite) TestGetProxyForAccount_CacheHit() {
 	s.redis.On("Get", s.ctx, "proxy:account:"+accountID).Return(proxyID.Hex(), nil)
 	s.proxyRepo.On("GetProxyByID", s.ctx, proxyID).Return(proxy, nil)
 
-	s.redis.AssertExpectations(s.T())
+	found, err := s.newService().GetProxyForAccount(s.ctx, accountID)
+	s.Require().NoError(err)
+	s.Equal(proxy, found)
+
+	s.assertMocks()
+	s.proxyRepo.AssertNotCalled(s.T(), "GetProxyByAccountID", mock.Anything, mock.Anything)
 }
 
 // Test GetProxyForAccount - cache miss, DB lookup
 func (s *ProxyServiceTestSuite) TestGetProxyForAccount_CacheMiss_DBLookup() {
 	accountID := "account456"
 	proxyID := primitive.NewObjectID()
-	
+
 	proxy := &models.Proxy{
 		ID:     proxyID,
 		IP:     "192.168.1.1",
@@ -512,7 +635,11 @@ func (s *ProxyServiceTestSuite) TestGetProxyForAccount_CacheMiss_DBLookup() {
 	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(proxy, nil)
 	s.redis.On("Set", s.ctx, "proxy:account:"+accountID, proxyID.Hex(), time.Hour).Return(nil)
 
-	s.redis.AssertExpectations(s.T())
+	found, err := s.newService().GetProxyForAccount(s.ctx, accountID)
+	s.Require().NoError(err)
+	s.Equal(proxy, found)
+
+	s.assertMocks()
 }
 
 // Test RefreshProxyPool - sufficient pool
@@ -525,7 +652,10 @@ func (s *ProxyServiceTestSuite) TestRefreshProxyPool_SufficientPool() {
 	s.proxyRepo.On("GetProxyStatistics", s.ctx).Return(stats, nil)
 
 	// With 50 active and 30 bindings, target is 40 (30+10), so pool is sufficient
-	s.proxyRepo.AssertExpectations(s.T())
+	s.Require().NoError(s.newService().RefreshProxyPool(s.ctx))
+
+	s.assertMocks()
+	s.proxyRepo.AssertNotCalled(s.T(), "CreateProxy", mock.Anything, mock.Anything)
 }
 
 // Test RefreshProxyPool - needs more proxies
@@ -546,12 +676,14 @@ func (s *ProxyServiceTestSuite) TestRefreshProxyPool_NeedsMoreProxies() {
 		ExpireAt: time.Now().Add(24 * time.Hour),
 	}
 
+	// Target is 20 (10 bindings + 10 spare), 15 are missing
 	s.proxyRepo.On("GetProxyStatistics", s.ctx).Return(stats, nil)
-	s.providerManager.On("GetActiveProviders").Return([]ProviderAdapter{mockProvider})
-	mockProvider.On("PurchaseProxy", s.ctx, mock.AnythingOfType("models.ProxyPurchaseParams")).Return(newProxyResponse, nil)
-	s.proxyRepo.On("CreateProxy", s.ctx, mock.AnythingOfType("*models.Proxy")).Return(nil)
+	s.expectPurchase(mockProvider, newProxyResponse, 15)
+	s.proxyRepo.On("CreateProxy", s.ctx, mock.AnythingOfType("*models.Proxy")).Return(nil).Times(15)
 
-	s.proxyRepo.AssertExpectations(s.T())
+	s.Require().NoError(s.newService(mockProvider).RefreshProxyPool(s.ctx))
+
+	s.assertMocks(mockProvider)
 }
 
 // Test ForceRotateProxy - successful rotation
@@ -559,12 +691,15 @@ func (s *ProxyServiceTestSuite) TestForceRotateProxy_Success() {
 	accountID := "account123"
 	proxyID := primitive.NewObjectID()
 	newProxyID := primitive.NewObjectID()
-	
+
 	oldProxy := &models.Proxy{
 		ID:       proxyID,
 		Provider: "test-provider",
 		IP:       "192.168.1.1",
 		Port:     8080,
+		Type:     models.ProxyTypeMobile,
+		Country:  "US",
+		Protocol: models.ProtocolHTTP,
 		Status:   models.ProxyStatusActive,
 	}
 
@@ -576,11 +711,30 @@ func (s *ProxyServiceTestSuite) TestForceRotateProxy_Success() {
 		Status:   models.ProxyStatusActive,
 	}
 
+	mockProvider := NewMockProviderAdapterTest("test-provider")
+
 	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(oldProxy, nil).Once()
-	s.rotationManager.On("RotateProxy", s.ctx, proxyID, accountID).Return(nil)
+	s.proxyRepo.On("GetProxyByID", s.ctx, proxyID).Return(oldProxy, nil)
+	mockProvider.On("PurchaseProxy", s.ctx, mock.AnythingOfType("models.ProxyPurchaseParams")).Return(&models.ProxyResponse{
+		IP:       "192.168.1.2",
+		Port:     8080,
+		Protocol: models.ProtocolHTTP,
+		Country:  "US",
+		ExpireAt: time.Now().Add(24 * time.Hour),
+	}, nil)
+	s.proxyRepo.On("CreateProxy", s.ctx, mock.AnythingOfType("*models.Proxy")).Return(nil)
+	s.proxyRepo.On("UpdateProxyStatus", s.ctx, proxyID, models.ProxyStatusRotating).Return(nil)
+	s.proxyRepo.On("BindProxyToAccount", s.ctx, mock.AnythingOfType("primitive.ObjectID"), accountID).Return(nil)
+	s.proxyRepo.On("RecordProxyUsage", s.ctx, mock.AnythingOfType("models.ProxyUsageRecord")).Return(nil)
+	s.providerRepo.On("IncrementProviderCounter", s.ctx, "test-provider", "total_rotated").Return(nil)
+	s.rabbitmq.On("Publish", "proxy.events", "proxy.rotated", mock.AnythingOfType("service.RotationEvent")).Return(nil)
 	s.proxyRepo.On("GetProxyByAccountID", s.ctx, accountID).Return(newProxy, nil).Once()
 
-	s.proxyRepo.AssertExpectations(s.T())
+	rotated, err := s.newService(mockProvider).ForceRotateProxy(s.ctx, accountID)
+	s.Require().NoError(err)
+	s.Equal(newProxy, rotated)
+
+	s.assertMocks(mockProvider)
 }
 
 // Test GetProxyStatistics
@@ -606,7 +760,11 @@ func (s *ProxyServiceTestSuite) TestGetProxyStatistics_Success() {
 
 	s.proxyRepo.On("GetProxyStatistics", s.ctx).Return(expectedStats, nil)
 
-	s.proxyRepo.AssertExpectations(s.T())
+	stats, err := s.newService().GetProxyStatistics(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(expectedStats, stats)
+
+	s.assertMocks()
 }
 
 // Test consumer handler for allocation requests
@@ -616,7 +774,7 @@ func (s *ProxyServiceTestSuite) TestConsumeAllocationRequests_ValidJSON() {
 		Type:      models.ProxyTypeMobile,
 		Country:   "US",
 	}
-	
+
 	requestJSON, err := json.Marshal(request)
 	require.NoError(s.T(), err)
 
@@ -630,7 +788,7 @@ func (s *ProxyServiceTestSuite) TestConsumeAllocationRequests_ValidJSON() {
 // Test consumer handler for allocation requests - invalid JSON
 func (s *ProxyServiceTestSuite) TestConsumeAllocationRequests_InvalidJSON() {
 	invalidJSON := []byte("{invalid json}")
-	
+
 	var request models.ProxyAllocationRequest
 	err := json.Unmarshal(invalidJSON, &request)
 	assert.Error(s.T(), err)
@@ -643,7 +801,7 @@ func (s *ProxyServiceTestSuite) TestConsumeReleaseRequests_ValidJSON() {
 	}{
 		AccountID: "account123",
 	}
-	
+
 	requestJSON, err := json.Marshal(request)
 	require.NoError(s.T(), err)
 
@@ -659,12 +817,12 @@ func (s *ProxyServiceTestSuite) TestConsumeReleaseRequests_ValidJSON() {
 // Table-driven tests for proxy allocation scenarios
 func TestProxyAllocationScenarios(t *testing.T) {
 	tests := []struct {
-		name           string
-		accountID      string
-		proxyType      models.ProxyType
-		country        string
-		expectError    bool
-		errorContains  string
+		name          string
+		accountID     string
+		proxyType     models.ProxyType
+		country       string
+		expectError   bool
+		errorContains string
 	}{
 		{
 			name:        "valid mobile proxy request",
@@ -708,6 +866,47 @@ func TestProxyAllocationScenarios(t *testing.T) {
 	}
 }
 
+// Test country failover chains
+func TestProxyAllocationCountryChain(t *testing.T) {
+	t.Run("single country without chain", func(t *testing.T) {
+		request := models.ProxyAllocationRequest{AccountID: "account1", Country: "US"}
+		assert.Equal(t, []models.CountryPreference{{Country: "US"}}, request.Chain())
+	})
+
+	t.Run("chain overrides country", func(t *testing.T) {
+		request := models.ProxyAllocationRequest{
+			AccountID: "account1",
+			Country:   "US",
+			CountryChain: []models.CountryPreference{
+				{Country: "RU", MaxAllocations: 100},
+				{Country: "KZ"},
+			},
+		}
+		chain := request.Chain()
+		require.Len(t, chain, 2)
+		assert.Equal(t, "RU", chain[0].Country)
+		assert.Equal(t, 100, chain[0].MaxAllocations)
+	})
+
+	t.Run("chain from JSON", func(t *testing.T) {
+		var request models.ProxyAllocationRequest
+		err := json.Unmarshal([]byte(`{"account_id":"account1","country_chain":[{"country":"RU","max_allocations":5},{"country":"BY"}]}`), &request)
+		require.NoError(t, err)
+		assert.Equal(t, []models.CountryPreference{{Country: "RU", MaxAllocations: 5}, {Country: "BY"}}, request.Chain())
+	})
+
+	t.Run("existing proxy reports its chain level", func(t *testing.T) {
+		chain := []models.CountryPreference{{Country: "RU"}, {Country: "KZ"}, {Country: "BY"}}
+
+		allocation := newProxyAllocation(&models.Proxy{Country: "BY"}, chain)
+		assert.Equal(t, 2, allocation.FallbackLevel)
+		assert.Equal(t, "BY", allocation.Country)
+
+		allocation = newProxyAllocation(&models.Proxy{Country: "DE"}, chain)
+		assert.Equal(t, 0, allocation.FallbackLevel)
+	})
+}
+
 // Test proxy models
 func TestProxyModels(t *testing.T) {
 	t.Run("ProxyStatus constants", func(t *testing.T) {
@@ -795,3 +994,28 @@ func BenchmarkAllocationEvent_JSON(b *testing.B) {
 	}
 }
 
+func TestTrafficCapLevel(t *testing.T) {
+	const gb = int64(1 << 30)
+
+	tests := []struct {
+		name     string
+		used     int64
+		capBytes int64
+		alertPct int
+		expected int
+	}{
+		{"no cap", 100 * gb, 0, 80, 0},
+		{"below threshold", 7 * gb, 10 * gb, 80, 0},
+		{"at threshold", 8 * gb, 10 * gb, 80, 80},
+		{"custom threshold", 9 * gb, 10 * gb, 90, 90},
+		{"default threshold", 8 * gb, 10 * gb, 0, defaultTrafficAlertPct},
+		{"cap reached", 10 * gb, 10 * gb, 80, 100},
+		{"cap exceeded", 12 * gb, 10 * gb, 80, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, trafficCapLevel(tt.used, tt.capBytes, tt.alertPct))
+		})
+	}
+}
@@ -9,20 +9,18 @@ import (
 	"time"
 
 	"github.com/grigta/conveer/pkg/config"
-	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
-	"github.com/grigta/conveer/services/proxy-service/internal/repository"
 
-	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type RotationManager struct {
-	proxyRepo        *repository.ProxyRepository
-	providerRepo     *repository.ProviderRepository
+	proxyRepo        proxyStore
+	providerRepo     providerStore
 	providerManager  *ProviderManager
-	rabbitmq         *messaging.RabbitMQ
-	logger           *logrus.Logger
+	rabbitmq         broker
+	logger           logger.Logger
 	config           *config.Config
 	checkInterval    time.Duration
 	gracePeriod      time.Duration
@@ -30,6 +28,7 @@ type RotationManager struct {
 	wg               sync.WaitGroup
 	rotationSchedule map[string]*time.Timer
 	scheduleMutex    sync.RWMutex
+	events           *EventBus
 }
 
 type RotationRequest struct {
@@ -45,11 +44,11 @@ type RotationEvent struct {
 }
 
 func NewRotationManager(
-	proxyRepo *repository.ProxyRepository,
-	providerRepo *repository.ProviderRepository,
+	proxyRepo proxyStore,
+	providerRepo providerStore,
 	providerManager *ProviderManager,
-	rabbitmq *messaging.RabbitMQ,
-	logger *logrus.Logger,
+	rabbitmq broker,
+	logger logger.Logger,
 	config *config.Config,
 ) *RotationManager {
 	checkInterval := 5 * time.Minute
@@ -73,6 +72,11 @@ func NewRotationManager(
 	}
 }
 
+// SetEventBus enables pushing rotation events to in-process subscribers
+func (r *RotationManager) SetEventBus(events *EventBus) {
+	r.events = events
+}
+
 func (r *RotationManager) Start(ctx context.Context) {
 	r.wg.Add(1)
 	go func() {
@@ -214,6 +218,8 @@ func (r *RotationManager) RotateProxy(ctx context.Context, proxyID primitive.Obj
 		return err
 	}
 
+	recordProxyUsage(ctx, r.proxyRepo, r.logger, newProxy, accountID, "")
+
 	go func() {
 		time.Sleep(r.gracePeriod)
 
@@ -245,6 +251,10 @@ func (r *RotationManager) RotateProxy(ctx context.Context, proxyID primitive.Obj
 		r.logger.WithError(err).Error("Failed to publish rotation event")
 	}
 
+	rotated := proxyEvent(models.ProxyEventRotated, oldProxy, accountID)
+	rotated.Details = map[string]string{"new_proxy_id": newProxy.ID.Hex()}
+	r.events.Publish(rotated)
+
 	RecordProxyRotation("auto_expiry")
 
 	r.logger.Infof("Successfully rotated proxy from %s to %s for account %s",
@@ -3,16 +3,16 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/grigta/conveer/pkg/config"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/proxy-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -20,24 +20,21 @@ import (
 // RotationManagerTestSuite is the test suite for RotationManager
 type RotationManagerTestSuite struct {
 	suite.Suite
-	ctx             context.Context
-	cancel          context.CancelFunc
-	proxyRepo       *MockProxyRepository
-	providerRepo    *MockProviderRepository
-	providerManager *MockProviderManager
-	rabbitmq        *MockRabbitMQ
-	logger          *logrus.Logger
-	config          *config.Config
+	ctx          context.Context
+	cancel       context.CancelFunc
+	proxyRepo    *MockProxyRepository
+	providerRepo *MockProviderRepository
+	rabbitmq     *MockRabbitMQ
+	logger       logger.Logger
+	config       *config.Config
 }
 
 func (s *RotationManagerTestSuite) SetupTest() {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 	s.proxyRepo = new(MockProxyRepository)
 	s.providerRepo = new(MockProviderRepository)
-	s.providerManager = new(MockProviderManager)
 	s.rabbitmq = NewMockRabbitMQ()
-	s.logger = logrus.New()
-	s.logger.SetLevel(logrus.DebugLevel)
+	s.logger = logger.NewFromConfig(logger.Config{Output: io.Discard})
 	s.config = &config.Config{
 		Proxy: config.ProxyConfig{
 			RotationCheckInterval: "5m",
@@ -53,6 +50,11 @@ func TestRotationManagerTestSuite(t *testing.T) {
 	suite.Run(t, new(RotationManagerTestSuite))
 }
 
+// newManager builds a RotationManager on the suite mocks that buys from the given providers
+func (s *RotationManagerTestSuite) newManager(providers ...ProviderAdapter) *RotationManager {
+	return NewRotationManager(s.proxyRepo, s.providerRepo, newTestProviderManager(s.logger, providers...), s.rabbitmq, s.logger, s.config)
+}
+
 // Test NewRotationManager with default values
 func (s *RotationManagerTestSuite) TestNewRotationManager_DefaultValues() {
 	cfg := &config.Config{
@@ -171,20 +173,27 @@ func (s *RotationManagerTestSuite) TestRotateProxy_Success() {
 	mockProvider := NewMockProviderAdapterTest("test-provider")
 
 	s.proxyRepo.On("GetProxyByID", s.ctx, proxyID).Return(oldProxy, nil)
-	s.providerManager.On("GetProviderByName", "test-provider").Return(mockProvider, nil)
 	mockProvider.On("PurchaseProxy", s.ctx, mock.AnythingOfType("models.ProxyPurchaseParams")).Return(newProxyResponse, nil)
 	s.proxyRepo.On("CreateProxy", s.ctx, mock.AnythingOfType("*models.Proxy")).Return(nil)
 	s.proxyRepo.On("UpdateProxyStatus", s.ctx, proxyID, models.ProxyStatusRotating).Return(nil)
 	s.proxyRepo.On("BindProxyToAccount", s.ctx, mock.AnythingOfType("primitive.ObjectID"), accountID).Return(nil)
+	s.proxyRepo.On("RecordProxyUsage", s.ctx, mock.AnythingOfType("models.ProxyUsageRecord")).Return(nil)
 	s.providerRepo.On("IncrementProviderCounter", s.ctx, "test-provider", "total_rotated").Return(nil)
 	s.rabbitmq.On("Publish", "proxy.events", "proxy.rotated", mock.AnythingOfType("service.RotationEvent")).Return(nil)
 
+	err := s.newManager(mockProvider).RotateProxy(s.ctx, proxyID, accountID)
+	s.Require().NoError(err)
+
 	s.proxyRepo.AssertExpectations(s.T())
+	s.providerRepo.AssertExpectations(s.T())
+	s.rabbitmq.AssertExpectations(s.T())
+	mockProvider.AssertExpectations(s.T())
 }
 
 // Test RotateProxy - provider not available, fallback to another
 func (s *RotationManagerTestSuite) TestRotateProxy_ProviderFallback() {
 	proxyID := primitive.NewObjectID()
+	accountID := "account123"
 
 	oldProxy := &models.Proxy{
 		ID:       proxyID,
@@ -210,11 +219,21 @@ func (s *RotationManagerTestSuite) TestRotateProxy_ProviderFallback() {
 	mockFallbackProvider := NewMockProviderAdapterTest("fallback-provider")
 
 	s.proxyRepo.On("GetProxyByID", s.ctx, proxyID).Return(oldProxy, nil)
-	s.providerManager.On("GetProviderByName", "unavailable-provider").Return(nil, assert.AnError)
-	s.providerManager.On("GetActiveProviders").Return([]ProviderAdapter{mockFallbackProvider})
 	mockFallbackProvider.On("PurchaseProxy", s.ctx, mock.AnythingOfType("models.ProxyPurchaseParams")).Return(newProxyResponse, nil)
+	s.proxyRepo.On("CreateProxy", s.ctx, mock.MatchedBy(func(proxy *models.Proxy) bool {
+		return proxy.Provider == "fallback-provider"
+	})).Return(nil)
+	s.proxyRepo.On("UpdateProxyStatus", s.ctx, proxyID, models.ProxyStatusRotating).Return(nil)
+	s.proxyRepo.On("BindProxyToAccount", s.ctx, mock.AnythingOfType("primitive.ObjectID"), accountID).Return(nil)
+	s.proxyRepo.On("RecordProxyUsage", s.ctx, mock.AnythingOfType("models.ProxyUsageRecord")).Return(nil)
+	s.providerRepo.On("IncrementProviderCounter", s.ctx, "fallback-provider", "total_rotated").Return(nil)
+	s.rabbitmq.On("Publish", "proxy.events", "proxy.rotated", mock.AnythingOfType("service.RotationEvent")).Return(nil)
+
+	err := s.newManager(mockFallbackProvider).RotateProxy(s.ctx, proxyID, accountID)
+	s.Require().NoError(err)
 
 	s.proxyRepo.AssertExpectations(s.T())
+	mockFallbackProvider.AssertExpectations(s.T())
 }
 
 // Test RotateProxy - no active providers
@@ -227,11 +246,13 @@ func (s *RotationManagerTestSuite) TestRotateProxy_NoActiveProviders() {
 	}
 
 	s.proxyRepo.On("GetProxyByID", s.ctx, proxyID).Return(oldProxy, nil)
-	s.providerManager.On("GetProviderByName", "test-provider").Return(nil, assert.AnError)
-	s.providerManager.On("GetActiveProviders").Return([]ProviderAdapter{})
 
 	// Should return error when no providers are available
+	err := s.newManager().RotateProxy(s.ctx, proxyID, "account123")
+	s.EqualError(err, "no active providers available")
+
 	s.proxyRepo.AssertExpectations(s.T())
+	s.proxyRepo.AssertNotCalled(s.T(), "CreateProxy", mock.Anything, mock.Anything)
 }
 
 // Test RotateProxy - purchase fails
@@ -246,10 +267,15 @@ func (s *RotationManagerTestSuite) TestRotateProxy_PurchaseFails() {
 	mockProvider := NewMockProviderAdapterTest("test-provider")
 
 	s.proxyRepo.On("GetProxyByID", s.ctx, proxyID).Return(oldProxy, nil)
-	s.providerManager.On("GetProviderByName", "test-provider").Return(mockProvider, nil)
 	mockProvider.On("PurchaseProxy", s.ctx, mock.AnythingOfType("models.ProxyPurchaseParams")).Return(nil, assert.AnError)
 
+	err := s.newManager(mockProvider).RotateProxy(s.ctx, proxyID, "account123")
+	s.ErrorIs(err, assert.AnError)
+
 	s.proxyRepo.AssertExpectations(s.T())
+	mockProvider.AssertExpectations(s.T())
+	s.proxyRepo.AssertNotCalled(s.T(), "CreateProxy", mock.Anything, mock.Anything)
+	s.proxyRepo.AssertNotCalled(s.T(), "UpdateProxyStatus", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Test checkExpiredProxies - with expired proxies
@@ -274,15 +300,23 @@ func (s *RotationManagerTestSuite) TestCheckExpiredProxies_WithExpired() {
 
 	s.proxyRepo.On("GetExpiredProxies", s.ctx).Return(expiredProxies, nil)
 	s.proxyRepo.On("GetActiveBindingByProxyID", s.ctx, proxyID).Return(binding, nil)
+	// A bound proxy is rotated, the failing lookup ends the rotation right away
+	s.proxyRepo.On("GetProxyByID", s.ctx, proxyID).Return(nil, assert.AnError)
+
+	s.newManager().checkExpiredProxies(s.ctx)
 
 	s.proxyRepo.AssertExpectations(s.T())
+	s.proxyRepo.AssertNotCalled(s.T(), "ReleaseProxyBinding", mock.Anything, mock.Anything)
 }
 
 // Test checkExpiredProxies - no expired proxies
 func (s *RotationManagerTestSuite) TestCheckExpiredProxies_NoExpired() {
 	s.proxyRepo.On("GetExpiredProxies", s.ctx).Return([]models.Proxy{}, nil)
 
+	s.newManager().checkExpiredProxies(s.ctx)
+
 	s.proxyRepo.AssertExpectations(s.T())
+	s.proxyRepo.AssertNotCalled(s.T(), "GetActiveBindingByProxyID", mock.Anything, mock.Anything)
 }
 
 // Test checkExpiredProxies - unbound expired proxy
@@ -299,12 +333,18 @@ func (s *RotationManagerTestSuite) TestCheckExpiredProxies_UnboundProxy() {
 		},
 	}
 
+	mockProvider := NewMockProviderAdapterTest("test-provider")
+
 	s.proxyRepo.On("GetExpiredProxies", s.ctx).Return(expiredProxies, nil)
 	s.proxyRepo.On("GetActiveBindingByProxyID", s.ctx, proxyID).Return(nil, nil) // No binding
 	s.proxyRepo.On("ReleaseProxyBinding", s.ctx, proxyID).Return(nil)
+	mockProvider.On("ReleaseProxy", s.ctx, "192.168.1.1:8080").Return(nil)
 	s.proxyRepo.On("UpdateProxyStatus", s.ctx, proxyID, models.ProxyStatusReleased).Return(nil)
 
+	s.newManager(mockProvider).checkExpiredProxies(s.ctx)
+
 	s.proxyRepo.AssertExpectations(s.T())
+	mockProvider.AssertExpectations(s.T())
 }
 
 // Test RotationRequest JSON serialization
@@ -349,9 +389,9 @@ func (s *RotationManagerTestSuite) TestRotationEvent_JSON() {
 // Table-driven tests for rotation timing
 func TestRotationTiming(t *testing.T) {
 	tests := []struct {
-		name            string
-		expiresAt       time.Duration
-		expectedBefore  time.Duration // Expected time before expiration
+		name           string
+		expiresAt      time.Duration
+		expectedBefore time.Duration // Expected time before expiration
 	}{
 		{
 			name:           "1 hour expiry",
@@ -409,8 +449,7 @@ func (s *RotationManagerTestSuite) TestScheduleRotation_Concurrent() {
 // Benchmark tests
 func BenchmarkScheduleRotation(b *testing.B) {
 	ctx := context.Background()
-	logger := logrus.New()
-	logger.SetLevel(logrus.ErrorLevel)
+	logger := logger.NewFromConfig(logger.Config{Output: io.Discard})
 	cfg := &config.Config{}
 
 	rm := NewRotationManager(nil, nil, nil, nil, logger, cfg)
@@ -427,8 +466,7 @@ func BenchmarkScheduleRotation(b *testing.B) {
 
 func BenchmarkCancelScheduledRotation(b *testing.B) {
 	ctx := context.Background()
-	logger := logrus.New()
-	logger.SetLevel(logrus.ErrorLevel)
+	logger := logger.NewFromConfig(logger.Config{Output: io.Discard})
 	cfg := &config.Config{}
 
 	rm := NewRotationManager(nil, nil, nil, nil, logger, cfg)
@@ -471,4 +509,3 @@ func BenchmarkRotationEventJSON(b *testing.B) {
 		_, _ = json.Marshal(event)
 	}
 }
-
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/grigta/conveer/services/proxy-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// proxyStore is the part of repository.ProxyRepository the service layer uses
+type proxyStore interface {
+	CreateProxy(ctx context.Context, proxy *models.Proxy) error
+	ProxyExists(ctx context.Context, ip string, port int) (bool, error)
+	GetProxyByID(ctx context.Context, id primitive.ObjectID) (*models.Proxy, error)
+	GetProxyByAccountID(ctx context.Context, accountID string) (*models.Proxy, error)
+	GetAvailableProxies(ctx context.Context, filters models.ProxyFilters) ([]models.Proxy, error)
+	GetProxiesByStatus(ctx context.Context, status models.ProxyStatus) ([]models.Proxy, error)
+	GetExpiredProxies(ctx context.Context) ([]models.Proxy, error)
+	GetProxyStatistics(ctx context.Context) (*models.ProxyStats, error)
+	GetProxyHealthByIDs(ctx context.Context, proxyIDs []primitive.ObjectID) (map[primitive.ObjectID]*models.ProxyHealth, error)
+	UpdateProxyStatus(ctx context.Context, id primitive.ObjectID, status models.ProxyStatus) error
+	UpdateProxyHealth(ctx context.Context, id primitive.ObjectID, health *models.ProxyHealth) error
+	UpdateProxyCapabilities(ctx context.Context, id primitive.ObjectID, capabilities models.ProxyCapabilities) error
+	RecordExitIP(ctx context.Context, proxyID primitive.ObjectID, previousIP, ip string, checkedAt time.Time) (bool, error)
+	BindProxyToAccount(ctx context.Context, proxyID primitive.ObjectID, accountID string) error
+	BindProxyToAccountWithinLimit(ctx context.Context, proxyID primitive.ObjectID, accountID, country string, limit int) error
+	CountBoundProxiesByCountry(ctx context.Context, country string) (int64, error)
+	ReleaseProxyBinding(ctx context.Context, proxyID primitive.ObjectID) error
+	GetActiveBindingByProxyID(ctx context.Context, proxyID primitive.ObjectID) (*models.ProxyBinding, error)
+	GetBindingsByAccountID(ctx context.Context, accountID string) ([]models.ProxyBinding, error)
+	RecordBindingUsage(ctx context.Context, report models.ProxyUsageReport) (*models.Proxy, error)
+	RaiseTrafficAlertLevel(ctx context.Context, proxyID primitive.ObjectID, level int) (bool, error)
+	RecordProxyUsage(ctx context.Context, record models.ProxyUsageRecord) error
+	GetProxyHistory(ctx context.Context, accountID string) ([]models.ProxyUsageRecord, error)
+}
+
+// providerStore is the part of repository.ProviderRepository the service layer uses
+type providerStore interface {
+	IncrementProviderCounter(ctx context.Context, name string, counterType string) error
+	ReserveProviderSpend(ctx context.Context, name, month string, amount, budget float64) (bool, error)
+	AddProviderSpend(ctx context.Context, name, month string, amount float64, purchases int64) (*models.ProviderSpend, error)
+	GetProviderSpend(ctx context.Context, month string) ([]models.ProviderSpend, error)
+}
+
+// broker is the part of messaging.RabbitMQ the service layer uses
+type broker interface {
+	Publish(exchange, routingKey string, message interface{}) error
+	ConsumeWithHandler(ctx context.Context, queueName, consumerName string, handler func([]byte) error) error
+}
+
+// accountCache caches the account to proxy lookups
+type accountCache interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -27,6 +27,10 @@ type AllocateProxyRequest struct {
 	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
 	Protocol      string                 `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	CountryChain  []*CountryPreference   `protobuf:"bytes,5,rep,name=country_chain,json=countryChain,proto3" json:"country_chain,omitempty"`
+	Requires      []string               `protobuf:"bytes,6,rep,name=requires,proto3" json:"requires,omitempty"` // capabilities the proxy must support: "udp", "quic"
+	Policy        string                 `protobuf:"bytes,7,opt,name=policy,proto3" json:"policy,omitempty"`     // named allocation policy from providers.yaml, fills in empty fields
+	Platform      string                 `protobuf:"bytes,8,opt,name=platform,proto3" json:"platform,omitempty"` // platform of the account, e.g. "vk", recorded in its proxy history
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +93,86 @@ func (x *AllocateProxyRequest) GetProtocol() string {
 	return ""
 }
 
+func (x *AllocateProxyRequest) GetCountryChain() []*CountryPreference {
+	if x != nil {
+		return x.CountryChain
+	}
+	return nil
+}
+
+func (x *AllocateProxyRequest) GetRequires() []string {
+	if x != nil {
+		return x.Requires
+	}
+	return nil
+}
+
+func (x *AllocateProxyRequest) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *AllocateProxyRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type CountryPreference struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Country        string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	MaxAllocations int32                  `protobuf:"varint,2,opt,name=max_allocations,json=maxAllocations,proto3" json:"max_allocations,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CountryPreference) Reset() {
+	*x = CountryPreference{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountryPreference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryPreference) ProtoMessage() {}
+
+func (x *CountryPreference) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryPreference.ProtoReflect.Descriptor instead.
+func (*CountryPreference) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CountryPreference) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CountryPreference) GetMaxAllocations() int32 {
+	if x != nil {
+		return x.MaxAllocations
+	}
+	return 0
+}
+
 type ReleaseProxyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
@@ -98,7 +182,7 @@ type ReleaseProxyRequest struct {
 
 func (x *ReleaseProxyRequest) Reset() {
 	*x = ReleaseProxyRequest{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[1]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -110,7 +194,7 @@ func (x *ReleaseProxyRequest) String() string {
 func (*ReleaseProxyRequest) ProtoMessage() {}
 
 func (x *ReleaseProxyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[1]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,7 +207,7 @@ func (x *ReleaseProxyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseProxyRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseProxyRequest) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{1}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ReleaseProxyRequest) GetAccountId() string {
@@ -143,7 +227,7 @@ type ReleaseProxyResponse struct {
 
 func (x *ReleaseProxyResponse) Reset() {
 	*x = ReleaseProxyResponse{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[2]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -155,7 +239,7 @@ func (x *ReleaseProxyResponse) String() string {
 func (*ReleaseProxyResponse) ProtoMessage() {}
 
 func (x *ReleaseProxyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[2]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +252,7 @@ func (x *ReleaseProxyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseProxyResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseProxyResponse) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{2}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ReleaseProxyResponse) GetSuccess() bool {
@@ -194,7 +278,7 @@ type GetProxyRequest struct {
 
 func (x *GetProxyRequest) Reset() {
 	*x = GetProxyRequest{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[3]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -206,7 +290,7 @@ func (x *GetProxyRequest) String() string {
 func (*GetProxyRequest) ProtoMessage() {}
 
 func (x *GetProxyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[3]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,7 +303,7 @@ func (x *GetProxyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProxyRequest.ProtoReflect.Descriptor instead.
 func (*GetProxyRequest) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{3}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetProxyRequest) GetAccountId() string {
@@ -238,7 +322,7 @@ type GetProxyHealthRequest struct {
 
 func (x *GetProxyHealthRequest) Reset() {
 	*x = GetProxyHealthRequest{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[4]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -250,7 +334,7 @@ func (x *GetProxyHealthRequest) String() string {
 func (*GetProxyHealthRequest) ProtoMessage() {}
 
 func (x *GetProxyHealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[4]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +347,7 @@ func (x *GetProxyHealthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProxyHealthRequest.ProtoReflect.Descriptor instead.
 func (*GetProxyHealthRequest) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{4}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetProxyHealthRequest) GetProxyId() string {
@@ -282,7 +366,7 @@ type RotateProxyRequest struct {
 
 func (x *RotateProxyRequest) Reset() {
 	*x = RotateProxyRequest{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[5]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -294,7 +378,7 @@ func (x *RotateProxyRequest) String() string {
 func (*RotateProxyRequest) ProtoMessage() {}
 
 func (x *RotateProxyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[5]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -307,7 +391,7 @@ func (x *RotateProxyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RotateProxyRequest.ProtoReflect.Descriptor instead.
 func (*RotateProxyRequest) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{5}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RotateProxyRequest) GetAccountId() string {
@@ -325,7 +409,7 @@ type GetStatisticsRequest struct {
 
 func (x *GetStatisticsRequest) Reset() {
 	*x = GetStatisticsRequest{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[6]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +421,7 @@ func (x *GetStatisticsRequest) String() string {
 func (*GetStatisticsRequest) ProtoMessage() {}
 
 func (x *GetStatisticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[6]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +434,143 @@ func (x *GetStatisticsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStatisticsRequest.ProtoReflect.Descriptor instead.
 func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{6}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{7}
+}
+
+type GetExitIPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Refresh       bool                   `protobuf:"varint,2,opt,name=refresh,proto3" json:"refresh,omitempty"` // check through the proxy now instead of returning the last recorded IP
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExitIPRequest) Reset() {
+	*x = GetExitIPRequest{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExitIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExitIPRequest) ProtoMessage() {}
+
+func (x *GetExitIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExitIPRequest.ProtoReflect.Descriptor instead.
+func (*GetExitIPRequest) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetExitIPRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetExitIPRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+type ExitIPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProxyId       string                 `protobuf:"bytes,1,opt,name=proxy_id,json=proxyId,proto3" json:"proxy_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	PreviousIp    string                 `protobuf:"bytes,4,opt,name=previous_ip,json=previousIp,proto3" json:"previous_ip,omitempty"` // set when this check detected a change
+	Changed       bool                   `protobuf:"varint,5,opt,name=changed,proto3" json:"changed,omitempty"`
+	CheckedAt     int64                  `protobuf:"varint,6,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExitIPResponse) Reset() {
+	*x = ExitIPResponse{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExitIPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitIPResponse) ProtoMessage() {}
+
+func (x *ExitIPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitIPResponse.ProtoReflect.Descriptor instead.
+func (*ExitIPResponse) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExitIPResponse) GetProxyId() string {
+	if x != nil {
+		return x.ProxyId
+	}
+	return ""
+}
+
+func (x *ExitIPResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ExitIPResponse) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *ExitIPResponse) GetPreviousIp() string {
+	if x != nil {
+		return x.PreviousIp
+	}
+	return ""
+}
+
+func (x *ExitIPResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *ExitIPResponse) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
 }
 
 type ProxyResponse struct {
@@ -367,13 +587,15 @@ type ProxyResponse struct {
 	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
 	ExpiresAt     int64                  `protobuf:"varint,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	Provider      string                 `protobuf:"bytes,12,opt,name=provider,proto3" json:"provider,omitempty"`
+	FallbackLevel int32                  `protobuf:"varint,13,opt,name=fallback_level,json=fallbackLevel,proto3" json:"fallback_level,omitempty"`
+	Capabilities  []string               `protobuf:"bytes,14,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProxyResponse) Reset() {
 	*x = ProxyResponse{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[7]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -385,7 +607,7 @@ func (x *ProxyResponse) String() string {
 func (*ProxyResponse) ProtoMessage() {}
 
 func (x *ProxyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[7]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,7 +620,7 @@ func (x *ProxyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProxyResponse.ProtoReflect.Descriptor instead.
 func (*ProxyResponse) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{7}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ProxyResponse) GetId() string {
@@ -485,6 +707,20 @@ func (x *ProxyResponse) GetProvider() string {
 	return ""
 }
 
+func (x *ProxyResponse) GetFallbackLevel() int32 {
+	if x != nil {
+		return x.FallbackLevel
+	}
+	return 0
+}
+
+func (x *ProxyResponse) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 type ProxyHealthResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ProxyId         string                 `protobuf:"bytes,1,opt,name=proxy_id,json=proxyId,proto3" json:"proxy_id,omitempty"`
@@ -502,7 +738,7 @@ type ProxyHealthResponse struct {
 
 func (x *ProxyHealthResponse) Reset() {
 	*x = ProxyHealthResponse{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[8]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -514,7 +750,7 @@ func (x *ProxyHealthResponse) String() string {
 func (*ProxyHealthResponse) ProtoMessage() {}
 
 func (x *ProxyHealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[8]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -527,7 +763,7 @@ func (x *ProxyHealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProxyHealthResponse.ProtoReflect.Descriptor instead.
 func (*ProxyHealthResponse) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{8}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ProxyHealthResponse) GetProxyId() string {
@@ -594,23 +830,27 @@ func (x *ProxyHealthResponse) GetFailedChecks() int32 {
 }
 
 type ProxyStatisticsResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	TotalProxies     int64                  `protobuf:"varint,1,opt,name=total_proxies,json=totalProxies,proto3" json:"total_proxies,omitempty"`
-	ActiveProxies    int64                  `protobuf:"varint,2,opt,name=active_proxies,json=activeProxies,proto3" json:"active_proxies,omitempty"`
-	ExpiredProxies   int64                  `protobuf:"varint,3,opt,name=expired_proxies,json=expiredProxies,proto3" json:"expired_proxies,omitempty"`
-	BannedProxies    int64                  `protobuf:"varint,4,opt,name=banned_proxies,json=bannedProxies,proto3" json:"banned_proxies,omitempty"`
-	TotalBindings    int64                  `protobuf:"varint,5,opt,name=total_bindings,json=totalBindings,proto3" json:"total_bindings,omitempty"`
-	ProxiesByType    map[string]int64       `protobuf:"bytes,6,rep,name=proxies_by_type,json=proxiesByType,proto3" json:"proxies_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	ProxiesByCountry map[string]int64       `protobuf:"bytes,7,rep,name=proxies_by_country,json=proxiesByCountry,proto3" json:"proxies_by_country,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	AvgFraudScore    float64                `protobuf:"fixed64,8,opt,name=avg_fraud_score,json=avgFraudScore,proto3" json:"avg_fraud_score,omitempty"`
-	AvgLatency       float64                `protobuf:"fixed64,9,opt,name=avg_latency,json=avgLatency,proto3" json:"avg_latency,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TotalProxies       int64                  `protobuf:"varint,1,opt,name=total_proxies,json=totalProxies,proto3" json:"total_proxies,omitempty"`
+	ActiveProxies      int64                  `protobuf:"varint,2,opt,name=active_proxies,json=activeProxies,proto3" json:"active_proxies,omitempty"`
+	ExpiredProxies     int64                  `protobuf:"varint,3,opt,name=expired_proxies,json=expiredProxies,proto3" json:"expired_proxies,omitempty"`
+	BannedProxies      int64                  `protobuf:"varint,4,opt,name=banned_proxies,json=bannedProxies,proto3" json:"banned_proxies,omitempty"`
+	TotalBindings      int64                  `protobuf:"varint,5,opt,name=total_bindings,json=totalBindings,proto3" json:"total_bindings,omitempty"`
+	ProxiesByType      map[string]int64       `protobuf:"bytes,6,rep,name=proxies_by_type,json=proxiesByType,proto3" json:"proxies_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ProxiesByCountry   map[string]int64       `protobuf:"bytes,7,rep,name=proxies_by_country,json=proxiesByCountry,proto3" json:"proxies_by_country,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	AvgFraudScore      float64                `protobuf:"fixed64,8,opt,name=avg_fraud_score,json=avgFraudScore,proto3" json:"avg_fraud_score,omitempty"`
+	AvgLatency         float64                `protobuf:"fixed64,9,opt,name=avg_latency,json=avgLatency,proto3" json:"avg_latency,omitempty"`
+	TotalBytesSent     int64                  `protobuf:"varint,10,opt,name=total_bytes_sent,json=totalBytesSent,proto3" json:"total_bytes_sent,omitempty"`
+	TotalBytesReceived int64                  `protobuf:"varint,11,opt,name=total_bytes_received,json=totalBytesReceived,proto3" json:"total_bytes_received,omitempty"`
+	TotalRequests      int64                  `protobuf:"varint,12,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"`
+	BytesByProvider    map[string]int64       `protobuf:"bytes,13,rep,name=bytes_by_provider,json=bytesByProvider,proto3" json:"bytes_by_provider,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ProxyStatisticsResponse) Reset() {
 	*x = ProxyStatisticsResponse{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[9]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -622,7 +862,7 @@ func (x *ProxyStatisticsResponse) String() string {
 func (*ProxyStatisticsResponse) ProtoMessage() {}
 
 func (x *ProxyStatisticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[9]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,7 +875,7 @@ func (x *ProxyStatisticsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProxyStatisticsResponse.ProtoReflect.Descriptor instead.
 func (*ProxyStatisticsResponse) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{9}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ProxyStatisticsResponse) GetTotalProxies() int64 {
@@ -701,28 +941,56 @@ func (x *ProxyStatisticsResponse) GetAvgLatency() float64 {
 	return 0
 }
 
-type GetProviderStatisticsRequest struct {
+func (x *ProxyStatisticsResponse) GetTotalBytesSent() int64 {
+	if x != nil {
+		return x.TotalBytesSent
+	}
+	return 0
+}
+
+func (x *ProxyStatisticsResponse) GetTotalBytesReceived() int64 {
+	if x != nil {
+		return x.TotalBytesReceived
+	}
+	return 0
+}
+
+func (x *ProxyStatisticsResponse) GetTotalRequests() int64 {
+	if x != nil {
+		return x.TotalRequests
+	}
+	return 0
+}
+
+func (x *ProxyStatisticsResponse) GetBytesByProvider() map[string]int64 {
+	if x != nil {
+		return x.BytesByProvider
+	}
+	return nil
+}
+
+type GetAccountUsageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Days          int32                  `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"` // Number of days to look back (default 7)
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProviderStatisticsRequest) Reset() {
-	*x = GetProviderStatisticsRequest{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[10]
+func (x *GetAccountUsageRequest) Reset() {
+	*x = GetAccountUsageRequest{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProviderStatisticsRequest) String() string {
+func (x *GetAccountUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProviderStatisticsRequest) ProtoMessage() {}
+func (*GetAccountUsageRequest) ProtoMessage() {}
 
-func (x *GetProviderStatisticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[10]
+func (x *GetAccountUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,12 +1001,224 @@ func (x *GetProviderStatisticsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProviderStatisticsRequest.ProtoReflect.Descriptor instead.
-func (*GetProviderStatisticsRequest) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetAccountUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountUsageRequest) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetProviderStatisticsRequest) GetDays() int32 {
+func (x *GetAccountUsageRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type AccountUsageResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AccountId          string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TotalBytesSent     int64                  `protobuf:"varint,2,opt,name=total_bytes_sent,json=totalBytesSent,proto3" json:"total_bytes_sent,omitempty"`
+	TotalBytesReceived int64                  `protobuf:"varint,3,opt,name=total_bytes_received,json=totalBytesReceived,proto3" json:"total_bytes_received,omitempty"`
+	TotalRequests      int64                  `protobuf:"varint,4,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"`
+	Bindings           []*BindingUsage        `protobuf:"bytes,5,rep,name=bindings,proto3" json:"bindings,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AccountUsageResponse) Reset() {
+	*x = AccountUsageResponse{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountUsageResponse) ProtoMessage() {}
+
+func (x *AccountUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountUsageResponse.ProtoReflect.Descriptor instead.
+func (*AccountUsageResponse) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AccountUsageResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountUsageResponse) GetTotalBytesSent() int64 {
+	if x != nil {
+		return x.TotalBytesSent
+	}
+	return 0
+}
+
+func (x *AccountUsageResponse) GetTotalBytesReceived() int64 {
+	if x != nil {
+		return x.TotalBytesReceived
+	}
+	return 0
+}
+
+func (x *AccountUsageResponse) GetTotalRequests() int64 {
+	if x != nil {
+		return x.TotalRequests
+	}
+	return 0
+}
+
+func (x *AccountUsageResponse) GetBindings() []*BindingUsage {
+	if x != nil {
+		return x.Bindings
+	}
+	return nil
+}
+
+type BindingUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProxyId       string                 `protobuf:"bytes,1,opt,name=proxy_id,json=proxyId,proto3" json:"proxy_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	BoundAt       int64                  `protobuf:"varint,3,opt,name=bound_at,json=boundAt,proto3" json:"bound_at,omitempty"`
+	LastUsedAt    int64                  `protobuf:"varint,4,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	BytesSent     int64                  `protobuf:"varint,5,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived int64                  `protobuf:"varint,6,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	RequestCount  int64                  `protobuf:"varint,7,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BindingUsage) Reset() {
+	*x = BindingUsage{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BindingUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BindingUsage) ProtoMessage() {}
+
+func (x *BindingUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BindingUsage.ProtoReflect.Descriptor instead.
+func (*BindingUsage) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BindingUsage) GetProxyId() string {
+	if x != nil {
+		return x.ProxyId
+	}
+	return ""
+}
+
+func (x *BindingUsage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BindingUsage) GetBoundAt() int64 {
+	if x != nil {
+		return x.BoundAt
+	}
+	return 0
+}
+
+func (x *BindingUsage) GetLastUsedAt() int64 {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return 0
+}
+
+func (x *BindingUsage) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *BindingUsage) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *BindingUsage) GetRequestCount() int64 {
+	if x != nil {
+		return x.RequestCount
+	}
+	return 0
+}
+
+type GetProviderStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          int32                  `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"` // Number of days to look back (default 7)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProviderStatisticsRequest) Reset() {
+	*x = GetProviderStatisticsRequest{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProviderStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderStatisticsRequest) ProtoMessage() {}
+
+func (x *GetProviderStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetProviderStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetProviderStatisticsRequest) GetDays() int32 {
 	if x != nil {
 		return x.Days
 	}
@@ -754,7 +1234,7 @@ type ProviderStatisticsResponse struct {
 
 func (x *ProviderStatisticsResponse) Reset() {
 	*x = ProviderStatisticsResponse{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[11]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -766,7 +1246,7 @@ func (x *ProviderStatisticsResponse) String() string {
 func (*ProviderStatisticsResponse) ProtoMessage() {}
 
 func (x *ProviderStatisticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[11]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -779,7 +1259,7 @@ func (x *ProviderStatisticsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProviderStatisticsResponse.ProtoReflect.Descriptor instead.
 func (*ProviderStatisticsResponse) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{11}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ProviderStatisticsResponse) GetProviderStats() []*ProviderStats {
@@ -805,7 +1285,7 @@ type ProviderStats struct {
 
 func (x *ProviderStats) Reset() {
 	*x = ProviderStats{}
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[12]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -817,7 +1297,7 @@ func (x *ProviderStats) String() string {
 func (*ProviderStats) ProtoMessage() {}
 
 func (x *ProviderStats) ProtoReflect() protoreflect.Message {
-	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[12]
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,7 +1310,7 @@ func (x *ProviderStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProviderStats.ProtoReflect.Descriptor instead.
 func (*ProviderStats) Descriptor() ([]byte, []int) {
-	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{12}
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ProviderStats) GetProvider() string {
@@ -889,17 +1369,201 @@ func (x *ProviderStats) GetTotalProxies() int64 {
 	return 0
 }
 
+// Empty fields match all events
+type WatchProxyEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"` // allocated, released, rotated, health_degraded, blacklisted, banned, ip_changed
+	ProxyId       string                 `protobuf:"bytes,2,opt,name=proxy_id,json=proxyId,proto3" json:"proxy_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	Country       string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchProxyEventsRequest) Reset() {
+	*x = WatchProxyEventsRequest{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchProxyEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchProxyEventsRequest) ProtoMessage() {}
+
+func (x *WatchProxyEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchProxyEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchProxyEventsRequest) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchProxyEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *WatchProxyEventsRequest) GetProxyId() string {
+	if x != nil {
+		return x.ProxyId
+	}
+	return ""
+}
+
+func (x *WatchProxyEventsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *WatchProxyEventsRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *WatchProxyEventsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type ProxyEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	ProxyId       string                 `protobuf:"bytes,2,opt,name=proxy_id,json=proxyId,proto3" json:"proxy_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	Country       string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	ProxyType     string                 `protobuf:"bytes,6,opt,name=proxy_type,json=proxyType,proto3" json:"proxy_type,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,7,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Timestamp     int64                  `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProxyEvent) Reset() {
+	*x = ProxyEvent{}
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyEvent) ProtoMessage() {}
+
+func (x *ProxyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_services_proxy_service_proto_proxy_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProxyEvent.ProtoReflect.Descriptor instead.
+func (*ProxyEvent) Descriptor() ([]byte, []int) {
+	return file_services_proxy_service_proto_proxy_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ProxyEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProxyEvent) GetProxyId() string {
+	if x != nil {
+		return x.ProxyId
+	}
+	return ""
+}
+
+func (x *ProxyEvent) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ProxyEvent) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ProxyEvent) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ProxyEvent) GetProxyType() string {
+	if x != nil {
+		return x.ProxyType
+	}
+	return ""
+}
+
+func (x *ProxyEvent) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *ProxyEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 var File_services_proxy_service_proto_proxy_proto protoreflect.FileDescriptor
 
 const file_services_proxy_service_proto_proxy_proto_rawDesc = "" +
 	"\n" +
-	"(services/proxy-service/proto/proxy.proto\x12\x05proxy\"\x7f\n" +
+	"(services/proxy-service/proto/proxy.proto\x12\x05proxy\"\x8e\x02\n" +
 	"\x14AllocateProxyRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
 	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
-	"\bprotocol\x18\x04 \x01(\tR\bprotocol\"4\n" +
+	"\bprotocol\x18\x04 \x01(\tR\bprotocol\x12=\n" +
+	"\rcountry_chain\x18\x05 \x03(\v2\x18.proxy.CountryPreferenceR\fcountryChain\x12\x1a\n" +
+	"\brequires\x18\x06 \x03(\tR\brequires\x12\x16\n" +
+	"\x06policy\x18\a \x01(\tR\x06policy\x12\x1a\n" +
+	"\bplatform\x18\b \x01(\tR\bplatform\"V\n" +
+	"\x11CountryPreference\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12'\n" +
+	"\x0fmax_allocations\x18\x02 \x01(\x05R\x0emaxAllocations\"4\n" +
 	"\x13ReleaseProxyRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\"J\n" +
@@ -914,7 +1578,21 @@ const file_services_proxy_service_proto_proxy_proto_rawDesc = "" +
 	"\x12RotateProxyRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\"\x16\n" +
-	"\x14GetStatisticsRequest\"\xac\x02\n" +
+	"\x14GetStatisticsRequest\"K\n" +
+	"\x10GetExitIPRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x18\n" +
+	"\arefresh\x18\x02 \x01(\bR\arefresh\"\xb4\x01\n" +
+	"\x0eExitIPResponse\x12\x19\n" +
+	"\bproxy_id\x18\x01 \x01(\tR\aproxyId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x1f\n" +
+	"\vprevious_ip\x18\x04 \x01(\tR\n" +
+	"previousIp\x12\x18\n" +
+	"\achanged\x18\x05 \x01(\bR\achanged\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\x06 \x01(\x03R\tcheckedAt\"\xf7\x02\n" +
 	"\rProxyResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x0e\n" +
 	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x12\n" +
@@ -929,7 +1607,9 @@ const file_services_proxy_service_proto_proxy_proto_rawDesc = "" +
 	" \x01(\tR\x06status\x12\x1d\n" +
 	"\n" +
 	"expires_at\x18\v \x01(\x03R\texpiresAt\x12\x1a\n" +
-	"\bprovider\x18\f \x01(\tR\bprovider\"\xa3\x02\n" +
+	"\bprovider\x18\f \x01(\tR\bprovider\x12%\n" +
+	"\x0efallback_level\x18\r \x01(\x05R\rfallbackLevel\x12\"\n" +
+	"\fcapabilities\x18\x0e \x03(\tR\fcapabilities\"\xa3\x02\n" +
 	"\x13ProxyHealthResponse\x12\x19\n" +
 	"\bproxy_id\x18\x01 \x01(\tR\aproxyId\x12\x18\n" +
 	"\alatency\x18\x02 \x01(\x05R\alatency\x12\x1f\n" +
@@ -941,7 +1621,7 @@ const file_services_proxy_service_proto_proxy_proto_rawDesc = "" +
 	"\x10blacklist_status\x18\a \x01(\bR\x0fblacklistStatus\x12\x1d\n" +
 	"\n" +
 	"last_check\x18\b \x01(\x03R\tlastCheck\x12#\n" +
-	"\rfailed_checks\x18\t \x01(\x05R\ffailedChecks\"\xeb\x04\n" +
+	"\rfailed_checks\x18\t \x01(\x05R\ffailedChecks\"\x93\a\n" +
 	"\x17ProxyStatisticsResponse\x12#\n" +
 	"\rtotal_proxies\x18\x01 \x01(\x03R\ftotalProxies\x12%\n" +
 	"\x0eactive_proxies\x18\x02 \x01(\x03R\ractiveProxies\x12'\n" +
@@ -952,13 +1632,41 @@ const file_services_proxy_service_proto_proxy_proto_rawDesc = "" +
 	"\x12proxies_by_country\x18\a \x03(\v24.proxy.ProxyStatisticsResponse.ProxiesByCountryEntryR\x10proxiesByCountry\x12&\n" +
 	"\x0favg_fraud_score\x18\b \x01(\x01R\ravgFraudScore\x12\x1f\n" +
 	"\vavg_latency\x18\t \x01(\x01R\n" +
-	"avgLatency\x1a@\n" +
+	"avgLatency\x12(\n" +
+	"\x10total_bytes_sent\x18\n" +
+	" \x01(\x03R\x0etotalBytesSent\x120\n" +
+	"\x14total_bytes_received\x18\v \x01(\x03R\x12totalBytesReceived\x12%\n" +
+	"\x0etotal_requests\x18\f \x01(\x03R\rtotalRequests\x12_\n" +
+	"\x11bytes_by_provider\x18\r \x03(\v23.proxy.ProxyStatisticsResponse.BytesByProviderEntryR\x0fbytesByProvider\x1a@\n" +
 	"\x12ProxiesByTypeEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aC\n" +
 	"\x15ProxiesByCountryEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"2\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aB\n" +
+	"\x14BytesByProviderEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"7\n" +
+	"\x16GetAccountUsageRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xe9\x01\n" +
+	"\x14AccountUsageResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12(\n" +
+	"\x10total_bytes_sent\x18\x02 \x01(\x03R\x0etotalBytesSent\x120\n" +
+	"\x14total_bytes_received\x18\x03 \x01(\x03R\x12totalBytesReceived\x12%\n" +
+	"\x0etotal_requests\x18\x04 \x01(\x03R\rtotalRequests\x12/\n" +
+	"\bbindings\x18\x05 \x03(\v2\x13.proxy.BindingUsageR\bbindings\"\xe9\x01\n" +
+	"\fBindingUsage\x12\x19\n" +
+	"\bproxy_id\x18\x01 \x01(\tR\aproxyId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x19\n" +
+	"\bbound_at\x18\x03 \x01(\x03R\aboundAt\x12 \n" +
+	"\flast_used_at\x18\x04 \x01(\x03R\n" +
+	"lastUsedAt\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x05 \x01(\x03R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x06 \x01(\x03R\rbytesReceived\x12#\n" +
+	"\rrequest_count\x18\a \x01(\x03R\frequestCount\"2\n" +
 	"\x1cGetProviderStatisticsRequest\x12\x12\n" +
 	"\x04days\x18\x01 \x01(\x05R\x04days\"Y\n" +
 	"\x1aProviderStatisticsResponse\x12;\n" +
@@ -972,15 +1680,40 @@ const file_services_proxy_service_proto_proxy_proto_rawDesc = "" +
 	"\x0ecost_per_proxy\x18\x05 \x01(\x01R\fcostPerProxy\x12!\n" +
 	"\fsuccess_rate\x18\x06 \x01(\x01R\vsuccessRate\x12\x19\n" +
 	"\bban_rate\x18\a \x01(\x01R\abanRate\x12#\n" +
-	"\rtotal_proxies\x18\b \x01(\x03R\ftotalProxies2\x9f\x04\n" +
+	"\rtotal_proxies\x18\b \x01(\x03R\ftotalProxies\"\x9f\x01\n" +
+	"\x17WatchProxyEventsRequest\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\x12\x19\n" +
+	"\bproxy_id\x18\x02 \x01(\tR\aproxyId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\"\xc3\x02\n" +
+	"\n" +
+	"ProxyEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x19\n" +
+	"\bproxy_id\x18\x02 \x01(\tR\aproxyId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12\x1d\n" +
+	"\n" +
+	"proxy_type\x18\x06 \x01(\tR\tproxyType\x128\n" +
+	"\adetails\x18\a \x03(\v2\x1e.proxy.ProxyEvent.DetailsEntryR\adetails\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x03R\ttimestamp\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xf4\x05\n" +
 	"\fProxyService\x12B\n" +
 	"\rAllocateProxy\x12\x1b.proxy.AllocateProxyRequest\x1a\x14.proxy.ProxyResponse\x12G\n" +
 	"\fReleaseProxy\x12\x1a.proxy.ReleaseProxyRequest\x1a\x1b.proxy.ReleaseProxyResponse\x12B\n" +
 	"\x12GetProxyForAccount\x12\x16.proxy.GetProxyRequest\x1a\x14.proxy.ProxyResponse\x12J\n" +
 	"\x0eGetProxyHealth\x12\x1c.proxy.GetProxyHealthRequest\x1a\x1a.proxy.ProxyHealthResponse\x12>\n" +
 	"\vRotateProxy\x12\x19.proxy.RotateProxyRequest\x1a\x14.proxy.ProxyResponse\x12Q\n" +
-	"\x12GetProxyStatistics\x12\x1b.proxy.GetStatisticsRequest\x1a\x1e.proxy.ProxyStatisticsResponse\x12_\n" +
-	"\x15GetProviderStatistics\x12#.proxy.GetProviderStatisticsRequest\x1a!.proxy.ProviderStatisticsResponseB8Z6github.com/grigta/conveer/services/proxy-service/protob\x06proto3"
+	"\x12GetProxyStatistics\x12\x1b.proxy.GetStatisticsRequest\x1a\x1e.proxy.ProxyStatisticsResponse\x12M\n" +
+	"\x0fGetAccountUsage\x12\x1d.proxy.GetAccountUsageRequest\x1a\x1b.proxy.AccountUsageResponse\x12_\n" +
+	"\x15GetProviderStatistics\x12#.proxy.GetProviderStatisticsRequest\x1a!.proxy.ProviderStatisticsResponse\x12;\n" +
+	"\tGetExitIP\x12\x17.proxy.GetExitIPRequest\x1a\x15.proxy.ExitIPResponse\x12G\n" +
+	"\x10WatchProxyEvents\x12\x1e.proxy.WatchProxyEventsRequest\x1a\x11.proxy.ProxyEvent0\x01B8Z6github.com/grigta/conveer/services/proxy-service/protob\x06proto3"
 
 var (
 	file_services_proxy_service_proto_proxy_proto_rawDescOnce sync.Once
@@ -994,47 +1727,67 @@ func file_services_proxy_service_proto_proxy_proto_rawDescGZIP() []byte {
 	return file_services_proxy_service_proto_proxy_proto_rawDescData
 }
 
-var file_services_proxy_service_proto_proxy_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_services_proxy_service_proto_proxy_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_services_proxy_service_proto_proxy_proto_goTypes = []any{
 	(*AllocateProxyRequest)(nil),         // 0: proxy.AllocateProxyRequest
-	(*ReleaseProxyRequest)(nil),          // 1: proxy.ReleaseProxyRequest
-	(*ReleaseProxyResponse)(nil),         // 2: proxy.ReleaseProxyResponse
-	(*GetProxyRequest)(nil),              // 3: proxy.GetProxyRequest
-	(*GetProxyHealthRequest)(nil),        // 4: proxy.GetProxyHealthRequest
-	(*RotateProxyRequest)(nil),           // 5: proxy.RotateProxyRequest
-	(*GetStatisticsRequest)(nil),         // 6: proxy.GetStatisticsRequest
-	(*ProxyResponse)(nil),                // 7: proxy.ProxyResponse
-	(*ProxyHealthResponse)(nil),          // 8: proxy.ProxyHealthResponse
-	(*ProxyStatisticsResponse)(nil),      // 9: proxy.ProxyStatisticsResponse
-	(*GetProviderStatisticsRequest)(nil), // 10: proxy.GetProviderStatisticsRequest
-	(*ProviderStatisticsResponse)(nil),   // 11: proxy.ProviderStatisticsResponse
-	(*ProviderStats)(nil),                // 12: proxy.ProviderStats
-	nil,                                  // 13: proxy.ProxyStatisticsResponse.ProxiesByTypeEntry
-	nil,                                  // 14: proxy.ProxyStatisticsResponse.ProxiesByCountryEntry
+	(*CountryPreference)(nil),            // 1: proxy.CountryPreference
+	(*ReleaseProxyRequest)(nil),          // 2: proxy.ReleaseProxyRequest
+	(*ReleaseProxyResponse)(nil),         // 3: proxy.ReleaseProxyResponse
+	(*GetProxyRequest)(nil),              // 4: proxy.GetProxyRequest
+	(*GetProxyHealthRequest)(nil),        // 5: proxy.GetProxyHealthRequest
+	(*RotateProxyRequest)(nil),           // 6: proxy.RotateProxyRequest
+	(*GetStatisticsRequest)(nil),         // 7: proxy.GetStatisticsRequest
+	(*GetExitIPRequest)(nil),             // 8: proxy.GetExitIPRequest
+	(*ExitIPResponse)(nil),               // 9: proxy.ExitIPResponse
+	(*ProxyResponse)(nil),                // 10: proxy.ProxyResponse
+	(*ProxyHealthResponse)(nil),          // 11: proxy.ProxyHealthResponse
+	(*ProxyStatisticsResponse)(nil),      // 12: proxy.ProxyStatisticsResponse
+	(*GetAccountUsageRequest)(nil),       // 13: proxy.GetAccountUsageRequest
+	(*AccountUsageResponse)(nil),         // 14: proxy.AccountUsageResponse
+	(*BindingUsage)(nil),                 // 15: proxy.BindingUsage
+	(*GetProviderStatisticsRequest)(nil), // 16: proxy.GetProviderStatisticsRequest
+	(*ProviderStatisticsResponse)(nil),   // 17: proxy.ProviderStatisticsResponse
+	(*ProviderStats)(nil),                // 18: proxy.ProviderStats
+	(*WatchProxyEventsRequest)(nil),      // 19: proxy.WatchProxyEventsRequest
+	(*ProxyEvent)(nil),                   // 20: proxy.ProxyEvent
+	nil,                                  // 21: proxy.ProxyStatisticsResponse.ProxiesByTypeEntry
+	nil,                                  // 22: proxy.ProxyStatisticsResponse.ProxiesByCountryEntry
+	nil,                                  // 23: proxy.ProxyStatisticsResponse.BytesByProviderEntry
+	nil,                                  // 24: proxy.ProxyEvent.DetailsEntry
 }
 var file_services_proxy_service_proto_proxy_proto_depIdxs = []int32{
-	13, // 0: proxy.ProxyStatisticsResponse.proxies_by_type:type_name -> proxy.ProxyStatisticsResponse.ProxiesByTypeEntry
-	14, // 1: proxy.ProxyStatisticsResponse.proxies_by_country:type_name -> proxy.ProxyStatisticsResponse.ProxiesByCountryEntry
-	12, // 2: proxy.ProviderStatisticsResponse.provider_stats:type_name -> proxy.ProviderStats
-	0,  // 3: proxy.ProxyService.AllocateProxy:input_type -> proxy.AllocateProxyRequest
-	1,  // 4: proxy.ProxyService.ReleaseProxy:input_type -> proxy.ReleaseProxyRequest
-	3,  // 5: proxy.ProxyService.GetProxyForAccount:input_type -> proxy.GetProxyRequest
-	4,  // 6: proxy.ProxyService.GetProxyHealth:input_type -> proxy.GetProxyHealthRequest
-	5,  // 7: proxy.ProxyService.RotateProxy:input_type -> proxy.RotateProxyRequest
-	6,  // 8: proxy.ProxyService.GetProxyStatistics:input_type -> proxy.GetStatisticsRequest
-	10, // 9: proxy.ProxyService.GetProviderStatistics:input_type -> proxy.GetProviderStatisticsRequest
-	7,  // 10: proxy.ProxyService.AllocateProxy:output_type -> proxy.ProxyResponse
-	2,  // 11: proxy.ProxyService.ReleaseProxy:output_type -> proxy.ReleaseProxyResponse
-	7,  // 12: proxy.ProxyService.GetProxyForAccount:output_type -> proxy.ProxyResponse
-	8,  // 13: proxy.ProxyService.GetProxyHealth:output_type -> proxy.ProxyHealthResponse
-	7,  // 14: proxy.ProxyService.RotateProxy:output_type -> proxy.ProxyResponse
-	9,  // 15: proxy.ProxyService.GetProxyStatistics:output_type -> proxy.ProxyStatisticsResponse
-	11, // 16: proxy.ProxyService.GetProviderStatistics:output_type -> proxy.ProviderStatisticsResponse
-	10, // [10:17] is the sub-list for method output_type
-	3,  // [3:10] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	1,  // 0: proxy.AllocateProxyRequest.country_chain:type_name -> proxy.CountryPreference
+	21, // 1: proxy.ProxyStatisticsResponse.proxies_by_type:type_name -> proxy.ProxyStatisticsResponse.ProxiesByTypeEntry
+	22, // 2: proxy.ProxyStatisticsResponse.proxies_by_country:type_name -> proxy.ProxyStatisticsResponse.ProxiesByCountryEntry
+	23, // 3: proxy.ProxyStatisticsResponse.bytes_by_provider:type_name -> proxy.ProxyStatisticsResponse.BytesByProviderEntry
+	15, // 4: proxy.AccountUsageResponse.bindings:type_name -> proxy.BindingUsage
+	18, // 5: proxy.ProviderStatisticsResponse.provider_stats:type_name -> proxy.ProviderStats
+	24, // 6: proxy.ProxyEvent.details:type_name -> proxy.ProxyEvent.DetailsEntry
+	0,  // 7: proxy.ProxyService.AllocateProxy:input_type -> proxy.AllocateProxyRequest
+	2,  // 8: proxy.ProxyService.ReleaseProxy:input_type -> proxy.ReleaseProxyRequest
+	4,  // 9: proxy.ProxyService.GetProxyForAccount:input_type -> proxy.GetProxyRequest
+	5,  // 10: proxy.ProxyService.GetProxyHealth:input_type -> proxy.GetProxyHealthRequest
+	6,  // 11: proxy.ProxyService.RotateProxy:input_type -> proxy.RotateProxyRequest
+	7,  // 12: proxy.ProxyService.GetProxyStatistics:input_type -> proxy.GetStatisticsRequest
+	13, // 13: proxy.ProxyService.GetAccountUsage:input_type -> proxy.GetAccountUsageRequest
+	16, // 14: proxy.ProxyService.GetProviderStatistics:input_type -> proxy.GetProviderStatisticsRequest
+	8,  // 15: proxy.ProxyService.GetExitIP:input_type -> proxy.GetExitIPRequest
+	19, // 16: proxy.ProxyService.WatchProxyEvents:input_type -> proxy.WatchProxyEventsRequest
+	10, // 17: proxy.ProxyService.AllocateProxy:output_type -> proxy.ProxyResponse
+	3,  // 18: proxy.ProxyService.ReleaseProxy:output_type -> proxy.ReleaseProxyResponse
+	10, // 19: proxy.ProxyService.GetProxyForAccount:output_type -> proxy.ProxyResponse
+	11, // 20: proxy.ProxyService.GetProxyHealth:output_type -> proxy.ProxyHealthResponse
+	10, // 21: proxy.ProxyService.RotateProxy:output_type -> proxy.ProxyResponse
+	12, // 22: proxy.ProxyService.GetProxyStatistics:output_type -> proxy.ProxyStatisticsResponse
+	14, // 23: proxy.ProxyService.GetAccountUsage:output_type -> proxy.AccountUsageResponse
+	17, // 24: proxy.ProxyService.GetProviderStatistics:output_type -> proxy.ProviderStatisticsResponse
+	9,  // 25: proxy.ProxyService.GetExitIP:output_type -> proxy.ExitIPResponse
+	20, // 26: proxy.ProxyService.WatchProxyEvents:output_type -> proxy.ProxyEvent
+	17, // [17:27] is the sub-list for method output_type
+	7,  // [7:17] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_services_proxy_service_proto_proxy_proto_init() }
@@ -1048,7 +1801,7 @@ func file_services_proxy_service_proto_proxy_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_proxy_service_proto_proxy_proto_rawDesc), len(file_services_proxy_service_proto_proxy_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,7 +25,10 @@ const (
 	ProxyService_GetProxyHealth_FullMethodName        = "/proxy.ProxyService/GetProxyHealth"
 	ProxyService_RotateProxy_FullMethodName           = "/proxy.ProxyService/RotateProxy"
 	ProxyService_GetProxyStatistics_FullMethodName    = "/proxy.ProxyService/GetProxyStatistics"
+	ProxyService_GetAccountUsage_FullMethodName       = "/proxy.ProxyService/GetAccountUsage"
 	ProxyService_GetProviderStatistics_FullMethodName = "/proxy.ProxyService/GetProviderStatistics"
+	ProxyService_GetExitIP_FullMethodName             = "/proxy.ProxyService/GetExitIP"
+	ProxyService_WatchProxyEvents_FullMethodName      = "/proxy.ProxyService/WatchProxyEvents"
 )
 
 // ProxyServiceClient is the client API for ProxyService service.
@@ -38,7 +41,10 @@ type ProxyServiceClient interface {
 	GetProxyHealth(ctx context.Context, in *GetProxyHealthRequest, opts ...grpc.CallOption) (*ProxyHealthResponse, error)
 	RotateProxy(ctx context.Context, in *RotateProxyRequest, opts ...grpc.CallOption) (*ProxyResponse, error)
 	GetProxyStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*ProxyStatisticsResponse, error)
+	GetAccountUsage(ctx context.Context, in *GetAccountUsageRequest, opts ...grpc.CallOption) (*AccountUsageResponse, error)
 	GetProviderStatistics(ctx context.Context, in *GetProviderStatisticsRequest, opts ...grpc.CallOption) (*ProviderStatisticsResponse, error)
+	GetExitIP(ctx context.Context, in *GetExitIPRequest, opts ...grpc.CallOption) (*ExitIPResponse, error)
+	WatchProxyEvents(ctx context.Context, in *WatchProxyEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProxyEvent], error)
 }
 
 type proxyServiceClient struct {
@@ -109,6 +115,16 @@ func (c *proxyServiceClient) GetProxyStatistics(ctx context.Context, in *GetStat
 	return out, nil
 }
 
+func (c *proxyServiceClient) GetAccountUsage(ctx context.Context, in *GetAccountUsageRequest, opts ...grpc.CallOption) (*AccountUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountUsageResponse)
+	err := c.cc.Invoke(ctx, ProxyService_GetAccountUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *proxyServiceClient) GetProviderStatistics(ctx context.Context, in *GetProviderStatisticsRequest, opts ...grpc.CallOption) (*ProviderStatisticsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ProviderStatisticsResponse)
@@ -119,6 +135,35 @@ func (c *proxyServiceClient) GetProviderStatistics(ctx context.Context, in *GetP
 	return out, nil
 }
 
+func (c *proxyServiceClient) GetExitIP(ctx context.Context, in *GetExitIPRequest, opts ...grpc.CallOption) (*ExitIPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExitIPResponse)
+	err := c.cc.Invoke(ctx, ProxyService_GetExitIP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyServiceClient) WatchProxyEvents(ctx context.Context, in *WatchProxyEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProxyEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProxyService_ServiceDesc.Streams[0], ProxyService_WatchProxyEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchProxyEventsRequest, ProxyEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProxyService_WatchProxyEventsClient = grpc.ServerStreamingClient[ProxyEvent]
+
 // ProxyServiceServer is the server API for ProxyService service.
 // All implementations must embed UnimplementedProxyServiceServer
 // for forward compatibility.
@@ -129,7 +174,10 @@ type ProxyServiceServer interface {
 	GetProxyHealth(context.Context, *GetProxyHealthRequest) (*ProxyHealthResponse, error)
 	RotateProxy(context.Context, *RotateProxyRequest) (*ProxyResponse, error)
 	GetProxyStatistics(context.Context, *GetStatisticsRequest) (*ProxyStatisticsResponse, error)
+	GetAccountUsage(context.Context, *GetAccountUsageRequest) (*AccountUsageResponse, error)
 	GetProviderStatistics(context.Context, *GetProviderStatisticsRequest) (*ProviderStatisticsResponse, error)
+	GetExitIP(context.Context, *GetExitIPRequest) (*ExitIPResponse, error)
+	WatchProxyEvents(*WatchProxyEventsRequest, grpc.ServerStreamingServer[ProxyEvent]) error
 	mustEmbedUnimplementedProxyServiceServer()
 }
 
@@ -158,9 +206,18 @@ func (UnimplementedProxyServiceServer) RotateProxy(context.Context, *RotateProxy
 func (UnimplementedProxyServiceServer) GetProxyStatistics(context.Context, *GetStatisticsRequest) (*ProxyStatisticsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProxyStatistics not implemented")
 }
+func (UnimplementedProxyServiceServer) GetAccountUsage(context.Context, *GetAccountUsageRequest) (*AccountUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountUsage not implemented")
+}
 func (UnimplementedProxyServiceServer) GetProviderStatistics(context.Context, *GetProviderStatisticsRequest) (*ProviderStatisticsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProviderStatistics not implemented")
 }
+func (UnimplementedProxyServiceServer) GetExitIP(context.Context, *GetExitIPRequest) (*ExitIPResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetExitIP not implemented")
+}
+func (UnimplementedProxyServiceServer) WatchProxyEvents(*WatchProxyEventsRequest, grpc.ServerStreamingServer[ProxyEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchProxyEvents not implemented")
+}
 func (UnimplementedProxyServiceServer) mustEmbedUnimplementedProxyServiceServer() {}
 func (UnimplementedProxyServiceServer) testEmbeddedByValue()                      {}
 
@@ -290,6 +347,24 @@ func _ProxyService_GetProxyStatistics_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProxyService_GetAccountUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).GetAccountUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_GetAccountUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).GetAccountUsage(ctx, req.(*GetAccountUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProxyService_GetProviderStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetProviderStatisticsRequest)
 	if err := dec(in); err != nil {
@@ -308,6 +383,35 @@ func _ProxyService_GetProviderStatistics_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProxyService_GetExitIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExitIPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).GetExitIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_GetExitIP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).GetExitIP(ctx, req.(*GetExitIPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProxyService_WatchProxyEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchProxyEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProxyServiceServer).WatchProxyEvents(m, &grpc.GenericServerStream[WatchProxyEventsRequest, ProxyEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProxyService_WatchProxyEventsServer = grpc.ServerStreamingServer[ProxyEvent]
+
 // ProxyService_ServiceDesc is the grpc.ServiceDesc for ProxyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -339,11 +443,25 @@ var ProxyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProxyStatistics",
 			Handler:    _ProxyService_GetProxyStatistics_Handler,
 		},
+		{
+			MethodName: "GetAccountUsage",
+			Handler:    _ProxyService_GetAccountUsage_Handler,
+		},
 		{
 			MethodName: "GetProviderStatistics",
 			Handler:    _ProxyService_GetProviderStatistics_Handler,
 		},
+		{
+			MethodName: "GetExitIP",
+			Handler:    _ProxyService_GetExitIP_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchProxyEvents",
+			Handler:       _ProxyService_WatchProxyEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/proxy-service/proto/proxy.proto",
 }
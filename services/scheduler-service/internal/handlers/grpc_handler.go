@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/scheduler-service/internal/models"
+	"github.com/grigta/conveer/services/scheduler-service/internal/service"
+	pb "github.com/grigta/conveer/services/scheduler-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GRPCHandler struct {
+	pb.UnimplementedSchedulerServiceServer
+	schedulerService *service.SchedulerService
+	logger           logger.Logger
+}
+
+func NewGRPCHandler(schedulerService *service.SchedulerService, logger logger.Logger) *GRPCHandler {
+	return &GRPCHandler{
+		schedulerService: schedulerService,
+		logger:           logger,
+	}
+}
+
+func (h *GRPCHandler) CreateJob(ctx context.Context, req *pb.JobRequest) (*pb.Job, error) {
+	job, err := h.schedulerService.CreateJob(ctx, fromProtoRequest(req))
+	if err != nil {
+		h.logger.Errorf("Failed to create job: %v", err)
+		return nil, toStatus(err)
+	}
+
+	return toProtoJob(job), nil
+}
+
+func (h *GRPCHandler) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	job, err := h.schedulerService.GetJob(ctx, req.JobId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoJob(job), nil
+}
+
+func (h *GRPCHandler) ListJobs(ctx context.Context, req *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	jobs, err := h.schedulerService.ListJobs(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListJobsResponse{Jobs: make([]*pb.Job, 0, len(jobs))}
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, toProtoJob(job))
+	}
+	return resp, nil
+}
+
+func (h *GRPCHandler) UpdateJob(ctx context.Context, req *pb.UpdateJobRequest) (*pb.Job, error) {
+	if req.Job == nil {
+		return nil, status.Error(codes.InvalidArgument, "job is required")
+	}
+
+	job, err := h.schedulerService.UpdateJob(ctx, req.JobId, fromProtoRequest(req.Job))
+	if err != nil {
+		h.logger.Errorf("Failed to update job %s: %v", req.JobId, err)
+		return nil, toStatus(err)
+	}
+
+	return toProtoJob(job), nil
+}
+
+func (h *GRPCHandler) DeleteJob(ctx context.Context, req *pb.DeleteJobRequest) (*pb.DeleteJobResponse, error) {
+	if err := h.schedulerService.DeleteJob(ctx, req.JobId); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.DeleteJobResponse{Success: true}, nil
+}
+
+func (h *GRPCHandler) PauseJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	job, err := h.schedulerService.PauseJob(ctx, req.JobId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoJob(job), nil
+}
+
+func (h *GRPCHandler) ResumeJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	job, err := h.schedulerService.ResumeJob(ctx, req.JobId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoJob(job), nil
+}
+
+func (h *GRPCHandler) TriggerJob(ctx context.Context, req *pb.GetJobRequest) (*pb.JobRun, error) {
+	run, err := h.schedulerService.TriggerJob(ctx, req.JobId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoRun(run), nil
+}
+
+func (h *GRPCHandler) ListJobRuns(ctx context.Context, req *pb.ListJobRunsRequest) (*pb.ListJobRunsResponse, error) {
+	runs, err := h.schedulerService.ListRuns(ctx, req.JobId, int(req.Limit))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListJobRunsResponse{Runs: make([]*pb.JobRun, 0, len(runs))}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, toProtoRun(run))
+	}
+	return resp, nil
+}
+
+func fromProtoRequest(req *pb.JobRequest) *models.JobRequest {
+	enabled := !req.Paused
+	result := &models.JobRequest{
+		Name:          req.Name,
+		Description:   req.Description,
+		Schedule:      req.Schedule,
+		Timezone:      req.Timezone,
+		Exchange:      req.Exchange,
+		RoutingKey:    req.RoutingKey,
+		Count:         int(req.Count),
+		MisfirePolicy: models.MisfirePolicy(req.MisfirePolicy),
+		Enabled:       &enabled,
+	}
+	if req.Payload != "" {
+		result.Payload = json.RawMessage(req.Payload)
+	}
+	return result
+}
+
+func toProtoJob(job *models.Job) *pb.Job {
+	resp := &pb.Job{
+		Id:            job.ID.Hex(),
+		Name:          job.Name,
+		Description:   job.Description,
+		Schedule:      job.Schedule,
+		Timezone:      job.Timezone,
+		Exchange:      job.Exchange,
+		RoutingKey:    job.RoutingKey,
+		Payload:       string(job.Payload),
+		Count:         int32(job.Count),
+		MisfirePolicy: string(job.MisfirePolicy),
+		Enabled:       job.Enabled,
+		LastStatus:    job.LastStatus,
+		CreatedAt:     job.CreatedAt.Unix(),
+	}
+	if job.NextRunAt != nil {
+		resp.NextRunAt = job.NextRunAt.Unix()
+	}
+	if job.LastRunAt != nil {
+		resp.LastRunAt = job.LastRunAt.Unix()
+	}
+	return resp
+}
+
+func toProtoRun(run *models.JobRun) *pb.JobRun {
+	return &pb.JobRun{
+		Id:          run.ID.Hex(),
+		JobId:       run.JobID.Hex(),
+		JobName:     run.JobName,
+		Trigger:     run.Trigger,
+		Status:      run.Status,
+		ScheduledAt: run.ScheduledAt.Unix(),
+		StartedAt:   run.StartedAt.Unix(),
+		FinishedAt:  run.FinishedAt.Unix(),
+		Published:   int32(run.Published),
+		Error:       run.Error,
+	}
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, models.ErrJobNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrJobNameRequired),
+		errors.Is(err, models.ErrInvalidSchedule),
+		errors.Is(err, models.ErrInvalidTimezone),
+		errors.Is(err, models.ErrInvalidMisfire),
+		errors.Is(err, models.ErrExchangeNotAllowed),
+		errors.Is(err, models.ErrInvalidPayload),
+		errors.Is(err, models.ErrInvalidMessageCount):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrJobExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "scheduler service error: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/scheduler-service/internal/models"
+	"github.com/grigta/conveer/services/scheduler-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HTTPHandler struct {
+	schedulerService *service.SchedulerService
+	logger           logger.Logger
+}
+
+func NewHTTPHandler(schedulerService *service.SchedulerService, logger logger.Logger) *HTTPHandler {
+	return &HTTPHandler{
+		schedulerService: schedulerService,
+		logger:           logger,
+	}
+}
+
+func (h *HTTPHandler) CreateJob(c *gin.Context) {
+	var req models.JobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.schedulerService.CreateJob(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Errorf("Failed to create job: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"job": job})
+}
+
+func (h *HTTPHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.schedulerService.ListJobs(c.Request.Context())
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+}
+
+func (h *HTTPHandler) GetJob(c *gin.Context) {
+	job, err := h.schedulerService.GetJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+func (h *HTTPHandler) UpdateJob(c *gin.Context) {
+	var req models.JobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.schedulerService.UpdateJob(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		h.logger.Errorf("Failed to update job %s: %v", c.Param("id"), err)
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+func (h *HTTPHandler) DeleteJob(c *gin.Context) {
+	if err := h.schedulerService.DeleteJob(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *HTTPHandler) PauseJob(c *gin.Context) {
+	job, err := h.schedulerService.PauseJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+func (h *HTTPHandler) ResumeJob(c *gin.Context) {
+	job, err := h.schedulerService.ResumeJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+func (h *HTTPHandler) TriggerJob(c *gin.Context) {
+	run, err := h.schedulerService.TriggerJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"run": run})
+}
+
+func (h *HTTPHandler) ListRuns(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	runs, err := h.schedulerService.ListRuns(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrJobNameRequired),
+		errors.Is(err, models.ErrInvalidSchedule),
+		errors.Is(err, models.ErrInvalidTimezone),
+		errors.Is(err, models.ErrInvalidMisfire),
+		errors.Is(err, models.ErrExchangeNotAllowed),
+		errors.Is(err, models.ErrInvalidPayload),
+		errors.Is(err, models.ErrInvalidMessageCount):
+		return http.StatusBadRequest
+	case errors.Is(err, models.ErrJobExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
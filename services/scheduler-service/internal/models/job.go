@@ -0,0 +1,123 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MisfirePolicy decides what happens to fire times missed while the scheduler was down or behind
+type MisfirePolicy string
+
+const (
+	// MisfireFireOnce fires a single run for all missed fire times
+	MisfireFireOnce MisfirePolicy = "fire_once"
+	// MisfireSkip records missed fire times as skipped and waits for the next one
+	MisfireSkip MisfirePolicy = "skip"
+	// MisfireFireAll fires every missed fire time, up to the configured catch-up limit
+	MisfireFireAll MisfirePolicy = "fire_all"
+)
+
+const (
+	TriggerSchedule = "schedule"
+	TriggerMisfire  = "misfire"
+	TriggerManual   = "manual"
+)
+
+const (
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+	RunStatusSkipped   = "skipped"
+)
+
+// MaxMessagesPerRun caps Count so a typo can't flood a command queue
+const MaxMessagesPerRun = 1000
+
+var (
+	ErrJobNotFound         = errors.New("job not found")
+	ErrJobExists           = errors.New("job with this name already exists")
+	ErrJobNameRequired     = errors.New("job name is required")
+	ErrInvalidSchedule     = errors.New("invalid schedule")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+	ErrInvalidMisfire      = errors.New("invalid misfire policy")
+	ErrExchangeNotAllowed  = errors.New("exchange is not allowed for scheduled jobs")
+	ErrInvalidPayload      = errors.New("payload must be a JSON object")
+	ErrInvalidMessageCount = errors.New("count must be between 1 and 1000")
+)
+
+// Job publishes Payload to Exchange with RoutingKey Count times on every fire time of Schedule
+type Job struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name          string             `bson:"name" json:"name"`
+	Description   string             `bson:"description,omitempty" json:"description,omitempty"`
+	Schedule      string             `bson:"schedule" json:"schedule"`
+	Timezone      string             `bson:"timezone" json:"timezone"`
+	Exchange      string             `bson:"exchange" json:"exchange"`
+	RoutingKey    string             `bson:"routing_key" json:"routing_key"`
+	Payload       json.RawMessage    `bson:"payload" json:"payload"`
+	Count         int                `bson:"count" json:"count"`
+	MisfirePolicy MisfirePolicy      `bson:"misfire_policy" json:"misfire_policy"`
+	Enabled       bool               `bson:"enabled" json:"enabled"`
+	NextRunAt     *time.Time         `bson:"next_run_at,omitempty" json:"next_run_at,omitempty"`
+	LastRunAt     *time.Time         `bson:"last_run_at,omitempty" json:"last_run_at,omitempty"`
+	LastStatus    string             `bson:"last_status,omitempty" json:"last_status,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// JobRun is one entry of a job's run history
+type JobRun struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	JobID       primitive.ObjectID `bson:"job_id" json:"job_id"`
+	JobName     string             `bson:"job_name" json:"job_name"`
+	Trigger     string             `bson:"trigger" json:"trigger"`
+	Status      string             `bson:"status" json:"status"`
+	ScheduledAt time.Time          `bson:"scheduled_at" json:"scheduled_at"`
+	StartedAt   time.Time          `bson:"started_at" json:"started_at"`
+	FinishedAt  time.Time          `bson:"finished_at" json:"finished_at"`
+	Published   int                `bson:"published" json:"published"`
+	Error       string             `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+// JobRequest creates a job or replaces its definition
+type JobRequest struct {
+	Name          string          `json:"name" binding:"required"`
+	Description   string          `json:"description"`
+	Schedule      string          `json:"schedule" binding:"required"`
+	Timezone      string          `json:"timezone"`
+	Exchange      string          `json:"exchange" binding:"required"`
+	RoutingKey    string          `json:"routing_key"`
+	Payload       json.RawMessage `json:"payload"`
+	Count         int             `json:"count"`
+	MisfirePolicy MisfirePolicy   `json:"misfire_policy"`
+	Enabled       *bool           `json:"enabled"`
+}
+
+type SchedulerConfig struct {
+	DefaultTimezone  string
+	TickInterval     time.Duration
+	MisfireGrace     time.Duration // fire times older than this are handled by the misfire policy
+	MaxCatchup       int           // most missed fire times fired or recorded per job at once
+	AllowedExchanges []string
+	HistoryRetention time.Duration
+}
+
+func ValidMisfirePolicy(policy MisfirePolicy) bool {
+	switch policy {
+	case MisfireFireOnce, MisfireSkip, MisfireFireAll:
+		return true
+	}
+	return false
+}
+
+// ExchangeAllowed reports whether jobs may publish to exchange
+func (c *SchedulerConfig) ExchangeAllowed(exchange string) bool {
+	for _, allowed := range c.AllowedExchanges {
+		if allowed == exchange {
+			return true
+		}
+	}
+	return false
+}
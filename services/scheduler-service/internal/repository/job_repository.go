@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/scheduler-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type JobRepository struct {
+	collection *mongo.Collection
+	logger     logger.Logger
+}
+
+func NewJobRepository(db *mongo.Database, logger logger.Logger) *JobRepository {
+	return &JobRepository{
+		collection: db.Collection("scheduled_jobs"),
+		logger:     logger,
+	}
+}
+
+func (r *JobRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "enabled", Value: 1},
+				{Key: "next_run_at", Value: 1},
+			},
+		},
+	}
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create job indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) Create(ctx context.Context, job *models.Job) error {
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, job)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.ErrJobExists
+		}
+		return fmt.Errorf("failed to insert job: %w", err)
+	}
+
+	job.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *JobRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Job, error) {
+	var job models.Job
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find job: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *JobRepository) List(ctx context.Context) ([]*models.Job, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*models.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ListDue returns enabled jobs whose next fire time has come
+func (r *JobRepository) ListDue(ctx context.Context, now time.Time) ([]*models.Job, error) {
+	filter := bson.M{
+		"enabled":     true,
+		"next_run_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_run_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode due jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// Update replaces the job definition and its next fire time
+func (r *JobRepository) Update(ctx context.Context, job *models.Job) error {
+	job.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":           job.Name,
+			"description":    job.Description,
+			"schedule":       job.Schedule,
+			"timezone":       job.Timezone,
+			"exchange":       job.Exchange,
+			"routing_key":    job.RoutingKey,
+			"payload":        job.Payload,
+			"count":          job.Count,
+			"misfire_policy": job.MisfirePolicy,
+			"enabled":        job.Enabled,
+			"next_run_at":    job.NextRunAt,
+			"updated_at":     job.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": job.ID}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.ErrJobExists
+		}
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrJobNotFound
+	}
+	return nil
+}
+
+func (r *JobRepository) SetEnabled(ctx context.Context, id primitive.ObjectID, enabled bool, nextRunAt *time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"enabled":     enabled,
+			"next_run_at": nextRunAt,
+			"updated_at":  time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrJobNotFound
+	}
+	return nil
+}
+
+// ClaimNextRun moves the job from the fire time it was loaded with to the next one. Only one
+// scheduler replica succeeds, the others see the fire time already moved.
+func (r *JobRepository) ClaimNextRun(ctx context.Context, id primitive.ObjectID, due time.Time, next *time.Time) (bool, error) {
+	filter := bson.M{
+		"_id":         id,
+		"enabled":     true,
+		"next_run_at": due,
+	}
+	update := bson.M{"$set": bson.M{"next_run_at": next}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim job run: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *JobRepository) RecordRun(ctx context.Context, id primitive.ObjectID, at time.Time, status string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"last_run_at": at,
+			"last_status": status,
+		},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to record job run: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return models.ErrJobNotFound
+	}
+	return nil
+}
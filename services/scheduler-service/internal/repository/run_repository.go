@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/scheduler-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RunRepository struct {
+	collection *mongo.Collection
+	logger     logger.Logger
+}
+
+func NewRunRepository(db *mongo.Database, logger logger.Logger) *RunRepository {
+	return &RunRepository{
+		collection: db.Collection("job_runs"),
+		logger:     logger,
+	}
+}
+
+// CreateIndexes creates the history lookup index and the TTL index that expires old runs
+func (r *RunRepository) CreateIndexes(ctx context.Context, retention time.Duration) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "job_id", Value: 1},
+				{Key: "started_at", Value: -1},
+			},
+		},
+		{
+			Keys:    bson.D{{Key: "started_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+		},
+	}
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create job run indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *RunRepository) Create(ctx context.Context, run *models.JobRun) error {
+	result, err := r.collection.InsertOne(ctx, run)
+	if err != nil {
+		return fmt.Errorf("failed to insert job run: %w", err)
+	}
+
+	run.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// ListByJob returns the latest runs of a job, newest first
+func (r *RunRepository) ListByJob(ctx context.Context, jobID primitive.ObjectID, limit int) ([]*models.JobRun, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "started_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, bson.M{"job_id": jobID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	runs := []*models.JobRun{}
+	if err := cursor.All(ctx, &runs); err != nil {
+		return nil, fmt.Errorf("failed to decode job runs: %w", err)
+	}
+	return runs, nil
+}
+
+func (r *RunRepository) DeleteByJob(ctx context.Context, jobID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"job_id": jobID}); err != nil {
+		return fmt.Errorf("failed to delete job runs: %w", err)
+	}
+	return nil
+}
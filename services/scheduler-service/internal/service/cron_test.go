@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/scheduler-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustSchedule(t *testing.T, expr string) *Schedule {
+	t.Helper()
+	schedule, err := ParseSchedule(expr)
+	require.NoError(t, err)
+	return schedule
+}
+
+func TestParseScheduleRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"0 2 * *",
+		"60 * * * *",
+		"0 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"0 0 * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"0 0 * * funday",
+	} {
+		_, err := ParseSchedule(expr)
+		assert.True(t, errors.Is(err, models.ErrInvalidSchedule), "expression %q should be invalid", expr)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	// Monday
+	from := time.Date(2024, 1, 15, 10, 30, 0, 0, moscow)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 2 * * *", time.Date(2024, 1, 16, 2, 0, 0, 0, moscow)},
+		{"*/15 * * * *", time.Date(2024, 1, 15, 10, 45, 0, 0, moscow)},
+		{"30 10 * * *", time.Date(2024, 1, 16, 10, 30, 0, 0, moscow)},
+		{"0 3 * * sun", time.Date(2024, 1, 21, 3, 0, 0, 0, moscow)},
+		{"0 3 * * 7", time.Date(2024, 1, 21, 3, 0, 0, 0, moscow)},
+		{"0 9-18/3 * * mon-fri", time.Date(2024, 1, 15, 12, 0, 0, 0, moscow)},
+		{"0 0 1 feb *", time.Date(2024, 2, 1, 0, 0, 0, 0, moscow)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, moscow)},
+		{"@weekly", time.Date(2024, 1, 21, 0, 0, 0, 0, moscow)},
+		{"@hourly", time.Date(2024, 1, 15, 11, 0, 0, 0, moscow)},
+	}
+
+	for _, tt := range tests {
+		got := mustSchedule(t, tt.expr).Next(from)
+		assert.True(t, tt.want.Equal(got), "%s: want %s, got %s", tt.expr, tt.want, got)
+	}
+}
+
+func TestScheduleNextMatchesEitherDayField(t *testing.T) {
+	// Both day fields restricted: the 1st of the month or any Friday
+	schedule := mustSchedule(t, "0 0 1 * fri")
+
+	got := schedule.Next(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC), got)
+
+	got = schedule.Next(time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), got)
+}
+
+func TestScheduleNextNeverFires(t *testing.T) {
+	got := mustSchedule(t, "0 0 30 2 *").Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, got.IsZero())
+}
+
+func TestPlanRunsOnTime(t *testing.T) {
+	schedule := mustSchedule(t, "0 2 * * *")
+	due := time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC)
+	now := due.Add(10 * time.Second)
+
+	runs, next := planRuns(schedule, models.MisfireFireOnce, due, now, time.Minute, 10)
+
+	require.Len(t, runs, 1)
+	assert.Equal(t, due, runs[0].ScheduledAt)
+	assert.Equal(t, models.TriggerSchedule, runs[0].Trigger)
+	assert.False(t, runs[0].Skip)
+	assert.Equal(t, time.Date(2024, 1, 17, 2, 0, 0, 0, time.UTC), next)
+}
+
+func TestPlanRunsMisfirePolicies(t *testing.T) {
+	schedule := mustSchedule(t, "0 2 * * *")
+	due := time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC)
+	// Down for three nights, back in the afternoon
+	now := time.Date(2024, 1, 18, 15, 0, 0, 0, time.UTC)
+
+	skipped := func(runs []plannedRun) []bool {
+		result := make([]bool, len(runs))
+		for i, run := range runs {
+			assert.Equal(t, models.TriggerMisfire, run.Trigger)
+			result[i] = run.Skip
+		}
+		return result
+	}
+
+	runs, next := planRuns(schedule, models.MisfireFireOnce, due, now, time.Minute, 10)
+	assert.Equal(t, []bool{true, true, false}, skipped(runs))
+	assert.Equal(t, time.Date(2024, 1, 18, 2, 0, 0, 0, time.UTC), runs[2].ScheduledAt)
+	assert.Equal(t, time.Date(2024, 1, 19, 2, 0, 0, 0, time.UTC), next)
+
+	runs, _ = planRuns(schedule, models.MisfireFireAll, due, now, time.Minute, 10)
+	assert.Equal(t, []bool{false, false, false}, skipped(runs))
+
+	runs, _ = planRuns(schedule, models.MisfireSkip, due, now, time.Minute, 10)
+	assert.Equal(t, []bool{true, true, true}, skipped(runs))
+}
+
+func TestPlanRunsFireOnceSkipsMisfiresWhenOnTimeRunExists(t *testing.T) {
+	schedule := mustSchedule(t, "*/5 * * * *")
+	due := time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 1, 16, 10, 10, 20, 0, time.UTC)
+
+	runs, next := planRuns(schedule, models.MisfireFireOnce, due, now, time.Minute, 10)
+
+	require.Len(t, runs, 3)
+	assert.True(t, runs[0].Skip)
+	assert.True(t, runs[1].Skip)
+	assert.Equal(t, models.TriggerSchedule, runs[2].Trigger)
+	assert.Equal(t, time.Date(2024, 1, 16, 10, 10, 0, 0, time.UTC), runs[2].ScheduledAt)
+	assert.Equal(t, time.Date(2024, 1, 16, 10, 15, 0, 0, time.UTC), next)
+}
+
+func TestPlanRunsCapsCatchup(t *testing.T) {
+	schedule := mustSchedule(t, "@hourly")
+	due := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 1, 3, 0, 30, 0, 0, time.UTC)
+
+	runs, _ := planRuns(schedule, models.MisfireFireAll, due, now, time.Minute, 5)
+
+	require.Len(t, runs, 5)
+	// Only the latest missed fire times are kept
+	assert.Equal(t, time.Date(2024, 1, 2, 20, 0, 0, 0, time.UTC), runs[0].ScheduledAt)
+	assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), runs[4].ScheduledAt)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type MetricsCollector struct {
+	runs      *prometheus.CounterVec
+	published *prometheus.CounterVec
+	lag       prometheus.Histogram
+}
+
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{
+		runs: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "scheduler_job_runs_total",
+				Help: "Total number of scheduled job runs by trigger and status",
+			},
+			[]string{"job", "trigger", "status"},
+		),
+		published: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "scheduler_messages_published_total",
+				Help: "Total number of command messages published by scheduled jobs",
+			},
+			[]string{"exchange", "routing_key"},
+		),
+		lag: promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "scheduler_run_lag_seconds",
+				Help:    "Delay between a job's fire time and its run",
+				Buckets: []float64{0.5, 1, 5, 10, 30, 60, 300, 900, 3600},
+			},
+		),
+	}
+}
+
+func (m *MetricsCollector) IncrementRuns(job, trigger, status string) {
+	m.runs.WithLabelValues(job, trigger, status).Inc()
+}
+
+func (m *MetricsCollector) AddPublished(exchange, routingKey string, count int) {
+	m.published.WithLabelValues(exchange, routingKey).Add(float64(count))
+}
+
+func (m *MetricsCollector) ObserveLag(seconds float64) {
+	m.lag.Observe(seconds)
+}
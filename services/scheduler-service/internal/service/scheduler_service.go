@@ -0,0 +1,472 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/scheduler-service/internal/models"
+	"github.com/grigta/conveer/services/scheduler-service/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const defaultRunHistoryLimit = 50
+
+// Publisher sends job payloads to RabbitMQ, satisfied by *messaging.RabbitMQ
+type Publisher interface {
+	PublishWithHeaders(exchange, routingKey string, message interface{}, headers map[string]interface{}) error
+}
+
+type SchedulerService struct {
+	jobRepo   *repository.JobRepository
+	runRepo   *repository.RunRepository
+	publisher Publisher
+	config    *models.SchedulerConfig
+	metrics   *MetricsCollector
+	logger    logger.Logger
+}
+
+func NewSchedulerService(
+	jobRepo *repository.JobRepository,
+	runRepo *repository.RunRepository,
+	publisher Publisher,
+	config *models.SchedulerConfig,
+	metrics *MetricsCollector,
+	logger logger.Logger,
+) *SchedulerService {
+	return &SchedulerService{
+		jobRepo:   jobRepo,
+		runRepo:   runRepo,
+		publisher: publisher,
+		config:    config,
+		metrics:   metrics,
+		logger:    logger,
+	}
+}
+
+func (s *SchedulerService) CreateJob(ctx context.Context, req *models.JobRequest) (*models.Job, error) {
+	job := &models.Job{}
+	if err := s.applyRequest(job, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Created scheduled job %s (%s)", job.Name, job.Schedule)
+	return job, nil
+}
+
+func (s *SchedulerService) GetJob(ctx context.Context, id string) (*models.Job, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrJobNotFound
+	}
+
+	job, err := s.jobRepo.FindByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, models.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (s *SchedulerService) ListJobs(ctx context.Context) ([]*models.Job, error) {
+	return s.jobRepo.List(ctx)
+}
+
+// UpdateJob replaces the job definition. The next fire time is recomputed from now, so fire times
+// missed under the old schedule are not caught up.
+func (s *SchedulerService) UpdateJob(ctx context.Context, id string, req *models.JobRequest) (*models.Job, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRequest(job, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Updated scheduled job %s (%s)", job.Name, job.Schedule)
+	return job, nil
+}
+
+func (s *SchedulerService) DeleteJob(ctx context.Context, id string) error {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.jobRepo.Delete(ctx, job.ID); err != nil {
+		return err
+	}
+	if err := s.runRepo.DeleteByJob(ctx, job.ID); err != nil {
+		s.logger.Warnf("Failed to delete run history of job %s: %v", job.Name, err)
+	}
+
+	s.logger.Infof("Deleted scheduled job %s", job.Name)
+	return nil
+}
+
+// PauseJob stops a job from firing until it is resumed
+func (s *SchedulerService) PauseJob(ctx context.Context, id string) (*models.Job, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.SetEnabled(ctx, job.ID, false, nil); err != nil {
+		return nil, err
+	}
+
+	job.Enabled = false
+	job.NextRunAt = nil
+	return job, nil
+}
+
+// ResumeJob enables a job from its next fire time, fire times passed while paused are not misfires
+func (s *SchedulerService) ResumeJob(ctx context.Context, id string) (*models.Job, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := s.nextRunAt(job, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.SetEnabled(ctx, job.ID, true, next); err != nil {
+		return nil, err
+	}
+
+	job.Enabled = true
+	job.NextRunAt = next
+	return job, nil
+}
+
+// TriggerJob runs a job right away, independently of its schedule and even when paused
+func (s *SchedulerService) TriggerJob(ctx context.Context, id string) (*models.JobRun, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.execute(ctx, job, time.Now(), models.TriggerManual), nil
+}
+
+func (s *SchedulerService) ListRuns(ctx context.Context, id string, limit int) ([]*models.JobRun, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > defaultRunHistoryLimit*10 {
+		limit = defaultRunHistoryLimit
+	}
+	return s.runRepo.ListByJob(ctx, job.ID, limit)
+}
+
+// Start fires due jobs every tick until ctx is done
+func (s *SchedulerService) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.TickInterval)
+	defer ticker.Stop()
+
+	s.logger.Infof("Scheduler started, checking jobs every %s", s.config.TickInterval)
+
+	for {
+		s.runDueJobs(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *SchedulerService) runDueJobs(ctx context.Context) {
+	now := time.Now()
+
+	jobs, err := s.jobRepo.ListDue(ctx, now)
+	if err != nil {
+		s.logger.Errorf("Failed to load due jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		s.runDueJob(ctx, job, now)
+	}
+}
+
+func (s *SchedulerService) runDueJob(ctx context.Context, job *models.Job, now time.Time) {
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		s.logger.Errorf("Job %s has an invalid schedule: %v", job.Name, err)
+		return
+	}
+	loc, err := time.LoadLocation(job.Timezone)
+	if err != nil {
+		s.logger.Errorf("Job %s has an invalid timezone: %v", job.Name, err)
+		return
+	}
+
+	due := *job.NextRunAt
+	runs, next := planRuns(schedule, job.MisfirePolicy, due.In(loc), now.In(loc), s.config.MisfireGrace, s.config.MaxCatchup)
+
+	var nextRunAt *time.Time
+	if !next.IsZero() {
+		nextRunAt = &next
+	}
+
+	claimed, err := s.jobRepo.ClaimNextRun(ctx, job.ID, due, nextRunAt)
+	if err != nil {
+		s.logger.Errorf("Failed to claim run of job %s: %v", job.Name, err)
+		return
+	}
+	if !claimed {
+		// Another replica took it, or the job was changed meanwhile
+		return
+	}
+
+	for _, run := range runs {
+		if run.Skip {
+			s.skip(ctx, job, run.ScheduledAt)
+			continue
+		}
+		s.execute(ctx, job, run.ScheduledAt, run.Trigger)
+	}
+
+	if nextRunAt == nil {
+		s.logger.Warnf("Job %s will not fire again, its schedule has no future fire times", job.Name)
+	}
+}
+
+// execute publishes the job payload and records the run
+func (s *SchedulerService) execute(ctx context.Context, job *models.Job, scheduledAt time.Time, trigger string) *models.JobRun {
+	run := &models.JobRun{
+		JobID:       job.ID,
+		JobName:     job.Name,
+		Trigger:     trigger,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now(),
+	}
+
+	headers := map[string]interface{}{
+		"x-scheduler-job":     job.Name,
+		"x-scheduler-trigger": trigger,
+		"x-scheduled-at":      scheduledAt.Unix(),
+	}
+
+	for i := 0; i < job.Count; i++ {
+		if err := s.publisher.PublishWithHeaders(job.Exchange, job.RoutingKey, job.Payload, headers); err != nil {
+			run.Error = err.Error()
+			break
+		}
+		run.Published++
+	}
+
+	run.FinishedAt = time.Now()
+	run.Status = models.RunStatusSucceeded
+	if run.Error != "" {
+		run.Status = models.RunStatusFailed
+		s.logger.Errorf("Job %s published %d of %d messages: %s", job.Name, run.Published, job.Count, run.Error)
+	} else {
+		s.logger.Infof("Job %s published %d messages to %s/%s", job.Name, run.Published, job.Exchange, job.RoutingKey)
+	}
+
+	s.metrics.IncrementRuns(job.Name, trigger, run.Status)
+	s.metrics.AddPublished(job.Exchange, job.RoutingKey, run.Published)
+	if trigger != models.TriggerManual {
+		s.metrics.ObserveLag(run.StartedAt.Sub(scheduledAt).Seconds())
+	}
+
+	s.record(ctx, job, run)
+	return run
+}
+
+// skip records a missed fire time that the misfire policy drops
+func (s *SchedulerService) skip(ctx context.Context, job *models.Job, scheduledAt time.Time) {
+	now := time.Now()
+	run := &models.JobRun{
+		JobID:       job.ID,
+		JobName:     job.Name,
+		Trigger:     models.TriggerMisfire,
+		Status:      models.RunStatusSkipped,
+		ScheduledAt: scheduledAt,
+		StartedAt:   now,
+		FinishedAt:  now,
+		Error:       fmt.Sprintf("missed fire time, misfire policy %s", job.MisfirePolicy),
+	}
+
+	s.metrics.IncrementRuns(job.Name, run.Trigger, run.Status)
+	s.record(ctx, job, run)
+}
+
+func (s *SchedulerService) record(ctx context.Context, job *models.Job, run *models.JobRun) {
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		s.logger.Errorf("Failed to save run of job %s: %v", job.Name, err)
+	}
+	if err := s.jobRepo.RecordRun(ctx, job.ID, run.StartedAt, run.Status); err != nil {
+		s.logger.Errorf("Failed to update last run of job %s: %v", job.Name, err)
+	}
+}
+
+// applyRequest validates the request and copies it onto job, computing the next fire time
+func (s *SchedulerService) applyRequest(job *models.Job, req *models.JobRequest) error {
+	if req.Name == "" {
+		return models.ErrJobNameRequired
+	}
+	if _, err := ParseSchedule(req.Schedule); err != nil {
+		return err
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = s.config.DefaultTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("%w: %s", models.ErrInvalidTimezone, timezone)
+	}
+
+	if !s.config.ExchangeAllowed(req.Exchange) {
+		return fmt.Errorf("%w: %s", models.ErrExchangeNotAllowed, req.Exchange)
+	}
+
+	payload := req.Payload
+	if len(payload) == 0 {
+		payload = json.RawMessage("{}")
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(payload, &object); err != nil || object == nil {
+		return models.ErrInvalidPayload
+	}
+
+	count := req.Count
+	if count == 0 {
+		count = 1
+	}
+	if count < 1 || count > models.MaxMessagesPerRun {
+		return models.ErrInvalidMessageCount
+	}
+
+	policy := req.MisfirePolicy
+	if policy == "" {
+		policy = models.MisfireFireOnce
+	}
+	if !models.ValidMisfirePolicy(policy) {
+		return fmt.Errorf("%w: %s", models.ErrInvalidMisfire, policy)
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	job.Name = req.Name
+	job.Description = req.Description
+	job.Schedule = req.Schedule
+	job.Timezone = timezone
+	job.Exchange = req.Exchange
+	job.RoutingKey = req.RoutingKey
+	job.Payload = payload
+	job.Count = count
+	job.MisfirePolicy = policy
+	job.Enabled = enabled
+	job.NextRunAt = nil
+
+	if enabled {
+		next, err := s.nextRunAt(job, time.Now())
+		if err != nil {
+			return err
+		}
+		job.NextRunAt = next
+	}
+
+	return nil
+}
+
+func (s *SchedulerService) nextRunAt(job *models.Job, now time.Time) (*time.Time, error) {
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(job.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidTimezone, job.Timezone)
+	}
+
+	next := schedule.Next(now.In(loc))
+	if next.IsZero() {
+		return nil, fmt.Errorf("%w: schedule never fires", models.ErrInvalidSchedule)
+	}
+	return &next, nil
+}
+
+type plannedRun struct {
+	ScheduledAt time.Time
+	Trigger     string
+	Skip        bool
+}
+
+// planRuns decides what to do for a job whose fire time due has come: which fire times up to now
+// run, which are recorded as skipped, and the next fire time after now. A fire time later than
+// grace is a misfire handled by policy. At most maxCatchup misfires are kept, older ones are
+// dropped without a record.
+func planRuns(schedule *Schedule, policy models.MisfirePolicy, due, now time.Time, grace time.Duration, maxCatchup int) ([]plannedRun, time.Time) {
+	if maxCatchup < 1 {
+		maxCatchup = 1
+	}
+
+	fireTimes := []time.Time{due}
+	for t := schedule.Next(due); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		fireTimes = append(fireTimes, t)
+		// Keep the on-time candidate plus maxCatchup misfires
+		if len(fireTimes) > maxCatchup+1 {
+			fireTimes = fireTimes[1:]
+		}
+	}
+
+	missed := fireTimes
+	var onTime *time.Time
+	if last := fireTimes[len(fireTimes)-1]; now.Sub(last) <= grace {
+		onTime = &last
+		missed = fireTimes[:len(fireTimes)-1]
+	}
+	if len(missed) > maxCatchup {
+		missed = missed[len(missed)-maxCatchup:]
+	}
+
+	var runs []plannedRun
+	for i, t := range missed {
+		run := plannedRun{ScheduledAt: t, Trigger: models.TriggerMisfire}
+		switch policy {
+		case models.MisfireFireAll:
+		case models.MisfireSkip:
+			run.Skip = true
+		default:
+			// fire_once: only the latest missed fire time runs, and only if nothing runs on time
+			run.Skip = onTime != nil || i < len(missed)-1
+		}
+		runs = append(runs, run)
+	}
+	if onTime != nil {
+		runs = append(runs, plannedRun{ScheduledAt: *onTime, Trigger: models.TriggerSchedule})
+	}
+
+	return runs, schedule.Next(now)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,8 +11,15 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	// Restock schedules use provider time zones, and the alpine image ships without zoneinfo
+	_ "time/tzdata"
 
+	"github.com/grigta/conveer/pkg/health"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/serviceauth"
 	"github.com/grigta/conveer/services/sms-service/internal/handlers"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
 	"github.com/grigta/conveer/services/sms-service/internal/repository"
 	"github.com/grigta/conveer/services/sms-service/internal/service"
 	pb "github.com/grigta/conveer/services/sms-service/proto"
@@ -19,7 +27,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/streadway/amqp"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -29,8 +36,7 @@ import (
 )
 
 func main() {
-	logger := logrus.New()
-	logger.SetFormatter(&logrus.JSONFormatter{})
+	log := logger.FromEnv("sms-service")
 
 	// Load configuration
 	viper.SetConfigName("config")
@@ -50,7 +56,7 @@ func main() {
 	_ = viper.BindEnv("redis.db", "REDIS_DB")
 
 	if err := viper.ReadInConfig(); err != nil {
-		logger.Warnf("Config file not found, using env variables: %v", err)
+		log.Warnf("Config file not found, using env variables: %v", err)
 	}
 
 	// Set defaults
@@ -65,12 +71,36 @@ func main() {
 	viper.SetDefault("sms.max_retry_attempts", 3)
 	viper.SetDefault("sms.code_wait_timeout", "5m")
 	viper.SetDefault("sms.activation_expiry", "30m")
+	viper.SetDefault("sms.idempotency_ttl", "24h")
+	viper.SetDefault("sms.price_refresh_interval", "10m")
+	viper.SetDefault("sms.routing.min_available", 1)
+	viper.SetDefault("sms.routing.min_success_rate", 0.5)
+	viper.SetDefault("sms.routing.min_samples", 20)
+	viper.SetDefault("sms.routing.success_window", "24h")
+	viper.SetDefault("sms.health.window", "24h")
+	viper.SetDefault("sms.health.min_samples", 20)
+	viper.SetDefault("sms.health.quarantine_threshold", 40)
+	viper.SetDefault("sms.health.quarantine_duration", "1h")
+	viper.SetDefault("sms.health.target_delivery", "1m")
+	viper.SetDefault("sms.health.refresh_interval", "5m")
+	viper.SetDefault("sms.restock.lead", "45m")
+	viper.SetDefault("sms.restock.settle", "5m")
+	viper.SetDefault("sms.restock.max_deferral", "6h")
+	viper.SetDefault("sms.restock.low_stock", 10)
+	viper.SetDefault("sms.rental.default_hours", 168)
+	viper.SetDefault("sms.rental.max_hours", 720)
+	viper.SetDefault("sms.rental.alert_before", "24h")
+	viper.SetDefault("sms.rental.poll_every", "2m")
+	viper.SetDefault("sms.rental.poll_batch", 50)
+	viper.SetDefault("sms.restock.schedules", []map[string]interface{}{
+		{"provider": "smsactivate", "timezone": "Europe/Moscow", "times": []string{"00:00", "12:00"}},
+	})
 
 	// Initialize MongoDB
 	ctx := context.Background()
 	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(viper.GetString("mongodb.uri")))
 	if err != nil {
-		logger.Fatalf("Failed to connect to MongoDB: %v", err)
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
 	defer mongoClient.Disconnect(ctx)
 
@@ -84,77 +114,180 @@ func main() {
 	})
 
 	if _, err := redisClient.Ping(ctx).Result(); err != nil {
-		logger.Fatalf("Failed to connect to Redis: %v", err)
+		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
 	defer redisClient.Close()
 
 	// Initialize RabbitMQ
 	rabbitConn, err := amqp.Dial(viper.GetString("rabbitmq.uri"))
 	if err != nil {
-		logger.Fatalf("Failed to connect to RabbitMQ: %v", err)
+		log.Fatalf("Failed to connect to RabbitMQ: %v", err)
 	}
 	defer rabbitConn.Close()
 
 	rabbitChannel, err := rabbitConn.Channel()
 	if err != nil {
-		logger.Fatalf("Failed to open RabbitMQ channel: %v", err)
+		log.Fatalf("Failed to open RabbitMQ channel: %v", err)
 	}
 	defer rabbitChannel.Close()
 
 	// Setup RabbitMQ topology
 	if err := setupRabbitMQTopology(rabbitChannel); err != nil {
-		logger.Fatalf("Failed to setup RabbitMQ topology: %v", err)
+		log.Fatalf("Failed to setup RabbitMQ topology: %v", err)
 	}
 
 	// Initialize repositories
-	phoneRepo := repository.NewPhoneRepository(database, logger)
-	activationRepo := repository.NewActivationRepository(database, logger)
+	phoneRepo := repository.NewPhoneRepository(database, log)
+	activationRepo := repository.NewActivationRepository(database, log)
+	scheduledPurchaseRepo := repository.NewScheduledPurchaseRepository(database, log)
+	rentalRepo := repository.NewRentalRepository(database, log)
+
+	if err := phoneRepo.CreateIndex(ctx); err != nil {
+		log.Warnf("Failed to create phone indexes: %v", err)
+	}
+	if err := scheduledPurchaseRepo.CreateIndex(ctx); err != nil {
+		log.Warnf("Failed to create scheduled purchase indexes: %v", err)
+	}
+	if err := rentalRepo.CreateIndex(ctx); err != nil {
+		log.Warnf("Failed to create rental indexes: %v", err)
+	}
 
 	// Initialize services
-	providerAdapter := service.NewProviderAdapter(logger)
+	providerAdapter := service.NewProviderAdapter(log)
 	smsActivateClient := service.NewSMSActivateClient(
 		viper.GetString("SMS_ACTIVATE_API_KEY"),
-		logger,
+		log,
 	)
 
-	cacheService := service.NewCacheService(redisClient, logger)
-	retryManager := service.NewRetryManager(rabbitChannel, logger)
+	cacheService := service.NewCacheService(redisClient, log)
+	retryManager := service.NewRetryManager(rabbitChannel, log)
+	eventPublisher := service.NewEventPublisher(rabbitChannel)
 	metricsCollector := service.NewMetricsCollector()
 
+	priceCatalog := service.NewPriceCatalog(
+		activationRepo,
+		cacheService,
+		metricsCollector,
+		service.RoutingConstraints{
+			MinAvailable:   viper.GetInt("sms.routing.min_available"),
+			MinSuccessRate: viper.GetFloat64("sms.routing.min_success_rate"),
+			MinSamples:     viper.GetInt("sms.routing.min_samples"),
+			SuccessWindow:  viper.GetDuration("sms.routing.success_window"),
+		},
+		viper.GetDuration("sms.price_refresh_interval"),
+		log,
+	)
+	priceCatalog.RegisterProvider("smsactivate", smsActivateClient.GetPrices)
+
+	restockSchedules, err := loadRestockSchedules()
+	if err != nil {
+		log.Fatalf("Invalid restock schedules: %v", err)
+	}
+	priceCatalog.SetRestockSchedules(restockSchedules)
+
+	codeValidator := service.NewCodeValidator()
+
 	smsService := service.NewSMSService(
 		phoneRepo,
 		activationRepo,
 		providerAdapter,
 		smsActivateClient,
+		priceCatalog,
 		cacheService,
 		retryManager,
+		codeValidator,
+		eventPublisher,
+		metricsCollector,
+		log,
+	)
+	smsService.SetIdempotencyTTL(viper.GetDuration("sms.idempotency_ttl"))
+
+	providerHealth := service.NewProviderHealthTracker(
+		activationRepo.GetProviderOutcomes,
+		metricsCollector,
+		service.HealthConfig{
+			Window:              viper.GetDuration("sms.health.window"),
+			MinSamples:          viper.GetInt("sms.health.min_samples"),
+			QuarantineThreshold: viper.GetFloat64("sms.health.quarantine_threshold"),
+			QuarantineDuration:  viper.GetDuration("sms.health.quarantine_duration"),
+			TargetDelivery:      viper.GetDuration("sms.health.target_delivery"),
+			RefreshInterval:     viper.GetDuration("sms.health.refresh_interval"),
+		},
+		log,
+	)
+	smsService.SetProviderHealth(providerHealth)
+
+	purchaseScheduler := service.NewPurchaseScheduler(
+		smsService,
+		scheduledPurchaseRepo,
+		priceCatalog,
+		rabbitChannel,
+		metricsCollector,
+		service.DeferralPolicy{
+			Lead:        viper.GetDuration("sms.restock.lead"),
+			Settle:      viper.GetDuration("sms.restock.settle"),
+			MaxDeferral: viper.GetDuration("sms.restock.max_deferral"),
+			LowStock:    viper.GetInt("sms.restock.low_stock"),
+		},
+		log,
+	)
+
+	rentalManager := service.NewRentalManager(
+		rentalRepo,
+		smsActivateClient,
+		codeValidator,
+		eventPublisher,
 		metricsCollector,
-		logger,
+		service.RentalPolicy{
+			DefaultHours: viper.GetInt("sms.rental.default_hours"),
+			MaxHours:     viper.GetInt("sms.rental.max_hours"),
+			AlertBefore:  viper.GetDuration("sms.rental.alert_before"),
+			PollEvery:    viper.GetDuration("sms.rental.poll_every"),
+			PollBatch:    viper.GetInt64("sms.rental.poll_batch"),
+		},
+		log,
 	)
 
 	// Start background workers
 	go retryManager.StartWorker(ctx, smsService)
+	go purchaseScheduler.StartWorker(ctx)
+	go rentalManager.StartWorker(ctx)
 	go smsService.StartCodePoller(ctx)
+	go priceCatalog.Start(ctx)
+	go providerHealth.Start(ctx)
 
 	// Initialize handlers
-	grpcHandler := handlers.NewGRPCHandler(smsService, logger)
-	httpHandler := handlers.NewHTTPHandler(smsService, logger)
+	grpcHandler := handlers.NewGRPCHandler(smsService, purchaseScheduler, rentalManager, log)
+	httpHandler := handlers.NewHTTPHandler(smsService, purchaseScheduler, rentalManager, log)
+
+	// Readiness gates for gRPC health and /health
+	healthChecker := health.NewChecker("sms-service")
+	healthChecker.Add("mongodb", health.MongoCheck(mongoClient))
+	healthChecker.Add("redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
+	healthChecker.Add("rabbitmq", health.AMQPCheck(rabbitConn))
+
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	go healthChecker.Run(healthCtx, 10*time.Second)
 
 	// Start gRPC server
 	grpcPort := viper.GetString("grpc.port")
 	lis, err := net.Listen("tcp", ":"+grpcPort)
 	if err != nil {
-		logger.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+		log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
 	}
 
-	grpcServer := grpc.NewServer()
+	serviceAuth := serviceauth.FromEnv("sms-service")
+	grpcServer := grpc.NewServer(append(logger.ServerOptions(), serviceAuth.ServerOptions()...)...)
 	pb.RegisterSMSServiceServer(grpcServer, grpcHandler)
+	healthChecker.Register(grpcServer)
 	reflection.Register(grpcServer)
 
 	go func() {
-		logger.Infof("Starting gRPC server on port %s", grpcPort)
+		log.Infof("Starting gRPC server on port %s", grpcPort)
 		if err := grpcServer.Serve(lis); err != nil {
-			logger.Fatalf("Failed to serve gRPC: %v", err)
+			log.Fatalf("Failed to serve gRPC: %v", err)
 		}
 	}()
 
@@ -167,17 +300,31 @@ func main() {
 	}))
 
 	// Register HTTP routes
-	router.GET("/health", httpHandler.Health)
+	router.GET("/health", healthChecker.Handler())
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	api := router.Group("/api/v1")
 	{
 		api.POST("/purchase", httpHandler.PurchaseNumber)
+		api.POST("/purchase/batch", httpHandler.PurchaseNumbers)
+		api.POST("/purchase/scheduled", httpHandler.SchedulePurchase)
+		api.GET("/purchase/scheduled/:purchase_id", httpHandler.GetScheduledPurchase)
+		api.GET("/deferrals/statistics", httpHandler.GetDeferralStats)
+		api.POST("/batches/:batch_id/claim", httpHandler.ClaimBatchNumber)
 		api.GET("/code/:activation_id", httpHandler.GetSMSCode)
 		api.POST("/cancel/:activation_id", httpHandler.CancelActivation)
 		api.GET("/status/:activation_id", httpHandler.GetActivationStatus)
 		api.GET("/statistics", httpHandler.GetStatistics)
 		api.GET("/balance", httpHandler.GetProviderBalance)
+		api.GET("/prices", httpHandler.GetPrices)
+		api.GET("/providers/health", httpHandler.GetProviderHealth)
+		api.POST("/rentals", httpHandler.RentNumber)
+		api.GET("/rentals", httpHandler.ListRentals)
+		api.GET("/rentals/:rental_id", httpHandler.GetRental)
+		api.GET("/rentals/:rental_id/messages", httpHandler.GetRentalMessages)
+		api.POST("/rentals/:rental_id/renew", httpHandler.RenewRental)
+		api.POST("/rentals/:rental_id/release", httpHandler.ReleaseRental)
+		api.POST("/rentals/:rental_id/assign", httpHandler.AssignRental)
 	}
 
 	httpPort := viper.GetString("http.port")
@@ -187,9 +334,9 @@ func main() {
 	}
 
 	go func() {
-		logger.Infof("Starting HTTP server on port %s", httpPort)
+		log.Infof("Starting HTTP server on port %s", httpPort)
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Fatalf("Failed to start HTTP server: %v", err)
+			log.Fatalf("Failed to start HTTP server: %v", err)
 		}
 	}()
 
@@ -198,7 +345,10 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	logger.Info("Shutting down servers...")
+	log.Info("Shutting down servers...")
+
+	// Report NOT_SERVING so callers stop routing here before the servers stop
+	stopHealth()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -207,10 +357,31 @@ func main() {
 	grpcServer.GracefulStop()
 
 	if err := httpServer.Shutdown(ctx); err != nil {
-		logger.Errorf("HTTP server forced to shutdown: %v", err)
+		log.Errorf("HTTP server forced to shutdown: %v", err)
 	}
 
-	logger.Info("Servers exited")
+	log.Info("Servers exited")
+}
+
+// loadRestockSchedules reads provider restock schedules from the config file, or from
+// SMS_RESTOCK_SCHEDULES as a JSON list
+func loadRestockSchedules() ([]models.RestockSchedule, error) {
+	var schedules []models.RestockSchedule
+	if raw, ok := viper.Get("sms.restock.schedules").(string); ok {
+		if err := json.Unmarshal([]byte(raw), &schedules); err != nil {
+			return nil, fmt.Errorf("failed to parse restock schedules: %w", err)
+		}
+	} else if err := viper.UnmarshalKey("sms.restock.schedules", &schedules); err != nil {
+		return nil, fmt.Errorf("failed to decode restock schedules: %w", err)
+	}
+
+	for _, schedule := range schedules {
+		if err := schedule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return schedules, nil
 }
 
 func setupRabbitMQTopology(ch *amqp.Channel) error {
@@ -240,7 +411,7 @@ func setupRabbitMQTopology(ch *amqp.Channel) error {
 	}
 
 	// Declare queues
-	queues := []string{"sms.purchase", "sms.get_code", "sms.cancel", "sms.retry"}
+	queues := []string{"sms.purchase", "sms.get_code", "sms.cancel", "sms.scheduled_purchase"}
 	for _, queueName := range queues {
 		if _, err := ch.QueueDeclare(
 			queueName, // name
@@ -254,6 +425,18 @@ func setupRabbitMQTopology(ch *amqp.Channel) error {
 		}
 	}
 
+	// Retries of activations close to expiry jump the queue
+	if _, err := ch.QueueDeclare(
+		"sms.retry",
+		true,
+		false,
+		false,
+		false,
+		amqp.Table{"x-max-priority": int32(messaging.MaxPriority)},
+	); err != nil {
+		return fmt.Errorf("failed to declare queue sms.retry: %w", err)
+	}
+
 	// Bind queues to exchanges
 	bindings := []struct {
 		queue    string
@@ -264,6 +447,7 @@ func setupRabbitMQTopology(ch *amqp.Channel) error {
 		{"sms.get_code", "sms.commands", "get_code"},
 		{"sms.cancel", "sms.commands", "cancel"},
 		{"sms.retry", "sms.commands", "retry"},
+		{"sms.scheduled_purchase", "sms.commands", "scheduled_purchase"},
 	}
 
 	for _, binding := range bindings {
@@ -2,30 +2,37 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
 	"github.com/grigta/conveer/services/sms-service/internal/service"
 	pb "github.com/grigta/conveer/services/sms-service/proto"
 
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type GRPCHandler struct {
 	pb.UnimplementedSMSServiceServer
-	smsService *service.SMSService
-	logger     *logrus.Logger
+	smsService        *service.SMSService
+	purchaseScheduler *service.PurchaseScheduler
+	rentalManager     *service.RentalManager
+	logger            logger.Logger
 }
 
-func NewGRPCHandler(smsService *service.SMSService, logger *logrus.Logger) *GRPCHandler {
+func NewGRPCHandler(smsService *service.SMSService, purchaseScheduler *service.PurchaseScheduler, rentalManager *service.RentalManager, logger logger.Logger) *GRPCHandler {
 	return &GRPCHandler{
-		smsService: smsService,
-		logger:     logger,
+		smsService:        smsService,
+		purchaseScheduler: purchaseScheduler,
+		rentalManager:     rentalManager,
+		logger:            logger,
 	}
 }
 
 func (h *GRPCHandler) PurchaseNumber(ctx context.Context, req *pb.PurchaseNumberRequest) (*pb.PurchaseNumberResponse, error) {
-	activation, err := h.smsService.PurchaseNumber(
+	activation, deduplicated, err := h.smsService.PurchaseNumber(
 		ctx,
 		req.UserId,
 		req.Service,
@@ -33,13 +40,77 @@ func (h *GRPCHandler) PurchaseNumber(ctx context.Context, req *pb.PurchaseNumber
 		req.Operator,
 		req.Provider,
 		req.MaxPrice,
+		req.IdempotencyKey,
 	)
 
+	if errors.Is(err, service.ErrPurchaseInProgress) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, service.ErrIdempotencyKeyConflict) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		h.logger.Errorf("Failed to purchase number: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to purchase number: %v", err)
 	}
 
+	resp := toPurchaseResponse(activation)
+	resp.Deduplicated = deduplicated
+	return resp, nil
+}
+
+func (h *GRPCHandler) PurchaseNumbers(ctx context.Context, req *pb.PurchaseNumbersRequest) (*pb.PurchaseNumbersResponse, error) {
+	result, err := h.smsService.PurchaseNumbers(
+		ctx,
+		req.UserId,
+		req.Service,
+		req.Country,
+		req.Operator,
+		int(req.Quantity),
+		req.MaxPrice,
+	)
+	if errors.Is(err, service.ErrInvalidQuantity) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		h.logger.Errorf("Failed to purchase numbers: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to purchase numbers: %v", err)
+	}
+
+	resp := &pb.PurchaseNumbersResponse{
+		BatchId:   result.BatchID,
+		Requested: int32(result.Requested),
+		Fulfilled: int32(result.Fulfilled),
+	}
+	for _, activation := range result.Activations {
+		resp.Numbers = append(resp.Numbers, toPurchaseResponse(activation))
+	}
+	for _, failure := range result.Failures {
+		resp.Failures = append(resp.Failures, &pb.PurchaseFailure{
+			Index:    int32(failure.Index),
+			Provider: failure.Provider,
+			Code:     failure.Code,
+			Error:    failure.Error,
+		})
+	}
+
+	return resp, nil
+}
+
+func (h *GRPCHandler) ClaimBatchNumber(ctx context.Context, req *pb.ClaimBatchNumberRequest) (*pb.PurchaseNumberResponse, error) {
+	activation, err := h.smsService.ClaimBatchNumber(ctx, req.BatchId, req.UserId)
+	if errors.Is(err, service.ErrBatchExhausted) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		h.logger.Errorf("Failed to claim number from batch %s: %v", req.BatchId, err)
+		return nil, status.Errorf(codes.Internal, "failed to claim batch number: %v", err)
+	}
+
+	return toPurchaseResponse(activation), nil
+}
+
+func toPurchaseResponse(activation *models.Activation) *pb.PurchaseNumberResponse {
 	return &pb.PurchaseNumberResponse{
 		ActivationId: activation.ActivationID,
 		PhoneNumber:  activation.PhoneNumber,
@@ -47,5 +118,274 @@ func (h *GRPCHandler) PurchaseNumber(ctx context.Context, req *pb.PurchaseNumber
 		Price:        float32(activation.Price),
 		Provider:     activation.Provider,
 		ExpiresAt:    activation.ExpiresAt.Unix(),
+	}
+}
+
+func (h *GRPCHandler) GetPrices(ctx context.Context, req *pb.GetPricesRequest) (*pb.GetPricesResponse, error) {
+	prices, err := h.smsService.GetPrices(ctx, req.Service, req.Country, req.Provider)
+	if err != nil {
+		h.logger.Errorf("Failed to get prices: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "failed to get prices: %v", err)
+	}
+
+	entries := make([]*pb.PriceEntry, 0, len(prices))
+	for _, price := range prices {
+		entry := &pb.PriceEntry{
+			Provider:    price.Provider,
+			Service:     price.Service,
+			Country:     price.Country,
+			Price:       float32(price.Price),
+			Currency:    price.Currency,
+			Available:   int32(price.Available),
+			SuccessRate: float32(price.SuccessRate),
+			UpdatedAt:   price.UpdatedAt.Unix(),
+		}
+		if price.NextRestock != nil {
+			entry.NextRestockAt = price.NextRestock.Unix()
+		}
+		entries = append(entries, entry)
+	}
+
+	return &pb.GetPricesResponse{Prices: entries}, nil
+}
+
+func (h *GRPCHandler) SchedulePurchase(ctx context.Context, req *pb.SchedulePurchaseRequest) (*pb.SchedulePurchaseResponse, error) {
+	scheduleReq := models.ScheduledPurchaseRequest{
+		UserID:   req.UserId,
+		Service:  req.Service,
+		Country:  req.Country,
+		Operator: req.Operator,
+		Provider: req.Provider,
+		MaxPrice: req.MaxPrice,
+		Urgent:   req.Urgent,
+	}
+	if req.NotAfter > 0 {
+		scheduleReq.NotAfter = time.Unix(req.NotAfter, 0)
+	}
+
+	purchase, activation, err := h.purchaseScheduler.SchedulePurchase(ctx, scheduleReq)
+	if errors.Is(err, service.ErrInvalidDeadline) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		h.logger.Errorf("Failed to schedule purchase: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to schedule purchase: %v", err)
+	}
+
+	resp := &pb.SchedulePurchaseResponse{Purchase: toScheduledPurchase(purchase)}
+	if activation != nil {
+		resp.Number = toPurchaseResponse(activation)
+	}
+
+	return resp, nil
+}
+
+func (h *GRPCHandler) GetScheduledPurchase(ctx context.Context, req *pb.GetScheduledPurchaseRequest) (*pb.ScheduledPurchase, error) {
+	purchase, err := h.purchaseScheduler.GetScheduledPurchase(ctx, req.PurchaseId, req.UserId)
+	if errors.Is(err, service.ErrScheduledPurchaseNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		h.logger.Errorf("Failed to get scheduled purchase %s: %v", req.PurchaseId, err)
+		return nil, status.Errorf(codes.Internal, "failed to get scheduled purchase: %v", err)
+	}
+
+	return toScheduledPurchase(purchase), nil
+}
+
+func (h *GRPCHandler) GetDeferralStats(ctx context.Context, req *pb.GetDeferralStatsRequest) (*pb.GetDeferralStatsResponse, error) {
+	var from, to time.Time
+	if req.FromDate > 0 {
+		from = time.Unix(req.FromDate, 0)
+	}
+	if req.ToDate > 0 {
+		to = time.Unix(req.ToDate, 0)
+	}
+
+	stats, err := h.purchaseScheduler.GetDeferralStats(ctx, from, to)
+	if err != nil {
+		h.logger.Errorf("Failed to get deferral statistics: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get deferral statistics: %v", err)
+	}
+
+	return &pb.GetDeferralStatsResponse{
+		Total:              stats.Total,
+		Deferred:           stats.Deferred,
+		Pending:            stats.Pending,
+		FulfilledDeferred:  stats.FulfilledDeferred,
+		FailedDeferred:     stats.FailedDeferred,
+		AvgDeferralSeconds: stats.AvgDeferralSeconds,
+		ByReason:           stats.ByReason,
+		ByProvider:         stats.ByProvider,
 	}, nil
 }
+
+func (h *GRPCHandler) GetProviderHealth(ctx context.Context, req *pb.GetProviderHealthRequest) (*pb.GetProviderHealthResponse, error) {
+	health := h.smsService.GetProviderHealth()
+
+	providers := make([]*pb.ProviderHealth, 0, len(health))
+	for _, provider := range health {
+		item := &pb.ProviderHealth{
+			Provider:           provider.Provider,
+			Samples:            int32(provider.Samples),
+			SuccessRate:        provider.SuccessRate,
+			CancellationRate:   provider.CancellationRate,
+			AvgDeliverySeconds: provider.AvgDeliverySeconds,
+			Score:              provider.Score,
+			Quarantined:        provider.Quarantined,
+			UpdatedAt:          provider.UpdatedAt.Unix(),
+		}
+		if provider.QuarantinedUntil != nil {
+			item.QuarantinedUntil = provider.QuarantinedUntil.Unix()
+		}
+		providers = append(providers, item)
+	}
+
+	return &pb.GetProviderHealthResponse{Providers: providers}, nil
+}
+
+func toScheduledPurchase(purchase *models.ScheduledPurchase) *pb.ScheduledPurchase {
+	return &pb.ScheduledPurchase{
+		PurchaseId:      purchase.PurchaseID,
+		UserId:          purchase.UserID,
+		Service:         purchase.Service,
+		Country:         purchase.Country,
+		Provider:        purchase.Provider,
+		RestockProvider: purchase.RestockProvider,
+		Status:          string(purchase.Status),
+		Deferred:        purchase.Deferred,
+		Reason:          purchase.Reason,
+		ExecuteAt:       purchase.ExecuteAt.Unix(),
+		ActivationId:    purchase.ActivationID,
+		Error:           purchase.Error,
+		CreatedAt:       purchase.CreatedAt.Unix(),
+	}
+}
+
+func (h *GRPCHandler) RentNumber(ctx context.Context, req *pb.RentNumberRequest) (*pb.RentNumberResponse, error) {
+	rental, reused, err := h.rentalManager.RentNumber(ctx, models.RentalRequest{
+		UserID:    req.UserId,
+		AccountID: req.AccountId,
+		Service:   req.Service,
+		Country:   req.Country,
+		Operator:  req.Operator,
+		Provider:  req.Provider,
+		Hours:     int(req.Hours),
+	})
+	if err != nil {
+		return nil, rentalStatusError(h.logger, "rent number", err)
+	}
+
+	return &pb.RentNumberResponse{Rental: toRental(rental), Reused: reused}, nil
+}
+
+func (h *GRPCHandler) RenewRental(ctx context.Context, req *pb.RenewRentalRequest) (*pb.Rental, error) {
+	rental, err := h.rentalManager.RenewRental(ctx, req.RentalId, req.UserId, int(req.Hours))
+	if err != nil {
+		return nil, rentalStatusError(h.logger, "renew rental", err)
+	}
+	return toRental(rental), nil
+}
+
+func (h *GRPCHandler) ReleaseRental(ctx context.Context, req *pb.ReleaseRentalRequest) (*pb.Rental, error) {
+	rental, err := h.rentalManager.ReleaseRental(ctx, req.RentalId, req.UserId)
+	if err != nil {
+		return nil, rentalStatusError(h.logger, "release rental", err)
+	}
+	return toRental(rental), nil
+}
+
+func (h *GRPCHandler) AssignRental(ctx context.Context, req *pb.AssignRentalRequest) (*pb.Rental, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	rental, err := h.rentalManager.AssignRental(ctx, req.RentalId, req.UserId, req.AccountId)
+	if err != nil {
+		return nil, rentalStatusError(h.logger, "assign rental", err)
+	}
+	return toRental(rental), nil
+}
+
+func (h *GRPCHandler) GetRental(ctx context.Context, req *pb.GetRentalRequest) (*pb.Rental, error) {
+	rental, err := h.rentalManager.GetRental(ctx, req.RentalId, req.UserId)
+	if err != nil {
+		return nil, rentalStatusError(h.logger, "get rental", err)
+	}
+	return toRental(rental), nil
+}
+
+func (h *GRPCHandler) ListRentals(ctx context.Context, req *pb.ListRentalsRequest) (*pb.ListRentalsResponse, error) {
+	rentals, err := h.rentalManager.ListRentals(ctx, req.UserId, req.AccountId, models.RentalStatus(req.Status))
+	if err != nil {
+		return nil, rentalStatusError(h.logger, "list rentals", err)
+	}
+
+	resp := &pb.ListRentalsResponse{Rentals: make([]*pb.Rental, 0, len(rentals))}
+	for _, rental := range rentals {
+		resp.Rentals = append(resp.Rentals, toRental(rental))
+	}
+	return resp, nil
+}
+
+func (h *GRPCHandler) GetRentalMessages(ctx context.Context, req *pb.GetRentalMessagesRequest) (*pb.GetRentalMessagesResponse, error) {
+	var since time.Time
+	if req.Since > 0 {
+		since = time.Unix(req.Since, 0)
+	}
+
+	messages, err := h.rentalManager.GetRentalMessages(ctx, req.RentalId, req.UserId, since)
+	if err != nil {
+		return nil, rentalStatusError(h.logger, "get rental messages", err)
+	}
+
+	resp := &pb.GetRentalMessagesResponse{Messages: make([]*pb.RentalMessage, 0, len(messages))}
+	for _, message := range messages {
+		resp.Messages = append(resp.Messages, &pb.RentalMessage{
+			Sender:     message.Sender,
+			Text:       message.Text,
+			Code:       message.Code,
+			Anomalies:  message.Anomalies,
+			ReceivedAt: message.ReceivedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// rentalStatusError maps rental errors to gRPC codes
+func rentalStatusError(logger logger.Logger, operation string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrRentalNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrRentalNotActive):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidRentTime):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	logger.Errorf("Failed to %s: %v", operation, err)
+	return status.Errorf(codes.Internal, "failed to %s: %v", operation, err)
+}
+
+func toRental(rental *models.Rental) *pb.Rental {
+	resp := &pb.Rental{
+		RentalId:     rental.RentalID,
+		UserId:       rental.UserID,
+		AccountId:    rental.AccountID,
+		PhoneNumber:  rental.PhoneNumber,
+		Service:      rental.Service,
+		Country:      rental.Country,
+		Provider:     rental.Provider,
+		Status:       string(rental.Status),
+		Hours:        int32(rental.Hours),
+		Price:        float32(rental.Price),
+		Renewals:     int32(rental.Renewals),
+		MessageCount: int32(rental.MessageCount),
+		StartedAt:    rental.StartedAt.Unix(),
+		ExpiresAt:    rental.ExpiresAt.Unix(),
+	}
+	if rental.EndedAt != nil {
+		resp.EndedAt = rental.EndedAt.Unix()
+	}
+	return resp
+}
@@ -1,35 +1,34 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
 	"github.com/grigta/conveer/services/sms-service/internal/service"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
 )
 
 type HTTPHandler struct {
-	smsService *service.SMSService
-	logger     *logrus.Logger
+	smsService        *service.SMSService
+	purchaseScheduler *service.PurchaseScheduler
+	rentalManager     *service.RentalManager
+	logger            logger.Logger
 }
 
-func NewHTTPHandler(smsService *service.SMSService, logger *logrus.Logger) *HTTPHandler {
+func NewHTTPHandler(smsService *service.SMSService, purchaseScheduler *service.PurchaseScheduler, rentalManager *service.RentalManager, logger logger.Logger) *HTTPHandler {
 	return &HTTPHandler{
-		smsService: smsService,
-		logger:     logger,
+		smsService:        smsService,
+		purchaseScheduler: purchaseScheduler,
+		rentalManager:     rentalManager,
+		logger:            logger,
 	}
 }
 
-func (h *HTTPHandler) Health(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status": "healthy",
-		"time":   time.Now().Unix(),
-	})
-}
-
 func (h *HTTPHandler) PurchaseNumber(c *gin.Context) {
 	var req struct {
 		UserID   string `json:"user_id" binding:"required"`
@@ -45,7 +44,7 @@ func (h *HTTPHandler) PurchaseNumber(c *gin.Context) {
 		return
 	}
 
-	activation, err := h.smsService.PurchaseNumber(
+	activation, deduplicated, err := h.smsService.PurchaseNumber(
 		c.Request.Context(),
 		req.UserID,
 		req.Service,
@@ -53,8 +52,17 @@ func (h *HTTPHandler) PurchaseNumber(c *gin.Context) {
 		req.Operator,
 		req.Provider,
 		req.MaxPrice,
+		c.GetHeader("Idempotency-Key"),
 	)
 
+	if errors.Is(err, service.ErrPurchaseInProgress) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrIdempotencyKeyConflict) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		h.logger.Errorf("Failed to purchase number: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -67,6 +75,180 @@ func (h *HTTPHandler) PurchaseNumber(c *gin.Context) {
 		"price":         activation.Price,
 		"provider":      activation.Provider,
 		"expires_at":    activation.ExpiresAt.Unix(),
+		"deduplicated":  deduplicated,
+	})
+}
+
+func (h *HTTPHandler) PurchaseNumbers(c *gin.Context) {
+	var req struct {
+		UserID   string `json:"user_id" binding:"required"`
+		Service  string `json:"service" binding:"required"`
+		Country  string `json:"country" binding:"required"`
+		Operator string `json:"operator"`
+		Quantity int    `json:"quantity" binding:"required"`
+		MaxPrice int32  `json:"max_price"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.smsService.PurchaseNumbers(
+		c.Request.Context(),
+		req.UserID,
+		req.Service,
+		req.Country,
+		req.Operator,
+		req.Quantity,
+		req.MaxPrice,
+	)
+
+	if errors.Is(err, service.ErrInvalidQuantity) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Errorf("Failed to purchase numbers: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Partial fulfillment is still a success; nothing bought at all is not
+	statusCode := http.StatusOK
+	if result.Fulfilled == 0 {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	c.JSON(statusCode, result)
+}
+
+// SchedulePurchase buys a number now or defers it past the provider's next restock.
+// Deferred purchases are answered with 202 and can be followed by their purchase ID.
+func (h *HTTPHandler) SchedulePurchase(c *gin.Context) {
+	var req struct {
+		UserID   string `json:"user_id" binding:"required"`
+		Service  string `json:"service" binding:"required"`
+		Country  string `json:"country" binding:"required"`
+		Operator string `json:"operator"`
+		Provider string `json:"provider"`
+		MaxPrice int32  `json:"max_price"`
+		Urgent   bool   `json:"urgent"`
+		NotAfter int64  `json:"not_after"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scheduleReq := models.ScheduledPurchaseRequest{
+		UserID:   req.UserID,
+		Service:  req.Service,
+		Country:  req.Country,
+		Operator: req.Operator,
+		Provider: req.Provider,
+		MaxPrice: req.MaxPrice,
+		Urgent:   req.Urgent,
+	}
+	if req.NotAfter > 0 {
+		scheduleReq.NotAfter = time.Unix(req.NotAfter, 0)
+	}
+
+	purchase, activation, err := h.purchaseScheduler.SchedulePurchase(c.Request.Context(), scheduleReq)
+	if errors.Is(err, service.ErrInvalidDeadline) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Errorf("Failed to schedule purchase: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if purchase.Deferred {
+		c.JSON(http.StatusAccepted, gin.H{"purchase": purchase})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"purchase":   purchase,
+		"activation": activation,
+	})
+}
+
+func (h *HTTPHandler) GetScheduledPurchase(c *gin.Context) {
+	purchaseID := c.Param("purchase_id")
+	userID := c.Query("user_id")
+
+	if purchaseID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "purchase_id and user_id are required"})
+		return
+	}
+
+	purchase, err := h.purchaseScheduler.GetScheduledPurchase(c.Request.Context(), purchaseID, userID)
+	if errors.Is(err, service.ErrScheduledPurchaseNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, purchase)
+}
+
+func (h *HTTPHandler) GetDeferralStats(c *gin.Context) {
+	var fromDate, toDate time.Time
+
+	if from := c.Query("from_date"); from != "" {
+		if ts, err := strconv.ParseInt(from, 10, 64); err == nil {
+			fromDate = time.Unix(ts, 0)
+		}
+	}
+
+	if to := c.Query("to_date"); to != "" {
+		if ts, err := strconv.ParseInt(to, 10, 64); err == nil {
+			toDate = time.Unix(ts, 0)
+		}
+	}
+
+	stats, err := h.purchaseScheduler.GetDeferralStats(c.Request.Context(), fromDate, toDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *HTTPHandler) ClaimBatchNumber(c *gin.Context) {
+	batchID := c.Param("batch_id")
+	userID := c.Query("user_id")
+
+	if batchID == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "batch_id and user_id are required"})
+		return
+	}
+
+	activation, err := h.smsService.ClaimBatchNumber(c.Request.Context(), batchID, userID)
+	if errors.Is(err, service.ErrBatchExhausted) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"activation_id": activation.ActivationID,
+		"phone_number":  activation.PhoneNumber,
+		"price":         activation.Price,
+		"provider":      activation.Provider,
+		"batch_id":      activation.BatchID,
+		"expires_at":    activation.ExpiresAt.Unix(),
 	})
 }
 
@@ -80,6 +262,10 @@ func (h *HTTPHandler) GetSMSCode(c *gin.Context) {
 	}
 
 	code, fullSMS, err := h.smsService.GetSMSCode(c.Request.Context(), activationID, userID)
+	if errors.Is(err, service.ErrSuspiciousSMS) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -212,9 +398,208 @@ func (h *HTTPHandler) GetProviderBalance(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"provider":    provider,
-		"balance":     balance,
-		"currency":    currency,
-		"updated_at":  time.Now().Unix(),
+		"provider":   provider,
+		"balance":    balance,
+		"currency":   currency,
+		"updated_at": time.Now().Unix(),
+	})
+}
+
+func (h *HTTPHandler) GetPrices(c *gin.Context) {
+	prices, err := h.smsService.GetPrices(
+		c.Request.Context(),
+		c.Query("service"),
+		c.Query("country"),
+		c.Query("provider"),
+	)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"prices": prices,
+		"total":  len(prices),
+	})
+}
+
+// GetProviderHealth returns provider health scores, healthiest first
+func (h *HTTPHandler) GetProviderHealth(c *gin.Context) {
+	providers := h.smsService.GetProviderHealth()
+	c.JSON(http.StatusOK, gin.H{
+		"providers": providers,
+		"total":     len(providers),
+	})
+}
+
+// RentNumber rents a number for a period. A request for an account that already holds an
+// active rental for the service is answered with that rental and "reused": true.
+func (h *HTTPHandler) RentNumber(c *gin.Context) {
+	var req struct {
+		UserID    string `json:"user_id" binding:"required"`
+		AccountID string `json:"account_id"`
+		Service   string `json:"service" binding:"required"`
+		Country   string `json:"country" binding:"required"`
+		Operator  string `json:"operator"`
+		Provider  string `json:"provider"`
+		Hours     int    `json:"hours"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rental, reused, err := h.rentalManager.RentNumber(c.Request.Context(), models.RentalRequest{
+		UserID:    req.UserID,
+		AccountID: req.AccountID,
+		Service:   req.Service,
+		Country:   req.Country,
+		Operator:  req.Operator,
+		Provider:  req.Provider,
+		Hours:     req.Hours,
+	})
+	if err != nil {
+		h.rentalError(c, "rent number", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rental": rental,
+		"reused": reused,
 	})
 }
+
+func (h *HTTPHandler) RenewRental(c *gin.Context) {
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+		Hours  int    `json:"hours"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rental, err := h.rentalManager.RenewRental(c.Request.Context(), c.Param("rental_id"), req.UserID, req.Hours)
+	if err != nil {
+		h.rentalError(c, "renew rental", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rental)
+}
+
+func (h *HTTPHandler) ReleaseRental(c *gin.Context) {
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rental, err := h.rentalManager.ReleaseRental(c.Request.Context(), c.Param("rental_id"), req.UserID)
+	if err != nil {
+		h.rentalError(c, "release rental", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rental)
+}
+
+func (h *HTTPHandler) AssignRental(c *gin.Context) {
+	var req struct {
+		UserID    string `json:"user_id" binding:"required"`
+		AccountID string `json:"account_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rental, err := h.rentalManager.AssignRental(c.Request.Context(), c.Param("rental_id"), req.UserID, req.AccountID)
+	if err != nil {
+		h.rentalError(c, "assign rental", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rental)
+}
+
+func (h *HTTPHandler) GetRental(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	rental, err := h.rentalManager.GetRental(c.Request.Context(), c.Param("rental_id"), userID)
+	if err != nil {
+		h.rentalError(c, "get rental", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rental)
+}
+
+func (h *HTTPHandler) ListRentals(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	rentals, err := h.rentalManager.ListRentals(c.Request.Context(), userID, c.Query("account_id"), models.RentalStatus(c.Query("status")))
+	if err != nil {
+		h.rentalError(c, "list rentals", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rentals": rentals,
+		"total":   len(rentals),
+	})
+}
+
+func (h *HTTPHandler) GetRentalMessages(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	var since time.Time
+	if value := c.Query("since"); value != "" {
+		if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+			since = time.Unix(ts, 0)
+		}
+	}
+
+	messages, err := h.rentalManager.GetRentalMessages(c.Request.Context(), c.Param("rental_id"), userID, since)
+	if err != nil {
+		h.rentalError(c, "get rental messages", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"messages": messages,
+		"total":    len(messages),
+	})
+}
+
+func (h *HTTPHandler) rentalError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, service.ErrRentalNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrRentalNotActive):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidRentTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s: %v", operation, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
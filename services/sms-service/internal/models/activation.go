@@ -31,6 +31,11 @@ type Activation struct {
 	CancelledAt      *time.Time         `bson:"cancelled_at" json:"cancelled_at"`
 	CancellationNote string             `bson:"cancellation_note" json:"cancellation_note"`
 	Encrypted        bool               `bson:"encrypted" json:"-"`
+	BatchID          string             `bson:"batch_id,omitempty" json:"batch_id,omitempty"`
+	ClaimedAt        *time.Time         `bson:"claimed_at,omitempty" json:"claimed_at,omitempty"`
+	CodeAnomalies    []string           `bson:"code_anomalies,omitempty" json:"code_anomalies,omitempty"`
+	IdempotencyKey   string             `bson:"idempotency_key,omitempty" json:"-"`
+	IdempotencyHash  string             `bson:"idempotency_hash,omitempty" json:"-"`
 }
 
 type ActivationStatus string
@@ -43,4 +48,6 @@ const (
 	ActivationStatusCancelled  ActivationStatus = "cancelled"
 	ActivationStatusExpired    ActivationStatus = "expired"
 	ActivationStatusFailed     ActivationStatus = "failed"
+	// ActivationStatusFlagged means the received SMS failed validation and its code was withheld
+	ActivationStatusFlagged    ActivationStatus = "flagged"
 )
@@ -0,0 +1,28 @@
+package models
+
+// Failure codes of a single item in a bulk purchase
+const (
+	PurchaseFailureNoNumbers     = "no_numbers"
+	PurchaseFailureNoBalance     = "no_balance"
+	PurchaseFailurePriceExceeded = "price_exceeded"
+	PurchaseFailureTimeout       = "timeout"
+	PurchaseFailureProvider      = "provider_error"
+)
+
+// PurchaseFailure describes why one number of a bulk purchase could not be acquired
+type PurchaseFailure struct {
+	Index    int    `json:"index"`
+	Provider string `json:"provider,omitempty"`
+	Code     string `json:"code"`
+	Error    string `json:"error"`
+}
+
+// BatchPurchaseResult is the outcome of a bulk purchase. Acquired numbers are reserved
+// against BatchID until claimed or expired.
+type BatchPurchaseResult struct {
+	BatchID     string            `json:"batch_id"`
+	Requested   int               `json:"requested"`
+	Fulfilled   int               `json:"fulfilled"`
+	Activations []*Activation     `json:"activations"`
+	Failures    []PurchaseFailure `json:"failures"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ProviderOutcomes aggregates finished activations of a provider over the health window
+type ProviderOutcomes struct {
+	Provider           string  `bson:"provider"`
+	Total              int     `bson:"total"`
+	Received           int     `bson:"received"`
+	Cancelled          int     `bson:"cancelled"`
+	AvgDeliverySeconds float64 `bson:"avg_delivery_seconds"` // From purchase to code for activations that received one
+}
+
+// ProviderHealth is the current health score of a provider, used to order failover
+type ProviderHealth struct {
+	Provider           string     `json:"provider"`
+	Samples            int        `json:"samples"`
+	SuccessRate        float64    `json:"success_rate"`
+	CancellationRate   float64    `json:"cancellation_rate"`
+	AvgDeliverySeconds float64    `json:"avg_delivery_seconds"`
+	Score              float64    `json:"score"` // 0-100, higher is healthier
+	Quarantined        bool       `json:"quarantined"`
+	QuarantinedUntil   *time.Time `json:"quarantined_until,omitempty"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
@@ -22,6 +22,8 @@ type Phone struct {
 	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
 	ExpiresAt    time.Time          `bson:"expires_at" json:"expires_at"`
 	Encrypted    bool               `bson:"encrypted" json:"-"`
+	// NumberIndex is the blind index of Number, lookups by number match on it
+	NumberIndex string `bson:"number_index,omitempty" json:"-"`
 }
 
 type PhoneStatus string
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// ProviderPrice is a price catalog entry: what a provider charges for a service in a country
+type ProviderPrice struct {
+	Provider    string     `json:"provider"`
+	Service     string     `json:"service"`
+	Country     string     `json:"country"`
+	Price       float64    `json:"price"`
+	Currency    string     `json:"currency"`
+	Available   int        `json:"available"`
+	SuccessRate float64    `json:"success_rate"` // Share of recent activations that received a code
+	Samples     int        `json:"samples"`      // Recent activations the success rate is based on
+	UpdatedAt   time.Time  `json:"updated_at"`
+	NextRestock *time.Time `json:"next_restock_at,omitempty"` // Next stock refill of the provider in this country
+}
+
+// ProviderSuccessRate aggregates recent activation outcomes per provider, service and country
+type ProviderSuccessRate struct {
+	Provider string `bson:"provider"`
+	Service  string `bson:"service"`
+	Country  string `bson:"country"`
+	Total    int    `bson:"total"`
+	Received int    `bson:"received"`
+}
+
+// Rate returns the share of activations that received a code
+func (r ProviderSuccessRate) Rate() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Received) / float64(r.Total)
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RentalStatus string
+
+const (
+	RentalStatusActive   RentalStatus = "active"
+	RentalStatusReleased RentalStatus = "released"
+	RentalStatusExpired  RentalStatus = "expired"
+)
+
+// RentalRequest asks for a number rented for a period rather than a single activation.
+// AccountID binds the number to the account it verifies, so later re-verifications find it.
+type RentalRequest struct {
+	UserID    string
+	AccountID string
+	Service   string
+	Country   string
+	Operator  string
+	Provider  string
+	Hours     int
+}
+
+// Rental is a number kept for a period. It receives any number of SMS until it is released
+// or lapses, and can be renewed before that.
+type Rental struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	RentalID        string             `bson:"rental_id" json:"rental_id"`
+	ProviderRentID  string             `bson:"provider_rent_id" json:"-"`
+	UserID          string             `bson:"user_id" json:"user_id"`
+	AccountID       string             `bson:"account_id,omitempty" json:"account_id,omitempty"`
+	PhoneNumber     string             `bson:"phone_number" json:"phone_number"`
+	Service         string             `bson:"service" json:"service"`
+	Country         string             `bson:"country" json:"country"`
+	Operator        string             `bson:"operator,omitempty" json:"operator,omitempty"`
+	Provider        string             `bson:"provider" json:"provider"`
+	Status          RentalStatus       `bson:"status" json:"status"`
+	Hours           int                `bson:"hours" json:"hours"` // Total rented hours, renewals included
+	Price           float64            `bson:"price" json:"price"` // Total paid, renewals included
+	Renewals        int                `bson:"renewals" json:"renewals"`
+	MessageCount    int                `bson:"message_count" json:"message_count"`
+	StartedAt       time.Time          `bson:"started_at" json:"started_at"`
+	ExpiresAt       time.Time          `bson:"expires_at" json:"expires_at"`
+	ExpiryAlertedAt *time.Time         `bson:"expiry_alerted_at,omitempty" json:"expiry_alerted_at,omitempty"`
+	LastPolledAt    *time.Time         `bson:"last_polled_at,omitempty" json:"-"`
+	EndedAt         *time.Time         `bson:"ended_at,omitempty" json:"ended_at,omitempty"`
+	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// RentalMessage is an SMS received by a rented number. Suspicious texts are kept with
+// their anomalies and without a code, as for activations.
+type RentalMessage struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	RentalID   string             `bson:"rental_id" json:"rental_id"`
+	MessageKey string             `bson:"message_key" json:"-"` // Identifies the SMS across provider polls
+	Sender     string             `bson:"sender" json:"sender"`
+	Text       string             `bson:"text" json:"text"`
+	Code       string             `bson:"code,omitempty" json:"code,omitempty"`
+	Anomalies  []string           `bson:"anomalies,omitempty" json:"anomalies,omitempty"`
+	ReceivedAt time.Time          `bson:"received_at" json:"received_at"`
+	StoredAt   time.Time          `bson:"stored_at" json:"stored_at"`
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RestockSchedule lists the local times at which a provider refills its number stock
+type RestockSchedule struct {
+	Provider  string   `mapstructure:"provider" json:"provider"`
+	Countries []string `mapstructure:"countries" json:"countries,omitempty"` // Empty matches every country
+	Timezone  string   `mapstructure:"timezone" json:"timezone"`
+	Times     []string `mapstructure:"times" json:"times"` // HH:MM in Timezone
+}
+
+// Covers reports whether the schedule applies to the country
+func (s RestockSchedule) Covers(country string) bool {
+	if len(s.Countries) == 0 {
+		return true
+	}
+	for _, c := range s.Countries {
+		if strings.EqualFold(c, country) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the timezone and times of the schedule
+func (s RestockSchedule) Validate() error {
+	if s.Provider == "" {
+		return fmt.Errorf("restock schedule has no provider")
+	}
+	if len(s.Times) == 0 {
+		return fmt.Errorf("restock schedule of %s has no times", s.Provider)
+	}
+	_, err := s.Next(time.Now())
+	return err
+}
+
+// Next returns the first restock strictly after now, or the zero time if the schedule has no times
+func (s RestockSchedule) Next(now time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid restock timezone %q: %w", s.Timezone, err)
+	}
+
+	local := now.In(loc)
+	var next time.Time
+	for _, value := range s.Times {
+		clock, err := time.Parse("15:04", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid restock time %q: %w", value, err)
+		}
+
+		at := time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+		if !at.After(now) {
+			at = time.Date(local.Year(), local.Month(), local.Day()+1, clock.Hour(), clock.Minute(), 0, 0, loc)
+		}
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+
+	return next, nil
+}
+
+// Reasons a purchase is deferred to a post-restock window
+const (
+	DeferralPreRestock = "pre_restock" // Requested shortly before a restock, when stock is drained
+	DeferralLowStock   = "low_stock"   // Catalog stock is below the configured threshold
+)
+
+type ScheduledPurchaseStatus string
+
+const (
+	ScheduledPurchaseDeferred  ScheduledPurchaseStatus = "deferred"
+	ScheduledPurchaseExecuting ScheduledPurchaseStatus = "executing"
+	ScheduledPurchaseFulfilled ScheduledPurchaseStatus = "fulfilled"
+	ScheduledPurchaseFailed    ScheduledPurchaseStatus = "failed"
+)
+
+// ScheduledPurchaseRequest asks for a number that may be bought after the next restock.
+// Urgent requests are never deferred; NotAfter bounds how long a purchase may wait.
+type ScheduledPurchaseRequest struct {
+	UserID   string
+	Service  string
+	Country  string
+	Operator string
+	Provider string
+	MaxPrice int32
+	Urgent   bool
+	NotAfter time.Time
+}
+
+// ScheduledPurchase is a purchase that may wait for the provider's restock. Urgent purchases and
+// those with nothing to wait for are bought immediately and recorded with Deferred false.
+type ScheduledPurchase struct {
+	ID              primitive.ObjectID      `bson:"_id,omitempty" json:"-"`
+	PurchaseID      string                  `bson:"purchase_id" json:"purchase_id"`
+	UserID          string                  `bson:"user_id" json:"user_id"`
+	Service         string                  `bson:"service" json:"service"`
+	Country         string                  `bson:"country" json:"country"`
+	Operator        string                  `bson:"operator,omitempty" json:"operator,omitempty"`
+	Provider        string                  `bson:"provider,omitempty" json:"provider,omitempty"`
+	RestockProvider string                  `bson:"restock_provider,omitempty" json:"restock_provider,omitempty"` // Provider whose restock is awaited
+	MaxPrice        int32                   `bson:"max_price,omitempty" json:"max_price,omitempty"`
+	Status          ScheduledPurchaseStatus `bson:"status" json:"status"`
+	Deferred        bool                    `bson:"deferred" json:"deferred"`
+	Reason          string                  `bson:"reason,omitempty" json:"reason,omitempty"`
+	ExecuteAt       time.Time               `bson:"execute_at" json:"execute_at"`
+	ActivationID    string                  `bson:"activation_id,omitempty" json:"activation_id,omitempty"`
+	Error           string                  `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt       time.Time               `bson:"created_at" json:"created_at"`
+	CompletedAt     *time.Time              `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// DeferralStats summarizes scheduled purchases created in a period
+type DeferralStats struct {
+	Total              int64            `json:"total"`
+	Deferred           int64            `json:"deferred"`
+	Pending            int64            `json:"pending"`
+	FulfilledDeferred  int64            `json:"fulfilled_deferred"`
+	FailedDeferred     int64            `json:"failed_deferred"`
+	AvgDeferralSeconds float64          `json:"avg_deferral_seconds"`
+	ByReason           map[string]int64 `json:"by_reason"`
+	ByProvider         map[string]int64 `json:"by_provider"`
+}
@@ -14,6 +14,7 @@ type GetStatisticsResponse struct {
 	ByService             map[string]int32  `json:"by_service"`
 	ByCountry             map[string]int32  `json:"by_country"`
 	ByProvider            map[string]float32 `json:"by_provider"`
+	ProviderHealth        []ProviderHealth   `json:"provider_health,omitempty"`
 }
 
 
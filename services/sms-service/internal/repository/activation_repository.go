@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/sms-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -16,10 +16,10 @@ import (
 
 type ActivationRepository struct {
 	collection *mongo.Collection
-	logger     *logrus.Logger
+	logger     logger.Logger
 }
 
-func NewActivationRepository(db *mongo.Database, logger *logrus.Logger) *ActivationRepository {
+func NewActivationRepository(db *mongo.Database, logger logger.Logger) *ActivationRepository {
 	return &ActivationRepository{
 		collection: db.Collection("activations"),
 		logger:     logger,
@@ -65,6 +65,27 @@ func (r *ActivationRepository) FindByActivationID(ctx context.Context, activatio
 	return &activation, nil
 }
 
+// FindByIdempotencyKey returns the latest activation the user bought with the key since the given time
+func (r *ActivationRepository) FindByIdempotencyKey(ctx context.Context, userID, key string, since time.Time) (*models.Activation, error) {
+	filter := bson.M{
+		"user_id":         userID,
+		"idempotency_key": key,
+		"created_at":      bson.M{"$gte": since},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var activation models.Activation
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&activation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find activation by idempotency key: %w", err)
+	}
+
+	return &activation, nil
+}
+
 func (r *ActivationRepository) FindByUserID(ctx context.Context, userID string, limit int64) ([]*models.Activation, error) {
 	filter := bson.M{"user_id": userID}
 	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit)
@@ -114,6 +135,39 @@ func (r *ActivationRepository) FindPending(ctx context.Context) ([]*models.Activ
 	return activations, nil
 }
 
+// ClaimNextInBatch atomically hands out the oldest unclaimed, still valid number of a batch.
+// It returns nil when the batch has nothing left to claim.
+func (r *ActivationRepository) ClaimNextInBatch(ctx context.Context, batchID, userID string) (*models.Activation, error) {
+	now := time.Now()
+	filter := bson.M{
+		"batch_id":   batchID,
+		"user_id":    userID,
+		"claimed_at": bson.M{"$exists": false},
+		"status":     models.ActivationStatusWaiting,
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"claimed_at": now,
+			"updated_at": now,
+		},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var activation models.Activation
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&activation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim batch activation: %w", err)
+	}
+
+	return &activation, nil
+}
+
 func (r *ActivationRepository) Update(ctx context.Context, activation *models.Activation) error {
 	activation.UpdatedAt = time.Now()
 
@@ -128,6 +182,26 @@ func (r *ActivationRepository) Update(ctx context.Context, activation *models.Ac
 	return nil
 }
 
+// SetIdempotencyKey records the client key the activation was bought with and the hash of
+// the request parameters it was bought for
+func (r *ActivationRepository) SetIdempotencyKey(ctx context.Context, activationID, key, requestHash string) error {
+	filter := bson.M{"activation_id": activationID}
+	update := bson.M{
+		"$set": bson.M{
+			"idempotency_key":  key,
+			"idempotency_hash": requestHash,
+			"updated_at":       time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to set idempotency key: %w", err)
+	}
+
+	return nil
+}
+
 func (r *ActivationRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status models.ActivationStatus) error {
 	filter := bson.M{"_id": id}
 	update := bson.M{
@@ -155,11 +229,11 @@ func (r *ActivationRepository) UpdateCode(ctx context.Context, activationID, cod
 	filter := bson.M{"activation_id": activationID}
 	update := bson.M{
 		"$set": bson.M{
-			"code":              code,
-			"full_sms":          fullSMS,
-			"code_received_at":  &now,
-			"status":            models.ActivationStatusReceived,
-			"updated_at":        time.Now(),
+			"code":             code,
+			"full_sms":         fullSMS,
+			"code_received_at": &now,
+			"status":           models.ActivationStatusReceived,
+			"updated_at":       time.Now(),
 		},
 	}
 
@@ -171,6 +245,28 @@ func (r *ActivationRepository) UpdateCode(ctx context.Context, activationID, cod
 	return nil
 }
 
+// FlagCode stores an SMS that failed validation without exposing its code
+func (r *ActivationRepository) FlagCode(ctx context.Context, activationID, fullSMS string, anomalies []string) error {
+	now := time.Now()
+	filter := bson.M{"activation_id": activationID}
+	update := bson.M{
+		"$set": bson.M{
+			"full_sms":         fullSMS,
+			"code_anomalies":   anomalies,
+			"code_received_at": &now,
+			"status":           models.ActivationStatusFlagged,
+			"updated_at":       now,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to flag activation code: %w", err)
+	}
+
+	return nil
+}
+
 func (r *ActivationRepository) CancelActivation(ctx context.Context, activationID, reason string, refunded bool, refundAmount float64) error {
 	now := time.Now()
 	filter := bson.M{"activation_id": activationID}
@@ -243,7 +339,7 @@ func (r *ActivationRepository) GetStatistics(ctx context.Context, filter bson.M)
 	pipeline := []bson.M{
 		{"$match": filter},
 		{"$group": bson.M{
-			"_id": nil,
+			"_id":               nil,
 			"total_activations": bson.M{"$sum": 1},
 			"successful_activations": bson.M{
 				"$sum": bson.M{
@@ -269,7 +365,7 @@ func (r *ActivationRepository) GetStatistics(ctx context.Context, filter bson.M)
 					},
 				},
 			},
-			"total_spent": bson.M{"$sum": "$price"},
+			"total_spent":   bson.M{"$sum": "$price"},
 			"average_price": bson.M{"$avg": "$price"},
 		}},
 	}
@@ -389,6 +485,106 @@ func (r *ActivationRepository) GetStatistics(ctx context.Context, filter bson.M)
 	return stats, nil
 }
 
+// GetSuccessRates returns code delivery outcomes of activations created since the given time,
+// grouped by provider, service and country
+func (r *ActivationRepository) GetSuccessRates(ctx context.Context, since time.Time) ([]models.ProviderSuccessRate, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"created_at": bson.M{"$gte": since},
+			// Activations still waiting for a code say nothing about the provider yet
+			"status": bson.M{"$nin": []models.ActivationStatus{models.ActivationStatusPending, models.ActivationStatusWaiting}},
+		}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"provider": "$provider",
+				"service":  "$service",
+				"country":  "$country",
+			},
+			"total": bson.M{"$sum": 1},
+			"received": bson.M{
+				"$sum": bson.M{
+					"$cond": []interface{}{
+						bson.M{"$in": []interface{}{"$status", []models.ActivationStatus{models.ActivationStatusReceived, models.ActivationStatusCompleted}}},
+						1, 0,
+					},
+				},
+			},
+		}},
+		{"$project": bson.M{
+			"_id":      0,
+			"provider": "$_id.provider",
+			"service":  "$_id.service",
+			"country":  "$_id.country",
+			"total":    1,
+			"received": 1,
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get success rates: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rates []models.ProviderSuccessRate
+	if err := cursor.All(ctx, &rates); err != nil {
+		return nil, fmt.Errorf("failed to decode success rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+// GetProviderOutcomes returns outcomes of activations created since the given time, grouped by provider
+func (r *ActivationRepository) GetProviderOutcomes(ctx context.Context, since time.Time) ([]models.ProviderOutcomes, error) {
+	received := bson.M{"$in": []interface{}{"$status", []models.ActivationStatus{models.ActivationStatusReceived, models.ActivationStatusCompleted}}}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"created_at": bson.M{"$gte": since},
+			"status":     bson.M{"$nin": []models.ActivationStatus{models.ActivationStatusPending, models.ActivationStatusWaiting}},
+		}},
+		{"$group": bson.M{
+			"_id":   "$provider",
+			"total": bson.M{"$sum": 1},
+			"received": bson.M{
+				"$sum": bson.M{"$cond": []interface{}{received, 1, 0}},
+			},
+			"cancelled": bson.M{
+				"$sum": bson.M{"$cond": []interface{}{bson.M{"$eq": []interface{}{"$status", models.ActivationStatusCancelled}}, 1, 0}},
+			},
+			// $avg skips nulls, so only activations with a code count towards the delivery time
+			"avg_delivery_ms": bson.M{
+				"$avg": bson.M{"$cond": []interface{}{
+					bson.M{"$and": []interface{}{received, bson.M{"$gt": []interface{}{"$code_received_at", nil}}}},
+					bson.M{"$subtract": []interface{}{"$code_received_at", "$created_at"}},
+					nil,
+				}},
+			},
+		}},
+		{"$project": bson.M{
+			"_id":                  0,
+			"provider":             "$_id",
+			"total":                1,
+			"received":             1,
+			"cancelled":            1,
+			"avg_delivery_seconds": bson.M{"$divide": []interface{}{bson.M{"$ifNull": []interface{}{"$avg_delivery_ms", 0}}, 1000}},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider outcomes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var outcomes []models.ProviderOutcomes
+	if err := cursor.All(ctx, &outcomes); err != nil {
+		return nil, fmt.Errorf("failed to decode provider outcomes: %w", err)
+	}
+
+	return outcomes, nil
+}
+
 func (r *ActivationRepository) CreateIndex(ctx context.Context) error {
 	indexes := []mongo.IndexModel{
 		{
@@ -404,6 +600,14 @@ func (r *ActivationRepository) CreateIndex(ctx context.Context) error {
 		{
 			Keys: bson.D{{Key: "phone_id", Value: 1}},
 		},
+		{
+			Keys:    bson.D{{Key: "batch_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "idempotency_key", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetSparse(true),
+		},
 	}
 
 	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
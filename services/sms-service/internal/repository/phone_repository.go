@@ -6,14 +6,16 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"time"
 
+	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/sms-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -21,21 +23,27 @@ import (
 )
 
 type PhoneRepository struct {
-	collection *mongo.Collection
-	logger     *logrus.Logger
-	encKey     []byte
+	collection  *mongo.Collection
+	logger      logger.Logger
+	encKey      []byte
+	numberIndex *crypto.BlindIndex
 }
 
-func NewPhoneRepository(db *mongo.Database, logger *logrus.Logger) *PhoneRepository {
+func NewPhoneRepository(db *mongo.Database, logger logger.Logger) *PhoneRepository {
 	encKeyStr := os.Getenv("ENCRYPTION_KEY")
 	if encKeyStr == "" {
 		encKeyStr = "default-32-byte-encryption-key!!" // 32 bytes for AES-256
 	}
 
+	encKey := []byte(encKeyStr)[:32]
+	// Cannot fail, the key is 32 bytes
+	numberIndex, _ := crypto.NewBlindIndex(encKey)
+
 	return &PhoneRepository{
-		collection: db.Collection("phones"),
-		logger:     logger,
-		encKey:     []byte(encKeyStr)[:32],
+		collection:  db.Collection("phones"),
+		logger:      logger,
+		encKey:      encKey,
+		numberIndex: numberIndex,
 	}
 }
 
@@ -77,14 +85,11 @@ func (r *PhoneRepository) FindByID(ctx context.Context, id primitive.ObjectID) (
 	return &phone, nil
 }
 
+// FindByNumber matches the blind index of the number. Phones stored before the index was
+// added get it from BackfillNumberIndex when the indexes are created.
 func (r *PhoneRepository) FindByNumber(ctx context.Context, number string) (*models.Phone, error) {
-	encryptedNumber, err := r.encrypt(number)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt number for search: %w", err)
-	}
-
 	var phone models.Phone
-	err = r.collection.FindOne(ctx, bson.M{"number": encryptedNumber}).Decode(&phone)
+	err := r.collection.FindOne(ctx, bson.M{"number_index": r.numberIndex.Phone(number)}).Decode(&phone)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -218,10 +223,10 @@ func (r *PhoneRepository) GetStatistics(ctx context.Context, filter bson.M) (map
 	pipeline := []bson.M{
 		{"$match": filter},
 		{"$group": bson.M{
-			"_id": nil,
-			"total": bson.M{"$sum": 1},
+			"_id":        nil,
+			"total":      bson.M{"$sum": 1},
 			"totalPrice": bson.M{"$sum": "$price"},
-			"avgPrice": bson.M{"$avg": "$price"},
+			"avgPrice":   bson.M{"$avg": "$price"},
 			"byStatus": bson.M{
 				"$push": bson.M{
 					"status": "$status",
@@ -253,11 +258,21 @@ func (r *PhoneRepository) GetStatistics(ctx context.Context, filter bson.M) (map
 	return result, nil
 }
 
+// CreateIndex backfills the number index before making it unique. The number itself is
+// encrypted with a random IV, so a unique index on it never matched duplicates and is dropped.
 func (r *PhoneRepository) CreateIndex(ctx context.Context) error {
+	if _, err := r.BackfillNumberIndex(ctx); err != nil {
+		return err
+	}
+
+	if _, err := r.collection.Indexes().DropOne(ctx, "number_1"); err != nil && !isIndexNotFound(err) {
+		return fmt.Errorf("failed to drop number index: %w", err)
+	}
+
 	indexes := []mongo.IndexModel{
 		{
-			Keys:    bson.D{{Key: "number", Value: 1}},
-			Options: options.Index().SetUnique(true),
+			Keys:    bson.D{{Key: "number_index", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
 		},
 		{
 			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}},
@@ -278,7 +293,60 @@ func (r *PhoneRepository) CreateIndex(ctx context.Context) error {
 	return nil
 }
 
+// BackfillNumberIndex sets the blind index on phones stored without one and returns how many
+// were updated
+func (r *PhoneRepository) BackfillNumberIndex(ctx context.Context) (int, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"number_index": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"number": 1, "encrypted": 1}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find phones without number index: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var phone models.Phone
+		if err := cursor.Decode(&phone); err != nil {
+			return updated, fmt.Errorf("failed to decode phone: %w", err)
+		}
+
+		if phone.Encrypted {
+			if err := r.decryptPhone(&phone); err != nil {
+				r.logger.Warnf("Skipping phone %s without readable number: %v", phone.ID.Hex(), err)
+				continue
+			}
+		}
+
+		_, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": phone.ID},
+			bson.M{"$set": bson.M{"number_index": r.numberIndex.Phone(phone.Number)}})
+		if err != nil {
+			return updated, fmt.Errorf("failed to set number index of phone %s: %w", phone.ID.Hex(), err)
+		}
+		updated++
+	}
+	if err := cursor.Err(); err != nil {
+		return updated, fmt.Errorf("failed to iterate phones: %w", err)
+	}
+
+	if updated > 0 {
+		r.logger.Infof("Backfilled number index of %d phones", updated)
+	}
+	return updated, nil
+}
+
+// isIndexNotFound reports whether dropping an index failed because it does not exist
+func isIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == 27 || cmdErr.Code == 26 // IndexNotFound, NamespaceNotFound
+	}
+	return false
+}
+
 func (r *PhoneRepository) encryptPhone(phone *models.Phone) error {
+	phone.NumberIndex = r.numberIndex.Phone(phone.Number)
+
 	var err error
 	phone.Number, err = r.encrypt(phone.Number)
 	if err != nil {
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RentalRepository struct {
+	collection *mongo.Collection
+	messages   *mongo.Collection
+	logger     logger.Logger
+}
+
+func NewRentalRepository(db *mongo.Database, logger logger.Logger) *RentalRepository {
+	return &RentalRepository{
+		collection: db.Collection("rentals"),
+		messages:   db.Collection("rental_messages"),
+		logger:     logger,
+	}
+}
+
+func (r *RentalRepository) Create(ctx context.Context, rental *models.Rental) error {
+	rental.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, rental)
+	if err != nil {
+		return fmt.Errorf("failed to insert rental: %w", err)
+	}
+
+	rental.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *RentalRepository) FindByRentalID(ctx context.Context, rentalID string) (*models.Rental, error) {
+	return r.findOne(ctx, bson.M{"rental_id": rentalID})
+}
+
+// FindActiveByAccount returns the active rental bound to the account for the service
+func (r *RentalRepository) FindActiveByAccount(ctx context.Context, accountID, service string) (*models.Rental, error) {
+	return r.findOne(ctx, bson.M{
+		"account_id": accountID,
+		"service":    service,
+		"status":     models.RentalStatusActive,
+	})
+}
+
+func (r *RentalRepository) findOne(ctx context.Context, filter bson.M) (*models.Rental, error) {
+	var rental models.Rental
+	err := r.collection.FindOne(ctx, filter).Decode(&rental)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find rental: %w", err)
+	}
+
+	return &rental, nil
+}
+
+// List returns the rentals of the user, newest first. Empty filters match everything.
+func (r *RentalRepository) List(ctx context.Context, userID, accountID string, status models.RentalStatus) ([]*models.Rental, error) {
+	filter := bson.M{"user_id": userID}
+	if accountID != "" {
+		filter["account_id"] = accountID
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}}).SetLimit(500)
+	return r.find(ctx, filter, opts)
+}
+
+// FindActive returns active rentals that were not polled since the given time, least recently polled first
+func (r *RentalRepository) FindActive(ctx context.Context, polledBefore time.Time, limit int64) ([]*models.Rental, error) {
+	filter := bson.M{
+		"status": models.RentalStatusActive,
+		"$or": []bson.M{
+			{"last_polled_at": bson.M{"$exists": false}},
+			{"last_polled_at": bson.M{"$lt": polledBefore}},
+		},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "last_polled_at", Value: 1}}).SetLimit(limit)
+	return r.find(ctx, filter, opts)
+}
+
+// FindExpiring returns active rentals lapsing before the given time that were not alerted yet
+func (r *RentalRepository) FindExpiring(ctx context.Context, before time.Time) ([]*models.Rental, error) {
+	filter := bson.M{
+		"status":            models.RentalStatusActive,
+		"expires_at":        bson.M{"$lt": before},
+		"expiry_alerted_at": bson.M{"$exists": false},
+	}
+
+	return r.find(ctx, filter, options.Find().SetLimit(100))
+}
+
+// FindLapsed returns active rentals whose period is over
+func (r *RentalRepository) FindLapsed(ctx context.Context, now time.Time) ([]*models.Rental, error) {
+	filter := bson.M{
+		"status":     models.RentalStatusActive,
+		"expires_at": bson.M{"$lte": now},
+	}
+
+	return r.find(ctx, filter, options.Find().SetLimit(100))
+}
+
+func (r *RentalRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*models.Rental, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find rentals: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rentals []*models.Rental
+	if err := cursor.All(ctx, &rentals); err != nil {
+		return nil, fmt.Errorf("failed to decode rentals: %w", err)
+	}
+
+	return rentals, nil
+}
+
+// Extend records a renewal. The expiry alert is reset, so the new period is alerted again.
+func (r *RentalRepository) Extend(ctx context.Context, rentalID string, expiresAt time.Time, hours int, price float64) error {
+	update := bson.M{
+		"$set":   bson.M{"expires_at": expiresAt, "updated_at": time.Now()},
+		"$inc":   bson.M{"hours": hours, "price": price, "renewals": 1},
+		"$unset": bson.M{"expiry_alerted_at": ""},
+	}
+
+	return r.updateActive(ctx, rentalID, update)
+}
+
+// End moves an active rental to released or expired. It returns false when the rental was not active anymore.
+func (r *RentalRepository) End(ctx context.Context, rentalID string, status models.RentalStatus) (bool, error) {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"status": status, "ended_at": now, "updated_at": now},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"rental_id": rentalID, "status": models.RentalStatusActive}, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to end rental: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// MarkExpiryAlerted records that the rental's expiry was alerted
+func (r *RentalRepository) MarkExpiryAlerted(ctx context.Context, rentalID string) error {
+	return r.updateActive(ctx, rentalID, bson.M{"$set": bson.M{"expiry_alerted_at": time.Now()}})
+}
+
+// AssignAccount binds the rental to an account
+func (r *RentalRepository) AssignAccount(ctx context.Context, rentalID, accountID string) error {
+	return r.updateActive(ctx, rentalID, bson.M{"$set": bson.M{"account_id": accountID, "updated_at": time.Now()}})
+}
+
+func (r *RentalRepository) updateActive(ctx context.Context, rentalID string, update bson.M) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"rental_id": rentalID, "status": models.RentalStatusActive}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update rental: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("rental %s is not active", rentalID)
+	}
+
+	return nil
+}
+
+// SaveMessages stores the SMS received by the rental and returns the ones not seen before.
+// Provider polls return the whole inbox, so known messages are skipped by their key.
+func (r *RentalRepository) SaveMessages(ctx context.Context, rentalID string, messages []*models.RentalMessage) ([]*models.RentalMessage, error) {
+	now := time.Now()
+	var stored []*models.RentalMessage
+
+	for _, message := range messages {
+		message.RentalID = rentalID
+		message.StoredAt = now
+
+		result, err := r.messages.UpdateOne(ctx,
+			bson.M{"rental_id": rentalID, "message_key": message.MessageKey},
+			bson.M{"$setOnInsert": message},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return stored, fmt.Errorf("failed to save rental message: %w", err)
+		}
+		if result.UpsertedCount > 0 {
+			stored = append(stored, message)
+		}
+	}
+
+	update := bson.M{"$set": bson.M{"last_polled_at": now}}
+	if len(stored) > 0 {
+		update["$inc"] = bson.M{"message_count": len(stored)}
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"rental_id": rentalID}, update); err != nil {
+		return stored, fmt.Errorf("failed to update rental poll: %w", err)
+	}
+
+	return stored, nil
+}
+
+// ListMessages returns the SMS history of the rental, newest first
+func (r *RentalRepository) ListMessages(ctx context.Context, rentalID string, since time.Time) ([]*models.RentalMessage, error) {
+	filter := bson.M{"rental_id": rentalID}
+	if !since.IsZero() {
+		filter["received_at"] = bson.M{"$gte": since}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "received_at", Value: -1}}).SetLimit(500)
+	cursor, err := r.messages.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find rental messages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*models.RentalMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode rental messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (r *RentalRepository) CreateIndex(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "rental_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "account_id", Value: 1}, {Key: "service", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "started_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}},
+		},
+	}
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	messageIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "rental_id", Value: 1}, {Key: "message_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "rental_id", Value: 1}, {Key: "received_at", Value: -1}},
+		},
+	}
+
+	if _, err := r.messages.Indexes().CreateMany(ctx, messageIndexes); err != nil {
+		return fmt.Errorf("failed to create message indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ScheduledPurchaseRepository struct {
+	collection *mongo.Collection
+	logger     logger.Logger
+}
+
+func NewScheduledPurchaseRepository(db *mongo.Database, logger logger.Logger) *ScheduledPurchaseRepository {
+	return &ScheduledPurchaseRepository{
+		collection: db.Collection("scheduled_purchases"),
+		logger:     logger,
+	}
+}
+
+func (r *ScheduledPurchaseRepository) Create(ctx context.Context, purchase *models.ScheduledPurchase) error {
+	purchase.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, purchase)
+	if err != nil {
+		return fmt.Errorf("failed to insert scheduled purchase: %w", err)
+	}
+
+	purchase.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *ScheduledPurchaseRepository) FindByPurchaseID(ctx context.Context, purchaseID string) (*models.ScheduledPurchase, error) {
+	var purchase models.ScheduledPurchase
+	err := r.collection.FindOne(ctx, bson.M{"purchase_id": purchaseID}).Decode(&purchase)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find scheduled purchase: %w", err)
+	}
+
+	return &purchase, nil
+}
+
+// Claim atomically moves a deferred purchase to executing, so a duplicate delivery of its
+// message does not buy twice. It returns nil when the purchase is not deferred anymore.
+func (r *ScheduledPurchaseRepository) Claim(ctx context.Context, purchaseID string) (*models.ScheduledPurchase, error) {
+	filter := bson.M{
+		"purchase_id": purchaseID,
+		"status":      models.ScheduledPurchaseDeferred,
+	}
+	update := bson.M{
+		"$set": bson.M{"status": models.ScheduledPurchaseExecuting},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var purchase models.ScheduledPurchase
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&purchase)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim scheduled purchase: %w", err)
+	}
+
+	return &purchase, nil
+}
+
+// Complete records the outcome of an executed purchase
+func (r *ScheduledPurchaseRepository) Complete(ctx context.Context, purchaseID string, status models.ScheduledPurchaseStatus, activationID, errMessage string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":        status,
+			"activation_id": activationID,
+			"error":         errMessage,
+			"completed_at":  time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"purchase_id": purchaseID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to complete scheduled purchase: %w", err)
+	}
+
+	return nil
+}
+
+// FindOverdue returns deferred purchases that should have run before the given time
+func (r *ScheduledPurchaseRepository) FindOverdue(ctx context.Context, before time.Time) ([]*models.ScheduledPurchase, error) {
+	filter := bson.M{
+		"status":     models.ScheduledPurchaseDeferred,
+		"execute_at": bson.M{"$lt": before},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetLimit(100))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find overdue scheduled purchases: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var purchases []*models.ScheduledPurchase
+	if err := cursor.All(ctx, &purchases); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduled purchases: %w", err)
+	}
+
+	return purchases, nil
+}
+
+// GetDeferralStats summarizes scheduled purchases created in the period
+func (r *ScheduledPurchaseRepository) GetDeferralStats(ctx context.Context, from, to time.Time) (*models.DeferralStats, error) {
+	match := bson.M{}
+	if !from.IsZero() || !to.IsZero() {
+		created := bson.M{}
+		if !from.IsZero() {
+			created["$gte"] = from
+		}
+		if !to.IsZero() {
+			created["$lte"] = to
+		}
+		match["created_at"] = created
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"deferred": "$deferred",
+				"status":   "$status",
+				"reason":   "$reason",
+				"provider": "$restock_provider",
+			},
+			"count": bson.M{"$sum": 1},
+			"deferral_ms": bson.M{
+				"$sum": bson.M{"$subtract": []interface{}{"$execute_at", "$created_at"}},
+			},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deferral statistics: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		ID struct {
+			Deferred bool                           `bson:"deferred"`
+			Status   models.ScheduledPurchaseStatus `bson:"status"`
+			Reason   string                         `bson:"reason"`
+			Provider string                         `bson:"provider"`
+		} `bson:"_id"`
+		Count      int64 `bson:"count"`
+		DeferralMs int64 `bson:"deferral_ms"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode deferral statistics: %w", err)
+	}
+
+	stats := &models.DeferralStats{
+		ByReason:   make(map[string]int64),
+		ByProvider: make(map[string]int64),
+	}
+	var deferralMs int64
+	for _, group := range groups {
+		stats.Total += group.Count
+		if !group.ID.Deferred {
+			continue
+		}
+
+		stats.Deferred += group.Count
+		deferralMs += group.DeferralMs
+		stats.ByReason[group.ID.Reason] += group.Count
+		if group.ID.Provider != "" {
+			stats.ByProvider[group.ID.Provider] += group.Count
+		}
+
+		switch group.ID.Status {
+		case models.ScheduledPurchaseDeferred, models.ScheduledPurchaseExecuting:
+			stats.Pending += group.Count
+		case models.ScheduledPurchaseFulfilled:
+			stats.FulfilledDeferred += group.Count
+		case models.ScheduledPurchaseFailed:
+			stats.FailedDeferred += group.Count
+		}
+	}
+	if stats.Deferred > 0 {
+		stats.AvgDeferralSeconds = float64(deferralMs) / float64(stats.Deferred) / 1000
+	}
+
+	return stats, nil
+}
+
+func (r *ScheduledPurchaseRepository) CreateIndex(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "purchase_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "execute_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxBatchQuantity     = 100
+	batchPurchaseWorkers = 5
+)
+
+var (
+	ErrInvalidQuantity = fmt.Errorf("quantity must be between 1 and %d", maxBatchQuantity)
+	ErrBatchExhausted  = errors.New("batch has no unclaimed numbers left")
+)
+
+// PurchaseNumbers acquires up to quantity numbers concurrently and reserves them against a
+// new batch ID. Numbers that could not be bought are reported per item instead of failing
+// the whole batch.
+func (s *SMSService) PurchaseNumbers(ctx context.Context, userID, service, country, operator string, quantity int, maxPrice int32) (*models.BatchPurchaseResult, error) {
+	if quantity < 1 || quantity > maxBatchQuantity {
+		return nil, ErrInvalidQuantity
+	}
+
+	result := &models.BatchPurchaseResult{
+		BatchID:     uuid.New().String(),
+		Requested:   quantity,
+		Activations: []*models.Activation{},
+		Failures:    []models.PurchaseFailure{},
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchPurchaseWorkers)
+
+	for i := 0; i < quantity; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				mu.Lock()
+				result.Failures = append(result.Failures, purchaseFailure(index, "", ctx.Err()))
+				mu.Unlock()
+				return
+			}
+
+			// Provider is left empty so each item is routed to the cheapest available provider
+			activation, provider, err := s.purchase(ctx, userID, service, country, operator, "", maxPrice, result.BatchID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failures = append(result.Failures, purchaseFailure(index, provider, err))
+				return
+			}
+			result.Activations = append(result.Activations, activation)
+		}(i)
+	}
+
+	wg.Wait()
+
+	result.Fulfilled = len(result.Activations)
+
+	s.logger.Infof("Batch %s for user %s: %d of %d numbers purchased",
+		result.BatchID, userID, result.Fulfilled, quantity)
+
+	return result, nil
+}
+
+// ClaimBatchNumber hands out the next reserved number of a batch. Each number is claimed once.
+func (s *SMSService) ClaimBatchNumber(ctx context.Context, batchID, userID string) (*models.Activation, error) {
+	activation, err := s.activationRepo.ClaimNextInBatch(ctx, batchID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if activation == nil {
+		return nil, ErrBatchExhausted
+	}
+
+	s.cache.SetActivation(ctx, activation.ActivationID, activation, time.Until(activation.ExpiresAt))
+
+	return activation, nil
+}
+
+func purchaseFailure(index int, provider string, err error) models.PurchaseFailure {
+	return models.PurchaseFailure{
+		Index:    index,
+		Provider: provider,
+		Code:     classifyPurchaseError(err),
+		Error:    err.Error(),
+	}
+}
+
+func classifyPurchaseError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return models.PurchaseFailureTimeout
+	}
+
+	// Provider statuses are passed through in the error text
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "NO_NUMBERS"):
+		return models.PurchaseFailureNoNumbers
+	case strings.Contains(message, "NO_BALANCE"):
+		return models.PurchaseFailureNoBalance
+	case strings.Contains(message, "WRONG_MAX_PRICE"):
+		return models.PurchaseFailurePriceExceeded
+	}
+	return models.PurchaseFailureProvider
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyPurchaseError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{fmt.Errorf("all 2 catalog providers failed: %w", errors.New("failed to get number: NO_NUMBERS")), models.PurchaseFailureNoNumbers},
+		{errors.New("failed to get number: NO_BALANCE"), models.PurchaseFailureNoBalance},
+		{errors.New("failed to get number: WRONG_MAX_PRICE:15"), models.PurchaseFailurePriceExceeded},
+		{fmt.Errorf("request failed: %w", context.DeadlineExceeded), models.PurchaseFailureTimeout},
+		{errors.New("unsupported provider: other"), models.PurchaseFailureProvider},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, classifyPurchaseError(tt.err), tt.err.Error())
+	}
+}
+
+func TestPurchaseNumbersRejectsInvalidQuantity(t *testing.T) {
+	s := &SMSService{}
+
+	for _, quantity := range []int{0, -1, maxBatchQuantity + 1} {
+		result, err := s.PurchaseNumbers(context.Background(), "user", "vk", "RU", "", quantity, 0)
+		assert.ErrorIs(t, err, ErrInvalidQuantity)
+		assert.Nil(t, result)
+	}
+}
+
+func TestPurchaseFailure(t *testing.T) {
+	failure := purchaseFailure(3, "smsactivate", errors.New("failed to get number: NO_NUMBERS"))
+
+	assert.Equal(t, 3, failure.Index)
+	assert.Equal(t, "smsactivate", failure.Provider)
+	assert.Equal(t, models.PurchaseFailureNoNumbers, failure.Code)
+	assert.Equal(t, "failed to get number: NO_NUMBERS", failure.Error)
+}
@@ -6,18 +6,18 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/sms-service/internal/models"
 
 	"github.com/go-redis/redis/v8"
-	"github.com/sirupsen/logrus"
 )
 
 type CacheService struct {
 	client *redis.Client
-	logger *logrus.Logger
+	logger logger.Logger
 }
 
-func NewCacheService(client *redis.Client, logger *logrus.Logger) *CacheService {
+func NewCacheService(client *redis.Client, logger logger.Logger) *CacheService {
 	return &CacheService{
 		client: client,
 		logger: logger,
@@ -57,6 +57,30 @@ func (s *CacheService) DeleteActivation(ctx context.Context, activationID string
 	return s.client.Del(ctx, key).Err()
 }
 
+// ReserveIdempotencyKey marks a purchase with the key as in progress. It returns false when
+// the key is already reserved or mapped to an activation.
+func (s *CacheService) ReserveIdempotencyKey(ctx context.Context, userID, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, idempotencyCacheKey(userID, key), idempotencyPending, ttl).Result()
+}
+
+// GetIdempotencyKey returns the activation ID the key maps to, idempotencyPending while the
+// purchase is running, or an empty string for an unknown key
+func (s *CacheService) GetIdempotencyKey(ctx context.Context, userID, key string) (string, error) {
+	value, err := s.client.Get(ctx, idempotencyCacheKey(userID, key)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return value, err
+}
+
+func (s *CacheService) SetIdempotencyKey(ctx context.Context, userID, key, activationID string, ttl time.Duration) error {
+	return s.client.Set(ctx, idempotencyCacheKey(userID, key), activationID, ttl).Err()
+}
+
+func (s *CacheService) ReleaseIdempotencyKey(ctx context.Context, userID, key string) error {
+	return s.client.Del(ctx, idempotencyCacheKey(userID, key)).Err()
+}
+
 func (s *CacheService) SetProviderBalance(ctx context.Context, provider string, balance float64, currency string, ttl time.Duration) error {
 	key := fmt.Sprintf("provider:balance:%s", provider)
 	value := fmt.Sprintf("%.2f:%s", balance, currency)
@@ -79,3 +103,31 @@ func (s *CacheService) GetProviderBalance(ctx context.Context, provider string)
 
 	return balance, currency, nil
 }
+
+func (s *CacheService) SetPrices(ctx context.Context, provider string, prices []models.ProviderPrice, ttl time.Duration) error {
+	data, err := json.Marshal(prices)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("provider:prices:%s", provider)
+	return s.client.Set(ctx, key, data, ttl).Err()
+}
+
+func (s *CacheService) GetPrices(ctx context.Context, provider string) ([]models.ProviderPrice, error) {
+	key := fmt.Sprintf("provider:prices:%s", provider)
+	data, err := s.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var prices []models.ProviderPrice
+	if err := json.Unmarshal([]byte(data), &prices); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
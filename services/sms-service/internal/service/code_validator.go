@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Anomalies a received SMS can be flagged with
+const (
+	AnomalyUnexpectedSender = "unexpected_sender"
+	AnomalyWrongService     = "wrong_service"
+	AnomalyPhishing         = "phishing"
+	AnomalyCodeFormat       = "code_format"
+)
+
+var ErrSuspiciousSMS = errors.New("received SMS failed validation")
+
+// CodeRule describes what a genuine SMS of a target service looks like
+type CodeRule struct {
+	// Senders match the sender name when the provider reports it
+	Senders *regexp.Regexp
+	// Brand matches the service name as it appears in the text
+	Brand *regexp.Regexp
+	// Code captures the code in its first group
+	Code *regexp.Regexp
+}
+
+// CodeCheck is the outcome of validating a received SMS
+type CodeCheck struct {
+	Code      string
+	Anomalies []string
+}
+
+func (c CodeCheck) Suspicious() bool {
+	return len(c.Anomalies) > 0
+}
+
+// codePattern matches a standalone number of min to max digits
+func codePattern(min, max string) *regexp.Regexp {
+	return regexp.MustCompile(`(?:^|\D)(\d{` + min + `,` + max + `})(?:\D|$)`)
+}
+
+var defaultCodeRules = map[string]CodeRule{
+	"vk": {
+		Senders: regexp.MustCompile(`(?i)^(vk|vkontakte|vk\.com)$`),
+		Brand:   regexp.MustCompile(`(?i)(\bvk\b|вконтакте)`),
+		Code:    codePattern("4", "6"),
+	},
+	"telegram": {
+		Senders: regexp.MustCompile(`(?i)^telegram$`),
+		Brand:   regexp.MustCompile(`(?i)(telegram|телеграм)`),
+		Code:    codePattern("5", "6"),
+	},
+	"mail.ru": {
+		Senders: regexp.MustCompile(`(?i)^(mail\.ru|mailru)$`),
+		Brand:   regexp.MustCompile(`(?i)mail\.ru`),
+		Code:    codePattern("4", "6"),
+	},
+	// MAX accounts sign in with VK ID, so its texts may name VK as well
+	"max": {
+		Senders: regexp.MustCompile(`(?i)^(max|vk)$`),
+		Brand:   regexp.MustCompile(`(?i)(\bmax\b|\bмакс\b|\bvk\b)`),
+		Code:    codePattern("4", "6"),
+	},
+}
+
+// fallbackCodePattern is used for services without a rule
+var fallbackCodePattern = codePattern("4", "8")
+
+// otherBrands names services that have no rule but often send codes to shared numbers
+var otherBrands = regexp.MustCompile(`(?i)(whatsapp|google|facebook|instagram|twitter|tiktok|ozon|wildberries|avito|yandex|яндекс|сбер|sber|тинькофф|tinkoff)`)
+
+// officialLinks are links genuine texts carry, e.g. Telegram login links
+var officialLinks = regexp.MustCompile(`(?i)https?://(t\.me|telegram\.org|vk\.com|id\.vk\.com|mail\.ru|max\.ru)(/\S*)?`)
+
+var phishingPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)https?://`),
+	regexp.MustCompile(`(?i)\b[a-z0-9-]+\.(xyz|top|site|online|click|link|info)\b`),
+	regexp.MustCompile(`(?i)(выигр|приз|бонус|компенсац|prize|you won|winner)`),
+	regexp.MustCompile(`(?i)(оплатит|переведите|карт[аыу]\s+заблокирован|card.{0,10}blocked)`),
+}
+
+// forwardRequest matches asking to pass the code on, with the preceding word to rule out "do not send"
+var forwardRequest = regexp.MustCompile(`(?i)(\S+\s+)?(перешлите|отправьте|сообщите|передайте|продиктуйте|send|forward|give|tell)\s+(?:\S+\s+){0,2}(?:код|code)`)
+
+var negations = map[string]bool{"не": true, "not": true, "never": true, "don't": true, "никому": true}
+
+// CodeValidator checks received SMS against the expectations of the target service
+type CodeValidator struct {
+	rules map[string]CodeRule
+}
+
+func NewCodeValidator() *CodeValidator {
+	return &CodeValidator{rules: defaultCodeRules}
+}
+
+// Validate extracts the code and lists anomalies. Sender may be empty when the provider doesn't report it.
+func (v *CodeValidator) Validate(service, sender, text string) CodeCheck {
+	var check CodeCheck
+
+	rule, known := v.rules[strings.ToLower(service)]
+	codeRe := fallbackCodePattern
+	if known {
+		codeRe = rule.Code
+	}
+
+	if match := codeRe.FindStringSubmatch(text); match != nil {
+		check.Code = match[1]
+	} else {
+		check.Anomalies = append(check.Anomalies, AnomalyCodeFormat)
+	}
+
+	if known && sender != "" && !rule.Senders.MatchString(strings.TrimSpace(sender)) {
+		check.Anomalies = append(check.Anomalies, AnomalyUnexpectedSender)
+	}
+
+	if known && !rule.Brand.MatchString(text) && v.mentionsOtherService(service, text) {
+		check.Anomalies = append(check.Anomalies, AnomalyWrongService)
+	}
+
+	if isPhishing(text) {
+		check.Anomalies = append(check.Anomalies, AnomalyPhishing)
+	}
+
+	return check
+}
+
+// mentionsOtherService reports whether the text names a service other than the target one
+func (v *CodeValidator) mentionsOtherService(service, text string) bool {
+	if otherBrands.MatchString(text) {
+		return true
+	}
+	for name, rule := range v.rules {
+		if name != strings.ToLower(service) && rule.Brand.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPhishing(text string) bool {
+	text = officialLinks.ReplaceAllString(text, "")
+
+	for _, pattern := range phishingPatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+
+	for _, match := range forwardRequest.FindAllStringSubmatch(text, -1) {
+		if !negations[strings.ToLower(strings.TrimSpace(match[1]))] {
+			return true
+		}
+	}
+
+	return false
+}
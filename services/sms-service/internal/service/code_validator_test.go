@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeValidatorGenuineSMS(t *testing.T) {
+	validator := NewCodeValidator()
+
+	tests := []struct {
+		service string
+		text    string
+		code    string
+	}{
+		{"vk", "VK: 482913 — код подтверждения. Никому не сообщайте его.", "482913"},
+		{"vk", "Ваш код для ВКонтакте: 5521", "5521"},
+		{"telegram", "Telegram code: 73215. Do not give this code to anyone, even if they say they are from Telegram!", "73215"},
+		{"telegram", "Telegram code 73215\n\nYou can also tap on this link to log in:\nhttps://t.me/login/73215", "73215"},
+		{"mail.ru", "Код подтверждения Mail.ru: 904412", "904412"},
+		{"max", "Код для входа в MAX: 118204", "118204"},
+		{"VK", "Код 123456", "123456"},
+		{"whatsapp", "Your WhatsApp code 123-456 is 12345678", "12345678"},
+	}
+
+	for _, tt := range tests {
+		check := validator.Validate(tt.service, "", tt.text)
+		assert.False(t, check.Suspicious(), "%s: %q flagged %v", tt.service, tt.text, check.Anomalies)
+		assert.Equal(t, tt.code, check.Code, tt.text)
+	}
+}
+
+func TestCodeValidatorAnomalies(t *testing.T) {
+	validator := NewCodeValidator()
+
+	tests := []struct {
+		name      string
+		service   string
+		sender    string
+		text      string
+		anomalies []string
+	}{
+		{
+			name:      "code of another platform",
+			service:   "vk",
+			text:      "Telegram code: 73215",
+			anomalies: []string{AnomalyWrongService},
+		},
+		{
+			name:      "code of an unrelated service",
+			service:   "telegram",
+			text:      "Код для входа в Яндекс ID: 48213",
+			anomalies: []string{AnomalyWrongService},
+		},
+		{
+			name:      "phishing link",
+			service:   "vk",
+			text:      "VK: аккаунт будет удалён, подтвердите 482913 на https://vk-secure.xyz/login",
+			anomalies: []string{AnomalyPhishing},
+		},
+		{
+			name:      "request to forward the code",
+			service:   "vk",
+			text:      "VK: перешлите этот код 482913 сотруднику поддержки",
+			anomalies: []string{AnomalyPhishing},
+		},
+		{
+			name:      "prize lure",
+			service:   "telegram",
+			text:      "Telegram: вы выиграли приз! Код 55213",
+			anomalies: []string{AnomalyPhishing},
+		},
+		{
+			name:      "no code in expected format",
+			service:   "telegram",
+			text:      "Telegram code: 1234",
+			anomalies: []string{AnomalyCodeFormat},
+		},
+		{
+			name:      "unexpected sender",
+			service:   "vk",
+			sender:    "Bank",
+			text:      "VK: 482913",
+			anomalies: []string{AnomalyUnexpectedSender},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := validator.Validate(tt.service, tt.sender, tt.text)
+			assert.Equal(t, tt.anomalies, check.Anomalies)
+		})
+	}
+}
+
+func TestCodeValidatorKnownSender(t *testing.T) {
+	check := NewCodeValidator().Validate("vk", "VK.com", "Код 482913")
+	assert.False(t, check.Suspicious())
+	assert.Equal(t, "482913", check.Code)
+}
+
+func TestSuspiciousSMSError(t *testing.T) {
+	err := suspiciousSMSError([]string{AnomalyPhishing, AnomalyWrongService})
+	assert.True(t, errors.Is(err, ErrSuspiciousSMS))
+	assert.Contains(t, err.Error(), "phishing, wrong_service")
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/streadway/amqp"
+)
+
+const (
+	smsEventsExchange     = "sms.events"
+	codeAnomalyRoutingKey = "sms.code.anomaly"
+
+	numberPurchasedRoutingKey     = "sms.number.purchased"
+	codeReceivedRoutingKey        = "sms.code.received"
+	activationCancelledRoutingKey = "sms.activation.cancelled"
+	activationExpiredRoutingKey   = "sms.activation.expired"
+
+	rentalStartedRoutingKey  = "sms.rental.started"
+	rentalRenewedRoutingKey  = "sms.rental.renewed"
+	rentalReleasedRoutingKey = "sms.rental.released"
+	rentalExpiringRoutingKey = "sms.rental.expiring"
+	rentalExpiredRoutingKey  = "sms.rental.expired"
+	rentalSMSRoutingKey      = "sms.rental.sms_received"
+)
+
+// CodeAnomalyEvent is published when a received SMS fails validation
+type CodeAnomalyEvent struct {
+	Type         string    `json:"type"`
+	ActivationID string    `json:"activation_id"`
+	UserID       string    `json:"user_id"`
+	Service      string    `json:"service"`
+	Country      string    `json:"country"`
+	Provider     string    `json:"provider"`
+	PhoneNumber  string    `json:"phone_number"`
+	Anomalies    []string  `json:"anomalies"`
+	FullSMS      string    `json:"full_sms"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// ActivationEvent is published on every step of the activation lifecycle, so consumers can
+// follow spending per provider without polling the API
+type ActivationEvent struct {
+	Type         string    `json:"type"`
+	ActivationID string    `json:"activation_id"`
+	UserID       string    `json:"user_id"`
+	BatchID      string    `json:"batch_id,omitempty"`
+	Service      string    `json:"service"`
+	Country      string    `json:"country"`
+	Provider     string    `json:"provider"`
+	PhoneNumber  string    `json:"phone_number"`
+	Price        float64   `json:"price"`
+	Cost         float64   `json:"cost"`
+	Refunded     bool      `json:"refunded"`
+	RefundAmount float64   `json:"refund_amount,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+	WaitSeconds  float64   `json:"wait_seconds,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// RentalEvent is published on every step of a rental. Cost is what the step charged: the rent
+// price on start and the renewal price on renewal.
+type RentalEvent struct {
+	Type        string    `json:"type"`
+	RentalID    string    `json:"rental_id"`
+	UserID      string    `json:"user_id"`
+	AccountID   string    `json:"account_id,omitempty"`
+	Service     string    `json:"service"`
+	Country     string    `json:"country"`
+	Provider    string    `json:"provider"`
+	PhoneNumber string    `json:"phone_number"`
+	Cost        float64   `json:"cost,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	HoursLeft   float64   `json:"hours_left,omitempty"`
+	Code        string    `json:"code,omitempty"`
+	Anomalies   []string  `json:"anomalies,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+type EventPublisher struct {
+	channel *amqp.Channel
+}
+
+func NewEventPublisher(channel *amqp.Channel) *EventPublisher {
+	return &EventPublisher{channel: channel}
+}
+
+func (p *EventPublisher) PublishCodeAnomaly(activation *models.Activation, anomalies []string) error {
+	return p.publish(codeAnomalyRoutingKey, CodeAnomalyEvent{
+		Type:         codeAnomalyRoutingKey,
+		ActivationID: activation.ActivationID,
+		UserID:       activation.UserID,
+		Service:      activation.Service,
+		Country:      activation.Country,
+		Provider:     activation.Provider,
+		PhoneNumber:  activation.PhoneNumber,
+		Anomalies:    anomalies,
+		FullSMS:      activation.FullSMS,
+		Timestamp:    time.Now(),
+	})
+}
+
+func (p *EventPublisher) PublishNumberPurchased(activation *models.Activation) error {
+	return p.publish(numberPurchasedRoutingKey, newActivationEvent(numberPurchasedRoutingKey, activation, "", time.Now()))
+}
+
+func (p *EventPublisher) PublishCodeReceived(activation *models.Activation) error {
+	return p.publish(codeReceivedRoutingKey, newActivationEvent(codeReceivedRoutingKey, activation, "", time.Now()))
+}
+
+func (p *EventPublisher) PublishActivationCancelled(activation *models.Activation, reason string) error {
+	return p.publish(activationCancelledRoutingKey, newActivationEvent(activationCancelledRoutingKey, activation, reason, time.Now()))
+}
+
+func (p *EventPublisher) PublishActivationExpired(activation *models.Activation) error {
+	return p.publish(activationExpiredRoutingKey, newActivationEvent(activationExpiredRoutingKey, activation, "", time.Now()))
+}
+
+func (p *EventPublisher) PublishRentalStarted(rental *models.Rental) error {
+	event := newRentalEvent(rentalStartedRoutingKey, rental, time.Now())
+	event.Cost = rental.Price
+	return p.publish(rentalStartedRoutingKey, event)
+}
+
+func (p *EventPublisher) PublishRentalRenewed(rental *models.Rental, cost float64) error {
+	event := newRentalEvent(rentalRenewedRoutingKey, rental, time.Now())
+	event.Cost = cost
+	return p.publish(rentalRenewedRoutingKey, event)
+}
+
+func (p *EventPublisher) PublishRentalReleased(rental *models.Rental) error {
+	return p.publish(rentalReleasedRoutingKey, newRentalEvent(rentalReleasedRoutingKey, rental, time.Now()))
+}
+
+func (p *EventPublisher) PublishRentalExpiring(rental *models.Rental) error {
+	return p.publish(rentalExpiringRoutingKey, newRentalEvent(rentalExpiringRoutingKey, rental, time.Now()))
+}
+
+func (p *EventPublisher) PublishRentalExpired(rental *models.Rental) error {
+	return p.publish(rentalExpiredRoutingKey, newRentalEvent(rentalExpiredRoutingKey, rental, time.Now()))
+}
+
+// PublishRentalSMS announces an SMS received by a rented number, so the platform service
+// waiting for a re-verification code does not have to poll
+func (p *EventPublisher) PublishRentalSMS(rental *models.Rental, message *models.RentalMessage) error {
+	event := newRentalEvent(rentalSMSRoutingKey, rental, time.Now())
+	event.Code = message.Code
+	event.Anomalies = message.Anomalies
+	return p.publish(rentalSMSRoutingKey, event)
+}
+
+func newRentalEvent(eventType string, rental *models.Rental, now time.Time) RentalEvent {
+	event := RentalEvent{
+		Type:        eventType,
+		RentalID:    rental.RentalID,
+		UserID:      rental.UserID,
+		AccountID:   rental.AccountID,
+		Service:     rental.Service,
+		Country:     rental.Country,
+		Provider:    rental.Provider,
+		PhoneNumber: rental.PhoneNumber,
+		ExpiresAt:   rental.ExpiresAt,
+		Timestamp:   now,
+	}
+
+	if rental.Status == models.RentalStatusActive && rental.ExpiresAt.After(now) {
+		event.HoursLeft = rental.ExpiresAt.Sub(now).Hours()
+	}
+
+	return event
+}
+
+// newActivationEvent snapshots the activation after a lifecycle step. Cost is what the step
+// leaves charged: the purchase price less the refund once the provider returned the money.
+func newActivationEvent(eventType string, activation *models.Activation, reason string, now time.Time) ActivationEvent {
+	event := ActivationEvent{
+		Type:         eventType,
+		ActivationID: activation.ActivationID,
+		UserID:       activation.UserID,
+		BatchID:      activation.BatchID,
+		Service:      activation.Service,
+		Country:      activation.Country,
+		Provider:     activation.Provider,
+		PhoneNumber:  activation.PhoneNumber,
+		Price:        activation.Price,
+		Cost:         activation.Price,
+		Refunded:     activation.Refunded,
+		Reason:       reason,
+		Timestamp:    now,
+	}
+
+	if activation.Refunded {
+		event.RefundAmount = activation.RefundAmount
+		event.Cost = math.Max(activation.Price-activation.RefundAmount, 0)
+	}
+
+	if activation.CodeReceivedAt != nil && !activation.CreatedAt.IsZero() {
+		event.WaitSeconds = activation.CodeReceivedAt.Sub(activation.CreatedAt).Seconds()
+	}
+
+	return event
+}
+
+func (p *EventPublisher) publish(routingKey string, event interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.channel.Publish(
+		smsEventsExchange,
+		routingKey,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         data,
+			DeliveryMode: amqp.Persistent,
+		},
+	)
+}
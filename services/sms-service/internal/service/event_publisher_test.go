@@ -0,0 +1,82 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestActivation() *models.Activation {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &models.Activation{
+		ActivationID: "12345",
+		UserID:       "user-1",
+		BatchID:      "batch-1",
+		PhoneNumber:  "79001234567",
+		Service:      "vk",
+		Country:      "RU",
+		Provider:     "smsactivate",
+		Price:        12.5,
+		CreatedAt:    createdAt,
+	}
+}
+
+func TestNewActivationEventPurchased(t *testing.T) {
+	now := time.Now()
+	event := newActivationEvent(numberPurchasedRoutingKey, newTestActivation(), "", now)
+
+	assert.Equal(t, "sms.number.purchased", event.Type)
+	assert.Equal(t, "smsactivate", event.Provider)
+	assert.Equal(t, "batch-1", event.BatchID)
+	assert.Equal(t, 12.5, event.Price)
+	assert.Equal(t, 12.5, event.Cost)
+	assert.False(t, event.Refunded)
+	assert.Zero(t, event.WaitSeconds)
+	assert.Equal(t, now, event.Timestamp)
+}
+
+func TestNewActivationEventCodeReceived(t *testing.T) {
+	activation := newTestActivation()
+	receivedAt := activation.CreatedAt.Add(90 * time.Second)
+	activation.CodeReceivedAt = &receivedAt
+
+	event := newActivationEvent(codeReceivedRoutingKey, activation, "", time.Now())
+	assert.Equal(t, 12.5, event.Cost)
+	assert.InDelta(t, 90, event.WaitSeconds, 1e-9)
+}
+
+func TestNewActivationEventCancelled(t *testing.T) {
+	activation := newTestActivation()
+	activation.Refunded = true
+	activation.RefundAmount = 10
+
+	event := newActivationEvent(activationCancelledRoutingKey, activation, "registration aborted", time.Now())
+	assert.True(t, event.Refunded)
+	assert.Equal(t, 10.0, event.RefundAmount)
+	assert.InDelta(t, 2.5, event.Cost, 1e-9)
+	assert.Equal(t, "registration aborted", event.Reason)
+
+	// The provider never returns more than was paid
+	activation.RefundAmount = 20
+	event = newActivationEvent(activationCancelledRoutingKey, activation, "", time.Now())
+	assert.Zero(t, event.Cost)
+}
+
+func TestActivationEventSerialization(t *testing.T) {
+	event := newActivationEvent(activationExpiredRoutingKey, newTestActivation(), "", time.Now())
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &payload))
+	assert.Equal(t, "sms.activation.expired", payload["type"])
+	assert.Equal(t, 12.5, payload["cost"])
+	assert.NotContains(t, payload, "refund_amount")
+	assert.NotContains(t, payload, "reason")
+}
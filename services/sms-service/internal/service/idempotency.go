@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+)
+
+const (
+	defaultIdempotencyTTL = 24 * time.Hour
+	// idempotencyLockTTL bounds how long a crashed purchase keeps its key reserved
+	idempotencyLockTTL = 2 * time.Minute
+	idempotencyPending = "pending"
+)
+
+// Outcomes of a duplicate idempotency key
+const (
+	dedupOutcomeReplayed   = "replayed"
+	dedupOutcomeInProgress = "in_progress"
+	dedupOutcomeConflict   = "conflict"
+)
+
+var (
+	ErrPurchaseInProgress     = errors.New("purchase with this idempotency key is already in progress")
+	ErrIdempotencyKeyConflict = errors.New("idempotency key was already used for a different purchase")
+)
+
+func idempotencyCacheKey(userID, key string) string {
+	return fmt.Sprintf("idempotency:purchase:%s:%s", userID, key)
+}
+
+// purchaseRequestHash identifies the parameters a key was first used with, so a reused key
+// cannot replay the number of a different service or country
+func purchaseRequestHash(service, country, operator string) string {
+	sum := sha256.Sum256([]byte(service + "\x00" + country + "\x00" + operator))
+	return hex.EncodeToString(sum[:])
+}
+
+// isLiveActivation reports whether the number of an activation can still receive codes.
+// Duplicates of a cancelled or expired purchase buy a new number instead of replaying it.
+func isLiveActivation(activation *models.Activation) bool {
+	switch activation.Status {
+	case models.ActivationStatusPending, models.ActivationStatusWaiting, models.ActivationStatusReceived:
+		return time.Now().Before(activation.ExpiresAt)
+	}
+	return false
+}
+
+// SetIdempotencyTTL sets how long a purchase can be replayed by its idempotency key
+func (s *SMSService) SetIdempotencyTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.idempotencyTTL = ttl
+	}
+}
+
+// purchaseIdempotent buys a number once per user and key. A repeated key returns the original
+// activation while it is live, a duplicate racing the first request gets ErrPurchaseInProgress
+// and a key reused with other service, country or operator gets ErrIdempotencyKeyConflict.
+func (s *SMSService) purchaseIdempotent(ctx context.Context, userID, service, country, operator, provider string, maxPrice int32, key string) (*models.Activation, bool, error) {
+	requestHash := purchaseRequestHash(service, country, operator)
+
+	original, err := s.findIdempotentActivation(ctx, userID, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if original != nil {
+		// Activations bought before the hash was stored have none and are not checked
+		if original.IdempotencyHash != "" && original.IdempotencyHash != requestHash {
+			s.metrics.IncrementPurchaseDeduplicated(service, dedupOutcomeConflict)
+			return nil, false, ErrIdempotencyKeyConflict
+		}
+		if isLiveActivation(original) {
+			s.metrics.IncrementPurchaseDeduplicated(service, dedupOutcomeReplayed)
+			s.logger.Infof("Replaying activation %s for idempotency key %s", original.ActivationID, key)
+			return original, true, nil
+		}
+		if err := s.cache.ReleaseIdempotencyKey(ctx, userID, key); err != nil {
+			return nil, false, fmt.Errorf("failed to release idempotency key: %w", err)
+		}
+	}
+
+	reserved, err := s.cache.ReserveIdempotencyKey(ctx, userID, key, idempotencyLockTTL)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if !reserved {
+		s.metrics.IncrementPurchaseDeduplicated(service, dedupOutcomeInProgress)
+		return nil, false, ErrPurchaseInProgress
+	}
+
+	activation, _, err := s.purchase(ctx, userID, service, country, operator, provider, maxPrice, "")
+	if err != nil {
+		if releaseErr := s.cache.ReleaseIdempotencyKey(ctx, userID, key); releaseErr != nil {
+			s.logger.Warnf("Failed to release idempotency key %s: %v", key, releaseErr)
+		}
+		return nil, false, err
+	}
+
+	activation.IdempotencyKey = key
+	activation.IdempotencyHash = requestHash
+	if err := s.activationRepo.SetIdempotencyKey(ctx, activation.ActivationID, key, requestHash); err != nil {
+		s.logger.Warnf("Failed to store idempotency key of activation %s: %v", activation.ActivationID, err)
+	}
+	if err := s.cache.SetIdempotencyKey(ctx, userID, key, activation.ActivationID, s.idempotencyTTL); err != nil {
+		s.logger.Warnf("Failed to cache idempotency key of activation %s: %v", activation.ActivationID, err)
+	}
+
+	return activation, false, nil
+}
+
+// findIdempotentActivation looks the key up in Redis and falls back to Mongo when Redis lost it
+func (s *SMSService) findIdempotentActivation(ctx context.Context, userID, key string) (*models.Activation, error) {
+	activationID, err := s.cache.GetIdempotencyKey(ctx, userID, key)
+	if err != nil {
+		s.logger.Warnf("Failed to read idempotency key %s from cache: %v", key, err)
+	}
+
+	switch activationID {
+	case idempotencyPending:
+		return nil, nil
+	case "":
+		return s.activationRepo.FindByIdempotencyKey(ctx, userID, key, time.Now().Add(-s.idempotencyTTL))
+	default:
+		return s.activationRepo.FindByActivationID(ctx, activationID)
+	}
+}
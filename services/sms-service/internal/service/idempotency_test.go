@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLiveActivation(t *testing.T) {
+	future := time.Now().Add(10 * time.Minute)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		status   models.ActivationStatus
+		expires  time.Time
+		expected bool
+	}{
+		{models.ActivationStatusWaiting, future, true},
+		{models.ActivationStatusPending, future, true},
+		{models.ActivationStatusReceived, future, true},
+		{models.ActivationStatusWaiting, past, false},
+		{models.ActivationStatusCancelled, future, false},
+		{models.ActivationStatusExpired, future, false},
+		{models.ActivationStatusCompleted, future, false},
+		{models.ActivationStatusFlagged, future, false},
+	}
+
+	for _, tt := range tests {
+		activation := &models.Activation{Status: tt.status, ExpiresAt: tt.expires}
+		assert.Equal(t, tt.expected, isLiveActivation(activation), string(tt.status))
+	}
+}
+
+func TestIdempotencyCacheKeyIsScopedToUser(t *testing.T) {
+	assert.NotEqual(t, idempotencyCacheKey("user1", "vk:registration:1"), idempotencyCacheKey("user2", "vk:registration:1"))
+	assert.Equal(t, "idempotency:purchase:user1:key", idempotencyCacheKey("user1", "key"))
+}
+
+func TestSetIdempotencyTTLIgnoresNonPositive(t *testing.T) {
+	s := &SMSService{idempotencyTTL: defaultIdempotencyTTL}
+
+	s.SetIdempotencyTTL(0)
+	assert.Equal(t, defaultIdempotencyTTL, s.idempotencyTTL)
+
+	s.SetIdempotencyTTL(time.Hour)
+	assert.Equal(t, time.Hour, s.idempotencyTTL)
+}
+
+func TestPurchaseRequestHash(t *testing.T) {
+	hash := purchaseRequestHash("vk", "ru", "mts")
+
+	assert.Equal(t, hash, purchaseRequestHash("vk", "ru", "mts"))
+	assert.NotEqual(t, hash, purchaseRequestHash("tg", "ru", "mts"))
+	assert.NotEqual(t, hash, purchaseRequestHash("vk", "kz", "mts"))
+	assert.NotEqual(t, hash, purchaseRequestHash("vk", "ru", "beeline"))
+	// Fields are separated, moving text between them changes the hash
+	assert.NotEqual(t, purchaseRequestHash("vk", "ru", ""), purchaseRequestHash("vkr", "u", ""))
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -12,6 +14,19 @@ type MetricsCollector struct {
 	codeReceived      *prometheus.CounterVec
 	cancellations     *prometheus.CounterVec
 	activationDuration *prometheus.HistogramVec
+	catalogPrice      *prometheus.GaugeVec
+	priceRatio        *prometheus.HistogramVec
+	codeAnomalies     *prometheus.CounterVec
+	scheduledPurchases *prometheus.CounterVec
+	deferredPurchases  *prometheus.CounterVec
+	deferredOutcomes   *prometheus.CounterVec
+	deferralDuration   *prometheus.HistogramVec
+	dedupedPurchases   *prometheus.CounterVec
+	rentalOperations   *prometheus.CounterVec
+	rentalSpend        *prometheus.CounterVec
+	rentalMessages     *prometheus.CounterVec
+	providerHealth     *prometheus.GaugeVec
+	providerQuarantine *prometheus.GaugeVec
 }
 
 func NewMetricsCollector() *MetricsCollector {
@@ -60,6 +75,99 @@ func NewMetricsCollector() *MetricsCollector {
 			},
 			[]string{"provider", "service"},
 		),
+		catalogPrice: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sms_catalog_price",
+				Help: "Current catalog price per provider, service and country",
+			},
+			[]string{"provider", "service", "country"},
+		),
+		priceRatio: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "sms_realized_price_ratio",
+				Help:    "Ratio of realized purchase price to catalog price",
+				Buckets: []float64{0.5, 0.8, 0.9, 1, 1.1, 1.25, 1.5, 2, 3},
+			},
+			[]string{"provider", "service"},
+		),
+		codeAnomalies: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sms_code_anomalies_total",
+				Help: "Total number of received SMS flagged by validation",
+			},
+			[]string{"provider", "service", "anomaly"},
+		),
+		scheduledPurchases: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sms_scheduled_purchases_total",
+				Help: "Total number of purchases submitted to the restock-aware scheduler",
+			},
+			[]string{"deferred"},
+		),
+		deferredPurchases: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sms_purchases_deferred_total",
+				Help: "Total number of purchases deferred to a post-restock window",
+			},
+			[]string{"provider", "country", "reason"},
+		),
+		deferredOutcomes: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sms_deferred_purchase_outcomes_total",
+				Help: "Total number of executed deferred purchases by outcome",
+			},
+			[]string{"outcome"},
+		),
+		deferralDuration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "sms_purchase_deferral_seconds",
+				Help:    "How long deferred purchases wait for the restock window",
+				Buckets: []float64{60, 300, 900, 1800, 3600, 7200, 14400, 21600},
+			},
+			[]string{"reason"},
+		),
+		dedupedPurchases: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sms_purchases_deduplicated_total",
+				Help: "Total number of purchase requests answered with the activation of an earlier request with the same idempotency key",
+			},
+			[]string{"service", "outcome"},
+		),
+		rentalOperations: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sms_rental_operations_total",
+				Help: "Total number of rental operations by outcome",
+			},
+			[]string{"provider", "operation", "outcome"},
+		),
+		rentalSpend: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sms_rental_spend_total",
+				Help: "Total amount paid for rented numbers, renewals included",
+			},
+			[]string{"provider", "service"},
+		),
+		rentalMessages: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sms_rental_messages_total",
+				Help: "Total number of SMS received by rented numbers",
+			},
+			[]string{"provider", "service", "suspicious"},
+		),
+		providerHealth: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sms_provider_health_score",
+				Help: "Current provider health score from 0 to 100",
+			},
+			[]string{"provider"},
+		),
+		providerQuarantine: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sms_provider_quarantined",
+				Help: "Whether the provider is quarantined for a low health score (1) or not (0)",
+			},
+			[]string{"provider"},
+		),
 	}
 }
 
@@ -90,3 +198,60 @@ func (m *MetricsCollector) IncrementCancellation(provider, service string, refun
 func (m *MetricsCollector) RecordActivationDuration(provider, service string, duration float64) {
 	m.activationDuration.WithLabelValues(provider, service).Observe(duration)
 }
+
+func (m *MetricsCollector) SetCatalogPrice(provider, service, country string, price float64) {
+	m.catalogPrice.WithLabelValues(provider, service, country).Set(price)
+}
+
+func (m *MetricsCollector) RecordRealizedPrice(provider, service string, realized, catalog float64) {
+	if catalog <= 0 {
+		return
+	}
+	m.priceRatio.WithLabelValues(provider, service).Observe(realized / catalog)
+}
+
+func (m *MetricsCollector) IncrementCodeAnomaly(provider, service, anomaly string) {
+	m.codeAnomalies.WithLabelValues(provider, service, anomaly).Inc()
+}
+
+func (m *MetricsCollector) IncrementScheduledPurchase(deferred bool) {
+	m.scheduledPurchases.WithLabelValues(strconv.FormatBool(deferred)).Inc()
+}
+
+func (m *MetricsCollector) RecordDeferral(provider, country, reason string, delay float64) {
+	m.deferredPurchases.WithLabelValues(provider, country, reason).Inc()
+	m.deferralDuration.WithLabelValues(reason).Observe(delay)
+}
+
+func (m *MetricsCollector) IncrementDeferredOutcome(outcome string) {
+	m.deferredOutcomes.WithLabelValues(outcome).Inc()
+}
+
+func (m *MetricsCollector) IncrementPurchaseDeduplicated(service, outcome string) {
+	m.dedupedPurchases.WithLabelValues(service, outcome).Inc()
+}
+
+// IncrementRentalOperation counts rent, renew, release, expiry alert and expiry operations
+func (m *MetricsCollector) IncrementRentalOperation(provider, operation, outcome string) {
+	m.rentalOperations.WithLabelValues(provider, operation, outcome).Inc()
+}
+
+func (m *MetricsCollector) RecordRentalSpend(provider, service string, amount float64) {
+	if amount <= 0 {
+		return
+	}
+	m.rentalSpend.WithLabelValues(provider, service).Add(amount)
+}
+
+func (m *MetricsCollector) IncrementRentalMessage(provider, service string, suspicious bool) {
+	m.rentalMessages.WithLabelValues(provider, service, strconv.FormatBool(suspicious)).Inc()
+}
+
+func (m *MetricsCollector) SetProviderHealth(provider string, score float64, quarantined bool) {
+	m.providerHealth.WithLabelValues(provider).Set(score)
+	value := 0.0
+	if quarantined {
+		value = 1
+	}
+	m.providerQuarantine.WithLabelValues(provider).Set(value)
+}
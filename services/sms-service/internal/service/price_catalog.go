@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+	"github.com/grigta/conveer/services/sms-service/internal/repository"
+)
+
+// PriceFetcher loads the current price list of a provider
+type PriceFetcher func(ctx context.Context) ([]models.ProviderPrice, error)
+
+// RoutingConstraints filter catalog entries before the cheapest one is picked
+type RoutingConstraints struct {
+	MinAvailable   int           // Numbers the provider must have in stock
+	MinSuccessRate float64       // Required share of recent activations that received a code
+	MinSamples     int           // Success rate is only enforced once this many activations were seen
+	SuccessWindow  time.Duration // How far back activations count towards the success rate
+}
+
+// PriceCatalog keeps provider price lists per service and country and ranks providers by cost
+type PriceCatalog struct {
+	mu              sync.RWMutex
+	fetchers        map[string]PriceFetcher
+	prices          map[string][]models.ProviderPrice
+	activationRepo  *repository.ActivationRepository
+	cache           *CacheService
+	metrics         *MetricsCollector
+	restock         []models.RestockSchedule
+	constraints     RoutingConstraints
+	health          *ProviderHealthTracker
+	refreshInterval time.Duration
+	logger          logger.Logger
+}
+
+func NewPriceCatalog(
+	activationRepo *repository.ActivationRepository,
+	cache *CacheService,
+	metrics *MetricsCollector,
+	constraints RoutingConstraints,
+	refreshInterval time.Duration,
+	logger logger.Logger,
+) *PriceCatalog {
+	if refreshInterval <= 0 {
+		refreshInterval = 10 * time.Minute
+	}
+	if constraints.SuccessWindow <= 0 {
+		constraints.SuccessWindow = 24 * time.Hour
+	}
+
+	return &PriceCatalog{
+		fetchers:        make(map[string]PriceFetcher),
+		prices:          make(map[string][]models.ProviderPrice),
+		activationRepo:  activationRepo,
+		cache:           cache,
+		metrics:         metrics,
+		constraints:     constraints,
+		refreshInterval: refreshInterval,
+		logger:          logger,
+	}
+}
+
+// RegisterProvider adds a provider whose prices are tracked by the catalog
+func (c *PriceCatalog) RegisterProvider(provider string, fetcher PriceFetcher) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetchers[provider] = fetcher
+}
+
+// SetRestockSchedules replaces the known restock times of providers
+func (c *PriceCatalog) SetRestockSchedules(schedules []models.RestockSchedule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.restock = schedules
+}
+
+// SetHealth makes candidate ranking demote quarantined providers and prefer healthier ones at equal price
+func (c *PriceCatalog) SetHealth(health *ProviderHealthTracker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.health = health
+}
+
+// NextRestock returns the next time the provider refills its stock in the country. Without a
+// matching schedule it reports false.
+func (c *PriceCatalog) NextRestock(provider, country string, now time.Time) (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return nextRestock(c.restock, provider, country, now)
+}
+
+// Start refreshes the catalog immediately and then on every refresh interval
+func (c *PriceCatalog) Start(ctx context.Context) {
+	c.Refresh(ctx)
+
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Refresh(ctx)
+		}
+	}
+}
+
+// Refresh reloads all provider price lists. A provider that fails to respond keeps
+// its previous prices, or the last cached ones after a restart.
+func (c *PriceCatalog) Refresh(ctx context.Context) {
+	c.mu.RLock()
+	fetchers := make(map[string]PriceFetcher, len(c.fetchers))
+	for provider, fetcher := range c.fetchers {
+		fetchers[provider] = fetcher
+	}
+	c.mu.RUnlock()
+
+	rates := c.loadSuccessRates(ctx)
+
+	for provider, fetcher := range fetchers {
+		prices, err := fetcher(ctx)
+		if err != nil {
+			c.logger.Errorf("Failed to fetch prices from %s: %v", provider, err)
+			c.restoreFromCache(ctx, provider)
+			continue
+		}
+
+		normalizePrices(prices)
+		applySuccessRates(prices, rates)
+
+		c.mu.Lock()
+		c.prices[provider] = prices
+		c.mu.Unlock()
+
+		if c.cache != nil {
+			if err := c.cache.SetPrices(ctx, provider, prices, 24*time.Hour); err != nil {
+				c.logger.Warnf("Failed to cache prices of %s: %v", provider, err)
+			}
+		}
+
+		for _, price := range prices {
+			c.metrics.SetCatalogPrice(price.Provider, price.Service, price.Country, price.Price)
+		}
+
+		c.logger.Infof("Refreshed %d catalog prices from %s", len(prices), provider)
+	}
+}
+
+func (c *PriceCatalog) restoreFromCache(ctx context.Context, provider string) {
+	c.mu.RLock()
+	_, loaded := c.prices[provider]
+	c.mu.RUnlock()
+
+	if loaded || c.cache == nil {
+		return
+	}
+
+	prices, err := c.cache.GetPrices(ctx, provider)
+	if err != nil || len(prices) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.prices[provider] = prices
+	c.mu.Unlock()
+
+	c.logger.Infof("Restored %d cached catalog prices for %s", len(prices), provider)
+}
+
+func (c *PriceCatalog) loadSuccessRates(ctx context.Context) map[string]models.ProviderSuccessRate {
+	rates := make(map[string]models.ProviderSuccessRate)
+	if c.activationRepo == nil {
+		return rates
+	}
+
+	stats, err := c.activationRepo.GetSuccessRates(ctx, time.Now().Add(-c.constraints.SuccessWindow))
+	if err != nil {
+		c.logger.Warnf("Failed to load provider success rates: %v", err)
+		return rates
+	}
+
+	for _, rate := range stats {
+		key := priceKey(rate.Provider, rate.Service, rate.Country)
+		// Merge groups that only differ in letter case
+		existing := rates[key]
+		existing.Total += rate.Total
+		existing.Received += rate.Received
+		rates[key] = existing
+	}
+
+	return rates
+}
+
+// GetPrices returns catalog entries matching the filters, cheapest first. Empty filters match everything.
+func (c *PriceCatalog) GetPrices(service, country, provider string) []models.ProviderPrice {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	service = strings.ToLower(service)
+	country = strings.ToUpper(country)
+	now := time.Now()
+
+	var result []models.ProviderPrice
+	for name, prices := range c.prices {
+		if provider != "" && name != provider {
+			continue
+		}
+		for _, price := range prices {
+			if service != "" && price.Service != service {
+				continue
+			}
+			if country != "" && price.Country != country {
+				continue
+			}
+			if restock, ok := nextRestock(c.restock, price.Provider, price.Country, now); ok {
+				price.NextRestock = &restock
+			}
+			result = append(result, price)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Price != result[j].Price {
+			return result[i].Price < result[j].Price
+		}
+		return result[i].Provider < result[j].Provider
+	})
+
+	return result
+}
+
+// Candidates returns providers able to sell a number for the service and country, cheapest first.
+// Quarantined providers come last so they are only tried when everyone else failed.
+func (c *PriceCatalog) Candidates(service, country string, maxPrice float64) []models.ProviderPrice {
+	candidates := rankCandidates(c.GetPrices(service, country, ""), maxPrice, c.constraints)
+
+	c.mu.RLock()
+	health := c.health
+	c.mu.RUnlock()
+
+	if health != nil {
+		orderByHealth(candidates, health)
+	}
+	return candidates
+}
+
+// CatalogPrice returns the current catalog price of a provider for the service and country
+func (c *PriceCatalog) CatalogPrice(provider, service, country string) (float64, bool) {
+	prices := c.GetPrices(service, country, provider)
+	if len(prices) == 0 {
+		return 0, false
+	}
+	return prices[0].Price, true
+}
+
+// rankCandidates drops entries violating the constraints and orders the rest by price,
+// preferring the higher success rate when prices are equal
+func rankCandidates(prices []models.ProviderPrice, maxPrice float64, constraints RoutingConstraints) []models.ProviderPrice {
+	var candidates []models.ProviderPrice
+	for _, price := range prices {
+		if maxPrice > 0 && price.Price > maxPrice {
+			continue
+		}
+		if price.Available < constraints.MinAvailable || price.Available == 0 {
+			continue
+		}
+		if price.Samples >= constraints.MinSamples && price.Samples > 0 && price.SuccessRate < constraints.MinSuccessRate {
+			continue
+		}
+		candidates = append(candidates, price)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Price != candidates[j].Price {
+			return candidates[i].Price < candidates[j].Price
+		}
+		return candidates[i].SuccessRate > candidates[j].SuccessRate
+	})
+
+	return candidates
+}
+
+// orderByHealth moves quarantined providers to the end and breaks price ties by health score,
+// entries that are still equal keep their order
+func orderByHealth(candidates []models.ProviderPrice, health *ProviderHealthTracker) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		qi, qj := health.Quarantined(candidates[i].Provider), health.Quarantined(candidates[j].Provider)
+		if qi != qj {
+			return !qi
+		}
+		if candidates[i].Price != candidates[j].Price {
+			return candidates[i].Price < candidates[j].Price
+		}
+		return health.Score(candidates[i].Provider) > health.Score(candidates[j].Provider)
+	})
+}
+
+// nextRestock picks the earliest upcoming restock among the schedules covering the provider and country
+func nextRestock(schedules []models.RestockSchedule, provider, country string, now time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, schedule := range schedules {
+		if schedule.Provider != provider || !schedule.Covers(country) {
+			continue
+		}
+		at, err := schedule.Next(now)
+		if err != nil || at.IsZero() {
+			continue
+		}
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+	return next, !next.IsZero()
+}
+
+func applySuccessRates(prices []models.ProviderPrice, rates map[string]models.ProviderSuccessRate) {
+	for i := range prices {
+		rate, ok := rates[priceKey(prices[i].Provider, prices[i].Service, prices[i].Country)]
+		if !ok {
+			continue
+		}
+		prices[i].SuccessRate = rate.Rate()
+		prices[i].Samples = rate.Total
+	}
+}
+
+func normalizePrices(prices []models.ProviderPrice) {
+	for i := range prices {
+		prices[i].Service = strings.ToLower(prices[i].Service)
+		prices[i].Country = strings.ToUpper(prices[i].Country)
+	}
+}
+
+func priceKey(provider, service, country string) string {
+	return fmt.Sprintf("%s:%s:%s", provider, strings.ToLower(service), strings.ToUpper(country))
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankCandidates(t *testing.T) {
+	constraints := RoutingConstraints{MinAvailable: 5, MinSuccessRate: 0.5, MinSamples: 10}
+
+	prices := []models.ProviderPrice{
+		{Provider: "expensive", Price: 30, Available: 100, SuccessRate: 0.9, Samples: 50},
+		{Provider: "cheap-unreliable", Price: 5, Available: 100, SuccessRate: 0.2, Samples: 50},
+		{Provider: "cheap-new", Price: 8, Available: 100, SuccessRate: 0.1, Samples: 3},
+		{Provider: "out-of-stock", Price: 6, Available: 2, SuccessRate: 0.9, Samples: 50},
+		{Provider: "reliable", Price: 8, Available: 100, SuccessRate: 0.8, Samples: 50},
+	}
+
+	candidates := rankCandidates(prices, 0, constraints)
+	require.Len(t, candidates, 3)
+	// Equal price prefers the higher success rate; too few samples do not disqualify
+	assert.Equal(t, "reliable", candidates[0].Provider)
+	assert.Equal(t, "cheap-new", candidates[1].Provider)
+	assert.Equal(t, "expensive", candidates[2].Provider)
+
+	capped := rankCandidates(prices, 10, constraints)
+	require.Len(t, capped, 2)
+	for _, candidate := range capped {
+		assert.LessOrEqual(t, candidate.Price, 10.0)
+	}
+}
+
+func TestApplySuccessRates(t *testing.T) {
+	prices := []models.ProviderPrice{
+		{Provider: "smsactivate", Service: "vk", Country: "RU"},
+		{Provider: "smsactivate", Service: "telegram", Country: "KZ"},
+	}
+	rates := map[string]models.ProviderSuccessRate{
+		priceKey("smsactivate", "VK", "ru"): {Total: 40, Received: 30},
+	}
+
+	applySuccessRates(prices, rates)
+
+	assert.Equal(t, 0.75, prices[0].SuccessRate)
+	assert.Equal(t, 40, prices[0].Samples)
+	assert.Zero(t, prices[1].Samples)
+}
+
+func TestPriceCatalogGetPrices(t *testing.T) {
+	catalog := NewPriceCatalog(nil, nil, nil, RoutingConstraints{MinAvailable: 1}, 0, logger.NewFromConfig(logger.Config{Output: io.Discard}))
+	catalog.prices["a"] = []models.ProviderPrice{
+		{Provider: "a", Service: "vk", Country: "RU", Price: 15, Available: 10},
+		{Provider: "a", Service: "vk", Country: "KZ", Price: 9, Available: 10},
+	}
+	catalog.prices["b"] = []models.ProviderPrice{
+		{Provider: "b", Service: "vk", Country: "RU", Price: 11, Available: 0},
+	}
+
+	prices := catalog.GetPrices("VK", "ru", "")
+	require.Len(t, prices, 2)
+	assert.Equal(t, "b", prices[0].Provider)
+	assert.Equal(t, "a", prices[1].Provider)
+
+	// Provider without numbers in stock is skipped by routing
+	candidates := catalog.Candidates("vk", "RU", 0)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "a", candidates[0].Provider)
+
+	price, ok := catalog.CatalogPrice("a", "vk", "KZ")
+	assert.True(t, ok)
+	assert.Equal(t, 9.0, price)
+
+	_, ok = catalog.CatalogPrice("b", "vk", "KZ")
+	assert.False(t, ok)
+}
+
+func TestPriceCatalogNextRestock(t *testing.T) {
+	catalog := NewPriceCatalog(nil, nil, nil, RoutingConstraints{}, 0, logger.NewFromConfig(logger.Config{Output: io.Discard}))
+	catalog.SetRestockSchedules([]models.RestockSchedule{
+		{Provider: "a", Timezone: "Europe/Moscow", Times: []string{"00:00", "12:00"}},
+		{Provider: "a", Countries: []string{"KZ"}, Timezone: "Asia/Almaty", Times: []string{"09:30"}},
+	})
+	catalog.prices["a"] = []models.ProviderPrice{
+		{Provider: "a", Service: "vk", Country: "KZ", Price: 9, Available: 10},
+	}
+
+	// 11:00 in Moscow, 13:00 in Almaty
+	now := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+
+	restock, ok := catalog.NextRestock("a", "RU", now)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), restock.UTC())
+
+	// The Moscow noon restock comes before the next Almaty one
+	restock, ok = catalog.NextRestock("a", "kz", now)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), restock.UTC())
+
+	// Right at the Moscow midnight restock, which no longer counts
+	midnight := time.Date(2024, 1, 15, 21, 0, 0, 0, time.UTC)
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	restock, ok = catalog.NextRestock("a", "KZ", midnight)
+	require.True(t, ok)
+	assert.True(t, time.Date(2024, 1, 16, 9, 30, 0, 0, almaty).Equal(restock))
+
+	// The KZ-only schedule does not apply to other countries
+	restock, ok = catalog.NextRestock("a", "RU", midnight)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC), restock.UTC())
+
+	_, ok = catalog.NextRestock("b", "RU", now)
+	assert.False(t, ok)
+
+	prices := catalog.GetPrices("vk", "KZ", "")
+	require.Len(t, prices, 1)
+	require.NotNil(t, prices[0].NextRestock)
+}
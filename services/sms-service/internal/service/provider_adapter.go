@@ -2,33 +2,35 @@ package service
 
 import (
 	"math/rand"
+	"sort"
 	"time"
 
-	"github.com/sirupsen/logrus"
+	"github.com/grigta/conveer/pkg/logger"
 )
 
 type ProviderAdapter struct {
-	logger    *logrus.Logger
+	logger    logger.Logger
 	providers map[string]ProviderConfig
+	health    *ProviderHealthTracker
 }
 
 type ProviderConfig struct {
-	Name     string
-	Priority int
-	Services []string
+	Name      string
+	Priority  int
+	Services  []string
 	Countries []string
-	Enabled  bool
+	Enabled   bool
 }
 
-func NewProviderAdapter(logger *logrus.Logger) *ProviderAdapter {
+func NewProviderAdapter(logger logger.Logger) *ProviderAdapter {
 	// Default configuration - should be loaded from config file
 	providers := map[string]ProviderConfig{
 		"smsactivate": {
-			Name:     "smsactivate",
-			Priority: 1,
-			Services: []string{"all"},
+			Name:      "smsactivate",
+			Priority:  1,
+			Services:  []string{"all"},
 			Countries: []string{"all"},
-			Enabled:  true,
+			Enabled:   true,
 		},
 	}
 
@@ -38,10 +40,46 @@ func NewProviderAdapter(logger *logrus.Logger) *ProviderAdapter {
 	}
 }
 
+// SetHealth switches selection from random to the healthiest provider
+func (pa *ProviderAdapter) SetHealth(health *ProviderHealthTracker) {
+	pa.health = health
+}
+
 func (pa *ProviderAdapter) SelectProvider(service, country string) string {
-	// Simple selection logic - can be enhanced
-	availableProviders := []string{}
+	availableProviders := pa.supportedProviders(service, country)
+
+	if len(availableProviders) == 0 {
+		return "smsactivate" // Default fallback
+	}
+
+	if pa.health != nil {
+		return pa.FailoverOrder(service, country)[0]
+	}
+
+	// Random selection for load balancing
+	rand.Seed(time.Now().UnixNano())
+	return availableProviders[rand.Intn(len(availableProviders))]
+}
 
+// FailoverOrder returns enabled providers supporting the service and country in the order they
+// should be tried: by health score with quarantined providers last, then by configured priority
+func (pa *ProviderAdapter) FailoverOrder(service, country string) []string {
+	providers := pa.supportedProviders(service, country)
+	sort.SliceStable(providers, func(i, j int) bool {
+		if pa.providers[providers[i]].Priority != pa.providers[providers[j]].Priority {
+			return pa.providers[providers[i]].Priority < pa.providers[providers[j]].Priority
+		}
+		return providers[i] < providers[j]
+	})
+
+	if pa.health == nil {
+		return providers
+	}
+	return pa.health.Order(providers)
+}
+
+func (pa *ProviderAdapter) supportedProviders(service, country string) []string {
+	var providers []string
 	for name, config := range pa.providers {
 		if !config.Enabled {
 			continue
@@ -49,17 +87,10 @@ func (pa *ProviderAdapter) SelectProvider(service, country string) string {
 
 		// Check if provider supports service and country
 		if pa.supportsService(config, service) && pa.supportsCountry(config, country) {
-			availableProviders = append(availableProviders, name)
+			providers = append(providers, name)
 		}
 	}
-
-	if len(availableProviders) == 0 {
-		return "smsactivate" // Default fallback
-	}
-
-	// Random selection for load balancing
-	rand.Seed(time.Now().UnixNano())
-	return availableProviders[rand.Intn(len(availableProviders))]
+	return providers
 }
 
 func (pa *ProviderAdapter) supportsService(config ProviderConfig, service string) bool {
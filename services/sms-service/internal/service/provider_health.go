@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+)
+
+// unratedScore is used for ordering providers with too few recent activations to be scored
+const unratedScore = 50.0
+
+// OutcomesLoader loads activation outcomes per provider since the given time
+type OutcomesLoader func(ctx context.Context, since time.Time) ([]models.ProviderOutcomes, error)
+
+// HealthConfig controls how provider health is scored and when a provider is quarantined
+type HealthConfig struct {
+	Window              time.Duration // How far back activations count towards the score
+	MinSamples          int           // Providers with fewer finished activations are not scored
+	QuarantineThreshold float64       // Providers scoring below it are quarantined
+	QuarantineDuration  time.Duration // How long a quarantined provider is only used as a last resort
+	TargetDelivery      time.Duration // Average delivery time that still gets the full delivery score
+	RefreshInterval     time.Duration
+}
+
+// ProviderHealthTracker scores providers by success rate, delivery time and cancellation rate
+// of their recent activations. Failover tries healthier providers first and quarantined ones last.
+type ProviderHealthTracker struct {
+	mu      sync.RWMutex
+	health  map[string]models.ProviderHealth
+	load    OutcomesLoader
+	metrics *MetricsCollector
+	config  HealthConfig
+	logger  logger.Logger
+}
+
+func NewProviderHealthTracker(load OutcomesLoader, metrics *MetricsCollector, config HealthConfig, logger logger.Logger) *ProviderHealthTracker {
+	if config.Window <= 0 {
+		config.Window = 24 * time.Hour
+	}
+	if config.QuarantineDuration <= 0 {
+		config.QuarantineDuration = time.Hour
+	}
+	if config.TargetDelivery <= 0 {
+		config.TargetDelivery = time.Minute
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = 5 * time.Minute
+	}
+
+	return &ProviderHealthTracker{
+		health:  make(map[string]models.ProviderHealth),
+		load:    load,
+		metrics: metrics,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// Start scores providers immediately and then on every refresh interval
+func (t *ProviderHealthTracker) Start(ctx context.Context) {
+	t.Refresh(ctx)
+
+	ticker := time.NewTicker(t.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Refresh(ctx)
+		}
+	}
+}
+
+// Refresh reloads activation outcomes and rescores providers. On a load error the previous
+// scores are kept.
+func (t *ProviderHealthTracker) Refresh(ctx context.Context) {
+	now := time.Now()
+	outcomes, err := t.load(ctx, now.Add(-t.config.Window))
+	if err != nil {
+		t.logger.Warnf("Failed to load provider outcomes: %v", err)
+		return
+	}
+	t.update(outcomes, now)
+}
+
+func (t *ProviderHealthTracker) update(outcomes []models.ProviderOutcomes, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	scored := make(map[string]models.ProviderHealth, len(outcomes))
+	for _, outcome := range outcomes {
+		if outcome.Provider == "" {
+			continue
+		}
+
+		health := scoreProvider(outcome, t.config)
+		health.UpdatedAt = now
+
+		previous := t.health[outcome.Provider]
+		switch {
+		case previous.QuarantinedUntil != nil && now.Before(*previous.QuarantinedUntil):
+			// A quarantine runs its full duration even if the score recovers meanwhile
+			health.Quarantined = true
+			health.QuarantinedUntil = previous.QuarantinedUntil
+		case health.Samples >= t.config.MinSamples && health.Score < t.config.QuarantineThreshold:
+			until := now.Add(t.config.QuarantineDuration)
+			health.Quarantined = true
+			health.QuarantinedUntil = &until
+			t.logger.Warnf("Provider %s quarantined until %s: health score %.1f below %.1f",
+				outcome.Provider, until.Format(time.RFC3339), health.Score, t.config.QuarantineThreshold)
+		case previous.Quarantined:
+			t.logger.Infof("Provider %s released from quarantine with health score %.1f", outcome.Provider, health.Score)
+		}
+
+		scored[outcome.Provider] = health
+
+		if t.metrics != nil {
+			t.metrics.SetProviderHealth(outcome.Provider, health.Score, health.Quarantined)
+		}
+	}
+
+	// Providers without activations in the window are forgotten once their quarantine is over
+	for provider, health := range t.health {
+		if _, ok := scored[provider]; ok {
+			continue
+		}
+		if health.QuarantinedUntil != nil && now.Before(*health.QuarantinedUntil) {
+			scored[provider] = health
+		}
+	}
+
+	t.health = scored
+}
+
+// Snapshot returns the health of all scored providers, healthiest first
+func (t *ProviderHealthTracker) Snapshot() []models.ProviderHealth {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := make([]models.ProviderHealth, 0, len(t.health))
+	for _, health := range t.health {
+		result = append(result, health)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Quarantined != result[j].Quarantined {
+			return !result[i].Quarantined
+		}
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Provider < result[j].Provider
+	})
+
+	return result
+}
+
+// Get returns the health of a provider, false when it has no recent activations
+func (t *ProviderHealthTracker) Get(provider string) (models.ProviderHealth, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	health, ok := t.health[provider]
+	return health, ok
+}
+
+// Quarantined reports whether the provider is quarantined
+func (t *ProviderHealthTracker) Quarantined(provider string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	health, ok := t.health[provider]
+	return ok && health.Quarantined && health.QuarantinedUntil != nil && time.Now().Before(*health.QuarantinedUntil)
+}
+
+// Score returns the score used for ordering, providers without enough samples get a neutral one
+func (t *ProviderHealthTracker) Score(provider string) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	health, ok := t.health[provider]
+	if !ok || health.Samples < t.config.MinSamples {
+		return unratedScore
+	}
+	return health.Score
+}
+
+// Order sorts providers for failover: healthy ones by score, then quarantined ones.
+// Providers with equal standing keep their given order.
+func (t *ProviderHealthTracker) Order(providers []string) []string {
+	ordered := append([]string(nil), providers...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		qi, qj := t.Quarantined(ordered[i]), t.Quarantined(ordered[j])
+		if qi != qj {
+			return !qi
+		}
+		return t.Score(ordered[i]) > t.Score(ordered[j])
+	})
+	return ordered
+}
+
+// SetProviderHealth makes catalog routing and provider selection follow provider health
+func (s *SMSService) SetProviderHealth(health *ProviderHealthTracker) {
+	s.health = health
+	if s.priceCatalog != nil {
+		s.priceCatalog.SetHealth(health)
+	}
+	if s.providerAdapter != nil {
+		s.providerAdapter.SetHealth(health)
+	}
+}
+
+// GetProviderHealth returns current provider health scores, healthiest first
+func (s *SMSService) GetProviderHealth() []models.ProviderHealth {
+	if s.health == nil {
+		return nil
+	}
+	return s.health.Snapshot()
+}
+
+// scoreProvider weights success rate at 60%, delivery time and the share of activations
+// that were not cancelled at 20% each
+func scoreProvider(outcome models.ProviderOutcomes, config HealthConfig) models.ProviderHealth {
+	health := models.ProviderHealth{
+		Provider:           outcome.Provider,
+		Samples:            outcome.Total,
+		AvgDeliverySeconds: math.Round(outcome.AvgDeliverySeconds*10) / 10,
+	}
+	if outcome.Total == 0 {
+		return health
+	}
+
+	health.SuccessRate = float64(outcome.Received) / float64(outcome.Total)
+	health.CancellationRate = float64(outcome.Cancelled) / float64(outcome.Total)
+
+	delivery := 1.0
+	if target := config.TargetDelivery.Seconds(); outcome.AvgDeliverySeconds > target {
+		delivery = target / outcome.AvgDeliverySeconds
+	}
+
+	score := 100 * (0.6*health.SuccessRate + 0.2*delivery + 0.2*(1-health.CancellationRate))
+	health.Score = math.Round(score*10) / 10
+
+	return health
+}
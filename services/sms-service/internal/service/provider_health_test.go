@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHealthTracker(outcomes []models.ProviderOutcomes) *ProviderHealthTracker {
+	load := func(ctx context.Context, since time.Time) ([]models.ProviderOutcomes, error) {
+		return outcomes, nil
+	}
+	return NewProviderHealthTracker(load, nil, HealthConfig{
+		MinSamples:          10,
+		QuarantineThreshold: 40,
+		QuarantineDuration:  time.Hour,
+		TargetDelivery:      time.Minute,
+	}, logger.NewFromConfig(logger.Config{Output: io.Discard}))
+}
+
+func TestScoreProvider(t *testing.T) {
+	config := HealthConfig{TargetDelivery: time.Minute}
+
+	perfect := scoreProvider(models.ProviderOutcomes{Provider: "a", Total: 10, Received: 10, AvgDeliverySeconds: 30}, config)
+	assert.Equal(t, 100.0, perfect.Score)
+	assert.Equal(t, 1.0, perfect.SuccessRate)
+	assert.Equal(t, 0.0, perfect.CancellationRate)
+
+	// Half delivered, a fifth cancelled, delivery twice the target
+	mixed := scoreProvider(models.ProviderOutcomes{Provider: "b", Total: 10, Received: 5, Cancelled: 2, AvgDeliverySeconds: 120}, config)
+	assert.Equal(t, 56.0, mixed.Score)
+	assert.Equal(t, 0.2, mixed.CancellationRate)
+
+	empty := scoreProvider(models.ProviderOutcomes{Provider: "c"}, config)
+	assert.Zero(t, empty.Score)
+}
+
+func TestProviderHealthQuarantine(t *testing.T) {
+	tracker := newTestHealthTracker([]models.ProviderOutcomes{
+		{Provider: "good", Total: 20, Received: 19, AvgDeliverySeconds: 40},
+		{Provider: "bad", Total: 20, Received: 2, Cancelled: 15, AvgDeliverySeconds: 300},
+		{Provider: "new", Total: 3, Received: 0, Cancelled: 3},
+	})
+	tracker.Refresh(context.Background())
+
+	assert.False(t, tracker.Quarantined("good"))
+	assert.True(t, tracker.Quarantined("bad"))
+	// Too few samples to judge
+	assert.False(t, tracker.Quarantined("new"))
+	assert.Equal(t, unratedScore, tracker.Score("new"))
+
+	bad, ok := tracker.Get("bad")
+	require.True(t, ok)
+	require.NotNil(t, bad.QuarantinedUntil)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *bad.QuarantinedUntil, time.Minute)
+
+	snapshot := tracker.Snapshot()
+	require.Len(t, snapshot, 3)
+	assert.Equal(t, "good", snapshot[0].Provider)
+	assert.Equal(t, "bad", snapshot[2].Provider)
+}
+
+func TestProviderHealthQuarantineRunsFullDuration(t *testing.T) {
+	tracker := newTestHealthTracker(nil)
+	now := time.Now()
+
+	tracker.update([]models.ProviderOutcomes{{Provider: "flaky", Total: 20, Received: 1, Cancelled: 10}}, now)
+	require.True(t, tracker.Quarantined("flaky"))
+
+	// Recovered score does not lift the quarantine early
+	tracker.update([]models.ProviderOutcomes{{Provider: "flaky", Total: 20, Received: 20}}, now.Add(10*time.Minute))
+	assert.True(t, tracker.Quarantined("flaky"))
+
+	// Gone from the window but still quarantined, the provider is kept
+	tracker.update(nil, now.Add(20*time.Minute))
+	_, ok := tracker.Get("flaky")
+	assert.True(t, ok)
+
+	tracker.update(nil, now.Add(2*time.Hour))
+	_, ok = tracker.Get("flaky")
+	assert.False(t, ok)
+}
+
+func TestProviderHealthRefreshKeepsScoresOnError(t *testing.T) {
+	fail := false
+	load := func(ctx context.Context, since time.Time) ([]models.ProviderOutcomes, error) {
+		if fail {
+			return nil, errors.New("mongo down")
+		}
+		return []models.ProviderOutcomes{{Provider: "a", Total: 20, Received: 20}}, nil
+	}
+	tracker := NewProviderHealthTracker(load, nil, HealthConfig{MinSamples: 10}, logger.NewFromConfig(logger.Config{Output: io.Discard}))
+
+	tracker.Refresh(context.Background())
+	fail = true
+	tracker.Refresh(context.Background())
+
+	assert.Equal(t, 100.0, tracker.Score("a"))
+}
+
+func TestProviderHealthOrder(t *testing.T) {
+	tracker := newTestHealthTracker([]models.ProviderOutcomes{
+		{Provider: "fast", Total: 20, Received: 20, AvgDeliverySeconds: 20},
+		{Provider: "slow", Total: 20, Received: 18, AvgDeliverySeconds: 240},
+		{Provider: "broken", Total: 20, Received: 0, Cancelled: 20},
+	})
+	tracker.Refresh(context.Background())
+
+	order := tracker.Order([]string{"broken", "unknown", "slow", "fast"})
+	assert.Equal(t, []string{"fast", "slow", "unknown", "broken"}, order)
+}
+
+func TestOrderByHealth(t *testing.T) {
+	tracker := newTestHealthTracker([]models.ProviderOutcomes{
+		{Provider: "healthy", Total: 20, Received: 20},
+		{Provider: "average", Total: 20, Received: 14, Cancelled: 4},
+		{Provider: "broken", Total: 20, Received: 0, Cancelled: 20},
+	})
+	tracker.Refresh(context.Background())
+
+	candidates := []models.ProviderPrice{
+		{Provider: "broken", Price: 5},
+		{Provider: "average", Price: 8},
+		{Provider: "healthy", Price: 8},
+		{Provider: "expensive", Price: 20},
+	}
+	orderByHealth(candidates, tracker)
+
+	providers := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		providers[i] = candidate.Provider
+	}
+	// Price still leads, health breaks ties and quarantined providers go last
+	assert.Equal(t, []string{"healthy", "average", "expensive", "broken"}, providers)
+}
+
+func TestProviderAdapterFailoverOrder(t *testing.T) {
+	tracker := newTestHealthTracker([]models.ProviderOutcomes{
+		{Provider: "primary", Total: 20, Received: 0, Cancelled: 20},
+		{Provider: "backup", Total: 20, Received: 20},
+	})
+	tracker.Refresh(context.Background())
+
+	adapter := &ProviderAdapter{
+		logger: logger.NewFromConfig(logger.Config{Output: io.Discard}),
+		providers: map[string]ProviderConfig{
+			"primary":  {Name: "primary", Priority: 1, Services: []string{"all"}, Countries: []string{"all"}, Enabled: true},
+			"backup":   {Name: "backup", Priority: 2, Services: []string{"all"}, Countries: []string{"all"}, Enabled: true},
+			"disabled": {Name: "disabled", Priority: 0, Services: []string{"all"}, Countries: []string{"all"}},
+		},
+	}
+
+	assert.Equal(t, []string{"primary", "backup"}, adapter.FailoverOrder("vk", "RU"))
+
+	adapter.SetHealth(tracker)
+	assert.Equal(t, []string{"backup", "primary"}, adapter.FailoverOrder("vk", "RU"))
+	assert.Equal(t, "backup", adapter.SelectProvider("vk", "RU"))
+}
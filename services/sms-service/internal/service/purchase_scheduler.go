@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+	"github.com/grigta/conveer/services/sms-service/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+)
+
+var (
+	ErrInvalidDeadline           = errors.New("not_after must be in the future")
+	ErrScheduledPurchaseNotFound = errors.New("scheduled purchase not found")
+)
+
+// Deferred purchases whose message got lost are picked up once they are this late
+const overduePurchaseGrace = 2 * time.Minute
+
+// DeferralPolicy decides which non-urgent purchases wait for the provider's restock
+type DeferralPolicy struct {
+	Lead        time.Duration // Purchases this close to a restock wait for it
+	Settle      time.Duration // Pause after the restock before buying, while the stock fills up
+	MaxDeferral time.Duration // Purchases never wait longer than this
+	LowStock    int           // Catalog stock below this defers the purchase to the next restock
+}
+
+// PurchaseScheduler defers purchases that would likely fail right before a provider restock
+// and executes them once the restock window opens
+type PurchaseScheduler struct {
+	smsService *SMSService
+	repo       *repository.ScheduledPurchaseRepository
+	catalog    *PriceCatalog
+	channel    *amqp.Channel
+	delayed    *messaging.DelayedPublisher
+	metrics    *MetricsCollector
+	policy     DeferralPolicy
+	logger     logger.Logger
+}
+
+func NewPurchaseScheduler(
+	smsService *SMSService,
+	repo *repository.ScheduledPurchaseRepository,
+	catalog *PriceCatalog,
+	channel *amqp.Channel,
+	metrics *MetricsCollector,
+	policy DeferralPolicy,
+	logger logger.Logger,
+) *PurchaseScheduler {
+	return &PurchaseScheduler{
+		smsService: smsService,
+		repo:       repo,
+		catalog:    catalog,
+		channel:    channel,
+		delayed:    messaging.NewDelayedPublisher(channel, messaging.DelayModeFromEnv()),
+		metrics:    metrics,
+		policy:     policy,
+		logger:     logger,
+	}
+}
+
+// SchedulePurchase buys a number now or defers it to the next restock window. The activation
+// is only returned for purchases made immediately.
+func (s *PurchaseScheduler) SchedulePurchase(ctx context.Context, req models.ScheduledPurchaseRequest) (*models.ScheduledPurchase, *models.Activation, error) {
+	now := time.Now()
+	if !req.NotAfter.IsZero() && !req.NotAfter.After(now) {
+		return nil, nil, ErrInvalidDeadline
+	}
+
+	restockProvider, stock, restock := s.restockTarget(req, now)
+	executeAt, reason := planPurchase(s.policy, now, restock, stock, req.Urgent, req.NotAfter)
+
+	purchase := &models.ScheduledPurchase{
+		PurchaseID:      uuid.New().String(),
+		UserID:          req.UserID,
+		Service:         req.Service,
+		Country:         req.Country,
+		Operator:        req.Operator,
+		Provider:        req.Provider,
+		RestockProvider: restockProvider,
+		MaxPrice:        req.MaxPrice,
+		Deferred:        reason != "",
+		Reason:          reason,
+		ExecuteAt:       executeAt,
+	}
+
+	s.metrics.IncrementScheduledPurchase(purchase.Deferred)
+
+	if !purchase.Deferred {
+		return s.purchaseNow(ctx, purchase)
+	}
+
+	purchase.Status = models.ScheduledPurchaseDeferred
+	if err := s.repo.Create(ctx, purchase); err != nil {
+		return nil, nil, err
+	}
+
+	delay := executeAt.Sub(now)
+	if err := s.publish(purchase.PurchaseID, delay); err != nil {
+		// The overdue sweep still executes the purchase, just later
+		s.logger.Errorf("Failed to publish scheduled purchase %s: %v", purchase.PurchaseID, err)
+	}
+
+	s.metrics.RecordDeferral(restockProvider, purchase.Country, reason, delay.Seconds())
+
+	s.logger.Infof("Deferred purchase %s for user %s until %s (%s, restock of %s)",
+		purchase.PurchaseID, req.UserID, executeAt.Format(time.RFC3339), reason, restockProvider)
+
+	return purchase, nil, nil
+}
+
+func (s *PurchaseScheduler) purchaseNow(ctx context.Context, purchase *models.ScheduledPurchase) (*models.ScheduledPurchase, *models.Activation, error) {
+	activation, _, purchaseErr := s.smsService.purchase(ctx, purchase.UserID, purchase.Service, purchase.Country,
+		purchase.Operator, purchase.Provider, purchase.MaxPrice, "")
+
+	completedAt := time.Now()
+	purchase.CompletedAt = &completedAt
+	if purchaseErr != nil {
+		purchase.Status = models.ScheduledPurchaseFailed
+		purchase.Error = purchaseErr.Error()
+	} else {
+		purchase.Status = models.ScheduledPurchaseFulfilled
+		purchase.ActivationID = activation.ActivationID
+	}
+
+	if err := s.repo.Create(ctx, purchase); err != nil {
+		s.logger.Errorf("Failed to record purchase %s: %v", purchase.PurchaseID, err)
+	}
+
+	if purchaseErr != nil {
+		return purchase, nil, purchaseErr
+	}
+	return purchase, activation, nil
+}
+
+// restockTarget returns the provider the purchase would be routed to, its stock (-1 when
+// unknown) and its next restock (zero when it has no schedule)
+func (s *PurchaseScheduler) restockTarget(req models.ScheduledPurchaseRequest, now time.Time) (string, int, time.Time) {
+	if s.catalog == nil {
+		return req.Provider, -1, time.Time{}
+	}
+
+	// Prices are ordered cheapest first, which is where routing sends the purchase
+	prices := s.catalog.GetPrices(req.Service, req.Country, req.Provider)
+	if len(prices) == 0 {
+		if req.Provider == "" {
+			return "", -1, time.Time{}
+		}
+		restock, _ := s.catalog.NextRestock(req.Provider, req.Country, now)
+		return req.Provider, -1, restock
+	}
+
+	target := prices[0]
+	var restock time.Time
+	if target.NextRestock != nil {
+		restock = *target.NextRestock
+	}
+	return target.Provider, target.Available, restock
+}
+
+// planPurchase returns when a purchase should run and why it waits. An empty reason means
+// the purchase runs now. stock is negative when the catalog does not know it.
+func planPurchase(policy DeferralPolicy, now, restock time.Time, stock int, urgent bool, notAfter time.Time) (time.Time, string) {
+	if urgent || restock.IsZero() || !restock.After(now) {
+		return now, ""
+	}
+
+	executeAt := restock.Add(policy.Settle)
+	if executeAt.Sub(now) > policy.MaxDeferral {
+		return now, ""
+	}
+	if !notAfter.IsZero() && executeAt.After(notAfter) {
+		return now, ""
+	}
+
+	switch {
+	case restock.Sub(now) <= policy.Lead:
+		return executeAt, models.DeferralPreRestock
+	case stock >= 0 && stock < policy.LowStock:
+		return executeAt, models.DeferralLowStock
+	}
+	return now, ""
+}
+
+type scheduledPurchaseMessage struct {
+	PurchaseID string `json:"purchase_id"`
+}
+
+func (s *PurchaseScheduler) publish(purchaseID string, delay time.Duration) error {
+	data, err := json.Marshal(scheduledPurchaseMessage{PurchaseID: purchaseID})
+	if err != nil {
+		return err
+	}
+
+	return s.delayed.Publish(
+		"sms.commands",
+		"scheduled_purchase",
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         data,
+			DeliveryMode: amqp.Persistent,
+		},
+		delay,
+	)
+}
+
+// GetScheduledPurchase returns a scheduled purchase of the user
+func (s *PurchaseScheduler) GetScheduledPurchase(ctx context.Context, purchaseID, userID string) (*models.ScheduledPurchase, error) {
+	purchase, err := s.repo.FindByPurchaseID(ctx, purchaseID)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil || purchase.UserID != userID {
+		return nil, ErrScheduledPurchaseNotFound
+	}
+	return purchase, nil
+}
+
+// GetDeferralStats summarizes purchases scheduled in the period. Zero bounds are open.
+func (s *PurchaseScheduler) GetDeferralStats(ctx context.Context, from, to time.Time) (*models.DeferralStats, error) {
+	return s.repo.GetDeferralStats(ctx, from, to)
+}
+
+// StartWorker executes deferred purchases when their messages arrive and sweeps up
+// purchases whose messages were lost
+func (s *PurchaseScheduler) StartWorker(ctx context.Context) {
+	msgs, err := s.channel.Consume(
+		"sms.scheduled_purchase",
+		"",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		s.logger.Errorf("Failed to start scheduled purchase worker: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.executeOverdue(ctx)
+		case msg := <-msgs:
+			var message scheduledPurchaseMessage
+			if err := json.Unmarshal(msg.Body, &message); err != nil {
+				s.logger.Errorf("Failed to unmarshal scheduled purchase message: %v", err)
+				continue
+			}
+			s.execute(ctx, message.PurchaseID)
+		}
+	}
+}
+
+func (s *PurchaseScheduler) executeOverdue(ctx context.Context) {
+	purchases, err := s.repo.FindOverdue(ctx, time.Now().Add(-overduePurchaseGrace))
+	if err != nil {
+		s.logger.Errorf("Failed to find overdue scheduled purchases: %v", err)
+		return
+	}
+
+	for _, purchase := range purchases {
+		s.execute(ctx, purchase.PurchaseID)
+	}
+}
+
+func (s *PurchaseScheduler) execute(ctx context.Context, purchaseID string) {
+	// A purchase delivered twice or already swept up is claimed only once
+	purchase, err := s.repo.Claim(ctx, purchaseID)
+	if err != nil {
+		s.logger.Errorf("Failed to claim scheduled purchase %s: %v", purchaseID, err)
+		return
+	}
+	if purchase == nil {
+		return
+	}
+
+	activation, provider, err := s.smsService.purchase(ctx, purchase.UserID, purchase.Service, purchase.Country,
+		purchase.Operator, purchase.Provider, purchase.MaxPrice, "")
+	if err != nil {
+		s.logger.Errorf("Deferred purchase %s failed with %s: %v", purchaseID, provider, err)
+		s.metrics.IncrementDeferredOutcome(classifyPurchaseError(err))
+		if err := s.repo.Complete(ctx, purchaseID, models.ScheduledPurchaseFailed, "", err.Error()); err != nil {
+			s.logger.Errorf("Failed to record outcome of scheduled purchase %s: %v", purchaseID, err)
+		}
+		return
+	}
+
+	s.metrics.IncrementDeferredOutcome(string(models.ScheduledPurchaseFulfilled))
+	if err := s.repo.Complete(ctx, purchaseID, models.ScheduledPurchaseFulfilled, activation.ActivationID, ""); err != nil {
+		s.logger.Errorf("Failed to record outcome of scheduled purchase %s: %v", purchaseID, err)
+	}
+
+	s.logger.Infof("Deferred purchase %s fulfilled with activation %s", purchaseID, activation.ActivationID)
+}
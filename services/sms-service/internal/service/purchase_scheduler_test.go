@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanPurchase(t *testing.T) {
+	policy := DeferralPolicy{Lead: 45 * time.Minute, Settle: 5 * time.Minute, MaxDeferral: 6 * time.Hour, LowStock: 10}
+	now := time.Date(2024, 1, 15, 11, 30, 0, 0, time.UTC)
+	soon := now.Add(30 * time.Minute)
+	later := now.Add(3 * time.Hour)
+	afterSoon := soon.Add(policy.Settle)
+
+	tests := []struct {
+		name     string
+		restock  time.Time
+		stock    int
+		urgent   bool
+		notAfter time.Time
+		want     time.Time
+		reason   string
+	}{
+		{"right before restock", soon, 500, false, time.Time{}, afterSoon, models.DeferralPreRestock},
+		{"urgent is never deferred", soon, 500, true, time.Time{}, now, ""},
+		{"deadline before the window", soon, 500, false, soon, now, ""},
+		{"deadline after the window", soon, 500, false, later, afterSoon, models.DeferralPreRestock},
+		{"restock far off with stock", later, 500, false, time.Time{}, now, ""},
+		{"restock far off without stock", later, 3, false, time.Time{}, later.Add(policy.Settle), models.DeferralLowStock},
+		{"unknown stock", later, -1, false, time.Time{}, now, ""},
+		{"restock beyond max deferral", now.Add(8 * time.Hour), 0, false, time.Time{}, now, ""},
+		{"no schedule", time.Time{}, 0, false, time.Time{}, now, ""},
+	}
+
+	for _, tt := range tests {
+		got, reason := planPurchase(policy, now, tt.restock, tt.stock, tt.urgent, tt.notAfter)
+		assert.Equal(t, tt.want, got, tt.name)
+		assert.Equal(t, tt.reason, reason, tt.name)
+	}
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+	"github.com/grigta/conveer/services/sms-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRentalNotFound  = errors.New("rental not found")
+	ErrRentalNotActive = errors.New("rental is not active")
+	ErrInvalidRentTime = errors.New("invalid rent time")
+)
+
+// Providers do not rent numbers for less than this
+const minRentHours = 4
+
+// Rental operations as counted in metrics
+const (
+	rentalOpRent    = "rent"
+	rentalOpRenew   = "renew"
+	rentalOpRelease = "release"
+	rentalOpAlert   = "expiry_alert"
+	rentalOpExpire  = "expire"
+)
+
+// RentalPolicy configures rent periods and the rental worker
+type RentalPolicy struct {
+	DefaultHours int           // Rent period when the request does not set one
+	MaxHours     int           // Longest period a single rent or renewal may buy
+	AlertBefore  time.Duration // Rentals lapsing within this are alerted once per period
+	PollEvery    time.Duration // Inbox of each active rental is read this often
+	PollBatch    int64         // Rentals read per worker tick
+}
+
+// RentalManager keeps numbers rented for a period, for platforms that ask to verify the
+// number again long after registration. Rentals are registered against the accounts they
+// verify, and every SMS they receive is stored.
+type RentalManager struct {
+	repo        *repository.RentalRepository
+	smsActivate *SMSActivateClient
+	validator   *CodeValidator
+	events      *EventPublisher
+	metrics     *MetricsCollector
+	policy      RentalPolicy
+	logger      logger.Logger
+}
+
+func NewRentalManager(
+	repo *repository.RentalRepository,
+	smsActivate *SMSActivateClient,
+	validator *CodeValidator,
+	events *EventPublisher,
+	metrics *MetricsCollector,
+	policy RentalPolicy,
+	logger logger.Logger,
+) *RentalManager {
+	return &RentalManager{
+		repo:        repo,
+		smsActivate: smsActivate,
+		validator:   validator,
+		events:      events,
+		metrics:     metrics,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+// RentNumber rents a number. A request for an account that already holds an active rental
+// for the service returns that rental, reported by the second return value.
+func (m *RentalManager) RentNumber(ctx context.Context, req models.RentalRequest) (*models.Rental, bool, error) {
+	hours, err := m.rentHours(req.Hours)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if req.AccountID != "" {
+		existing, err := m.repo.FindActiveByAccount(ctx, req.AccountID, req.Service)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing != nil {
+			if existing.UserID != req.UserID {
+				return nil, false, fmt.Errorf("account %s holds a rental of another user", req.AccountID)
+			}
+			return existing, true, nil
+		}
+	}
+
+	provider := req.Provider
+	if provider == "" {
+		provider = "smsactivate"
+	}
+
+	var rental *models.Rental
+	switch provider {
+	case "smsactivate":
+		rental, err = m.smsActivate.RentNumber(ctx, req.Service, req.Country, req.Operator, hours)
+	default:
+		return nil, false, fmt.Errorf("unsupported provider: %s", provider)
+	}
+	if err != nil {
+		m.logger.Errorf("Failed to rent number from %s: %v", provider, err)
+		m.metrics.IncrementRentalOperation(provider, rentalOpRent, "failed")
+		return nil, false, err
+	}
+
+	rental.RentalID = uuid.New().String()
+	rental.UserID = req.UserID
+	rental.AccountID = req.AccountID
+	rental.Status = models.RentalStatusActive
+	rental.StartedAt = time.Now()
+
+	if err := m.repo.Create(ctx, rental); err != nil {
+		// The number is paid for, keep the provider rent ID in the log to recover it
+		m.logger.Errorf("Failed to save rental of %s (%s rent %s): %v", rental.PhoneNumber, provider, rental.ProviderRentID, err)
+		return nil, false, err
+	}
+
+	m.metrics.IncrementRentalOperation(provider, rentalOpRent, "success")
+	m.metrics.RecordRentalSpend(provider, rental.Service, rental.Price)
+
+	if err := m.events.PublishRentalStarted(rental); err != nil {
+		m.logger.Errorf("Failed to publish start of rental %s: %v", rental.RentalID, err)
+	}
+
+	m.logger.Infof("Rented number %s for user %s until %s, rental %s",
+		rental.PhoneNumber, req.UserID, rental.ExpiresAt.Format(time.RFC3339), rental.RentalID)
+
+	return rental, false, nil
+}
+
+// RenewRental extends an active rental by the given hours, the default period when zero
+func (m *RentalManager) RenewRental(ctx context.Context, rentalID, userID string, hours int) (*models.Rental, error) {
+	hours, err := m.rentHours(hours)
+	if err != nil {
+		return nil, err
+	}
+
+	rental, err := m.activeRental(ctx, rentalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt time.Time
+	var price float64
+	switch rental.Provider {
+	case "smsactivate":
+		expiresAt, price, err = m.smsActivate.RenewRental(ctx, rental.ProviderRentID, rental.Service, rental.Country, hours)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", rental.Provider)
+	}
+	if err != nil {
+		m.logger.Errorf("Failed to renew rental %s: %v", rentalID, err)
+		m.metrics.IncrementRentalOperation(rental.Provider, rentalOpRenew, "failed")
+		return nil, err
+	}
+
+	if err := m.repo.Extend(ctx, rentalID, expiresAt, hours, price); err != nil {
+		m.logger.Errorf("Failed to save renewal of rental %s until %s: %v", rentalID, expiresAt.Format(time.RFC3339), err)
+		return nil, err
+	}
+
+	rental.ExpiresAt = expiresAt
+	rental.Hours += hours
+	rental.Price += price
+	rental.Renewals++
+	rental.ExpiryAlertedAt = nil
+
+	m.metrics.IncrementRentalOperation(rental.Provider, rentalOpRenew, "success")
+	m.metrics.RecordRentalSpend(rental.Provider, rental.Service, price)
+
+	if err := m.events.PublishRentalRenewed(rental, price); err != nil {
+		m.logger.Errorf("Failed to publish renewal of rental %s: %v", rentalID, err)
+	}
+
+	m.logger.Infof("Renewed rental %s by %dh until %s", rentalID, hours, expiresAt.Format(time.RFC3339))
+
+	return rental, nil
+}
+
+// ReleaseRental finishes an active rental before it lapses
+func (m *RentalManager) ReleaseRental(ctx context.Context, rentalID, userID string) (*models.Rental, error) {
+	rental, err := m.activeRental(ctx, rentalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rental.Provider {
+	case "smsactivate":
+		err = m.smsActivate.ReleaseRental(ctx, rental.ProviderRentID)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", rental.Provider)
+	}
+	if err != nil {
+		m.logger.Errorf("Failed to release rental %s: %v", rentalID, err)
+		m.metrics.IncrementRentalOperation(rental.Provider, rentalOpRelease, "failed")
+		return nil, err
+	}
+
+	if _, err := m.repo.End(ctx, rentalID, models.RentalStatusReleased); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rental.Status = models.RentalStatusReleased
+	rental.EndedAt = &now
+
+	m.metrics.IncrementRentalOperation(rental.Provider, rentalOpRelease, "success")
+
+	if err := m.events.PublishRentalReleased(rental); err != nil {
+		m.logger.Errorf("Failed to publish release of rental %s: %v", rentalID, err)
+	}
+
+	m.logger.Infof("Released rental %s of number %s", rentalID, rental.PhoneNumber)
+
+	return rental, nil
+}
+
+// AssignRental binds an active rental to the account it verifies
+func (m *RentalManager) AssignRental(ctx context.Context, rentalID, userID, accountID string) (*models.Rental, error) {
+	rental, err := m.activeRental(ctx, rentalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := m.repo.FindActiveByAccount(ctx, accountID, rental.Service)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.RentalID != rentalID {
+		return nil, fmt.Errorf("account %s already holds rental %s", accountID, existing.RentalID)
+	}
+
+	if err := m.repo.AssignAccount(ctx, rentalID, accountID); err != nil {
+		return nil, err
+	}
+
+	rental.AccountID = accountID
+	return rental, nil
+}
+
+// GetRental returns a rental of the user
+func (m *RentalManager) GetRental(ctx context.Context, rentalID, userID string) (*models.Rental, error) {
+	rental, err := m.repo.FindByRentalID(ctx, rentalID)
+	if err != nil {
+		return nil, err
+	}
+	if rental == nil || rental.UserID != userID {
+		return nil, ErrRentalNotFound
+	}
+	return rental, nil
+}
+
+// ListRentals returns rentals of the user, optionally of one account or status
+func (m *RentalManager) ListRentals(ctx context.Context, userID, accountID string, status models.RentalStatus) ([]*models.Rental, error) {
+	return m.repo.List(ctx, userID, accountID, status)
+}
+
+// GetRentalMessages returns the SMS history of a rental, newest first. The inbox of an active
+// rental is read from the provider first, so a code that just arrived is included.
+func (m *RentalManager) GetRentalMessages(ctx context.Context, rentalID, userID string, since time.Time) ([]*models.RentalMessage, error) {
+	rental, err := m.GetRental(ctx, rentalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rental.Status == models.RentalStatusActive {
+		if err := m.syncMessages(ctx, rental); err != nil {
+			// The stored history is still returned
+			m.logger.Warnf("Failed to read inbox of rental %s: %v", rentalID, err)
+		}
+	}
+
+	return m.repo.ListMessages(ctx, rentalID, since)
+}
+
+func (m *RentalManager) activeRental(ctx context.Context, rentalID, userID string) (*models.Rental, error) {
+	rental, err := m.GetRental(ctx, rentalID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if rental.Status != models.RentalStatusActive {
+		return nil, fmt.Errorf("%w: %s", ErrRentalNotActive, rental.Status)
+	}
+	return rental, nil
+}
+
+// rentHours applies the default period and checks the bounds
+func (m *RentalManager) rentHours(hours int) (int, error) {
+	if hours == 0 {
+		hours = m.policy.DefaultHours
+	}
+	if hours < minRentHours || (m.policy.MaxHours > 0 && hours > m.policy.MaxHours) {
+		return 0, fmt.Errorf("%w: %dh, allowed %d-%dh", ErrInvalidRentTime, hours, minRentHours, m.policy.MaxHours)
+	}
+	return hours, nil
+}
+
+// syncMessages reads the rental's inbox from the provider and stores the SMS not seen before
+func (m *RentalManager) syncMessages(ctx context.Context, rental *models.Rental) error {
+	var messages []*models.RentalMessage
+	var err error
+
+	switch rental.Provider {
+	case "smsactivate":
+		messages, err = m.smsActivate.GetRentMessages(ctx, rental.ProviderRentID)
+	default:
+		return fmt.Errorf("unsupported provider: %s", rental.Provider)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Rented numbers keep receiving SMS after the code they were rented for, so every text
+	// goes through the same checks as activation codes
+	for _, message := range messages {
+		check := m.validator.Validate(rental.Service, message.Sender, message.Text)
+		message.Anomalies = check.Anomalies
+		if !check.Suspicious() {
+			message.Code = check.Code
+		}
+	}
+
+	stored, err := m.repo.SaveMessages(ctx, rental.RentalID, messages)
+	for _, message := range stored {
+		m.metrics.IncrementRentalMessage(rental.Provider, rental.Service, len(message.Anomalies) > 0)
+		if err := m.events.PublishRentalSMS(rental, message); err != nil {
+			m.logger.Errorf("Failed to publish SMS of rental %s: %v", rental.RentalID, err)
+		}
+	}
+	return err
+}
+
+// StartWorker reads the inboxes of active rentals, alerts rentals about to lapse and
+// closes the ones that lapsed
+func (m *RentalManager) StartWorker(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.pollInboxes(ctx)
+			m.alertExpiring(ctx)
+			m.expireLapsed(ctx)
+		}
+	}
+}
+
+func (m *RentalManager) pollInboxes(ctx context.Context) {
+	rentals, err := m.repo.FindActive(ctx, time.Now().Add(-m.policy.PollEvery), m.policy.PollBatch)
+	if err != nil {
+		m.logger.Errorf("Failed to find active rentals: %v", err)
+		return
+	}
+
+	for _, rental := range rentals {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := m.syncMessages(ctx, rental); err != nil {
+			m.logger.Warnf("Failed to read inbox of rental %s: %v", rental.RentalID, err)
+		}
+	}
+}
+
+func (m *RentalManager) alertExpiring(ctx context.Context) {
+	rentals, err := m.repo.FindExpiring(ctx, time.Now().Add(m.policy.AlertBefore))
+	if err != nil {
+		m.logger.Errorf("Failed to find expiring rentals: %v", err)
+		return
+	}
+
+	for _, rental := range rentals {
+		if err := m.events.PublishRentalExpiring(rental); err != nil {
+			// Not marked, so the alert is retried on the next tick
+			m.logger.Errorf("Failed to publish expiry alert for rental %s: %v", rental.RentalID, err)
+			continue
+		}
+		if err := m.repo.MarkExpiryAlerted(ctx, rental.RentalID); err != nil {
+			m.logger.Errorf("Failed to mark expiry alert for rental %s: %v", rental.RentalID, err)
+		}
+
+		m.metrics.IncrementRentalOperation(rental.Provider, rentalOpAlert, "success")
+		m.logger.Infof("Rental %s of account %q lapses at %s", rental.RentalID, rental.AccountID, rental.ExpiresAt.Format(time.RFC3339))
+	}
+}
+
+func (m *RentalManager) expireLapsed(ctx context.Context) {
+	rentals, err := m.repo.FindLapsed(ctx, time.Now())
+	if err != nil {
+		m.logger.Errorf("Failed to find lapsed rentals: %v", err)
+		return
+	}
+
+	for _, rental := range rentals {
+		// SMS that arrived in the last minutes of the period are kept in the history
+		if err := m.syncMessages(ctx, rental); err != nil {
+			m.logger.Warnf("Failed to read inbox of lapsed rental %s: %v", rental.RentalID, err)
+		}
+
+		ended, err := m.repo.End(ctx, rental.RentalID, models.RentalStatusExpired)
+		if err != nil {
+			m.logger.Errorf("Failed to expire rental %s: %v", rental.RentalID, err)
+			continue
+		}
+		if !ended {
+			continue
+		}
+
+		rental.Status = models.RentalStatusExpired
+		m.metrics.IncrementRentalOperation(rental.Provider, rentalOpExpire, "success")
+
+		if err := m.events.PublishRentalExpired(rental); err != nil {
+			m.logger.Errorf("Failed to publish expiry of rental %s: %v", rental.RentalID, err)
+		}
+
+		m.logger.Infof("Rental %s of number %s lapsed", rental.RentalID, rental.PhoneNumber)
+	}
+}
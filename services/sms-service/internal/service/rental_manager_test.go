@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/sms-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRentTestClient(t *testing.T, responses map[string]string) *SMSActivateClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responses[r.URL.Query().Get("action")]))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewSMSActivateClient("key", logger.NewFromConfig(logger.Config{Output: io.Discard}))
+	client.baseURL = server.URL
+	return client
+}
+
+func TestRentHours(t *testing.T) {
+	m := &RentalManager{policy: RentalPolicy{DefaultHours: 168, MaxHours: 720}}
+
+	hours, err := m.rentHours(0)
+	require.NoError(t, err)
+	assert.Equal(t, 168, hours)
+
+	hours, err = m.rentHours(24)
+	require.NoError(t, err)
+	assert.Equal(t, 24, hours)
+
+	_, err = m.rentHours(2)
+	assert.ErrorIs(t, err, ErrInvalidRentTime)
+
+	_, err = m.rentHours(1000)
+	assert.ErrorIs(t, err, ErrInvalidRentTime)
+}
+
+func TestSMSActivateRentNumber(t *testing.T) {
+	client := newRentTestClient(t, map[string]string{
+		"getRentServicesAndCountries": `{"services":{"vk":{"cost":21.95,"quant":20},"full":{"cost":42.93,"quant":20}}}`,
+		"getRentNumber":               `{"status":"success","phone":{"id":1049,"endDate":"2024-01-22T12:00:00","number":"79959707564"}}`,
+	})
+
+	rental, err := client.RentNumber(context.Background(), "vk", "RU", "", 168)
+	require.NoError(t, err)
+	assert.Equal(t, "1049", rental.ProviderRentID)
+	assert.Equal(t, "79959707564", rental.PhoneNumber)
+	assert.Equal(t, 21.95, rental.Price)
+	assert.Equal(t, 168, rental.Hours)
+	assert.Equal(t, time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC), rental.ExpiresAt.UTC())
+}
+
+func TestSMSActivateRentNumberFailure(t *testing.T) {
+	client := newRentTestClient(t, map[string]string{
+		"getRentNumber": `{"status":"error","message":"NO_NUMBERS"}`,
+	})
+
+	_, err := client.RentNumber(context.Background(), "vk", "RU", "", 168)
+	assert.ErrorContains(t, err, "NO_NUMBERS")
+}
+
+func TestSMSActivateGetRentMessages(t *testing.T) {
+	client := newRentTestClient(t, map[string]string{
+		"getRentStatus": `{"status":"success","quantity":"2","values":{
+			"0":{"phoneFrom":"VK","text":"VK: 482913 - код подтверждения","service":"vk","date":"2024-01-16 10:00:00"},
+			"1":{"phoneFrom":"VK","text":"VK: 113355 - код подтверждения","service":"vk","date":"2024-01-15 10:00:00"}}}`,
+	})
+
+	messages, err := client.GetRentMessages(context.Background(), "1049")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	// Oldest first, with keys stable across polls
+	assert.Contains(t, messages[0].Text, "113355")
+	assert.Equal(t, "VK", messages[0].Sender)
+	assert.Equal(t, time.Date(2024, 1, 15, 7, 0, 0, 0, time.UTC), messages[0].ReceivedAt.UTC())
+	assert.Equal(t, rentMessageKey("2024-01-15 10:00:00", "VK", messages[0].Text), messages[0].MessageKey)
+	assert.NotEqual(t, messages[0].MessageKey, messages[1].MessageKey)
+}
+
+func TestSMSActivateGetRentMessagesEmptyInbox(t *testing.T) {
+	client := newRentTestClient(t, map[string]string{
+		"getRentStatus": `{"status":"error","message":"STATUS_WAIT_CODE"}`,
+	})
+
+	messages, err := client.GetRentMessages(context.Background(), "1049")
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestNewRentalEvent(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	rental := &models.Rental{
+		RentalID:    "rental-1",
+		UserID:      "user-1",
+		AccountID:   "account-1",
+		PhoneNumber: "79959707564",
+		Service:     "vk",
+		Country:     "RU",
+		Provider:    "smsactivate",
+		Status:      models.RentalStatusActive,
+		ExpiresAt:   now.Add(18 * time.Hour),
+	}
+
+	event := newRentalEvent(rentalExpiringRoutingKey, rental, now)
+	assert.Equal(t, "sms.rental.expiring", event.Type)
+	assert.Equal(t, "account-1", event.AccountID)
+	assert.InDelta(t, 18, event.HoursLeft, 1e-9)
+
+	rental.Status = models.RentalStatusExpired
+	event = newRentalEvent(rentalExpiredRoutingKey, rental, now)
+	assert.Zero(t, event.HoursLeft)
+}
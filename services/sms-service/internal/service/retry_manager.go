@@ -5,20 +5,23 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
 	"github.com/grigta/conveer/services/sms-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
 )
 
 type RetryManager struct {
 	channel *amqp.Channel
-	logger  *logrus.Logger
+	delayed *messaging.DelayedPublisher
+	logger  logger.Logger
 }
 
-func NewRetryManager(channel *amqp.Channel, logger *logrus.Logger) *RetryManager {
+func NewRetryManager(channel *amqp.Channel, logger logger.Logger) *RetryManager {
 	return &RetryManager{
 		channel: channel,
+		delayed: messaging.NewDelayedPublisher(channel, messaging.DelayModeFromEnv()),
 		logger:  logger,
 	}
 }
@@ -29,21 +32,33 @@ func (r *RetryManager) ScheduleRetry(ctx context.Context, activation *models.Act
 		return err
 	}
 
-	// Publish to retry queue with delay
-	return r.channel.Publish(
+	// The broker holds the message back until the delay has passed
+	return r.delayed.Publish(
 		"sms.commands",
 		"retry",
-		false,
-		false,
 		amqp.Publishing{
 			ContentType:  "application/json",
 			Body:         data,
 			DeliveryMode: amqp.Persistent,
-			Expiration:   delay.String(),
+			Priority:     retryPriority(activation, time.Now()),
 		},
+		delay,
 	)
 }
 
+// retryPriority favours activations that are about to expire
+func retryPriority(activation *models.Activation, now time.Time) uint8 {
+	left := activation.ExpiresAt.Sub(now)
+	switch {
+	case left <= 5*time.Minute:
+		return messaging.MaxPriority
+	case left <= 15*time.Minute:
+		return messaging.MaxPriority / 2
+	default:
+		return 0
+	}
+}
+
 func (r *RetryManager) StartWorker(ctx context.Context, smsService *SMSService) {
 	msgs, err := r.channel.Consume(
 		"sms.retry",
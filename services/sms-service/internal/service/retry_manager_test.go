@@ -3,12 +3,13 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"testing"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/sms-service/internal/models"
 
-	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -42,14 +43,13 @@ type RetryManagerTestSuite struct {
 	ctx     context.Context
 	cancel  context.CancelFunc
 	channel *MockAMQPChannel
-	logger  *logrus.Logger
+	logger  logger.Logger
 }
 
 func (s *RetryManagerTestSuite) SetupTest() {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 	s.channel = new(MockAMQPChannel)
-	s.logger = logrus.New()
-	s.logger.SetLevel(logrus.DebugLevel)
+	s.logger = logger.NewFromConfig(logger.Config{Output: io.Discard})
 }
 
 func (s *RetryManagerTestSuite) TearDownTest() {
@@ -113,10 +113,10 @@ func TestRetryBackoffCalculation(t *testing.T) {
 // Test maximum retry limit
 func TestMaxRetryLimit(t *testing.T) {
 	maxRetries := 4
-	
+
 	for retryCount := 0; retryCount <= 5; retryCount++ {
 		shouldRetry := retryCount < maxRetries
-		
+
 		if retryCount < maxRetries {
 			assert.True(t, shouldRetry, "Retry count %d should allow retry", retryCount)
 		} else {
@@ -319,7 +319,7 @@ func TestRetryScenarios(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			maxRetries := 4
-			
+
 			var action string
 			switch {
 			case tt.currentStatus == "STATUS_OK":
@@ -331,7 +331,7 @@ func TestRetryScenarios(t *testing.T) {
 			default:
 				action = "retry"
 			}
-			
+
 			assert.Equal(t, tt.expectedAction, action)
 		})
 	}
@@ -384,3 +384,25 @@ func BenchmarkExponentialBackoff(b *testing.B) {
 	}
 }
 
+// Test retryPriority - activations about to expire are retried first
+func TestRetryPriority(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		left     time.Duration
+		expected uint8
+	}{
+		{"expiring soon", 3 * time.Minute, 10},
+		{"expiring within a quarter hour", 10 * time.Minute, 5},
+		{"plenty of time", 25 * time.Minute, 0},
+		{"already expired", -time.Minute, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activation := &models.Activation{ExpiresAt: now.Add(tt.left)}
+			assert.Equal(t, tt.expected, retryPriority(activation, now))
+		})
+	}
+}
@@ -3,25 +3,31 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/sms-service/internal/models"
 	"github.com/grigta/conveer/services/sms-service/internal/repository"
 
 	"github.com/google/uuid"
-	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
 type SMSService struct {
-	phoneRepo        *repository.PhoneRepository
-	activationRepo   *repository.ActivationRepository
-	providerAdapter  *ProviderAdapter
-	smsActivate      *SMSActivateClient
-	cache            *CacheService
-	retryManager     *RetryManager
-	metrics          *MetricsCollector
-	logger           *logrus.Logger
+	phoneRepo       *repository.PhoneRepository
+	activationRepo  *repository.ActivationRepository
+	providerAdapter *ProviderAdapter
+	smsActivate     *SMSActivateClient
+	priceCatalog    *PriceCatalog
+	cache           *CacheService
+	retryManager    *RetryManager
+	validator       *CodeValidator
+	events          *EventPublisher
+	metrics         *MetricsCollector
+	logger          logger.Logger
+	idempotencyTTL  time.Duration
+	health          *ProviderHealthTracker
 }
 
 func NewSMSService(
@@ -29,49 +35,72 @@ func NewSMSService(
 	activationRepo *repository.ActivationRepository,
 	providerAdapter *ProviderAdapter,
 	smsActivate *SMSActivateClient,
+	priceCatalog *PriceCatalog,
 	cache *CacheService,
 	retryManager *RetryManager,
+	validator *CodeValidator,
+	events *EventPublisher,
 	metrics *MetricsCollector,
-	logger *logrus.Logger,
+	logger logger.Logger,
 ) *SMSService {
 	return &SMSService{
-		phoneRepo:        phoneRepo,
-		activationRepo:   activationRepo,
-		providerAdapter:  providerAdapter,
-		smsActivate:      smsActivate,
-		cache:            cache,
-		retryManager:     retryManager,
-		metrics:          metrics,
-		logger:           logger,
+		phoneRepo:       phoneRepo,
+		activationRepo:  activationRepo,
+		providerAdapter: providerAdapter,
+		smsActivate:     smsActivate,
+		priceCatalog:    priceCatalog,
+		cache:           cache,
+		retryManager:    retryManager,
+		validator:       validator,
+		events:          events,
+		metrics:         metrics,
+		logger:          logger,
+		idempotencyTTL:  defaultIdempotencyTTL,
 	}
 }
 
-func (s *SMSService) PurchaseNumber(ctx context.Context, userID, service, country, operator, provider string, maxPrice int32) (*models.Activation, error) {
+// PurchaseNumber buys a number. With an idempotency key, a repeated request returns the
+// activation of the first one, reported by the second return value.
+func (s *SMSService) PurchaseNumber(ctx context.Context, userID, service, country, operator, provider string, maxPrice int32, idempotencyKey string) (*models.Activation, bool, error) {
+	if idempotencyKey != "" {
+		return s.purchaseIdempotent(ctx, userID, service, country, operator, provider, maxPrice, idempotencyKey)
+	}
+
+	activation, _, err := s.purchase(ctx, userID, service, country, operator, provider, maxPrice, "")
+	return activation, false, err
+}
+
+// purchase acquires one number and stores its activation. It also returns the provider
+// that was tried last, so failures can be attributed.
+func (s *SMSService) purchase(ctx context.Context, userID, service, country, operator, provider string, maxPrice int32, batchID string) (*models.Activation, string, error) {
 	// Generate activation ID
 	activationID := uuid.New().String()
 
-	// Select provider if not specified
-	if provider == "" {
-		provider = s.providerAdapter.SelectProvider(service, country)
-	}
-
-	// Purchase number from provider
 	var phone *models.Phone
 	var err error
 
-	switch provider {
-	case "smsactivate":
-		phone, err = s.smsActivate.PurchaseNumber(ctx, service, country, operator, float64(maxPrice))
-	default:
-		return nil, fmt.Errorf("unsupported provider: %s", provider)
+	if provider == "" {
+		// Route to the cheapest provider satisfying the constraints, then fall back to the adapter
+		phone, provider, err = s.purchaseCheapest(ctx, service, country, operator, float64(maxPrice))
+		if phone == nil && err == nil {
+			provider = s.providerAdapter.SelectProvider(service, country)
+		}
+	}
+
+	if phone == nil && err == nil {
+		phone, err = s.purchaseFromProvider(ctx, provider, service, country, operator, float64(maxPrice))
+		if err != nil {
+			s.logger.Errorf("Failed to purchase number from %s: %v", provider, err)
+			s.metrics.IncrementPurchaseFailed(provider, service)
+		}
 	}
 
 	if err != nil {
-		s.logger.Errorf("Failed to purchase number from %s: %v", provider, err)
-		s.metrics.IncrementPurchaseFailed(provider, service)
-		return nil, err
+		return nil, provider, err
 	}
 
+	s.recordRealizedPrice(provider, service, country, phone.Price)
+
 	// Save phone to database
 	phone.UserID = userID
 	phone.ActivationID = activationID
@@ -80,7 +109,7 @@ func (s *SMSService) PurchaseNumber(ctx context.Context, userID, service, countr
 
 	if err := s.phoneRepo.Create(ctx, phone); err != nil {
 		s.logger.Errorf("Failed to save phone: %v", err)
-		return nil, err
+		return nil, provider, err
 	}
 
 	// Create activation
@@ -95,11 +124,12 @@ func (s *SMSService) PurchaseNumber(ctx context.Context, userID, service, countr
 		Status:       models.ActivationStatusWaiting,
 		Price:        phone.Price,
 		ExpiresAt:    phone.ExpiresAt,
+		BatchID:      batchID,
 	}
 
 	if err := s.activationRepo.Create(ctx, activation); err != nil {
 		s.logger.Errorf("Failed to create activation: %v", err)
-		return nil, err
+		return nil, provider, err
 	}
 
 	// Cache activation
@@ -109,10 +139,69 @@ func (s *SMSService) PurchaseNumber(ctx context.Context, userID, service, countr
 	s.metrics.IncrementPurchaseSuccess(provider, service)
 	s.metrics.RecordPurchasePrice(provider, phone.Price)
 
+	if err := s.events.PublishNumberPurchased(activation); err != nil {
+		s.logger.Errorf("Failed to publish number purchase for activation %s: %v", activationID, err)
+	}
+
 	s.logger.Infof("Successfully purchased number %s for user %s, activation %s",
 		phone.Number, userID, activationID)
 
-	return activation, nil
+	return activation, provider, nil
+}
+
+// purchaseCheapest tries catalog candidates cheapest first. It returns no phone and no error
+// when the catalog has no candidate, so the caller can fall back to regular selection.
+func (s *SMSService) purchaseCheapest(ctx context.Context, service, country, operator string, maxPrice float64) (*models.Phone, string, error) {
+	if s.priceCatalog == nil {
+		return nil, "", nil
+	}
+
+	candidates := s.priceCatalog.Candidates(service, country, maxPrice)
+	if len(candidates) == 0 {
+		return nil, "", nil
+	}
+
+	var lastErr error
+	for _, candidate := range candidates {
+		phone, err := s.purchaseFromProvider(ctx, candidate.Provider, service, country, operator, maxPrice)
+		if err == nil {
+			return phone, candidate.Provider, nil
+		}
+
+		s.logger.Warnf("Failed to purchase number from %s at catalog price %.2f: %v",
+			candidate.Provider, candidate.Price, err)
+		s.metrics.IncrementPurchaseFailed(candidate.Provider, service)
+		lastErr = err
+	}
+
+	lastProvider := candidates[len(candidates)-1].Provider
+	return nil, lastProvider, fmt.Errorf("all %d catalog providers failed: %w", len(candidates), lastErr)
+}
+
+func (s *SMSService) purchaseFromProvider(ctx context.Context, provider, service, country, operator string, maxPrice float64) (*models.Phone, error) {
+	switch provider {
+	case "smsactivate":
+		return s.smsActivate.PurchaseNumber(ctx, service, country, operator, maxPrice)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", provider)
+	}
+}
+
+func (s *SMSService) recordRealizedPrice(provider, service, country string, price float64) {
+	if s.priceCatalog == nil {
+		return
+	}
+	if catalogPrice, ok := s.priceCatalog.CatalogPrice(provider, service, country); ok {
+		s.metrics.RecordRealizedPrice(provider, service, price, catalogPrice)
+	}
+}
+
+// GetPrices returns catalog prices, cheapest first. Empty filters match everything.
+func (s *SMSService) GetPrices(ctx context.Context, service, country, provider string) ([]models.ProviderPrice, error) {
+	if s.priceCatalog == nil {
+		return nil, fmt.Errorf("price catalog is not configured")
+	}
+	return s.priceCatalog.GetPrices(service, country, provider), nil
 }
 
 func (s *SMSService) GetSMSCode(ctx context.Context, activationID, userID string) (string, string, error) {
@@ -145,13 +234,16 @@ func (s *SMSService) GetSMSCode(ctx context.Context, activationID, userID string
 	if activation.Status == models.ActivationStatusExpired {
 		return "", "", fmt.Errorf("activation is expired")
 	}
+	if activation.Status == models.ActivationStatusFlagged {
+		return "", "", suspiciousSMSError(activation.CodeAnomalies)
+	}
 
-	// Get code from provider
-	var code, fullSMS string
+	// Get SMS from provider
+	var fullSMS string
 
 	switch activation.Provider {
 	case "smsactivate":
-		code, fullSMS, err = s.smsActivate.GetSMSCode(ctx, activation.ActivationID)
+		fullSMS, err = s.smsActivate.GetSMSCode(ctx, activation.ActivationID)
 	default:
 		return "", "", fmt.Errorf("unsupported provider: %s", activation.Provider)
 	}
@@ -163,6 +255,13 @@ func (s *SMSService) GetSMSCode(ctx context.Context, activationID, userID string
 		return "", "", fmt.Errorf("SMS code not yet received, please try again later")
 	}
 
+	// Numbers are reused, so the SMS may come from another service or be a phishing text
+	check := s.validator.Validate(activation.Service, "", fullSMS)
+	if check.Suspicious() {
+		return "", "", s.flagCode(ctx, activation, fullSMS, check.Anomalies)
+	}
+	code := check.Code
+
 	// Update activation with code
 	if err := s.activationRepo.UpdateCode(ctx, activationID, code, fullSMS); err != nil {
 		s.logger.Errorf("Failed to update activation code: %v", err)
@@ -180,11 +279,45 @@ func (s *SMSService) GetSMSCode(ctx context.Context, activationID, userID string
 	// Update metrics
 	s.metrics.IncrementCodeReceived(activation.Provider, activation.Service)
 
+	if err := s.events.PublishCodeReceived(activation); err != nil {
+		s.logger.Errorf("Failed to publish code receipt for activation %s: %v", activationID, err)
+	}
+
 	s.logger.Infof("Successfully received SMS code for activation %s", activationID)
 
 	return code, fullSMS, nil
 }
 
+// flagCode withholds the code of an SMS that failed validation and reports the anomalies
+func (s *SMSService) flagCode(ctx context.Context, activation *models.Activation, fullSMS string, anomalies []string) error {
+	if err := s.activationRepo.FlagCode(ctx, activation.ActivationID, fullSMS, anomalies); err != nil {
+		s.logger.Errorf("Failed to flag activation %s: %v", activation.ActivationID, err)
+		return err
+	}
+
+	activation.FullSMS = fullSMS
+	activation.CodeAnomalies = anomalies
+	activation.Status = models.ActivationStatusFlagged
+	s.cache.SetActivation(ctx, activation.ActivationID, activation, 10*time.Minute)
+
+	for _, anomaly := range anomalies {
+		s.metrics.IncrementCodeAnomaly(activation.Provider, activation.Service, anomaly)
+	}
+
+	if err := s.events.PublishCodeAnomaly(activation, anomalies); err != nil {
+		s.logger.Errorf("Failed to publish code anomaly for activation %s: %v", activation.ActivationID, err)
+	}
+
+	s.logger.Warnf("SMS for activation %s (%s) flagged: %s",
+		activation.ActivationID, activation.Service, strings.Join(anomalies, ", "))
+
+	return suspiciousSMSError(anomalies)
+}
+
+func suspiciousSMSError(anomalies []string) error {
+	return fmt.Errorf("%w: %s", ErrSuspiciousSMS, strings.Join(anomalies, ", "))
+}
+
 func (s *SMSService) CancelActivation(ctx context.Context, activationID, userID, reason string) (bool, float64, error) {
 	// Get activation
 	activation, err := s.activationRepo.FindByActivationID(ctx, activationID)
@@ -209,7 +342,8 @@ func (s *SMSService) CancelActivation(ctx context.Context, activationID, userID,
 	refunded := false
 	refundAmount := 0.0
 
-	if activation.Code == "" && activation.Status == models.ActivationStatusWaiting {
+	// A flagged SMS never reached the caller, so the activation is still cancelled with the provider
+	if activation.Code == "" && (activation.Status == models.ActivationStatusWaiting || activation.Status == models.ActivationStatusFlagged) {
 		// Cancel with provider
 		switch activation.Provider {
 		case "smsactivate":
@@ -239,6 +373,13 @@ func (s *SMSService) CancelActivation(ctx context.Context, activationID, userID,
 	// Update metrics
 	s.metrics.IncrementCancellation(activation.Provider, activation.Service, refunded)
 
+	activation.Status = models.ActivationStatusCancelled
+	activation.Refunded = refunded
+	activation.RefundAmount = refundAmount
+	if err := s.events.PublishActivationCancelled(activation, reason); err != nil {
+		s.logger.Errorf("Failed to publish cancellation for activation %s: %v", activationID, err)
+	}
+
 	s.logger.Infof("Successfully cancelled activation %s, refunded: %v, amount: %.2f",
 		activationID, refunded, refundAmount)
 
@@ -292,6 +433,7 @@ func (s *SMSService) GetStatistics(ctx context.Context, userID string, fromDate,
 	if err != nil {
 		return nil, err
 	}
+	stats.ProviderHealth = s.GetProviderHealth()
 
 	return stats, nil
 }
@@ -394,6 +536,11 @@ func (s *SMSService) processExpiredActivations(ctx context.Context) {
 		// Clear cache
 		s.cache.DeleteActivation(ctx, activation.ActivationID)
 
+		activation.Status = models.ActivationStatusExpired
+		if err := s.events.PublishActivationExpired(activation); err != nil {
+			s.logger.Errorf("Failed to publish expiry for activation %s: %v", activation.ActivationID, err)
+		}
+
 		s.logger.Infof("Marked activation %s as expired", activation.ActivationID)
 	}
 }
@@ -2,27 +2,30 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/sms-service/internal/models"
-
-	"github.com/sirupsen/logrus"
 )
 
 type SMSActivateClient struct {
 	apiKey  string
 	baseURL string
 	client  *http.Client
-	logger  *logrus.Logger
+	logger  logger.Logger
 }
 
-func NewSMSActivateClient(apiKey string, logger *logrus.Logger) *SMSActivateClient {
+func NewSMSActivateClient(apiKey string, logger logger.Logger) *SMSActivateClient {
 	return &SMSActivateClient{
 		apiKey:  apiKey,
 		baseURL: "https://api.sms-activate.org/stubs/handler_api.php",
@@ -36,7 +39,7 @@ func NewSMSActivateClient(apiKey string, logger *logrus.Logger) *SMSActivateClie
 func (c *SMSActivateClient) PurchaseNumber(ctx context.Context, service, country, operator string, maxPrice float64) (*models.Phone, error) {
 	params := url.Values{}
 	params.Set("api_key", c.apiKey)
-	params.Set("action", "getNumber")
+	params.Set("action", "getNumberV2")
 	params.Set("service", c.mapService(service))
 	params.Set("country", c.mapCountry(country))
 
@@ -52,37 +55,88 @@ func (c *SMSActivateClient) PurchaseNumber(ctx context.Context, service, country
 		return nil, err
 	}
 
-	// Parse response: ACCESS_NUMBER:123456789:1234567890
-	parts := strings.Split(resp, ":")
-	if len(parts) < 3 {
-		return nil, fmt.Errorf("invalid response format: %s", resp)
+	// V2 responds with JSON on success and a plain status (NO_NUMBERS, NO_BALANCE, ...) on failure
+	var number struct {
+		ActivationID   json.Number `json:"activationId"`
+		PhoneNumber    string      `json:"phoneNumber"`
+		ActivationCost json.Number `json:"activationCost"`
 	}
-
-	if parts[0] != "ACCESS_NUMBER" {
+	if err := json.Unmarshal([]byte(resp), &number); err != nil || number.ActivationID == "" {
 		return nil, fmt.Errorf("failed to get number: %s", resp)
 	}
 
-	activationID := parts[1]
-	phoneNumber := parts[2]
-
-	// Get price info
-	price := c.getPrice(service, country)
+	// Realized price; falls back to the estimate when the provider omits it
+	price, err := number.ActivationCost.Float64()
+	if err != nil || price <= 0 {
+		price = c.getPrice(service, country)
+	}
 
 	phone := &models.Phone{
-		Number:       phoneNumber,
+		Number:       number.PhoneNumber,
 		CountryCode:  c.getCountryCode(country),
 		Country:      country,
 		Operator:     operator,
 		Provider:     "smsactivate",
 		Service:      service,
 		Price:        price,
-		ActivationID: activationID,
+		ActivationID: number.ActivationID.String(),
 	}
 
 	return phone, nil
 }
 
-func (c *SMSActivateClient) GetSMSCode(ctx context.Context, activationID string) (string, string, error) {
+// GetPrices fetches the current price list for all supported services and countries
+func (c *SMSActivateClient) GetPrices(ctx context.Context) ([]models.ProviderPrice, error) {
+	params := url.Values{}
+	params.Set("api_key", c.apiKey)
+	params.Set("action", "getPrices")
+
+	resp, err := c.makeRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response: {"<country id>": {"<service code>": {"cost": 10.5, "count": 120}}}
+	var catalog map[string]map[string]struct {
+		Cost  float64 `json:"cost"`
+		Count int     `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(resp), &catalog); err != nil {
+		return nil, fmt.Errorf("failed to get prices: %s", resp)
+	}
+
+	services := reverseMap(smsActivateServices)
+	countries := reverseMap(smsActivateCountries)
+	now := time.Now()
+
+	var prices []models.ProviderPrice
+	for countryID, byService := range catalog {
+		country, ok := countries[countryID]
+		if !ok {
+			continue
+		}
+		for serviceCode, entry := range byService {
+			service, ok := services[serviceCode]
+			if !ok {
+				continue
+			}
+			prices = append(prices, models.ProviderPrice{
+				Provider:  "smsactivate",
+				Service:   service,
+				Country:   country,
+				Price:     entry.Cost,
+				Currency:  "RUB",
+				Available: entry.Count,
+				UpdatedAt: now,
+			})
+		}
+	}
+
+	return prices, nil
+}
+
+// GetSMSCode returns the full text of the received SMS. Extracting the code is left to CodeValidator.
+func (c *SMSActivateClient) GetSMSCode(ctx context.Context, activationID string) (string, error) {
 	params := url.Values{}
 	params.Set("api_key", c.apiKey)
 	params.Set("action", "getStatus")
@@ -90,24 +144,23 @@ func (c *SMSActivateClient) GetSMSCode(ctx context.Context, activationID string)
 
 	resp, err := c.makeRequest(ctx, params)
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
 
 	// Parse response
 	if resp == "STATUS_WAIT_CODE" {
-		return "", "", fmt.Errorf("waiting for code")
+		return "", fmt.Errorf("waiting for code")
 	}
 
 	if strings.HasPrefix(resp, "STATUS_OK:") {
-		code := strings.TrimPrefix(resp, "STATUS_OK:")
-		return c.extractCode(code), code, nil
+		return strings.TrimPrefix(resp, "STATUS_OK:"), nil
 	}
 
 	if strings.HasPrefix(resp, "STATUS_CANCEL") {
-		return "", "", fmt.Errorf("activation cancelled")
+		return "", fmt.Errorf("activation cancelled")
 	}
 
-	return "", "", fmt.Errorf("unexpected status: %s", resp)
+	return "", fmt.Errorf("unexpected status: %s", resp)
 }
 
 func (c *SMSActivateClient) CancelActivation(ctx context.Context, activationID string) (bool, float64, error) {
@@ -153,6 +206,213 @@ func (c *SMSActivateClient) GetBalance(ctx context.Context) (float64, string, er
 	return 0, "", fmt.Errorf("failed to get balance: %s", resp)
 }
 
+// smsActivateZone is the time zone of dates in SMS-Activate rent responses
+var smsActivateZone = time.FixedZone("MSK", 3*60*60)
+
+// smsActivateRentPhone is the phone object of rent and renewal responses
+type smsActivateRentPhone struct {
+	ID      json.Number `json:"id"`
+	EndDate string      `json:"endDate"`
+	Number  string      `json:"number"`
+}
+
+// RentNumber rents a number for the given hours. The price is quoted from the rent price list
+// before the purchase, since the rent response does not report it.
+func (c *SMSActivateClient) RentNumber(ctx context.Context, service, country, operator string, hours int) (*models.Rental, error) {
+	price, err := c.GetRentPrice(ctx, service, country, hours)
+	if err != nil {
+		c.logger.Warnf("Failed to quote rent of %s in %s: %v", service, country, err)
+	}
+
+	params := url.Values{}
+	params.Set("api_key", c.apiKey)
+	params.Set("action", "getRentNumber")
+	params.Set("service", c.mapService(service))
+	params.Set("country", c.mapCountry(country))
+	params.Set("rent_time", strconv.Itoa(hours))
+	if operator != "" {
+		params.Set("operator", operator)
+	}
+
+	phone, err := c.rentRequest(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rent number: %w", err)
+	}
+
+	expiresAt, err := time.ParseInLocation("2006-01-02T15:04:05", phone.EndDate, smsActivateZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rent end date %q: %w", phone.EndDate, err)
+	}
+
+	return &models.Rental{
+		ProviderRentID: phone.ID.String(),
+		PhoneNumber:    phone.Number,
+		Service:        service,
+		Country:        country,
+		Operator:       operator,
+		Provider:       "smsactivate",
+		Hours:          hours,
+		Price:          price,
+		ExpiresAt:      expiresAt,
+	}, nil
+}
+
+// RenewRental extends the rent by the given hours and returns the new end of the period and its price
+func (c *SMSActivateClient) RenewRental(ctx context.Context, rentID, service, country string, hours int) (time.Time, float64, error) {
+	price, err := c.GetRentPrice(ctx, service, country, hours)
+	if err != nil {
+		c.logger.Warnf("Failed to quote renewal of rent %s: %v", rentID, err)
+	}
+
+	params := url.Values{}
+	params.Set("api_key", c.apiKey)
+	params.Set("action", "continueRentNumber")
+	params.Set("id", rentID)
+	params.Set("rent_time", strconv.Itoa(hours))
+
+	phone, err := c.rentRequest(ctx, params)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("failed to renew rent: %w", err)
+	}
+
+	expiresAt, err := time.ParseInLocation("2006-01-02T15:04:05", phone.EndDate, smsActivateZone)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid rent end date %q: %w", phone.EndDate, err)
+	}
+
+	return expiresAt, price, nil
+}
+
+// ReleaseRental finishes the rent, the number goes back to the provider
+func (c *SMSActivateClient) ReleaseRental(ctx context.Context, rentID string) error {
+	params := url.Values{}
+	params.Set("api_key", c.apiKey)
+	params.Set("action", "setRentStatus")
+	params.Set("id", rentID)
+	params.Set("status", "1") // Finish
+
+	resp, err := c.makeRequest(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil || result.Status != "success" {
+		return fmt.Errorf("failed to release rent: %s", resp)
+	}
+
+	return nil
+}
+
+// GetRentMessages returns every SMS the rented number received, oldest first
+func (c *SMSActivateClient) GetRentMessages(ctx context.Context, rentID string) ([]*models.RentalMessage, error) {
+	params := url.Values{}
+	params.Set("api_key", c.apiKey)
+	params.Set("action", "getRentStatus")
+	params.Set("id", rentID)
+
+	resp, err := c.makeRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response: {"status":"success","quantity":"2","values":{"0":{"phoneFrom":"VK","text":"...","service":"vk","date":"2024-01-15 12:00:00"}}}
+	var result struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Values  map[string]struct {
+			PhoneFrom string `json:"phoneFrom"`
+			Text      string `json:"text"`
+			Date      string `json:"date"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		return nil, fmt.Errorf("failed to get rent status: %s", resp)
+	}
+	if result.Status != "success" {
+		// An inbox without SMS is reported as an error
+		if result.Message == "STATUS_WAIT_CODE" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get rent status: %s", resp)
+	}
+
+	messages := make([]*models.RentalMessage, 0, len(result.Values))
+	for _, value := range result.Values {
+		receivedAt, err := time.ParseInLocation("2006-01-02 15:04:05", value.Date, smsActivateZone)
+		if err != nil {
+			receivedAt = time.Now()
+		}
+		messages = append(messages, &models.RentalMessage{
+			MessageKey: rentMessageKey(value.Date, value.PhoneFrom, value.Text),
+			Sender:     value.PhoneFrom,
+			Text:       value.Text,
+			ReceivedAt: receivedAt,
+		})
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ReceivedAt.Before(messages[j].ReceivedAt) })
+
+	return messages, nil
+}
+
+// GetRentPrice quotes the rent of a number for the given hours
+func (c *SMSActivateClient) GetRentPrice(ctx context.Context, service, country string, hours int) (float64, error) {
+	params := url.Values{}
+	params.Set("api_key", c.apiKey)
+	params.Set("action", "getRentServicesAndCountries")
+	params.Set("country", c.mapCountry(country))
+	params.Set("rent_time", strconv.Itoa(hours))
+
+	resp, err := c.makeRequest(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		Services map[string]struct {
+			Cost float64 `json:"cost"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		return 0, fmt.Errorf("failed to get rent prices: %s", resp)
+	}
+
+	entry, ok := result.Services[c.mapService(service)]
+	if !ok {
+		return 0, fmt.Errorf("no rent price for %s", service)
+	}
+
+	return entry.Cost, nil
+}
+
+// rentRequest runs a rent or renewal request and returns the rented phone
+func (c *SMSActivateClient) rentRequest(ctx context.Context, params url.Values) (*smsActivateRentPhone, error) {
+	resp, err := c.makeRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status  string                `json:"status"`
+		Message string                `json:"message"`
+		Phone   *smsActivateRentPhone `json:"phone"`
+	}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil || result.Status != "success" || result.Phone == nil {
+		return nil, fmt.Errorf("%s", resp)
+	}
+
+	return result.Phone, nil
+}
+
+// rentMessageKey identifies an SMS of a rent inbox, which has no stable message IDs
+func rentMessageKey(date, sender, text string) string {
+	sum := sha256.Sum256([]byte(date + "\x00" + sender + "\x00" + text))
+	return hex.EncodeToString(sum[:16])
+}
+
 func (c *SMSActivateClient) makeRequest(ctx context.Context, params url.Values) (string, error) {
 	url := c.baseURL + "?" + params.Encode()
 
@@ -175,51 +435,59 @@ func (c *SMSActivateClient) makeRequest(ctx context.Context, params url.Values)
 	return string(body), nil
 }
 
+// Internal service names to SMSActivate service codes
+var smsActivateServices = map[string]string{
+	"whatsapp":  "wa",
+	"telegram":  "tg",
+	"google":    "go",
+	"facebook":  "fb",
+	"instagram": "ig",
+	"twitter":   "tw",
+	"vk":        "vk",
+	"other":     "ot",
+}
+
+// Country codes to SMSActivate country ids (simplified)
+var smsActivateCountries = map[string]string{
+	"US": "0",  // USA
+	"RU": "1",  // Russia
+	"KZ": "2",  // Kazakhstan
+	"CN": "3",  // China
+	"PH": "4",  // Philippines
+	"MM": "5",  // Myanmar
+	"ID": "6",  // Indonesia
+	"MY": "7",  // Malaysia
+	"KE": "8",  // Kenya
+	"TZ": "9",  // Tanzania
+	"VN": "10", // Vietnam
+	"GB": "16", // United Kingdom
+	"LV": "49", // Latvia
+	"PL": "15", // Poland
+	"UA": "46", // Ukraine
+}
+
 func (c *SMSActivateClient) mapService(service string) string {
-	// Map internal service names to SMSActivate service codes
-	serviceMap := map[string]string{
-		"whatsapp":  "wa",
-		"telegram":  "tg",
-		"google":    "go",
-		"facebook":  "fb",
-		"instagram": "ig",
-		"twitter":   "tw",
-		"vk":        "vk",
-		"other":     "ot",
-	}
-
-	if code, ok := serviceMap[strings.ToLower(service)]; ok {
+	if code, ok := smsActivateServices[strings.ToLower(service)]; ok {
 		return code
 	}
 	return "ot" // Default to "other"
 }
 
 func (c *SMSActivateClient) mapCountry(country string) string {
-	// Map country codes (simplified)
-	countryMap := map[string]string{
-		"US": "0",   // USA
-		"RU": "1",   // Russia
-		"KZ": "2",   // Kazakhstan
-		"CN": "3",   // China
-		"PH": "4",   // Philippines
-		"MM": "5",   // Myanmar
-		"ID": "6",   // Indonesia
-		"MY": "7",   // Malaysia
-		"KE": "8",   // Kenya
-		"TZ": "9",   // Tanzania
-		"VN": "10",  // Vietnam
-		"GB": "16",  // United Kingdom
-		"LV": "49",  // Latvia
-		"PL": "15",  // Poland
-		"UA": "46",  // Ukraine
-	}
-
-	if code, ok := countryMap[strings.ToUpper(country)]; ok {
+	if code, ok := smsActivateCountries[strings.ToUpper(country)]; ok {
 		return code
 	}
 	return "0" // Default to USA
 }
 
+func reverseMap(m map[string]string) map[string]string {
+	reversed := make(map[string]string, len(m))
+	for k, v := range m {
+		reversed[v] = k
+	}
+	return reversed
+}
+
 func (c *SMSActivateClient) getCountryCode(country string) string {
 	// Get country dial code
 	codeMap := map[string]string{
@@ -265,17 +533,3 @@ func (c *SMSActivateClient) getPrice(service, country string) float64 {
 
 	return basePrice
 }
-
-func (c *SMSActivateClient) extractCode(fullSMS string) string {
-	// Extract numeric code from SMS
-	// Simple implementation - can be enhanced with regex
-	parts := strings.Fields(fullSMS)
-	for _, part := range parts {
-		if len(part) >= 4 && len(part) <= 8 {
-			if _, err := strconv.Atoi(part); err == nil {
-				return part
-			}
-		}
-	}
-	return fullSMS // Return full SMS if no code found
-}
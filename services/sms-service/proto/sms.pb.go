@@ -22,15 +22,17 @@ const (
 )
 
 type PurchaseNumberRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
-	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
-	Operator      string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
-	Provider      string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
-	MaxPrice      int32                  `protobuf:"varint,6,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Service  string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Country  string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Operator string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	Provider string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
+	MaxPrice int32                  `protobuf:"varint,6,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	// Repeating a request with the same key returns the original activation while it is live
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PurchaseNumberRequest) Reset() {
@@ -105,6 +107,13 @@ func (x *PurchaseNumberRequest) GetMaxPrice() int32 {
 	return 0
 }
 
+func (x *PurchaseNumberRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type PurchaseNumberResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ActivationId  string                 `protobuf:"bytes,1,opt,name=activation_id,json=activationId,proto3" json:"activation_id,omitempty"`
@@ -113,6 +122,7 @@ type PurchaseNumberResponse struct {
 	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`
 	Provider      string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
 	ExpiresAt     int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Deduplicated  bool                   `protobuf:"varint,7,opt,name=deduplicated,proto3" json:"deduplicated,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -189,6 +199,13 @@ func (x *PurchaseNumberResponse) GetExpiresAt() int64 {
 	return 0
 }
 
+func (x *PurchaseNumberResponse) GetDeduplicated() bool {
+	if x != nil {
+		return x.Deduplicated
+	}
+	return false
+}
+
 type GetSMSCodeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ActivationId  string                 `protobuf:"bytes,1,opt,name=activation_id,json=activationId,proto3" json:"activation_id,omitempty"`
@@ -869,102 +886,2281 @@ func (x *GetProviderBalanceResponse) GetUpdatedAt() int64 {
 	return 0
 }
 
-var File_services_sms_service_proto_sms_proto protoreflect.FileDescriptor
+type GetPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_services_sms_service_proto_sms_proto_rawDesc = "" +
-	"\n" +
-	"$services/sms-service/proto/sms.proto\x12\x03sms\"\xb9\x01\n" +
-	"\x15PurchaseNumberRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
-	"\aservice\x18\x02 \x01(\tR\aservice\x12\x18\n" +
-	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
-	"\boperator\x18\x04 \x01(\tR\boperator\x12\x1a\n" +
-	"\bprovider\x18\x05 \x01(\tR\bprovider\x12\x1b\n" +
-	"\tmax_price\x18\x06 \x01(\x05R\bmaxPrice\"\xd4\x01\n" +
-	"\x16PurchaseNumberResponse\x12#\n" +
-	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12!\n" +
-	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12!\n" +
-	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\x12\x1a\n" +
-	"\bprovider\x18\x05 \x01(\tR\bprovider\x12\x1d\n" +
-	"\n" +
-	"expires_at\x18\x06 \x01(\x03R\texpiresAt\"Q\n" +
-	"\x11GetSMSCodeRequest\x12#\n" +
-	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"d\n" +
-	"\x12GetSMSCodeResponse\x12\x12\n" +
-	"\x04code\x18\x01 \x01(\tR\x04code\x12\x19\n" +
-	"\bfull_sms\x18\x02 \x01(\tR\afullSms\x12\x1f\n" +
-	"\vreceived_at\x18\x03 \x01(\x03R\n" +
-	"receivedAt\"o\n" +
-	"\x17CancelActivationRequest\x12#\n" +
-	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"u\n" +
-	"\x18CancelActivationResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
-	"\brefunded\x18\x02 \x01(\bR\brefunded\x12#\n" +
-	"\rrefund_amount\x18\x03 \x01(\x02R\frefundAmount\"Z\n" +
-	"\x1aGetActivationStatusRequest\x12#\n" +
-	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x8c\x02\n" +
-	"\x1bGetActivationStatusResponse\x12#\n" +
-	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
-	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\x12\x18\n" +
-	"\aservice\x18\x04 \x01(\tR\aservice\x12\x12\n" +
-	"\x04code\x18\x05 \x01(\tR\x04code\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"expires_at\x18\a \x01(\x03R\texpiresAt\x12!\n" +
-	"\fcompleted_at\x18\b \x01(\x03R\vcompletedAt\"\x99\x01\n" +
-	"\x14GetStatisticsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
-	"\tfrom_date\x18\x02 \x01(\x03R\bfromDate\x12\x17\n" +
-	"\ato_date\x18\x03 \x01(\x03R\x06toDate\x12\x18\n" +
-	"\aservice\x18\x04 \x01(\tR\aservice\x12\x18\n" +
-	"\acountry\x18\x05 \x01(\tR\acountry\"\xc1\x05\n" +
-	"\x15GetStatisticsResponse\x12+\n" +
-	"\x11total_activations\x18\x01 \x01(\x05R\x10totalActivations\x125\n" +
-	"\x16successful_activations\x18\x02 \x01(\x05R\x15successfulActivations\x12-\n" +
-	"\x12failed_activations\x18\x03 \x01(\x05R\x11failedActivations\x123\n" +
-	"\x15cancelled_activations\x18\x04 \x01(\x05R\x14cancelledActivations\x12\x1f\n" +
-	"\vtotal_spent\x18\x05 \x01(\x02R\n" +
-	"totalSpent\x12#\n" +
-	"\raverage_price\x18\x06 \x01(\x02R\faveragePrice\x12H\n" +
-	"\n" +
-	"by_service\x18\a \x03(\v2).sms.GetStatisticsResponse.ByServiceEntryR\tbyService\x12H\n" +
-	"\n" +
-	"by_country\x18\b \x03(\v2).sms.GetStatisticsResponse.ByCountryEntryR\tbyCountry\x12K\n" +
-	"\vby_provider\x18\t \x03(\v2*.sms.GetStatisticsResponse.ByProviderEntryR\n" +
-	"byProvider\x1a<\n" +
-	"\x0eByServiceEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a<\n" +
-	"\x0eByCountryEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a=\n" +
-	"\x0fByProviderEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"7\n" +
-	"\x19GetProviderBalanceRequest\x12\x1a\n" +
-	"\bprovider\x18\x01 \x01(\tR\bprovider\"\x8d\x01\n" +
-	"\x1aGetProviderBalanceResponse\x12\x1a\n" +
-	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
-	"\abalance\x18\x02 \x01(\x02R\abalance\x12\x1a\n" +
-	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\x04 \x01(\x03R\tupdatedAt2\xe0\x03\n" +
-	"\n" +
-	"SMSService\x12I\n" +
-	"\x0ePurchaseNumber\x12\x1a.sms.PurchaseNumberRequest\x1a\x1b.sms.PurchaseNumberResponse\x12=\n" +
-	"\n" +
-	"GetSMSCode\x12\x16.sms.GetSMSCodeRequest\x1a\x17.sms.GetSMSCodeResponse\x12O\n" +
-	"\x10CancelActivation\x12\x1c.sms.CancelActivationRequest\x1a\x1d.sms.CancelActivationResponse\x12X\n" +
-	"\x13GetActivationStatus\x12\x1f.sms.GetActivationStatusRequest\x1a .sms.GetActivationStatusResponse\x12F\n" +
-	"\rGetStatistics\x12\x19.sms.GetStatisticsRequest\x1a\x1a.sms.GetStatisticsResponse\x12U\n" +
-	"\x12GetProviderBalance\x12\x1e.sms.GetProviderBalanceRequest\x1a\x1f.sms.GetProviderBalanceResponseB6Z4github.com/grigta/conveer/services/sms-service/protob\x06proto3"
+func (x *GetPricesRequest) Reset() {
+	*x = GetPricesRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPricesRequest) ProtoMessage() {}
+
+func (x *GetPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPricesRequest.ProtoReflect.Descriptor instead.
+func (*GetPricesRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPricesRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *GetPricesRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *GetPricesRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type PriceEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Available     int32                  `protobuf:"varint,6,opt,name=available,proto3" json:"available,omitempty"`
+	SuccessRate   float32                `protobuf:"fixed32,7,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	NextRestockAt int64                  `protobuf:"varint,9,opt,name=next_restock_at,json=nextRestockAt,proto3" json:"next_restock_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceEntry) Reset() {
+	*x = PriceEntry{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceEntry) ProtoMessage() {}
+
+func (x *PriceEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceEntry.ProtoReflect.Descriptor instead.
+func (*PriceEntry) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PriceEntry) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *PriceEntry) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *PriceEntry) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *PriceEntry) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceEntry) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PriceEntry) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *PriceEntry) GetSuccessRate() float32 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *PriceEntry) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *PriceEntry) GetNextRestockAt() int64 {
+	if x != nil {
+		return x.NextRestockAt
+	}
+	return 0
+}
+
+type GetPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*PriceEntry          `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPricesResponse) Reset() {
+	*x = GetPricesResponse{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPricesResponse) ProtoMessage() {}
+
+func (x *GetPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPricesResponse.ProtoReflect.Descriptor instead.
+func (*GetPricesResponse) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetPricesResponse) GetPrices() []*PriceEntry {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type PurchaseNumbersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Operator      string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	MaxPrice      int32                  `protobuf:"varint,6,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurchaseNumbersRequest) Reset() {
+	*x = PurchaseNumbersRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseNumbersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseNumbersRequest) ProtoMessage() {}
+
+func (x *PurchaseNumbersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseNumbersRequest.ProtoReflect.Descriptor instead.
+func (*PurchaseNumbersRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PurchaseNumbersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PurchaseNumbersRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *PurchaseNumbersRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *PurchaseNumbersRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *PurchaseNumbersRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PurchaseNumbersRequest) GetMaxPrice() int32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+type PurchaseFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurchaseFailure) Reset() {
+	*x = PurchaseFailure{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseFailure) ProtoMessage() {}
+
+func (x *PurchaseFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseFailure.ProtoReflect.Descriptor instead.
+func (*PurchaseFailure) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PurchaseFailure) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PurchaseFailure) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *PurchaseFailure) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PurchaseFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PurchaseNumbersResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	BatchId       string                    `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Requested     int32                     `protobuf:"varint,2,opt,name=requested,proto3" json:"requested,omitempty"`
+	Fulfilled     int32                     `protobuf:"varint,3,opt,name=fulfilled,proto3" json:"fulfilled,omitempty"`
+	Numbers       []*PurchaseNumberResponse `protobuf:"bytes,4,rep,name=numbers,proto3" json:"numbers,omitempty"`
+	Failures      []*PurchaseFailure        `protobuf:"bytes,5,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurchaseNumbersResponse) Reset() {
+	*x = PurchaseNumbersResponse{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseNumbersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseNumbersResponse) ProtoMessage() {}
+
+func (x *PurchaseNumbersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseNumbersResponse.ProtoReflect.Descriptor instead.
+func (*PurchaseNumbersResponse) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PurchaseNumbersResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *PurchaseNumbersResponse) GetRequested() int32 {
+	if x != nil {
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *PurchaseNumbersResponse) GetFulfilled() int32 {
+	if x != nil {
+		return x.Fulfilled
+	}
+	return 0
+}
+
+func (x *PurchaseNumbersResponse) GetNumbers() []*PurchaseNumberResponse {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *PurchaseNumbersResponse) GetFailures() []*PurchaseFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type ClaimBatchNumberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimBatchNumberRequest) Reset() {
+	*x = ClaimBatchNumberRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimBatchNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimBatchNumberRequest) ProtoMessage() {}
+
+func (x *ClaimBatchNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimBatchNumberRequest.ProtoReflect.Descriptor instead.
+func (*ClaimBatchNumberRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ClaimBatchNumberRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *ClaimBatchNumberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SchedulePurchaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Operator      string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	Provider      string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
+	MaxPrice      int32                  `protobuf:"varint,6,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	Urgent        bool                   `protobuf:"varint,7,opt,name=urgent,proto3" json:"urgent,omitempty"`
+	NotAfter      int64                  `protobuf:"varint,8,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePurchaseRequest) Reset() {
+	*x = SchedulePurchaseRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePurchaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePurchaseRequest) ProtoMessage() {}
+
+func (x *SchedulePurchaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePurchaseRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePurchaseRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SchedulePurchaseRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SchedulePurchaseRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *SchedulePurchaseRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SchedulePurchaseRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *SchedulePurchaseRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *SchedulePurchaseRequest) GetMaxPrice() int32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *SchedulePurchaseRequest) GetUrgent() bool {
+	if x != nil {
+		return x.Urgent
+	}
+	return false
+}
+
+func (x *SchedulePurchaseRequest) GetNotAfter() int64 {
+	if x != nil {
+		return x.NotAfter
+	}
+	return 0
+}
+
+type ScheduledPurchase struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PurchaseId      string                 `protobuf:"bytes,1,opt,name=purchase_id,json=purchaseId,proto3" json:"purchase_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Service         string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	Country         string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	Provider        string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
+	RestockProvider string                 `protobuf:"bytes,6,opt,name=restock_provider,json=restockProvider,proto3" json:"restock_provider,omitempty"`
+	Status          string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Deferred        bool                   `protobuf:"varint,8,opt,name=deferred,proto3" json:"deferred,omitempty"`
+	Reason          string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExecuteAt       int64                  `protobuf:"varint,10,opt,name=execute_at,json=executeAt,proto3" json:"execute_at,omitempty"`
+	ActivationId    string                 `protobuf:"bytes,11,opt,name=activation_id,json=activationId,proto3" json:"activation_id,omitempty"`
+	Error           string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt       int64                  `protobuf:"varint,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScheduledPurchase) Reset() {
+	*x = ScheduledPurchase{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPurchase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPurchase) ProtoMessage() {}
+
+func (x *ScheduledPurchase) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPurchase.ProtoReflect.Descriptor instead.
+func (*ScheduledPurchase) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ScheduledPurchase) GetPurchaseId() string {
+	if x != nil {
+		return x.PurchaseId
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetRestockProvider() string {
+	if x != nil {
+		return x.RestockProvider
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetDeferred() bool {
+	if x != nil {
+		return x.Deferred
+	}
+	return false
+}
+
+func (x *ScheduledPurchase) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetExecuteAt() int64 {
+	if x != nil {
+		return x.ExecuteAt
+	}
+	return 0
+}
+
+func (x *ScheduledPurchase) GetActivationId() string {
+	if x != nil {
+		return x.ActivationId
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduledPurchase) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type SchedulePurchaseResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Purchase      *ScheduledPurchase      `protobuf:"bytes,1,opt,name=purchase,proto3" json:"purchase,omitempty"`
+	Number        *PurchaseNumberResponse `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePurchaseResponse) Reset() {
+	*x = SchedulePurchaseResponse{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePurchaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePurchaseResponse) ProtoMessage() {}
+
+func (x *SchedulePurchaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePurchaseResponse.ProtoReflect.Descriptor instead.
+func (*SchedulePurchaseResponse) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SchedulePurchaseResponse) GetPurchase() *ScheduledPurchase {
+	if x != nil {
+		return x.Purchase
+	}
+	return nil
+}
+
+func (x *SchedulePurchaseResponse) GetNumber() *PurchaseNumberResponse {
+	if x != nil {
+		return x.Number
+	}
+	return nil
+}
+
+type GetScheduledPurchaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurchaseId    string                 `protobuf:"bytes,1,opt,name=purchase_id,json=purchaseId,proto3" json:"purchase_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduledPurchaseRequest) Reset() {
+	*x = GetScheduledPurchaseRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduledPurchaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledPurchaseRequest) ProtoMessage() {}
+
+func (x *GetScheduledPurchaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledPurchaseRequest.ProtoReflect.Descriptor instead.
+func (*GetScheduledPurchaseRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetScheduledPurchaseRequest) GetPurchaseId() string {
+	if x != nil {
+		return x.PurchaseId
+	}
+	return ""
+}
+
+func (x *GetScheduledPurchaseRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetDeferralStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDate      int64                  `protobuf:"varint,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        int64                  `protobuf:"varint,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeferralStatsRequest) Reset() {
+	*x = GetDeferralStatsRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeferralStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeferralStatsRequest) ProtoMessage() {}
+
+func (x *GetDeferralStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeferralStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetDeferralStatsRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetDeferralStatsRequest) GetFromDate() int64 {
+	if x != nil {
+		return x.FromDate
+	}
+	return 0
+}
+
+func (x *GetDeferralStatsRequest) GetToDate() int64 {
+	if x != nil {
+		return x.ToDate
+	}
+	return 0
+}
+
+type GetDeferralStatsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Total              int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Deferred           int64                  `protobuf:"varint,2,opt,name=deferred,proto3" json:"deferred,omitempty"`
+	Pending            int64                  `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	FulfilledDeferred  int64                  `protobuf:"varint,4,opt,name=fulfilled_deferred,json=fulfilledDeferred,proto3" json:"fulfilled_deferred,omitempty"`
+	FailedDeferred     int64                  `protobuf:"varint,5,opt,name=failed_deferred,json=failedDeferred,proto3" json:"failed_deferred,omitempty"`
+	AvgDeferralSeconds float64                `protobuf:"fixed64,6,opt,name=avg_deferral_seconds,json=avgDeferralSeconds,proto3" json:"avg_deferral_seconds,omitempty"`
+	ByReason           map[string]int64       `protobuf:"bytes,7,rep,name=by_reason,json=byReason,proto3" json:"by_reason,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ByProvider         map[string]int64       `protobuf:"bytes,8,rep,name=by_provider,json=byProvider,proto3" json:"by_provider,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetDeferralStatsResponse) Reset() {
+	*x = GetDeferralStatsResponse{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeferralStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeferralStatsResponse) ProtoMessage() {}
+
+func (x *GetDeferralStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeferralStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetDeferralStatsResponse) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetDeferralStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetDeferralStatsResponse) GetDeferred() int64 {
+	if x != nil {
+		return x.Deferred
+	}
+	return 0
+}
+
+func (x *GetDeferralStatsResponse) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *GetDeferralStatsResponse) GetFulfilledDeferred() int64 {
+	if x != nil {
+		return x.FulfilledDeferred
+	}
+	return 0
+}
+
+func (x *GetDeferralStatsResponse) GetFailedDeferred() int64 {
+	if x != nil {
+		return x.FailedDeferred
+	}
+	return 0
+}
+
+func (x *GetDeferralStatsResponse) GetAvgDeferralSeconds() float64 {
+	if x != nil {
+		return x.AvgDeferralSeconds
+	}
+	return 0
+}
+
+func (x *GetDeferralStatsResponse) GetByReason() map[string]int64 {
+	if x != nil {
+		return x.ByReason
+	}
+	return nil
+}
+
+func (x *GetDeferralStatsResponse) GetByProvider() map[string]int64 {
+	if x != nil {
+		return x.ByProvider
+	}
+	return nil
+}
+
+type GetProviderHealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProviderHealthRequest) Reset() {
+	*x = GetProviderHealthRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProviderHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderHealthRequest) ProtoMessage() {}
+
+func (x *GetProviderHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderHealthRequest.ProtoReflect.Descriptor instead.
+func (*GetProviderHealthRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{25}
+}
+
+type ProviderHealth struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Provider           string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Samples            int32                  `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`
+	SuccessRate        float64                `protobuf:"fixed64,3,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	CancellationRate   float64                `protobuf:"fixed64,4,opt,name=cancellation_rate,json=cancellationRate,proto3" json:"cancellation_rate,omitempty"`
+	AvgDeliverySeconds float64                `protobuf:"fixed64,5,opt,name=avg_delivery_seconds,json=avgDeliverySeconds,proto3" json:"avg_delivery_seconds,omitempty"`
+	// 0-100, higher is healthier
+	Score            float64 `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	Quarantined      bool    `protobuf:"varint,7,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	QuarantinedUntil int64   `protobuf:"varint,8,opt,name=quarantined_until,json=quarantinedUntil,proto3" json:"quarantined_until,omitempty"`
+	UpdatedAt        int64   `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProviderHealth) Reset() {
+	*x = ProviderHealth{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderHealth) ProtoMessage() {}
+
+func (x *ProviderHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderHealth.ProtoReflect.Descriptor instead.
+func (*ProviderHealth) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ProviderHealth) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ProviderHealth) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *ProviderHealth) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *ProviderHealth) GetCancellationRate() float64 {
+	if x != nil {
+		return x.CancellationRate
+	}
+	return 0
+}
+
+func (x *ProviderHealth) GetAvgDeliverySeconds() float64 {
+	if x != nil {
+		return x.AvgDeliverySeconds
+	}
+	return 0
+}
+
+func (x *ProviderHealth) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ProviderHealth) GetQuarantined() bool {
+	if x != nil {
+		return x.Quarantined
+	}
+	return false
+}
+
+func (x *ProviderHealth) GetQuarantinedUntil() int64 {
+	if x != nil {
+		return x.QuarantinedUntil
+	}
+	return 0
+}
+
+func (x *ProviderHealth) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetProviderHealthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Healthiest first, quarantined providers last
+	Providers     []*ProviderHealth `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProviderHealthResponse) Reset() {
+	*x = GetProviderHealthResponse{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProviderHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderHealthResponse) ProtoMessage() {}
+
+func (x *GetProviderHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderHealthResponse.ProtoReflect.Descriptor instead.
+func (*GetProviderHealthResponse) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetProviderHealthResponse) GetProviders() []*ProviderHealth {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type RentNumberRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Account the number verifies; an account holds one active rental per service
+	AccountId string `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Service   string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	Country   string `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	Operator  string `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	Provider  string `protobuf:"bytes,6,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Rent period, the configured default when zero
+	Hours         int32 `protobuf:"varint,7,opt,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RentNumberRequest) Reset() {
+	*x = RentNumberRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RentNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RentNumberRequest) ProtoMessage() {}
+
+func (x *RentNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RentNumberRequest.ProtoReflect.Descriptor instead.
+func (*RentNumberRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RentNumberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RentNumberRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RentNumberRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *RentNumberRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *RentNumberRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *RentNumberRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *RentNumberRequest) GetHours() int32 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+type Rental struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RentalId      string                 `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Service       string                 `protobuf:"bytes,5,opt,name=service,proto3" json:"service,omitempty"`
+	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Provider      string                 `protobuf:"bytes,7,opt,name=provider,proto3" json:"provider,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Hours         int32                  `protobuf:"varint,9,opt,name=hours,proto3" json:"hours,omitempty"`
+	Price         float32                `protobuf:"fixed32,10,opt,name=price,proto3" json:"price,omitempty"`
+	Renewals      int32                  `protobuf:"varint,11,opt,name=renewals,proto3" json:"renewals,omitempty"`
+	MessageCount  int32                  `protobuf:"varint,12,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	StartedAt     int64                  `protobuf:"varint,13,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	EndedAt       int64                  `protobuf:"varint,15,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rental) Reset() {
+	*x = Rental{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rental) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rental) ProtoMessage() {}
+
+func (x *Rental) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rental.ProtoReflect.Descriptor instead.
+func (*Rental) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Rental) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+func (x *Rental) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Rental) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Rental) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Rental) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Rental) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Rental) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Rental) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Rental) GetHours() int32 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+func (x *Rental) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Rental) GetRenewals() int32 {
+	if x != nil {
+		return x.Renewals
+	}
+	return 0
+}
+
+func (x *Rental) GetMessageCount() int32 {
+	if x != nil {
+		return x.MessageCount
+	}
+	return 0
+}
+
+func (x *Rental) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Rental) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Rental) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+type RentNumberResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Rental *Rental                `protobuf:"bytes,1,opt,name=rental,proto3" json:"rental,omitempty"`
+	// The account already held an active rental for the service, it is returned instead
+	Reused        bool `protobuf:"varint,2,opt,name=reused,proto3" json:"reused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RentNumberResponse) Reset() {
+	*x = RentNumberResponse{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RentNumberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RentNumberResponse) ProtoMessage() {}
+
+func (x *RentNumberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RentNumberResponse.ProtoReflect.Descriptor instead.
+func (*RentNumberResponse) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RentNumberResponse) GetRental() *Rental {
+	if x != nil {
+		return x.Rental
+	}
+	return nil
+}
+
+func (x *RentNumberResponse) GetReused() bool {
+	if x != nil {
+		return x.Reused
+	}
+	return false
+}
+
+type RenewRentalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RentalId      string                 `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Hours         int32                  `protobuf:"varint,3,opt,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewRentalRequest) Reset() {
+	*x = RenewRentalRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewRentalRequest) ProtoMessage() {}
+
+func (x *RenewRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewRentalRequest.ProtoReflect.Descriptor instead.
+func (*RenewRentalRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RenewRentalRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+func (x *RenewRentalRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RenewRentalRequest) GetHours() int32 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+type ReleaseRentalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RentalId      string                 `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRentalRequest) Reset() {
+	*x = ReleaseRentalRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRentalRequest) ProtoMessage() {}
+
+func (x *ReleaseRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRentalRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRentalRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReleaseRentalRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+func (x *ReleaseRentalRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AssignRentalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RentalId      string                 `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignRentalRequest) Reset() {
+	*x = AssignRentalRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRentalRequest) ProtoMessage() {}
+
+func (x *AssignRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRentalRequest.ProtoReflect.Descriptor instead.
+func (*AssignRentalRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AssignRentalRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+func (x *AssignRentalRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AssignRentalRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type GetRentalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RentalId      string                 `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRentalRequest) Reset() {
+	*x = GetRentalRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRentalRequest) ProtoMessage() {}
+
+func (x *GetRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRentalRequest.ProtoReflect.Descriptor instead.
+func (*GetRentalRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetRentalRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+func (x *GetRentalRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListRentalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRentalsRequest) Reset() {
+	*x = ListRentalsRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRentalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRentalsRequest) ProtoMessage() {}
+
+func (x *ListRentalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRentalsRequest.ProtoReflect.Descriptor instead.
+func (*ListRentalsRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListRentalsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListRentalsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListRentalsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListRentalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rentals       []*Rental              `protobuf:"bytes,1,rep,name=rentals,proto3" json:"rentals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRentalsResponse) Reset() {
+	*x = ListRentalsResponse{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRentalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRentalsResponse) ProtoMessage() {}
+
+func (x *ListRentalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRentalsResponse.ProtoReflect.Descriptor instead.
+func (*ListRentalsResponse) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListRentalsResponse) GetRentals() []*Rental {
+	if x != nil {
+		return x.Rentals
+	}
+	return nil
+}
+
+type GetRentalMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RentalId      string                 `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Since         int64                  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRentalMessagesRequest) Reset() {
+	*x = GetRentalMessagesRequest{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRentalMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRentalMessagesRequest) ProtoMessage() {}
+
+func (x *GetRentalMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRentalMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetRentalMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetRentalMessagesRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+func (x *GetRentalMessagesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRentalMessagesRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type RentalMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sender        string                 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Anomalies     []string               `protobuf:"bytes,4,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	ReceivedAt    int64                  `protobuf:"varint,5,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RentalMessage) Reset() {
+	*x = RentalMessage{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RentalMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RentalMessage) ProtoMessage() {}
+
+func (x *RentalMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RentalMessage.ProtoReflect.Descriptor instead.
+func (*RentalMessage) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RentalMessage) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *RentalMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *RentalMessage) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RentalMessage) GetAnomalies() []string {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
+}
+
+func (x *RentalMessage) GetReceivedAt() int64 {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return 0
+}
+
+type GetRentalMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*RentalMessage       `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRentalMessagesResponse) Reset() {
+	*x = GetRentalMessagesResponse{}
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRentalMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRentalMessagesResponse) ProtoMessage() {}
+
+func (x *GetRentalMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_sms_service_proto_sms_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRentalMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetRentalMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_services_sms_service_proto_sms_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetRentalMessagesResponse) GetMessages() []*RentalMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+var File_services_sms_service_proto_sms_proto protoreflect.FileDescriptor
+
+const file_services_sms_service_proto_sms_proto_rawDesc = "" +
+	"\n" +
+	"$services/sms-service/proto/sms.proto\x12\x03sms\"\xe2\x01\n" +
+	"\x15PurchaseNumberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
+	"\boperator\x18\x04 \x01(\tR\boperator\x12\x1a\n" +
+	"\bprovider\x18\x05 \x01(\tR\bprovider\x12\x1b\n" +
+	"\tmax_price\x18\x06 \x01(\x05R\bmaxPrice\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\"\xf8\x01\n" +
+	"\x16PurchaseNumberResponse\x12#\n" +
+	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12!\n" +
+	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x02R\x05price\x12\x1a\n" +
+	"\bprovider\x18\x05 \x01(\tR\bprovider\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\x12\"\n" +
+	"\fdeduplicated\x18\a \x01(\bR\fdeduplicated\"Q\n" +
+	"\x11GetSMSCodeRequest\x12#\n" +
+	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"d\n" +
+	"\x12GetSMSCodeResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x19\n" +
+	"\bfull_sms\x18\x02 \x01(\tR\afullSms\x12\x1f\n" +
+	"\vreceived_at\x18\x03 \x01(\x03R\n" +
+	"receivedAt\"o\n" +
+	"\x17CancelActivationRequest\x12#\n" +
+	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"u\n" +
+	"\x18CancelActivationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\brefunded\x18\x02 \x01(\bR\brefunded\x12#\n" +
+	"\rrefund_amount\x18\x03 \x01(\x02R\frefundAmount\"Z\n" +
+	"\x1aGetActivationStatusRequest\x12#\n" +
+	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x8c\x02\n" +
+	"\x1bGetActivationStatusResponse\x12#\n" +
+	"\ractivation_id\x18\x01 \x01(\tR\factivationId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\x12\x18\n" +
+	"\aservice\x18\x04 \x01(\tR\aservice\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x12!\n" +
+	"\fcompleted_at\x18\b \x01(\x03R\vcompletedAt\"\x99\x01\n" +
+	"\x14GetStatisticsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfrom_date\x18\x02 \x01(\x03R\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x03 \x01(\x03R\x06toDate\x12\x18\n" +
+	"\aservice\x18\x04 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\"\xc1\x05\n" +
+	"\x15GetStatisticsResponse\x12+\n" +
+	"\x11total_activations\x18\x01 \x01(\x05R\x10totalActivations\x125\n" +
+	"\x16successful_activations\x18\x02 \x01(\x05R\x15successfulActivations\x12-\n" +
+	"\x12failed_activations\x18\x03 \x01(\x05R\x11failedActivations\x123\n" +
+	"\x15cancelled_activations\x18\x04 \x01(\x05R\x14cancelledActivations\x12\x1f\n" +
+	"\vtotal_spent\x18\x05 \x01(\x02R\n" +
+	"totalSpent\x12#\n" +
+	"\raverage_price\x18\x06 \x01(\x02R\faveragePrice\x12H\n" +
+	"\n" +
+	"by_service\x18\a \x03(\v2).sms.GetStatisticsResponse.ByServiceEntryR\tbyService\x12H\n" +
+	"\n" +
+	"by_country\x18\b \x03(\v2).sms.GetStatisticsResponse.ByCountryEntryR\tbyCountry\x12K\n" +
+	"\vby_provider\x18\t \x03(\v2*.sms.GetStatisticsResponse.ByProviderEntryR\n" +
+	"byProvider\x1a<\n" +
+	"\x0eByServiceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a<\n" +
+	"\x0eByCountryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a=\n" +
+	"\x0fByProviderEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"7\n" +
+	"\x19GetProviderBalanceRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\"\x8d\x01\n" +
+	"\x1aGetProviderBalanceResponse\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x02R\abalance\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\"b\n" +
+	"\x10GetPricesRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\"\x96\x02\n" +
+	"\n" +
+	"PriceEntry\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x02R\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1c\n" +
+	"\tavailable\x18\x06 \x01(\x05R\tavailable\x12!\n" +
+	"\fsuccess_rate\x18\a \x01(\x02R\vsuccessRate\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\x12&\n" +
+	"\x0fnext_restock_at\x18\t \x01(\x03R\rnextRestockAt\"<\n" +
+	"\x11GetPricesResponse\x12'\n" +
+	"\x06prices\x18\x01 \x03(\v2\x0f.sms.PriceEntryR\x06prices\"\xba\x01\n" +
+	"\x16PurchaseNumbersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
+	"\boperator\x18\x04 \x01(\tR\boperator\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1b\n" +
+	"\tmax_price\x18\x06 \x01(\x05R\bmaxPrice\"m\n" +
+	"\x0fPurchaseFailure\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xd9\x01\n" +
+	"\x17PurchaseNumbersResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12\x1c\n" +
+	"\trequested\x18\x02 \x01(\x05R\trequested\x12\x1c\n" +
+	"\tfulfilled\x18\x03 \x01(\x05R\tfulfilled\x125\n" +
+	"\anumbers\x18\x04 \x03(\v2\x1b.sms.PurchaseNumberResponseR\anumbers\x120\n" +
+	"\bfailures\x18\x05 \x03(\v2\x14.sms.PurchaseFailureR\bfailures\"M\n" +
+	"\x17ClaimBatchNumberRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xf0\x01\n" +
+	"\x17SchedulePurchaseRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
+	"\boperator\x18\x04 \x01(\tR\boperator\x12\x1a\n" +
+	"\bprovider\x18\x05 \x01(\tR\bprovider\x12\x1b\n" +
+	"\tmax_price\x18\x06 \x01(\x05R\bmaxPrice\x12\x16\n" +
+	"\x06urgent\x18\a \x01(\bR\x06urgent\x12\x1b\n" +
+	"\tnot_after\x18\b \x01(\x03R\bnotAfter\"\x8d\x03\n" +
+	"\x11ScheduledPurchase\x12\x1f\n" +
+	"\vpurchase_id\x18\x01 \x01(\tR\n" +
+	"purchaseId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12\x1a\n" +
+	"\bprovider\x18\x05 \x01(\tR\bprovider\x12)\n" +
+	"\x10restock_provider\x18\x06 \x01(\tR\x0frestockProvider\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1a\n" +
+	"\bdeferred\x18\b \x01(\bR\bdeferred\x12\x16\n" +
+	"\x06reason\x18\t \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"execute_at\x18\n" +
+	" \x01(\x03R\texecuteAt\x12#\n" +
+	"\ractivation_id\x18\v \x01(\tR\factivationId\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\x03R\tcreatedAt\"\x83\x01\n" +
+	"\x18SchedulePurchaseResponse\x122\n" +
+	"\bpurchase\x18\x01 \x01(\v2\x16.sms.ScheduledPurchaseR\bpurchase\x123\n" +
+	"\x06number\x18\x02 \x01(\v2\x1b.sms.PurchaseNumberResponseR\x06number\"W\n" +
+	"\x1bGetScheduledPurchaseRequest\x12\x1f\n" +
+	"\vpurchase_id\x18\x01 \x01(\tR\n" +
+	"purchaseId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"O\n" +
+	"\x17GetDeferralStatsRequest\x12\x1b\n" +
+	"\tfrom_date\x18\x01 \x01(\x03R\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x02 \x01(\x03R\x06toDate\"\x86\x04\n" +
+	"\x18GetDeferralStatsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x1a\n" +
+	"\bdeferred\x18\x02 \x01(\x03R\bdeferred\x12\x18\n" +
+	"\apending\x18\x03 \x01(\x03R\apending\x12-\n" +
+	"\x12fulfilled_deferred\x18\x04 \x01(\x03R\x11fulfilledDeferred\x12'\n" +
+	"\x0ffailed_deferred\x18\x05 \x01(\x03R\x0efailedDeferred\x120\n" +
+	"\x14avg_deferral_seconds\x18\x06 \x01(\x01R\x12avgDeferralSeconds\x12H\n" +
+	"\tby_reason\x18\a \x03(\v2+.sms.GetDeferralStatsResponse.ByReasonEntryR\bbyReason\x12N\n" +
+	"\vby_provider\x18\b \x03(\v2-.sms.GetDeferralStatsResponse.ByProviderEntryR\n" +
+	"byProvider\x1a;\n" +
+	"\rByReasonEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a=\n" +
+	"\x0fByProviderEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x1a\n" +
+	"\x18GetProviderHealthRequest\"\xcc\x02\n" +
+	"\x0eProviderHealth\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\asamples\x18\x02 \x01(\x05R\asamples\x12!\n" +
+	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12+\n" +
+	"\x11cancellation_rate\x18\x04 \x01(\x01R\x10cancellationRate\x120\n" +
+	"\x14avg_delivery_seconds\x18\x05 \x01(\x01R\x12avgDeliverySeconds\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\x12 \n" +
+	"\vquarantined\x18\a \x01(\bR\vquarantined\x12+\n" +
+	"\x11quarantined_until\x18\b \x01(\x03R\x10quarantinedUntil\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\"N\n" +
+	"\x19GetProviderHealthResponse\x121\n" +
+	"\tproviders\x18\x01 \x03(\v2\x13.sms.ProviderHealthR\tproviders\"\xcd\x01\n" +
+	"\x11RentNumberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12\x1a\n" +
+	"\boperator\x18\x05 \x01(\tR\boperator\x12\x1a\n" +
+	"\bprovider\x18\x06 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05hours\x18\a \x01(\x05R\x05hours\"\xae\x03\n" +
+	"\x06Rental\x12\x1b\n" +
+	"\trental_id\x18\x01 \x01(\tR\brentalId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12!\n" +
+	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12\x18\n" +
+	"\aservice\x18\x05 \x01(\tR\aservice\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x1a\n" +
+	"\bprovider\x18\a \x01(\tR\bprovider\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x14\n" +
+	"\x05hours\x18\t \x01(\x05R\x05hours\x12\x14\n" +
+	"\x05price\x18\n" +
+	" \x01(\x02R\x05price\x12\x1a\n" +
+	"\brenewals\x18\v \x01(\x05R\brenewals\x12#\n" +
+	"\rmessage_count\x18\f \x01(\x05R\fmessageCount\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\r \x01(\x03R\tstartedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x0e \x01(\x03R\texpiresAt\x12\x19\n" +
+	"\bended_at\x18\x0f \x01(\x03R\aendedAt\"Q\n" +
+	"\x12RentNumberResponse\x12#\n" +
+	"\x06rental\x18\x01 \x01(\v2\v.sms.RentalR\x06rental\x12\x16\n" +
+	"\x06reused\x18\x02 \x01(\bR\x06reused\"`\n" +
+	"\x12RenewRentalRequest\x12\x1b\n" +
+	"\trental_id\x18\x01 \x01(\tR\brentalId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05hours\x18\x03 \x01(\x05R\x05hours\"L\n" +
+	"\x14ReleaseRentalRequest\x12\x1b\n" +
+	"\trental_id\x18\x01 \x01(\tR\brentalId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"j\n" +
+	"\x13AssignRentalRequest\x12\x1b\n" +
+	"\trental_id\x18\x01 \x01(\tR\brentalId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\"H\n" +
+	"\x10GetRentalRequest\x12\x1b\n" +
+	"\trental_id\x18\x01 \x01(\tR\brentalId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"d\n" +
+	"\x12ListRentalsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"<\n" +
+	"\x13ListRentalsResponse\x12%\n" +
+	"\arentals\x18\x01 \x03(\v2\v.sms.RentalR\arentals\"f\n" +
+	"\x18GetRentalMessagesRequest\x12\x1b\n" +
+	"\trental_id\x18\x01 \x01(\tR\brentalId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\x03R\x05since\"\x8e\x01\n" +
+	"\rRentalMessage\x12\x16\n" +
+	"\x06sender\x18\x01 \x01(\tR\x06sender\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x1c\n" +
+	"\tanomalies\x18\x04 \x03(\tR\tanomalies\x12\x1f\n" +
+	"\vreceived_at\x18\x05 \x01(\x03R\n" +
+	"receivedAt\"K\n" +
+	"\x19GetRentalMessagesResponse\x12.\n" +
+	"\bmessages\x18\x01 \x03(\v2\x12.sms.RentalMessageR\bmessages2\xac\v\n" +
+	"\n" +
+	"SMSService\x12I\n" +
+	"\x0ePurchaseNumber\x12\x1a.sms.PurchaseNumberRequest\x1a\x1b.sms.PurchaseNumberResponse\x12=\n" +
+	"\n" +
+	"GetSMSCode\x12\x16.sms.GetSMSCodeRequest\x1a\x17.sms.GetSMSCodeResponse\x12O\n" +
+	"\x10CancelActivation\x12\x1c.sms.CancelActivationRequest\x1a\x1d.sms.CancelActivationResponse\x12X\n" +
+	"\x13GetActivationStatus\x12\x1f.sms.GetActivationStatusRequest\x1a .sms.GetActivationStatusResponse\x12F\n" +
+	"\rGetStatistics\x12\x19.sms.GetStatisticsRequest\x1a\x1a.sms.GetStatisticsResponse\x12U\n" +
+	"\x12GetProviderBalance\x12\x1e.sms.GetProviderBalanceRequest\x1a\x1f.sms.GetProviderBalanceResponse\x12:\n" +
+	"\tGetPrices\x12\x15.sms.GetPricesRequest\x1a\x16.sms.GetPricesResponse\x12L\n" +
+	"\x0fPurchaseNumbers\x12\x1b.sms.PurchaseNumbersRequest\x1a\x1c.sms.PurchaseNumbersResponse\x12M\n" +
+	"\x10ClaimBatchNumber\x12\x1c.sms.ClaimBatchNumberRequest\x1a\x1b.sms.PurchaseNumberResponse\x12O\n" +
+	"\x10SchedulePurchase\x12\x1c.sms.SchedulePurchaseRequest\x1a\x1d.sms.SchedulePurchaseResponse\x12P\n" +
+	"\x14GetScheduledPurchase\x12 .sms.GetScheduledPurchaseRequest\x1a\x16.sms.ScheduledPurchase\x12O\n" +
+	"\x10GetDeferralStats\x12\x1c.sms.GetDeferralStatsRequest\x1a\x1d.sms.GetDeferralStatsResponse\x12=\n" +
+	"\n" +
+	"RentNumber\x12\x16.sms.RentNumberRequest\x1a\x17.sms.RentNumberResponse\x123\n" +
+	"\vRenewRental\x12\x17.sms.RenewRentalRequest\x1a\v.sms.Rental\x127\n" +
+	"\rReleaseRental\x12\x19.sms.ReleaseRentalRequest\x1a\v.sms.Rental\x125\n" +
+	"\fAssignRental\x12\x18.sms.AssignRentalRequest\x1a\v.sms.Rental\x12/\n" +
+	"\tGetRental\x12\x15.sms.GetRentalRequest\x1a\v.sms.Rental\x12@\n" +
+	"\vListRentals\x12\x17.sms.ListRentalsRequest\x1a\x18.sms.ListRentalsResponse\x12R\n" +
+	"\x11GetRentalMessages\x12\x1d.sms.GetRentalMessagesRequest\x1a\x1e.sms.GetRentalMessagesResponse\x12R\n" +
+	"\x11GetProviderHealth\x12\x1d.sms.GetProviderHealthRequest\x1a\x1e.sms.GetProviderHealthResponseB6Z4github.com/grigta/conveer/services/sms-service/protob\x06proto3"
 
 var (
 	file_services_sms_service_proto_sms_proto_rawDescOnce sync.Once
@@ -978,7 +3174,7 @@ func file_services_sms_service_proto_sms_proto_rawDescGZIP() []byte {
 	return file_services_sms_service_proto_sms_proto_rawDescData
 }
 
-var file_services_sms_service_proto_sms_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_services_sms_service_proto_sms_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_services_sms_service_proto_sms_proto_goTypes = []any{
 	(*PurchaseNumberRequest)(nil),       // 0: sms.PurchaseNumberRequest
 	(*PurchaseNumberResponse)(nil),      // 1: sms.PurchaseNumberResponse
@@ -992,31 +3188,100 @@ var file_services_sms_service_proto_sms_proto_goTypes = []any{
 	(*GetStatisticsResponse)(nil),       // 9: sms.GetStatisticsResponse
 	(*GetProviderBalanceRequest)(nil),   // 10: sms.GetProviderBalanceRequest
 	(*GetProviderBalanceResponse)(nil),  // 11: sms.GetProviderBalanceResponse
-	nil,                                 // 12: sms.GetStatisticsResponse.ByServiceEntry
-	nil,                                 // 13: sms.GetStatisticsResponse.ByCountryEntry
-	nil,                                 // 14: sms.GetStatisticsResponse.ByProviderEntry
+	(*GetPricesRequest)(nil),            // 12: sms.GetPricesRequest
+	(*PriceEntry)(nil),                  // 13: sms.PriceEntry
+	(*GetPricesResponse)(nil),           // 14: sms.GetPricesResponse
+	(*PurchaseNumbersRequest)(nil),      // 15: sms.PurchaseNumbersRequest
+	(*PurchaseFailure)(nil),             // 16: sms.PurchaseFailure
+	(*PurchaseNumbersResponse)(nil),     // 17: sms.PurchaseNumbersResponse
+	(*ClaimBatchNumberRequest)(nil),     // 18: sms.ClaimBatchNumberRequest
+	(*SchedulePurchaseRequest)(nil),     // 19: sms.SchedulePurchaseRequest
+	(*ScheduledPurchase)(nil),           // 20: sms.ScheduledPurchase
+	(*SchedulePurchaseResponse)(nil),    // 21: sms.SchedulePurchaseResponse
+	(*GetScheduledPurchaseRequest)(nil), // 22: sms.GetScheduledPurchaseRequest
+	(*GetDeferralStatsRequest)(nil),     // 23: sms.GetDeferralStatsRequest
+	(*GetDeferralStatsResponse)(nil),    // 24: sms.GetDeferralStatsResponse
+	(*GetProviderHealthRequest)(nil),    // 25: sms.GetProviderHealthRequest
+	(*ProviderHealth)(nil),              // 26: sms.ProviderHealth
+	(*GetProviderHealthResponse)(nil),   // 27: sms.GetProviderHealthResponse
+	(*RentNumberRequest)(nil),           // 28: sms.RentNumberRequest
+	(*Rental)(nil),                      // 29: sms.Rental
+	(*RentNumberResponse)(nil),          // 30: sms.RentNumberResponse
+	(*RenewRentalRequest)(nil),          // 31: sms.RenewRentalRequest
+	(*ReleaseRentalRequest)(nil),        // 32: sms.ReleaseRentalRequest
+	(*AssignRentalRequest)(nil),         // 33: sms.AssignRentalRequest
+	(*GetRentalRequest)(nil),            // 34: sms.GetRentalRequest
+	(*ListRentalsRequest)(nil),          // 35: sms.ListRentalsRequest
+	(*ListRentalsResponse)(nil),         // 36: sms.ListRentalsResponse
+	(*GetRentalMessagesRequest)(nil),    // 37: sms.GetRentalMessagesRequest
+	(*RentalMessage)(nil),               // 38: sms.RentalMessage
+	(*GetRentalMessagesResponse)(nil),   // 39: sms.GetRentalMessagesResponse
+	nil,                                 // 40: sms.GetStatisticsResponse.ByServiceEntry
+	nil,                                 // 41: sms.GetStatisticsResponse.ByCountryEntry
+	nil,                                 // 42: sms.GetStatisticsResponse.ByProviderEntry
+	nil,                                 // 43: sms.GetDeferralStatsResponse.ByReasonEntry
+	nil,                                 // 44: sms.GetDeferralStatsResponse.ByProviderEntry
 }
 var file_services_sms_service_proto_sms_proto_depIdxs = []int32{
-	12, // 0: sms.GetStatisticsResponse.by_service:type_name -> sms.GetStatisticsResponse.ByServiceEntry
-	13, // 1: sms.GetStatisticsResponse.by_country:type_name -> sms.GetStatisticsResponse.ByCountryEntry
-	14, // 2: sms.GetStatisticsResponse.by_provider:type_name -> sms.GetStatisticsResponse.ByProviderEntry
-	0,  // 3: sms.SMSService.PurchaseNumber:input_type -> sms.PurchaseNumberRequest
-	2,  // 4: sms.SMSService.GetSMSCode:input_type -> sms.GetSMSCodeRequest
-	4,  // 5: sms.SMSService.CancelActivation:input_type -> sms.CancelActivationRequest
-	6,  // 6: sms.SMSService.GetActivationStatus:input_type -> sms.GetActivationStatusRequest
-	8,  // 7: sms.SMSService.GetStatistics:input_type -> sms.GetStatisticsRequest
-	10, // 8: sms.SMSService.GetProviderBalance:input_type -> sms.GetProviderBalanceRequest
-	1,  // 9: sms.SMSService.PurchaseNumber:output_type -> sms.PurchaseNumberResponse
-	3,  // 10: sms.SMSService.GetSMSCode:output_type -> sms.GetSMSCodeResponse
-	5,  // 11: sms.SMSService.CancelActivation:output_type -> sms.CancelActivationResponse
-	7,  // 12: sms.SMSService.GetActivationStatus:output_type -> sms.GetActivationStatusResponse
-	9,  // 13: sms.SMSService.GetStatistics:output_type -> sms.GetStatisticsResponse
-	11, // 14: sms.SMSService.GetProviderBalance:output_type -> sms.GetProviderBalanceResponse
-	9,  // [9:15] is the sub-list for method output_type
-	3,  // [3:9] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	40, // 0: sms.GetStatisticsResponse.by_service:type_name -> sms.GetStatisticsResponse.ByServiceEntry
+	41, // 1: sms.GetStatisticsResponse.by_country:type_name -> sms.GetStatisticsResponse.ByCountryEntry
+	42, // 2: sms.GetStatisticsResponse.by_provider:type_name -> sms.GetStatisticsResponse.ByProviderEntry
+	13, // 3: sms.GetPricesResponse.prices:type_name -> sms.PriceEntry
+	1,  // 4: sms.PurchaseNumbersResponse.numbers:type_name -> sms.PurchaseNumberResponse
+	16, // 5: sms.PurchaseNumbersResponse.failures:type_name -> sms.PurchaseFailure
+	20, // 6: sms.SchedulePurchaseResponse.purchase:type_name -> sms.ScheduledPurchase
+	1,  // 7: sms.SchedulePurchaseResponse.number:type_name -> sms.PurchaseNumberResponse
+	43, // 8: sms.GetDeferralStatsResponse.by_reason:type_name -> sms.GetDeferralStatsResponse.ByReasonEntry
+	44, // 9: sms.GetDeferralStatsResponse.by_provider:type_name -> sms.GetDeferralStatsResponse.ByProviderEntry
+	26, // 10: sms.GetProviderHealthResponse.providers:type_name -> sms.ProviderHealth
+	29, // 11: sms.RentNumberResponse.rental:type_name -> sms.Rental
+	29, // 12: sms.ListRentalsResponse.rentals:type_name -> sms.Rental
+	38, // 13: sms.GetRentalMessagesResponse.messages:type_name -> sms.RentalMessage
+	0,  // 14: sms.SMSService.PurchaseNumber:input_type -> sms.PurchaseNumberRequest
+	2,  // 15: sms.SMSService.GetSMSCode:input_type -> sms.GetSMSCodeRequest
+	4,  // 16: sms.SMSService.CancelActivation:input_type -> sms.CancelActivationRequest
+	6,  // 17: sms.SMSService.GetActivationStatus:input_type -> sms.GetActivationStatusRequest
+	8,  // 18: sms.SMSService.GetStatistics:input_type -> sms.GetStatisticsRequest
+	10, // 19: sms.SMSService.GetProviderBalance:input_type -> sms.GetProviderBalanceRequest
+	12, // 20: sms.SMSService.GetPrices:input_type -> sms.GetPricesRequest
+	15, // 21: sms.SMSService.PurchaseNumbers:input_type -> sms.PurchaseNumbersRequest
+	18, // 22: sms.SMSService.ClaimBatchNumber:input_type -> sms.ClaimBatchNumberRequest
+	19, // 23: sms.SMSService.SchedulePurchase:input_type -> sms.SchedulePurchaseRequest
+	22, // 24: sms.SMSService.GetScheduledPurchase:input_type -> sms.GetScheduledPurchaseRequest
+	23, // 25: sms.SMSService.GetDeferralStats:input_type -> sms.GetDeferralStatsRequest
+	28, // 26: sms.SMSService.RentNumber:input_type -> sms.RentNumberRequest
+	31, // 27: sms.SMSService.RenewRental:input_type -> sms.RenewRentalRequest
+	32, // 28: sms.SMSService.ReleaseRental:input_type -> sms.ReleaseRentalRequest
+	33, // 29: sms.SMSService.AssignRental:input_type -> sms.AssignRentalRequest
+	34, // 30: sms.SMSService.GetRental:input_type -> sms.GetRentalRequest
+	35, // 31: sms.SMSService.ListRentals:input_type -> sms.ListRentalsRequest
+	37, // 32: sms.SMSService.GetRentalMessages:input_type -> sms.GetRentalMessagesRequest
+	25, // 33: sms.SMSService.GetProviderHealth:input_type -> sms.GetProviderHealthRequest
+	1,  // 34: sms.SMSService.PurchaseNumber:output_type -> sms.PurchaseNumberResponse
+	3,  // 35: sms.SMSService.GetSMSCode:output_type -> sms.GetSMSCodeResponse
+	5,  // 36: sms.SMSService.CancelActivation:output_type -> sms.CancelActivationResponse
+	7,  // 37: sms.SMSService.GetActivationStatus:output_type -> sms.GetActivationStatusResponse
+	9,  // 38: sms.SMSService.GetStatistics:output_type -> sms.GetStatisticsResponse
+	11, // 39: sms.SMSService.GetProviderBalance:output_type -> sms.GetProviderBalanceResponse
+	14, // 40: sms.SMSService.GetPrices:output_type -> sms.GetPricesResponse
+	17, // 41: sms.SMSService.PurchaseNumbers:output_type -> sms.PurchaseNumbersResponse
+	1,  // 42: sms.SMSService.ClaimBatchNumber:output_type -> sms.PurchaseNumberResponse
+	21, // 43: sms.SMSService.SchedulePurchase:output_type -> sms.SchedulePurchaseResponse
+	20, // 44: sms.SMSService.GetScheduledPurchase:output_type -> sms.ScheduledPurchase
+	24, // 45: sms.SMSService.GetDeferralStats:output_type -> sms.GetDeferralStatsResponse
+	30, // 46: sms.SMSService.RentNumber:output_type -> sms.RentNumberResponse
+	29, // 47: sms.SMSService.RenewRental:output_type -> sms.Rental
+	29, // 48: sms.SMSService.ReleaseRental:output_type -> sms.Rental
+	29, // 49: sms.SMSService.AssignRental:output_type -> sms.Rental
+	29, // 50: sms.SMSService.GetRental:output_type -> sms.Rental
+	36, // 51: sms.SMSService.ListRentals:output_type -> sms.ListRentalsResponse
+	39, // 52: sms.SMSService.GetRentalMessages:output_type -> sms.GetRentalMessagesResponse
+	27, // 53: sms.SMSService.GetProviderHealth:output_type -> sms.GetProviderHealthResponse
+	34, // [34:54] is the sub-list for method output_type
+	14, // [14:34] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_services_sms_service_proto_sms_proto_init() }
@@ -1030,7 +3295,7 @@ func file_services_sms_service_proto_sms_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_sms_service_proto_sms_proto_rawDesc), len(file_services_sms_service_proto_sms_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
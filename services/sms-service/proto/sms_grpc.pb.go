@@ -19,12 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SMSService_PurchaseNumber_FullMethodName      = "/sms.SMSService/PurchaseNumber"
-	SMSService_GetSMSCode_FullMethodName          = "/sms.SMSService/GetSMSCode"
-	SMSService_CancelActivation_FullMethodName    = "/sms.SMSService/CancelActivation"
-	SMSService_GetActivationStatus_FullMethodName = "/sms.SMSService/GetActivationStatus"
-	SMSService_GetStatistics_FullMethodName       = "/sms.SMSService/GetStatistics"
-	SMSService_GetProviderBalance_FullMethodName  = "/sms.SMSService/GetProviderBalance"
+	SMSService_PurchaseNumber_FullMethodName       = "/sms.SMSService/PurchaseNumber"
+	SMSService_GetSMSCode_FullMethodName           = "/sms.SMSService/GetSMSCode"
+	SMSService_CancelActivation_FullMethodName     = "/sms.SMSService/CancelActivation"
+	SMSService_GetActivationStatus_FullMethodName  = "/sms.SMSService/GetActivationStatus"
+	SMSService_GetStatistics_FullMethodName        = "/sms.SMSService/GetStatistics"
+	SMSService_GetProviderBalance_FullMethodName   = "/sms.SMSService/GetProviderBalance"
+	SMSService_GetPrices_FullMethodName            = "/sms.SMSService/GetPrices"
+	SMSService_PurchaseNumbers_FullMethodName      = "/sms.SMSService/PurchaseNumbers"
+	SMSService_ClaimBatchNumber_FullMethodName     = "/sms.SMSService/ClaimBatchNumber"
+	SMSService_SchedulePurchase_FullMethodName     = "/sms.SMSService/SchedulePurchase"
+	SMSService_GetScheduledPurchase_FullMethodName = "/sms.SMSService/GetScheduledPurchase"
+	SMSService_GetDeferralStats_FullMethodName     = "/sms.SMSService/GetDeferralStats"
+	SMSService_RentNumber_FullMethodName           = "/sms.SMSService/RentNumber"
+	SMSService_RenewRental_FullMethodName          = "/sms.SMSService/RenewRental"
+	SMSService_ReleaseRental_FullMethodName        = "/sms.SMSService/ReleaseRental"
+	SMSService_AssignRental_FullMethodName         = "/sms.SMSService/AssignRental"
+	SMSService_GetRental_FullMethodName            = "/sms.SMSService/GetRental"
+	SMSService_ListRentals_FullMethodName          = "/sms.SMSService/ListRentals"
+	SMSService_GetRentalMessages_FullMethodName    = "/sms.SMSService/GetRentalMessages"
+	SMSService_GetProviderHealth_FullMethodName    = "/sms.SMSService/GetProviderHealth"
 )
 
 // SMSServiceClient is the client API for SMSService service.
@@ -37,6 +51,20 @@ type SMSServiceClient interface {
 	GetActivationStatus(ctx context.Context, in *GetActivationStatusRequest, opts ...grpc.CallOption) (*GetActivationStatusResponse, error)
 	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error)
 	GetProviderBalance(ctx context.Context, in *GetProviderBalanceRequest, opts ...grpc.CallOption) (*GetProviderBalanceResponse, error)
+	GetPrices(ctx context.Context, in *GetPricesRequest, opts ...grpc.CallOption) (*GetPricesResponse, error)
+	PurchaseNumbers(ctx context.Context, in *PurchaseNumbersRequest, opts ...grpc.CallOption) (*PurchaseNumbersResponse, error)
+	ClaimBatchNumber(ctx context.Context, in *ClaimBatchNumberRequest, opts ...grpc.CallOption) (*PurchaseNumberResponse, error)
+	SchedulePurchase(ctx context.Context, in *SchedulePurchaseRequest, opts ...grpc.CallOption) (*SchedulePurchaseResponse, error)
+	GetScheduledPurchase(ctx context.Context, in *GetScheduledPurchaseRequest, opts ...grpc.CallOption) (*ScheduledPurchase, error)
+	GetDeferralStats(ctx context.Context, in *GetDeferralStatsRequest, opts ...grpc.CallOption) (*GetDeferralStatsResponse, error)
+	RentNumber(ctx context.Context, in *RentNumberRequest, opts ...grpc.CallOption) (*RentNumberResponse, error)
+	RenewRental(ctx context.Context, in *RenewRentalRequest, opts ...grpc.CallOption) (*Rental, error)
+	ReleaseRental(ctx context.Context, in *ReleaseRentalRequest, opts ...grpc.CallOption) (*Rental, error)
+	AssignRental(ctx context.Context, in *AssignRentalRequest, opts ...grpc.CallOption) (*Rental, error)
+	GetRental(ctx context.Context, in *GetRentalRequest, opts ...grpc.CallOption) (*Rental, error)
+	ListRentals(ctx context.Context, in *ListRentalsRequest, opts ...grpc.CallOption) (*ListRentalsResponse, error)
+	GetRentalMessages(ctx context.Context, in *GetRentalMessagesRequest, opts ...grpc.CallOption) (*GetRentalMessagesResponse, error)
+	GetProviderHealth(ctx context.Context, in *GetProviderHealthRequest, opts ...grpc.CallOption) (*GetProviderHealthResponse, error)
 }
 
 type sMSServiceClient struct {
@@ -107,6 +135,146 @@ func (c *sMSServiceClient) GetProviderBalance(ctx context.Context, in *GetProvid
 	return out, nil
 }
 
+func (c *sMSServiceClient) GetPrices(ctx context.Context, in *GetPricesRequest, opts ...grpc.CallOption) (*GetPricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPricesResponse)
+	err := c.cc.Invoke(ctx, SMSService_GetPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) PurchaseNumbers(ctx context.Context, in *PurchaseNumbersRequest, opts ...grpc.CallOption) (*PurchaseNumbersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurchaseNumbersResponse)
+	err := c.cc.Invoke(ctx, SMSService_PurchaseNumbers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) ClaimBatchNumber(ctx context.Context, in *ClaimBatchNumberRequest, opts ...grpc.CallOption) (*PurchaseNumberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurchaseNumberResponse)
+	err := c.cc.Invoke(ctx, SMSService_ClaimBatchNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) SchedulePurchase(ctx context.Context, in *SchedulePurchaseRequest, opts ...grpc.CallOption) (*SchedulePurchaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SchedulePurchaseResponse)
+	err := c.cc.Invoke(ctx, SMSService_SchedulePurchase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) GetScheduledPurchase(ctx context.Context, in *GetScheduledPurchaseRequest, opts ...grpc.CallOption) (*ScheduledPurchase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledPurchase)
+	err := c.cc.Invoke(ctx, SMSService_GetScheduledPurchase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) GetDeferralStats(ctx context.Context, in *GetDeferralStatsRequest, opts ...grpc.CallOption) (*GetDeferralStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeferralStatsResponse)
+	err := c.cc.Invoke(ctx, SMSService_GetDeferralStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) RentNumber(ctx context.Context, in *RentNumberRequest, opts ...grpc.CallOption) (*RentNumberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RentNumberResponse)
+	err := c.cc.Invoke(ctx, SMSService_RentNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) RenewRental(ctx context.Context, in *RenewRentalRequest, opts ...grpc.CallOption) (*Rental, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rental)
+	err := c.cc.Invoke(ctx, SMSService_RenewRental_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) ReleaseRental(ctx context.Context, in *ReleaseRentalRequest, opts ...grpc.CallOption) (*Rental, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rental)
+	err := c.cc.Invoke(ctx, SMSService_ReleaseRental_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) AssignRental(ctx context.Context, in *AssignRentalRequest, opts ...grpc.CallOption) (*Rental, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rental)
+	err := c.cc.Invoke(ctx, SMSService_AssignRental_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) GetRental(ctx context.Context, in *GetRentalRequest, opts ...grpc.CallOption) (*Rental, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rental)
+	err := c.cc.Invoke(ctx, SMSService_GetRental_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) ListRentals(ctx context.Context, in *ListRentalsRequest, opts ...grpc.CallOption) (*ListRentalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRentalsResponse)
+	err := c.cc.Invoke(ctx, SMSService_ListRentals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) GetRentalMessages(ctx context.Context, in *GetRentalMessagesRequest, opts ...grpc.CallOption) (*GetRentalMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRentalMessagesResponse)
+	err := c.cc.Invoke(ctx, SMSService_GetRentalMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMSServiceClient) GetProviderHealth(ctx context.Context, in *GetProviderHealthRequest, opts ...grpc.CallOption) (*GetProviderHealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProviderHealthResponse)
+	err := c.cc.Invoke(ctx, SMSService_GetProviderHealth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SMSServiceServer is the server API for SMSService service.
 // All implementations must embed UnimplementedSMSServiceServer
 // for forward compatibility.
@@ -117,6 +285,20 @@ type SMSServiceServer interface {
 	GetActivationStatus(context.Context, *GetActivationStatusRequest) (*GetActivationStatusResponse, error)
 	GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsResponse, error)
 	GetProviderBalance(context.Context, *GetProviderBalanceRequest) (*GetProviderBalanceResponse, error)
+	GetPrices(context.Context, *GetPricesRequest) (*GetPricesResponse, error)
+	PurchaseNumbers(context.Context, *PurchaseNumbersRequest) (*PurchaseNumbersResponse, error)
+	ClaimBatchNumber(context.Context, *ClaimBatchNumberRequest) (*PurchaseNumberResponse, error)
+	SchedulePurchase(context.Context, *SchedulePurchaseRequest) (*SchedulePurchaseResponse, error)
+	GetScheduledPurchase(context.Context, *GetScheduledPurchaseRequest) (*ScheduledPurchase, error)
+	GetDeferralStats(context.Context, *GetDeferralStatsRequest) (*GetDeferralStatsResponse, error)
+	RentNumber(context.Context, *RentNumberRequest) (*RentNumberResponse, error)
+	RenewRental(context.Context, *RenewRentalRequest) (*Rental, error)
+	ReleaseRental(context.Context, *ReleaseRentalRequest) (*Rental, error)
+	AssignRental(context.Context, *AssignRentalRequest) (*Rental, error)
+	GetRental(context.Context, *GetRentalRequest) (*Rental, error)
+	ListRentals(context.Context, *ListRentalsRequest) (*ListRentalsResponse, error)
+	GetRentalMessages(context.Context, *GetRentalMessagesRequest) (*GetRentalMessagesResponse, error)
+	GetProviderHealth(context.Context, *GetProviderHealthRequest) (*GetProviderHealthResponse, error)
 	mustEmbedUnimplementedSMSServiceServer()
 }
 
@@ -145,6 +327,48 @@ func (UnimplementedSMSServiceServer) GetStatistics(context.Context, *GetStatisti
 func (UnimplementedSMSServiceServer) GetProviderBalance(context.Context, *GetProviderBalanceRequest) (*GetProviderBalanceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProviderBalance not implemented")
 }
+func (UnimplementedSMSServiceServer) GetPrices(context.Context, *GetPricesRequest) (*GetPricesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPrices not implemented")
+}
+func (UnimplementedSMSServiceServer) PurchaseNumbers(context.Context, *PurchaseNumbersRequest) (*PurchaseNumbersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurchaseNumbers not implemented")
+}
+func (UnimplementedSMSServiceServer) ClaimBatchNumber(context.Context, *ClaimBatchNumberRequest) (*PurchaseNumberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClaimBatchNumber not implemented")
+}
+func (UnimplementedSMSServiceServer) SchedulePurchase(context.Context, *SchedulePurchaseRequest) (*SchedulePurchaseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SchedulePurchase not implemented")
+}
+func (UnimplementedSMSServiceServer) GetScheduledPurchase(context.Context, *GetScheduledPurchaseRequest) (*ScheduledPurchase, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetScheduledPurchase not implemented")
+}
+func (UnimplementedSMSServiceServer) GetDeferralStats(context.Context, *GetDeferralStatsRequest) (*GetDeferralStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeferralStats not implemented")
+}
+func (UnimplementedSMSServiceServer) RentNumber(context.Context, *RentNumberRequest) (*RentNumberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RentNumber not implemented")
+}
+func (UnimplementedSMSServiceServer) RenewRental(context.Context, *RenewRentalRequest) (*Rental, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenewRental not implemented")
+}
+func (UnimplementedSMSServiceServer) ReleaseRental(context.Context, *ReleaseRentalRequest) (*Rental, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseRental not implemented")
+}
+func (UnimplementedSMSServiceServer) AssignRental(context.Context, *AssignRentalRequest) (*Rental, error) {
+	return nil, status.Error(codes.Unimplemented, "method AssignRental not implemented")
+}
+func (UnimplementedSMSServiceServer) GetRental(context.Context, *GetRentalRequest) (*Rental, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRental not implemented")
+}
+func (UnimplementedSMSServiceServer) ListRentals(context.Context, *ListRentalsRequest) (*ListRentalsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRentals not implemented")
+}
+func (UnimplementedSMSServiceServer) GetRentalMessages(context.Context, *GetRentalMessagesRequest) (*GetRentalMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRentalMessages not implemented")
+}
+func (UnimplementedSMSServiceServer) GetProviderHealth(context.Context, *GetProviderHealthRequest) (*GetProviderHealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProviderHealth not implemented")
+}
 func (UnimplementedSMSServiceServer) mustEmbedUnimplementedSMSServiceServer() {}
 func (UnimplementedSMSServiceServer) testEmbeddedByValue()                    {}
 
@@ -274,6 +498,258 @@ func _SMSService_GetProviderBalance_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SMSService_GetPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).GetPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_GetPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).GetPrices(ctx, req.(*GetPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_PurchaseNumbers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseNumbersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).PurchaseNumbers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_PurchaseNumbers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).PurchaseNumbers(ctx, req.(*PurchaseNumbersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_ClaimBatchNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimBatchNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).ClaimBatchNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_ClaimBatchNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).ClaimBatchNumber(ctx, req.(*ClaimBatchNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_SchedulePurchase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePurchaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).SchedulePurchase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_SchedulePurchase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).SchedulePurchase(ctx, req.(*SchedulePurchaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_GetScheduledPurchase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledPurchaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).GetScheduledPurchase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_GetScheduledPurchase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).GetScheduledPurchase(ctx, req.(*GetScheduledPurchaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_GetDeferralStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeferralStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).GetDeferralStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_GetDeferralStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).GetDeferralStats(ctx, req.(*GetDeferralStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_RentNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RentNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).RentNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_RentNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).RentNumber(ctx, req.(*RentNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_RenewRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).RenewRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_RenewRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).RenewRental(ctx, req.(*RenewRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_ReleaseRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).ReleaseRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_ReleaseRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).ReleaseRental(ctx, req.(*ReleaseRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_AssignRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).AssignRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_AssignRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).AssignRental(ctx, req.(*AssignRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_GetRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).GetRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_GetRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).GetRental(ctx, req.(*GetRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_ListRentals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRentalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).ListRentals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_ListRentals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).ListRentals(ctx, req.(*ListRentalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_GetRentalMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRentalMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).GetRentalMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_GetRentalMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).GetRentalMessages(ctx, req.(*GetRentalMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMSService_GetProviderHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMSServiceServer).GetProviderHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMSService_GetProviderHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMSServiceServer).GetProviderHealth(ctx, req.(*GetProviderHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SMSService_ServiceDesc is the grpc.ServiceDesc for SMSService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +781,62 @@ var SMSService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProviderBalance",
 			Handler:    _SMSService_GetProviderBalance_Handler,
 		},
+		{
+			MethodName: "GetPrices",
+			Handler:    _SMSService_GetPrices_Handler,
+		},
+		{
+			MethodName: "PurchaseNumbers",
+			Handler:    _SMSService_PurchaseNumbers_Handler,
+		},
+		{
+			MethodName: "ClaimBatchNumber",
+			Handler:    _SMSService_ClaimBatchNumber_Handler,
+		},
+		{
+			MethodName: "SchedulePurchase",
+			Handler:    _SMSService_SchedulePurchase_Handler,
+		},
+		{
+			MethodName: "GetScheduledPurchase",
+			Handler:    _SMSService_GetScheduledPurchase_Handler,
+		},
+		{
+			MethodName: "GetDeferralStats",
+			Handler:    _SMSService_GetDeferralStats_Handler,
+		},
+		{
+			MethodName: "RentNumber",
+			Handler:    _SMSService_RentNumber_Handler,
+		},
+		{
+			MethodName: "RenewRental",
+			Handler:    _SMSService_RenewRental_Handler,
+		},
+		{
+			MethodName: "ReleaseRental",
+			Handler:    _SMSService_ReleaseRental_Handler,
+		},
+		{
+			MethodName: "AssignRental",
+			Handler:    _SMSService_AssignRental_Handler,
+		},
+		{
+			MethodName: "GetRental",
+			Handler:    _SMSService_GetRental_Handler,
+		},
+		{
+			MethodName: "ListRentals",
+			Handler:    _SMSService_ListRentals_Handler,
+		},
+		{
+			MethodName: "GetRentalMessages",
+			Handler:    _SMSService_GetRentalMessages_Handler,
+		},
+		{
+			MethodName: "GetProviderHealth",
+			Handler:    _SMSService_GetProviderHealth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/sms-service/proto/sms.proto",
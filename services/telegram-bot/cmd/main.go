@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"log"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,6 +12,7 @@ import (
 	"github.com/grigta/conveer/services/telegram-bot/internal/models"
 	"github.com/grigta/conveer/services/telegram-bot/internal/repository"
 	"github.com/grigta/conveer/services/telegram-bot/internal/service"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
 	"github.com/go-telegram/bot"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -20,6 +20,9 @@ import (
 )
 
 func main() {
+	log := logger.FromEnv("telegram-bot")
+	logger.SetDefault(log)
+
 	// Create context
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -38,14 +41,26 @@ func main() {
 	defer mongoClient.Disconnect(ctx)
 
 	db := mongoClient.Database(cfg.DatabaseName)
-	log.Println("Connected to MongoDB")
+	log.Info("Connected to MongoDB")
 
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
+	digestRepo := repository.NewDigestRepository(db)
+	presetRepo := repository.NewPresetRepository(db)
+	interventionRepo := repository.NewInterventionRepository(db)
 
 	// Create indexes
 	if err := userRepo.CreateIndexes(ctx); err != nil {
-		log.Printf("Warning: Failed to create indexes: %v", err)
+		log.Warnf("Failed to create indexes: %v", err)
+	}
+	if err := digestRepo.CreateIndexes(ctx); err != nil {
+		log.Warnf("Failed to create digest indexes: %v", err)
+	}
+	if err := presetRepo.CreateIndexes(ctx); err != nil {
+		log.Warnf("Failed to create preset indexes: %v", err)
+	}
+	if err := interventionRepo.CreateIndexes(ctx); err != nil {
+		log.Warnf("Failed to create intervention indexes: %v", err)
 	}
 
 	// Initialize admin users from config
@@ -59,9 +74,9 @@ func main() {
 				Whitelist:  true,
 			}
 			if err := userRepo.Create(ctx, newUser); err != nil {
-				log.Printf("Warning: Failed to create admin user %d: %v", adminID, err)
+				log.Warnf("Failed to create admin user %d: %v", adminID, err)
 			} else {
-				log.Printf("Created admin user: %d", adminID)
+				log.Infof("Created admin user: %d", adminID)
 			}
 		}
 	}
@@ -72,18 +87,18 @@ func main() {
 		log.Fatalf("Failed to connect to RabbitMQ: %v", err)
 	}
 	defer rabbitmq.Close()
-	log.Println("Connected to RabbitMQ")
+	log.Info("Connected to RabbitMQ")
 
 	// Initialize gRPC clients
 	grpcClients, err := service.InitializeGRPCClients(cfg)
 	if err != nil {
-		log.Printf("Warning: Failed to initialize all gRPC clients: %v", err)
+		log.Warnf("Failed to initialize all gRPC clients: %v", err)
 		// Continue anyway, some services might not be available
 	}
 	if grpcClients != nil {
 		defer grpcClients.Close()
 	}
-	log.Println("Initialized gRPC clients")
+	log.Info("Initialized gRPC clients")
 
 	// Create export repository
 	var exportClients *repository.ExportClients
@@ -101,15 +116,33 @@ func main() {
 	commandService := service.NewCommandService(rabbitmq)
 	exportService := service.NewExportService(exportRepo)
 	statsService := service.NewStatsService(grpcClients)
+	presetService := service.NewPresetService(presetRepo)
+	scenarioEditor := service.NewScenarioEditorService(grpcClients)
+	interventionService := service.NewInterventionService(interventionRepo, grpcClients)
+	accountSearch := service.NewAccountSearchService(grpcClients, cfg.Inline)
 	botService, err := service.NewBotService(cfg.BotToken, authService)
 	if err != nil {
 		log.Fatalf("Failed to create bot service: %v", err)
 	}
 
+	// Initialize incident manager
+	incidentManager := service.NewIncidentManager(cfg.Incident, botService)
+	incidentManager.Start(ctx)
+
+	// Initialize digest scheduler
+	digestScheduler := service.NewDigestScheduler(cfg.Digest, digestRepo, grpcClients, botService)
+	digestScheduler.Start(ctx)
+
+	// Alert routing follows notification preferences from the auth service when it is configured
+	var preferencesClient service.PreferencesClient
+	if cfg.AuthServiceURL != "" {
+		preferencesClient = service.NewPreferencesClient(cfg.AuthServiceURL)
+	}
+
 	// Initialize event consumer
-	eventConsumer := service.NewEventConsumer(rabbitmq, botService, authService)
+	eventConsumer := service.NewEventConsumer(rabbitmq, botService, authService, preferencesClient, incidentManager, interventionService)
 	if err := eventConsumer.Start(ctx); err != nil {
-		log.Printf("Warning: Failed to start event consumer: %v", err)
+		log.Warnf("Failed to start event consumer: %v", err)
 	}
 
 	// Initialize handlers
@@ -119,6 +152,11 @@ func main() {
 		exportService,
 		statsService,
 		botService,
+		incidentManager,
+		digestScheduler,
+		presetService,
+		scenarioEditor,
+		interventionService,
 	)
 
 	callbackHandlers := handlers.NewCallbackHandlers(
@@ -127,13 +165,17 @@ func main() {
 		exportService,
 		statsService,
 		botService,
+		scenarioEditor,
+		interventionService,
 	)
 
+	inlineHandlers := handlers.NewInlineHandlers(authService, accountSearch, cfg.Inline)
+
 	// Get bot instance
 	b := botService.GetBot()
 
-	// Register middlewares
-	b.Use(handlers.LoggingMiddleware())
+	// Middlewares are set per handler: the bot is created before the handlers exist
+	logging := handlers.LoggingMiddleware()
 
 	// Register command handlers with auth middleware
 	registerCommand := func(command string, handler bot.HandlerFunc, requiredRole string) {
@@ -142,6 +184,7 @@ func main() {
 			command,
 			bot.MatchTypePrefix,
 			handlers.AuthMiddleware(authService, requiredRole)(handler),
+			logging,
 		)
 	}
 
@@ -150,11 +193,18 @@ func main() {
 	registerCommand("/help", commandHandlers.HandleHelp, models.RoleViewer)
 	registerCommand("/accounts", commandHandlers.HandleAccounts, models.RoleViewer)
 	registerCommand("/stats", commandHandlers.HandleStats, models.RoleViewer)
+	registerCommand("/digest", commandHandlers.HandleDigest, models.RoleViewer)
+	registerCommand("/preset", commandHandlers.HandlePreset, models.RoleViewer)
+	registerCommand("/run", commandHandlers.HandleRun, models.RoleViewer)
 	registerCommand("/export", commandHandlers.HandleExport, models.RoleOperator)
 	registerCommand("/register", commandHandlers.HandleRegister, models.RoleOperator)
 	registerCommand("/warming", commandHandlers.HandleWarming, models.RoleOperator)
+	registerCommand("/scenario", commandHandlers.HandleScenario, models.RoleOperator)
 	registerCommand("/proxies", commandHandlers.HandleProxies, models.RoleOperator)
 	registerCommand("/sms", commandHandlers.HandleSMS, models.RoleOperator)
+	registerCommand("/incident", commandHandlers.HandleIncident, models.RoleOperator)
+	registerCommand("/interventions", commandHandlers.HandleInterventions, models.RoleOperator)
+	registerCommand("/users", commandHandlers.HandleUsers, models.RoleAdmin)
 
 	// Register callback handler
 	b.RegisterHandler(
@@ -162,24 +212,28 @@ func main() {
 		"",
 		bot.MatchTypePrefix,
 		handlers.AuthMiddleware(authService, models.RoleViewer)(callbackHandlers.HandleCallback),
+		logging,
 	)
 
-	log.Println("Bot handlers registered")
+	// Inline account lookup checks access itself: there is no chat to reply to with a denial
+	b.RegisterHandlerMatchFunc(handlers.IsInlineQuery, inlineHandlers.HandleInlineQuery, logging)
+
+	log.Info("Bot handlers registered")
 
 	// Start the bot
 	go func() {
-		log.Println("Starting bot...")
+		log.Info("Starting bot...")
 		botService.Start(ctx)
 	}()
 
-	log.Println("Telegram bot is running...")
+	log.Info("Telegram bot is running...")
 
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
-	log.Println("Shutting down...")
+	log.Info("Shutting down...")
 
 	// Give the bot time to finish current operations
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -187,14 +241,14 @@ func main() {
 
 	// Stop event consumer
 	if err := eventConsumer.Stop(); err != nil {
-		log.Printf("Error stopping event consumer: %v", err)
+		log.Errorf("Error stopping event consumer: %v", err)
 	}
 
 	// Wait for shutdown or timeout
 	select {
 	case <-shutdownCtx.Done():
-		log.Println("Shutdown timeout exceeded")
+		log.Warn("Shutdown timeout exceeded")
 	case <-time.After(1 * time.Second):
-		log.Println("Graceful shutdown completed")
+		log.Info("Graceful shutdown completed")
 	}
 }
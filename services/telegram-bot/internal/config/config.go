@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v3"
@@ -19,8 +20,12 @@ type Config struct {
 	LogLevel         string            `yaml:"log_level" envconfig:"LOG_LEVEL" default:"info"`
 	AdminTelegramIDs []int64           `yaml:"admin_telegram_ids" envconfig:"ADMIN_TELEGRAM_IDS"`
 	EncryptionKey    string            `yaml:"encryption_key" envconfig:"ENCRYPTION_KEY"`
+	AuthServiceURL   string            `yaml:"auth_service_url" envconfig:"AUTH_SERVICE_URL" default:"http://auth-service:8001"`
 	GRPCServices     map[string]string `yaml:"grpc_services"`
 	Features         Features          `yaml:"features"`
+	Incident         IncidentConfig    `yaml:"incident"`
+	Digest           DigestConfig      `yaml:"digest"`
+	Inline           InlineConfig      `yaml:"inline"`
 }
 
 type Features struct {
@@ -29,6 +34,28 @@ type Features struct {
 	GrafanaAPIKey            string `yaml:"grafana_api_key" envconfig:"GRAFANA_API_KEY"`
 }
 
+// IncidentConfig controls aggregation of notifications during alert storms
+type IncidentConfig struct {
+	AutoDetect     bool          `yaml:"auto_detect" envconfig:"INCIDENT_AUTO_DETECT" default:"true"`
+	StormThreshold int           `yaml:"storm_threshold" envconfig:"INCIDENT_STORM_THRESHOLD" default:"20"`
+	StormWindow    time.Duration `yaml:"storm_window" envconfig:"INCIDENT_STORM_WINDOW" default:"1m"`
+	UpdateInterval time.Duration `yaml:"update_interval" envconfig:"INCIDENT_UPDATE_INTERVAL" default:"30s"`
+	QuietPeriod    time.Duration `yaml:"quiet_period" envconfig:"INCIDENT_QUIET_PERIOD" default:"5m"`
+}
+
+// DigestConfig controls scheduled summary digests
+type DigestConfig struct {
+	CheckInterval   time.Duration `yaml:"check_interval" envconfig:"DIGEST_CHECK_INTERVAL" default:"1m"`
+	DefaultTimezone string        `yaml:"default_timezone" envconfig:"DIGEST_DEFAULT_TIMEZONE" default:"Europe/Moscow"`
+}
+
+// InlineConfig controls inline account lookup (@bot find vk +7900...)
+type InlineConfig struct {
+	ScanLimit  int           `yaml:"scan_limit" envconfig:"INLINE_SCAN_LIMIT" default:"1000"`
+	MaxResults int           `yaml:"max_results" envconfig:"INLINE_MAX_RESULTS" default:"20"`
+	CacheTime  time.Duration `yaml:"cache_time" envconfig:"INLINE_CACHE_TIME" default:"10s"`
+}
+
 func LoadConfig(path string) (*Config, error) {
 	cfg := &Config{
 		GRPCServices: make(map[string]string),
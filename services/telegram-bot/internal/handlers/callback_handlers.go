@@ -19,6 +19,8 @@ type CallbackHandlers struct {
 	exportService  service.ExportService
 	statsService   service.StatsService
 	botService     service.BotService
+	scenarios      service.ScenarioEditorService
+	interventions  service.InterventionService
 }
 
 func NewCallbackHandlers(
@@ -27,6 +29,8 @@ func NewCallbackHandlers(
 	exportService service.ExportService,
 	statsService service.StatsService,
 	botService service.BotService,
+	scenarios service.ScenarioEditorService,
+	interventions service.InterventionService,
 ) *CallbackHandlers {
 	return &CallbackHandlers{
 		authService:    authService,
@@ -34,6 +38,8 @@ func NewCallbackHandlers(
 		exportService:  exportService,
 		statsService:   statsService,
 		botService:     botService,
+		scenarios:      scenarios,
+		interventions:  interventions,
 	}
 }
 
@@ -44,7 +50,7 @@ func (h *CallbackHandlers) HandleCallback(ctx context.Context, b *bot.Bot, updat
 	}
 
 	// Answer callback query to remove loading animation
-	b.AnswerCallbackQuery(ctx, &botmodels.AnswerCallbackQueryParams{
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 		CallbackQueryID: query.ID,
 	})
 
@@ -71,6 +77,10 @@ func (h *CallbackHandlers) HandleCallback(ctx context.Context, b *bot.Bot, updat
 		h.handleSMSCallback(ctx, b, query, parts[1:])
 	case "menu":
 		h.handleMenuCallback(ctx, b, query, parts[1:])
+	case "scn":
+		h.handleScenarioCallback(ctx, b, query, parts[1:])
+	case "itv":
+		h.handleInterventionCallback(ctx, b, query, parts[1:])
 	}
 }
 
@@ -90,9 +100,9 @@ func (h *CallbackHandlers) handleAccountsCallback(ctx context.Context, b *bot.Bo
 	// Get account stats
 	stats, err := h.statsService.GetAccountStats(ctx, platform)
 	if err != nil {
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:    query.Message.Chat.ID,
-			MessageID: query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    query.Message.Message.Chat.ID,
+			MessageID: query.Message.Message.ID,
 			Text:      "❌ Ошибка получения данных аккаунтов",
 		})
 		return
@@ -115,9 +125,9 @@ func (h *CallbackHandlers) handleAccountsCallback(ctx context.Context, b *bot.Bo
 	// Add pagination keyboard
 	keyboard := utils.PaginationKeyboard(page, 10, fmt.Sprintf("accounts:%s", platform))
 
-	b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-		ChatID:      query.Message.Chat.ID,
-		MessageID:   query.Message.MessageID,
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      query.Message.Message.Chat.ID,
+		MessageID:   query.Message.Message.ID,
 		Text:        text,
 		ParseMode:   botmodels.ParseModeMarkdown,
 		ReplyMarkup: keyboard,
@@ -134,9 +144,9 @@ func (h *CallbackHandlers) handleExportCallback(ctx context.Context, b *bot.Bot,
 		platform := params[1]
 		keyboard := utils.ExportFormatKeyboard(platform)
 
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        fmt.Sprintf("📤 Экспорт %s\n\nВыберите формат:", strings.ToUpper(platform)),
 			ReplyMarkup: keyboard,
 		})
@@ -151,30 +161,30 @@ func (h *CallbackHandlers) handleExportCallback(ctx context.Context, b *bot.Bot,
 	format := models.ExportFormat(params[1])
 
 	// Update message to show progress
-	b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-		ChatID:    query.Message.Chat.ID,
-		MessageID: query.Message.MessageID,
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    query.Message.Message.Chat.ID,
+		MessageID: query.Message.Message.ID,
 		Text:      "⏳ Экспортирую аккаунты...",
 	})
 
 	// Export all accounts
 	data, filename, err := h.exportService.ExportAccounts(ctx, platform, []string{"all"}, format)
 	if err != nil {
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:    query.Message.Chat.ID,
-			MessageID: query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    query.Message.Message.Chat.ID,
+			MessageID: query.Message.Message.ID,
 			Text:      fmt.Sprintf("❌ Ошибка экспорта: %v", err),
 		})
 		return
 	}
 
 	// Send file
-	h.botService.SendDocument(ctx, query.Message.Chat.ID, data, filename)
+	h.botService.SendDocument(ctx, query.Message.Message.Chat.ID, data, filename)
 
 	// Update message
-	b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-		ChatID:    query.Message.Chat.ID,
-		MessageID: query.Message.MessageID,
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    query.Message.Message.Chat.ID,
+		MessageID: query.Message.Message.ID,
 		Text:      fmt.Sprintf("✅ Экспорт завершен!\nФайл: %s", filename),
 	})
 }
@@ -191,7 +201,7 @@ func (h *CallbackHandlers) handleStatsCallback(ctx context.Context, b *bot.Bot,
 		// Refresh stats
 		stats, err := h.statsService.GetOverallStats(ctx)
 		if err != nil {
-			b.AnswerCallbackQuery(ctx, &botmodels.AnswerCallbackQueryParams{
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: query.ID,
 				Text:            "❌ Ошибка обновления",
 				ShowAlert:       true,
@@ -202,15 +212,15 @@ func (h *CallbackHandlers) handleStatsCallback(ctx context.Context, b *bot.Bot,
 		text := utils.FormatOverallStats(stats)
 		keyboard := utils.StatsActionsKeyboard()
 
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        text,
 			ParseMode:   botmodels.ParseModeMarkdown,
 			ReplyMarkup: keyboard,
 		})
 
-		b.AnswerCallbackQuery(ctx, &botmodels.AnswerCallbackQueryParams{
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: query.ID,
 			Text:            "✅ Обновлено",
 		})
@@ -223,7 +233,7 @@ func (h *CallbackHandlers) handleStatsCallback(ctx context.Context, b *bot.Bot,
 
 		stats, err := h.statsService.GetDetailedStats(ctx, platform)
 		if err != nil {
-			b.AnswerCallbackQuery(ctx, &botmodels.AnswerCallbackQueryParams{
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: query.ID,
 				Text:            "❌ Ошибка получения статистики",
 				ShowAlert:       true,
@@ -233,9 +243,9 @@ func (h *CallbackHandlers) handleStatsCallback(ctx context.Context, b *bot.Bot,
 
 		text := utils.FormatDetailedStats(stats)
 
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:    query.Message.Chat.ID,
-			MessageID: query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    query.Message.Message.Chat.ID,
+			MessageID: query.Message.Message.ID,
 			Text:      text,
 			ParseMode: botmodels.ParseModeMarkdown,
 		})
@@ -253,9 +263,9 @@ func (h *CallbackHandlers) handleWarmingCallback(ctx context.Context, b *bot.Bot
 	case "start":
 		// Show warming start form
 		keyboard := utils.WarmingStartKeyboard()
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        "🔥 Запуск прогрева\n\nВыберите параметры:",
 			ReplyMarkup: keyboard,
 		})
@@ -267,9 +277,9 @@ func (h *CallbackHandlers) handleWarmingCallback(ctx context.Context, b *bot.Bot
 		// Show duration selection for scenario
 		scenario := params[1]
 		keyboard := utils.WarmingDurationKeyboard(scenario)
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        fmt.Sprintf("🔥 Сценарий: %s\n\nВыберите длительность:", scenario),
 			ReplyMarkup: keyboard,
 		})
@@ -287,9 +297,9 @@ func (h *CallbackHandlers) handleProxyCallback(ctx context.Context, b *bot.Bot,
 	case "allocate":
 		// Show proxy type selection
 		keyboard := utils.ProxyTypeKeyboard()
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        "🌐 Выберите тип прокси:",
 			ReplyMarkup: keyboard,
 		})
@@ -303,7 +313,7 @@ func (h *CallbackHandlers) handleProxyCallback(ctx context.Context, b *bot.Bot,
 		// Here would be account selection, for now just simulate
 		err := h.commandService.AllocateProxy(ctx, "sample_account_id", proxyType)
 		if err != nil {
-			b.AnswerCallbackQuery(ctx, &botmodels.AnswerCallbackQueryParams{
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: query.ID,
 				Text:            "❌ Ошибка выделения прокси",
 				ShowAlert:       true,
@@ -311,9 +321,9 @@ func (h *CallbackHandlers) handleProxyCallback(ctx context.Context, b *bot.Bot,
 			return
 		}
 
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:    query.Message.Chat.ID,
-			MessageID: query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    query.Message.Message.Chat.ID,
+			MessageID: query.Message.Message.ID,
 			Text:      fmt.Sprintf("✅ Прокси типа %s выделен", proxyType),
 		})
 	}
@@ -330,9 +340,9 @@ func (h *CallbackHandlers) handleSMSCallback(ctx context.Context, b *bot.Bot, qu
 	case "purchase":
 		// Show service selection
 		keyboard := utils.SMSServiceKeyboard()
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        "📱 Выберите сервис:",
 			ReplyMarkup: keyboard,
 		})
@@ -345,9 +355,9 @@ func (h *CallbackHandlers) handleSMSCallback(ctx context.Context, b *bot.Bot, qu
 
 		// Show country selection
 		keyboard := utils.SMSCountryKeyboard(service)
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        fmt.Sprintf("📱 Сервис: %s\n\nВыберите страну:", strings.ToUpper(service)),
 			ReplyMarkup: keyboard,
 		})
@@ -361,7 +371,7 @@ func (h *CallbackHandlers) handleSMSCallback(ctx context.Context, b *bot.Bot, qu
 
 		err := h.commandService.PurchaseNumber(ctx, service, country)
 		if err != nil {
-			b.AnswerCallbackQuery(ctx, &botmodels.AnswerCallbackQueryParams{
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: query.ID,
 				Text:            "❌ Ошибка покупки номера",
 				ShowAlert:       true,
@@ -369,9 +379,9 @@ func (h *CallbackHandlers) handleSMSCallback(ctx context.Context, b *bot.Bot, qu
 			return
 		}
 
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:    query.Message.Chat.ID,
-			MessageID: query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    query.Message.Message.Chat.ID,
+			MessageID: query.Message.Message.ID,
 			Text:      fmt.Sprintf("✅ Номер для %s (%s) куплен", strings.ToUpper(service), country),
 		})
 	}
@@ -394,9 +404,9 @@ func (h *CallbackHandlers) handleMenuCallback(ctx context.Context, b *bot.Bot, q
 		text := utils.FormatOverallStats(stats)
 		keyboard := utils.StatsActionsKeyboard()
 
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        text,
 			ParseMode:   botmodels.ParseModeMarkdown,
 			ReplyMarkup: keyboard,
@@ -404,27 +414,27 @@ func (h *CallbackHandlers) handleMenuCallback(ctx context.Context, b *bot.Bot, q
 
 	case "accounts":
 		keyboard := utils.PlatformSelectionKeyboard()
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        "👥 Выберите платформу:",
 			ReplyMarkup: keyboard,
 		})
 
 	case "management":
 		keyboard := utils.ManagementMenuKeyboard(user)
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        "⚙️ Управление:",
 			ReplyMarkup: keyboard,
 		})
 
 	case "export":
 		keyboard := utils.PlatformSelectionKeyboard()
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        "📤 Выберите платформу для экспорта:",
 			ReplyMarkup: keyboard,
 		})
@@ -434,12 +444,233 @@ func (h *CallbackHandlers) handleMenuCallback(ctx context.Context, b *bot.Bot, q
 		welcomeText := "👋 *Главное меню*\n\nВыберите раздел:"
 		keyboard := utils.MainMenuKeyboard(user)
 
-		b.EditMessageText(ctx, &botmodels.EditMessageTextParams{
-			ChatID:      query.Message.Chat.ID,
-			MessageID:   query.Message.MessageID,
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      query.Message.Message.Chat.ID,
+			MessageID:   query.Message.Message.ID,
 			Text:        welcomeText,
 			ParseMode:   botmodels.ParseModeMarkdown,
 			ReplyMarkup: keyboard,
 		})
 	}
 }
+
+// handleScenarioCallback drives the guided scenario dialog started by /scenario:
+// platform -> duration -> intensity per stage -> validated timeline -> save
+func (h *CallbackHandlers) handleScenarioCallback(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, params []string) {
+	if len(params) < 1 {
+		return
+	}
+
+	chatID := query.Message.Message.Chat.ID
+	userID := query.From.ID
+
+	// The callback handler is open to viewers, scenarios are edited by operators
+	hasAccess, err := h.authService.CheckAccess(ctx, userID, models.RoleOperator)
+	if err != nil || !hasAccess {
+		h.editScenarioMessage(ctx, b, query, "🚫 Доступ запрещен. Обратитесь к администратору.", nil)
+		return
+	}
+
+	draft, err := h.scenarios.GetDraft(ctx, chatID, userID)
+	if err != nil {
+		text := fmt.Sprintf("❌ Ошибка получения черновика: %v", err)
+		if err == models.ErrScenarioDraftNotFound {
+			text = "ℹ️ Черновик сценария не найден или устарел. Начните заново: /scenario new [name]"
+		}
+		h.editScenarioMessage(ctx, b, query, text, nil)
+		return
+	}
+
+	switch params[0] {
+	case "platform":
+		if len(params) < 2 {
+			return
+		}
+		if _, ok := models.ScenarioActionMix[params[1]]; !ok {
+			return
+		}
+		draft.Platform = params[1]
+		draft.DurationDays = 0
+		draft.Intensities = nil
+
+	case "duration":
+		if len(params) < 2 {
+			return
+		}
+		days, err := strconv.Atoi(params[1])
+		if err != nil || days <= 0 {
+			return
+		}
+		draft.DurationDays = days
+		draft.Intensities = nil
+
+	case "stage":
+		if len(params) < 3 {
+			return
+		}
+		stage, err := strconv.Atoi(params[1])
+		// A tap on an older message of the dialog must not skip or repeat a stage
+		if err != nil || stage != len(draft.Intensities) || stage >= draft.StageCount() {
+			return
+		}
+		if _, ok := models.IntensityActions[params[2]]; !ok {
+			return
+		}
+		draft.Intensities = append(draft.Intensities, params[2])
+
+	case "restart":
+		draft.DurationDays = 0
+		draft.Intensities = nil
+
+	case "cancel":
+		h.scenarios.DiscardDraft(ctx, chatID, userID)
+		h.editScenarioMessage(ctx, b, query, "✖️ Редактирование сценария отменено", nil)
+		return
+
+	case "save":
+		if !draft.Complete() {
+			return
+		}
+		scenario, err := h.scenarios.Submit(ctx, draft, fmt.Sprintf("telegram:%d", userID))
+		if err != nil {
+			h.editScenarioMessage(ctx, b, query, fmt.Sprintf("❌ Ошибка сохранения сценария: %v", err), utils.ScenarioConfirmKeyboard(false))
+			return
+		}
+		h.editScenarioMessage(ctx, b, query, fmt.Sprintf("✅ Сценарий %s сохранен\nID: %s", scenario.Name, scenario.Id), nil)
+		return
+
+	default:
+		return
+	}
+
+	if err := h.scenarios.SaveDraft(ctx, draft); err != nil {
+		h.editScenarioMessage(ctx, b, query, fmt.Sprintf("❌ Ошибка сохранения черновика: %v", err), nil)
+		return
+	}
+
+	h.showScenarioStep(ctx, b, query, draft)
+}
+
+// showScenarioStep asks for the next missing choice of the draft, or shows the validated timeline
+func (h *CallbackHandlers) showScenarioStep(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, draft *models.ScenarioDraft) {
+	title := fmt.Sprintf("🗓 Сценарий %s", draft.Name)
+
+	switch {
+	case draft.Platform == "":
+		h.editScenarioMessage(ctx, b, query, title+"\n\nВыберите платформу:", utils.ScenarioPlatformKeyboard())
+
+	case draft.DurationDays == 0:
+		h.editScenarioMessage(ctx, b, query, fmt.Sprintf("%s (%s)\n\nВыберите длительность прогрева:", title, strings.ToUpper(draft.Platform)),
+			utils.ScenarioDurationKeyboard())
+
+	case !draft.Complete():
+		stage := len(draft.Intensities)
+		days := strings.Split(strings.TrimPrefix(models.ScenarioStages[stage], "days_"), "_")
+		h.editScenarioMessage(ctx, b, query, fmt.Sprintf("%s (%s, %d дней)\n\nЭтап %d из %d, дни %s-%s. Выберите интенсивность:",
+			title, strings.ToUpper(draft.Platform), draft.DurationDays, stage+1, draft.StageCount(), days[0], days[1]),
+			utils.ScenarioIntensityKeyboard(stage))
+
+	default:
+		result, err := h.scenarios.Validate(ctx, draft)
+		if err != nil {
+			h.editScenarioMessage(ctx, b, query, fmt.Sprintf("❌ Ошибка проверки сценария: %v", err), utils.ScenarioConfirmKeyboard(false))
+			return
+		}
+		h.editScenarioMessage(ctx, b, query, utils.FormatScenarioTimeline(draft, result), utils.ScenarioConfirmKeyboard(result.Valid))
+	}
+}
+
+func (h *CallbackHandlers) editScenarioMessage(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, text string, keyboard *botmodels.InlineKeyboardMarkup) {
+	params := &bot.EditMessageTextParams{
+		ChatID:    query.Message.Message.Chat.ID,
+		MessageID: query.Message.Message.ID,
+		Text:      text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+	b.EditMessageText(ctx, params)
+}
+
+// handleInterventionCallback pages through the intervention queue and runs the resolve and abandon actions
+func (h *CallbackHandlers) handleInterventionCallback(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, params []string) {
+	if len(params) < 2 {
+		return
+	}
+
+	userID := query.From.ID
+
+	// The callback handler is open to viewers, interventions are handled by operators
+	hasAccess, err := h.authService.CheckAccess(ctx, userID, models.RoleOperator)
+	if err != nil || !hasAccess {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: query.ID,
+			Text:            "🚫 Доступ запрещен",
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	page := 1
+	switch params[0] {
+	case "page":
+		if p, err := strconv.Atoi(params[1]); err == nil && p > 0 {
+			page = p
+		}
+
+	case "resolve", "abandon":
+		if len(params) < 3 {
+			return
+		}
+		if p, err := strconv.Atoi(params[2]); err == nil && p > 0 {
+			page = p
+		}
+
+		var (
+			intervention *models.Intervention
+			done         string
+		)
+		if params[0] == "resolve" {
+			intervention, err = h.interventions.Resolve(ctx, params[1], userID)
+			done = "✅ Регистрация перезапущена"
+		} else {
+			intervention, err = h.interventions.Abandon(ctx, params[1], userID)
+			done = "✖️ Аккаунт помечен ошибкой"
+		}
+
+		text := done
+		switch {
+		case err == models.ErrInterventionClosed || err == models.ErrInterventionNotFound:
+			text = "ℹ️ Уже обработано другим оператором"
+		case err != nil:
+			text = fmt.Sprintf("❌ %v", err)
+		default:
+			text = fmt.Sprintf("%s: %s %s", done, strings.ToUpper(intervention.Platform), intervention.AccountID)
+		}
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: query.ID,
+			Text:            text,
+			ShowAlert:       err != nil,
+		})
+
+	default:
+		return
+	}
+
+	text, keyboard, err := renderInterventions(ctx, h.interventions, page)
+	if err != nil {
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    query.Message.Message.Chat.ID,
+			MessageID: query.Message.Message.ID,
+			Text:      fmt.Sprintf("❌ Ошибка получения очереди: %v", err),
+		})
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      query.Message.Message.Chat.ID,
+		MessageID:   query.Message.Message.ID,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/grigta/conveer/services/telegram-bot/internal/models"
 	"github.com/grigta/conveer/services/telegram-bot/internal/service"
@@ -19,6 +22,11 @@ type CommandHandlers struct {
 	exportService  service.ExportService
 	statsService   service.StatsService
 	botService     service.BotService
+	incidents      service.IncidentManager
+	digests        service.DigestScheduler
+	presets        service.PresetService
+	scenarios      service.ScenarioEditorService
+	interventions  service.InterventionService
 }
 
 func NewCommandHandlers(
@@ -27,6 +35,11 @@ func NewCommandHandlers(
 	exportService service.ExportService,
 	statsService service.StatsService,
 	botService service.BotService,
+	incidents service.IncidentManager,
+	digests service.DigestScheduler,
+	presets service.PresetService,
+	scenarios service.ScenarioEditorService,
+	interventions service.InterventionService,
 ) *CommandHandlers {
 	return &CommandHandlers{
 		authService:    authService,
@@ -34,6 +47,11 @@ func NewCommandHandlers(
 		exportService:  exportService,
 		statsService:   statsService,
 		botService:     botService,
+		incidents:      incidents,
+		digests:        digests,
+		presets:        presets,
+		scenarios:      scenarios,
+		interventions:  interventions,
 	}
 }
 
@@ -43,16 +61,7 @@ func (h *CommandHandlers) HandleStart(ctx context.Context, b *bot.Bot, update *b
 
 	var roleText string
 	if ok && user != nil {
-		switch user.Role {
-		case models.RoleAdmin:
-			roleText = "Администратор"
-		case models.RoleOperator:
-			roleText = "Оператор"
-		case models.RoleViewer:
-			roleText = "Наблюдатель"
-		default:
-			roleText = "Неизвестная роль"
-		}
+		roleText = utils.RoleName(user.Role)
 	}
 
 	welcomeText := fmt.Sprintf(`👋 *Добро пожаловать в панель управления Conveer!*
@@ -63,7 +72,7 @@ func (h *CommandHandlers) HandleStart(ctx context.Context, b *bot.Bot, update *b
 
 	keyboard := utils.MainMenuKeyboard(user)
 
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
 		Text:        welcomeText,
 		ParseMode:   botmodels.ParseModeMarkdown,
@@ -81,20 +90,28 @@ func (h *CommandHandlers) HandleHelp(ctx context.Context, b *bot.Bot, update *bo
 	helpText.WriteString("/help - Список команд\n")
 	helpText.WriteString("/accounts [platform] - Список аккаунтов\n")
 	helpText.WriteString("/stats [platform] - Статистика\n")
+	helpText.WriteString("/digest [daily|weekly|off|now] - Сводки по расписанию\n")
+	helpText.WriteString("/preset [save|delete] - Сохраненные команды\n")
+	helpText.WriteString("/run [name] [key=value] - Запуск сохраненной команды\n")
 
 	if user != nil && user.Role != models.RoleViewer {
 		helpText.WriteString("/export [platform] [format] - Экспорт аккаунтов\n")
 		helpText.WriteString("/register [platform] [count] - Регистрация аккаунтов\n")
 		helpText.WriteString("/warming [action] - Управление прогревом\n")
+		helpText.WriteString("/scenario [new|edit] - Редактор сценариев прогрева\n")
 		helpText.WriteString("/proxies - Управление прокси\n")
 		helpText.WriteString("/sms - Управление SMS\n")
+		helpText.WriteString("/incident [on|off|status] - Режим инцидента\n")
+		helpText.WriteString("/interventions - Очередь ручного вмешательства\n")
 	}
 
 	if user != nil && user.Role == models.RoleAdmin {
 		helpText.WriteString("/users - Управление пользователями\n")
 	}
 
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	helpText.WriteString("\nПоиск аккаунта из любого чата: `@<имя бота> find vk +7900...`\n")
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:    chatID,
 		Text:      helpText.String(),
 		ParseMode: botmodels.ParseModeMarkdown,
@@ -108,7 +125,7 @@ func (h *CommandHandlers) HandleAccounts(ctx context.Context, b *bot.Bot, update
 	if len(args) < 2 {
 		// Show platform selection
 		keyboard := utils.PlatformSelectionKeyboard()
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
 			Text:        "👥 Выберите платформу:",
 			ReplyMarkup: keyboard,
@@ -127,7 +144,7 @@ func (h *CommandHandlers) HandleAccounts(ctx context.Context, b *bot.Bot, update
 	// Get account stats
 	stats, err := h.statsService.GetAccountStats(ctx, platform)
 	if err != nil {
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   "❌ Ошибка получения данных аккаунтов",
 		})
@@ -151,7 +168,7 @@ func (h *CommandHandlers) HandleAccounts(ctx context.Context, b *bot.Bot, update
 	// Add pagination keyboard
 	keyboard := utils.PaginationKeyboard(page, 10, fmt.Sprintf("accounts:%s", platform))
 
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
 		Text:        text,
 		ParseMode:   botmodels.ParseModeMarkdown,
@@ -166,7 +183,7 @@ func (h *CommandHandlers) HandleExport(ctx context.Context, b *bot.Bot, update *
 	if len(args) < 2 {
 		// Show platform selection
 		keyboard := utils.PlatformSelectionKeyboard()
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
 			Text:        "📤 Выберите платформу для экспорта:",
 			ReplyMarkup: keyboard,
@@ -179,7 +196,7 @@ func (h *CommandHandlers) HandleExport(ctx context.Context, b *bot.Bot, update *
 	if len(args) < 3 {
 		// Show format selection
 		keyboard := utils.ExportFormatKeyboard(platform)
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
 			Text:        "📄 Выберите формат экспорта:",
 			ReplyMarkup: keyboard,
@@ -190,7 +207,7 @@ func (h *CommandHandlers) HandleExport(ctx context.Context, b *bot.Bot, update *
 	format := models.ExportFormat(args[2])
 
 	// Start export process
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
 		Text:   "⏳ Экспортирую аккаунты...",
 	})
@@ -198,7 +215,7 @@ func (h *CommandHandlers) HandleExport(ctx context.Context, b *bot.Bot, update *
 	// Export all accounts (simplified)
 	data, filename, err := h.exportService.ExportAccounts(ctx, platform, []string{"all"}, format)
 	if err != nil {
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   fmt.Sprintf("❌ Ошибка экспорта: %v", err),
 		})
@@ -208,7 +225,7 @@ func (h *CommandHandlers) HandleExport(ctx context.Context, b *bot.Bot, update *
 	// Send file
 	h.botService.SendDocument(ctx, chatID, data, filename)
 
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
 		Text:   fmt.Sprintf("✅ Экспорт завершен!\nФайл: %s", filename),
 	})
@@ -219,13 +236,12 @@ func (h *CommandHandlers) HandleStats(ctx context.Context, b *bot.Bot, update *b
 	args := strings.Fields(update.Message.Text)
 
 	var text string
-	var err error
 
 	if len(args) < 2 {
 		// Get overall stats
 		stats, err := h.statsService.GetOverallStats(ctx)
 		if err != nil {
-			b.SendMessage(ctx, &botmodels.SendMessageParams{
+			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
 				Text:   "❌ Ошибка получения статистики",
 			})
@@ -237,7 +253,7 @@ func (h *CommandHandlers) HandleStats(ctx context.Context, b *bot.Bot, update *b
 		platform := args[1]
 		stats, err := h.statsService.GetDetailedStats(ctx, platform)
 		if err != nil {
-			b.SendMessage(ctx, &botmodels.SendMessageParams{
+			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
 				Text:   "❌ Ошибка получения статистики",
 			})
@@ -246,7 +262,7 @@ func (h *CommandHandlers) HandleStats(ctx context.Context, b *bot.Bot, update *b
 		text = utils.FormatDetailedStats(stats)
 	}
 
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:    chatID,
 		Text:      text,
 		ParseMode: botmodels.ParseModeMarkdown,
@@ -258,7 +274,7 @@ func (h *CommandHandlers) HandleRegister(ctx context.Context, b *bot.Bot, update
 	args := strings.Fields(update.Message.Text)
 
 	if len(args) < 3 {
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   "❌ Использование: /register [platform] [count]\nПример: /register vk 10",
 		})
@@ -268,7 +284,7 @@ func (h *CommandHandlers) HandleRegister(ctx context.Context, b *bot.Bot, update
 	platform := args[1]
 	count, err := strconv.Atoi(args[2])
 	if err != nil {
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   "❌ Некорректное количество аккаунтов",
 		})
@@ -277,14 +293,14 @@ func (h *CommandHandlers) HandleRegister(ctx context.Context, b *bot.Bot, update
 
 	// Start registration
 	if err := h.commandService.StartRegistration(ctx, platform, count); err != nil {
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   fmt.Sprintf("❌ Ошибка запуска регистрации: %v", err),
 		})
 		return
 	}
 
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
 		Text:   fmt.Sprintf("✅ Запущена регистрация %d аккаунтов на %s.\n\nВы получите уведомление по завершении.", count, strings.ToUpper(platform)),
 	})
@@ -296,7 +312,7 @@ func (h *CommandHandlers) HandleWarming(ctx context.Context, b *bot.Bot, update
 
 	if len(args) < 2 {
 		keyboard := utils.WarmingActionsKeyboard()
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
 			Text:        "🔥 Управление прогревом:",
 			ReplyMarkup: keyboard,
@@ -309,7 +325,7 @@ func (h *CommandHandlers) HandleWarming(ctx context.Context, b *bot.Bot, update
 	switch action {
 	case "start":
 		if len(args) < 6 {
-			b.SendMessage(ctx, &botmodels.SendMessageParams{
+			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
 				Text:   "❌ Использование: /warming start [account_id] [platform] [scenario] [days]\nПример: /warming start ACC123 vk standard 7",
 			})
@@ -320,30 +336,30 @@ func (h *CommandHandlers) HandleWarming(ctx context.Context, b *bot.Bot, update
 		scenario := args[4]
 		days, err := strconv.Atoi(args[5])
 		if err != nil || days <= 0 {
-			b.SendMessage(ctx, &botmodels.SendMessageParams{
+			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
 				Text:   "❌ Некорректное количество дней. Укажите положительное целое число.",
 			})
 			return
 		}
 
-		err := h.commandService.StartWarming(ctx, accountID, platform, scenario, days)
+		err = h.commandService.StartWarming(ctx, accountID, platform, scenario, days)
 		if err != nil {
-			b.SendMessage(ctx, &botmodels.SendMessageParams{
+			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
 				Text:   fmt.Sprintf("❌ Ошибка запуска прогрева: %v", err),
 			})
 			return
 		}
 
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   fmt.Sprintf("✅ Прогрев запущен для аккаунта %s", accountID),
 		})
 
 	case "pause", "resume", "stop":
 		if len(args) < 3 {
-			b.SendMessage(ctx, &botmodels.SendMessageParams{
+			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
 				Text:   fmt.Sprintf("❌ Использование: /warming %s [task_id]", action),
 			})
@@ -362,20 +378,20 @@ func (h *CommandHandlers) HandleWarming(ctx context.Context, b *bot.Bot, update
 		}
 
 		if err != nil {
-			b.SendMessage(ctx, &botmodels.SendMessageParams{
+			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
 				Text:   fmt.Sprintf("❌ Ошибка: %v", err),
 			})
 			return
 		}
 
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   fmt.Sprintf("✅ Прогрев %s для задачи %s", action, taskID),
 		})
 
 	default:
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   "❌ Неизвестное действие. Доступны: start, pause, resume, stop",
 		})
@@ -387,7 +403,7 @@ func (h *CommandHandlers) HandleProxies(ctx context.Context, b *bot.Bot, update
 
 	stats, err := h.statsService.GetProxyStats(ctx)
 	if err != nil {
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   "❌ Ошибка получения статистики прокси",
 		})
@@ -397,7 +413,7 @@ func (h *CommandHandlers) HandleProxies(ctx context.Context, b *bot.Bot, update
 	text := utils.FormatProxyStats(stats)
 	keyboard := utils.ProxyActionsKeyboard()
 
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
 		Text:        text,
 		ParseMode:   botmodels.ParseModeMarkdown,
@@ -410,7 +426,7 @@ func (h *CommandHandlers) HandleSMS(ctx context.Context, b *bot.Bot, update *bot
 
 	stats, err := h.statsService.GetSMSStats(ctx)
 	if err != nil {
-		b.SendMessage(ctx, &botmodels.SendMessageParams{
+		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   "❌ Ошибка получения статистики SMS",
 		})
@@ -420,10 +436,717 @@ func (h *CommandHandlers) HandleSMS(ctx context.Context, b *bot.Bot, update *bot
 	text := utils.FormatSMSStats(stats)
 	keyboard := utils.SMSActionsKeyboard()
 
-	b.SendMessage(ctx, &botmodels.SendMessageParams{
+	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
 		Text:        text,
 		ParseMode:   botmodels.ParseModeMarkdown,
 		ReplyMarkup: keyboard,
 	})
 }
+
+func (h *CommandHandlers) HandleIncident(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	chatID := update.Message.Chat.ID
+	args := strings.Fields(update.Message.Text)
+
+	action := "status"
+	if len(args) >= 2 {
+		action = strings.ToLower(args[1])
+	}
+
+	var text string
+	switch action {
+	case "on":
+		reason := strings.Join(args[2:], " ")
+		incident, started := h.incidents.Activate(reason, update.Message.From.ID)
+		if started {
+			text = "🔥 Режим инцидента включен. Уведомления будут собираться в сводку."
+		} else {
+			text = fmt.Sprintf("ℹ️ Инцидент уже активен с %s", incident.StartedAt.Format("15:04:05"))
+		}
+	case "off":
+		incident, resolved := h.incidents.Resolve(ctx)
+		if resolved {
+			text = fmt.Sprintf("✅ Режим инцидента выключен. Собрано событий: %d", incident.EventCount)
+		} else {
+			text = "ℹ️ Активного инцидента нет"
+		}
+	case "status":
+		incident := h.incidents.Current()
+		if incident == nil {
+			text = "✅ Активного инцидента нет"
+		} else {
+			text = fmt.Sprintf("🔥 Инцидент активен с %s\nСобытий: %d",
+				incident.StartedAt.Format("15:04:05"), incident.EventCount)
+		}
+	default:
+		text = "Использование: /incident [on|off|status] [причина]"
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+}
+
+var digestWeekdays = map[string]time.Weekday{
+	"mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+	"fri": time.Friday, "sat": time.Saturday, "sun": time.Sunday,
+	"пн": time.Monday, "вт": time.Tuesday, "ср": time.Wednesday, "чт": time.Thursday,
+	"пт": time.Friday, "сб": time.Saturday, "вс": time.Sunday,
+}
+
+var digestWeekdayNames = map[time.Weekday]string{
+	time.Monday: "пн", time.Tuesday: "вт", time.Wednesday: "ср", time.Thursday: "чт",
+	time.Friday: "пт", time.Saturday: "сб", time.Sunday: "вс",
+}
+
+const digestUsage = `Использование:
+/digest - Текущие подписки
+/digest daily 09:00 [timezone] [sections]
+/digest weekly mon 09:00 [timezone] [sections]
+/digest off [daily|weekly]
+/digest now [daily|weekly]
+
+Разделы: accounts,bans,spend,warming,errors (по умолчанию все)`
+
+func (h *CommandHandlers) HandleDigest(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	chatID := update.Message.Chat.ID
+	args := strings.Fields(update.Message.Text)
+
+	action := "list"
+	if len(args) >= 2 {
+		action = strings.ToLower(args[1])
+	}
+
+	var text string
+	switch action {
+	case "list":
+		digests, err := h.digests.List(ctx, chatID)
+		if err != nil {
+			text = fmt.Sprintf("❌ Не удалось получить подписки: %v", err)
+		} else if len(digests) == 0 {
+			text = "ℹ️ Подписок на сводки нет\n\n" + digestUsage
+		} else {
+			var builder strings.Builder
+			builder.WriteString("📰 Подписки на сводки:\n")
+			for _, d := range digests {
+				schedule := d.SendAt
+				if d.Frequency == models.DigestWeekly {
+					schedule = digestWeekdayNames[d.Weekday] + " " + schedule
+				}
+				sections := "все разделы"
+				if len(d.Sections) > 0 {
+					sections = strings.Join(d.Sections, ", ")
+				}
+				builder.WriteString(fmt.Sprintf("• %s: %s (%s), %s\n", d.Frequency, schedule, d.Timezone, sections))
+			}
+			text = builder.String()
+		}
+	case models.DigestDaily, models.DigestWeekly:
+		digest, err := parseDigestArgs(action, args[2:])
+		if err != nil {
+			text = fmt.Sprintf("❌ %v\n\n%s", err, digestUsage)
+			break
+		}
+		digest.ChatID = chatID
+		digest.CreatedBy = update.Message.From.ID
+
+		if err := h.digests.Subscribe(ctx, digest); err != nil {
+			text = fmt.Sprintf("❌ Не удалось сохранить подписку: %v", err)
+		} else {
+			text = fmt.Sprintf("✅ Сводка %s будет приходить в %s (%s)", digest.Frequency, digest.SendAt, digest.Timezone)
+		}
+	case "off":
+		frequency := ""
+		if len(args) >= 3 {
+			frequency = strings.ToLower(args[2])
+		}
+		if err := h.digests.Unsubscribe(ctx, chatID, frequency); err != nil {
+			if err == models.ErrDigestNotFound {
+				text = "ℹ️ Подписок на сводки нет"
+			} else {
+				text = fmt.Sprintf("❌ Не удалось отменить подписку: %v", err)
+			}
+		} else {
+			text = "✅ Подписка на сводки отменена"
+		}
+	case "now":
+		digest := &models.DigestSubscription{
+			Frequency: models.DigestDaily,
+			Timezone:  h.digests.DefaultTimezone(),
+		}
+		if len(args) >= 3 && strings.ToLower(args[2]) == models.DigestWeekly {
+			digest.Frequency = models.DigestWeekly
+		}
+
+		composed, err := h.digests.Compose(ctx, digest)
+		if err != nil {
+			text = fmt.Sprintf("❌ Не удалось собрать сводку: %v", err)
+			break
+		}
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      composed,
+			ParseMode: botmodels.ParseModeMarkdown,
+		})
+		return
+	default:
+		text = digestUsage
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+}
+
+// parseDigestArgs parses "[weekday] HH:MM [timezone] [sections]"
+func parseDigestArgs(frequency string, args []string) (*models.DigestSubscription, error) {
+	digest := &models.DigestSubscription{Frequency: frequency}
+
+	if frequency == models.DigestWeekly {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("не указан день недели")
+		}
+		weekday, ok := digestWeekdays[strings.ToLower(args[0])]
+		if !ok {
+			return nil, fmt.Errorf("неизвестный день недели: %s", args[0])
+		}
+		digest.Weekday = weekday
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("не указано время отправки")
+	}
+	sendAt, err := time.Parse("15:04", args[0])
+	if err != nil {
+		return nil, fmt.Errorf("неверное время %s, ожидается ЧЧ:ММ", args[0])
+	}
+	digest.SendAt = sendAt.Format("15:04")
+
+	for _, arg := range args[1:] {
+		if sections, ok := parseDigestSections(arg); ok {
+			digest.Sections = sections
+			continue
+		}
+		if _, err := time.LoadLocation(arg); err != nil {
+			return nil, fmt.Errorf("неизвестный часовой пояс или раздел: %s", arg)
+		}
+		digest.Timezone = arg
+	}
+
+	return digest, nil
+}
+
+func parseDigestSections(arg string) ([]string, bool) {
+	parts := strings.Split(strings.ToLower(arg), ",")
+	sections := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if !models.IsDigestSection(part) {
+			return nil, false
+		}
+		sections = append(sections, part)
+	}
+	return sections, true
+}
+
+const presetUsage = `Использование:
+/preset - Ваши сохраненные команды
+/preset save [name] [command] [args]
+/preset delete [name]
+/run [name] [key=value ...]
+
+Пример: /preset save vkbatch register vk 20
+Запуск с другим количеством: /run vkbatch count=30`
+
+func (h *CommandHandlers) HandlePreset(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	args := strings.Fields(update.Message.Text)
+
+	action := "list"
+	if len(args) >= 2 {
+		action = strings.ToLower(args[1])
+	}
+
+	var text string
+	switch action {
+	case "list":
+		presets, err := h.presets.List(ctx, chatID, userID)
+		if err != nil {
+			text = fmt.Sprintf("❌ Не удалось получить сохраненные команды: %v", err)
+		} else if len(presets) == 0 {
+			text = "ℹ️ Сохраненных команд нет\n\n" + presetUsage
+		} else {
+			var builder strings.Builder
+			builder.WriteString("📌 Сохраненные команды:\n")
+			for _, p := range presets {
+				command, err := p.CommandText(nil)
+				if err != nil {
+					command = fmt.Sprintf("/%s (%v)", p.Command, err)
+				}
+				builder.WriteString(fmt.Sprintf("• %s: %s\n", p.Name, command))
+			}
+			text = builder.String()
+		}
+	case "save":
+		if len(args) < 4 {
+			text = presetUsage
+			break
+		}
+		command := strings.ToLower(strings.TrimPrefix(args[3], "/"))
+		params, err := parsePresetArgs(command, args[4:])
+		if err != nil {
+			text = fmt.Sprintf("❌ %v\n\n%s", err, presetUsage)
+			break
+		}
+		if !h.canRunPreset(ctx, b, chatID, userID, command) {
+			return
+		}
+
+		preset := &models.CommandPreset{
+			ChatID:  chatID,
+			UserID:  userID,
+			Name:    strings.ToLower(args[2]),
+			Command: command,
+			Params:  params,
+		}
+		if err := h.presets.Save(ctx, preset); err != nil {
+			text = fmt.Sprintf("❌ Не удалось сохранить команду: %v", err)
+			break
+		}
+		rendered, _ := preset.CommandText(nil)
+		text = fmt.Sprintf("✅ Команда %s сохранена: %s\nЗапуск: /run %s", preset.Name, rendered, preset.Name)
+	case "delete":
+		if len(args) < 3 {
+			text = presetUsage
+			break
+		}
+		name := strings.ToLower(args[2])
+		if err := h.presets.Delete(ctx, chatID, userID, name); err != nil {
+			if err == models.ErrPresetNotFound {
+				text = fmt.Sprintf("ℹ️ Команда %s не найдена", name)
+			} else {
+				text = fmt.Sprintf("❌ Не удалось удалить команду: %v", err)
+			}
+		} else {
+			text = fmt.Sprintf("✅ Команда %s удалена", name)
+		}
+	default:
+		text = presetUsage
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+}
+
+// HandleRun expands a saved preset with the given overrides and passes it to the command's handler
+func (h *CommandHandlers) HandleRun(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	args := strings.Fields(update.Message.Text)
+
+	if len(args) < 2 {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   presetUsage,
+		})
+		return
+	}
+
+	name := strings.ToLower(args[1])
+	preset, err := h.presets.Get(ctx, chatID, userID, name)
+	if err != nil {
+		text := fmt.Sprintf("❌ Не удалось получить команду: %v", err)
+		if err == models.ErrPresetNotFound {
+			text = fmt.Sprintf("ℹ️ Команда %s не найдена. Список: /preset", name)
+		}
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		return
+	}
+
+	overrides := make(map[string]string)
+	for _, arg := range args[2:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" || value == "" {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   fmt.Sprintf("❌ Параметры задаются как key=value: %s", arg),
+			})
+			return
+		}
+		overrides[strings.ToLower(key)] = value
+	}
+
+	commandText, err := preset.CommandText(overrides)
+	if err != nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ %v", err),
+		})
+		return
+	}
+
+	// The role is checked again, it could have been lowered since the preset was saved
+	if !h.canRunPreset(ctx, b, chatID, userID, preset.Command) {
+		return
+	}
+
+	handler := h.presetHandler(preset.Command)
+	if handler == nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ Команда /%s не поддерживается", preset.Command),
+		})
+		return
+	}
+
+	message := *update.Message
+	message.Text = commandText
+	handler(ctx, b, &botmodels.Update{ID: update.ID, Message: &message})
+}
+
+func (h *CommandHandlers) presetHandler(command string) bot.HandlerFunc {
+	switch command {
+	case "register":
+		return h.HandleRegister
+	case "warming":
+		return h.HandleWarming
+	case "export":
+		return h.HandleExport
+	case "accounts":
+		return h.HandleAccounts
+	case "stats":
+		return h.HandleStats
+	}
+	return nil
+}
+
+// canRunPreset checks the role the preset's command requires and tells the user when it's missing
+func (h *CommandHandlers) canRunPreset(ctx context.Context, b *bot.Bot, chatID, userID int64, command string) bool {
+	role, ok := models.PresetCommandRoles[command]
+	if !ok {
+		role = models.RoleAdmin
+	}
+
+	hasAccess, err := h.authService.CheckAccess(ctx, userID, role)
+	if err == nil && hasAccess {
+		return true
+	}
+
+	text := "🚫 Доступ запрещен. Обратитесь к администратору."
+	if err != nil {
+		text = "❌ Произошла ошибка при проверке доступа."
+	}
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+	return false
+}
+
+// parsePresetArgs maps "[args] [key=value]" to the command's parameters. Positional arguments
+// fill the parameters in the order the command expects them.
+func parsePresetArgs(command string, args []string) (map[string]string, error) {
+	names, ok := models.PresetCommands[command]
+	if !ok {
+		supported := make([]string, 0, len(models.PresetCommands))
+		for name := range models.PresetCommands {
+			supported = append(supported, name)
+		}
+		sort.Strings(supported)
+		return nil, fmt.Errorf("команду /%s нельзя сохранить, доступны: %s", command, strings.Join(supported, ", "))
+	}
+
+	params := make(map[string]string)
+	position := 0
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			if position >= len(names) {
+				return nil, fmt.Errorf("лишний аргумент: %s", arg)
+			}
+			key, value = names[position], arg
+			position++
+		}
+		key = strings.ToLower(key)
+		if key == "" || value == "" {
+			return nil, fmt.Errorf("параметры задаются как key=value: %s", arg)
+		}
+		if _, exists := params[key]; exists {
+			return nil, fmt.Errorf("параметр %s указан дважды", key)
+		}
+		params[key] = value
+	}
+
+	return params, nil
+}
+
+const scenarioUsage = `Использование:
+/scenario new [name] - создать сценарий прогрева
+/scenario edit [scenario_id] - изменить расписание сценария
+
+Платформа, длительность и интенсивность этапов выбираются кнопками, перед сохранением сценарий проверяется warming-service`
+
+// HandleScenario starts the guided dialog that creates or edits a custom warming scenario
+func (h *CommandHandlers) HandleScenario(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	args := strings.Fields(update.Message.Text)
+
+	if len(args) < 3 {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   scenarioUsage,
+		})
+		return
+	}
+
+	var (
+		draft *models.ScenarioDraft
+		err   error
+	)
+	switch strings.ToLower(args[1]) {
+	case "new":
+		draft, err = h.scenarios.StartNew(ctx, chatID, userID, strings.Join(args[2:], " "))
+	case "edit":
+		draft, err = h.scenarios.StartEdit(ctx, chatID, userID, args[2])
+	default:
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   scenarioUsage,
+		})
+		return
+	}
+	if err != nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ Не удалось начать редактирование сценария: %v", err),
+		})
+		return
+	}
+
+	text := fmt.Sprintf("🗓 Сценарий %s\n\nВыберите платформу:", draft.Name)
+	keyboard := utils.ScenarioPlatformKeyboard()
+	if draft.Platform != "" {
+		// The platform of an existing scenario can't change
+		text = fmt.Sprintf("🗓 Сценарий %s (%s)\n\nВыберите длительность прогрева:", draft.Name, strings.ToUpper(draft.Platform))
+		keyboard = utils.ScenarioDurationKeyboard()
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+}
+
+const interventionsPageSize = 5
+
+// HandleInterventions shows the pending manual interventions of all platforms with their actions
+func (h *CommandHandlers) HandleInterventions(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	chatID := update.Message.Chat.ID
+	args := strings.Fields(update.Message.Text)
+
+	page := 1
+	if len(args) > 1 {
+		if p, err := strconv.Atoi(args[1]); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	text, keyboard, err := renderInterventions(ctx, h.interventions, page)
+	if err != nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ Ошибка получения очереди: %v", err),
+		})
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+}
+
+// renderInterventions builds a page of the queue, a page past the end shows the last one
+func renderInterventions(ctx context.Context, interventions service.InterventionService, page int) (string, *botmodels.InlineKeyboardMarkup, error) {
+	items, total, err := interventions.ListPending(ctx, page, interventionsPageSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	totalPages := int((total + interventionsPageSize - 1) / interventionsPageSize)
+	if totalPages > 0 && page > totalPages {
+		page = totalPages
+		if items, total, err = interventions.ListPending(ctx, page, interventionsPageSize); err != nil {
+			return "", nil, err
+		}
+	}
+
+	text := utils.FormatInterventions(items, total, page, interventionsPageSize)
+	return text, utils.InterventionsKeyboard(items, page, interventionsPageSize, totalPages), nil
+}
+
+const usersAuditLimit = 20
+
+const usersUsage = `Использование:
+/users [admin|operator|viewer] - Список пользователей
+/users add <telegram_id> [viewer|operator|admin] - Выдать доступ
+/users role <telegram_id> <viewer|operator|admin> - Сменить роль
+/users whitelist <telegram_id> [on|off] - Белый список (без on/off — переключить)
+/users revoke <telegram_id> - Отозвать доступ
+/users audit [telegram_id] - Журнал изменений`
+
+// HandleUsers lets admins manage bot users instead of editing the users collection by hand
+func (h *CommandHandlers) HandleUsers(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	chatID := update.Message.Chat.ID
+	actorID := update.Message.From.ID
+	args := strings.Fields(update.Message.Text)
+
+	reply := func(text string) {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+	}
+
+	action := "list"
+	if len(args) >= 2 {
+		action = strings.ToLower(args[1])
+	}
+
+	// /users <role> filters the list
+	if models.IsValidRole(action) {
+		args = append([]string{args[0], "list"}, args[1:]...)
+		action = "list"
+	}
+
+	switch action {
+	case "list":
+		filter := map[string]interface{}{}
+		if len(args) >= 3 {
+			filter["role"] = strings.ToLower(args[2])
+		}
+		users, err := h.authService.ListUsers(ctx, filter)
+		if err != nil {
+			reply(fmt.Sprintf("❌ Ошибка получения пользователей: %v", err))
+			return
+		}
+		reply(utils.FormatBotUsers(users))
+		return
+
+	case "audit":
+		var targetID int64
+		if len(args) >= 3 {
+			id, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				reply(usersUsage)
+				return
+			}
+			targetID = id
+		}
+		entries, err := h.authService.ListAudit(ctx, targetID, usersAuditLimit)
+		if err != nil {
+			reply(fmt.Sprintf("❌ Ошибка получения журнала: %v", err))
+			return
+		}
+		reply(utils.FormatUserAudit(entries))
+		return
+	}
+
+	if len(args) < 3 {
+		reply(usersUsage)
+		return
+	}
+	targetID, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil || targetID <= 0 {
+		reply("❌ Неверный Telegram ID")
+		return
+	}
+
+	var user *models.TelegramBotUser
+	switch action {
+	case "add":
+		role := models.RoleViewer
+		if len(args) >= 4 {
+			role = strings.ToLower(args[3])
+		}
+		user, err = h.authService.GrantAccess(ctx, actorID, targetID, role)
+
+	case "role":
+		if len(args) < 4 {
+			reply(usersUsage)
+			return
+		}
+		user, err = h.authService.ChangeRole(ctx, actorID, targetID, strings.ToLower(args[3]))
+
+	case "whitelist":
+		var whitelisted bool
+		switch {
+		case len(args) < 4:
+			current, getErr := h.authService.GetUser(ctx, targetID)
+			if getErr != nil {
+				err = getErr
+				break
+			}
+			whitelisted = !current.Whitelist
+		case strings.EqualFold(args[3], "on"):
+			whitelisted = true
+		case strings.EqualFold(args[3], "off"):
+			whitelisted = false
+		default:
+			reply(usersUsage)
+			return
+		}
+		if err == nil {
+			user, err = h.authService.SetWhitelist(ctx, actorID, targetID, whitelisted)
+		}
+
+	case "revoke":
+		user, err = h.authService.RevokeAccess(ctx, actorID, targetID)
+
+	default:
+		reply(usersUsage)
+		return
+	}
+
+	if err != nil {
+		reply(usersErrorText(err))
+		return
+	}
+
+	whitelist := "да"
+	if !user.Whitelist {
+		whitelist = "нет"
+	}
+	active := "активен"
+	if !user.IsActive {
+		active = "доступ отозван"
+	}
+	reply(fmt.Sprintf("✅ Пользователь %d: %s, белый список: %s, %s",
+		user.TelegramID, utils.RoleName(user.Role), whitelist, active))
+}
+
+func usersErrorText(err error) string {
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		return "❌ Пользователь не найден. Выдайте доступ через /users add"
+	case errors.Is(err, models.ErrInvalidRole):
+		return "❌ Неизвестная роль, допустимы: viewer, operator, admin"
+	case errors.Is(err, models.ErrSelfModification):
+		return "❌ Нельзя менять собственный доступ"
+	case errors.Is(err, models.ErrLastAdmin):
+		return "❌ Должен остаться хотя бы один активный администратор"
+	default:
+		return fmt.Sprintf("❌ Ошибка: %v", err)
+	}
+}
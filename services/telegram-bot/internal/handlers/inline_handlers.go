@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	botmodels "github.com/go-telegram/bot/models"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/telegram-bot/internal/config"
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	"github.com/grigta/conveer/services/telegram-bot/internal/service"
+	"github.com/grigta/conveer/services/telegram-bot/internal/utils"
+)
+
+// maxInlineResults is the most results Telegram accepts in one answer
+const maxInlineResults = 50
+
+type InlineHandlers struct {
+	authService service.AuthService
+	search      service.AccountSearchService
+	config      config.InlineConfig
+}
+
+func NewInlineHandlers(authService service.AuthService, search service.AccountSearchService, cfg config.InlineConfig) *InlineHandlers {
+	if cfg.MaxResults <= 0 || cfg.MaxResults > maxInlineResults {
+		cfg.MaxResults = maxInlineResults
+	}
+	return &InlineHandlers{
+		authService: authService,
+		search:      search,
+		config:      cfg,
+	}
+}
+
+// IsInlineQuery matches updates of inline mode
+func IsInlineQuery(update *botmodels.Update) bool {
+	return update.InlineQuery != nil
+}
+
+// HandleInlineQuery answers "@bot find vk +7900..." with the matching accounts. Users without
+// bot access get no results; contact details are shown in full from the operator role up.
+func (h *InlineHandlers) HandleInlineQuery(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	inline := update.InlineQuery
+	if inline.From == nil {
+		return
+	}
+
+	hasAccess, err := h.authService.CheckAccess(ctx, inline.From.ID, models.RoleViewer)
+	if err != nil {
+		logger.Errorf("Error checking inline access for user %d: %v", inline.From.ID, err)
+	}
+	if err != nil || !hasAccess {
+		h.answer(ctx, b, inline.ID, nil, "")
+		return
+	}
+
+	user, err := h.authService.GetUser(ctx, inline.From.ID)
+	if err != nil || user == nil {
+		h.answer(ctx, b, inline.ID, nil, "")
+		return
+	}
+
+	query, err := models.ParseAccountQuery(inline.Query)
+	if err != nil {
+		h.answer(ctx, b, inline.ID, nil, "")
+		return
+	}
+
+	matches, err := h.search.Search(ctx, query)
+	if err != nil {
+		logger.Errorf("Inline account search failed for user %d: %v", inline.From.ID, err)
+		h.answer(ctx, b, inline.ID, nil, "")
+		return
+	}
+
+	// Telegram asks for the next page with the offset of the previous answer
+	offset, _ := strconv.Atoi(inline.Offset)
+	if offset < 0 || offset > len(matches) {
+		offset = len(matches)
+	}
+	end := offset + h.config.MaxResults
+	nextOffset := ""
+	if end < len(matches) {
+		nextOffset = strconv.Itoa(end)
+	} else {
+		end = len(matches)
+	}
+
+	showSensitive := user.HasPermission(models.RoleOperator)
+	results := make([]botmodels.InlineQueryResult, 0, end-offset)
+	for _, match := range matches[offset:end] {
+		results = append(results, &botmodels.InlineQueryResultArticle{
+			ID:          match.Platform + ":" + match.ID,
+			Title:       utils.AccountMatchTitle(match, showSensitive),
+			Description: utils.AccountMatchDescription(match),
+			InputMessageContent: &botmodels.InputTextMessageContent{
+				MessageText: utils.FormatAccountMatch(match, showSensitive),
+			},
+		})
+	}
+
+	h.answer(ctx, b, inline.ID, results, nextOffset)
+}
+
+// answer replies to the inline query. Results depend on the role, so Telegram must not share
+// them between users.
+func (h *InlineHandlers) answer(ctx context.Context, b *bot.Bot, queryID string, results []botmodels.InlineQueryResult, nextOffset string) {
+	if results == nil {
+		results = []botmodels.InlineQueryResult{}
+	}
+
+	if _, err := b.AnswerInlineQuery(ctx, &bot.AnswerInlineQueryParams{
+		InlineQueryID: queryID,
+		Results:       results,
+		CacheTime:     int(h.config.CacheTime.Seconds()),
+		IsPersonal:    true,
+		NextOffset:    nextOffset,
+	}); err != nil {
+		logger.Errorf("Failed to answer inline query: %v", err)
+	}
+}
@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"log"
 
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/telegram-bot/internal/models"
 	"github.com/grigta/conveer/services/telegram-bot/internal/service"
 	"github.com/go-telegram/bot"
@@ -26,9 +26,9 @@ func AuthMiddleware(authService service.AuthService, requiredRole string) bot.Mi
 			if update.Message != nil && update.Message.From != nil {
 				telegramID = update.Message.From.ID
 				chatID = update.Message.Chat.ID
-			} else if update.CallbackQuery != nil && update.CallbackQuery.From != nil {
+			} else if update.CallbackQuery != nil && update.CallbackQuery.Message.Message != nil {
 				telegramID = update.CallbackQuery.From.ID
-				chatID = update.CallbackQuery.Message.Chat.ID
+				chatID = update.CallbackQuery.Message.Message.Chat.ID
 			} else {
 				// Can't identify user
 				return
@@ -37,8 +37,8 @@ func AuthMiddleware(authService service.AuthService, requiredRole string) bot.Mi
 			// Check access
 			hasAccess, err := authService.CheckAccess(ctx, telegramID, requiredRole)
 			if err != nil {
-				log.Printf("Error checking access for user %d: %v", telegramID, err)
-				b.SendMessage(ctx, &botmodels.SendMessageParams{
+				logger.Errorf("Error checking access for user %d: %v", telegramID, err)
+				b.SendMessage(ctx, &bot.SendMessageParams{
 					ChatID: chatID,
 					Text:   "❌ Произошла ошибка при проверке доступа.",
 				})
@@ -46,7 +46,7 @@ func AuthMiddleware(authService service.AuthService, requiredRole string) bot.Mi
 			}
 
 			if !hasAccess {
-				b.SendMessage(ctx, &botmodels.SendMessageParams{
+				b.SendMessage(ctx, &bot.SendMessageParams{
 					ChatID: chatID,
 					Text:   "🚫 Доступ запрещен. Обратитесь к администратору.",
 				})
@@ -71,11 +71,11 @@ func LoggingMiddleware() bot.Middleware {
 			// Log incoming update
 			if update.Message != nil {
 				if update.Message.Text != "" {
-					log.Printf("[TelegramBot] User %d executed command: %s",
+					logger.Infof("[TelegramBot] User %d executed command: %s",
 						update.Message.From.ID, update.Message.Text)
 				}
 			} else if update.CallbackQuery != nil {
-				log.Printf("[TelegramBot] User %d executed callback: %s",
+				logger.Infof("[TelegramBot] User %d executed callback: %s",
 					update.CallbackQuery.From.ID, update.CallbackQuery.Data)
 			}
 
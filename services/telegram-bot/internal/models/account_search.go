@@ -0,0 +1,95 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MinAccountQueryLength is the shortest search term inline lookup answers, shorter ones match too much
+const MinAccountQueryLength = 3
+
+// Errors
+var (
+	ErrAccountQueryTooShort = errors.New("search term is too short")
+)
+
+// AccountQuery is a parsed inline lookup: "find vk +7900...", "vk ivanov" or just "+7900..."
+type AccountQuery struct {
+	Platform string // empty searches every platform
+	Term     string
+}
+
+// ParseAccountQuery parses the text of an inline query. The leading "find" and the platform are optional.
+func ParseAccountQuery(text string) (AccountQuery, error) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) > 0 && (fields[0] == "find" || fields[0] == "найти") {
+		fields = fields[1:]
+	}
+
+	var query AccountQuery
+	if len(fields) > 0 && IsInterventionPlatform(fields[0]) {
+		query.Platform = fields[0]
+		fields = fields[1:]
+	}
+
+	query.Term = strings.Join(fields, " ")
+	if len([]rune(query.Term)) < MinAccountQueryLength {
+		return query, ErrAccountQueryTooShort
+	}
+	return query, nil
+}
+
+// AccountMatch is an account found by inline lookup
+type AccountMatch struct {
+	Platform     string
+	ID           string
+	Phone        string
+	Email        string
+	Username     string
+	Name         string
+	UserID       string
+	Status       string
+	Quality      string
+	ProxyID      string
+	ErrorMessage string
+	CreatedAt    time.Time
+}
+
+// Matches reports whether the account matches the query term. Phones match by digits,
+// so "+7 900 123" finds "79001234567"; other fields match case-insensitively by substring.
+func (m *AccountMatch) Matches(term string) bool {
+	if digits, ok := phoneTerm(term); ok {
+		return strings.Contains(onlyDigits(m.Phone), digits) || m.UserID == digits || m.ID == term
+	}
+
+	term = strings.ToLower(strings.TrimPrefix(term, "@"))
+	for _, field := range []string{m.ID, m.Email, m.Username, m.Name} {
+		if field != "" && strings.Contains(strings.ToLower(field), term) {
+			return true
+		}
+	}
+	return false
+}
+
+// phoneTerm returns the digits of a term written as a phone number, e.g. "+7 (900) 123-45"
+func phoneTerm(term string) (string, bool) {
+	digits := strings.TrimPrefix(strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, term), "+")
+	return digits, digits != "" && onlyDigits(digits) == digits
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, value)
+}
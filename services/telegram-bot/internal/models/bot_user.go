@@ -34,14 +34,40 @@ var (
 	ErrInvalidRole       = errors.New("invalid role")
 	ErrUserNotFound      = errors.New("user not found")
 	ErrAccessDenied      = errors.New("access denied")
+	ErrSelfModification  = errors.New("admins cannot change their own access")
+	ErrLastAdmin         = errors.New("at least one active admin must remain")
 )
 
+// User audit actions
+const (
+	UserAuditGrant     = "grant"
+	UserAuditRole      = "role"
+	UserAuditWhitelist = "whitelist"
+	UserAuditRevoke    = "revoke"
+)
+
+// UserAuditEntry records a change of a bot user's access made by an admin
+type UserAuditEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ActorID   int64              `bson:"actor_id" json:"actor_id"`
+	TargetID  int64              `bson:"target_id" json:"target_id"`
+	Action    string             `bson:"action" json:"action"`
+	OldValue  string             `bson:"old_value,omitempty" json:"old_value,omitempty"`
+	NewValue  string             `bson:"new_value,omitempty" json:"new_value,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// IsValidRole reports whether role is one of the bot roles
+func IsValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleOperator || role == RoleViewer
+}
+
 // Validate validates TelegramBotUser fields
 func (u *TelegramBotUser) Validate() error {
 	if u.TelegramID == 0 {
 		return ErrInvalidTelegramID
 	}
-	if u.Role != RoleAdmin && u.Role != RoleOperator && u.Role != RoleViewer {
+	if !IsValidRole(u.Role) {
 		return ErrInvalidRole
 	}
 	return nil
@@ -20,6 +20,9 @@ type Event struct {
 	Status      string                 `json:"status,omitempty"`
 	Message     string                 `json:"message,omitempty"`
 	Error       string                 `json:"error,omitempty"`
+	Reason      string                 `json:"reason,omitempty"`
+	Step        string                 `json:"step,omitempty"`
+	Screenshot  string                 `json:"screenshot_url,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	Priority    string                 `json:"priority,omitempty"` // critical, warning, info
 	Timestamp   time.Time              `json:"timestamp"`
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Digest frequencies
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// Digest sections
+const (
+	DigestSectionAccounts = "accounts"
+	DigestSectionBans     = "bans"
+	DigestSectionSpend    = "spend"
+	DigestSectionWarming  = "warming"
+	DigestSectionErrors   = "errors"
+)
+
+// DigestSections lists all sections in the order they appear in a digest
+var DigestSections = []string{
+	DigestSectionAccounts,
+	DigestSectionBans,
+	DigestSectionSpend,
+	DigestSectionWarming,
+	DigestSectionErrors,
+}
+
+// Errors
+var (
+	ErrInvalidDigestFrequency = errors.New("invalid digest frequency")
+	ErrInvalidDigestTime      = errors.New("invalid digest time, expected HH:MM")
+	ErrInvalidDigestSection   = errors.New("invalid digest section")
+	ErrDigestNotFound         = errors.New("digest subscription not found")
+)
+
+// DigestSubscription describes a summary digest delivered to a chat on a schedule
+type DigestSubscription struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ChatID     int64              `bson:"chat_id" json:"chat_id"`
+	Frequency  string             `bson:"frequency" json:"frequency"`
+	SendAt     string             `bson:"send_at" json:"send_at"`
+	Weekday    time.Weekday       `bson:"weekday" json:"weekday"`
+	Timezone   string             `bson:"timezone" json:"timezone"`
+	Sections   []string           `bson:"sections" json:"sections"`
+	CreatedBy  int64              `bson:"created_by" json:"created_by"`
+	LastSentAt *time.Time         `bson:"last_sent_at,omitempty" json:"last_sent_at,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Validate validates DigestSubscription fields
+func (d *DigestSubscription) Validate() error {
+	if d.ChatID == 0 {
+		return ErrInvalidTelegramID
+	}
+	if d.Frequency != DigestDaily && d.Frequency != DigestWeekly {
+		return ErrInvalidDigestFrequency
+	}
+	if _, err := time.Parse("15:04", d.SendAt); err != nil {
+		return ErrInvalidDigestTime
+	}
+	if _, err := time.LoadLocation(d.Timezone); err != nil {
+		return err
+	}
+	for _, section := range d.Sections {
+		if !IsDigestSection(section) {
+			return ErrInvalidDigestSection
+		}
+	}
+	return nil
+}
+
+// HasSection reports whether the section is included in the digest. An empty list means all sections.
+func (d *DigestSubscription) HasSection(section string) bool {
+	if len(d.Sections) == 0 {
+		return true
+	}
+	for _, s := range d.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduledAt returns the most recent moment at or before now when the digest was due
+func (d *DigestSubscription) ScheduledAt(now time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sendAt, err := time.Parse("15:04", d.SendAt)
+	if err != nil {
+		return time.Time{}, ErrInvalidDigestTime
+	}
+
+	local := now.In(loc)
+	due := time.Date(local.Year(), local.Month(), local.Day(), sendAt.Hour(), sendAt.Minute(), 0, 0, loc)
+
+	if d.Frequency == DigestWeekly {
+		due = due.AddDate(0, 0, -int((local.Weekday()-d.Weekday+7)%7))
+	}
+	if due.After(local) {
+		if d.Frequency == DigestWeekly {
+			due = due.AddDate(0, 0, -7)
+		} else {
+			due = due.AddDate(0, 0, -1)
+		}
+	}
+
+	return due, nil
+}
+
+// Period returns how far back the digest looks
+func (d *DigestSubscription) Period() time.Duration {
+	if d.Frequency == DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// IsDigestSection checks that the section name is known
+func IsDigestSection(section string) bool {
+	for _, s := range DigestSections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
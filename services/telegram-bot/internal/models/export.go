@@ -27,6 +27,7 @@ type Account struct {
 	LocalStorage string                 `json:"local_storage,omitempty"`
 	ProxyID      string                 `json:"proxy_id,omitempty"`
 	Status       string                 `json:"status"`
+	Quality      string                 `json:"quality,omitempty"` // "high" when a recovery email is bound
 	CreatedAt    time.Time             `json:"created_at"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 }
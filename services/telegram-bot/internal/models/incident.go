@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Incident triggers
+const (
+	IncidentTriggerAuto   = "auto"
+	IncidentTriggerManual = "manual"
+)
+
+// IncidentEvent is a short record of an event folded into an incident summary
+type IncidentEvent struct {
+	Type      string    `json:"type"`
+	Priority  string    `json:"priority"`
+	Platform  string    `json:"platform,omitempty"`
+	AccountID string    `json:"account_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Incident represents an active alert storm during which notifications are aggregated
+type Incident struct {
+	Trigger     string          `json:"trigger"`
+	Reason      string          `json:"reason"`
+	StartedBy   int64           `json:"started_by,omitempty"`
+	StartedAt   time.Time       `json:"started_at"`
+	LastEventAt time.Time       `json:"last_event_at"`
+	ResolvedAt  *time.Time      `json:"resolved_at,omitempty"`
+	EventCount  int             `json:"event_count"`
+	ByPriority  map[string]int  `json:"by_priority"`
+	ByType      map[string]int  `json:"by_type"`
+	LastEvents  []IncidentEvent `json:"last_events"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Intervention statuses
+const (
+	InterventionPending   = "pending"
+	InterventionResolved  = "resolved"  // registration re-queued
+	InterventionAbandoned = "abandoned" // account marked as error
+)
+
+// InterventionPlatforms lists the platforms whose interventions can be resolved from the bot
+var InterventionPlatforms = []string{"vk", "telegram", "mail", "max"}
+
+// Errors
+var (
+	ErrInterventionNotFound = errors.New("intervention not found")
+	ErrInterventionClosed   = errors.New("intervention already closed")
+)
+
+// Intervention is a manual_intervention request of a platform service waiting for an operator.
+// Repeated requests for the same account fold into one pending item.
+type Intervention struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Platform      string             `bson:"platform" json:"platform"`
+	AccountID     string             `bson:"account_id" json:"account_id"`
+	Reason        string             `bson:"reason" json:"reason"`
+	Step          string             `bson:"step,omitempty" json:"step,omitempty"`
+	Error         string             `bson:"error,omitempty" json:"error,omitempty"`
+	ScreenshotURL string             `bson:"screenshot_url,omitempty" json:"screenshot_url,omitempty"`
+	Status        string             `bson:"status" json:"status"`
+	Occurrences   int                `bson:"occurrences" json:"occurrences"`
+	FirstSeenAt   time.Time          `bson:"first_seen_at" json:"first_seen_at"`
+	LastSeenAt    time.Time          `bson:"last_seen_at" json:"last_seen_at"`
+	ClosedBy      int64              `bson:"closed_by,omitempty" json:"closed_by,omitempty"`
+	ClosedAt      *time.Time         `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+}
+
+// IsInterventionPlatform reports whether interventions of platform can be resolved from the bot
+func IsInterventionPlatform(platform string) bool {
+	for _, p := range InterventionPlatforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
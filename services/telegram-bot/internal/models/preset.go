@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PresetCommands lists the commands a preset can run and the order of their arguments
+var PresetCommands = map[string][]string{
+	"register": {"platform", "count"},
+	"warming":  {"action", "account_id", "platform", "scenario", "days"},
+	"export":   {"platform", "format"},
+	"accounts": {"platform", "page"},
+	"stats":    {"platform"},
+}
+
+// PresetCommandRoles holds the role required to run each preset command
+var PresetCommandRoles = map[string]string{
+	"register": RoleOperator,
+	"warming":  RoleOperator,
+	"export":   RoleOperator,
+	"accounts": RoleViewer,
+	"stats":    RoleViewer,
+}
+
+var presetNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// Errors
+var (
+	ErrInvalidPresetName    = errors.New("invalid preset name, use up to 32 latin letters, digits, - and _")
+	ErrInvalidPresetCommand = errors.New("unsupported preset command")
+	ErrPresetNotFound       = errors.New("preset not found")
+)
+
+// CommandPreset is a named command with saved arguments, owned by a user within a chat
+type CommandPreset struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ChatID    int64              `bson:"chat_id" json:"chat_id"`
+	UserID    int64              `bson:"user_id" json:"user_id"`
+	Name      string             `bson:"name" json:"name"`
+	Command   string             `bson:"command" json:"command"`
+	Params    map[string]string  `bson:"params" json:"params"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Validate validates CommandPreset fields
+func (p *CommandPreset) Validate() error {
+	if p.ChatID == 0 || p.UserID == 0 {
+		return ErrInvalidTelegramID
+	}
+	if !presetNamePattern.MatchString(p.Name) {
+		return ErrInvalidPresetName
+	}
+	if _, ok := PresetCommands[p.Command]; !ok {
+		return ErrInvalidPresetCommand
+	}
+	_, err := p.Args(nil)
+	return err
+}
+
+// Args returns the command arguments in order with the overrides applied. Trailing arguments
+// may be left out, the command then asks for them itself.
+func (p *CommandPreset) Args(overrides map[string]string) ([]string, error) {
+	names, ok := PresetCommands[p.Command]
+	if !ok {
+		return nil, ErrInvalidPresetCommand
+	}
+
+	params := make(map[string]string, len(p.Params)+len(overrides))
+	for key, value := range p.Params {
+		params[key] = value
+	}
+	for key, value := range overrides {
+		params[key] = value
+	}
+
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+	for key := range params {
+		if !known[key] {
+			return nil, fmt.Errorf("unknown parameter %s for /%s, expected: %s", key, p.Command, strings.Join(names, ", "))
+		}
+	}
+
+	args := make([]string, 0, len(names))
+	for i, name := range names {
+		value, ok := params[name]
+		if !ok {
+			// Arguments are positional, a gap would shift the ones after it
+			for _, later := range names[i+1:] {
+				if _, ok := params[later]; ok {
+					return nil, fmt.Errorf("parameter %s is required when %s is set", name, later)
+				}
+			}
+			break
+		}
+		args = append(args, value)
+	}
+
+	return args, nil
+}
+
+// CommandText renders the preset as the bot command it stands for
+func (p *CommandPreset) CommandText(overrides map[string]string) (string, error) {
+	args, err := p.Args(overrides)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace("/" + p.Command + " " + strings.Join(args, " ")), nil
+}
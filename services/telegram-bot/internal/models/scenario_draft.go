@@ -0,0 +1,66 @@
+package models
+
+import "errors"
+
+var ErrScenarioDraftNotFound = errors.New("scenario draft not found")
+
+// Scenario intensities offered per stage in the scenario editor
+const (
+	IntensityLow    = "low"
+	IntensityMedium = "medium"
+	IntensityHigh   = "high"
+)
+
+// ScenarioStages are the warming-service schedule stages in the order a task goes through them
+var ScenarioStages = []string{"days_1_7", "days_8_14", "days_15_30", "days_31_60"}
+
+// ScenarioDurations are the warming durations offered in the scenario editor
+var ScenarioDurations = []int{14, 30, 60}
+
+// IntensityActions holds the [min, max] actions per day of every stage for an intensity.
+// The first week stays low: fresh accounts that start at full speed get flagged.
+var IntensityActions = map[string][][2]int{
+	IntensityLow:    {{2, 4}, {4, 7}, {6, 10}, {8, 12}},
+	IntensityMedium: {{3, 6}, {6, 10}, {10, 15}, {12, 20}},
+	IntensityHigh:   {{5, 10}, {10, 15}, {15, 25}, {20, 30}},
+}
+
+// ScenarioActionMix is the default weighted action set per platform used by editor scenarios
+var ScenarioActionMix = map[string]map[string]int{
+	"vk":       {"view_feed": 4, "view_profile": 3, "like_post": 3, "subscribe_group": 1, "comment_post": 1},
+	"telegram": {"read_channel": 4, "react_message": 2, "join_group": 1, "send_message": 1},
+	"mail":     {"read_email": 4, "move_email": 1, "create_folder": 1, "send_email": 1},
+	"max":      {"read_messages": 4, "send_message": 2, "update_status": 1},
+}
+
+// ScenarioDraft is a custom warming scenario being assembled in the bot's guided dialog.
+// It lives in Redis per chat and user until it is saved or abandoned.
+type ScenarioDraft struct {
+	ChatID       int64    `json:"chat_id"`
+	UserID       int64    `json:"user_id"`
+	ScenarioID   string   `json:"scenario_id,omitempty"` // set when editing an existing scenario
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Platform     string   `json:"platform,omitempty"`
+	DurationDays int      `json:"duration_days,omitempty"`
+	Intensities  []string `json:"intensities,omitempty"` // chosen intensity per stage, in stage order
+}
+
+// StageCount returns how many schedule stages a task of the draft's duration goes through
+func (d *ScenarioDraft) StageCount() int {
+	switch {
+	case d.DurationDays <= 0:
+		return 0
+	case d.DurationDays <= 14:
+		return 2
+	case d.DurationDays <= 30:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// Complete reports whether an intensity was chosen for every stage
+func (d *ScenarioDraft) Complete() bool {
+	return d.Platform != "" && d.StageCount() > 0 && len(d.Intensities) == d.StageCount()
+}
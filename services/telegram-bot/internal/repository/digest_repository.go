@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DigestRepository interface {
+	Upsert(ctx context.Context, digest *models.DigestSubscription) error
+	ListByChat(ctx context.Context, chatID int64) ([]*models.DigestSubscription, error)
+	ListAll(ctx context.Context) ([]*models.DigestSubscription, error)
+	Delete(ctx context.Context, chatID int64, frequency string) error
+	MarkSent(ctx context.Context, id primitive.ObjectID, sentAt time.Time) error
+	CreateIndexes(ctx context.Context) error
+}
+
+type digestRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDigestRepository(db *mongo.Database) DigestRepository {
+	return &digestRepository{
+		collection: db.Collection("telegram_bot_digests"),
+	}
+}
+
+// Upsert stores the subscription, replacing the chat's existing one with the same frequency
+func (r *digestRepository) Upsert(ctx context.Context, digest *models.DigestSubscription) error {
+	if err := digest.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	digest.UpdatedAt = now
+
+	filter := bson.M{"chat_id": digest.ChatID, "frequency": digest.Frequency}
+	update := bson.M{
+		"$set": bson.M{
+			"send_at":    digest.SendAt,
+			"weekday":    digest.Weekday,
+			"timezone":   digest.Timezone,
+			"sections":   digest.Sections,
+			"created_by": digest.CreatedBy,
+			"updated_at": digest.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": now,
+		},
+		// A new schedule starts fresh, otherwise a digest sent under the old one could suppress it
+		"$unset": bson.M{"last_sent_at": ""},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save digest subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *digestRepository) ListByChat(ctx context.Context, chatID int64) ([]*models.DigestSubscription, error) {
+	return r.find(ctx, bson.M{"chat_id": chatID})
+}
+
+func (r *digestRepository) ListAll(ctx context.Context) ([]*models.DigestSubscription, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *digestRepository) find(ctx context.Context, filter bson.M) ([]*models.DigestSubscription, error) {
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest subscriptions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var digests []*models.DigestSubscription
+	if err := cursor.All(ctx, &digests); err != nil {
+		return nil, fmt.Errorf("failed to decode digest subscriptions: %w", err)
+	}
+
+	return digests, nil
+}
+
+// Delete removes the chat's subscription with the given frequency, or all of them when frequency is empty
+func (r *digestRepository) Delete(ctx context.Context, chatID int64, frequency string) error {
+	filter := bson.M{"chat_id": chatID}
+	if frequency != "" {
+		filter["frequency"] = frequency
+	}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete digest subscription: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return models.ErrDigestNotFound
+	}
+	return nil
+}
+
+func (r *digestRepository) MarkSent(ctx context.Context, id primitive.ObjectID, sentAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"last_sent_at": sentAt}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark digest as sent: %w", err)
+	}
+	return nil
+}
+
+func (r *digestRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "chat_id", Value: 1}, {Key: "frequency", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+	return nil
+}
@@ -145,6 +145,7 @@ func (r *exportRepository) convertVKAccount(pbAccount *vkpb.Account) (*models.Ac
 		UserID:   pbAccount.UserId,
 		Status:   pbAccount.Status,
 		ProxyID:  pbAccount.ProxyId,
+		Quality:  pbAccount.Quality,
 	}
 
 	// Decrypt phone
@@ -177,6 +178,9 @@ func (r *exportRepository) convertVKAccount(pbAccount *vkpb.Account) (*models.Ac
 	for key, value := range pbAccount.Fingerprint {
 		account.Metadata[key] = value
 	}
+	if pbAccount.EmailBoundAt != nil {
+		account.Metadata["email_bound_at"] = pbAccount.EmailBoundAt.AsTime()
+	}
 
 	return account, nil
 }
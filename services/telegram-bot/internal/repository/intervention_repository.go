@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type InterventionRepository interface {
+	Record(ctx context.Context, intervention *models.Intervention) error
+	Get(ctx context.Context, id primitive.ObjectID) (*models.Intervention, error)
+	ListPending(ctx context.Context, offset, limit int64) ([]*models.Intervention, int64, error)
+	Close(ctx context.Context, id primitive.ObjectID, status string, closedBy int64) (*models.Intervention, error)
+	Reopen(ctx context.Context, id primitive.ObjectID) error
+	CreateIndexes(ctx context.Context) error
+}
+
+type interventionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewInterventionRepository(db *mongo.Database) InterventionRepository {
+	return &interventionRepository{
+		collection: db.Collection("telegram_bot_interventions"),
+	}
+}
+
+// Record adds a pending intervention or folds the request into the account's pending one
+func (r *interventionRepository) Record(ctx context.Context, intervention *models.Intervention) error {
+	now := time.Now()
+
+	filter := bson.M{
+		"platform":   intervention.Platform,
+		"account_id": intervention.AccountID,
+		"status":     models.InterventionPending,
+	}
+	set := bson.M{
+		"reason":       intervention.Reason,
+		"last_seen_at": now,
+	}
+	if intervention.Step != "" {
+		set["step"] = intervention.Step
+	}
+	if intervention.Error != "" {
+		set["error"] = intervention.Error
+	}
+	if intervention.ScreenshotURL != "" {
+		set["screenshot_url"] = intervention.ScreenshotURL
+	}
+	update := bson.M{
+		"$set": set,
+		"$inc": bson.M{"occurrences": 1},
+		"$setOnInsert": bson.M{
+			"first_seen_at": now,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to record intervention: %w", err)
+	}
+	return nil
+}
+
+func (r *interventionRepository) Get(ctx context.Context, id primitive.ObjectID) (*models.Intervention, error) {
+	var intervention models.Intervention
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&intervention)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, models.ErrInterventionNotFound
+		}
+		return nil, fmt.Errorf("failed to get intervention: %w", err)
+	}
+	return &intervention, nil
+}
+
+// ListPending returns pending interventions, oldest first, and their total count
+func (r *interventionRepository) ListPending(ctx context.Context, offset, limit int64) ([]*models.Intervention, int64, error) {
+	filter := bson.M{"status": models.InterventionPending}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count interventions: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "first_seen_at", Value: 1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list interventions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var interventions []*models.Intervention
+	if err := cursor.All(ctx, &interventions); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode interventions: %w", err)
+	}
+
+	return interventions, total, nil
+}
+
+// Close moves a pending intervention to status, so two operators can't act on the same item
+func (r *interventionRepository) Close(ctx context.Context, id primitive.ObjectID, status string, closedBy int64) (*models.Intervention, error) {
+	now := time.Now()
+	filter := bson.M{"_id": id, "status": models.InterventionPending}
+	update := bson.M{
+		"$set": bson.M{
+			"status":    status,
+			"closed_by": closedBy,
+			"closed_at": now,
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var intervention models.Intervention
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&intervention)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			if _, getErr := r.Get(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, models.ErrInterventionClosed
+		}
+		return nil, fmt.Errorf("failed to close intervention: %w", err)
+	}
+	return &intervention, nil
+}
+
+// Reopen returns an intervention to the queue after its platform action failed
+func (r *interventionRepository) Reopen(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$set":   bson.M{"status": models.InterventionPending},
+		"$unset": bson.M{"closed_by": "", "closed_at": ""},
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to reopen intervention: %w", err)
+	}
+	return nil
+}
+
+func (r *interventionRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "first_seen_at", Value: 1}},
+		},
+		{
+			// One pending item per account
+			Keys:    bson.D{{Key: "platform", Value: 1}, {Key: "account_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"status": models.InterventionPending}),
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PresetRepository interface {
+	Upsert(ctx context.Context, preset *models.CommandPreset) error
+	Get(ctx context.Context, chatID, userID int64, name string) (*models.CommandPreset, error)
+	List(ctx context.Context, chatID, userID int64) ([]*models.CommandPreset, error)
+	Delete(ctx context.Context, chatID, userID int64, name string) error
+	CreateIndexes(ctx context.Context) error
+}
+
+type presetRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPresetRepository(db *mongo.Database) PresetRepository {
+	return &presetRepository{
+		collection: db.Collection("telegram_bot_presets"),
+	}
+}
+
+// Upsert stores the preset, replacing the user's existing one with the same name in the chat
+func (r *presetRepository) Upsert(ctx context.Context, preset *models.CommandPreset) error {
+	if err := preset.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	preset.UpdatedAt = now
+
+	filter := bson.M{"chat_id": preset.ChatID, "user_id": preset.UserID, "name": preset.Name}
+	update := bson.M{
+		"$set": bson.M{
+			"command":    preset.Command,
+			"params":     preset.Params,
+			"updated_at": preset.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": now,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save preset: %w", err)
+	}
+	return nil
+}
+
+func (r *presetRepository) Get(ctx context.Context, chatID, userID int64, name string) (*models.CommandPreset, error) {
+	var preset models.CommandPreset
+	err := r.collection.FindOne(ctx, bson.M{"chat_id": chatID, "user_id": userID, "name": name}).Decode(&preset)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, models.ErrPresetNotFound
+		}
+		return nil, fmt.Errorf("failed to get preset: %w", err)
+	}
+	return &preset, nil
+}
+
+func (r *presetRepository) List(ctx context.Context, chatID, userID int64) ([]*models.CommandPreset, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"chat_id": chatID, "user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list presets: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var presets []*models.CommandPreset
+	if err := cursor.All(ctx, &presets); err != nil {
+		return nil, fmt.Errorf("failed to decode presets: %w", err)
+	}
+
+	return presets, nil
+}
+
+func (r *presetRepository) Delete(ctx context.Context, chatID, userID int64, name string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"chat_id": chatID, "user_id": userID, "name": name})
+	if err != nil {
+		return fmt.Errorf("failed to delete preset: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return models.ErrPresetNotFound
+	}
+	return nil
+}
+
+func (r *presetRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "chat_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+	return nil
+}
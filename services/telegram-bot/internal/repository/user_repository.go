@@ -18,16 +18,20 @@ type UserRepository interface {
 	Update(ctx context.Context, telegramID int64, updates map[string]interface{}) error
 	List(ctx context.Context, filter map[string]interface{}) ([]*models.TelegramBotUser, error)
 	Delete(ctx context.Context, telegramID int64) error
+	AddAuditEntry(ctx context.Context, entry *models.UserAuditEntry) error
+	ListAuditEntries(ctx context.Context, targetID int64, limit int64) ([]*models.UserAuditEntry, error)
 	CreateIndexes(ctx context.Context) error
 }
 
 type userRepository struct {
 	collection *mongo.Collection
+	audit      *mongo.Collection
 }
 
 func NewUserRepository(db *mongo.Database) UserRepository {
 	return &userRepository{
 		collection: db.Collection("telegram_bot_users"),
+		audit:      db.Collection("telegram_bot_user_audit"),
 	}
 }
 
@@ -113,6 +117,45 @@ func (r *userRepository) Delete(ctx context.Context, telegramID int64) error {
 	return nil
 }
 
+func (r *userRepository) AddAuditEntry(ctx context.Context, entry *models.UserAuditEntry) error {
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if _, err := r.audit.InsertOne(ctx, entry); err != nil {
+		return fmt.Errorf("failed to add audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries returns the latest entries, of all users when targetID is 0
+func (r *userRepository) ListAuditEntries(ctx context.Context, targetID int64, limit int64) ([]*models.UserAuditEntry, error) {
+	filter := bson.M{}
+	if targetID != 0 {
+		filter["target_id"] = targetID
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(limit)
+
+	cursor, err := r.audit.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*models.UserAuditEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode audit entries: %w", err)
+	}
+
+	return entries, nil
+}
+
 func (r *userRepository) CreateIndexes(ctx context.Context) error {
 	indexes := []mongo.IndexModel{
 		{
@@ -134,5 +177,12 @@ func (r *userRepository) CreateIndexes(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create indexes: %w", err)
 	}
+
+	_, err = r.audit.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create audit indexes: %w", err)
+	}
 	return nil
 }
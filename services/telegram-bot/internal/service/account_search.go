@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
+	maxpb "github.com/grigta/conveer/services/max-service/proto"
+	"github.com/grigta/conveer/services/telegram-bot/internal/config"
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	telegrampb "github.com/grigta/conveer/services/telegram-service/proto"
+	vkpb "github.com/grigta/conveer/services/vk-service/proto"
+)
+
+// AccountSearchService finds accounts of the platform services for inline lookup
+type AccountSearchService interface {
+	Search(ctx context.Context, query models.AccountQuery) ([]*models.AccountMatch, error)
+}
+
+type accountLister func(ctx context.Context, limit int) ([]*models.AccountMatch, error)
+
+// accountSearchService reads up to ScanLimit accounts of each platform and matches them here,
+// the platform services have no search by phone or name
+type accountSearchService struct {
+	clients *GRPCClients
+	config  config.InlineConfig
+}
+
+func NewAccountSearchService(clients *GRPCClients, cfg config.InlineConfig) AccountSearchService {
+	return &accountSearchService{clients: clients, config: cfg}
+}
+
+// Search returns the accounts matching the query, newest first. Platforms that fail or are not
+// configured are skipped unless none answers.
+func (s *accountSearchService) Search(ctx context.Context, query models.AccountQuery) ([]*models.AccountMatch, error) {
+	listers := s.listers()
+
+	platforms := models.InterventionPlatforms
+	if query.Platform != "" {
+		platforms = []string{query.Platform}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		matches []*models.AccountMatch
+		lastErr error
+		failed  int
+	)
+	for _, platform := range platforms {
+		list, ok := listers[platform]
+		if !ok {
+			failed++
+			lastErr = fmt.Errorf("%s service not configured", platform)
+			continue
+		}
+
+		wg.Add(1)
+		go func(platform string, list accountLister) {
+			defer wg.Done()
+
+			accounts, err := list(ctx, s.config.ScanLimit)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				lastErr = fmt.Errorf("failed to list %s accounts: %w", platform, err)
+				return
+			}
+			for _, account := range accounts {
+				if account.Matches(query.Term) {
+					matches = append(matches, account)
+				}
+			}
+		}(platform, list)
+	}
+	wg.Wait()
+
+	if failed == len(platforms) {
+		return nil, lastErr
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.After(matches[j].CreatedAt)
+	})
+	return matches, nil
+}
+
+// listers returns the account listing of every configured platform service
+func (s *accountSearchService) listers() map[string]accountLister {
+	listers := make(map[string]accountLister)
+	if s.clients == nil {
+		return listers
+	}
+
+	if client := s.clients.VKServiceClient; client != nil {
+		listers["vk"] = func(ctx context.Context, limit int) ([]*models.AccountMatch, error) {
+			resp, err := client.ListAccounts(ctx, &vkpb.ListAccountsRequest{Limit: int32(limit)})
+			if err != nil {
+				return nil, err
+			}
+			accounts := make([]*models.AccountMatch, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, &models.AccountMatch{
+					Platform:     "vk",
+					ID:           a.Id,
+					Phone:        a.Phone,
+					Email:        a.Email,
+					Username:     a.Username,
+					Name:         fullName(a.FirstName, a.LastName),
+					UserID:       a.UserId,
+					Status:       a.Status,
+					Quality:      a.Quality,
+					ProxyID:      a.ProxyId,
+					ErrorMessage: a.ErrorMessage,
+					CreatedAt:    a.CreatedAt.AsTime(),
+				})
+			}
+			return accounts, nil
+		}
+	}
+
+	if client := s.clients.TelegramServiceClient; client != nil {
+		listers["telegram"] = func(ctx context.Context, limit int) ([]*models.AccountMatch, error) {
+			resp, err := client.ListAccounts(ctx, &telegrampb.ListAccountsRequest{Limit: int32(limit)})
+			if err != nil {
+				return nil, err
+			}
+			accounts := make([]*models.AccountMatch, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, &models.AccountMatch{
+					Platform:     "telegram",
+					ID:           a.Id,
+					Phone:        a.Phone,
+					Username:     a.Username,
+					Name:         fullName(a.FirstName, a.LastName),
+					UserID:       a.UserId,
+					Status:       a.Status,
+					ProxyID:      a.ProxyId,
+					ErrorMessage: a.ErrorMessage,
+					CreatedAt:    a.CreatedAt.AsTime(),
+				})
+			}
+			return accounts, nil
+		}
+	}
+
+	if client := s.clients.MailServiceClient; client != nil {
+		listers["mail"] = func(ctx context.Context, limit int) ([]*models.AccountMatch, error) {
+			resp, err := client.ListAccounts(ctx, &mailpb.ListAccountsRequest{Limit: int32(limit)})
+			if err != nil {
+				return nil, err
+			}
+			accounts := make([]*models.AccountMatch, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, &models.AccountMatch{
+					Platform:     "mail",
+					ID:           a.Id,
+					Phone:        a.Phone,
+					Email:        a.Email,
+					Name:         fullName(a.FirstName, a.LastName),
+					Status:       a.Status,
+					ErrorMessage: a.ErrorMessage,
+					CreatedAt:    time.Unix(a.CreatedAt, 0),
+				})
+			}
+			return accounts, nil
+		}
+	}
+
+	if client := s.clients.MaxServiceClient; client != nil {
+		listers["max"] = func(ctx context.Context, limit int) ([]*models.AccountMatch, error) {
+			resp, err := client.ListAccounts(ctx, &maxpb.ListAccountsRequest{Limit: int32(limit)})
+			if err != nil {
+				return nil, err
+			}
+			accounts := make([]*models.AccountMatch, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, &models.AccountMatch{
+					Platform:     "max",
+					ID:           a.Id,
+					Phone:        a.Phone,
+					Username:     a.Username,
+					Name:         fullName(a.FirstName, a.LastName),
+					UserID:       a.VkUserId,
+					Status:       a.Status,
+					ErrorMessage: a.ErrorMessage,
+					CreatedAt:    time.Unix(a.CreatedAt, 0),
+				})
+			}
+			return accounts, nil
+		}
+	}
+
+	return listers
+}
+
+func fullName(firstName, lastName string) string {
+	switch {
+	case firstName == "":
+		return lastName
+	case lastName == "":
+		return firstName
+	default:
+		return firstName + " " + lastName
+	}
+}
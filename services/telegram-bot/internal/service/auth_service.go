@@ -15,6 +15,13 @@ type AuthService interface {
 	UpdateUser(ctx context.Context, telegramID int64, updates map[string]interface{}) error
 	ListUsers(ctx context.Context, filter map[string]interface{}) ([]*models.TelegramBotUser, error)
 	DeleteUser(ctx context.Context, telegramID int64) error
+
+	// Admin management of bot access, every change is recorded in the user audit log
+	GrantAccess(ctx context.Context, actorID, telegramID int64, role string) (*models.TelegramBotUser, error)
+	ChangeRole(ctx context.Context, actorID, telegramID int64, role string) (*models.TelegramBotUser, error)
+	SetWhitelist(ctx context.Context, actorID, telegramID int64, whitelisted bool) (*models.TelegramBotUser, error)
+	RevokeAccess(ctx context.Context, actorID, telegramID int64) (*models.TelegramBotUser, error)
+	ListAudit(ctx context.Context, telegramID int64, limit int64) ([]*models.UserAuditEntry, error)
 }
 
 type authService struct {
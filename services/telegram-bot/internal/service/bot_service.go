@@ -11,10 +11,11 @@ import (
 
 type BotService interface {
 	Start(ctx context.Context) error
-	SendMessage(ctx context.Context, chatID int64, text string, opts ...botmodels.SendMessageParams) error
+	SendMessage(ctx context.Context, chatID int64, text string, opts ...bot.SendMessageParams) error
+	SendMessageWithID(ctx context.Context, chatID int64, text string) (int, error)
 	SendDocument(ctx context.Context, chatID int64, document []byte, filename string) error
 	SendAlert(ctx context.Context, userID int64, message string) error
-	EditMessage(ctx context.Context, chatID int64, messageID int, text string, opts ...botmodels.EditMessageTextParams) error
+	EditMessage(ctx context.Context, chatID int64, messageID int, text string, opts ...bot.EditMessageTextParams) error
 	GetBot() *bot.Bot
 }
 
@@ -44,8 +45,8 @@ func (s *botService) Start(ctx context.Context) error {
 	return nil
 }
 
-func (s *botService) SendMessage(ctx context.Context, chatID int64, text string, opts ...botmodels.SendMessageParams) error {
-	params := &botmodels.SendMessageParams{
+func (s *botService) SendMessage(ctx context.Context, chatID int64, text string, opts ...bot.SendMessageParams) error {
+	params := &bot.SendMessageParams{
 		ChatID:    chatID,
 		Text:      text,
 		ParseMode: botmodels.ParseModeMarkdown,
@@ -68,12 +69,25 @@ func (s *botService) SendMessage(ctx context.Context, chatID int64, text string,
 	return nil
 }
 
+func (s *botService) SendMessageWithID(ctx context.Context, chatID int64, text string) (int, error) {
+	msg, err := s.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: botmodels.ParseModeMarkdown,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return msg.ID, nil
+}
+
 func (s *botService) SendDocument(ctx context.Context, chatID int64, document []byte, filename string) error {
-	params := &botmodels.SendDocumentParams{
+	params := &bot.SendDocumentParams{
 		ChatID: chatID,
 		Document: &botmodels.InputFileUpload{
 			Filename: filename,
-			Data:     bot.FileReader(bytes.NewReader(document)),
+			Data:     bytes.NewReader(document),
 		},
 	}
 
@@ -89,8 +103,8 @@ func (s *botService) SendAlert(ctx context.Context, userID int64, message string
 	return s.SendMessage(ctx, userID, message)
 }
 
-func (s *botService) EditMessage(ctx context.Context, chatID int64, messageID int, text string, opts ...botmodels.EditMessageTextParams) error {
-	params := &botmodels.EditMessageTextParams{
+func (s *botService) EditMessage(ctx context.Context, chatID int64, messageID int, text string, opts ...bot.EditMessageTextParams) error {
+	params := &bot.EditMessageTextParams{
 		ChatID:    chatID,
 		MessageID: messageID,
 		Text:      text,
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	analyticspb "github.com/grigta/conveer/services/analytics-service/proto"
+	"github.com/grigta/conveer/services/telegram-bot/internal/config"
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	"github.com/grigta/conveer/services/telegram-bot/internal/repository"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const digestTopErrors = 5
+
+// DigestScheduler sends daily and weekly summary digests to subscribed chats
+type DigestScheduler interface {
+	Start(ctx context.Context)
+	Subscribe(ctx context.Context, digest *models.DigestSubscription) error
+	Unsubscribe(ctx context.Context, chatID int64, frequency string) error
+	List(ctx context.Context, chatID int64) ([]*models.DigestSubscription, error)
+	Compose(ctx context.Context, digest *models.DigestSubscription) (string, error)
+	DefaultTimezone() string
+}
+
+type digestScheduler struct {
+	cfg             config.DigestConfig
+	repo            repository.DigestRepository
+	analyticsClient analyticspb.AnalyticsServiceClient
+	botService      BotService
+}
+
+func NewDigestScheduler(cfg config.DigestConfig, repo repository.DigestRepository, grpcClients *GRPCClients, botService BotService) DigestScheduler {
+	var analyticsClient analyticspb.AnalyticsServiceClient
+	if grpcClients != nil {
+		analyticsClient = grpcClients.AnalyticsServiceClient
+	}
+
+	return &digestScheduler{
+		cfg:             cfg,
+		repo:            repo,
+		analyticsClient: analyticsClient,
+		botService:      botService,
+	}
+}
+
+func (s *digestScheduler) Start(ctx context.Context) {
+	interval := s.cfg.CheckInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.sendDue(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *digestScheduler) Subscribe(ctx context.Context, digest *models.DigestSubscription) error {
+	if digest.Timezone == "" {
+		digest.Timezone = s.DefaultTimezone()
+	}
+	return s.repo.Upsert(ctx, digest)
+}
+
+func (s *digestScheduler) Unsubscribe(ctx context.Context, chatID int64, frequency string) error {
+	return s.repo.Delete(ctx, chatID, frequency)
+}
+
+func (s *digestScheduler) List(ctx context.Context, chatID int64) ([]*models.DigestSubscription, error) {
+	return s.repo.ListByChat(ctx, chatID)
+}
+
+func (s *digestScheduler) DefaultTimezone() string {
+	if s.cfg.DefaultTimezone == "" {
+		return "UTC"
+	}
+	return s.cfg.DefaultTimezone
+}
+
+// sendDue delivers every digest whose scheduled time has passed since it was last sent.
+// Subscriptions that were only just created or changed wait for their next slot.
+func (s *digestScheduler) sendDue(ctx context.Context) {
+	digests, err := s.repo.ListAll(ctx)
+	if err != nil {
+		logger.Errorf("Failed to load digest subscriptions: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, digest := range digests {
+		due, err := digest.ScheduledAt(now)
+		if err != nil {
+			logger.Warnf("Skipping digest %s for chat %d: %v", digest.Frequency, digest.ChatID, err)
+			continue
+		}
+
+		reference := digest.UpdatedAt
+		if digest.LastSentAt != nil {
+			reference = *digest.LastSentAt
+		}
+		if !due.After(reference) {
+			continue
+		}
+
+		text, err := s.Compose(ctx, digest)
+		if err != nil {
+			logger.Errorf("Failed to compose %s digest for chat %d: %v", digest.Frequency, digest.ChatID, err)
+			continue
+		}
+
+		if err := s.botService.SendMessage(ctx, digest.ChatID, text); err != nil {
+			logger.Errorf("Failed to send %s digest to %d: %v", digest.Frequency, digest.ChatID, err)
+			continue
+		}
+
+		if err := s.repo.MarkSent(ctx, digest.ID, now); err != nil {
+			logger.Errorf("Failed to mark digest for chat %d as sent: %v", digest.ChatID, err)
+		}
+	}
+}
+
+// Compose builds the digest text from analytics-service data
+func (s *digestScheduler) Compose(ctx context.Context, digest *models.DigestSubscription) (string, error) {
+	if s.analyticsClient == nil {
+		return "", fmt.Errorf("analytics service not available")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	since := now.Add(-digest.Period())
+
+	data, err := s.analyticsClient.GetOverallAnalytics(ctx, &analyticspb.AnalyticsRequest{
+		StartDate: timestamppb.New(since),
+		EndDate:   timestamppb.New(now),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get analytics: %w", err)
+	}
+
+	loc, err := time.LoadLocation(digest.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	var builder strings.Builder
+	if digest.Frequency == models.DigestWeekly {
+		builder.WriteString("🗓 *Недельная сводка*\n")
+	} else {
+		builder.WriteString("📰 *Ежедневная сводка*\n")
+	}
+	builder.WriteString(fmt.Sprintf("_%s — %s_\n\n",
+		since.In(loc).Format("02.01 15:04"), now.In(loc).Format("02.01 15:04")))
+
+	// The daily digest opens with analytics' summary of the previous day; the digest
+	// is still sent without it when the summary is unavailable
+	if digest.Frequency == models.DigestDaily {
+		summary, err := s.analyticsClient.GetDailySummary(ctx, &analyticspb.DailySummaryRequest{})
+		if err != nil {
+			logger.Errorf("Failed to get daily summary for chat %d: %v", digest.ChatID, err)
+		} else if summary.Text != "" {
+			builder.WriteString("📝 " + markdownCleaner.Replace(summary.Text) + "\n\n")
+		}
+	}
+
+	if digest.HasSection(models.DigestSectionAccounts) {
+		created := trendDelta(data.Trends, since, func(t *analyticspb.TrendData) int64 { return t.AccountsCreated })
+		if digest.Frequency == models.DigestDaily && data.Performance != nil {
+			created = data.Performance.AccountsCreatedToday
+		}
+		builder.WriteString(fmt.Sprintf("👥 *Создано аккаунтов:* %d\n", created))
+		if data.Performance != nil && digest.Frequency == models.DigestDaily {
+			builder.WriteString(fmt.Sprintf("├─ Готово к работе: %d\n", data.Performance.AccountsReadyToday))
+		}
+		builder.WriteString(fmt.Sprintf("└─ Всего: %d\n", data.TotalAccounts))
+	}
+
+	if digest.HasSection(models.DigestSectionBans) {
+		banned := trendDelta(data.Trends, since, func(t *analyticspb.TrendData) int64 { return t.AccountsBanned })
+		builder.WriteString(fmt.Sprintf("🚫 *Баны:* %d (ban rate %.1f%%)\n", banned, data.OverallBanRate))
+	}
+
+	if digest.HasSection(models.DigestSectionSpend) && data.Expenses != nil {
+		spent := data.Expenses.TotalSpentToday
+		if digest.Frequency == models.DigestWeekly {
+			spent = data.Expenses.TotalSpentWeek
+		}
+		builder.WriteString(fmt.Sprintf("💰 *Расходы:* %.2f ₽\n", spent))
+		if data.Expenses.AvgCostPerAccount > 0 {
+			builder.WriteString(fmt.Sprintf("└─ Средняя цена аккаунта: %.2f ₽\n", data.Expenses.AvgCostPerAccount))
+		}
+	}
+
+	if digest.HasSection(models.DigestSectionWarming) && data.Resources != nil {
+		builder.WriteString(fmt.Sprintf("🔥 *Активных задач прогрева:* %d\n", data.Resources.WarmingTasksActive))
+	}
+
+	if digest.HasSection(models.DigestSectionErrors) && data.Performance != nil {
+		builder.WriteString(fmt.Sprintf("\n⚠️ *Топ ошибок* (error rate %.1f%%):\n", data.Performance.ErrorRate))
+
+		topErrors := make([]*analyticspb.ErrorStat, len(data.Performance.TopErrors))
+		copy(topErrors, data.Performance.TopErrors)
+		sort.Slice(topErrors, func(i, j int) bool { return topErrors[i].Count > topErrors[j].Count })
+		if len(topErrors) > digestTopErrors {
+			topErrors = topErrors[:digestTopErrors]
+		}
+
+		if len(topErrors) == 0 {
+			builder.WriteString("Ошибок нет ✅\n")
+		}
+		for i, e := range topErrors {
+			builder.WriteString(fmt.Sprintf("%d. %s — %d\n", i+1, markdownCleaner.Replace(e.Type), e.Count))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// trendDelta returns how much a cumulative trend value grew since the given moment.
+// When no snapshot is that old the earliest one is used as the baseline.
+func trendDelta(trends []*analyticspb.TrendData, since time.Time, value func(*analyticspb.TrendData) int64) int64 {
+	if len(trends) == 0 {
+		return 0
+	}
+
+	sorted := make([]*analyticspb.TrendData, len(trends))
+	copy(sorted, trends)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.AsTime().Before(sorted[j].Date.AsTime())
+	})
+
+	base := sorted[0]
+	for _, t := range sorted {
+		if t.Date.AsTime().After(since) {
+			break
+		}
+		base = t
+	}
+
+	delta := value(sorted[len(sorted)-1]) - value(base)
+	if delta < 0 {
+		return 0
+	}
+	return delta
+}
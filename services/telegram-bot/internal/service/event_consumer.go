@@ -4,11 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
+	sharedmodels "github.com/grigta/conveer/pkg/models"
 )
 
 type EventConsumer interface {
@@ -20,13 +21,19 @@ type eventConsumer struct {
 	rabbitmq   *messaging.RabbitMQ
 	botService BotService
 	authService AuthService
+	preferences PreferencesClient
+	incidents  IncidentManager
+	interventions InterventionService
 }
 
-func NewEventConsumer(rabbitmq *messaging.RabbitMQ, botService BotService, authService AuthService) EventConsumer {
+func NewEventConsumer(rabbitmq *messaging.RabbitMQ, botService BotService, authService AuthService, preferences PreferencesClient, incidents IncidentManager, interventions InterventionService) EventConsumer {
 	return &eventConsumer{
 		rabbitmq:   rabbitmq,
 		botService: botService,
 		authService: authService,
+		preferences: preferences,
+		incidents:  incidents,
+		interventions: interventions,
 	}
 }
 
@@ -63,6 +70,7 @@ func (c *eventConsumer) setupTopology() error {
 		"proxy.rotation.failed",
 		"analytics.alert.*",
 		"analytics.manual_intervention",
+		"auth.security.*",
 	}
 
 	for _, key := range routingKeys {
@@ -82,7 +90,7 @@ func (c *eventConsumer) setupTopology() error {
 		// Bind our queue to each platform's events
 		for _, key := range routingKeys {
 			if err := c.rabbitmq.BindQueue("bot.alerts", key, exchange); err != nil {
-				log.Printf("Warning: failed to bind to %s exchange with key %s: %v", exchange, key, err)
+				logger.Warnf("Failed to bind to %s exchange with key %s: %v", exchange, key, err)
 			}
 		}
 	}
@@ -94,53 +102,92 @@ func (c *eventConsumer) consumeEvents(ctx context.Context) {
 	handler := func(message []byte) error {
 		var event models.Event
 		if err := json.Unmarshal(message, &event); err != nil {
-			log.Printf("Failed to unmarshal event: %v", err)
+			logger.Errorf("Failed to unmarshal event: %v", err)
 			return nil // Don't requeue malformed messages
 		}
 
-		// Determine priority
-		priority := c.determinePriority(event.Type)
-		event.Priority = priority
-
-		// Format alert message
-		alertMessage := c.formatAlert(&event)
-
-		// Get admin users
-		admins, err := c.authService.ListUsers(ctx, map[string]interface{}{
-			"role": models.RoleAdmin,
-			"is_active": true,
-		})
-		if err != nil {
-			log.Printf("Failed to get admin users: %v", err)
-			return nil
+		c.handleEvent(ctx, &event)
+		return nil
+	}
+
+	if err := c.rabbitmq.ConsumeWithHandler(ctx, "bot.alerts", "telegram-bot-alerts", handler); err != nil {
+		logger.Errorf("Error consuming events: %v", err)
+	}
+}
+
+func (c *eventConsumer) handleEvent(ctx context.Context, event *models.Event) {
+	if c.interventions != nil && strings.Contains(event.Type, "manual_intervention") {
+		if err := c.interventions.Record(ctx, event); err != nil {
+			logger.Errorf("Failed to record intervention: %v", err)
 		}
+	}
 
-		// Send alert to all admins
-		for _, admin := range admins {
-			if err := c.botService.SendAlert(ctx, admin.TelegramID, alertMessage); err != nil {
-				log.Printf("Failed to send alert to admin %d: %v", admin.TelegramID, err)
-			}
+	// Determine priority
+	event.Priority = c.determinePriority(event.Type)
+
+	// Format alert message
+	alertMessage := c.formatAlert(event)
+
+	recipients, err := c.resolveRecipients(ctx, event)
+	if err != nil {
+		logger.Errorf("Failed to resolve alert recipients: %v", err)
+		return
+	}
+
+	// Incident manager sends directly or aggregates during alert storms
+	c.incidents.Deliver(ctx, event, recipients, alertMessage)
+}
+
+// resolveRecipients picks chats from notification preferences, limited to active bot users
+func (c *eventConsumer) resolveRecipients(ctx context.Context, event *models.Event) ([]int64, error) {
+	// Security alerts concern a single account and go to its owner only
+	if strings.HasPrefix(event.Type, "auth.security.") {
+		telegramID, ok := event.Metadata["telegram_id"].(float64)
+		if !ok || telegramID == 0 {
+			return nil, nil
 		}
+		return []int64{int64(telegramID)}, nil
+	}
 
-		// For critical alerts, also send to operators
-		if priority == "critical" {
-			operators, err := c.authService.ListUsers(ctx, map[string]interface{}{
-				"role": models.RoleOperator,
-				"is_active": true,
-			})
-			if err == nil {
-				for _, operator := range operators {
-					c.botService.SendAlert(ctx, operator.TelegramID, alertMessage)
+	users, err := c.authService.ListUsers(ctx, map[string]interface{}{
+		"is_active": true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot users: %w", err)
+	}
+
+	if c.preferences != nil {
+		category := sharedmodels.CategoryAlert
+		if strings.Contains(event.Type, "manual_intervention") {
+			category = sharedmodels.CategoryIntervention
+		}
+
+		ids, err := c.preferences.TelegramRecipients(ctx, category, event.Priority, event.Platform)
+		if err == nil {
+			allowed := make(map[int64]bool, len(users))
+			for _, user := range users {
+				allowed[user.TelegramID] = true
+			}
+
+			recipients := make([]int64, 0, len(ids))
+			for _, id := range ids {
+				if allowed[id] {
+					recipients = append(recipients, id)
 				}
 			}
+			return recipients, nil
 		}
-
-		return nil
+		logger.Warnf("Failed to get notification preferences, falling back to role routing: %v", err)
 	}
 
-	if err := c.rabbitmq.ConsumeWithHandler(ctx, "bot.alerts", "telegram-bot-alerts", handler); err != nil {
-		log.Printf("Error consuming events: %v", err)
+	// Without preferences: admins get everything, operators only critical alerts
+	recipients := make([]int64, 0, len(users))
+	for _, user := range users {
+		if user.Role == models.RoleAdmin || (user.Role == models.RoleOperator && event.Priority == "critical") {
+			recipients = append(recipients, user.TelegramID)
+		}
 	}
+	return recipients, nil
 }
 
 func (c *eventConsumer) determinePriority(eventType string) string {
@@ -158,6 +205,10 @@ func (c *eventConsumer) determinePriority(eventType string) string {
 		}
 	}
 
+	if strings.HasPrefix(eventType, "auth.security.") {
+		return "critical"
+	}
+
 	if strings.Contains(eventType, "banned") ||
 	   strings.Contains(eventType, "failed") ||
 	   strings.Contains(eventType, "balance.low") {
@@ -201,6 +252,10 @@ func (c *eventConsumer) formatAlert(event *models.Event) string {
 		message += fmt.Sprintf("Message: %s\n", event.Message)
 	}
 
+	if event.Reason != "" {
+		message += fmt.Sprintf("Reason: %s\n", event.Reason)
+	}
+
 	if event.Error != "" {
 		message += fmt.Sprintf("Error: %s\n", event.Error)
 	}
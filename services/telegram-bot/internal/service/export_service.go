@@ -7,6 +7,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/grigta/conveer/services/telegram-bot/internal/models"
@@ -38,6 +39,11 @@ func (s *exportService) ExportAccounts(ctx context.Context, platform string, acc
 		return nil, "", fmt.Errorf("no accounts found for export")
 	}
 
+	// Accounts with a bound recovery email go first, buyers value them more
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return accounts[i].Quality == "high" && accounts[j].Quality != "high"
+	})
+
 	// Generate filename
 	timestamp := time.Now().Format("2006-01-02")
 	filename := fmt.Sprintf("%s_%s_%s", platform, format, timestamp)
@@ -248,7 +254,7 @@ func (s *exportService) exportCSV(accounts []*models.Account) ([]byte, error) {
 	w := csv.NewWriter(buf)
 
 	// Write header
-	header := []string{"ID", "Phone", "Email", "Password", "Username", "UserID", "Status", "ProxyID", "CreatedAt"}
+	header := []string{"ID", "Phone", "Email", "Password", "Username", "UserID", "Status", "Quality", "ProxyID", "CreatedAt"}
 	if err := w.Write(header); err != nil {
 		return nil, fmt.Errorf("failed to write CSV header: %w", err)
 	}
@@ -263,6 +269,7 @@ func (s *exportService) exportCSV(accounts []*models.Account) ([]byte, error) {
 			account.Username,
 			account.UserID,
 			account.Status,
+			account.Quality,
 			account.ProxyID,
 			account.CreatedAt.Format("2006-01-02 15:04:05"),
 		}
@@ -8,12 +8,16 @@ import (
 	"github.com/grigta/conveer/services/telegram-bot/internal/config"
 	vkpb "github.com/grigta/conveer/services/vk-service/proto"
 	telegrampb "github.com/grigta/conveer/services/telegram-service/proto"
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
+	maxpb "github.com/grigta/conveer/services/max-service/proto"
 	analyticspb "github.com/grigta/conveer/services/analytics-service/proto"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
 	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/serviceauth"
 	"github.com/go-redis/redis/v8"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type GRPCClients struct {
@@ -29,7 +33,10 @@ type GRPCClients struct {
 	// Protobuf clients
 	VKServiceClient       vkpb.VKServiceClient
 	TelegramServiceClient telegrampb.TelegramServiceClient
+	MailServiceClient      mailpb.MailServiceClient
+	MaxServiceClient       maxpb.MaxServiceClient
 	AnalyticsServiceClient analyticspb.AnalyticsServiceClient
+	WarmingServiceClient   warmingpb.WarmingServiceClient
 
 	// Encryption
 	Encryptor *crypto.Encryptor
@@ -62,6 +69,9 @@ func InitializeGRPCClients(cfg *config.Config) (*GRPCClients, error) {
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
+	// Calls carry telegram-bot's service token when SERVICE_AUTH_SECRET is set
+	serviceAuth := serviceauth.FromEnv("telegram-bot")
+
 	// Helper function to create a connection with timeout
 	createConn := func(service, url string) (*grpc.ClientConn, error) {
 		if url == "" {
@@ -71,10 +81,12 @@ func InitializeGRPCClients(cfg *config.Config) (*GRPCClients, error) {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		conn, err := grpc.DialContext(ctx, url,
+		opts := append([]grpc.DialOption{
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
 			grpc.WithBlock(),
-		)
+		}, logger.DialOptions()...)
+		opts = append(opts, serviceAuth.DialOptions()...)
+		conn, err := grpc.DialContext(ctx, url, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to %s service at %s: %w", service, url, err)
 		}
@@ -101,16 +113,25 @@ func InitializeGRPCClients(cfg *config.Config) (*GRPCClients, error) {
 	if clients.MailClient, err = createConn("mail", cfg.GRPCServices["mail"]); err != nil {
 		return nil, err
 	}
+	if clients.MailClient != nil {
+		clients.MailServiceClient = mailpb.NewMailServiceClient(clients.MailClient)
+	}
 
 	// Initialize Max client
 	if clients.MaxClient, err = createConn("max", cfg.GRPCServices["max"]); err != nil {
 		return nil, err
 	}
+	if clients.MaxClient != nil {
+		clients.MaxServiceClient = maxpb.NewMaxServiceClient(clients.MaxClient)
+	}
 
 	// Initialize Warming client
 	if clients.WarmingClient, err = createConn("warming", cfg.GRPCServices["warming"]); err != nil {
 		return nil, err
 	}
+	if clients.WarmingClient != nil {
+		clients.WarmingServiceClient = warmingpb.NewWarmingServiceClient(clients.WarmingClient)
+	}
 
 	// Initialize Proxy client
 	if clients.ProxyClient, err = createConn("proxy", cfg.GRPCServices["proxy"]); err != nil {
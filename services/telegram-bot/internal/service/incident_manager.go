@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/telegram-bot/internal/config"
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+)
+
+const (
+	incidentTopTypes   = 10
+	incidentLastEvents = 5
+)
+
+// markdownCleaner drops characters that would break Markdown in free-form text
+var markdownCleaner = strings.NewReplacer("*", "", "_", " ", "`", "", "[", "(", "]", ")")
+
+// IncidentManager batches alerts into one periodically edited summary per chat during alert storms
+type IncidentManager interface {
+	Start(ctx context.Context)
+	Deliver(ctx context.Context, event *models.Event, chatIDs []int64, message string)
+	Activate(reason string, startedBy int64) (*models.Incident, bool)
+	Resolve(ctx context.Context) (*models.Incident, bool)
+	Current() *models.Incident
+}
+
+type incidentChat struct {
+	messageID int
+	dirty     bool
+}
+
+type incidentManager struct {
+	cfg        config.IncidentConfig
+	botService BotService
+
+	mu       sync.Mutex
+	recent   []time.Time
+	incident *models.Incident
+	chats    map[int64]*incidentChat
+}
+
+func NewIncidentManager(cfg config.IncidentConfig, botService BotService) IncidentManager {
+	return &incidentManager{
+		cfg:        cfg,
+		botService: botService,
+		chats:      make(map[int64]*incidentChat),
+	}
+}
+
+func (m *incidentManager) Start(ctx context.Context) {
+	interval := m.cfg.UpdateInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.flush(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Deliver sends the alert directly, or folds it into the incident summary when a storm is in progress
+func (m *incidentManager) Deliver(ctx context.Context, event *models.Event, chatIDs []int64, message string) {
+	now := time.Now()
+
+	m.mu.Lock()
+	m.trackRate(now)
+
+	if m.incident == nil && m.cfg.AutoDetect && m.cfg.StormThreshold > 0 && len(m.recent) >= m.cfg.StormThreshold {
+		reason := fmt.Sprintf("%d событий за %s", len(m.recent), m.cfg.StormWindow)
+		m.start(models.IncidentTriggerAuto, reason, 0, now)
+		logger.Infof("Incident mode activated automatically: %s", reason)
+	}
+
+	if m.incident == nil {
+		m.mu.Unlock()
+		for _, chatID := range chatIDs {
+			if err := m.botService.SendAlert(ctx, chatID, message); err != nil {
+				logger.Errorf("Failed to send alert to %d: %v", chatID, err)
+			}
+		}
+		return
+	}
+
+	m.record(event, now)
+	for _, chatID := range chatIDs {
+		chat, ok := m.chats[chatID]
+		if !ok {
+			chat = &incidentChat{}
+			m.chats[chatID] = chat
+		}
+		chat.dirty = true
+	}
+	m.mu.Unlock()
+}
+
+// Activate starts a manual incident. Returns false if an incident is already active.
+func (m *incidentManager) Activate(reason string, startedBy int64) (*models.Incident, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.incident != nil {
+		return m.snapshot(), false
+	}
+
+	if reason == "" {
+		reason = "включен вручную"
+	}
+	m.start(models.IncidentTriggerManual, reason, startedBy, time.Now())
+	logger.Infof("Incident mode activated by %d: %s", startedBy, reason)
+
+	return m.snapshot(), true
+}
+
+// Resolve ends the current incident and posts the final summary to every chat
+func (m *incidentManager) Resolve(ctx context.Context) (*models.Incident, bool) {
+	m.mu.Lock()
+	if m.incident == nil {
+		m.mu.Unlock()
+		return nil, false
+	}
+
+	resolvedAt := time.Now()
+	m.incident.ResolvedAt = &resolvedAt
+	incident := m.snapshot()
+	text := formatIncidentSummary(incident, resolvedAt)
+
+	chats := m.chats
+	m.incident = nil
+	m.chats = make(map[int64]*incidentChat)
+	m.mu.Unlock()
+
+	for chatID, chat := range chats {
+		m.publish(ctx, chatID, chat.messageID, text)
+	}
+
+	logger.Infof("Incident resolved after %s, %d events aggregated", resolvedAt.Sub(incident.StartedAt).Round(time.Second), incident.EventCount)
+	return incident, true
+}
+
+func (m *incidentManager) Current() *models.Incident {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.incident == nil {
+		return nil
+	}
+	return m.snapshot()
+}
+
+func (m *incidentManager) flush(ctx context.Context) {
+	now := time.Now()
+
+	m.mu.Lock()
+	if m.incident == nil {
+		m.mu.Unlock()
+		return
+	}
+
+	// Manual incidents stay open until an operator turns them off
+	if m.incident.Trigger == models.IncidentTriggerAuto && now.Sub(m.incident.LastEventAt) >= m.cfg.QuietPeriod {
+		m.mu.Unlock()
+		m.Resolve(ctx)
+		return
+	}
+
+	text := formatIncidentSummary(m.snapshot(), now)
+
+	pending := make(map[int64]int)
+	for chatID, chat := range m.chats {
+		if chat.dirty {
+			pending[chatID] = chat.messageID
+			chat.dirty = false
+		}
+	}
+	m.mu.Unlock()
+
+	for chatID, messageID := range pending {
+		newID := m.publish(ctx, chatID, messageID, text)
+
+		m.mu.Lock()
+		if chat, ok := m.chats[chatID]; ok && chat.messageID == 0 {
+			chat.messageID = newID
+		}
+		m.mu.Unlock()
+	}
+}
+
+// publish edits the summary message or sends a new one if there is nothing to edit yet
+func (m *incidentManager) publish(ctx context.Context, chatID int64, messageID int, text string) int {
+	if messageID != 0 {
+		err := m.botService.EditMessage(ctx, chatID, messageID, text)
+		if err == nil {
+			return messageID
+		}
+		logger.Warnf("Failed to edit incident summary for %d, sending new one: %v", chatID, err)
+	}
+
+	newID, err := m.botService.SendMessageWithID(ctx, chatID, text)
+	if err != nil {
+		logger.Errorf("Failed to send incident summary to %d: %v", chatID, err)
+		return 0
+	}
+	return newID
+}
+
+func (m *incidentManager) start(trigger, reason string, startedBy int64, now time.Time) {
+	m.incident = &models.Incident{
+		Trigger:     trigger,
+		Reason:      reason,
+		StartedBy:   startedBy,
+		StartedAt:   now,
+		LastEventAt: now,
+		ByPriority:  make(map[string]int),
+		ByType:      make(map[string]int),
+	}
+}
+
+func (m *incidentManager) record(event *models.Event, now time.Time) {
+	m.incident.EventCount++
+	m.incident.LastEventAt = now
+	m.incident.ByPriority[event.Priority]++
+	m.incident.ByType[event.Type]++
+
+	m.incident.LastEvents = append(m.incident.LastEvents, models.IncidentEvent{
+		Type:      event.Type,
+		Priority:  event.Priority,
+		Platform:  event.Platform,
+		AccountID: event.AccountID,
+		Timestamp: now,
+	})
+	if len(m.incident.LastEvents) > incidentLastEvents {
+		m.incident.LastEvents = m.incident.LastEvents[len(m.incident.LastEvents)-incidentLastEvents:]
+	}
+}
+
+// trackRate keeps timestamps of events inside the storm detection window
+func (m *incidentManager) trackRate(now time.Time) {
+	m.recent = append(m.recent, now)
+
+	cutoff := now.Add(-m.cfg.StormWindow)
+	i := 0
+	for i < len(m.recent) && m.recent[i].Before(cutoff) {
+		i++
+	}
+	m.recent = m.recent[i:]
+}
+
+func (m *incidentManager) snapshot() *models.Incident {
+	incident := *m.incident
+	incident.ByPriority = make(map[string]int, len(m.incident.ByPriority))
+	for k, v := range m.incident.ByPriority {
+		incident.ByPriority[k] = v
+	}
+	incident.ByType = make(map[string]int, len(m.incident.ByType))
+	for k, v := range m.incident.ByType {
+		incident.ByType[k] = v
+	}
+	incident.LastEvents = append([]models.IncidentEvent(nil), m.incident.LastEvents...)
+	return &incident
+}
+
+func formatIncidentSummary(incident *models.Incident, now time.Time) string {
+	var sb strings.Builder
+
+	if incident.ResolvedAt != nil {
+		sb.WriteString("✅ *Инцидент завершен*\n\n")
+	} else {
+		sb.WriteString("🔥 *Режим инцидента*\n\n")
+	}
+
+	trigger := "автоматически"
+	if incident.Trigger == models.IncidentTriggerManual {
+		trigger = "вручную"
+	}
+
+	sb.WriteString(fmt.Sprintf("Начало: %s (%s)\n", incident.StartedAt.Format("15:04:05"), trigger))
+	sb.WriteString(fmt.Sprintf("Длительность: %s\n", now.Sub(incident.StartedAt).Round(time.Second)))
+	sb.WriteString(fmt.Sprintf("Причина: %s\n", markdownCleaner.Replace(incident.Reason)))
+	sb.WriteString(fmt.Sprintf("Событий: %d (🚨 %d, ⚠️ %d, ℹ️ %d)\n",
+		incident.EventCount,
+		incident.ByPriority["critical"],
+		incident.ByPriority["warning"],
+		incident.ByPriority["info"],
+	))
+
+	if len(incident.ByType) > 0 {
+		types := make([]string, 0, len(incident.ByType))
+		for t := range incident.ByType {
+			types = append(types, t)
+		}
+		sort.Slice(types, func(i, j int) bool {
+			if incident.ByType[types[i]] != incident.ByType[types[j]] {
+				return incident.ByType[types[i]] > incident.ByType[types[j]]
+			}
+			return types[i] < types[j]
+		})
+
+		sb.WriteString("\n*По типам:*\n")
+		for i, t := range types {
+			if i == incidentTopTypes {
+				sb.WriteString(fmt.Sprintf("• ... и еще %d\n", len(types)-incidentTopTypes))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("• `%s` — %d\n", t, incident.ByType[t]))
+		}
+	}
+
+	if len(incident.LastEvents) > 0 && incident.ResolvedAt == nil {
+		sb.WriteString("\n*Последние:*\n")
+		for i := len(incident.LastEvents) - 1; i >= 0; i-- {
+			event := incident.LastEvents[i]
+			line := fmt.Sprintf("• %s `%s`", event.Timestamp.Format("15:04:05"), event.Type)
+			if event.Platform != "" {
+				line += " " + event.Platform
+			}
+			if event.AccountID != "" {
+				line += fmt.Sprintf(" `%s`", event.AccountID)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n_Обновлено: %s_", now.Format("15:04:05")))
+
+	return sb.String()
+}
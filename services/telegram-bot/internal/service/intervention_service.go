@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
+	maxpb "github.com/grigta/conveer/services/max-service/proto"
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	"github.com/grigta/conveer/services/telegram-bot/internal/repository"
+	telegrampb "github.com/grigta/conveer/services/telegram-service/proto"
+	vkpb "github.com/grigta/conveer/services/vk-service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const abandonedStatus = "error"
+
+// InterventionService keeps the queue of manual_intervention requests of the platform services
+// and resolves them through the platform's gRPC API
+type InterventionService interface {
+	Record(ctx context.Context, event *models.Event) error
+	Get(ctx context.Context, id string) (*models.Intervention, error)
+	ListPending(ctx context.Context, page, pageSize int) ([]*models.Intervention, int64, error)
+	Resolve(ctx context.Context, id string, userID int64) (*models.Intervention, error)
+	Abandon(ctx context.Context, id string, userID int64) (*models.Intervention, error)
+}
+
+type interventionService struct {
+	repo    repository.InterventionRepository
+	clients *GRPCClients
+}
+
+func NewInterventionService(repo repository.InterventionRepository, clients *GRPCClients) InterventionService {
+	return &interventionService{repo: repo, clients: clients}
+}
+
+// Record queues the intervention an event asks for. Events without an account, e.g. paused
+// warming tasks, are only alerted.
+func (s *interventionService) Record(ctx context.Context, event *models.Event) error {
+	platform := event.Platform
+	if platform == "" {
+		platform = strings.SplitN(event.Type, ".", 2)[0]
+	}
+	if event.AccountID == "" || !models.IsInterventionPlatform(platform) {
+		return nil
+	}
+
+	reason := event.Reason
+	if reason == "" {
+		reason = event.Message
+	}
+
+	return s.repo.Record(ctx, &models.Intervention{
+		Platform:      platform,
+		AccountID:     event.AccountID,
+		Reason:        reason,
+		Step:          event.Step,
+		Error:         event.Error,
+		ScreenshotURL: event.Screenshot,
+	})
+}
+
+func (s *interventionService) Get(ctx context.Context, id string) (*models.Intervention, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInterventionNotFound
+	}
+	return s.repo.Get(ctx, objectID)
+}
+
+func (s *interventionService) ListPending(ctx context.Context, page, pageSize int) ([]*models.Intervention, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	return s.repo.ListPending(ctx, int64((page-1)*pageSize), int64(pageSize))
+}
+
+// Resolve re-queues the registration of the intervention's account
+func (s *interventionService) Resolve(ctx context.Context, id string, userID int64) (*models.Intervention, error) {
+	return s.close(ctx, id, models.InterventionResolved, userID, s.retryRegistration)
+}
+
+// Abandon gives up on the account and marks it as error on its platform
+func (s *interventionService) Abandon(ctx context.Context, id string, userID int64) (*models.Intervention, error) {
+	return s.close(ctx, id, models.InterventionAbandoned, userID, s.markError)
+}
+
+// close claims the intervention before calling the platform so a second tap does nothing,
+// and puts it back in the queue when the call fails
+func (s *interventionService) close(ctx context.Context, id, status string, userID int64, action func(context.Context, *models.Intervention) error) (*models.Intervention, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, models.ErrInterventionNotFound
+	}
+
+	intervention, err := s.repo.Close(ctx, objectID, status, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := action(ctx, intervention); err != nil {
+		if reopenErr := s.repo.Reopen(ctx, objectID); reopenErr != nil {
+			return nil, fmt.Errorf("%w (%v)", err, reopenErr)
+		}
+		return nil, err
+	}
+
+	return intervention, nil
+}
+
+func (s *interventionService) retryRegistration(ctx context.Context, intervention *models.Intervention) error {
+	var err error
+	switch intervention.Platform {
+	case "vk":
+		var client vkpb.VKServiceClient
+		if client, err = s.vkClient(); err == nil {
+			_, err = client.RetryRegistration(ctx, &vkpb.RetryRequest{AccountId: intervention.AccountID})
+		}
+	case "telegram":
+		var client telegrampb.TelegramServiceClient
+		if client, err = s.telegramClient(); err == nil {
+			_, err = client.RetryRegistration(ctx, &telegrampb.RetryRequest{AccountId: intervention.AccountID})
+		}
+	case "mail":
+		var client mailpb.MailServiceClient
+		if client, err = s.mailClient(); err == nil {
+			_, err = client.RetryRegistration(ctx, &mailpb.RetryRegistrationRequest{AccountId: intervention.AccountID})
+		}
+	case "max":
+		var client maxpb.MaxServiceClient
+		if client, err = s.maxClient(); err == nil {
+			_, err = client.RetryRegistration(ctx, &maxpb.RetryRegistrationRequest{AccountId: intervention.AccountID})
+		}
+	default:
+		return fmt.Errorf("unknown platform: %s", intervention.Platform)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to retry registration: %w", err)
+	}
+	return nil
+}
+
+func (s *interventionService) markError(ctx context.Context, intervention *models.Intervention) error {
+	message := fmt.Sprintf("Abandoned after manual intervention: %s", intervention.Reason)
+
+	var err error
+	switch intervention.Platform {
+	case "vk":
+		var client vkpb.VKServiceClient
+		if client, err = s.vkClient(); err == nil {
+			_, err = client.UpdateAccountStatus(ctx, &vkpb.UpdateStatusRequest{AccountId: intervention.AccountID, Status: abandonedStatus})
+		}
+	case "telegram":
+		var client telegrampb.TelegramServiceClient
+		if client, err = s.telegramClient(); err == nil {
+			_, err = client.UpdateAccountStatus(ctx, &telegrampb.UpdateStatusRequest{AccountId: intervention.AccountID, Status: abandonedStatus})
+		}
+	case "mail":
+		var client mailpb.MailServiceClient
+		if client, err = s.mailClient(); err == nil {
+			_, err = client.UpdateAccountStatus(ctx, &mailpb.UpdateAccountStatusRequest{AccountId: intervention.AccountID, Status: abandonedStatus, ErrorMessage: message})
+		}
+	case "max":
+		var client maxpb.MaxServiceClient
+		if client, err = s.maxClient(); err == nil {
+			_, err = client.UpdateAccountStatus(ctx, &maxpb.UpdateAccountStatusRequest{AccountId: intervention.AccountID, Status: abandonedStatus, ErrorMessage: message})
+		}
+	default:
+		return fmt.Errorf("unknown platform: %s", intervention.Platform)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to update account status: %w", err)
+	}
+	return nil
+}
+
+func (s *interventionService) vkClient() (vkpb.VKServiceClient, error) {
+	if s.clients == nil || s.clients.VKServiceClient == nil {
+		return nil, fmt.Errorf("VK service not configured")
+	}
+	return s.clients.VKServiceClient, nil
+}
+
+func (s *interventionService) telegramClient() (telegrampb.TelegramServiceClient, error) {
+	if s.clients == nil || s.clients.TelegramServiceClient == nil {
+		return nil, fmt.Errorf("Telegram service not configured")
+	}
+	return s.clients.TelegramServiceClient, nil
+}
+
+func (s *interventionService) mailClient() (mailpb.MailServiceClient, error) {
+	if s.clients == nil || s.clients.MailServiceClient == nil {
+		return nil, fmt.Errorf("Mail service not configured")
+	}
+	return s.clients.MailServiceClient, nil
+}
+
+func (s *interventionService) maxClient() (maxpb.MaxServiceClient, error) {
+	if s.clients == nil || s.clients.MaxServiceClient == nil {
+		return nil, fmt.Errorf("Max service not configured")
+	}
+	return s.clients.MaxServiceClient, nil
+}
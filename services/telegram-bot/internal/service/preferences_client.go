@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	sharedmodels "github.com/grigta/conveer/pkg/models"
+	"github.com/grigta/conveer/pkg/serviceauth"
+)
+
+// preferencesCacheTTL keeps alert storms from hammering the auth service
+const preferencesCacheTTL = time.Minute
+
+// PreferencesClient resolves notification recipients from preferences stored in the auth service
+type PreferencesClient interface {
+	TelegramRecipients(ctx context.Context, category sharedmodels.NotificationCategory, value, platform string) ([]int64, error)
+}
+
+type cachedRecipients struct {
+	ids       []int64
+	expiresAt time.Time
+}
+
+type preferencesClient struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cachedRecipients
+}
+
+// NewPreferencesClient queries recipients with telegram-bot's service token, the auth service
+// serves them only to services with the auth-service scope
+func NewPreferencesClient(baseURL string) PreferencesClient {
+	return &preferencesClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second, Transport: serviceauth.FromEnv("telegram-bot").Transport(nil)},
+		cache:      make(map[string]cachedRecipients),
+	}
+}
+
+func (c *preferencesClient) TelegramRecipients(ctx context.Context, category sharedmodels.NotificationCategory, value, platform string) ([]int64, error) {
+	key := fmt.Sprintf("%s:%s:%s", category, value, platform)
+
+	c.mu.Lock()
+	if cached, ok := c.cache[key]; ok && time.Now().Before(cached.expiresAt) {
+		c.mu.Unlock()
+		return cached.ids, nil
+	}
+	c.mu.Unlock()
+
+	query := url.Values{}
+	query.Set("category", string(category))
+	query.Set("value", value)
+	query.Set("platform", platform)
+	query.Set("channel", string(sharedmodels.ChannelTelegram))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/notification-preferences/recipients?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query auth service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth service returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Recipients []sharedmodels.NotificationRecipient `json:"recipients"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode recipients: %w", err)
+	}
+
+	ids := make([]int64, 0, len(body.Recipients))
+	for _, recipient := range body.Recipients {
+		ids = append(ids, recipient.TelegramID)
+	}
+
+	c.mu.Lock()
+	c.cache[key] = cachedRecipients{ids: ids, expiresAt: time.Now().Add(preferencesCacheTTL)}
+	c.mu.Unlock()
+
+	return ids, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	"github.com/grigta/conveer/services/telegram-bot/internal/repository"
+)
+
+const maxPresetsPerUser = 50
+
+// PresetService manages the command presets operators save per chat
+type PresetService interface {
+	Save(ctx context.Context, preset *models.CommandPreset) error
+	Get(ctx context.Context, chatID, userID int64, name string) (*models.CommandPreset, error)
+	List(ctx context.Context, chatID, userID int64) ([]*models.CommandPreset, error)
+	Delete(ctx context.Context, chatID, userID int64, name string) error
+}
+
+type presetService struct {
+	repo repository.PresetRepository
+}
+
+func NewPresetService(repo repository.PresetRepository) PresetService {
+	return &presetService{repo: repo}
+}
+
+func (s *presetService) Save(ctx context.Context, preset *models.CommandPreset) error {
+	presets, err := s.repo.List(ctx, preset.ChatID, preset.UserID)
+	if err != nil {
+		return err
+	}
+	if len(presets) >= maxPresetsPerUser {
+		// Overwriting an existing preset doesn't grow the list
+		exists := false
+		for _, p := range presets {
+			if p.Name == preset.Name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			return fmt.Errorf("preset limit of %d reached, delete unused presets first", maxPresetsPerUser)
+		}
+	}
+
+	return s.repo.Upsert(ctx, preset)
+}
+
+func (s *presetService) Get(ctx context.Context, chatID, userID int64, name string) (*models.CommandPreset, error) {
+	return s.repo.Get(ctx, chatID, userID, name)
+}
+
+func (s *presetService) List(ctx context.Context, chatID, userID int64) ([]*models.CommandPreset, error) {
+	return s.repo.List(ctx, chatID, userID)
+}
+
+func (s *presetService) Delete(ctx context.Context, chatID, userID int64, name string) error {
+	return s.repo.Delete(ctx, chatID, userID, name)
+}
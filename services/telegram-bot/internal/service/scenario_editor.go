@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const scenarioDraftTTL = 30 * time.Minute
+
+// ScenarioEditorService keeps the drafts of the guided scenario dialog and turns them into
+// warming-service custom scenarios
+type ScenarioEditorService interface {
+	StartNew(ctx context.Context, chatID, userID int64, name string) (*models.ScenarioDraft, error)
+	StartEdit(ctx context.Context, chatID, userID int64, scenarioID string) (*models.ScenarioDraft, error)
+	GetDraft(ctx context.Context, chatID, userID int64) (*models.ScenarioDraft, error)
+	SaveDraft(ctx context.Context, draft *models.ScenarioDraft) error
+	DiscardDraft(ctx context.Context, chatID, userID int64) error
+	Validate(ctx context.Context, draft *models.ScenarioDraft) (*warmingpb.ValidateScenarioResponse, error)
+	Submit(ctx context.Context, draft *models.ScenarioDraft, createdBy string) (*warmingpb.WarmingScenario, error)
+}
+
+type scenarioEditorService struct {
+	clients *GRPCClients
+}
+
+func NewScenarioEditorService(clients *GRPCClients) ScenarioEditorService {
+	return &scenarioEditorService{clients: clients}
+}
+
+func (s *scenarioEditorService) StartNew(ctx context.Context, chatID, userID int64, name string) (*models.ScenarioDraft, error) {
+	draft := &models.ScenarioDraft{
+		ChatID: chatID,
+		UserID: userID,
+		Name:   name,
+	}
+	if err := s.SaveDraft(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (s *scenarioEditorService) StartEdit(ctx context.Context, chatID, userID int64, scenarioID string) (*models.ScenarioDraft, error) {
+	client, err := s.warmingClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.ListScenarios(ctx, &warmingpb.ListScenariosRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scenarios: %w", err)
+	}
+
+	for _, scenario := range resp.Scenarios {
+		if scenario.Id != scenarioID {
+			continue
+		}
+		// Duration and intensities are chosen again, the stored schedule is replaced on save
+		draft := &models.ScenarioDraft{
+			ChatID:      chatID,
+			UserID:      userID,
+			ScenarioID:  scenario.Id,
+			Name:        scenario.Name,
+			Description: scenario.Description,
+			Platform:    scenario.Platform,
+		}
+		if err := s.SaveDraft(ctx, draft); err != nil {
+			return nil, err
+		}
+		return draft, nil
+	}
+
+	return nil, fmt.Errorf("scenario %s not found", scenarioID)
+}
+
+func (s *scenarioEditorService) GetDraft(ctx context.Context, chatID, userID int64) (*models.ScenarioDraft, error) {
+	if s.clients == nil || s.clients.RedisClient == nil {
+		return nil, fmt.Errorf("redis not configured")
+	}
+
+	data, err := s.clients.RedisClient.Get(ctx, draftKey(chatID, userID)).Bytes()
+	if err == redis.Nil {
+		return nil, models.ErrScenarioDraftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var draft models.ScenarioDraft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (s *scenarioEditorService) SaveDraft(ctx context.Context, draft *models.ScenarioDraft) error {
+	if s.clients == nil || s.clients.RedisClient == nil {
+		return fmt.Errorf("redis not configured")
+	}
+
+	data, err := json.Marshal(draft)
+	if err != nil {
+		return err
+	}
+	return s.clients.RedisClient.Set(ctx, draftKey(draft.ChatID, draft.UserID), data, scenarioDraftTTL).Err()
+}
+
+func (s *scenarioEditorService) DiscardDraft(ctx context.Context, chatID, userID int64) error {
+	if s.clients == nil || s.clients.RedisClient == nil {
+		return fmt.Errorf("redis not configured")
+	}
+	return s.clients.RedisClient.Del(ctx, draftKey(chatID, userID)).Err()
+}
+
+// Validate runs the draft through the warming-service scenario validator, the response
+// carries the simulated timeline
+func (s *scenarioEditorService) Validate(ctx context.Context, draft *models.ScenarioDraft) (*warmingpb.ValidateScenarioResponse, error) {
+	client, err := s.warmingClient()
+	if err != nil {
+		return nil, err
+	}
+
+	actionsJSON, scheduleJSON, err := buildScenarioJSON(draft)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ValidateScenario(ctx, &warmingpb.ValidateScenarioRequest{
+		Name:         draft.Name,
+		Platform:     draft.Platform,
+		ActionsJson:  actionsJSON,
+		ScheduleJson: scheduleJSON,
+		DurationDays: int32(draft.DurationDays),
+	})
+}
+
+// Submit creates the scenario, or updates it when the draft edits an existing one
+func (s *scenarioEditorService) Submit(ctx context.Context, draft *models.ScenarioDraft, createdBy string) (*warmingpb.WarmingScenario, error) {
+	client, err := s.warmingClient()
+	if err != nil {
+		return nil, err
+	}
+
+	actionsJSON, scheduleJSON, err := buildScenarioJSON(draft)
+	if err != nil {
+		return nil, err
+	}
+
+	var scenario *warmingpb.WarmingScenario
+	if draft.ScenarioID != "" {
+		scenario, err = client.UpdateCustomScenario(ctx, &warmingpb.UpdateScenarioRequest{
+			ScenarioId:   draft.ScenarioID,
+			Name:         draft.Name,
+			Description:  draft.Description,
+			ActionsJson:  actionsJSON,
+			ScheduleJson: scheduleJSON,
+		})
+	} else {
+		description := draft.Description
+		if description == "" {
+			description = fmt.Sprintf("Создан в боте: %d дней, интенсивность %s", draft.DurationDays, strings.Join(draft.Intensities, "/"))
+		}
+		scenario, err = client.CreateCustomScenario(ctx, &warmingpb.CreateScenarioRequest{
+			Name:         draft.Name,
+			Description:  description,
+			Platform:     draft.Platform,
+			ActionsJson:  actionsJSON,
+			ScheduleJson: scheduleJSON,
+			CreatedBy:    createdBy,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The scenario is saved either way, a draft that failed to delete expires on its own
+	_ = s.DiscardDraft(ctx, draft.ChatID, draft.UserID)
+	return scenario, nil
+}
+
+func (s *scenarioEditorService) warmingClient() (warmingpb.WarmingServiceClient, error) {
+	if s.clients == nil || s.clients.WarmingServiceClient == nil {
+		return nil, fmt.Errorf("warming service not configured")
+	}
+	return s.clients.WarmingServiceClient, nil
+}
+
+func draftKey(chatID, userID int64) string {
+	return fmt.Sprintf("scenario_draft:%d:%d", chatID, userID)
+}
+
+// buildScenarioJSON renders the draft in the actions_json/schedule_json format the
+// warming-service expects. Stages after the draft's duration are left out.
+func buildScenarioJSON(draft *models.ScenarioDraft) (string, string, error) {
+	type scenarioAction struct {
+		Type   string `json:"type"`
+		Weight int    `json:"weight"`
+	}
+	type daySchedule struct {
+		MinActions int `json:"min_actions"`
+		MaxActions int `json:"max_actions"`
+	}
+
+	mix, ok := models.ScenarioActionMix[draft.Platform]
+	if !ok {
+		return "", "", fmt.Errorf("unsupported platform %q", draft.Platform)
+	}
+
+	types := make([]string, 0, len(mix))
+	for actionType := range mix {
+		types = append(types, actionType)
+	}
+	sort.Strings(types)
+
+	actions := make([]scenarioAction, 0, len(types))
+	for _, actionType := range types {
+		actions = append(actions, scenarioAction{Type: actionType, Weight: mix[actionType]})
+	}
+
+	schedule := make(map[string]daySchedule)
+	for i, intensity := range draft.Intensities {
+		if i >= len(models.ScenarioStages) {
+			break
+		}
+		bounds, ok := models.IntensityActions[intensity]
+		if !ok {
+			return "", "", fmt.Errorf("unknown intensity %q", intensity)
+		}
+		schedule[models.ScenarioStages[i]] = daySchedule{MinActions: bounds[i][0], MaxActions: bounds[i][1]}
+	}
+
+	actionsJSON, err := json.Marshal(actions)
+	if err != nil {
+		return "", "", err
+	}
+	scheduleJSON, err := json.Marshal(schedule)
+	if err != nil {
+		return "", "", err
+	}
+	return string(actionsJSON), string(scheduleJSON), nil
+}
@@ -146,7 +146,7 @@ func (s *statsService) getVKStats(ctx context.Context) (*AccountStats, error) {
 		ByStatus:    pbStats.ByStatus,
 		SuccessRate: pbStats.SuccessRate,
 		LastHour:    pbStats.LastHour,
-		Last24Hours: pbStats.Last24Hours,
+		Last24Hours: pbStats.Last_24Hours,
 	}
 
 	return stats, nil
@@ -167,7 +167,7 @@ func (s *statsService) getTelegramStats(ctx context.Context) (*AccountStats, err
 		ByStatus:    pbStats.ByStatus,
 		SuccessRate: pbStats.SuccessRate,
 		LastHour:    pbStats.LastHour,
-		Last24Hours: pbStats.Last24Hours,
+		Last24Hours: pbStats.Last_24Hours,
 	}
 
 	return stats, nil
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/telegram-bot/internal/models"
+)
+
+// GrantAccess adds a user with role, or reactivates and whitelists an existing one
+func (s *authService) GrantAccess(ctx context.Context, actorID, telegramID int64, role string) (*models.TelegramBotUser, error) {
+	if !models.IsValidRole(role) {
+		return nil, models.ErrInvalidRole
+	}
+	if actorID == telegramID {
+		return nil, models.ErrSelfModification
+	}
+
+	user, err := s.userRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil && err != models.ErrUserNotFound {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	oldValue := ""
+	if user == nil {
+		user = &models.TelegramBotUser{
+			TelegramID: telegramID,
+			Role:       role,
+			IsActive:   true,
+			Whitelist:  true,
+		}
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+	} else {
+		oldValue = accessState(user)
+		user.Role = role
+		user.IsActive = true
+		user.Whitelist = true
+		if err := s.userRepo.Update(ctx, telegramID, map[string]interface{}{
+			"role":      role,
+			"is_active": true,
+			"whitelist": true,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	s.audit(ctx, actorID, telegramID, models.UserAuditGrant, oldValue, accessState(user))
+	return user, nil
+}
+
+// ChangeRole promotes or demotes a user
+func (s *authService) ChangeRole(ctx context.Context, actorID, telegramID int64, role string) (*models.TelegramBotUser, error) {
+	if !models.IsValidRole(role) {
+		return nil, models.ErrInvalidRole
+	}
+
+	user, err := s.managedUser(ctx, actorID, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	if role != models.RoleAdmin {
+		if err := s.ensureAnotherAdmin(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, telegramID, map[string]interface{}{"role": role}); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, actorID, telegramID, models.UserAuditRole, user.Role, role)
+	user.Role = role
+	return user, nil
+}
+
+// SetWhitelist allows or blocks a user without removing them
+func (s *authService) SetWhitelist(ctx context.Context, actorID, telegramID int64, whitelisted bool) (*models.TelegramBotUser, error) {
+	user, err := s.managedUser(ctx, actorID, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Whitelist == whitelisted {
+		return user, nil
+	}
+	if !whitelisted {
+		if err := s.ensureAnotherAdmin(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, telegramID, map[string]interface{}{"whitelist": whitelisted}); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, actorID, telegramID, models.UserAuditWhitelist, strconv.FormatBool(user.Whitelist), strconv.FormatBool(whitelisted))
+	user.Whitelist = whitelisted
+	return user, nil
+}
+
+// RevokeAccess deactivates a user. The record is kept so the audit log stays readable,
+// GrantAccess restores access.
+func (s *authService) RevokeAccess(ctx context.Context, actorID, telegramID int64) (*models.TelegramBotUser, error) {
+	user, err := s.managedUser(ctx, actorID, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return user, nil
+	}
+	if err := s.ensureAnotherAdmin(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Update(ctx, telegramID, map[string]interface{}{"is_active": false}); err != nil {
+		return nil, err
+	}
+
+	oldValue := accessState(user)
+	user.IsActive = false
+	s.audit(ctx, actorID, telegramID, models.UserAuditRevoke, oldValue, accessState(user))
+	return user, nil
+}
+
+func (s *authService) ListAudit(ctx context.Context, telegramID int64, limit int64) ([]*models.UserAuditEntry, error) {
+	return s.userRepo.ListAuditEntries(ctx, telegramID, limit)
+}
+
+// managedUser loads a user an admin may change; admins can't change themselves to avoid lockouts
+func (s *authService) managedUser(ctx context.Context, actorID, telegramID int64) (*models.TelegramBotUser, error) {
+	if actorID == telegramID {
+		return nil, models.ErrSelfModification
+	}
+	return s.userRepo.GetByTelegramID(ctx, telegramID)
+}
+
+// ensureAnotherAdmin fails when user is the last admin who can still use the bot
+func (s *authService) ensureAnotherAdmin(ctx context.Context, user *models.TelegramBotUser) error {
+	if user.Role != models.RoleAdmin || !user.IsActive || !user.Whitelist {
+		return nil
+	}
+
+	admins, err := s.userRepo.List(ctx, map[string]interface{}{
+		"role":      models.RoleAdmin,
+		"is_active": true,
+		"whitelist": true,
+	})
+	if err != nil {
+		return err
+	}
+	for _, admin := range admins {
+		if admin.TelegramID != user.TelegramID {
+			return nil
+		}
+	}
+	return models.ErrLastAdmin
+}
+
+// audit records a change; the change itself is already stored, so failures are only logged
+func (s *authService) audit(ctx context.Context, actorID, targetID int64, action, oldValue, newValue string) {
+	entry := &models.UserAuditEntry{
+		ActorID:  actorID,
+		TargetID: targetID,
+		Action:   action,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+	if err := s.userRepo.AddAuditEntry(ctx, entry); err != nil {
+		logger.Errorf("Failed to record audit entry %s for user %d: %v", action, targetID, err)
+	}
+}
+
+// accessState describes role and access flags for the audit log
+func accessState(user *models.TelegramBotUser) string {
+	return fmt.Sprintf("role=%s active=%t whitelist=%t", user.Role, user.IsActive, user.Whitelist)
+}
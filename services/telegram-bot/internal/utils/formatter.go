@@ -2,11 +2,13 @@ package utils
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/grigta/conveer/services/telegram-bot/internal/models"
 	"github.com/grigta/conveer/services/telegram-bot/internal/service"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
 )
 
 func FormatAccountsTable(accounts []*models.Account) string {
@@ -223,6 +225,298 @@ func FormatAlert(event *models.Event) string {
 	return builder.String()
 }
 
+// FormatScenarioTimeline shows the simulated timeline of a scenario draft. Plain text:
+// action types contain underscores that break Markdown.
+func FormatScenarioTimeline(draft *models.ScenarioDraft, result *warmingpb.ValidateScenarioResponse) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("🗓 Сценарий %s (%s, %d дней)\n\n", draft.Name, strings.ToUpper(draft.Platform), draft.DurationDays))
+
+	for i, stage := range result.Stages {
+		intensity := ""
+		if i < len(draft.Intensities) {
+			intensity = ScenarioIntensityName(draft.Intensities[i])
+		}
+		builder.WriteString(fmt.Sprintf("Дни %d-%d · %s\n", stage.FromDay, stage.ToDay, intensity))
+		builder.WriteString(fmt.Sprintf("├─ %d-%d действий в день, всего %d-%d\n",
+			stage.MinActionsPerDay, stage.MaxActionsPerDay, stage.MinActions, stage.MaxActions))
+
+		actions := make([]string, 0, len(stage.ActionShares))
+		for action := range stage.ActionShares {
+			actions = append(actions, action)
+		}
+		sort.Slice(actions, func(a, b int) bool {
+			return stage.ActionShares[actions[a]] > stage.ActionShares[actions[b]]
+		})
+		shares := make([]string, 0, len(actions))
+		for _, action := range actions {
+			shares = append(shares, fmt.Sprintf("%s %.0f%%", action, stage.ActionShares[action]*100))
+		}
+		builder.WriteString(fmt.Sprintf("└─ %s\n\n", strings.Join(shares, ", ")))
+	}
+
+	builder.WriteString(fmt.Sprintf("Всего действий: %d-%d\n", result.TotalMinActions, result.TotalMaxActions))
+
+	if result.Valid {
+		builder.WriteString("\n✅ Сценарий прошел проверку")
+	} else {
+		builder.WriteString("\n❌ Сценарий не прошел проверку:\n")
+		for _, problem := range result.Errors {
+			builder.WriteString(fmt.Sprintf("• %s\n", problem))
+		}
+	}
+
+	return builder.String()
+}
+
+func ScenarioIntensityName(intensity string) string {
+	switch intensity {
+	case models.IntensityLow:
+		return "Щадящий"
+	case models.IntensityMedium:
+		return "Умеренный"
+	case models.IntensityHigh:
+		return "Интенсивный"
+	}
+	return intensity
+}
+
+// FormatInterventions lists a page of pending interventions, numbered like the buttons of
+// InterventionsKeyboard. Plain text: reasons and errors come from the platforms unescaped.
+func FormatInterventions(interventions []*models.Intervention, total int64, page, pageSize int) string {
+	if total == 0 {
+		return "✅ Очередь ручного вмешательства пуста"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🛠 Ручное вмешательство: %d в очереди\n\n", total))
+
+	for i, item := range interventions {
+		builder.WriteString(fmt.Sprintf("%d. %s · %s\n", (page-1)*pageSize+i+1, strings.ToUpper(item.Platform), item.AccountID))
+		if item.Reason != "" {
+			builder.WriteString(fmt.Sprintf("Причина: %s\n", item.Reason))
+		}
+		if item.Step != "" {
+			builder.WriteString(fmt.Sprintf("Шаг: %s\n", item.Step))
+		}
+		if item.Error != "" {
+			builder.WriteString(fmt.Sprintf("Ошибка: %s\n", item.Error))
+		}
+		builder.WriteString(fmt.Sprintf("Ждет %s", formatDuration(time.Since(item.FirstSeenAt))))
+		if item.Occurrences > 1 {
+			builder.WriteString(fmt.Sprintf(", запросов: %d", item.Occurrences))
+		}
+		builder.WriteString("\n\n")
+	}
+
+	builder.WriteString("✅ — перезапустить регистрацию, ✖️ — пометить аккаунт ошибкой")
+	return builder.String()
+}
+
+// RoleName returns the Russian name of a bot role
+func RoleName(role string) string {
+	switch role {
+	case models.RoleAdmin:
+		return "Администратор"
+	case models.RoleOperator:
+		return "Оператор"
+	case models.RoleViewer:
+		return "Наблюдатель"
+	default:
+		return "Неизвестная роль"
+	}
+}
+
+// FormatBotUsers lists bot users, admins first
+func FormatBotUsers(users []*models.TelegramBotUser) string {
+	if len(users) == 0 {
+		return "👤 Пользователей нет"
+	}
+
+	roleOrder := map[string]int{models.RoleAdmin: 0, models.RoleOperator: 1, models.RoleViewer: 2}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Role != users[j].Role {
+			return roleOrder[users[i].Role] < roleOrder[users[j].Role]
+		}
+		return users[i].TelegramID < users[j].TelegramID
+	})
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("👤 Пользователи бота: %d\n\n", len(users)))
+
+	for _, user := range users {
+		status := "✅"
+		switch {
+		case !user.IsActive:
+			status = "⛔️"
+		case !user.Whitelist:
+			status = "🚫"
+		}
+
+		builder.WriteString(fmt.Sprintf("%s %d", status, user.TelegramID))
+		if user.TelegramUsername != "" {
+			builder.WriteString(" @" + user.TelegramUsername)
+		}
+		if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+			builder.WriteString(" · " + name)
+		}
+		builder.WriteString(fmt.Sprintf(" — %s\n", RoleName(user.Role)))
+	}
+
+	builder.WriteString("\n✅ активен, 🚫 не в белом списке, ⛔️ доступ отозван")
+	return builder.String()
+}
+
+// FormatUserAudit lists changes of bot access, newest first
+func FormatUserAudit(entries []*models.UserAuditEntry) string {
+	if len(entries) == 0 {
+		return "📜 Изменений доступа нет"
+	}
+
+	actions := map[string]string{
+		models.UserAuditGrant:     "выдан доступ",
+		models.UserAuditRole:      "смена роли",
+		models.UserAuditWhitelist: "белый список",
+		models.UserAuditRevoke:    "доступ отозван",
+	}
+
+	var builder strings.Builder
+	builder.WriteString("📜 Изменения доступа:\n\n")
+
+	for _, entry := range entries {
+		action, ok := actions[entry.Action]
+		if !ok {
+			action = entry.Action
+		}
+
+		builder.WriteString(fmt.Sprintf("%s · %d → %d: %s",
+			entry.CreatedAt.Format("02.01 15:04"), entry.ActorID, entry.TargetID, action))
+		if entry.OldValue != "" || entry.NewValue != "" {
+			builder.WriteString(fmt.Sprintf(" (%s → %s)", valueOrDash(entry.OldValue), valueOrDash(entry.NewValue)))
+		}
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// AccountMatchTitle is the inline result title of an account: status badge, platform and phone
+// or email, masked for users who may not see contact details
+func AccountMatchTitle(match *models.AccountMatch, showSensitive bool) string {
+	contact := match.Phone
+	if contact == "" {
+		contact = match.Email
+	}
+	if !showSensitive {
+		contact = maskContact(contact)
+	}
+	if contact == "" {
+		contact = match.ID
+	}
+
+	return fmt.Sprintf("%s %s · %s", accountBadge(match), strings.ToUpper(match.Platform), contact)
+}
+
+// AccountMatchDescription is the second line of an inline result
+func AccountMatchDescription(match *models.AccountMatch) string {
+	parts := []string{match.Status}
+	if match.Name != "" {
+		parts = append(parts, match.Name)
+	}
+	if match.Username != "" {
+		parts = append(parts, "@"+match.Username)
+	}
+	if !match.CreatedAt.IsZero() {
+		parts = append(parts, match.CreatedAt.Format("02.01.2006"))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// FormatAccountMatch is the message an inline result sends to the chat. Contact details are
+// masked and the proxy, platform user ID and error are left out unless showSensitive is set.
+// Plain text: names and errors come from the platforms unescaped.
+func FormatAccountMatch(match *models.AccountMatch, showSensitive bool) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s · %s\n", accountBadge(match), strings.ToUpper(match.Platform), match.ID))
+	builder.WriteString(fmt.Sprintf("Статус: %s\n", match.Status))
+
+	phone, email := match.Phone, match.Email
+	if !showSensitive {
+		phone, email = maskContact(phone), maskContact(email)
+	}
+	if phone != "" {
+		builder.WriteString(fmt.Sprintf("Телефон: %s\n", phone))
+	}
+	if email != "" {
+		builder.WriteString(fmt.Sprintf("Email: %s\n", email))
+	}
+	if match.Name != "" {
+		builder.WriteString(fmt.Sprintf("Имя: %s\n", match.Name))
+	}
+	if match.Username != "" {
+		builder.WriteString(fmt.Sprintf("Username: @%s\n", match.Username))
+	}
+	if match.Quality != "" {
+		builder.WriteString(fmt.Sprintf("Качество: %s\n", match.Quality))
+	}
+
+	if showSensitive {
+		if match.UserID != "" {
+			builder.WriteString(fmt.Sprintf("ID на платформе: %s\n", match.UserID))
+		}
+		if match.ProxyID != "" {
+			builder.WriteString(fmt.Sprintf("Прокси: %s\n", match.ProxyID))
+		}
+		if match.ErrorMessage != "" {
+			builder.WriteString(fmt.Sprintf("Ошибка: %s\n", match.ErrorMessage))
+		}
+	}
+
+	if !match.CreatedAt.IsZero() {
+		builder.WriteString(fmt.Sprintf("Создан: %s", match.CreatedAt.Format("02.01.2006 15:04")))
+	}
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+// accountBadge is the status emoji, with a star for accounts with a bound recovery email
+func accountBadge(match *models.AccountMatch) string {
+	badge := getStatusEmoji(match.Status)
+	if match.Quality == "high" {
+		badge += "⭐️"
+	}
+	return badge
+}
+
+// maskContact keeps the start and the end of a phone number and the first letters and domain
+// of an email: "+79001234567" becomes "+7900*****67", "ivanov@mail.ru" becomes "iv****@mail.ru"
+func maskContact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	if at := strings.LastIndex(value, "@"); at > 0 {
+		local := []rune(value[:at])
+		keep := 2
+		if len(local) <= keep {
+			keep = 1
+		}
+		return string(local[:keep]) + strings.Repeat("*", len(local)-keep) + value[at:]
+	}
+
+	runes := []rune(value)
+	if len(runes) <= 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:5]) + strings.Repeat("*", len(runes)-7) + string(runes[len(runes)-2:])
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "—"
+	}
+	return value
+}
+
 // Helper functions
 
 func getStatusEmoji(status string) string {
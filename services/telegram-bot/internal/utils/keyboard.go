@@ -309,3 +309,122 @@ func SMSCountryKeyboard(service string) *botmodels.InlineKeyboardMarkup {
 		},
 	}
 }
+
+func ScenarioPlatformKeyboard() *botmodels.InlineKeyboardMarkup {
+	return &botmodels.InlineKeyboardMarkup{
+		InlineKeyboard: [][]botmodels.InlineKeyboardButton{
+			{
+				{Text: "VK", CallbackData: "scn:platform:vk"},
+				{Text: "Telegram", CallbackData: "scn:platform:telegram"},
+			},
+			{
+				{Text: "Mail.ru", CallbackData: "scn:platform:mail"},
+				{Text: "Max", CallbackData: "scn:platform:max"},
+			},
+			{
+				{Text: "✖️ Отмена", CallbackData: "scn:cancel"},
+			},
+		},
+	}
+}
+
+func ScenarioDurationKeyboard() *botmodels.InlineKeyboardMarkup {
+	row := make([]botmodels.InlineKeyboardButton, 0, len(models.ScenarioDurations))
+	for _, days := range models.ScenarioDurations {
+		row = append(row, botmodels.InlineKeyboardButton{
+			Text:         fmt.Sprintf("%d дней", days),
+			CallbackData: fmt.Sprintf("scn:duration:%d", days),
+		})
+	}
+
+	return &botmodels.InlineKeyboardMarkup{
+		InlineKeyboard: [][]botmodels.InlineKeyboardButton{
+			row,
+			{
+				{Text: "✖️ Отмена", CallbackData: "scn:cancel"},
+			},
+		},
+	}
+}
+
+// ScenarioIntensityKeyboard offers the intensity presets of a stage with their actions per day
+func ScenarioIntensityKeyboard(stage int) *botmodels.InlineKeyboardMarkup {
+	var buttons [][]botmodels.InlineKeyboardButton
+	for _, intensity := range []string{models.IntensityLow, models.IntensityMedium, models.IntensityHigh} {
+		bounds := models.IntensityActions[intensity][stage]
+		buttons = append(buttons, []botmodels.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("%s · %d-%d в день", ScenarioIntensityName(intensity), bounds[0], bounds[1]),
+				CallbackData: fmt.Sprintf("scn:stage:%d:%s", stage, intensity),
+			},
+		})
+	}
+
+	buttons = append(buttons, []botmodels.InlineKeyboardButton{
+		{Text: "🔄 Заново", CallbackData: "scn:restart"},
+		{Text: "✖️ Отмена", CallbackData: "scn:cancel"},
+	})
+
+	return &botmodels.InlineKeyboardMarkup{
+		InlineKeyboard: buttons,
+	}
+}
+
+func ScenarioConfirmKeyboard(valid bool) *botmodels.InlineKeyboardMarkup {
+	var buttons [][]botmodels.InlineKeyboardButton
+	if valid {
+		buttons = append(buttons, []botmodels.InlineKeyboardButton{
+			{Text: "✅ Сохранить", CallbackData: "scn:save"},
+		})
+	}
+
+	buttons = append(buttons, []botmodels.InlineKeyboardButton{
+		{Text: "🔄 Заново", CallbackData: "scn:restart"},
+		{Text: "✖️ Отмена", CallbackData: "scn:cancel"},
+	})
+
+	return &botmodels.InlineKeyboardMarkup{
+		InlineKeyboard: buttons,
+	}
+}
+
+// InterventionsKeyboard has a row of actions per listed intervention and the page navigation
+func InterventionsKeyboard(interventions []*models.Intervention, page, pageSize, totalPages int) *botmodels.InlineKeyboardMarkup {
+	var buttons [][]botmodels.InlineKeyboardButton
+	for i, item := range interventions {
+		number := (page-1)*pageSize + i + 1
+		id := item.ID.Hex()
+
+		row := []botmodels.InlineKeyboardButton{
+			{Text: fmt.Sprintf("✅ %d", number), CallbackData: fmt.Sprintf("itv:resolve:%s:%d", id, page)},
+			{Text: fmt.Sprintf("✖️ %d", number), CallbackData: fmt.Sprintf("itv:abandon:%s:%d", id, page)},
+		}
+		if item.ScreenshotURL != "" {
+			row = append(row, botmodels.InlineKeyboardButton{Text: fmt.Sprintf("📷 %d", number), URL: item.ScreenshotURL})
+		}
+		buttons = append(buttons, row)
+	}
+
+	navigation := []botmodels.InlineKeyboardButton{}
+	if page > 1 {
+		navigation = append(navigation, botmodels.InlineKeyboardButton{
+			Text:         "◀️ Назад",
+			CallbackData: fmt.Sprintf("itv:page:%d", page-1),
+		})
+	}
+	navigation = append(navigation, botmodels.InlineKeyboardButton{
+		Text:         "🔄 Обновить",
+		CallbackData: fmt.Sprintf("itv:page:%d", page),
+	})
+	if page < totalPages {
+		navigation = append(navigation, botmodels.InlineKeyboardButton{
+			Text:         "Вперед ▶️",
+			CallbackData: fmt.Sprintf("itv:page:%d", page+1),
+		})
+	}
+	buttons = append(buttons, navigation)
+
+	return &botmodels.InlineKeyboardMarkup{
+		InlineKeyboard: buttons,
+	}
+}
@@ -12,14 +12,18 @@ import (
 
 	"github.com/grigta/conveer/pkg/database"
 	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/pkg/health"
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/serviceauth"
 	"github.com/grigta/conveer/services/telegram-service/internal/config"
 	"github.com/grigta/conveer/services/telegram-service/internal/handlers"
 	"github.com/grigta/conveer/services/telegram-service/internal/service"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
 	smspb "github.com/grigta/conveer/services/sms-service/proto"
 	pb "github.com/grigta/conveer/services/telegram-service/proto"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -37,9 +41,9 @@ func main() {
 	}
 
 	// Initialize logger
-	log := logger.New(logger.Config{
-		Level:  "info",
-		Format: "json",
+	log := logger.NewFromConfig(logger.Config{
+		Service: "telegram-service",
+		Level:   getEnvOrDefault("LOG_LEVEL", "info"),
 	})
 
 	log.Info("Starting Telegram service")
@@ -59,7 +63,8 @@ func main() {
 		log.Fatal("ENCRYPTION_KEY environment variable is required")
 	}
 
-	if err := encryption.Initialize(encryptionKey); err != nil {
+	encryptor, err := crypto.NewEncryptor(encryptionKey)
+	if err != nil {
 		log.Fatal("Failed to initialize encryption", "error", err)
 	}
 
@@ -88,21 +93,33 @@ func main() {
 	// Initialize gRPC clients
 	proxyServiceURL := getEnvOrDefault("PROXY_SERVICE_GRPC_URL", "proxy-service:50050")
 	smsServiceURL := getEnvOrDefault("SMS_SERVICE_GRPC_URL", "sms-service:50055")
+	personaServiceURL := getEnvOrDefault("PERSONA_SERVICE_GRPC_URL", "persona-service:50064")
+
+	serviceAuth := serviceauth.FromEnv("telegram-service")
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, logger.DialOptions()...)
+	dialOpts = append(dialOpts, serviceAuth.DialOptions()...)
 
-	proxyConn, err := grpc.Dial(proxyServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	proxyConn, err := grpc.Dial(proxyServiceURL, dialOpts...)
 	if err != nil {
 		log.Fatal("Failed to connect to proxy service", "error", err)
 	}
 	defer proxyConn.Close()
 
-	smsConn, err := grpc.Dial(smsServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	smsConn, err := grpc.Dial(smsServiceURL, dialOpts...)
 	if err != nil {
 		log.Fatal("Failed to connect to SMS service", "error", err)
 	}
 	defer smsConn.Close()
 
+	personaConn, err := grpc.Dial(personaServiceURL, dialOpts...)
+	if err != nil {
+		log.Fatal("Failed to connect to persona service", "error", err)
+	}
+	defer personaConn.Close()
+
 	proxyClient := proxypb.NewProxyServiceClient(proxyConn)
 	smsClient := smspb.NewSMSServiceClient(smsConn)
+	personaClient := personapb.NewPersonaServiceClient(personaConn)
 
 	// Initialize browser manager
 	browserManager := service.NewBrowserManager(
@@ -121,6 +138,7 @@ func main() {
 		browserManager,
 		proxyClient,
 		smsClient,
+		personaClient,
 		redisClient,
 		rabbitPublisher,
 		cfg,
@@ -135,9 +153,36 @@ func main() {
 		log.Error("Failed to start monitoring", "error", err)
 	}
 
+	// Drop pooled MTProto connections when proxy-service rotates or releases their proxy
+	var rabbitConsumer *messaging.RabbitMQ
+	if rabbitURL != "" {
+		rabbitConsumer, err = messaging.NewRabbitMQ(rabbitURL)
+		if err != nil {
+			log.Error("Failed to connect to RabbitMQ for proxy events", "error", err)
+		} else if err := telegramService.ConsumeProxyEvents(context.Background(), rabbitConsumer); err != nil {
+			log.Error("Failed to consume proxy events", "error", err)
+		}
+	}
+
+	// Readiness gates for gRPC health and /health, downstream services only degrade registration
+	healthChecker := health.NewChecker("telegram-service")
+	healthChecker.Add("mongodb", health.MongoCheck(db.Client()))
+	healthChecker.Add("redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
+	if rabbitConsumer != nil {
+		healthChecker.Add("rabbitmq", health.PingCheck(rabbitConsumer))
+	}
+	healthChecker.AddOptional("proxy-service", health.GRPCCheck(proxyConn, ""))
+	healthChecker.AddOptional("sms-service", health.GRPCCheck(smsConn, ""))
+	healthChecker.AddOptional("persona-service", health.GRPCCheck(personaConn, ""))
+
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	go healthChecker.Run(healthCtx, 10*time.Second)
+
 	// Initialize handlers
-	httpHandler := handlers.NewHTTPHandler(telegramService, log)
-	grpcHandler := handlers.NewGRPCHandler(telegramService, log)
+	httpHandler := handlers.NewHTTPHandler(telegramService, browserManager, log)
+	grpcHandler := handlers.NewGRPCHandler(telegramService, encryptor, log)
 
 	// Setup HTTP server
 	gin.SetMode(gin.ReleaseMode)
@@ -145,9 +190,7 @@ func main() {
 	router.Use(gin.Recovery())
 
 	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
-	})
+	router.GET("/health", healthChecker.Handler())
 
 	// Metrics endpoint
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -176,8 +219,10 @@ func main() {
 		log.Fatal("Failed to listen on gRPC port", "error", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(append(logger.ServerOptions(), serviceAuth.ServerOptions()...)...)
 	pb.RegisterTelegramServiceServer(grpcServer, grpcHandler)
+	warmingpb.RegisterActionExecutorServer(grpcServer, handlers.NewActionStreamHandler(grpcHandler, log))
+	healthChecker.Register(grpcServer)
 	reflection.Register(grpcServer)
 
 	go func() {
@@ -196,6 +241,9 @@ func main() {
 
 	log.Info("Shutting down telegram service")
 
+	// Report NOT_SERVING so callers stop routing here before the servers stop
+	stopHealth()
+
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -224,6 +272,11 @@ func main() {
 			log.Error("Failed to close RabbitMQ connection", "error", err)
 		}
 	}
+	if rabbitConsumer != nil {
+		if err := rabbitConsumer.Close(); err != nil {
+			log.Error("Failed to close RabbitMQ consumer connection", "error", err)
+		}
+	}
 
 	log.Info("Telegram service shutdown complete")
 }
@@ -16,6 +16,9 @@ type TelegramConfig struct {
 	AntiDetection  AntiDetectionConfig  `yaml:"anti_detection"`
 	Monitoring     MonitoringConfig     `yaml:"monitoring"`
 	API            APIConfig            `yaml:"api"`
+	MTProto        MTProtoConfig        `yaml:"mtproto"`
+	Posting        PostingConfig        `yaml:"posting"`
+	Dialogs        DialogsConfig        `yaml:"dialogs"`
 }
 
 type RegistrationConfig struct {
@@ -54,6 +57,37 @@ type APIConfig struct {
 	WebURL         string `yaml:"web_url"`
 }
 
+type MTProtoConfig struct {
+	DialTimeout        int    `yaml:"dial_timeout"`   // seconds
+	ActionTimeout      int    `yaml:"action_timeout"` // seconds
+	DeviceModel        string `yaml:"device_model"`
+	SystemVersion      string `yaml:"system_version"`
+	AppVersion         string `yaml:"app_version"`
+	LangCode           string `yaml:"lang_code"`
+	PoolIdleTimeout    int    `yaml:"pool_idle_timeout"`    // seconds an unused connection stays open
+	PoolMaxConnections int    `yaml:"pool_max_connections"` // idle connections are evicted above this
+}
+
+type PostingConfig struct {
+	StoriesPerDay        int   `yaml:"stories_per_day"`
+	StoryMinInterval     int   `yaml:"story_min_interval"` // minutes
+	ScheduledPerDay      int   `yaml:"scheduled_per_day"`
+	ScheduledMinInterval int   `yaml:"scheduled_min_interval"` // minutes
+	MaxScheduleAhead     int   `yaml:"max_schedule_ahead"`     // hours
+	MaxMediaSize         int64 `yaml:"max_media_size"`         // bytes
+	ActionDelayMin       int   `yaml:"action_delay_min"`       // ms
+	ActionDelayMax       int   `yaml:"action_delay_max"`       // ms
+}
+
+type DialogsConfig struct {
+	ImportPerDay        int      `yaml:"import_per_day"`
+	MaxContacts         int      `yaml:"max_contacts"`
+	MutualPeers         int      `yaml:"mutual_peers"` // contacts taken from other warming accounts per import
+	GreetingsPerDay     int      `yaml:"greetings_per_day"`
+	GreetingMinInterval int      `yaml:"greeting_min_interval"` // minutes
+	Greetings           []string `yaml:"greetings"`             // {name} is replaced with the contact's first name
+}
+
 type Config struct {
 	Telegram TelegramConfig `yaml:"telegram"`
 }
@@ -110,6 +144,38 @@ func (c *Config) setDefaults() {
 	c.Telegram.Monitoring.SessionExpiry = 120
 
 	c.Telegram.API.WebURL = "https://web.telegram.org/k/"
+
+	c.Telegram.MTProto.DialTimeout = 15
+	c.Telegram.MTProto.ActionTimeout = 60
+	c.Telegram.MTProto.DeviceModel = "Desktop"
+	c.Telegram.MTProto.SystemVersion = "Windows 10"
+	c.Telegram.MTProto.AppVersion = "4.16.8 x64"
+	c.Telegram.MTProto.LangCode = "en"
+	c.Telegram.MTProto.PoolIdleTimeout = 600
+	c.Telegram.MTProto.PoolMaxConnections = 200
+
+	c.Telegram.Posting.StoriesPerDay = 1
+	c.Telegram.Posting.StoryMinInterval = 1200
+	c.Telegram.Posting.ScheduledPerDay = 3
+	c.Telegram.Posting.ScheduledMinInterval = 120
+	c.Telegram.Posting.MaxScheduleAhead = 168
+	c.Telegram.Posting.MaxMediaSize = 10 << 20
+	c.Telegram.Posting.ActionDelayMin = 800
+	c.Telegram.Posting.ActionDelayMax = 2500
+
+	c.Telegram.Dialogs.ImportPerDay = 10
+	c.Telegram.Dialogs.MaxContacts = 50
+	c.Telegram.Dialogs.MutualPeers = 5
+	c.Telegram.Dialogs.GreetingsPerDay = 3
+	c.Telegram.Dialogs.GreetingMinInterval = 90
+	c.Telegram.Dialogs.Greetings = []string{
+		"Привет, {name}!",
+		"Привет, {name} 👋",
+		"{name}, привет! Как дела?",
+		"Добрый день, {name}",
+		"Привет! Как ты?",
+		"Привет 🙂",
+	}
 }
 
 func (c *Config) overrideFromEnv() {
@@ -174,6 +240,51 @@ func (c *Config) overrideFromEnv() {
 	if val := os.Getenv("TELEGRAM_WEB_URL"); val != "" {
 		c.Telegram.API.WebURL = val
 	}
+
+	// MTProto
+	if val := getEnvInt("TELEGRAM_MTPROTO_DIAL_TIMEOUT"); val > 0 {
+		c.Telegram.MTProto.DialTimeout = val
+	}
+	if val := getEnvInt("TELEGRAM_MTPROTO_ACTION_TIMEOUT"); val > 0 {
+		c.Telegram.MTProto.ActionTimeout = val
+	}
+	if val := os.Getenv("TELEGRAM_MTPROTO_DEVICE_MODEL"); val != "" {
+		c.Telegram.MTProto.DeviceModel = val
+	}
+	if val := getEnvInt("TELEGRAM_MTPROTO_POOL_IDLE_TIMEOUT"); val > 0 {
+		c.Telegram.MTProto.PoolIdleTimeout = val
+	}
+	if val := getEnvInt("TELEGRAM_MTPROTO_POOL_MAX_CONNECTIONS"); val > 0 {
+		c.Telegram.MTProto.PoolMaxConnections = val
+	}
+
+	// Posting
+	if val := getEnvInt("TELEGRAM_STORIES_PER_DAY"); val > 0 {
+		c.Telegram.Posting.StoriesPerDay = val
+	}
+	if val := getEnvInt("TELEGRAM_STORY_MIN_INTERVAL"); val > 0 {
+		c.Telegram.Posting.StoryMinInterval = val
+	}
+	if val := getEnvInt("TELEGRAM_SCHEDULED_PER_DAY"); val > 0 {
+		c.Telegram.Posting.ScheduledPerDay = val
+	}
+	if val := getEnvInt("TELEGRAM_SCHEDULED_MIN_INTERVAL"); val > 0 {
+		c.Telegram.Posting.ScheduledMinInterval = val
+	}
+
+	// Dialogs
+	if val := getEnvInt("TELEGRAM_CONTACTS_IMPORT_PER_DAY"); val > 0 {
+		c.Telegram.Dialogs.ImportPerDay = val
+	}
+	if val := getEnvInt("TELEGRAM_MAX_CONTACTS"); val > 0 {
+		c.Telegram.Dialogs.MaxContacts = val
+	}
+	if val := getEnvInt("TELEGRAM_GREETINGS_PER_DAY"); val > 0 {
+		c.Telegram.Dialogs.GreetingsPerDay = val
+	}
+	if val := getEnvInt("TELEGRAM_GREETING_MIN_INTERVAL"); val > 0 {
+		c.Telegram.Dialogs.GreetingMinInterval = val
+	}
 }
 
 func getEnvInt(key string) int {
@@ -209,3 +320,46 @@ func (c *Config) ToBrowserConfig() *models.BrowserConfig {
 		DefaultTimeout: time.Duration(c.Telegram.Registration.PageLoadTimeout) * time.Second,
 	}
 }
+
+// ToMTProtoConfig converts to models.MTProtoConfig
+func (c *Config) ToMTProtoConfig() *models.MTProtoConfig {
+	return &models.MTProtoConfig{
+		DefaultAPIID:       c.Telegram.API.DefaultAPIID,
+		DefaultAPIHash:     c.Telegram.API.DefaultAPIHash,
+		DialTimeout:        time.Duration(c.Telegram.MTProto.DialTimeout) * time.Second,
+		ActionTimeout:      time.Duration(c.Telegram.MTProto.ActionTimeout) * time.Second,
+		DeviceModel:        c.Telegram.MTProto.DeviceModel,
+		SystemVersion:      c.Telegram.MTProto.SystemVersion,
+		AppVersion:         c.Telegram.MTProto.AppVersion,
+		LangCode:           c.Telegram.MTProto.LangCode,
+		PoolIdleTimeout:    time.Duration(c.Telegram.MTProto.PoolIdleTimeout) * time.Second,
+		PoolMaxConnections: c.Telegram.MTProto.PoolMaxConnections,
+	}
+}
+
+// ToPostingConfig converts to models.PostingConfig
+func (c *Config) ToPostingConfig() *models.PostingConfig {
+	return &models.PostingConfig{
+		WebURL:               c.Telegram.API.WebURL,
+		StoriesPerDay:        c.Telegram.Posting.StoriesPerDay,
+		StoryMinInterval:     time.Duration(c.Telegram.Posting.StoryMinInterval) * time.Minute,
+		ScheduledPerDay:      c.Telegram.Posting.ScheduledPerDay,
+		ScheduledMinInterval: time.Duration(c.Telegram.Posting.ScheduledMinInterval) * time.Minute,
+		MaxScheduleAhead:     time.Duration(c.Telegram.Posting.MaxScheduleAhead) * time.Hour,
+		MaxMediaSize:         c.Telegram.Posting.MaxMediaSize,
+		ActionDelayMin:       c.Telegram.Posting.ActionDelayMin,
+		ActionDelayMax:       c.Telegram.Posting.ActionDelayMax,
+	}
+}
+
+// ToDialogsConfig converts to models.DialogsConfig
+func (c *Config) ToDialogsConfig() *models.DialogsConfig {
+	return &models.DialogsConfig{
+		ImportPerDay:        c.Telegram.Dialogs.ImportPerDay,
+		MaxContacts:         c.Telegram.Dialogs.MaxContacts,
+		MutualPeers:         c.Telegram.Dialogs.MutualPeers,
+		GreetingsPerDay:     c.Telegram.Dialogs.GreetingsPerDay,
+		GreetingMinInterval: time.Duration(c.Telegram.Dialogs.GreetingMinInterval) * time.Minute,
+		Greetings:           c.Telegram.Dialogs.Greetings,
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	pb "github.com/grigta/conveer/services/telegram-service/proto"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ActionStreamHandler serves the warming executor's action stream. Every action runs in its own
+// goroutine, so a slow MTProto call does not hold back the results of the others.
+type ActionStreamHandler struct {
+	warmingpb.UnimplementedActionExecutorServer
+	handler *GRPCHandler
+	logger  logger.Logger
+}
+
+func NewActionStreamHandler(handler *GRPCHandler, logger logger.Logger) *ActionStreamHandler {
+	return &ActionStreamHandler{
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+func (h *ActionStreamHandler) ExecuteActions(stream grpc.BidiStreamingServer[warmingpb.ActionRequest, warmingpb.ActionResult]) error {
+	ctx := stream.Context()
+
+	var (
+		wg     sync.WaitGroup
+		sendMu sync.Mutex
+	)
+	defer wg.Wait()
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result := h.execute(ctx, req)
+
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			if err := stream.Send(result); err != nil {
+				h.logger.Warn("Failed to send action result", "request_id", req.RequestId, "error", err)
+			}
+		}()
+	}
+}
+
+func (h *ActionStreamHandler) execute(ctx context.Context, req *warmingpb.ActionRequest) *warmingpb.ActionResult {
+	start := time.Now()
+	resp, err := h.dispatch(ctx, req)
+
+	result := &warmingpb.ActionResult{
+		RequestId: req.RequestId,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.ErrorType, result.Retryable = actionErrorType(err)
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Success = true
+	if resp != nil {
+		result.Details = map[string]string{
+			"peer":       resp.Peer,
+			"message_id": strconv.Itoa(int(resp.MessageId)),
+		}
+	}
+	return result
+}
+
+// dispatch runs the action through the unary handler, so both paths validate and map errors alike
+func (h *ActionStreamHandler) dispatch(ctx context.Context, req *warmingpb.ActionRequest) (*pb.ActionResponse, error) {
+	params := req.Params
+
+	switch req.ActionType {
+	case "join_group":
+		return h.handler.JoinChannel(ctx, &pb.JoinChannelRequest{
+			AccountId: req.AccountId,
+			Channel:   params["channel"],
+		})
+	case "send_message":
+		return h.handler.SendMessage(ctx, &pb.SendMessageRequest{
+			AccountId: req.AccountId,
+			Peer:      params["peer"],
+			Text:      params["text"],
+		})
+	case "react_message":
+		messageID, _ := strconv.Atoi(params["message_id"])
+		return h.handler.ReactToMessage(ctx, &pb.ReactToMessageRequest{
+			AccountId: req.AccountId,
+			Peer:      params["peer"],
+			MessageId: int32(messageID),
+			Reaction:  params["reaction"],
+		})
+	case "post_story":
+		return h.handler.PostStory(ctx, &pb.PostStoryRequest{
+			AccountId: req.AccountId,
+			MediaUrl:  params["media_url"],
+			Caption:   params["caption"],
+		})
+	case "schedule_message":
+		scheduled := &pb.ScheduleMessageRequest{
+			AccountId: req.AccountId,
+			Peer:      params["peer"],
+			Text:      params["text"],
+			MediaUrl:  params["media_url"],
+		}
+		if sendAt, err := time.Parse(time.RFC3339, params["send_at"]); err == nil {
+			scheduled.SendAt = timestamppb.New(sendAt)
+		}
+		return h.handler.ScheduleMessage(ctx, scheduled)
+	case "seed_dialog":
+		return h.handler.SeedDialog(ctx, &pb.SeedDialogRequest{
+			AccountId: req.AccountId,
+		})
+	default:
+		return nil, errUnsupportedAction
+	}
+}
+
+var errUnsupportedAction = errors.New("action is not supported over the stream")
+
+// actionErrorType maps handler errors to the error types of the warming executor and tells
+// whether sending the action again right away may succeed
+func actionErrorType(err error) (string, bool) {
+	if errors.Is(err, errUnsupportedAction) {
+		return "unsupported", false
+	}
+
+	switch status.Code(err) {
+	case codes.ResourceExhausted:
+		// Flood waits and cadence limits last minutes to hours, an immediate retry only makes them longer
+		return "rate_limit", false
+	case codes.FailedPrecondition:
+		return "auth_failed", false
+	case codes.NotFound:
+		return "not_found", false
+	case codes.Unavailable:
+		return "network", true
+	case codes.DeadlineExceeded:
+		return "timeout", true
+	default:
+		return "unknown", false
+	}
+}
@@ -2,12 +2,18 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
 
+	"github.com/grigta/conveer/pkg/crypto"
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/telegram-service/internal/models"
 	"github.com/grigta/conveer/services/telegram-service/internal/service"
 	pb "github.com/grigta/conveer/services/telegram-service/proto"
 
+	"github.com/gotd/td/session"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,14 +23,16 @@ import (
 
 type GRPCHandler struct {
 	pb.UnimplementedTelegramServiceServer
-	service service.TelegramService
-	logger  logger.Logger
+	service   service.TelegramService
+	encryptor *crypto.Encryptor
+	logger    logger.Logger
 }
 
-func NewGRPCHandler(service service.TelegramService, logger logger.Logger) *GRPCHandler {
+func NewGRPCHandler(service service.TelegramService, encryptor *crypto.Encryptor, logger logger.Logger) *GRPCHandler {
 	return &GRPCHandler{
-		service: service,
-		logger:  logger,
+		service:   service,
+		encryptor: encryptor,
+		logger:    logger,
 	}
 }
 
@@ -40,6 +48,7 @@ func (h *GRPCHandler) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 		UseRandomProfile: req.UseRandomProfile,
 		ApiID:            int(req.ApiId),
 		ApiHash:          req.ApiHash,
+		PersonaID:        req.PersonaId,
 	}
 
 	account, err := h.service.CreateAccount(ctx, registrationReq)
@@ -151,6 +160,229 @@ func (h *GRPCHandler) GetStatistics(ctx context.Context, req *emptypb.Empty) (*p
 	}, nil
 }
 
+func (h *GRPCHandler) JoinChannel(ctx context.Context, req *pb.JoinChannelRequest) (*pb.ActionResponse, error) {
+	accountID, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+	if req.Channel == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel is required")
+	}
+
+	if err := h.service.JoinChannel(ctx, accountID, req.Channel); err != nil {
+		return nil, actionError("failed to join channel", err)
+	}
+
+	return &pb.ActionResponse{
+		AccountId: req.AccountId,
+		Peer:      req.Channel,
+	}, nil
+}
+
+func (h *GRPCHandler) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.ActionResponse, error) {
+	accountID, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+	if req.Peer == "" || req.Text == "" {
+		return nil, status.Error(codes.InvalidArgument, "peer and text are required")
+	}
+
+	messageID, err := h.service.SendMessage(ctx, accountID, req.Peer, req.Text, int(req.ReplyToMessageId))
+	if err != nil {
+		return nil, actionError("failed to send message", err)
+	}
+
+	return &pb.ActionResponse{
+		AccountId: req.AccountId,
+		Peer:      req.Peer,
+		MessageId: int32(messageID),
+	}, nil
+}
+
+func (h *GRPCHandler) ReactToMessage(ctx context.Context, req *pb.ReactToMessageRequest) (*pb.ActionResponse, error) {
+	accountID, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+	if req.Peer == "" || req.Reaction == "" {
+		return nil, status.Error(codes.InvalidArgument, "peer and reaction are required")
+	}
+
+	messageID, err := h.service.ReactToMessage(ctx, accountID, req.Peer, int(req.MessageId), req.Reaction)
+	if err != nil {
+		return nil, actionError("failed to react to message", err)
+	}
+
+	return &pb.ActionResponse{
+		AccountId: req.AccountId,
+		Peer:      req.Peer,
+		MessageId: int32(messageID),
+	}, nil
+}
+
+func (h *GRPCHandler) PostStory(ctx context.Context, req *pb.PostStoryRequest) (*pb.ActionResponse, error) {
+	accountID, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+	if req.MediaUrl == "" {
+		return nil, status.Error(codes.InvalidArgument, "media_url is required")
+	}
+
+	if err := h.service.PostStory(ctx, accountID, &models.StoryRequest{
+		MediaURL: req.MediaUrl,
+		Caption:  req.Caption,
+	}); err != nil {
+		return nil, actionError("failed to post story", err)
+	}
+
+	return &pb.ActionResponse{
+		AccountId: req.AccountId,
+	}, nil
+}
+
+func (h *GRPCHandler) ScheduleMessage(ctx context.Context, req *pb.ScheduleMessageRequest) (*pb.ActionResponse, error) {
+	accountID, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+	if req.Peer == "" || req.SendAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "peer and send_at are required")
+	}
+
+	if err := h.service.ScheduleMessage(ctx, accountID, &models.ScheduledMessageRequest{
+		Peer:     req.Peer,
+		Text:     req.Text,
+		MediaURL: req.MediaUrl,
+		SendAt:   req.SendAt.AsTime(),
+	}); err != nil {
+		return nil, actionError("failed to schedule message", err)
+	}
+
+	return &pb.ActionResponse{
+		AccountId: req.AccountId,
+		Peer:      req.Peer,
+	}, nil
+}
+
+func (h *GRPCHandler) ImportContacts(ctx context.Context, req *pb.ImportContactsRequest) (*pb.ImportContactsResponse, error) {
+	accountID, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	contacts := make([]models.Contact, 0, len(req.Contacts))
+	for _, contact := range req.Contacts {
+		if contact.Phone == "" {
+			return nil, status.Error(codes.InvalidArgument, "contact phone is required")
+		}
+		contacts = append(contacts, models.Contact{
+			Phone:     contact.Phone,
+			FirstName: contact.FirstName,
+			LastName:  contact.LastName,
+		})
+	}
+
+	result, err := h.service.ImportContacts(ctx, accountID, contacts)
+	if err != nil {
+		return nil, actionError("failed to import contacts", err)
+	}
+
+	return &pb.ImportContactsResponse{
+		AccountId:     req.AccountId,
+		Imported:      int32(result.Imported),
+		NotFound:      int32(result.NotFound),
+		TotalContacts: int32(result.TotalContacts),
+	}, nil
+}
+
+func (h *GRPCHandler) SeedDialog(ctx context.Context, req *pb.SeedDialogRequest) (*pb.ActionResponse, error) {
+	accountID, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	peer, messageID, err := h.service.SeedDialog(ctx, accountID)
+	if err != nil {
+		return nil, actionError("failed to seed dialog", err)
+	}
+
+	return &pb.ActionResponse{
+		AccountId: req.AccountId,
+		Peer:      peer,
+		MessageId: int32(messageID),
+	}, nil
+}
+
+// GetSessionData hands the account session to the export in the bot. The session fields are
+// encrypted with the shared key, they never leave the service in the clear.
+func (h *GRPCHandler) GetSessionData(ctx context.Context, req *pb.GetSessionDataRequest) (*pb.SessionData, error) {
+	accountID, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	account, err := h.service.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "account not found: %v", err)
+	}
+
+	data := &pb.SessionData{AccountId: req.AccountId}
+	fields := []struct {
+		value string
+		dst   *string
+	}{
+		{account.Phone, &data.Phone},
+		{account.SessionString, &data.SessionString},
+		{string(account.Cookies), &data.Cookies},
+		{string(account.WebStorage), &data.LocalStorage},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		if *field.dst, err = h.encryptor.Encrypt(field.value); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encrypt session data: %v", err)
+		}
+	}
+
+	data.DcId, data.ServerAddress, data.Port = sessionDC(account.SessionString)
+	return data, nil
+}
+
+// sessionDC reads the data center of a stored MTProto session, zero values if the session is
+// missing or not in the gotd storage format
+func sessionDC(sessionString string) (int32, string, int32) {
+	var stored struct{ Data session.Data }
+	if sessionString == "" || json.Unmarshal([]byte(sessionString), &stored) != nil {
+		return 0, "", 0
+	}
+
+	host, portStr, err := net.SplitHostPort(stored.Data.Addr)
+	if err != nil {
+		return int32(stored.Data.DC), stored.Data.Addr, 0
+	}
+	port, _ := strconv.Atoi(portStr)
+	return int32(stored.Data.DC), host, int32(port)
+}
+
+// actionError maps warming action failures to codes the warming service can act on
+func actionError(msg string, err error) error {
+	var floodErr *service.FloodWaitError
+	switch {
+	case errors.As(err, &floodErr), errors.Is(err, service.ErrPostingCadence), errors.Is(err, service.ErrDialogCadence):
+		return status.Errorf(codes.ResourceExhausted, "%s: %v", msg, err)
+	case errors.Is(err, service.ErrNoMTProtoSession), errors.Is(err, service.ErrNoWebSession),
+		errors.Is(err, service.ErrWebSessionExpired), errors.Is(err, service.ErrAccountNotActive):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	case errors.Is(err, service.ErrNoMessages), errors.Is(err, service.ErrNoContacts):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
 func (h *GRPCHandler) accountToProto(account *models.TelegramAccount) *pb.Account {
 	protoAccount := &pb.Account{
 		Id:             account.ID.Hex(),
@@ -167,6 +399,7 @@ func (h *GRPCHandler) accountToProto(account *models.TelegramAccount) *pb.Accoun
 		ErrorMessage:   account.ErrorMessage,
 		RetryCount:     int32(account.RetryCount),
 		HasTwoFactor:   account.TwoFactorSecret != "",
+		PersonaId:      account.PersonaID,
 		CreatedAt:      timestamppb.New(account.CreatedAt),
 		UpdatedAt:      timestamppb.New(account.UpdatedAt),
 	}
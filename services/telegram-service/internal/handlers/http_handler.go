@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -13,14 +14,16 @@ import (
 )
 
 type HTTPHandler struct {
-	service service.TelegramService
-	logger  logger.Logger
+	service     service.TelegramService
+	logger      logger.Logger
+	browserPool service.BrowserManager
 }
 
-func NewHTTPHandler(service service.TelegramService, logger logger.Logger) *HTTPHandler {
+func NewHTTPHandler(service service.TelegramService, browserPool service.BrowserManager, logger logger.Logger) *HTTPHandler {
 	return &HTTPHandler{
-		service: service,
-		logger:  logger,
+		service:     service,
+		logger:      logger,
+		browserPool: browserPool,
 	}
 }
 
@@ -38,6 +41,9 @@ func (h *HTTPHandler) RegisterRoutes(router *gin.Engine) {
 		}
 
 		api.GET("/statistics", h.GetStatistics)
+
+		api.GET("/browser-pool", h.GetBrowserPool)
+		api.PUT("/browser-pool", h.ResizeBrowserPool)
 	}
 }
 
@@ -204,3 +210,37 @@ func (h *HTTPHandler) GetStatistics(c *gin.Context) {
 
 	c.JSON(http.StatusOK, stats)
 }
+
+func (h *HTTPHandler) GetBrowserPool(c *gin.Context) {
+	c.JSON(http.StatusOK, h.browserPool.GetPoolStats())
+}
+
+// ResizeBrowserPool grows or shrinks the browser pool without a restart
+func (h *HTTPHandler) ResizeBrowserPool(c *gin.Context) {
+	var req struct {
+		Size int `json:"size" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	stats, err := h.browserPool.Resize(c.Request.Context(), req.Size)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPoolSize) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to resize browser pool", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -34,6 +34,7 @@ type TelegramAccount struct {
 	ActivationID    string                 `bson:"activation_id,omitempty" json:"activation_id,omitempty"`
 	SessionString   string                 `bson:"session_string,encrypted" json:"-"`
 	Cookies         []byte                 `bson:"cookies,encrypted" json:"-"`
+	WebStorage      []byte                 `bson:"web_storage,encrypted" json:"-"` // web.telegram.org localStorage snapshot
 	UserAgent       string                 `bson:"user_agent" json:"user_agent,omitempty"`
 	Fingerprint     map[string]interface{} `bson:"fingerprint" json:"fingerprint,omitempty"`
 	RegistrationIP  string                 `bson:"registration_ip" json:"registration_ip,omitempty"`
@@ -44,6 +45,8 @@ type TelegramAccount struct {
 	RetryCount      int                    `bson:"retry_count" json:"retry_count"`
 	ApiID           int                    `bson:"api_id,omitempty" json:"api_id,omitempty"`
 	ApiHash         string                 `bson:"api_hash,encrypted" json:"-"`
+	PersonaID       string                 `bson:"persona_id,omitempty" json:"persona_id,omitempty"`
+	Contacts        []Contact              `bson:"contacts,omitempty" json:"-"`
 }
 
 type AccountStatistics struct {
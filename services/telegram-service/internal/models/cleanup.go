@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Resources released when an abandoned registration is compensated
+const (
+	CleanupResourceActivation = "activation"
+	CleanupResourceProxy      = "proxy"
+	CleanupResourceAccount    = "account"
+)
+
+const (
+	CleanupResultReclaimed = "reclaimed"
+	CleanupResultFailed    = "failed"
+)
+
+// CleanupAudit records one compensation step taken for an abandoned registration
+type CleanupAudit struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SessionID     primitive.ObjectID `bson:"session_id" json:"session_id"`
+	AccountID     primitive.ObjectID `bson:"account_id" json:"account_id"`
+	Step          RegistrationStep   `bson:"step" json:"step"` // step the registration was stuck on
+	Resource      string             `bson:"resource" json:"resource"`
+	ResourceID    string             `bson:"resource_id" json:"resource_id"`
+	Result        string             `bson:"result" json:"result"`
+	Error         string             `bson:"error,omitempty" json:"error,omitempty"`
+	StuckSince    time.Time          `bson:"stuck_since" json:"stuck_since"`
+	CompensatedAt time.Time          `bson:"compensated_at" json:"compensated_at"`
+}
+
+// CleanupSummary counts the compensation steps of a period by resource
+type CleanupSummary struct {
+	Sessions    int64            `json:"sessions"`
+	Reclaimed   map[string]int64 `json:"reclaimed"`
+	Failed      map[string]int64 `json:"failed"`
+	PeriodStart time.Time        `json:"period_start"`
+	PeriodEnd   time.Time        `json:"period_end"`
+}
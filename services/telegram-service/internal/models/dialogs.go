@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+type ContactSource string
+
+const (
+	// ContactSourceImported contacts come from a list supplied by the caller
+	ContactSourceImported ContactSource = "imported"
+	// ContactSourceMutual contacts are other accounts in warming, so both sides of a dialog are ours
+	ContactSourceMutual ContactSource = "mutual"
+)
+
+// Contact is an entry of the account's Telegram address book
+type Contact struct {
+	Phone      string        `bson:"phone,encrypted" json:"phone"`
+	FirstName  string        `bson:"first_name" json:"first_name"`
+	LastName   string        `bson:"last_name,omitempty" json:"last_name,omitempty"`
+	UserID     int64         `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	AccountID  string        `bson:"account_id,omitempty" json:"account_id,omitempty"`
+	Source     ContactSource `bson:"source" json:"source"`
+	ImportedAt time.Time     `bson:"imported_at" json:"imported_at"`
+	GreetedAt  *time.Time    `bson:"greeted_at,omitempty" json:"greeted_at,omitempty"`
+}
+
+type ContactImportResult struct {
+	Imported      int `json:"imported"`
+	NotFound      int `json:"not_found"`
+	TotalContacts int `json:"total_contacts"`
+}
+
+// DialogsConfig limits how fast a single account grows its address book and starts new dialogs
+type DialogsConfig struct {
+	ImportPerDay        int           `json:"import_per_day"`
+	MaxContacts         int           `json:"max_contacts"`
+	MutualPeers         int           `json:"mutual_peers"`
+	GreetingsPerDay     int           `json:"greetings_per_day"`
+	GreetingMinInterval time.Duration `json:"greeting_min_interval"`
+	Greetings           []string      `json:"greetings"`
+}
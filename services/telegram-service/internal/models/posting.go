@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type PostingAction string
+
+const (
+	PostingActionStory           PostingAction = "post_story"
+	PostingActionScheduleMessage PostingAction = "schedule_message"
+)
+
+type StoryRequest struct {
+	MediaURL string `json:"media_url"`
+	Caption  string `json:"caption,omitempty"`
+}
+
+type ScheduledMessageRequest struct {
+	Peer     string    `json:"peer"`
+	Text     string    `json:"text"`
+	MediaURL string    `json:"media_url,omitempty"`
+	SendAt   time.Time `json:"send_at"`
+}
+
+// PostingConfig limits how often a single account creates content through the web client
+type PostingConfig struct {
+	WebURL               string        `json:"web_url"`
+	StoriesPerDay        int           `json:"stories_per_day"`
+	StoryMinInterval     time.Duration `json:"story_min_interval"`
+	ScheduledPerDay      int           `json:"scheduled_per_day"`
+	ScheduledMinInterval time.Duration `json:"scheduled_min_interval"`
+	MaxScheduleAhead     time.Duration `json:"max_schedule_ahead"`
+	MaxMediaSize         int64         `json:"max_media_size"`
+	ActionDelayMin       int           `json:"action_delay_min"`
+	ActionDelayMax       int           `json:"action_delay_max"`
+}
@@ -18,6 +18,7 @@ const (
 	StepAvatarUpload      RegistrationStep = "avatar_upload"
 	StepTwoFactorSetup    RegistrationStep = "two_factor_setup"
 	StepComplete          RegistrationStep = "complete"
+	StepAbandoned         RegistrationStep = "abandoned"
 )
 
 type RegistrationRequest struct {
@@ -31,6 +32,8 @@ type RegistrationRequest struct {
 	UseRandomProfile  bool      `json:"use_random_profile,omitempty"`
 	ApiID             int       `json:"api_id,omitempty"`
 	ApiHash           string    `json:"api_hash,omitempty"`
+	PersonaID         string    `json:"persona_id,omitempty"`
+	PersonaReservationID string `json:"persona_reservation_id,omitempty"`
 }
 
 type RegistrationSession struct {
@@ -92,4 +95,18 @@ type BrowserConfig struct {
 	Headless       bool          `json:"headless"`
 	UserDataDir    string        `json:"user_data_dir"`
 	DefaultTimeout time.Duration `json:"default_timeout"`
+}
+
+// MTProtoConfig controls the API sessions used for warming actions
+type MTProtoConfig struct {
+	DefaultAPIID       int           `json:"default_api_id"`
+	DefaultAPIHash     string        `json:"-"`
+	DialTimeout        time.Duration `json:"dial_timeout"`
+	ActionTimeout      time.Duration `json:"action_timeout"`
+	DeviceModel        string        `json:"device_model"`
+	SystemVersion      string        `json:"system_version"`
+	AppVersion         string        `json:"app_version"`
+	LangCode           string        `json:"lang_code"`
+	PoolIdleTimeout    time.Duration `json:"pool_idle_timeout"`
+	PoolMaxConnections int           `json:"pool_max_connections"`
 }
\ No newline at end of file
@@ -96,6 +96,31 @@ func (r *AccountRepository) ListByStatus(ctx context.Context, status models.Acco
 	return accounts, total, nil
 }
 
+// SampleWarmingPeers returns random accounts in warming or ready to be added as contacts of another account
+func (r *AccountRepository) SampleWarmingPeers(ctx context.Context, exclude []primitive.ObjectID, limit int) ([]*models.TelegramAccount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"_id":    bson.M{"$nin": exclude},
+			"status": bson.M{"$in": []models.AccountStatus{models.StatusWarming, models.StatusReady}},
+			"phone":  bson.M{"$nin": []interface{}{"", nil}},
+		}}},
+		{{Key: "$sample", Value: bson.M{"size": limit}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample warming peers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var accounts []*models.TelegramAccount
+	if err := cursor.All(ctx, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to decode warming peers: %w", err)
+	}
+
+	return accounts, nil
+}
+
 func (r *AccountRepository) Update(ctx context.Context, account *models.TelegramAccount) error {
 	account.UpdatedAt = time.Now()
 
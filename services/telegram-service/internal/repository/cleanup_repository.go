@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/telegram-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CleanupRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCleanupRepository(db *mongo.Database) *CleanupRepository {
+	return &CleanupRepository{
+		collection: db.Collection("telegram_cleanup_audit"),
+	}
+}
+
+func (r *CleanupRepository) Create(ctx context.Context, audit *models.CleanupAudit) error {
+	audit.ID = primitive.NewObjectID()
+	if audit.CompensatedAt.IsZero() {
+		audit.CompensatedAt = time.Now()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, audit); err != nil {
+		return fmt.Errorf("failed to create cleanup audit: %w", err)
+	}
+	return nil
+}
+
+// Summarize counts the compensation steps taken in [since, until) by resource and result
+func (r *CleanupRepository) Summarize(ctx context.Context, since, until time.Time) (*models.CleanupSummary, error) {
+	match := bson.M{"compensated_at": bson.M{"$gte": since, "$lt": until}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"resource": "$resource", "result": "$result"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize cleanup audit: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID struct {
+			Resource string `bson:"resource"`
+			Result   string `bson:"result"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode cleanup summary: %w", err)
+	}
+
+	summary := &models.CleanupSummary{
+		Reclaimed:   make(map[string]int64),
+		Failed:      make(map[string]int64),
+		PeriodStart: since,
+		PeriodEnd:   until,
+	}
+	for _, row := range rows {
+		if row.ID.Result == models.CleanupResultReclaimed {
+			summary.Reclaimed[row.ID.Resource] += row.Count
+		} else {
+			summary.Failed[row.ID.Resource] += row.Count
+		}
+	}
+
+	sessions, err := r.collection.Distinct(ctx, "session_id", match)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count compensated sessions: %w", err)
+	}
+	summary.Sessions = int64(len(sessions))
+
+	return summary, nil
+}
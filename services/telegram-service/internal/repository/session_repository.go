@@ -176,3 +176,33 @@ func (r *SessionRepository) GetStuckSessions(ctx context.Context, stuckDuration
 
 	return sessions, nil
 }
+
+// ClaimStuck marks a session stuck since before threshold as abandoned so only one reaper compensates it.
+// Returns nil when the session made progress or was claimed by another replica in the meantime.
+func (r *SessionRepository) ClaimStuck(ctx context.Context, sessionID primitive.ObjectID, threshold time.Time, reason string) (*models.RegistrationSession, error) {
+	filter := bson.M{
+		"_id":              sessionID,
+		"completed_at":     bson.M{"$eq": nil},
+		"last_activity_at": bson.M{"$lt": threshold},
+	}
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"current_step":     models.StepAbandoned,
+			"last_error":       reason,
+			"completed_at":     now,
+			"last_activity_at": now,
+		},
+	}
+
+	var session models.RegistrationSession
+	err := r.collection.FindOneAndUpdate(ctx, filter, update).Decode(&session)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim stuck session: %w", err)
+	}
+
+	return &session, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -12,9 +13,18 @@ import (
 	"github.com/playwright-community/playwright-go"
 )
 
+// browserTimezone is the timezone every browser context reports to Telegram Web
+const browserTimezone = "America/New_York"
+
+// maxBrowserPoolSize caps runtime resizing, each browser holds a few hundred MB
+const maxBrowserPoolSize = 100
+
+var ErrInvalidPoolSize = errors.New("invalid browser pool size")
+
 type BrowserInstance struct {
 	Browser   playwright.Browser
 	InUse     bool
+	Draining  bool // Closed on release instead of returning to the pool after a shrink
 	CreatedAt time.Time
 	ProxyURL  string
 }
@@ -25,6 +35,7 @@ type BrowserManager interface {
 	ReleaseBrowser(browser playwright.Browser) error
 	Shutdown(ctx context.Context) error
 	GetPoolStats() PoolStats
+	Resize(ctx context.Context, size int) (PoolStats, error)
 }
 
 type browserManager struct {
@@ -45,9 +56,11 @@ type ProxyConfig struct {
 }
 
 type PoolStats struct {
-	TotalBrowsers    int
-	AvailableBrowsers int
-	InUseBrowsers    int
+	TotalBrowsers     int `json:"total_browsers"`
+	AvailableBrowsers int `json:"available_browsers"`
+	InUseBrowsers     int `json:"in_use_browsers"`
+	DrainingBrowsers  int `json:"draining_browsers"`
+	TargetSize        int `json:"target_size"`
 }
 
 func NewBrowserManager(config *models.BrowserConfig, metrics MetricsCollector, logger logger.Logger) BrowserManager {
@@ -150,7 +163,7 @@ func (m *browserManager) AcquireBrowser(ctx context.Context, proxyConfig *ProxyC
 
 	// Try to find an available browser with matching proxy
 	for _, instance := range m.pool {
-		if !instance.InUse && instance.ProxyURL == proxyConfig.Server {
+		if !instance.InUse && !instance.Draining && instance.ProxyURL == proxyConfig.Server {
 			instance.InUse = true
 
 			// Create new context for the browser
@@ -161,7 +174,7 @@ func (m *browserManager) AcquireBrowser(ctx context.Context, proxyConfig *ProxyC
 				},
 				UserAgent: playwright.String(generateUserAgent()),
 				Locale:    playwright.String("en-US"),
-				TimezoneID: playwright.String("America/New_York"),
+				TimezoneId: playwright.String(browserTimezone),
 			}
 
 			context, err := instance.Browser.NewContext(contextOptions)
@@ -173,6 +186,7 @@ func (m *browserManager) AcquireBrowser(ctx context.Context, proxyConfig *ProxyC
 			if m.metrics != nil {
 				m.metrics.IncrementBrowserAcquisitions()
 			}
+			m.recordPoolMetrics(m.poolStatsLocked())
 
 			return instance.Browser, context, nil
 		}
@@ -194,12 +208,23 @@ func (m *browserManager) ReleaseBrowser(browser playwright.Browser) error {
 	m.poolMu.Lock()
 	defer m.poolMu.Unlock()
 
-	for _, instance := range m.pool {
+	for i, instance := range m.pool {
 		if instance.Browser == browser {
-			instance.InUse = false
 			if m.metrics != nil {
 				m.metrics.IncrementBrowserReleases()
 			}
+
+			if instance.Draining {
+				// The pool was shrunk while the browser was busy
+				m.pool = append(m.pool[:i], m.pool[i+1:]...)
+				m.recordPoolMetrics(m.poolStatsLocked())
+				go m.closeInstance(instance)
+				m.logger.Info("Drained browser closed after release", "proxy", instance.ProxyURL)
+				return nil
+			}
+
+			instance.InUse = false
+			m.recordPoolMetrics(m.poolStatsLocked())
 			return nil
 		}
 	}
@@ -270,14 +295,24 @@ func (m *browserManager) GetPoolStats() PoolStats {
 	m.poolMu.RLock()
 	defer m.poolMu.RUnlock()
 
+	return m.poolStatsLocked()
+}
+
+// poolStatsLocked expects poolMu to be held
+func (m *browserManager) poolStatsLocked() PoolStats {
 	stats := PoolStats{
 		TotalBrowsers: len(m.pool),
+		TargetSize:    m.config.PoolSize,
 	}
 
 	for _, instance := range m.pool {
-		if instance.InUse {
+		switch {
+		case instance.Draining:
+			stats.DrainingBrowsers++
 			stats.InUseBrowsers++
-		} else {
+		case instance.InUse:
+			stats.InUseBrowsers++
+		default:
 			stats.AvailableBrowsers++
 		}
 	}
@@ -285,6 +320,115 @@ func (m *browserManager) GetPoolStats() PoolStats {
 	return stats
 }
 
+// Resize changes the target pool size at runtime. Growing warms the new browsers in the
+// background. Shrinking closes idle browsers right away and drains busy ones: they finish
+// their current job and are closed on release instead of returning to the pool.
+func (m *browserManager) Resize(ctx context.Context, size int) (PoolStats, error) {
+	if size < 1 || size > maxBrowserPoolSize {
+		return PoolStats{}, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPoolSize, maxBrowserPoolSize)
+	}
+
+	m.poolMu.Lock()
+	previous := m.config.PoolSize
+	m.config.PoolSize = size
+
+	active := 0
+	for _, instance := range m.pool {
+		if !instance.Draining {
+			active++
+		}
+	}
+
+	// Idle browsers go first, busy ones are only marked
+	excess := active - size
+	var toClose []*BrowserInstance
+	kept := make([]*BrowserInstance, 0, len(m.pool))
+	for _, instance := range m.pool {
+		if excess > 0 && !instance.InUse && !instance.Draining {
+			toClose = append(toClose, instance)
+			excess--
+			continue
+		}
+		kept = append(kept, instance)
+	}
+	for _, instance := range kept {
+		if excess > 0 && instance.InUse && !instance.Draining {
+			instance.Draining = true
+			excess--
+		}
+	}
+
+	// Growing first takes back browsers that are still draining
+	missing := size - active
+	for _, instance := range kept {
+		if missing > 0 && instance.Draining {
+			instance.Draining = false
+			missing--
+		}
+	}
+
+	m.pool = kept
+	stats := m.poolStatsLocked()
+	m.recordPoolMetrics(stats)
+	m.poolMu.Unlock()
+
+	for _, instance := range toClose {
+		m.closeInstance(instance)
+	}
+	if missing > 0 {
+		go m.warmBrowsers(missing)
+	}
+
+	m.logger.Info("Browser pool resized", "from", previous, "to", size, "closed", len(toClose), "draining", stats.DrainingBrowsers, "warming", max(missing, 0))
+	return stats, nil
+}
+
+// warmBrowsers launches browsers one by one until the pool reaches its target size again.
+// It stops early when the pool is shrunk meanwhile or the manager shuts down.
+func (m *browserManager) warmBrowsers(count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case <-m.shutdownCh:
+			return
+		default:
+		}
+
+		m.poolMu.RLock()
+		stats := m.poolStatsLocked()
+		m.poolMu.RUnlock()
+		if stats.TotalBrowsers-stats.DrainingBrowsers >= stats.TargetSize {
+			return
+		}
+
+		if err := m.createBrowserInstance(nil); err != nil {
+			m.logger.Error("Failed to warm browser for resized pool", "error", err)
+			return
+		}
+		m.recordPoolMetrics(m.GetPoolStats())
+	}
+}
+
+// closeInstance closes the browser's contexts before the browser itself, so pages get
+// their unload handlers and cookies are flushed
+func (m *browserManager) closeInstance(instance *BrowserInstance) {
+	for _, browserContext := range instance.Browser.Contexts() {
+		if err := browserContext.Close(); err != nil {
+			m.logger.Warn("Failed to close browser context", "error", err)
+		}
+	}
+	if err := instance.Browser.Close(); err != nil {
+		m.logger.Error("Failed to close browser", "error", err)
+	}
+}
+
+func (m *browserManager) recordPoolMetrics(stats PoolStats) {
+	if m.metrics == nil {
+		return
+	}
+	m.metrics.UpdateBrowserPoolSize(stats.TotalBrowsers)
+	m.metrics.UpdateBrowserPoolUtilization(stats.InUseBrowsers, stats.TargetSize)
+}
+
 func (m *browserManager) Shutdown(ctx context.Context) error {
 	close(m.shutdownCh)
 
@@ -20,10 +20,16 @@ type MetricsCollector interface {
 	IncrementProxySuccess()
 	IncrementProxyFailure()
 	UpdateBrowserPoolSize(size int)
+	UpdateBrowserPoolUtilization(inUse, target int)
 	IncrementBrowserAcquisitions()
 	IncrementBrowserReleases()
 	IncrementManualInterventions()
 	RecordStepDuration(step string, seconds float64)
+	IncrementMTProtoActions(action, result string)
+	IncrementMTProtoConnections(event string)
+	UpdateMTProtoPoolSize(size int)
+	IncrementPostingActions(action, result string)
+	IncrementResourceCleanups(resource, result string)
 }
 
 type metricsCollector struct {
@@ -40,10 +46,18 @@ type metricsCollector struct {
 	proxySuccess           prometheus.Counter
 	proxyFailures          prometheus.Counter
 	browserPoolSize        prometheus.Gauge
+	browserPoolInUse       prometheus.Gauge
+	browserPoolTarget      prometheus.Gauge
+	browserPoolUtilization prometheus.Gauge
 	browserAcquisitions    prometheus.Counter
 	browserReleases        prometheus.Counter
 	manualInterventions    prometheus.Counter
 	stepDuration           *prometheus.HistogramVec
+	mtprotoActions         *prometheus.CounterVec
+	mtprotoConnections     *prometheus.CounterVec
+	mtprotoPoolSize        prometheus.Gauge
+	postingActions         *prometheus.CounterVec
+	resourceCleanups       *prometheus.CounterVec
 }
 
 func NewMetricsCollector(namespace string) MetricsCollector {
@@ -143,6 +157,27 @@ func NewMetricsCollector(namespace string) MetricsCollector {
 				Help:      "Current size of browser pool",
 			},
 		),
+		browserPoolInUse: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "browser_pool_in_use",
+				Help:      "Number of browsers currently running a job, including draining ones",
+			},
+		),
+		browserPoolTarget: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "browser_pool_target_size",
+				Help:      "Target size of browser pool, changed at runtime by resizing",
+			},
+		),
+		browserPoolUtilization: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "browser_pool_utilization",
+				Help:      "Share of the target browser pool size currently in use",
+			},
+		),
 		browserAcquisitions: promauto.NewCounter(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -173,6 +208,45 @@ func NewMetricsCollector(namespace string) MetricsCollector {
 			},
 			[]string{"step"},
 		),
+		mtprotoActions: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "mtproto_actions_total",
+				Help:      "Total number of MTProto warming actions by action and result",
+			},
+			[]string{"action", "result"},
+		),
+		mtprotoConnections: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "mtproto_connections_total",
+				Help:      "Total number of pooled MTProto connection events: opened, reused, rotated, expired, evicted, closed, failed",
+			},
+			[]string{"event"},
+		),
+		mtprotoPoolSize: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "mtproto_pool_connections",
+				Help:      "Current number of pooled MTProto connections",
+			},
+		),
+		postingActions: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "posting_actions_total",
+				Help:      "Total number of stories and scheduled messages posted via the web client by result",
+			},
+			[]string{"action", "result"},
+		),
+		resourceCleanups: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "resource_cleanups_total",
+				Help:      "Total number of compensation steps for abandoned registrations by resource and result",
+			},
+			[]string{"resource", "result"},
+		),
 	}
 }
 
@@ -228,6 +302,14 @@ func (m *metricsCollector) UpdateBrowserPoolSize(size int) {
 	m.browserPoolSize.Set(float64(size))
 }
 
+func (m *metricsCollector) UpdateBrowserPoolUtilization(inUse, target int) {
+	m.browserPoolInUse.Set(float64(inUse))
+	m.browserPoolTarget.Set(float64(target))
+	if target > 0 {
+		m.browserPoolUtilization.Set(float64(inUse) / float64(target))
+	}
+}
+
 func (m *metricsCollector) IncrementBrowserAcquisitions() {
 	m.browserAcquisitions.Inc()
 }
@@ -243,3 +325,23 @@ func (m *metricsCollector) IncrementManualInterventions() {
 func (m *metricsCollector) RecordStepDuration(step string, seconds float64) {
 	m.stepDuration.WithLabelValues(step).Observe(seconds)
 }
+
+func (m *metricsCollector) IncrementMTProtoActions(action, result string) {
+	m.mtprotoActions.WithLabelValues(action, result).Inc()
+}
+
+func (m *metricsCollector) IncrementMTProtoConnections(event string) {
+	m.mtprotoConnections.WithLabelValues(event).Inc()
+}
+
+func (m *metricsCollector) UpdateMTProtoPoolSize(size int) {
+	m.mtprotoPoolSize.Set(float64(size))
+}
+
+func (m *metricsCollector) IncrementPostingActions(action, result string) {
+	m.postingActions.WithLabelValues(action, result).Inc()
+}
+
+func (m *metricsCollector) IncrementResourceCleanups(resource, result string) {
+	m.resourceCleanups.WithLabelValues(resource, result).Inc()
+}
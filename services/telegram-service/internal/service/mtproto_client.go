@@ -0,0 +1,326 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"github.com/grigta/conveer/services/telegram-service/internal/models"
+
+	"github.com/gotd/td/session"
+	"github.com/gotd/td/telegram/dcs"
+	"github.com/gotd/td/telegram/message"
+	"github.com/gotd/td/tg"
+	"github.com/gotd/td/tgerr"
+	"golang.org/x/net/proxy"
+)
+
+var (
+	ErrNoMTProtoSession = errors.New("account has no MTProto session")
+	ErrNoMessages       = errors.New("peer has no messages to react to")
+)
+
+// mtprotoProxyRequirements are the capabilities requested from proxy-service for accounts
+// driven over MTProto: calls and media transports go over UDP through the same exit
+var mtprotoProxyRequirements = []string{"udp"}
+
+// FloodWaitError is returned when Telegram asks the account to back off before the next request
+type FloodWaitError struct {
+	Wait time.Duration
+}
+
+func (e *FloodWaitError) Error() string {
+	return fmt.Sprintf("flood wait for %s", e.Wait)
+}
+
+// MTProtoSession carries everything needed to act on behalf of an account over MTProto.
+// SessionString is updated in place when Telegram rotates auth keys or migrates the DC.
+type MTProtoSession struct {
+	AccountID     string
+	SessionString string
+	ApiID         int
+	ApiHash       string
+	Proxy         *proxypb.ProxyResponse
+}
+
+type MTProtoClient interface {
+	JoinChannel(ctx context.Context, sess *MTProtoSession, channel string) error
+	SendMessage(ctx context.Context, sess *MTProtoSession, peer, text string, replyTo int) (int, error)
+	ReactToMessage(ctx context.Context, sess *MTProtoSession, peer string, messageID int, reaction string) (int, error)
+	ImportContacts(ctx context.Context, sess *MTProtoSession, contacts []models.Contact) error
+	SendToContact(ctx context.Context, sess *MTProtoSession, phone, text string) (int, error)
+}
+
+type mtprotoClient struct {
+	pool   MTProtoPool
+	logger logger.Logger
+}
+
+func NewMTProtoClient(pool MTProtoPool, logger logger.Logger) MTProtoClient {
+	return &mtprotoClient{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+func (c *mtprotoClient) JoinChannel(ctx context.Context, sess *MTProtoSession, channel string) error {
+	return c.run(ctx, sess, func(ctx context.Context, api *tg.Client) error {
+		sender := message.NewSender(api)
+
+		var err error
+		if isInviteLink(channel) {
+			_, err = sender.JoinLink(ctx, channel)
+		} else {
+			_, err = sender.Resolve(channel).Join(ctx)
+		}
+
+		if tgerr.Is(err, "USER_ALREADY_PARTICIPANT") {
+			return nil
+		}
+		return err
+	})
+}
+
+func (c *mtprotoClient) SendMessage(ctx context.Context, sess *MTProtoSession, peer, text string, replyTo int) (int, error) {
+	var messageID int
+	err := c.run(ctx, sess, func(ctx context.Context, api *tg.Client) error {
+		builder := message.NewSender(api).Resolve(peer)
+
+		var (
+			updates tg.UpdatesClass
+			err     error
+		)
+		if replyTo > 0 {
+			updates, err = builder.Reply(replyTo).Text(ctx, text)
+		} else {
+			updates, err = builder.Text(ctx, text)
+		}
+		if err != nil {
+			return err
+		}
+
+		messageID = sentMessageID(updates)
+		return nil
+	})
+
+	return messageID, err
+}
+
+func (c *mtprotoClient) ReactToMessage(ctx context.Context, sess *MTProtoSession, peer string, messageID int, reaction string) (int, error) {
+	err := c.run(ctx, sess, func(ctx context.Context, api *tg.Client) error {
+		inputPeer, err := message.NewSender(api).Resolve(peer).AsInputPeer(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Without an explicit target react to the latest post, which is what a reader scrolling the channel sees first
+		if messageID == 0 {
+			latest, err := latestMessageID(ctx, api, inputPeer)
+			if err != nil {
+				return err
+			}
+			messageID = latest
+		}
+
+		_, err = api.MessagesSendReaction(ctx, &tg.MessagesSendReactionRequest{
+			Peer:     inputPeer,
+			MsgID:    messageID,
+			Reaction: []tg.ReactionClass{&tg.ReactionEmoji{Emoticon: reaction}},
+		})
+		return err
+	})
+
+	return messageID, err
+}
+
+// ImportContacts adds contacts to the account's address book and fills in the user IDs of the
+// phones registered in Telegram, contacts left without a user ID were not imported
+func (c *mtprotoClient) ImportContacts(ctx context.Context, sess *MTProtoSession, contacts []models.Contact) error {
+	return c.run(ctx, sess, func(ctx context.Context, api *tg.Client) error {
+		input := make([]tg.InputPhoneContact, 0, len(contacts))
+		for i, contact := range contacts {
+			input = append(input, tg.InputPhoneContact{
+				ClientID:  int64(i),
+				Phone:     contact.Phone,
+				FirstName: contact.FirstName,
+				LastName:  contact.LastName,
+			})
+		}
+
+		result, err := api.ContactsImportContacts(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		for _, imported := range result.Imported {
+			if imported.ClientID >= 0 && int(imported.ClientID) < len(contacts) {
+				contacts[imported.ClientID].UserID = imported.UserID
+			}
+		}
+		return nil
+	})
+}
+
+// SendToContact writes to an imported contact by phone, which resolves even without a username
+func (c *mtprotoClient) SendToContact(ctx context.Context, sess *MTProtoSession, phone, text string) (int, error) {
+	var messageID int
+	err := c.run(ctx, sess, func(ctx context.Context, api *tg.Client) error {
+		updates, err := message.NewSender(api).ResolvePhone(phone).Text(ctx, text)
+		if err != nil {
+			return err
+		}
+
+		messageID = sentMessageID(updates)
+		return nil
+	})
+
+	return messageID, err
+}
+
+// run executes fn over the account's pooled connection and maps Telegram back-off errors
+func (c *mtprotoClient) run(ctx context.Context, sess *MTProtoSession, fn func(ctx context.Context, api *tg.Client) error) error {
+	err := c.pool.Run(ctx, sess, fn)
+
+	if wait, ok := tgerr.AsFloodWait(err); ok {
+		c.logger.Warn("MTProto flood wait", "account_id", sess.AccountID, "wait", wait.String())
+		return &FloodWaitError{Wait: wait}
+	}
+
+	return err
+}
+
+// proxyDialer dials Telegram DCs through the account's proxy, SOCKS5 natively and anything else
+// as an HTTP CONNECT tunnel
+func proxyDialer(p *proxypb.ProxyResponse, timeout time.Duration) (dcs.DialFunc, error) {
+	direct := &net.Dialer{Timeout: timeout}
+	if p == nil || p.Ip == "" {
+		return direct.DialContext, nil
+	}
+
+	addr := net.JoinHostPort(p.Ip, fmt.Sprintf("%d", p.Port))
+
+	switch strings.ToLower(p.Protocol) {
+	case "socks5", "socks":
+		var auth *proxy.Auth
+		if p.Username != "" {
+			auth = &proxy.Auth{User: p.Username, Password: p.Password}
+		}
+
+		dialer, err := proxy.SOCKS5("tcp", addr, auth, direct)
+		if err != nil {
+			return nil, err
+		}
+
+		contextDialer, ok := dialer.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("SOCKS5 dialer does not support context")
+		}
+		return contextDialer.DialContext, nil
+	default:
+		return func(ctx context.Context, network, target string) (net.Conn, error) {
+			return dialHTTPConnect(ctx, direct, addr, target, p.Username, p.Password)
+		}, nil
+	}
+}
+
+// dialHTTPConnect tunnels a TCP connection through an HTTP proxy with the CONNECT method
+func dialHTTPConnect(ctx context.Context, dialer *net.Dialer, proxyAddr, target, username, password string) (net.Conn, error) {
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	req := fmt.Sprintf("CONNECT %s HTTP/1.1\r\nHost: %s\r\n", target, target)
+	if username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		req += fmt.Sprintf("Proxy-Authorization: Basic %s\r\n", credentials)
+	}
+	req += "\r\n"
+
+	if _, err := conn.Write([]byte(req)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT failed: %s", resp.Status)
+	}
+
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// loadSession accepts both the native gotd JSON session and the Telethon string session format
+func loadSession(ctx context.Context, storage *session.StorageMemory, sessionString string) error {
+	if strings.HasPrefix(strings.TrimSpace(sessionString), "{") {
+		return storage.StoreSession(ctx, []byte(sessionString))
+	}
+
+	data, err := session.TelethonSession(sessionString)
+	if err != nil {
+		return err
+	}
+
+	loader := session.Loader{Storage: storage}
+	return loader.Save(ctx, data)
+}
+
+func isInviteLink(channel string) bool {
+	return strings.Contains(channel, "/+") || strings.Contains(channel, "joinchat/")
+}
+
+func latestMessageID(ctx context.Context, api *tg.Client, peer tg.InputPeerClass) (int, error) {
+	history, err := api.MessagesGetHistory(ctx, &tg.MessagesGetHistoryRequest{
+		Peer:  peer,
+		Limit: 1,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	modified, ok := history.AsModified()
+	if !ok || len(modified.GetMessages()) == 0 {
+		return 0, ErrNoMessages
+	}
+
+	return modified.GetMessages()[0].GetID(), nil
+}
+
+func sentMessageID(updates tg.UpdatesClass) int {
+	switch u := updates.(type) {
+	case *tg.UpdateShortSentMessage:
+		return u.ID
+	case *tg.Updates:
+		for _, update := range u.Updates {
+			switch upd := update.(type) {
+			case *tg.UpdateMessageID:
+				return upd.ID
+			case *tg.UpdateNewMessage:
+				return upd.Message.GetID()
+			case *tg.UpdateNewChannelMessage:
+				return upd.Message.GetID()
+			}
+		}
+	}
+	return 0
+}
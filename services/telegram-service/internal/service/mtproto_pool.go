@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"github.com/grigta/conveer/services/telegram-service/internal/models"
+
+	"github.com/gotd/td/session"
+	"github.com/gotd/td/telegram"
+	"github.com/gotd/td/telegram/dcs"
+	"github.com/gotd/td/tg"
+)
+
+var errConnectionClosed = errors.New("MTProto connection closed")
+
+// MTProtoPool keeps one MTProto connection per account open over the account's bound proxy,
+// so consecutive warming actions reuse the authorized connection instead of handshaking each time
+type MTProtoPool interface {
+	Run(ctx context.Context, sess *MTProtoSession, fn func(ctx context.Context, api *tg.Client) error) error
+	Start(ctx context.Context)
+	CloseAccount(accountID string)
+	CloseProxy(proxyID string)
+	Shutdown(ctx context.Context)
+}
+
+type pooledConn struct {
+	accountID string
+	proxyID   string
+	proxyKey  string
+	session   string // session string the connection was last synced with
+	storage   *session.StorageMemory
+	api       *tg.Client
+	err       error
+	ready     chan struct{}
+	readyOnce sync.Once
+	done      chan struct{}
+	cancel    context.CancelFunc
+	lastUsed  time.Time
+	active    int
+}
+
+func (c *pooledConn) markReady(err error) {
+	c.readyOnce.Do(func() {
+		c.err = err
+		close(c.ready)
+	})
+}
+
+type mtprotoPool struct {
+	config  *models.MTProtoConfig
+	logger  logger.Logger
+	metrics MetricsCollector
+
+	mu    sync.Mutex
+	conns map[string]*pooledConn
+}
+
+func NewMTProtoPool(config *models.MTProtoConfig, logger logger.Logger, metrics MetricsCollector) MTProtoPool {
+	return &mtprotoPool{
+		config:  config,
+		logger:  logger,
+		metrics: metrics,
+		conns:   make(map[string]*pooledConn),
+	}
+}
+
+// Run executes fn over the account's pooled connection, opening it first if needed, and stores
+// the refreshed session back
+func (p *mtprotoPool) Run(ctx context.Context, sess *MTProtoSession, fn func(ctx context.Context, api *tg.Client) error) error {
+	if sess.SessionString == "" {
+		return ErrNoMTProtoSession
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.ActionTimeout)
+	defer cancel()
+
+	conn, err := p.acquire(sess)
+	if err != nil {
+		return err
+	}
+	defer p.release(conn)
+
+	select {
+	case <-conn.ready:
+	case <-ctx.Done():
+		// A connection that could not come up within the action timeout is not kept around
+		p.closeIfNotReady(conn)
+		return ctx.Err()
+	}
+	if conn.err != nil {
+		return conn.err
+	}
+
+	err = fn(ctx, conn.api)
+
+	if data, loadErr := conn.storage.LoadSession(ctx); loadErr == nil && len(data) > 0 {
+		sess.SessionString = string(data)
+
+		p.mu.Lock()
+		conn.session = sess.SessionString
+		p.mu.Unlock()
+	}
+
+	return err
+}
+
+func (p *mtprotoPool) acquire(sess *MTProtoSession) (*pooledConn, error) {
+	key := proxyKey(sess.Proxy)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if conn, ok := p.conns[sess.AccountID]; ok {
+		// The account was moved to another proxy, or its session was replaced while the
+		// connection sat idle
+		stale := conn.proxyKey != key || (conn.active == 0 && conn.session != sess.SessionString)
+		if !stale {
+			conn.active++
+			conn.lastUsed = time.Now()
+			p.metrics.IncrementMTProtoConnections("reused")
+			return conn, nil
+		}
+		p.closeLocked(conn, "rotated")
+	}
+
+	if p.config.PoolMaxConnections > 0 && len(p.conns) >= p.config.PoolMaxConnections {
+		p.evictIdleLocked()
+	}
+
+	conn, err := p.dial(sess, key)
+	if err != nil {
+		p.metrics.IncrementMTProtoConnections("failed")
+		return nil, err
+	}
+
+	conn.active++
+	p.conns[sess.AccountID] = conn
+	p.metrics.IncrementMTProtoConnections("opened")
+	p.metrics.UpdateMTProtoPoolSize(len(p.conns))
+
+	return conn, nil
+}
+
+func (p *mtprotoPool) release(conn *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.active--
+	conn.lastUsed = time.Now()
+}
+
+// dial starts the connection in the background. It stays open until it is closed by the pool
+// or Telegram drops it for good.
+func (p *mtprotoPool) dial(sess *MTProtoSession, key string) (*pooledConn, error) {
+	storage := &session.StorageMemory{}
+	if err := loadSession(context.Background(), storage, sess.SessionString); err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	apiID, apiHash := sess.ApiID, sess.ApiHash
+	if apiID == 0 || apiHash == "" {
+		apiID, apiHash = p.config.DefaultAPIID, p.config.DefaultAPIHash
+	}
+	if apiID == 0 || apiHash == "" {
+		return nil, fmt.Errorf("no API credentials configured")
+	}
+
+	dial, err := proxyDialer(sess.Proxy, p.config.DialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proxy dialer: %w", err)
+	}
+
+	client := telegram.NewClient(apiID, apiHash, telegram.Options{
+		SessionStorage: storage,
+		Resolver:       dcs.Plain(dcs.PlainOptions{Dial: dial}),
+		DialTimeout:    p.config.DialTimeout,
+		NoUpdates:      true,
+		Device: telegram.DeviceConfig{
+			DeviceModel:    p.config.DeviceModel,
+			SystemVersion:  p.config.SystemVersion,
+			AppVersion:     p.config.AppVersion,
+			SystemLangCode: p.config.LangCode,
+			LangCode:       p.config.LangCode,
+		},
+	})
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	conn := &pooledConn{
+		accountID: sess.AccountID,
+		proxyKey:  key,
+		session:   sess.SessionString,
+		storage:   storage,
+		ready:     make(chan struct{}),
+		done:      make(chan struct{}),
+		cancel:    cancel,
+		lastUsed:  time.Now(),
+	}
+	if sess.Proxy != nil {
+		conn.proxyID = sess.Proxy.Id
+	}
+
+	go func() {
+		defer close(conn.done)
+
+		err := client.Run(runCtx, func(ctx context.Context) error {
+			status, err := client.Auth().Status(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to check auth status: %w", err)
+			}
+			if !status.Authorized {
+				return ErrNoMTProtoSession
+			}
+
+			conn.api = client.API()
+			conn.markReady(nil)
+
+			<-ctx.Done()
+			return nil
+		})
+
+		if err == nil {
+			err = errConnectionClosed
+		}
+		conn.markReady(err)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		// Still in the pool means nobody closed it, Telegram or the proxy dropped it
+		if p.conns[conn.accountID] == conn {
+			delete(p.conns, conn.accountID)
+			p.metrics.IncrementMTProtoConnections("failed")
+			p.metrics.UpdateMTProtoPoolSize(len(p.conns))
+			p.logger.Warn("MTProto connection dropped", "account_id", conn.accountID, "error", err)
+		}
+	}()
+
+	return conn, nil
+}
+
+func (p *mtprotoPool) closeIfNotReady(conn *pooledConn) {
+	select {
+	case <-conn.ready:
+		return
+	default:
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closeLocked(conn, "failed")
+}
+
+// closeLocked removes the connection from the pool and stops it, callers hold p.mu
+func (p *mtprotoPool) closeLocked(conn *pooledConn, event string) {
+	if p.conns[conn.accountID] != conn {
+		return
+	}
+
+	delete(p.conns, conn.accountID)
+	conn.cancel()
+
+	p.metrics.IncrementMTProtoConnections(event)
+	p.metrics.UpdateMTProtoPoolSize(len(p.conns))
+}
+
+// evictIdleLocked closes the least recently used idle connection. When every connection is busy
+// the pool grows past the limit rather than blocking actions.
+func (p *mtprotoPool) evictIdleLocked() {
+	var oldest *pooledConn
+	for _, conn := range p.conns {
+		if conn.active > 0 {
+			continue
+		}
+		if oldest == nil || conn.lastUsed.Before(oldest.lastUsed) {
+			oldest = conn
+		}
+	}
+
+	if oldest != nil {
+		p.closeLocked(oldest, "evicted")
+	}
+}
+
+// Start closes connections left idle longer than the idle timeout until ctx is done
+func (p *mtprotoPool) Start(ctx context.Context) {
+	if p.config.PoolIdleTimeout <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.closeIdle()
+		}
+	}
+}
+
+func (p *mtprotoPool) closeIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, conn := range p.conns {
+		if conn.active == 0 && time.Since(conn.lastUsed) > p.config.PoolIdleTimeout {
+			p.closeLocked(conn, "expired")
+		}
+	}
+}
+
+// CloseAccount drops the account's connection, the next action reconnects with a fresh proxy
+func (p *mtprotoPool) CloseAccount(accountID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if conn, ok := p.conns[accountID]; ok {
+		p.closeLocked(conn, "rotated")
+	}
+}
+
+// CloseProxy drops every connection going out through the proxy
+func (p *mtprotoPool) CloseProxy(proxyID string) {
+	if proxyID == "" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, conn := range p.conns {
+		if conn.proxyID == proxyID {
+			p.closeLocked(conn, "rotated")
+		}
+	}
+}
+
+// Shutdown closes all connections and waits for them to stop or ctx to expire
+func (p *mtprotoPool) Shutdown(ctx context.Context) {
+	p.mu.Lock()
+	conns := make([]*pooledConn, 0, len(p.conns))
+	for _, conn := range p.conns {
+		conns = append(conns, conn)
+		p.closeLocked(conn, "closed")
+	}
+	p.mu.Unlock()
+
+	for _, conn := range conns {
+		select {
+		case <-conn.done:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// proxyKey identifies the exit a connection was opened through, any change means the account's
+// proxy was rotated
+func proxyKey(p *proxypb.ProxyResponse) string {
+	if p == nil || p.Ip == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s|%s:%d|%s|%s", p.Protocol, p.Ip, p.Port, p.Username, p.Password)
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"github.com/grigta/conveer/services/telegram-service/internal/models"
+
+	"github.com/gotd/td/session"
+	"github.com/gotd/td/tg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// poolMetrics records the pool metrics; other metrics are not used by the pool
+type poolMetrics struct {
+	MetricsCollector
+
+	mu     sync.Mutex
+	events []string
+	size   int
+}
+
+func (m *poolMetrics) IncrementMTProtoConnections(event string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *poolMetrics) UpdateMTProtoPoolSize(size int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.size = size
+}
+
+func (m *poolMetrics) recorded() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.events...)
+}
+
+func newTestPool(config *models.MTProtoConfig) (*mtprotoPool, *poolMetrics) {
+	metrics := &poolMetrics{}
+	pool := NewMTProtoPool(config, logger.NewFromConfig(logger.Config{Output: io.Discard}), metrics).(*mtprotoPool)
+	return pool, metrics
+}
+
+func testProxy(id, ip string) *proxypb.ProxyResponse {
+	return &proxypb.ProxyResponse{Id: id, Ip: ip, Port: 1080, Protocol: "socks5"}
+}
+
+// addReadyConn puts an established connection into the pool without dialing Telegram
+func addReadyConn(t *testing.T, pool *mtprotoPool, sess *MTProtoSession, lastUsed time.Time) *pooledConn {
+	t.Helper()
+
+	storage := &session.StorageMemory{}
+	require.NoError(t, storage.StoreSession(context.Background(), []byte(sess.SessionString)))
+
+	conn := &pooledConn{
+		accountID: sess.AccountID,
+		proxyKey:  proxyKey(sess.Proxy),
+		session:   sess.SessionString,
+		storage:   storage,
+		ready:     make(chan struct{}),
+		done:      make(chan struct{}),
+		lastUsed:  lastUsed,
+	}
+	if sess.Proxy != nil {
+		conn.proxyID = sess.Proxy.Id
+	}
+
+	var ctx context.Context
+	ctx, conn.cancel = context.WithCancel(context.Background())
+	go func() {
+		<-ctx.Done()
+		close(conn.done)
+	}()
+	conn.markReady(nil)
+
+	pool.conns[sess.AccountID] = conn
+	return conn
+}
+
+func closed(conn *pooledConn) bool {
+	select {
+	case <-conn.done:
+		return true
+	case <-time.After(time.Second):
+		return false
+	}
+}
+
+func TestMTProtoPool_RunWithoutSession(t *testing.T) {
+	pool, _ := newTestPool(&models.MTProtoConfig{ActionTimeout: time.Second})
+
+	err := pool.Run(context.Background(), &MTProtoSession{AccountID: "a1"}, func(ctx context.Context, api *tg.Client) error {
+		t.Fatal("fn must not run without a session")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrNoMTProtoSession)
+}
+
+func TestMTProtoPool_RunReusesConnectionAndStoresSession(t *testing.T) {
+	pool, metrics := newTestPool(&models.MTProtoConfig{ActionTimeout: time.Second})
+	sess := &MTProtoSession{AccountID: "a1", SessionString: `{"v":1}`, Proxy: testProxy("p1", "10.0.0.1")}
+	conn := addReadyConn(t, pool, sess, time.Now())
+
+	// The connection refreshed its auth key while the action ran
+	ran := false
+	err := pool.Run(context.Background(), sess, func(ctx context.Context, api *tg.Client) error {
+		ran = true
+		return conn.storage.StoreSession(ctx, []byte(`{"v":2}`))
+	})
+	require.NoError(t, err)
+
+	assert.True(t, ran)
+	assert.Equal(t, `{"v":2}`, sess.SessionString)
+	assert.Equal(t, `{"v":2}`, conn.session)
+	assert.Equal(t, 0, conn.active)
+	assert.Equal(t, []string{"reused"}, metrics.recorded())
+	assert.Same(t, conn, pool.conns["a1"])
+}
+
+func TestMTProtoPool_RunReturnsConnectionError(t *testing.T) {
+	pool, _ := newTestPool(&models.MTProtoConfig{ActionTimeout: time.Second})
+	sess := &MTProtoSession{AccountID: "a1", SessionString: `{"v":1}`}
+	conn := addReadyConn(t, pool, sess, time.Now())
+	conn.err = ErrNoMTProtoSession
+
+	err := pool.Run(context.Background(), sess, func(ctx context.Context, api *tg.Client) error {
+		t.Fatal("fn must not run over a failed connection")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrNoMTProtoSession)
+}
+
+func TestMTProtoPool_RunPassesActionError(t *testing.T) {
+	pool, _ := newTestPool(&models.MTProtoConfig{ActionTimeout: time.Second})
+	sess := &MTProtoSession{AccountID: "a1", SessionString: `{"v":1}`}
+	addReadyConn(t, pool, sess, time.Now())
+
+	actionErr := errors.New("flood")
+	err := pool.Run(context.Background(), sess, func(ctx context.Context, api *tg.Client) error {
+		return actionErr
+	})
+	assert.ErrorIs(t, err, actionErr)
+}
+
+func TestMTProtoPool_AcquireRotatesOnProxyChange(t *testing.T) {
+	// Without API credentials the redial fails before reaching Telegram
+	pool, metrics := newTestPool(&models.MTProtoConfig{ActionTimeout: time.Second})
+	old := addReadyConn(t, pool, &MTProtoSession{AccountID: "a1", SessionString: `{"v":1}`, Proxy: testProxy("p1", "10.0.0.1")}, time.Now())
+
+	_, err := pool.acquire(&MTProtoSession{AccountID: "a1", SessionString: `{"v":1}`, Proxy: testProxy("p2", "10.0.0.2")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no API credentials")
+
+	assert.True(t, closed(old))
+	assert.NotContains(t, pool.conns, "a1")
+	assert.Equal(t, []string{"rotated", "failed"}, metrics.recorded())
+}
+
+func TestMTProtoPool_AcquireSessionChange(t *testing.T) {
+	pool, metrics := newTestPool(&models.MTProtoConfig{ActionTimeout: time.Second})
+	proxy := testProxy("p1", "10.0.0.1")
+	conn := addReadyConn(t, pool, &MTProtoSession{AccountID: "a1", SessionString: `{"v":1}`, Proxy: proxy}, time.Now())
+
+	// A busy connection is kept even though the caller has a newer session
+	conn.active = 1
+	reused, err := pool.acquire(&MTProtoSession{AccountID: "a1", SessionString: `{"v":2}`, Proxy: proxy})
+	require.NoError(t, err)
+	assert.Same(t, conn, reused)
+	pool.release(reused)
+	pool.release(conn)
+
+	// Once idle, a replaced session reconnects
+	_, err = pool.acquire(&MTProtoSession{AccountID: "a1", SessionString: `{"v":2}`, Proxy: proxy})
+	require.Error(t, err)
+	assert.True(t, closed(conn))
+	assert.Equal(t, []string{"reused", "rotated", "failed"}, metrics.recorded())
+}
+
+func TestMTProtoPool_AcquireEvictsLeastRecentlyUsed(t *testing.T) {
+	pool, metrics := newTestPool(&models.MTProtoConfig{ActionTimeout: time.Second, PoolMaxConnections: 2})
+	oldest := addReadyConn(t, pool, &MTProtoSession{AccountID: "a1", SessionString: `{}`}, time.Now().Add(-time.Hour))
+	busy := addReadyConn(t, pool, &MTProtoSession{AccountID: "a2", SessionString: `{}`}, time.Now().Add(-2*time.Hour))
+	busy.active = 1
+
+	_, err := pool.acquire(&MTProtoSession{AccountID: "a3", SessionString: `{}`})
+	require.Error(t, err)
+
+	assert.True(t, closed(oldest))
+	assert.NotContains(t, pool.conns, "a1")
+	assert.Contains(t, pool.conns, "a2")
+	assert.Equal(t, []string{"evicted", "failed"}, metrics.recorded())
+}
+
+func TestMTProtoPool_CloseIdle(t *testing.T) {
+	pool, metrics := newTestPool(&models.MTProtoConfig{PoolIdleTimeout: time.Minute})
+	idle := addReadyConn(t, pool, &MTProtoSession{AccountID: "a1", SessionString: `{}`}, time.Now().Add(-2*time.Minute))
+	recent := addReadyConn(t, pool, &MTProtoSession{AccountID: "a2", SessionString: `{}`}, time.Now())
+	busy := addReadyConn(t, pool, &MTProtoSession{AccountID: "a3", SessionString: `{}`}, time.Now().Add(-2*time.Minute))
+	busy.active = 1
+
+	pool.closeIdle()
+
+	assert.True(t, closed(idle))
+	assert.Contains(t, pool.conns, recent.accountID)
+	assert.Contains(t, pool.conns, busy.accountID)
+	assert.Equal(t, []string{"expired"}, metrics.recorded())
+	assert.Equal(t, 2, metrics.size)
+}
+
+func TestMTProtoPool_CloseProxy(t *testing.T) {
+	pool, _ := newTestPool(&models.MTProtoConfig{})
+	a1 := addReadyConn(t, pool, &MTProtoSession{AccountID: "a1", SessionString: `{}`, Proxy: testProxy("p1", "10.0.0.1")}, time.Now())
+	addReadyConn(t, pool, &MTProtoSession{AccountID: "a2", SessionString: `{}`, Proxy: testProxy("p2", "10.0.0.2")}, time.Now())
+
+	pool.CloseProxy("")
+	assert.Len(t, pool.conns, 2)
+
+	pool.CloseProxy("p1")
+	assert.True(t, closed(a1))
+	assert.NotContains(t, pool.conns, "a1")
+	assert.Contains(t, pool.conns, "a2")
+}
+
+func TestMTProtoPool_CloseAccount(t *testing.T) {
+	pool, metrics := newTestPool(&models.MTProtoConfig{})
+	conn := addReadyConn(t, pool, &MTProtoSession{AccountID: "a1", SessionString: `{}`}, time.Now())
+
+	pool.CloseAccount("a1")
+	pool.CloseAccount("missing")
+
+	assert.True(t, closed(conn))
+	assert.Empty(t, pool.conns)
+	assert.Equal(t, []string{"rotated"}, metrics.recorded())
+}
+
+func TestMTProtoPool_Shutdown(t *testing.T) {
+	pool, metrics := newTestPool(&models.MTProtoConfig{})
+	a1 := addReadyConn(t, pool, &MTProtoSession{AccountID: "a1", SessionString: `{}`}, time.Now())
+	a2 := addReadyConn(t, pool, &MTProtoSession{AccountID: "a2", SessionString: `{}`}, time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	pool.Shutdown(ctx)
+
+	assert.True(t, closed(a1))
+	assert.True(t, closed(a2))
+	assert.Empty(t, pool.conns)
+	assert.Equal(t, []string{"closed", "closed"}, metrics.recorded())
+	assert.Equal(t, 0, metrics.size)
+}
+
+func TestProxyKey(t *testing.T) {
+	assert.Empty(t, proxyKey(nil))
+	assert.Empty(t, proxyKey(&proxypb.ProxyResponse{}))
+	assert.Equal(t, proxyKey(testProxy("p1", "10.0.0.1")), proxyKey(testProxy("p2", "10.0.0.1")))
+	assert.NotEqual(t, proxyKey(testProxy("p1", "10.0.0.1")), proxyKey(testProxy("p1", "10.0.0.2")))
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"github.com/grigta/conveer/services/telegram-service/internal/models"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// PostingFlow creates content from an account through Telegram Web
+type PostingFlow interface {
+	PostStory(ctx context.Context, account *models.TelegramAccount, req *models.StoryRequest) error
+	ScheduleMessage(ctx context.Context, account *models.TelegramAccount, req *models.ScheduledMessageRequest) error
+}
+
+type postingFlow struct {
+	browserManager  BrowserManager
+	stealthInjector StealthInjector
+	proxyClient     proxypb.ProxyServiceClient
+	httpClient      *http.Client
+	config          *models.PostingConfig
+	logger          logger.Logger
+}
+
+func NewPostingFlow(
+	browserManager BrowserManager,
+	stealthInjector StealthInjector,
+	proxyClient proxypb.ProxyServiceClient,
+	config *models.PostingConfig,
+	logger logger.Logger,
+) PostingFlow {
+	return &postingFlow{
+		browserManager:  browserManager,
+		stealthInjector: stealthInjector,
+		proxyClient:     proxyClient,
+		httpClient:      &http.Client{Timeout: 60 * time.Second},
+		config:          config,
+		logger:          logger,
+	}
+}
+
+func (f *postingFlow) PostStory(ctx context.Context, account *models.TelegramAccount, req *models.StoryRequest) error {
+	mediaPath, err := f.downloadMedia(ctx, req.MediaURL)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(mediaPath)
+
+	browser, browserCtx, page, err := openWebSession(ctx, f.browserManager, f.proxyClient, f.stealthInjector, account, f.config.WebURL, f.logger)
+	if err != nil {
+		return err
+	}
+	defer closeWebSession(f.browserManager, browser, browserCtx, page, f.logger)
+
+	// Look around the chat list before opening the story editor
+	f.humanDelay()
+
+	addStory := page.Locator(".stories-my .story-add, button[aria-label*='story' i]").First()
+	if err := addStory.Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(10000),
+	}); err != nil {
+		return fmt.Errorf("story button not found: %w", err)
+	}
+
+	f.humanDelay()
+
+	if err := page.Locator("input[type='file']").First().SetInputFiles(mediaPath); err != nil {
+		return fmt.Errorf("failed to attach story media: %w", err)
+	}
+
+	// Give the editor time to render the preview
+	time.Sleep(time.Duration(2+rand.Intn(2)) * time.Second)
+
+	if req.Caption != "" {
+		caption := page.Locator(".story-caption [contenteditable='true'], .popup-story [contenteditable='true']").First()
+		if err := f.typeText(caption, req.Caption); err != nil {
+			f.logger.Warn("Failed to add story caption", "error", err, "account_id", account.ID.Hex())
+		}
+	}
+
+	f.humanDelay()
+
+	if err := page.Locator("button:has-text('Post Story'), button:has-text('Publish')").First().Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(10000),
+	}); err != nil {
+		return fmt.Errorf("failed to publish story: %w", err)
+	}
+
+	// Uploads are slow on mobile proxies
+	time.Sleep(time.Duration(5+rand.Intn(5)) * time.Second)
+
+	f.refreshWebStorage(page, account)
+	return nil
+}
+
+func (f *postingFlow) ScheduleMessage(ctx context.Context, account *models.TelegramAccount, req *models.ScheduledMessageRequest) error {
+	var mediaPath string
+	if req.MediaURL != "" {
+		var err error
+		mediaPath, err = f.downloadMedia(ctx, req.MediaURL)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(mediaPath)
+	}
+
+	browser, browserCtx, page, err := openWebSession(ctx, f.browserManager, f.proxyClient, f.stealthInjector, account, f.config.WebURL, f.logger)
+	if err != nil {
+		return err
+	}
+	defer closeWebSession(f.browserManager, browser, browserCtx, page, f.logger)
+
+	f.humanDelay()
+
+	if _, err := page.Goto(strings.TrimSuffix(f.config.WebURL, "/")+"/#"+normalizePeer(req.Peer), playwright.PageGotoOptions{
+		WaitUntil: playwright.WaitUntilStateNetworkidle,
+		Timeout:   playwright.Float(30000),
+	}); err != nil {
+		return fmt.Errorf("failed to open chat: %w", err)
+	}
+
+	input := page.Locator(".input-message-input[contenteditable='true']").First()
+	if err := input.WaitFor(playwright.LocatorWaitForOptions{
+		State:   playwright.WaitForSelectorStateVisible,
+		Timeout: playwright.Float(15000),
+	}); err != nil {
+		return fmt.Errorf("message input not found: %w", err)
+	}
+
+	f.humanDelay()
+
+	sendButton := page.Locator(".btn-send").First()
+	if mediaPath != "" {
+		if err := page.Locator("input[type='file']").First().SetInputFiles(mediaPath); err != nil {
+			return fmt.Errorf("failed to attach media: %w", err)
+		}
+
+		// With media the text goes into the caption of the send-media popup
+		input = page.Locator(".popup-send-photo .input-message-input[contenteditable='true']").First()
+		sendButton = page.Locator(".popup-send-photo .btn-primary").First()
+		time.Sleep(time.Duration(2+rand.Intn(2)) * time.Second)
+	}
+
+	if err := f.typeText(input, req.Text); err != nil {
+		return fmt.Errorf("failed to type message: %w", err)
+	}
+
+	f.humanDelay()
+
+	// Scheduling lives in the send button context menu
+	if err := sendButton.Click(playwright.LocatorClickOptions{
+		Button:  playwright.MouseButtonRight,
+		Timeout: playwright.Float(5000),
+	}); err != nil {
+		return fmt.Errorf("send button not found: %w", err)
+	}
+
+	if err := page.Locator(".btn-menu-item:has-text('Schedule Message')").First().Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(5000),
+	}); err != nil {
+		return fmt.Errorf("schedule option not found: %w", err)
+	}
+
+	if err := f.pickScheduleTime(page, req.SendAt); err != nil {
+		return err
+	}
+
+	time.Sleep(time.Duration(2+rand.Intn(2)) * time.Second)
+
+	f.refreshWebStorage(page, account)
+	return nil
+}
+
+// pickScheduleTime fills the date picker popup shown after choosing "Schedule Message"
+func (f *postingFlow) pickScheduleTime(page playwright.Page, sendAt time.Time) error {
+	popup := page.Locator(".popup-date-picker").First()
+	if err := popup.WaitFor(playwright.LocatorWaitForOptions{
+		State:   playwright.WaitForSelectorStateVisible,
+		Timeout: playwright.Float(5000),
+	}); err != nil {
+		return fmt.Errorf("date picker not found: %w", err)
+	}
+
+	// The picker works in the browser's local time, not the service's
+	loc, err := time.LoadLocation(browserTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := sendAt.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	day := popup.Locator(fmt.Sprintf("[data-timestamp='%d']", midnight.UnixMilli())).First()
+	if err := day.Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(5000),
+	}); err != nil {
+		return fmt.Errorf("schedule date not available: %w", err)
+	}
+
+	f.humanDelay()
+
+	timeInputs := popup.Locator(".date-picker-time input")
+	if err := timeInputs.Nth(0).Fill(fmt.Sprintf("%02d", local.Hour())); err != nil {
+		return fmt.Errorf("failed to set schedule hour: %w", err)
+	}
+	if err := timeInputs.Nth(1).Fill(fmt.Sprintf("%02d", local.Minute())); err != nil {
+		return fmt.Errorf("failed to set schedule minute: %w", err)
+	}
+
+	f.humanDelay()
+
+	if err := popup.Locator(".btn-primary").First().Click(); err != nil {
+		return fmt.Errorf("failed to confirm schedule: %w", err)
+	}
+
+	return nil
+}
+
+func (f *postingFlow) typeText(input playwright.Locator, text string) error {
+	if err := input.Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(5000),
+	}); err != nil {
+		return err
+	}
+
+	return input.Type(text, playwright.LocatorTypeOptions{
+		Delay: playwright.Float(float64(80 + rand.Intn(120))),
+	})
+}
+
+// downloadMedia fetches content library media into a temp file the browser can upload
+func (f *postingFlow) downloadMedia(ctx context.Context, mediaURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid media URL: %w", err)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download media: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download media: status %d", resp.StatusCode)
+	}
+
+	ext := path.Ext(req.URL.Path)
+	if ext == "" {
+		ext = ".jpg"
+	}
+
+	file, err := os.CreateTemp("", "telegram-media-*"+ext)
+	if err != nil {
+		return "", fmt.Errorf("failed to create media file: %w", err)
+	}
+	defer file.Close()
+
+	written, err := io.Copy(file, io.LimitReader(resp.Body, f.config.MaxMediaSize+1))
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to save media: %w", err)
+	}
+	if written > f.config.MaxMediaSize {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("media exceeds %d bytes", f.config.MaxMediaSize)
+	}
+
+	return file.Name(), nil
+}
+
+// refreshWebStorage keeps the stored snapshot current so the next action resumes the same web session
+func (f *postingFlow) refreshWebStorage(page playwright.Page, account *models.TelegramAccount) {
+	storage, err := captureWebStorage(page)
+	if err != nil {
+		f.logger.Warn("Failed to refresh web storage", "error", err, "account_id", account.ID.Hex())
+		return
+	}
+	account.WebStorage = storage
+}
+
+func (f *postingFlow) humanDelay() {
+	delay := f.config.ActionDelayMin
+	if f.config.ActionDelayMax > f.config.ActionDelayMin {
+		delay += rand.Intn(f.config.ActionDelayMax - f.config.ActionDelayMin)
+	}
+	time.Sleep(time.Duration(delay) * time.Millisecond)
+}
+
+// normalizePeer turns t.me links and bare usernames into the @username form Telegram Web routes by
+func normalizePeer(peer string) string {
+	peer = strings.TrimSpace(peer)
+	for _, prefix := range []string{"https://t.me/", "http://t.me/", "t.me/"} {
+		peer = strings.TrimPrefix(peer, prefix)
+	}
+
+	// Numeric IDs are routed as is
+	if _, err := strconv.ParseInt(peer, 10, 64); err == nil || strings.HasPrefix(peer, "@") {
+		return peer
+	}
+	return "@" + peer
+}
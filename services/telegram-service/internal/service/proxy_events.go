@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grigta/conveer/pkg/messaging"
+)
+
+const proxyEventsExchange = "proxy.events"
+
+// proxyEventRoutingKeys are the proxy-service events after which an account's pooled MTProto
+// connection no longer goes out through its current proxy
+var proxyEventRoutingKeys = []string{
+	"proxy.allocated",
+	"proxy.rotated",
+	"proxy.released",
+	"proxy.health_failed",
+}
+
+type proxyEvent struct {
+	ProxyID    string `json:"proxy_id"`
+	OldProxyID string `json:"old_proxy_id"`
+	AccountID  string `json:"account_id"`
+}
+
+// ConsumeProxyEvents closes pooled MTProto connections when proxy-service rebinds or drops the
+// proxy they use. Every replica holds its own pool, so each one consumes from an exclusive queue.
+func (s *telegramService) ConsumeProxyEvents(ctx context.Context, rabbit *messaging.RabbitMQ) error {
+	if err := rabbit.DeclareExchange(proxyEventsExchange, "topic", true, false); err != nil {
+		return fmt.Errorf("failed to declare proxy events exchange: %w", err)
+	}
+
+	queue, err := rabbit.DeclareQueue("", false, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to declare proxy events queue: %w", err)
+	}
+
+	for _, routingKey := range proxyEventRoutingKeys {
+		if err := rabbit.BindQueue(queue.Name, routingKey, proxyEventsExchange); err != nil {
+			return fmt.Errorf("failed to bind proxy events queue to %s: %w", routingKey, err)
+		}
+	}
+
+	handler := func(body []byte) error {
+		var event proxyEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			s.logger.Warn("Failed to decode proxy event", "error", err)
+			return nil
+		}
+
+		if event.AccountID != "" {
+			s.mtprotoPool.CloseAccount(event.AccountID)
+		}
+		s.mtprotoPool.CloseProxy(event.ProxyID)
+		s.mtprotoPool.CloseProxy(event.OldProxyID)
+		return nil
+	}
+
+	if err := rabbit.ConsumeWithHandler(ctx, queue.Name, "telegram-proxy-events", handler); err != nil {
+		return fmt.Errorf("failed to consume proxy events: %w", err)
+	}
+
+	s.logger.Info("Consuming proxy events for MTProto connection pool")
+	return nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	smspb "github.com/grigta/conveer/services/sms-service/proto"
+	"github.com/grigta/conveer/services/telegram-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	reaperInterval        = 5 * time.Minute
+	cleanupReportInterval = 24 * time.Hour
+	cleanupReportEvent    = "registration.cleanup_report"
+)
+
+// monitorStuckRegistrations compensates registrations that made no progress within the SLA
+func (s *telegramService) monitorStuckRegistrations(ctx context.Context) {
+	ticker := time.NewTicker(reaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.shutdownCh:
+			return
+		case <-ticker.C:
+			s.reapStuckRegistrations(ctx)
+		}
+	}
+}
+
+func (s *telegramService) reapStuckRegistrations(ctx context.Context) {
+	sla := time.Duration(s.config.Telegram.Monitoring.StuckRegistrationTimeout) * time.Minute
+	threshold := time.Now().Add(-sla)
+
+	sessions, err := s.sessionRepo.GetStuckSessions(ctx, sla)
+	if err != nil {
+		s.logger.Error("Failed to get stuck sessions", "error", err)
+		return
+	}
+
+	for _, stuck := range sessions {
+		reason := fmt.Sprintf("Registration abandoned at step %s", stuck.CurrentStep)
+
+		// Claiming re-checks the activity time, the flow may have moved on since the query
+		session, err := s.sessionRepo.ClaimStuck(ctx, stuck.ID, threshold, reason)
+		if err != nil {
+			s.logger.Error("Failed to claim stuck session", "session_id", stuck.ID.Hex(), "error", err)
+			continue
+		}
+		if session == nil {
+			continue
+		}
+
+		s.logger.Warn("Compensating stuck registration", "account_id", session.AccountID.Hex(), "step", session.CurrentStep)
+		s.metrics.IncrementManualInterventions()
+		s.compensateRegistration(ctx, session, reason)
+	}
+}
+
+// compensateRegistration releases what the registration acquired and audits every step.
+// Steps are independent, a failed one does not stop the rest.
+func (s *telegramService) compensateRegistration(ctx context.Context, session *models.RegistrationSession, reason string) {
+	if session.ActivationID != "" {
+		_, err := s.smsClient.CancelActivation(ctx, &smspb.CancelActivationRequest{
+			ActivationId: session.ActivationID,
+			Reason:       reason,
+		})
+		s.auditCleanup(ctx, session, models.CleanupResourceActivation, session.ActivationID, err)
+	}
+
+	if session.ProxyID != primitive.NilObjectID {
+		_, err := s.proxyClient.ReleaseProxy(ctx, &proxypb.ReleaseProxyRequest{
+			AccountId: session.AccountID.Hex(),
+		})
+		s.auditCleanup(ctx, session, models.CleanupResourceProxy, session.ProxyID.Hex(), err)
+	}
+
+	err := s.accountRepo.UpdateStatus(ctx, session.AccountID, models.StatusError, reason)
+	s.auditCleanup(ctx, session, models.CleanupResourceAccount, session.AccountID.Hex(), err)
+	if err == nil {
+		s.metrics.IncrementAccountStatusChange(models.StatusCreating, models.StatusError)
+		if account, getErr := s.accountRepo.GetByID(ctx, session.AccountID); getErr == nil {
+			s.publishAccountEvent("account.status.changed", account)
+		}
+	}
+}
+
+func (s *telegramService) auditCleanup(ctx context.Context, session *models.RegistrationSession, resource, resourceID string, stepErr error) {
+	audit := &models.CleanupAudit{
+		SessionID:  session.ID,
+		AccountID:  session.AccountID,
+		Step:       session.CurrentStep,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Result:     models.CleanupResultReclaimed,
+		StuckSince: session.LastActivityAt,
+	}
+	if stepErr != nil {
+		audit.Result = models.CleanupResultFailed
+		audit.Error = stepErr.Error()
+		s.logger.Error("Compensation step failed", "resource", resource, "resource_id", resourceID, "error", stepErr)
+	}
+
+	s.metrics.IncrementResourceCleanups(resource, audit.Result)
+
+	if err := s.cleanupRepo.Create(ctx, audit); err != nil {
+		s.logger.Error("Failed to record cleanup audit", "resource", resource, "resource_id", resourceID, "error", err)
+	}
+}
+
+// reportCleanups sends analytics the resources reclaimed over the last day
+func (s *telegramService) reportCleanups(ctx context.Context) {
+	ticker := time.NewTicker(cleanupReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.shutdownCh:
+			return
+		case now := <-ticker.C:
+			s.publishCleanupReport(ctx, now.Add(-cleanupReportInterval), now)
+		}
+	}
+}
+
+func (s *telegramService) publishCleanupReport(ctx context.Context, since, until time.Time) {
+	if s.rabbitPublisher == nil {
+		return
+	}
+
+	summary, err := s.cleanupRepo.Summarize(ctx, since, until)
+	if err != nil {
+		s.logger.Error("Failed to summarize cleanups", "error", err)
+		return
+	}
+
+	event := map[string]interface{}{
+		"type":         cleanupReportEvent,
+		"platform":     "telegram",
+		"sessions":     summary.Sessions,
+		"reclaimed":    summary.Reclaimed,
+		"failed":       summary.Failed,
+		"period_start": summary.PeriodStart.Unix(),
+		"period_end":   summary.PeriodEnd.Unix(),
+		"timestamp":    time.Now().Unix(),
+	}
+
+	if err := s.rabbitPublisher.Publish("telegram.events", cleanupReportEvent, event); err != nil {
+		s.logger.Error("Failed to publish cleanup report", "error", err)
+	}
+}
@@ -32,8 +32,19 @@ type registrationFlow struct {
 	config          *models.RegistrationConfig
 	logger          logger.Logger
 	metrics         MetricsCollector
+	publisher       eventPublisher
 }
 
+// eventPublisher is the part of the RabbitMQ publisher the flow reports step timings through
+type eventPublisher interface {
+	Publish(exchange, routingKey string, message interface{}) error
+}
+
+const (
+	registrationStepEvent = "registration.step_completed"
+	registrationStepTotal = "total"
+)
+
 func NewRegistrationFlow(
 	accountRepo *repository.AccountRepository,
 	sessionRepo *repository.SessionRepository,
@@ -45,6 +56,7 @@ func NewRegistrationFlow(
 	config *models.RegistrationConfig,
 	logger logger.Logger,
 	metrics MetricsCollector,
+	publisher eventPublisher,
 ) RegistrationFlow {
 	return &registrationFlow{
 		accountRepo:     accountRepo,
@@ -57,6 +69,7 @@ func NewRegistrationFlow(
 		config:          config,
 		logger:          logger,
 		metrics:         metrics,
+		publisher:       publisher,
 	}
 }
 
@@ -74,6 +87,7 @@ func (f *registrationFlow) StartRegistration(ctx context.Context, req *models.Re
 		Status:    models.StatusCreating,
 		ApiID:     req.ApiID,
 		ApiHash:   req.ApiHash,
+		PersonaID: req.PersonaID,
 	}
 
 	// Generate fingerprint
@@ -102,6 +116,9 @@ func (f *registrationFlow) StartRegistration(ctx context.Context, req *models.Re
 	// Execute registration steps
 	result := f.executeRegistrationFlow(ctx, account, session, req)
 	result.Duration = time.Since(startTime).Seconds()
+	if result.Success {
+		f.publishStepCompleted(account, registrationStepTotal, result.Duration)
+	}
 
 	return result, nil
 }
@@ -112,6 +129,11 @@ func (f *registrationFlow) executeRegistrationFlow(
 	session *models.RegistrationSession,
 	req *models.RegistrationRequest,
 ) *models.RegistrationResult {
+	fail := func(step models.RegistrationStep, err error) *models.RegistrationResult {
+		result, _ := f.handleError(account, step, err, time.Now())
+		return result
+	}
+
 	var browser playwright.Browser
 	var browserContext playwright.BrowserContext
 	var page playwright.Page
@@ -130,19 +152,19 @@ func (f *registrationFlow) executeRegistrationFlow(
 	// Step 1: Allocate proxy
 	proxyConfig, err := f.allocateProxy(ctx, account, session)
 	if err != nil {
-		return f.handleError(account, models.StepProxyAllocation, err, time.Now())
+		return fail(models.StepProxyAllocation, err)
 	}
 
 	// Step 2: Acquire browser with proxy
 	browser, browserContext, err = f.browserManager.AcquireBrowser(ctx, proxyConfig)
 	if err != nil {
-		return f.handleError(account, models.StepProxyAllocation, err, time.Now())
+		return fail(models.StepProxyAllocation, err)
 	}
 
 	// Create new page
 	page, err = browserContext.NewPage()
 	if err != nil {
-		return f.handleError(account, models.StepProxyAllocation, err, time.Now())
+		return fail(models.StepProxyAllocation, err)
 	}
 
 	// Inject stealth
@@ -153,7 +175,7 @@ func (f *registrationFlow) executeRegistrationFlow(
 	// Step 3: Purchase phone number
 	phone, activationID, err := f.purchasePhone(ctx, account, session, req.PreferredCountry)
 	if err != nil {
-		return f.handleError(account, models.StepPhonePurchase, err, time.Now())
+		return fail(models.StepPhonePurchase, err)
 	}
 	account.Phone = phone
 	account.ActivationID = activationID
@@ -162,17 +184,17 @@ func (f *registrationFlow) executeRegistrationFlow(
 
 	// Step 4: Navigate to Telegram Web and enter phone
 	if err := f.navigateAndEnterPhone(ctx, page, account, session); err != nil {
-		return f.handleError(account, models.StepPhoneEntry, err, time.Now())
+		return fail(models.StepPhoneEntry, err)
 	}
 
 	// Step 5: Wait for and enter SMS code
 	if err := f.handleSMSVerification(ctx, page, account, session); err != nil {
-		return f.handleError(account, models.StepSMSVerification, err, time.Now())
+		return fail(models.StepSMSVerification, err)
 	}
 
 	// Step 6: Setup profile
 	if err := f.setupProfile(ctx, page, account, session, req); err != nil {
-		return f.handleError(account, models.StepProfileSetup, err, time.Now())
+		return fail(models.StepProfileSetup, err)
 	}
 
 	// Step 7: Setup username if provided
@@ -204,6 +226,13 @@ func (f *registrationFlow) executeRegistrationFlow(
 	cookieBytes, _ := serializeCookies(cookies)
 	account.Cookies = cookieBytes
 
+	// Telegram Web keeps its authorization in localStorage, which later web actions restore
+	if storage, err := captureWebStorage(page); err != nil {
+		f.logger.Warn("Failed to capture web storage", "error", err)
+	} else {
+		account.WebStorage = storage
+	}
+
 	// Mark as complete
 	account.Status = models.StatusCreated
 	f.accountRepo.Update(ctx, account)
@@ -224,14 +253,13 @@ func (f *registrationFlow) executeRegistrationFlow(
 func (f *registrationFlow) allocateProxy(ctx context.Context, account *models.TelegramAccount, session *models.RegistrationSession) (*ProxyConfig, error) {
 	f.metrics.IncrementProxyRequests()
 	stepStart := time.Now()
-	defer func() {
-		f.metrics.RecordStepDuration("proxy_allocation", time.Since(stepStart).Seconds())
-	}()
+	defer f.recordStep(account, "proxy_allocation", stepStart)
 
 	resp, err := f.proxyClient.AllocateProxy(ctx, &proxypb.AllocateProxyRequest{
 		Type:     "mobile",
 		Country:  "US",
-		Duration: 3600,
+		Requires: mtprotoProxyRequirements,
+		Platform: "telegram",
 	})
 
 	if err != nil {
@@ -241,18 +269,19 @@ func (f *registrationFlow) allocateProxy(ctx context.Context, account *models.Te
 
 	f.metrics.IncrementProxySuccess()
 
-	proxyID, _ := primitive.ObjectIDFromHex(resp.ProxyId)
+	proxyID, _ := primitive.ObjectIDFromHex(resp.Id)
+	proxyURL := fmt.Sprintf("%s://%s:%d", resp.Protocol, resp.Ip, resp.Port)
 	account.ProxyID = proxyID
 	session.ProxyID = proxyID
-	session.ProxyURL = resp.ProxyUrl
+	session.ProxyURL = proxyURL
 
 	f.sessionRepo.UpdateStep(ctx, session.ID, models.StepProxyAllocation, map[string]interface{}{
-		"proxy_id":  resp.ProxyId,
-		"proxy_url": resp.ProxyUrl,
+		"proxy_id":  resp.Id,
+		"proxy_url": proxyURL,
 	})
 
 	return &ProxyConfig{
-		Server:   resp.ProxyUrl,
+		Server:   proxyURL,
 		Username: resp.Username,
 		Password: resp.Password,
 	}, nil
@@ -261,9 +290,7 @@ func (f *registrationFlow) allocateProxy(ctx context.Context, account *models.Te
 func (f *registrationFlow) purchasePhone(ctx context.Context, account *models.TelegramAccount, session *models.RegistrationSession, preferredCountry string) (string, string, error) {
 	f.metrics.IncrementSMSRequests()
 	stepStart := time.Now()
-	defer func() {
-		f.metrics.RecordStepDuration("phone_purchase", time.Since(stepStart).Seconds())
-	}()
+	defer f.recordStep(account, "phone_purchase", stepStart)
 
 	country := preferredCountry
 	if country == "" {
@@ -271,8 +298,9 @@ func (f *registrationFlow) purchasePhone(ctx context.Context, account *models.Te
 	}
 
 	resp, err := f.smsClient.PurchaseNumber(ctx, &smspb.PurchaseNumberRequest{
-		Service: "telegram",
-		Country: country,
+		Service:        "telegram",
+		Country:        country,
+		IdempotencyKey: "telegram:registration:" + account.ID.Hex(),
 	})
 
 	if err != nil {
@@ -281,18 +309,16 @@ func (f *registrationFlow) purchasePhone(ctx context.Context, account *models.Te
 	}
 
 	f.sessionRepo.UpdateStep(ctx, session.ID, models.StepPhonePurchase, map[string]interface{}{
-		"phone":         resp.Phone,
+		"phone":         resp.PhoneNumber,
 		"activation_id": resp.ActivationId,
 	})
 
-	return resp.Phone, resp.ActivationId, nil
+	return resp.PhoneNumber, resp.ActivationId, nil
 }
 
 func (f *registrationFlow) navigateAndEnterPhone(ctx context.Context, page playwright.Page, account *models.TelegramAccount, session *models.RegistrationSession) error {
 	stepStart := time.Now()
-	defer func() {
-		f.metrics.RecordStepDuration("phone_entry", time.Since(stepStart).Seconds())
-	}()
+	defer f.recordStep(account, "phone_entry", stepStart)
 
 	// Navigate to Telegram Web
 	if _, err := page.Goto("https://web.telegram.org/k/", playwright.PageGotoOptions{
@@ -325,7 +351,7 @@ func (f *registrationFlow) navigateAndEnterPhone(ctx context.Context, page playw
 	// Type phone number with delays
 	for _, digit := range account.Phone {
 		phoneInput.Type(string(digit), playwright.LocatorTypeOptions{
-			Delay: playwright.Float(rand.Intn(200) + 100),
+			Delay: playwright.Float(float64(rand.Intn(200) + 100)),
 		})
 	}
 
@@ -347,9 +373,7 @@ func (f *registrationFlow) navigateAndEnterPhone(ctx context.Context, page playw
 
 func (f *registrationFlow) handleSMSVerification(ctx context.Context, page playwright.Page, account *models.TelegramAccount, session *models.RegistrationSession) error {
 	stepStart := time.Now()
-	defer func() {
-		f.metrics.RecordStepDuration("sms_verification", time.Since(stepStart).Seconds())
-	}()
+	defer f.recordStep(account, "sms_verification", stepStart)
 
 	// Wait for SMS code input to appear
 	codeInput := page.Locator("input[type='tel'][autocomplete='off']")
@@ -387,7 +411,7 @@ func (f *registrationFlow) handleSMSVerification(ctx context.Context, page playw
 	// Enter SMS code
 	for _, digit := range smsCode {
 		codeInput.Type(string(digit), playwright.LocatorTypeOptions{
-			Delay: playwright.Float(rand.Intn(200) + 100),
+			Delay: playwright.Float(float64(rand.Intn(200) + 100)),
 		})
 	}
 
@@ -403,9 +427,7 @@ func (f *registrationFlow) handleSMSVerification(ctx context.Context, page playw
 
 func (f *registrationFlow) setupProfile(ctx context.Context, page playwright.Page, account *models.TelegramAccount, session *models.RegistrationSession, req *models.RegistrationRequest) error {
 	stepStart := time.Now()
-	defer func() {
-		f.metrics.RecordStepDuration("profile_setup", time.Since(stepStart).Seconds())
-	}()
+	defer f.recordStep(account, "profile_setup", stepStart)
 
 	// Wait for profile setup page
 	time.Sleep(3 * time.Second)
@@ -445,9 +467,7 @@ func (f *registrationFlow) setupProfile(ctx context.Context, page playwright.Pag
 
 func (f *registrationFlow) setupUsername(ctx context.Context, page playwright.Page, account *models.TelegramAccount, session *models.RegistrationSession) error {
 	stepStart := time.Now()
-	defer func() {
-		f.metrics.RecordStepDuration("username_setup", time.Since(stepStart).Seconds())
-	}()
+	defer f.recordStep(account, "username_setup", stepStart)
 
 	// Navigate to settings if needed
 	// Implementation depends on Telegram Web UI
@@ -461,9 +481,7 @@ func (f *registrationFlow) setupUsername(ctx context.Context, page playwright.Pa
 
 func (f *registrationFlow) uploadAvatar(ctx context.Context, page playwright.Page, account *models.TelegramAccount, session *models.RegistrationSession) error {
 	stepStart := time.Now()
-	defer func() {
-		f.metrics.RecordStepDuration("avatar_upload", time.Since(stepStart).Seconds())
-	}()
+	defer f.recordStep(account, "avatar_upload", stepStart)
 
 	// Implementation for avatar upload
 	// This would involve downloading the image from AvatarURL and uploading it
@@ -477,9 +495,7 @@ func (f *registrationFlow) uploadAvatar(ctx context.Context, page playwright.Pag
 
 func (f *registrationFlow) setupTwoFactor(ctx context.Context, page playwright.Page, account *models.TelegramAccount, session *models.RegistrationSession) error {
 	stepStart := time.Now()
-	defer func() {
-		f.metrics.RecordStepDuration("two_factor_setup", time.Since(stepStart).Seconds())
-	}()
+	defer f.recordStep(account, "two_factor_setup", stepStart)
 
 	// Generate random password for 2FA
 	password := generateRandomPassword()
@@ -529,7 +545,12 @@ func (f *registrationFlow) RetryRegistration(ctx context.Context, accountID prim
 	}
 
 	// Execute registration flow
+	startTime := time.Now()
 	result := f.executeRegistrationFlow(ctx, account, session, req)
+	result.Duration = time.Since(startTime).Seconds()
+	if result.Success {
+		f.publishStepCompleted(account, registrationStepTotal, result.Duration)
+	}
 	return result, nil
 }
 
@@ -563,3 +584,30 @@ func generateRandomPassword() string {
 	}
 	return string(password)
 }
+
+// recordStep observes a step duration and reports it to analytics, failed attempts included
+func (f *registrationFlow) recordStep(account *models.TelegramAccount, step string, stepStart time.Time) {
+	seconds := time.Since(stepStart).Seconds()
+	f.metrics.RecordStepDuration(step, seconds)
+	f.publishStepCompleted(account, step, seconds)
+}
+
+// publishStepCompleted reports a step duration, registrationStepTotal carries the end-to-end time
+func (f *registrationFlow) publishStepCompleted(account *models.TelegramAccount, step string, seconds float64) {
+	if f.publisher == nil {
+		return
+	}
+
+	event := map[string]interface{}{
+		"type":         registrationStepEvent,
+		"platform":     "telegram",
+		"account_id":   account.ID.Hex(),
+		"step":         step,
+		"duration_sec": seconds,
+		"timestamp":    time.Now().Unix(),
+	}
+
+	if err := f.publisher.Publish("telegram.events", registrationStepEvent, event); err != nil {
+		f.logger.Error("Failed to publish registration step", "step", step, "error", err)
+	}
+}
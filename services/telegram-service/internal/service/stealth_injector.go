@@ -55,7 +55,7 @@ func (s *stealthInjector) DisableWebdriverFlag(page playwright.Page) error {
 		};
 	`
 
-	_, err := page.AddInitScript(playwright.Script{Content: &script})
+	err := page.AddInitScript(playwright.Script{Content: &script})
 	return err
 }
 
@@ -113,7 +113,7 @@ func (s *stealthInjector) OverrideNavigatorProperties(page playwright.Page) erro
 		});
 	`
 
-	_, err := page.AddInitScript(playwright.Script{Content: &script})
+	err := page.AddInitScript(playwright.Script{Content: &script})
 	return err
 }
 
@@ -177,7 +177,7 @@ func (s *stealthInjector) hideAutomationIndicators(page playwright.Page) error {
 		}
 	`
 
-	_, err := page.AddInitScript(playwright.Script{Content: &script})
+	err := page.AddInitScript(playwright.Script{Content: &script})
 	return err
 }
 
@@ -232,7 +232,7 @@ func (s *stealthInjector) patchChromeRuntime(page playwright.Page) error {
 		};
 	`
 
-	_, err := page.AddInitScript(playwright.Script{Content: &script})
+	err := page.AddInitScript(playwright.Script{Content: &script})
 	if err != nil {
 		return fmt.Errorf("failed to patch chrome runtime: %w", err)
 	}
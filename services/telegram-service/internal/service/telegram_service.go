@@ -1,10 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
@@ -12,6 +16,7 @@ import (
 	"github.com/grigta/conveer/services/telegram-service/internal/config"
 	"github.com/grigta/conveer/services/telegram-service/internal/models"
 	"github.com/grigta/conveer/services/telegram-service/internal/repository"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
 	smspb "github.com/grigta/conveer/services/sms-service/proto"
 
@@ -20,6 +25,15 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+const personaPlatform = "telegram"
+
+var (
+	ErrAccountNotActive = errors.New("account is not active")
+	ErrPostingCadence   = errors.New("posting cadence limit reached")
+	ErrDialogCadence    = errors.New("dialog cadence limit reached")
+	ErrNoContacts       = errors.New("no imported contacts left to greet")
+)
+
 type TelegramService interface {
 	CreateAccount(ctx context.Context, req *models.RegistrationRequest) (*models.TelegramAccount, error)
 	GetAccount(ctx context.Context, accountID primitive.ObjectID) (*models.TelegramAccount, error)
@@ -28,19 +42,34 @@ type TelegramService interface {
 	RetryRegistration(ctx context.Context, accountID primitive.ObjectID) (*models.TelegramAccount, error)
 	DeleteAccount(ctx context.Context, accountID primitive.ObjectID) error
 	GetStatistics(ctx context.Context) (*models.AccountStatistics, error)
+	JoinChannel(ctx context.Context, accountID primitive.ObjectID, channel string) error
+	SendMessage(ctx context.Context, accountID primitive.ObjectID, peer, text string, replyTo int) (int, error)
+	ReactToMessage(ctx context.Context, accountID primitive.ObjectID, peer string, messageID int, reaction string) (int, error)
+	PostStory(ctx context.Context, accountID primitive.ObjectID, req *models.StoryRequest) error
+	ScheduleMessage(ctx context.Context, accountID primitive.ObjectID, req *models.ScheduledMessageRequest) error
+	ImportContacts(ctx context.Context, accountID primitive.ObjectID, contacts []models.Contact) (*models.ContactImportResult, error)
+	SeedDialog(ctx context.Context, accountID primitive.ObjectID) (string, int, error)
 	StartMonitoring(ctx context.Context) error
+	ConsumeProxyEvents(ctx context.Context, rabbit *messaging.RabbitMQ) error
 	Shutdown(ctx context.Context) error
 }
 
 type telegramService struct {
 	accountRepo      *repository.AccountRepository
 	sessionRepo      *repository.SessionRepository
+	cleanupRepo      *repository.CleanupRepository
 	browserManager   BrowserManager
 	registrationFlow RegistrationFlow
+	mtprotoPool      MTProtoPool
+	mtprotoClient    MTProtoClient
+	postingFlow      PostingFlow
+	postingConfig    *models.PostingConfig
+	dialogsConfig    *models.DialogsConfig
 	proxyClient      proxypb.ProxyServiceClient
 	smsClient        smspb.SMSServiceClient
+	personaClient    personapb.PersonaServiceClient
 	redisClient      *redis.Client
-	rabbitPublisher  rabbitmq.Publisher
+	rabbitPublisher  messaging.Publisher
 	config           *config.Config
 	logger           logger.Logger
 	metrics          MetricsCollector
@@ -52,14 +81,16 @@ func NewTelegramService(
 	browserManager BrowserManager,
 	proxyClient proxypb.ProxyServiceClient,
 	smsClient smspb.SMSServiceClient,
+	personaClient personapb.PersonaServiceClient,
 	redisClient *redis.Client,
-	rabbitPublisher rabbitmq.Publisher,
+	rabbitPublisher messaging.Publisher,
 	config *config.Config,
 	logger logger.Logger,
 ) (TelegramService, error) {
 	// Create repositories
 	accountRepo := repository.NewAccountRepository(db)
 	sessionRepo := repository.NewSessionRepository(db)
+	cleanupRepo := repository.NewCleanupRepository(db)
 
 	// Create metrics collector
 	metrics := NewMetricsCollector("telegram")
@@ -80,15 +111,31 @@ func NewTelegramService(
 		config.ToRegistrationConfig(),
 		logger,
 		metrics,
+		rabbitPublisher,
 	)
 
+	// Create MTProto client for warming actions, connections are pooled per account
+	mtprotoPool := NewMTProtoPool(config.ToMTProtoConfig(), logger, metrics)
+	mtprotoClient := NewMTProtoClient(mtprotoPool, logger)
+
+	// Create posting flow for web client content actions
+	postingConfig := config.ToPostingConfig()
+	postingFlow := NewPostingFlow(browserManager, stealthInjector, proxyClient, postingConfig, logger)
+
 	return &telegramService{
 		accountRepo:      accountRepo,
 		sessionRepo:      sessionRepo,
+		cleanupRepo:      cleanupRepo,
 		browserManager:   browserManager,
 		registrationFlow: registrationFlow,
+		mtprotoPool:      mtprotoPool,
+		mtprotoClient:    mtprotoClient,
+		postingFlow:      postingFlow,
+		postingConfig:    postingConfig,
+		dialogsConfig:    config.ToDialogsConfig(),
 		proxyClient:      proxyClient,
 		smsClient:        smsClient,
+		personaClient:    personaClient,
 		redisClient:      redisClient,
 		rabbitPublisher:  rabbitPublisher,
 		config:           config,
@@ -101,20 +148,25 @@ func NewTelegramService(
 func (s *telegramService) CreateAccount(ctx context.Context, req *models.RegistrationRequest) (*models.TelegramAccount, error) {
 	s.logger.Info("Creating new Telegram account", "first_name", req.FirstName)
 
+	// Take the profile from an existing persona or generate one if requested
+	if req.PersonaID != "" {
+		if err := s.applyPersona(ctx, req); err != nil {
+			return nil, err
+		}
+	} else if req.UseRandomProfile {
+		s.applyRandomProfile(ctx, req)
+	}
+
 	// Validate request
 	if req.FirstName == "" {
 		return nil, fmt.Errorf("first name is required")
 	}
 
-	// Use random profile if requested
-	if req.UseRandomProfile {
-		req = s.generateRandomProfile()
-	}
-
 	// Start registration flow
 	result, err := s.registrationFlow.StartRegistration(ctx, req)
 	if err != nil {
 		s.logger.Error("Registration failed", "error", err)
+		s.cancelPersona(ctx, req)
 		return nil, fmt.Errorf("registration failed: %w", err)
 	}
 
@@ -125,6 +177,8 @@ func (s *telegramService) CreateAccount(ctx context.Context, req *models.Registr
 		return nil, fmt.Errorf("failed to get created account: %w", err)
 	}
 
+	s.confirmPersona(ctx, account, req.PersonaReservationID)
+
 	// Publish event
 	s.publishAccountEvent("account.created", account)
 
@@ -228,7 +282,7 @@ func (s *telegramService) DeleteAccount(ctx context.Context, accountID primitive
 	// Release proxy if allocated
 	if account.ProxyID != primitive.NilObjectID {
 		s.proxyClient.ReleaseProxy(ctx, &proxypb.ReleaseProxyRequest{
-			ProxyId: account.ProxyID.Hex(),
+			AccountId: account.ID.Hex(),
 		})
 	}
 
@@ -262,16 +316,209 @@ func (s *telegramService) GetStatistics(ctx context.Context) (*models.AccountSta
 	return stats, nil
 }
 
+func (s *telegramService) JoinChannel(ctx context.Context, accountID primitive.ObjectID, channel string) error {
+	account, sess, err := s.mtprotoSession(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	err = s.mtprotoClient.JoinChannel(ctx, sess, channel)
+	s.finishMTProtoAction(ctx, "join_channel", account, sess, err)
+	if err != nil {
+		return fmt.Errorf("failed to join channel: %w", err)
+	}
+
+	return nil
+}
+
+func (s *telegramService) SendMessage(ctx context.Context, accountID primitive.ObjectID, peer, text string, replyTo int) (int, error) {
+	account, sess, err := s.mtprotoSession(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	messageID, err := s.mtprotoClient.SendMessage(ctx, sess, peer, text, replyTo)
+	s.finishMTProtoAction(ctx, "send_message", account, sess, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return messageID, nil
+}
+
+func (s *telegramService) ReactToMessage(ctx context.Context, accountID primitive.ObjectID, peer string, messageID int, reaction string) (int, error) {
+	account, sess, err := s.mtprotoSession(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	messageID, err = s.mtprotoClient.ReactToMessage(ctx, sess, peer, messageID, reaction)
+	s.finishMTProtoAction(ctx, "react", account, sess, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to react to message: %w", err)
+	}
+
+	return messageID, nil
+}
+
+func (s *telegramService) PostStory(ctx context.Context, accountID primitive.ObjectID, req *models.StoryRequest) error {
+	if req.MediaURL == "" {
+		return fmt.Errorf("story media is required")
+	}
+
+	return s.runPostingAction(ctx, accountID, models.PostingActionStory, func(account *models.TelegramAccount) error {
+		return s.postingFlow.PostStory(ctx, account, req)
+	})
+}
+
+func (s *telegramService) ScheduleMessage(ctx context.Context, accountID primitive.ObjectID, req *models.ScheduledMessageRequest) error {
+	if req.Peer == "" || (req.Text == "" && req.MediaURL == "") {
+		return fmt.Errorf("peer and text or media are required")
+	}
+
+	// Telegram rejects schedules in the past; a far-away date does not look like a real user
+	delay := time.Until(req.SendAt)
+	if delay < time.Minute || delay > s.postingConfig.MaxScheduleAhead {
+		return fmt.Errorf("send time must be between 1 minute and %s ahead", s.postingConfig.MaxScheduleAhead)
+	}
+
+	return s.runPostingAction(ctx, accountID, models.PostingActionScheduleMessage, func(account *models.TelegramAccount) error {
+		return s.postingFlow.ScheduleMessage(ctx, account, req)
+	})
+}
+
+// ImportContacts adds contacts to the account's address book. Without an explicit list other accounts
+// in warming are imported, so the first dialogs of a fresh account happen between our own accounts.
+func (s *telegramService) ImportContacts(ctx context.Context, accountID primitive.ObjectID, contacts []models.Contact) (*models.ContactImportResult, error) {
+	account, sess, err := s.mtprotoSession(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("telegram:dialogs:%s:import", accountID.Hex())
+	allowed, err := s.dialogAllowance(ctx, key, s.dialogsConfig.ImportPerDay, 0)
+	if err != nil {
+		return nil, err
+	}
+	if allowed <= 0 {
+		return nil, fmt.Errorf("%w: %d contacts per day", ErrDialogCadence, s.dialogsConfig.ImportPerDay)
+	}
+	// A full address book is not an error, the account just stops growing it
+	if room := s.dialogsConfig.MaxContacts - len(account.Contacts); room < allowed {
+		allowed = room
+	}
+
+	result := &models.ContactImportResult{TotalContacts: len(account.Contacts)}
+	if allowed <= 0 {
+		return result, nil
+	}
+
+	if len(contacts) == 0 {
+		limit := s.dialogsConfig.MutualPeers
+		if allowed < limit {
+			limit = allowed
+		}
+		if contacts, err = s.mutualContacts(ctx, account, limit); err != nil {
+			return nil, err
+		}
+	}
+
+	contacts = newContacts(account.Contacts, contacts, allowed)
+	if len(contacts) == 0 {
+		return result, nil
+	}
+
+	err = s.mtprotoClient.ImportContacts(ctx, sess, contacts)
+	s.finishMTProtoAction(ctx, "import_contacts", account, sess, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import contacts: %w", err)
+	}
+
+	// Telegram limits import attempts, not only successful ones
+	s.recordDialogAction(ctx, key, len(contacts), 0)
+
+	now := time.Now()
+	for _, contact := range contacts {
+		if contact.UserID == 0 {
+			result.NotFound++
+			continue
+		}
+		contact.ImportedAt = now
+		account.Contacts = append(account.Contacts, contact)
+		result.Imported++
+	}
+	result.TotalContacts = len(account.Contacts)
+
+	if result.Imported > 0 {
+		if err := s.accountRepo.Update(ctx, account); err != nil {
+			return nil, fmt.Errorf("failed to save contacts: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// SeedDialog sends a greeting to one imported contact that has not been written to yet.
+// It returns the contact's user ID and the message ID.
+func (s *telegramService) SeedDialog(ctx context.Context, accountID primitive.ObjectID) (string, int, error) {
+	account, sess, err := s.mtprotoSession(ctx, accountID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	key := fmt.Sprintf("telegram:dialogs:%s:greeting", accountID.Hex())
+	allowed, err := s.dialogAllowance(ctx, key, s.dialogsConfig.GreetingsPerDay, s.dialogsConfig.GreetingMinInterval)
+	if err != nil {
+		return "", 0, err
+	}
+	if allowed <= 0 {
+		return "", 0, fmt.Errorf("%w: %d greetings per day", ErrDialogCadence, s.dialogsConfig.GreetingsPerDay)
+	}
+
+	var candidates []int
+	for i, contact := range account.Contacts {
+		if contact.UserID != 0 && contact.GreetedAt == nil {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", 0, ErrNoContacts
+	}
+
+	contact := &account.Contacts[candidates[randomIndex(len(candidates))]]
+	greeting := pickGreeting(s.dialogsConfig.Greetings, contact.FirstName)
+
+	messageID, err := s.mtprotoClient.SendToContact(ctx, sess, contact.Phone, greeting)
+	s.finishMTProtoAction(ctx, "seed_dialog", account, sess, err)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to greet contact: %w", err)
+	}
+
+	s.recordDialogAction(ctx, key, 1, s.dialogsConfig.GreetingMinInterval)
+
+	now := time.Now()
+	contact.GreetedAt = &now
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		s.logger.Error("Failed to save greeted contact", "account_id", accountID.Hex(), "error", err)
+	}
+
+	return strconv.FormatInt(contact.UserID, 10), messageID, nil
+}
+
 func (s *telegramService) StartMonitoring(ctx context.Context) error {
 	// Start session cleanup
 	go s.cleanupStaleSessions(ctx)
 
-	// Start stuck registration monitoring
+	// Start compensating stuck registrations and reporting what was reclaimed
 	go s.monitorStuckRegistrations(ctx)
+	go s.reportCleanups(ctx)
 
 	// Start metrics updater
 	go s.updateMetrics(ctx)
 
+	// Start closing idle MTProto connections
+	go s.mtprotoPool.Start(ctx)
+
 	s.logger.Info("Monitoring started")
 	return nil
 }
@@ -284,6 +531,9 @@ func (s *telegramService) Shutdown(ctx context.Context) error {
 		s.logger.Error("Failed to shutdown browser manager", "error", err)
 	}
 
+	// Close pooled MTProto connections
+	s.mtprotoPool.Shutdown(ctx)
+
 	// Close Redis connection
 	if err := s.redisClient.Close(); err != nil {
 		s.logger.Error("Failed to close Redis connection", "error", err)
@@ -293,20 +543,373 @@ func (s *telegramService) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-func (s *telegramService) generateRandomProfile() *models.RegistrationRequest {
-	firstNames := []string{"Alex", "Sam", "Jordan", "Taylor", "Morgan", "Casey", "Riley", "Avery"}
-	lastNames := []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis"}
+// applyRandomProfile reserves a persona for the registration, reusing one already registered
+// on other platforms when possible. Falls back to a local name pool if the persona service is unavailable.
+func (s *telegramService) applyRandomProfile(ctx context.Context, req *models.RegistrationRequest) {
+	persona, err := s.personaClient.AllocatePersona(ctx, &personapb.AllocatePersonaRequest{
+		Platform:      personaPlatform,
+		ReuseExisting: true,
+	})
+	if err != nil {
+		s.logger.Warn("Persona service unavailable, generating profile locally", "error", err)
+
+		firstNames := []string{"Alex", "Sam", "Jordan", "Taylor", "Morgan", "Casey", "Riley", "Avery"}
+		lastNames := []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis"}
+
+		n1, _ := rand.Int(rand.Reader, big.NewInt(int64(len(firstNames))))
+		n2, _ := rand.Int(rand.Reader, big.NewInt(int64(len(lastNames))))
+
+		req.FirstName = firstNames[n1.Int64()]
+		req.LastName = lastNames[n2.Int64()]
+		req.Bio = "Hi there! I'm using Telegram."
+		return
+	}
+
+	fillFromPersona(req, persona)
+}
+
+// applyPersona reserves an existing persona, e.g. one shared with an account on another platform
+func (s *telegramService) applyPersona(ctx context.Context, req *models.RegistrationRequest) error {
+	persona, err := s.personaClient.AllocatePersona(ctx, &personapb.AllocatePersonaRequest{
+		Platform:  personaPlatform,
+		PersonaId: req.PersonaID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reserve persona %s: %w", req.PersonaID, err)
+	}
+
+	fillFromPersona(req, persona)
+	return nil
+}
+
+func fillFromPersona(req *models.RegistrationRequest, persona *personapb.Persona) {
+	req.PersonaID = persona.Id
+	req.PersonaReservationID = persona.ReservationId
+	req.FirstName = persona.FirstName
+	req.LastName = persona.LastName
+	req.Username = persona.Username
+	req.Bio = persona.Bio
+
+	// Avatars are only downloadable when the persona service is configured with a public base URL
+	if req.AvatarURL == "" && len(persona.Avatars) > 0 && strings.Contains(persona.Avatars[0], "://") {
+		req.AvatarURL = persona.Avatars[0]
+	}
+}
+
+// confirmPersona turns the reservation into an assignment so the identity is not reused on Telegram
+func (s *telegramService) confirmPersona(ctx context.Context, account *models.TelegramAccount, reservationID string) {
+	if account.PersonaID == "" || reservationID == "" {
+		return
+	}
+
+	if _, err := s.personaClient.ConfirmReservation(ctx, &personapb.ConfirmReservationRequest{
+		PersonaId:     account.PersonaID,
+		ReservationId: reservationID,
+		AccountId:     account.ID.Hex(),
+	}); err != nil {
+		s.logger.Warn("Failed to confirm persona reservation", "error", err, "account_id", account.ID.Hex(), "persona_id", account.PersonaID)
+	}
+}
+
+// cancelPersona frees the persona of a registration that did not produce an account
+func (s *telegramService) cancelPersona(ctx context.Context, req *models.RegistrationRequest) {
+	if req.PersonaID == "" || req.PersonaReservationID == "" {
+		return
+	}
+
+	if _, err := s.personaClient.CancelReservation(ctx, &personapb.CancelReservationRequest{
+		PersonaId:     req.PersonaID,
+		ReservationId: req.PersonaReservationID,
+	}); err != nil {
+		s.logger.Warn("Failed to cancel persona reservation", "error", err, "persona_id", req.PersonaID)
+	}
+}
+
+// mtprotoSession loads the account and its proxy for an MTProto action.
+// Actions never fall back to a direct connection so the account is not exposed from the service IP.
+func (s *telegramService) mtprotoSession(ctx context.Context, accountID primitive.ObjectID) (*models.TelegramAccount, *MTProtoSession, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := ensureActive(account); err != nil {
+		return nil, nil, err
+	}
+
+	if account.SessionString == "" {
+		return nil, nil, ErrNoMTProtoSession
+	}
+
+	sess := &MTProtoSession{
+		AccountID:     account.ID.Hex(),
+		SessionString: account.SessionString,
+		ApiID:         account.ApiID,
+		ApiHash:       account.ApiHash,
+	}
+
+	if account.ProxyID != primitive.NilObjectID {
+		proxy, err := s.proxyClient.GetProxyForAccount(ctx, &proxypb.GetProxyRequest{
+			AccountId: account.ID.Hex(),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get proxy: %w", err)
+		}
+		sess.Proxy = proxy
+	}
+
+	return account, sess, nil
+}
+
+// finishMTProtoAction records the action outcome and persists the session if Telegram refreshed it
+func (s *telegramService) finishMTProtoAction(ctx context.Context, action string, account *models.TelegramAccount, sess *MTProtoSession, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+
+		var floodErr *FloodWaitError
+		if errors.As(err, &floodErr) {
+			result = "flood_wait"
+		}
+
+		s.logger.Error("MTProto action failed", "action", action, "account_id", account.ID.Hex(), "error", err)
+	}
+	s.metrics.IncrementMTProtoActions(action, result)
+
+	if sess.SessionString == account.SessionString {
+		return
+	}
+
+	account.SessionString = sess.SessionString
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		s.logger.Error("Failed to save MTProto session", "account_id", account.ID.Hex(), "error", err)
+	}
+}
+
+func ensureActive(account *models.TelegramAccount) error {
+	switch account.Status {
+	case models.StatusCreated, models.StatusWarming, models.StatusReady:
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrAccountNotActive, account.Status)
+}
+
+// runPostingAction enforces the per-account cadence around a web client posting action
+func (s *telegramService) runPostingAction(ctx context.Context, accountID primitive.ObjectID, action models.PostingAction, fn func(account *models.TelegramAccount) error) error {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := ensureActive(account); err != nil {
+		return err
+	}
+
+	if err := s.checkPostingCadence(ctx, accountID, action); err != nil {
+		s.metrics.IncrementPostingActions(string(action), "cadence_limited")
+		return err
+	}
+
+	webStorage := account.WebStorage
+	if err := fn(account); err != nil {
+		s.metrics.IncrementPostingActions(string(action), "failure")
+		s.logger.Error("Posting action failed", "action", action, "account_id", accountID.Hex(), "error", err)
+		return fmt.Errorf("failed to %s: %w", strings.ReplaceAll(string(action), "_", " "), err)
+	}
+
+	s.metrics.IncrementPostingActions(string(action), "success")
+	s.recordPosting(ctx, accountID, action)
+
+	if !bytes.Equal(webStorage, account.WebStorage) {
+		if err := s.accountRepo.Update(ctx, account); err != nil {
+			s.logger.Error("Failed to save web session", "account_id", accountID.Hex(), "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *telegramService) postingLimits(action models.PostingAction) (int, time.Duration) {
+	if action == models.PostingActionStory {
+		return s.postingConfig.StoriesPerDay, s.postingConfig.StoryMinInterval
+	}
+	return s.postingConfig.ScheduledPerDay, s.postingConfig.ScheduledMinInterval
+}
+
+func (s *telegramService) checkPostingCadence(ctx context.Context, accountID primitive.ObjectID, action models.PostingAction) error {
+	if s.redisClient == nil {
+		return nil
+	}
+
+	perDay, minInterval := s.postingLimits(action)
+	key := fmt.Sprintf("telegram:posting:%s:%s", accountID.Hex(), action)
+
+	count, err := s.redisClient.Get(ctx, key+":count").Int()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to check posting cadence: %w", err)
+	}
+	if perDay > 0 && count >= perDay {
+		return fmt.Errorf("%w: %d %s per day", ErrPostingCadence, perDay, action)
+	}
+
+	last, err := s.redisClient.Get(ctx, key+":last").Int64()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to check posting cadence: %w", err)
+	}
+	if last > 0 && time.Since(time.Unix(last, 0)) < minInterval {
+		return fmt.Errorf("%w: next %s allowed after %s", ErrPostingCadence, action, time.Unix(last, 0).Add(minInterval).Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (s *telegramService) recordPosting(ctx context.Context, accountID primitive.ObjectID, action models.PostingAction) {
+	if s.redisClient == nil {
+		return
+	}
+
+	_, minInterval := s.postingLimits(action)
+	key := fmt.Sprintf("telegram:posting:%s:%s", accountID.Hex(), action)
+
+	// The daily window starts with the first post, so the counter expires a day after it
+	pipe := s.redisClient.TxPipeline()
+	pipe.Incr(ctx, key+":count")
+	pipe.ExpireNX(ctx, key+":count", 24*time.Hour)
+	pipe.Set(ctx, key+":last", time.Now().Unix(), minInterval)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Error("Failed to record posting cadence", "account_id", accountID.Hex(), "error", err)
+	}
+}
+
+// mutualContacts picks other accounts in warming that are not in the address book yet
+func (s *telegramService) mutualContacts(ctx context.Context, account *models.TelegramAccount, limit int) ([]models.Contact, error) {
+	exclude := []primitive.ObjectID{account.ID}
+	for _, contact := range account.Contacts {
+		if peerID, err := primitive.ObjectIDFromHex(contact.AccountID); err == nil {
+			exclude = append(exclude, peerID)
+		}
+	}
+
+	peers, err := s.accountRepo.SampleWarmingPeers(ctx, exclude, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]models.Contact, 0, len(peers))
+	for _, peer := range peers {
+		contacts = append(contacts, models.Contact{
+			Phone:     peer.Phone,
+			FirstName: peer.FirstName,
+			LastName:  peer.LastName,
+			AccountID: peer.ID.Hex(),
+			Source:    models.ContactSourceMutual,
+		})
+	}
+	return contacts, nil
+}
+
+// dialogAllowance returns how many more contact actions the account may take today.
+// A zero minInterval only checks the daily count.
+func (s *telegramService) dialogAllowance(ctx context.Context, key string, perDay int, minInterval time.Duration) (int, error) {
+	if s.redisClient == nil {
+		return perDay, nil
+	}
+
+	count, err := s.redisClient.Get(ctx, key+":count").Int()
+	if err != nil && err != redis.Nil {
+		return 0, fmt.Errorf("failed to check dialog cadence: %w", err)
+	}
+
+	if minInterval > 0 {
+		last, err := s.redisClient.Get(ctx, key+":last").Int64()
+		if err != nil && err != redis.Nil {
+			return 0, fmt.Errorf("failed to check dialog cadence: %w", err)
+		}
+		if last > 0 && time.Since(time.Unix(last, 0)) < minInterval {
+			return 0, fmt.Errorf("%w: next allowed after %s", ErrDialogCadence, time.Unix(last, 0).Add(minInterval).Format(time.RFC3339))
+		}
+	}
 
-	n1, _ := rand.Int(rand.Reader, big.NewInt(int64(len(firstNames))))
-	n2, _ := rand.Int(rand.Reader, big.NewInt(int64(len(lastNames))))
+	return perDay - count, nil
+}
+
+func (s *telegramService) recordDialogAction(ctx context.Context, key string, n int, minInterval time.Duration) {
+	if s.redisClient == nil {
+		return
+	}
 
-	return &models.RegistrationRequest{
-		FirstName: firstNames[n1.Int64()],
-		LastName:  lastNames[n2.Int64()],
-		Bio:       "Hi there! I'm using Telegram.",
+	// Same daily window as posting: it starts with the first action
+	pipe := s.redisClient.TxPipeline()
+	pipe.IncrBy(ctx, key+":count", int64(n))
+	pipe.ExpireNX(ctx, key+":count", 24*time.Hour)
+	if minInterval > 0 {
+		pipe.Set(ctx, key+":last", time.Now().Unix(), minInterval)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Error("Failed to record dialog cadence", "key", key, "error", err)
 	}
 }
 
+// newContacts drops phones already in the address book or repeated in the list and caps the result
+func newContacts(existing, contacts []models.Contact, limit int) []models.Contact {
+	seen := make(map[string]bool, len(existing)+len(contacts))
+	for _, contact := range existing {
+		seen[normalizePhone(contact.Phone)] = true
+	}
+
+	var result []models.Contact
+	for _, contact := range contacts {
+		phone := normalizePhone(contact.Phone)
+		if phone == "" || seen[phone] {
+			continue
+		}
+		seen[phone] = true
+
+		if contact.Source == "" {
+			contact.Source = models.ContactSourceImported
+		}
+		result = append(result, contact)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result
+}
+
+func normalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return digits.String()
+}
+
+// pickGreeting returns a random greeting; templates with {name} are skipped for contacts without a first name
+func pickGreeting(templates []string, firstName string) string {
+	var candidates []string
+	for _, template := range templates {
+		if firstName == "" && strings.Contains(template, "{name}") {
+			continue
+		}
+		candidates = append(candidates, template)
+	}
+	if len(candidates) == 0 {
+		return "Привет!"
+	}
+
+	return strings.ReplaceAll(candidates[randomIndex(len(candidates))], "{name}", firstName)
+}
+
+func randomIndex(n int) int {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0
+	}
+	return int(i.Int64())
+}
+
 func (s *telegramService) publishAccountEvent(eventType string, account *models.TelegramAccount) {
 	if s.rabbitPublisher == nil {
 		return
@@ -373,35 +976,6 @@ func (s *telegramService) cleanupStaleSessions(ctx context.Context) {
 	}
 }
 
-func (s *telegramService) monitorStuckRegistrations(ctx context.Context) {
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-s.shutdownCh:
-			return
-		case <-ticker.C:
-			stuckDuration := time.Duration(s.config.Telegram.Monitoring.StuckRegistrationTimeout) * time.Minute
-			sessions, err := s.sessionRepo.GetStuckSessions(ctx, stuckDuration)
-			if err != nil {
-				s.logger.Error("Failed to get stuck sessions", "error", err)
-				continue
-			}
-
-			for _, session := range sessions {
-				s.logger.Warn("Found stuck registration", "account_id", session.AccountID.Hex())
-				s.metrics.IncrementManualInterventions()
-
-				// Update account status to error
-				s.accountRepo.UpdateStatus(ctx, session.AccountID, models.StatusError, "Registration stuck")
-			}
-		}
-	}
-}
-
 func (s *telegramService) updateMetrics(ctx context.Context) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"github.com/grigta/conveer/services/telegram-service/internal/models"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+var (
+	ErrNoWebSession      = errors.New("account has no web session")
+	ErrWebSessionExpired = errors.New("web session expired")
+)
+
+// restoreStorageScript seeds web.telegram.org localStorage once per tab, before the client boots
+const restoreStorageScript = `(() => {
+	if (location.hostname !== 'web.telegram.org' || sessionStorage.getItem('__conveer_restored')) return;
+	const data = %s;
+	for (const [key, value] of Object.entries(data)) localStorage.setItem(key, value);
+	sessionStorage.setItem('__conveer_restored', '1');
+})()`
+
+const webChatListSelector = "#column-left .chatlist, .chat-list"
+
+// openWebSession starts a browser logged into Telegram Web with the account's stored localStorage
+func openWebSession(ctx context.Context, browserManager BrowserManager, proxyClient proxypb.ProxyServiceClient, stealthInjector StealthInjector, account *models.TelegramAccount, webURL string, logger logger.Logger) (playwright.Browser, playwright.BrowserContext, playwright.Page, error) {
+	if len(account.WebStorage) == 0 {
+		return nil, nil, nil, ErrNoWebSession
+	}
+
+	// Reuse the account's proxy so Telegram sees the registration IP
+	proxy, err := proxyClient.GetProxyForAccount(ctx, &proxypb.GetProxyRequest{
+		AccountId: account.ID.Hex(),
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get proxy for account: %w", err)
+	}
+
+	browser, browserCtx, err := browserManager.AcquireBrowser(ctx, &ProxyConfig{
+		Server:   fmt.Sprintf("%s://%s:%d", proxy.Protocol, proxy.Ip, proxy.Port),
+		Username: proxy.Username,
+		Password: proxy.Password,
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to acquire browser: %w", err)
+	}
+
+	script := fmt.Sprintf(restoreStorageScript, string(account.WebStorage))
+	if err := browserCtx.AddInitScript(playwright.Script{Content: playwright.String(script)}); err != nil {
+		closeWebSession(browserManager, browser, browserCtx, nil, logger)
+		return nil, nil, nil, fmt.Errorf("failed to restore web storage: %w", err)
+	}
+
+	page, err := browserCtx.NewPage()
+	if err != nil {
+		closeWebSession(browserManager, browser, browserCtx, nil, logger)
+		return nil, nil, nil, fmt.Errorf("failed to create page: %w", err)
+	}
+
+	if err := stealthInjector.InjectStealth(page); err != nil {
+		logger.Warn("Failed to inject stealth", "error", err)
+	}
+
+	if _, err := page.Goto(webURL, playwright.PageGotoOptions{
+		WaitUntil: playwright.WaitUntilStateNetworkidle,
+		Timeout:   playwright.Float(30000),
+	}); err != nil {
+		closeWebSession(browserManager, browser, browserCtx, page, logger)
+		return nil, nil, nil, fmt.Errorf("failed to navigate to Telegram: %w", err)
+	}
+
+	// The login screen means Telegram dropped the authorization behind the snapshot
+	if err := page.Locator(webChatListSelector).First().WaitFor(playwright.LocatorWaitForOptions{
+		State:   playwright.WaitForSelectorStateVisible,
+		Timeout: playwright.Float(20000),
+	}); err != nil {
+		closeWebSession(browserManager, browser, browserCtx, page, logger)
+		return nil, nil, nil, ErrWebSessionExpired
+	}
+
+	return browser, browserCtx, page, nil
+}
+
+func closeWebSession(browserManager BrowserManager, browser playwright.Browser, ctx playwright.BrowserContext, page playwright.Page, logger logger.Logger) {
+	if page != nil {
+		if err := page.Close(); err != nil {
+			logger.Warn("Failed to close page", "error", err)
+		}
+	}
+	if ctx != nil {
+		if err := ctx.Close(); err != nil {
+			logger.Warn("Failed to close browser context", "error", err)
+		}
+	}
+	if browser != nil {
+		if err := browserManager.ReleaseBrowser(browser); err != nil {
+			logger.Warn("Failed to release browser", "error", err)
+		}
+	}
+}
+
+// captureWebStorage snapshots web.telegram.org localStorage, which holds the web client authorization
+func captureWebStorage(page playwright.Page) ([]byte, error) {
+	result, err := page.Evaluate("() => JSON.stringify(Object.assign({}, window.localStorage))")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local storage: %w", err)
+	}
+
+	snapshot, ok := result.(string)
+	if !ok || snapshot == "" || snapshot == "{}" {
+		return nil, fmt.Errorf("local storage is empty")
+	}
+
+	return []byte(snapshot), nil
+}
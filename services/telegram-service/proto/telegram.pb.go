@@ -35,6 +35,7 @@ type CreateAccountRequest struct {
 	UseRandomProfile bool                   `protobuf:"varint,8,opt,name=use_random_profile,json=useRandomProfile,proto3" json:"use_random_profile,omitempty"`
 	ApiId            int32                  `protobuf:"varint,9,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
 	ApiHash          string                 `protobuf:"bytes,10,opt,name=api_hash,json=apiHash,proto3" json:"api_hash,omitempty"`
+	PersonaId        string                 `protobuf:"bytes,11,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -139,6 +140,13 @@ func (x *CreateAccountRequest) GetApiHash() string {
 	return ""
 }
 
+func (x *CreateAccountRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
 type GetAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
@@ -183,6 +191,151 @@ func (x *GetAccountRequest) GetAccountId() string {
 	return ""
 }
 
+// Session fields are encrypted with the shared ENCRYPTION_KEY
+type GetSessionDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSessionDataRequest) Reset() {
+	*x = GetSessionDataRequest{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSessionDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionDataRequest) ProtoMessage() {}
+
+func (x *GetSessionDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionDataRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionDataRequest) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSessionDataRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type SessionData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	SessionString string                 `protobuf:"bytes,3,opt,name=session_string,json=sessionString,proto3" json:"session_string,omitempty"`
+	Cookies       string                 `protobuf:"bytes,4,opt,name=cookies,proto3" json:"cookies,omitempty"`
+	LocalStorage  string                 `protobuf:"bytes,5,opt,name=local_storage,json=localStorage,proto3" json:"local_storage,omitempty"`
+	DcId          int32                  `protobuf:"varint,6,opt,name=dc_id,json=dcId,proto3" json:"dc_id,omitempty"`
+	ServerAddress string                 `protobuf:"bytes,7,opt,name=server_address,json=serverAddress,proto3" json:"server_address,omitempty"`
+	Port          int32                  `protobuf:"varint,8,opt,name=port,proto3" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionData) Reset() {
+	*x = SessionData{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionData) ProtoMessage() {}
+
+func (x *SessionData) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionData.ProtoReflect.Descriptor instead.
+func (*SessionData) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SessionData) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SessionData) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *SessionData) GetSessionString() string {
+	if x != nil {
+		return x.SessionString
+	}
+	return ""
+}
+
+func (x *SessionData) GetCookies() string {
+	if x != nil {
+		return x.Cookies
+	}
+	return ""
+}
+
+func (x *SessionData) GetLocalStorage() string {
+	if x != nil {
+		return x.LocalStorage
+	}
+	return ""
+}
+
+func (x *SessionData) GetDcId() int32 {
+	if x != nil {
+		return x.DcId
+	}
+	return 0
+}
+
+func (x *SessionData) GetServerAddress() string {
+	if x != nil {
+		return x.ServerAddress
+	}
+	return ""
+}
+
+func (x *SessionData) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
 type ListAccountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
@@ -194,7 +347,7 @@ type ListAccountsRequest struct {
 
 func (x *ListAccountsRequest) Reset() {
 	*x = ListAccountsRequest{}
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[2]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -206,7 +359,7 @@ func (x *ListAccountsRequest) String() string {
 func (*ListAccountsRequest) ProtoMessage() {}
 
 func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[2]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,7 +372,7 @@ func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
 func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
-	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{2}
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListAccountsRequest) GetStatus() string {
@@ -253,7 +406,7 @@ type UpdateStatusRequest struct {
 
 func (x *UpdateStatusRequest) Reset() {
 	*x = UpdateStatusRequest{}
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[3]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -265,7 +418,7 @@ func (x *UpdateStatusRequest) String() string {
 func (*UpdateStatusRequest) ProtoMessage() {}
 
 func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[3]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -278,7 +431,7 @@ func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateStatusRequest) Descriptor() ([]byte, []int) {
-	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{3}
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpdateStatusRequest) GetAccountId() string {
@@ -304,7 +457,7 @@ type RetryRequest struct {
 
 func (x *RetryRequest) Reset() {
 	*x = RetryRequest{}
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[4]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -316,7 +469,7 @@ func (x *RetryRequest) String() string {
 func (*RetryRequest) ProtoMessage() {}
 
 func (x *RetryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[4]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -329,7 +482,7 @@ func (x *RetryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetryRequest.ProtoReflect.Descriptor instead.
 func (*RetryRequest) Descriptor() ([]byte, []int) {
-	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{4}
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RetryRequest) GetAccountId() string {
@@ -348,7 +501,7 @@ type DeleteAccountRequest struct {
 
 func (x *DeleteAccountRequest) Reset() {
 	*x = DeleteAccountRequest{}
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[5]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -360,7 +513,7 @@ func (x *DeleteAccountRequest) String() string {
 func (*DeleteAccountRequest) ProtoMessage() {}
 
 func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[5]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +526,7 @@ func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
 func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
-	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{5}
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteAccountRequest) GetAccountId() string {
@@ -405,13 +558,14 @@ type Account struct {
 	ErrorMessage   string                 `protobuf:"bytes,18,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	RetryCount     int32                  `protobuf:"varint,19,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
 	HasTwoFactor   bool                   `protobuf:"varint,20,opt,name=has_two_factor,json=hasTwoFactor,proto3" json:"has_two_factor,omitempty"`
+	PersonaId      string                 `protobuf:"bytes,21,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Account) Reset() {
 	*x = Account{}
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[6]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -423,7 +577,7 @@ func (x *Account) String() string {
 func (*Account) ProtoMessage() {}
 
 func (x *Account) ProtoReflect() protoreflect.Message {
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[6]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,7 +590,7 @@ func (x *Account) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Account.ProtoReflect.Descriptor instead.
 func (*Account) Descriptor() ([]byte, []int) {
-	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{6}
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Account) GetId() string {
@@ -579,6 +733,13 @@ func (x *Account) GetHasTwoFactor() bool {
 	return false
 }
 
+func (x *Account) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
 type ListAccountsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
@@ -591,7 +752,7 @@ type ListAccountsResponse struct {
 
 func (x *ListAccountsResponse) Reset() {
 	*x = ListAccountsResponse{}
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[7]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -603,7 +764,7 @@ func (x *ListAccountsResponse) String() string {
 func (*ListAccountsResponse) ProtoMessage() {}
 
 func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[7]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -616,7 +777,7 @@ func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
 func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
-	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{7}
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListAccountsResponse) GetAccounts() []*Account {
@@ -661,7 +822,7 @@ type Statistics struct {
 
 func (x *Statistics) Reset() {
 	*x = Statistics{}
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[8]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -673,7 +834,7 @@ func (x *Statistics) String() string {
 func (*Statistics) ProtoMessage() {}
 
 func (x *Statistics) ProtoReflect() protoreflect.Message {
-	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[8]
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,7 +847,7 @@ func (x *Statistics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Statistics.ProtoReflect.Descriptor instead.
 func (*Statistics) Descriptor() ([]byte, []int) {
-	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{8}
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Statistics) GetTotal() int64 {
@@ -731,98 +892,787 @@ func (x *Statistics) GetLast_24Hours() int64 {
 	return 0
 }
 
-var File_services_telegram_service_proto_telegram_proto protoreflect.FileDescriptor
+type JoinChannelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Channel       string                 `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_services_telegram_service_proto_telegram_proto_rawDesc = "" +
-	"\n" +
-	".services/telegram-service/proto/telegram.proto\x12\btelegram\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xd8\x02\n" +
-	"\x14CreateAccountRequest\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x02 \x01(\tR\blastName\x12\x1a\n" +
-	"\busername\x18\x03 \x01(\tR\busername\x12\x10\n" +
-	"\x03bio\x18\x04 \x01(\tR\x03bio\x12\x1d\n" +
-	"\n" +
-	"avatar_url\x18\x05 \x01(\tR\tavatarUrl\x12*\n" +
-	"\x11enable_two_factor\x18\x06 \x01(\bR\x0fenableTwoFactor\x12+\n" +
-	"\x11preferred_country\x18\a \x01(\tR\x10preferredCountry\x12,\n" +
-	"\x12use_random_profile\x18\b \x01(\bR\x10useRandomProfile\x12\x15\n" +
-	"\x06api_id\x18\t \x01(\x05R\x05apiId\x12\x19\n" +
-	"\bapi_hash\x18\n" +
-	" \x01(\tR\aapiHash\"2\n" +
-	"\x11GetAccountRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"[\n" +
-	"\x13ListAccountsRequest\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"L\n" +
-	"\x13UpdateStatusRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"-\n" +
-	"\fRetryRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"5\n" +
-	"\x14DeleteAccountRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"\x99\x06\n" +
-	"\aAccount\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x03 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x04 \x01(\tR\blastName\x12\x1a\n" +
-	"\busername\x18\x05 \x01(\tR\busername\x12\x17\n" +
-	"\auser_id\x18\x06 \x01(\tR\x06userId\x12\x10\n" +
-	"\x03bio\x18\a \x01(\tR\x03bio\x12\x1d\n" +
-	"\n" +
-	"avatar_url\x18\b \x01(\tR\tavatarUrl\x12\x16\n" +
-	"\x06status\x18\t \x01(\tR\x06status\x12\x19\n" +
-	"\bproxy_id\x18\n" +
-	" \x01(\tR\aproxyId\x12#\n" +
-	"\ractivation_id\x18\v \x01(\tR\factivationId\x12\x1d\n" +
-	"\n" +
-	"user_agent\x18\f \x01(\tR\tuserAgent\x12D\n" +
-	"\vfingerprint\x18\r \x03(\v2\".telegram.Account.FingerprintEntryR\vfingerprint\x12'\n" +
-	"\x0fregistration_ip\x18\x0e \x01(\tR\x0eregistrationIp\x129\n" +
-	"\n" +
-	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
-	"\rlast_login_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\x12#\n" +
-	"\rerror_message\x18\x12 \x01(\tR\ferrorMessage\x12\x1f\n" +
-	"\vretry_count\x18\x13 \x01(\x05R\n" +
-	"retryCount\x12$\n" +
-	"\x0ehas_two_factor\x18\x14 \x01(\bR\fhasTwoFactor\x1a>\n" +
-	"\x10FingerprintEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x89\x01\n" +
-	"\x14ListAccountsResponse\x12-\n" +
-	"\baccounts\x18\x01 \x03(\v2\x11.telegram.AccountR\baccounts\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xad\x02\n" +
-	"\n" +
-	"Statistics\x12\x14\n" +
-	"\x05total\x18\x01 \x01(\x03R\x05total\x12?\n" +
-	"\tby_status\x18\x02 \x03(\v2\".telegram.Statistics.ByStatusEntryR\bbyStatus\x12!\n" +
-	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12'\n" +
-	"\x0faverage_retries\x18\x04 \x01(\x01R\x0eaverageRetries\x12\x1b\n" +
-	"\tlast_hour\x18\x05 \x01(\x03R\blastHour\x12\"\n" +
-	"\rlast_24_hours\x18\x06 \x01(\x03R\vlast24Hours\x1a;\n" +
-	"\rByStatusEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x012\xf3\x03\n" +
-	"\x0fTelegramService\x12B\n" +
-	"\rCreateAccount\x12\x1e.telegram.CreateAccountRequest\x1a\x11.telegram.Account\x12<\n" +
-	"\n" +
-	"GetAccount\x12\x1b.telegram.GetAccountRequest\x1a\x11.telegram.Account\x12M\n" +
-	"\fListAccounts\x12\x1d.telegram.ListAccountsRequest\x1a\x1e.telegram.ListAccountsResponse\x12G\n" +
-	"\x13UpdateAccountStatus\x12\x1d.telegram.UpdateStatusRequest\x1a\x11.telegram.Account\x12>\n" +
-	"\x11RetryRegistration\x12\x16.telegram.RetryRequest\x1a\x11.telegram.Account\x12G\n" +
-	"\rDeleteAccount\x12\x1e.telegram.DeleteAccountRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
-	"\rGetStatistics\x12\x16.google.protobuf.Empty\x1a\x14.telegram.StatisticsB;Z9github.com/grigta/conveer/services/telegram-service/protob\x06proto3"
+func (x *JoinChannelRequest) Reset() {
+	*x = JoinChannelRequest{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinChannelRequest) ProtoMessage() {}
+
+func (x *JoinChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinChannelRequest.ProtoReflect.Descriptor instead.
+func (*JoinChannelRequest) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *JoinChannelRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *JoinChannelRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type SendMessageRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AccountId        string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Peer             string                 `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	Text             string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	ReplyToMessageId int32                  `protobuf:"varint,4,opt,name=reply_to_message_id,json=replyToMessageId,proto3" json:"reply_to_message_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SendMessageRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetReplyToMessageId() int32 {
+	if x != nil {
+		return x.ReplyToMessageId
+	}
+	return 0
+}
+
+type ReactToMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Peer          string                 `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	MessageId     int32                  `protobuf:"varint,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Reaction      string                 `protobuf:"bytes,4,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactToMessageRequest) Reset() {
+	*x = ReactToMessageRequest{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactToMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactToMessageRequest) ProtoMessage() {}
+
+func (x *ReactToMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactToMessageRequest.ProtoReflect.Descriptor instead.
+func (*ReactToMessageRequest) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReactToMessageRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ReactToMessageRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *ReactToMessageRequest) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ReactToMessageRequest) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+type PostStoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	MediaUrl      string                 `protobuf:"bytes,2,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
+	Caption       string                 `protobuf:"bytes,3,opt,name=caption,proto3" json:"caption,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostStoryRequest) Reset() {
+	*x = PostStoryRequest{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostStoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostStoryRequest) ProtoMessage() {}
+
+func (x *PostStoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostStoryRequest.ProtoReflect.Descriptor instead.
+func (*PostStoryRequest) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PostStoryRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PostStoryRequest) GetMediaUrl() string {
+	if x != nil {
+		return x.MediaUrl
+	}
+	return ""
+}
+
+func (x *PostStoryRequest) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+type ScheduleMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Peer          string                 `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	MediaUrl      string                 `protobuf:"bytes,4,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
+	SendAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleMessageRequest) Reset() {
+	*x = ScheduleMessageRequest{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleMessageRequest) ProtoMessage() {}
+
+func (x *ScheduleMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleMessageRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleMessageRequest) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ScheduleMessageRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetMediaUrl() string {
+	if x != nil {
+		return x.MediaUrl
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetSendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SendAt
+	}
+	return nil
+}
+
+type Contact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phone         string                 `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Contact) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Contact) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Contact) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+type ImportContactsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Contacts to import, when empty other accounts in warming are imported
+	Contacts      []*Contact `protobuf:"bytes,2,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportContactsRequest) Reset() {
+	*x = ImportContactsRequest{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportContactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportContactsRequest) ProtoMessage() {}
+
+func (x *ImportContactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportContactsRequest.ProtoReflect.Descriptor instead.
+func (*ImportContactsRequest) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ImportContactsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ImportContactsRequest) GetContacts() []*Contact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+type ImportContactsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Imported  int32                  `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	// Phones that are not registered in Telegram
+	NotFound      int32 `protobuf:"varint,3,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+	TotalContacts int32 `protobuf:"varint,4,opt,name=total_contacts,json=totalContacts,proto3" json:"total_contacts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportContactsResponse) Reset() {
+	*x = ImportContactsResponse{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportContactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportContactsResponse) ProtoMessage() {}
+
+func (x *ImportContactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportContactsResponse.ProtoReflect.Descriptor instead.
+func (*ImportContactsResponse) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ImportContactsResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ImportContactsResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportContactsResponse) GetNotFound() int32 {
+	if x != nil {
+		return x.NotFound
+	}
+	return 0
+}
+
+func (x *ImportContactsResponse) GetTotalContacts() int32 {
+	if x != nil {
+		return x.TotalContacts
+	}
+	return 0
+}
+
+type SeedDialogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedDialogRequest) Reset() {
+	*x = SeedDialogRequest{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedDialogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedDialogRequest) ProtoMessage() {}
+
+func (x *SeedDialogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedDialogRequest.ProtoReflect.Descriptor instead.
+func (*SeedDialogRequest) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SeedDialogRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Peer          string                 `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	MessageId     int32                  `protobuf:"varint,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionResponse) Reset() {
+	*x = ActionResponse{}
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionResponse) ProtoMessage() {}
+
+func (x *ActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_telegram_service_proto_telegram_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionResponse.ProtoReflect.Descriptor instead.
+func (*ActionResponse) Descriptor() ([]byte, []int) {
+	return file_services_telegram_service_proto_telegram_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ActionResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ActionResponse) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *ActionResponse) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+var File_services_telegram_service_proto_telegram_proto protoreflect.FileDescriptor
+
+const file_services_telegram_service_proto_telegram_proto_rawDesc = "" +
+	"\n" +
+	".services/telegram-service/proto/telegram.proto\x12\btelegram\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xf7\x02\n" +
+	"\x14CreateAccountRequest\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x10\n" +
+	"\x03bio\x18\x04 \x01(\tR\x03bio\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x05 \x01(\tR\tavatarUrl\x12*\n" +
+	"\x11enable_two_factor\x18\x06 \x01(\bR\x0fenableTwoFactor\x12+\n" +
+	"\x11preferred_country\x18\a \x01(\tR\x10preferredCountry\x12,\n" +
+	"\x12use_random_profile\x18\b \x01(\bR\x10useRandomProfile\x12\x15\n" +
+	"\x06api_id\x18\t \x01(\x05R\x05apiId\x12\x19\n" +
+	"\bapi_hash\x18\n" +
+	" \x01(\tR\aapiHash\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\v \x01(\tR\tpersonaId\"2\n" +
+	"\x11GetAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"6\n" +
+	"\x15GetSessionDataRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xf8\x01\n" +
+	"\vSessionData\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12%\n" +
+	"\x0esession_string\x18\x03 \x01(\tR\rsessionString\x12\x18\n" +
+	"\acookies\x18\x04 \x01(\tR\acookies\x12#\n" +
+	"\rlocal_storage\x18\x05 \x01(\tR\flocalStorage\x12\x13\n" +
+	"\x05dc_id\x18\x06 \x01(\x05R\x04dcId\x12%\n" +
+	"\x0eserver_address\x18\a \x01(\tR\rserverAddress\x12\x12\n" +
+	"\x04port\x18\b \x01(\x05R\x04port\"[\n" +
+	"\x13ListAccountsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"L\n" +
+	"\x13UpdateStatusRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"-\n" +
+	"\fRetryRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"5\n" +
+	"\x14DeleteAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xb8\x06\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x03 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x04 \x01(\tR\blastName\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03bio\x18\a \x01(\tR\x03bio\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\b \x01(\tR\tavatarUrl\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x19\n" +
+	"\bproxy_id\x18\n" +
+	" \x01(\tR\aproxyId\x12#\n" +
+	"\ractivation_id\x18\v \x01(\tR\factivationId\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\f \x01(\tR\tuserAgent\x12D\n" +
+	"\vfingerprint\x18\r \x03(\v2\".telegram.Account.FingerprintEntryR\vfingerprint\x12'\n" +
+	"\x0fregistration_ip\x18\x0e \x01(\tR\x0eregistrationIp\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
+	"\rlast_login_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\x12#\n" +
+	"\rerror_message\x18\x12 \x01(\tR\ferrorMessage\x12\x1f\n" +
+	"\vretry_count\x18\x13 \x01(\x05R\n" +
+	"retryCount\x12$\n" +
+	"\x0ehas_two_factor\x18\x14 \x01(\bR\fhasTwoFactor\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x15 \x01(\tR\tpersonaId\x1a>\n" +
+	"\x10FingerprintEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x89\x01\n" +
+	"\x14ListAccountsResponse\x12-\n" +
+	"\baccounts\x18\x01 \x03(\v2\x11.telegram.AccountR\baccounts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xad\x02\n" +
+	"\n" +
+	"Statistics\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12?\n" +
+	"\tby_status\x18\x02 \x03(\v2\".telegram.Statistics.ByStatusEntryR\bbyStatus\x12!\n" +
+	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12'\n" +
+	"\x0faverage_retries\x18\x04 \x01(\x01R\x0eaverageRetries\x12\x1b\n" +
+	"\tlast_hour\x18\x05 \x01(\x03R\blastHour\x12\"\n" +
+	"\rlast_24_hours\x18\x06 \x01(\x03R\vlast24Hours\x1a;\n" +
+	"\rByStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"M\n" +
+	"\x12JoinChannelRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\"\x8a\x01\n" +
+	"\x12SendMessageRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04peer\x18\x02 \x01(\tR\x04peer\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12-\n" +
+	"\x13reply_to_message_id\x18\x04 \x01(\x05R\x10replyToMessageId\"\x85\x01\n" +
+	"\x15ReactToMessageRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04peer\x18\x02 \x01(\tR\x04peer\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\x05R\tmessageId\x12\x1a\n" +
+	"\breaction\x18\x04 \x01(\tR\breaction\"h\n" +
+	"\x10PostStoryRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1b\n" +
+	"\tmedia_url\x18\x02 \x01(\tR\bmediaUrl\x12\x18\n" +
+	"\acaption\x18\x03 \x01(\tR\acaption\"\xb1\x01\n" +
+	"\x16ScheduleMessageRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04peer\x18\x02 \x01(\tR\x04peer\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x1b\n" +
+	"\tmedia_url\x18\x04 \x01(\tR\bmediaUrl\x123\n" +
+	"\asend_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06sendAt\"[\n" +
+	"\aContact\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\"e\n" +
+	"\x15ImportContactsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12-\n" +
+	"\bcontacts\x18\x02 \x03(\v2\x11.telegram.ContactR\bcontacts\"\x97\x01\n" +
+	"\x16ImportContactsResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x05R\bimported\x12\x1b\n" +
+	"\tnot_found\x18\x03 \x01(\x05R\bnotFound\x12%\n" +
+	"\x0etotal_contacts\x18\x04 \x01(\x05R\rtotalContacts\"2\n" +
+	"\x11SeedDialogRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"b\n" +
+	"\x0eActionResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04peer\x18\x02 \x01(\tR\x04peer\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\x05R\tmessageId2\xc4\b\n" +
+	"\x0fTelegramService\x12B\n" +
+	"\rCreateAccount\x12\x1e.telegram.CreateAccountRequest\x1a\x11.telegram.Account\x12<\n" +
+	"\n" +
+	"GetAccount\x12\x1b.telegram.GetAccountRequest\x1a\x11.telegram.Account\x12M\n" +
+	"\fListAccounts\x12\x1d.telegram.ListAccountsRequest\x1a\x1e.telegram.ListAccountsResponse\x12G\n" +
+	"\x13UpdateAccountStatus\x12\x1d.telegram.UpdateStatusRequest\x1a\x11.telegram.Account\x12>\n" +
+	"\x11RetryRegistration\x12\x16.telegram.RetryRequest\x1a\x11.telegram.Account\x12G\n" +
+	"\rDeleteAccount\x12\x1e.telegram.DeleteAccountRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\rGetStatistics\x12\x16.google.protobuf.Empty\x1a\x14.telegram.Statistics\x12E\n" +
+	"\vJoinChannel\x12\x1c.telegram.JoinChannelRequest\x1a\x18.telegram.ActionResponse\x12E\n" +
+	"\vSendMessage\x12\x1c.telegram.SendMessageRequest\x1a\x18.telegram.ActionResponse\x12K\n" +
+	"\x0eReactToMessage\x12\x1f.telegram.ReactToMessageRequest\x1a\x18.telegram.ActionResponse\x12A\n" +
+	"\tPostStory\x12\x1a.telegram.PostStoryRequest\x1a\x18.telegram.ActionResponse\x12M\n" +
+	"\x0fScheduleMessage\x12 .telegram.ScheduleMessageRequest\x1a\x18.telegram.ActionResponse\x12S\n" +
+	"\x0eImportContacts\x12\x1f.telegram.ImportContactsRequest\x1a .telegram.ImportContactsResponse\x12C\n" +
+	"\n" +
+	"SeedDialog\x12\x1b.telegram.SeedDialogRequest\x1a\x18.telegram.ActionResponse\x12H\n" +
+	"\x0eGetSessionData\x12\x1f.telegram.GetSessionDataRequest\x1a\x15.telegram.SessionDataB;Z9github.com/grigta/conveer/services/telegram-service/protob\x06proto3"
 
 var (
 	file_services_telegram_service_proto_telegram_proto_rawDescOnce sync.Once
@@ -836,48 +1686,78 @@ func file_services_telegram_service_proto_telegram_proto_rawDescGZIP() []byte {
 	return file_services_telegram_service_proto_telegram_proto_rawDescData
 }
 
-var file_services_telegram_service_proto_telegram_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_services_telegram_service_proto_telegram_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_services_telegram_service_proto_telegram_proto_goTypes = []any{
-	(*CreateAccountRequest)(nil),  // 0: telegram.CreateAccountRequest
-	(*GetAccountRequest)(nil),     // 1: telegram.GetAccountRequest
-	(*ListAccountsRequest)(nil),   // 2: telegram.ListAccountsRequest
-	(*UpdateStatusRequest)(nil),   // 3: telegram.UpdateStatusRequest
-	(*RetryRequest)(nil),          // 4: telegram.RetryRequest
-	(*DeleteAccountRequest)(nil),  // 5: telegram.DeleteAccountRequest
-	(*Account)(nil),               // 6: telegram.Account
-	(*ListAccountsResponse)(nil),  // 7: telegram.ListAccountsResponse
-	(*Statistics)(nil),            // 8: telegram.Statistics
-	nil,                           // 9: telegram.Account.FingerprintEntry
-	nil,                           // 10: telegram.Statistics.ByStatusEntry
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+	(*CreateAccountRequest)(nil),   // 0: telegram.CreateAccountRequest
+	(*GetAccountRequest)(nil),      // 1: telegram.GetAccountRequest
+	(*GetSessionDataRequest)(nil),  // 2: telegram.GetSessionDataRequest
+	(*SessionData)(nil),            // 3: telegram.SessionData
+	(*ListAccountsRequest)(nil),    // 4: telegram.ListAccountsRequest
+	(*UpdateStatusRequest)(nil),    // 5: telegram.UpdateStatusRequest
+	(*RetryRequest)(nil),           // 6: telegram.RetryRequest
+	(*DeleteAccountRequest)(nil),   // 7: telegram.DeleteAccountRequest
+	(*Account)(nil),                // 8: telegram.Account
+	(*ListAccountsResponse)(nil),   // 9: telegram.ListAccountsResponse
+	(*Statistics)(nil),             // 10: telegram.Statistics
+	(*JoinChannelRequest)(nil),     // 11: telegram.JoinChannelRequest
+	(*SendMessageRequest)(nil),     // 12: telegram.SendMessageRequest
+	(*ReactToMessageRequest)(nil),  // 13: telegram.ReactToMessageRequest
+	(*PostStoryRequest)(nil),       // 14: telegram.PostStoryRequest
+	(*ScheduleMessageRequest)(nil), // 15: telegram.ScheduleMessageRequest
+	(*Contact)(nil),                // 16: telegram.Contact
+	(*ImportContactsRequest)(nil),  // 17: telegram.ImportContactsRequest
+	(*ImportContactsResponse)(nil), // 18: telegram.ImportContactsResponse
+	(*SeedDialogRequest)(nil),      // 19: telegram.SeedDialogRequest
+	(*ActionResponse)(nil),         // 20: telegram.ActionResponse
+	nil,                            // 21: telegram.Account.FingerprintEntry
+	nil,                            // 22: telegram.Statistics.ByStatusEntry
+	(*timestamppb.Timestamp)(nil),  // 23: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),          // 24: google.protobuf.Empty
 }
 var file_services_telegram_service_proto_telegram_proto_depIdxs = []int32{
-	9,  // 0: telegram.Account.fingerprint:type_name -> telegram.Account.FingerprintEntry
-	11, // 1: telegram.Account.created_at:type_name -> google.protobuf.Timestamp
-	11, // 2: telegram.Account.updated_at:type_name -> google.protobuf.Timestamp
-	11, // 3: telegram.Account.last_login_at:type_name -> google.protobuf.Timestamp
-	6,  // 4: telegram.ListAccountsResponse.accounts:type_name -> telegram.Account
-	10, // 5: telegram.Statistics.by_status:type_name -> telegram.Statistics.ByStatusEntry
-	0,  // 6: telegram.TelegramService.CreateAccount:input_type -> telegram.CreateAccountRequest
-	1,  // 7: telegram.TelegramService.GetAccount:input_type -> telegram.GetAccountRequest
-	2,  // 8: telegram.TelegramService.ListAccounts:input_type -> telegram.ListAccountsRequest
-	3,  // 9: telegram.TelegramService.UpdateAccountStatus:input_type -> telegram.UpdateStatusRequest
-	4,  // 10: telegram.TelegramService.RetryRegistration:input_type -> telegram.RetryRequest
-	5,  // 11: telegram.TelegramService.DeleteAccount:input_type -> telegram.DeleteAccountRequest
-	12, // 12: telegram.TelegramService.GetStatistics:input_type -> google.protobuf.Empty
-	6,  // 13: telegram.TelegramService.CreateAccount:output_type -> telegram.Account
-	6,  // 14: telegram.TelegramService.GetAccount:output_type -> telegram.Account
-	7,  // 15: telegram.TelegramService.ListAccounts:output_type -> telegram.ListAccountsResponse
-	6,  // 16: telegram.TelegramService.UpdateAccountStatus:output_type -> telegram.Account
-	6,  // 17: telegram.TelegramService.RetryRegistration:output_type -> telegram.Account
-	12, // 18: telegram.TelegramService.DeleteAccount:output_type -> google.protobuf.Empty
-	8,  // 19: telegram.TelegramService.GetStatistics:output_type -> telegram.Statistics
-	13, // [13:20] is the sub-list for method output_type
-	6,  // [6:13] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	21, // 0: telegram.Account.fingerprint:type_name -> telegram.Account.FingerprintEntry
+	23, // 1: telegram.Account.created_at:type_name -> google.protobuf.Timestamp
+	23, // 2: telegram.Account.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 3: telegram.Account.last_login_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: telegram.ListAccountsResponse.accounts:type_name -> telegram.Account
+	22, // 5: telegram.Statistics.by_status:type_name -> telegram.Statistics.ByStatusEntry
+	23, // 6: telegram.ScheduleMessageRequest.send_at:type_name -> google.protobuf.Timestamp
+	16, // 7: telegram.ImportContactsRequest.contacts:type_name -> telegram.Contact
+	0,  // 8: telegram.TelegramService.CreateAccount:input_type -> telegram.CreateAccountRequest
+	1,  // 9: telegram.TelegramService.GetAccount:input_type -> telegram.GetAccountRequest
+	4,  // 10: telegram.TelegramService.ListAccounts:input_type -> telegram.ListAccountsRequest
+	5,  // 11: telegram.TelegramService.UpdateAccountStatus:input_type -> telegram.UpdateStatusRequest
+	6,  // 12: telegram.TelegramService.RetryRegistration:input_type -> telegram.RetryRequest
+	7,  // 13: telegram.TelegramService.DeleteAccount:input_type -> telegram.DeleteAccountRequest
+	24, // 14: telegram.TelegramService.GetStatistics:input_type -> google.protobuf.Empty
+	11, // 15: telegram.TelegramService.JoinChannel:input_type -> telegram.JoinChannelRequest
+	12, // 16: telegram.TelegramService.SendMessage:input_type -> telegram.SendMessageRequest
+	13, // 17: telegram.TelegramService.ReactToMessage:input_type -> telegram.ReactToMessageRequest
+	14, // 18: telegram.TelegramService.PostStory:input_type -> telegram.PostStoryRequest
+	15, // 19: telegram.TelegramService.ScheduleMessage:input_type -> telegram.ScheduleMessageRequest
+	17, // 20: telegram.TelegramService.ImportContacts:input_type -> telegram.ImportContactsRequest
+	19, // 21: telegram.TelegramService.SeedDialog:input_type -> telegram.SeedDialogRequest
+	2,  // 22: telegram.TelegramService.GetSessionData:input_type -> telegram.GetSessionDataRequest
+	8,  // 23: telegram.TelegramService.CreateAccount:output_type -> telegram.Account
+	8,  // 24: telegram.TelegramService.GetAccount:output_type -> telegram.Account
+	9,  // 25: telegram.TelegramService.ListAccounts:output_type -> telegram.ListAccountsResponse
+	8,  // 26: telegram.TelegramService.UpdateAccountStatus:output_type -> telegram.Account
+	8,  // 27: telegram.TelegramService.RetryRegistration:output_type -> telegram.Account
+	24, // 28: telegram.TelegramService.DeleteAccount:output_type -> google.protobuf.Empty
+	10, // 29: telegram.TelegramService.GetStatistics:output_type -> telegram.Statistics
+	20, // 30: telegram.TelegramService.JoinChannel:output_type -> telegram.ActionResponse
+	20, // 31: telegram.TelegramService.SendMessage:output_type -> telegram.ActionResponse
+	20, // 32: telegram.TelegramService.ReactToMessage:output_type -> telegram.ActionResponse
+	20, // 33: telegram.TelegramService.PostStory:output_type -> telegram.ActionResponse
+	20, // 34: telegram.TelegramService.ScheduleMessage:output_type -> telegram.ActionResponse
+	18, // 35: telegram.TelegramService.ImportContacts:output_type -> telegram.ImportContactsResponse
+	20, // 36: telegram.TelegramService.SeedDialog:output_type -> telegram.ActionResponse
+	3,  // 37: telegram.TelegramService.GetSessionData:output_type -> telegram.SessionData
+	23, // [23:38] is the sub-list for method output_type
+	8,  // [8:23] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_services_telegram_service_proto_telegram_proto_init() }
@@ -891,7 +1771,7 @@ func file_services_telegram_service_proto_telegram_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_telegram_service_proto_telegram_proto_rawDesc), len(file_services_telegram_service_proto_telegram_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,14 @@ const (
 	TelegramService_RetryRegistration_FullMethodName   = "/telegram.TelegramService/RetryRegistration"
 	TelegramService_DeleteAccount_FullMethodName       = "/telegram.TelegramService/DeleteAccount"
 	TelegramService_GetStatistics_FullMethodName       = "/telegram.TelegramService/GetStatistics"
+	TelegramService_JoinChannel_FullMethodName         = "/telegram.TelegramService/JoinChannel"
+	TelegramService_SendMessage_FullMethodName         = "/telegram.TelegramService/SendMessage"
+	TelegramService_ReactToMessage_FullMethodName      = "/telegram.TelegramService/ReactToMessage"
+	TelegramService_PostStory_FullMethodName           = "/telegram.TelegramService/PostStory"
+	TelegramService_ScheduleMessage_FullMethodName     = "/telegram.TelegramService/ScheduleMessage"
+	TelegramService_ImportContacts_FullMethodName      = "/telegram.TelegramService/ImportContacts"
+	TelegramService_SeedDialog_FullMethodName          = "/telegram.TelegramService/SeedDialog"
+	TelegramService_GetSessionData_FullMethodName      = "/telegram.TelegramService/GetSessionData"
 )
 
 // TelegramServiceClient is the client API for TelegramService service.
@@ -40,6 +48,14 @@ type TelegramServiceClient interface {
 	RetryRegistration(ctx context.Context, in *RetryRequest, opts ...grpc.CallOption) (*Account, error)
 	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetStatistics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Statistics, error)
+	JoinChannel(ctx context.Context, in *JoinChannelRequest, opts ...grpc.CallOption) (*ActionResponse, error)
+	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*ActionResponse, error)
+	ReactToMessage(ctx context.Context, in *ReactToMessageRequest, opts ...grpc.CallOption) (*ActionResponse, error)
+	PostStory(ctx context.Context, in *PostStoryRequest, opts ...grpc.CallOption) (*ActionResponse, error)
+	ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...grpc.CallOption) (*ActionResponse, error)
+	ImportContacts(ctx context.Context, in *ImportContactsRequest, opts ...grpc.CallOption) (*ImportContactsResponse, error)
+	SeedDialog(ctx context.Context, in *SeedDialogRequest, opts ...grpc.CallOption) (*ActionResponse, error)
+	GetSessionData(ctx context.Context, in *GetSessionDataRequest, opts ...grpc.CallOption) (*SessionData, error)
 }
 
 type telegramServiceClient struct {
@@ -120,6 +136,86 @@ func (c *telegramServiceClient) GetStatistics(ctx context.Context, in *emptypb.E
 	return out, nil
 }
 
+func (c *telegramServiceClient) JoinChannel(ctx context.Context, in *JoinChannelRequest, opts ...grpc.CallOption) (*ActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionResponse)
+	err := c.cc.Invoke(ctx, TelegramService_JoinChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramServiceClient) SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*ActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionResponse)
+	err := c.cc.Invoke(ctx, TelegramService_SendMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramServiceClient) ReactToMessage(ctx context.Context, in *ReactToMessageRequest, opts ...grpc.CallOption) (*ActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionResponse)
+	err := c.cc.Invoke(ctx, TelegramService_ReactToMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramServiceClient) PostStory(ctx context.Context, in *PostStoryRequest, opts ...grpc.CallOption) (*ActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionResponse)
+	err := c.cc.Invoke(ctx, TelegramService_PostStory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramServiceClient) ScheduleMessage(ctx context.Context, in *ScheduleMessageRequest, opts ...grpc.CallOption) (*ActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionResponse)
+	err := c.cc.Invoke(ctx, TelegramService_ScheduleMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramServiceClient) ImportContacts(ctx context.Context, in *ImportContactsRequest, opts ...grpc.CallOption) (*ImportContactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportContactsResponse)
+	err := c.cc.Invoke(ctx, TelegramService_ImportContacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramServiceClient) SeedDialog(ctx context.Context, in *SeedDialogRequest, opts ...grpc.CallOption) (*ActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionResponse)
+	err := c.cc.Invoke(ctx, TelegramService_SeedDialog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telegramServiceClient) GetSessionData(ctx context.Context, in *GetSessionDataRequest, opts ...grpc.CallOption) (*SessionData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionData)
+	err := c.cc.Invoke(ctx, TelegramService_GetSessionData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TelegramServiceServer is the server API for TelegramService service.
 // All implementations must embed UnimplementedTelegramServiceServer
 // for forward compatibility.
@@ -131,6 +227,14 @@ type TelegramServiceServer interface {
 	RetryRegistration(context.Context, *RetryRequest) (*Account, error)
 	DeleteAccount(context.Context, *DeleteAccountRequest) (*emptypb.Empty, error)
 	GetStatistics(context.Context, *emptypb.Empty) (*Statistics, error)
+	JoinChannel(context.Context, *JoinChannelRequest) (*ActionResponse, error)
+	SendMessage(context.Context, *SendMessageRequest) (*ActionResponse, error)
+	ReactToMessage(context.Context, *ReactToMessageRequest) (*ActionResponse, error)
+	PostStory(context.Context, *PostStoryRequest) (*ActionResponse, error)
+	ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ActionResponse, error)
+	ImportContacts(context.Context, *ImportContactsRequest) (*ImportContactsResponse, error)
+	SeedDialog(context.Context, *SeedDialogRequest) (*ActionResponse, error)
+	GetSessionData(context.Context, *GetSessionDataRequest) (*SessionData, error)
 	mustEmbedUnimplementedTelegramServiceServer()
 }
 
@@ -162,6 +266,30 @@ func (UnimplementedTelegramServiceServer) DeleteAccount(context.Context, *Delete
 func (UnimplementedTelegramServiceServer) GetStatistics(context.Context, *emptypb.Empty) (*Statistics, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStatistics not implemented")
 }
+func (UnimplementedTelegramServiceServer) JoinChannel(context.Context, *JoinChannelRequest) (*ActionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinChannel not implemented")
+}
+func (UnimplementedTelegramServiceServer) SendMessage(context.Context, *SendMessageRequest) (*ActionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedTelegramServiceServer) ReactToMessage(context.Context, *ReactToMessageRequest) (*ActionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReactToMessage not implemented")
+}
+func (UnimplementedTelegramServiceServer) PostStory(context.Context, *PostStoryRequest) (*ActionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PostStory not implemented")
+}
+func (UnimplementedTelegramServiceServer) ScheduleMessage(context.Context, *ScheduleMessageRequest) (*ActionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScheduleMessage not implemented")
+}
+func (UnimplementedTelegramServiceServer) ImportContacts(context.Context, *ImportContactsRequest) (*ImportContactsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportContacts not implemented")
+}
+func (UnimplementedTelegramServiceServer) SeedDialog(context.Context, *SeedDialogRequest) (*ActionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SeedDialog not implemented")
+}
+func (UnimplementedTelegramServiceServer) GetSessionData(context.Context, *GetSessionDataRequest) (*SessionData, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSessionData not implemented")
+}
 func (UnimplementedTelegramServiceServer) mustEmbedUnimplementedTelegramServiceServer() {}
 func (UnimplementedTelegramServiceServer) testEmbeddedByValue()                         {}
 
@@ -309,6 +437,150 @@ func _TelegramService_GetStatistics_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TelegramService_JoinChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramServiceServer).JoinChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelegramService_JoinChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramServiceServer).JoinChannel(ctx, req.(*JoinChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelegramService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramService_ReactToMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactToMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramServiceServer).ReactToMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelegramService_ReactToMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramServiceServer).ReactToMessage(ctx, req.(*ReactToMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramService_PostStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostStoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramServiceServer).PostStory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelegramService_PostStory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramServiceServer).PostStory(ctx, req.(*PostStoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramService_ScheduleMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramServiceServer).ScheduleMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelegramService_ScheduleMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramServiceServer).ScheduleMessage(ctx, req.(*ScheduleMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramService_ImportContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramServiceServer).ImportContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelegramService_ImportContacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramServiceServer).ImportContacts(ctx, req.(*ImportContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramService_SeedDialog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedDialogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramServiceServer).SeedDialog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelegramService_SeedDialog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramServiceServer).SeedDialog(ctx, req.(*SeedDialogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelegramService_GetSessionData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelegramServiceServer).GetSessionData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelegramService_GetSessionData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelegramServiceServer).GetSessionData(ctx, req.(*GetSessionDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TelegramService_ServiceDesc is the grpc.ServiceDesc for TelegramService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -344,6 +616,38 @@ var TelegramService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatistics",
 			Handler:    _TelegramService_GetStatistics_Handler,
 		},
+		{
+			MethodName: "JoinChannel",
+			Handler:    _TelegramService_JoinChannel_Handler,
+		},
+		{
+			MethodName: "SendMessage",
+			Handler:    _TelegramService_SendMessage_Handler,
+		},
+		{
+			MethodName: "ReactToMessage",
+			Handler:    _TelegramService_ReactToMessage_Handler,
+		},
+		{
+			MethodName: "PostStory",
+			Handler:    _TelegramService_PostStory_Handler,
+		},
+		{
+			MethodName: "ScheduleMessage",
+			Handler:    _TelegramService_ScheduleMessage_Handler,
+		},
+		{
+			MethodName: "ImportContacts",
+			Handler:    _TelegramService_ImportContacts_Handler,
+		},
+		{
+			MethodName: "SeedDialog",
+			Handler:    _TelegramService_SeedDialog_Handler,
+		},
+		{
+			MethodName: "GetSessionData",
+			Handler:    _TelegramService_GetSessionData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/telegram-service/proto/telegram.proto",
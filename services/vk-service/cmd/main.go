@@ -12,13 +12,18 @@ import (
 	"github.com/grigta/conveer/pkg/config"
 	"github.com/grigta/conveer/pkg/crypto"
 	"github.com/grigta/conveer/pkg/database"
+	"github.com/grigta/conveer/pkg/health"
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/serviceauth"
 	vkconfig "github.com/grigta/conveer/services/vk-service/internal/config"
 	"github.com/grigta/conveer/services/vk-service/internal/handlers"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
 	"github.com/grigta/conveer/services/vk-service/internal/repository"
 	"github.com/grigta/conveer/services/vk-service/internal/service"
 	pb "github.com/grigta/conveer/services/vk-service/proto"
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
 	smspb "github.com/grigta/conveer/services/sms-service/proto"
 
@@ -31,7 +36,7 @@ import (
 
 func main() {
 	// Initialize logger
-	log := logger.New("vk-service")
+	log := logger.FromEnv("vk-service")
 	log.Info("Starting VK Service")
 
 	// Load configuration
@@ -73,6 +78,14 @@ func main() {
 		log.Fatal("Failed to setup RabbitMQ topology", "error", err)
 	}
 
+	// Readiness gates for gRPC health and /health, downstream services only degrade registration
+	healthChecker := health.NewChecker("vk-service")
+	healthChecker.Add("mongodb", health.MongoCheck(mongoClient))
+	healthChecker.Add("redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
+	healthChecker.Add("rabbitmq", health.PingCheck(messagingClient))
+
 	// Initialize encryptor
 	encryptor, err := crypto.NewEncryptor(cfg.Security.EncryptionKey)
 	if err != nil {
@@ -85,23 +98,45 @@ func main() {
 	// Initialize repositories
 	accountRepo := repository.NewAccountRepository(mongoDB, encryptor, log)
 	sessionRepo := repository.NewSessionRepository(mongoDB, redisClient, log)
+	appealRepo := repository.NewAppealRepository(mongoDB, log)
+	batchRepo := repository.NewBatchRepository(mongoDB, log)
+	selectorRepo := repository.NewSelectorRepository(mongoDB, log)
 
 	// Create indexes
 	if err := accountRepo.CreateIndexes(context.Background()); err != nil {
 		log.Error("Failed to create indexes", "error", err)
 	}
+	if err := appealRepo.CreateIndexes(context.Background()); err != nil {
+		log.Error("Failed to create appeal indexes", "error", err)
+	}
+	if err := batchRepo.CreateIndexes(context.Background()); err != nil {
+		log.Error("Failed to create batch indexes", "error", err)
+	}
+	if err := selectorRepo.CreateIndexes(context.Background()); err != nil {
+		log.Error("Failed to create selector indexes", "error", err)
+	}
 
 	// Initialize gRPC clients
-	proxyClient, err := createProxyClient(cfg)
+	proxyClient, err := createProxyClient(cfg, healthChecker)
 	if err != nil {
 		log.Fatal("Failed to create proxy service client", "error", err)
 	}
 
-	smsClient, err := createSMSClient(cfg)
+	smsClient, err := createSMSClient(cfg, healthChecker)
 	if err != nil {
 		log.Fatal("Failed to create SMS service client", "error", err)
 	}
 
+	personaClient, err := createPersonaClient(cfg, healthChecker)
+	if err != nil {
+		log.Fatal("Failed to create persona service client", "error", err)
+	}
+
+	mailClient, err := createMailClient(cfg, healthChecker)
+	if err != nil {
+		log.Fatal("Failed to create mail service client", "error", err)
+	}
+
 	// Initialize metrics first
 	metrics := service.NewMetricsCollector()
 
@@ -120,6 +155,19 @@ func main() {
 	// Initialize registration config from file
 	registrationConfig := vkCfg.ToRegistrationConfig()
 
+	// Initialize per-proxy and per-SMS-provider admission control
+	admission := service.NewAdmissionController(registrationConfig, metrics)
+
+	// Initialize registration selectors: file map hot-reloaded, remote override polled from MongoDB
+	selectorConfig := vkCfg.ToSelectorConfig()
+	selectorRegistry := service.NewSelectorRegistry(selectorRepo, metrics, log)
+	selectorCtx, stopSelectors := context.WithCancel(context.Background())
+	defer stopSelectors()
+	if selectorWatcher := setupSelectorWatcher(selectorCtx, selectorConfig, selectorRegistry, log); selectorWatcher != nil {
+		defer selectorWatcher.Stop()
+	}
+	go selectorRegistry.Start(selectorCtx, selectorConfig.RefreshInterval)
+
 	// Initialize registration flow
 	registrationFlow := service.NewRegistrationFlow(
 		accountRepo,
@@ -132,16 +180,66 @@ func main() {
 		encryptor,
 		passwordGen,
 		registrationConfig,
+		admission,
+		selectorRegistry,
 		messagingClient,
 		log,
 	)
 
+	// Initialize profile populator
+	profileConfig := vkCfg.ToProfileConfig()
+	profilePopulator := service.NewProfilePopulator(
+		accountRepo,
+		browserManager,
+		stealthInjector,
+		proxyClient,
+		profileConfig,
+		metrics,
+		log,
+	)
+
+	// Initialize recovery email binder
+	emailBindingConfig := vkCfg.ToEmailBindingConfig()
+	emailBinder := service.NewEmailBinder(
+		accountRepo,
+		browserManager,
+		stealthInjector,
+		proxyClient,
+		mailClient,
+		emailBindingConfig,
+		metrics,
+		log,
+	)
+
+	// Initialize ban appeal flow
+	appealConfig := vkCfg.ToAppealConfig()
+	appealFlow := service.NewAppealFlow(
+		browserManager,
+		stealthInjector,
+		proxyClient,
+		smsClient,
+		appealConfig,
+		registrationConfig,
+		metrics,
+		log,
+	)
+
 	// Initialize VK service
 	vkService := service.NewVKService(
 		accountRepo,
 		sessionRepo,
 		registrationFlow,
+		registrationConfig,
+		batchRepo,
+		profilePopulator,
+		profileConfig,
+		emailBinder,
+		emailBindingConfig,
+		appealRepo,
+		appealFlow,
+		appealConfig,
 		proxyClient,
+		personaClient,
 		messagingClient,
 		metrics,
 		log,
@@ -153,14 +251,17 @@ func main() {
 	}
 
 	// Initialize HTTP handler
-	httpHandler := handlers.NewHTTPHandler(vkService, log)
+	httpHandler := handlers.NewHTTPHandler(vkService, browserManager, selectorRegistry, healthChecker, log)
 
 	// Initialize gRPC handler
 	grpcHandler := handlers.NewGRPCHandler(vkService, log)
 
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	go healthChecker.Run(healthCtx, 10*time.Second)
+
 	// Start gRPC server
 	grpcPort := getEnvInt("GRPC_PORT", 50059)
-	go startGRPCServer(grpcPort, grpcHandler, log)
+	go startGRPCServer(grpcPort, grpcHandler, healthChecker, log)
 
 	// Start HTTP server
 	httpPort := getEnvInt("HTTP_PORT", 8009)
@@ -173,8 +274,11 @@ func main() {
 
 	log.Info("Shutting down VK Service")
 
-	// Shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Report NOT_SERVING so callers stop routing here while registrations drain
+	stopHealth()
+
+	// Shutdown context with timeout, long enough to drain in-flight registrations
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), registrationConfig.DrainTimeout+10*time.Second)
 	defer cancel()
 
 	// Shutdown services
@@ -185,6 +289,38 @@ func main() {
 	log.Info("VK Service stopped")
 }
 
+// setupSelectorWatcher loads the selector map and reapplies it whenever the file or config backend changes
+func setupSelectorWatcher(ctx context.Context, selectorConfig *models.SelectorConfig, registry service.SelectorRegistry, log logger.Logger) *config.ConfigWatcher {
+	source, err := config.NewConfigSource(config.ConfigWatchFromEnv(), "vk-selectors", selectorConfig.Path)
+	if err != nil {
+		log.Error("Selector hot-reload disabled, using built-in selectors", "error", err)
+		return nil
+	}
+
+	watcher := config.NewConfigWatcher(source)
+	if err := watcher.Load(ctx); err != nil {
+		log.Error("Failed to load selector map, using built-in selectors", "error", err)
+	} else {
+		var selectors models.SelectorMap
+		if err := watcher.Decode(&selectors); err != nil {
+			log.Error("Failed to parse selector map", "error", err)
+		} else if err := registry.ApplyFile(&selectors); err != nil {
+			log.Error("Failed to apply selector map", "error", err)
+		}
+	}
+
+	watcher.Subscribe("vk-selectors", nil, func(ctx context.Context, changes config.ChangeSet) error {
+		var selectors models.SelectorMap
+		if err := changes.Decode(&selectors); err != nil {
+			return err
+		}
+		return registry.ApplyFile(&selectors)
+	})
+
+	watcher.Start(ctx)
+	return watcher
+}
+
 func setupRabbitMQTopology(client messaging.Client) error {
 	// Declare exchanges
 	if err := client.DeclareExchange("vk.events", "topic"); err != nil {
@@ -196,7 +332,7 @@ func setupRabbitMQTopology(client messaging.Client) error {
 	}
 
 	// Declare queues
-	queues := []string{"vk.register", "vk.retry", "vk.manual_intervention"}
+	queues := []string{"vk.register", "vk.retry", "vk.manual_intervention", "vk.populate_profile", "vk.bind_email", "vk.appeal"}
 	for _, queue := range queues {
 		if err := client.DeclareQueue(queue); err != nil {
 			return fmt.Errorf("failed to declare queue %s: %w", queue, err)
@@ -207,7 +343,10 @@ func setupRabbitMQTopology(client messaging.Client) error {
 	bindings := map[string]string{
 		"vk.register":            "vk.commands",
 		"vk.retry":               "vk.commands",
-		"vk.manual_intervention": "vk.commands",
+		"vk.manual_intervention": "vk.events",
+		"vk.populate_profile":    "vk.commands",
+		"vk.bind_email":          "vk.commands",
+		"vk.appeal":              "vk.commands",
 	}
 
 	for queue, exchange := range bindings {
@@ -219,32 +358,63 @@ func setupRabbitMQTopology(client messaging.Client) error {
 	return nil
 }
 
-func createProxyClient(cfg *config.Config) (proxypb.ProxyServiceClient, error) {
+// serviceAuth attaches vk-service's token to calls of other services and checks incoming ones
+var serviceAuth = serviceauth.FromEnv("vk-service")
+
+func dialOptions() []grpc.DialOption {
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, logger.DialOptions()...)
+	return append(opts, serviceAuth.DialOptions()...)
+}
+
+func createProxyClient(cfg *config.Config, healthChecker *health.Checker) (proxypb.ProxyServiceClient, error) {
 	proxyServiceURL := getEnv("PROXY_SERVICE_URL", "proxy-service:50057")
-	conn, err := grpc.Dial(proxyServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(proxyServiceURL, dialOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to proxy service: %w", err)
 	}
+	healthChecker.AddOptional("proxy-service", health.GRPCCheck(conn, ""))
 	return proxypb.NewProxyServiceClient(conn), nil
 }
 
-func createSMSClient(cfg *config.Config) (smspb.SMSServiceClient, error) {
+func createSMSClient(cfg *config.Config, healthChecker *health.Checker) (smspb.SMSServiceClient, error) {
 	smsServiceURL := getEnv("SMS_SERVICE_URL", "sms-service:50058")
-	conn, err := grpc.Dial(smsServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(smsServiceURL, dialOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to SMS service: %w", err)
 	}
+	healthChecker.AddOptional("sms-service", health.GRPCCheck(conn, ""))
 	return smspb.NewSMSServiceClient(conn), nil
 }
 
-func startGRPCServer(port int, handler *handlers.GRPCHandler, log logger.Logger) {
+func createPersonaClient(cfg *config.Config, healthChecker *health.Checker) (personapb.PersonaServiceClient, error) {
+	personaServiceURL := getEnv("PERSONA_SERVICE_URL", "persona-service:50064")
+	conn, err := grpc.Dial(personaServiceURL, dialOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to persona service: %w", err)
+	}
+	healthChecker.AddOptional("persona-service", health.GRPCCheck(conn, ""))
+	return personapb.NewPersonaServiceClient(conn), nil
+}
+
+func createMailClient(cfg *config.Config, healthChecker *health.Checker) (mailpb.MailServiceClient, error) {
+	mailServiceURL := getEnv("MAIL_SERVICE_URL", "mail-service:50061")
+	conn, err := grpc.Dial(mailServiceURL, dialOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mail service: %w", err)
+	}
+	healthChecker.AddOptional("mail-service", health.GRPCCheck(conn, ""))
+	return mailpb.NewMailServiceClient(conn), nil
+}
+
+func startGRPCServer(port int, handler *handlers.GRPCHandler, healthChecker *health.Checker, log logger.Logger) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.Fatal("Failed to listen on gRPC port", "port", port, "error", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(append(logger.ServerOptions(), serviceAuth.ServerOptions()...)...)
 	pb.RegisterVKServiceServer(grpcServer, handler)
+	healthChecker.Register(grpcServer)
 	reflection.Register(grpcServer)
 
 	log.Info("Starting gRPC server", "port", port)
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/grigta/conveer/services/vk-service/internal/models"
@@ -16,17 +17,28 @@ type VKConfig struct {
 	Browser        BrowserConfig        `yaml:"browser"`
 	AntiDetection  AntiDetectionConfig  `yaml:"anti_detection"`
 	Monitoring     MonitoringConfig     `yaml:"monitoring"`
+	Profile        ProfileConfig        `yaml:"profile"`
+	Appeal         AppealConfig         `yaml:"appeal"`
+	EmailBinding   EmailBindingConfig   `yaml:"email_binding"`
+	Selectors      SelectorsConfig      `yaml:"selectors"`
 }
 
 type RegistrationConfig struct {
-	MaxRetryAttempts   int `yaml:"max_retry_attempts"`
-	RetryBackoffBase   int `yaml:"retry_backoff_base"`     // seconds
-	FormFillDelayMin   int `yaml:"form_fill_delay_min"`     // ms
-	FormFillDelayMax   int `yaml:"form_fill_delay_max"`     // ms
-	SMSWaitTimeout     int `yaml:"sms_wait_timeout"`        // seconds
-	PageLoadTimeout    int `yaml:"page_load_timeout"`       // seconds
-	SMSPollingInterval int `yaml:"sms_polling_interval"`    // seconds
-	MaxSMSPolls        int `yaml:"max_sms_polls"`
+	MaxRetryAttempts   int            `yaml:"max_retry_attempts"`
+	RetryBackoffBase   int            `yaml:"retry_backoff_base"`   // seconds
+	FormFillDelayMin   int            `yaml:"form_fill_delay_min"`  // ms
+	FormFillDelayMax   int            `yaml:"form_fill_delay_max"`  // ms
+	SMSWaitTimeout     int            `yaml:"sms_wait_timeout"`     // seconds
+	PageLoadTimeout    int            `yaml:"page_load_timeout"`    // seconds
+	SMSPollingInterval int            `yaml:"sms_polling_interval"` // seconds
+	MaxSMSPolls        int            `yaml:"max_sms_polls"`
+	Concurrency        int            `yaml:"concurrency"`
+	QueueSize          int            `yaml:"queue_size"`
+	ProxyMaxConcurrent int            `yaml:"proxy_max_concurrent"`
+	SMSProviderLimits  map[string]int `yaml:"sms_provider_limits"` // provider -> numbers in use at once
+	AdmissionTimeout   int            `yaml:"admission_timeout"`   // seconds
+	DrainTimeout       int            `yaml:"drain_timeout"`       // seconds
+	MaxBatchSize       int            `yaml:"max_batch_size"`
 }
 
 type BrowserConfig struct {
@@ -47,6 +59,36 @@ type MonitoringConfig struct {
 	SessionExpiry            int `yaml:"session_expiry"`              // minutes
 }
 
+type ProfileConfig struct {
+	PopulateAfterRegistration bool   `yaml:"populate_after_registration"`
+	AvatarPoolDir             string `yaml:"avatar_pool_dir"`
+	ActionDelayMin            int    `yaml:"action_delay_min"` // ms
+	ActionDelayMax            int    `yaml:"action_delay_max"` // ms
+}
+
+type AppealConfig struct {
+	Enabled           bool `yaml:"enabled"`
+	AutoAppeal        bool `yaml:"auto_appeal"`         // appeal as soon as an account gets banned
+	AllowRentedNumber bool `yaml:"allow_rented_number"` // rent a number when the original one is gone
+	CheckInterval     int  `yaml:"check_interval"`      // minutes
+	MaxDuration       int  `yaml:"max_duration"`        // hours
+	ActionDelayMin    int  `yaml:"action_delay_min"`    // ms
+	ActionDelayMax    int  `yaml:"action_delay_max"`    // ms
+}
+
+type EmailBindingConfig struct {
+	BindAfterRegistration bool `yaml:"bind_after_registration"`
+	Timeout               int  `yaml:"timeout"`          // seconds to wait for the confirmation email
+	PollInterval          int  `yaml:"poll_interval"`    // seconds between mailbox checks
+	ActionDelayMin        int  `yaml:"action_delay_min"` // ms
+	ActionDelayMax        int  `yaml:"action_delay_max"` // ms
+}
+
+type SelectorsConfig struct {
+	Path            string `yaml:"path"`             // YAML selector map, hot-reloaded
+	RefreshInterval int    `yaml:"refresh_interval"` // seconds between remote override checks
+}
+
 type Config struct {
 	VK VKConfig `yaml:"vk"`
 }
@@ -88,6 +130,12 @@ func (c *Config) setDefaults() {
 	c.VK.Registration.PageLoadTimeout = 30
 	c.VK.Registration.SMSPollingInterval = 10
 	c.VK.Registration.MaxSMSPolls = 30
+	c.VK.Registration.Concurrency = 4
+	c.VK.Registration.QueueSize = 4
+	c.VK.Registration.ProxyMaxConcurrent = 1
+	c.VK.Registration.AdmissionTimeout = 600
+	c.VK.Registration.DrainTimeout = 300
+	c.VK.Registration.MaxBatchSize = 500
 
 	c.VK.Browser.PoolSize = 10
 	c.VK.Browser.Headless = true
@@ -100,6 +148,28 @@ func (c *Config) setDefaults() {
 	c.VK.Monitoring.StuckRegistrationTimeout = 30
 	c.VK.Monitoring.SessionCleanupInterval = 60
 	c.VK.Monitoring.SessionExpiry = 120
+
+	c.VK.Profile.PopulateAfterRegistration = false
+	c.VK.Profile.AvatarPoolDir = "/data/avatars"
+	c.VK.Profile.ActionDelayMin = 1000
+	c.VK.Profile.ActionDelayMax = 3000
+
+	c.VK.Appeal.Enabled = true
+	c.VK.Appeal.AutoAppeal = false
+	c.VK.Appeal.AllowRentedNumber = true
+	c.VK.Appeal.CheckInterval = 360
+	c.VK.Appeal.MaxDuration = 336
+	c.VK.Appeal.ActionDelayMin = 1000
+	c.VK.Appeal.ActionDelayMax = 3000
+
+	c.VK.EmailBinding.BindAfterRegistration = false
+	c.VK.EmailBinding.Timeout = 300
+	c.VK.EmailBinding.PollInterval = 15
+	c.VK.EmailBinding.ActionDelayMin = 1000
+	c.VK.EmailBinding.ActionDelayMax = 3000
+
+	c.VK.Selectors.Path = "./configs/vk_selectors.yaml"
+	c.VK.Selectors.RefreshInterval = 60
 }
 
 func (c *Config) overrideFromEnv() {
@@ -128,6 +198,27 @@ func (c *Config) overrideFromEnv() {
 	if val := getEnvInt("VK_MAX_SMS_POLLS"); val > 0 {
 		c.VK.Registration.MaxSMSPolls = val
 	}
+	if val := getEnvInt("VK_REGISTRATION_CONCURRENCY"); val > 0 {
+		c.VK.Registration.Concurrency = val
+	}
+	if val := getEnvInt("VK_REGISTRATION_QUEUE_SIZE"); val > 0 {
+		c.VK.Registration.QueueSize = val
+	}
+	if val := getEnvInt("VK_PROXY_MAX_CONCURRENT"); val > 0 {
+		c.VK.Registration.ProxyMaxConcurrent = val
+	}
+	if val := os.Getenv("VK_SMS_PROVIDER_LIMITS"); val != "" {
+		c.VK.Registration.SMSProviderLimits = parseLimits(val)
+	}
+	if val := getEnvInt("VK_ADMISSION_TIMEOUT"); val > 0 {
+		c.VK.Registration.AdmissionTimeout = val
+	}
+	if val := getEnvInt("VK_DRAIN_TIMEOUT"); val > 0 {
+		c.VK.Registration.DrainTimeout = val
+	}
+	if val := getEnvInt("VK_MAX_BATCH_SIZE"); val > 0 {
+		c.VK.Registration.MaxBatchSize = val
+	}
 
 	// Browser
 	if val := getEnvInt("VK_BROWSER_POOL_SIZE"); val > 0 {
@@ -150,6 +241,50 @@ func (c *Config) overrideFromEnv() {
 	if val := os.Getenv("VK_MOUSE_EMULATION"); val != "" {
 		c.VK.AntiDetection.MouseEmulation = val == "true" || val == "1"
 	}
+
+	// Profile
+	if val := os.Getenv("VK_POPULATE_PROFILE"); val != "" {
+		c.VK.Profile.PopulateAfterRegistration = val == "true" || val == "1"
+	}
+	if val := os.Getenv("VK_AVATAR_POOL_DIR"); val != "" {
+		c.VK.Profile.AvatarPoolDir = val
+	}
+
+	// Appeal
+	if val := os.Getenv("VK_APPEAL_ENABLED"); val != "" {
+		c.VK.Appeal.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("VK_AUTO_APPEAL"); val != "" {
+		c.VK.Appeal.AutoAppeal = val == "true" || val == "1"
+	}
+	if val := os.Getenv("VK_APPEAL_ALLOW_RENTED_NUMBER"); val != "" {
+		c.VK.Appeal.AllowRentedNumber = val == "true" || val == "1"
+	}
+	if val := getEnvInt("VK_APPEAL_CHECK_INTERVAL"); val > 0 {
+		c.VK.Appeal.CheckInterval = val
+	}
+	if val := getEnvInt("VK_APPEAL_MAX_DURATION"); val > 0 {
+		c.VK.Appeal.MaxDuration = val
+	}
+
+	// Email binding
+	if val := os.Getenv("VK_BIND_EMAIL"); val != "" {
+		c.VK.EmailBinding.BindAfterRegistration = val == "true" || val == "1"
+	}
+	if val := getEnvInt("VK_EMAIL_BINDING_TIMEOUT"); val > 0 {
+		c.VK.EmailBinding.Timeout = val
+	}
+	if val := getEnvInt("VK_EMAIL_BINDING_POLL_INTERVAL"); val > 0 {
+		c.VK.EmailBinding.PollInterval = val
+	}
+
+	// Selectors
+	if val := os.Getenv("VK_SELECTORS_PATH"); val != "" {
+		c.VK.Selectors.Path = val
+	}
+	if val := getEnvInt("VK_SELECTORS_REFRESH_INTERVAL"); val > 0 {
+		c.VK.Selectors.RefreshInterval = val
+	}
 }
 
 func getEnvInt(key string) int {
@@ -161,6 +296,22 @@ func getEnvInt(key string) int {
 	return 0
 }
 
+// parseLimits parses "provider=limit" pairs separated by commas, malformed pairs are skipped
+func parseLimits(val string) map[string]int {
+	limits := make(map[string]int)
+	for _, pair := range strings.Split(val, ",") {
+		name, limit, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		var n int
+		if _, err := fmt.Sscanf(limit, "%d", &n); err == nil && n > 0 {
+			limits[strings.TrimSpace(name)] = n
+		}
+	}
+	return limits
+}
+
 // ToRegistrationConfig converts to models.RegistrationConfig
 func (c *Config) ToRegistrationConfig() *models.RegistrationConfig {
 	return &models.RegistrationConfig{
@@ -172,6 +323,13 @@ func (c *Config) ToRegistrationConfig() *models.RegistrationConfig {
 		PageLoadTimeout:    time.Duration(c.VK.Registration.PageLoadTimeout) * time.Second,
 		SMSPollingInterval: time.Duration(c.VK.Registration.SMSPollingInterval) * time.Second,
 		MaxSMSPolls:        c.VK.Registration.MaxSMSPolls,
+		Concurrency:        c.VK.Registration.Concurrency,
+		QueueSize:          c.VK.Registration.QueueSize,
+		ProxyMaxConcurrent: c.VK.Registration.ProxyMaxConcurrent,
+		SMSProviderLimits:  c.VK.Registration.SMSProviderLimits,
+		AdmissionTimeout:   time.Duration(c.VK.Registration.AdmissionTimeout) * time.Second,
+		DrainTimeout:       time.Duration(c.VK.Registration.DrainTimeout) * time.Second,
+		MaxBatchSize:       c.VK.Registration.MaxBatchSize,
 	}
 }
 
@@ -184,3 +342,45 @@ func (c *Config) ToBrowserConfig() *service.BrowserConfig {
 		DefaultTimeout: time.Duration(c.VK.Registration.PageLoadTimeout) * time.Second,
 	}
 }
+
+// ToProfileConfig converts to models.ProfileConfig
+func (c *Config) ToProfileConfig() *models.ProfileConfig {
+	return &models.ProfileConfig{
+		PopulateAfterRegistration: c.VK.Profile.PopulateAfterRegistration,
+		AvatarPoolDir:             c.VK.Profile.AvatarPoolDir,
+		ActionDelayMin:            c.VK.Profile.ActionDelayMin,
+		ActionDelayMax:            c.VK.Profile.ActionDelayMax,
+	}
+}
+
+// ToEmailBindingConfig converts to models.EmailBindingConfig
+func (c *Config) ToEmailBindingConfig() *models.EmailBindingConfig {
+	return &models.EmailBindingConfig{
+		BindAfterRegistration: c.VK.EmailBinding.BindAfterRegistration,
+		Timeout:               time.Duration(c.VK.EmailBinding.Timeout) * time.Second,
+		PollInterval:          time.Duration(c.VK.EmailBinding.PollInterval) * time.Second,
+		ActionDelayMin:        c.VK.EmailBinding.ActionDelayMin,
+		ActionDelayMax:        c.VK.EmailBinding.ActionDelayMax,
+	}
+}
+
+// ToAppealConfig converts to models.AppealConfig
+func (c *Config) ToAppealConfig() *models.AppealConfig {
+	return &models.AppealConfig{
+		Enabled:           c.VK.Appeal.Enabled,
+		AutoAppeal:        c.VK.Appeal.AutoAppeal,
+		AllowRentedNumber: c.VK.Appeal.AllowRentedNumber,
+		CheckInterval:     time.Duration(c.VK.Appeal.CheckInterval) * time.Minute,
+		MaxDuration:       time.Duration(c.VK.Appeal.MaxDuration) * time.Hour,
+		ActionDelayMin:    c.VK.Appeal.ActionDelayMin,
+		ActionDelayMax:    c.VK.Appeal.ActionDelayMax,
+	}
+}
+
+// ToSelectorConfig converts to models.SelectorConfig
+func (c *Config) ToSelectorConfig() *models.SelectorConfig {
+	return &models.SelectorConfig{
+		Path:            c.VK.Selectors.Path,
+		RefreshInterval: time.Duration(c.VK.Selectors.RefreshInterval) * time.Second,
+	}
+}
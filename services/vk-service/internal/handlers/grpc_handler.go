@@ -2,7 +2,7 @@ package handlers
 
 import (
 	"context"
-	"time"
+	"errors"
 
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/vk-service/internal/models"
@@ -35,6 +35,7 @@ func (h *GRPCHandler) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 		LastName:         req.LastName,
 		PreferredCountry: req.PreferredCountry,
 		UseRandomProfile: req.UseRandomProfile,
+		PersonaID:        req.PersonaId,
 	}
 
 	if req.BirthDate != nil {
@@ -149,6 +150,142 @@ func (h *GRPCHandler) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRe
 	return &emptypb.Empty{}, nil
 }
 
+func (h *GRPCHandler) PopulateProfile(ctx context.Context, req *pb.PopulateProfileRequest) (*pb.PopulateProfileResponse, error) {
+	id, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	result, err := h.vkService.PopulateProfile(ctx, id, &models.ProfilePopulationOptions{
+		SkipAvatar: req.SkipAvatar,
+		Status:     req.Status,
+		City:       req.City,
+		Interests:  req.Interests,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to populate profile: %v", err)
+	}
+
+	resp := &pb.PopulateProfileResponse{
+		AccountId:   result.AccountID,
+		City:        result.Profile.City,
+		Status:      result.Profile.Status,
+		Interests:   result.Profile.Interests,
+		AvatarUrl:   result.Profile.AvatarURL,
+		FailedSteps: result.FailedSteps,
+	}
+	if result.Profile.Education != nil {
+		resp.University = result.Profile.Education.University
+		resp.GraduationYear = int32(result.Profile.Education.GraduationYear)
+	}
+	for _, step := range result.CompletedSteps {
+		resp.CompletedSteps = append(resp.CompletedSteps, string(step))
+	}
+
+	return resp, nil
+}
+
+func (h *GRPCHandler) BindEmail(ctx context.Context, req *pb.BindEmailRequest) (*pb.BindEmailResponse, error) {
+	id, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	result, err := h.vkService.BindEmail(ctx, id)
+	if err != nil {
+		if errors.Is(err, service.ErrNoRecoveryMailbox) {
+			return nil, status.Errorf(codes.ResourceExhausted, "failed to bind email: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to bind email: %v", err)
+	}
+
+	return &pb.BindEmailResponse{
+		AccountId:     result.AccountID,
+		Email:         result.Email,
+		MailAccountId: result.MailAccountID,
+		BoundAt:       timestamppb.New(result.BoundAt),
+	}, nil
+}
+
+func (h *GRPCHandler) CreateAccountsBatch(ctx context.Context, req *pb.CreateAccountsBatchRequest) (*pb.RegistrationBatch, error) {
+	opts := models.BatchOptions{
+		Gender:           models.Gender(req.Gender),
+		PreferredCountry: req.PreferredCountry,
+	}
+
+	batch, err := h.vkService.CreateAccountsBatch(ctx, int(req.Count), opts)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBatchSize) {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to create batch: %v", err)
+		}
+		h.logger.Error("Failed to create batch", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create batch: %v", err)
+	}
+
+	return batchToProto(batch), nil
+}
+
+func (h *GRPCHandler) GetBatchStatus(ctx context.Context, req *pb.GetBatchStatusRequest) (*pb.RegistrationBatch, error) {
+	id, err := primitive.ObjectIDFromHex(req.BatchId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid batch ID: %v", err)
+	}
+
+	batch, err := h.vkService.GetBatchStatus(ctx, id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "batch not found: %v", err)
+	}
+
+	return batchToProto(batch), nil
+}
+
+func (h *GRPCHandler) CancelBatch(ctx context.Context, req *pb.CancelBatchRequest) (*pb.RegistrationBatch, error) {
+	id, err := primitive.ObjectIDFromHex(req.BatchId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid batch ID: %v", err)
+	}
+
+	batch, err := h.vkService.CancelBatch(ctx, id)
+	if err != nil {
+		if errors.Is(err, service.ErrBatchNotRunning) {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to cancel batch: %v", err)
+		}
+		return nil, status.Errorf(codes.NotFound, "failed to cancel batch: %v", err)
+	}
+
+	return batchToProto(batch), nil
+}
+
+func (h *GRPCHandler) ExportAccount(ctx context.Context, req *pb.ExportAccountRequest) (*pb.AccountExport, error) {
+	id, err := primitive.ObjectIDFromHex(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	export, err := h.vkService.ExportAccount(ctx, id, models.ExportOptions{
+		RecipientPublicKey: []byte(req.RecipientPublicKey),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRecipientKey):
+			return nil, status.Errorf(codes.InvalidArgument, "failed to export account: %v", err)
+		case errors.Is(err, service.ErrAccountNotExportable):
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to export account: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to export account: %v", err)
+	}
+
+	return &pb.AccountExport{
+		AccountId:       export.AccountID,
+		CredentialsJson: export.Credentials,
+		CookiesTxt:      export.Cookies,
+		Encrypted:       export.Encrypted,
+		Encryption:      export.Encryption,
+		EncryptedKey:    export.EncryptedKey,
+		ExportedAt:      timestamppb.New(export.ExportedAt),
+	}, nil
+}
+
 func (h *GRPCHandler) GetStatistics(ctx context.Context, req *emptypb.Empty) (*pb.Statistics, error) {
 	stats, err := h.vkService.GetStatistics(ctx)
 	if err != nil {
@@ -185,6 +322,8 @@ func (h *GRPCHandler) accountToProto(account *models.VKAccount) *pb.Account {
 		RegistrationIp: account.RegistrationIP,
 		ErrorMessage:   account.ErrorMessage,
 		RetryCount:     int32(account.RetryCount),
+		PersonaId:      account.PersonaID,
+		Quality:        string(account.QualityOrDefault()),
 		CreatedAt:      timestamppb.New(account.CreatedAt),
 		UpdatedAt:      timestamppb.New(account.UpdatedAt),
 	}
@@ -197,6 +336,10 @@ func (h *GRPCHandler) accountToProto(account *models.VKAccount) *pb.Account {
 		protoAccount.LastLoginAt = timestamppb.New(*account.LastLoginAt)
 	}
 
+	if account.EmailBoundAt != nil {
+		protoAccount.EmailBoundAt = timestamppb.New(*account.EmailBoundAt)
+	}
+
 	// Convert fingerprint to string map
 	if account.Fingerprint != nil {
 		protoAccount.Fingerprint = make(map[string]string)
@@ -209,3 +352,39 @@ func (h *GRPCHandler) accountToProto(account *models.VKAccount) *pb.Account {
 
 	return protoAccount
 }
+
+func batchToProto(batch *models.RegistrationBatch) *pb.RegistrationBatch {
+	protoBatch := &pb.RegistrationBatch{
+		Id:        batch.ID.Hex(),
+		Status:    string(batch.Status),
+		Requested: int32(batch.Requested),
+		Pending:   int32(batch.Pending),
+		Succeeded: int32(batch.Succeeded),
+		Failed:    int32(batch.Failed),
+		Cancelled: int32(batch.Cancelled),
+		CreatedAt: timestamppb.New(batch.CreatedAt),
+	}
+
+	if batch.CancelledAt != nil {
+		protoBatch.CancelledAt = timestamppb.New(*batch.CancelledAt)
+	}
+
+	if batch.CompletedAt != nil {
+		protoBatch.CompletedAt = timestamppb.New(*batch.CompletedAt)
+	}
+
+	for _, item := range batch.Items {
+		protoItem := &pb.BatchItem{
+			Status:    string(item.Status),
+			Step:      string(item.Step),
+			Error:     item.Error,
+			UpdatedAt: timestamppb.New(item.UpdatedAt),
+		}
+		if !item.AccountID.IsZero() {
+			protoItem.AccountId = item.AccountID.Hex()
+		}
+		protoBatch.Items = append(protoBatch.Items, protoItem)
+	}
+
+	return protoBatch
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/grigta/conveer/pkg/health"
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/services/vk-service/internal/models"
 	"github.com/grigta/conveer/services/vk-service/internal/service"
@@ -14,20 +16,26 @@ import (
 )
 
 type HTTPHandler struct {
-	vkService service.VKService
-	logger    logger.Logger
+	vkService     service.VKService
+	logger        logger.Logger
+	browserPool   service.BrowserManager
+	selectors     service.SelectorRegistry
+	healthChecker *health.Checker
 }
 
-func NewHTTPHandler(vkService service.VKService, logger logger.Logger) *HTTPHandler {
+func NewHTTPHandler(vkService service.VKService, browserPool service.BrowserManager, selectors service.SelectorRegistry, healthChecker *health.Checker, logger logger.Logger) *HTTPHandler {
 	return &HTTPHandler{
-		vkService: vkService,
-		logger:    logger,
+		vkService:     vkService,
+		logger:        logger,
+		browserPool:   browserPool,
+		selectors:     selectors,
+		healthChecker: healthChecker,
 	}
 }
 
 func (h *HTTPHandler) RegisterRoutes(router *gin.Engine) {
 	// Health check
-	router.GET("/health", h.HealthCheck)
+	router.GET("/health", h.healthChecker.Handler())
 
 	// Metrics endpoint
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -42,18 +50,33 @@ func (h *HTTPHandler) RegisterRoutes(router *gin.Engine) {
 			accounts.GET("", h.ListAccounts)
 			accounts.PUT("/:id/status", h.UpdateAccountStatus)
 			accounts.POST("/:id/retry", h.RetryRegistration)
+			accounts.POST("/:id/populate-profile", h.PopulateProfile)
+			accounts.POST("/:id/bind-email", h.BindEmail)
+			accounts.POST("/:id/appeal", h.AppealBan)
+			accounts.GET("/:id/appeal", h.GetAppeal)
 			accounts.DELETE("/:id", h.DeleteAccount)
 		}
 
+		batches := api.Group("/batches")
+		{
+			batches.POST("", h.CreateAccountsBatch)
+			batches.GET("/:id", h.GetBatchStatus)
+			batches.POST("/:id/cancel", h.CancelBatch)
+		}
+
 		api.GET("/statistics", h.GetStatistics)
-	}
-}
 
-func (h *HTTPHandler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "vk-service",
-	})
+		api.GET("/browser-pool", h.GetBrowserPool)
+		api.PUT("/browser-pool", h.ResizeBrowserPool)
+
+		selectors := api.Group("/selectors")
+		{
+			selectors.GET("", h.GetSelectors)
+			selectors.PUT("", h.PublishSelectors)
+			selectors.GET("/versions", h.ListSelectorVersions)
+			selectors.POST("/rollback/:version", h.RollbackSelectors)
+		}
+	}
 }
 
 func (h *HTTPHandler) CreateAccount(c *gin.Context) {
@@ -198,6 +221,111 @@ func (h *HTTPHandler) RetryRegistration(c *gin.Context) {
 	})
 }
 
+func (h *HTTPHandler) PopulateProfile(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account ID",
+		})
+		return
+	}
+
+	var opts models.ProfilePopulationOptions
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&opts); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := h.vkService.PopulateProfile(c.Request.Context(), id, &opts)
+	if err != nil {
+		h.logger.Error("Failed to populate profile", "error", err, "id", idStr)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to populate profile",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *HTTPHandler) BindEmail(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account ID",
+		})
+		return
+	}
+
+	result, err := h.vkService.BindEmail(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to bind email", "error", err, "id", idStr)
+		code := http.StatusInternalServerError
+		if errors.Is(err, service.ErrNoRecoveryMailbox) {
+			code = http.StatusConflict
+		}
+		c.JSON(code, gin.H{
+			"error":   "Failed to bind email",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *HTTPHandler) AppealBan(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account ID",
+		})
+		return
+	}
+
+	appeal, err := h.vkService.AppealBan(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to queue appeal", "error", err, "id", idStr)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to queue appeal",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, appeal)
+}
+
+func (h *HTTPHandler) GetAppeal(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account ID",
+		})
+		return
+	}
+
+	appeal, err := h.vkService.GetAppeal(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Appeal not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, appeal)
+}
+
 func (h *HTTPHandler) DeleteAccount(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := primitive.ObjectIDFromHex(idStr)
@@ -222,6 +350,85 @@ func (h *HTTPHandler) DeleteAccount(c *gin.Context) {
 	})
 }
 
+// CreateAccountsBatch queues count registrations under one batch and returns it right away
+func (h *HTTPHandler) CreateAccountsBatch(c *gin.Context) {
+	var req struct {
+		Count int `json:"count" binding:"required"`
+		models.BatchOptions
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	batch, err := h.vkService.CreateAccountsBatch(c.Request.Context(), req.Count, req.BatchOptions)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBatchSize) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to create batch", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create batch",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, batch)
+}
+
+func (h *HTTPHandler) GetBatchStatus(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid batch ID",
+		})
+		return
+	}
+
+	batch, err := h.vkService.GetBatchStatus(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Batch not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+func (h *HTTPHandler) CancelBatch(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid batch ID",
+		})
+		return
+	}
+
+	batch, err := h.vkService.CancelBatch(c.Request.Context(), id)
+	if err != nil {
+		code := http.StatusNotFound
+		if errors.Is(err, service.ErrBatchNotRunning) {
+			code = http.StatusConflict
+		}
+		c.JSON(code, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
 func (h *HTTPHandler) GetStatistics(c *gin.Context) {
 	stats, err := h.vkService.GetStatistics(c.Request.Context())
 	if err != nil {
@@ -234,3 +441,119 @@ func (h *HTTPHandler) GetStatistics(c *gin.Context) {
 
 	c.JSON(http.StatusOK, stats)
 }
+
+func (h *HTTPHandler) GetBrowserPool(c *gin.Context) {
+	c.JSON(http.StatusOK, h.browserPool.GetPoolStats())
+}
+
+// ResizeBrowserPool grows or shrinks the browser pool without a restart
+func (h *HTTPHandler) ResizeBrowserPool(c *gin.Context) {
+	var req struct {
+		Size int `json:"size" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	stats, err := h.browserPool.Resize(c.Request.Context(), req.Size)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPoolSize) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to resize browser pool", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *HTTPHandler) GetSelectors(c *gin.Context) {
+	c.JSON(http.StatusOK, h.selectors.Snapshot())
+}
+
+// PublishSelectors stores a new remote selector override, listed keys replace the file selectors
+func (h *HTTPHandler) PublishSelectors(c *gin.Context) {
+	var req struct {
+		Selectors map[string][]string `json:"selectors"`
+		Comment   string              `json:"comment"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	selectorMap, err := h.selectors.Publish(c.Request.Context(), req.Selectors, req.Comment)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownSelector) || errors.Is(err, service.ErrInvalidSelectors) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to publish selectors", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, selectorMap)
+}
+
+func (h *HTTPHandler) ListSelectorVersions(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	versions, err := h.selectors.ListVersions(c.Request.Context(), limit)
+	if err != nil {
+		h.logger.Error("Failed to list selector versions", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"versions": versions,
+	})
+}
+
+func (h *HTTPHandler) RollbackSelectors(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid selectors version",
+		})
+		return
+	}
+
+	selectorMap, err := h.selectors.Rollback(c.Request.Context(), version)
+	if err != nil {
+		if errors.Is(err, service.ErrSelectorVersion) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to roll back selectors", "error", err, "version", version)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, selectorMap)
+}
@@ -16,6 +16,7 @@ const (
 	StatusBanned     AccountStatus = "banned"
 	StatusError      AccountStatus = "error"
 	StatusSuspended  AccountStatus = "suspended"
+	StatusAppealing  AccountStatus = "appealing"
 )
 
 type VKAccount struct {
@@ -41,6 +42,37 @@ type VKAccount struct {
 	LastLoginAt     *time.Time             `bson:"last_login_at,omitempty" json:"last_login_at,omitempty"`
 	ErrorMessage    string                 `bson:"error_message,omitempty" json:"error_message,omitempty"`
 	RetryCount      int                    `bson:"retry_count" json:"retry_count"`
+	PersonaID       string                 `bson:"persona_id,omitempty" json:"persona_id,omitempty"`
+	PersonaProfile  *PersonaProfile        `bson:"persona_profile,omitempty" json:"persona_profile,omitempty"`
+	Profile         *ProfileData           `bson:"profile,omitempty" json:"profile,omitempty"`
+	ProfileFilledAt *time.Time             `bson:"profile_filled_at,omitempty" json:"profile_filled_at,omitempty"`
+	MailAccountID   string                 `bson:"mail_account_id,omitempty" json:"mail_account_id,omitempty"` // mail-service account bound as the recovery email
+	EmailBoundAt    *time.Time             `bson:"email_bound_at,omitempty" json:"email_bound_at,omitempty"`
+	Quality         AccountQuality         `bson:"quality,omitempty" json:"quality,omitempty"`
+}
+
+// AccountQuality grades accounts for export, higher quality accounts are easier to recover
+type AccountQuality string
+
+const (
+	QualityStandard AccountQuality = "standard"
+	QualityHigh     AccountQuality = "high"
+)
+
+// QualityOrDefault reports accounts graded before quality was tracked as standard
+func (a *VKAccount) QualityOrDefault() AccountQuality {
+	if a.Quality == "" {
+		return QualityStandard
+	}
+	return a.Quality
+}
+
+// PersonaProfile is the part of the persona applied when the profile is populated after registration
+type PersonaProfile struct {
+	City      string   `bson:"city,omitempty" json:"city,omitempty"`
+	Interests []string `bson:"interests,omitempty" json:"interests,omitempty"`
+	Bio       string   `bson:"bio,omitempty" json:"bio,omitempty"`
+	Avatars   []string `bson:"avatars,omitempty" json:"avatars,omitempty"` // Paths inside the shared avatar pool
 }
 
 type AccountStatistics struct {
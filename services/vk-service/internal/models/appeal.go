@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AppealStatus string
+
+const (
+	AppealStatusPending     AppealStatus = "pending"
+	AppealStatusSubmitted   AppealStatus = "submitted"
+	AppealStatusApproved    AppealStatus = "approved"
+	AppealStatusRejected    AppealStatus = "rejected"
+	AppealStatusUnavailable AppealStatus = "unavailable"
+	AppealStatusExpired     AppealStatus = "expired"
+	AppealStatusFailed      AppealStatus = "failed"
+)
+
+// PhoneSource tells which number completed the SMS re-verification of an appeal
+type PhoneSource string
+
+const (
+	PhoneSourceOriginal PhoneSource = "original"
+	PhoneSourceRented   PhoneSource = "rented"
+)
+
+// Appeal tracks a restore-from-ban request from submission until VK resolves it
+type Appeal struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AccountID     primitive.ObjectID `bson:"account_id" json:"account_id"`
+	Status        AppealStatus       `bson:"status" json:"status"`
+	BanStatus     AccountStatus      `bson:"ban_status" json:"ban_status"`
+	BanReason     string             `bson:"ban_reason,omitempty" json:"ban_reason,omitempty"`
+	PhoneSource   PhoneSource        `bson:"phone_source,omitempty" json:"phone_source,omitempty"`
+	ActivationID  string             `bson:"activation_id,omitempty" json:"activation_id,omitempty"`
+	Checks        int                `bson:"checks" json:"checks"`
+	ErrorMessage  string             `bson:"error_message,omitempty" json:"error_message,omitempty"`
+	SubmittedAt   *time.Time         `bson:"submitted_at,omitempty" json:"submitted_at,omitempty"`
+	LastCheckedAt *time.Time         `bson:"last_checked_at,omitempty" json:"last_checked_at,omitempty"`
+	NextCheckAt   time.Time          `bson:"next_check_at" json:"next_check_at"`
+	ResolvedAt    *time.Time         `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// IsFinal reports whether VK will not change the appeal outcome anymore
+func (a *Appeal) IsFinal() bool {
+	switch a.Status {
+	case AppealStatusPending, AppealStatusSubmitted:
+		return false
+	}
+	return true
+}
+
+type AppealConfig struct {
+	Enabled           bool          `json:"enabled"`
+	AutoAppeal        bool          `json:"auto_appeal"`
+	AllowRentedNumber bool          `json:"allow_rented_number"`
+	CheckInterval     time.Duration `json:"check_interval"`
+	MaxDuration       time.Duration `json:"max_duration"`
+	ActionDelayMin    int           `json:"action_delay_min"`
+	ActionDelayMax    int           `json:"action_delay_max"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BatchStatus string
+
+const (
+	BatchStatusRunning   BatchStatus = "running"
+	BatchStatusCompleted BatchStatus = "completed"
+	BatchStatusCancelled BatchStatus = "cancelled"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemQueued    BatchItemStatus = "queued"
+	BatchItemSucceeded BatchItemStatus = "succeeded"
+	BatchItemFailed    BatchItemStatus = "failed"
+	BatchItemCancelled BatchItemStatus = "cancelled"
+)
+
+// BatchOptions applies to every registration of a batch, profiles always come from persona-service
+type BatchOptions struct {
+	Gender           Gender `bson:"gender,omitempty" json:"gender,omitempty"`
+	PreferredCountry string `bson:"preferred_country,omitempty" json:"preferred_country,omitempty"`
+}
+
+// RegistrationBatch groups registrations requested together so they can be tracked and cancelled as one job.
+// Pending counts registrations without an outcome yet, including ones not queued so far.
+type RegistrationBatch struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Requested   int                `bson:"requested" json:"requested"`
+	Options     BatchOptions       `bson:"options" json:"options"`
+	Status      BatchStatus        `bson:"status" json:"status"`
+	Items       []BatchItem        `bson:"items" json:"items"`
+	Pending     int                `bson:"pending" json:"pending"`
+	Succeeded   int                `bson:"succeeded" json:"succeeded"`
+	Failed      int                `bson:"failed" json:"failed"`
+	Cancelled   int                `bson:"cancelled" json:"cancelled"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	CancelledAt *time.Time         `bson:"cancelled_at,omitempty" json:"cancelled_at,omitempty"`
+	CompletedAt *time.Time         `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// BatchItem is one registration of a batch. Step is filled from the registration session when read.
+type BatchItem struct {
+	AccountID primitive.ObjectID `bson:"account_id,omitempty" json:"account_id,omitempty"`
+	Status    BatchItemStatus    `bson:"status" json:"status"`
+	Step      RegistrationStep   `bson:"-" json:"step,omitempty"`
+	Error     string             `bson:"error,omitempty" json:"error,omitempty"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// IsFinal reports whether the batch will not change anymore
+func (b *RegistrationBatch) IsFinal() bool {
+	return b.CompletedAt != nil
+}
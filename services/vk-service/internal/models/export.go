@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// ExportFormatVersion is bumped when the layout of the exported credentials changes
+const ExportFormatVersion = 1
+
+// ExportEncryption names the scheme of encrypted exports: RSA-OAEP (SHA-256) wrapped AES-256-GCM key
+const ExportEncryption = "rsa-oaep-sha256+aes-256-gcm"
+
+// ExportOptions controls how an account is exported
+type ExportOptions struct {
+	RecipientPublicKey []byte // PEM encoded RSA key, the export is encrypted for it when set
+}
+
+// ExportedCredentials is the credentials file of an account export
+type ExportedCredentials struct {
+	FormatVersion int        `json:"format_version"`
+	Platform      string     `json:"platform"`
+	AccountID     string     `json:"account_id"`
+	UserID        string     `json:"user_id,omitempty"`
+	Username      string     `json:"username,omitempty"`
+	Phone         string     `json:"phone,omitempty"`
+	Email         string     `json:"email,omitempty"`
+	Password      string     `json:"password"`
+	FirstName     string     `json:"first_name,omitempty"`
+	LastName      string     `json:"last_name,omitempty"`
+	UserAgent     string     `json:"user_agent,omitempty"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastLoginAt   *time.Time `json:"last_login_at,omitempty"`
+	ExportedAt    time.Time  `json:"exported_at"`
+}
+
+// AccountExport is an exported account: the credentials as JSON and the session cookies as a
+// Netscape cookie file. When encrypted, both files are sealed for the recipient and EncryptedKey
+// holds the data key wrapped with the recipient's public key.
+type AccountExport struct {
+	AccountID    string
+	Credentials  []byte
+	Cookies      []byte
+	Encrypted    bool
+	Encryption   string
+	EncryptedKey []byte
+	ExportedAt   time.Time
+}
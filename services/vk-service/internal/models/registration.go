@@ -31,6 +31,10 @@ type RegistrationRequest struct {
 	Gender            Gender    `json:"gender,omitempty"`
 	PreferredCountry  string    `json:"preferred_country,omitempty"`
 	UseRandomProfile  bool      `json:"use_random_profile,omitempty"`
+	PersonaID         string    `json:"persona_id,omitempty"`
+	PersonaReservationID string `json:"persona_reservation_id,omitempty"`
+	PersonaProfile    *PersonaProfile `json:"persona_profile,omitempty"`
+	BatchID           string    `json:"batch_id,omitempty"`
 }
 
 type RegistrationSession struct {
@@ -63,23 +67,87 @@ type RegistrationResult struct {
 }
 
 type RegistrationConfig struct {
-	MaxRetryAttempts    int           `json:"max_retry_attempts"`
-	RetryBackoffBase    time.Duration `json:"retry_backoff_base"`
-	FormFillDelayMin    int           `json:"form_fill_delay_min"`
-	FormFillDelayMax    int           `json:"form_fill_delay_max"`
-	SMSWaitTimeout      time.Duration `json:"sms_wait_timeout"`
-	PageLoadTimeout     time.Duration `json:"page_load_timeout"`
-	SMSPollingInterval  time.Duration `json:"sms_polling_interval"`
-	MaxSMSPolls         int           `json:"max_sms_polls"`
+	MaxRetryAttempts   int            `json:"max_retry_attempts"`
+	RetryBackoffBase   time.Duration  `json:"retry_backoff_base"`
+	FormFillDelayMin   int            `json:"form_fill_delay_min"`
+	FormFillDelayMax   int            `json:"form_fill_delay_max"`
+	SMSWaitTimeout     time.Duration  `json:"sms_wait_timeout"`
+	PageLoadTimeout    time.Duration  `json:"page_load_timeout"`
+	SMSPollingInterval time.Duration  `json:"sms_polling_interval"`
+	MaxSMSPolls        int            `json:"max_sms_polls"`
+	Concurrency        int            `json:"concurrency"`          // registrations running at once per instance
+	QueueSize          int            `json:"queue_size"`           // commands accepted ahead of a free worker
+	ProxyMaxConcurrent int            `json:"proxy_max_concurrent"` // registrations sharing one proxy
+	SMSProviderLimits  map[string]int `json:"sms_provider_limits"`  // numbers in use per SMS provider, empty means no limit
+	AdmissionTimeout   time.Duration  `json:"admission_timeout"`
+	DrainTimeout       time.Duration  `json:"drain_timeout"`
+	MaxBatchSize       int            `json:"max_batch_size"` // registrations accepted in one batch
 }
 
 type ProfileData struct {
-	FirstName  string    `json:"first_name"`
-	LastName   string    `json:"last_name"`
-	BirthDate  time.Time `json:"birth_date"`
-	Gender     Gender    `json:"gender"`
-	City       string    `json:"city,omitempty"`
-	About      string    `json:"about,omitempty"`
-	Interests  []string  `json:"interests,omitempty"`
-	AvatarURL  string    `json:"avatar_url,omitempty"`
+	FirstName  string     `bson:"first_name" json:"first_name"`
+	LastName   string     `bson:"last_name" json:"last_name"`
+	BirthDate  time.Time  `bson:"birth_date" json:"birth_date"`
+	Gender     Gender     `bson:"gender" json:"gender"`
+	City       string     `bson:"city,omitempty" json:"city,omitempty"`
+	About      string     `bson:"about,omitempty" json:"about,omitempty"`
+	Interests  []string   `bson:"interests,omitempty" json:"interests,omitempty"`
+	AvatarURL  string     `bson:"avatar_url,omitempty" json:"avatar_url,omitempty"`
+	Status     string     `bson:"status,omitempty" json:"status,omitempty"`
+	Education  *Education `bson:"education,omitempty" json:"education,omitempty"`
+}
+
+type Education struct {
+	University     string `bson:"university" json:"university"`
+	Faculty        string `bson:"faculty,omitempty" json:"faculty,omitempty"`
+	GraduationYear int    `bson:"graduation_year,omitempty" json:"graduation_year,omitempty"`
+}
+
+type ProfileStep string
+
+const (
+	ProfileStepAvatar    ProfileStep = "avatar"
+	ProfileStepStatus    ProfileStep = "status"
+	ProfileStepCity      ProfileStep = "city"
+	ProfileStepEducation ProfileStep = "education"
+	ProfileStepInterests ProfileStep = "interests"
+)
+
+// ProfilePopulationOptions overrides generated values; empty fields are generated from the persona
+type ProfilePopulationOptions struct {
+	SkipAvatar bool     `json:"skip_avatar,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	City       string   `json:"city,omitempty"`
+	Interests  []string `json:"interests,omitempty"`
+}
+
+type ProfilePopulationResult struct {
+	AccountID      string            `json:"account_id"`
+	Profile        *ProfileData      `json:"profile"`
+	CompletedSteps []ProfileStep     `json:"completed_steps"`
+	FailedSteps    map[string]string `json:"failed_steps,omitempty"`
+	Duration       float64           `json:"duration_seconds"`
+}
+
+type ProfileConfig struct {
+	PopulateAfterRegistration bool   `json:"populate_after_registration"`
+	AvatarPoolDir             string `json:"avatar_pool_dir"`
+	ActionDelayMin            int    `json:"action_delay_min"`
+	ActionDelayMax            int    `json:"action_delay_max"`
+}
+
+type EmailBindingConfig struct {
+	BindAfterRegistration bool          `json:"bind_after_registration"`
+	Timeout               time.Duration `json:"timeout"`       // waiting for the confirmation email
+	PollInterval          time.Duration `json:"poll_interval"` // between mailbox checks
+	ActionDelayMin        int           `json:"action_delay_min"`
+	ActionDelayMax        int           `json:"action_delay_max"`
+}
+
+type EmailBindingResult struct {
+	AccountID     string    `json:"account_id"`
+	Email         string    `json:"email"`
+	MailAccountID string    `json:"mail_account_id"`
+	BoundAt       time.Time `json:"bound_at"`
+	Duration      float64   `json:"duration_seconds"`
 }
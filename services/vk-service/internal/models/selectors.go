@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Sources of a resolved selector
+const (
+	SelectorSourceDefault = "default"
+	SelectorSourceFile    = "file"
+	SelectorSourceRemote  = "remote"
+)
+
+// SelectorMap maps a selector key to CSS variants tried in order, the first matching one wins.
+// Remote maps are stored in Mongo with an increasing version and only list the keys they override.
+type SelectorMap struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty" yaml:"-"`
+	Version   int                 `bson:"version" json:"version" yaml:"version"`
+	Selectors map[string][]string `bson:"selectors" json:"selectors" yaml:"selectors"`
+	Comment   string              `bson:"comment,omitempty" json:"comment,omitempty" yaml:"-"`
+	CreatedAt time.Time           `bson:"created_at" json:"created_at" yaml:"-"`
+}
+
+// ResolvedSelector is the effective variant list of one key
+type ResolvedSelector struct {
+	Variants []string `json:"variants"`
+	Source   string   `json:"source"`
+}
+
+// SelectorSnapshot describes the selectors currently used by registrations
+type SelectorSnapshot struct {
+	FileVersion   int                         `json:"file_version"`
+	RemoteVersion int                         `json:"remote_version"` // 0 while no remote override was published
+	Selectors     map[string]ResolvedSelector `json:"selectors"`
+}
+
+type SelectorConfig struct {
+	Path            string        `json:"path"`
+	RefreshInterval time.Duration `json:"refresh_interval"` // how often the remote override is re-read
+}
@@ -27,6 +27,7 @@ type AccountRepository interface {
 	GetAccountStatistics(ctx context.Context) (*models.AccountStatistics, error)
 	CreateIndexes(ctx context.Context) error
 	UpdateAccount(ctx context.Context, id primitive.ObjectID, update bson.M) error
+	BindRecoveryEmail(ctx context.Context, id primitive.ObjectID, email, mailAccountID string) error
 	GetStuckAccounts(ctx context.Context, duration time.Duration) ([]*models.VKAccount, error)
 	DeleteAccount(ctx context.Context, id primitive.ObjectID) error
 }
@@ -46,7 +47,7 @@ func NewAccountRepository(db *mongo.Database, encryptor crypto.Encryptor, logger
 }
 
 func (r *accountRepository) collection() *mongo.Collection {
-	return r.db.GetCollection("vk_accounts")
+	return r.db.Collection("vk_accounts")
 }
 
 func (r *accountRepository) CreateAccount(ctx context.Context, account *models.VKAccount) error {
@@ -416,6 +417,22 @@ func (r *accountRepository) UpdateAccount(ctx context.Context, id primitive.Obje
 	return nil
 }
 
+// BindRecoveryEmail stores the confirmed recovery email, accounts with one are exported as high quality
+func (r *accountRepository) BindRecoveryEmail(ctx context.Context, id primitive.ObjectID, email, mailAccountID string) error {
+	encrypted, err := r.encryptor.Encrypt(email)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt email: %w", err)
+	}
+
+	now := time.Now()
+	return r.UpdateAccount(ctx, id, bson.M{
+		"email":           encrypted,
+		"mail_account_id": mailAccountID,
+		"email_bound_at":  now,
+		"quality":         models.QualityHigh,
+	})
+}
+
 func (r *accountRepository) GetStuckAccounts(ctx context.Context, duration time.Duration) ([]*models.VKAccount, error) {
 	filter := bson.M{
 		"status": models.StatusCreating,
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AppealRepository interface {
+	CreateAppeal(ctx context.Context, appeal *models.Appeal) error
+	GetAppeal(ctx context.Context, id primitive.ObjectID) (*models.Appeal, error)
+	GetLatestAppealByAccount(ctx context.Context, accountID primitive.ObjectID) (*models.Appeal, error)
+	UpdateAppeal(ctx context.Context, id primitive.ObjectID, update bson.M) error
+	GetDueAppeals(ctx context.Context, limit int64) ([]*models.Appeal, error)
+	CreateIndexes(ctx context.Context) error
+}
+
+type appealRepository struct {
+	db     *mongo.Database
+	logger logger.Logger
+}
+
+func NewAppealRepository(db *mongo.Database, logger logger.Logger) AppealRepository {
+	return &appealRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *appealRepository) collection() *mongo.Collection {
+	return r.db.Collection("vk_appeals")
+}
+
+func (r *appealRepository) CreateAppeal(ctx context.Context, appeal *models.Appeal) error {
+	appeal.CreatedAt = time.Now()
+	appeal.UpdatedAt = time.Now()
+
+	result, err := r.collection().InsertOne(ctx, appeal)
+	if err != nil {
+		return fmt.Errorf("failed to create appeal: %w", err)
+	}
+
+	appeal.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *appealRepository) GetAppeal(ctx context.Context, id primitive.ObjectID) (*models.Appeal, error) {
+	var appeal models.Appeal
+	err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&appeal)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("appeal not found")
+		}
+		return nil, fmt.Errorf("failed to get appeal: %w", err)
+	}
+
+	return &appeal, nil
+}
+
+func (r *appealRepository) GetLatestAppealByAccount(ctx context.Context, accountID primitive.ObjectID) (*models.Appeal, error) {
+	opts := options.FindOne().SetSort(bson.M{"created_at": -1})
+
+	var appeal models.Appeal
+	err := r.collection().FindOne(ctx, bson.M{"account_id": accountID}, opts).Decode(&appeal)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get appeal: %w", err)
+	}
+
+	return &appeal, nil
+}
+
+func (r *appealRepository) UpdateAppeal(ctx context.Context, id primitive.ObjectID, update bson.M) error {
+	update["updated_at"] = time.Now()
+
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": update})
+	if err != nil {
+		return fmt.Errorf("failed to update appeal: %w", err)
+	}
+
+	return nil
+}
+
+// GetDueAppeals returns submitted appeals whose next status check is due
+func (r *appealRepository) GetDueAppeals(ctx context.Context, limit int64) ([]*models.Appeal, error) {
+	filter := bson.M{
+		"status":        models.AppealStatusSubmitted,
+		"next_check_at": bson.M{"$lte": time.Now()},
+	}
+	opts := options.Find().SetLimit(limit).SetSort(bson.M{"next_check_at": 1})
+
+	cursor, err := r.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due appeals: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var appeals []*models.Appeal
+	for cursor.Next(ctx) {
+		var appeal models.Appeal
+		if err := cursor.Decode(&appeal); err != nil {
+			r.logger.Error("Failed to decode appeal", "error", err)
+			continue
+		}
+		appeals = append(appeals, &appeal)
+	}
+
+	return appeals, nil
+}
+
+func (r *appealRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "account_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_check_at", Value: 1}},
+		},
+	}
+
+	_, err := r.collection().Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BatchRepository stores registration batches. Counters are updated together with the items
+// so that several instances can record outcomes of the same batch.
+type BatchRepository interface {
+	CreateBatch(ctx context.Context, batch *models.RegistrationBatch) error
+	GetBatch(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error)
+	AddItem(ctx context.Context, id primitive.ObjectID, item models.BatchItem) (*models.RegistrationBatch, error)
+	SetItemOutcome(ctx context.Context, id, accountID primitive.ObjectID, status models.BatchItemStatus, reason string) (*models.RegistrationBatch, error)
+	ReleaseUnqueued(ctx context.Context, id primitive.ObjectID, count int, status models.BatchItemStatus) (*models.RegistrationBatch, error)
+	Cancel(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error)
+	MarkCompleted(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error)
+	GetStalledBatches(ctx context.Context, idle time.Duration) ([]models.RegistrationBatch, error)
+	Claim(ctx context.Context, batch *models.RegistrationBatch) (*models.RegistrationBatch, error)
+	CreateIndexes(ctx context.Context) error
+}
+
+type batchRepository struct {
+	db     *mongo.Database
+	logger logger.Logger
+}
+
+func NewBatchRepository(db *mongo.Database, logger logger.Logger) BatchRepository {
+	return &batchRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *batchRepository) collection() *mongo.Collection {
+	return r.db.Collection("vk_registration_batches")
+}
+
+// counterField is the batch counter an item outcome is added to
+func counterField(status models.BatchItemStatus) (string, error) {
+	switch status {
+	case models.BatchItemSucceeded:
+		return "succeeded", nil
+	case models.BatchItemFailed:
+		return "failed", nil
+	case models.BatchItemCancelled:
+		return "cancelled", nil
+	}
+	return "", fmt.Errorf("batch item status %q is not an outcome", status)
+}
+
+func (r *batchRepository) CreateBatch(ctx context.Context, batch *models.RegistrationBatch) error {
+	batch.CreatedAt = time.Now()
+	batch.UpdatedAt = time.Now()
+	if batch.Items == nil {
+		batch.Items = []models.BatchItem{}
+	}
+
+	result, err := r.collection().InsertOne(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	batch.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *batchRepository) GetBatch(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error) {
+	var batch models.RegistrationBatch
+	err := r.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&batch)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("batch not found")
+		}
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+
+	return &batch, nil
+}
+
+// AddItem appends a registration to the batch; an item added with an outcome is counted right away
+func (r *batchRepository) AddItem(ctx context.Context, id primitive.ObjectID, item models.BatchItem) (*models.RegistrationBatch, error) {
+	item.UpdatedAt = time.Now()
+	update := bson.M{
+		"$push": bson.M{"items": item},
+		"$set":  bson.M{"updated_at": item.UpdatedAt},
+	}
+	if item.Status != models.BatchItemQueued {
+		field, err := counterField(item.Status)
+		if err != nil {
+			return nil, err
+		}
+		update["$inc"] = bson.M{field: 1, "pending": -1}
+	}
+
+	return r.findOneAndUpdate(ctx, bson.M{"_id": id}, update)
+}
+
+// SetItemOutcome records the outcome of a queued registration. Nil is returned when the account
+// is not queued in the batch, e.g. the outcome was already recorded.
+func (r *batchRepository) SetItemOutcome(ctx context.Context, id, accountID primitive.ObjectID, status models.BatchItemStatus, reason string) (*models.RegistrationBatch, error) {
+	field, err := counterField(status)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	filter := bson.M{
+		"_id": id,
+		"items": bson.M{"$elemMatch": bson.M{
+			"account_id": accountID,
+			"status":     models.BatchItemQueued,
+		}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"items.$.status":     status,
+			"items.$.error":      reason,
+			"items.$.updated_at": now,
+			"updated_at":         now,
+		},
+		"$inc": bson.M{field: 1, "pending": -1},
+	}
+
+	return r.findOneAndUpdate(ctx, filter, update)
+}
+
+// ReleaseUnqueued counts registrations that will never be queued with the given outcome
+func (r *batchRepository) ReleaseUnqueued(ctx context.Context, id primitive.ObjectID, count int, status models.BatchItemStatus) (*models.RegistrationBatch, error) {
+	field, err := counterField(status)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{
+		"$set": bson.M{"updated_at": time.Now()},
+		"$inc": bson.M{field: count, "pending": -count},
+	}
+
+	return r.findOneAndUpdate(ctx, bson.M{"_id": id}, update)
+}
+
+// Cancel marks a running batch as cancelled, nil is returned when the batch is not running
+func (r *batchRepository) Cancel(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error) {
+	now := time.Now()
+	filter := bson.M{"_id": id, "status": models.BatchStatusRunning}
+	update := bson.M{"$set": bson.M{
+		"status":       models.BatchStatusCancelled,
+		"cancelled_at": now,
+		"updated_at":   now,
+	}}
+
+	return r.findOneAndUpdate(ctx, filter, update)
+}
+
+// MarkCompleted completes a batch without pending registrations. Only one caller gets the
+// batch back, so the completion is reported once; others get nil.
+func (r *batchRepository) MarkCompleted(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id":          id,
+		"pending":      bson.M{"$lte": 0},
+		"completed_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{
+		"completed_at": now,
+		"updated_at":   now,
+	}}
+
+	batch, err := r.findOneAndUpdate(ctx, filter, update)
+	if err != nil || batch == nil {
+		return batch, err
+	}
+
+	if batch.Status == models.BatchStatusRunning {
+		if _, err := r.collection().UpdateOne(ctx,
+			bson.M{"_id": id, "status": models.BatchStatusRunning},
+			bson.M{"$set": bson.M{"status": models.BatchStatusCompleted}},
+		); err != nil {
+			return nil, fmt.Errorf("failed to complete batch: %w", err)
+		}
+		batch.Status = models.BatchStatusCompleted
+	}
+
+	return batch, nil
+}
+
+// GetStalledBatches returns unfinished batches that were not updated for idle
+func (r *batchRepository) GetStalledBatches(ctx context.Context, idle time.Duration) ([]models.RegistrationBatch, error) {
+	filter := bson.M{
+		"completed_at": bson.M{"$exists": false},
+		"updated_at":   bson.M{"$lt": time.Now().Add(-idle)},
+	}
+
+	cursor, err := r.collection().Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stalled batches: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var batches []models.RegistrationBatch
+	if err := cursor.All(ctx, &batches); err != nil {
+		return nil, fmt.Errorf("failed to decode batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// Claim takes over a batch read earlier by touching it. Nil is returned when the batch changed
+// since it was read, e.g. another instance claimed it or an outcome was recorded.
+func (r *batchRepository) Claim(ctx context.Context, batch *models.RegistrationBatch) (*models.RegistrationBatch, error) {
+	filter := bson.M{"_id": batch.ID, "updated_at": batch.UpdatedAt}
+	update := bson.M{"$set": bson.M{"updated_at": time.Now()}}
+
+	return r.findOneAndUpdate(ctx, filter, update)
+}
+
+func (r *batchRepository) findOneAndUpdate(ctx context.Context, filter, update bson.M) (*models.RegistrationBatch, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var batch models.RegistrationBatch
+	err := r.collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&batch)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update batch: %w", err)
+	}
+
+	return &batch, nil
+}
+
+func (r *batchRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "items.account_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "completed_at", Value: 1}, {Key: "updated_at", Value: 1}},
+		},
+	}
+
+	_, err := r.collection().Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
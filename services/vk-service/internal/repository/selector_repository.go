@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SelectorRepository stores versioned remote selector overrides
+type SelectorRepository interface {
+	GetLatest(ctx context.Context) (*models.SelectorMap, error)
+	GetByVersion(ctx context.Context, version int) (*models.SelectorMap, error)
+	ListVersions(ctx context.Context, limit int64) ([]*models.SelectorMap, error)
+	Create(ctx context.Context, selectors *models.SelectorMap) error
+	CreateIndexes(ctx context.Context) error
+}
+
+type selectorRepository struct {
+	db     *mongo.Database
+	logger logger.Logger
+}
+
+func NewSelectorRepository(db *mongo.Database, logger logger.Logger) SelectorRepository {
+	return &selectorRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *selectorRepository) collection() *mongo.Collection {
+	return r.db.Collection("vk_selector_maps")
+}
+
+// GetLatest returns nil when no override was published yet
+func (r *selectorRepository) GetLatest(ctx context.Context) (*models.SelectorMap, error) {
+	opts := options.FindOne().SetSort(bson.M{"version": -1})
+
+	var selectors models.SelectorMap
+	err := r.collection().FindOne(ctx, bson.M{}, opts).Decode(&selectors)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest selectors: %w", err)
+	}
+
+	return &selectors, nil
+}
+
+func (r *selectorRepository) GetByVersion(ctx context.Context, version int) (*models.SelectorMap, error) {
+	var selectors models.SelectorMap
+	err := r.collection().FindOne(ctx, bson.M{"version": version}).Decode(&selectors)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get selectors version %d: %w", version, err)
+	}
+
+	return &selectors, nil
+}
+
+func (r *selectorRepository) ListVersions(ctx context.Context, limit int64) ([]*models.SelectorMap, error) {
+	opts := options.Find().SetSort(bson.M{"version": -1}).SetLimit(limit)
+
+	cursor, err := r.collection().Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list selector versions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var versions []*models.SelectorMap
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, fmt.Errorf("failed to decode selector versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// Create stores a new version, the unique index rejects concurrent publishes of the same version
+func (r *selectorRepository) Create(ctx context.Context, selectors *models.SelectorMap) error {
+	selectors.CreatedAt = time.Now()
+
+	result, err := r.collection().InsertOne(ctx, selectors)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("selectors version %d already exists", selectors.Version)
+		}
+		return fmt.Errorf("failed to create selectors: %w", err)
+	}
+
+	selectors.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *selectorRepository) CreateIndexes(ctx context.Context) error {
+	_, err := r.collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "version", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create selector indexes: %w", err)
+	}
+
+	return nil
+}
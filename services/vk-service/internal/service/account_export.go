@@ -0,0 +1,149 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/crypto"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrAccountNotExportable is returned for accounts whose registration has not finished
+	ErrAccountNotExportable = errors.New("account is not exportable")
+	// ErrInvalidRecipientKey is returned when the export cannot be encrypted for the given key
+	ErrInvalidRecipientKey = errors.New("invalid recipient public key")
+)
+
+// ExportAccount exports the credentials and the session cookies of a registered account,
+// encrypted for the recipient when a public key is given
+func (s *vkService) ExportAccount(ctx context.Context, id primitive.ObjectID, opts models.ExportOptions) (*models.AccountExport, error) {
+	account, err := s.accountRepo.GetAccountByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Status == models.StatusCreating || account.Password == "" {
+		return nil, fmt.Errorf("%w: registration is not finished", ErrAccountNotExportable)
+	}
+
+	exportedAt := time.Now().UTC()
+	credentials, err := json.MarshalIndent(models.ExportedCredentials{
+		FormatVersion: models.ExportFormatVersion,
+		Platform:      "vk",
+		AccountID:     account.ID.Hex(),
+		UserID:        account.UserID,
+		Username:      account.Username,
+		Phone:         account.Phone,
+		Email:         account.Email,
+		Password:      account.Password,
+		FirstName:     account.FirstName,
+		LastName:      account.LastName,
+		UserAgent:     account.UserAgent,
+		Status:        string(account.Status),
+		CreatedAt:     account.CreatedAt,
+		LastLoginAt:   account.LastLoginAt,
+		ExportedAt:    exportedAt,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	var cookies []models.Cookie
+	if len(account.Cookies) > 0 {
+		if err := json.Unmarshal(account.Cookies, &cookies); err != nil {
+			return nil, fmt.Errorf("failed to decode cookies: %w", err)
+		}
+	}
+
+	export := &models.AccountExport{
+		AccountID:   account.ID.Hex(),
+		Credentials: credentials,
+		Cookies:     netscapeCookieFile(cookies),
+		ExportedAt:  exportedAt,
+	}
+
+	if len(opts.RecipientPublicKey) > 0 {
+		if err := sealExport(export, opts.RecipientPublicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Account exported", "account_id", account.ID, "cookies", len(cookies), "encrypted", export.Encrypted)
+	return export, nil
+}
+
+// sealExport encrypts both files of the export for the recipient with one data key
+func sealExport(export *models.AccountExport, recipientPublicKey []byte) error {
+	sealer, err := crypto.NewRecipientSealer(recipientPublicKey)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecipientKey, err)
+	}
+
+	credentials, err := sealer.Seal(export.Credentials)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+	cookies, err := sealer.Seal(export.Cookies)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt cookies: %w", err)
+	}
+
+	export.Credentials = credentials
+	export.Cookies = cookies
+	export.Encrypted = true
+	export.Encryption = models.ExportEncryption
+	export.EncryptedKey = sealer.EncryptedKey()
+	return nil
+}
+
+// netscapeCookieFile writes cookies in the Netscape cookies.txt format read by curl, wget and
+// browser cookie importers. HttpOnly cookies carry the "#HttpOnly_" domain prefix curl uses,
+// session cookies have expiry 0.
+func netscapeCookieFile(cookies []models.Cookie) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# Netscape HTTP Cookie File\n")
+
+	for _, c := range cookies {
+		domain := c.Domain
+		if c.HTTPOnly {
+			domain = "#HttpOnly_" + domain
+		}
+
+		path := c.Path
+		if path == "" {
+			path = "/"
+		}
+
+		var expires int64
+		if !c.Expires.IsZero() {
+			expires = c.Expires.Unix()
+		}
+
+		fmt.Fprintf(&buf, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			domain,
+			netscapeBool(strings.HasPrefix(c.Domain, ".")),
+			path,
+			netscapeBool(c.Secure),
+			expires,
+			c.Name,
+			c.Value,
+		)
+	}
+
+	return buf.Bytes()
+}
+
+func netscapeBool(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
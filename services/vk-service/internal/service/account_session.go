@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// openAccountSession starts a browser logged into an existing account via its stored cookies
+func openAccountSession(ctx context.Context, browserManager BrowserManager, proxyClient proxypb.ProxyServiceClient, account *models.VKAccount, logger logger.Logger) (playwright.Browser, playwright.BrowserContext, error) {
+	// Reuse the account's proxy so VK sees the registration IP
+	proxy, err := proxyClient.GetProxyForAccount(ctx, &proxypb.GetProxyRequest{
+		AccountId: account.ID.Hex(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get proxy for account: %w", err)
+	}
+
+	browser, browserCtx, err := browserManager.AcquireBrowser(ctx, &ProxyConfig{
+		Server:   fmt.Sprintf("%s://%s:%d", proxy.Protocol, proxy.Ip, proxy.Port),
+		Username: proxy.Username,
+		Password: proxy.Password,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire browser: %w", err)
+	}
+
+	var stored []models.Cookie
+	if err := json.Unmarshal(account.Cookies, &stored); err != nil {
+		closeAccountSession(browserManager, browser, browserCtx, logger)
+		return nil, nil, fmt.Errorf("failed to decode cookies: %w", err)
+	}
+
+	cookies := make([]playwright.OptionalCookie, 0, len(stored))
+	for _, c := range stored {
+		cookie := playwright.OptionalCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   playwright.String(c.Domain),
+			Path:     playwright.String(c.Path),
+			HttpOnly: playwright.Bool(c.HTTPOnly),
+			Secure:   playwright.Bool(c.Secure),
+		}
+		if !c.Expires.IsZero() {
+			cookie.Expires = playwright.Float(float64(c.Expires.Unix()))
+		}
+		if c.SameSite != "" {
+			sameSite := playwright.SameSiteAttribute(c.SameSite)
+			cookie.SameSite = &sameSite
+		}
+		cookies = append(cookies, cookie)
+	}
+
+	if err := browserCtx.AddCookies(cookies); err != nil {
+		closeAccountSession(browserManager, browser, browserCtx, logger)
+		return nil, nil, fmt.Errorf("failed to load cookies: %w", err)
+	}
+
+	return browser, browserCtx, nil
+}
+
+func closeAccountSession(browserManager BrowserManager, browser playwright.Browser, ctx playwright.BrowserContext, logger logger.Logger) {
+	if ctx != nil {
+		if err := ctx.Close(); err != nil {
+			logger.Warn("Failed to close browser context", "error", err)
+		}
+	}
+	if browser != nil {
+		if err := browserManager.ReleaseBrowser(browser); err != nil {
+			logger.Warn("Failed to release browser", "error", err)
+		}
+	}
+}
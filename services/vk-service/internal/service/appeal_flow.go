@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	smspb "github.com/grigta/conveer/services/sms-service/proto"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// AppealFlow drives the VK restore-from-ban form in the account's own browser session
+type AppealFlow interface {
+	SubmitAppeal(ctx context.Context, account *models.VKAccount, appeal *models.Appeal) error
+	CheckAppeal(ctx context.Context, account *models.VKAccount) (models.AppealStatus, error)
+}
+
+type appealFlow struct {
+	browserManager     BrowserManager
+	stealthInjector    StealthInjector
+	proxyClient        proxypb.ProxyServiceClient
+	smsClient          smspb.SMSServiceClient
+	config             *models.AppealConfig
+	registrationConfig *models.RegistrationConfig
+	metrics            MetricsCollector
+	logger             logger.Logger
+	rand               *rand.Rand
+}
+
+func NewAppealFlow(
+	browserManager BrowserManager,
+	stealthInjector StealthInjector,
+	proxyClient proxypb.ProxyServiceClient,
+	smsClient smspb.SMSServiceClient,
+	config *models.AppealConfig,
+	registrationConfig *models.RegistrationConfig,
+	metrics MetricsCollector,
+	logger logger.Logger,
+) AppealFlow {
+	return &appealFlow{
+		browserManager:     browserManager,
+		stealthInjector:    stealthInjector,
+		proxyClient:        proxyClient,
+		smsClient:          smsClient,
+		config:             config,
+		registrationConfig: registrationConfig,
+		metrics:            metrics,
+		logger:             logger,
+		rand:               rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// banState is what the account page shows after login
+type banState int
+
+const (
+	banStateNone banState = iota
+	banStateAppealable
+	banStateUnderReview
+	banStatePermanent
+	banStateUnknown
+)
+
+const (
+	appealButtonSelector = "button:has-text('Разморозить страницу'), a:has-text('Восстановить доступ'), button:has-text('Восстановить страницу'), button:has-text('Подать апелляцию')"
+	appealPhoneSelector  = "input[name='phone'], input[type='tel']"
+	appealCodeSelector   = "input[name='code'], input[autocomplete='one-time-code']"
+)
+
+var appealReasons = []string{
+	"Здравствуйте! Страницу никто, кроме меня, не использовал, спам я не рассылал. Прошу восстановить доступ.",
+	"Добрый день. Не понимаю, за что заблокировали страницу, правила не нарушал. Прошу разблокировать.",
+	"Здравствуйте, возможно, страницу взломали. Пароль сменю сразу после восстановления, прошу вернуть доступ.",
+}
+
+// activationReusable lists SMS activation states that can still receive a new code
+var activationReusable = map[string]bool{
+	"pending":  true,
+	"waiting":  true,
+	"received": true,
+}
+
+// activationClosed lists SMS activation states that will not receive a code any more
+var activationClosed = map[string]bool{
+	"cancelled": true,
+	"expired":   true,
+	"failed":    true,
+	"flagged":   true,
+}
+
+// activationEnded reports whether the activation was closed and polling for its code is pointless
+func activationEnded(ctx context.Context, smsClient smspb.SMSServiceClient, activationID string) bool {
+	status, err := smsClient.GetActivationStatus(ctx, &smspb.GetActivationStatusRequest{
+		ActivationId: activationID,
+	})
+	return err == nil && activationClosed[status.Status]
+}
+
+// maskPhone keeps the country prefix of a phone number for logs
+func maskPhone(phone string) string {
+	if len(phone) <= 3 {
+		return "***"
+	}
+	return phone[:3] + "***"
+}
+
+// appealNumber is the phone used for SMS re-verification of the appeal
+type appealNumber struct {
+	Phone        string
+	ActivationID string
+	Source       models.PhoneSource
+	// staleCode is the code already delivered to a reused activation; it must not be entered again
+	staleCode string
+}
+
+func (f *appealFlow) SubmitAppeal(ctx context.Context, account *models.VKAccount, appeal *models.Appeal) error {
+	if len(account.Cookies) == 0 {
+		return fmt.Errorf("account has no session cookies")
+	}
+
+	browser, browserCtx, page, err := f.openAccountPage(ctx, account)
+	if err != nil {
+		return err
+	}
+	defer closeAccountSession(f.browserManager, browser, browserCtx, f.logger)
+
+	switch f.detectBanState(page) {
+	case banStateNone:
+		// VK lifted the restriction before we got to it
+		appeal.Status = models.AppealStatusApproved
+		return nil
+	case banStateUnderReview:
+		appeal.Status = models.AppealStatusSubmitted
+		return nil
+	case banStatePermanent, banStateUnknown:
+		appeal.Status = models.AppealStatusUnavailable
+		return nil
+	}
+
+	if err := page.Locator(appealButtonSelector).First().Click(); err != nil {
+		return fmt.Errorf("appeal button not found: %w", err)
+	}
+	time.Sleep(f.stealthInjector.RandomDelay(f.config.ActionDelayMin, f.config.ActionDelayMax))
+
+	// The reason field is only shown for some ban types
+	if count, _ := page.Locator("textarea").Count(); count > 0 {
+		reason := appealReasons[f.rand.Intn(len(appealReasons))]
+		if err := f.typeInto(page, "textarea[name='reason'], textarea", reason); err != nil {
+			f.logger.Warn("Failed to fill appeal reason", "error", err, "account_id", account.ID)
+		}
+	}
+
+	if err := f.verifyPhone(ctx, page, account, appeal); err != nil {
+		f.metrics.IncrementErrorsTotal("appeal_sms_error")
+		return err
+	}
+
+	time.Sleep(f.stealthInjector.RandomDelay(f.config.ActionDelayMin, f.config.ActionDelayMax))
+	submitBtn := page.Locator("button:has-text('Отправить'), button:has-text('Подать заявку'), button[type='submit']").First()
+	if count, _ := submitBtn.Count(); count > 0 {
+		if err := submitBtn.Click(); err != nil {
+			return fmt.Errorf("failed to submit appeal: %w", err)
+		}
+		time.Sleep(3 * time.Second)
+	}
+
+	switch f.detectBanState(page) {
+	case banStateNone:
+		appeal.Status = models.AppealStatusApproved
+	case banStatePermanent:
+		appeal.Status = models.AppealStatusRejected
+	default:
+		appeal.Status = models.AppealStatusSubmitted
+	}
+
+	f.logger.Info("Appeal submitted",
+		"account_id", account.ID,
+		"status", appeal.Status,
+		"phone_source", appeal.PhoneSource)
+
+	return nil
+}
+
+func (f *appealFlow) CheckAppeal(ctx context.Context, account *models.VKAccount) (models.AppealStatus, error) {
+	browser, browserCtx, page, err := f.openAccountPage(ctx, account)
+	if err != nil {
+		return "", err
+	}
+	defer closeAccountSession(f.browserManager, browser, browserCtx, f.logger)
+
+	switch f.detectBanState(page) {
+	case banStateNone:
+		return models.AppealStatusApproved, nil
+	case banStatePermanent:
+		return models.AppealStatusRejected, nil
+	case banStateUnknown:
+		return "", fmt.Errorf("unrecognized account page state")
+	}
+
+	return models.AppealStatusSubmitted, nil
+}
+
+func (f *appealFlow) openAccountPage(ctx context.Context, account *models.VKAccount) (playwright.Browser, playwright.BrowserContext, playwright.Page, error) {
+	browser, browserCtx, err := openAccountSession(ctx, f.browserManager, f.proxyClient, account, f.logger)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	page, err := browserCtx.NewPage()
+	if err != nil {
+		closeAccountSession(f.browserManager, browser, browserCtx, f.logger)
+		return nil, nil, nil, fmt.Errorf("failed to create page: %w", err)
+	}
+	if err := f.stealthInjector.InjectStealth(page); err != nil {
+		f.logger.Warn("Failed to inject stealth scripts", "error", err)
+	}
+
+	if _, err := page.Goto("https://vk.com/feed", playwright.PageGotoOptions{
+		WaitUntil: playwright.WaitUntilStateNetworkidle,
+		Timeout:   playwright.Float(30000),
+	}); err != nil {
+		closeAccountSession(f.browserManager, browser, browserCtx, f.logger)
+		return nil, nil, nil, fmt.Errorf("failed to open account page: %w", err)
+	}
+
+	f.stealthInjector.EmulateHumanBehavior(page)
+	return browser, browserCtx, page, nil
+}
+
+// detectBanState reads the page VK shows a restricted account instead of the feed
+func (f *appealFlow) detectBanState(page playwright.Page) banState {
+	if count, _ := page.Locator("#l_pr").Count(); count > 0 {
+		return banStateNone
+	}
+
+	text, err := page.Locator("body").InnerText()
+	if err != nil {
+		return banStateUnknown
+	}
+	text = strings.ToLower(text)
+
+	switch {
+	case strings.Contains(text, "на рассмотрении") || strings.Contains(text, "заявка принята"):
+		return banStateUnderReview
+	case strings.Contains(text, "навсегда") || strings.Contains(text, "заявка отклонена"):
+		return banStatePermanent
+	}
+
+	if count, _ := page.Locator(appealButtonSelector).Count(); count > 0 {
+		return banStateAppealable
+	}
+
+	return banStateUnknown
+}
+
+// verifyPhone completes the SMS re-verification step of the appeal form
+func (f *appealFlow) verifyPhone(ctx context.Context, page playwright.Page, account *models.VKAccount, appeal *models.Appeal) error {
+	if count, _ := page.Locator(appealCodeSelector).Count(); count == 0 {
+		if count, _ := page.Locator(appealPhoneSelector).Count(); count == 0 {
+			// Some appeals only need the reason
+			return nil
+		}
+	}
+
+	number, err := f.acquireNumber(ctx, account)
+	if err != nil {
+		return err
+	}
+	appeal.PhoneSource = number.Source
+	appeal.ActivationID = number.ActivationID
+
+	// VK pre-fills the original number and keeps the field read-only
+	phoneInput := page.Locator(appealPhoneSelector).First()
+	if editable, _ := phoneInput.IsEditable(); editable {
+		if err := f.typeInto(page, appealPhoneSelector, number.Phone); err != nil {
+			return err
+		}
+	}
+
+	requestBtn := page.Locator("button:has-text('Получить код'), button:has-text('Продолжить')").First()
+	if err := requestBtn.Click(); err != nil {
+		return fmt.Errorf("code request button not found: %w", err)
+	}
+
+	code, err := f.waitForCode(ctx, number)
+	if err != nil {
+		if number.Source == models.PhoneSourceRented {
+			f.smsClient.CancelActivation(ctx, &smspb.CancelActivationRequest{
+				ActivationId: number.ActivationID,
+			})
+		}
+		return err
+	}
+
+	if err := f.typeInto(page, appealCodeSelector, code); err != nil {
+		return err
+	}
+
+	confirmBtn := page.Locator("button:has-text('Подтвердить'), button:has-text('Отправить код')").First()
+	if count, _ := confirmBtn.Count(); count > 0 {
+		if err := confirmBtn.Click(); err != nil {
+			return fmt.Errorf("failed to confirm code: %w", err)
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	return nil
+}
+
+// acquireNumber prefers the registration number while its activation is alive and rents a new one otherwise
+func (f *appealFlow) acquireNumber(ctx context.Context, account *models.VKAccount) (*appealNumber, error) {
+	if account.ActivationID != "" && account.Phone != "" {
+		status, err := f.smsClient.GetActivationStatus(ctx, &smspb.GetActivationStatusRequest{
+			ActivationId: account.ActivationID,
+		})
+		if err != nil {
+			f.logger.Warn("Failed to check original activation", "error", err, "account_id", account.ID)
+		} else if activationReusable[status.Status] {
+			return &appealNumber{
+				Phone:        account.Phone,
+				ActivationID: account.ActivationID,
+				Source:       models.PhoneSourceOriginal,
+				staleCode:    status.Code,
+			}, nil
+		}
+	}
+
+	if !f.config.AllowRentedNumber {
+		return nil, fmt.Errorf("original number is unavailable and renting is disabled")
+	}
+
+	resp, err := f.smsClient.PurchaseNumber(ctx, &smspb.PurchaseNumberRequest{
+		Service:        "vk",
+		Country:        "RU",
+		IdempotencyKey: "vk:appeal:" + account.ID.Hex(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rent phone number: %w", err)
+	}
+
+	f.logger.Info("Rented number for appeal", "account_id", account.ID, "phone", maskPhone(resp.PhoneNumber))
+	return &appealNumber{
+		Phone:        resp.PhoneNumber,
+		ActivationID: resp.ActivationId,
+		Source:       models.PhoneSourceRented,
+	}, nil
+}
+
+func (f *appealFlow) waitForCode(ctx context.Context, number *appealNumber) (string, error) {
+	maxPolls := f.registrationConfig.MaxSMSPolls
+	if maxPolls == 0 {
+		maxPolls = 30
+	}
+
+	for i := 0; i < maxPolls; i++ {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		resp, err := f.smsClient.GetSMSCode(ctx, &smspb.GetSMSCodeRequest{
+			ActivationId: number.ActivationID,
+		})
+		if err != nil {
+			f.logger.Warn("Failed to get SMS code", "attempt", i+1, "error", err)
+			if activationEnded(ctx, f.smsClient, number.ActivationID) {
+				return "", fmt.Errorf("SMS activation closed")
+			}
+		} else if resp.Code != "" && resp.Code != number.staleCode {
+			return resp.Code, nil
+		}
+
+		time.Sleep(f.registrationConfig.SMSPollingInterval)
+	}
+
+	return "", fmt.Errorf("SMS code not received within timeout")
+}
+
+func (f *appealFlow) typeInto(page playwright.Page, selector, text string) error {
+	input := page.Locator(selector).First()
+	if err := input.Click(); err != nil {
+		return fmt.Errorf("field %s not found: %w", selector, err)
+	}
+	if err := input.Fill(""); err != nil {
+		return fmt.Errorf("failed to clear field %s: %w", selector, err)
+	}
+	time.Sleep(f.stealthInjector.RandomDelay(300, 800))
+
+	handle, err := input.ElementHandle()
+	if err != nil {
+		return fmt.Errorf("failed to get element handle: %w", err)
+	}
+	return f.stealthInjector.TypeWithHumanSpeed(handle, text)
+}
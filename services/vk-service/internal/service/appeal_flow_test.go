@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/grigta/conveer/pkg/logger"
+	smspb "github.com/grigta/conveer/services/sms-service/proto"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+// fakeSMSClient answers the SMS calls of the appeal flow; other methods are not used
+type fakeSMSClient struct {
+	smspb.SMSServiceClient
+
+	status    string
+	purchased *smspb.PurchaseNumberRequest
+	codes     []string
+	codeErr   error
+	codeCalls int
+}
+
+func (c *fakeSMSClient) GetActivationStatus(ctx context.Context, in *smspb.GetActivationStatusRequest, opts ...grpc.CallOption) (*smspb.GetActivationStatusResponse, error) {
+	return &smspb.GetActivationStatusResponse{ActivationId: in.ActivationId, Status: c.status, Code: "111111"}, nil
+}
+
+func (c *fakeSMSClient) PurchaseNumber(ctx context.Context, in *smspb.PurchaseNumberRequest, opts ...grpc.CallOption) (*smspb.PurchaseNumberResponse, error) {
+	c.purchased = in
+	return &smspb.PurchaseNumberResponse{ActivationId: "rented-1", PhoneNumber: "79990001122"}, nil
+}
+
+func (c *fakeSMSClient) GetSMSCode(ctx context.Context, in *smspb.GetSMSCodeRequest, opts ...grpc.CallOption) (*smspb.GetSMSCodeResponse, error) {
+	c.codeCalls++
+	if c.codeErr != nil {
+		return nil, c.codeErr
+	}
+	code := ""
+	if len(c.codes) > 0 {
+		code, c.codes = c.codes[0], c.codes[1:]
+	}
+	return &smspb.GetSMSCodeResponse{Code: code}, nil
+}
+
+func newTestAppealFlow(sms *fakeSMSClient, allowRented bool) *appealFlow {
+	return &appealFlow{
+		smsClient:          sms,
+		config:             &models.AppealConfig{AllowRentedNumber: allowRented},
+		registrationConfig: &models.RegistrationConfig{MaxSMSPolls: 5},
+		logger:             logger.NewFromConfig(logger.Config{Output: io.Discard}),
+	}
+}
+
+func newTestAppealAccount() *models.VKAccount {
+	return &models.VKAccount{
+		ID:           primitive.NewObjectID(),
+		Phone:        "79991112233",
+		ActivationID: "original-1",
+	}
+}
+
+func TestAppealFlow_AcquireNumber_ReusesLiveActivation(t *testing.T) {
+	sms := &fakeSMSClient{status: "waiting"}
+	flow := newTestAppealFlow(sms, true)
+	account := newTestAppealAccount()
+
+	number, err := flow.acquireNumber(context.Background(), account)
+	require.NoError(t, err)
+
+	assert.Equal(t, account.Phone, number.Phone)
+	assert.Equal(t, "original-1", number.ActivationID)
+	assert.Equal(t, models.PhoneSourceOriginal, number.Source)
+	assert.Equal(t, "111111", number.staleCode)
+	assert.Nil(t, sms.purchased)
+}
+
+func TestAppealFlow_AcquireNumber_RentsWhenActivationExpired(t *testing.T) {
+	sms := &fakeSMSClient{status: "expired"}
+	flow := newTestAppealFlow(sms, true)
+	account := newTestAppealAccount()
+
+	number, err := flow.acquireNumber(context.Background(), account)
+	require.NoError(t, err)
+
+	require.NotNil(t, sms.purchased)
+	assert.Equal(t, "vk", sms.purchased.Service)
+	assert.Equal(t, "RU", sms.purchased.Country)
+	assert.Equal(t, "vk:appeal:"+account.ID.Hex(), sms.purchased.IdempotencyKey)
+
+	assert.Equal(t, "79990001122", number.Phone)
+	assert.Equal(t, "rented-1", number.ActivationID)
+	assert.Equal(t, models.PhoneSourceRented, number.Source)
+}
+
+func TestAppealFlow_AcquireNumber_RentingDisabled(t *testing.T) {
+	sms := &fakeSMSClient{status: "cancelled"}
+	flow := newTestAppealFlow(sms, false)
+
+	_, err := flow.acquireNumber(context.Background(), newTestAppealAccount())
+	assert.Error(t, err)
+	assert.Nil(t, sms.purchased)
+}
+
+func TestAppealFlow_WaitForCode_SkipsStaleCode(t *testing.T) {
+	sms := &fakeSMSClient{codes: []string{"111111", "", "222222"}}
+	flow := newTestAppealFlow(sms, true)
+
+	code, err := flow.waitForCode(context.Background(), &appealNumber{ActivationID: "original-1", staleCode: "111111"})
+	require.NoError(t, err)
+	assert.Equal(t, "222222", code)
+	assert.Equal(t, 3, sms.codeCalls)
+}
+
+func TestAppealFlow_WaitForCode_StopsOnClosedActivation(t *testing.T) {
+	sms := &fakeSMSClient{status: "cancelled", codeErr: errors.New("activation is cancelled")}
+	flow := newTestAppealFlow(sms, true)
+
+	_, err := flow.waitForCode(context.Background(), &appealNumber{ActivationID: "rented-1"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, sms.codeCalls)
+}
+
+func TestMaskPhone(t *testing.T) {
+	assert.Equal(t, "799***", maskPhone("79990001122"))
+	assert.Equal(t, "***", maskPhone("79"))
+}
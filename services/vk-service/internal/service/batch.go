@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// batchStallTimeout is how long an unfinished batch may go without updates before it is recovered
+const batchStallTimeout = 15 * time.Minute
+
+var (
+	ErrInvalidBatchSize = errors.New("invalid batch size")
+	ErrBatchNotRunning  = errors.New("batch is not running")
+)
+
+// CreateAccountsBatch starts a job registering count accounts with persona profiles.
+// Registrations are queued in the background, the batch is returned right away.
+func (s *vkService) CreateAccountsBatch(ctx context.Context, count int, opts models.BatchOptions) (*models.RegistrationBatch, error) {
+	if count <= 0 || count > s.registrationCfg.MaxBatchSize {
+		return nil, fmt.Errorf("%w: %d, allowed 1-%d", ErrInvalidBatchSize, count, s.registrationCfg.MaxBatchSize)
+	}
+	if s.workerCtx == nil {
+		return nil, fmt.Errorf("registration workers are not running")
+	}
+
+	batch := &models.RegistrationBatch{
+		Requested: count,
+		Options:   opts,
+		Status:    models.BatchStatusRunning,
+		Pending:   count,
+	}
+	if err := s.batchRepo.CreateBatch(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	go s.queueBatch(s.workerCtx, batch)
+
+	s.logger.Info("Registration batch created", "batch_id", batch.ID, "count", count)
+	return batch, nil
+}
+
+// GetBatchStatus returns the batch with the current registration step of queued accounts
+func (s *vkService) GetBatchStatus(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error) {
+	batch, err := s.batchRepo.GetBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range batch.Items {
+		item := &batch.Items[i]
+		if item.Status != models.BatchItemQueued {
+			continue
+		}
+		session, err := s.sessionRepo.GetSession(ctx, item.AccountID)
+		if err != nil {
+			s.logger.Warn("Failed to get registration session", "error", err, "account_id", item.AccountID)
+			continue
+		}
+		if session != nil {
+			item.Step = session.CurrentStep
+		}
+	}
+
+	return batch, nil
+}
+
+// CancelBatch stops queueing the rest of the batch and drops queued registrations that have not
+// started yet. Registrations already running are finished and counted as usual.
+func (s *vkService) CancelBatch(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error) {
+	batch, err := s.batchRepo.Cancel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		current, err := s.batchRepo.GetBatch(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w (status: %s)", ErrBatchNotRunning, current.Status)
+	}
+
+	s.logger.Info("Registration batch cancelled", "batch_id", id, "pending", batch.Pending)
+	return batch, nil
+}
+
+// queueBatch creates the batch accounts one by one, checking for cancellation before each.
+// Each item is stored before its command is published so a quick failure always finds it.
+// Items already in the batch are kept, so a recovered batch continues where it stopped.
+func (s *vkService) queueBatch(ctx context.Context, batch *models.RegistrationBatch) {
+	// Bookkeeping must survive the shutdown that stops queueing
+	storeCtx := context.WithoutCancel(ctx)
+
+	for queued := len(batch.Items); queued < batch.Requested; queued++ {
+		remaining := batch.Requested - queued
+
+		if ctx.Err() != nil {
+			s.logger.Warn("Registration batch interrupted by shutdown", "batch_id", batch.ID, "unqueued", remaining)
+			s.releaseBatch(storeCtx, batch.ID, remaining, models.BatchItemFailed)
+			return
+		}
+
+		current, err := s.batchRepo.GetBatch(ctx, batch.ID)
+		if err != nil {
+			s.logger.Error("Failed to check registration batch", "error", err, "batch_id", batch.ID)
+		} else if current.Status == models.BatchStatusCancelled {
+			s.releaseBatch(storeCtx, batch.ID, remaining, models.BatchItemCancelled)
+			return
+		}
+
+		request := &models.RegistrationRequest{
+			Gender:           batch.Options.Gender,
+			PreferredCountry: batch.Options.PreferredCountry,
+			UseRandomProfile: true,
+			BatchID:          batch.ID.Hex(),
+		}
+
+		account, err := s.createAccountRecord(ctx, request)
+		if err != nil {
+			s.logger.Error("Failed to create batch account", "error", err, "batch_id", batch.ID)
+			s.addBatchItem(storeCtx, batch.ID, models.BatchItem{Status: models.BatchItemFailed, Error: err.Error()})
+			continue
+		}
+
+		s.addBatchItem(storeCtx, batch.ID, models.BatchItem{AccountID: account.ID, Status: models.BatchItemQueued})
+
+		if err := s.queueRegistration(ctx, account, request); err != nil {
+			s.setBatchOutcome(storeCtx, batch.ID, account.ID, models.BatchItemFailed, err.Error())
+		}
+	}
+}
+
+// monitorStalledBatches recovers batches left unfinished by a restart, on start and then periodically
+func (s *vkService) monitorStalledBatches(ctx context.Context) {
+	s.recoverStalledBatches(ctx)
+
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.recoverStalledBatches(ctx)
+		}
+	}
+}
+
+// recoverStalledBatches finishes batches nobody works on anymore: queued items whose registration
+// already ended get their outcome, the rest of the batch is queued again and a batch without
+// pending registrations is completed. Claiming the batch first keeps instances from recovering it twice.
+func (s *vkService) recoverStalledBatches(ctx context.Context) {
+	stalled, err := s.batchRepo.GetStalledBatches(ctx, batchStallTimeout)
+	if err != nil {
+		s.logger.Error("Failed to get stalled registration batches", "error", err)
+		return
+	}
+
+	for i := range stalled {
+		batch, err := s.batchRepo.Claim(ctx, &stalled[i])
+		if err != nil {
+			s.logger.Error("Failed to claim registration batch", "error", err, "batch_id", stalled[i].ID)
+			continue
+		}
+		if batch == nil {
+			// Another instance recovered it or the batch moved on
+			continue
+		}
+
+		s.logger.Warn("Recovering stalled registration batch",
+			"batch_id", batch.ID,
+			"status", batch.Status,
+			"pending", batch.Pending,
+			"unqueued", batch.Requested-len(batch.Items))
+
+		s.settleQueuedItems(ctx, batch)
+
+		if len(batch.Items) < batch.Requested {
+			go s.queueBatch(ctx, batch)
+			continue
+		}
+		s.completeBatch(ctx, batch)
+	}
+}
+
+// settleQueuedItems records outcomes of queued items whose accounts finished registration without
+// the batch being told, e.g. the instance stopped in between or the account was retried later
+func (s *vkService) settleQueuedItems(ctx context.Context, batch *models.RegistrationBatch) {
+	for _, item := range batch.Items {
+		if item.Status != models.BatchItemQueued {
+			continue
+		}
+
+		account, err := s.accountRepo.GetAccountByID(ctx, item.AccountID)
+		if err != nil {
+			s.logger.Warn("Failed to get batch account", "error", err, "batch_id", batch.ID, "account_id", item.AccountID)
+			continue
+		}
+
+		status, ok := batchItemOutcome(account.Status)
+		if !ok {
+			continue
+		}
+		reason := ""
+		if status == models.BatchItemFailed {
+			reason = account.ErrorMessage
+		}
+		s.setBatchOutcome(ctx, batch.ID, item.AccountID, status, reason)
+	}
+}
+
+// batchItemOutcome maps the account status to the batch outcome, false while registration is still running
+func batchItemOutcome(status models.AccountStatus) (models.BatchItemStatus, bool) {
+	switch status {
+	case models.StatusCreating:
+		return "", false
+	case models.StatusError, models.StatusBanned, models.StatusSuspended:
+		return models.BatchItemFailed, true
+	}
+	return models.BatchItemSucceeded, true
+}
+
+// skipCancelledBatch drops a registration of a cancelled batch before it starts
+func (s *vkService) skipCancelledBatch(ctx context.Context, job registrationJob) bool {
+	batchID, err := primitive.ObjectIDFromHex(job.request.BatchID)
+	if err != nil {
+		return false
+	}
+
+	batch, err := s.batchRepo.GetBatch(ctx, batchID)
+	if err != nil {
+		s.logger.Warn("Failed to check registration batch", "error", err, "batch_id", batchID)
+		return false
+	}
+	if batch.Status != models.BatchStatusCancelled {
+		return false
+	}
+
+	if err := s.accountRepo.UpdateAccountStatus(ctx, job.accountID, models.StatusError, "registration batch cancelled"); err != nil {
+		s.logger.Error("Failed to mark cancelled batch account", "error", err, "account_id", job.accountID)
+	}
+	s.setBatchOutcome(ctx, batchID, job.accountID, models.BatchItemCancelled, "batch cancelled")
+
+	s.logger.Info("Skipped registration of cancelled batch", "account_id", job.accountID, "batch_id", batchID)
+	return true
+}
+
+// recordBatchOutcome counts the first registration attempt of a batch account, later retries are not tracked
+func (s *vkService) recordBatchOutcome(ctx context.Context, job registrationJob, status models.BatchItemStatus, reason string) {
+	if job.request.BatchID == "" {
+		return
+	}
+
+	batchID, err := primitive.ObjectIDFromHex(job.request.BatchID)
+	if err != nil {
+		s.logger.Warn("Invalid batch ID in registration command", "batch_id", job.request.BatchID)
+		return
+	}
+
+	s.setBatchOutcome(ctx, batchID, job.accountID, status, reason)
+}
+
+func (s *vkService) addBatchItem(ctx context.Context, batchID primitive.ObjectID, item models.BatchItem) {
+	batch, err := s.batchRepo.AddItem(ctx, batchID, item)
+	if err != nil {
+		s.logger.Error("Failed to add batch item", "error", err, "batch_id", batchID)
+		return
+	}
+	s.completeBatch(ctx, batch)
+}
+
+func (s *vkService) setBatchOutcome(ctx context.Context, batchID, accountID primitive.ObjectID, status models.BatchItemStatus, reason string) {
+	batch, err := s.batchRepo.SetItemOutcome(ctx, batchID, accountID, status, reason)
+	if err != nil {
+		s.logger.Error("Failed to record batch outcome", "error", err, "batch_id", batchID, "account_id", accountID)
+		return
+	}
+	s.completeBatch(ctx, batch)
+}
+
+func (s *vkService) releaseBatch(ctx context.Context, batchID primitive.ObjectID, count int, status models.BatchItemStatus) {
+	batch, err := s.batchRepo.ReleaseUnqueued(ctx, batchID, count, status)
+	if err != nil {
+		s.logger.Error("Failed to release unqueued batch registrations", "error", err, "batch_id", batchID)
+		return
+	}
+	s.completeBatch(ctx, batch)
+}
+
+// completeBatch publishes vk.batch.completed once the last registration of the batch has an outcome
+func (s *vkService) completeBatch(ctx context.Context, batch *models.RegistrationBatch) {
+	if batch == nil || batch.Pending > 0 || batch.IsFinal() {
+		return
+	}
+
+	batch, err := s.batchRepo.MarkCompleted(ctx, batch.ID)
+	if err != nil {
+		s.logger.Error("Failed to complete registration batch", "error", err)
+		return
+	}
+	if batch == nil {
+		// Another worker completed it
+		return
+	}
+
+	accountIDs := make([]string, 0, batch.Succeeded)
+	for _, item := range batch.Items {
+		if item.Status == models.BatchItemSucceeded {
+			accountIDs = append(accountIDs, item.AccountID.Hex())
+		}
+	}
+
+	event := map[string]interface{}{
+		"batch_id":     batch.ID.Hex(),
+		"platform":     "vk",
+		"status":       batch.Status,
+		"requested":    batch.Requested,
+		"succeeded":    batch.Succeeded,
+		"failed":       batch.Failed,
+		"cancelled":    batch.Cancelled,
+		"account_ids":  accountIDs,
+		"duration_sec": time.Since(batch.CreatedAt).Seconds(),
+		"timestamp":    time.Now(),
+	}
+	if err := s.messagingClient.PublishEvent("vk.events", "vk.batch.completed", event); err != nil {
+		s.logger.Warn("Failed to publish batch completed event", "error", err, "batch_id", batch.ID)
+	}
+
+	s.logger.Info("Registration batch completed",
+		"batch_id", batch.ID,
+		"status", batch.Status,
+		"succeeded", batch.Succeeded,
+		"failed", batch.Failed,
+		"cancelled", batch.Cancelled)
+}
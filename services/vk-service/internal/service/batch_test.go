@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchItemOutcome(t *testing.T) {
+	tests := []struct {
+		status  models.AccountStatus
+		want    models.BatchItemStatus
+		settled bool
+	}{
+		{models.StatusCreating, "", false},
+		{models.StatusCreated, models.BatchItemSucceeded, true},
+		{models.StatusWarming, models.BatchItemSucceeded, true},
+		{models.StatusReady, models.BatchItemSucceeded, true},
+		{models.StatusAppealing, models.BatchItemSucceeded, true},
+		{models.StatusError, models.BatchItemFailed, true},
+		{models.StatusBanned, models.BatchItemFailed, true},
+		{models.StatusSuspended, models.BatchItemFailed, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			got, settled := batchItemOutcome(tt.status)
+			assert.Equal(t, tt.settled, settled)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -18,9 +19,15 @@ type BrowserConfig struct {
 	DefaultTimeout time.Duration
 }
 
+// maxBrowserPoolSize caps runtime resizing, each browser holds a few hundred MB
+const maxBrowserPoolSize = 100
+
+var ErrInvalidPoolSize = errors.New("invalid browser pool size")
+
 type BrowserInstance struct {
 	Browser   playwright.Browser
 	InUse     bool
+	Draining  bool // Closed on release instead of returning to the pool after a shrink
 	CreatedAt time.Time
 	ProxyURL  string
 }
@@ -31,6 +38,7 @@ type BrowserManager interface {
 	ReleaseBrowser(browser playwright.Browser) error
 	Shutdown(ctx context.Context) error
 	GetPoolStats() PoolStats
+	Resize(ctx context.Context, size int) (PoolStats, error)
 }
 
 type browserManager struct {
@@ -51,9 +59,11 @@ type ProxyConfig struct {
 }
 
 type PoolStats struct {
-	TotalBrowsers    int
-	AvailableBrowsers int
-	InUseBrowsers    int
+	TotalBrowsers     int `json:"total_browsers"`
+	AvailableBrowsers int `json:"available_browsers"`
+	InUseBrowsers     int `json:"in_use_browsers"`
+	DrainingBrowsers  int `json:"draining_browsers"`
+	TargetSize        int `json:"target_size"`
 }
 
 func NewBrowserManager(config *BrowserConfig, metrics MetricsCollector, logger logger.Logger) BrowserManager {
@@ -154,7 +164,7 @@ func (m *browserManager) AcquireBrowser(ctx context.Context, proxyConfig *ProxyC
 	defer m.poolMu.Unlock()
 
 	for _, instance := range m.pool {
-		if !instance.InUse {
+		if !instance.InUse && !instance.Draining {
 			// Check if proxy matches (if specified)
 			if proxyConfig == nil || proxyConfig.Server == "" || instance.ProxyURL == proxyConfig.Server {
 				instance.InUse = true
@@ -162,7 +172,7 @@ func (m *browserManager) AcquireBrowser(ctx context.Context, proxyConfig *ProxyC
 				// Create new context with specific configuration
 				contextOptions := playwright.BrowserNewContextOptions{
 					AcceptDownloads: playwright.Bool(false),
-					IgnoreHTTPSErrors: playwright.Bool(true),
+					IgnoreHttpsErrors: playwright.Bool(true),
 				}
 
 				context, err := instance.Browser.NewContext(contextOptions)
@@ -176,6 +186,7 @@ func (m *browserManager) AcquireBrowser(ctx context.Context, proxyConfig *ProxyC
 					context.SetDefaultTimeout(float64(m.config.DefaultTimeout.Milliseconds()))
 				}
 
+				m.recordPoolMetrics(m.poolStatsLocked())
 				m.logger.Debug("Browser acquired from pool", "proxy", instance.ProxyURL)
 				return instance.Browser, context, nil
 			}
@@ -194,7 +205,7 @@ func (m *browserManager) AcquireBrowser(ctx context.Context, proxyConfig *ProxyC
 
 		contextOptions := playwright.BrowserNewContextOptions{
 			AcceptDownloads: playwright.Bool(false),
-			IgnoreHTTPSErrors: playwright.Bool(true),
+			IgnoreHttpsErrors: playwright.Bool(true),
 		}
 
 		context, err := newInstance.Browser.NewContext(contextOptions)
@@ -207,6 +218,7 @@ func (m *browserManager) AcquireBrowser(ctx context.Context, proxyConfig *ProxyC
 			context.SetDefaultTimeout(float64(m.config.DefaultTimeout.Milliseconds()))
 		}
 
+		m.recordPoolMetrics(m.poolStatsLocked())
 		m.logger.Debug("New browser created and acquired", "proxy", newInstance.ProxyURL)
 		return newInstance.Browser, context, nil
 	}
@@ -218,9 +230,19 @@ func (m *browserManager) ReleaseBrowser(browser playwright.Browser) error {
 	m.poolMu.Lock()
 	defer m.poolMu.Unlock()
 
-	for _, instance := range m.pool {
+	for i, instance := range m.pool {
 		if instance.Browser == browser {
+			if instance.Draining {
+				// The pool was shrunk while the browser was busy
+				m.pool = append(m.pool[:i], m.pool[i+1:]...)
+				m.recordPoolMetrics(m.poolStatsLocked())
+				go m.closeInstance(instance)
+				m.logger.Info("Drained browser closed after release", "proxy", instance.ProxyURL)
+				return nil
+			}
+
 			instance.InUse = false
+			m.recordPoolMetrics(m.poolStatsLocked())
 			m.logger.Debug("Browser released to pool", "proxy", instance.ProxyURL)
 			return nil
 		}
@@ -329,17 +351,136 @@ func (m *browserManager) GetPoolStats() PoolStats {
 	m.poolMu.RLock()
 	defer m.poolMu.RUnlock()
 
+	return m.poolStatsLocked()
+}
+
+// poolStatsLocked expects poolMu to be held
+func (m *browserManager) poolStatsLocked() PoolStats {
 	stats := PoolStats{
 		TotalBrowsers: len(m.pool),
+		TargetSize:    m.config.PoolSize,
 	}
 
 	for _, instance := range m.pool {
-		if instance.InUse {
+		switch {
+		case instance.Draining:
+			stats.DrainingBrowsers++
+			stats.InUseBrowsers++
+		case instance.InUse:
 			stats.InUseBrowsers++
-		} else {
+		default:
 			stats.AvailableBrowsers++
 		}
 	}
 
 	return stats
 }
+
+// Resize changes the target pool size at runtime. Growing warms the new browsers in the
+// background. Shrinking closes idle browsers right away and drains busy ones: they finish
+// their current job and are closed on release instead of returning to the pool.
+func (m *browserManager) Resize(ctx context.Context, size int) (PoolStats, error) {
+	if size < 1 || size > maxBrowserPoolSize {
+		return PoolStats{}, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPoolSize, maxBrowserPoolSize)
+	}
+
+	m.poolMu.Lock()
+	previous := m.config.PoolSize
+	m.config.PoolSize = size
+
+	active := 0
+	for _, instance := range m.pool {
+		if !instance.Draining {
+			active++
+		}
+	}
+
+	// Idle browsers go first, busy ones are only marked
+	excess := active - size
+	var toClose []*BrowserInstance
+	kept := make([]*BrowserInstance, 0, len(m.pool))
+	for _, instance := range m.pool {
+		if excess > 0 && !instance.InUse && !instance.Draining {
+			toClose = append(toClose, instance)
+			excess--
+			continue
+		}
+		kept = append(kept, instance)
+	}
+	for _, instance := range kept {
+		if excess > 0 && instance.InUse && !instance.Draining {
+			instance.Draining = true
+			excess--
+		}
+	}
+
+	// Growing first takes back browsers that are still draining
+	missing := size - active
+	for _, instance := range kept {
+		if missing > 0 && instance.Draining {
+			instance.Draining = false
+			missing--
+		}
+	}
+
+	m.pool = kept
+	stats := m.poolStatsLocked()
+	m.recordPoolMetrics(stats)
+	m.poolMu.Unlock()
+
+	for _, instance := range toClose {
+		m.closeInstance(instance)
+	}
+	if missing > 0 {
+		go m.warmBrowsers(missing)
+	}
+
+	m.logger.Info("Browser pool resized", "from", previous, "to", size, "closed", len(toClose), "draining", stats.DrainingBrowsers, "warming", max(missing, 0))
+	return stats, nil
+}
+
+// warmBrowsers launches browsers one by one until the pool reaches its target size again.
+// It stops early when the pool is shrunk meanwhile or the manager shuts down.
+func (m *browserManager) warmBrowsers(count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case <-m.shutdownCh:
+			return
+		default:
+		}
+
+		m.poolMu.RLock()
+		stats := m.poolStatsLocked()
+		m.poolMu.RUnlock()
+		if stats.TotalBrowsers-stats.DrainingBrowsers >= stats.TargetSize {
+			return
+		}
+
+		if err := m.createBrowserInstance(nil); err != nil {
+			m.logger.Error("Failed to warm browser for resized pool", "error", err)
+			return
+		}
+		m.recordPoolMetrics(m.GetPoolStats())
+	}
+}
+
+// closeInstance closes the browser's contexts before the browser itself, so pages get
+// their unload handlers and cookies are flushed
+func (m *browserManager) closeInstance(instance *BrowserInstance) {
+	for _, browserContext := range instance.Browser.Contexts() {
+		if err := browserContext.Close(); err != nil {
+			m.logger.Warn("Failed to close browser context", "error", err)
+		}
+	}
+	if err := instance.Browser.Close(); err != nil {
+		m.logger.Error("Failed to close browser", "error", err)
+	}
+}
+
+func (m *browserManager) recordPoolMetrics(stats PoolStats) {
+	if m.metrics == nil {
+		return
+	}
+	m.metrics.UpdateBrowserPoolSize(stats.TotalBrowsers)
+	m.metrics.UpdateBrowserPoolUtilization(stats.InUseBrowsers, stats.TargetSize)
+}
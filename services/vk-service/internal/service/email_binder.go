@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+	"github.com/grigta/conveer/services/vk-service/internal/repository"
+
+	"github.com/playwright-community/playwright-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoRecoveryMailbox is returned when mail-service has no verified mailbox left to bind
+var ErrNoRecoveryMailbox = errors.New("no verified recovery mailbox available")
+
+// recoveryMailPlatform is how VK accounts are identified in mail-service bindings
+const recoveryMailPlatform = "vk"
+
+// vkMailSender matches the From of VK notification emails, IMAP FROM searches are substring matches
+const vkMailSender = "vk.com"
+
+// vkConfirmLinkPattern matches the email confirmation link in the VK notification
+var vkConfirmLinkPattern = regexp.MustCompile(`^https://(?:[a-z0-9-]+\.)*vk\.com/.*(?:hash=|confirm|activat)`)
+
+type EmailBinder interface {
+	BindEmail(ctx context.Context, accountID primitive.ObjectID) (*models.EmailBindingResult, error)
+}
+
+type emailBinder struct {
+	accountRepo     repository.AccountRepository
+	browserManager  BrowserManager
+	stealthInjector StealthInjector
+	proxyClient     proxypb.ProxyServiceClient
+	mailClient      mailpb.MailServiceClient
+	config          *models.EmailBindingConfig
+	metrics         MetricsCollector
+	logger          logger.Logger
+}
+
+func NewEmailBinder(
+	accountRepo repository.AccountRepository,
+	browserManager BrowserManager,
+	stealthInjector StealthInjector,
+	proxyClient proxypb.ProxyServiceClient,
+	mailClient mailpb.MailServiceClient,
+	config *models.EmailBindingConfig,
+	metrics MetricsCollector,
+	logger logger.Logger,
+) EmailBinder {
+	return &emailBinder{
+		accountRepo:     accountRepo,
+		browserManager:  browserManager,
+		stealthInjector: stealthInjector,
+		proxyClient:     proxyClient,
+		mailClient:      mailClient,
+		config:          config,
+		metrics:         metrics,
+		logger:          logger,
+	}
+}
+
+// BindEmail reserves a verified mailbox in mail-service, adds it to the account's VK settings
+// and confirms it through the link VK sends. The mailbox is released if binding fails.
+func (b *emailBinder) BindEmail(ctx context.Context, accountID primitive.ObjectID) (*models.EmailBindingResult, error) {
+	startTime := time.Now()
+
+	account, err := b.accountRepo.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// Binding twice would only replace one working recovery email with another
+	if account.EmailBoundAt != nil {
+		return &models.EmailBindingResult{
+			AccountID:     accountID.Hex(),
+			Email:         account.Email,
+			MailAccountID: account.MailAccountID,
+			BoundAt:       *account.EmailBoundAt,
+		}, nil
+	}
+
+	switch account.Status {
+	case models.StatusCreated, models.StatusWarming, models.StatusReady:
+	default:
+		return nil, fmt.Errorf("account is not registered (status: %s)", account.Status)
+	}
+	if len(account.Cookies) == 0 {
+		return nil, fmt.Errorf("account has no session cookies")
+	}
+
+	mailbox, err := b.mailClient.ReserveRecoveryMailbox(ctx, &mailpb.ReserveRecoveryMailboxRequest{
+		Platform:          recoveryMailPlatform,
+		ConsumerAccountId: accountID.Hex(),
+	})
+	if err != nil {
+		if status.Code(err) == codes.ResourceExhausted {
+			b.metrics.IncrementEmailBindings("no_mailbox")
+			return nil, ErrNoRecoveryMailbox
+		}
+		b.metrics.IncrementEmailBindings("failed")
+		return nil, fmt.Errorf("failed to reserve mailbox: %w", err)
+	}
+
+	if err := b.bind(ctx, account, mailbox); err != nil {
+		b.metrics.IncrementEmailBindings("failed")
+		b.releaseMailbox(accountID)
+		return nil, err
+	}
+
+	if err := b.accountRepo.BindRecoveryEmail(ctx, accountID, mailbox.Email, mailbox.AccountId); err != nil {
+		// VK already has the address, so the mailbox stays bound to this account
+		b.metrics.IncrementEmailBindings("failed")
+		return nil, fmt.Errorf("failed to save bound email: %w", err)
+	}
+
+	b.metrics.IncrementEmailBindings("success")
+	b.logger.Info("Recovery email bound", "account_id", accountID, "mail_account_id", mailbox.AccountId)
+
+	return &models.EmailBindingResult{
+		AccountID:     accountID.Hex(),
+		Email:         mailbox.Email,
+		MailAccountID: mailbox.AccountId,
+		BoundAt:       time.Now(),
+		Duration:      time.Since(startTime).Seconds(),
+	}, nil
+}
+
+// bind enters the mailbox in VK settings and follows the confirmation link from the mailbox
+func (b *emailBinder) bind(ctx context.Context, account *models.VKAccount, mailbox *mailpb.RecoveryMailbox) error {
+	browser, browserCtx, err := openAccountSession(ctx, b.browserManager, b.proxyClient, account, b.logger)
+	if err != nil {
+		return err
+	}
+	defer closeAccountSession(b.browserManager, browser, browserCtx, b.logger)
+
+	page, err := browserCtx.NewPage()
+	if err != nil {
+		return fmt.Errorf("failed to create page: %w", err)
+	}
+	if err := b.stealthInjector.InjectStealth(page); err != nil {
+		b.logger.Warn("Failed to inject stealth scripts", "error", err)
+	}
+
+	// Mail older than the request belongs to an earlier attempt
+	requestedAt := time.Now().Add(-time.Minute)
+	if err := b.submitEmail(page, account, mailbox.Email); err != nil {
+		return err
+	}
+
+	mail, err := b.waitForConfirmation(ctx, mailbox.AccountId, requestedAt)
+	if err != nil {
+		return err
+	}
+
+	return b.confirm(page, mail)
+}
+
+func (b *emailBinder) submitEmail(page playwright.Page, account *models.VKAccount, email string) error {
+	if _, err := page.Goto("https://vk.com/settings", playwright.PageGotoOptions{
+		WaitUntil: playwright.WaitUntilStateNetworkidle,
+		Timeout:   playwright.Float(30000),
+	}); err != nil {
+		return fmt.Errorf("failed to open settings page: %w", err)
+	}
+	if count, _ := page.Locator("#l_pr").Count(); count == 0 {
+		return fmt.Errorf("session cookies are no longer valid")
+	}
+	b.stealthInjector.EmulateHumanBehavior(page)
+
+	trigger := page.Locator("#chgemail, .settings_email a, button:has-text('Добавить почту'), a:has-text('Добавить почту')").First()
+	if err := trigger.Click(); err != nil {
+		return fmt.Errorf("email settings not found: %w", err)
+	}
+	time.Sleep(b.stealthInjector.RandomDelay(b.config.ActionDelayMin, b.config.ActionDelayMax))
+
+	if err := b.typeInto(page, "#new_email, input[name='email'], input[type='email']", email); err != nil {
+		return err
+	}
+	time.Sleep(b.stealthInjector.RandomDelay(b.config.ActionDelayMin, b.config.ActionDelayMax))
+
+	if err := page.Locator("#chgemail_submit, button:has-text('Сохранить'), button:has-text('Продолжить')").First().Click(); err != nil {
+		return fmt.Errorf("email submit button not found: %w", err)
+	}
+	time.Sleep(b.stealthInjector.RandomDelay(1500, 3000))
+
+	// VK asks for the password before changing contact details
+	passwordInput := page.Locator("input[type='password']").First()
+	if count, _ := passwordInput.Count(); count > 0 {
+		if account.Password == "" {
+			return fmt.Errorf("vk asked for the password but the account has none stored")
+		}
+		if err := b.typeInto(page, "input[type='password']", account.Password); err != nil {
+			return err
+		}
+		if err := page.Locator("button[type='submit'], button:has-text('Подтвердить'), button:has-text('Продолжить')").First().Click(); err != nil {
+			return fmt.Errorf("password submit button not found: %w", err)
+		}
+		time.Sleep(b.stealthInjector.RandomDelay(1500, 3000))
+	}
+
+	if count, _ := page.Locator(".error, .msg.error, [role='alert']").Count(); count > 0 {
+		text, _ := page.Locator(".error, .msg.error, [role='alert']").First().TextContent()
+		return fmt.Errorf("vk rejected the email: %s", text)
+	}
+
+	return nil
+}
+
+// waitForConfirmation polls the mailbox through mail-service until the VK email arrives
+func (b *emailBinder) waitForConfirmation(ctx context.Context, mailAccountID string, since time.Time) (*mailpb.VerificationMail, error) {
+	ctx, cancel := context.WithTimeout(ctx, b.config.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(b.config.PollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		mail, err := b.mailClient.ReadVerificationMail(ctx, &mailpb.ReadVerificationMailRequest{
+			AccountId: mailAccountID,
+			From:      vkMailSender,
+			Since:     since.Unix(),
+		})
+		switch {
+		case err != nil:
+			lastErr = err
+		case mail.Found:
+			return mail, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("confirmation email not received within %s: %w", b.config.Timeout, lastErr)
+			}
+			return nil, fmt.Errorf("confirmation email not received within %s", b.config.Timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// confirm opens the confirmation link in the account session, or enters the code when VK sent one instead
+func (b *emailBinder) confirm(page playwright.Page, mail *mailpb.VerificationMail) error {
+	for _, link := range mail.Links {
+		if !vkConfirmLinkPattern.MatchString(link) {
+			continue
+		}
+		if _, err := page.Goto(link, playwright.PageGotoOptions{
+			WaitUntil: playwright.WaitUntilStateNetworkidle,
+			Timeout:   playwright.Float(30000),
+		}); err != nil {
+			return fmt.Errorf("failed to open confirmation link: %w", err)
+		}
+		time.Sleep(b.stealthInjector.RandomDelay(b.config.ActionDelayMin, b.config.ActionDelayMax))
+		return nil
+	}
+
+	if mail.Code == "" {
+		return fmt.Errorf("confirmation email has neither a link nor a code")
+	}
+	if err := b.typeInto(page, "input[name='code'], input[autocomplete='one-time-code'], #email_code", mail.Code); err != nil {
+		return err
+	}
+	if err := page.Locator("button[type='submit'], button:has-text('Подтвердить')").First().Click(); err != nil {
+		return fmt.Errorf("code submit button not found: %w", err)
+	}
+	time.Sleep(b.stealthInjector.RandomDelay(1500, 3000))
+	return nil
+}
+
+func (b *emailBinder) typeInto(page playwright.Page, selector, text string) error {
+	input := page.Locator(selector).First()
+	if err := input.Click(); err != nil {
+		return fmt.Errorf("field %s not found: %w", selector, err)
+	}
+	if err := input.Fill(""); err != nil {
+		return fmt.Errorf("failed to clear field %s: %w", selector, err)
+	}
+	time.Sleep(b.stealthInjector.RandomDelay(300, 800))
+
+	handle, err := input.ElementHandle()
+	if err != nil {
+		return fmt.Errorf("failed to get element handle: %w", err)
+	}
+	return b.stealthInjector.TypeWithHumanSpeed(handle, text)
+}
+
+func (b *emailBinder) releaseMailbox(accountID primitive.ObjectID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := b.mailClient.ReleaseRecoveryMailbox(ctx, &mailpb.ReleaseRecoveryMailboxRequest{
+		Platform:          recoveryMailPlatform,
+		ConsumerAccountId: accountID.Hex(),
+	}); err != nil {
+		b.logger.Warn("Failed to release recovery mailbox", "error", err, "account_id", accountID)
+	}
+}
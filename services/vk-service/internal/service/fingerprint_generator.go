@@ -99,9 +99,11 @@ func (g *fingerprintGenerator) GenerateFingerprint() *Fingerprint {
 }
 
 func (g *fingerprintGenerator) ApplyFingerprint(context playwright.BrowserContext, fingerprint *Fingerprint) error {
-	// Set viewport
-	if err := context.SetViewportSize(fingerprint.Viewport.Width, fingerprint.Viewport.Height); err != nil {
-		return fmt.Errorf("failed to set viewport: %w", err)
+	// Set viewport of the pages already open, new pages get it from the context options
+	for _, page := range context.Pages() {
+		if err := page.SetViewportSize(fingerprint.Viewport.Width, fingerprint.Viewport.Height); err != nil {
+			return fmt.Errorf("failed to set viewport: %w", err)
+		}
 	}
 
 	// Set user agent
@@ -16,8 +16,17 @@ type MetricsCollector interface {
 	IncrementActiveRegistrations()
 	DecrementActiveRegistrations()
 	UpdateBrowserPoolSize(size int)
+	UpdateBrowserPoolUtilization(inUse, target int)
 	IncrementErrorsTotal(errorType string)
 	IncrementManualInterventions()
+	IncrementProfileSteps(step, result string)
+	IncrementAppeals(result string)
+	IncrementEmailBindings(result string)
+	SetRegistrationQueueDepth(depth int)
+	IncrementAdmissionWaits(resource string)
+	IncrementSelectorMatches(key, variant string)
+	IncrementSelectorMisses(key string)
+	SetSelectorVersion(source string, version int)
 	GetTotalAccounts() int64
 }
 
@@ -28,8 +37,19 @@ type metricsCollector struct {
 	retryAttemptsTotal      prometheus.Counter
 	activeRegistrations     prometheus.Gauge
 	browserPoolSize         prometheus.Gauge
+	browserPoolInUse        prometheus.Gauge
+	browserPoolTarget       prometheus.Gauge
+	browserPoolUtilization  prometheus.Gauge
 	errorsTotal             *prometheus.CounterVec
 	manualInterventionsTotal prometheus.Counter
+	profileStepsTotal       *prometheus.CounterVec
+	appealsTotal            *prometheus.CounterVec
+	emailBindingsTotal      *prometheus.CounterVec
+	registrationQueueDepth  prometheus.Gauge
+	admissionWaitsTotal     *prometheus.CounterVec
+	selectorMatchesTotal    *prometheus.CounterVec
+	selectorMissesTotal     *prometheus.CounterVec
+	selectorMapVersion      *prometheus.GaugeVec
 	totalAccountsCache      int64
 }
 
@@ -74,6 +94,24 @@ func NewMetricsCollector() MetricsCollector {
 				Help: "Current size of the browser pool",
 			},
 		),
+		browserPoolInUse: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "vk_browser_pool_in_use",
+				Help: "Number of browsers currently running a job, including draining ones",
+			},
+		),
+		browserPoolTarget: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "vk_browser_pool_target_size",
+				Help: "Target size of the browser pool, changed at runtime by resizing",
+			},
+		),
+		browserPoolUtilization: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "vk_browser_pool_utilization",
+				Help: "Share of the target browser pool size currently in use",
+			},
+		),
 		errorsTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "vk_errors_total",
@@ -87,6 +125,61 @@ func NewMetricsCollector() MetricsCollector {
 				Help: "Total number of manual intervention requests",
 			},
 		),
+		profileStepsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "vk_profile_steps_total",
+				Help: "Total number of profile population steps by step and result",
+			},
+			[]string{"step", "result"},
+		),
+		appealsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "vk_appeals_total",
+				Help: "Total number of ban appeals by outcome",
+			},
+			[]string{"result"},
+		),
+		emailBindingsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "vk_email_bindings_total",
+				Help: "Total number of recovery email bindings by result",
+			},
+			[]string{"result"},
+		),
+		registrationQueueDepth: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "vk_registration_queue_depth",
+				Help: "Number of registration commands waiting for a free worker",
+			},
+		),
+		admissionWaitsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "vk_registration_admission_waits_total",
+				Help: "Total number of registrations that had to wait for a proxy or SMS provider slot",
+			},
+			[]string{"resource"},
+		),
+		selectorMatchesTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "vk_selector_matches_total",
+				Help: "Total number of page elements found by selector key and matched variant",
+			},
+			[]string{"key", "variant"},
+		),
+		selectorMissesTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "vk_selector_misses_total",
+				Help: "Total number of lookups where no selector variant matched",
+			},
+			[]string{"key"},
+		),
+		selectorMapVersion: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "vk_selector_map_version",
+				Help: "Version of the loaded selector map by source",
+			},
+			[]string{"source"},
+		),
 	}
 }
 
@@ -124,6 +217,14 @@ func (m *metricsCollector) UpdateBrowserPoolSize(size int) {
 	m.browserPoolSize.Set(float64(size))
 }
 
+func (m *metricsCollector) UpdateBrowserPoolUtilization(inUse, target int) {
+	m.browserPoolInUse.Set(float64(inUse))
+	m.browserPoolTarget.Set(float64(target))
+	if target > 0 {
+		m.browserPoolUtilization.Set(float64(inUse) / float64(target))
+	}
+}
+
 func (m *metricsCollector) IncrementErrorsTotal(errorType string) {
 	m.errorsTotal.WithLabelValues(errorType).Inc()
 }
@@ -132,6 +233,38 @@ func (m *metricsCollector) IncrementManualInterventions() {
 	m.manualInterventionsTotal.Inc()
 }
 
+func (m *metricsCollector) IncrementProfileSteps(step, result string) {
+	m.profileStepsTotal.WithLabelValues(step, result).Inc()
+}
+
+func (m *metricsCollector) IncrementAppeals(result string) {
+	m.appealsTotal.WithLabelValues(result).Inc()
+}
+
+func (m *metricsCollector) IncrementEmailBindings(result string) {
+	m.emailBindingsTotal.WithLabelValues(result).Inc()
+}
+
+func (m *metricsCollector) SetRegistrationQueueDepth(depth int) {
+	m.registrationQueueDepth.Set(float64(depth))
+}
+
+func (m *metricsCollector) IncrementAdmissionWaits(resource string) {
+	m.admissionWaitsTotal.WithLabelValues(resource).Inc()
+}
+
+func (m *metricsCollector) IncrementSelectorMatches(key, variant string) {
+	m.selectorMatchesTotal.WithLabelValues(key, variant).Inc()
+}
+
+func (m *metricsCollector) IncrementSelectorMisses(key string) {
+	m.selectorMissesTotal.WithLabelValues(key).Inc()
+}
+
+func (m *metricsCollector) SetSelectorVersion(source string, version int) {
+	m.selectorMapVersion.WithLabelValues(source).Set(float64(version))
+}
+
 func (m *metricsCollector) GetTotalAccounts() int64 {
 	return m.totalAccountsCache
 }
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+)
+
+const personaPlatform = "vk"
+
+// applyRandomProfile reserves a persona for the registration, reusing one already registered
+// on other platforms when possible. Falls back to local generation so registrations keep
+// working while the persona service is down.
+func (s *vkService) applyRandomProfile(ctx context.Context, request *models.RegistrationRequest) {
+	persona, err := s.personaClient.AllocatePersona(ctx, &personapb.AllocatePersonaRequest{
+		Platform:      personaPlatform,
+		Gender:        string(request.Gender),
+		ReuseExisting: true,
+	})
+	if err != nil {
+		s.logger.Warn("Persona service unavailable, generating profile locally", "error", err)
+
+		profile := NewFingerprintGenerator().GenerateRandomProfile()
+		request.FirstName = profile.FirstName
+		request.LastName = profile.LastName
+		request.BirthDate = profile.BirthDate
+		request.Gender = models.Gender(profile.Gender)
+		return
+	}
+
+	s.fillFromPersona(request, persona)
+}
+
+// applyPersona reserves an existing persona, e.g. one shared with a linked account
+func (s *vkService) applyPersona(ctx context.Context, request *models.RegistrationRequest) error {
+	persona, err := s.personaClient.AllocatePersona(ctx, &personapb.AllocatePersonaRequest{
+		Platform:  personaPlatform,
+		PersonaId: request.PersonaID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reserve persona %s: %w", request.PersonaID, err)
+	}
+
+	s.fillFromPersona(request, persona)
+	return nil
+}
+
+func (s *vkService) fillFromPersona(request *models.RegistrationRequest, persona *personapb.Persona) {
+	request.PersonaID = persona.Id
+	request.PersonaReservationID = persona.ReservationId
+	request.FirstName = persona.FirstName
+	request.LastName = persona.LastName
+	request.Gender = models.Gender(persona.Gender)
+	request.PersonaProfile = &models.PersonaProfile{
+		City:      persona.City,
+		Interests: persona.Interests,
+		Bio:       persona.Bio,
+		Avatars:   persona.Avatars,
+	}
+
+	if birthDate, err := time.Parse("2006-01-02", persona.BirthDate); err == nil {
+		request.BirthDate = birthDate
+	}
+}
+
+// confirmPersona turns the reservation into an assignment so the identity is not reused on VK
+func (s *vkService) confirmPersona(ctx context.Context, account *models.VKAccount, reservationID string) {
+	if account.PersonaID == "" || reservationID == "" {
+		return
+	}
+
+	if _, err := s.personaClient.ConfirmReservation(ctx, &personapb.ConfirmReservationRequest{
+		PersonaId:     account.PersonaID,
+		ReservationId: reservationID,
+		AccountId:     account.ID.Hex(),
+	}); err != nil {
+		s.logger.Warn("Failed to confirm persona reservation", "error", err, "account_id", account.ID, "persona_id", account.PersonaID)
+	}
+}
+
+// cancelPersona frees the persona of a registration that did not produce an account
+func (s *vkService) cancelPersona(ctx context.Context, request *models.RegistrationRequest) {
+	if request.PersonaID == "" || request.PersonaReservationID == "" {
+		return
+	}
+
+	if _, err := s.personaClient.CancelReservation(ctx, &personapb.CancelReservationRequest{
+		PersonaId:     request.PersonaID,
+		ReservationId: request.PersonaReservationID,
+	}); err != nil {
+		s.logger.Warn("Failed to cancel persona reservation", "error", err, "persona_id", request.PersonaID)
+	}
+}
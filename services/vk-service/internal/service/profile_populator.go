@@ -0,0 +1,535 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+	"github.com/grigta/conveer/services/vk-service/internal/repository"
+
+	"github.com/playwright-community/playwright-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProfilePopulator interface {
+	PopulateProfile(ctx context.Context, accountID primitive.ObjectID, opts *models.ProfilePopulationOptions) (*models.ProfilePopulationResult, error)
+}
+
+type profilePopulator struct {
+	accountRepo     repository.AccountRepository
+	browserManager  BrowserManager
+	stealthInjector StealthInjector
+	proxyClient     proxypb.ProxyServiceClient
+	config          *models.ProfileConfig
+	metrics         MetricsCollector
+	logger          logger.Logger
+	rand            *rand.Rand
+}
+
+func NewProfilePopulator(
+	accountRepo repository.AccountRepository,
+	browserManager BrowserManager,
+	stealthInjector StealthInjector,
+	proxyClient proxypb.ProxyServiceClient,
+	config *models.ProfileConfig,
+	metrics MetricsCollector,
+	logger logger.Logger,
+) ProfilePopulator {
+	return &profilePopulator{
+		accountRepo:     accountRepo,
+		browserManager:  browserManager,
+		stealthInjector: stealthInjector,
+		proxyClient:     proxyClient,
+		config:          config,
+		metrics:         metrics,
+		logger:          logger,
+		rand:            rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// cityPersona ties a city to universities a resident would plausibly have attended
+type cityPersona struct {
+	City         string
+	Universities []string
+}
+
+// personaCities maps fingerprint timezones to cities so the profile matches the browser persona
+var personaCities = map[string][]cityPersona{
+	"Europe/Moscow": {
+		{City: "Москва", Universities: []string{"МГУ им. М. В. Ломоносова", "МГТУ им. Н. Э. Баумана", "РЭУ им. Г. В. Плеханова", "НИУ ВШЭ"}},
+		{City: "Санкт-Петербург", Universities: []string{"СПбГУ", "СПбПУ Петра Великого", "Университет ИТМО"}},
+		{City: "Казань", Universities: []string{"КФУ", "КНИТУ-КАИ"}},
+		{City: "Нижний Новгород", Universities: []string{"ННГУ им. Н. И. Лобачевского"}},
+		{City: "Воронеж", Universities: []string{"ВГУ"}},
+	},
+	"Europe/Kiev": {
+		{City: "Киев", Universities: []string{"КНУ им. Т. Шевченко", "КПИ им. И. Сикорского"}},
+		{City: "Харьков", Universities: []string{"ХНУ им. В. Н. Каразина"}},
+	},
+	"Europe/Minsk": {
+		{City: "Минск", Universities: []string{"БГУ", "БНТУ"}},
+	},
+	"Asia/Yekaterinburg": {
+		{City: "Екатеринбург", Universities: []string{"УрФУ"}},
+		{City: "Челябинск", Universities: []string{"ЮУрГУ"}},
+		{City: "Пермь", Universities: []string{"ПГНИУ"}},
+	},
+	"Asia/Novosibirsk": {
+		{City: "Новосибирск", Universities: []string{"НГУ", "НГТУ"}},
+		{City: "Омск", Universities: []string{"ОмГТУ"}},
+	},
+	"Europe/Samara": {
+		{City: "Самара", Universities: []string{"Самарский университет"}},
+		{City: "Ижевск", Universities: []string{"УдГУ"}},
+	},
+	"Asia/Krasnoyarsk": {
+		{City: "Красноярск", Universities: []string{"СФУ"}},
+	},
+}
+
+var profileFaculties = []string{
+	"Экономический факультет",
+	"Юридический факультет",
+	"Факультет журналистики",
+	"Факультет информатики",
+	"Исторический факультет",
+	"Факультет иностранных языков",
+	"Факультет менеджмента",
+}
+
+var profileStatuses = []string{
+	"Всё будет хорошо",
+	"Жизнь прекрасна",
+	"В поиске вдохновения",
+	"Меньше слов — больше дела",
+	"Кофе и хорошее настроение",
+	"Живу моментом",
+	"Путешествую при любой возможности",
+	"",
+}
+
+var profileInterests = map[models.Gender][]string{
+	models.GenderMale:   {"футбол", "автомобили", "рыбалка", "компьютерные игры", "технологии", "хоккей", "спортзал"},
+	models.GenderFemale: {"йога", "кулинария", "мода", "фотография", "танцы", "книги", "рукоделие"},
+}
+
+var commonInterests = []string{"музыка", "путешествия", "кино", "природа", "сериалы", "психология"}
+
+var avatarExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true}
+
+func (p *profilePopulator) PopulateProfile(ctx context.Context, accountID primitive.ObjectID, opts *models.ProfilePopulationOptions) (*models.ProfilePopulationResult, error) {
+	startTime := time.Now()
+	if opts == nil {
+		opts = &models.ProfilePopulationOptions{}
+	}
+
+	account, err := p.accountRepo.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	switch account.Status {
+	case models.StatusCreated, models.StatusWarming, models.StatusReady:
+	default:
+		return nil, fmt.Errorf("account is not registered (status: %s)", account.Status)
+	}
+	if len(account.Cookies) == 0 {
+		return nil, fmt.Errorf("account has no session cookies")
+	}
+
+	profile := p.generateProfile(account, opts)
+	result := &models.ProfilePopulationResult{
+		AccountID:   accountID.Hex(),
+		Profile:     profile,
+		FailedSteps: make(map[string]string),
+	}
+
+	browser, browserCtx, err := openAccountSession(ctx, p.browserManager, p.proxyClient, account, p.logger)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAccountSession(p.browserManager, browser, browserCtx, p.logger)
+
+	page, err := browserCtx.NewPage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page: %w", err)
+	}
+	if err := p.stealthInjector.InjectStealth(page); err != nil {
+		p.logger.Warn("Failed to inject stealth scripts", "error", err)
+	}
+
+	if err := p.openProfilePage(page, account); err != nil {
+		return nil, err
+	}
+
+	steps := []struct {
+		step models.ProfileStep
+		run  func() error
+	}{
+		{models.ProfileStepAvatar, func() error { return p.uploadAvatar(page, account, profile) }},
+		{models.ProfileStepStatus, func() error { return p.setStatus(page, profile.Status) }},
+		{models.ProfileStepCity, func() error { return p.setCity(page, profile.City) }},
+		{models.ProfileStepEducation, func() error { return p.setEducation(page, profile.Education) }},
+		{models.ProfileStepInterests, func() error { return p.setInterests(page, profile.Interests) }},
+	}
+
+	for _, s := range steps {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if s.step == models.ProfileStepAvatar && opts.SkipAvatar {
+			continue
+		}
+		if s.step == models.ProfileStepStatus && profile.Status == "" {
+			continue
+		}
+		if s.step == models.ProfileStepEducation && profile.Education == nil {
+			continue
+		}
+
+		if err := s.run(); err != nil {
+			// A broken step shouldn't throw away the rest of the profile
+			p.logger.Warn("Profile step failed", "error", err, "account_id", accountID, "step", s.step)
+			result.FailedSteps[string(s.step)] = err.Error()
+			p.metrics.IncrementProfileSteps(string(s.step), "failed")
+		} else {
+			result.CompletedSteps = append(result.CompletedSteps, s.step)
+			p.metrics.IncrementProfileSteps(string(s.step), "success")
+		}
+
+		time.Sleep(p.stealthInjector.RandomDelay(p.config.ActionDelayMin, p.config.ActionDelayMax))
+	}
+
+	update := bson.M{"profile": profile}
+	if len(result.CompletedSteps) > 0 {
+		update["profile_filled_at"] = time.Now()
+	}
+	if err := p.accountRepo.UpdateAccount(ctx, accountID, update); err != nil {
+		p.logger.Error("Failed to save profile data", "error", err, "account_id", accountID)
+	}
+
+	result.Duration = time.Since(startTime).Seconds()
+	p.logger.Info("Profile population finished",
+		"account_id", accountID,
+		"completed", len(result.CompletedSteps),
+		"failed", len(result.FailedSteps))
+
+	return result, nil
+}
+
+// generateProfile builds profile content consistent with the account's fingerprint timezone, gender and age
+func (p *profilePopulator) generateProfile(account *models.VKAccount, opts *models.ProfilePopulationOptions) *models.ProfileData {
+	profile := &models.ProfileData{
+		FirstName: account.FirstName,
+		LastName:  account.LastName,
+		Gender:    models.Gender(account.Gender),
+	}
+
+	age := 25
+	if account.BirthDate != nil {
+		profile.BirthDate = *account.BirthDate
+		age = int(time.Since(*account.BirthDate).Hours() / 24 / 365)
+	}
+
+	timezone, _ := account.Fingerprint["timezone"].(string)
+	cities, ok := personaCities[timezone]
+	if !ok {
+		cities = personaCities["Europe/Moscow"]
+	}
+	persona := cities[p.rand.Intn(len(cities))]
+	profile.City = persona.City
+
+	// Only adults get a university; the graduation year follows from the birth year
+	if age >= 18 && len(persona.Universities) > 0 {
+		graduationYear := time.Now().Year() - age + 22
+		if account.BirthDate != nil {
+			graduationYear = account.BirthDate.Year() + 22
+		}
+		profile.Education = &models.Education{
+			University:     persona.Universities[p.rand.Intn(len(persona.Universities))],
+			Faculty:        profileFaculties[p.rand.Intn(len(profileFaculties))],
+			GraduationYear: graduationYear,
+		}
+	}
+
+	profile.Status = profileStatuses[p.rand.Intn(len(profileStatuses))]
+	profile.Interests = p.pickInterests(profile.Gender)
+
+	// The persona keeps the profile the same as on the account's other platforms
+	if persona := account.PersonaProfile; persona != nil {
+		if persona.City != "" {
+			profile.City = persona.City
+		}
+		if persona.Bio != "" {
+			profile.Status = persona.Bio
+		}
+		if len(persona.Interests) > 0 {
+			profile.Interests = persona.Interests
+		}
+	}
+
+	if opts.City != "" {
+		profile.City = opts.City
+	}
+	if opts.Status != "" {
+		profile.Status = opts.Status
+	}
+	if len(opts.Interests) > 0 {
+		profile.Interests = opts.Interests
+	}
+
+	return profile
+}
+
+func (p *profilePopulator) pickInterests(gender models.Gender) []string {
+	pool := append([]string{}, commonInterests...)
+	pool = append(pool, profileInterests[gender]...)
+	p.rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+	count := 3 + p.rand.Intn(3)
+	if count > len(pool) {
+		count = len(pool)
+	}
+	return pool[:count]
+}
+
+// personaAvatar returns the first persona photo present in the local avatar pool
+func (p *profilePopulator) personaAvatar(account *models.VKAccount) (string, bool) {
+	if account.PersonaProfile == nil || p.config.AvatarPoolDir == "" {
+		return "", false
+	}
+
+	for _, avatar := range account.PersonaProfile.Avatars {
+		if strings.Contains(avatar, "://") {
+			continue
+		}
+		path := filepath.Join(p.config.AvatarPoolDir, filepath.Clean("/"+avatar))
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// pickAvatar chooses a random image from the pool, preferring the gender subdirectory if it exists
+func (p *profilePopulator) pickAvatar(gender string) (string, error) {
+	if p.config.AvatarPoolDir == "" {
+		return "", fmt.Errorf("avatar pool is not configured")
+	}
+
+	dirs := []string{p.config.AvatarPoolDir}
+	if gender != "" {
+		dirs = append([]string{filepath.Join(p.config.AvatarPoolDir, gender)}, dirs...)
+	}
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		var images []string
+		for _, entry := range entries {
+			if !entry.IsDir() && avatarExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+				images = append(images, filepath.Join(dir, entry.Name()))
+			}
+		}
+		if len(images) > 0 {
+			return images[p.rand.Intn(len(images))], nil
+		}
+	}
+
+	return "", fmt.Errorf("no images found in avatar pool %s", p.config.AvatarPoolDir)
+}
+
+func (p *profilePopulator) openProfilePage(page playwright.Page, account *models.VKAccount) error {
+	url := "https://vk.com/feed"
+	if account.UserID != "" {
+		url = "https://vk.com/id" + account.UserID
+	}
+
+	if _, err := page.Goto(url, playwright.PageGotoOptions{
+		WaitUntil: playwright.WaitUntilStateNetworkidle,
+		Timeout:   playwright.Float(30000),
+	}); err != nil {
+		return fmt.Errorf("failed to open profile page: %w", err)
+	}
+
+	// The left menu profile link is only rendered for logged in users
+	if count, _ := page.Locator("#l_pr").Count(); count == 0 {
+		return fmt.Errorf("session cookies are no longer valid")
+	}
+
+	p.stealthInjector.EmulateHumanBehavior(page)
+	return nil
+}
+
+func (p *profilePopulator) uploadAvatar(page playwright.Page, account *models.VKAccount, profile *models.ProfileData) error {
+	avatar, ok := p.personaAvatar(account)
+	if !ok {
+		var err error
+		if avatar, err = p.pickAvatar(account.Gender); err != nil {
+			return err
+		}
+	}
+
+	trigger := page.Locator(".ProfileHeader__avatar, #owner_photo_wrap, .page_avatar_wrap").First()
+	if err := trigger.Click(); err != nil {
+		return fmt.Errorf("avatar block not found: %w", err)
+	}
+	time.Sleep(p.stealthInjector.RandomDelay(1000, 2000))
+
+	if err := page.Locator("input[type='file']").First().SetInputFiles(avatar); err != nil {
+		return fmt.Errorf("failed to select avatar file: %w", err)
+	}
+	time.Sleep(5 * time.Second)
+
+	// VK asks to confirm the crop for the photo and then for the thumbnail
+	saveBtn := page.Locator("button:has-text('Сохранить и продолжить'), button:has-text('Сохранить')")
+	for i := 0; i < 2; i++ {
+		if count, _ := saveBtn.Count(); count > 0 {
+			saveBtn.First().Click()
+			time.Sleep(p.stealthInjector.RandomDelay(2000, 3000))
+		}
+	}
+
+	if src, err := page.Locator(".ProfileHeader__avatar img, .page_avatar_img").First().GetAttribute("src"); err == nil {
+		profile.AvatarURL = src
+	}
+
+	return nil
+}
+
+func (p *profilePopulator) setStatus(page playwright.Page, status string) error {
+	trigger := page.Locator(".ProfileInfo__status, #page_current_info, .profile_status_text").First()
+	if err := trigger.Click(); err != nil {
+		return fmt.Errorf("status field not found: %w", err)
+	}
+	time.Sleep(p.stealthInjector.RandomDelay(500, 1000))
+
+	if err := p.typeInto(page, "#currinfo_input, .ProfileStatusEditor textarea, [contenteditable='true']", status); err != nil {
+		return err
+	}
+
+	return p.save(page)
+}
+
+func (p *profilePopulator) setCity(page playwright.Page, city string) error {
+	if err := p.openEditPage(page, "contacts"); err != nil {
+		return err
+	}
+
+	if err := p.typeInto(page, "#pedit_city, input[name='city']", city); err != nil {
+		return err
+	}
+	if err := p.pickSuggestion(page, city); err != nil {
+		return err
+	}
+
+	return p.save(page)
+}
+
+func (p *profilePopulator) setEducation(page playwright.Page, education *models.Education) error {
+	if err := p.openEditPage(page, "education"); err != nil {
+		return err
+	}
+
+	if err := p.typeInto(page, "#univ_uni, input[name='university']", education.University); err != nil {
+		return err
+	}
+	if err := p.pickSuggestion(page, education.University); err != nil {
+		return err
+	}
+
+	if education.Faculty != "" {
+		if err := p.typeInto(page, "#univ_fac, input[name='faculty']", education.Faculty); err != nil {
+			p.logger.Warn("Failed to fill faculty", "error", err)
+		} else {
+			p.pickSuggestion(page, education.Faculty)
+		}
+	}
+
+	if education.GraduationYear > 0 {
+		year := fmt.Sprintf("%d", education.GraduationYear)
+		if _, err := page.Locator("#univ_grad, select[name='graduation']").First().SelectOption(playwright.SelectOptionValues{
+			Values: &[]string{year},
+		}); err != nil {
+			p.logger.Warn("Failed to select graduation year", "error", err, "year", year)
+		}
+	}
+
+	return p.save(page)
+}
+
+func (p *profilePopulator) setInterests(page playwright.Page, interests []string) error {
+	if len(interests) == 0 {
+		return nil
+	}
+	if err := p.openEditPage(page, "interests"); err != nil {
+		return err
+	}
+
+	if err := p.typeInto(page, "#pedit_interests, textarea[name='interests']", strings.Join(interests, ", ")); err != nil {
+		return err
+	}
+
+	return p.save(page)
+}
+
+func (p *profilePopulator) openEditPage(page playwright.Page, act string) error {
+	if _, err := page.Goto("https://vk.com/edit?act="+act, playwright.PageGotoOptions{
+		WaitUntil: playwright.WaitUntilStateNetworkidle,
+		Timeout:   playwright.Float(30000),
+	}); err != nil {
+		return fmt.Errorf("failed to open edit page %s: %w", act, err)
+	}
+	time.Sleep(p.stealthInjector.RandomDelay(1000, 2000))
+	return nil
+}
+
+func (p *profilePopulator) typeInto(page playwright.Page, selector, text string) error {
+	input := page.Locator(selector).First()
+	if err := input.Click(); err != nil {
+		return fmt.Errorf("field %s not found: %w", selector, err)
+	}
+	if err := input.Fill(""); err != nil {
+		return fmt.Errorf("failed to clear field %s: %w", selector, err)
+	}
+	time.Sleep(p.stealthInjector.RandomDelay(300, 800))
+
+	handle, err := input.ElementHandle()
+	if err != nil {
+		return fmt.Errorf("failed to get element handle: %w", err)
+	}
+	return p.stealthInjector.TypeWithHumanSpeed(handle, text)
+}
+
+// pickSuggestion selects the autocomplete entry VK shows for city and university fields
+func (p *profilePopulator) pickSuggestion(page playwright.Page, text string) error {
+	time.Sleep(p.stealthInjector.RandomDelay(1500, 2500))
+
+	suggestion := page.Locator(fmt.Sprintf(".ui_search_suggest_item:has-text('%s'), .ListItem:has-text('%s')", text, text)).First()
+	if count, _ := suggestion.Count(); count == 0 {
+		return fmt.Errorf("no suggestion for %q", text)
+	}
+	return suggestion.Click()
+}
+
+func (p *profilePopulator) save(page playwright.Page) error {
+	time.Sleep(p.stealthInjector.RandomDelay(500, 1500))
+
+	saveBtn := page.Locator("#pedit_save, button:has-text('Сохранить')").First()
+	if err := saveBtn.Click(); err != nil {
+		return fmt.Errorf("save button not found: %w", err)
+	}
+	time.Sleep(2 * time.Second)
+	return nil
+}
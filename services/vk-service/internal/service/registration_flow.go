@@ -19,6 +19,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// proxyCountryChain lets registration fall back to neighbouring countries when the RU pool is empty
+var proxyCountryChain = []*proxypb.CountryPreference{
+	{Country: "RU"},
+	{Country: "KZ"},
+	{Country: "BY"},
+}
+
 type RegistrationFlow interface {
 	RegisterAccount(ctx context.Context, accountID primitive.ObjectID, request *models.RegistrationRequest) (*models.RegistrationResult, error)
 	RetryRegistration(ctx context.Context, accountID primitive.ObjectID) (*models.RegistrationResult, error)
@@ -35,7 +42,9 @@ type registrationFlow struct {
 	encryptor        crypto.Encryptor
 	passwordGen      crypto.PasswordGenerator
 	config           *models.RegistrationConfig
-	messagingClient  interface{ PublishToQueue(string, interface{}) error }
+	admission        AdmissionController
+	selectors        SelectorRegistry
+	messagingClient  interface{ PublishEvent(string, string, interface{}) error }
 	logger           logger.Logger
 }
 
@@ -50,7 +59,9 @@ func NewRegistrationFlow(
 	encryptor crypto.Encryptor,
 	passwordGen crypto.PasswordGenerator,
 	config *models.RegistrationConfig,
-	messagingClient interface{ PublishToQueue(string, interface{}) error },
+	admission AdmissionController,
+	selectors SelectorRegistry,
+	messagingClient interface{ PublishEvent(string, string, interface{}) error },
 	logger logger.Logger,
 ) RegistrationFlow {
 	return &registrationFlow{
@@ -64,6 +75,8 @@ func NewRegistrationFlow(
 		encryptor:        encryptor,
 		passwordGen:      passwordGen,
 		config:           config,
+		admission:        admission,
+		selectors:        selectors,
 		messagingClient:  messagingClient,
 		logger:           logger,
 	}
@@ -112,7 +125,18 @@ func (f *registrationFlow) RegisterAccount(ctx context.Context, accountID primit
 
 	// Step 2: Purchase Phone Number
 	if session.CurrentStep == models.StepPhonePurchase {
-		if err := f.purchasePhoneNumber(ctx, accountID, session, request); err != nil {
+		// The provider slot is held until SMS verification is over, that's when the number stops being in use
+		provider, releaseProvider, err := f.admission.AcquireSMSProvider(ctx)
+		if err != nil {
+			f.handleStepError(ctx, accountID, session, models.StepPhonePurchase, err)
+			result.Success = false
+			result.ErrorMessage = fmt.Sprintf("SMS provider admission failed: %v", err)
+			result.Step = string(models.StepPhonePurchase)
+			return result, nil
+		}
+		defer releaseProvider()
+
+		if err := f.purchasePhoneNumber(ctx, accountID, session, request, provider); err != nil {
 			f.handleStepError(ctx, accountID, session, models.StepPhonePurchase, err)
 			result.Success = false
 			result.ErrorMessage = fmt.Sprintf("phone purchase failed: %v", err)
@@ -123,7 +147,19 @@ func (f *registrationFlow) RegisterAccount(ctx context.Context, accountID primit
 		f.sessionRepo.UpdateSession(ctx, accountID, bson.M{"current_step": session.CurrentStep})
 	}
 
-	// Step 3-6: Browser automation
+	// Step 3-6: Browser automation, limited per proxy so VK doesn't see parallel signups from one IP
+	if !session.ProxyID.IsZero() {
+		releaseProxy, err := f.admission.AcquireProxy(ctx, session.ProxyID.Hex())
+		if err != nil {
+			f.handleStepError(ctx, accountID, session, session.CurrentStep, err)
+			result.Success = false
+			result.ErrorMessage = fmt.Sprintf("proxy admission failed: %v", err)
+			result.Step = string(session.CurrentStep)
+			return result, nil
+		}
+		defer releaseProxy()
+	}
+
 	browser, browserCtx, err := f.setupBrowser(ctx, session)
 	if err != nil {
 		f.handleStepError(ctx, accountID, session, session.CurrentStep, err)
@@ -274,17 +310,23 @@ func (f *registrationFlow) RetryRegistration(ctx context.Context, accountID prim
 func (f *registrationFlow) allocateProxy(ctx context.Context, accountID primitive.ObjectID, session *models.RegistrationSession) error {
 	// Call proxy service to allocate proxy
 	resp, err := f.proxyClient.AllocateProxy(ctx, &proxypb.AllocateProxyRequest{
-		AccountId: accountID.Hex(),
-		Type:      "mobile",
-		Country:   "RU",
+		AccountId:    accountID.Hex(),
+		Type:         "mobile",
+		Country:      "RU",
+		CountryChain: proxyCountryChain,
+		Platform:     "vk",
 	})
 	if err != nil {
 		return fmt.Errorf("failed to allocate proxy: %w", err)
 	}
 
 	// Save proxy details in session
-	session.ProxyID, _ = primitive.ObjectIDFromHex(resp.Proxy.Id)
-	session.ProxyURL = fmt.Sprintf("%s://%s:%d", resp.Proxy.Protocol, resp.Proxy.Host, resp.Proxy.Port)
+	session.ProxyID, _ = primitive.ObjectIDFromHex(resp.Id)
+	if resp.Username != "" {
+		session.ProxyURL = fmt.Sprintf("%s://%s:%s@%s:%d", resp.Protocol, resp.Username, resp.Password, resp.Ip, resp.Port)
+	} else {
+		session.ProxyURL = fmt.Sprintf("%s://%s:%d", resp.Protocol, resp.Ip, resp.Port)
+	}
 
 	// Update account with proxy ID
 	f.accountRepo.UpdateAccount(ctx, accountID, bson.M{"proxy_id": session.ProxyID})
@@ -293,7 +335,7 @@ func (f *registrationFlow) allocateProxy(ctx context.Context, accountID primitiv
 	return nil
 }
 
-func (f *registrationFlow) purchasePhoneNumber(ctx context.Context, accountID primitive.ObjectID, session *models.RegistrationSession, request *models.RegistrationRequest) error {
+func (f *registrationFlow) purchasePhoneNumber(ctx context.Context, accountID primitive.ObjectID, session *models.RegistrationSession, request *models.RegistrationRequest, provider string) error {
 	country := request.PreferredCountry
 	if country == "" {
 		country = "RU"
@@ -301,16 +343,17 @@ func (f *registrationFlow) purchasePhoneNumber(ctx context.Context, accountID pr
 
 	// Call SMS service to purchase number
 	resp, err := f.smsClient.PurchaseNumber(ctx, &smspb.PurchaseNumberRequest{
-		Service:   "vk",
-		Country:   country,
-		AccountId: accountID.Hex(),
+		Service:        "vk",
+		Country:        country,
+		Provider:       provider,
+		IdempotencyKey: "vk:registration:" + accountID.Hex(),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to purchase phone number: %w", err)
 	}
 
 	// Save phone details in session
-	session.Phone = resp.Phone
+	session.Phone = resp.PhoneNumber
 	session.ActivationID = resp.ActivationId
 
 	// Update account with phone (encrypted)
@@ -319,7 +362,7 @@ func (f *registrationFlow) purchasePhoneNumber(ctx context.Context, accountID pr
 		"activation_id": session.ActivationID,
 	})
 
-	f.logger.Info("Phone number purchased", "account_id", accountID, "phone", session.Phone[:3]+"***")
+	f.logger.Info("Phone number purchased", "account_id", accountID, "phone", maskPhone(session.Phone))
 	return nil
 }
 
@@ -382,14 +425,15 @@ func (f *registrationFlow) fillRegistrationForm(ctx context.Context, page playwr
 	f.stealthInjector.EmulateHumanBehavior(page)
 
 	// Wait for form to load
-	if err := page.WaitForSelector("#ij_form", playwright.PageWaitForSelectorOptions{
-		Timeout: playwright.Float(30000),
-	}); err != nil {
+	if _, err := f.selectors.WaitFor(page, SelectorRegistrationForm, 30*time.Second); err != nil {
 		return fmt.Errorf("registration form not found: %w", err)
 	}
 
 	// Fill first name
-	firstNameInput := page.Locator("input[name='first_name']")
+	firstNameInput, err := f.selectors.Locate(page, SelectorFirstName)
+	if err != nil {
+		return fmt.Errorf("first name input not found: %w", err)
+	}
 	if err := firstNameInput.Click(); err != nil {
 		return fmt.Errorf("failed to click first name input: %w", err)
 	}
@@ -403,7 +447,10 @@ func (f *registrationFlow) fillRegistrationForm(ctx context.Context, page playwr
 	}
 
 	// Fill last name
-	lastNameInput := page.Locator("input[name='last_name']")
+	lastNameInput, err := f.selectors.Locate(page, SelectorLastName)
+	if err != nil {
+		return fmt.Errorf("last name input not found: %w", err)
+	}
 	if err := lastNameInput.Click(); err != nil {
 		return fmt.Errorf("failed to click last name input: %w", err)
 	}
@@ -418,41 +465,48 @@ func (f *registrationFlow) fillRegistrationForm(ctx context.Context, page playwr
 
 	// Fill birth date
 	if !request.BirthDate.IsZero() {
-		daySelect := page.Locator("select[name='bday']")
-		monthSelect := page.Locator("select[name='bmonth']")
-		yearSelect := page.Locator("select[name='byear']")
-
-		daySelect.SelectOption(playwright.SelectOptionValues{
-			Values: &[]string{fmt.Sprintf("%d", request.BirthDate.Day())},
-		})
-		time.Sleep(f.stealthInjector.RandomDelay(200, 500))
-
-		monthSelect.SelectOption(playwright.SelectOptionValues{
-			Values: &[]string{fmt.Sprintf("%d", request.BirthDate.Month())},
-		})
-		time.Sleep(f.stealthInjector.RandomDelay(200, 500))
-
-		yearSelect.SelectOption(playwright.SelectOptionValues{
-			Values: &[]string{fmt.Sprintf("%d", request.BirthDate.Year())},
-		})
-		time.Sleep(f.stealthInjector.RandomDelay(200, 500))
+		birthFields := []struct {
+			key   string
+			value int
+		}{
+			{SelectorBirthDay, request.BirthDate.Day()},
+			{SelectorBirthMonth, int(request.BirthDate.Month())},
+			{SelectorBirthYear, request.BirthDate.Year()},
+		}
+		for _, field := range birthFields {
+			birthSelect, err := f.selectors.Locate(page, field.key)
+			if err != nil {
+				f.logger.Warn("Failed to select birth date", "error", err)
+				continue
+			}
+			birthSelect.SelectOption(playwright.SelectOptionValues{
+				Values: &[]string{fmt.Sprintf("%d", field.value)},
+			})
+			time.Sleep(f.stealthInjector.RandomDelay(200, 500))
+		}
 	}
 
 	// Select gender
 	if request.Gender != "" {
-		genderValue := "2" // male
+		genderKey := SelectorGenderMale
 		if request.Gender == models.GenderFemale {
-			genderValue = "1"
+			genderKey = SelectorGenderFemale
+		}
+		genderRadio, err := f.selectors.Locate(page, genderKey)
+		if err == nil {
+			err = genderRadio.Click()
 		}
-		genderRadio := page.Locator(fmt.Sprintf("input[name='sex'][value='%s']", genderValue))
-		if err := genderRadio.Click(); err != nil {
+		if err != nil {
 			f.logger.Warn("Failed to select gender", "error", err)
 		}
 		time.Sleep(f.stealthInjector.RandomDelay(200, 500))
 	}
 
 	// Fill phone number
-	phoneInput := page.Locator("input[name='phone']")
+	phoneInput, err := f.selectors.Locate(page, SelectorPhone)
+	if err != nil {
+		return fmt.Errorf("phone input not found: %w", err)
+	}
 	if err := phoneInput.Click(); err != nil {
 		return fmt.Errorf("failed to click phone input: %w", err)
 	}
@@ -467,7 +521,10 @@ func (f *registrationFlow) fillRegistrationForm(ctx context.Context, page playwr
 
 	// Click continue/get code button
 	time.Sleep(f.stealthInjector.RandomDelay(1000, 2000))
-	continueBtn := page.Locator("button[type='submit'], .FlatButton__content:has-text('Получить код')")
+	continueBtn, err := f.selectors.Locate(page, SelectorGetCodeButton)
+	if err != nil {
+		return fmt.Errorf("continue button not found: %w", err)
+	}
 	if err := continueBtn.Click(); err != nil {
 		return fmt.Errorf("failed to click continue button: %w", err)
 	}
@@ -478,9 +535,7 @@ func (f *registrationFlow) fillRegistrationForm(ctx context.Context, page playwr
 
 func (f *registrationFlow) verifySMSCode(ctx context.Context, page playwright.Page, session *models.RegistrationSession) error {
 	// Wait for SMS code input to appear
-	if err := page.WaitForSelector("input[name='code'], input[placeholder*='код']", playwright.PageWaitForSelectorOptions{
-		Timeout: playwright.Float(60000),
-	}); err != nil {
+	if _, err := f.selectors.WaitFor(page, SelectorCodeInput, 60*time.Second); err != nil {
 		return fmt.Errorf("SMS code input not found: %w", err)
 	}
 
@@ -497,6 +552,9 @@ func (f *registrationFlow) verifySMSCode(ctx context.Context, page playwright.Pa
 		})
 		if err != nil {
 			f.logger.Warn("Failed to get SMS code", "attempt", i+1, "error", err)
+			if activationEnded(ctx, f.smsClient, session.ActivationID) {
+				return fmt.Errorf("SMS activation closed")
+			}
 			time.Sleep(f.config.SMSPollingInterval)
 			continue
 		}
@@ -506,10 +564,6 @@ func (f *registrationFlow) verifySMSCode(ctx context.Context, page playwright.Pa
 			break
 		}
 
-		if resp.Status == "cancelled" {
-			return fmt.Errorf("SMS activation cancelled")
-		}
-
 		time.Sleep(f.config.SMSPollingInterval)
 	}
 
@@ -522,7 +576,10 @@ func (f *registrationFlow) verifySMSCode(ctx context.Context, page playwright.Pa
 	}
 
 	// Enter SMS code
-	codeInput := page.Locator("input[name='code'], input[placeholder*='код']").First()
+	codeInput, err := f.selectors.Locate(page, SelectorCodeInput)
+	if err != nil {
+		return fmt.Errorf("SMS code input not found: %w", err)
+	}
 	if err := codeInput.Click(); err != nil {
 		return fmt.Errorf("failed to click code input: %w", err)
 	}
@@ -538,8 +595,11 @@ func (f *registrationFlow) verifySMSCode(ctx context.Context, page playwright.Pa
 
 	// Submit code
 	time.Sleep(f.stealthInjector.RandomDelay(1000, 2000))
-	submitBtn := page.Locator("button[type='submit'], .FlatButton__content:has-text('Продолжить')")
-	if err := submitBtn.Click(); err != nil {
+	submitBtn, err := f.selectors.Locate(page, SelectorCodeSubmitButton)
+	if err == nil {
+		err = submitBtn.Click()
+	}
+	if err != nil {
 		// Try pressing Enter
 		if err := codeInput.Press("Enter"); err != nil {
 			return fmt.Errorf("failed to submit SMS code: %w", err)
@@ -552,14 +612,12 @@ func (f *registrationFlow) verifySMSCode(ctx context.Context, page playwright.Pa
 
 func (f *registrationFlow) setupProfile(ctx context.Context, page playwright.Page, session *models.RegistrationSession, password string) error {
 	// Wait for password field
-	if err := page.WaitForSelector("input[type='password'], input[name='password']", playwright.PageWaitForSelectorOptions{
-		Timeout: playwright.Float(30000),
-	}); err != nil {
+	passwordInput, err := f.selectors.WaitFor(page, SelectorPassword, 30*time.Second)
+	if err != nil {
 		return fmt.Errorf("password field not found: %w", err)
 	}
 
 	// Set password
-	passwordInput := page.Locator("input[type='password'], input[name='password']").First()
 	if err := passwordInput.Click(); err != nil {
 		return fmt.Errorf("failed to click password input: %w", err)
 	}
@@ -573,8 +631,7 @@ func (f *registrationFlow) setupProfile(ctx context.Context, page playwright.Pag
 	}
 
 	// Confirm password if needed
-	confirmInput := page.Locator("input[name='password_confirm'], input[placeholder*='Повторите']")
-	if count, _ := confirmInput.Count(); count > 0 {
+	if confirmInput, ok := f.selectors.Lookup(page, SelectorPasswordConfirm); ok {
 		if err := confirmInput.Click(); err != nil {
 			f.logger.Warn("Failed to click confirm password", "error", err)
 		}
@@ -589,8 +646,11 @@ func (f *registrationFlow) setupProfile(ctx context.Context, page playwright.Pag
 
 	// Submit password
 	time.Sleep(f.stealthInjector.RandomDelay(1000, 2000))
-	submitBtn := page.Locator("button[type='submit'], .FlatButton__content:has-text('Готово'), .FlatButton__content:has-text('Продолжить')")
-	if err := submitBtn.Click(); err != nil {
+	submitBtn, err := f.selectors.Locate(page, SelectorPasswordSubmit)
+	if err == nil {
+		err = submitBtn.Click()
+	}
+	if err != nil {
 		f.logger.Warn("Failed to click submit button", "error", err)
 		// Try pressing Enter
 		passwordInput.Press("Enter")
@@ -600,10 +660,9 @@ func (f *registrationFlow) setupProfile(ctx context.Context, page playwright.Pag
 	time.Sleep(5 * time.Second)
 
 	// Skip optional steps (photo upload, friend suggestions)
-	skipBtn := page.Locator(".FlatButton__content:has-text('Пропустить'), a:has-text('Пропустить')")
 	for i := 0; i < 3; i++ {
-		if count, _ := skipBtn.Count(); count > 0 {
-			skipBtn.First().Click()
+		if skipBtn, ok := f.selectors.Lookup(page, SelectorSkipButton); ok {
+			skipBtn.Click()
 			time.Sleep(2 * time.Second)
 		}
 	}
@@ -669,7 +728,10 @@ func (f *registrationFlow) extractUserID(page playwright.Page) string {
 	}
 
 	// Try to get from page content
-	userIDElement := page.Locator("a[href*='/id']").First()
+	userIDElement, ok := f.selectors.Lookup(page, SelectorUserIDLink)
+	if !ok {
+		return ""
+	}
 	if href, err := userIDElement.GetAttribute("href"); err == nil && href != "" {
 		if strings.Contains(href, "id") {
 			parts := strings.Split(href, "id")
@@ -735,6 +797,8 @@ func (f *registrationFlow) handleStepError(ctx context.Context, accountID primit
 	// Publish to manual intervention queue if needed
 	if requiresManualIntervention && f.messagingClient != nil {
 		message := map[string]interface{}{
+			"type":         "manual_intervention",
+			"platform":     "vk",
 			"account_id":   accountID.Hex(),
 			"reason":       interventionReason,
 			"step":         string(step),
@@ -744,7 +808,7 @@ func (f *registrationFlow) handleStepError(ctx context.Context, accountID primit
 			"timestamp":    time.Now(),
 		}
 
-		if pubErr := f.messagingClient.PublishToQueue("vk.manual_intervention", message); pubErr != nil {
+		if pubErr := f.messagingClient.PublishEvent("vk.events", "vk.manual_intervention", message); pubErr != nil {
 			f.logger.Error("Failed to publish manual intervention request",
 				"account_id", accountID,
 				"reason", interventionReason,
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// admissionPollInterval is how often a waiting registration re-checks a busy proxy or SMS provider
+const admissionPollInterval = time.Second
+
+var (
+	ErrAdmissionTimeout = errors.New("timed out waiting for admission")
+	ErrPoolClosed       = errors.New("registration pool is draining")
+)
+
+// AdmissionController limits how many registrations share a proxy or an SMS provider
+type AdmissionController interface {
+	AcquireProxy(ctx context.Context, proxyID string) (func(), error)
+	AcquireSMSProvider(ctx context.Context) (string, func(), error)
+}
+
+type admissionController struct {
+	mu                 sync.Mutex
+	proxyMaxConcurrent int
+	providerLimits     map[string]int
+	proxies            map[string]int
+	providers          map[string]int
+	timeout            time.Duration
+	metrics            MetricsCollector
+}
+
+func NewAdmissionController(config *models.RegistrationConfig, metrics MetricsCollector) AdmissionController {
+	proxyMaxConcurrent := config.ProxyMaxConcurrent
+	if proxyMaxConcurrent <= 0 {
+		proxyMaxConcurrent = 1
+	}
+
+	return &admissionController{
+		proxyMaxConcurrent: proxyMaxConcurrent,
+		providerLimits:     config.SMSProviderLimits,
+		proxies:            make(map[string]int),
+		providers:          make(map[string]int),
+		timeout:            config.AdmissionTimeout,
+		metrics:            metrics,
+	}
+}
+
+// AcquireProxy waits until the proxy has a free slot and returns the function releasing it
+func (a *admissionController) AcquireProxy(ctx context.Context, proxyID string) (func(), error) {
+	_, release, err := a.acquire(ctx, "proxy", func() (string, bool) {
+		if a.proxies[proxyID] >= a.proxyMaxConcurrent {
+			return "", false
+		}
+		a.proxies[proxyID]++
+		return proxyID, true
+	}, func(string) {
+		if a.proxies[proxyID]--; a.proxies[proxyID] <= 0 {
+			delete(a.proxies, proxyID)
+		}
+	})
+	return release, err
+}
+
+// AcquireSMSProvider picks the least loaded SMS provider with a free slot.
+// Without configured limits the choice is left to sms-service and an empty name is returned.
+func (a *admissionController) AcquireSMSProvider(ctx context.Context) (string, func(), error) {
+	if len(a.providerLimits) == 0 {
+		return "", func() {}, nil
+	}
+
+	return a.acquire(ctx, "sms_provider", func() (string, bool) {
+		best, bestLoad := "", 1.0
+		for provider, limit := range a.providerLimits {
+			load := float64(a.providers[provider]) / float64(limit)
+			if load < bestLoad || (load == bestLoad && best != "" && provider < best) {
+				best, bestLoad = provider, load
+			}
+		}
+		if best == "" {
+			return "", false
+		}
+		a.providers[best]++
+		return best, true
+	}, func(provider string) {
+		a.providers[provider]--
+	})
+}
+
+// acquire polls take until it grants a slot, the admission timeout passes or ctx is done
+func (a *admissionController) acquire(ctx context.Context, resource string, take func() (string, bool), give func(string)) (string, func(), error) {
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+
+	waited := false
+	for {
+		a.mu.Lock()
+		key, ok := take()
+		a.mu.Unlock()
+
+		if ok {
+			var once sync.Once
+			return key, func() {
+				once.Do(func() {
+					a.mu.Lock()
+					give(key)
+					a.mu.Unlock()
+				})
+			}, nil
+		}
+
+		if !waited {
+			waited = true
+			a.metrics.IncrementAdmissionWaits(resource)
+		}
+
+		timer := time.NewTimer(admissionPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", nil, fmt.Errorf("%w for %s: %v", ErrAdmissionTimeout, resource, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+type registrationJob struct {
+	accountID primitive.ObjectID
+	request   models.RegistrationRequest
+}
+
+// registrationPool runs registration commands on a bounded number of workers.
+// Submit blocks while the queue is full, which keeps the rest of the commands in RabbitMQ.
+type registrationPool struct {
+	mu          sync.RWMutex
+	closed      bool
+	jobs        chan registrationJob
+	concurrency int
+	pending     int64
+	wg          sync.WaitGroup
+	metrics     MetricsCollector
+}
+
+func newRegistrationPool(concurrency, queueSize int, metrics MetricsCollector) *registrationPool {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	return &registrationPool{
+		jobs:        make(chan registrationJob, queueSize),
+		concurrency: concurrency,
+		metrics:     metrics,
+	}
+}
+
+func (p *registrationPool) Start(process func(registrationJob)) {
+	for i := 0; i < p.concurrency; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for job := range p.jobs {
+				p.updateDepth(-1)
+				process(job)
+			}
+		}()
+	}
+}
+
+// Submit queues the job, waiting for room until ctx is done
+func (p *registrationPool) Submit(ctx context.Context, job registrationJob) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	p.updateDepth(1)
+	select {
+	case p.jobs <- job:
+		return nil
+	case <-ctx.Done():
+		p.updateDepth(-1)
+		return ctx.Err()
+	}
+}
+
+// Drain stops accepting jobs and waits until queued and running ones finish or ctx is done.
+// Submit callers must be unblocked by cancelling their context first.
+func (p *registrationPool) Drain(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *registrationPool) updateDepth(delta int64) {
+	p.metrics.SetRegistrationQueueDepth(int(atomic.AddInt64(&p.pending, delta)))
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/vk-service/internal/models"
+	"github.com/grigta/conveer/services/vk-service/internal/repository"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Selector keys used by the registration flow
+const (
+	SelectorRegistrationForm = "registration_form"
+	SelectorFirstName        = "first_name"
+	SelectorLastName         = "last_name"
+	SelectorBirthDay         = "birth_day"
+	SelectorBirthMonth       = "birth_month"
+	SelectorBirthYear        = "birth_year"
+	SelectorGenderMale       = "gender_male"
+	SelectorGenderFemale     = "gender_female"
+	SelectorPhone            = "phone"
+	SelectorGetCodeButton    = "get_code_button"
+	SelectorCodeInput        = "code_input"
+	SelectorCodeSubmitButton = "code_submit_button"
+	SelectorPassword         = "password"
+	SelectorPasswordConfirm  = "password_confirm"
+	SelectorPasswordSubmit   = "password_submit_button"
+	SelectorSkipButton       = "skip_button"
+	SelectorUserIDLink       = "user_id_link"
+)
+
+var (
+	ErrSelectorNotFound = errors.New("no selector variant matched")
+	ErrUnknownSelector  = errors.New("unknown selector key")
+	ErrInvalidSelectors = errors.New("invalid selector map")
+	ErrSelectorVersion  = errors.New("selectors version not found")
+)
+
+// defaultSelectors are used for keys missing from both the file and the remote override
+var defaultSelectors = map[string][]string{
+	SelectorRegistrationForm: {"#ij_form"},
+	SelectorFirstName:        {"input[name='first_name']"},
+	SelectorLastName:         {"input[name='last_name']"},
+	SelectorBirthDay:         {"select[name='bday']"},
+	SelectorBirthMonth:       {"select[name='bmonth']"},
+	SelectorBirthYear:        {"select[name='byear']"},
+	SelectorGenderMale:       {"input[name='sex'][value='2']"},
+	SelectorGenderFemale:     {"input[name='sex'][value='1']"},
+	SelectorPhone:            {"input[name='phone']"},
+	SelectorGetCodeButton:    {"button[type='submit']", ".FlatButton__content:has-text('Получить код')"},
+	SelectorCodeInput:        {"input[name='code']", "input[placeholder*='код']"},
+	SelectorCodeSubmitButton: {"button[type='submit']", ".FlatButton__content:has-text('Продолжить')"},
+	SelectorPassword:         {"input[type='password']", "input[name='password']"},
+	SelectorPasswordConfirm:  {"input[name='password_confirm']", "input[placeholder*='Повторите']"},
+	SelectorPasswordSubmit:   {"button[type='submit']", ".FlatButton__content:has-text('Готово')", ".FlatButton__content:has-text('Продолжить')"},
+	SelectorSkipButton:       {".FlatButton__content:has-text('Пропустить')", "a:has-text('Пропустить')"},
+	SelectorUserIDLink:       {"a[href*='/id']"},
+}
+
+// SelectorRegistry resolves page elements through versioned selector maps.
+// Precedence per key: remote override from Mongo, then the YAML file, then the built-in defaults.
+type SelectorRegistry interface {
+	Variants(key string) []string
+	Locate(page playwright.Page, key string) (playwright.Locator, error)
+	Lookup(page playwright.Page, key string) (playwright.Locator, bool)
+	WaitFor(page playwright.Page, key string, timeout time.Duration) (playwright.Locator, error)
+	ApplyFile(selectors *models.SelectorMap) error
+	Refresh(ctx context.Context) error
+	Start(ctx context.Context, interval time.Duration)
+	Snapshot() *models.SelectorSnapshot
+	Publish(ctx context.Context, selectors map[string][]string, comment string) (*models.SelectorMap, error)
+	Rollback(ctx context.Context, version int) (*models.SelectorMap, error)
+	ListVersions(ctx context.Context, limit int64) ([]*models.SelectorMap, error)
+}
+
+type selectorRegistry struct {
+	repo    repository.SelectorRepository
+	metrics MetricsCollector
+	logger  logger.Logger
+
+	mu      sync.RWMutex
+	file    *models.SelectorMap
+	remote  *models.SelectorMap
+	publish sync.Mutex
+}
+
+func NewSelectorRegistry(repo repository.SelectorRepository, metrics MetricsCollector, logger logger.Logger) SelectorRegistry {
+	return &selectorRegistry{
+		repo:    repo,
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+func (r *selectorRegistry) Variants(key string) []string {
+	variants, _ := r.resolve(key)
+	return variants
+}
+
+func (r *selectorRegistry) resolve(key string) ([]string, string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.remote != nil {
+		if variants := r.remote.Selectors[key]; len(variants) > 0 {
+			return variants, models.SelectorSourceRemote
+		}
+	}
+	if r.file != nil {
+		if variants := r.file.Selectors[key]; len(variants) > 0 {
+			return variants, models.SelectorSourceFile
+		}
+	}
+	return defaultSelectors[key], models.SelectorSourceDefault
+}
+
+// Locate returns the first element matched by the first present variant and counts the miss otherwise
+func (r *selectorRegistry) Locate(page playwright.Page, key string) (playwright.Locator, error) {
+	if locator, ok := r.Lookup(page, key); ok {
+		return locator, nil
+	}
+
+	r.metrics.IncrementSelectorMisses(key)
+	r.logger.Warn("No selector variant matched", "key", key, "variants", r.Variants(key))
+	return nil, fmt.Errorf("%w: %s", ErrSelectorNotFound, key)
+}
+
+// Lookup is Locate for optional elements, an absent element is not counted as a miss
+func (r *selectorRegistry) Lookup(page playwright.Page, key string) (playwright.Locator, bool) {
+	for _, variant := range r.Variants(key) {
+		locator := page.Locator(variant)
+		if count, err := locator.Count(); err != nil || count == 0 {
+			continue
+		}
+
+		r.metrics.IncrementSelectorMatches(key, variant)
+		return locator.First(), true
+	}
+	return nil, false
+}
+
+// WaitFor waits until any variant appears, then resolves which one matched
+func (r *selectorRegistry) WaitFor(page playwright.Page, key string, timeout time.Duration) (playwright.Locator, error) {
+	variants := r.Variants(key)
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSelector, key)
+	}
+
+	err := page.Locator(strings.Join(variants, ", ")).First().WaitFor(playwright.LocatorWaitForOptions{
+		Timeout: playwright.Float(float64(timeout.Milliseconds())),
+	})
+	if err != nil {
+		r.metrics.IncrementSelectorMisses(key)
+		return nil, fmt.Errorf("%w: %s: %v", ErrSelectorNotFound, key, err)
+	}
+
+	return r.Locate(page, key)
+}
+
+// ApplyFile replaces the selectors loaded from the YAML file
+func (r *selectorRegistry) ApplyFile(selectors *models.SelectorMap) error {
+	if err := validateSelectors(selectors.Selectors); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.file = selectors
+	r.mu.Unlock()
+
+	r.metrics.SetSelectorVersion(models.SelectorSourceFile, selectors.Version)
+	r.logger.Info("Selector map loaded from file", "version", selectors.Version, "keys", len(selectors.Selectors))
+	return nil
+}
+
+// Refresh picks up the latest remote override, published by another replica or rolled back
+func (r *selectorRegistry) Refresh(ctx context.Context) error {
+	latest, err := r.repo.GetLatest(ctx)
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	changed := r.remote == nil || r.remote.Version != latest.Version
+	r.remote = latest
+	r.mu.Unlock()
+
+	if changed {
+		r.metrics.SetSelectorVersion(models.SelectorSourceRemote, latest.Version)
+		r.logger.Info("Remote selector override applied", "version", latest.Version, "keys", len(latest.Selectors))
+	}
+	return nil
+}
+
+// Start polls the remote override until ctx is done
+func (r *selectorRegistry) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	if err := r.Refresh(ctx); err != nil {
+		r.logger.Error("Failed to load remote selectors", "error", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				r.logger.Error("Failed to refresh remote selectors", "error", err)
+			}
+		}
+	}
+}
+
+func (r *selectorRegistry) Snapshot() *models.SelectorSnapshot {
+	snapshot := &models.SelectorSnapshot{
+		Selectors: make(map[string]models.ResolvedSelector, len(defaultSelectors)),
+	}
+
+	r.mu.RLock()
+	if r.file != nil {
+		snapshot.FileVersion = r.file.Version
+	}
+	if r.remote != nil {
+		snapshot.RemoteVersion = r.remote.Version
+	}
+	r.mu.RUnlock()
+
+	for key := range defaultSelectors {
+		variants, source := r.resolve(key)
+		snapshot.Selectors[key] = models.ResolvedSelector{Variants: variants, Source: source}
+	}
+	return snapshot
+}
+
+// Publish stores a new remote override version and applies it right away.
+// An empty map clears the override so the file selectors are used again.
+func (r *selectorRegistry) Publish(ctx context.Context, selectors map[string][]string, comment string) (*models.SelectorMap, error) {
+	if err := validateSelectors(selectors); err != nil {
+		return nil, err
+	}
+
+	r.publish.Lock()
+	defer r.publish.Unlock()
+
+	latest, err := r.repo.GetLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version := 1
+	if latest != nil {
+		version = latest.Version + 1
+	}
+
+	selectorMap := &models.SelectorMap{
+		Version:   version,
+		Selectors: selectors,
+		Comment:   comment,
+	}
+	if err := r.repo.Create(ctx, selectorMap); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.remote = selectorMap
+	r.mu.Unlock()
+
+	r.metrics.SetSelectorVersion(models.SelectorSourceRemote, version)
+	r.logger.Info("Selector override published", "version", version, "keys", len(selectors))
+	return selectorMap, nil
+}
+
+// Rollback republishes an older version, the history stays append-only
+func (r *selectorRegistry) Rollback(ctx context.Context, version int) (*models.SelectorMap, error) {
+	target, err := r.repo.GetByVersion(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("%w: %d", ErrSelectorVersion, version)
+	}
+
+	return r.Publish(ctx, target.Selectors, fmt.Sprintf("rollback to version %d", version))
+}
+
+func (r *selectorRegistry) ListVersions(ctx context.Context, limit int64) ([]*models.SelectorMap, error) {
+	return r.repo.ListVersions(ctx, limit)
+}
+
+func validateSelectors(selectors map[string][]string) error {
+	keys := make([]string, 0, len(selectors))
+	for key := range selectors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, ok := defaultSelectors[key]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownSelector, key)
+		}
+		if len(selectors[key]) == 0 {
+			return fmt.Errorf("%w: %s has no variants", ErrInvalidSelectors, key)
+		}
+		for _, variant := range selectors[key] {
+			if strings.TrimSpace(variant) == "" {
+				return fmt.Errorf("%w: %s has an empty variant", ErrInvalidSelectors, key)
+			}
+		}
+	}
+	return nil
+}
@@ -2,16 +2,19 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
 	"github.com/grigta/conveer/services/vk-service/internal/models"
 	"github.com/grigta/conveer/services/vk-service/internal/repository"
+	personapb "github.com/grigta/conveer/services/persona-service/proto"
 	proxypb "github.com/grigta/conveer/services/proxy-service/proto"
 
-	"github.com/streadway/amqp"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -24,7 +27,15 @@ type VKService interface {
 	RetryRegistration(ctx context.Context, accountID primitive.ObjectID) error
 	DeleteAccount(ctx context.Context, id primitive.ObjectID) error
 	GetStatistics(ctx context.Context) (*models.AccountStatistics, error)
+	PopulateProfile(ctx context.Context, accountID primitive.ObjectID, opts *models.ProfilePopulationOptions) (*models.ProfilePopulationResult, error)
+	BindEmail(ctx context.Context, accountID primitive.ObjectID) (*models.EmailBindingResult, error)
 	PublishManualInterventionRequired(ctx context.Context, accountID primitive.ObjectID, reason string, details map[string]interface{}) error
+	AppealBan(ctx context.Context, accountID primitive.ObjectID) (*models.Appeal, error)
+	GetAppeal(ctx context.Context, accountID primitive.ObjectID) (*models.Appeal, error)
+	CreateAccountsBatch(ctx context.Context, count int, opts models.BatchOptions) (*models.RegistrationBatch, error)
+	GetBatchStatus(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error)
+	CancelBatch(ctx context.Context, id primitive.ObjectID) (*models.RegistrationBatch, error)
+	ExportAccount(ctx context.Context, id primitive.ObjectID, opts models.ExportOptions) (*models.AccountExport, error)
 	StartWorkers(ctx context.Context) error
 	Shutdown(ctx context.Context) error
 }
@@ -33,19 +44,43 @@ type vkService struct {
 	accountRepo      repository.AccountRepository
 	sessionRepo      repository.SessionRepository
 	registrationFlow RegistrationFlow
+	registrationCfg  *models.RegistrationConfig
+	registrationPool *registrationPool
+	batchRepo        repository.BatchRepository
+	profilePopulator ProfilePopulator
+	profileConfig    *models.ProfileConfig
+	emailBinder      EmailBinder
+	emailBindingCfg  *models.EmailBindingConfig
+	appealRepo       repository.AppealRepository
+	appealFlow       AppealFlow
+	appealConfig     *models.AppealConfig
 	proxyClient      proxypb.ProxyServiceClient
+	personaClient    personapb.PersonaServiceClient
 	messagingClient  messaging.Client
 	metrics          MetricsCollector
 	logger           logger.Logger
 	workerCtx        context.Context
 	workerCancel     context.CancelFunc
+	// registrationCtx outlives workerCtx so shutdown lets in-flight registrations finish
+	registrationCtx    context.Context
+	registrationCancel context.CancelFunc
 }
 
 func NewVKService(
 	accountRepo repository.AccountRepository,
 	sessionRepo repository.SessionRepository,
 	registrationFlow RegistrationFlow,
+	registrationCfg *models.RegistrationConfig,
+	batchRepo repository.BatchRepository,
+	profilePopulator ProfilePopulator,
+	profileConfig *models.ProfileConfig,
+	emailBinder EmailBinder,
+	emailBindingCfg *models.EmailBindingConfig,
+	appealRepo repository.AppealRepository,
+	appealFlow AppealFlow,
+	appealConfig *models.AppealConfig,
 	proxyClient proxypb.ProxyServiceClient,
+	personaClient personapb.PersonaServiceClient,
 	messagingClient messaging.Client,
 	metrics MetricsCollector,
 	logger logger.Logger,
@@ -54,7 +89,18 @@ func NewVKService(
 		accountRepo:      accountRepo,
 		sessionRepo:      sessionRepo,
 		registrationFlow: registrationFlow,
+		registrationCfg:  registrationCfg,
+		registrationPool: newRegistrationPool(registrationCfg.Concurrency, registrationCfg.QueueSize, metrics),
+		batchRepo:        batchRepo,
+		profilePopulator: profilePopulator,
+		profileConfig:    profileConfig,
+		emailBinder:      emailBinder,
+		emailBindingCfg:  emailBindingCfg,
+		appealRepo:       appealRepo,
+		appealFlow:       appealFlow,
+		appealConfig:     appealConfig,
 		proxyClient:      proxyClient,
+		personaClient:    personaClient,
 		messagingClient:  messagingClient,
 		metrics:          metrics,
 		logger:           logger,
@@ -62,13 +108,27 @@ func NewVKService(
 }
 
 func (s *vkService) CreateAccount(ctx context.Context, request *models.RegistrationRequest) (*models.VKAccount, error) {
-	// Generate profile if requested
-	if request.UseRandomProfile {
-		profile := NewFingerprintGenerator().GenerateRandomProfile()
-		request.FirstName = profile.FirstName
-		request.LastName = profile.LastName
-		request.BirthDate = profile.BirthDate
-		request.Gender = models.Gender(profile.Gender)
+	account, err := s.createAccountRecord(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queueRegistration(ctx, account, request); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// createAccountRecord fills the profile and stores the account before its registration is queued
+func (s *vkService) createAccountRecord(ctx context.Context, request *models.RegistrationRequest) (*models.VKAccount, error) {
+	// Take the profile from an existing persona or generate one if requested
+	if request.PersonaID != "" {
+		if err := s.applyPersona(ctx, request); err != nil {
+			return nil, err
+		}
+	} else if request.UseRandomProfile {
+		s.applyRandomProfile(ctx, request)
 	}
 
 	// Create account record
@@ -78,19 +138,28 @@ func (s *vkService) CreateAccount(ctx context.Context, request *models.Registrat
 		Gender:     string(request.Gender),
 		Status:     models.StatusCreating,
 		RetryCount: 0,
+		PersonaID:  request.PersonaID,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
+	account.PersonaProfile = request.PersonaProfile
 
 	if !request.BirthDate.IsZero() {
 		account.BirthDate = &request.BirthDate
 	}
 
 	if err := s.accountRepo.CreateAccount(ctx, account); err != nil {
+		s.cancelPersona(ctx, request)
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
 
-	// Publish registration command to queue
+	s.confirmPersona(ctx, account, request.PersonaReservationID)
+
+	return account, nil
+}
+
+// queueRegistration publishes the registration command for a created account record
+func (s *vkService) queueRegistration(ctx context.Context, account *models.VKAccount, request *models.RegistrationRequest) error {
 	command := map[string]interface{}{
 		"account_id": account.ID.Hex(),
 		"request":    request,
@@ -101,13 +170,13 @@ func (s *vkService) CreateAccount(ctx context.Context, request *models.Registrat
 		s.logger.Error("Failed to publish registration command", "error", err, "account_id", account.ID)
 		// Update status to error
 		s.accountRepo.UpdateAccountStatus(ctx, account.ID, models.StatusError, "failed to queue registration")
-		return nil, fmt.Errorf("failed to queue registration: %w", err)
+		return fmt.Errorf("failed to queue registration: %w", err)
 	}
 
 	s.metrics.IncrementAccountsTotal(string(models.StatusCreating))
 	s.logger.Info("Account creation initiated", "account_id", account.ID)
 
-	return account, nil
+	return nil
 }
 
 func (s *vkService) GetAccount(ctx context.Context, id primitive.ObjectID) (*models.VKAccount, error) {
@@ -150,6 +219,10 @@ func (s *vkService) UpdateAccountStatus(ctx context.Context, id primitive.Object
 		s.logger.Warn("Failed to publish status change event", "error", err, "account_id", id)
 	}
 
+	if status == models.StatusBanned && account.Status != models.StatusBanned {
+		s.queueAutoAppeal(ctx, id)
+	}
+
 	return nil
 }
 
@@ -227,9 +300,51 @@ func (s *vkService) GetStatistics(ctx context.Context) (*models.AccountStatistic
 	return stats, nil
 }
 
+func (s *vkService) PopulateProfile(ctx context.Context, accountID primitive.ObjectID, opts *models.ProfilePopulationOptions) (*models.ProfilePopulationResult, error) {
+	result, err := s.profilePopulator.PopulateProfile(ctx, accountID, opts)
+	if err != nil {
+		s.metrics.IncrementErrorsTotal("profile_error")
+		return nil, fmt.Errorf("failed to populate profile: %w", err)
+	}
+
+	event := map[string]interface{}{
+		"account_id":      accountID.Hex(),
+		"completed_steps": result.CompletedSteps,
+		"failed_steps":    result.FailedSteps,
+		"timestamp":       time.Now(),
+	}
+	if err := s.messagingClient.PublishEvent("vk.events", "vk.account.profile_populated", event); err != nil {
+		s.logger.Warn("Failed to publish profile populated event", "error", err, "account_id", accountID)
+	}
+
+	return result, nil
+}
+
+func (s *vkService) BindEmail(ctx context.Context, accountID primitive.ObjectID) (*models.EmailBindingResult, error) {
+	result, err := s.emailBinder.BindEmail(ctx, accountID)
+	if err != nil {
+		s.metrics.IncrementErrorsTotal("email_binding_error")
+		return nil, fmt.Errorf("failed to bind email: %w", err)
+	}
+
+	event := map[string]interface{}{
+		"account_id":      accountID.Hex(),
+		"mail_account_id": result.MailAccountID,
+		"quality":         models.QualityHigh,
+		"timestamp":       time.Now(),
+	}
+	if err := s.messagingClient.PublishEvent("vk.events", "vk.account.email_bound", event); err != nil {
+		s.logger.Warn("Failed to publish email bound event", "error", err, "account_id", accountID)
+	}
+
+	return result, nil
+}
+
 func (s *vkService) PublishManualInterventionRequired(ctx context.Context, accountID primitive.ObjectID, reason string, details map[string]interface{}) error {
 	// Create intervention message
 	message := map[string]interface{}{
+		"type":       "manual_intervention",
+		"platform":   "vk",
 		"account_id": accountID.Hex(),
 		"reason":     reason,
 		"details":    details,
@@ -244,8 +359,8 @@ func (s *vkService) PublishManualInterventionRequired(ctx context.Context, accou
 		message["retry_count"] = account.RetryCount
 	}
 
-	// Publish to manual intervention queue
-	if err := s.messagingClient.PublishToQueue("vk.manual_intervention", message); err != nil {
+	// Published as an event so both intervention-service and the bot alerts get it
+	if err := s.messagingClient.PublishEvent("vk.events", "vk.manual_intervention", message); err != nil {
 		return fmt.Errorf("failed to publish manual intervention request: %w", err)
 	}
 
@@ -267,16 +382,33 @@ func (s *vkService) PublishManualInterventionRequired(ctx context.Context, accou
 
 func (s *vkService) StartWorkers(ctx context.Context) error {
 	s.workerCtx, s.workerCancel = context.WithCancel(ctx)
+	s.registrationCtx, s.registrationCancel = context.WithCancel(context.WithoutCancel(ctx))
 
-	// Start registration command consumer
+	// Start registration workers and the command consumer feeding them
+	s.registrationPool.Start(s.processRegistration)
 	go s.consumeRegistrationCommands(s.workerCtx)
 
 	// Start retry command consumer
 	go s.consumeRetryCommands(s.workerCtx)
 
+	// Start profile population consumer
+	go s.consumeProfileCommands(s.workerCtx)
+
+	// Start recovery email binding consumer
+	go s.consumeEmailBindingCommands(s.workerCtx)
+
+	// Start ban appeal consumer and status tracker
+	if s.appealConfig != nil && s.appealConfig.Enabled {
+		go s.consumeAppealCommands(s.workerCtx)
+		go s.monitorAppeals(s.workerCtx)
+	}
+
 	// Start stuck registration monitor
 	go s.monitorStuckRegistrations(s.workerCtx)
 
+	// Start recovery of batches left unfinished by a restart
+	go s.monitorStalledBatches(s.workerCtx)
+
 	// Start session cleanup worker
 	go s.cleanupExpiredSessions(s.workerCtx)
 
@@ -285,13 +417,13 @@ func (s *vkService) StartWorkers(ctx context.Context) error {
 }
 
 func (s *vkService) consumeRegistrationCommands(ctx context.Context) {
-	consumer := func(delivery amqp.Delivery) error {
+	consumer := func(body []byte) error {
 		var command struct {
 			AccountID string                       `json:"account_id"`
 			Request   models.RegistrationRequest   `json:"request"`
 		}
 
-		if err := messaging.DecodeMessage(delivery.Body, &command); err != nil {
+		if err := json.Unmarshal(body, &command); err != nil {
 			s.logger.Error("Failed to decode registration command", "error", err)
 			return err
 		}
@@ -302,51 +434,91 @@ func (s *vkService) consumeRegistrationCommands(ctx context.Context) {
 			return err
 		}
 
-		s.logger.Info("Processing registration command", "account_id", accountID)
-		s.metrics.IncrementActiveRegistrations()
-		defer s.metrics.DecrementActiveRegistrations()
-
-		// Execute registration
-		startTime := time.Now()
-		result, err := s.registrationFlow.RegisterAccount(ctx, accountID, &command.Request)
-		duration := time.Since(startTime)
-		s.metrics.RecordRegistrationDuration(duration)
-
-		if err != nil {
-			s.logger.Error("Registration failed", "error", err, "account_id", accountID)
-			s.metrics.IncrementRegistrationsTotal("failed")
-			s.metrics.IncrementErrorsTotal("registration_error")
-
-			// Publish error event
-			s.publishAccountEvent(accountID, "error", err.Error())
+		// Blocks while all workers are busy and the local queue is full, so the backlog stays in RabbitMQ.
+		// Accepted commands survive a crash through the session checkpoint and the stuck registration monitor.
+		if err := s.registrationPool.Submit(ctx, registrationJob{accountID: accountID, request: command.Request}); err != nil {
+			s.logger.Warn("Registration command returned to queue", "error", err, "account_id", accountID)
 			return err
 		}
 
-		if result.Success {
-			s.metrics.IncrementRegistrationsTotal("success")
-			s.publishAccountEvent(accountID, "created", "")
-			s.logger.Info("Registration completed", "account_id", accountID, "user_id", result.UserID)
-		} else {
-			s.metrics.IncrementRegistrationsTotal("failed")
-			s.publishAccountEvent(accountID, "error", result.ErrorMessage)
-		}
-
 		return nil
 	}
 
-	if err := s.messagingClient.ConsumeQueue("vk.register", consumer); err != nil {
+	if err := s.messagingClient.ConsumeQueue(ctx, "vk.register", consumer); err != nil {
 		s.logger.Error("Failed to start registration consumer", "error", err)
 	}
 }
 
+// processRegistration runs one registration on a pool worker
+func (s *vkService) processRegistration(job registrationJob) {
+	ctx := s.registrationCtx
+	accountID := job.accountID
+
+	if job.request.BatchID != "" && s.skipCancelledBatch(ctx, job) {
+		return
+	}
+
+	s.logger.Info("Processing registration command", "account_id", accountID)
+	s.metrics.IncrementActiveRegistrations()
+	defer s.metrics.DecrementActiveRegistrations()
+
+	// Execute registration
+	startTime := time.Now()
+	result, err := s.registrationFlow.RegisterAccount(ctx, accountID, &job.request)
+	duration := time.Since(startTime)
+	s.metrics.RecordRegistrationDuration(duration)
+
+	if err != nil {
+		s.logger.Error("Registration failed", "error", err, "account_id", accountID)
+		s.metrics.IncrementRegistrationsTotal("failed")
+		s.metrics.IncrementErrorsTotal("registration_error")
+
+		// Publish error event
+		s.publishAccountEvent(accountID, "error", err.Error())
+		s.recordBatchOutcome(ctx, job, models.BatchItemFailed, err.Error())
+		return
+	}
+
+	if result.Success {
+		s.metrics.IncrementRegistrationsTotal("success")
+		s.publishAccountEvent(accountID, "created", "")
+		s.publishRegistrationDuration(accountID, duration)
+		s.queueProfilePopulation(accountID)
+		s.queueEmailBinding(accountID)
+		s.recordBatchOutcome(ctx, job, models.BatchItemSucceeded, "")
+		s.logger.Info("Registration completed", "account_id", accountID, "user_id", result.UserID)
+	} else {
+		s.metrics.IncrementRegistrationsTotal("failed")
+		s.publishAccountEvent(accountID, "error", result.ErrorMessage)
+		s.recordBatchOutcome(ctx, job, models.BatchItemFailed, result.ErrorMessage)
+	}
+}
+
+// publishRegistrationDuration reports the end-to-end registration time to analytics.
+// The VK flow does not time individual steps, so only the "total" step is published.
+func (s *vkService) publishRegistrationDuration(accountID primitive.ObjectID, duration time.Duration) {
+	event := map[string]interface{}{
+		"type":         "registration.step_completed",
+		"platform":     "vk",
+		"account_id":   accountID.Hex(),
+		"step":         "total",
+		"duration_sec": duration.Seconds(),
+		"timestamp":    time.Now(),
+	}
+
+	if err := s.messagingClient.PublishEvent("vk.events", "vk.registration.step_completed", event); err != nil {
+		s.logger.Warn("Failed to publish registration duration", "error", err, "account_id", accountID)
+	}
+}
+
 func (s *vkService) consumeRetryCommands(ctx context.Context) {
-	consumer := func(delivery amqp.Delivery) error {
+	consumer := func(body []byte) error {
 		var command struct {
 			AccountID  string `json:"account_id"`
 			RetryCount int    `json:"retry_count"`
 		}
 
-		if err := messaging.DecodeMessage(delivery.Body, &command); err != nil {
+		if err := json.Unmarshal(body, &command); err != nil {
 			s.logger.Error("Failed to decode retry command", "error", err)
 			return err
 		}
@@ -377,6 +549,8 @@ func (s *vkService) consumeRetryCommands(ctx context.Context) {
 
 		if result.Success {
 			s.publishAccountEvent(accountID, "created", "")
+			s.queueProfilePopulation(accountID)
+			s.queueEmailBinding(accountID)
 		} else {
 			s.publishAccountEvent(accountID, "error", result.ErrorMessage)
 		}
@@ -384,11 +558,402 @@ func (s *vkService) consumeRetryCommands(ctx context.Context) {
 		return nil
 	}
 
-	if err := s.messagingClient.ConsumeQueue("vk.retry", consumer); err != nil {
+	if err := s.messagingClient.ConsumeQueue(ctx, "vk.retry", consumer); err != nil {
 		s.logger.Error("Failed to start retry consumer", "error", err)
 	}
 }
 
+func (s *vkService) consumeProfileCommands(ctx context.Context) {
+	consumer := func(body []byte) error {
+		var command struct {
+			AccountID string                          `json:"account_id"`
+			Options   models.ProfilePopulationOptions `json:"options"`
+		}
+
+		if err := json.Unmarshal(body, &command); err != nil {
+			s.logger.Error("Failed to decode profile command", "error", err)
+			return err
+		}
+
+		accountID, err := primitive.ObjectIDFromHex(command.AccountID)
+		if err != nil {
+			s.logger.Error("Invalid account ID", "error", err, "account_id", command.AccountID)
+			return err
+		}
+
+		s.logger.Info("Processing profile population command", "account_id", accountID)
+
+		// Let the fresh account settle before touching the profile
+		time.Sleep(time.Duration(1+rand.Intn(5)) * time.Minute)
+
+		if _, err := s.PopulateProfile(ctx, accountID, &command.Options); err != nil {
+			s.logger.Error("Profile population failed", "error", err, "account_id", accountID)
+			return err
+		}
+
+		return nil
+	}
+
+	if err := s.messagingClient.ConsumeQueue(ctx, "vk.populate_profile", consumer); err != nil {
+		s.logger.Error("Failed to start profile consumer", "error", err)
+	}
+}
+
+// queueProfilePopulation schedules profile filling for a freshly registered account when enabled
+func (s *vkService) queueProfilePopulation(accountID primitive.ObjectID) {
+	if s.profileConfig == nil || !s.profileConfig.PopulateAfterRegistration {
+		return
+	}
+
+	command := map[string]interface{}{
+		"account_id": accountID.Hex(),
+		"timestamp":  time.Now(),
+	}
+
+	if err := s.messagingClient.PublishToQueue("vk.populate_profile", command); err != nil {
+		s.logger.Error("Failed to queue profile population", "error", err, "account_id", accountID)
+	}
+}
+
+func (s *vkService) consumeEmailBindingCommands(ctx context.Context) {
+	consumer := func(body []byte) error {
+		var command struct {
+			AccountID string `json:"account_id"`
+		}
+
+		if err := json.Unmarshal(body, &command); err != nil {
+			s.logger.Error("Failed to decode email binding command", "error", err)
+			return err
+		}
+
+		accountID, err := primitive.ObjectIDFromHex(command.AccountID)
+		if err != nil {
+			s.logger.Error("Invalid account ID", "error", err, "account_id", command.AccountID)
+			return err
+		}
+
+		s.logger.Info("Processing email binding command", "account_id", accountID)
+
+		// Bind after profile population has had its turn, VK is wary of fresh accounts changing contacts
+		time.Sleep(time.Duration(10+rand.Intn(10)) * time.Minute)
+
+		if _, err := s.BindEmail(ctx, accountID); err != nil {
+			s.logger.Error("Email binding failed", "error", err, "account_id", accountID)
+			if errors.Is(err, ErrNoRecoveryMailbox) {
+				// Redelivering won't help until mail-service verifies more mailboxes
+				return nil
+			}
+			return err
+		}
+
+		return nil
+	}
+
+	if err := s.messagingClient.ConsumeQueue(ctx, "vk.bind_email", consumer); err != nil {
+		s.logger.Error("Failed to start email binding consumer", "error", err)
+	}
+}
+
+// queueEmailBinding schedules recovery email binding for a freshly registered account when enabled
+func (s *vkService) queueEmailBinding(accountID primitive.ObjectID) {
+	if s.emailBindingCfg == nil || !s.emailBindingCfg.BindAfterRegistration {
+		return
+	}
+
+	command := map[string]interface{}{
+		"account_id": accountID.Hex(),
+		"timestamp":  time.Now(),
+	}
+
+	if err := s.messagingClient.PublishToQueue("vk.bind_email", command); err != nil {
+		s.logger.Error("Failed to queue email binding", "error", err, "account_id", accountID)
+	}
+}
+
+func (s *vkService) AppealBan(ctx context.Context, accountID primitive.ObjectID) (*models.Appeal, error) {
+	if s.appealConfig == nil || !s.appealConfig.Enabled {
+		return nil, fmt.Errorf("ban appeals are disabled")
+	}
+
+	account, err := s.accountRepo.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if account.Status != models.StatusBanned && account.Status != models.StatusSuspended {
+		return nil, fmt.Errorf("account is not banned (status: %s)", account.Status)
+	}
+
+	latest, err := s.appealRepo.GetLatestAppealByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && !latest.IsFinal() {
+		return nil, fmt.Errorf("appeal %s is already in progress", latest.ID.Hex())
+	}
+
+	appeal := &models.Appeal{
+		AccountID:   accountID,
+		Status:      models.AppealStatusPending,
+		BanStatus:   account.Status,
+		BanReason:   account.ErrorMessage,
+		NextCheckAt: time.Now(),
+	}
+	if err := s.appealRepo.CreateAppeal(ctx, appeal); err != nil {
+		return nil, err
+	}
+
+	command := map[string]interface{}{
+		"appeal_id":  appeal.ID.Hex(),
+		"account_id": accountID.Hex(),
+		"timestamp":  time.Now(),
+	}
+
+	if err := s.messagingClient.PublishToQueue("vk.appeal", command); err != nil {
+		s.appealRepo.UpdateAppeal(ctx, appeal.ID, bson.M{
+			"status":        models.AppealStatusFailed,
+			"error_message": "failed to queue appeal",
+		})
+		return nil, fmt.Errorf("failed to queue appeal: %w", err)
+	}
+
+	s.logger.Info("Ban appeal queued", "account_id", accountID, "appeal_id", appeal.ID)
+	return appeal, nil
+}
+
+func (s *vkService) GetAppeal(ctx context.Context, accountID primitive.ObjectID) (*models.Appeal, error) {
+	appeal, err := s.appealRepo.GetLatestAppealByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if appeal == nil {
+		return nil, fmt.Errorf("appeal not found")
+	}
+	return appeal, nil
+}
+
+// queueAutoAppeal appeals a fresh ban once; accounts that already lost an appeal are left alone
+func (s *vkService) queueAutoAppeal(ctx context.Context, accountID primitive.ObjectID) {
+	if s.appealConfig == nil || !s.appealConfig.Enabled || !s.appealConfig.AutoAppeal {
+		return
+	}
+
+	latest, err := s.appealRepo.GetLatestAppealByAccount(ctx, accountID)
+	if err != nil {
+		s.logger.Error("Failed to check previous appeals", "error", err, "account_id", accountID)
+		return
+	}
+	if latest != nil {
+		return
+	}
+
+	if _, err := s.AppealBan(ctx, accountID); err != nil {
+		s.logger.Error("Failed to queue automatic appeal", "error", err, "account_id", accountID)
+	}
+}
+
+func (s *vkService) consumeAppealCommands(ctx context.Context) {
+	consumer := func(body []byte) error {
+		var command struct {
+			AppealID string `json:"appeal_id"`
+		}
+
+		if err := json.Unmarshal(body, &command); err != nil {
+			s.logger.Error("Failed to decode appeal command", "error", err)
+			return err
+		}
+
+		appealID, err := primitive.ObjectIDFromHex(command.AppealID)
+		if err != nil {
+			s.logger.Error("Invalid appeal ID", "error", err, "appeal_id", command.AppealID)
+			return err
+		}
+
+		appeal, err := s.appealRepo.GetAppeal(ctx, appealID)
+		if err != nil {
+			return err
+		}
+		if appeal.Status != models.AppealStatusPending {
+			return nil
+		}
+
+		account, err := s.accountRepo.GetAccountByID(ctx, appeal.AccountID)
+		if err != nil {
+			return err
+		}
+
+		s.logger.Info("Processing appeal command", "account_id", account.ID, "appeal_id", appealID)
+
+		// Retrying burns SMS numbers, so a failed submission is final and can be appealed again manually
+		if err := s.appealFlow.SubmitAppeal(ctx, account, appeal); err != nil {
+			s.logger.Error("Appeal submission failed", "error", err, "account_id", account.ID)
+			appeal.Status = models.AppealStatusFailed
+			appeal.ErrorMessage = err.Error()
+			s.resolveAppeal(ctx, account, appeal)
+			return nil
+		}
+
+		if appeal.Status != models.AppealStatusSubmitted {
+			s.resolveAppeal(ctx, account, appeal)
+			return nil
+		}
+
+		now := time.Now()
+		if err := s.appealRepo.UpdateAppeal(ctx, appeal.ID, bson.M{
+			"status":        appeal.Status,
+			"phone_source":  appeal.PhoneSource,
+			"activation_id": appeal.ActivationID,
+			"submitted_at":  now,
+			"next_check_at": now.Add(s.appealConfig.CheckInterval),
+		}); err != nil {
+			s.logger.Error("Failed to save appeal", "error", err, "appeal_id", appeal.ID)
+		}
+
+		if err := s.UpdateAccountStatus(ctx, account.ID, models.StatusAppealing); err != nil {
+			s.logger.Error("Failed to mark account as appealing", "error", err, "account_id", account.ID)
+		}
+
+		s.metrics.IncrementAppeals(string(models.AppealStatusSubmitted))
+		s.publishAppealEvent(appeal, "appeal_submitted")
+		return nil
+	}
+
+	if err := s.messagingClient.ConsumeQueue(ctx, "vk.appeal", consumer); err != nil {
+		s.logger.Error("Failed to start appeal consumer", "error", err)
+	}
+}
+
+// monitorAppeals re-checks submitted appeals until VK decides; reviews can take days
+func (s *vkService) monitorAppeals(ctx context.Context) {
+	ticker := time.NewTicker(15 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkAppeals(ctx)
+		}
+	}
+}
+
+func (s *vkService) checkAppeals(ctx context.Context) {
+	appeals, err := s.appealRepo.GetDueAppeals(ctx, 50)
+	if err != nil {
+		s.logger.Error("Failed to get due appeals", "error", err)
+		return
+	}
+
+	for _, appeal := range appeals {
+		account, err := s.accountRepo.GetAccountByID(ctx, appeal.AccountID)
+		if err != nil {
+			s.logger.Error("Failed to get appealed account", "error", err, "appeal_id", appeal.ID)
+			continue
+		}
+
+		if appeal.SubmittedAt != nil && time.Since(*appeal.SubmittedAt) > s.appealConfig.MaxDuration {
+			appeal.Status = models.AppealStatusExpired
+			s.resolveAppeal(ctx, account, appeal)
+			continue
+		}
+
+		status, err := s.appealFlow.CheckAppeal(ctx, account)
+		now := time.Now()
+		if err != nil || status == models.AppealStatusSubmitted {
+			update := bson.M{
+				"checks":          appeal.Checks + 1,
+				"last_checked_at": now,
+				"next_check_at":   now.Add(s.appealConfig.CheckInterval),
+			}
+			if err != nil {
+				s.logger.Warn("Failed to check appeal", "error", err, "appeal_id", appeal.ID)
+				update["error_message"] = err.Error()
+			}
+			if err := s.appealRepo.UpdateAppeal(ctx, appeal.ID, update); err != nil {
+				s.logger.Error("Failed to update appeal", "error", err, "appeal_id", appeal.ID)
+			}
+			continue
+		}
+
+		appeal.Checks++
+		appeal.LastCheckedAt = &now
+		appeal.Status = status
+		s.resolveAppeal(ctx, account, appeal)
+	}
+
+	if len(appeals) > 0 {
+		s.logger.Info("Checked pending appeals", "count", len(appeals))
+	}
+}
+
+// resolveAppeal stores the final outcome and moves the account out of the appeal
+func (s *vkService) resolveAppeal(ctx context.Context, account *models.VKAccount, appeal *models.Appeal) {
+	now := time.Now()
+	appeal.ResolvedAt = &now
+
+	update := bson.M{
+		"status":        appeal.Status,
+		"checks":        appeal.Checks,
+		"phone_source":  appeal.PhoneSource,
+		"activation_id": appeal.ActivationID,
+		"error_message": appeal.ErrorMessage,
+		"resolved_at":   now,
+	}
+	if appeal.LastCheckedAt != nil {
+		update["last_checked_at"] = *appeal.LastCheckedAt
+	}
+	if err := s.appealRepo.UpdateAppeal(ctx, appeal.ID, update); err != nil {
+		s.logger.Error("Failed to save appeal outcome", "error", err, "appeal_id", appeal.ID)
+	}
+
+	status := models.StatusBanned
+	if appeal.Status == models.AppealStatusApproved {
+		status = models.StatusReady
+	} else if account.Status != models.StatusAppealing {
+		// The account never left its ban status, nothing to restore
+		status = account.Status
+	}
+	if status != account.Status {
+		if err := s.UpdateAccountStatus(ctx, account.ID, status); err != nil {
+			s.logger.Error("Failed to update account after appeal", "error", err, "account_id", account.ID)
+		}
+	}
+
+	s.metrics.IncrementAppeals(string(appeal.Status))
+	s.publishAppealEvent(appeal, "appeal_"+string(appeal.Status))
+
+	s.logger.Info("Appeal resolved",
+		"account_id", account.ID,
+		"appeal_id", appeal.ID,
+		"status", appeal.Status,
+		"checks", appeal.Checks)
+}
+
+func (s *vkService) publishAppealEvent(appeal *models.Appeal, eventType string) {
+	event := map[string]interface{}{
+		"account_id":   appeal.AccountID.Hex(),
+		"appeal_id":    appeal.ID.Hex(),
+		"type":         eventType,
+		"platform":     "vk",
+		"status":       appeal.Status,
+		"phone_source": appeal.PhoneSource,
+		"timestamp":    time.Now(),
+	}
+
+	if appeal.ResolvedAt != nil {
+		event["duration_hours"] = appeal.ResolvedAt.Sub(appeal.CreatedAt).Hours()
+	}
+	if appeal.ErrorMessage != "" {
+		event["error"] = appeal.ErrorMessage
+	}
+
+	routingKey := fmt.Sprintf("vk.account.%s", eventType)
+	if err := s.messagingClient.PublishEvent("vk.events", routingKey, event); err != nil {
+		s.logger.Error("Failed to publish appeal event", "error", err, "account_id", appeal.AccountID, "event_type", eventType)
+	}
+}
+
 func (s *vkService) monitorStuckRegistrations(ctx context.Context) {
 	ticker := time.NewTicker(10 * time.Minute)
 	defer ticker.Stop()
@@ -467,21 +1032,28 @@ func (s *vkService) publishAccountEvent(accountID primitive.ObjectID, eventType
 func (s *vkService) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down VK service workers")
 
-	// Cancel worker context
+	// Cancel worker context, this also stops the registration consumer from submitting
 	if s.workerCancel != nil {
 		s.workerCancel()
 	}
 
-	// Give workers time to finish
-	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	// Let accepted registrations finish, a half-filled form can't be resumed cheaply
+	drainCtx := ctx
+	if s.registrationCfg.DrainTimeout > 0 {
+		var cancel context.CancelFunc
+		drainCtx, cancel = context.WithTimeout(ctx, s.registrationCfg.DrainTimeout)
+		defer cancel()
+	}
 
-	select {
-	case <-shutdownCtx.Done():
-		s.logger.Warn("Worker shutdown timeout exceeded")
-	case <-time.After(5 * time.Second):
+	if err := s.registrationPool.Drain(drainCtx); err != nil {
+		s.logger.Warn("Registration drain timeout exceeded, aborting in-flight registrations", "error", err)
+	} else {
 		s.logger.Info("Workers shut down gracefully")
 	}
 
+	if s.registrationCancel != nil {
+		s.registrationCancel()
+	}
+
 	return nil
 }
@@ -31,6 +31,7 @@ type CreateAccountRequest struct {
 	Gender           string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
 	PreferredCountry string                 `protobuf:"bytes,5,opt,name=preferred_country,json=preferredCountry,proto3" json:"preferred_country,omitempty"`
 	UseRandomProfile bool                   `protobuf:"varint,6,opt,name=use_random_profile,json=useRandomProfile,proto3" json:"use_random_profile,omitempty"`
+	PersonaId        string                 `protobuf:"bytes,7,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -107,6 +108,13 @@ func (x *CreateAccountRequest) GetUseRandomProfile() bool {
 	return false
 }
 
+func (x *CreateAccountRequest) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
 type GetAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
@@ -371,6 +379,9 @@ type Account struct {
 	LastLoginAt    *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
 	ErrorMessage   string                 `protobuf:"bytes,17,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	RetryCount     int32                  `protobuf:"varint,18,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	PersonaId      string                 `protobuf:"bytes,19,opt,name=persona_id,json=personaId,proto3" json:"persona_id,omitempty"`
+	Quality        string                 `protobuf:"bytes,20,opt,name=quality,proto3" json:"quality,omitempty"`
+	EmailBoundAt   *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=email_bound_at,json=emailBoundAt,proto3" json:"email_bound_at,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -531,6 +542,27 @@ func (x *Account) GetRetryCount() int32 {
 	return 0
 }
 
+func (x *Account) GetPersonaId() string {
+	if x != nil {
+		return x.PersonaId
+	}
+	return ""
+}
+
+func (x *Account) GetQuality() string {
+	if x != nil {
+		return x.Quality
+	}
+	return ""
+}
+
+func (x *Account) GetEmailBoundAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EmailBoundAt
+	}
+	return nil
+}
+
 type ListAccountsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
@@ -751,87 +783,966 @@ func (x *AccountCredentials) GetAccessToken() string {
 	return ""
 }
 
-var File_services_vk_service_proto_vk_proto protoreflect.FileDescriptor
+type PopulateProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	SkipAvatar    bool                   `protobuf:"varint,2,opt,name=skip_avatar,json=skipAvatar,proto3" json:"skip_avatar,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Interests     []string               `protobuf:"bytes,5,rep,name=interests,proto3" json:"interests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_services_vk_service_proto_vk_proto_rawDesc = "" +
-	"\n" +
-	"\"services/vk-service/proto/vk.proto\x12\x02vk\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x80\x02\n" +
-	"\x14CreateAccountRequest\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x02 \x01(\tR\blastName\x129\n" +
-	"\n" +
-	"birth_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tbirthDate\x12\x16\n" +
-	"\x06gender\x18\x04 \x01(\tR\x06gender\x12+\n" +
-	"\x11preferred_country\x18\x05 \x01(\tR\x10preferredCountry\x12,\n" +
-	"\x12use_random_profile\x18\x06 \x01(\bR\x10useRandomProfile\"2\n" +
-	"\x11GetAccountRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"[\n" +
-	"\x13ListAccountsRequest\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"L\n" +
-	"\x13UpdateStatusRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"-\n" +
-	"\fRetryRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"5\n" +
-	"\x14DeleteAccountRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"\xd2\x05\n" +
-	"\aAccount\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x04 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x1a\n" +
-	"\busername\x18\x06 \x01(\tR\busername\x12\x17\n" +
-	"\auser_id\x18\a \x01(\tR\x06userId\x12\x16\n" +
-	"\x06status\x18\b \x01(\tR\x06status\x12\x19\n" +
-	"\bproxy_id\x18\t \x01(\tR\aproxyId\x12#\n" +
-	"\ractivation_id\x18\n" +
-	" \x01(\tR\factivationId\x12\x1d\n" +
-	"\n" +
-	"user_agent\x18\v \x01(\tR\tuserAgent\x12>\n" +
-	"\vfingerprint\x18\f \x03(\v2\x1c.vk.Account.FingerprintEntryR\vfingerprint\x12'\n" +
-	"\x0fregistration_ip\x18\r \x01(\tR\x0eregistrationIp\x129\n" +
-	"\n" +
-	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
-	"\rlast_login_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\x12#\n" +
-	"\rerror_message\x18\x11 \x01(\tR\ferrorMessage\x12\x1f\n" +
-	"\vretry_count\x18\x12 \x01(\x05R\n" +
-	"retryCount\x1a>\n" +
-	"\x10FingerprintEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
-	"\x14ListAccountsResponse\x12'\n" +
-	"\baccounts\x18\x01 \x03(\v2\v.vk.AccountR\baccounts\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xa7\x02\n" +
-	"\n" +
-	"Statistics\x12\x14\n" +
-	"\x05total\x18\x01 \x01(\x03R\x05total\x129\n" +
-	"\tby_status\x18\x02 \x03(\v2\x1c.vk.Statistics.ByStatusEntryR\bbyStatus\x12!\n" +
-	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12'\n" +
-	"\x0faverage_retries\x18\x04 \x01(\x01R\x0eaverageRetries\x12\x1b\n" +
-	"\tlast_hour\x18\x05 \x01(\x03R\blastHour\x12\"\n" +
-	"\rlast_24_hours\x18\x06 \x01(\x03R\vlast24Hours\x1a;\n" +
-	"\rByStatusEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x8c\x01\n" +
-	"\x12AccountCredentials\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x18\n" +
-	"\acookies\x18\x03 \x01(\tR\acookies\x12!\n" +
-	"\faccess_token\x18\x04 \x01(\tR\vaccessToken2\xed\x03\n" +
+func (x *PopulateProfileRequest) Reset() {
+	*x = PopulateProfileRequest{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PopulateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PopulateProfileRequest) ProtoMessage() {}
+
+func (x *PopulateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PopulateProfileRequest.ProtoReflect.Descriptor instead.
+func (*PopulateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PopulateProfileRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PopulateProfileRequest) GetSkipAvatar() bool {
+	if x != nil {
+		return x.SkipAvatar
+	}
+	return false
+}
+
+func (x *PopulateProfileRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PopulateProfileRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *PopulateProfileRequest) GetInterests() []string {
+	if x != nil {
+		return x.Interests
+	}
+	return nil
+}
+
+type PopulateProfileResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	City           string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	University     string                 `protobuf:"bytes,4,opt,name=university,proto3" json:"university,omitempty"`
+	GraduationYear int32                  `protobuf:"varint,5,opt,name=graduation_year,json=graduationYear,proto3" json:"graduation_year,omitempty"`
+	Interests      []string               `protobuf:"bytes,6,rep,name=interests,proto3" json:"interests,omitempty"`
+	AvatarUrl      string                 `protobuf:"bytes,7,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	CompletedSteps []string               `protobuf:"bytes,8,rep,name=completed_steps,json=completedSteps,proto3" json:"completed_steps,omitempty"`
+	FailedSteps    map[string]string      `protobuf:"bytes,9,rep,name=failed_steps,json=failedSteps,proto3" json:"failed_steps,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PopulateProfileResponse) Reset() {
+	*x = PopulateProfileResponse{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PopulateProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PopulateProfileResponse) ProtoMessage() {}
+
+func (x *PopulateProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PopulateProfileResponse.ProtoReflect.Descriptor instead.
+func (*PopulateProfileResponse) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PopulateProfileResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PopulateProfileResponse) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *PopulateProfileResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PopulateProfileResponse) GetUniversity() string {
+	if x != nil {
+		return x.University
+	}
+	return ""
+}
+
+func (x *PopulateProfileResponse) GetGraduationYear() int32 {
+	if x != nil {
+		return x.GraduationYear
+	}
+	return 0
+}
+
+func (x *PopulateProfileResponse) GetInterests() []string {
+	if x != nil {
+		return x.Interests
+	}
+	return nil
+}
+
+func (x *PopulateProfileResponse) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *PopulateProfileResponse) GetCompletedSteps() []string {
+	if x != nil {
+		return x.CompletedSteps
+	}
+	return nil
+}
+
+func (x *PopulateProfileResponse) GetFailedSteps() map[string]string {
+	if x != nil {
+		return x.FailedSteps
+	}
+	return nil
+}
+
+type BindEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BindEmailRequest) Reset() {
+	*x = BindEmailRequest{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BindEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BindEmailRequest) ProtoMessage() {}
+
+func (x *BindEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BindEmailRequest.ProtoReflect.Descriptor instead.
+func (*BindEmailRequest) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BindEmailRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type BindEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	MailAccountId string                 `protobuf:"bytes,3,opt,name=mail_account_id,json=mailAccountId,proto3" json:"mail_account_id,omitempty"`
+	BoundAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=bound_at,json=boundAt,proto3" json:"bound_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BindEmailResponse) Reset() {
+	*x = BindEmailResponse{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BindEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BindEmailResponse) ProtoMessage() {}
+
+func (x *BindEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BindEmailResponse.ProtoReflect.Descriptor instead.
+func (*BindEmailResponse) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BindEmailResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *BindEmailResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BindEmailResponse) GetMailAccountId() string {
+	if x != nil {
+		return x.MailAccountId
+	}
+	return ""
+}
+
+func (x *BindEmailResponse) GetBoundAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BoundAt
+	}
+	return nil
+}
+
+type CreateAccountsBatchRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Count            int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Gender           string                 `protobuf:"bytes,2,opt,name=gender,proto3" json:"gender,omitempty"`
+	PreferredCountry string                 `protobuf:"bytes,3,opt,name=preferred_country,json=preferredCountry,proto3" json:"preferred_country,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateAccountsBatchRequest) Reset() {
+	*x = CreateAccountsBatchRequest{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountsBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountsBatchRequest) ProtoMessage() {}
+
+func (x *CreateAccountsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountsBatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateAccountsBatchRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CreateAccountsBatchRequest) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *CreateAccountsBatchRequest) GetPreferredCountry() string {
+	if x != nil {
+		return x.PreferredCountry
+	}
+	return ""
+}
+
+type GetBatchStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchStatusRequest) Reset() {
+	*x = GetBatchStatusRequest{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchStatusRequest) ProtoMessage() {}
+
+func (x *GetBatchStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBatchStatusRequest) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetBatchStatusRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+type CancelBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBatchRequest) Reset() {
+	*x = CancelBatchRequest{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBatchRequest) ProtoMessage() {}
+
+func (x *CancelBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBatchRequest.ProtoReflect.Descriptor instead.
+func (*CancelBatchRequest) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelBatchRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+type BatchItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Step          string                 `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItem.ProtoReflect.Descriptor instead.
+func (*BatchItem) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchItem) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *BatchItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchItem) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *BatchItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type RegistrationBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Requested     int32                  `protobuf:"varint,3,opt,name=requested,proto3" json:"requested,omitempty"`
+	Pending       int32                  `protobuf:"varint,4,opt,name=pending,proto3" json:"pending,omitempty"`
+	Succeeded     int32                  `protobuf:"varint,5,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Cancelled     int32                  `protobuf:"varint,7,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Items         []*BatchItem           `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CancelledAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationBatch) Reset() {
+	*x = RegistrationBatch{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationBatch) ProtoMessage() {}
+
+func (x *RegistrationBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationBatch.ProtoReflect.Descriptor instead.
+func (*RegistrationBatch) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RegistrationBatch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RegistrationBatch) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RegistrationBatch) GetRequested() int32 {
+	if x != nil {
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *RegistrationBatch) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *RegistrationBatch) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *RegistrationBatch) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *RegistrationBatch) GetCancelled() int32 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+func (x *RegistrationBatch) GetItems() []*BatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RegistrationBatch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RegistrationBatch) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *RegistrationBatch) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type ExportAccountRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// PEM encoded RSA public key, the export is encrypted for it when set
+	RecipientPublicKey string `protobuf:"bytes,2,opt,name=recipient_public_key,json=recipientPublicKey,proto3" json:"recipient_public_key,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExportAccountRequest) Reset() {
+	*x = ExportAccountRequest{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAccountRequest) ProtoMessage() {}
+
+func (x *ExportAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAccountRequest.ProtoReflect.Descriptor instead.
+func (*ExportAccountRequest) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExportAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ExportAccountRequest) GetRecipientPublicKey() string {
+	if x != nil {
+		return x.RecipientPublicKey
+	}
+	return ""
+}
+
+type AccountExport struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Credentials as JSON, sealed for the recipient when encrypted
+	CredentialsJson []byte `protobuf:"bytes,2,opt,name=credentials_json,json=credentialsJson,proto3" json:"credentials_json,omitempty"`
+	// Session cookies as a Netscape cookies.txt file, sealed for the recipient when encrypted
+	CookiesTxt []byte `protobuf:"bytes,3,opt,name=cookies_txt,json=cookiesTxt,proto3" json:"cookies_txt,omitempty"`
+	Encrypted  bool   `protobuf:"varint,4,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	Encryption string `protobuf:"bytes,5,opt,name=encryption,proto3" json:"encryption,omitempty"`
+	// Data key wrapped with the recipient public key, every sealed file is nonce || AES-GCM ciphertext
+	EncryptedKey  []byte                 `protobuf:"bytes,6,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
+	ExportedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountExport) Reset() {
+	*x = AccountExport{}
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountExport) ProtoMessage() {}
+
+func (x *AccountExport) ProtoReflect() protoreflect.Message {
+	mi := &file_services_vk_service_proto_vk_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountExport.ProtoReflect.Descriptor instead.
+func (*AccountExport) Descriptor() ([]byte, []int) {
+	return file_services_vk_service_proto_vk_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AccountExport) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountExport) GetCredentialsJson() []byte {
+	if x != nil {
+		return x.CredentialsJson
+	}
+	return nil
+}
+
+func (x *AccountExport) GetCookiesTxt() []byte {
+	if x != nil {
+		return x.CookiesTxt
+	}
+	return nil
+}
+
+func (x *AccountExport) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *AccountExport) GetEncryption() string {
+	if x != nil {
+		return x.Encryption
+	}
+	return ""
+}
+
+func (x *AccountExport) GetEncryptedKey() []byte {
+	if x != nil {
+		return x.EncryptedKey
+	}
+	return nil
+}
+
+func (x *AccountExport) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+var File_services_vk_service_proto_vk_proto protoreflect.FileDescriptor
+
+const file_services_vk_service_proto_vk_proto_rawDesc = "" +
+	"\n" +
+	"\"services/vk-service/proto/vk.proto\x12\x02vk\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x9f\x02\n" +
+	"\x14CreateAccountRequest\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x129\n" +
+	"\n" +
+	"birth_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tbirthDate\x12\x16\n" +
+	"\x06gender\x18\x04 \x01(\tR\x06gender\x12+\n" +
+	"\x11preferred_country\x18\x05 \x01(\tR\x10preferredCountry\x12,\n" +
+	"\x12use_random_profile\x18\x06 \x01(\bR\x10useRandomProfile\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\a \x01(\tR\tpersonaId\"2\n" +
+	"\x11GetAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"[\n" +
+	"\x13ListAccountsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"L\n" +
+	"\x13UpdateStatusRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"-\n" +
+	"\fRetryRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"5\n" +
+	"\x14DeleteAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xcd\x06\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x04 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x1a\n" +
+	"\busername\x18\x06 \x01(\tR\busername\x12\x17\n" +
+	"\auser_id\x18\a \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x19\n" +
+	"\bproxy_id\x18\t \x01(\tR\aproxyId\x12#\n" +
+	"\ractivation_id\x18\n" +
+	" \x01(\tR\factivationId\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\v \x01(\tR\tuserAgent\x12>\n" +
+	"\vfingerprint\x18\f \x03(\v2\x1c.vk.Account.FingerprintEntryR\vfingerprint\x12'\n" +
+	"\x0fregistration_ip\x18\r \x01(\tR\x0eregistrationIp\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
+	"\rlast_login_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\x12#\n" +
+	"\rerror_message\x18\x11 \x01(\tR\ferrorMessage\x12\x1f\n" +
+	"\vretry_count\x18\x12 \x01(\x05R\n" +
+	"retryCount\x12\x1d\n" +
+	"\n" +
+	"persona_id\x18\x13 \x01(\tR\tpersonaId\x12\x18\n" +
+	"\aquality\x18\x14 \x01(\tR\aquality\x12@\n" +
+	"\x0eemail_bound_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\femailBoundAt\x1a>\n" +
+	"\x10FingerprintEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
+	"\x14ListAccountsResponse\x12'\n" +
+	"\baccounts\x18\x01 \x03(\v2\v.vk.AccountR\baccounts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xa7\x02\n" +
+	"\n" +
+	"Statistics\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x129\n" +
+	"\tby_status\x18\x02 \x03(\v2\x1c.vk.Statistics.ByStatusEntryR\bbyStatus\x12!\n" +
+	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12'\n" +
+	"\x0faverage_retries\x18\x04 \x01(\x01R\x0eaverageRetries\x12\x1b\n" +
+	"\tlast_hour\x18\x05 \x01(\x03R\blastHour\x12\"\n" +
+	"\rlast_24_hours\x18\x06 \x01(\x03R\vlast24Hours\x1a;\n" +
+	"\rByStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x8c\x01\n" +
+	"\x12AccountCredentials\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x18\n" +
+	"\acookies\x18\x03 \x01(\tR\acookies\x12!\n" +
+	"\faccess_token\x18\x04 \x01(\tR\vaccessToken\"\xa2\x01\n" +
+	"\x16PopulateProfileRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1f\n" +
+	"\vskip_avatar\x18\x02 \x01(\bR\n" +
+	"skipAvatar\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x1c\n" +
+	"\tinterests\x18\x05 \x03(\tR\tinterests\"\xa4\x03\n" +
+	"\x17PopulateProfileResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"university\x18\x04 \x01(\tR\n" +
+	"university\x12'\n" +
+	"\x0fgraduation_year\x18\x05 \x01(\x05R\x0egraduationYear\x12\x1c\n" +
+	"\tinterests\x18\x06 \x03(\tR\tinterests\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\a \x01(\tR\tavatarUrl\x12'\n" +
+	"\x0fcompleted_steps\x18\b \x03(\tR\x0ecompletedSteps\x12O\n" +
+	"\ffailed_steps\x18\t \x03(\v2,.vk.PopulateProfileResponse.FailedStepsEntryR\vfailedSteps\x1a>\n" +
+	"\x10FailedStepsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"1\n" +
+	"\x10BindEmailRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xa7\x01\n" +
+	"\x11BindEmailResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12&\n" +
+	"\x0fmail_account_id\x18\x03 \x01(\tR\rmailAccountId\x125\n" +
+	"\bbound_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aboundAt\"w\n" +
+	"\x1aCreateAccountsBatchRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06gender\x18\x02 \x01(\tR\x06gender\x12+\n" +
+	"\x11preferred_country\x18\x03 \x01(\tR\x10preferredCountry\"2\n" +
+	"\x15GetBatchStatusRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\"/\n" +
+	"\x12CancelBatchRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\"\xa7\x01\n" +
+	"\tBatchItem\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\tR\x04step\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa5\x03\n" +
+	"\x11RegistrationBatch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
+	"\trequested\x18\x03 \x01(\x05R\trequested\x12\x18\n" +
+	"\apending\x18\x04 \x01(\x05R\apending\x12\x1c\n" +
+	"\tsucceeded\x18\x05 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12\x1c\n" +
+	"\tcancelled\x18\a \x01(\x05R\tcancelled\x12#\n" +
+	"\x05items\x18\b \x03(\v2\r.vk.BatchItemR\x05items\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcancelled_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"g\n" +
+	"\x14ExportAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x120\n" +
+	"\x14recipient_public_key\x18\x02 \x01(\tR\x12recipientPublicKey\"\x9a\x02\n" +
+	"\rAccountExport\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12)\n" +
+	"\x10credentials_json\x18\x02 \x01(\fR\x0fcredentialsJson\x12\x1f\n" +
+	"\vcookies_txt\x18\x03 \x01(\fR\n" +
+	"cookiesTxt\x12\x1c\n" +
+	"\tencrypted\x18\x04 \x01(\bR\tencrypted\x12\x1e\n" +
+	"\n" +
+	"encryption\x18\x05 \x01(\tR\n" +
+	"encryption\x12#\n" +
+	"\rencrypted_key\x18\x06 \x01(\fR\fencryptedKey\x12;\n" +
+	"\vexported_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAt2\x81\a\n" +
 	"\tVKService\x126\n" +
 	"\rCreateAccount\x12\x18.vk.CreateAccountRequest\x1a\v.vk.Account\x120\n" +
 	"\n" +
@@ -841,7 +1752,13 @@ const file_services_vk_service_proto_vk_proto_rawDesc = "" +
 	"\x13UpdateAccountStatus\x12\x17.vk.UpdateStatusRequest\x1a\v.vk.Account\x122\n" +
 	"\x11RetryRegistration\x12\x10.vk.RetryRequest\x1a\v.vk.Account\x12A\n" +
 	"\rDeleteAccount\x12\x18.vk.DeleteAccountRequest\x1a\x16.google.protobuf.Empty\x127\n" +
-	"\rGetStatistics\x12\x16.google.protobuf.Empty\x1a\x0e.vk.StatisticsB5Z3github.com/grigta/conveer/services/vk-service/protob\x06proto3"
+	"\rGetStatistics\x12\x16.google.protobuf.Empty\x1a\x0e.vk.Statistics\x12J\n" +
+	"\x0fPopulateProfile\x12\x1a.vk.PopulateProfileRequest\x1a\x1b.vk.PopulateProfileResponse\x128\n" +
+	"\tBindEmail\x12\x14.vk.BindEmailRequest\x1a\x15.vk.BindEmailResponse\x12L\n" +
+	"\x13CreateAccountsBatch\x12\x1e.vk.CreateAccountsBatchRequest\x1a\x15.vk.RegistrationBatch\x12B\n" +
+	"\x0eGetBatchStatus\x12\x19.vk.GetBatchStatusRequest\x1a\x15.vk.RegistrationBatch\x12<\n" +
+	"\vCancelBatch\x12\x16.vk.CancelBatchRequest\x1a\x15.vk.RegistrationBatch\x12<\n" +
+	"\rExportAccount\x12\x18.vk.ExportAccountRequest\x1a\x11.vk.AccountExportB5Z3github.com/grigta/conveer/services/vk-service/protob\x06proto3"
 
 var (
 	file_services_vk_service_proto_vk_proto_rawDescOnce sync.Once
@@ -855,52 +1772,85 @@ func file_services_vk_service_proto_vk_proto_rawDescGZIP() []byte {
 	return file_services_vk_service_proto_vk_proto_rawDescData
 }
 
-var file_services_vk_service_proto_vk_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_services_vk_service_proto_vk_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_services_vk_service_proto_vk_proto_goTypes = []any{
-	(*CreateAccountRequest)(nil),  // 0: vk.CreateAccountRequest
-	(*GetAccountRequest)(nil),     // 1: vk.GetAccountRequest
-	(*ListAccountsRequest)(nil),   // 2: vk.ListAccountsRequest
-	(*UpdateStatusRequest)(nil),   // 3: vk.UpdateStatusRequest
-	(*RetryRequest)(nil),          // 4: vk.RetryRequest
-	(*DeleteAccountRequest)(nil),  // 5: vk.DeleteAccountRequest
-	(*Account)(nil),               // 6: vk.Account
-	(*ListAccountsResponse)(nil),  // 7: vk.ListAccountsResponse
-	(*Statistics)(nil),            // 8: vk.Statistics
-	(*AccountCredentials)(nil),    // 9: vk.AccountCredentials
-	nil,                           // 10: vk.Account.FingerprintEntry
-	nil,                           // 11: vk.Statistics.ByStatusEntry
-	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 13: google.protobuf.Empty
+	(*CreateAccountRequest)(nil),       // 0: vk.CreateAccountRequest
+	(*GetAccountRequest)(nil),          // 1: vk.GetAccountRequest
+	(*ListAccountsRequest)(nil),        // 2: vk.ListAccountsRequest
+	(*UpdateStatusRequest)(nil),        // 3: vk.UpdateStatusRequest
+	(*RetryRequest)(nil),               // 4: vk.RetryRequest
+	(*DeleteAccountRequest)(nil),       // 5: vk.DeleteAccountRequest
+	(*Account)(nil),                    // 6: vk.Account
+	(*ListAccountsResponse)(nil),       // 7: vk.ListAccountsResponse
+	(*Statistics)(nil),                 // 8: vk.Statistics
+	(*AccountCredentials)(nil),         // 9: vk.AccountCredentials
+	(*PopulateProfileRequest)(nil),     // 10: vk.PopulateProfileRequest
+	(*PopulateProfileResponse)(nil),    // 11: vk.PopulateProfileResponse
+	(*BindEmailRequest)(nil),           // 12: vk.BindEmailRequest
+	(*BindEmailResponse)(nil),          // 13: vk.BindEmailResponse
+	(*CreateAccountsBatchRequest)(nil), // 14: vk.CreateAccountsBatchRequest
+	(*GetBatchStatusRequest)(nil),      // 15: vk.GetBatchStatusRequest
+	(*CancelBatchRequest)(nil),         // 16: vk.CancelBatchRequest
+	(*BatchItem)(nil),                  // 17: vk.BatchItem
+	(*RegistrationBatch)(nil),          // 18: vk.RegistrationBatch
+	(*ExportAccountRequest)(nil),       // 19: vk.ExportAccountRequest
+	(*AccountExport)(nil),              // 20: vk.AccountExport
+	nil,                                // 21: vk.Account.FingerprintEntry
+	nil,                                // 22: vk.Statistics.ByStatusEntry
+	nil,                                // 23: vk.PopulateProfileResponse.FailedStepsEntry
+	(*timestamppb.Timestamp)(nil),      // 24: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),              // 25: google.protobuf.Empty
 }
 var file_services_vk_service_proto_vk_proto_depIdxs = []int32{
-	12, // 0: vk.CreateAccountRequest.birth_date:type_name -> google.protobuf.Timestamp
-	10, // 1: vk.Account.fingerprint:type_name -> vk.Account.FingerprintEntry
-	12, // 2: vk.Account.created_at:type_name -> google.protobuf.Timestamp
-	12, // 3: vk.Account.updated_at:type_name -> google.protobuf.Timestamp
-	12, // 4: vk.Account.last_login_at:type_name -> google.protobuf.Timestamp
-	6,  // 5: vk.ListAccountsResponse.accounts:type_name -> vk.Account
-	11, // 6: vk.Statistics.by_status:type_name -> vk.Statistics.ByStatusEntry
-	0,  // 7: vk.VKService.CreateAccount:input_type -> vk.CreateAccountRequest
-	1,  // 8: vk.VKService.GetAccount:input_type -> vk.GetAccountRequest
-	1,  // 9: vk.VKService.GetAccountCredentials:input_type -> vk.GetAccountRequest
-	2,  // 10: vk.VKService.ListAccounts:input_type -> vk.ListAccountsRequest
-	3,  // 11: vk.VKService.UpdateAccountStatus:input_type -> vk.UpdateStatusRequest
-	4,  // 12: vk.VKService.RetryRegistration:input_type -> vk.RetryRequest
-	5,  // 13: vk.VKService.DeleteAccount:input_type -> vk.DeleteAccountRequest
-	13, // 14: vk.VKService.GetStatistics:input_type -> google.protobuf.Empty
-	6,  // 15: vk.VKService.CreateAccount:output_type -> vk.Account
-	6,  // 16: vk.VKService.GetAccount:output_type -> vk.Account
-	9,  // 17: vk.VKService.GetAccountCredentials:output_type -> vk.AccountCredentials
-	7,  // 18: vk.VKService.ListAccounts:output_type -> vk.ListAccountsResponse
-	6,  // 19: vk.VKService.UpdateAccountStatus:output_type -> vk.Account
-	6,  // 20: vk.VKService.RetryRegistration:output_type -> vk.Account
-	13, // 21: vk.VKService.DeleteAccount:output_type -> google.protobuf.Empty
-	8,  // 22: vk.VKService.GetStatistics:output_type -> vk.Statistics
-	15, // [15:23] is the sub-list for method output_type
-	7,  // [7:15] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	24, // 0: vk.CreateAccountRequest.birth_date:type_name -> google.protobuf.Timestamp
+	21, // 1: vk.Account.fingerprint:type_name -> vk.Account.FingerprintEntry
+	24, // 2: vk.Account.created_at:type_name -> google.protobuf.Timestamp
+	24, // 3: vk.Account.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 4: vk.Account.last_login_at:type_name -> google.protobuf.Timestamp
+	24, // 5: vk.Account.email_bound_at:type_name -> google.protobuf.Timestamp
+	6,  // 6: vk.ListAccountsResponse.accounts:type_name -> vk.Account
+	22, // 7: vk.Statistics.by_status:type_name -> vk.Statistics.ByStatusEntry
+	23, // 8: vk.PopulateProfileResponse.failed_steps:type_name -> vk.PopulateProfileResponse.FailedStepsEntry
+	24, // 9: vk.BindEmailResponse.bound_at:type_name -> google.protobuf.Timestamp
+	24, // 10: vk.BatchItem.updated_at:type_name -> google.protobuf.Timestamp
+	17, // 11: vk.RegistrationBatch.items:type_name -> vk.BatchItem
+	24, // 12: vk.RegistrationBatch.created_at:type_name -> google.protobuf.Timestamp
+	24, // 13: vk.RegistrationBatch.cancelled_at:type_name -> google.protobuf.Timestamp
+	24, // 14: vk.RegistrationBatch.completed_at:type_name -> google.protobuf.Timestamp
+	24, // 15: vk.AccountExport.exported_at:type_name -> google.protobuf.Timestamp
+	0,  // 16: vk.VKService.CreateAccount:input_type -> vk.CreateAccountRequest
+	1,  // 17: vk.VKService.GetAccount:input_type -> vk.GetAccountRequest
+	1,  // 18: vk.VKService.GetAccountCredentials:input_type -> vk.GetAccountRequest
+	2,  // 19: vk.VKService.ListAccounts:input_type -> vk.ListAccountsRequest
+	3,  // 20: vk.VKService.UpdateAccountStatus:input_type -> vk.UpdateStatusRequest
+	4,  // 21: vk.VKService.RetryRegistration:input_type -> vk.RetryRequest
+	5,  // 22: vk.VKService.DeleteAccount:input_type -> vk.DeleteAccountRequest
+	25, // 23: vk.VKService.GetStatistics:input_type -> google.protobuf.Empty
+	10, // 24: vk.VKService.PopulateProfile:input_type -> vk.PopulateProfileRequest
+	12, // 25: vk.VKService.BindEmail:input_type -> vk.BindEmailRequest
+	14, // 26: vk.VKService.CreateAccountsBatch:input_type -> vk.CreateAccountsBatchRequest
+	15, // 27: vk.VKService.GetBatchStatus:input_type -> vk.GetBatchStatusRequest
+	16, // 28: vk.VKService.CancelBatch:input_type -> vk.CancelBatchRequest
+	19, // 29: vk.VKService.ExportAccount:input_type -> vk.ExportAccountRequest
+	6,  // 30: vk.VKService.CreateAccount:output_type -> vk.Account
+	6,  // 31: vk.VKService.GetAccount:output_type -> vk.Account
+	9,  // 32: vk.VKService.GetAccountCredentials:output_type -> vk.AccountCredentials
+	7,  // 33: vk.VKService.ListAccounts:output_type -> vk.ListAccountsResponse
+	6,  // 34: vk.VKService.UpdateAccountStatus:output_type -> vk.Account
+	6,  // 35: vk.VKService.RetryRegistration:output_type -> vk.Account
+	25, // 36: vk.VKService.DeleteAccount:output_type -> google.protobuf.Empty
+	8,  // 37: vk.VKService.GetStatistics:output_type -> vk.Statistics
+	11, // 38: vk.VKService.PopulateProfile:output_type -> vk.PopulateProfileResponse
+	13, // 39: vk.VKService.BindEmail:output_type -> vk.BindEmailResponse
+	18, // 40: vk.VKService.CreateAccountsBatch:output_type -> vk.RegistrationBatch
+	18, // 41: vk.VKService.GetBatchStatus:output_type -> vk.RegistrationBatch
+	18, // 42: vk.VKService.CancelBatch:output_type -> vk.RegistrationBatch
+	20, // 43: vk.VKService.ExportAccount:output_type -> vk.AccountExport
+	30, // [30:44] is the sub-list for method output_type
+	16, // [16:30] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_services_vk_service_proto_vk_proto_init() }
@@ -914,7 +1864,7 @@ func file_services_vk_service_proto_vk_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_vk_service_proto_vk_proto_rawDesc), len(file_services_vk_service_proto_vk_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -28,6 +28,12 @@ const (
 	VKService_RetryRegistration_FullMethodName     = "/vk.VKService/RetryRegistration"
 	VKService_DeleteAccount_FullMethodName         = "/vk.VKService/DeleteAccount"
 	VKService_GetStatistics_FullMethodName         = "/vk.VKService/GetStatistics"
+	VKService_PopulateProfile_FullMethodName       = "/vk.VKService/PopulateProfile"
+	VKService_BindEmail_FullMethodName             = "/vk.VKService/BindEmail"
+	VKService_CreateAccountsBatch_FullMethodName   = "/vk.VKService/CreateAccountsBatch"
+	VKService_GetBatchStatus_FullMethodName        = "/vk.VKService/GetBatchStatus"
+	VKService_CancelBatch_FullMethodName           = "/vk.VKService/CancelBatch"
+	VKService_ExportAccount_FullMethodName         = "/vk.VKService/ExportAccount"
 )
 
 // VKServiceClient is the client API for VKService service.
@@ -42,6 +48,12 @@ type VKServiceClient interface {
 	RetryRegistration(ctx context.Context, in *RetryRequest, opts ...grpc.CallOption) (*Account, error)
 	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetStatistics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Statistics, error)
+	PopulateProfile(ctx context.Context, in *PopulateProfileRequest, opts ...grpc.CallOption) (*PopulateProfileResponse, error)
+	BindEmail(ctx context.Context, in *BindEmailRequest, opts ...grpc.CallOption) (*BindEmailResponse, error)
+	CreateAccountsBatch(ctx context.Context, in *CreateAccountsBatchRequest, opts ...grpc.CallOption) (*RegistrationBatch, error)
+	GetBatchStatus(ctx context.Context, in *GetBatchStatusRequest, opts ...grpc.CallOption) (*RegistrationBatch, error)
+	CancelBatch(ctx context.Context, in *CancelBatchRequest, opts ...grpc.CallOption) (*RegistrationBatch, error)
+	ExportAccount(ctx context.Context, in *ExportAccountRequest, opts ...grpc.CallOption) (*AccountExport, error)
 }
 
 type vKServiceClient struct {
@@ -132,6 +144,66 @@ func (c *vKServiceClient) GetStatistics(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
+func (c *vKServiceClient) PopulateProfile(ctx context.Context, in *PopulateProfileRequest, opts ...grpc.CallOption) (*PopulateProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PopulateProfileResponse)
+	err := c.cc.Invoke(ctx, VKService_PopulateProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vKServiceClient) BindEmail(ctx context.Context, in *BindEmailRequest, opts ...grpc.CallOption) (*BindEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BindEmailResponse)
+	err := c.cc.Invoke(ctx, VKService_BindEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vKServiceClient) CreateAccountsBatch(ctx context.Context, in *CreateAccountsBatchRequest, opts ...grpc.CallOption) (*RegistrationBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationBatch)
+	err := c.cc.Invoke(ctx, VKService_CreateAccountsBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vKServiceClient) GetBatchStatus(ctx context.Context, in *GetBatchStatusRequest, opts ...grpc.CallOption) (*RegistrationBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationBatch)
+	err := c.cc.Invoke(ctx, VKService_GetBatchStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vKServiceClient) CancelBatch(ctx context.Context, in *CancelBatchRequest, opts ...grpc.CallOption) (*RegistrationBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationBatch)
+	err := c.cc.Invoke(ctx, VKService_CancelBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vKServiceClient) ExportAccount(ctx context.Context, in *ExportAccountRequest, opts ...grpc.CallOption) (*AccountExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountExport)
+	err := c.cc.Invoke(ctx, VKService_ExportAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VKServiceServer is the server API for VKService service.
 // All implementations must embed UnimplementedVKServiceServer
 // for forward compatibility.
@@ -144,6 +216,12 @@ type VKServiceServer interface {
 	RetryRegistration(context.Context, *RetryRequest) (*Account, error)
 	DeleteAccount(context.Context, *DeleteAccountRequest) (*emptypb.Empty, error)
 	GetStatistics(context.Context, *emptypb.Empty) (*Statistics, error)
+	PopulateProfile(context.Context, *PopulateProfileRequest) (*PopulateProfileResponse, error)
+	BindEmail(context.Context, *BindEmailRequest) (*BindEmailResponse, error)
+	CreateAccountsBatch(context.Context, *CreateAccountsBatchRequest) (*RegistrationBatch, error)
+	GetBatchStatus(context.Context, *GetBatchStatusRequest) (*RegistrationBatch, error)
+	CancelBatch(context.Context, *CancelBatchRequest) (*RegistrationBatch, error)
+	ExportAccount(context.Context, *ExportAccountRequest) (*AccountExport, error)
 	mustEmbedUnimplementedVKServiceServer()
 }
 
@@ -178,6 +256,24 @@ func (UnimplementedVKServiceServer) DeleteAccount(context.Context, *DeleteAccoun
 func (UnimplementedVKServiceServer) GetStatistics(context.Context, *emptypb.Empty) (*Statistics, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStatistics not implemented")
 }
+func (UnimplementedVKServiceServer) PopulateProfile(context.Context, *PopulateProfileRequest) (*PopulateProfileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PopulateProfile not implemented")
+}
+func (UnimplementedVKServiceServer) BindEmail(context.Context, *BindEmailRequest) (*BindEmailResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BindEmail not implemented")
+}
+func (UnimplementedVKServiceServer) CreateAccountsBatch(context.Context, *CreateAccountsBatchRequest) (*RegistrationBatch, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAccountsBatch not implemented")
+}
+func (UnimplementedVKServiceServer) GetBatchStatus(context.Context, *GetBatchStatusRequest) (*RegistrationBatch, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBatchStatus not implemented")
+}
+func (UnimplementedVKServiceServer) CancelBatch(context.Context, *CancelBatchRequest) (*RegistrationBatch, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelBatch not implemented")
+}
+func (UnimplementedVKServiceServer) ExportAccount(context.Context, *ExportAccountRequest) (*AccountExport, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportAccount not implemented")
+}
 func (UnimplementedVKServiceServer) mustEmbedUnimplementedVKServiceServer() {}
 func (UnimplementedVKServiceServer) testEmbeddedByValue()                   {}
 
@@ -343,6 +439,114 @@ func _VKService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VKService_PopulateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PopulateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VKServiceServer).PopulateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VKService_PopulateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VKServiceServer).PopulateProfile(ctx, req.(*PopulateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VKService_BindEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BindEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VKServiceServer).BindEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VKService_BindEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VKServiceServer).BindEmail(ctx, req.(*BindEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VKService_CreateAccountsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountsBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VKServiceServer).CreateAccountsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VKService_CreateAccountsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VKServiceServer).CreateAccountsBatch(ctx, req.(*CreateAccountsBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VKService_GetBatchStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VKServiceServer).GetBatchStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VKService_GetBatchStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VKServiceServer).GetBatchStatus(ctx, req.(*GetBatchStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VKService_CancelBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VKServiceServer).CancelBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VKService_CancelBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VKServiceServer).CancelBatch(ctx, req.(*CancelBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VKService_ExportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VKServiceServer).ExportAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VKService_ExportAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VKServiceServer).ExportAccount(ctx, req.(*ExportAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VKService_ServiceDesc is the grpc.ServiceDesc for VKService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -382,6 +586,30 @@ var VKService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatistics",
 			Handler:    _VKService_GetStatistics_Handler,
 		},
+		{
+			MethodName: "PopulateProfile",
+			Handler:    _VKService_PopulateProfile_Handler,
+		},
+		{
+			MethodName: "BindEmail",
+			Handler:    _VKService_BindEmail_Handler,
+		},
+		{
+			MethodName: "CreateAccountsBatch",
+			Handler:    _VKService_CreateAccountsBatch_Handler,
+		},
+		{
+			MethodName: "GetBatchStatus",
+			Handler:    _VKService_GetBatchStatus_Handler,
+		},
+		{
+			MethodName: "CancelBatch",
+			Handler:    _VKService_CancelBatch_Handler,
+		},
+		{
+			MethodName: "ExportAccount",
+			Handler:    _VKService_ExportAccount_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/vk-service/proto/vk.proto",
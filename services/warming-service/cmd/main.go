@@ -3,19 +3,21 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
-	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/grigta/conveer/pkg/cache"
+	pkgconfig "github.com/grigta/conveer/pkg/config"
 	"github.com/grigta/conveer/pkg/database"
+	"github.com/grigta/conveer/pkg/health"
 	"github.com/grigta/conveer/pkg/logger"
 	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/pkg/serviceauth"
 	"github.com/grigta/conveer/services/warming-service/internal/config"
 	"github.com/grigta/conveer/services/warming-service/internal/handlers"
 	"github.com/grigta/conveer/services/warming-service/internal/repository"
@@ -23,6 +25,7 @@ import (
 	pb "github.com/grigta/conveer/services/warming-service/proto"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc"
@@ -37,35 +40,51 @@ type GRPCClients struct {
 	MaxClient      *grpc.ClientConn
 }
 
+// serviceAuth attaches warming-service's token to calls of platform services and checks incoming ones
+var serviceAuth = serviceauth.FromEnv("warming-service")
+
 func main() {
 	cfg := config.Load()
-	log := logger.New(cfg.LogLevel)
+	log := logger.NewFromConfig(logger.Config{Service: "warming-service", Level: cfg.LogLevel})
+	logger.SetDefault(log)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	// Initialize MongoDB
-	mongoClient := database.ConnectMongoDB(cfg.MongoURI)
+	mongoClient, err := database.NewMongoClient(cfg.MongoURI)
+	if err != nil {
+		log.Errorf("Failed to connect to MongoDB: %v", err)
+		panic(err)
+	}
 	defer mongoClient.Disconnect(ctx)
 	db := mongoClient.Database(cfg.DatabaseName)
 
 	// Ensure indexes are created
 	if err := ensureIndexes(db); err != nil {
-		log.Error("Failed to ensure indexes: %v", err)
+		log.Errorf("Failed to ensure indexes: %v", err)
 		// Continue anyway, indexes may already exist
 	}
 
 	// Initialize Redis
-	redisClient := cache.ConnectRedis(cfg.RedisURL)
+	redisClient, err := connectRedis(cfg.RedisURL)
+	if err != nil {
+		log.Errorf("Failed to connect to Redis: %v", err)
+		panic(err)
+	}
 	defer redisClient.Close()
 
 	// Initialize RabbitMQ
-	messagingClient := messaging.NewRabbitMQClient(cfg.RabbitMQURL)
+	messagingClient, err := messaging.NewRabbitMQ(cfg.RabbitMQURL)
+	if err != nil {
+		log.Errorf("Failed to connect to RabbitMQ: %v", err)
+		panic(err)
+	}
 	defer messagingClient.Close()
 
 	// Setup RabbitMQ topology
 	if err := setupRabbitMQTopology(messagingClient); err != nil {
-		log.Error("Failed to setup RabbitMQ topology: %v", err)
+		log.Errorf("Failed to setup RabbitMQ topology: %v", err)
 		panic(err)
 	}
 
@@ -77,6 +96,12 @@ func main() {
 	scenarioRepo := repository.NewScenarioRepository(db)
 	statsRepo := repository.NewStatsRepository(db)
 	scheduleRepo := repository.NewScheduleRepository(db)
+	contentRepo := repository.NewContentRepository(db)
+	experimentRepo := repository.NewExperimentRepository(db)
+	evidenceRepo := repository.NewEvidenceRepository(db)
+	if err := evidenceRepo.CreateIndexes(ctx, cfg.WarmingConfig.Verification.Retention); err != nil {
+		log.Errorf("Failed to create evidence indexes: %v", err)
+	}
 
 	// Initialize services
 	warmingService := service.NewWarmingService(
@@ -84,6 +109,9 @@ func main() {
 		scenarioRepo,
 		statsRepo,
 		scheduleRepo,
+		contentRepo,
+		experimentRepo,
+		evidenceRepo,
 		messagingClient,
 		redisClient,
 		grpcClients.VKClient,
@@ -94,9 +122,14 @@ func main() {
 		log,
 	)
 
-	// Initialize metrics
-	metrics := service.NewMetrics()
-	metrics.Register()
+	// Reload scenario limits without restarts
+	if configWatcher := setupConfigWatcher(ctx, cfg, log); configWatcher != nil {
+		defer configWatcher.Stop()
+	}
+
+	// Readiness gates for gRPC health and /health
+	healthChecker := newHealthChecker(mongoClient, redisClient, messagingClient, grpcClients)
+	go healthChecker.Run(ctx, 10*time.Second)
 
 	// Start background workers
 	var wg sync.WaitGroup
@@ -110,14 +143,14 @@ func main() {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		startGRPCServer(cfg.GRPCPort, warmingService, log)
+		startGRPCServer(cfg.GRPCPort, warmingService, healthChecker, log)
 	}()
 
 	// Start HTTP server
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		startHTTPServer(cfg.HTTPPort, warmingService, log)
+		startHTTPServer(cfg.HTTPPort, warmingService, healthChecker, log)
 	}()
 
 	// Wait for termination signal
@@ -143,7 +176,55 @@ func main() {
 	}
 }
 
-func setupRabbitMQTopology(client *messaging.RabbitMQClient) error {
+// setupConfigWatcher applies scenario and concurrency limits when the warming config changes
+func setupConfigWatcher(ctx context.Context, cfg *config.Config, log logger.Logger) *pkgconfig.ConfigWatcher {
+	source, err := pkgconfig.NewConfigSource(pkgconfig.ConfigWatchFromEnv(), "warming", cfg.WarmingConfigPath)
+	if err != nil {
+		log.Warnf("Config hot-reload disabled: %v", err)
+		return nil
+	}
+
+	watcher := pkgconfig.NewConfigWatcher(source)
+	if err := watcher.Load(ctx); err != nil {
+		log.Errorf("Failed to load config for hot-reload: %v", err)
+	}
+
+	limits := []string{"warming.scenarios", "warming.max_concurrent_tasks"}
+	watcher.Subscribe("warming-limits", limits, func(ctx context.Context, changes pkgconfig.ChangeSet) error {
+		warmingConfig, err := config.ParseWarmingConfig(changes.Data)
+		if err != nil {
+			return err
+		}
+
+		cfg.ApplyWarmingLimits(warmingConfig)
+		log.Infof("Applied %d warming limit changes from %s", len(changes.Events), changes.Source)
+		return nil
+	})
+
+	watcher.Start(ctx)
+	return watcher
+}
+
+// connectRedis opens the cache described by a redis:// URL
+func connectRedis(rawURL string) (*cache.RedisCache, error) {
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	host, port, err := net.SplitHostPort(opts.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis address %s: %w", opts.Addr, err)
+	}
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis port %s: %w", port, err)
+	}
+
+	return cache.NewRedisCache(host, portNum, opts.Password, opts.DB)
+}
+
+func setupRabbitMQTopology(client *messaging.RabbitMQ) error {
 	// Declare exchanges
 	exchanges := []struct {
 		name string
@@ -154,7 +235,7 @@ func setupRabbitMQTopology(client *messaging.RabbitMQClient) error {
 	}
 
 	for _, ex := range exchanges {
-		if err := client.DeclareExchange(ex.name, ex.kind); err != nil {
+		if err := client.DeclareExchange(ex.name, ex.kind, true, false); err != nil {
 			return fmt.Errorf("failed to declare exchange %s: %v", ex.name, err)
 		}
 	}
@@ -170,16 +251,17 @@ func setupRabbitMQTopology(client *messaging.RabbitMQClient) error {
 		{"warming.pause", "warming.commands", "pause"},
 		{"warming.resume", "warming.commands", "resume"},
 		{"warming.status_sync", "warming.commands", "status_sync"},
+		{"warming.callbacks", "warming.commands", "callback"},
 		{"warming.auto_start", "", ""}, // Will bind to multiple exchanges
 	}
 
 	for _, q := range queues {
-		if err := client.DeclareQueue(q.name); err != nil {
+		if _, err := client.DeclareQueue(q.name, true, false, false); err != nil {
 			return fmt.Errorf("failed to declare queue %s: %v", q.name, err)
 		}
 
 		if q.exchange != "" {
-			if err := client.BindQueue(q.name, q.exchange, q.routingKey); err != nil {
+			if err := client.BindQueue(q.name, q.routingKey, q.exchange); err != nil {
 				return fmt.Errorf("failed to bind queue %s: %v", q.name, err)
 			}
 		}
@@ -190,11 +272,30 @@ func setupRabbitMQTopology(client *messaging.RabbitMQClient) error {
 	for _, platform := range platforms {
 		exchange := fmt.Sprintf("%s.events", platform)
 		routingKey := fmt.Sprintf("%s.account.created", platform)
-		if err := client.BindQueue("warming.auto_start", exchange, routingKey); err != nil {
+		if err := client.BindQueue("warming.auto_start", routingKey, exchange); err != nil {
 			return fmt.Errorf("failed to bind auto_start to %s: %v", platform, err)
 		}
 	}
 
+	// Bind resurrection queues to status changes and lifted restrictions
+	for _, platform := range platforms {
+		queue := service.ResurrectionQueue(platform)
+		if _, err := client.DeclareQueue(queue, true, false, false); err != nil {
+			return fmt.Errorf("failed to declare queue %s: %v", queue, err)
+		}
+
+		exchange := fmt.Sprintf("%s.events", platform)
+		routingKeys := []string{
+			fmt.Sprintf("%s.account.status_changed.*", platform),
+			fmt.Sprintf("%s.account.appeal_approved", platform),
+		}
+		for _, routingKey := range routingKeys {
+			if err := client.BindQueue(queue, routingKey, exchange); err != nil {
+				return fmt.Errorf("failed to bind %s to %s: %v", queue, routingKey, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -206,29 +307,31 @@ func initializeGRPCClients(cfg *config.Config) *GRPCClients {
 			grpc.MaxCallSendMsgSize(50 * 1024 * 1024), // 50MB
 		),
 	}
+	opts = append(opts, logger.DialOptions()...)
+	opts = append(opts, serviceAuth.DialOptions()...)
 
 	// Connect to VK service
 	vkConn, err := grpc.Dial(cfg.VKServiceURL, opts...)
 	if err != nil {
-		log.Printf("Failed to connect to VK service: %v", err)
+		logger.Errorf("Failed to connect to VK service: %v", err)
 	}
 
 	// Connect to Telegram service
 	telegramConn, err := grpc.Dial(cfg.TelegramServiceURL, opts...)
 	if err != nil {
-		log.Printf("Failed to connect to Telegram service: %v", err)
+		logger.Errorf("Failed to connect to Telegram service: %v", err)
 	}
 
 	// Connect to Mail service
 	mailConn, err := grpc.Dial(cfg.MailServiceURL, opts...)
 	if err != nil {
-		log.Printf("Failed to connect to Mail service: %v", err)
+		logger.Errorf("Failed to connect to Mail service: %v", err)
 	}
 
 	// Connect to Max service
 	maxConn, err := grpc.Dial(cfg.MaxServiceURL, opts...)
 	if err != nil {
-		log.Printf("Failed to connect to Max service: %v", err)
+		logger.Errorf("Failed to connect to Max service: %v", err)
 	}
 
 	return &GRPCClients{
@@ -239,29 +342,53 @@ func initializeGRPCClients(cfg *config.Config) *GRPCClients {
 	}
 }
 
-func startGRPCServer(port int, warmingService service.WarmingService, log logger.Logger) {
+// newHealthChecker gates readiness on storage and the broker, platform services only degrade warming
+func newHealthChecker(mongoClient *mongo.Client, redisClient *cache.RedisCache, messagingClient *messaging.RabbitMQ, clients *GRPCClients) *health.Checker {
+	checker := health.NewChecker("warming-service")
+	checker.Add("mongodb", health.MongoCheck(mongoClient))
+	checker.Add("redis", health.PingCheck(redisClient))
+	checker.Add("rabbitmq", health.PingCheck(messagingClient))
+
+	downstream := map[string]*grpc.ClientConn{
+		"vk-service":       clients.VKClient,
+		"telegram-service": clients.TelegramClient,
+		"mail-service":     clients.MailClient,
+		"max-service":      clients.MaxClient,
+	}
+	for name, conn := range downstream {
+		if conn != nil {
+			checker.AddOptional(name, health.GRPCCheck(conn, ""))
+		}
+	}
+
+	return checker
+}
+
+func startGRPCServer(port int, warmingService service.WarmingService, healthChecker *health.Checker, log logger.Logger) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
-		log.Error("Failed to listen on port %d: %v", port, err)
+		log.Errorf("Failed to listen on port %d: %v", port, err)
 		return
 	}
 
-	grpcServer := grpc.NewServer(
+	serverOpts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(50 * 1024 * 1024), // 50MB
 		grpc.MaxSendMsgSize(50 * 1024 * 1024), // 50MB
-	)
+	}
+	grpcServer := grpc.NewServer(append(append(serverOpts, logger.ServerOptions()...), serviceAuth.ServerOptions()...)...)
 
 	handler := handlers.NewGRPCHandler(warmingService, log)
 	pb.RegisterWarmingServiceServer(grpcServer, handler)
+	healthChecker.Register(grpcServer)
 	reflection.Register(grpcServer)
 
-	log.Info("gRPC server listening on port %d", port)
+	log.Infof("gRPC server listening on port %d", port)
 	if err := grpcServer.Serve(lis); err != nil {
-		log.Error("gRPC server failed: %v", err)
+		log.Errorf("gRPC server failed: %v", err)
 	}
 }
 
-func startHTTPServer(port int, warmingService service.WarmingService, log logger.Logger) {
+func startHTTPServer(port int, warmingService service.WarmingService, healthChecker *health.Checker, log logger.Logger) {
 	router := gin.Default()
 
 	// Middleware
@@ -272,17 +399,15 @@ func startHTTPServer(port int, warmingService service.WarmingService, log logger
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
-	})
+	router.GET("/health", healthChecker.Handler())
 
 	// Initialize HTTP handler
 	httpHandler := handlers.NewHTTPHandler(warmingService, log)
 	httpHandler.RegisterRoutes(router)
 
-	log.Info("HTTP server listening on port %d", port)
+	log.Infof("HTTP server listening on port %d", port)
 	if err := router.Run(fmt.Sprintf(":%d", port)); err != nil {
-		log.Error("HTTP server failed: %v", err)
+		log.Errorf("HTTP server failed: %v", err)
 	}
 }
 
@@ -298,6 +423,14 @@ func ensureIndexes(db *mongo.Database) error {
 		},
 		"warming_actions_log": {
 			{Keys: map[string]interface{}{"task_id": 1, "timestamp": -1}, Options: nil},
+			{Keys: map[string]interface{}{"platform": 1, "timestamp": 1}, Options: nil},
+		},
+		"warming_stats": {
+			{Keys: map[string]interface{}{"platform": 1, "date": 1}, Options: nil},
+		},
+		"warming_content_usage": {
+			{Keys: map[string]interface{}{"account_id": 1, "used_at": -1}, Options: nil},
+			{Keys: map[string]interface{}{"content_id": 1, "used_at": -1}, Options: nil},
 		},
 	}
 
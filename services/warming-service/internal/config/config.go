@@ -1,11 +1,13 @@
 package config
 
 import (
-	"log"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
+
 	"gopkg.in/yaml.v2"
 )
 
@@ -22,7 +24,11 @@ type Config struct {
 	TelegramServiceURL string
 	MailServiceURL     string
 	MaxServiceURL      string
+	WarmingConfigPath  string
 	WarmingConfig      WarmingConfig
+
+	// limitsMu guards the warming limits that can be reloaded at runtime
+	limitsMu sync.RWMutex
 }
 
 type WarmingConfig struct {
@@ -31,6 +37,153 @@ type WarmingConfig struct {
 	Scenarios           map[string]ScenarioConfig `yaml:"scenarios"`
 	MaxConcurrentTasks  int                       `yaml:"max_concurrent_tasks"`
 	EnableAutoStart     bool                      `yaml:"enable_auto_start"`
+	Capacity            CapacityConfig            `yaml:"capacity"`
+	Content             ContentConfig             `yaml:"content"`
+	Resurrection        ResurrectionConfig        `yaml:"resurrection"`
+	Experiments         ExperimentsConfig         `yaml:"experiments"`
+	Verification        VerificationConfig        `yaml:"verification"`
+	Readiness           ReadinessConfig           `yaml:"readiness"`
+	ActionStream        ActionStreamConfig        `yaml:"action_stream"`
+	Callbacks           CallbacksConfig           `yaml:"callbacks"`
+	ActionPlugins       ActionPluginsConfig       `yaml:"action_plugins"`
+}
+
+// ActionPluginsConfig points to the manifest of actions added to platform executors
+type ActionPluginsConfig struct {
+	Manifest string `yaml:"manifest"` // empty leaves only the built-in actions
+}
+
+// ActionPluginManifest lists actions registered on top of the built-in ones of each platform
+type ActionPluginManifest struct {
+	Plugins []ActionPluginSpec `yaml:"plugins"`
+}
+
+// ActionPluginSpec describes one action of a platform and the kind of plugin executing it
+type ActionPluginSpec struct {
+	Platform    string            `yaml:"platform"`
+	Action      string            `yaml:"action"`       // action type used in scenarios
+	Kind        string            `yaml:"kind"`         // stream, alias or simulate
+	Target      string            `yaml:"target"`       // stream: action sent to the service, the action itself when empty; alias: built-in action run
+	Params      map[string]string `yaml:"params"`       // stream: sent with every request
+	DailyLimit  int               `yaml:"daily_limit"`  // actions of this type per task and day, 0 for no limit
+	MinDuration time.Duration     `yaml:"min_duration"` // simulate: shortest dwell time
+	MaxDuration time.Duration     `yaml:"max_duration"` // simulate: longest dwell time
+}
+
+// CallbacksConfig controls the milestone webhooks tasks can be created with
+type CallbacksConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	Secret       string        `yaml:"secret"`        // signs calls of tasks created without their own secret
+	MaxURLs      int           `yaml:"max_urls"`      // callback URLs accepted per task
+	Timeout      time.Duration `yaml:"timeout"`       // one call, the receiver must answer within it
+	MaxAttempts  int           `yaml:"max_attempts"`  // calls failing with network errors, 429 or 5xx are retried
+	RetryBackoff time.Duration `yaml:"retry_backoff"` // pause before the first retry, doubled after each
+}
+
+// ActionStreamConfig controls the gRPC stream actions are sent to platform services over
+type ActionStreamConfig struct {
+	Enabled            bool          `yaml:"enabled"`
+	Timeout            time.Duration `yaml:"timeout"`             // how long the platform service may take for one action
+	MaxAttempts        int           `yaml:"max_attempts"`        // attempts of an action failing with a retryable error
+	RetryBackoff       time.Duration `yaml:"retry_backoff"`       // pause before the first retry, doubled after each
+	UnsupportedRecheck time.Duration `yaml:"unsupported_recheck"` // a service without the stream is asked again after it
+}
+
+// ReadinessConfig controls the readiness score that graduates accounts before the scenario ends
+type ReadinessConfig struct {
+	Enabled   bool                        `yaml:"enabled"`
+	Default   ReadinessFormula            `yaml:"default"`
+	Platforms map[string]ReadinessFormula `yaml:"platforms"` // unset fields fall back to default
+}
+
+// ReadinessFormula weighs the readiness components into a 0-100 score
+type ReadinessFormula struct {
+	Threshold          float64          `yaml:"threshold"`            // score at which the account is marked ready
+	MinDays            int              `yaml:"min_days"`             // no graduation before this day, whatever the score
+	TargetDays         int              `yaml:"target_days"`          // days warmed for the full days component
+	TargetActions      int              `yaml:"target_actions"`       // completed actions for the full actions component
+	IncidentErrorTypes []string         `yaml:"incident_error_types"` // failed actions of these types count as incidents
+	Weights            ReadinessWeights `yaml:"weights"`
+}
+
+type ReadinessWeights struct {
+	Days      float64 `yaml:"days"`
+	Actions   float64 `yaml:"actions"`
+	Incidents float64 `yaml:"incidents"`
+	Profile   float64 `yaml:"profile"`
+}
+
+// VerificationConfig controls evidence sampling used to verify that executed actions took effect
+type VerificationConfig struct {
+	Enabled    bool          `yaml:"enabled"`
+	SampleRate float64       `yaml:"sample_rate"` // share of actions sampled for evidence, 0-1
+	Retention  time.Duration `yaml:"retention"`   // evidence and screenshots expire after it
+	Window     time.Duration `yaml:"window"`      // period the verification rate metric is computed over
+}
+
+// ExperimentsConfig controls scenario A/B experiments run on auto-started accounts
+type ExperimentsConfig struct {
+	Enabled           bool    `yaml:"enabled"`
+	SignificanceLevel float64 `yaml:"significance_level"` // p-value below which a variant differs from the control
+	MinSampleSize     int     `yaml:"min_sample_size"`    // finished tasks per variant before significance is reported
+}
+
+// ResurrectionConfig controls the low-intensity scenario run on accounts returning from quarantine
+type ResurrectionConfig struct {
+	Enabled            bool     `yaml:"enabled"`
+	DurationDays       int      `yaml:"duration_days"`
+	DelayMultiplier    float64  `yaml:"delay_multiplier"`    // stretches the gap between actions compared to regular scenarios
+	CheckpointDays     []int    `yaml:"checkpoint_days"`     // days on which the account is validated before acting
+	QuarantineStatuses []string `yaml:"quarantine_statuses"` // account statuses treated as quarantine
+	ActiveStatuses     []string `yaml:"active_statuses"`     // statuses an account returns to when released
+}
+
+// ContentConfig lists the material used by posting and commenting actions
+type ContentConfig struct {
+	Enabled            bool                         `yaml:"enabled"`
+	DefaultNiche       string                       `yaml:"default_niche"`
+	DedupWindow        time.Duration                `yaml:"dedup_window"`      // an account never repeats an item within the window
+	FleetReuseLimit    int                          `yaml:"fleet_reuse_limit"` // accounts allowed to post the same item within the window
+	RSSRefreshInterval time.Duration                `yaml:"rss_refresh_interval"`
+	Feeds              []FeedConfig                 `yaml:"feeds"`
+	Pools              map[string]ContentPoolConfig `yaml:"pools"` // keyed by niche
+	LLM                LLMConfig                    `yaml:"llm"`
+}
+
+type FeedConfig struct {
+	URL   string `yaml:"url"`
+	Niche string `yaml:"niche"`
+}
+
+type ContentPoolConfig struct {
+	Texts    []string `yaml:"texts"`
+	Comments []string `yaml:"comments"`
+	Images   []string `yaml:"images"`
+}
+
+// LLMConfig configures an optional OpenAI-compatible text generator
+type LLMConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Endpoint string        `yaml:"endpoint"`
+	APIKey   string        `yaml:"api_key"`
+	Model    string        `yaml:"model"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// CapacityConfig describes the executor resources used to admit new warming tasks
+type CapacityConfig struct {
+	Enabled           bool                              `yaml:"enabled"`
+	Policy            string                            `yaml:"policy"` // reject or queue
+	TargetUtilization float64                           `yaml:"target_utilization"`
+	Platforms         map[string]PlatformCapacityConfig `yaml:"platforms"`
+}
+
+type PlatformCapacityConfig struct {
+	BrowserPoolSize     int     `yaml:"browser_pool_size"`
+	AvgActionSeconds    float64 `yaml:"avg_action_seconds"`
+	ProxyCount          int     `yaml:"proxy_count"`
+	ActionsPerProxyHour int     `yaml:"actions_per_proxy_hour"`
+	MaxActionsPerHour   int     `yaml:"max_actions_per_hour"`
 }
 
 type SchedulerConfig struct {
@@ -40,13 +193,17 @@ type SchedulerConfig struct {
 }
 
 type BehaviorSimulationConfig struct {
-	EnableRandomDelays        bool    `yaml:"enable_random_delays"`
-	DelayMinSeconds           int     `yaml:"delay_min_seconds"`
-	DelayMaxSeconds           int     `yaml:"delay_max_seconds"`
-	ActiveHoursStart          int     `yaml:"active_hours_start"`
-	ActiveHoursEnd            int     `yaml:"active_hours_end"`
-	WeekendActivityReduction  float64 `yaml:"weekend_activity_reduction"`
-	NightPauseProbability     float64 `yaml:"night_pause_probability"`
+	EnableRandomDelays       bool     `yaml:"enable_random_delays"`
+	DelayMinSeconds          int      `yaml:"delay_min_seconds"`
+	DelayMaxSeconds          int      `yaml:"delay_max_seconds"`
+	ActiveHoursStart         int      `yaml:"active_hours_start"`
+	ActiveHoursEnd           int      `yaml:"active_hours_end"`
+	WeekendActivityReduction float64  `yaml:"weekend_activity_reduction"`
+	NightPauseProbability    float64  `yaml:"night_pause_probability"`
+	Timezone                 string   `yaml:"timezone"`
+	DayOffProbability        float64  `yaml:"day_off_probability"`
+	Holidays                 []string `yaml:"holidays"`        // YYYY-MM-DD, applied to every account
+	PersonaPresets           bool     `yaml:"persona_presets"` // give each account a hashed persona preset instead of the active hours
 }
 
 type ScenarioConfig map[string]PlatformScenarioConfig
@@ -91,10 +248,10 @@ func Load() *Config {
 	}
 
 	// Load warming config from YAML file
-	configPath := getEnv("WARMING_CONFIG_PATH", "./configs/warming_config.yaml")
-	warmingConfig, err := loadWarmingConfig(configPath)
+	cfg.WarmingConfigPath = getEnv("WARMING_CONFIG_PATH", "./configs/warming_config.yaml")
+	warmingConfig, err := loadWarmingConfig(cfg.WarmingConfigPath)
 	if err != nil {
-		log.Printf("Failed to load warming config from %s, using defaults: %v", configPath, err)
+		logger.Warnf("Failed to load warming config from %s, using defaults: %v", cfg.WarmingConfigPath, err)
 		cfg.WarmingConfig = getDefaultWarmingConfig()
 	} else {
 		cfg.WarmingConfig = *warmingConfig
@@ -109,22 +266,76 @@ func Load() *Config {
 		cfg.WarmingConfig.EnableAutoStart = enableAutoStart == "true"
 	}
 
+	if capacityEnabled := getEnv("WARMING_CAPACITY_ENABLED", ""); capacityEnabled != "" {
+		cfg.WarmingConfig.Capacity.Enabled = capacityEnabled == "true"
+	}
+
+	if policy := getEnv("WARMING_CAPACITY_POLICY", ""); policy != "" {
+		cfg.WarmingConfig.Capacity.Policy = policy
+	}
+
+	if resurrectionEnabled := getEnv("WARMING_RESURRECTION_ENABLED", ""); resurrectionEnabled != "" {
+		cfg.WarmingConfig.Resurrection.Enabled = resurrectionEnabled == "true"
+	}
+
+	if experimentsEnabled := getEnv("WARMING_EXPERIMENTS_ENABLED", ""); experimentsEnabled != "" {
+		cfg.WarmingConfig.Experiments.Enabled = experimentsEnabled == "true"
+	}
+
+	if verificationEnabled := getEnv("WARMING_VERIFICATION_ENABLED", ""); verificationEnabled != "" {
+		cfg.WarmingConfig.Verification.Enabled = verificationEnabled == "true"
+	}
+
+	if readinessEnabled := getEnv("WARMING_READINESS_ENABLED", ""); readinessEnabled != "" {
+		cfg.WarmingConfig.Readiness.Enabled = readinessEnabled == "true"
+	}
+
+	if streamEnabled := getEnv("WARMING_ACTION_STREAM_ENABLED", ""); streamEnabled != "" {
+		cfg.WarmingConfig.ActionStream.Enabled = streamEnabled == "true"
+	}
+
+	if callbacksEnabled := getEnv("WARMING_CALLBACKS_ENABLED", ""); callbacksEnabled != "" {
+		cfg.WarmingConfig.Callbacks.Enabled = callbacksEnabled == "true"
+	}
+
+	if sampleRate := getEnv("WARMING_VERIFICATION_SAMPLE_RATE", ""); sampleRate != "" {
+		if rate, err := strconv.ParseFloat(sampleRate, 64); err == nil && rate >= 0 && rate <= 1 {
+			cfg.WarmingConfig.Verification.SampleRate = rate
+		}
+	}
+
+	// Keep the LLM key out of the config file
+	if apiKey := getEnv("WARMING_LLM_API_KEY", ""); apiKey != "" {
+		cfg.WarmingConfig.Content.LLM.APIKey = apiKey
+	}
+
+	if secret := getEnv("WARMING_CALLBACK_SECRET", ""); secret != "" {
+		cfg.WarmingConfig.Callbacks.Secret = secret
+	}
+
+	if manifest := getEnv("WARMING_ACTION_PLUGINS_MANIFEST", ""); manifest != "" {
+		cfg.WarmingConfig.ActionPlugins.Manifest = manifest
+	}
+
 	return cfg
 }
 
 func loadWarmingConfig(path string) (*WarmingConfig, error) {
-	file, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
+	return ParseWarmingConfig(data)
+}
+
+// ParseWarmingConfig decodes the warming section of a config document and applies defaults
+func ParseWarmingConfig(data []byte) (*WarmingConfig, error) {
 	var config struct {
 		Warming WarmingConfig `yaml:"warming"`
 	}
 
-	decoder := yaml.NewDecoder(file)
-	if err := decoder.Decode(&config); err != nil {
+	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, err
 	}
 
@@ -136,10 +347,219 @@ func loadWarmingConfig(path string) (*WarmingConfig, error) {
 		config.Warming.Scheduler.ActionTimeout = 5 * time.Minute
 	}
 
+	if config.Warming.Content.DefaultNiche == "" {
+		config.Warming.Content.DefaultNiche = "general"
+	}
+	if config.Warming.Content.DedupWindow == 0 {
+		config.Warming.Content.DedupWindow = 30 * 24 * time.Hour
+	}
+	if config.Warming.Content.FleetReuseLimit <= 0 {
+		config.Warming.Content.FleetReuseLimit = 3
+	}
+	if config.Warming.Content.RSSRefreshInterval == 0 {
+		config.Warming.Content.RSSRefreshInterval = time.Hour
+	}
+	if config.Warming.Content.LLM.Timeout == 0 {
+		config.Warming.Content.LLM.Timeout = 30 * time.Second
+	}
+
+	config.Warming.Resurrection.applyDefaults()
+
+	if config.Warming.Experiments.SignificanceLevel <= 0 || config.Warming.Experiments.SignificanceLevel >= 1 {
+		config.Warming.Experiments.SignificanceLevel = 0.05
+	}
+	if config.Warming.Experiments.MinSampleSize <= 0 {
+		config.Warming.Experiments.MinSampleSize = 30
+	}
+
+	config.Warming.Verification.applyDefaults()
+	config.Warming.Readiness.Default.applyDefaults()
+	config.Warming.ActionStream.applyDefaults()
+	config.Warming.Callbacks.applyDefaults()
+
+	if config.Warming.Capacity.Policy == "" {
+		config.Warming.Capacity.Policy = "queue"
+	}
+	if config.Warming.Capacity.TargetUtilization <= 0 || config.Warming.Capacity.TargetUtilization > 1 {
+		config.Warming.Capacity.TargetUtilization = 0.8
+	}
+
 	return &config.Warming, nil
 }
 
+// LoadActionPluginManifest reads the action plugin manifest
+func LoadActionPluginManifest(path string) (*ActionPluginManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseActionPluginManifest(data)
+}
+
+// ParseActionPluginManifest decodes a manifest, entries are checked when they are registered
+func ParseActionPluginManifest(data []byte) (*ActionPluginManifest, error) {
+	var manifest ActionPluginManifest
+	if err := yaml.UnmarshalStrict(data, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// ApplyWarmingLimits swaps scenario limits and the concurrency cap from a reloaded config.
+// WARMING_MAX_CONCURRENT_TASKS keeps precedence over the file, as on startup.
+func (c *Config) ApplyWarmingLimits(warming *WarmingConfig) {
+	c.limitsMu.Lock()
+	defer c.limitsMu.Unlock()
+
+	c.WarmingConfig.Scenarios = warming.Scenarios
+	if getEnvAsInt("WARMING_MAX_CONCURRENT_TASKS", 0) <= 0 && warming.MaxConcurrentTasks > 0 {
+		c.WarmingConfig.MaxConcurrentTasks = warming.MaxConcurrentTasks
+	}
+}
+
+// Scenario returns the per-platform config of a scenario type
+func (c *Config) Scenario(scenarioType string) ScenarioConfig {
+	c.limitsMu.RLock()
+	defer c.limitsMu.RUnlock()
+
+	return c.WarmingConfig.Scenarios[scenarioType]
+}
+
+// MaxConcurrentTasks returns the current cap of tasks executed per scheduler tick
+func (c *Config) MaxConcurrentTasks() int {
+	c.limitsMu.RLock()
+	defer c.limitsMu.RUnlock()
+
+	return c.WarmingConfig.MaxConcurrentTasks
+}
+
+func (r *ResurrectionConfig) applyDefaults() {
+	if r.DurationDays == 0 {
+		r.DurationDays = 14
+	}
+	if r.DelayMultiplier < 1 {
+		r.DelayMultiplier = 3
+	}
+	if len(r.CheckpointDays) == 0 {
+		r.CheckpointDays = []int{0, 3, 7, 13}
+	}
+	if len(r.QuarantineStatuses) == 0 {
+		r.QuarantineStatuses = []string{"suspended", "appealing", "restricted", "quarantine"}
+	}
+	if len(r.ActiveStatuses) == 0 {
+		r.ActiveStatuses = []string{"created", "ready"}
+	}
+}
+
+func (v *VerificationConfig) applyDefaults() {
+	if v.SampleRate <= 0 || v.SampleRate > 1 {
+		v.SampleRate = 0.05
+	}
+	if v.Retention == 0 {
+		v.Retention = 14 * 24 * time.Hour
+	}
+	if v.Window == 0 {
+		v.Window = 24 * time.Hour
+	}
+}
+
+func (a *ActionStreamConfig) applyDefaults() {
+	if a.Timeout == 0 {
+		a.Timeout = 2 * time.Minute
+	}
+	if a.MaxAttempts <= 0 {
+		a.MaxAttempts = 3
+	}
+	if a.RetryBackoff == 0 {
+		a.RetryBackoff = 2 * time.Second
+	}
+	if a.UnsupportedRecheck == 0 {
+		a.UnsupportedRecheck = 10 * time.Minute
+	}
+}
+
+func (c *CallbacksConfig) applyDefaults() {
+	if c.MaxURLs <= 0 {
+		c.MaxURLs = 5
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 5
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = 30 * time.Second
+	}
+}
+
+func (f *ReadinessFormula) applyDefaults() {
+	if f.Threshold <= 0 || f.Threshold > 100 {
+		f.Threshold = 80
+	}
+	if f.MinDays <= 0 {
+		f.MinDays = 7
+	}
+	if f.TargetDays <= 0 {
+		f.TargetDays = 14
+	}
+	if f.TargetActions <= 0 {
+		f.TargetActions = 150
+	}
+	if len(f.IncidentErrorTypes) == 0 {
+		f.IncidentErrorTypes = []string{"captcha", "ban", "auth_failed"}
+	}
+	if f.Weights == (ReadinessWeights{}) {
+		f.Weights = ReadinessWeights{Days: 0.35, Actions: 0.25, Incidents: 0.2, Profile: 0.2}
+	}
+}
+
+// Formula returns the readiness formula of a platform, fields it leaves unset come from the default
+func (r ReadinessConfig) Formula(platform string) ReadinessFormula {
+	formula := r.Default
+	override, ok := r.Platforms[platform]
+	if !ok {
+		return formula
+	}
+
+	if override.Threshold > 0 && override.Threshold <= 100 {
+		formula.Threshold = override.Threshold
+	}
+	if override.MinDays > 0 {
+		formula.MinDays = override.MinDays
+	}
+	if override.TargetDays > 0 {
+		formula.TargetDays = override.TargetDays
+	}
+	if override.TargetActions > 0 {
+		formula.TargetActions = override.TargetActions
+	}
+	if len(override.IncidentErrorTypes) > 0 {
+		formula.IncidentErrorTypes = override.IncidentErrorTypes
+	}
+	if override.Weights != (ReadinessWeights{}) {
+		formula.Weights = override.Weights
+	}
+	return formula
+}
+
 func getDefaultWarmingConfig() WarmingConfig {
+	resurrection := ResurrectionConfig{Enabled: true}
+	resurrection.applyDefaults()
+
+	verification := VerificationConfig{Enabled: true}
+	verification.applyDefaults()
+
+	readiness := ReadinessConfig{Enabled: true}
+	readiness.Default.applyDefaults()
+
+	actionStream := ActionStreamConfig{Enabled: true}
+	actionStream.applyDefaults()
+
+	callbacks := CallbacksConfig{Enabled: true}
+	callbacks.applyDefaults()
+
 	return WarmingConfig{
 		Scheduler: SchedulerConfig{
 			CheckInterval:      5 * time.Minute,
@@ -154,10 +574,32 @@ func getDefaultWarmingConfig() WarmingConfig {
 			ActiveHoursEnd:           23,
 			WeekendActivityReduction: 0.7,
 			NightPauseProbability:    0.9,
+			Timezone:                 "Europe/Moscow",
+			DayOffProbability:        0.05,
 		},
 		MaxConcurrentTasks: 50,
 		EnableAutoStart:    true,
 		Scenarios:          make(map[string]ScenarioConfig),
+		Capacity: CapacityConfig{
+			Policy:            "queue",
+			TargetUtilization: 0.8,
+		},
+		Content: ContentConfig{
+			DefaultNiche:       "general",
+			DedupWindow:        30 * 24 * time.Hour,
+			FleetReuseLimit:    3,
+			RSSRefreshInterval: time.Hour,
+			LLM:                LLMConfig{Timeout: 30 * time.Second},
+		},
+		Resurrection: resurrection,
+		Experiments: ExperimentsConfig{
+			Enabled:           true,
+			SignificanceLevel: 0.05,
+			MinSampleSize:     30,
+		},
+		Verification: verification,
+		Readiness:    readiness,
+		Callbacks:    callbacks,
 	}
 }
 
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
@@ -55,9 +56,20 @@ func (h *GRPCHandler) StartWarming(ctx context.Context, req *pb.StartWarmingRequ
 		scenarioID = &sid
 	}
 
+	var callbacks *models.TaskCallbacks
+	if len(req.CallbackUrls) > 0 {
+		callbacks = &models.TaskCallbacks{URLs: req.CallbackUrls, Secret: req.CallbackSecret}
+	}
+
 	// Start warming
-	task, err := h.service.StartWarming(ctx, accountID, req.Platform, req.ScenarioType, scenarioID, int(req.DurationDays))
+	task, err := h.service.StartWarming(ctx, accountID, req.Platform, req.ScenarioType, scenarioID, int(req.DurationDays), callbacks)
 	if err != nil {
+		if errors.Is(err, service.ErrCapacityExceeded) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if errors.Is(err, service.ErrInvalidCallback) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		h.logger.Error("Failed to start warming: %v", err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -168,6 +180,9 @@ func (h *GRPCHandler) CreateCustomScenario(ctx context.Context, req *pb.CreateSc
 
 	createdScenario, err := h.service.CreateCustomScenario(ctx, scenario)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidScenario) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		h.logger.Error("Failed to create custom scenario: %v", err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -205,6 +220,9 @@ func (h *GRPCHandler) UpdateCustomScenario(ctx context.Context, req *pb.UpdateSc
 
 	updatedScenario, err := h.service.UpdateCustomScenario(ctx, scenarioID, scenario)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidScenario) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		h.logger.Error("Failed to update custom scenario: %v", err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -212,6 +230,133 @@ func (h *GRPCHandler) UpdateCustomScenario(ctx context.Context, req *pb.UpdateSc
 	return h.scenarioToProto(updatedScenario), nil
 }
 
+func (h *GRPCHandler) ValidateScenario(ctx context.Context, req *pb.ValidateScenarioRequest) (*pb.ValidateScenarioResponse, error) {
+	scenario := &models.WarmingScenario{
+		Name:     req.Name,
+		Platform: req.Platform,
+	}
+
+	if req.ActionsJson != "" {
+		if err := json.Unmarshal([]byte(req.ActionsJson), &scenario.Actions); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid actions_json format")
+		}
+	}
+	if req.ScheduleJson != "" {
+		if err := json.Unmarshal([]byte(req.ScheduleJson), &scenario.Schedule); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid schedule_json format")
+		}
+	}
+
+	result := service.ValidateScenario(scenario, int(req.DurationDays))
+
+	resp := &pb.ValidateScenarioResponse{
+		Valid:           result.Valid,
+		Errors:          result.Errors,
+		DurationDays:    int32(result.DurationDays),
+		TotalMinActions: int32(result.TotalMinActions),
+		TotalMaxActions: int32(result.TotalMaxActions),
+	}
+	for _, stage := range result.Stages {
+		resp.Stages = append(resp.Stages, &pb.ScenarioTimelineStage{
+			Stage:            stage.Stage,
+			FromDay:          int32(stage.FromDay),
+			ToDay:            int32(stage.ToDay),
+			MinActionsPerDay: int32(stage.MinActionsPerDay),
+			MaxActionsPerDay: int32(stage.MaxActionsPerDay),
+			MinActions:       int32(stage.MinActions),
+			MaxActions:       int32(stage.MaxActions),
+			ActionShares:     stage.ActionShares,
+		})
+	}
+
+	return resp, nil
+}
+
+func (h *GRPCHandler) SimulateScenario(ctx context.Context, req *pb.SimulateScenarioRequest) (*pb.SimulateScenarioResponse, error) {
+	scenario := &models.WarmingScenario{
+		Name:     req.Name,
+		Platform: req.Platform,
+	}
+
+	if req.ActionsJson != "" {
+		if err := json.Unmarshal([]byte(req.ActionsJson), &scenario.Actions); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid actions_json format")
+		}
+	}
+	if req.ScheduleJson != "" {
+		if err := json.Unmarshal([]byte(req.ScheduleJson), &scenario.Schedule); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid schedule_json format")
+		}
+	}
+
+	params := models.ScenarioSimulationParams{
+		Accounts:     int(req.Accounts),
+		DurationDays: int(req.DurationDays),
+		Seed:         req.Seed,
+	}
+	if req.StartAt != nil {
+		params.StartAt = req.StartAt.AsTime()
+	}
+
+	result := h.service.SimulateScenario(scenario, params)
+
+	resp := &pb.SimulateScenarioResponse{
+		Valid:             result.Valid,
+		Errors:            result.Errors,
+		Accounts:          int32(result.Accounts),
+		DurationDays:      int32(result.DurationDays),
+		Seed:              result.Seed,
+		TotalActions:      int32(result.TotalActions),
+		CapacityPerHour:   result.CapacityPerHour,
+		TimelineTruncated: result.TimelineTruncated,
+		CollisionCounts:   make(map[string]int32, len(result.CollisionCounts)),
+	}
+	if !result.StartAt.IsZero() {
+		resp.StartAt = timestamppb.New(result.StartAt)
+	}
+	for _, volume := range result.DailyVolumes {
+		byType := make(map[string]int32, len(volume.ActionsByType))
+		for actionType, count := range volume.ActionsByType {
+			byType[actionType] = int32(count)
+		}
+		resp.DailyVolumes = append(resp.DailyVolumes, &pb.SimulatedDayVolume{
+			Day:             int32(volume.Day),
+			Date:            volume.Date,
+			Stage:           volume.Stage,
+			Actions:         int32(volume.Actions),
+			ActiveAccounts:  int32(volume.ActiveAccounts),
+			MinPerAccount:   int32(volume.MinPerAccount),
+			MaxPerAccount:   int32(volume.MaxPerAccount),
+			PeakHourActions: int32(volume.PeakHourActions),
+			ActionsByType:   byType,
+		})
+	}
+	for _, action := range result.Timeline {
+		resp.Timeline = append(resp.Timeline, &pb.SimulatedAction{
+			Account:    int32(action.Account),
+			Day:        int32(action.Day),
+			At:         timestamppb.New(action.At),
+			ActionType: action.ActionType,
+		})
+	}
+	for _, collision := range result.Collisions {
+		resp.Collisions = append(resp.Collisions, &pb.SimulationCollision{
+			Type:       collision.Type,
+			Account:    int32(collision.Account),
+			Day:        int32(collision.Day),
+			At:         timestamppb.New(collision.At),
+			ActionType: collision.ActionType,
+			Count:      int32(collision.Count),
+			Limit:      int32(collision.Limit),
+		})
+	}
+	for collisionType, count := range result.CollisionCounts {
+		resp.CollisionCounts[collisionType] = int32(count)
+	}
+
+	return resp, nil
+}
+
 func (h *GRPCHandler) ListScenarios(ctx context.Context, req *pb.ListScenariosRequest) (*pb.ListScenariosResponse, error) {
 	scenarios, err := h.service.ListScenarios(ctx, req.Platform)
 	if err != nil {
@@ -262,6 +407,178 @@ func (h *GRPCHandler) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (
 	}, nil
 }
 
+func (h *GRPCHandler) UpdateActivityCalendar(ctx context.Context, req *pb.UpdateActivityCalendarRequest) (*pb.WarmingTask, error) {
+	taskID, err := primitive.ObjectIDFromHex(req.TaskId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid task_id format")
+	}
+	if req.Calendar == nil {
+		return nil, status.Error(codes.InvalidArgument, "calendar is required")
+	}
+
+	calendar := &models.ActivityCalendar{
+		Timezone:          req.Calendar.Timezone,
+		Persona:           req.Calendar.Persona,
+		WakeHour:          int(req.Calendar.WakeHour),
+		SleepHour:         int(req.Calendar.SleepHour),
+		WeekendWakeHour:   int(req.Calendar.WeekendWakeHour),
+		WeekendSleepHour:  int(req.Calendar.WeekendSleepHour),
+		DayOffProbability: req.Calendar.DayOffProbability,
+		DaysOff:           req.Calendar.DaysOff,
+	}
+	if err := service.ValidateActivityCalendar(calendar); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	task, err := h.service.UpdateActivityCalendar(ctx, taskID, calendar)
+	if err != nil {
+		h.logger.Error("Failed to update activity calendar: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return h.taskToProto(task), nil
+}
+
+func (h *GRPCHandler) GetCapacity(ctx context.Context, req *pb.CapacityRequest) (*pb.CapacityResponse, error) {
+	capacities, err := h.service.GetCapacity(ctx, req.Platform)
+	if err != nil {
+		h.logger.Error("Failed to get capacity: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &pb.CapacityResponse{}
+	for _, c := range capacities {
+		response.Platforms = append(response.Platforms, &pb.PlatformCapacity{
+			Platform:            c.Platform,
+			CapacityPerHour:     c.CapacityPerHour,
+			DemandPerHour:       c.DemandPerHour,
+			Utilization:         c.Utilization,
+			BrowserLimitPerHour: c.BrowserLimitPerHour,
+			ProxyLimitPerHour:   c.ProxyLimitPerHour,
+			PlatformCapPerHour:  c.PlatformCapPerHour,
+			Bottleneck:          c.Bottleneck,
+			AvgActionSeconds:    c.AvgActionSeconds,
+			ExpectedWaitSeconds: c.ExpectedWaitSeconds,
+			ActiveTasks:         int32(c.ActiveTasks),
+			QueuedTasks:         int32(c.QueuedTasks),
+		})
+	}
+
+	return response, nil
+}
+
+func (h *GRPCHandler) CreateExperiment(ctx context.Context, req *pb.CreateExperimentRequest) (*pb.Experiment, error) {
+	experiment := &models.Experiment{
+		Name:        req.Name,
+		Description: req.Description,
+		Platform:    req.Platform,
+		CreatedBy:   req.CreatedBy,
+	}
+
+	for _, v := range req.Variants {
+		variant := models.ExperimentVariant{
+			Name:         v.Name,
+			ScenarioType: v.ScenarioType,
+			DurationDays: int(v.DurationDays),
+			Weight:       int(v.Weight),
+		}
+		if v.ScenarioId != "" {
+			sid, err := primitive.ObjectIDFromHex(v.ScenarioId)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "invalid scenario_id format")
+			}
+			variant.ScenarioID = &sid
+		}
+		experiment.Variants = append(experiment.Variants, variant)
+	}
+
+	created, err := h.service.CreateExperiment(ctx, experiment)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidExperiment) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, service.ErrExperimentConflict) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		h.logger.Error("Failed to create experiment: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return h.experimentToProto(created), nil
+}
+
+func (h *GRPCHandler) StopExperiment(ctx context.Context, req *pb.ExperimentRequest) (*pb.Experiment, error) {
+	experimentID, err := primitive.ObjectIDFromHex(req.ExperimentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid experiment_id format")
+	}
+
+	experiment, err := h.service.StopExperiment(ctx, experimentID)
+	if err != nil {
+		h.logger.Error("Failed to stop experiment: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return h.experimentToProto(experiment), nil
+}
+
+func (h *GRPCHandler) ListExperiments(ctx context.Context, req *pb.ListExperimentsRequest) (*pb.ListExperimentsResponse, error) {
+	experiments, err := h.service.ListExperiments(ctx, req.Platform, req.Status)
+	if err != nil {
+		h.logger.Error("Failed to list experiments: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &pb.ListExperimentsResponse{}
+	for _, experiment := range experiments {
+		response.Experiments = append(response.Experiments, h.experimentToProto(experiment))
+	}
+
+	return response, nil
+}
+
+func (h *GRPCHandler) GetExperimentResults(ctx context.Context, req *pb.ExperimentRequest) (*pb.ExperimentResults, error) {
+	experimentID, err := primitive.ObjectIDFromHex(req.ExperimentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid experiment_id format")
+	}
+
+	results, err := h.service.GetExperimentResults(ctx, experimentID)
+	if err != nil {
+		h.logger.Error("Failed to get experiment results: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &pb.ExperimentResults{
+		ExperimentId: results.ExperimentID,
+		Name:         results.Name,
+		Platform:     results.Platform,
+		Status:       results.Status,
+		Control:      results.Control,
+		Winner:       results.Winner,
+		ComputedAt:   timestamppb.New(results.ComputedAt),
+	}
+	for _, v := range results.Variants {
+		response.Variants = append(response.Variants, &pb.VariantResult{
+			Name:         v.Name,
+			ScenarioType: v.ScenarioType,
+			DurationDays: int32(v.DurationDays),
+			Assigned:     v.Assigned,
+			InProgress:   v.InProgress,
+			Ready:        v.Ready,
+			Banned:       v.Banned,
+			Failed:       v.Failed,
+			ReadyRate:    v.ReadyRate,
+			BanRate:      v.BanRate,
+			SurvivalRate: v.SurvivalRate,
+			PValue:       v.PValue,
+			Significant:  v.Significant,
+		})
+	}
+
+	return response, nil
+}
+
 // Helper functions
 func (h *GRPCHandler) taskToProto(task *models.WarmingTask) *pb.WarmingTask {
 	protoTask := &pb.WarmingTask{
@@ -288,6 +605,27 @@ func (h *GRPCHandler) taskToProto(task *models.WarmingTask) *pb.WarmingTask {
 		protoTask.CompletedAt = timestamppb.New(*task.CompletedAt)
 	}
 
+	if task.Readiness != nil {
+		protoTask.ReadinessScore = task.Readiness.Score
+	}
+
+	if task.Callbacks != nil {
+		protoTask.CallbackUrls = task.Callbacks.URLs
+	}
+
+	if task.Calendar != nil {
+		protoTask.Calendar = &pb.ActivityCalendar{
+			Timezone:          task.Calendar.Timezone,
+			Persona:           task.Calendar.Persona,
+			WakeHour:          int32(task.Calendar.WakeHour),
+			SleepHour:         int32(task.Calendar.SleepHour),
+			WeekendWakeHour:   int32(task.Calendar.WeekendWakeHour),
+			WeekendSleepHour:  int32(task.Calendar.WeekendSleepHour),
+			DayOffProbability: task.Calendar.DayOffProbability,
+			DaysOff:           task.Calendar.DaysOff,
+		}
+	}
+
 	return protoTask
 }
 
@@ -348,3 +686,34 @@ func (h *GRPCHandler) statsToProto(stats *models.AggregatedStats) *pb.WarmingSta
 
 	return protoStats
 }
+
+func (h *GRPCHandler) experimentToProto(experiment *models.Experiment) *pb.Experiment {
+	protoExperiment := &pb.Experiment{
+		Id:          experiment.ID.Hex(),
+		Name:        experiment.Name,
+		Description: experiment.Description,
+		Platform:    experiment.Platform,
+		Status:      experiment.Status,
+		StartedAt:   timestamppb.New(experiment.StartedAt),
+		CreatedBy:   experiment.CreatedBy,
+	}
+
+	if experiment.StoppedAt != nil {
+		protoExperiment.StoppedAt = timestamppb.New(*experiment.StoppedAt)
+	}
+
+	for _, v := range experiment.Variants {
+		variant := &pb.ExperimentVariant{
+			Name:         v.Name,
+			ScenarioType: v.ScenarioType,
+			DurationDays: int32(v.DurationDays),
+			Weight:       int32(v.Weight),
+		}
+		if v.ScenarioID != nil {
+			variant.ScenarioId = v.ScenarioID.Hex()
+		}
+		protoExperiment.Variants = append(protoExperiment.Variants, variant)
+	}
+
+	return protoExperiment
+}
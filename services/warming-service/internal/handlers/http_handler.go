@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -36,18 +37,31 @@ func (h *HTTPHandler) RegisterRoutes(router *gin.Engine) {
 		api.GET("/statistics", h.GetWarmingStatistics)
 		api.POST("/scenarios", h.CreateCustomScenario)
 		api.PUT("/scenarios/:scenarioId", h.UpdateCustomScenario)
+		api.POST("/scenarios/validate", h.ValidateScenario)
+		api.POST("/scenarios/simulate", h.SimulateScenario)
 		api.GET("/scenarios", h.ListScenarios)
 		api.GET("/tasks", h.ListTasks)
+		api.PUT("/:taskId/calendar", h.UpdateActivityCalendar)
+		api.GET("/capacity", h.GetCapacity)
+		api.POST("/experiments", h.CreateExperiment)
+		api.GET("/experiments", h.ListExperiments)
+		api.POST("/experiments/:experimentId/stop", h.StopExperiment)
+		api.GET("/experiments/:experimentId/results", h.GetExperimentResults)
+		api.GET("/verification", h.GetVerificationStats)
+		api.GET("/:taskId/evidence", h.ListActionEvidence)
+		api.GET("/evidence/:evidenceId/screenshot", h.GetEvidenceScreenshot)
 	}
 }
 
 func (h *HTTPHandler) StartWarming(c *gin.Context) {
 	var req struct {
-		AccountID    string `json:"account_id" binding:"required"`
-		Platform     string `json:"platform" binding:"required"`
-		ScenarioType string `json:"scenario_type" binding:"required"`
-		ScenarioID   string `json:"scenario_id"`
-		DurationDays int    `json:"duration_days" binding:"required,min=14,max=60"`
+		AccountID      string   `json:"account_id" binding:"required"`
+		Platform       string   `json:"platform" binding:"required"`
+		ScenarioType   string   `json:"scenario_type" binding:"required"`
+		ScenarioID     string   `json:"scenario_id"`
+		DurationDays   int      `json:"duration_days" binding:"required,min=14,max=60"`
+		CallbackURLs   []string `json:"callback_urls"`
+		CallbackSecret string   `json:"callback_secret"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -71,8 +85,21 @@ func (h *HTTPHandler) StartWarming(c *gin.Context) {
 		scenarioID = &sid
 	}
 
-	task, err := h.service.StartWarming(c.Request.Context(), accountID, req.Platform, req.ScenarioType, scenarioID, req.DurationDays)
+	var callbacks *models.TaskCallbacks
+	if len(req.CallbackURLs) > 0 {
+		callbacks = &models.TaskCallbacks{URLs: req.CallbackURLs, Secret: req.CallbackSecret}
+	}
+
+	task, err := h.service.StartWarming(c.Request.Context(), accountID, req.Platform, req.ScenarioType, scenarioID, req.DurationDays, callbacks)
 	if err != nil {
+		if errors.Is(err, service.ErrCapacityExceeded) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidCallback) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to start warming: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -187,6 +214,10 @@ func (h *HTTPHandler) CreateCustomScenario(c *gin.Context) {
 
 	createdScenario, err := h.service.CreateCustomScenario(c.Request.Context(), &scenario)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidScenario) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to create custom scenario: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -210,6 +241,10 @@ func (h *HTTPHandler) UpdateCustomScenario(c *gin.Context) {
 
 	updatedScenario, err := h.service.UpdateCustomScenario(c.Request.Context(), scenarioID, &scenario)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidScenario) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to update custom scenario: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -218,6 +253,35 @@ func (h *HTTPHandler) UpdateCustomScenario(c *gin.Context) {
 	c.JSON(http.StatusOK, updatedScenario)
 }
 
+// ValidateScenario checks a scenario without saving it and returns the simulated timeline
+func (h *HTTPHandler) ValidateScenario(c *gin.Context) {
+	var req struct {
+		models.WarmingScenario
+		DurationDays int `json:"duration_days"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, service.ValidateScenario(&req.WarmingScenario, req.DurationDays))
+}
+
+// SimulateScenario dry-runs a scenario for a number of accounts and returns the planned actions,
+// daily volumes and rate-limit collisions
+func (h *HTTPHandler) SimulateScenario(c *gin.Context) {
+	var req struct {
+		models.WarmingScenario
+		models.ScenarioSimulationParams
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.service.SimulateScenario(&req.WarmingScenario, req.ScenarioSimulationParams))
+}
+
 func (h *HTTPHandler) ListScenarios(c *gin.Context) {
 	platform := c.Query("platform")
 
@@ -272,3 +336,162 @@ func (h *HTTPHandler) ListTasks(c *gin.Context) {
 		"total": len(tasks),
 	})
 }
+
+func (h *HTTPHandler) UpdateActivityCalendar(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task_id format"})
+		return
+	}
+
+	var calendar models.ActivityCalendar
+	if err := c.ShouldBindJSON(&calendar); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := service.ValidateActivityCalendar(&calendar); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.service.UpdateActivityCalendar(c.Request.Context(), taskID, &calendar)
+	if err != nil {
+		h.logger.Error("Failed to update activity calendar: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *HTTPHandler) GetCapacity(c *gin.Context) {
+	capacities, err := h.service.GetCapacity(c.Request.Context(), c.Query("platform"))
+	if err != nil {
+		h.logger.Error("Failed to get capacity: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"platforms": capacities})
+}
+
+func (h *HTTPHandler) CreateExperiment(c *gin.Context) {
+	var experiment models.Experiment
+	if err := c.ShouldBindJSON(&experiment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.service.CreateExperiment(c.Request.Context(), &experiment)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidExperiment) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrExperimentConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to create experiment: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *HTTPHandler) ListExperiments(c *gin.Context) {
+	experiments, err := h.service.ListExperiments(c.Request.Context(), c.Query("platform"), c.Query("status"))
+	if err != nil {
+		h.logger.Error("Failed to list experiments: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"experiments": experiments})
+}
+
+func (h *HTTPHandler) StopExperiment(c *gin.Context) {
+	experimentID, err := primitive.ObjectIDFromHex(c.Param("experimentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid experiment_id format"})
+		return
+	}
+
+	experiment, err := h.service.StopExperiment(c.Request.Context(), experimentID)
+	if err != nil {
+		h.logger.Error("Failed to stop experiment: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, experiment)
+}
+
+func (h *HTTPHandler) GetExperimentResults(c *gin.Context) {
+	experimentID, err := primitive.ObjectIDFromHex(c.Param("experimentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid experiment_id format"})
+		return
+	}
+
+	results, err := h.service.GetExperimentResults(c.Request.Context(), experimentID)
+	if err != nil {
+		h.logger.Error("Failed to get experiment results: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+func (h *HTTPHandler) GetVerificationStats(c *gin.Context) {
+	scenarios, err := h.service.GetVerificationStats(c.Request.Context(), c.Query("platform"))
+	if err != nil {
+		h.logger.Error("Failed to get verification stats: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"scenarios": scenarios})
+}
+
+func (h *HTTPHandler) ListActionEvidence(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task_id format"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	evidence, err := h.service.ListActionEvidence(c.Request.Context(), taskID, limit)
+	if err != nil {
+		h.logger.Error("Failed to list action evidence: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"evidence": evidence})
+}
+
+func (h *HTTPHandler) GetEvidenceScreenshot(c *gin.Context) {
+	evidenceID, err := primitive.ObjectIDFromHex(c.Param("evidenceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid evidence_id format"})
+		return
+	}
+
+	evidence, err := h.service.GetActionEvidence(c.Request.Context(), evidenceID)
+	if err != nil {
+		h.logger.Error("Failed to get action evidence: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(evidence.Screenshot) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "evidence has no screenshot"})
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", evidence.Screenshot)
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Evidence kinds an executor can attach to a sampled action
+const (
+	EvidenceScreenshot = "screenshot" // page screenshot taken after the action
+	EvidenceDOM        = "dom"        // DOM assertion, e.g. the like button is in the active state
+	EvidenceAPI        = "api"        // platform API response proving the update was accepted
+)
+
+// ActionEvidence proves that a sampled warming action left a visible trace on the platform.
+// A failed assertion after a successful action usually means a selector broke.
+type ActionEvidence struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	TaskID        primitive.ObjectID  `bson:"task_id" json:"task_id"`
+	AccountID     primitive.ObjectID  `bson:"account_id" json:"account_id"`
+	Platform      string              `bson:"platform" json:"platform"`
+	ScenarioType  string              `bson:"scenario_type" json:"scenario_type"`
+	ScenarioID    *primitive.ObjectID `bson:"scenario_id,omitempty" json:"scenario_id,omitempty"`
+	ActionType    string              `bson:"action_type" json:"action_type"`
+	Kind          string              `bson:"kind" json:"kind"`           // screenshot, dom, api
+	Assertion     string              `bson:"assertion" json:"assertion"` // what had to be visible
+	Selector      string              `bson:"selector,omitempty" json:"selector,omitempty"`
+	Verified      bool                `bson:"verified" json:"verified"`
+	Screenshot    []byte              `bson:"screenshot,omitempty" json:"-"`                        // PNG, served separately
+	DOMSnapshot   string              `bson:"dom_snapshot,omitempty" json:"dom_snapshot,omitempty"` // outer HTML of the asserted element
+	Details       map[string]string   `bson:"details,omitempty" json:"details,omitempty"`
+	HasScreenshot bool                `bson:"has_screenshot" json:"has_screenshot"` // screenshots are left out of listings
+	CapturedAt    time.Time           `bson:"captured_at" json:"captured_at"`
+}
+
+// ScenarioVerification is the share of sampled actions of a scenario whose evidence confirmed them
+type ScenarioVerification struct {
+	Platform     string                        `json:"platform"`
+	ScenarioType string                        `json:"scenario_type"`
+	ScenarioID   string                        `json:"scenario_id,omitempty"`
+	Sampled      int64                         `json:"sampled"`
+	Verified     int64                         `json:"verified"`
+	Rate         float64                       `json:"rate"`
+	ByAction     map[string]ActionVerification `json:"by_action"`
+}
+
+type ActionVerification struct {
+	Sampled  int64   `json:"sampled"`
+	Verified int64   `json:"verified"`
+	Rate     float64 `json:"rate"`
+}
+
+// EvidenceCount is the number of sampled actions of a scenario and action type, and how many were verified
+type EvidenceCount struct {
+	Platform     string
+	ScenarioType string
+	ScenarioID   string
+	ActionType   string
+	Sampled      int64
+	Verified     int64
+}
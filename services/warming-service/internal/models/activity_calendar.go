@@ -0,0 +1,20 @@
+package models
+
+// ActivityCalendar describes when an account behaves like an awake human.
+// Hours are local to Timezone; SleepHour <= WakeHour means the window crosses midnight.
+type ActivityCalendar struct {
+	Timezone          string   `bson:"timezone" json:"timezone"`                   // IANA name, e.g. Europe/Moscow
+	Persona           string   `bson:"persona,omitempty" json:"persona,omitempty"` // early_bird, office, night_owl
+	WakeHour          int      `bson:"wake_hour" json:"wake_hour"`
+	SleepHour         int      `bson:"sleep_hour" json:"sleep_hour"`
+	WeekendWakeHour   int      `bson:"weekend_wake_hour" json:"weekend_wake_hour"`
+	WeekendSleepHour  int      `bson:"weekend_sleep_hour" json:"weekend_sleep_hour"`
+	DayOffProbability float64  `bson:"day_off_probability" json:"day_off_probability"` // Chance of a random inactive day
+	DaysOff           []string `bson:"days_off,omitempty" json:"days_off,omitempty"`   // YYYY-MM-DD, holidays and vacations
+}
+
+const (
+	PersonaEarlyBird = "early_bird"
+	PersonaOffice    = "office"
+	PersonaNightOwl  = "night_owl"
+)
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ContentKind tells which action a content item is meant for
+type ContentKind string
+
+const (
+	ContentKindPost    ContentKind = "post"
+	ContentKindComment ContentKind = "comment"
+)
+
+// ContentItem is a piece of material for posting actions
+type ContentItem struct {
+	ID       string      `bson:"content_id" json:"id"` // Stable hash of the content, used for deduplication
+	Kind     ContentKind `bson:"kind" json:"kind"`
+	Niche    string      `bson:"niche" json:"niche"`
+	Source   string      `bson:"source" json:"source"` // pool, rss or llm
+	Text     string      `bson:"text" json:"text"`
+	ImageURL string      `bson:"image_url,omitempty" json:"image_url,omitempty"`
+	Link     string      `bson:"link,omitempty" json:"link,omitempty"`
+}
+
+// ContentUsage records that an account posted a content item
+type ContentUsage struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ContentID string             `bson:"content_id" json:"content_id"`
+	AccountID primitive.ObjectID `bson:"account_id" json:"account_id"`
+	Platform  string             `bson:"platform" json:"platform"`
+	Niche     string             `bson:"niche" json:"niche"`
+	Source    string             `bson:"source" json:"source"`
+	UsedAt    time.Time          `bson:"used_at" json:"used_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ReadinessScore is the latest readiness evaluation of a warming task.
+// Component values are 0-1, the score is their weighted sum scaled to 0-100.
+type ReadinessScore struct {
+	Score       float64   `bson:"score" json:"score"`
+	Threshold   float64   `bson:"threshold" json:"threshold"`
+	Day         int       `bson:"day" json:"day"` // warming day the task was scored on
+	Days        float64   `bson:"days" json:"days"`
+	Actions     float64   `bson:"actions" json:"actions"`
+	Incidents   int       `bson:"incidents" json:"incidents"`
+	Profile     float64   `bson:"profile" json:"profile"`
+	Ready       bool      `bson:"ready" json:"ready"`
+	EvaluatedAt time.Time `bson:"evaluated_at" json:"evaluated_at"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// ResurrectionCheckpoint records a diagnostic check of an account warmed by the resurrection scenario
+type ResurrectionCheckpoint struct {
+	Day       int       `bson:"day" json:"day"`
+	Passed    bool      `bson:"passed" json:"passed"`
+	Error     string    `bson:"error,omitempty" json:"error,omitempty"`
+	CheckedAt time.Time `bson:"checked_at" json:"checked_at"`
+}
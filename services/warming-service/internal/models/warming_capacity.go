@@ -0,0 +1,32 @@
+package models
+
+// PlatformCapacity compares the sustainable action throughput of a platform executor
+// with the demand of its active warming tasks. Rates are actions per hour.
+type PlatformCapacity struct {
+	Platform            string  `json:"platform"`
+	CapacityPerHour     float64 `json:"capacity_per_hour"` // 0 when the platform has no capacity limits configured
+	DemandPerHour       float64 `json:"demand_per_hour"`
+	Utilization         float64 `json:"utilization"`
+	BrowserLimitPerHour float64 `json:"browser_limit_per_hour"`
+	ProxyLimitPerHour   float64 `json:"proxy_limit_per_hour"`
+	PlatformCapPerHour  float64 `json:"platform_cap_per_hour"`
+	Bottleneck          string  `json:"bottleneck"` // browsers, proxies, platform_cap
+	AvgActionSeconds    float64 `json:"avg_action_seconds"`
+	ExpectedWaitSeconds float64 `json:"expected_wait_seconds"` // -1 when the browser pool is saturated
+	ActiveTasks         int     `json:"active_tasks"`
+	QueuedTasks         int     `json:"queued_tasks"`
+}
+
+type AdmissionDecision string
+
+const (
+	AdmissionAccepted AdmissionDecision = "accepted"
+	AdmissionQueued   AdmissionDecision = "queued"
+	AdmissionRejected AdmissionDecision = "rejected"
+)
+
+const (
+	BottleneckBrowsers    = "browsers"
+	BottleneckProxies     = "proxies"
+	BottleneckPlatformCap = "platform_cap"
+)
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Experiment splits new accounts of a platform between scenario variants to measure
+// which one gets accounts ready with fewer bans
+type Experiment struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Name        string              `bson:"name" json:"name"`
+	Description string              `bson:"description,omitempty" json:"description,omitempty"`
+	Platform    string              `bson:"platform" json:"platform"`
+	Status      string              `bson:"status" json:"status"`     // running, stopped
+	Variants    []ExperimentVariant `bson:"variants" json:"variants"` // the first variant is the control
+	CreatedBy   string              `bson:"created_by,omitempty" json:"created_by,omitempty"`
+	StartedAt   time.Time           `bson:"started_at" json:"started_at"`
+	StoppedAt   *time.Time          `bson:"stopped_at,omitempty" json:"stopped_at,omitempty"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+type ExperimentVariant struct {
+	Name         string              `bson:"name" json:"name"`
+	ScenarioType string              `bson:"scenario_type" json:"scenario_type"`
+	ScenarioID   *primitive.ObjectID `bson:"scenario_id,omitempty" json:"scenario_id,omitempty"`
+	DurationDays int                 `bson:"duration_days" json:"duration_days"`
+	Weight       int                 `bson:"weight" json:"weight"` // share of new accounts relative to the other variants
+}
+
+type ExperimentStatus string
+
+const (
+	ExperimentStatusRunning ExperimentStatus = "running"
+	ExperimentStatusStopped ExperimentStatus = "stopped"
+)
+
+// ExperimentAssignment records the variant a task was started with
+type ExperimentAssignment struct {
+	ExperimentID primitive.ObjectID `bson:"experiment_id" json:"experiment_id"`
+	Variant      string             `bson:"variant" json:"variant"`
+}
+
+// ExperimentTaskCount is the number of experiment tasks of a variant sharing a status and last error
+type ExperimentTaskCount struct {
+	Variant   string
+	Status    string
+	LastError string
+	Count     int64
+}
+
+type ExperimentResults struct {
+	ExperimentID string          `json:"experiment_id"`
+	Name         string          `json:"name"`
+	Platform     string          `json:"platform"`
+	Status       string          `json:"status"`
+	Control      string          `json:"control"`
+	Variants     []VariantResult `json:"variants"`
+	Winner       string          `json:"winner,omitempty"` // empty until a variant beats the control significantly
+	ComputedAt   time.Time       `json:"computed_at"`
+}
+
+// VariantResult holds outcome rates of a variant in percent. Ready rate is measured on finished
+// tasks only, ban and survival rates on all assigned accounts.
+type VariantResult struct {
+	Name         string  `json:"name"`
+	ScenarioType string  `json:"scenario_type"`
+	DurationDays int     `json:"duration_days"`
+	Assigned     int64   `json:"assigned"`
+	InProgress   int64   `json:"in_progress"`
+	Ready        int64   `json:"ready"`
+	Banned       int64   `json:"banned"`
+	Failed       int64   `json:"failed"`
+	ReadyRate    float64 `json:"ready_rate"`
+	BanRate      float64 `json:"ban_rate"`
+	SurvivalRate float64 `json:"survival_rate"`
+	PValue       float64 `json:"p_value"`     // ready rate compared with the control
+	Significant  bool    `json:"significant"` // p-value below the significance level with enough finished tasks
+}
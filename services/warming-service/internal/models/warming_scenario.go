@@ -72,6 +72,10 @@ const (
 	ActionTelegramSendMessage      ActionType = "send_message"
 	ActionTelegramCommentPost      ActionType = "comment_post"
 	ActionTelegramCreateChannelPost ActionType = "create_channel_post"
+	ActionTelegramPostStory         ActionType = "post_story"
+	ActionTelegramScheduleMessage   ActionType = "schedule_message"
+	ActionTelegramImportContacts    ActionType = "import_contacts"
+	ActionTelegramSeedDialog        ActionType = "seed_dialog"
 
 	// Mail Actions
 	ActionMailReadEmail    ActionType = "read_email"
@@ -86,3 +90,90 @@ const (
 	ActionMaxUpdateStatus ActionType = "update_status"
 	ActionMaxCreateChat   ActionType = "create_chat"
 )
+
+// ScenarioValidation is the outcome of checking a custom scenario together with the timeline
+// it would produce for a given warming duration
+type ScenarioValidation struct {
+	Valid           bool                    `json:"valid"`
+	Errors          []string                `json:"errors,omitempty"`
+	DurationDays    int                     `json:"duration_days"`
+	Stages          []ScenarioTimelineStage `json:"stages"`
+	TotalMinActions int                     `json:"total_min_actions"`
+	TotalMaxActions int                     `json:"total_max_actions"`
+}
+
+// ScenarioTimelineStage is one schedule stage as the scheduler would run it
+type ScenarioTimelineStage struct {
+	Stage            string             `json:"stage"` // days_1_7, days_8_14, days_15_30, days_31_60
+	FromDay          int                `json:"from_day"`
+	ToDay            int                `json:"to_day"`
+	MinActionsPerDay int                `json:"min_actions_per_day"`
+	MaxActionsPerDay int                `json:"max_actions_per_day"`
+	MinActions       int                `json:"min_actions"`
+	MaxActions       int                `json:"max_actions"`
+	ActionShares     map[string]float64 `json:"action_shares"` // action type -> share of the stage's actions
+}
+
+// Collision types reported by a scenario simulation
+const (
+	CollisionMaxPerDay = "max_per_day" // an account does an action more often than its max_per_day param allows
+	CollisionCapacity  = "capacity"    // the fleet plans more actions in an hour than the platform executor sustains
+)
+
+// ScenarioSimulationParams describes the fleet a scenario is simulated for
+type ScenarioSimulationParams struct {
+	Accounts     int       `json:"accounts"`
+	DurationDays int       `json:"duration_days"`
+	StartAt      time.Time `json:"start_at"` // first warming day, tomorrow when zero
+	Seed         int64     `json:"seed"`     // same seed and params give the same timeline, random when zero
+}
+
+// ScenarioSimulation is a dry run of a scenario's scheduling for a number of accounts.
+// No action is executed and nothing is stored.
+type ScenarioSimulation struct {
+	Valid             bool                  `json:"valid"`
+	Errors            []string              `json:"errors,omitempty"`
+	Accounts          int                   `json:"accounts"`
+	DurationDays      int                   `json:"duration_days"`
+	StartAt           time.Time             `json:"start_at"`
+	Seed              int64                 `json:"seed"`
+	TotalActions      int                   `json:"total_actions"`
+	CapacityPerHour   float64               `json:"capacity_per_hour"` // 0 when the platform has no limits configured
+	DailyVolumes      []SimulatedDayVolume  `json:"daily_volumes"`
+	Timeline          []SimulatedAction     `json:"timeline"`
+	TimelineTruncated bool                  `json:"timeline_truncated"`
+	Collisions        []SimulationCollision `json:"collisions"`
+	CollisionCounts   map[string]int        `json:"collision_counts"` // collision type -> total, including the ones not listed
+}
+
+// SimulatedAction is one action the scheduler would plan
+type SimulatedAction struct {
+	Account    int       `json:"account"` // 1-based index of the simulated account
+	Day        int       `json:"day"`
+	At         time.Time `json:"at"`
+	ActionType string    `json:"action_type"`
+}
+
+// SimulatedDayVolume sums up one warming day across the simulated fleet
+type SimulatedDayVolume struct {
+	Day             int            `json:"day"`
+	Date            string         `json:"date"`
+	Stage           string         `json:"stage"`
+	Actions         int            `json:"actions"`
+	ActiveAccounts  int            `json:"active_accounts"` // accounts not on a day off
+	MinPerAccount   int            `json:"min_per_account"`
+	MaxPerAccount   int            `json:"max_per_account"`
+	PeakHourActions int            `json:"peak_hour_actions"`
+	ActionsByType   map[string]int `json:"actions_by_type"`
+}
+
+// SimulationCollision is a point where the simulated schedule runs into a rate limit
+type SimulationCollision struct {
+	Type       string    `json:"type"`
+	Account    int       `json:"account,omitempty"` // 0 for fleet-wide collisions
+	Day        int       `json:"day"`
+	At         time.Time `json:"at"`
+	ActionType string    `json:"action_type,omitempty"`
+	Count      int       `json:"count"`
+	Limit      int       `json:"limit"`
+}
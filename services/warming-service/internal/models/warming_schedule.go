@@ -51,8 +51,13 @@ type ExecutionContext struct {
 	CurrentDay        int                    `bson:"current_day" json:"current_day"`
 	TotalDays         int                    `bson:"total_days" json:"total_days"`
 	ActionsToday      int                    `bson:"actions_today" json:"actions_today"`
+	Niche             string                 `bson:"niche,omitempty" json:"niche,omitempty"`
 	LastActionTime    *time.Time             `bson:"last_action_time,omitempty" json:"last_action_time,omitempty"`
 	SessionStartTime  time.Time              `bson:"session_start_time" json:"session_start_time"`
 	Credentials       map[string]interface{} `bson:"credentials,omitempty" json:"credentials,omitempty"`
 	BrowserProfile    map[string]interface{} `bson:"browser_profile,omitempty" json:"browser_profile,omitempty"`
+	CaptureEvidence   bool                   `bson:"-" json:"-"` // the action was sampled for verification
+	Evidence          *ActionEvidence        `bson:"-" json:"-"` // attached by the executor when CaptureEvidence is set
+	Attempts          int                    `bson:"-" json:"-"` // attempts the platform service made, set by streamed actions
+	PlatformLatencyMs int64                  `bson:"-" json:"-"` // time the platform service spent on the action
 }
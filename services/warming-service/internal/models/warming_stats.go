@@ -23,6 +23,9 @@ type WarmingStats struct {
 	ByScenarioType   map[string]int64   `bson:"by_scenario_type" json:"by_scenario_type"`
 	ByActionType     map[string]int64   `bson:"by_action_type" json:"by_action_type"`
 	ErrorTypes       map[string]int64   `bson:"error_types" json:"error_types"`
+	ActionSuccesses  map[string]int64   `bson:"action_successes" json:"action_successes"`
+	ActionDurationMs map[string]int64   `bson:"action_duration_ms" json:"action_duration_ms"` // summed per action type
+	RolledUpAt       *time.Time         `bson:"rolled_up_at,omitempty" json:"rolled_up_at,omitempty"` // set once the day's action logs are folded in
 	CreatedAt        time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt        time.Time          `bson:"updated_at" json:"updated_at"`
 }
@@ -43,6 +46,8 @@ type WarmingActionLog struct {
 	UserAgent    string             `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
 	IPAddress    string             `bson:"ip_address,omitempty" json:"ip_address,omitempty"`
 	ResponseCode int                `bson:"response_code,omitempty" json:"response_code,omitempty"`
+	Attempts     int                `bson:"attempts,omitempty" json:"attempts,omitempty"`
+	PlatformLatencyMs int64         `bson:"platform_latency_ms,omitempty" json:"platform_latency_ms,omitempty"` // part of DurationMs spent in the platform service
 	Metadata     map[string]interface{} `bson:"metadata,omitempty" json:"metadata,omitempty"`
 }
 
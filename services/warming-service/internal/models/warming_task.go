@@ -10,10 +10,10 @@ type WarmingTask struct {
 	ID               primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	AccountID        primitive.ObjectID `bson:"account_id" json:"account_id"`
 	Platform         string             `bson:"platform" json:"platform"` // vk, telegram, mail, max
-	ScenarioType     string             `bson:"scenario_type" json:"scenario_type"` // basic, advanced, custom
+	ScenarioType     string             `bson:"scenario_type" json:"scenario_type"` // basic, advanced, custom, resurrection
 	ScenarioID       primitive.ObjectID `bson:"scenario_id,omitempty" json:"scenario_id,omitempty"`
 	DurationDays     int                `bson:"duration_days" json:"duration_days"` // 14-30 or 30-60
-	Status           string             `bson:"status" json:"status"` // scheduled, in_progress, paused, completed, failed
+	Status           string             `bson:"status" json:"status"` // queued, scheduled, in_progress, paused, completed, failed
 	CurrentDay       int                `bson:"current_day" json:"current_day"`
 	NextActionAt     *time.Time         `bson:"next_action_at,omitempty" json:"next_action_at,omitempty"`
 	ActionsCompleted int                `bson:"actions_completed" json:"actions_completed"`
@@ -23,11 +23,32 @@ type WarmingTask struct {
 	UpdatedAt        time.Time          `bson:"updated_at" json:"updated_at"`
 	CompletedAt      *time.Time         `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
 	Metadata         map[string]interface{} `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	Calendar         *ActivityCalendar      `bson:"calendar,omitempty" json:"calendar,omitempty"`
+	Checkpoints      []ResurrectionCheckpoint `bson:"checkpoints,omitempty" json:"checkpoints,omitempty"`
+	Experiment       *ExperimentAssignment    `bson:"experiment,omitempty" json:"experiment,omitempty"`
+	Readiness        *ReadinessScore          `bson:"readiness,omitempty" json:"readiness,omitempty"`
+	Callbacks        *TaskCallbacks           `bson:"callbacks,omitempty" json:"callbacks,omitempty"`
 }
 
+// TaskCallbacks are webhooks called on the task's milestones
+type TaskCallbacks struct {
+	URLs   []string `bson:"urls" json:"urls"`
+	Secret string   `bson:"secret,omitempty" json:"-"` // signs the calls instead of the service secret
+}
+
+// Milestones reported to task callbacks
+const (
+	MilestoneDayCompleted = "day_completed"
+	MilestoneHalfway      = "progress_50"
+	MilestoneCompleted    = "completed"
+	MilestoneFailed       = "failed"
+	MilestoneStopped      = "stopped" // stopped by hand before the scenario ended
+)
+
 type WarmingTaskStatus string
 
 const (
+	TaskStatusQueued     WarmingTaskStatus = "queued" // waiting for executor capacity
 	TaskStatusScheduled  WarmingTaskStatus = "scheduled"
 	TaskStatusInProgress WarmingTaskStatus = "in_progress"
 	TaskStatusPaused     WarmingTaskStatus = "paused"
@@ -50,6 +71,8 @@ const (
 	ScenarioBasic    ScenarioType = "basic"
 	ScenarioAdvanced ScenarioType = "advanced"
 	ScenarioCustom   ScenarioType = "custom"
+	// ScenarioResurrection brings an account back after quarantine or a soft restriction
+	ScenarioResurrection ScenarioType = "resurrection"
 )
 
 type TaskFilter struct {
@@ -69,4 +92,7 @@ type TaskUpdate struct {
 	ActionsFailed    *int
 	LastError        *string
 	CompletedAt      *time.Time
+	Calendar         *ActivityCalendar
+	Checkpoint       *ResurrectionCheckpoint // appended to the task's checkpoints
+	Readiness        *ReadinessScore
 }
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ContentRepository interface {
+	RecordUsage(ctx context.Context, usage *models.ContentUsage) error
+	GetAccountUsage(ctx context.Context, accountID primitive.ObjectID, since time.Time) (map[string]bool, error)
+	GetFleetUsage(ctx context.Context, contentIDs []string, since time.Time) (map[string]int, error)
+}
+
+type contentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewContentRepository(db *mongo.Database) ContentRepository {
+	return &contentRepository{
+		collection: db.Collection("warming_content_usage"),
+	}
+}
+
+func (r *contentRepository) RecordUsage(ctx context.Context, usage *models.ContentUsage) error {
+	if usage.ID.IsZero() {
+		usage.ID = primitive.NewObjectID()
+	}
+	if usage.UsedAt.IsZero() {
+		usage.UsedAt = time.Now()
+	}
+
+	_, err := r.collection.InsertOne(ctx, usage)
+	return err
+}
+
+// GetAccountUsage returns the IDs of content items the account used since the given time
+func (r *contentRepository) GetAccountUsage(ctx context.Context, accountID primitive.ObjectID, since time.Time) (map[string]bool, error) {
+	filter := bson.M{
+		"account_id": accountID,
+		"used_at":    bson.M{"$gte": since},
+	}
+
+	ids, err := r.collection.Distinct(ctx, "content_id", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if contentID, ok := id.(string); ok {
+			used[contentID] = true
+		}
+	}
+	return used, nil
+}
+
+// GetFleetUsage counts how many accounts used each content item since the given time
+func (r *contentRepository) GetFleetUsage(ctx context.Context, contentIDs []string, since time.Time) (map[string]int, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"content_id": bson.M{"$in": contentIDs},
+			"used_at":    bson.M{"$gte": since},
+		}},
+		{"$group": bson.M{
+			"_id":      "$content_id",
+			"accounts": bson.M{"$addToSet": "$account_id"},
+		}},
+		{"$project": bson.M{
+			"count": bson.M{"$size": "$accounts"},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID    string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]int, len(results))
+	for _, result := range results {
+		usage[result.ID] = result.Count
+	}
+	return usage, nil
+}
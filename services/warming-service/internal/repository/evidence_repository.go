@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type EvidenceRepository interface {
+	CreateIndexes(ctx context.Context, retention time.Duration) error
+	Save(ctx context.Context, evidence *models.ActionEvidence) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*models.ActionEvidence, error)
+	ListByTask(ctx context.Context, taskID primitive.ObjectID, limit int) ([]*models.ActionEvidence, error)
+	CountVerifications(ctx context.Context, platform string, since time.Time) ([]models.EvidenceCount, error)
+}
+
+type evidenceRepository struct {
+	collection *mongo.Collection
+}
+
+func NewEvidenceRepository(db *mongo.Database) EvidenceRepository {
+	return &evidenceRepository{
+		collection: db.Collection("warming_action_evidence"),
+	}
+}
+
+// CreateIndexes creates the task lookup index and the TTL index that expires old artifacts
+func (r *evidenceRepository) CreateIndexes(ctx context.Context, retention time.Duration) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "task_id", Value: 1},
+				{Key: "captured_at", Value: -1},
+			},
+		},
+		{
+			Keys:    bson.D{{Key: "captured_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+		},
+	}
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create action evidence indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *evidenceRepository) Save(ctx context.Context, evidence *models.ActionEvidence) error {
+	evidence.CapturedAt = time.Now()
+	evidence.HasScreenshot = len(evidence.Screenshot) > 0
+
+	result, err := r.collection.InsertOne(ctx, evidence)
+	if err != nil {
+		return fmt.Errorf("failed to save action evidence: %w", err)
+	}
+
+	evidence.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *evidenceRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.ActionEvidence, error) {
+	var evidence models.ActionEvidence
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&evidence)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("action evidence not found")
+		}
+		return nil, fmt.Errorf("failed to get action evidence: %w", err)
+	}
+
+	return &evidence, nil
+}
+
+// ListByTask returns the latest evidence of a task without the screenshots
+func (r *evidenceRepository) ListByTask(ctx context.Context, taskID primitive.ObjectID, limit int) ([]*models.ActionEvidence, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "captured_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"screenshot": 0})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"task_id": taskID}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list action evidence: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var evidence []*models.ActionEvidence
+	if err := cursor.All(ctx, &evidence); err != nil {
+		return nil, fmt.Errorf("failed to decode action evidence: %w", err)
+	}
+
+	return evidence, nil
+}
+
+// CountVerifications counts sampled and verified actions per scenario and action type since the given time.
+// An empty platform counts all platforms.
+func (r *evidenceRepository) CountVerifications(ctx context.Context, platform string, since time.Time) ([]models.EvidenceCount, error) {
+	match := bson.M{"captured_at": bson.M{"$gte": since}}
+	if platform != "" {
+		match["platform"] = platform
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"platform":      "$platform",
+				"scenario_type": "$scenario_type",
+				"scenario_id":   "$scenario_id",
+				"action_type":   "$action_type",
+			},
+			"sampled":  bson.M{"$sum": 1},
+			"verified": bson.M{"$sum": bson.M{"$cond": bson.A{"$verified", 1, 0}}},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count verifications: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID struct {
+			Platform     string              `bson:"platform"`
+			ScenarioType string              `bson:"scenario_type"`
+			ScenarioID   *primitive.ObjectID `bson:"scenario_id"`
+			ActionType   string              `bson:"action_type"`
+		} `bson:"_id"`
+		Sampled  int64 `bson:"sampled"`
+		Verified int64 `bson:"verified"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode verifications: %w", err)
+	}
+
+	counts := make([]models.EvidenceCount, 0, len(rows))
+	for _, row := range rows {
+		count := models.EvidenceCount{
+			Platform:     row.ID.Platform,
+			ScenarioType: row.ID.ScenarioType,
+			ActionType:   row.ID.ActionType,
+			Sampled:      row.Sampled,
+			Verified:     row.Verified,
+		}
+		if row.ID.ScenarioID != nil {
+			count.ScenarioID = row.ID.ScenarioID.Hex()
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ExperimentRepository interface {
+	Create(ctx context.Context, experiment *models.Experiment) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Experiment, error)
+	GetRunning(ctx context.Context, platform string) (*models.Experiment, error)
+	List(ctx context.Context, platform, status string) ([]*models.Experiment, error)
+	Stop(ctx context.Context, id primitive.ObjectID) error
+}
+
+type experimentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewExperimentRepository(db *mongo.Database) ExperimentRepository {
+	return &experimentRepository{
+		collection: db.Collection("warming_experiments"),
+	}
+}
+
+func (r *experimentRepository) Create(ctx context.Context, experiment *models.Experiment) error {
+	now := time.Now()
+	experiment.CreatedAt = now
+	experiment.UpdatedAt = now
+	experiment.StartedAt = now
+	experiment.Status = string(models.ExperimentStatusRunning)
+
+	result, err := r.collection.InsertOne(ctx, experiment)
+	if err != nil {
+		return fmt.Errorf("failed to create experiment: %w", err)
+	}
+
+	experiment.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *experimentRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Experiment, error) {
+	var experiment models.Experiment
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&experiment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("experiment not found")
+		}
+		return nil, fmt.Errorf("failed to get experiment: %w", err)
+	}
+
+	return &experiment, nil
+}
+
+// GetRunning returns the running experiment of a platform or nil when there is none
+func (r *experimentRepository) GetRunning(ctx context.Context, platform string) (*models.Experiment, error) {
+	var experiment models.Experiment
+
+	filter := bson.M{
+		"platform": platform,
+		"status":   string(models.ExperimentStatusRunning),
+	}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&experiment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get running experiment: %w", err)
+	}
+
+	return &experiment, nil
+}
+
+func (r *experimentRepository) List(ctx context.Context, platform, status string) ([]*models.Experiment, error) {
+	filter := bson.M{}
+	if platform != "" {
+		filter["platform"] = platform
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var experiments []*models.Experiment
+	if err = cursor.All(ctx, &experiments); err != nil {
+		return nil, fmt.Errorf("failed to decode experiments: %w", err)
+	}
+
+	return experiments, nil
+}
+
+func (r *experimentRepository) Stop(ctx context.Context, id primitive.ObjectID) error {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":     string(models.ExperimentStatusStopped),
+			"stopped_at": now,
+			"updated_at": now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "status": string(models.ExperimentStatusRunning)}, update)
+	if err != nil {
+		return fmt.Errorf("failed to stop experiment: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("experiment not found or already stopped")
+	}
+
+	return nil
+}
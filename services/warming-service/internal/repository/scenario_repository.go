@@ -108,7 +108,7 @@ func (r *scenarioRepository) List(ctx context.Context, platform string) ([]*mode
 		filter["platform"] = platform
 	}
 
-	findOptions := options.Find().SetSort(bson.D{{"created_at", -1}})
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -87,7 +87,7 @@ func (r *scheduleRepository) GetTodayActions(ctx context.Context, taskID primiti
 	var todayActions []models.PlannedAction
 
 	for _, action := range schedule.PlannedActions {
-		if action.PlannedAt.Format("2006-01-02") == today {
+		if action.ScheduledAt.Format("2006-01-02") == today {
 			if actionType == "" || action.ActionType == actionType {
 				todayActions = append(todayActions, action)
 			}
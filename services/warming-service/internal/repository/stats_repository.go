@@ -17,12 +17,15 @@ type StatsRepository interface {
 	SaveActionLog(ctx context.Context, log *models.WarmingActionLog) error
 	GetActionLogs(ctx context.Context, taskID primitive.ObjectID, limit int) ([]*models.WarmingActionLog, error)
 	UpdateDailyStats(ctx context.Context, platform string, stats *models.WarmingStats) error
+	SaveActionRollup(ctx context.Context, stats *models.WarmingStats) error
+	AggregateActionLogs(ctx context.Context, platform string, startTime, endTime time.Time) (*models.WarmingStats, error)
 	GetDailyStats(ctx context.Context, platform string, startDate, endDate time.Time) ([]*models.WarmingStats, error)
 	GetAggregatedStats(ctx context.Context, platform string, startDate, endDate time.Time) (*models.AggregatedStats, error)
 	GetTopActions(ctx context.Context, platform string, limit int) ([]models.ActionStatistic, error)
 	GetCommonErrors(ctx context.Context, platform string, limit int) ([]models.ErrorStatistic, error)
 	CleanupOldLogs(ctx context.Context, retentionDays int) error
 	CountActionsByType(ctx context.Context, taskID primitive.ObjectID, actionType string, startTime, endTime time.Time) (int, error)
+	CountFailedActions(ctx context.Context, taskID primitive.ObjectID, errorTypes []string) (int, error)
 }
 
 type statsRepository struct {
@@ -52,7 +55,7 @@ func (r *statsRepository) GetActionLogs(ctx context.Context, taskID primitive.Ob
 	filter := bson.M{"task_id": taskID}
 
 	findOptions := options.Find().
-		SetSort(bson.D{{"timestamp", -1}}).
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
 		SetLimit(int64(limit))
 
 	cursor, err := r.actionLogCollection.Find(ctx, filter, findOptions)
@@ -87,14 +90,45 @@ func (r *statsRepository) UpdateDailyStats(ctx context.Context, platform string,
 			"failed_tasks":       stats.FailedTasks,
 			"in_progress_tasks":  stats.InProgressTasks,
 			"paused_tasks":       stats.PausedTasks,
-			"total_actions":      stats.TotalActions,
-			"successful_actions": stats.SuccessfulActions,
-			"failed_actions":     stats.FailedActions,
 			"avg_duration_days":  stats.AvgDurationDays,
 			"success_rate":       stats.SuccessRate,
 			"by_scenario_type":   stats.ByScenarioType,
+			"updated_at":         now,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": now,
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	_, err := r.statsCollection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return fmt.Errorf("failed to update daily stats: %w", err)
+	}
+
+	return nil
+}
+
+// SaveActionRollup stores the action counters of a finished day. Task counters written by
+// UpdateDailyStats are left untouched.
+func (r *statsRepository) SaveActionRollup(ctx context.Context, stats *models.WarmingStats) error {
+	now := time.Now()
+
+	filter := bson.M{
+		"platform": stats.Platform,
+		"date":     stats.Date,
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"total_actions":      stats.TotalActions,
+			"successful_actions": stats.SuccessfulActions,
+			"failed_actions":     stats.FailedActions,
 			"by_action_type":     stats.ByActionType,
+			"action_successes":   stats.ActionSuccesses,
+			"action_duration_ms": stats.ActionDurationMs,
 			"error_types":        stats.ErrorTypes,
+			"rolled_up_at":       now,
 			"updated_at":         now,
 		},
 		"$setOnInsert": bson.M{
@@ -105,12 +139,124 @@ func (r *statsRepository) UpdateDailyStats(ctx context.Context, platform string,
 	opts := options.Update().SetUpsert(true)
 	_, err := r.statsCollection.UpdateOne(ctx, filter, update, opts)
 	if err != nil {
-		return fmt.Errorf("failed to update daily stats: %w", err)
+		return fmt.Errorf("failed to save action rollup: %w", err)
 	}
 
 	return nil
 }
 
+// AggregateActionLogs folds the raw action logs of a platform in [startTime, endTime) into
+// action counters
+func (r *statsRepository) AggregateActionLogs(ctx context.Context, platform string, startTime, endTime time.Time) (*models.WarmingStats, error) {
+	match := bson.M{
+		"platform": platform,
+		"timestamp": bson.M{
+			"$gte": startTime,
+			"$lt":  endTime,
+		},
+	}
+
+	stats := &models.WarmingStats{
+		Platform:         platform,
+		Date:             startTime,
+		ByActionType:     make(map[string]int64),
+		ActionSuccesses:  make(map[string]int64),
+		ActionDurationMs: make(map[string]int64),
+		ErrorTypes:       make(map[string]int64),
+	}
+
+	actionPipeline := []bson.M{
+		{"$match": match},
+		{
+			"$group": bson.M{
+				"_id":   "$action_type",
+				"count": bson.M{"$sum": 1},
+				"successful": bson.M{
+					"$sum": bson.M{
+						"$cond": bson.M{
+							"if":   bson.M{"$eq": []interface{}{"$status", "success"}},
+							"then": 1,
+							"else": 0,
+						},
+					},
+				},
+				"failed": bson.M{
+					"$sum": bson.M{
+						"$cond": bson.M{
+							"if":   bson.M{"$eq": []interface{}{"$status", "failed"}},
+							"then": 1,
+							"else": 0,
+						},
+					},
+				},
+				"duration_ms": bson.M{"$sum": "$duration_ms"},
+			},
+		},
+	}
+
+	cursor, err := r.actionLogCollection.Aggregate(ctx, actionPipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate action logs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var actions []bson.M
+	if err = cursor.All(ctx, &actions); err != nil {
+		return nil, fmt.Errorf("failed to decode action aggregation: %w", err)
+	}
+
+	for _, result := range actions {
+		actionType, _ := result["_id"].(string)
+		if actionType == "" {
+			continue
+		}
+
+		count := getInt64(result, "count")
+		successful := getInt64(result, "successful")
+
+		stats.TotalActions += count
+		stats.SuccessfulActions += successful
+		stats.FailedActions += getInt64(result, "failed")
+		stats.ByActionType[actionType] = count
+		stats.ActionSuccesses[actionType] = successful
+		stats.ActionDurationMs[actionType] = getInt64(result, "duration_ms")
+	}
+
+	errorMatch := bson.M{"status": "failed"}
+	for key, value := range match {
+		errorMatch[key] = value
+	}
+
+	errorPipeline := []bson.M{
+		{"$match": errorMatch},
+		{
+			"$group": bson.M{
+				"_id":   "$error_type",
+				"count": bson.M{"$sum": 1},
+			},
+		},
+	}
+
+	errCursor, err := r.actionLogCollection.Aggregate(ctx, errorPipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate action errors: %w", err)
+	}
+	defer errCursor.Close(ctx)
+
+	var errorResults []bson.M
+	if err = errCursor.All(ctx, &errorResults); err != nil {
+		return nil, fmt.Errorf("failed to decode error aggregation: %w", err)
+	}
+
+	for _, result := range errorResults {
+		if errorType, ok := result["_id"].(string); ok && errorType != "" {
+			stats.ErrorTypes[errorType] = getInt64(result, "count")
+		}
+	}
+
+	return stats, nil
+}
+
 func (r *statsRepository) GetDailyStats(ctx context.Context, platform string, startDate, endDate time.Time) ([]*models.WarmingStats, error) {
 	filter := bson.M{
 		"date": bson.M{
@@ -123,7 +269,7 @@ func (r *statsRepository) GetDailyStats(ctx context.Context, platform string, st
 		filter["platform"] = platform
 	}
 
-	findOptions := options.Find().SetSort(bson.D{{"date", 1}})
+	findOptions := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
 
 	cursor, err := r.statsCollection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -387,6 +533,22 @@ func (r *statsRepository) CountActionsByType(ctx context.Context, taskID primiti
 	return int(count), nil
 }
 
+// CountFailedActions counts failed actions of the task with one of the error types
+func (r *statsRepository) CountFailedActions(ctx context.Context, taskID primitive.ObjectID, errorTypes []string) (int, error) {
+	filter := bson.M{
+		"task_id":    taskID,
+		"status":     "failed",
+		"error_type": bson.M{"$in": errorTypes},
+	}
+
+	count, err := r.actionLogCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count failed actions: %w", err)
+	}
+
+	return int(count), nil
+}
+
 // Helper functions
 func getInt64(m bson.M, key string) int64 {
 	if val, ok := m[key]; ok {
@@ -26,6 +26,7 @@ type TaskRepository interface {
 	GetStuckTasks(ctx context.Context, stuckDuration time.Duration) ([]*models.WarmingTask, error)
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	Count(ctx context.Context, filter models.TaskFilter) (int64, error)
+	CountExperimentTasks(ctx context.Context, experimentID primitive.ObjectID) ([]models.ExperimentTaskCount, error)
 }
 
 type taskRepository struct {
@@ -108,6 +109,15 @@ func (r *taskRepository) Update(ctx context.Context, id primitive.ObjectID, upda
 	if update.CompletedAt != nil {
 		updateDoc["$set"].(bson.M)["completed_at"] = *update.CompletedAt
 	}
+	if update.Calendar != nil {
+		updateDoc["$set"].(bson.M)["calendar"] = update.Calendar
+	}
+	if update.Readiness != nil {
+		updateDoc["$set"].(bson.M)["readiness"] = update.Readiness
+	}
+	if update.Checkpoint != nil {
+		updateDoc["$push"] = bson.M{"checkpoints": update.Checkpoint}
+	}
 
 	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, updateDoc)
 	if err != nil {
@@ -196,7 +206,7 @@ func (r *taskRepository) List(ctx context.Context, filter models.TaskFilter) ([]
 	if filter.Offset > 0 {
 		findOptions.SetSkip(int64(filter.Offset))
 	}
-	findOptions.SetSort(bson.D{{"created_at", -1}})
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
 
 	cursor, err := r.collection.Find(ctx, findFilter, findOptions)
 	if err != nil {
@@ -223,7 +233,7 @@ func (r *taskRepository) GetTasksForExecution(ctx context.Context, limit int) ([
 	if limit > 0 {
 		findOptions.SetLimit(int64(limit))
 	}
-	findOptions.SetSort(bson.D{{"next_action_at", 1}})
+	findOptions.SetSort(bson.D{{Key: "next_action_at", Value: 1}})
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -290,3 +300,48 @@ func (r *taskRepository) Count(ctx context.Context, filter models.TaskFilter) (i
 
 	return count, nil
 }
+
+// CountExperimentTasks groups the tasks of an experiment by variant, status and last error
+func (r *taskRepository) CountExperimentTasks(ctx context.Context, experimentID primitive.ObjectID) ([]models.ExperimentTaskCount, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"experiment.experiment_id": experimentID}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"variant":    "$experiment.variant",
+				"status":     "$status",
+				"last_error": "$last_error",
+			},
+			"count": bson.M{"$sum": 1},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count experiment tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID struct {
+			Variant   string `bson:"variant"`
+			Status    string `bson:"status"`
+			LastError string `bson:"last_error"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode experiment tasks: %w", err)
+	}
+
+	counts := make([]models.ExperimentTaskCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, models.ExperimentTaskCount{
+			Variant:   row.ID.Variant,
+			Status:    row.ID.Status,
+			LastError: row.ID.LastError,
+			Count:     row.Count,
+		})
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+)
+
+// Kinds of plugins the manifest can declare
+const (
+	PluginKindStream   = "stream"   // the action is sent to the platform service over the action stream
+	PluginKindAlias    = "alias"    // the action runs a built-in action of the platform executor
+	PluginKindSimulate = "simulate" // the action only takes time, like the view actions of the executors
+)
+
+// ActionPlugin executes one action type of a platform
+type ActionPlugin interface {
+	Execute(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error
+}
+
+// ActionPluginFunc adapts a function to ActionPlugin
+type ActionPluginFunc func(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error
+
+func (f ActionPluginFunc) Execute(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
+	return f(ctx, task, execCtx)
+}
+
+// PluginPlatform is what a plugin factory can build on: the platform executor and its action stream
+type PluginPlatform struct {
+	Executor PlatformExecutor
+	Stream   *ActionStream // nil when the action stream is disabled
+}
+
+// ActionPluginFactory builds the plugin of a manifest entry
+type ActionPluginFactory func(spec config.ActionPluginSpec, platform PluginPlatform) (ActionPlugin, error)
+
+type registeredAction struct {
+	plugin     ActionPlugin
+	kind       string // empty for built-in actions
+	dailyLimit int
+}
+
+// ActionRegistry maps action types of each platform to the plugins executing them. Built-in
+// actions of the platform executors are registered first, the manifest adds new ones without
+// changes to the scheduler or the executors.
+type ActionRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]ActionPluginFactory
+	platforms map[string]PluginPlatform
+	actions   map[string]map[string]registeredAction // platform -> action type
+	logger    logger.Logger
+}
+
+func NewActionRegistry(logger logger.Logger) *ActionRegistry {
+	r := &ActionRegistry{
+		factories: make(map[string]ActionPluginFactory),
+		platforms: make(map[string]PluginPlatform),
+		actions:   make(map[string]map[string]registeredAction),
+		logger:    logger,
+	}
+
+	r.RegisterKind(PluginKindStream, newStreamPlugin)
+	r.RegisterKind(PluginKindAlias, newAliasPlugin)
+	r.RegisterKind(PluginKindSimulate, newSimulatePlugin)
+
+	return r
+}
+
+// RegisterKind adds a plugin kind manifests can use, replacing a kind of the same name
+func (r *ActionRegistry) RegisterKind(kind string, factory ActionPluginFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[kind] = factory
+}
+
+// RegisterPlatform registers the built-in actions of a platform executor and keeps the
+// executor and stream for plugins of the platform
+func (r *ActionRegistry) RegisterPlatform(name string, platform PluginPlatform) {
+	r.mu.Lock()
+	r.platforms[name] = platform
+	r.mu.Unlock()
+
+	limits := platform.Executor.GetActionLimits()
+	for _, action := range platform.Executor.GetSupportedActions() {
+		action := action
+		executor := platform.Executor
+		plugin := ActionPluginFunc(func(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
+			return executor.ExecuteAction(ctx, task, action, execCtx)
+		})
+		// Limits of built-in actions stay advisory, as before plugins
+		r.register(name, action, registeredAction{plugin: plugin, dailyLimit: limits[action]})
+	}
+}
+
+// Register adds a plugin for an action type of the platform
+func (r *ActionRegistry) Register(platform, action string, plugin ActionPlugin, dailyLimit int) error {
+	r.mu.RLock()
+	_, exists := r.actions[platform][action]
+	r.mu.RUnlock()
+
+	if exists {
+		return fmt.Errorf("action %s is already registered on %s", action, platform)
+	}
+
+	r.register(platform, action, registeredAction{plugin: plugin, kind: "custom", dailyLimit: dailyLimit})
+	return nil
+}
+
+func (r *ActionRegistry) register(platform, action string, entry registeredAction) {
+	r.mu.Lock()
+	if r.actions[platform] == nil {
+		r.actions[platform] = make(map[string]registeredAction)
+	}
+	r.actions[platform][action] = entry
+	r.mu.Unlock()
+
+	if entry.kind != "" {
+		registerScenarioAction(platform, action)
+	}
+}
+
+// LoadManifest registers the plugins of a manifest. An invalid entry is skipped, the others
+// are registered and the problems are returned together.
+func (r *ActionRegistry) LoadManifest(manifest *config.ActionPluginManifest) error {
+	var problems []error
+	for i, spec := range manifest.Plugins {
+		if err := r.registerSpec(spec); err != nil {
+			problems = append(problems, fmt.Errorf("plugin %d (%s/%s): %w", i+1, spec.Platform, spec.Action, err))
+			continue
+		}
+		r.logger.Info("Registered %s action plugin %s on %s", spec.Kind, spec.Action, spec.Platform)
+	}
+	return errors.Join(problems...)
+}
+
+func (r *ActionRegistry) registerSpec(spec config.ActionPluginSpec) error {
+	if spec.Action == "" {
+		return fmt.Errorf("action is required")
+	}
+	if spec.DailyLimit < 0 {
+		return fmt.Errorf("daily_limit must not be negative")
+	}
+
+	r.mu.RLock()
+	platform, platformOK := r.platforms[spec.Platform]
+	factory, kindOK := r.factories[spec.Kind]
+	_, exists := r.actions[spec.Platform][spec.Action]
+	r.mu.RUnlock()
+
+	switch {
+	case !platformOK:
+		return fmt.Errorf("unknown platform %q", spec.Platform)
+	case !kindOK:
+		return fmt.Errorf("unknown kind %q", spec.Kind)
+	case exists:
+		return fmt.Errorf("action is already registered")
+	}
+
+	plugin, err := factory(spec, platform)
+	if err != nil {
+		return err
+	}
+
+	r.register(spec.Platform, spec.Action, registeredAction{plugin: plugin, kind: spec.Kind, dailyLimit: spec.DailyLimit})
+	return nil
+}
+
+// Execute runs the action with its plugin. Plugin actions over their daily limit fail with a
+// rate limit error that neither pauses nor stops the task.
+func (r *ActionRegistry) Execute(ctx context.Context, platform string, task *models.WarmingTask, actionType string, execCtx *models.ExecutionContext) error {
+	r.mu.RLock()
+	entry, ok := r.actions[platform][actionType]
+	executor, platformOK := r.platforms[platform]
+	r.mu.RUnlock()
+
+	if !ok {
+		// Unlisted actions go to the executor as before, it rejects the ones it does not know
+		if !platformOK {
+			return fmt.Errorf("executor not found for platform %s", platform)
+		}
+		return executor.Executor.ExecuteAction(ctx, task, actionType, execCtx)
+	}
+
+	if entry.kind != "" && entry.dailyLimit > 0 && execCtx.ActionsToday >= entry.dailyLimit {
+		return &ActionExecutionError{
+			Type:    ErrorTypeRateLimit,
+			Message: fmt.Sprintf("daily limit of %d %s actions reached", entry.dailyLimit, actionType),
+		}
+	}
+
+	return entry.plugin.Execute(ctx, task, execCtx)
+}
+
+// Supports reports whether the platform has the action
+func (r *ActionRegistry) Supports(platform, actionType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.actions[platform][actionType]
+	return ok
+}
+
+// Actions returns the action types registered on the platform, sorted
+func (r *ActionRegistry) Actions(platform string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	actions := make([]string, 0, len(r.actions[platform]))
+	for action := range r.actions[platform] {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// newStreamPlugin sends the action to the platform service, which must serve it over the action stream
+func newStreamPlugin(spec config.ActionPluginSpec, platform PluginPlatform) (ActionPlugin, error) {
+	if platform.Stream == nil {
+		return nil, fmt.Errorf("the action stream of %s is disabled", spec.Platform)
+	}
+
+	remote := spec.Target
+	if remote == "" {
+		remote = spec.Action
+	}
+
+	return ActionPluginFunc(func(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
+		result, err := platform.Stream.Execute(ctx, execCtx, remote, spec.Params)
+		if err != nil {
+			return err
+		}
+		if result.ErrorType == ErrorTypeUnsupported {
+			return &ActionExecutionError{
+				Type:    ErrorTypeUnsupported,
+				Message: fmt.Sprintf("%s service does not serve action %s", spec.Platform, remote),
+			}
+		}
+		return actionResultError(result)
+	}), nil
+}
+
+// newAliasPlugin runs a built-in action under another name, e.g. to weigh it separately in scenarios
+func newAliasPlugin(spec config.ActionPluginSpec, platform PluginPlatform) (ActionPlugin, error) {
+	if spec.Target == "" {
+		return nil, fmt.Errorf("target is required")
+	}
+
+	supported := false
+	for _, action := range platform.Executor.GetSupportedActions() {
+		if action == spec.Target {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("target %q is not a built-in action of %s", spec.Target, spec.Platform)
+	}
+
+	return ActionPluginFunc(func(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
+		return platform.Executor.ExecuteAction(ctx, task, spec.Target, execCtx)
+	}), nil
+}
+
+// newSimulatePlugin waits a random time between the min and max duration
+func newSimulatePlugin(spec config.ActionPluginSpec, platform PluginPlatform) (ActionPlugin, error) {
+	if spec.MinDuration <= 0 || spec.MaxDuration < spec.MinDuration {
+		return nil, fmt.Errorf("min_duration must be positive and not greater than max_duration")
+	}
+
+	return ActionPluginFunc(func(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
+		dwell := spec.MinDuration
+		if spread := spec.MaxDuration - spec.MinDuration; spread > 0 {
+			dwell += time.Duration(rand.Int63n(int64(spread) + 1))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dwell):
+			return nil
+		}
+	}), nil
+}
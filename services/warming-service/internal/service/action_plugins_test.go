@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// recordingExecutor remembers the actions it was asked to execute
+type recordingExecutor struct {
+	BaseExecutor
+	executed []string
+}
+
+func newRecordingExecutor(actions ...string) *recordingExecutor {
+	return &recordingExecutor{BaseExecutor: BaseExecutor{supportedActions: actions, actionLimits: map[string]int{}}}
+}
+
+func (e *recordingExecutor) ExecuteAction(ctx context.Context, task *models.WarmingTask, actionType string, execCtx *models.ExecutionContext) error {
+	e.executed = append(e.executed, actionType)
+	return nil
+}
+
+func (e *recordingExecutor) ValidateAccount(ctx context.Context, accountID primitive.ObjectID) error {
+	return nil
+}
+
+func TestActionRegistryBuiltInActions(t *testing.T) {
+	executor := newRecordingExecutor("view_profile", "like_post")
+	registry := NewActionRegistry(new(MockLogger))
+	registry.RegisterPlatform("vk", PluginPlatform{Executor: executor})
+
+	assert.Equal(t, []string{"like_post", "view_profile"}, registry.Actions("vk"))
+
+	task := &models.WarmingTask{ID: primitive.NewObjectID()}
+	require.NoError(t, registry.Execute(context.Background(), "vk", task, "like_post", newTestExecutionContext()))
+	// Unlisted actions still reach the executor
+	require.NoError(t, registry.Execute(context.Background(), "vk", task, "join_group_later", newTestExecutionContext()))
+	assert.Equal(t, []string{"like_post", "join_group_later"}, executor.executed)
+
+	err := registry.Execute(context.Background(), "ok", task, "like_post", newTestExecutionContext())
+	assert.EqualError(t, err, "executor not found for platform ok")
+}
+
+func TestActionRegistryLoadManifest(t *testing.T) {
+	manifest, err := config.ParseActionPluginManifest([]byte(`
+plugins:
+  - platform: vk
+    action: comment_clip
+    kind: alias
+    target: comment_post
+    daily_limit: 2
+  - platform: vk
+    action: view_story
+    kind: simulate
+    min_duration: 1ms
+    max_duration: 2ms
+  - platform: vk
+    action: vote_poll
+    kind: stream
+  - platform: vk
+    action: like_post
+    kind: simulate
+    min_duration: 1ms
+    max_duration: 2ms
+  - platform: vk
+    action: repost
+    kind: alias
+    target: repost
+  - platform: ok
+    action: view_story
+    kind: simulate
+`))
+	require.NoError(t, err)
+
+	executor := newRecordingExecutor("like_post", "comment_post")
+	registry := NewActionRegistry(new(MockLogger))
+	registry.RegisterPlatform("vk", PluginPlatform{Executor: executor})
+
+	err = registry.LoadManifest(manifest)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "plugin 3 (vk/vote_poll): the action stream of vk is disabled")
+	assert.Contains(t, err.Error(), "plugin 4 (vk/like_post): action is already registered")
+	assert.Contains(t, err.Error(), `plugin 5 (vk/repost): target "repost" is not a built-in action of vk`)
+	assert.Contains(t, err.Error(), `plugin 6 (ok/view_story): unknown platform "ok"`)
+
+	assert.True(t, registry.Supports("vk", "comment_clip"))
+	assert.True(t, registry.Supports("vk", "view_story"))
+	assert.False(t, registry.Supports("vk", "vote_poll"))
+
+	task := &models.WarmingTask{ID: primitive.NewObjectID()}
+	require.NoError(t, registry.Execute(context.Background(), "vk", task, "comment_clip", newTestExecutionContext()))
+	require.NoError(t, registry.Execute(context.Background(), "vk", task, "view_story", newTestExecutionContext()))
+	assert.Equal(t, []string{"comment_post"}, executor.executed)
+
+	// Scenarios of the platform may now use the plugin actions
+	allowed, ok := allowedScenarioActions("vk")
+	require.True(t, ok)
+	assert.True(t, allowed["comment_clip"])
+	assert.True(t, allowed["view_story"])
+}
+
+func TestActionRegistryDailyLimit(t *testing.T) {
+	executor := newRecordingExecutor("comment_post")
+	registry := NewActionRegistry(new(MockLogger))
+	registry.RegisterPlatform("vk", PluginPlatform{Executor: executor})
+
+	require.NoError(t, registry.LoadManifest(&config.ActionPluginManifest{Plugins: []config.ActionPluginSpec{
+		{Platform: "vk", Action: "comment_clip", Kind: PluginKindAlias, Target: "comment_post", DailyLimit: 2},
+	}}))
+
+	execCtx := newTestExecutionContext()
+	execCtx.ActionsToday = 2
+	err := registry.Execute(context.Background(), "vk", &models.WarmingTask{}, "comment_clip", execCtx)
+
+	var actionErr *ActionExecutionError
+	require.ErrorAs(t, err, &actionErr)
+	assert.Equal(t, ErrorTypeRateLimit, actionErr.Type)
+	assert.False(t, actionErr.ShouldPause)
+	assert.False(t, actionErr.ShouldStop)
+	assert.Empty(t, executor.executed)
+}
+
+func TestActionRegistryCustomKind(t *testing.T) {
+	registry := NewActionRegistry(new(MockLogger))
+	registry.RegisterPlatform("max", PluginPlatform{Executor: newRecordingExecutor()})
+
+	var got string
+	registry.RegisterKind("echo", func(spec config.ActionPluginSpec, platform PluginPlatform) (ActionPlugin, error) {
+		return ActionPluginFunc(func(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
+			got = spec.Params["text"]
+			return nil
+		}), nil
+	})
+
+	require.NoError(t, registry.LoadManifest(&config.ActionPluginManifest{Plugins: []config.ActionPluginSpec{
+		{Platform: "max", Action: "echo", Kind: "echo", Params: map[string]string{"text": "hi"}},
+	}}))
+	require.NoError(t, registry.Execute(context.Background(), "max", &models.WarmingTask{}, "echo", newTestExecutionContext()))
+	assert.Equal(t, "hi", got)
+}
+
+func TestStreamPlugin(t *testing.T) {
+	srv := &fakeActionExecutor{results: []*warmingpb.ActionResult{
+		{Success: true},
+		{ErrorType: ErrorTypeUnsupported, Error: "unknown action"},
+	}}
+	stream := NewActionStream("telegram", dialActionExecutor(t, srv), testActionStreamConfig(), nil, new(MockLogger))
+
+	plugin, err := newStreamPlugin(config.ActionPluginSpec{
+		Platform: "telegram",
+		Action:   "view_story",
+		Target:   "read_stories",
+		Params:   map[string]string{"source": "contacts"},
+	}, PluginPlatform{Stream: stream})
+	require.NoError(t, err)
+
+	require.NoError(t, plugin.Execute(context.Background(), &models.WarmingTask{}, newTestExecutionContext()))
+	require.Len(t, srv.requests, 1)
+	assert.Equal(t, "read_stories", srv.requests[0].ActionType)
+	assert.Equal(t, "contacts", srv.requests[0].Params["source"])
+
+	err = plugin.Execute(context.Background(), &models.WarmingTask{}, newTestExecutionContext())
+	assert.True(t, isActionErrorType(err, ErrorTypeUnsupported))
+}
+
+func TestSimulatePluginValidation(t *testing.T) {
+	_, err := newSimulatePlugin(config.ActionPluginSpec{MinDuration: time.Second}, PluginPlatform{})
+	assert.Error(t, err)
+
+	plugin, err := newSimulatePlugin(config.ActionPluginSpec{MinDuration: time.Hour, MaxDuration: time.Hour}, PluginPlatform{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, plugin.Execute(ctx, &models.WarmingTask{}, newTestExecutionContext()), context.Canceled)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error types platform services report over the action stream in addition to the ErrorType* ones
+const (
+	ErrorTypeUnsupported = "unsupported" // the service has no stream handler for the action
+	ErrorTypeNotFound    = "not_found"   // nothing to act on, e.g. every contact already got a greeting
+)
+
+var (
+	// errActionStreamUnavailable means the action never reached the platform service,
+	// so it is safe to run it with the unary call instead
+	errActionStreamUnavailable = errors.New("action stream unavailable")
+	// errActionOutcomeUnknown means the stream broke after the action was sent. The action may
+	// have been performed, so it is neither retried nor run again with the unary call.
+	errActionOutcomeUnknown = errors.New("action outcome unknown")
+)
+
+// ActionStream keeps one ExecuteActions stream open to a platform service. Actions of all tasks
+// share it, results are matched to the waiting executor by request ID.
+type ActionStream struct {
+	platform string
+	client   warmingpb.ActionExecutorClient
+	cfg      config.ActionStreamConfig
+	metrics  *Metrics
+	logger   logger.Logger
+
+	mu               sync.Mutex
+	conn             *actionConn
+	unsupportedUntil time.Time // the service answered Unimplemented, the stream is not tried until then
+}
+
+// actionConn is one opened stream with the actions waiting for results on it
+type actionConn struct {
+	stream  warmingpb.ActionExecutor_ExecuteActionsClient
+	cancel  context.CancelFunc
+	sendMu  sync.Mutex                  // grpc streams allow only one concurrent Send
+	waiters map[string]chan actionReply // guarded by ActionStream.mu
+}
+
+type actionReply struct {
+	result *warmingpb.ActionResult
+	err    error
+}
+
+// NewActionStream returns nil when the stream is disabled or the platform service is not connected
+func NewActionStream(platform string, client *grpc.ClientConn, cfg config.ActionStreamConfig, metrics *Metrics, logger logger.Logger) *ActionStream {
+	if client == nil || !cfg.Enabled {
+		return nil
+	}
+
+	return &ActionStream{
+		platform: platform,
+		client:   warmingpb.NewActionExecutorClient(client),
+		cfg:      cfg,
+		metrics:  metrics,
+		logger:   logger,
+	}
+}
+
+// Execute sends the action and waits for its result. Failures the service reports as retryable
+// are sent again right away, up to MaxAttempts. Attempts and platform latency are added to execCtx.
+func (s *ActionStream) Execute(ctx context.Context, execCtx *models.ExecutionContext, actionType string, params map[string]string) (*warmingpb.ActionResult, error) {
+	if s == nil {
+		return nil, errActionStreamUnavailable
+	}
+
+	backoff := s.cfg.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		result, err := s.send(ctx, &warmingpb.ActionRequest{
+			RequestId:  primitive.NewObjectID().Hex(),
+			AccountId:  execCtx.AccountID.Hex(),
+			TaskId:     execCtx.TaskID.Hex(),
+			ActionType: actionType,
+			Attempt:    int32(attempt),
+			Params:     params,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		execCtx.Attempts = attempt
+		execCtx.PlatformLatencyMs += result.LatencyMs
+		if s.metrics != nil {
+			s.metrics.ObservePlatformLatency(s.platform, actionType, float64(result.LatencyMs)/1000)
+		}
+
+		if result.Success || !result.Retryable || attempt >= s.cfg.MaxAttempts {
+			return result, nil
+		}
+
+		s.logger.Warn("%s action %s failed on attempt %d, retrying: %s", s.platform, actionType, attempt, result.Error)
+		if s.metrics != nil {
+			s.metrics.IncrementActionRetries(s.platform, actionType)
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, nil
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send makes one attempt of the action
+func (s *ActionStream) send(ctx context.Context, req *warmingpb.ActionRequest) (*warmingpb.ActionResult, error) {
+	conn, reply, err := s.register(req.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.sendMu.Lock()
+	err = conn.stream.Send(req)
+	conn.sendMu.Unlock()
+	if err != nil {
+		// A failed Send did not deliver the request, the receive loop closes the stream
+		s.unregister(conn, req.RequestId)
+		return nil, fmt.Errorf("%w: %v", errActionStreamUnavailable, err)
+	}
+
+	timer := time.NewTimer(s.cfg.Timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-reply:
+		return r.result, r.err
+	case <-ctx.Done():
+		s.unregister(conn, req.RequestId)
+		return nil, ctx.Err()
+	case <-timer.C:
+		s.unregister(conn, req.RequestId)
+		return nil, fmt.Errorf("%w: no result from %s service in %v", errActionOutcomeUnknown, s.platform, s.cfg.Timeout)
+	}
+}
+
+// register opens the stream when there is none and adds a waiter for the request
+func (s *ActionStream) register(requestID string) (*actionConn, chan actionReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Now().Before(s.unsupportedUntil) {
+		return nil, nil, errActionStreamUnavailable
+	}
+
+	if s.conn == nil {
+		streamCtx, cancel := context.WithCancel(context.Background())
+		stream, err := s.client.ExecuteActions(streamCtx)
+		if err != nil {
+			cancel()
+			return nil, nil, fmt.Errorf("%w: %v", errActionStreamUnavailable, err)
+		}
+
+		s.conn = &actionConn{
+			stream:  stream,
+			cancel:  cancel,
+			waiters: make(map[string]chan actionReply),
+		}
+		if s.metrics != nil {
+			s.metrics.SetActionStreamOpen(s.platform, true)
+		}
+		go s.receive(s.conn)
+	}
+
+	reply := make(chan actionReply, 1)
+	s.conn.waiters[requestID] = reply
+	return s.conn, reply, nil
+}
+
+func (s *ActionStream) unregister(conn *actionConn, requestID string) {
+	s.mu.Lock()
+	delete(conn.waiters, requestID)
+	s.mu.Unlock()
+}
+
+// receive hands results to their waiters until the stream breaks
+func (s *ActionStream) receive(conn *actionConn) {
+	for {
+		result, err := conn.stream.Recv()
+		if err != nil {
+			s.closeConn(conn, err)
+			return
+		}
+
+		s.mu.Lock()
+		reply, ok := conn.waiters[result.RequestId]
+		delete(conn.waiters, result.RequestId)
+		s.mu.Unlock()
+
+		if ok {
+			reply <- actionReply{result: result}
+		}
+	}
+}
+
+// closeConn drops the broken stream and fails the actions still waiting on it. The next action
+// opens a new stream, except for services without the handler which are asked again later.
+func (s *ActionStream) closeConn(conn *actionConn, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == conn {
+		s.conn = nil
+		if s.metrics != nil {
+			s.metrics.SetActionStreamOpen(s.platform, false)
+		}
+	}
+	conn.cancel()
+
+	// Unimplemented comes before any request is handled, so the waiting actions can still fall back
+	waitErr := fmt.Errorf("%w: %v", errActionOutcomeUnknown, err)
+	if status.Code(err) == codes.Unimplemented {
+		s.unsupportedUntil = time.Now().Add(s.cfg.UnsupportedRecheck)
+		s.logger.Info("%s service does not serve the action stream, using unary calls", s.platform)
+		waitErr = fmt.Errorf("%w: %v", errActionStreamUnavailable, err)
+	} else {
+		s.logger.Warn("%s action stream closed: %v", s.platform, err)
+	}
+
+	for requestID, reply := range conn.waiters {
+		reply <- actionReply{err: waitErr}
+		delete(conn.waiters, requestID)
+	}
+}
+
+// actionResultError converts a failed result into the error the workers act on: bans stop
+// the task and captchas pause it, like the errors of local executors
+func actionResultError(result *warmingpb.ActionResult) error {
+	if result.Success {
+		return nil
+	}
+
+	switch result.ErrorType {
+	case ErrorTypeBan:
+		return NewBanError(result.Error)
+	case ErrorTypeCaptcha:
+		return NewCaptchaError(result.Error)
+	}
+
+	errorType := result.ErrorType
+	if errorType == "" {
+		errorType = ErrorTypeUnknown
+	}
+	return &ActionExecutionError{
+		Type:      errorType,
+		Message:   result.Error,
+		Retryable: result.Retryable,
+	}
+}
+
+// isActionErrorType reports whether err is an ActionExecutionError of the type
+func isActionErrorType(err error, errorType string) bool {
+	var actionErr *ActionExecutionError
+	return errors.As(err, &actionErr) && actionErr.Type == errorType
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeActionExecutor answers every action with the next result of its script
+type fakeActionExecutor struct {
+	warmingpb.UnimplementedActionExecutorServer
+	mu       sync.Mutex
+	results  []*warmingpb.ActionResult
+	requests []*warmingpb.ActionRequest
+}
+
+func (f *fakeActionExecutor) ExecuteActions(stream grpc.BidiStreamingServer[warmingpb.ActionRequest, warmingpb.ActionResult]) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		f.mu.Lock()
+		f.requests = append(f.requests, req)
+		result := &warmingpb.ActionResult{Success: true}
+		if len(f.results) > 0 {
+			result, f.results = f.results[0], f.results[1:]
+		}
+		f.mu.Unlock()
+
+		result.RequestId = req.RequestId
+		if err := stream.Send(result); err != nil {
+			return err
+		}
+	}
+}
+
+// dialActionExecutor serves srv over an in-memory listener; a nil srv serves nothing, like
+// a platform service without the stream
+func dialActionExecutor(t *testing.T, srv warmingpb.ActionExecutorServer) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	if srv != nil {
+		warmingpb.RegisterActionExecutorServer(server, srv)
+	}
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func testActionStreamConfig() config.ActionStreamConfig {
+	return config.ActionStreamConfig{
+		Enabled:            true,
+		Timeout:            5 * time.Second,
+		MaxAttempts:        3,
+		RetryBackoff:       time.Millisecond,
+		UnsupportedRecheck: time.Minute,
+	}
+}
+
+func newTestExecutionContext() *models.ExecutionContext {
+	return &models.ExecutionContext{
+		TaskID:    primitive.NewObjectID(),
+		AccountID: primitive.NewObjectID(),
+		Platform:  "telegram",
+	}
+}
+
+func TestNewActionStreamDisabled(t *testing.T) {
+	cfg := testActionStreamConfig()
+	cfg.Enabled = false
+
+	stream := NewActionStream("telegram", dialActionExecutor(t, nil), cfg, nil, new(MockLogger))
+	assert.Nil(t, stream)
+
+	_, err := stream.Execute(context.Background(), newTestExecutionContext(), "join_group", nil)
+	assert.ErrorIs(t, err, errActionStreamUnavailable)
+}
+
+func TestActionStreamExecute(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		srv := &fakeActionExecutor{results: []*warmingpb.ActionResult{
+			{Success: true, LatencyMs: 120, Details: map[string]string{"peer": "@news", "message_id": "42"}},
+		}}
+		stream := NewActionStream("telegram", dialActionExecutor(t, srv), testActionStreamConfig(), nil, new(MockLogger))
+		execCtx := newTestExecutionContext()
+
+		result, err := stream.Execute(context.Background(), execCtx, "react_message", map[string]string{"peer": "@news"})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, 1, execCtx.Attempts)
+		assert.Equal(t, int64(120), execCtx.PlatformLatencyMs)
+
+		require.Len(t, srv.requests, 1)
+		assert.Equal(t, "react_message", srv.requests[0].ActionType)
+		assert.Equal(t, execCtx.AccountID.Hex(), srv.requests[0].AccountId)
+		assert.Equal(t, "@news", srv.requests[0].Params["peer"])
+	})
+
+	t.Run("retryable failure is sent again", func(t *testing.T) {
+		srv := &fakeActionExecutor{results: []*warmingpb.ActionResult{
+			{ErrorType: ErrorTypeNetwork, Retryable: true, LatencyMs: 100},
+			{Success: true, LatencyMs: 50},
+		}}
+		stream := NewActionStream("telegram", dialActionExecutor(t, srv), testActionStreamConfig(), nil, new(MockLogger))
+		execCtx := newTestExecutionContext()
+
+		result, err := stream.Execute(context.Background(), execCtx, "join_group", nil)
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, 2, execCtx.Attempts)
+		assert.Equal(t, int64(150), execCtx.PlatformLatencyMs)
+
+		require.Len(t, srv.requests, 2)
+		assert.Equal(t, int32(2), srv.requests[1].Attempt)
+		assert.NotEqual(t, srv.requests[0].RequestId, srv.requests[1].RequestId)
+	})
+
+	t.Run("attempts are capped", func(t *testing.T) {
+		retryable := &warmingpb.ActionResult{ErrorType: ErrorTypeTimeout, Retryable: true}
+		srv := &fakeActionExecutor{results: []*warmingpb.ActionResult{retryable, retryable, retryable, {Success: true}}}
+		stream := NewActionStream("telegram", dialActionExecutor(t, srv), testActionStreamConfig(), nil, new(MockLogger))
+		execCtx := newTestExecutionContext()
+
+		result, err := stream.Execute(context.Background(), execCtx, "join_group", nil)
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Equal(t, 3, execCtx.Attempts)
+		assert.Len(t, srv.requests, 3)
+	})
+
+	t.Run("permanent failure is not retried", func(t *testing.T) {
+		srv := &fakeActionExecutor{results: []*warmingpb.ActionResult{{ErrorType: ErrorTypeRateLimit, Error: "flood wait"}}}
+		stream := NewActionStream("telegram", dialActionExecutor(t, srv), testActionStreamConfig(), nil, new(MockLogger))
+
+		result, err := stream.Execute(context.Background(), newTestExecutionContext(), "send_message", nil)
+		require.NoError(t, err)
+		assert.Equal(t, ErrorTypeRateLimit, result.ErrorType)
+		assert.Len(t, srv.requests, 1)
+	})
+}
+
+func TestActionStreamUnimplemented(t *testing.T) {
+	stream := NewActionStream("vk", dialActionExecutor(t, nil), testActionStreamConfig(), nil, new(MockLogger))
+
+	_, err := stream.Execute(context.Background(), newTestExecutionContext(), "like_post", nil)
+	assert.ErrorIs(t, err, errActionStreamUnavailable)
+
+	// The service is not asked again until the recheck interval passes
+	_, err = stream.Execute(context.Background(), newTestExecutionContext(), "like_post", nil)
+	assert.ErrorIs(t, err, errActionStreamUnavailable)
+	assert.True(t, time.Now().Before(stream.unsupportedUntil))
+}
+
+func TestActionResultError(t *testing.T) {
+	assert.NoError(t, actionResultError(&warmingpb.ActionResult{Success: true}))
+
+	var actionErr *ActionExecutionError
+	require.ErrorAs(t, actionResultError(&warmingpb.ActionResult{ErrorType: ErrorTypeBan, Error: "account banned"}), &actionErr)
+	assert.True(t, actionErr.ShouldStop)
+
+	require.ErrorAs(t, actionResultError(&warmingpb.ActionResult{ErrorType: ErrorTypeCaptcha}), &actionErr)
+	assert.True(t, actionErr.ShouldPause)
+
+	require.ErrorAs(t, actionResultError(&warmingpb.ActionResult{Error: "boom"}), &actionErr)
+	assert.Equal(t, ErrorTypeUnknown, actionErr.Type)
+	assert.False(t, actionErr.ShouldPause || actionErr.ShouldStop)
+
+	assert.True(t, isActionErrorType(actionResultError(&warmingpb.ActionResult{ErrorType: ErrorTypeNotFound}), ErrorTypeNotFound))
+	assert.False(t, isActionErrorType(errors.New("not_found"), ErrorTypeNotFound))
+}
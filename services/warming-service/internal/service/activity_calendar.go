@@ -0,0 +1,172 @@
+package service
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+)
+
+const dayOffDateLayout = "2006-01-02"
+
+// maxCalendarLookahead bounds the search for the next active slot
+const maxCalendarLookahead = 14 * 24
+
+type personaHours struct {
+	wake, sleep               int
+	weekendWake, weekendSleep int
+}
+
+var personaPresets = map[string]personaHours{
+	models.PersonaEarlyBird: {wake: 6, sleep: 22, weekendWake: 8, weekendSleep: 23},
+	models.PersonaOffice:    {wake: 8, sleep: 23, weekendWake: 10, weekendSleep: 1},
+	models.PersonaNightOwl:  {wake: 11, sleep: 2, weekendWake: 13, weekendSleep: 3},
+}
+
+var personaNames = []string{models.PersonaEarlyBird, models.PersonaOffice, models.PersonaNightOwl}
+
+// DefaultActivityCalendar builds the calendar used when a task has no override from the
+// configured active hours. With persona_presets on, the hours come from a persona derived from
+// the account ID instead, so the same account keeps the same habits.
+func DefaultActivityCalendar(accountKey string, behavior config.BehaviorSimulationConfig) *models.ActivityCalendar {
+	calendar := &models.ActivityCalendar{
+		Timezone:          behavior.Timezone,
+		DayOffProbability: behavior.DayOffProbability,
+		WakeHour:          behavior.ActiveHoursStart,
+		SleepHour:         behavior.ActiveHoursEnd,
+		WeekendWakeHour:   behavior.ActiveHoursStart,
+		WeekendSleepHour:  behavior.ActiveHoursEnd,
+	}
+
+	if behavior.PersonaPresets && accountKey != "" {
+		calendar.Persona = personaNames[hashKey(accountKey)%uint32(len(personaNames))]
+		applyPersona(calendar)
+	}
+
+	return calendar
+}
+
+// ValidateActivityCalendar checks a calendar override submitted via the task API
+func ValidateActivityCalendar(calendar *models.ActivityCalendar) error {
+	if calendar == nil {
+		return fmt.Errorf("calendar is required")
+	}
+	if calendar.Timezone != "" {
+		if _, err := time.LoadLocation(calendar.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", calendar.Timezone, err)
+		}
+	}
+	if calendar.Persona != "" {
+		if _, ok := personaPresets[calendar.Persona]; !ok {
+			return fmt.Errorf("unknown persona %q", calendar.Persona)
+		}
+	}
+	for _, hour := range []int{calendar.WakeHour, calendar.SleepHour, calendar.WeekendWakeHour, calendar.WeekendSleepHour} {
+		if hour < 0 || hour > 23 {
+			return fmt.Errorf("hours must be between 0 and 23")
+		}
+	}
+	if calendar.DayOffProbability < 0 || calendar.DayOffProbability > 1 {
+		return fmt.Errorf("day_off_probability must be between 0 and 1")
+	}
+	for _, day := range calendar.DaysOff {
+		if _, err := time.Parse(dayOffDateLayout, day); err != nil {
+			return fmt.Errorf("invalid day off %q: expected YYYY-MM-DD", day)
+		}
+	}
+	return nil
+}
+
+// ApplyActivityCalendar moves t to the nearest moment the account would be awake.
+// Times already inside the waking window are returned unchanged.
+func ApplyActivityCalendar(calendar *models.ActivityCalendar, accountKey string, holidays []string, t time.Time) time.Time {
+	loc := time.Local
+	if calendar.Timezone != "" {
+		if l, err := time.LoadLocation(calendar.Timezone); err == nil {
+			loc = l
+		}
+	}
+
+	local := t.In(loc)
+	if isActiveAt(calendar, accountKey, holidays, local) {
+		return t
+	}
+
+	candidate := local.Truncate(time.Hour)
+	for i := 0; i < maxCalendarLookahead; i++ {
+		candidate = candidate.Add(time.Hour)
+		if isActiveAt(calendar, accountKey, holidays, candidate) {
+			// Don't wake up exactly on the hour
+			return candidate.Add(time.Duration(rand.Intn(45)) * time.Minute).In(t.Location())
+		}
+	}
+
+	return t
+}
+
+func isActiveAt(calendar *models.ActivityCalendar, accountKey string, holidays []string, t time.Time) bool {
+	wake, sleep := calendar.WakeHour, calendar.SleepHour
+	day := t
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		wake, sleep = calendar.WeekendWakeHour, calendar.WeekendSleepHour
+	}
+
+	hour := t.Hour()
+	if wake < sleep {
+		if hour < wake || hour >= sleep {
+			return false
+		}
+	} else {
+		if hour < wake && hour >= sleep {
+			return false
+		}
+		// After midnight still belongs to the previous day
+		if hour < sleep {
+			day = t.AddDate(0, 0, -1)
+		}
+	}
+
+	return !isDayOff(calendar, accountKey, holidays, day)
+}
+
+func isDayOff(calendar *models.ActivityCalendar, accountKey string, holidays []string, t time.Time) bool {
+	date := t.Format(dayOffDateLayout)
+	for _, day := range calendar.DaysOff {
+		if day == date {
+			return true
+		}
+	}
+	for _, day := range holidays {
+		if day == date {
+			return true
+		}
+	}
+
+	if calendar.DayOffProbability <= 0 {
+		return false
+	}
+
+	// Deterministic per account and date so rescheduling doesn't flip the decision
+	roll := float64(hashKey(accountKey+date)%10000) / 10000
+	return roll < calendar.DayOffProbability
+}
+
+func applyPersona(calendar *models.ActivityCalendar) {
+	preset, ok := personaPresets[calendar.Persona]
+	if !ok {
+		return
+	}
+	calendar.WakeHour = preset.wake
+	calendar.SleepHour = preset.sleep
+	calendar.WeekendWakeHour = preset.weekendWake
+	calendar.WeekendSleepHour = preset.weekendSleep
+}
+
+func hashKey(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
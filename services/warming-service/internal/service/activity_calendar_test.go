@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test ApplyActivityCalendar
+func TestApplyActivityCalendar(t *testing.T) {
+	calendar := &models.ActivityCalendar{
+		Timezone:         "UTC",
+		WakeHour:         9,
+		SleepHour:        22,
+		WeekendWakeHour:  11,
+		WeekendSleepHour: 23,
+	}
+
+	tests := []struct {
+		name         string
+		current      time.Time
+		expectedDay  int
+		expectedHour int
+	}{
+		{"weekday inside window", time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC), 15, 12},
+		{"weekday before wake", time.Date(2024, 1, 15, 6, 30, 0, 0, time.UTC), 15, 9},
+		{"weekday after sleep", time.Date(2024, 1, 15, 22, 30, 0, 0, time.UTC), 16, 9},
+		{"friday night to saturday", time.Date(2024, 1, 19, 23, 0, 0, 0, time.UTC), 20, 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ApplyActivityCalendar(calendar, "account", nil, tt.current)
+
+			assert.Equal(t, tt.expectedDay, result.Day())
+			assert.Equal(t, tt.expectedHour, result.Hour())
+		})
+	}
+}
+
+// Test ApplyActivityCalendar - window crossing midnight
+func TestApplyActivityCalendar_NightOwl(t *testing.T) {
+	calendar := &models.ActivityCalendar{
+		Timezone:         "UTC",
+		WakeHour:         11,
+		SleepHour:        2,
+		WeekendWakeHour:  11,
+		WeekendSleepHour: 2,
+	}
+
+	late := time.Date(2024, 1, 16, 1, 15, 0, 0, time.UTC)
+	assert.Equal(t, late, ApplyActivityCalendar(calendar, "account", nil, late))
+
+	early := time.Date(2024, 1, 16, 5, 0, 0, 0, time.UTC)
+	assert.Equal(t, 11, ApplyActivityCalendar(calendar, "account", nil, early).Hour())
+}
+
+// Test ApplyActivityCalendar - explicit days off and holidays
+func TestApplyActivityCalendar_DaysOff(t *testing.T) {
+	calendar := &models.ActivityCalendar{
+		Timezone:         "UTC",
+		WakeHour:         9,
+		SleepHour:        22,
+		WeekendWakeHour:  9,
+		WeekendSleepHour: 22,
+		DaysOff:          []string{"2024-01-15"},
+	}
+
+	current := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	result := ApplyActivityCalendar(calendar, "account", nil, current)
+	assert.Equal(t, 16, result.Day())
+	assert.Equal(t, 9, result.Hour())
+
+	result = ApplyActivityCalendar(calendar, "account", []string{"2024-01-16"}, current)
+	assert.Equal(t, 17, result.Day())
+}
+
+// Test ApplyActivityCalendar - timezone conversion
+func TestApplyActivityCalendar_Timezone(t *testing.T) {
+	calendar := &models.ActivityCalendar{
+		Timezone:         "Asia/Vladivostok", // UTC+10
+		WakeHour:         9,
+		SleepHour:        22,
+		WeekendWakeHour:  9,
+		WeekendSleepHour: 22,
+	}
+
+	// 13:00 UTC is 23:00 in Vladivostok, so the account is asleep
+	current := time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC)
+	result := ApplyActivityCalendar(calendar, "account", nil, current)
+
+	loc, _ := time.LoadLocation("Asia/Vladivostok")
+	assert.Equal(t, 9, result.In(loc).Hour())
+	assert.Equal(t, time.UTC, result.Location())
+}
+
+// Test DefaultActivityCalendar
+func TestDefaultActivityCalendar(t *testing.T) {
+	behavior := config.BehaviorSimulationConfig{
+		ActiveHoursStart:  8,
+		ActiveHoursEnd:    23,
+		Timezone:          "Europe/Moscow",
+		DayOffProbability: 0.05,
+	}
+
+	// The configured hours apply unless persona presets are turned on
+	configured := DefaultActivityCalendar("507f1f77bcf86cd799439011", behavior)
+	assert.Empty(t, configured.Persona)
+	assert.Equal(t, 8, configured.WakeHour)
+	assert.Equal(t, 23, configured.SleepHour)
+	assert.Equal(t, 8, configured.WeekendWakeHour)
+	assert.Equal(t, 23, configured.WeekendSleepHour)
+	assert.Equal(t, "Europe/Moscow", configured.Timezone)
+
+	behavior.PersonaPresets = true
+	first := DefaultActivityCalendar("507f1f77bcf86cd799439011", behavior)
+	second := DefaultActivityCalendar("507f1f77bcf86cd799439011", behavior)
+
+	assert.Equal(t, first, second)
+	assert.Contains(t, personaNames, first.Persona)
+	assert.Equal(t, "Europe/Moscow", first.Timezone)
+
+	noAccount := DefaultActivityCalendar("", behavior)
+	assert.Empty(t, noAccount.Persona)
+	assert.Equal(t, 8, noAccount.WakeHour)
+	assert.Equal(t, 23, noAccount.SleepHour)
+}
+
+// Test ValidateActivityCalendar
+func TestValidateActivityCalendar(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar *models.ActivityCalendar
+		wantErr  bool
+	}{
+		{"valid", &models.ActivityCalendar{Timezone: "Europe/Moscow", WakeHour: 8, SleepHour: 23}, false},
+		{"persona only", &models.ActivityCalendar{Persona: models.PersonaNightOwl}, false},
+		{"nil", nil, true},
+		{"bad timezone", &models.ActivityCalendar{Timezone: "Mars/Olympus"}, true},
+		{"bad persona", &models.ActivityCalendar{Persona: "vampire"}, true},
+		{"bad hour", &models.ActivityCalendar{WakeHour: 24}, true},
+		{"bad probability", &models.ActivityCalendar{DayOffProbability: 1.5}, true},
+		{"bad day off", &models.ActivityCalendar{DaysOff: []string{"15.01.2024"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateActivityCalendar(tt.calendar)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
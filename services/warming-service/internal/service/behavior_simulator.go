@@ -196,9 +196,6 @@ func (b *BehaviorSimulator) AddHumanDelay(minMs, maxMs int) time.Duration {
 }
 
 func (b *BehaviorSimulator) GenerateBurstPattern(minActions, maxActions int) int {
-	// Generate number of actions in a burst
-	burstSize := b.rand.Intn(maxActions-minActions+1) + minActions
-
 	// Apply gaussian distribution for more realistic bursts
 	// Most bursts are medium-sized, few are very small or very large
 	mean := float64(minActions+maxActions) / 2
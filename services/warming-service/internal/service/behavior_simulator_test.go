@@ -6,7 +6,6 @@ import (
 
 	"github.com/grigta/conveer/services/warming-service/internal/config"
 
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -258,26 +257,17 @@ func (s *BehaviorSimulatorTestSuite) TestGenerateBurstPattern() {
 func (s *BehaviorSimulatorTestSuite) TestSimulateScrollDelay() {
 	sim := NewBehaviorSimulator(s.config, s.logger)
 
-	// Test distribution of scroll types
-	fastCount := 0
-	normalCount := 0
-	slowCount := 0
-
+	// Scroll types overlap once the micro-variation (0-500ms) is added, so the mix is checked
+	// through the mean: 20% fast (~450ms), 50% normal (~800ms), 30% slow (~1650ms) average ~985ms
+	var total time.Duration
 	for i := 0; i < 1000; i++ {
 		delay := sim.SimulateScrollDelay()
-		if delay < 350*time.Millisecond {
-			fastCount++
-		} else if delay < 850*time.Millisecond {
-			normalCount++
-		} else {
-			slowCount++
-		}
+		s.True(delay >= 100*time.Millisecond && delay < 2500*time.Millisecond, "Scroll delay %v out of range", delay)
+		total += delay
 	}
 
-	// Should have roughly 20% fast, 50% normal, 30% slow
-	s.True(fastCount > 100 && fastCount < 300, "Fast scroll count: %d", fastCount)
-	s.True(normalCount > 400 && normalCount < 600, "Normal scroll count: %d", normalCount)
-	s.True(slowCount > 200 && slowCount < 400, "Slow scroll count: %d", slowCount)
+	mean := total / 1000
+	s.True(mean > 850*time.Millisecond && mean < 1150*time.Millisecond, "Mean scroll delay: %v", mean)
 }
 
 // Test SimulateTypingDelay
@@ -299,7 +289,6 @@ func (s *BehaviorSimulatorTestSuite) TestSimulateTypingDelay() {
 			
 			// Delay should be proportional to text length
 			minExpected := time.Duration(tt.textLength*200) * time.Millisecond
-			maxExpected := time.Duration(tt.textLength*500) * time.Millisecond
 			
 			s.True(delay >= minExpected, "Delay %v should be >= %v for length %d", delay, minExpected, tt.textLength)
 		})
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Ranges net.IP doesn't classify that still don't belong to a callback receiver: "this network",
+// carrier-grade NAT used by cluster overlays, IETF protocol assignments, benchmarking and reserved
+var internalCallbackNets = mustParseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+)
+
+// callbackResolver resolves the hosts of callback URLs and rejects the ones pointing into the
+// cluster, so task creators can't make the service call internal endpoints
+type callbackResolver struct {
+	lookupIP func(ctx context.Context, network, host string) ([]net.IP, error)
+	allowed  func(ip net.IP) bool
+}
+
+func newCallbackResolver() *callbackResolver {
+	return &callbackResolver{lookupIP: net.DefaultResolver.LookupIP, allowed: publicCallbackIP}
+}
+
+// resolve returns the addresses of host, or an error if any of them is internal
+func (r *callbackResolver) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		var err error
+		ips, err = r.lookupIP(ctx, "ip", host)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to resolve %s: %v", ErrInvalidCallback, host, err)
+		}
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("%w: %s has no addresses", ErrInvalidCallback, host)
+		}
+	}
+
+	for _, ip := range ips {
+		if !r.allowed(ip) {
+			return nil, fmt.Errorf("%w: %s resolves to internal address %s", ErrInvalidCallback, host, ip)
+		}
+	}
+	return ips, nil
+}
+
+// client returns the HTTP client for callback calls. It connects to the addresses resolve checked
+// instead of resolving the host again, so DNS can't swap in an internal address after the check.
+// Redirects are returned as they are, and no proxy is used.
+func (r *callbackResolver) client() *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			host, port, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+
+			ips, err := r.resolve(ctx, host)
+			if err != nil {
+				return nil, err
+			}
+
+			var dialErr error
+			for _, ip := range ips {
+				conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+				if err == nil {
+					return conn, nil
+				}
+				dialErr = err
+			}
+			return nil, dialErr
+		},
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        20,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// publicCallbackIP reports whether ip may receive callbacks: no loopback, private (RFC 1918 and
+// unique local), link-local (cloud metadata included), multicast or otherwise internal ranges
+func publicCallbackIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, block := range internalCallbackNets {
+		if block.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, block, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, block)
+	}
+	return nets
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidCallback = errors.New("invalid callback")
+
+// CallbackPayload is the body of a task callback call
+type CallbackPayload struct {
+	ID               string    `json:"id"`
+	Milestone        string    `json:"milestone"`
+	TaskID           string    `json:"task_id"`
+	AccountID        string    `json:"account_id"`
+	Platform         string    `json:"platform"`
+	ScenarioType     string    `json:"scenario_type"`
+	Status           string    `json:"status"`
+	Day              int       `json:"day"`
+	DurationDays     int       `json:"duration_days"`
+	Progress         float64   `json:"progress"` // share of the scenario's days passed, 0-100
+	ActionsCompleted int       `json:"actions_completed"`
+	ActionsFailed    int       `json:"actions_failed"`
+	Reason           string    `json:"reason,omitempty"`
+	ReadinessScore   float64   `json:"readiness_score,omitempty"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// callbackDelivery is one call of one callback URL, queued on warming.callbacks. Retries are
+// published again with a delay, so pending calls survive restarts.
+type callbackDelivery struct {
+	ID        string          `json:"id"`
+	TaskID    string          `json:"task_id"`
+	URL       string          `json:"url"`
+	Milestone string          `json:"milestone"`
+	Attempt   int             `json:"attempt"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// validateCallbacks checks the callbacks a task is created with, nil callbacks are valid.
+// Hosts resolving to internal addresses are rejected here and again on every call.
+func (s *warmingService) validateCallbacks(ctx context.Context, callbacks *models.TaskCallbacks) error {
+	if callbacks == nil || len(callbacks.URLs) == 0 {
+		return nil
+	}
+
+	cfg := s.config.WarmingConfig.Callbacks
+	if !cfg.Enabled {
+		return fmt.Errorf("%w: task callbacks are disabled", ErrInvalidCallback)
+	}
+	if len(callbacks.URLs) > cfg.MaxURLs {
+		return fmt.Errorf("%w: at most %d callback URLs are allowed", ErrInvalidCallback, cfg.MaxURLs)
+	}
+
+	for _, rawURL := range callbacks.URLs {
+		parsed, err := url.Parse(rawURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: %q is not an absolute http(s) URL", ErrInvalidCallback, rawURL)
+		}
+		if _, err := s.callbackHosts.resolve(ctx, parsed.Hostname()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notifyMilestone queues a call of every callback URL of the task
+func (s *warmingService) notifyMilestone(task *models.WarmingTask, milestone string, day int, reason string) {
+	if task.Callbacks == nil || len(task.Callbacks.URLs) == 0 || !s.config.WarmingConfig.Callbacks.Enabled {
+		return
+	}
+
+	payload := newCallbackPayload(task, milestone, day, reason, time.Now())
+	body, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Error("Failed to marshal callback of task %s: %v", task.ID.Hex(), err)
+		return
+	}
+
+	for _, callbackURL := range task.Callbacks.URLs {
+		s.queueCallback(callbackDelivery{
+			ID:        payload.ID,
+			TaskID:    payload.TaskID,
+			URL:       callbackURL,
+			Milestone: milestone,
+			Attempt:   1,
+			Body:      body,
+		}, 0)
+	}
+}
+
+// notifyMilestoneByID loads the task and notifies its callbacks, for paths that only have the task ID
+func (s *warmingService) notifyMilestoneByID(ctx context.Context, taskID primitive.ObjectID, milestone string, reason string) {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil || task == nil {
+		s.logger.Error("Failed to load task %s for callbacks: %v", taskID.Hex(), err)
+		return
+	}
+	s.notifyMilestone(task, milestone, task.CurrentDay, reason)
+}
+
+func newCallbackPayload(task *models.WarmingTask, milestone string, day int, reason string, now time.Time) *CallbackPayload {
+	payload := &CallbackPayload{
+		ID:               primitive.NewObjectID().Hex(),
+		Milestone:        milestone,
+		TaskID:           task.ID.Hex(),
+		AccountID:        task.AccountID.Hex(),
+		Platform:         task.Platform,
+		ScenarioType:     task.ScenarioType,
+		Status:           task.Status,
+		Day:              day,
+		DurationDays:     task.DurationDays,
+		ActionsCompleted: task.ActionsCompleted,
+		ActionsFailed:    task.ActionsFailed,
+		Reason:           reason,
+		Timestamp:        now,
+	}
+
+	switch milestone {
+	case models.MilestoneCompleted:
+		payload.Status = string(models.TaskStatusCompleted)
+		payload.Progress = 100
+	case models.MilestoneFailed:
+		payload.Status = string(models.TaskStatusFailed)
+	}
+	if payload.Progress == 0 && task.DurationDays > 0 {
+		payload.Progress = float64(min(day, task.DurationDays)) / float64(task.DurationDays) * 100
+	}
+	if task.Readiness != nil {
+		payload.ReadinessScore = task.Readiness.Score
+	}
+
+	return payload
+}
+
+// crossedHalfway reports whether moving from day prev to day next passes half of the scenario
+func crossedHalfway(prev, next, durationDays int) bool {
+	return prev*2 < durationDays && next*2 >= durationDays
+}
+
+func (s *warmingService) queueCallback(delivery callbackDelivery, delay time.Duration) {
+	opts := messaging.PublishOptions{Delay: delay}
+	if err := s.messaging.PublishWithOptions("warming.commands", "callback", delivery, opts); err != nil {
+		s.logger.Error("Failed to queue %s callback of task %s: %v", delivery.Milestone, delivery.TaskID, err)
+	}
+}
+
+func (s *warmingService) runCallbackWorker(ctx context.Context) {
+	err := s.messaging.ConsumeWithHandler(ctx, "warming.callbacks", "warming-callbacks", func(msg []byte) error {
+		var delivery callbackDelivery
+		if err := json.Unmarshal(msg, &delivery); err != nil {
+			return fmt.Errorf("failed to unmarshal callback: %w", err)
+		}
+
+		s.deliverCallback(ctx, delivery)
+		return nil
+	})
+
+	if err != nil {
+		s.logger.Error("Callback worker error: %v", err)
+	}
+}
+
+// deliverCallback makes one call. Network errors, 429 and 5xx are queued again with a doubling
+// delay until max_attempts, other answers are final.
+func (s *warmingService) deliverCallback(ctx context.Context, delivery callbackDelivery) {
+	cfg := s.config.WarmingConfig.Callbacks
+
+	secret := cfg.Secret
+	taskID, _ := primitive.ObjectIDFromHex(delivery.TaskID)
+	if task, err := s.taskRepo.GetByID(ctx, taskID); err == nil && task != nil && task.Callbacks != nil && task.Callbacks.Secret != "" {
+		secret = task.Callbacks.Secret
+	}
+
+	retryable, err := s.postCallback(ctx, delivery, secret)
+	if err == nil {
+		s.metrics.IncrementCallbackDeliveries(delivery.Milestone, "delivered")
+		return
+	}
+
+	if !retryable || delivery.Attempt >= cfg.MaxAttempts {
+		s.metrics.IncrementCallbackDeliveries(delivery.Milestone, "failed")
+		s.logger.Error("Giving up %s callback %s of task %s to %s after %d attempts: %v",
+			delivery.Milestone, delivery.ID, delivery.TaskID, delivery.URL, delivery.Attempt, err)
+		return
+	}
+
+	s.metrics.IncrementCallbackDeliveries(delivery.Milestone, "retried")
+	s.logger.Warn("Callback %s of task %s to %s failed, retrying: %v", delivery.ID, delivery.TaskID, delivery.URL, err)
+
+	backoff := cfg.RetryBackoff << (delivery.Attempt - 1)
+	delivery.Attempt++
+	s.queueCallback(delivery, backoff)
+}
+
+// postCallback performs one call and reports whether it is worth retrying
+func (s *warmingService) postCallback(ctx context.Context, delivery callbackDelivery, secret string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.WarmingConfig.Callbacks.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "conveer-warming-callbacks")
+	req.Header.Set("X-Conveer-Event", "warming."+delivery.Milestone)
+	req.Header.Set("X-Conveer-Delivery", delivery.ID)
+	req.Header.Set("X-Conveer-Timestamp", timestamp)
+	if secret != "" {
+		req.Header.Set("X-Conveer-Signature", "sha256="+signCallback(secret, timestamp, delivery.Body))
+	}
+
+	resp, err := s.callbackClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("callback responded with status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("callback responded with status %d", resp.StatusCode)
+	}
+}
+
+// signCallback signs "<timestamp>.<body>" like the analytics webhooks, so receivers verify both the same way
+func signCallback(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeLookup resolves example.com names to a public address and internal.example.com to a private one
+func fakeLookup(ctx context.Context, network, host string) ([]net.IP, error) {
+	switch host {
+	case "internal.example.com":
+		return []net.IP{net.ParseIP("203.0.113.10"), net.ParseIP("10.0.0.5")}, nil
+	case "localhost":
+		return []net.IP{net.ParseIP("127.0.0.1")}, nil
+	default:
+		return []net.IP{net.ParseIP("203.0.113.10")}, nil
+	}
+}
+
+func callbackService() *warmingService {
+	callbacks := config.CallbacksConfig{Enabled: true, MaxURLs: 2, Timeout: time.Second}
+	hosts := &callbackResolver{lookupIP: fakeLookup, allowed: publicCallbackIP}
+	return &warmingService{
+		config:         &config.Config{WarmingConfig: config.WarmingConfig{Callbacks: callbacks}},
+		callbackHosts:  hosts,
+		callbackClient: hosts.client(),
+	}
+}
+
+func TestValidateCallbacks(t *testing.T) {
+	s := callbackService()
+	ctx := context.Background()
+
+	assert.NoError(t, s.validateCallbacks(ctx, nil))
+	assert.NoError(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"https://crm.example.com/hooks/warming"}}))
+	assert.ErrorIs(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"ftp://crm.example.com"}}), ErrInvalidCallback)
+	assert.ErrorIs(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"/hooks/warming"}}), ErrInvalidCallback)
+	assert.ErrorIs(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"http://a", "http://b", "http://c"}}), ErrInvalidCallback)
+
+	// Internal receivers
+	assert.ErrorIs(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"http://localhost:8080/hook"}}), ErrInvalidCallback)
+	assert.ErrorIs(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"https://internal.example.com/hook"}}), ErrInvalidCallback)
+	assert.ErrorIs(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"http://169.254.169.254/latest/meta-data"}}), ErrInvalidCallback)
+	assert.ErrorIs(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"http://[::1]:9000/hook"}}), ErrInvalidCallback)
+
+	s.config.WarmingConfig.Callbacks.Enabled = false
+	assert.ErrorIs(t, s.validateCallbacks(ctx, &models.TaskCallbacks{URLs: []string{"https://crm.example.com"}}), ErrInvalidCallback)
+}
+
+func TestPublicCallbackIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"203.0.113.10", true},
+		{"8.8.8.8", true},
+		{"2001:4860:4860::8888", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.96.0.1", false},
+		{"172.16.5.4", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.want, publicCallbackIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestCallbackClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The URL was valid when the task was created, the check at dial time still stops the call
+	s := callbackService()
+	delivery := callbackDelivery{ID: "d1", URL: server.URL, Milestone: models.MilestoneHalfway, Body: []byte(`{}`)}
+
+	_, err := s.postCallback(context.Background(), delivery, "")
+	assert.ErrorIs(t, err, ErrInvalidCallback)
+}
+
+func TestCallbackClientDoesNotFollowRedirects(t *testing.T) {
+	var internalCalls int
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalCalls++
+	}))
+	defer internal.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	s := callbackService()
+	s.callbackHosts.allowed = func(ip net.IP) bool { return true }
+	delivery := callbackDelivery{ID: "d1", URL: server.URL, Milestone: models.MilestoneHalfway, Body: []byte(`{}`)}
+
+	retryable, err := s.postCallback(context.Background(), delivery, "")
+	assert.Error(t, err)
+	assert.False(t, retryable)
+	assert.Zero(t, internalCalls)
+}
+
+func TestCrossedHalfway(t *testing.T) {
+	assert.True(t, crossedHalfway(10, 11, 21))
+	assert.False(t, crossedHalfway(11, 12, 21))
+	assert.True(t, crossedHalfway(6, 7, 14))
+	assert.False(t, crossedHalfway(7, 8, 14))
+	assert.False(t, crossedHalfway(2, 3, 14))
+}
+
+func TestNewCallbackPayload(t *testing.T) {
+	task := &models.WarmingTask{
+		ID:           primitive.NewObjectID(),
+		AccountID:    primitive.NewObjectID(),
+		Platform:     "telegram",
+		Status:       string(models.TaskStatusInProgress),
+		DurationDays: 20,
+	}
+
+	payload := newCallbackPayload(task, models.MilestoneDayCompleted, 5, "", time.Now())
+	assert.Equal(t, 25.0, payload.Progress)
+	assert.Equal(t, string(models.TaskStatusInProgress), payload.Status)
+
+	payload = newCallbackPayload(task, models.MilestoneCompleted, 12, "", time.Now())
+	assert.Equal(t, 100.0, payload.Progress)
+	assert.Equal(t, string(models.TaskStatusCompleted), payload.Status)
+
+	payload = newCallbackPayload(task, models.MilestoneFailed, 5, "account banned", time.Now())
+	assert.Equal(t, string(models.TaskStatusFailed), payload.Status)
+	assert.Equal(t, "account banned", payload.Reason)
+
+	// A manual stop reports the progress made, not the end of the scenario
+	payload = newCallbackPayload(task, models.MilestoneStopped, 8, "stopped manually", time.Now())
+	assert.Equal(t, models.MilestoneStopped, payload.Milestone)
+	assert.Equal(t, 40.0, payload.Progress)
+}
+
+func TestPostCallbackSignsAndClassifiesErrors(t *testing.T) {
+	var signature, timestamp string
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get("X-Conveer-Signature")
+		timestamp = r.Header.Get("X-Conveer-Timestamp")
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	s := callbackService()
+	s.callbackHosts.allowed = func(ip net.IP) bool { return true } // the test server listens on loopback
+	delivery := callbackDelivery{ID: "d1", URL: server.URL, Milestone: models.MilestoneHalfway, Body: []byte(`{"milestone":"progress_50"}`)}
+
+	retryable, err := s.postCallback(context.Background(), delivery, "secret")
+	require.NoError(t, err)
+	assert.False(t, retryable)
+	assert.Equal(t, "sha256="+signCallback("secret", timestamp, delivery.Body), signature)
+
+	statusCode = http.StatusServiceUnavailable
+	retryable, err = s.postCallback(context.Background(), delivery, "")
+	assert.Error(t, err)
+	assert.True(t, retryable)
+	assert.Empty(t, signature)
+
+	statusCode = http.StatusGone
+	retryable, err = s.postCallback(context.Background(), delivery, "")
+	assert.Error(t, err)
+	assert.False(t, retryable)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+)
+
+var capacityPlatforms = []string{
+	string(models.PlatformVK),
+	string(models.PlatformTelegram),
+	string(models.PlatformMail),
+	string(models.PlatformMax),
+}
+
+func (s *warmingService) GetCapacity(ctx context.Context, platform string) ([]*models.PlatformCapacity, error) {
+	platforms := capacityPlatforms
+	if platform != "" {
+		platforms = []string{platform}
+	}
+
+	result := make([]*models.PlatformCapacity, 0, len(platforms))
+	for _, p := range platforms {
+		capacity, _, err := s.platformCapacity(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, capacity)
+	}
+
+	return result, nil
+}
+
+// platformCapacity estimates the platform load and returns its queued tasks, oldest first
+func (s *warmingService) platformCapacity(ctx context.Context, platform string) (*models.PlatformCapacity, []*models.WarmingTask, error) {
+	var active []*models.WarmingTask
+	for _, status := range []models.WarmingTaskStatus{models.TaskStatusScheduled, models.TaskStatusInProgress} {
+		tasks, err := s.taskRepo.List(ctx, models.TaskFilter{Platform: platform, Status: string(status)})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list %s tasks: %w", status, err)
+		}
+		active = append(active, tasks...)
+	}
+
+	queued, err := s.taskRepo.List(ctx, models.TaskFilter{Platform: platform, Status: string(models.TaskStatusQueued)})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list queued tasks: %w", err)
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		return queued[i].CreatedAt.Before(queued[j].CreatedAt)
+	})
+
+	return s.capacity.Estimate(platform, active, len(queued)), queued, nil
+}
+
+// admitTask checks a new task against the platform capacity
+func (s *warmingService) admitTask(ctx context.Context, task *models.WarmingTask) (models.AdmissionDecision, error) {
+	if !s.capacity.Enabled() {
+		return models.AdmissionAccepted, nil
+	}
+
+	current, _, err := s.platformCapacity(ctx, task.Platform)
+	if err != nil {
+		return "", fmt.Errorf("failed to estimate capacity: %w", err)
+	}
+
+	// Tasks already waiting go first
+	if current.QueuedTasks > 0 && s.capacity.QueueOnOverload() {
+		return models.AdmissionQueued, nil
+	}
+
+	return s.capacity.Admit(current, s.capacity.TaskDemand(task)), nil
+}
+
+func (s *warmingService) runCapacityWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.config.WarmingConfig.Scheduler.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshCapacity(ctx)
+		}
+	}
+}
+
+// refreshCapacity exports utilization metrics and admits queued tasks that fit again
+func (s *warmingService) refreshCapacity(ctx context.Context) {
+	s.admissionMu.Lock()
+	defer s.admissionMu.Unlock()
+
+	for _, platform := range capacityPlatforms {
+		current, queued, err := s.platformCapacity(ctx, platform)
+		if err != nil {
+			s.logger.Error("Failed to estimate %s capacity: %v", platform, err)
+			continue
+		}
+
+		if s.capacity.Enabled() {
+			s.admitQueuedTasks(ctx, current, queued)
+		}
+
+		s.metrics.ObserveCapacity(current)
+	}
+}
+
+// admitQueuedTasks starts queued tasks in FIFO order while the platform has headroom
+func (s *warmingService) admitQueuedTasks(ctx context.Context, current *models.PlatformCapacity, queued []*models.WarmingTask) {
+	for _, task := range queued {
+		demand := s.capacity.TaskDemand(task)
+		if s.capacity.Admit(current, demand) != models.AdmissionAccepted {
+			return
+		}
+
+		if err := s.taskRepo.UpdateStatus(ctx, task.ID, string(models.TaskStatusScheduled)); err != nil {
+			s.logger.Error("Failed to admit queued task %s: %v", task.ID.Hex(), err)
+			return
+		}
+
+		task.Status = string(models.TaskStatusScheduled)
+		current.DemandPerHour += demand
+		current.ActiveTasks++
+		current.QueuedTasks--
+		if current.CapacityPerHour > 0 {
+			current.Utilization = current.DemandPerHour / current.CapacityPerHour
+		}
+
+		s.metrics.IncrementAdmissions(task.Platform, "dequeued")
+		s.launchTask(ctx, task)
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+)
+
+var ErrCapacityExceeded = errors.New("warming capacity exceeded for platform")
+
+// observedDurationWeight is the EWMA weight of a new action duration sample
+const observedDurationWeight = 0.05
+
+// CapacityModel estimates the sustainable actions/hour of each platform executor.
+//
+// Every platform is treated as an M/M/c queue: the browser pool is c servers with an
+// average service time of one action. Throughput is capped at the target utilization so
+// queueing delay stays bounded, then further limited by proxy and platform rate caps.
+type CapacityModel struct {
+	config    config.CapacityConfig
+	scheduler *Scheduler
+
+	mu              sync.RWMutex
+	observedSeconds map[string]float64
+}
+
+func NewCapacityModel(cfg config.CapacityConfig, scheduler *Scheduler) *CapacityModel {
+	return &CapacityModel{
+		config:          cfg,
+		scheduler:       scheduler,
+		observedSeconds: make(map[string]float64),
+	}
+}
+
+// Enabled reports whether new tasks should be checked against capacity
+func (m *CapacityModel) Enabled() bool {
+	return m.config.Enabled
+}
+
+// QueueOnOverload reports whether tasks over capacity are queued instead of rejected
+func (m *CapacityModel) QueueOnOverload() bool {
+	return m.config.Policy != "reject"
+}
+
+// ObserveAction feeds an executed action duration into the service time estimate
+func (m *CapacityModel) ObserveAction(platform string, duration time.Duration) {
+	seconds := duration.Seconds()
+	if seconds <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.observedSeconds[platform]
+	if !ok {
+		if configured := m.config.Platforms[platform].AvgActionSeconds; configured > 0 {
+			current = configured
+		} else {
+			current = seconds
+		}
+	}
+	m.observedSeconds[platform] = current + observedDurationWeight*(seconds-current)
+}
+
+// avgActionSeconds returns the observed service time, falling back to the configured one
+func (m *CapacityModel) avgActionSeconds(platform string) float64 {
+	m.mu.RLock()
+	observed, ok := m.observedSeconds[platform]
+	m.mu.RUnlock()
+
+	if ok {
+		return observed
+	}
+	return m.config.Platforms[platform].AvgActionSeconds
+}
+
+// TaskDemand estimates the actions/hour a task generates while its account is awake
+func (m *CapacityModel) TaskDemand(task *models.WarmingTask) float64 {
+	scenarioConfig := m.scheduler.getScenarioConfig(task)
+	if scenarioConfig == nil {
+		return 0
+	}
+
+	day := task.CurrentDay
+	if day < 1 {
+		day = 1
+	}
+
+	dayConfig := m.scheduler.getDayConfig(scenarioConfig, day, task.DurationDays)
+	if dayConfig == nil {
+		return 0
+	}
+
+	minActions, maxActions := m.scheduler.parseActionsPerDay(dayConfig.ActionsPerDay)
+	actionsPerDay := float64(minActions+maxActions) / 2
+
+	return actionsPerDay / float64(activeHours(m.scheduler.calendarForTask(task)))
+}
+
+// Estimate computes capacity and utilization for a platform given its active tasks
+func (m *CapacityModel) Estimate(platform string, active []*models.WarmingTask, queued int) *models.PlatformCapacity {
+	result := &models.PlatformCapacity{
+		Platform:         platform,
+		AvgActionSeconds: m.avgActionSeconds(platform),
+		ActiveTasks:      len(active),
+		QueuedTasks:      queued,
+	}
+
+	for _, task := range active {
+		result.DemandPerHour += m.TaskDemand(task)
+	}
+
+	platformConfig, ok := m.config.Platforms[platform]
+	if !ok {
+		return result
+	}
+
+	targetUtilization := m.config.TargetUtilization
+	if targetUtilization <= 0 || targetUtilization > 1 {
+		targetUtilization = 0.8
+	}
+
+	estimateLimits(result, platformConfig, targetUtilization)
+
+	if result.CapacityPerHour > 0 {
+		result.Utilization = result.DemandPerHour / result.CapacityPerHour
+	}
+	result.ExpectedWaitSeconds = expectedWait(platformConfig.BrowserPoolSize, result.AvgActionSeconds, result.DemandPerHour)
+
+	return result
+}
+
+// Admit decides whether a task with the given demand fits next to the current load
+func (m *CapacityModel) Admit(current *models.PlatformCapacity, demand float64) models.AdmissionDecision {
+	if current.CapacityPerHour <= 0 || current.DemandPerHour+demand <= current.CapacityPerHour {
+		return models.AdmissionAccepted
+	}
+	if m.QueueOnOverload() {
+		return models.AdmissionQueued
+	}
+	return models.AdmissionRejected
+}
+
+type capacityLimit struct {
+	name  string
+	value float64
+}
+
+// estimateLimits fills the per-resource limits, the overall capacity and the bottleneck
+func estimateLimits(result *models.PlatformCapacity, cfg config.PlatformCapacityConfig, targetUtilization float64) {
+	var limits []capacityLimit
+
+	if cfg.BrowserPoolSize > 0 && result.AvgActionSeconds > 0 {
+		result.BrowserLimitPerHour = float64(cfg.BrowserPoolSize) * 3600 / result.AvgActionSeconds * targetUtilization
+		limits = append(limits, capacityLimit{models.BottleneckBrowsers, result.BrowserLimitPerHour})
+	}
+	if cfg.ProxyCount > 0 && cfg.ActionsPerProxyHour > 0 {
+		result.ProxyLimitPerHour = float64(cfg.ProxyCount * cfg.ActionsPerProxyHour)
+		limits = append(limits, capacityLimit{models.BottleneckProxies, result.ProxyLimitPerHour})
+	}
+	if cfg.MaxActionsPerHour > 0 {
+		result.PlatformCapPerHour = float64(cfg.MaxActionsPerHour)
+		limits = append(limits, capacityLimit{models.BottleneckPlatformCap, result.PlatformCapPerHour})
+	}
+
+	for _, limit := range limits {
+		if result.CapacityPerHour == 0 || limit.value < result.CapacityPerHour {
+			result.CapacityPerHour = limit.value
+			result.Bottleneck = limit.name
+		}
+	}
+}
+
+// expectedWait returns the mean time an action waits for a free browser in an M/M/c queue
+func expectedWait(servers int, serviceSeconds, demandPerHour float64) float64 {
+	if servers <= 0 || serviceSeconds <= 0 || demandPerHour <= 0 {
+		return 0
+	}
+
+	arrivalRate := demandPerHour / 3600
+	offeredLoad := arrivalRate * serviceSeconds
+	if offeredLoad >= float64(servers) {
+		return -1
+	}
+
+	return erlangC(servers, offeredLoad) * serviceSeconds / (float64(servers) - offeredLoad)
+}
+
+// erlangC returns the probability that an arriving action has to wait,
+// for c servers and an offered load of a erlangs (a < c)
+func erlangC(c int, a float64) float64 {
+	// Erlang B via the stable recurrence, then converted to Erlang C
+	b := 1.0
+	for k := 1; k <= c; k++ {
+		b = a * b / (float64(k) + a*b)
+	}
+
+	rho := a / float64(c)
+	return b / (1 - rho + rho*b)
+}
+
+// activeHours returns the length of the calendar's weekday activity window
+func activeHours(calendar *models.ActivityCalendar) int {
+	if calendar == nil {
+		return 24
+	}
+
+	hours := (calendar.SleepHour - calendar.WakeHour + 24) % 24
+	if hours == 0 {
+		return 24
+	}
+	return hours
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCapacityModel(policy string) *CapacityModel {
+	return NewCapacityModel(config.CapacityConfig{
+		Enabled:           true,
+		Policy:            policy,
+		TargetUtilization: 0.8,
+		Platforms: map[string]config.PlatformCapacityConfig{
+			"vk": {
+				BrowserPoolSize:     10,
+				AvgActionSeconds:    20,
+				ProxyCount:          100,
+				ActionsPerProxyHour: 30,
+				MaxActionsPerHour:   1500,
+			},
+		},
+	}, &Scheduler{config: &config.Config{}, logger: new(MockLogger)})
+}
+
+func TestErlangC(t *testing.T) {
+	// Two servers at one erlang wait with probability 1/3
+	assert.InDelta(t, 1.0/3, erlangC(2, 1), 1e-9)
+	// A single server reduces to M/M/1 where P(wait) = rho
+	assert.InDelta(t, 0.5, erlangC(1, 0.5), 1e-9)
+}
+
+func TestExpectedWait(t *testing.T) {
+	// M/M/1 with rho = 0.5 and 10s service: Wq = rho / (1 - rho) * S = 10s
+	assert.InDelta(t, 10, expectedWait(1, 10, 180), 1e-9)
+	assert.Equal(t, float64(-1), expectedWait(1, 10, 360))
+	assert.Equal(t, float64(0), expectedWait(0, 10, 100))
+}
+
+func TestCapacityEstimateBottleneck(t *testing.T) {
+	model := newTestCapacityModel("queue")
+
+	capacity := model.Estimate("vk", nil, 0)
+	// 10 browsers * 3600 / 20s * 0.8 = 1440 < 1500 platform cap < 3000 proxy limit
+	assert.InDelta(t, 1440, capacity.BrowserLimitPerHour, 1e-9)
+	assert.InDelta(t, 3000, capacity.ProxyLimitPerHour, 1e-9)
+	assert.InDelta(t, 1500, capacity.PlatformCapPerHour, 1e-9)
+	assert.InDelta(t, 1440, capacity.CapacityPerHour, 1e-9)
+	assert.Equal(t, models.BottleneckBrowsers, capacity.Bottleneck)
+
+	// Faster actions move the bottleneck to the platform cap
+	for i := 0; i < 500; i++ {
+		model.ObserveAction("vk", 5*time.Second)
+	}
+	capacity = model.Estimate("vk", nil, 0)
+	assert.Equal(t, models.BottleneckPlatformCap, capacity.Bottleneck)
+	assert.InDelta(t, 1500, capacity.CapacityPerHour, 1e-9)
+}
+
+func TestCapacityUnconfiguredPlatformIsUnbounded(t *testing.T) {
+	model := newTestCapacityModel("reject")
+
+	capacity := model.Estimate("mail", nil, 0)
+	assert.Zero(t, capacity.CapacityPerHour)
+	assert.Equal(t, models.AdmissionAccepted, model.Admit(capacity, 1000))
+}
+
+func TestCapacityAdmit(t *testing.T) {
+	current := &models.PlatformCapacity{Platform: "vk", CapacityPerHour: 100, DemandPerHour: 95}
+
+	assert.Equal(t, models.AdmissionAccepted, newTestCapacityModel("queue").Admit(current, 5))
+	assert.Equal(t, models.AdmissionQueued, newTestCapacityModel("queue").Admit(current, 6))
+	assert.Equal(t, models.AdmissionRejected, newTestCapacityModel("reject").Admit(current, 6))
+}
+
+func TestObserveActionStartsFromConfiguredDuration(t *testing.T) {
+	model := newTestCapacityModel("queue")
+
+	model.ObserveAction("vk", 40*time.Second)
+	assert.InDelta(t, 21, model.avgActionSeconds("vk"), 1e-9)
+
+	model.ObserveAction("telegram", 12*time.Second)
+	assert.InDelta(t, 12, model.avgActionSeconds("telegram"), 1e-9)
+}
+
+func TestActiveHours(t *testing.T) {
+	assert.Equal(t, 15, activeHours(&models.ActivityCalendar{WakeHour: 8, SleepHour: 23}))
+	assert.Equal(t, 15, activeHours(&models.ActivityCalendar{WakeHour: 11, SleepHour: 2}))
+	assert.Equal(t, 24, activeHours(&models.ActivityCalendar{WakeHour: 0, SleepHour: 0}))
+	assert.Equal(t, 24, activeHours(nil))
+}
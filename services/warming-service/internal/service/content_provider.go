@@ -0,0 +1,421 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+	"github.com/grigta/conveer/services/warming-service/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ContentSource supplies candidate content items for a niche
+type ContentSource interface {
+	Name() string
+	Fetch(ctx context.Context, niche string, kind models.ContentKind) ([]*models.ContentItem, error)
+}
+
+// TextGenerator produces a text for a niche, e.g. backed by an LLM
+type TextGenerator interface {
+	Generate(ctx context.Context, niche string, kind models.ContentKind) (string, error)
+}
+
+// ContentProvider picks content for posting actions so that an account never repeats
+// itself and the same item is not spread across the whole fleet
+type ContentProvider struct {
+	sources     []ContentSource
+	contentRepo repository.ContentRepository
+	config      config.ContentConfig
+	logger      logger.Logger
+}
+
+func NewContentProvider(contentRepo repository.ContentRepository, cfg config.ContentConfig, logger logger.Logger) *ContentProvider {
+	p := &ContentProvider{
+		contentRepo: contentRepo,
+		config:      cfg,
+		logger:      logger,
+	}
+
+	if len(cfg.Pools) > 0 {
+		p.AddSource(NewPoolSource(cfg.Pools))
+	}
+	if len(cfg.Feeds) > 0 {
+		p.AddSource(NewRSSSource(cfg.Feeds, cfg.RSSRefreshInterval))
+	}
+	if cfg.LLM.Enabled {
+		p.AddSource(NewLLMSource(NewHTTPTextGenerator(cfg.LLM)))
+	}
+
+	return p
+}
+
+func (p *ContentProvider) AddSource(source ContentSource) {
+	p.sources = append(p.sources, source)
+}
+
+// Next returns a fresh content item for the account and records its usage.
+// It returns nil when content is disabled or nothing unused is available,
+// in which case executors fall back to their built-in templates.
+func (p *ContentProvider) Next(ctx context.Context, accountID primitive.ObjectID, platform, niche string, kind models.ContentKind) *models.ContentItem {
+	if p == nil || !p.config.Enabled || len(p.sources) == 0 {
+		return nil
+	}
+
+	if niche == "" {
+		niche = p.config.DefaultNiche
+	}
+
+	var candidates []*models.ContentItem
+	for _, source := range p.sources {
+		items, err := source.Fetch(ctx, niche, kind)
+		if err != nil {
+			p.logger.Warn("Content source %s failed for niche %s: %v", source.Name(), niche, err)
+			continue
+		}
+		candidates = append(candidates, items...)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	since := time.Now().Add(-p.config.DedupWindow)
+
+	used, err := p.contentRepo.GetAccountUsage(ctx, accountID, since)
+	if err != nil {
+		p.logger.Error("Failed to get content usage for account %s: %v", accountID.Hex(), err)
+		return nil
+	}
+
+	ids := make([]string, 0, len(candidates))
+	for _, item := range candidates {
+		ids = append(ids, item.ID)
+	}
+
+	fleetUsage, err := p.contentRepo.GetFleetUsage(ctx, ids, since)
+	if err != nil {
+		p.logger.Error("Failed to get fleet content usage: %v", err)
+		return nil
+	}
+
+	item := selectContent(candidates, used, fleetUsage, p.config.FleetReuseLimit)
+	if item == nil {
+		p.logger.Debug("No unused content left for account %s in niche %s", accountID.Hex(), niche)
+		return nil
+	}
+
+	usage := &models.ContentUsage{
+		ContentID: item.ID,
+		AccountID: accountID,
+		Platform:  platform,
+		Niche:     item.Niche,
+		Source:    item.Source,
+	}
+	if err := p.contentRepo.RecordUsage(ctx, usage); err != nil {
+		p.logger.Error("Failed to record content usage: %v", err)
+	}
+
+	return item
+}
+
+// selectContent drops items the account already posted or the fleet has used up,
+// then prefers the least used ones, picking randomly among equals
+func selectContent(candidates []*models.ContentItem, used map[string]bool, fleetUsage map[string]int, reuseLimit int) *models.ContentItem {
+	var best []*models.ContentItem
+	bestCount := -1
+
+	seen := make(map[string]bool, len(candidates))
+	for _, item := range candidates {
+		if seen[item.ID] || used[item.ID] {
+			continue
+		}
+		seen[item.ID] = true
+
+		count := fleetUsage[item.ID]
+		if reuseLimit > 0 && count >= reuseLimit {
+			continue
+		}
+
+		switch {
+		case bestCount == -1 || count < bestCount:
+			best = []*models.ContentItem{item}
+			bestCount = count
+		case count == bestCount:
+			best = append(best, item)
+		}
+	}
+
+	if len(best) == 0 {
+		return nil
+	}
+	return best[rand.Intn(len(best))]
+}
+
+func newContentItem(kind models.ContentKind, niche, source, text, imageURL, link string) *models.ContentItem {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(text) + "|" + imageURL + "|" + link))
+	return &models.ContentItem{
+		ID:       hex.EncodeToString(sum[:16]),
+		Kind:     kind,
+		Niche:    niche,
+		Source:   source,
+		Text:     strings.TrimSpace(text),
+		ImageURL: imageURL,
+		Link:     link,
+	}
+}
+
+// PoolSource serves pre-curated texts and images from the configuration
+type PoolSource struct {
+	pools map[string]config.ContentPoolConfig
+}
+
+func NewPoolSource(pools map[string]config.ContentPoolConfig) *PoolSource {
+	return &PoolSource{pools: pools}
+}
+
+func (s *PoolSource) Name() string {
+	return "pool"
+}
+
+func (s *PoolSource) Fetch(ctx context.Context, niche string, kind models.ContentKind) ([]*models.ContentItem, error) {
+	pool, ok := s.pools[niche]
+	if !ok {
+		return nil, nil
+	}
+
+	if kind == models.ContentKindComment {
+		items := make([]*models.ContentItem, 0, len(pool.Comments))
+		for _, text := range pool.Comments {
+			items = append(items, newContentItem(kind, niche, s.Name(), text, "", ""))
+		}
+		return items, nil
+	}
+
+	// Every text can be posted alone or paired with any of the images
+	items := make([]*models.ContentItem, 0, len(pool.Texts)*(len(pool.Images)+1))
+	for _, text := range pool.Texts {
+		items = append(items, newContentItem(kind, niche, s.Name(), text, "", ""))
+		for _, image := range pool.Images {
+			items = append(items, newContentItem(kind, niche, s.Name(), text, image, ""))
+		}
+	}
+	return items, nil
+}
+
+// RSSSource turns recent feed entries into posts, refreshing each feed at most once per interval
+type RSSSource struct {
+	feeds    []config.FeedConfig
+	interval time.Duration
+	client   *http.Client
+	mu       sync.Mutex
+	cache    map[string]*rssCacheEntry
+}
+
+type rssCacheEntry struct {
+	items     []*models.ContentItem
+	fetchedAt time.Time
+}
+
+type rssDocument struct {
+	Channel struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			Enclosure   struct {
+				URL  string `xml:"url,attr"`
+				Type string `xml:"type,attr"`
+			} `xml:"enclosure"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+func NewRSSSource(feeds []config.FeedConfig, interval time.Duration) *RSSSource {
+	return &RSSSource{
+		feeds:    feeds,
+		interval: interval,
+		client:   &http.Client{Timeout: 15 * time.Second},
+		cache:    make(map[string]*rssCacheEntry),
+	}
+}
+
+func (s *RSSSource) Name() string {
+	return "rss"
+}
+
+func (s *RSSSource) Fetch(ctx context.Context, niche string, kind models.ContentKind) ([]*models.ContentItem, error) {
+	// Feed entries make posts, not comments
+	if kind != models.ContentKindPost {
+		return nil, nil
+	}
+
+	var items []*models.ContentItem
+	var lastErr error
+	for _, feed := range s.feeds {
+		if feed.Niche != niche {
+			continue
+		}
+
+		feedItems, err := s.fetchFeed(ctx, feed)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		items = append(items, feedItems...)
+	}
+
+	if len(items) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+func (s *RSSSource) fetchFeed(ctx context.Context, feed config.FeedConfig) ([]*models.ContentItem, error) {
+	s.mu.Lock()
+	entry, ok := s.cache[feed.URL]
+	s.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < s.interval {
+		return entry.items, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feed.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed %s: %w", feed.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed %s returned status %d", feed.URL, resp.StatusCode)
+	}
+
+	var doc rssDocument
+	if err := xml.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse feed %s: %w", feed.URL, err)
+	}
+
+	items := make([]*models.ContentItem, 0, len(doc.Channel.Items))
+	for _, entry := range doc.Channel.Items {
+		if entry.Title == "" {
+			continue
+		}
+
+		var image string
+		if strings.HasPrefix(entry.Enclosure.Type, "image/") {
+			image = entry.Enclosure.URL
+		}
+		items = append(items, newContentItem(models.ContentKindPost, feed.Niche, s.Name(), entry.Title, image, entry.Link))
+	}
+
+	s.mu.Lock()
+	s.cache[feed.URL] = &rssCacheEntry{items: items, fetchedAt: time.Now()}
+	s.mu.Unlock()
+
+	return items, nil
+}
+
+// LLMSource asks a text generator for a single fresh item on every fetch
+type LLMSource struct {
+	generator TextGenerator
+}
+
+func NewLLMSource(generator TextGenerator) *LLMSource {
+	return &LLMSource{generator: generator}
+}
+
+func (s *LLMSource) Name() string {
+	return "llm"
+}
+
+func (s *LLMSource) Fetch(ctx context.Context, niche string, kind models.ContentKind) ([]*models.ContentItem, error) {
+	text, err := s.generator.Generate(ctx, niche, kind)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	return []*models.ContentItem{newContentItem(kind, niche, s.Name(), text, "", "")}, nil
+}
+
+// HTTPTextGenerator calls an OpenAI-compatible chat completions endpoint
+type HTTPTextGenerator struct {
+	config config.LLMConfig
+	client *http.Client
+}
+
+func NewHTTPTextGenerator(cfg config.LLMConfig) *HTTPTextGenerator {
+	return &HTTPTextGenerator{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (g *HTTPTextGenerator) Generate(ctx context.Context, niche string, kind models.ContentKind) (string, error) {
+	prompt := fmt.Sprintf("Write one short social media post in Russian about %s. Reply with the post text only.", niche)
+	if kind == models.ContentKindComment {
+		prompt = fmt.Sprintf("Write one short friendly comment in Russian for a post about %s. Reply with the comment text only.", niche)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model": g.config.Model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"temperature": 1.0,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.config.APIKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("llm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("llm returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode llm response: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("llm returned no choices")
+	}
+
+	return result.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryContentRepository keeps usage in memory, mirroring the Mongo queries
+type memoryContentRepository struct {
+	usage []*models.ContentUsage
+}
+
+func (r *memoryContentRepository) RecordUsage(ctx context.Context, usage *models.ContentUsage) error {
+	usage.UsedAt = time.Now()
+	r.usage = append(r.usage, usage)
+	return nil
+}
+
+func (r *memoryContentRepository) GetAccountUsage(ctx context.Context, accountID primitive.ObjectID, since time.Time) (map[string]bool, error) {
+	used := make(map[string]bool)
+	for _, u := range r.usage {
+		if u.AccountID == accountID && !u.UsedAt.Before(since) {
+			used[u.ContentID] = true
+		}
+	}
+	return used, nil
+}
+
+func (r *memoryContentRepository) GetFleetUsage(ctx context.Context, contentIDs []string, since time.Time) (map[string]int, error) {
+	accounts := make(map[string]map[primitive.ObjectID]bool)
+	for _, u := range r.usage {
+		if u.UsedAt.Before(since) {
+			continue
+		}
+		if accounts[u.ContentID] == nil {
+			accounts[u.ContentID] = make(map[primitive.ObjectID]bool)
+		}
+		accounts[u.ContentID][u.AccountID] = true
+	}
+
+	counts := make(map[string]int)
+	for _, id := range contentIDs {
+		counts[id] = len(accounts[id])
+	}
+	return counts, nil
+}
+
+type staticTextGenerator struct {
+	text string
+}
+
+func (g *staticTextGenerator) Generate(ctx context.Context, niche string, kind models.ContentKind) (string, error) {
+	return g.text, nil
+}
+
+func newTestContentProvider(repo *memoryContentRepository, reuseLimit int) *ContentProvider {
+	cfg := config.ContentConfig{
+		Enabled:         true,
+		DefaultNiche:    "general",
+		DedupWindow:     24 * time.Hour,
+		FleetReuseLimit: reuseLimit,
+		Pools: map[string]config.ContentPoolConfig{
+			"general": {
+				Texts:    []string{"first", "second", "third"},
+				Comments: []string{"nice", "thanks"},
+			},
+		},
+	}
+	return NewContentProvider(repo, cfg, new(MockLogger))
+}
+
+func TestContentProvider_NoRepeatsPerAccount(t *testing.T) {
+	repo := &memoryContentRepository{}
+	provider := newTestContentProvider(repo, 0)
+	accountID := primitive.NewObjectID()
+	ctx := context.Background()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		item := provider.Next(ctx, accountID, "vk", "", models.ContentKindPost)
+		require.NotNil(t, item)
+		assert.False(t, seen[item.Text], "text %q repeated", item.Text)
+		assert.Equal(t, "general", item.Niche)
+		seen[item.Text] = true
+	}
+
+	// The pool is exhausted for this account, executors fall back to templates
+	assert.Nil(t, provider.Next(ctx, accountID, "vk", "", models.ContentKindPost))
+
+	// Comments are tracked separately from posts
+	assert.NotNil(t, provider.Next(ctx, accountID, "vk", "", models.ContentKindComment))
+}
+
+func TestContentProvider_SpreadsAcrossFleet(t *testing.T) {
+	repo := &memoryContentRepository{}
+	provider := newTestContentProvider(repo, 1)
+	ctx := context.Background()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		item := provider.Next(ctx, primitive.NewObjectID(), "telegram", "general", models.ContentKindPost)
+		require.NotNil(t, item)
+		assert.False(t, seen[item.ID], "item %q reused across accounts", item.Text)
+		seen[item.ID] = true
+	}
+
+	// Every item reached the reuse limit
+	assert.Nil(t, provider.Next(ctx, primitive.NewObjectID(), "telegram", "general", models.ContentKindPost))
+}
+
+func TestContentProvider_Disabled(t *testing.T) {
+	repo := &memoryContentRepository{}
+	provider := newTestContentProvider(repo, 0)
+	provider.config.Enabled = false
+
+	assert.Nil(t, provider.Next(context.Background(), primitive.NewObjectID(), "vk", "", models.ContentKindPost))
+	assert.Empty(t, repo.usage)
+
+	var nilProvider *ContentProvider
+	assert.Nil(t, nilProvider.Next(context.Background(), primitive.NewObjectID(), "vk", "", models.ContentKindPost))
+}
+
+func TestContentProvider_LLMSource(t *testing.T) {
+	repo := &memoryContentRepository{}
+	provider := newTestContentProvider(repo, 0)
+	provider.sources = nil
+	provider.AddSource(NewLLMSource(&staticTextGenerator{text: "  generated post  "}))
+
+	item := provider.Next(context.Background(), primitive.NewObjectID(), "vk", "travel", models.ContentKindPost)
+	require.NotNil(t, item)
+	assert.Equal(t, "generated post", item.Text)
+	assert.Equal(t, "llm", item.Source)
+	assert.Equal(t, "travel", item.Niche)
+}
+
+func TestPoolSource_PairsTextsWithImages(t *testing.T) {
+	source := NewPoolSource(map[string]config.ContentPoolConfig{
+		"food": {Texts: []string{"lunch"}, Images: []string{"a.jpg", "b.jpg"}},
+	})
+
+	items, err := source.Fetch(context.Background(), "food", models.ContentKindPost)
+	require.NoError(t, err)
+	assert.Len(t, items, 3)
+
+	ids := make(map[string]bool)
+	for _, item := range items {
+		ids[item.ID] = true
+	}
+	assert.Len(t, ids, 3)
+
+	items, err = source.Fetch(context.Background(), "unknown", models.ContentKindPost)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidExperiment  = errors.New("invalid experiment")
+	ErrExperimentConflict = errors.New("an experiment is already running on platform")
+)
+
+var experimentPlatforms = map[string]bool{"vk": true, "telegram": true, "mail": true, "max": true}
+
+func (s *warmingService) CreateExperiment(ctx context.Context, experiment *models.Experiment) (*models.Experiment, error) {
+	if err := validateExperiment(experiment); err != nil {
+		return nil, err
+	}
+
+	// One experiment per platform keeps every new account in a single split
+	running, err := s.experimentRepo.GetRunning(ctx, experiment.Platform)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		return nil, fmt.Errorf("%w %s: %s", ErrExperimentConflict, experiment.Platform, running.Name)
+	}
+
+	if err := s.experimentRepo.Create(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Started warming experiment %s on %s with %d variants", experiment.Name, experiment.Platform, len(experiment.Variants))
+	return experiment, nil
+}
+
+func (s *warmingService) StopExperiment(ctx context.Context, experimentID primitive.ObjectID) (*models.Experiment, error) {
+	if err := s.experimentRepo.Stop(ctx, experimentID); err != nil {
+		return nil, err
+	}
+
+	// Tasks already started keep their variant and still count towards the results
+	return s.experimentRepo.GetByID(ctx, experimentID)
+}
+
+func (s *warmingService) ListExperiments(ctx context.Context, platform, status string) ([]*models.Experiment, error) {
+	return s.experimentRepo.List(ctx, platform, status)
+}
+
+func (s *warmingService) GetExperimentResults(ctx context.Context, experimentID primitive.ObjectID) (*models.ExperimentResults, error) {
+	experiment, err := s.experimentRepo.GetByID(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.taskRepo.CountExperimentTasks(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := s.config.WarmingConfig.Experiments
+	return ComputeExperimentResults(experiment, counts, cfg.SignificanceLevel, cfg.MinSampleSize, time.Now()), nil
+}
+
+// assignExperiment picks the variant of the platform's running experiment for a new account.
+// Returns nils when experiments are disabled or none is running.
+func (s *warmingService) assignExperiment(ctx context.Context, accountID primitive.ObjectID, platform string) (*models.ExperimentVariant, *models.ExperimentAssignment) {
+	if !s.config.WarmingConfig.Experiments.Enabled {
+		return nil, nil
+	}
+
+	experiment, err := s.experimentRepo.GetRunning(ctx, platform)
+	if err != nil {
+		s.logger.Error("Failed to get running experiment for %s: %v", platform, err)
+		return nil, nil
+	}
+	if experiment == nil {
+		return nil, nil
+	}
+
+	variant := PickVariant(experiment, accountID)
+	if variant == nil {
+		return nil, nil
+	}
+
+	s.metrics.IncrementExperimentAssignments(platform, experiment.Name, variant.Name)
+	return variant, &models.ExperimentAssignment{
+		ExperimentID: experiment.ID,
+		Variant:      variant.Name,
+	}
+}
+
+// PickVariant maps an account to a variant by weight. The choice is derived from the experiment
+// and account IDs, so a retried auto-start lands in the same variant.
+func PickVariant(experiment *models.Experiment, accountID primitive.ObjectID) *models.ExperimentVariant {
+	totalWeight := 0
+	for _, variant := range experiment.Variants {
+		totalWeight += variant.Weight
+	}
+	if totalWeight <= 0 {
+		return nil
+	}
+
+	hash := fnv.New64a()
+	hash.Write(experiment.ID[:])
+	hash.Write(accountID[:])
+	point := int(hash.Sum64() % uint64(totalWeight))
+
+	for i := range experiment.Variants {
+		point -= experiment.Variants[i].Weight
+		if point < 0 {
+			return &experiment.Variants[i]
+		}
+	}
+
+	return nil
+}
+
+func validateExperiment(experiment *models.Experiment) error {
+	if experiment.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidExperiment)
+	}
+	if !experimentPlatforms[experiment.Platform] {
+		return fmt.Errorf("%w: unsupported platform %q", ErrInvalidExperiment, experiment.Platform)
+	}
+	if len(experiment.Variants) < 2 {
+		return fmt.Errorf("%w: at least two variants are required", ErrInvalidExperiment)
+	}
+
+	names := make(map[string]bool)
+	for i := range experiment.Variants {
+		variant := &experiment.Variants[i]
+		if variant.Name == "" {
+			return fmt.Errorf("%w: variant %d has no name", ErrInvalidExperiment, i+1)
+		}
+		if names[variant.Name] {
+			return fmt.Errorf("%w: duplicate variant %q", ErrInvalidExperiment, variant.Name)
+		}
+		names[variant.Name] = true
+
+		switch models.ScenarioType(variant.ScenarioType) {
+		case models.ScenarioBasic, models.ScenarioAdvanced:
+		case models.ScenarioCustom:
+			if variant.ScenarioID == nil {
+				return fmt.Errorf("%w: custom variant %q needs a scenario_id", ErrInvalidExperiment, variant.Name)
+			}
+		default:
+			// Resurrection runs on returning accounts, it can't compete for new ones
+			return fmt.Errorf("%w: variant %q has unsupported scenario type %q", ErrInvalidExperiment, variant.Name, variant.ScenarioType)
+		}
+
+		if variant.DurationDays < 14 || variant.DurationDays > 60 {
+			return fmt.Errorf("%w: variant %q duration must be between 14 and 60 days", ErrInvalidExperiment, variant.Name)
+		}
+		if variant.Weight == 0 {
+			variant.Weight = 1
+		}
+		if variant.Weight < 0 {
+			return fmt.Errorf("%w: variant %q has a negative weight", ErrInvalidExperiment, variant.Name)
+		}
+	}
+
+	return nil
+}
+
+// ComputeExperimentResults turns task counts into per-variant rates and compares the ready rate
+// of every variant with the control (the first variant) using a two-proportion z-test
+func ComputeExperimentResults(experiment *models.Experiment, counts []models.ExperimentTaskCount, significanceLevel float64, minSampleSize int, now time.Time) *models.ExperimentResults {
+	results := &models.ExperimentResults{
+		ExperimentID: experiment.ID.Hex(),
+		Name:         experiment.Name,
+		Platform:     experiment.Platform,
+		Status:       experiment.Status,
+		ComputedAt:   now,
+	}
+	if len(experiment.Variants) == 0 {
+		return results
+	}
+	results.Control = experiment.Variants[0].Name
+
+	index := make(map[string]int, len(experiment.Variants))
+	for i, variant := range experiment.Variants {
+		index[variant.Name] = i
+		results.Variants = append(results.Variants, models.VariantResult{
+			Name:         variant.Name,
+			ScenarioType: variant.ScenarioType,
+			DurationDays: variant.DurationDays,
+			PValue:       1,
+		})
+	}
+
+	for _, count := range counts {
+		i, ok := index[count.Variant]
+		if !ok {
+			continue
+		}
+		result := &results.Variants[i]
+		result.Assigned += count.Count
+
+		switch count.Status {
+		case string(models.TaskStatusCompleted):
+			result.Ready += count.Count
+		case string(models.TaskStatusFailed):
+			if isBanReason(count.LastError) {
+				result.Banned += count.Count
+			} else {
+				result.Failed += count.Count
+			}
+		default:
+			result.InProgress += count.Count
+		}
+	}
+
+	for i := range results.Variants {
+		result := &results.Variants[i]
+		finished := result.Ready + result.Banned + result.Failed
+		result.ReadyRate = rate(result.Ready, finished)
+		result.BanRate = rate(result.Banned, result.Assigned)
+		if result.Assigned > 0 {
+			result.SurvivalRate = 100 - result.BanRate
+		}
+	}
+
+	control := results.Variants[0]
+	controlFinished := control.Ready + control.Banned + control.Failed
+	worseThanControl := 0
+
+	for i := 1; i < len(results.Variants); i++ {
+		result := &results.Variants[i]
+		finished := result.Ready + result.Banned + result.Failed
+
+		result.PValue = TwoProportionPValue(control.Ready, controlFinished, result.Ready, finished)
+		result.Significant = controlFinished >= int64(minSampleSize) && finished >= int64(minSampleSize) &&
+			result.PValue < significanceLevel
+		if !result.Significant {
+			continue
+		}
+
+		if result.ReadyRate > control.ReadyRate {
+			if results.Winner == "" || result.ReadyRate > results.Variants[index[results.Winner]].ReadyRate {
+				results.Winner = result.Name
+			}
+		} else {
+			worseThanControl++
+		}
+	}
+
+	// The control wins only when it is significantly better than every other variant
+	if results.Winner == "" && worseThanControl == len(results.Variants)-1 {
+		results.Winner = control.Name
+	}
+
+	return results
+}
+
+// TwoProportionPValue returns the two-sided p-value of the difference between successes1/n1 and successes2/n2
+func TwoProportionPValue(successes1, n1, successes2, n2 int64) float64 {
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	p1 := float64(successes1) / float64(n1)
+	p2 := float64(successes2) / float64(n2)
+	pooled := float64(successes1+successes2) / float64(n1+n2)
+
+	standardError := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if standardError == 0 {
+		return 1
+	}
+
+	z := (p1 - p2) / standardError
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// isBanReason tells bans apart from other failures by the reason the task was stopped with
+func isBanReason(reason string) bool {
+	return reason != "" && categorizeError(errors.New(reason)) == ErrorTypeBan
+}
+
+func rate(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestExperiment() *models.Experiment {
+	return &models.Experiment{
+		ID:       primitive.NewObjectID(),
+		Name:     "advanced-vs-basic",
+		Platform: "vk",
+		Status:   string(models.ExperimentStatusRunning),
+		Variants: []models.ExperimentVariant{
+			{Name: "control", ScenarioType: "basic", DurationDays: 21, Weight: 1},
+			{Name: "advanced", ScenarioType: "advanced", DurationDays: 30, Weight: 3},
+		},
+	}
+}
+
+func TestPickVariantIsStable(t *testing.T) {
+	experiment := newTestExperiment()
+	accountID := primitive.NewObjectID()
+
+	first := PickVariant(experiment, accountID)
+	require.NotNil(t, first)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first.Name, PickVariant(experiment, accountID).Name)
+	}
+}
+
+func TestPickVariantFollowsWeights(t *testing.T) {
+	experiment := newTestExperiment()
+
+	counts := make(map[string]int)
+	for i := 0; i < 4000; i++ {
+		counts[PickVariant(experiment, primitive.NewObjectID()).Name]++
+	}
+
+	// Weights 1:3 put about a quarter of the accounts in the control
+	assert.InDelta(t, 1000, counts["control"], 150)
+	assert.InDelta(t, 3000, counts["advanced"], 150)
+}
+
+func TestPickVariantWithoutWeights(t *testing.T) {
+	experiment := newTestExperiment()
+	for i := range experiment.Variants {
+		experiment.Variants[i].Weight = 0
+	}
+
+	assert.Nil(t, PickVariant(experiment, primitive.NewObjectID()))
+}
+
+func TestValidateExperiment(t *testing.T) {
+	experiment := newTestExperiment()
+	experiment.Variants[0].Weight = 0
+	require.NoError(t, validateExperiment(experiment))
+	assert.Equal(t, 1, experiment.Variants[0].Weight)
+
+	invalid := map[string]func(e *models.Experiment){
+		"no name":           func(e *models.Experiment) { e.Name = "" },
+		"unknown platform":  func(e *models.Experiment) { e.Platform = "ok" },
+		"single variant":    func(e *models.Experiment) { e.Variants = e.Variants[:1] },
+		"duplicate variant": func(e *models.Experiment) { e.Variants[1].Name = "control" },
+		"resurrection":      func(e *models.Experiment) { e.Variants[1].ScenarioType = "resurrection" },
+		"custom without id": func(e *models.Experiment) { e.Variants[1].ScenarioType = "custom" },
+		"short duration":    func(e *models.Experiment) { e.Variants[1].DurationDays = 7 },
+		"negative weight":   func(e *models.Experiment) { e.Variants[1].Weight = -1 },
+		"unnamed variant":   func(e *models.Experiment) { e.Variants[0].Name = "" },
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			experiment := newTestExperiment()
+			mutate(experiment)
+			assert.True(t, errors.Is(validateExperiment(experiment), ErrInvalidExperiment))
+		})
+	}
+}
+
+func TestTwoProportionPValue(t *testing.T) {
+	// 60/100 vs 40/100: z = 2.828, two-sided p = 0.00468
+	assert.InDelta(t, 0.00468, TwoProportionPValue(60, 100, 40, 100), 1e-4)
+	assert.InDelta(t, 1, TwoProportionPValue(50, 100, 50, 100), 1e-9)
+	assert.Equal(t, float64(1), TwoProportionPValue(0, 0, 5, 10))
+	assert.Equal(t, float64(1), TwoProportionPValue(10, 10, 20, 20))
+}
+
+func TestComputeExperimentResults(t *testing.T) {
+	experiment := newTestExperiment()
+	counts := []models.ExperimentTaskCount{
+		{Variant: "control", Status: "completed", Count: 40},
+		{Variant: "control", Status: "failed", LastError: "Account banned", Count: 50},
+		{Variant: "control", Status: "failed", LastError: "Replaced by resurrection scenario", Count: 10},
+		{Variant: "control", Status: "in_progress", Count: 20},
+		{Variant: "advanced", Status: "completed", Count: 60},
+		{Variant: "advanced", Status: "failed", LastError: "Account banned", Count: 40},
+		{Variant: "removed", Status: "completed", Count: 5},
+	}
+
+	results := ComputeExperimentResults(experiment, counts, 0.05, 30, time.Now())
+	require.Len(t, results.Variants, 2)
+	assert.Equal(t, "control", results.Control)
+
+	control := results.Variants[0]
+	assert.Equal(t, int64(120), control.Assigned)
+	assert.Equal(t, int64(20), control.InProgress)
+	assert.Equal(t, int64(40), control.Ready)
+	assert.Equal(t, int64(50), control.Banned)
+	assert.Equal(t, int64(10), control.Failed)
+	assert.InDelta(t, 40, control.ReadyRate, 1e-9)
+	assert.InDelta(t, 50.0/120*100, control.BanRate, 1e-9)
+	assert.InDelta(t, 100-50.0/120*100, control.SurvivalRate, 1e-9)
+	assert.False(t, control.Significant)
+
+	advanced := results.Variants[1]
+	assert.InDelta(t, 60, advanced.ReadyRate, 1e-9)
+	assert.Less(t, advanced.PValue, 0.05)
+	assert.True(t, advanced.Significant)
+	assert.Equal(t, "advanced", results.Winner)
+}
+
+func TestComputeExperimentResultsNeedsSamples(t *testing.T) {
+	experiment := newTestExperiment()
+	counts := []models.ExperimentTaskCount{
+		{Variant: "control", Status: "completed", Count: 2},
+		{Variant: "control", Status: "failed", LastError: "Account banned", Count: 8},
+		{Variant: "advanced", Status: "completed", Count: 9},
+		{Variant: "advanced", Status: "failed", LastError: "Account banned", Count: 1},
+	}
+
+	results := ComputeExperimentResults(experiment, counts, 0.05, 30, time.Now())
+	assert.Less(t, results.Variants[1].PValue, 0.05)
+	assert.False(t, results.Variants[1].Significant)
+	assert.Empty(t, results.Winner)
+}
+
+func TestComputeExperimentResultsControlWins(t *testing.T) {
+	experiment := newTestExperiment()
+	counts := []models.ExperimentTaskCount{
+		{Variant: "control", Status: "completed", Count: 80},
+		{Variant: "control", Status: "failed", LastError: "Account banned", Count: 20},
+		{Variant: "advanced", Status: "completed", Count: 50},
+		{Variant: "advanced", Status: "failed", LastError: "Account banned", Count: 50},
+	}
+
+	results := ComputeExperimentResults(experiment, counts, 0.05, 30, time.Now())
+	assert.Equal(t, "control", results.Winner)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
+	mailpb "github.com/grigta/conveer/services/mail-service/proto"
 	"github.com/grigta/conveer/services/warming-service/internal/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc"
@@ -68,6 +69,20 @@ func (e *MailExecutor) ValidateAccount(ctx context.Context, accountID primitive.
 	return nil
 }
 
+// ProfileCompleteness reports how much of the account profile is filled in Mail service
+func (e *MailExecutor) ProfileCompleteness(ctx context.Context, accountID primitive.ObjectID) (float64, error) {
+	if e.client == nil {
+		return 0, fmt.Errorf("mail service is not connected")
+	}
+
+	account, err := mailpb.NewMailServiceClient(e.client).GetAccount(ctx, &mailpb.GetAccountRequest{AccountId: accountID.Hex()})
+	if err != nil {
+		return 0, err
+	}
+
+	return filledShare(account.FirstName, account.LastName, account.Phone, account.PersonaId), nil
+}
+
 func (e *MailExecutor) readEmail(ctx context.Context, execCtx *models.ExecutionContext) error {
 	// Simulate reading emails
 	emailCount := 3 + rand.Intn(5)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
+	maxpb "github.com/grigta/conveer/services/max-service/proto"
 	"github.com/grigta/conveer/services/warming-service/internal/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc"
@@ -65,6 +66,20 @@ func (e *MaxExecutor) ValidateAccount(ctx context.Context, accountID primitive.O
 	return nil
 }
 
+// ProfileCompleteness reports how much of the account profile is filled in Max service
+func (e *MaxExecutor) ProfileCompleteness(ctx context.Context, accountID primitive.ObjectID) (float64, error) {
+	if e.client == nil {
+		return 0, fmt.Errorf("max service is not connected")
+	}
+
+	account, err := maxpb.NewMaxServiceClient(e.client).GetAccount(ctx, &maxpb.GetAccountRequest{AccountId: accountID.Hex()})
+	if err != nil {
+		return 0, err
+	}
+
+	return filledShare(account.FirstName, account.LastName, account.Username, account.PersonaId), nil
+}
+
 func (e *MaxExecutor) readMessages(ctx context.Context, execCtx *models.ExecutionContext) error {
 	// Simulate reading messages
 	messageCount := 5 + rand.Intn(10)
@@ -1,18 +1,35 @@
 package service
 
 import (
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 type Metrics struct {
-	tasksTotal       *prometheus.CounterVec
-	tasksActive      *prometheus.GaugeVec
-	actionsTotal     *prometheus.CounterVec
-	actionDuration   *prometheus.HistogramVec
-	taskDuration     *prometheus.HistogramVec
-	errorsTotal      *prometheus.CounterVec
-	accountsReady    *prometheus.CounterVec
+	tasksTotal            *prometheus.CounterVec
+	tasksActive           *prometheus.GaugeVec
+	actionsTotal          *prometheus.CounterVec
+	actionDuration        *prometheus.HistogramVec
+	taskDuration          *prometheus.HistogramVec
+	errorsTotal           *prometheus.CounterVec
+	accountsReady         *prometheus.CounterVec
+	capacity              *prometheus.GaugeVec
+	demand                *prometheus.GaugeVec
+	utilization           *prometheus.GaugeVec
+	tasksQueued           *prometheus.GaugeVec
+	admissionsTotal       *prometheus.CounterVec
+	checkpointsTotal      *prometheus.CounterVec
+	experimentAssignments *prometheus.CounterVec
+	verificationsTotal    *prometheus.CounterVec
+	verificationRate      *prometheus.GaugeVec
+	readinessScore        *prometheus.HistogramVec
+	graduationsTotal      *prometheus.CounterVec
+	platformLatency       *prometheus.HistogramVec
+	actionRetries         *prometheus.CounterVec
+	actionStreamsOpen     *prometheus.GaugeVec
+	callbackDeliveries    *prometheus.CounterVec
 }
 
 func NewMetrics() *Metrics {
@@ -74,6 +91,128 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"platform"},
 		),
+
+		capacity: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "warming_capacity_actions_per_hour",
+				Help: "Sustainable warming actions per hour of the platform executor",
+			},
+			[]string{"platform"},
+		),
+
+		demand: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "warming_demand_actions_per_hour",
+				Help: "Expected warming actions per hour of active tasks",
+			},
+			[]string{"platform"},
+		),
+
+		utilization: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "warming_capacity_utilization",
+				Help: "Ratio of warming demand to executor capacity",
+			},
+			[]string{"platform"},
+		),
+
+		tasksQueued: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "warming_tasks_queued",
+				Help: "Number of warming tasks waiting for executor capacity",
+			},
+			[]string{"platform"},
+		),
+
+		admissionsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "warming_admissions_total",
+				Help: "Total number of capacity admission decisions",
+			},
+			[]string{"platform", "decision"},
+		),
+
+		checkpointsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "warming_resurrection_checkpoints_total",
+				Help: "Total number of diagnostic checkpoints run by the resurrection scenario",
+			},
+			[]string{"platform", "result"},
+		),
+
+		experimentAssignments: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "warming_experiment_assignments_total",
+				Help: "Total number of new accounts assigned to experiment variants",
+			},
+			[]string{"platform", "experiment", "variant"},
+		),
+
+		verificationsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "warming_action_verifications_total",
+				Help: "Total number of sampled warming actions checked for evidence",
+			},
+			[]string{"platform", "scenario", "result"},
+		),
+
+		verificationRate: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "warming_action_verification_rate",
+				Help: "Share of sampled warming actions of a scenario confirmed by evidence",
+			},
+			[]string{"platform", "scenario"},
+		),
+
+		readinessScore: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "warming_readiness_score",
+				Help:    "Daily readiness scores of warming tasks",
+				Buckets: prometheus.LinearBuckets(10, 10, 10),
+			},
+			[]string{"platform"},
+		),
+
+		graduationsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "warming_graduations_total",
+				Help: "Total number of accounts marked ready by readiness score before the scenario ended",
+			},
+			[]string{"platform"},
+		),
+
+		platformLatency: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "warming_platform_action_latency_seconds",
+				Help:    "Time platform services spent on actions sent over the action stream",
+				Buckets: prometheus.ExponentialBuckets(0.05, 2, 10), // 50ms to ~25s
+			},
+			[]string{"platform", "action_type"},
+		),
+
+		actionRetries: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "warming_action_retries_total",
+				Help: "Total number of actions sent again after a retryable failure",
+			},
+			[]string{"platform", "action_type"},
+		),
+
+		actionStreamsOpen: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "warming_action_streams_open",
+				Help: "Whether the action stream to the platform service is open",
+			},
+			[]string{"platform"},
+		),
+
+		callbackDeliveries: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "warming_callback_deliveries_total",
+				Help: "Total number of task callback calls by result: delivered, retried or failed",
+			},
+			[]string{"milestone", "result"},
+		),
 	}
 }
 
@@ -116,3 +255,58 @@ func (m *Metrics) IncrementErrorsTotal(platform, errorType string) {
 func (m *Metrics) IncrementAccountsReady(platform string) {
 	m.accountsReady.WithLabelValues(platform).Inc()
 }
+
+func (m *Metrics) ObserveCapacity(capacity *models.PlatformCapacity) {
+	m.capacity.WithLabelValues(capacity.Platform).Set(capacity.CapacityPerHour)
+	m.demand.WithLabelValues(capacity.Platform).Set(capacity.DemandPerHour)
+	m.utilization.WithLabelValues(capacity.Platform).Set(capacity.Utilization)
+	m.tasksQueued.WithLabelValues(capacity.Platform).Set(float64(capacity.QueuedTasks))
+}
+
+func (m *Metrics) IncrementAdmissions(platform, decision string) {
+	m.admissionsTotal.WithLabelValues(platform, decision).Inc()
+}
+
+func (m *Metrics) IncrementCheckpoints(platform, result string) {
+	m.checkpointsTotal.WithLabelValues(platform, result).Inc()
+}
+
+func (m *Metrics) IncrementExperimentAssignments(platform, experiment, variant string) {
+	m.experimentAssignments.WithLabelValues(platform, experiment, variant).Inc()
+}
+
+func (m *Metrics) IncrementVerifications(platform, scenario, result string) {
+	m.verificationsTotal.WithLabelValues(platform, scenario, result).Inc()
+}
+
+func (m *Metrics) SetVerificationRate(platform, scenario string, rate float64) {
+	m.verificationRate.WithLabelValues(platform, scenario).Set(rate)
+}
+
+func (m *Metrics) ObserveReadinessScore(platform string, score float64) {
+	m.readinessScore.WithLabelValues(platform).Observe(score)
+}
+
+func (m *Metrics) IncrementGraduations(platform string) {
+	m.graduationsTotal.WithLabelValues(platform).Inc()
+}
+
+func (m *Metrics) ObservePlatformLatency(platform, actionType string, seconds float64) {
+	m.platformLatency.WithLabelValues(platform, actionType).Observe(seconds)
+}
+
+func (m *Metrics) IncrementActionRetries(platform, actionType string) {
+	m.actionRetries.WithLabelValues(platform, actionType).Inc()
+}
+
+func (m *Metrics) SetActionStreamOpen(platform string, open bool) {
+	value := 0.0
+	if open {
+		value = 1
+	}
+	m.actionStreamsOpen.WithLabelValues(platform).Set(value)
+}
+
+func (m *Metrics) IncrementCallbackDeliveries(milestone, result string) {
+	m.callbackDeliveries.WithLabelValues(milestone, result).Inc()
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProfileInspector is implemented by executors whose platform service reports the account profile
+type ProfileInspector interface {
+	// ProfileCompleteness returns the share of filled profile fields, 0-1
+	ProfileCompleteness(ctx context.Context, accountID primitive.ObjectID) (float64, error)
+}
+
+// ReadinessInput is what the readiness formula is computed from
+type ReadinessInput struct {
+	DaysWarmed          int
+	ActionsCompleted    int
+	Incidents           int
+	ProfileCompleteness float64
+}
+
+// ComputeReadiness weighs the components of the input into a 0-100 score.
+// The incidents component is all or nothing: any incident zeroes it.
+func ComputeReadiness(formula config.ReadinessFormula, input ReadinessInput) models.ReadinessScore {
+	score := models.ReadinessScore{
+		Threshold:   formula.Threshold,
+		Day:         input.DaysWarmed,
+		Days:        fraction(input.DaysWarmed, formula.TargetDays),
+		Actions:     fraction(input.ActionsCompleted, formula.TargetActions),
+		Incidents:   input.Incidents,
+		Profile:     math.Max(0, math.Min(input.ProfileCompleteness, 1)),
+		EvaluatedAt: time.Now(),
+	}
+
+	incidents := 0.0
+	if input.Incidents == 0 {
+		incidents = 1
+	}
+
+	w := formula.Weights
+	total := w.Days + w.Actions + w.Incidents + w.Profile
+	if total <= 0 {
+		return score
+	}
+
+	weighted := w.Days*score.Days + w.Actions*score.Actions + w.Incidents*incidents + w.Profile*score.Profile
+	score.Score = math.Round(weighted/total*1000) / 10
+	score.Ready = input.DaysWarmed >= formula.MinDays && score.Score >= formula.Threshold
+
+	return score
+}
+
+func fraction(value, target int) float64 {
+	if target <= 0 {
+		return 1
+	}
+	return math.Min(float64(value)/float64(target), 1)
+}
+
+// filledShare is the profile completeness of an account with the given profile fields
+func filledShare(fields ...string) float64 {
+	if len(fields) == 0 {
+		return 0
+	}
+
+	filled := 0
+	for _, field := range fields {
+		if field != "" {
+			filled++
+		}
+	}
+	return float64(filled) / float64(len(fields))
+}
+
+// evaluateReadiness scores the task as of the given day and stores the result on it
+func (s *warmingService) evaluateReadiness(ctx context.Context, task *models.WarmingTask, day int) models.ReadinessScore {
+	formula := s.config.WarmingConfig.Readiness.Formula(task.Platform)
+
+	incidents, err := s.statsRepo.CountFailedActions(ctx, task.ID, formula.IncidentErrorTypes)
+	if err != nil {
+		s.logger.Error("Failed to count incidents of task %s: %v", task.ID.Hex(), err)
+	}
+	for _, checkpoint := range task.Checkpoints {
+		if !checkpoint.Passed {
+			incidents++
+		}
+	}
+
+	// An unreachable platform service leaves the profile component at zero, delaying graduation
+	var profile float64
+	if inspector, ok := s.platformExecs[task.Platform].(ProfileInspector); ok {
+		if profile, err = inspector.ProfileCompleteness(ctx, task.AccountID); err != nil {
+			s.logger.Warn("Failed to get profile of account %s: %v", task.AccountID.Hex(), err)
+		}
+	}
+
+	score := ComputeReadiness(formula, ReadinessInput{
+		DaysWarmed:          day,
+		ActionsCompleted:    task.ActionsCompleted,
+		Incidents:           incidents,
+		ProfileCompleteness: profile,
+	})
+
+	if err := s.taskRepo.Update(ctx, task.ID, models.TaskUpdate{Readiness: &score}); err != nil {
+		s.logger.Error("Failed to save readiness of task %s: %v", task.ID.Hex(), err)
+	}
+	task.Readiness = &score
+	s.metrics.ObserveReadinessScore(task.Platform, score.Score)
+
+	return score
+}
+
+// graduateIfReady marks the account ready and stops warming once the score reaches the threshold.
+// Returns true when the task was completed.
+func (s *warmingService) graduateIfReady(ctx context.Context, task *models.WarmingTask, day int) bool {
+	// Resurrection tasks run to the end so every checkpoint is passed
+	if !s.config.WarmingConfig.Readiness.Enabled || task.ScenarioType == string(models.ScenarioResurrection) {
+		return false
+	}
+
+	score := s.evaluateReadiness(ctx, task, day)
+	if !score.Ready {
+		return false
+	}
+
+	s.logger.Info("Account %s reached readiness %.1f on day %d of %d, graduating task %s",
+		task.AccountID.Hex(), score.Score, day, task.DurationDays, task.ID.Hex())
+
+	if err := s.completeTask(ctx, task.ID); err != nil {
+		s.logger.Error("Failed to graduate task %s: %v", task.ID.Hex(), err)
+		return false
+	}
+	s.metrics.IncrementGraduations(task.Platform)
+
+	return true
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readinessFormula() config.ReadinessFormula {
+	return config.ReadinessFormula{
+		Threshold:     80,
+		MinDays:       7,
+		TargetDays:    14,
+		TargetActions: 100,
+		Weights:       config.ReadinessWeights{Days: 0.4, Actions: 0.2, Incidents: 0.2, Profile: 0.2},
+	}
+}
+
+func TestComputeReadiness(t *testing.T) {
+	formula := readinessFormula()
+
+	score := ComputeReadiness(formula, ReadinessInput{DaysWarmed: 14, ActionsCompleted: 150, ProfileCompleteness: 1})
+	assert.Equal(t, 100.0, score.Score)
+	assert.Equal(t, 1.0, score.Actions)
+	assert.True(t, score.Ready)
+
+	// 0.4*0.5 + 0.2*0.5 + 0.2 + 0.2*0.5
+	score = ComputeReadiness(formula, ReadinessInput{DaysWarmed: 7, ActionsCompleted: 50, ProfileCompleteness: 0.5})
+	assert.Equal(t, 60.0, score.Score)
+	assert.False(t, score.Ready)
+	assert.Equal(t, 7, score.Day)
+}
+
+func TestComputeReadinessIncidentsZeroTheComponent(t *testing.T) {
+	score := ComputeReadiness(readinessFormula(), ReadinessInput{DaysWarmed: 14, ActionsCompleted: 100, Incidents: 1, ProfileCompleteness: 1})
+	assert.Equal(t, 80.0, score.Score)
+	assert.Equal(t, 1, score.Incidents)
+	assert.True(t, score.Ready)
+
+	formula := readinessFormula()
+	formula.Threshold = 90
+	assert.False(t, ComputeReadiness(formula, ReadinessInput{DaysWarmed: 14, ActionsCompleted: 100, Incidents: 2, ProfileCompleteness: 1}).Ready)
+}
+
+func TestComputeReadinessRespectsMinDays(t *testing.T) {
+	formula := readinessFormula()
+	formula.TargetDays = 3
+
+	score := ComputeReadiness(formula, ReadinessInput{DaysWarmed: 5, ActionsCompleted: 100, ProfileCompleteness: 1})
+	assert.Equal(t, 100.0, score.Score)
+	assert.False(t, score.Ready)
+}
+
+func TestReadinessFormulaPlatformOverride(t *testing.T) {
+	cfg := config.ReadinessConfig{
+		Default: readinessFormula(),
+		Platforms: map[string]config.ReadinessFormula{
+			"mail": {Threshold: 70, Weights: config.ReadinessWeights{Days: 1}},
+		},
+	}
+
+	mail := cfg.Formula("mail")
+	assert.Equal(t, 70.0, mail.Threshold)
+	assert.Equal(t, 14, mail.TargetDays)
+	assert.Equal(t, config.ReadinessWeights{Days: 1}, mail.Weights)
+
+	assert.Equal(t, readinessFormula(), cfg.Formula("vk"))
+}
+
+func TestFilledShare(t *testing.T) {
+	assert.Equal(t, 0.5, filledShare("Ivan", "", "ivan_p", ""))
+	assert.Equal(t, 0.0, filledShare())
+}
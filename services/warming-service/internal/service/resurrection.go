@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// appealApprovedEvent is published by vk-service when an appeal lifts a restriction
+const appealApprovedEvent = "appeal_approved"
+
+// ResurrectionQueue returns the queue receiving status events of a platform's accounts
+func ResurrectionQueue(platform string) string {
+	return "warming.resurrection." + platform
+}
+
+// accountStatusEvent covers the status change and appeal events published by platform services
+type accountStatusEvent struct {
+	AccountID string `json:"account_id"`
+	Type      string `json:"type"`
+	OldStatus string `json:"old_status"`
+	NewStatus string `json:"new_status"`
+}
+
+// SelectScenarioType picks the scenario for an account whose status changed.
+// Returns an empty string when the change doesn't call for a new warming task.
+func SelectScenarioType(cfg config.ResurrectionConfig, eventType, oldStatus, newStatus string) string {
+	if !cfg.Enabled {
+		return ""
+	}
+
+	if eventType == appealApprovedEvent {
+		return string(models.ScenarioResurrection)
+	}
+
+	if containsStatus(cfg.QuarantineStatuses, oldStatus) && containsStatus(cfg.ActiveStatuses, newStatus) {
+		return string(models.ScenarioResurrection)
+	}
+
+	return ""
+}
+
+// DueCheckpoint returns the latest checkpoint day the task has reached but not yet passed
+func DueCheckpoint(task *models.WarmingTask, checkpointDays []int) (int, bool) {
+	due := -1
+	for _, day := range checkpointDays {
+		if day <= task.CurrentDay && day > due {
+			due = day
+		}
+	}
+	if due < 0 {
+		return 0, false
+	}
+
+	for _, checkpoint := range task.Checkpoints {
+		if checkpoint.Day == due && checkpoint.Passed {
+			return 0, false
+		}
+	}
+
+	return due, true
+}
+
+// stretchDelay lengthens the wait before the next action by the given factor
+func stretchDelay(currentTime, nextTime time.Time, multiplier float64) time.Time {
+	if multiplier <= 1 || !nextTime.After(currentTime) {
+		return nextTime
+	}
+	return currentTime.Add(time.Duration(float64(nextTime.Sub(currentTime)) * multiplier))
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *warmingService) runResurrectionConsumer(ctx context.Context, platform string) {
+	err := s.messaging.ConsumeWithHandler(ctx, ResurrectionQueue(platform), "warming-resurrection-"+platform, func(msg []byte) error {
+		var event accountStatusEvent
+		if err := json.Unmarshal(msg, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+
+		scenarioType := SelectScenarioType(s.config.WarmingConfig.Resurrection, event.Type, event.OldStatus, event.NewStatus)
+		if scenarioType == "" {
+			return nil
+		}
+
+		accountID, err := primitive.ObjectIDFromHex(event.AccountID)
+		if err != nil {
+			s.logger.Error("Skipping status event with invalid account id %s", event.AccountID)
+			return nil
+		}
+
+		return s.startResurrection(ctx, accountID, platform)
+	})
+
+	if err != nil {
+		s.logger.Error("Resurrection consumer error for %s: %v", platform, err)
+	}
+}
+
+// startResurrection replaces whatever task the account had before quarantine with the resurrection scenario
+func (s *warmingService) startResurrection(ctx context.Context, accountID primitive.ObjectID, platform string) error {
+	existing, err := s.taskRepo.GetByAccountAndPlatform(ctx, accountID, platform)
+	if err != nil {
+		return fmt.Errorf("failed to check existing task: %w", err)
+	}
+
+	if existing != nil && existing.Status != string(models.TaskStatusCompleted) && existing.Status != string(models.TaskStatusFailed) {
+		if existing.ScenarioType == string(models.ScenarioResurrection) {
+			return nil
+		}
+		if err := s.stopTask(ctx, existing.ID, "Replaced by resurrection scenario"); err != nil {
+			return fmt.Errorf("failed to stop task %s: %w", existing.ID.Hex(), err)
+		}
+	}
+
+	task, err := s.StartWarming(ctx, accountID, platform, string(models.ScenarioResurrection), nil, s.config.WarmingConfig.Resurrection.DurationDays, nil)
+	if err != nil {
+		s.logger.Error("Failed to start resurrection for account %s: %v", accountID.Hex(), err)
+		return err
+	}
+
+	s.logger.Info("Started resurrection task %s for account %s on %s after quarantine", task.ID.Hex(), accountID.Hex(), platform)
+	return nil
+}
+
+// runResurrectionCheckpoint validates the account on checkpoint days before it is allowed to act.
+// Returns false when the account failed the check and the task was paused or stopped.
+func (s *warmingService) runResurrectionCheckpoint(ctx context.Context, task *models.WarmingTask) bool {
+	day, due := DueCheckpoint(task, s.config.WarmingConfig.Resurrection.CheckpointDays)
+	if !due {
+		return true
+	}
+
+	executor, ok := s.platformExecs[task.Platform]
+	if !ok {
+		return true
+	}
+
+	err := executor.ValidateAccount(ctx, task.AccountID)
+	checkpoint := models.ResurrectionCheckpoint{
+		Day:       day,
+		Passed:    err == nil,
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		checkpoint.Error = err.Error()
+	}
+
+	if updateErr := s.taskRepo.Update(ctx, task.ID, models.TaskUpdate{Checkpoint: &checkpoint}); updateErr != nil {
+		s.logger.Error("Failed to save checkpoint for task %s: %v", task.ID.Hex(), updateErr)
+	}
+	task.Checkpoints = append(task.Checkpoints, checkpoint)
+
+	result := "passed"
+	if err != nil {
+		result = "failed"
+	}
+	s.metrics.IncrementCheckpoints(task.Platform, result)
+
+	s.publishEvent("warming.resurrection.checkpoint", task.Platform, map[string]interface{}{
+		"task_id":    task.ID.Hex(),
+		"account_id": task.AccountID.Hex(),
+		"day":        day,
+		"passed":     checkpoint.Passed,
+		"error":      checkpoint.Error,
+	})
+
+	if err == nil {
+		s.logger.Info("Task %s passed resurrection checkpoint on day %d", task.ID.Hex(), day)
+		return true
+	}
+
+	if categorizeError(err) == ErrorTypeBan {
+		s.stopTask(ctx, task.ID, "Account restricted again: "+err.Error())
+		s.updateAccountStatus(ctx, task.AccountID, task.Platform, "banned")
+	} else {
+		s.pauseTask(ctx, task.ID, fmt.Sprintf("Resurrection checkpoint on day %d failed: %v", day, err))
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resurrectionConfig() config.ResurrectionConfig {
+	return config.ResurrectionConfig{
+		Enabled:            true,
+		QuarantineStatuses: []string{"suspended", "appealing"},
+		ActiveStatuses:     []string{"created", "ready"},
+	}
+}
+
+// Test SelectScenarioType
+func TestSelectScenarioType(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		oldStatus string
+		newStatus string
+		expected  string
+	}{
+		{"released from suspension", "", "suspended", "ready", "resurrection"},
+		{"appeal approved", "appeal_approved", "", "", "resurrection"},
+		{"regular progress", "", "warming", "ready", ""},
+		{"put into quarantine", "", "ready", "suspended", ""},
+		{"quarantine to warming", "", "appealing", "warming", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SelectScenarioType(resurrectionConfig(), tt.eventType, tt.oldStatus, tt.newStatus))
+		})
+	}
+}
+
+// Test SelectScenarioType - disabled
+func TestSelectScenarioType_Disabled(t *testing.T) {
+	cfg := resurrectionConfig()
+	cfg.Enabled = false
+
+	assert.Empty(t, SelectScenarioType(cfg, "appeal_approved", "suspended", "ready"))
+}
+
+// Test DueCheckpoint
+func TestDueCheckpoint(t *testing.T) {
+	days := []int{0, 3, 7}
+
+	task := &models.WarmingTask{CurrentDay: 0}
+	day, due := DueCheckpoint(task, days)
+	assert.True(t, due)
+	assert.Equal(t, 0, day)
+
+	task.Checkpoints = []models.ResurrectionCheckpoint{{Day: 0, Passed: true}}
+	_, due = DueCheckpoint(task, days)
+	assert.False(t, due)
+
+	task.CurrentDay = 5
+	day, due = DueCheckpoint(task, days)
+	assert.True(t, due)
+	assert.Equal(t, 3, day)
+
+	// A failed checkpoint is retried once the task is resumed
+	task.Checkpoints = append(task.Checkpoints, models.ResurrectionCheckpoint{Day: 3, Passed: false})
+	_, due = DueCheckpoint(task, days)
+	assert.True(t, due)
+
+	task.Checkpoints = append(task.Checkpoints, models.ResurrectionCheckpoint{Day: 3, Passed: true})
+	_, due = DueCheckpoint(task, days)
+	assert.False(t, due)
+}
+
+// Test stretchDelay
+func TestStretchDelay(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	next := now.Add(10 * time.Minute)
+
+	assert.Equal(t, now.Add(30*time.Minute), stretchDelay(now, next, 3))
+	assert.Equal(t, next, stretchDelay(now, next, 1))
+	assert.Equal(t, now, stretchDelay(now, now, 3))
+}
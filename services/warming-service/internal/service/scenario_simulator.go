@@ -0,0 +1,244 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+)
+
+const (
+	maxSimulatedAccounts    = 1000
+	maxSimulationTimeline   = 2000 // volumes and collisions still cover every simulated action
+	maxSimulationCollisions = 200
+)
+
+// SimulateScenario dry-runs a scenario for a fleet of accounts with the current behavior
+// settings and the platform executor capacity. Nothing is executed or stored.
+func (s *warmingService) SimulateScenario(scenario *models.WarmingScenario, params models.ScenarioSimulationParams) *models.ScenarioSimulation {
+	capacityPerHour := 0.0
+	if scenario != nil {
+		capacityPerHour = s.capacity.Estimate(scenario.Platform, nil, 0).CapacityPerHour
+	}
+	return SimulateScenario(scenario, params, s.config.WarmingConfig.BehaviorSimulation, capacityPerHour)
+}
+
+// SimulateScenario plans every day of the scenario for params.Accounts accounts the way the
+// scheduler does: a random number of actions within the stage range, spread over the waking
+// hours of each account's default activity calendar, typed by weight with max_per_day limits.
+// Days off and weekend skips follow the behavior settings.
+//
+// Collisions are reported where an account has to exceed an action's max_per_day because
+// every other action is capped too, and where the fleet plans more actions in an hour than
+// capacityPerHour. Invalid scenarios are returned with errors and no timeline.
+func SimulateScenario(scenario *models.WarmingScenario, params models.ScenarioSimulationParams, behavior config.BehaviorSimulationConfig, capacityPerHour float64) *models.ScenarioSimulation {
+	validation := ValidateScenario(scenario, params.DurationDays)
+
+	result := &models.ScenarioSimulation{
+		Errors:          validation.Errors,
+		Accounts:        params.Accounts,
+		DurationDays:    params.DurationDays,
+		Seed:            params.Seed,
+		CapacityPerHour: capacityPerHour,
+		CollisionCounts: make(map[string]int),
+	}
+	if params.Accounts < 1 || params.Accounts > maxSimulatedAccounts {
+		result.Errors = append(result.Errors, fmt.Sprintf("accounts must be between 1 and %d", maxSimulatedAccounts))
+	}
+	if len(result.Errors) > 0 {
+		return result
+	}
+	result.Valid = true
+
+	if result.Seed == 0 {
+		result.Seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(result.Seed))
+
+	loc := time.Local
+	if behavior.Timezone != "" {
+		if l, err := time.LoadLocation(behavior.Timezone); err == nil {
+			loc = l
+		}
+	}
+	start := params.StartAt
+	if start.IsZero() {
+		start = time.Now().AddDate(0, 0, 1)
+	}
+	start = start.In(loc)
+	result.StartAt = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+
+	// Simulated accounts get stable keys, so their personas and days off repeat between runs
+	keys := make([]string, params.Accounts)
+	calendars := make([]*models.ActivityCalendar, params.Accounts)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("simulated-%d", i+1)
+		calendars[i] = DefaultActivityCalendar(keys[i], behavior)
+	}
+
+	hourly := make(map[time.Time]int)
+	var collisions []models.SimulationCollision
+
+	for _, stage := range scenarioStages(scenario, params.DurationDays) {
+		actions := stage.schedule.Actions
+		if len(actions) == 0 {
+			actions = scenario.Actions
+		}
+
+		for day := stage.fromDay; day <= stage.toDay; day++ {
+			date := result.StartAt.AddDate(0, 0, day-1)
+			volume := models.SimulatedDayVolume{
+				Day:           day,
+				Date:          date.Format(dayOffDateLayout),
+				Stage:         stage.name,
+				MinPerAccount: -1,
+				ActionsByType: make(map[string]int),
+			}
+
+			var planned []models.SimulatedAction
+			for i, calendar := range calendars {
+				if isDayOff(calendar, keys[i], behavior.Holidays, date) {
+					continue
+				}
+				volume.ActiveAccounts++
+
+				accountActions, accountCollisions := simulateAccountDay(rng, i+1, day, date, calendar, stage.schedule, actions, behavior)
+				collisions = append(collisions, accountCollisions...)
+
+				if volume.MinPerAccount < 0 || len(accountActions) < volume.MinPerAccount {
+					volume.MinPerAccount = len(accountActions)
+				}
+				volume.MaxPerAccount = max(volume.MaxPerAccount, len(accountActions))
+				for _, action := range accountActions {
+					volume.ActionsByType[action.ActionType]++
+					hourly[action.At.Truncate(time.Hour)]++
+				}
+				volume.Actions += len(accountActions)
+
+				if len(result.Timeline)+len(planned) < maxSimulationTimeline {
+					planned = append(planned, accountActions...)
+				}
+			}
+			if volume.MinPerAccount < 0 {
+				volume.MinPerAccount = 0
+			}
+
+			sort.SliceStable(planned, func(a, b int) bool { return planned[a].At.Before(planned[b].At) })
+			for _, action := range planned {
+				if len(result.Timeline) == maxSimulationTimeline {
+					break
+				}
+				result.Timeline = append(result.Timeline, action)
+			}
+
+			result.TotalActions += volume.Actions
+			result.DailyVolumes = append(result.DailyVolumes, volume)
+		}
+	}
+	result.TimelineTruncated = result.TotalActions > len(result.Timeline)
+
+	// Waking windows run past midnight for night owls, so hours are attributed to days by date
+	for hour, count := range hourly {
+		day := int(hour.In(loc).Sub(result.StartAt).Hours()/24) + 1
+		if day >= 1 && day <= len(result.DailyVolumes) {
+			volume := &result.DailyVolumes[day-1]
+			volume.PeakHourActions = max(volume.PeakHourActions, count)
+		}
+		if capacityPerHour > 0 && float64(count) > capacityPerHour {
+			collisions = append(collisions, models.SimulationCollision{
+				Type:  models.CollisionCapacity,
+				Day:   day,
+				At:    hour.In(loc),
+				Count: count,
+				Limit: int(math.Floor(capacityPerHour)),
+			})
+		}
+	}
+
+	sort.SliceStable(collisions, func(a, b int) bool { return collisions[a].At.Before(collisions[b].At) })
+	for _, collision := range collisions {
+		result.CollisionCounts[collision.Type]++
+	}
+	if len(collisions) > maxSimulationCollisions {
+		collisions = collisions[:maxSimulationCollisions]
+	}
+	result.Collisions = collisions
+
+	return result
+}
+
+// simulateAccountDay plans one day of one account inside its waking window
+func simulateAccountDay(rng *rand.Rand, account, day int, date time.Time, calendar *models.ActivityCalendar, schedule *models.DaySchedule, actions []models.ScenarioAction, behavior config.BehaviorSimulationConfig) ([]models.SimulatedAction, []models.SimulationCollision) {
+	wake, sleep := calendar.WakeHour, calendar.SleepHour
+	weekend := date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+	if weekend {
+		wake, sleep = calendar.WeekendWakeHour, calendar.WeekendSleepHour
+	}
+	windowStart := date.Add(time.Duration(wake) * time.Hour)
+	window := time.Duration(activeHours(&models.ActivityCalendar{WakeHour: wake, SleepHour: sleep})) * time.Hour
+
+	count := schedule.MinActions + rng.Intn(schedule.MaxActions-schedule.MinActions+1)
+
+	var planned []models.SimulatedAction
+	for i := 0; i < count; i++ {
+		// Same odds as Scheduler.ShouldSkipAction
+		if weekend && behavior.WeekendActivityReduction > 0 && rng.Float64() > behavior.WeekendActivityReduction {
+			continue
+		}
+		planned = append(planned, models.SimulatedAction{
+			Account: account,
+			Day:     day,
+			At:      windowStart.Add(time.Duration(rng.Int63n(int64(window)))),
+		})
+	}
+	sort.Slice(planned, func(a, b int) bool { return planned[a].At.Before(planned[b].At) })
+
+	var collisions []models.SimulationCollision
+	done := make(map[string]int)
+	for i := range planned {
+		action := pickSimulatedAction(rng, actions, done)
+		planned[i].ActionType = action.Type
+		done[action.Type]++
+
+		if limit := actionDailyLimit(action.Params); limit > 0 && done[action.Type] > limit {
+			collisions = append(collisions, models.SimulationCollision{
+				Type:       models.CollisionMaxPerDay,
+				Account:    account,
+				Day:        day,
+				At:         planned[i].At,
+				ActionType: action.Type,
+				Count:      done[action.Type],
+				Limit:      limit,
+			})
+		}
+	}
+
+	return planned, collisions
+}
+
+// pickSimulatedAction mirrors Scheduler.SelectNextAction: a capped action hands its turn to the
+// next one in weight order, and the first action is taken when every candidate is capped
+func pickSimulatedAction(rng *rand.Rand, actions []models.ScenarioAction, done map[string]int) models.ScenarioAction {
+	totalWeight := 0
+	for _, action := range actions {
+		totalWeight += action.Weight
+	}
+
+	random := rng.Intn(totalWeight)
+	currentWeight := 0
+	for _, action := range actions {
+		currentWeight += action.Weight
+		if random < currentWeight {
+			if limit := actionDailyLimit(action.Params); limit > 0 && done[action.Type] >= limit {
+				continue
+			}
+			return action
+		}
+	}
+
+	return actions[0]
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/config"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func simulationBehavior() config.BehaviorSimulationConfig {
+	return config.BehaviorSimulationConfig{
+		ActiveHoursStart:         8,
+		ActiveHoursEnd:           23,
+		WeekendActivityReduction: 1,
+		Timezone:                 "UTC",
+	}
+}
+
+func simulationParams(accounts, days int) models.ScenarioSimulationParams {
+	return models.ScenarioSimulationParams{
+		Accounts:     accounts,
+		DurationDays: days,
+		StartAt:      time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		Seed:         42,
+	}
+}
+
+// Test SimulateScenario volumes and timeline
+func TestSimulateScenario_Volumes(t *testing.T) {
+	result := SimulateScenario(testScenario(), simulationParams(3, 21), simulationBehavior(), 0)
+
+	require.True(t, result.Valid, result.Errors)
+	require.Len(t, result.DailyVolumes, 21)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), result.StartAt.UTC())
+
+	total := 0
+	for _, volume := range result.DailyVolumes {
+		assert.Equal(t, 3, volume.ActiveAccounts)
+		total += volume.Actions
+	}
+	assert.Equal(t, total, result.TotalActions)
+
+	first := result.DailyVolumes[0]
+	assert.Equal(t, "days_1_7", first.Stage)
+	assert.Equal(t, "2026-03-02", first.Date)
+	assert.GreaterOrEqual(t, first.MinPerAccount, 3)
+	assert.LessOrEqual(t, first.MaxPerAccount, 5)
+	assert.Equal(t, "days_15_30", result.DailyVolumes[20].Stage)
+
+	assert.Len(t, result.Timeline, total)
+	assert.False(t, result.TimelineTruncated)
+	for i := 1; i < len(result.Timeline); i++ {
+		assert.False(t, result.Timeline[i].At.Before(result.Timeline[i-1].At))
+	}
+	assert.Empty(t, result.Collisions)
+
+	// Same seed, same plan
+	again := SimulateScenario(testScenario(), simulationParams(3, 21), simulationBehavior(), 0)
+	assert.Equal(t, result.Timeline, again.Timeline)
+}
+
+// Test SimulateScenario collisions
+func TestSimulateScenario_Collisions(t *testing.T) {
+	scenario := testScenario()
+	scenario.Actions = []models.ScenarioAction{
+		{Type: string(models.ActionVKLikePost), Weight: 1, Params: map[string]interface{}{"max_per_day": 2}},
+	}
+
+	result := SimulateScenario(scenario, simulationParams(2, 14), simulationBehavior(), 1)
+	require.True(t, result.Valid, result.Errors)
+
+	// Every day plans at least 3 likes against a limit of 2
+	assert.Greater(t, result.CollisionCounts[models.CollisionMaxPerDay], 0)
+	assert.Greater(t, result.CollisionCounts[models.CollisionCapacity], 0)
+
+	for _, collision := range result.Collisions {
+		switch collision.Type {
+		case models.CollisionMaxPerDay:
+			assert.Equal(t, 2, collision.Limit)
+			assert.Greater(t, collision.Count, 2)
+			assert.NotZero(t, collision.Account)
+		case models.CollisionCapacity:
+			assert.Equal(t, 1, collision.Limit)
+			assert.Zero(t, collision.Account)
+		}
+	}
+}
+
+// Test SimulateScenario rejects invalid input
+func TestSimulateScenario_Invalid(t *testing.T) {
+	result := SimulateScenario(testScenario(), simulationParams(0, 21), simulationBehavior(), 0)
+	assert.False(t, result.Valid)
+	assert.Contains(t, result.Errors, "accounts must be between 1 and 1000")
+	assert.Empty(t, result.Timeline)
+
+	scenario := testScenario()
+	scenario.Platform = "unknown"
+	result = SimulateScenario(scenario, simulationParams(5, 21), simulationBehavior(), 0)
+	assert.False(t, result.Valid)
+	assert.Empty(t, result.DailyVolumes)
+}
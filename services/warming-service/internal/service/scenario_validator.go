@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+)
+
+var ErrInvalidScenario = errors.New("invalid scenario")
+
+const (
+	maxScenarioActionsPerDay  = 50
+	maxFirstWeekActionsPerDay = 15 // Fresh accounts get flagged when they start at full speed
+)
+
+// scenarioActionsMu guards scenarioActions, action plugins add to it on startup
+var scenarioActionsMu sync.RWMutex
+
+// scenarioActions lists the action types every platform executor understands
+var scenarioActions = map[string]map[models.ActionType]bool{
+	"vk": {
+		models.ActionVKViewProfile: true, models.ActionVKViewFeed: true, models.ActionVKLikePost: true,
+		models.ActionVKSubscribeGroup: true, models.ActionVKCommentPost: true, models.ActionVKSendMessage: true,
+		models.ActionVKCreatePost: true,
+	},
+	"telegram": {
+		models.ActionTelegramReadChannel: true, models.ActionTelegramReactMessage: true, models.ActionTelegramJoinGroup: true,
+		models.ActionTelegramSendMessage: true, models.ActionTelegramCommentPost: true, models.ActionTelegramCreateChannelPost: true,
+		models.ActionTelegramPostStory: true, models.ActionTelegramScheduleMessage: true,
+		models.ActionTelegramImportContacts: true, models.ActionTelegramSeedDialog: true,
+	},
+	"mail": {
+		models.ActionMailReadEmail: true, models.ActionMailSendEmail: true, models.ActionMailMarkSpam: true,
+		models.ActionMailCreateFolder: true, models.ActionMailMoveEmail: true,
+	},
+	"max": {
+		models.ActionMaxReadMessages: true, models.ActionMaxSendMessage: true, models.ActionMaxUpdateStatus: true,
+		models.ActionMaxCreateChat: true,
+	},
+}
+
+// scenarioStage ties a schedule stage to the days the scheduler runs it on
+type scenarioStage struct {
+	name     string
+	fromDay  int
+	toDay    int
+	schedule *models.DaySchedule
+}
+
+// ValidateScenario checks a custom scenario and simulates the timeline it produces over
+// durationDays. Stages are picked the same way as Scheduler.getDayConfig does for presets.
+func ValidateScenario(scenario *models.WarmingScenario, durationDays int) *models.ScenarioValidation {
+	result := &models.ScenarioValidation{DurationDays: durationDays}
+	if scenario == nil {
+		result.Errors = append(result.Errors, "scenario is required")
+		return result
+	}
+
+	if strings.TrimSpace(scenario.Name) == "" {
+		result.Errors = append(result.Errors, "name is required")
+	}
+	allowed, ok := allowedScenarioActions(scenario.Platform)
+	if !ok {
+		result.Errors = append(result.Errors, fmt.Sprintf("unsupported platform %q", scenario.Platform))
+	}
+	if durationDays < 14 || durationDays > 60 {
+		result.Errors = append(result.Errors, "duration must be between 14 and 60 days")
+		return result
+	}
+
+	result.Errors = append(result.Errors, validateScenarioActions("actions", scenario.Actions, allowed)...)
+
+	for _, stage := range scenarioStages(scenario, durationDays) {
+		day := stage.schedule
+		actions := day.Actions
+		if len(actions) == 0 {
+			actions = scenario.Actions
+		}
+
+		switch {
+		case day.MinActions <= 0 || day.MaxActions <= 0:
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: actions per day must be positive", stage.name))
+		case day.MinActions > day.MaxActions:
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: min_actions is greater than max_actions", stage.name))
+		case day.MaxActions > maxScenarioActionsPerDay:
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: at most %d actions per day are allowed", stage.name, maxScenarioActionsPerDay))
+		case stage.fromDay == 1 && day.MaxActions > maxFirstWeekActionsPerDay:
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: at most %d actions per day are allowed in the first week", stage.name, maxFirstWeekActionsPerDay))
+		}
+		if len(actions) == 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: no actions configured", stage.name))
+		}
+		result.Errors = append(result.Errors, validateScenarioActions(stage.name, day.Actions, allowed)...)
+
+		days := stage.toDay - stage.fromDay + 1
+		timeline := models.ScenarioTimelineStage{
+			Stage:            stage.name,
+			FromDay:          stage.fromDay,
+			ToDay:            stage.toDay,
+			MinActionsPerDay: day.MinActions,
+			MaxActionsPerDay: day.MaxActions,
+			MinActions:       day.MinActions * days,
+			MaxActions:       day.MaxActions * days,
+			ActionShares:     actionShares(actions),
+		}
+		result.Stages = append(result.Stages, timeline)
+		result.TotalMinActions += timeline.MinActions
+		result.TotalMaxActions += timeline.MaxActions
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+// validateStoredScenario checks a scenario before it is saved. The 31-60 day stage is optional,
+// it is only checked when the scenario defines it.
+func validateStoredScenario(scenario *models.WarmingScenario) error {
+	durationDays := 30
+	if scenario.Schedule.Days31_60.MaxActions > 0 || len(scenario.Schedule.Days31_60.Actions) > 0 {
+		durationDays = 60
+	}
+
+	result := ValidateScenario(scenario, durationDays)
+	if result.Valid {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidScenario, strings.Join(result.Errors, "; "))
+}
+
+// scenarioStages returns the stages a task of durationDays goes through
+func scenarioStages(scenario *models.WarmingScenario, durationDays int) []scenarioStage {
+	stages := []scenarioStage{
+		{name: "days_1_7", fromDay: 1, toDay: 7, schedule: &scenario.Schedule.Days1_7},
+		{name: "days_8_14", fromDay: 8, toDay: 14, schedule: &scenario.Schedule.Days8_14},
+	}
+	if durationDays > 14 {
+		stages = append(stages, scenarioStage{name: "days_15_30", fromDay: 15, toDay: min(durationDays, 30), schedule: &scenario.Schedule.Days15_30})
+	}
+	if durationDays > 30 {
+		stages = append(stages, scenarioStage{name: "days_31_60", fromDay: 31, toDay: durationDays, schedule: &scenario.Schedule.Days31_60})
+	}
+	return stages
+}
+
+// allowedScenarioActions returns a copy of the action types scenarios of the platform may use
+func allowedScenarioActions(platform string) (map[models.ActionType]bool, bool) {
+	scenarioActionsMu.RLock()
+	defer scenarioActionsMu.RUnlock()
+
+	actions, ok := scenarioActions[platform]
+	if !ok {
+		return nil, false
+	}
+	allowed := make(map[models.ActionType]bool, len(actions))
+	for action := range actions {
+		allowed[action] = true
+	}
+	return allowed, true
+}
+
+// registerScenarioAction lets scenarios of the platform use an action added by a plugin
+func registerScenarioAction(platform, action string) {
+	scenarioActionsMu.Lock()
+	defer scenarioActionsMu.Unlock()
+
+	if scenarioActions[platform] == nil {
+		scenarioActions[platform] = make(map[models.ActionType]bool)
+	}
+	scenarioActions[platform][models.ActionType(action)] = true
+}
+
+func validateScenarioActions(scope string, actions []models.ScenarioAction, allowed map[models.ActionType]bool) []string {
+	var problems []string
+	for _, action := range actions {
+		if allowed != nil && !allowed[models.ActionType(action.Type)] {
+			problems = append(problems, fmt.Sprintf("%s: action %q is not supported on this platform", scope, action.Type))
+		}
+		if action.Weight <= 0 {
+			problems = append(problems, fmt.Sprintf("%s: action %q needs a positive weight", scope, action.Type))
+		}
+	}
+	return problems
+}
+
+func actionShares(actions []models.ScenarioAction) map[string]float64 {
+	total := 0
+	for _, action := range actions {
+		if action.Weight > 0 {
+			total += action.Weight
+		}
+	}
+
+	shares := make(map[string]float64, len(actions))
+	if total == 0 {
+		return shares
+	}
+	for _, action := range actions {
+		if action.Weight > 0 {
+			shares[action.Type] += float64(action.Weight) / float64(total)
+		}
+	}
+	return shares
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScenario() *models.WarmingScenario {
+	return &models.WarmingScenario{
+		Name:     "gentle",
+		Platform: "vk",
+		Actions: []models.ScenarioAction{
+			{Type: string(models.ActionVKViewFeed), Weight: 3},
+			{Type: string(models.ActionVKLikePost), Weight: 1},
+		},
+		Schedule: models.ScenarioSchedule{
+			Days1_7:   models.DaySchedule{MinActions: 3, MaxActions: 5},
+			Days8_14:  models.DaySchedule{MinActions: 5, MaxActions: 10},
+			Days15_30: models.DaySchedule{MinActions: 10, MaxActions: 15},
+			Days31_60: models.DaySchedule{MinActions: 15, MaxActions: 20},
+		},
+	}
+}
+
+// Test ValidateScenario timeline
+func TestValidateScenario_Timeline(t *testing.T) {
+	result := ValidateScenario(testScenario(), 21)
+
+	require.True(t, result.Valid, result.Errors)
+	require.Len(t, result.Stages, 3)
+
+	last := result.Stages[2]
+	assert.Equal(t, "days_15_30", last.Stage)
+	assert.Equal(t, 15, last.FromDay)
+	assert.Equal(t, 21, last.ToDay)
+	assert.Equal(t, 70, last.MinActions)
+	assert.InDelta(t, 0.75, last.ActionShares["view_feed"], 0.001)
+
+	// 7*3 + 7*5 + 7*10
+	assert.Equal(t, 126, result.TotalMinActions)
+	assert.Equal(t, 7*5+7*10+7*15, result.TotalMaxActions)
+
+	result = ValidateScenario(testScenario(), 60)
+	require.True(t, result.Valid, result.Errors)
+	require.Len(t, result.Stages, 4)
+	assert.Equal(t, 60, result.Stages[3].ToDay)
+}
+
+// Test ValidateScenario problems
+func TestValidateScenario_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *models.WarmingScenario)
+		days   int
+	}{
+		{"unknown platform", func(s *models.WarmingScenario) { s.Platform = "icq" }, 30},
+		{"foreign action", func(s *models.WarmingScenario) { s.Actions[0].Type = "read_channel" }, 30},
+		{"zero weight", func(s *models.WarmingScenario) { s.Actions[1].Weight = 0 }, 30},
+		{"min above max", func(s *models.WarmingScenario) { s.Schedule.Days8_14.MinActions = 12 }, 30},
+		{"aggressive first week", func(s *models.WarmingScenario) { s.Schedule.Days1_7.MaxActions = 30 }, 30},
+		{"missing last stage", func(s *models.WarmingScenario) { s.Schedule.Days31_60 = models.DaySchedule{} }, 45},
+		{"duration out of range", func(s *models.WarmingScenario) {}, 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := testScenario()
+			tt.modify(scenario)
+
+			result := ValidateScenario(scenario, tt.days)
+
+			assert.False(t, result.Valid)
+			assert.NotEmpty(t, result.Errors)
+		})
+	}
+}
+
+// Test validateStoredScenario treats the 31-60 day stage as optional
+func TestValidateStoredScenario(t *testing.T) {
+	scenario := testScenario()
+	scenario.Schedule.Days31_60 = models.DaySchedule{}
+	assert.NoError(t, validateStoredScenario(scenario))
+
+	scenario.Schedule.Days31_60.MaxActions = 10
+	err := validateStoredScenario(scenario)
+	assert.True(t, errors.Is(err, ErrInvalidScenario))
+}
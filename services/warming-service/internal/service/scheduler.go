@@ -82,12 +82,13 @@ func (s *Scheduler) ScheduleTaskActions(ctx context.Context, task *models.Warmin
 	}
 
 	// Calculate next action time
-	nextActionTime := s.CalculateNextActionTime(time.Now(), task.CurrentDay, task.DurationDays)
+	nextActionTime := s.CalculateNextActionTimeForTask(task, time.Now())
 
 	// Update task with next action time
 	if err := s.service.taskRepo.UpdateNextActionTime(ctx, task.ID, nextActionTime); err != nil {
 		return fmt.Errorf("failed to update next action time: %w", err)
 	}
+	s.service.scheduleAction(task, nextActionTime)
 
 	s.logger.Info("Scheduled %d actions for task %s on day %d", actionsToday, task.ID.Hex(), task.CurrentDay)
 	return nil
@@ -114,6 +115,23 @@ func (s *Scheduler) CalculateNextActionTime(currentTime time.Time, currentDay, t
 	return nextTime
 }
 
+// CalculateNextActionTimeForTask applies the task's activity calendar on top of the
+// behavior-simulated delay so actions only fire while the account is "awake".
+func (s *Scheduler) CalculateNextActionTimeForTask(task *models.WarmingTask, currentTime time.Time) time.Time {
+	nextTime := s.CalculateNextActionTime(currentTime, task.CurrentDay, task.DurationDays)
+	if task.ScenarioType == string(models.ScenarioResurrection) {
+		nextTime = stretchDelay(currentTime, nextTime, s.config.WarmingConfig.Resurrection.DelayMultiplier)
+	}
+	return ApplyActivityCalendar(s.calendarForTask(task), task.AccountID.Hex(), s.config.WarmingConfig.BehaviorSimulation.Holidays, nextTime)
+}
+
+func (s *Scheduler) calendarForTask(task *models.WarmingTask) *models.ActivityCalendar {
+	if task.Calendar != nil {
+		return task.Calendar
+	}
+	return DefaultActivityCalendar(task.AccountID.Hex(), s.config.WarmingConfig.BehaviorSimulation)
+}
+
 func (s *Scheduler) SelectNextAction(task *models.WarmingTask, dayConfig *config.DayConfig) string {
 	if len(dayConfig.Actions) == 0 {
 		return ""
@@ -146,21 +164,17 @@ func (s *Scheduler) SelectNextAction(task *models.WarmingTask, dayConfig *config
 }
 
 func (s *Scheduler) getScenarioConfig(task *models.WarmingTask) *config.PlatformScenarioConfig {
-	scenarios := s.config.WarmingConfig.Scenarios[task.ScenarioType]
+	scenarios := s.config.Scenario(task.ScenarioType)
 	if scenarios == nil {
 		return nil
 	}
 
-	platformConfig := scenarios[task.Platform]
-	if platformConfig == nil {
+	platformConfig, ok := scenarios[task.Platform]
+	if !ok {
 		return nil
 	}
 
-	// Return config based on duration
-	if task.DurationDays <= 30 {
-		return &platformConfig["duration_14_30"]
-	}
-	return &platformConfig["duration_30_60"]
+	return &platformConfig
 }
 
 func (s *Scheduler) getDayConfig(scenarioConfig *config.PlatformScenarioConfig, currentDay, totalDays int) *config.DayConfig {
@@ -236,10 +250,10 @@ func (s *Scheduler) generateDailySchedule(actionsCount int, actions []config.Act
 
 		schedule = append(schedule, models.PlannedAction{
 			ActionType:  actionType,
-			PlannedAt:   scheduledTime,
+			ScheduledAt: scheduledTime,
 			TimeWindow:  30, // 30 minute window
 			Priority:    1,
-			Executed:    false,
+			Completed:   false,
 		})
 	}
 
@@ -292,25 +306,7 @@ func (s *Scheduler) getNextActiveTime(t time.Time) time.Time {
 
 func (s *Scheduler) hasReachedDailyLimit(task *models.WarmingTask, action config.ActionConfig) bool {
 	// Check if action has daily limit in params
-	if action.Params == nil {
-		return false
-	}
-
-	maxPerDay, ok := action.Params["max_per_day"]
-	if !ok {
-		return false
-	}
-
-	limit := 0
-	switch v := maxPerDay.(type) {
-	case int:
-		limit = v
-	case float64:
-		limit = int(v)
-	case string:
-		limit, _ = strconv.Atoi(v)
-	}
-
+	limit := actionDailyLimit(action.Params)
 	if limit <= 0 {
 		return false
 	}
@@ -329,6 +325,20 @@ func (s *Scheduler) hasReachedDailyLimit(task *models.WarmingTask, action config
 	return count >= limit
 }
 
+// actionDailyLimit returns the max_per_day param of an action, 0 when it is unlimited
+func actionDailyLimit(params map[string]interface{}) int {
+	limit := 0
+	switch v := params["max_per_day"].(type) {
+	case int:
+		limit = v
+	case float64:
+		limit = int(v)
+	case string:
+		limit, _ = strconv.Atoi(v)
+	}
+	return limit
+}
+
 func (s *Scheduler) ShouldSkipAction(currentTime time.Time) bool {
 	// Check if we should skip this action based on behavior patterns
 	hour := currentTime.Hour()
@@ -66,7 +66,7 @@ func (s *SchedulerTestSuite) SetupTest() {
 				NightPauseProbability:    0.9,
 				WeekendActivityReduction: 0.7,
 			},
-			Scenarios: make(map[string]map[string]map[string]config.PlatformScenarioConfig),
+			Scenarios: make(map[string]config.ScenarioConfig),
 		},
 	}
 }
@@ -108,7 +108,6 @@ func (s *SchedulerTestSuite) TestCalculateNextActionTime_OutsideActiveHours() {
 
 // Test CalculateNextActionTime - progression based adjustment
 func (s *SchedulerTestSuite) TestCalculateNextActionTime_EarlyStage() {
-	currentTime := time.Date(2024, 1, 15, 12, 0, 0, 0, time.Local)
 	currentDay := 3  // Early stage
 	totalDays := 14
 
@@ -336,7 +335,7 @@ func TestGenerateDailySchedule(t *testing.T) {
 	
 	for _, action := range schedule {
 		// All scheduled times should be within active hours
-		hour := action.PlannedAt.Hour()
+		hour := action.ScheduledAt.Hour()
 		assert.True(t, hour >= 8 && hour < 22)
 		
 		// Action type should be one of the defined actions
@@ -348,16 +347,16 @@ func TestGenerateDailySchedule(t *testing.T) {
 func TestPlannedActionModel(t *testing.T) {
 	action := models.PlannedAction{
 		ActionType:  "like_post",
-		PlannedAt:   time.Now().Add(1 * time.Hour),
+		ScheduledAt:   time.Now().Add(1 * time.Hour),
 		TimeWindow:  30,
 		Priority:    1,
-		Executed:    false,
+		Completed:   false,
 	}
 
 	assert.Equal(t, "like_post", action.ActionType)
 	assert.Equal(t, 30, action.TimeWindow)
 	assert.Equal(t, 1, action.Priority)
-	assert.False(t, action.Executed)
+	assert.False(t, action.Completed)
 }
 
 // Test WarmingSchedule model
@@ -367,8 +366,8 @@ func TestWarmingScheduleModel(t *testing.T) {
 		ID:     primitive.NewObjectID(),
 		TaskID: taskID,
 		PlannedActions: []models.PlannedAction{
-			{ActionType: "like_post", PlannedAt: time.Now()},
-			{ActionType: "view_feed", PlannedAt: time.Now()},
+			{ActionType: "like_post", ScheduledAt: time.Now()},
+			{ActionType: "view_feed", ScheduledAt: time.Now()},
 		},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+)
+
+const (
+	// actionLogRetentionDays is how long raw action logs are kept. Days older than that can no
+	// longer be rolled up.
+	actionLogRetentionDays = 90
+
+	statsCacheVersionKey = "warming:stats:version"
+	statsCacheTTL        = 1 * time.Hour
+	statsLiveCacheTTL    = 1 * time.Minute // ranges that include today change with every action
+	statsTopLimit        = 10
+)
+
+var statsPlatforms = []string{"vk", "telegram", "mail", "max"}
+
+// GetWarmingStatistics serves statistics for whole days from the precomputed daily documents.
+// Only today, which isn't rolled up yet, is aggregated from raw action logs.
+func (s *warmingService) GetWarmingStatistics(ctx context.Context, platform string, startDate, endDate time.Time) (*models.AggregatedStats, error) {
+	firstDay := startOfDay(startDate)
+	lastDay := startOfDay(endDate)
+	if lastDay.Before(firstDay) {
+		return nil, fmt.Errorf("end date %s is before start date %s", lastDay.Format("2006-01-02"), firstDay.Format("2006-01-02"))
+	}
+
+	today := startOfDay(time.Now())
+	includesToday := !lastDay.Before(today) && !firstDay.After(today)
+
+	cacheKey := s.statsCacheKey(ctx, platform, firstDay, lastDay)
+	if cacheKey != "" {
+		if cached, err := s.cache.Get(ctx, cacheKey); err == nil && cached != "" {
+			var stats models.AggregatedStats
+			if err := json.Unmarshal([]byte(cached), &stats); err == nil {
+				return &stats, nil
+			}
+		}
+	}
+
+	daily, err := s.statsRepo.GetDailyStats(ctx, platform, firstDay, lastDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warming statistics: %w", err)
+	}
+
+	if includesToday {
+		daily, err = s.withLiveActionStats(ctx, platform, today, daily)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get warming statistics: %w", err)
+		}
+	}
+
+	stats := buildAggregatedStats(platform, startDate, endDate, daily)
+
+	if cacheKey != "" {
+		ttl := statsCacheTTL
+		if includesToday {
+			ttl = statsLiveCacheTTL
+		}
+		if data, err := json.Marshal(stats); err == nil {
+			if err := s.cache.Set(ctx, cacheKey, string(data), ttl); err != nil {
+				s.logger.Warn("Failed to cache warming statistics: %v", err)
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// withLiveActionStats replaces today's action counters with a fresh aggregation of the raw logs
+func (s *warmingService) withLiveActionStats(ctx context.Context, platform string, today time.Time, daily []*models.WarmingStats) ([]*models.WarmingStats, error) {
+	platforms := statsPlatforms
+	if platform != "" {
+		platforms = []string{platform}
+	}
+
+	for _, p := range platforms {
+		live, err := s.statsRepo.AggregateActionLogs(ctx, p, today, today.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+
+		var current *models.WarmingStats
+		for _, day := range daily {
+			if day.Platform == p && day.Date.Equal(today) {
+				current = day
+				break
+			}
+		}
+		if current == nil {
+			current = &models.WarmingStats{Platform: p, Date: today}
+			daily = append(daily, current)
+		}
+
+		current.TotalActions = live.TotalActions
+		current.SuccessfulActions = live.SuccessfulActions
+		current.FailedActions = live.FailedActions
+		current.ByActionType = live.ByActionType
+		current.ActionSuccesses = live.ActionSuccesses
+		current.ActionDurationMs = live.ActionDurationMs
+		current.ErrorTypes = live.ErrorTypes
+	}
+
+	return daily, nil
+}
+
+// rollupDailyStats folds the action logs of finished days into their daily stats documents.
+// Days rolled up before they ended are redone, so late logs of a day are picked up once.
+func (s *warmingService) rollupDailyStats(ctx context.Context) {
+	today := startOfDay(time.Now())
+	// The oldest retained day is already partly cleaned up, start after it
+	firstDay := today.AddDate(0, 0, 1-actionLogRetentionDays)
+	yesterday := today.AddDate(0, 0, -1)
+
+	rolledUp := 0
+	for _, platform := range statsPlatforms {
+		existing, err := s.statsRepo.GetDailyStats(ctx, platform, firstDay, yesterday)
+		if err != nil {
+			s.logger.Error("Failed to load daily stats for %s: %v", platform, err)
+			continue
+		}
+
+		// Keyed by unix time, dates decoded from Mongo are in UTC
+		done := make(map[int64]bool, len(existing))
+		for _, day := range existing {
+			end := day.Date.AddDate(0, 0, 1)
+			if day.RolledUpAt != nil && !day.RolledUpAt.Before(end) {
+				done[day.Date.Unix()] = true
+			}
+		}
+
+		for day := firstDay; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+			if done[day.Unix()] {
+				continue
+			}
+
+			// Idle days are stored too, so they aren't aggregated again on every run
+			stats, err := s.statsRepo.AggregateActionLogs(ctx, platform, day, day.AddDate(0, 0, 1))
+			if err != nil {
+				s.logger.Error("Failed to aggregate action logs for %s on %s: %v", platform, day.Format("2006-01-02"), err)
+				continue
+			}
+
+			stats.Date = day
+			if err := s.statsRepo.SaveActionRollup(ctx, stats); err != nil {
+				s.logger.Error("Failed to save action rollup for %s on %s: %v", platform, day.Format("2006-01-02"), err)
+				continue
+			}
+			rolledUp++
+		}
+	}
+
+	if rolledUp > 0 {
+		s.invalidateStatsCache(ctx)
+		s.logger.Info("Rolled up %d days of warming statistics", rolledUp)
+	}
+}
+
+// statsCacheKey builds the cache key for a range. The key embeds a version bumped on every
+// rollup so ranges cached before their days were finalized are not served afterwards. An empty
+// key disables caching.
+func (s *warmingService) statsCacheKey(ctx context.Context, platform string, firstDay, lastDay time.Time) string {
+	if s.cache == nil {
+		return ""
+	}
+
+	version, err := s.cache.Get(ctx, statsCacheVersionKey)
+	if err != nil || version == "" {
+		version = "0"
+	}
+
+	if platform == "" {
+		platform = "all"
+	}
+
+	return fmt.Sprintf("warming:stats:%s:%s:%s:%s", version, platform, firstDay.Format("20060102"), lastDay.Format("20060102"))
+}
+
+func (s *warmingService) invalidateStatsCache(ctx context.Context) {
+	if s.cache == nil {
+		return
+	}
+
+	version := fmt.Sprintf("%d", time.Now().UnixNano())
+	if err := s.cache.Set(ctx, statsCacheVersionKey, version, 0); err != nil {
+		s.logger.Warn("Failed to invalidate warming statistics cache: %v", err)
+	}
+}
+
+// buildAggregatedStats merges daily stats documents, possibly of several platforms, into the
+// statistics of the whole range
+func buildAggregatedStats(platform string, startDate, endDate time.Time, daily []*models.WarmingStats) *models.AggregatedStats {
+	stats := &models.AggregatedStats{
+		Platform:   platform,
+		DateRange:  models.DateRange{StartDate: startDate, EndDate: endDate},
+		ByPlatform: make(map[string]int64),
+	}
+
+	actionCounts := make(map[string]int64)
+	actionSuccesses := make(map[string]int64)
+	actionDurations := make(map[string]int64)
+	errorCounts := make(map[string]int64)
+	breakdown := make(map[int64]*models.DailyStatistic)
+
+	var latest time.Time
+	var durationSum float64
+	for _, day := range daily {
+		stats.TotalTasks += day.TotalTasks
+		stats.CompletedTasks += day.CompletedTasks
+		stats.FailedTasks += day.FailedTasks
+		stats.ByPlatform[day.Platform] += day.TotalActions
+		durationSum += day.AvgDurationDays
+
+		// In-progress tasks are a snapshot, only the most recent day counts
+		if day.Date.After(latest) {
+			latest = day.Date
+			stats.InProgressTasks = 0
+		}
+		if day.Date.Equal(latest) {
+			stats.InProgressTasks += day.InProgressTasks
+		}
+
+		for action, count := range day.ByActionType {
+			actionCounts[action] += count
+		}
+		for action, count := range day.ActionSuccesses {
+			actionSuccesses[action] += count
+		}
+		for action, ms := range day.ActionDurationMs {
+			actionDurations[action] += ms
+		}
+		for errorType, count := range day.ErrorTypes {
+			errorCounts[errorType] += count
+		}
+
+		entry, ok := breakdown[day.Date.Unix()]
+		if !ok {
+			entry = &models.DailyStatistic{Date: day.Date}
+			breakdown[day.Date.Unix()] = entry
+		}
+		entry.TasksStarted += day.TotalTasks
+		entry.TasksCompleted += day.CompletedTasks
+		entry.TasksFailed += day.FailedTasks
+		entry.ActionsExecuted += day.TotalActions
+	}
+
+	if stats.TotalTasks > 0 {
+		stats.SuccessRate = float64(stats.CompletedTasks) / float64(stats.TotalTasks) * 100
+	}
+	if len(daily) > 0 {
+		stats.AvgDurationDays = durationSum / float64(len(daily))
+	}
+
+	for _, entry := range breakdown {
+		if entry.TasksStarted > 0 {
+			entry.SuccessRate = float64(entry.TasksCompleted) / float64(entry.TasksStarted) * 100
+		}
+		stats.DailyBreakdown = append(stats.DailyBreakdown, *entry)
+	}
+	sort.Slice(stats.DailyBreakdown, func(i, j int) bool {
+		return stats.DailyBreakdown[i].Date.Before(stats.DailyBreakdown[j].Date)
+	})
+
+	for action, count := range actionCounts {
+		if count == 0 {
+			continue
+		}
+		stats.TopActions = append(stats.TopActions, models.ActionStatistic{
+			ActionType:  action,
+			Count:       count,
+			SuccessRate: float64(actionSuccesses[action]) / float64(count) * 100,
+			AvgDuration: float64(actionDurations[action]) / float64(count),
+		})
+	}
+	sort.Slice(stats.TopActions, func(i, j int) bool {
+		if stats.TopActions[i].Count != stats.TopActions[j].Count {
+			return stats.TopActions[i].Count > stats.TopActions[j].Count
+		}
+		return stats.TopActions[i].ActionType < stats.TopActions[j].ActionType
+	})
+	if len(stats.TopActions) > statsTopLimit {
+		stats.TopActions = stats.TopActions[:statsTopLimit]
+	}
+
+	var totalErrors int64
+	for _, count := range errorCounts {
+		totalErrors += count
+	}
+	for errorType, count := range errorCounts {
+		if count == 0 {
+			continue
+		}
+		stats.CommonErrors = append(stats.CommonErrors, models.ErrorStatistic{
+			ErrorType:  errorType,
+			Count:      count,
+			Percentage: float64(count) / float64(totalErrors) * 100,
+		})
+	}
+	sort.Slice(stats.CommonErrors, func(i, j int) bool {
+		if stats.CommonErrors[i].Count != stats.CommonErrors[j].Count {
+			return stats.CommonErrors[i].Count > stats.CommonErrors[j].Count
+		}
+		return stats.CommonErrors[i].ErrorType < stats.CommonErrors[j].ErrorType
+	})
+	if len(stats.CommonErrors) > statsTopLimit {
+		stats.CommonErrors = stats.CommonErrors[:statsTopLimit]
+	}
+
+	return stats
+}
+
+// startOfDay returns local midnight of t's calendar date, the date daily stats are stored under.
+// Dates parsed from requests are in UTC but still name a local day.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
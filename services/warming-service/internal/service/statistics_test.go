@@ -0,0 +1,107 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAggregatedStats(t *testing.T) {
+	day1 := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+
+	daily := []*models.WarmingStats{
+		{
+			Platform:         "vk",
+			Date:             day1,
+			TotalTasks:       10,
+			CompletedTasks:   6,
+			FailedTasks:      1,
+			InProgressTasks:  3,
+			TotalActions:     100,
+			ByActionType:     map[string]int64{"like_post": 60, "view_feed": 40},
+			ActionSuccesses:  map[string]int64{"like_post": 54, "view_feed": 40},
+			ActionDurationMs: map[string]int64{"like_post": 60000, "view_feed": 20000},
+			ErrorTypes:       map[string]int64{"captcha": 6},
+		},
+		{
+			Platform:         "telegram",
+			Date:             day1,
+			TotalTasks:       5,
+			CompletedTasks:   5,
+			InProgressTasks:  2,
+			TotalActions:     20,
+			ByActionType:     map[string]int64{"view_feed": 20},
+			ActionSuccesses:  map[string]int64{"view_feed": 20},
+			ActionDurationMs: map[string]int64{"view_feed": 10000},
+		},
+		{
+			// Decoded from Mongo the same instant comes back in UTC
+			Platform:         "vk",
+			Date:             day2.UTC(),
+			TotalTasks:       4,
+			CompletedTasks:   2,
+			InProgressTasks:  1,
+			TotalActions:     30,
+			ByActionType:     map[string]int64{"like_post": 30},
+			ActionSuccesses:  map[string]int64{"like_post": 30},
+			ActionDurationMs: map[string]int64{"like_post": 30000},
+			ErrorTypes:       map[string]int64{"timeout": 2},
+		},
+	}
+
+	stats := buildAggregatedStats("", day1, day2, daily)
+
+	assert.Equal(t, int64(19), stats.TotalTasks)
+	assert.Equal(t, int64(13), stats.CompletedTasks)
+	assert.Equal(t, int64(1), stats.InProgressTasks, "in-progress tasks come from the latest day only")
+	assert.Equal(t, int64(130), stats.ByPlatform["vk"])
+	assert.Equal(t, int64(20), stats.ByPlatform["telegram"])
+
+	if assert.Len(t, stats.TopActions, 2) {
+		assert.Equal(t, "like_post", stats.TopActions[0].ActionType)
+		assert.Equal(t, int64(90), stats.TopActions[0].Count)
+		assert.InDelta(t, 93.33, stats.TopActions[0].SuccessRate, 0.01)
+		assert.InDelta(t, 1000, stats.TopActions[0].AvgDuration, 0.01)
+		assert.Equal(t, "view_feed", stats.TopActions[1].ActionType)
+		assert.InDelta(t, 500, stats.TopActions[1].AvgDuration, 0.01)
+	}
+
+	if assert.Len(t, stats.CommonErrors, 2) {
+		assert.Equal(t, "captcha", stats.CommonErrors[0].ErrorType)
+		assert.InDelta(t, 75, stats.CommonErrors[0].Percentage, 0.01)
+	}
+
+	if assert.Len(t, stats.DailyBreakdown, 2) {
+		assert.True(t, stats.DailyBreakdown[0].Date.Equal(day1))
+		assert.Equal(t, int64(15), stats.DailyBreakdown[0].TasksStarted)
+		assert.Equal(t, int64(120), stats.DailyBreakdown[0].ActionsExecuted)
+		assert.InDelta(t, 73.33, stats.DailyBreakdown[0].SuccessRate, 0.01)
+		assert.True(t, stats.DailyBreakdown[1].Date.Equal(day2))
+	}
+}
+
+func TestBuildAggregatedStats_Empty(t *testing.T) {
+	now := time.Now()
+	stats := buildAggregatedStats("vk", now, now, nil)
+
+	assert.Equal(t, "vk", stats.Platform)
+	assert.Zero(t, stats.TotalTasks)
+	assert.Zero(t, stats.SuccessRate)
+	assert.Empty(t, stats.TopActions)
+	assert.Empty(t, stats.DailyBreakdown)
+}
+
+func TestStartOfDay(t *testing.T) {
+	parsed, _ := time.Parse("2006-01-02", "2024-03-01")
+	day := startOfDay(parsed)
+
+	assert.Equal(t, time.Local, day.Location())
+	assert.Equal(t, 2024, day.Year())
+	assert.Equal(t, time.March, day.Month())
+	assert.Equal(t, 1, day.Day())
+	assert.Zero(t, day.Hour())
+}
@@ -2,28 +2,55 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
+	telegrampb "github.com/grigta/conveer/services/telegram-service/proto"
 	"github.com/grigta/conveer/services/warming-service/internal/models"
+	warmingpb "github.com/grigta/conveer/services/warming-service/proto"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Task metadata keys with the Telegram targets for MTProto actions.
+// Without them the executor only simulates the action timing.
+const (
+	telegramChannelsKey    = "telegram_channels"
+	telegramPeersKey       = "telegram_peers"
+	telegramOwnChannelsKey = "telegram_own_channels"
+	telegramContactsKey    = "telegram_contacts"
 )
 
 type TelegramExecutor struct {
 	BaseExecutor
-	client *grpc.ClientConn // Telegram service gRPC client
-	logger logger.Logger
+	client   *grpc.ClientConn // Telegram service gRPC client
+	telegram telegrampb.TelegramServiceClient
+	stream   *ActionStream // nil when the action stream is disabled
+	content  *ContentProvider
+	logger   logger.Logger
 }
 
-func NewTelegramExecutor(client *grpc.ClientConn, logger logger.Logger) *TelegramExecutor {
+func NewTelegramExecutor(client *grpc.ClientConn, stream *ActionStream, content *ContentProvider, logger logger.Logger) *TelegramExecutor {
+	var telegram telegrampb.TelegramServiceClient
+	if client != nil {
+		telegram = telegrampb.NewTelegramServiceClient(client)
+	}
+
 	return &TelegramExecutor{
 		BaseExecutor: BaseExecutor{
 			supportedActions: []string{
 				"read_channel", "react_message", "join_group",
 				"send_message", "comment_post", "create_channel_post",
+				"post_story", "schedule_message",
+				"import_contacts", "seed_dialog",
 			},
 			actionLimits: map[string]int{
 				"join_group":          2,  // per day (first 14 days)
@@ -32,10 +59,17 @@ func NewTelegramExecutor(client *grpc.ClientConn, logger logger.Logger) *Telegra
 				"comment_post":        10, // per day
 				"create_channel_post": 2,  // per day
 				"react_message":       30, // per day
+				"post_story":          1,  // per day
+				"schedule_message":    2,  // per day
+				"import_contacts":     1,  // per day
+				"seed_dialog":         2,  // per day (1 in the first week)
 			},
 		},
-		client: client,
-		logger: logger,
+		client:   client,
+		telegram: telegram,
+		stream:   stream,
+		content:  content,
+		logger:   logger,
 	}
 }
 
@@ -49,15 +83,23 @@ func (e *TelegramExecutor) ExecuteAction(ctx context.Context, task *models.Warmi
 	case "read_channel":
 		err = e.readChannel(ctx, execCtx)
 	case "react_message":
-		err = e.reactMessage(ctx, execCtx)
+		err = e.reactMessage(ctx, task, execCtx)
 	case "join_group":
-		err = e.joinGroup(ctx, execCtx)
+		err = e.joinGroup(ctx, task, execCtx)
 	case "send_message":
-		err = e.sendMessage(ctx, execCtx)
+		err = e.sendMessage(ctx, task, execCtx)
 	case "comment_post":
 		err = e.commentPost(ctx, execCtx)
 	case "create_channel_post":
 		err = e.createChannelPost(ctx, execCtx)
+	case "post_story":
+		err = e.postStory(ctx, execCtx)
+	case "schedule_message":
+		err = e.scheduleMessage(ctx, task, execCtx)
+	case "import_contacts":
+		err = e.importContacts(ctx, task, execCtx)
+	case "seed_dialog":
+		err = e.seedDialog(ctx, execCtx)
 	default:
 		err = fmt.Errorf("unsupported action type: %s", actionType)
 	}
@@ -73,6 +115,20 @@ func (e *TelegramExecutor) ValidateAccount(ctx context.Context, accountID primit
 	return nil
 }
 
+// ProfileCompleteness reports how much of the account profile is filled in Telegram service
+func (e *TelegramExecutor) ProfileCompleteness(ctx context.Context, accountID primitive.ObjectID) (float64, error) {
+	if e.telegram == nil {
+		return 0, fmt.Errorf("telegram service is not connected")
+	}
+
+	account, err := e.telegram.GetAccount(ctx, &telegrampb.GetAccountRequest{AccountId: accountID.Hex()})
+	if err != nil {
+		return 0, err
+	}
+
+	return filledShare(account.FirstName, account.LastName, account.Username, account.PersonaId), nil
+}
+
 func (e *TelegramExecutor) readChannel(ctx context.Context, execCtx *models.ExecutionContext) error {
 	// Simulate reading channel messages
 	// 1. Open channel
@@ -101,7 +157,7 @@ func (e *TelegramExecutor) readChannel(ctx context.Context, execCtx *models.Exec
 	return nil
 }
 
-func (e *TelegramExecutor) reactMessage(ctx context.Context, execCtx *models.ExecutionContext) error {
+func (e *TelegramExecutor) reactMessage(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
 	// Check daily limit
 	if execCtx.ActionsToday >= e.actionLimits["react_message"] {
 		return fmt.Errorf("daily limit reached for react_message")
@@ -119,10 +175,29 @@ func (e *TelegramExecutor) reactMessage(ctx context.Context, execCtx *models.Exe
 	// React (human-like delay)
 	time.Sleep(time.Duration(300+rand.Intn(500)) * time.Millisecond)
 
+	channel := pickTarget(task.Metadata, telegramChannelsKey)
+	if e.telegram == nil || channel == "" {
+		return nil
+	}
+
+	// Message ID 0 reacts to the latest post in the channel
+	params := map[string]string{"peer": channel, "reaction": reaction}
+	resp, err := e.perform(ctx, execCtx, "react_message", params, func() (*telegrampb.ActionResponse, error) {
+		return e.telegram.ReactToMessage(ctx, &telegrampb.ReactToMessageRequest{
+			AccountId: execCtx.AccountID.Hex(),
+			Peer:      channel,
+			Reaction:  reaction,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to react in %s: %w", channel, err)
+	}
+
+	attachMessageEvidence(execCtx, fmt.Sprintf("reaction %s is set on the latest post in %s", reaction, channel), resp)
 	return nil
 }
 
-func (e *TelegramExecutor) joinGroup(ctx context.Context, execCtx *models.ExecutionContext) error {
+func (e *TelegramExecutor) joinGroup(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
 	// Telegram has strict limits for new accounts
 	limit := e.actionLimits["join_group"]
 	if execCtx.CurrentDay > 14 {
@@ -149,13 +224,25 @@ func (e *TelegramExecutor) joinGroup(ctx context.Context, execCtx *models.Execut
 	// Join
 	time.Sleep(time.Duration(500+rand.Intn(1000)) * time.Millisecond)
 
+	if channel := pickTarget(task.Metadata, telegramChannelsKey); e.telegram != nil && channel != "" {
+		params := map[string]string{"channel": channel}
+		if _, err := e.perform(ctx, execCtx, "join_group", params, func() (*telegrampb.ActionResponse, error) {
+			return e.telegram.JoinChannel(ctx, &telegrampb.JoinChannelRequest{
+				AccountId: execCtx.AccountID.Hex(),
+				Channel:   channel,
+			})
+		}); err != nil {
+			return fmt.Errorf("failed to join %s: %w", channel, err)
+		}
+	}
+
 	// Read some messages after joining (10-20 seconds)
 	time.Sleep(time.Duration(10+rand.Intn(10)) * time.Second)
 
 	return nil
 }
 
-func (e *TelegramExecutor) sendMessage(ctx context.Context, execCtx *models.ExecutionContext) error {
+func (e *TelegramExecutor) sendMessage(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
 	// Telegram restricts DMs for new accounts
 	if execCtx.CurrentDay < 14 {
 		return fmt.Errorf("direct messaging not allowed before day 14")
@@ -188,6 +275,24 @@ func (e *TelegramExecutor) sendMessage(ctx context.Context, execCtx *models.Exec
 	// Send
 	time.Sleep(time.Duration(300+rand.Intn(200)) * time.Millisecond)
 
+	peer := pickTarget(task.Metadata, telegramPeersKey)
+	if e.telegram == nil || peer == "" {
+		return nil
+	}
+
+	params := map[string]string{"peer": peer, "text": message}
+	resp, err := e.perform(ctx, execCtx, "send_message", params, func() (*telegrampb.ActionResponse, error) {
+		return e.telegram.SendMessage(ctx, &telegrampb.SendMessageRequest{
+			AccountId: execCtx.AccountID.Hex(),
+			Peer:      peer,
+			Text:      message,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send message to %s: %w", peer, err)
+	}
+
+	attachMessageEvidence(execCtx, fmt.Sprintf("message is delivered to %s", peer), resp)
 	return nil
 }
 
@@ -213,6 +318,9 @@ func (e *TelegramExecutor) commentPost(ctx context.Context, execCtx *models.Exec
 	}
 
 	comment := comments[rand.Intn(len(comments))]
+	if item := e.content.Next(ctx, execCtx.AccountID, execCtx.Platform, execCtx.Niche, models.ContentKindComment); item != nil {
+		comment = item.Text
+	}
 
 	e.logger.Debug("Commenting in Telegram group: %s", comment)
 
@@ -249,12 +357,25 @@ func (e *TelegramExecutor) createChannelPost(ctx context.Context, execCtx *model
 	}
 
 	post := posts[rand.Intn(len(posts))]
+	var imageURL string
+	if item := e.content.Next(ctx, execCtx.AccountID, execCtx.Platform, execCtx.Niche, models.ContentKindPost); item != nil {
+		post = item.Text
+		if item.Link != "" {
+			post += "\n" + item.Link
+		}
+		imageURL = item.ImageURL
+	}
 
 	e.logger.Debug("Creating Telegram channel post: %s", post)
 
 	// Open channel (2-3 seconds)
 	time.Sleep(time.Duration(2+rand.Intn(1)) * time.Second)
 
+	// Upload image (2-4 seconds)
+	if imageURL != "" {
+		time.Sleep(time.Duration(2+rand.Intn(2)) * time.Second)
+	}
+
 	// Type post
 	typingDelay := len(post) * 300
 	time.Sleep(time.Duration(typingDelay) * time.Millisecond)
@@ -267,3 +388,280 @@ func (e *TelegramExecutor) createChannelPost(ctx context.Context, execCtx *model
 
 	return nil
 }
+
+func (e *TelegramExecutor) postStory(ctx context.Context, execCtx *models.ExecutionContext) error {
+	// Stories are a mature-account behavior
+	if execCtx.CurrentDay < 21 {
+		return fmt.Errorf("stories not allowed before day 21")
+	}
+
+	// Check daily limit
+	if execCtx.ActionsToday >= e.actionLimits["post_story"] {
+		return fmt.Errorf("daily limit reached for post_story")
+	}
+
+	// A story needs media, so only image posts from the content library fit
+	item := e.content.Next(ctx, execCtx.AccountID, execCtx.Platform, execCtx.Niche, models.ContentKindPost)
+	if item == nil || item.ImageURL == "" {
+		return fmt.Errorf("no media available for story")
+	}
+
+	e.logger.Debug("Posting Telegram story from %s", item.ImageURL)
+
+	if e.telegram == nil {
+		// Pick media and write caption (10-20 seconds)
+		time.Sleep(time.Duration(10+rand.Intn(10)) * time.Second)
+		return nil
+	}
+
+	caption := storyCaption(item.Text)
+	params := map[string]string{"media_url": item.ImageURL, "caption": caption}
+	if _, err := e.perform(ctx, execCtx, "post_story", params, func() (*telegrampb.ActionResponse, error) {
+		return e.telegram.PostStory(ctx, &telegrampb.PostStoryRequest{
+			AccountId: execCtx.AccountID.Hex(),
+			MediaUrl:  item.ImageURL,
+			Caption:   caption,
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to post story: %w", err)
+	}
+
+	return nil
+}
+
+func (e *TelegramExecutor) scheduleMessage(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
+	if execCtx.CurrentDay < 14 {
+		return fmt.Errorf("scheduled messages not allowed before day 14")
+	}
+
+	// Check daily limit
+	if execCtx.ActionsToday >= e.actionLimits["schedule_message"] {
+		return fmt.Errorf("daily limit reached for schedule_message")
+	}
+
+	text := "Доброе утро! ☀️"
+	var mediaURL string
+	if item := e.content.Next(ctx, execCtx.AccountID, execCtx.Platform, execCtx.Niche, models.ContentKindPost); item != nil {
+		text = item.Text
+		if item.Link != "" {
+			text += "\n" + item.Link
+		}
+		mediaURL = item.ImageURL
+	}
+
+	// Scheduled posts go to the account's own channels, otherwise to a regular chat
+	peer := pickTarget(task.Metadata, telegramOwnChannelsKey)
+	if peer == "" {
+		peer = pickTarget(task.Metadata, telegramPeersKey)
+	}
+
+	// Real users schedule a few hours ahead
+	sendAt := time.Now().Add(time.Duration(60+rand.Intn(660)) * time.Minute)
+
+	e.logger.Debug("Scheduling Telegram message for %s", sendAt.Format(time.RFC3339))
+
+	if e.telegram == nil || peer == "" {
+		// Type message and pick time (roughly typing speed plus the date picker)
+		time.Sleep(time.Duration(len(text)*200)*time.Millisecond + time.Duration(3+rand.Intn(3))*time.Second)
+		return nil
+	}
+
+	params := map[string]string{"peer": peer, "text": text, "media_url": mediaURL, "send_at": sendAt.Format(time.RFC3339)}
+	if _, err := e.perform(ctx, execCtx, "schedule_message", params, func() (*telegrampb.ActionResponse, error) {
+		return e.telegram.ScheduleMessage(ctx, &telegrampb.ScheduleMessageRequest{
+			AccountId: execCtx.AccountID.Hex(),
+			Peer:      peer,
+			Text:      text,
+			MediaUrl:  mediaURL,
+			SendAt:    timestamppb.New(sendAt),
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to schedule message to %s: %w", peer, err)
+	}
+
+	return nil
+}
+
+// importContacts fills the address book from the task's contact list or, without one, with other
+// accounts in warming. telegram-service caps how many contacts an account imports per day.
+func (e *TelegramExecutor) importContacts(ctx context.Context, task *models.WarmingTask, execCtx *models.ExecutionContext) error {
+	// Check daily limit
+	if execCtx.ActionsToday >= e.actionLimits["import_contacts"] {
+		return fmt.Errorf("daily limit reached for import_contacts")
+	}
+
+	e.logger.Debug("Importing Telegram contacts")
+
+	// Open contacts and sync the address book (5-10 seconds)
+	time.Sleep(time.Duration(5+rand.Intn(5)) * time.Second)
+
+	if e.telegram == nil {
+		return nil
+	}
+
+	resp, err := e.telegram.ImportContacts(ctx, &telegrampb.ImportContactsRequest{
+		AccountId: execCtx.AccountID.Hex(),
+		Contacts:  parseContacts(targetList(task.Metadata, telegramContactsKey)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import contacts: %w", err)
+	}
+
+	e.logger.Debug("Imported %d Telegram contacts, %d not found", resp.Imported, resp.NotFound)
+	return nil
+}
+
+// seedDialog writes a greeting to an imported contact, so the account has real dialogs
+// before it is allowed to message strangers
+func (e *TelegramExecutor) seedDialog(ctx context.Context, execCtx *models.ExecutionContext) error {
+	// Contacts are imported on the first day
+	if execCtx.CurrentDay < 2 {
+		return fmt.Errorf("dialog seeding not allowed before day 2")
+	}
+
+	limit := e.actionLimits["seed_dialog"]
+	if execCtx.CurrentDay <= 7 {
+		limit = 1
+	}
+	if execCtx.ActionsToday >= limit {
+		return fmt.Errorf("daily limit reached for seed_dialog")
+	}
+
+	e.logger.Debug("Seeding Telegram dialog")
+
+	// Open contact and type a short greeting (3-6 seconds)
+	time.Sleep(time.Duration(3+rand.Intn(3)) * time.Second)
+
+	if e.telegram == nil {
+		return nil
+	}
+
+	resp, err := e.perform(ctx, execCtx, "seed_dialog", nil, func() (*telegrampb.ActionResponse, error) {
+		return e.telegram.SeedDialog(ctx, &telegrampb.SeedDialogRequest{
+			AccountId: execCtx.AccountID.Hex(),
+		})
+	})
+	if status.Code(err) == codes.NotFound || isActionErrorType(err, ErrorTypeNotFound) {
+		// Every imported contact already got a greeting
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to seed dialog: %w", err)
+	}
+
+	attachMessageEvidence(execCtx, fmt.Sprintf("greeting is delivered to contact %s", resp.Peer), resp)
+	return nil
+}
+
+// perform runs the action in telegram-service over the action stream, so retryable failures are
+// sent again right away. Without the stream, or for actions telegram-service does not stream,
+// the unary call is made.
+func (e *TelegramExecutor) perform(ctx context.Context, execCtx *models.ExecutionContext, actionType string, params map[string]string, unary func() (*telegrampb.ActionResponse, error)) (*telegrampb.ActionResponse, error) {
+	result, err := e.stream.Execute(ctx, execCtx, actionType, params)
+	switch {
+	case err == nil && result.ErrorType != ErrorTypeUnsupported:
+		if err := actionResultError(result); err != nil {
+			return nil, err
+		}
+		return streamedActionResponse(execCtx, result), nil
+	case err != nil && !errors.Is(err, errActionStreamUnavailable):
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := unary()
+	execCtx.Attempts = 1
+	execCtx.PlatformLatencyMs = time.Since(start).Milliseconds()
+	return resp, err
+}
+
+// streamedActionResponse restores the unary response from the result details
+func streamedActionResponse(execCtx *models.ExecutionContext, result *warmingpb.ActionResult) *telegrampb.ActionResponse {
+	messageID, _ := strconv.Atoi(result.Details["message_id"])
+	return &telegrampb.ActionResponse{
+		AccountId: execCtx.AccountID.Hex(),
+		Peer:      result.Details["peer"],
+		MessageId: int32(messageID),
+	}
+}
+
+// attachMessageEvidence records the telegram-service response of a sampled action as evidence.
+// Telegram only returns a message ID once the update was applied, a zero ID means nothing reached the chat.
+func attachMessageEvidence(execCtx *models.ExecutionContext, assertion string, resp *telegrampb.ActionResponse) {
+	if !execCtx.CaptureEvidence || resp == nil {
+		return
+	}
+
+	execCtx.Evidence = &models.ActionEvidence{
+		Kind:      models.EvidenceAPI,
+		Assertion: assertion,
+		Verified:  resp.MessageId > 0,
+		Details: map[string]string{
+			"peer":       resp.Peer,
+			"message_id": strconv.Itoa(int(resp.MessageId)),
+		},
+	}
+}
+
+// storyCaption trims post text to the caption length Telegram allows for stories
+func storyCaption(text string) string {
+	const maxCaption = 200
+
+	runes := []rune(text)
+	if len(runes) <= maxCaption {
+		return text
+	}
+	return string(runes[:maxCaption-1]) + "…"
+}
+
+// parseContacts reads "<phone> [first name] [last name]" entries of the task contact list
+func parseContacts(entries []string) []*telegrampb.Contact {
+	var contacts []*telegrampb.Contact
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+
+		contact := &telegrampb.Contact{Phone: fields[0]}
+		if len(fields) > 1 {
+			contact.FirstName = fields[1]
+		}
+		if len(fields) > 2 {
+			contact.LastName = strings.Join(fields[2:], " ")
+		}
+		contacts = append(contacts, contact)
+	}
+	return contacts
+}
+
+// pickTarget returns a random entry of a string list stored in task metadata
+func pickTarget(metadata map[string]interface{}, key string) string {
+	targets := targetList(metadata, key)
+	if len(targets) == 0 {
+		return ""
+	}
+	return targets[rand.Intn(len(targets))]
+}
+
+// targetList returns a string list stored in task metadata, however it was decoded
+func targetList(metadata map[string]interface{}, key string) []string {
+	var targets []string
+	switch v := metadata[key].(type) {
+	case []string:
+		targets = v
+	case []interface{}:
+		for _, item := range v {
+			if target, ok := item.(string); ok && target != "" {
+				targets = append(targets, target)
+			}
+		}
+	case primitive.A:
+		for _, item := range v {
+			if target, ok := item.(string); ok && target != "" {
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPickTarget(t *testing.T) {
+	t.Run("missing key", func(t *testing.T) {
+		assert.Empty(t, pickTarget(map[string]interface{}{}, telegramChannelsKey))
+		assert.Empty(t, pickTarget(nil, telegramChannelsKey))
+	})
+
+	t.Run("string slice", func(t *testing.T) {
+		metadata := map[string]interface{}{telegramChannelsKey: []string{"@durov"}}
+		assert.Equal(t, "@durov", pickTarget(metadata, telegramChannelsKey))
+	})
+
+	t.Run("decoded from JSON", func(t *testing.T) {
+		metadata := map[string]interface{}{telegramPeersKey: []interface{}{"", 42, "@friend"}}
+		assert.Equal(t, "@friend", pickTarget(metadata, telegramPeersKey))
+	})
+
+	t.Run("decoded from BSON", func(t *testing.T) {
+		metadata := map[string]interface{}{telegramChannelsKey: primitive.A{"@news", "@tech"}}
+		assert.Contains(t, []string{"@news", "@tech"}, pickTarget(metadata, telegramChannelsKey))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		metadata := map[string]interface{}{telegramChannelsKey: "@durov"}
+		assert.Empty(t, pickTarget(metadata, telegramChannelsKey))
+	})
+}
+
+func TestParseContacts(t *testing.T) {
+	contacts := parseContacts([]string{"+79001234567", " ", "+79007654321 Анна", "+79005550000 Иван Петров Сидоров"})
+
+	if assert.Len(t, contacts, 3) {
+		assert.Equal(t, "+79001234567", contacts[0].Phone)
+		assert.Empty(t, contacts[0].FirstName)
+		assert.Equal(t, "Анна", contacts[1].FirstName)
+		assert.Empty(t, contacts[1].LastName)
+		assert.Equal(t, "Иван", contacts[2].FirstName)
+		assert.Equal(t, "Петров Сидоров", contacts[2].LastName)
+	}
+
+	assert.Empty(t, parseContacts(nil))
+}
+
+func TestStoryCaption(t *testing.T) {
+	assert.Equal(t, "Доброе утро!", storyCaption("Доброе утро!"))
+
+	long := strings.Repeat("я", 250)
+	caption := storyCaption(long)
+	assert.Equal(t, 200, utf8.RuneCountInString(caption))
+	assert.True(t, strings.HasSuffix(caption, "…"))
+}
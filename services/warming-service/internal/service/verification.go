@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Verification results of a sampled action
+const (
+	verificationVerified    = "verified"
+	verificationUnverified  = "unverified"
+	verificationUnsupported = "unsupported" // the executor cannot prove this action, e.g. it is only simulated
+)
+
+const maxEvidenceListLimit = 100
+
+// sampleForEvidence decides whether an action is sampled for evidence
+func (s *warmingService) sampleForEvidence() bool {
+	verification := s.config.WarmingConfig.Verification
+	return verification.Enabled && rand.Float64() < verification.SampleRate
+}
+
+// recordEvidence stores the evidence an executor attached to a sampled successful action
+func (s *warmingService) recordEvidence(ctx context.Context, task *models.WarmingTask, actionType string, execCtx *models.ExecutionContext) {
+	scenarioID := ""
+	if !task.ScenarioID.IsZero() {
+		scenarioID = task.ScenarioID.Hex()
+	}
+	scenario := scenarioLabel(task.ScenarioType, scenarioID)
+
+	evidence := execCtx.Evidence
+	if evidence == nil {
+		s.metrics.IncrementVerifications(task.Platform, scenario, verificationUnsupported)
+		return
+	}
+
+	evidence.TaskID = task.ID
+	evidence.AccountID = task.AccountID
+	evidence.Platform = task.Platform
+	evidence.ScenarioType = task.ScenarioType
+	evidence.ActionType = actionType
+	if scenarioID != "" {
+		id := task.ScenarioID
+		evidence.ScenarioID = &id
+	}
+
+	if err := s.evidenceRepo.Save(ctx, evidence); err != nil {
+		s.logger.Error("Failed to save evidence for task %s: %v", task.ID.Hex(), err)
+	}
+
+	if evidence.Verified {
+		s.metrics.IncrementVerifications(task.Platform, scenario, verificationVerified)
+		return
+	}
+
+	s.metrics.IncrementVerifications(task.Platform, scenario, verificationUnverified)
+	s.logger.Warn("Action %s of task %s reported success but was not verified: %s",
+		actionType, task.ID.Hex(), evidence.Assertion)
+}
+
+// scenarioLabel names a scenario in verification metrics, custom scenarios are told apart by ID
+func scenarioLabel(scenarioType, scenarioID string) string {
+	if scenarioID == "" {
+		return scenarioType
+	}
+	return scenarioType + ":" + scenarioID
+}
+
+// ComputeScenarioVerification folds evidence counts into verification rates per scenario and action type
+func ComputeScenarioVerification(counts []models.EvidenceCount) []*models.ScenarioVerification {
+	byScenario := make(map[string]*models.ScenarioVerification)
+	for _, count := range counts {
+		key := count.Platform + "/" + scenarioLabel(count.ScenarioType, count.ScenarioID)
+		scenario, ok := byScenario[key]
+		if !ok {
+			scenario = &models.ScenarioVerification{
+				Platform:     count.Platform,
+				ScenarioType: count.ScenarioType,
+				ScenarioID:   count.ScenarioID,
+				ByAction:     make(map[string]models.ActionVerification),
+			}
+			byScenario[key] = scenario
+		}
+
+		action := scenario.ByAction[count.ActionType]
+		action.Sampled += count.Sampled
+		action.Verified += count.Verified
+		action.Rate = verificationRate(action.Verified, action.Sampled)
+		scenario.ByAction[count.ActionType] = action
+
+		scenario.Sampled += count.Sampled
+		scenario.Verified += count.Verified
+	}
+
+	result := make([]*models.ScenarioVerification, 0, len(byScenario))
+	for _, scenario := range byScenario {
+		scenario.Rate = verificationRate(scenario.Verified, scenario.Sampled)
+		result = append(result, scenario)
+	}
+
+	// Worst scenarios first, they are the ones with broken selectors
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rate != result[j].Rate {
+			return result[i].Rate < result[j].Rate
+		}
+		if result[i].Platform != result[j].Platform {
+			return result[i].Platform < result[j].Platform
+		}
+		return scenarioLabel(result[i].ScenarioType, result[i].ScenarioID) < scenarioLabel(result[j].ScenarioType, result[j].ScenarioID)
+	})
+
+	return result
+}
+
+func verificationRate(verified, sampled int64) float64 {
+	if sampled == 0 {
+		return 0
+	}
+	return float64(verified) / float64(sampled)
+}
+
+// GetVerificationStats returns the verification rate of every scenario over the configured window
+func (s *warmingService) GetVerificationStats(ctx context.Context, platform string) ([]*models.ScenarioVerification, error) {
+	since := time.Now().Add(-s.config.WarmingConfig.Verification.Window)
+
+	counts, err := s.evidenceRepo.CountVerifications(ctx, platform, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputeScenarioVerification(counts), nil
+}
+
+func (s *warmingService) ListActionEvidence(ctx context.Context, taskID primitive.ObjectID, limit int) ([]*models.ActionEvidence, error) {
+	if limit <= 0 || limit > maxEvidenceListLimit {
+		limit = maxEvidenceListLimit
+	}
+	return s.evidenceRepo.ListByTask(ctx, taskID, limit)
+}
+
+func (s *warmingService) GetActionEvidence(ctx context.Context, evidenceID primitive.ObjectID) (*models.ActionEvidence, error) {
+	return s.evidenceRepo.GetByID(ctx, evidenceID)
+}
+
+// updateVerificationRates refreshes the per-scenario verification rate gauges
+func (s *warmingService) updateVerificationRates(ctx context.Context) {
+	if !s.config.WarmingConfig.Verification.Enabled {
+		return
+	}
+
+	stats, err := s.GetVerificationStats(ctx, "")
+	if err != nil {
+		s.logger.Error("Failed to compute verification rates: %v", err)
+		return
+	}
+
+	for _, scenario := range stats {
+		s.metrics.SetVerificationRate(scenario.Platform, scenarioLabel(scenario.ScenarioType, scenario.ScenarioID), scenario.Rate)
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	telegrampb "github.com/grigta/conveer/services/telegram-service/proto"
+	"github.com/grigta/conveer/services/warming-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test ComputeScenarioVerification folding
+func TestComputeScenarioVerification(t *testing.T) {
+	counts := []models.EvidenceCount{
+		{Platform: "telegram", ScenarioType: "basic", ActionType: "send_message", Sampled: 10, Verified: 9},
+		{Platform: "telegram", ScenarioType: "basic", ActionType: "react_message", Sampled: 10, Verified: 10},
+		{Platform: "telegram", ScenarioType: "custom", ScenarioID: "64b7f0c2a1b2c3d4e5f60718", ActionType: "react_message", Sampled: 4, Verified: 1},
+		{Platform: "vk", ScenarioType: "basic", ActionType: "like_post", Sampled: 0, Verified: 0},
+	}
+
+	result := ComputeScenarioVerification(counts)
+	require.Len(t, result, 3)
+
+	// Worst first
+	assert.Equal(t, "vk", result[0].Platform)
+	assert.Equal(t, 0.0, result[0].Rate)
+
+	custom := result[1]
+	assert.Equal(t, "64b7f0c2a1b2c3d4e5f60718", custom.ScenarioID)
+	assert.InDelta(t, 0.25, custom.Rate, 0.001)
+
+	basic := result[2]
+	assert.Equal(t, int64(20), basic.Sampled)
+	assert.Equal(t, int64(19), basic.Verified)
+	assert.InDelta(t, 0.95, basic.Rate, 0.001)
+	assert.InDelta(t, 0.9, basic.ByAction["send_message"].Rate, 0.001)
+	assert.InDelta(t, 1.0, basic.ByAction["react_message"].Rate, 0.001)
+}
+
+func TestScenarioLabel(t *testing.T) {
+	assert.Equal(t, "basic", scenarioLabel("basic", ""))
+	assert.Equal(t, "custom:64b7f0c2a1b2c3d4e5f60718", scenarioLabel("custom", "64b7f0c2a1b2c3d4e5f60718"))
+}
+
+// Test attachMessageEvidence only fills sampled actions
+func TestAttachMessageEvidence(t *testing.T) {
+	execCtx := &models.ExecutionContext{}
+	attachMessageEvidence(execCtx, "message is delivered to @friend", &telegrampb.ActionResponse{Peer: "@friend", MessageId: 42})
+	assert.Nil(t, execCtx.Evidence)
+
+	execCtx.CaptureEvidence = true
+	attachMessageEvidence(execCtx, "message is delivered to @friend", &telegrampb.ActionResponse{Peer: "@friend", MessageId: 42})
+	require.NotNil(t, execCtx.Evidence)
+	assert.Equal(t, models.EvidenceAPI, execCtx.Evidence.Kind)
+	assert.True(t, execCtx.Evidence.Verified)
+	assert.Equal(t, "42", execCtx.Evidence.Details["message_id"])
+
+	attachMessageEvidence(execCtx, "message is delivered to @friend", &telegrampb.ActionResponse{Peer: "@friend"})
+	assert.False(t, execCtx.Evidence.Verified)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grigta/conveer/pkg/logger"
+	vkpb "github.com/grigta/conveer/services/vk-service/proto"
 	"github.com/grigta/conveer/services/warming-service/internal/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc"
@@ -14,11 +15,12 @@ import (
 
 type VKExecutor struct {
 	BaseExecutor
-	client *grpc.ClientConn // VK service gRPC client
-	logger logger.Logger
+	client  *grpc.ClientConn // VK service gRPC client
+	content *ContentProvider
+	logger  logger.Logger
 }
 
-func NewVKExecutor(client *grpc.ClientConn, logger logger.Logger) *VKExecutor {
+func NewVKExecutor(client *grpc.ClientConn, content *ContentProvider, logger logger.Logger) *VKExecutor {
 	return &VKExecutor{
 		BaseExecutor: BaseExecutor{
 			supportedActions: []string{
@@ -33,8 +35,9 @@ func NewVKExecutor(client *grpc.ClientConn, logger logger.Logger) *VKExecutor {
 				"create_post":     3,   // per day
 			},
 		},
-		client: client,
-		logger: logger,
+		client:  client,
+		content: content,
+		logger:  logger,
 	}
 }
 
@@ -82,6 +85,20 @@ func (e *VKExecutor) ValidateAccount(ctx context.Context, accountID primitive.Ob
 	return nil
 }
 
+// ProfileCompleteness reports how much of the account profile is filled in VK service
+func (e *VKExecutor) ProfileCompleteness(ctx context.Context, accountID primitive.ObjectID) (float64, error) {
+	if e.client == nil {
+		return 0, fmt.Errorf("vk service is not connected")
+	}
+
+	account, err := vkpb.NewVKServiceClient(e.client).GetAccount(ctx, &vkpb.GetAccountRequest{AccountId: accountID.Hex()})
+	if err != nil {
+		return 0, err
+	}
+
+	return filledShare(account.FirstName, account.LastName, account.Username, account.PersonaId), nil
+}
+
 func (e *VKExecutor) viewProfile(ctx context.Context, execCtx *models.ExecutionContext) error {
 	// Simulate viewing a random profile
 	// 1. Navigate to VK profile page
@@ -198,6 +215,9 @@ func (e *VKExecutor) commentPost(ctx context.Context, execCtx *models.ExecutionC
 	}
 
 	comment := comments[rand.Intn(len(comments))]
+	if item := e.content.Next(ctx, execCtx.AccountID, execCtx.Platform, execCtx.Niche, models.ContentKindComment); item != nil {
+		comment = item.Text
+	}
 
 	e.logger.Debug("Commenting on VK post: %s", comment)
 
@@ -275,12 +295,25 @@ func (e *VKExecutor) createPost(ctx context.Context, execCtx *models.ExecutionCo
 	}
 
 	post := posts[rand.Intn(len(posts))]
+	var imageURL string
+	if item := e.content.Next(ctx, execCtx.AccountID, execCtx.Platform, execCtx.Niche, models.ContentKindPost); item != nil {
+		post = item.Text
+		if item.Link != "" {
+			post += "\n" + item.Link
+		}
+		imageURL = item.ImageURL
+	}
 
 	e.logger.Debug("Creating VK post: %s", post)
 
 	// Open post creator (2-3 seconds)
 	time.Sleep(time.Duration(2+rand.Intn(1)) * time.Second)
 
+	// Attach image (2-4 seconds)
+	if imageURL != "" {
+		time.Sleep(time.Duration(2+rand.Intn(2)) * time.Second)
+	}
+
 	// Type post
 	typingDelay := len(post) * 300
 	time.Sleep(time.Duration(typingDelay) * time.Millisecond)
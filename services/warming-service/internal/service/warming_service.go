@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/grigta/conveer/pkg/cache"
@@ -19,7 +21,7 @@ import (
 )
 
 type WarmingService interface {
-	StartWarming(ctx context.Context, accountID primitive.ObjectID, platform, scenarioType string, scenarioID *primitive.ObjectID, durationDays int) (*models.WarmingTask, error)
+	StartWarming(ctx context.Context, accountID primitive.ObjectID, platform, scenarioType string, scenarioID *primitive.ObjectID, durationDays int, callbacks *models.TaskCallbacks) (*models.WarmingTask, error)
 	PauseWarming(ctx context.Context, taskID primitive.ObjectID) (*models.WarmingTask, error)
 	ResumeWarming(ctx context.Context, taskID primitive.ObjectID) (*models.WarmingTask, error)
 	StopWarming(ctx context.Context, taskID primitive.ObjectID) (*models.WarmingTask, error)
@@ -27,18 +29,39 @@ type WarmingService interface {
 	GetWarmingStatistics(ctx context.Context, platform string, startDate, endDate time.Time) (*models.AggregatedStats, error)
 	CreateCustomScenario(ctx context.Context, scenario *models.WarmingScenario) (*models.WarmingScenario, error)
 	UpdateCustomScenario(ctx context.Context, scenarioID primitive.ObjectID, scenario *models.WarmingScenario) (*models.WarmingScenario, error)
+	SimulateScenario(scenario *models.WarmingScenario, params models.ScenarioSimulationParams) *models.ScenarioSimulation
 	ListScenarios(ctx context.Context, platform string) ([]*models.WarmingScenario, error)
 	ListTasks(ctx context.Context, filter models.TaskFilter) ([]*models.WarmingTask, error)
+	UpdateActivityCalendar(ctx context.Context, taskID primitive.ObjectID, calendar *models.ActivityCalendar) (*models.WarmingTask, error)
+	GetCapacity(ctx context.Context, platform string) ([]*models.PlatformCapacity, error)
+	CreateExperiment(ctx context.Context, experiment *models.Experiment) (*models.Experiment, error)
+	StopExperiment(ctx context.Context, experimentID primitive.ObjectID) (*models.Experiment, error)
+	ListExperiments(ctx context.Context, platform, status string) ([]*models.Experiment, error)
+	GetExperimentResults(ctx context.Context, experimentID primitive.ObjectID) (*models.ExperimentResults, error)
+	GetVerificationStats(ctx context.Context, platform string) ([]*models.ScenarioVerification, error)
+	ListActionEvidence(ctx context.Context, taskID primitive.ObjectID, limit int) ([]*models.ActionEvidence, error)
+	GetActionEvidence(ctx context.Context, evidenceID primitive.ObjectID) (*models.ActionEvidence, error)
 	StartWorkers(ctx context.Context)
 }
 
+// broker is the part of the RabbitMQ client the service uses. The client marshals messages to
+// JSON itself, so values are published as they are.
+type broker interface {
+	Publish(exchange, routingKey string, message interface{}) error
+	PublishWithOptions(exchange, routingKey string, message interface{}, opts messaging.PublishOptions) error
+	ConsumeWithHandler(ctx context.Context, queueName, consumerName string, handler func([]byte) error) error
+}
+
 type warmingService struct {
 	taskRepo        repository.TaskRepository
 	scenarioRepo    repository.ScenarioRepository
 	statsRepo       repository.StatsRepository
 	scheduleRepo    repository.ScheduleRepository
-	messaging       *messaging.RabbitMQClient
-	cache           *cache.RedisClient
+	contentRepo     repository.ContentRepository
+	experimentRepo  repository.ExperimentRepository
+	evidenceRepo    repository.EvidenceRepository
+	messaging       broker
+	cache           *cache.RedisCache
 	vkClient        *grpc.ClientConn
 	telegramClient  *grpc.ClientConn
 	mailClient      *grpc.ClientConn
@@ -48,7 +71,12 @@ type warmingService struct {
 	scheduler       *Scheduler
 	behaviorSim     *BehaviorSimulator
 	platformExecs   map[string]PlatformExecutor
+	actions         *ActionRegistry
 	metrics         *Metrics
+	capacity        *CapacityModel
+	callbackHosts   *callbackResolver
+	callbackClient  *http.Client
+	admissionMu     sync.Mutex
 }
 
 func NewWarmingService(
@@ -56,8 +84,11 @@ func NewWarmingService(
 	scenarioRepo repository.ScenarioRepository,
 	statsRepo repository.StatsRepository,
 	scheduleRepo repository.ScheduleRepository,
-	messaging *messaging.RabbitMQClient,
-	cache *cache.RedisClient,
+	contentRepo repository.ContentRepository,
+	experimentRepo repository.ExperimentRepository,
+	evidenceRepo repository.EvidenceRepository,
+	messaging *messaging.RabbitMQ,
+	cache *cache.RedisCache,
 	vkClient, telegramClient, mailClient, maxClient *grpc.ClientConn,
 	config *config.Config,
 	logger logger.Logger,
@@ -67,6 +98,9 @@ func NewWarmingService(
 		scenarioRepo:   scenarioRepo,
 		statsRepo:      statsRepo,
 		scheduleRepo:   scheduleRepo,
+		contentRepo:    contentRepo,
+		experimentRepo: experimentRepo,
+		evidenceRepo:   evidenceRepo,
 		messaging:      messaging,
 		cache:          cache,
 		vkClient:       vkClient,
@@ -81,19 +115,62 @@ func NewWarmingService(
 	// Initialize components
 	ws.scheduler = NewScheduler(ws, scheduleRepo, statsRepo, config, logger)
 	ws.behaviorSim = NewBehaviorSimulator(config, logger)
+	ws.capacity = NewCapacityModel(config.WarmingConfig.Capacity, ws.scheduler)
+	ws.callbackHosts = newCallbackResolver()
+	ws.callbackClient = ws.callbackHosts.client()
 
 	// Initialize platform executors
+	content := NewContentProvider(contentRepo, config.WarmingConfig.Content, logger)
+	streams := map[string]*ActionStream{
+		"vk":       NewActionStream("vk", vkClient, config.WarmingConfig.ActionStream, ws.metrics, logger),
+		"telegram": NewActionStream("telegram", telegramClient, config.WarmingConfig.ActionStream, ws.metrics, logger),
+		"mail":     NewActionStream("mail", mailClient, config.WarmingConfig.ActionStream, ws.metrics, logger),
+		"max":      NewActionStream("max", maxClient, config.WarmingConfig.ActionStream, ws.metrics, logger),
+	}
 	ws.platformExecs = map[string]PlatformExecutor{
-		"vk":       NewVKExecutor(vkClient, logger),
-		"telegram": NewTelegramExecutor(telegramClient, logger),
+		"vk":       NewVKExecutor(vkClient, content, logger),
+		"telegram": NewTelegramExecutor(telegramClient, streams["telegram"], content, logger),
 		"mail":     NewMailExecutor(mailClient, logger),
 		"max":      NewMaxExecutor(maxClient, logger),
 	}
 
+	ws.actions = NewActionRegistry(logger)
+	for platform, executor := range ws.platformExecs {
+		ws.actions.RegisterPlatform(platform, PluginPlatform{Executor: executor, Stream: streams[platform]})
+	}
+	ws.loadActionPlugins()
+
 	return ws
 }
 
-func (s *warmingService) StartWarming(ctx context.Context, accountID primitive.ObjectID, platform, scenarioType string, scenarioID *primitive.ObjectID, durationDays int) (*models.WarmingTask, error) {
+// loadActionPlugins registers the actions of the plugin manifest. A missing or broken manifest
+// leaves the built-in actions only, invalid entries are skipped.
+func (s *warmingService) loadActionPlugins() {
+	path := s.config.WarmingConfig.ActionPlugins.Manifest
+	if path == "" {
+		return
+	}
+
+	manifest, err := config.LoadActionPluginManifest(path)
+	if err != nil {
+		s.logger.Error("Failed to load action plugin manifest %s: %v", path, err)
+		return
+	}
+
+	if err := s.actions.LoadManifest(manifest); err != nil {
+		s.logger.Error("Skipped invalid action plugins of %s: %v", path, err)
+	}
+}
+
+func (s *warmingService) StartWarming(ctx context.Context, accountID primitive.ObjectID, platform, scenarioType string, scenarioID *primitive.ObjectID, durationDays int, callbacks *models.TaskCallbacks) (*models.WarmingTask, error) {
+	if err := s.validateCallbacks(ctx, callbacks); err != nil {
+		return nil, err
+	}
+	return s.startWarming(ctx, accountID, platform, scenarioType, scenarioID, durationDays, nil, callbacks)
+}
+
+// startWarming creates the task; assignment is set when the scenario comes from an experiment variant
+func (s *warmingService) startWarming(ctx context.Context, accountID primitive.ObjectID, platform, scenarioType string, scenarioID *primitive.ObjectID, durationDays int, assignment *models.ExperimentAssignment, callbacks *models.TaskCallbacks) (*models.WarmingTask, error) {
 	// Check if task already exists for this account
 	existingTask, err := s.taskRepo.GetByAccountAndPlatform(ctx, accountID, platform)
 	if err != nil {
@@ -117,6 +194,11 @@ func (s *warmingService) StartWarming(ctx context.Context, accountID primitive.O
 		DurationDays: durationDays,
 		Status:       string(models.TaskStatusScheduled),
 		CurrentDay:   0,
+		Experiment:   assignment,
+	}
+
+	if callbacks != nil && len(callbacks.URLs) > 0 {
+		task.Callbacks = callbacks
 	}
 
 	// Set ScenarioID only if it's not nil
@@ -124,33 +206,70 @@ func (s *warmingService) StartWarming(ctx context.Context, accountID primitive.O
 		task.ScenarioID = *scenarioID
 	}
 
+	// Admission check and insert are serialized so concurrent starts see each other's load
+	s.admissionMu.Lock()
+	decision, err := s.admitTask(ctx, task)
+	if err != nil {
+		s.admissionMu.Unlock()
+		return nil, err
+	}
+	s.metrics.IncrementAdmissions(platform, string(decision))
+
+	if decision == models.AdmissionRejected {
+		s.admissionMu.Unlock()
+		return nil, fmt.Errorf("%w %s", ErrCapacityExceeded, platform)
+	}
+	if decision == models.AdmissionQueued {
+		task.Status = string(models.TaskStatusQueued)
+	}
+
 	// Save task to database
-	if err := s.taskRepo.Create(ctx, task); err != nil {
+	err = s.taskRepo.Create(ctx, task)
+	s.admissionMu.Unlock()
+	if err != nil {
 		return nil, fmt.Errorf("failed to create warming task: %w", err)
 	}
 
+	if decision == models.AdmissionQueued {
+		s.metrics.IncrementTasksTotal(platform, scenarioType, "queued")
+		s.logger.Info("Queued warming task %s for account %s: %s executor is at capacity", task.ID.Hex(), accountID.Hex(), platform)
+		return task, nil
+	}
+
+	s.launchTask(ctx, task)
+
+	return task, nil
+}
+
+// launchTask hands a scheduled task over to the workers
+func (s *warmingService) launchTask(ctx context.Context, task *models.WarmingTask) {
 	// Publish start command
 	command := map[string]interface{}{
 		"task_id":    task.ID.Hex(),
-		"account_id": accountID.Hex(),
-		"platform":   platform,
+		"account_id": task.AccountID.Hex(),
+		"platform":   task.Platform,
 	}
 
-	commandJSON, _ := json.Marshal(command)
-	if err := s.messaging.Publish("warming.commands", "start", commandJSON); err != nil {
+	if err := s.messaging.Publish("warming.commands", "start", command); err != nil {
 		s.logger.Error("Failed to publish start command: %v", err)
 	}
 
 	// Update account status in platform service
-	s.updateAccountStatus(ctx, accountID, platform, "warming")
+	s.updateAccountStatus(ctx, task.AccountID, task.Platform, "warming")
+
+	// Analytics groups account survival by the scenario the account was first warmed with
+	s.publishEvent("warming.task.started", task.Platform, map[string]interface{}{
+		"task_id":       task.ID.Hex(),
+		"account_id":    task.AccountID.Hex(),
+		"platform":      task.Platform,
+		"scenario_type": task.ScenarioType,
+	})
 
 	// Increment metrics
-	s.metrics.IncrementTasksTotal(platform, scenarioType, "scheduled")
+	s.metrics.IncrementTasksTotal(task.Platform, task.ScenarioType, "scheduled")
 
 	// Log event
-	s.logger.Info("Started warming task %s for account %s on platform %s", task.ID.Hex(), accountID.Hex(), platform)
-
-	return task, nil
+	s.logger.Info("Started warming task %s for account %s on platform %s", task.ID.Hex(), task.AccountID.Hex(), task.Platform)
 }
 
 func (s *warmingService) PauseWarming(ctx context.Context, taskID primitive.ObjectID) (*models.WarmingTask, error) {
@@ -189,7 +308,7 @@ func (s *warmingService) ResumeWarming(ctx context.Context, taskID primitive.Obj
 	}
 
 	// Update task status and next action time
-	nextActionAt := s.scheduler.CalculateNextActionTime(time.Now(), task.CurrentDay, task.DurationDays)
+	nextActionAt := s.scheduler.CalculateNextActionTimeForTask(task, time.Now())
 	update := models.TaskUpdate{
 		Status:       stringPtr(string(models.TaskStatusInProgress)),
 		NextActionAt: &nextActionAt,
@@ -198,6 +317,7 @@ func (s *warmingService) ResumeWarming(ctx context.Context, taskID primitive.Obj
 	if err := s.taskRepo.Update(ctx, taskID, update); err != nil {
 		return nil, fmt.Errorf("failed to resume task: %w", err)
 	}
+	s.scheduleAction(task, nextActionAt)
 
 	// Publish resume event
 	s.publishEvent("warming.task.resumed", task.Platform, map[string]interface{}{
@@ -240,6 +360,10 @@ func (s *warmingService) StopWarming(ctx context.Context, taskID primitive.Objec
 
 	task.Status = string(models.TaskStatusCompleted)
 	task.CompletedAt = &now
+
+	// The scenario didn't run to its end, so the callback reports the progress actually made
+	s.notifyMilestone(task, models.MilestoneStopped, task.CurrentDay, "stopped manually")
+
 	return task, nil
 }
 
@@ -247,31 +371,13 @@ func (s *warmingService) GetWarmingStatus(ctx context.Context, taskID primitive.
 	return s.taskRepo.GetByID(ctx, taskID)
 }
 
-func (s *warmingService) GetWarmingStatistics(ctx context.Context, platform string, startDate, endDate time.Time) (*models.AggregatedStats, error) {
-	stats, err := s.statsRepo.GetAggregatedStats(ctx, platform, startDate, endDate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get warming statistics: %w", err)
-	}
-
-	// Get top actions
-	topActions, err := s.statsRepo.GetTopActions(ctx, platform, 10)
-	if err == nil {
-		stats.TopActions = topActions
-	}
-
-	// Get common errors
-	commonErrors, err := s.statsRepo.GetCommonErrors(ctx, platform, 10)
-	if err == nil {
-		stats.CommonErrors = commonErrors
-	}
-
-	return stats, nil
-}
-
 func (s *warmingService) CreateCustomScenario(ctx context.Context, scenario *models.WarmingScenario) (*models.WarmingScenario, error) {
 	// Validate scenario
 	if scenario.Name == "" || scenario.Platform == "" {
-		return nil, fmt.Errorf("scenario name and platform are required")
+		return nil, fmt.Errorf("%w: scenario name and platform are required", ErrInvalidScenario)
+	}
+	if err := validateStoredScenario(scenario); err != nil {
+		return nil, err
 	}
 
 	// Check if scenario with same name exists
@@ -305,6 +411,10 @@ func (s *warmingService) UpdateCustomScenario(ctx context.Context, scenarioID pr
 	existing.Actions = scenario.Actions
 	existing.Schedule = scenario.Schedule
 
+	if err := validateStoredScenario(existing); err != nil {
+		return nil, err
+	}
+
 	if err := s.scenarioRepo.Update(ctx, scenarioID, existing); err != nil {
 		return nil, fmt.Errorf("failed to update scenario: %w", err)
 	}
@@ -320,6 +430,47 @@ func (s *warmingService) ListTasks(ctx context.Context, filter models.TaskFilter
 	return s.taskRepo.List(ctx, filter)
 }
 
+func (s *warmingService) UpdateActivityCalendar(ctx context.Context, taskID primitive.ObjectID, calendar *models.ActivityCalendar) (*models.WarmingTask, error) {
+	if err := ValidateActivityCalendar(calendar); err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if calendar.Timezone == "" {
+		calendar.Timezone = s.config.WarmingConfig.BehaviorSimulation.Timezone
+	}
+	// Persona without explicit hours means "use the preset"
+	if calendar.Persona != "" && calendar.WakeHour == 0 && calendar.SleepHour == 0 {
+		applyPersona(calendar)
+	}
+
+	update := models.TaskUpdate{
+		Calendar: calendar,
+	}
+
+	// Reschedule right away so the override takes effect on the next action
+	task.Calendar = calendar
+	if task.Status == string(models.TaskStatusInProgress) || task.Status == string(models.TaskStatusScheduled) {
+		nextActionAt := s.scheduler.CalculateNextActionTimeForTask(task, time.Now())
+		update.NextActionAt = &nextActionAt
+		task.NextActionAt = &nextActionAt
+	}
+
+	if err := s.taskRepo.Update(ctx, taskID, update); err != nil {
+		return nil, fmt.Errorf("failed to update activity calendar: %w", err)
+	}
+	if update.NextActionAt != nil {
+		s.scheduleAction(task, *update.NextActionAt)
+	}
+
+	s.logger.Info("Updated activity calendar for task %s (timezone %s)", taskID.Hex(), calendar.Timezone)
+	return task, nil
+}
+
 func (s *warmingService) StartWorkers(ctx context.Context) {
 	// Start scheduler worker
 	go s.runSchedulerWorker(ctx)
@@ -338,9 +489,24 @@ func (s *warmingService) StartWorkers(ctx context.Context) {
 		go s.runAutoStartConsumer(ctx)
 	}
 
+	// Start resurrection consumers, one per platform since status events don't all carry it
+	if s.config.WarmingConfig.Resurrection.Enabled {
+		for platform := range s.platformExecs {
+			go s.runResurrectionConsumer(ctx, platform)
+		}
+	}
+
 	// Start stats aggregator
 	go s.runStatsAggregator(ctx)
 
+	// Start capacity monitor, it also admits queued tasks
+	go s.runCapacityWorker(ctx)
+
+	// Start task callback delivery
+	if s.config.WarmingConfig.Callbacks.Enabled {
+		go s.runCallbackWorker(ctx)
+	}
+
 	s.logger.Info("All warming service workers started")
 }
 
@@ -358,27 +524,24 @@ func (s *warmingService) runSchedulerWorker(ctx context.Context) {
 	}
 }
 
+// processScheduledTasks re-publishes commands of overdue tasks. Planned actions arrive as delayed
+// messages, so a task still due after ActionTimeout lost its command (e.g. it predates delayed scheduling).
 func (s *warmingService) processScheduledTasks(ctx context.Context) {
 	// Get tasks ready for execution
-	tasks, err := s.taskRepo.GetTasksForExecution(ctx, s.config.WarmingConfig.MaxConcurrentTasks)
+	tasks, err := s.taskRepo.GetTasksForExecution(ctx, s.config.MaxConcurrentTasks())
 	if err != nil {
 		s.logger.Error("Failed to get tasks for execution: %v", err)
 		return
 	}
 
+	grace := s.config.WarmingConfig.Scheduler.ActionTimeout
 	for _, task := range tasks {
-		// Publish execute action command
-		command := map[string]interface{}{
-			"task_id":    task.ID.Hex(),
-			"account_id": task.AccountID.Hex(),
-			"platform":   task.Platform,
-			"day":        task.CurrentDay,
+		if task.NextActionAt == nil || time.Since(*task.NextActionAt) < grace {
+			continue
 		}
 
-		commandJSON, _ := json.Marshal(command)
-		if err := s.messaging.Publish("warming.commands", "execute_action", commandJSON); err != nil {
-			s.logger.Error("Failed to publish execute action command: %v", err)
-		}
+		s.logger.Warn("Task %s is overdue since %s, re-publishing its action", task.ID.Hex(), task.NextActionAt.Format(time.RFC3339))
+		s.scheduleAction(task, *task.NextActionAt)
 	}
 }
 
@@ -460,18 +623,15 @@ func (s *warmingService) updateAccountStatus(ctx context.Context, accountID prim
 	// Update metrics
 	if s.metrics != nil {
 		if err != nil {
-			s.metrics.IncrementFailuresTotal(platform, "update_status")
-		} else {
-			s.metrics.IncrementSuccessTotal(platform, "update_status")
+			s.metrics.IncrementErrorsTotal(platform, "update_status")
 		}
 	}
 }
 
 func (s *warmingService) publishEvent(eventType, platform string, data map[string]interface{}) {
 	routingKey := fmt.Sprintf("%s.%s", eventType, platform)
-	eventData, _ := json.Marshal(data)
 
-	if err := s.messaging.Publish("warming.events", routingKey, eventData); err != nil {
+	if err := s.messaging.Publish("warming.events", routingKey, data); err != nil {
 		s.logger.Error("Failed to publish event %s: %v", eventType, err)
 	}
 }
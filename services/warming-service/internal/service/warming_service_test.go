@@ -3,14 +3,18 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
 
+	"github.com/grigta/conveer/pkg/logger"
+	"github.com/grigta/conveer/pkg/messaging"
+	"github.com/grigta/conveer/services/warming-service/internal/config"
 	"github.com/grigta/conveer/services/warming-service/internal/models"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -75,6 +79,37 @@ func (m *MockTaskRepository) List(ctx context.Context, filter models.TaskFilter)
 	return args.Get(0).([]*models.WarmingTask), args.Error(1)
 }
 
+func (m *MockTaskRepository) IncrementCounters(ctx context.Context, id primitive.ObjectID, completed, failed int) error {
+	args := m.Called(ctx, id, completed, failed)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetStuckTasks(ctx context.Context, stuckDuration time.Duration) ([]*models.WarmingTask, error) {
+	args := m.Called(ctx, stuckDuration)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WarmingTask), args.Error(1)
+}
+
+func (m *MockTaskRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Count(ctx context.Context, filter models.TaskFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTaskRepository) CountExperimentTasks(ctx context.Context, experimentID primitive.ObjectID) ([]models.ExperimentTaskCount, error) {
+	args := m.Called(ctx, experimentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ExperimentTaskCount), args.Error(1)
+}
+
 // MockScenarioRepository is a mock implementation of ScenarioRepository
 type MockScenarioRepository struct {
 	mock.Mock
@@ -117,11 +152,52 @@ func (m *MockScenarioRepository) List(ctx context.Context, platform string) ([]*
 	return args.Get(0).([]*models.WarmingScenario), args.Error(1)
 }
 
+func (m *MockScenarioRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockScenarioRepository) SetActive(ctx context.Context, id primitive.ObjectID, active bool) error {
+	args := m.Called(ctx, id, active)
+	return args.Error(0)
+}
+
 // MockStatsRepository is a mock implementation of StatsRepository
 type MockStatsRepository struct {
 	mock.Mock
 }
 
+func (m *MockStatsRepository) SaveActionLog(ctx context.Context, log *models.WarmingActionLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepository) GetActionLogs(ctx context.Context, taskID primitive.ObjectID, limit int) ([]*models.WarmingActionLog, error) {
+	args := m.Called(ctx, taskID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WarmingActionLog), args.Error(1)
+}
+
+func (m *MockStatsRepository) UpdateDailyStats(ctx context.Context, platform string, stats *models.WarmingStats) error {
+	args := m.Called(ctx, platform, stats)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepository) GetDailyStats(ctx context.Context, platform string, startDate, endDate time.Time) ([]*models.WarmingStats, error) {
+	args := m.Called(ctx, platform, startDate, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WarmingStats), args.Error(1)
+}
+
+func (m *MockStatsRepository) CleanupOldLogs(ctx context.Context, retentionDays int) error {
+	args := m.Called(ctx, retentionDays)
+	return args.Error(0)
+}
+
 func (m *MockStatsRepository) GetAggregatedStats(ctx context.Context, platform string, startDate, endDate time.Time) (*models.AggregatedStats, error) {
 	args := m.Called(ctx, platform, startDate, endDate)
 	if args.Get(0) == nil {
@@ -130,20 +206,20 @@ func (m *MockStatsRepository) GetAggregatedStats(ctx context.Context, platform s
 	return args.Get(0).(*models.AggregatedStats), args.Error(1)
 }
 
-func (m *MockStatsRepository) GetTopActions(ctx context.Context, platform string, limit int) ([]models.ActionStat, error) {
+func (m *MockStatsRepository) GetTopActions(ctx context.Context, platform string, limit int) ([]models.ActionStatistic, error) {
 	args := m.Called(ctx, platform, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.ActionStat), args.Error(1)
+	return args.Get(0).([]models.ActionStatistic), args.Error(1)
 }
 
-func (m *MockStatsRepository) GetCommonErrors(ctx context.Context, platform string, limit int) ([]models.ErrorStat, error) {
+func (m *MockStatsRepository) GetCommonErrors(ctx context.Context, platform string, limit int) ([]models.ErrorStatistic, error) {
 	args := m.Called(ctx, platform, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.ErrorStat), args.Error(1)
+	return args.Get(0).([]models.ErrorStatistic), args.Error(1)
 }
 
 func (m *MockStatsRepository) CountActionsByType(ctx context.Context, taskID primitive.ObjectID, actionType string, startTime, endTime time.Time) (int, error) {
@@ -151,35 +227,67 @@ func (m *MockStatsRepository) CountActionsByType(ctx context.Context, taskID pri
 	return args.Int(0), args.Error(1)
 }
 
-// MockMessaging is a mock implementation of RabbitMQClient
+func (m *MockStatsRepository) CountFailedActions(ctx context.Context, taskID primitive.ObjectID, errorTypes []string) (int, error) {
+	args := m.Called(ctx, taskID, errorTypes)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStatsRepository) SaveActionRollup(ctx context.Context, stats *models.WarmingStats) error {
+	args := m.Called(ctx, stats)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepository) AggregateActionLogs(ctx context.Context, platform string, startTime, endTime time.Time) (*models.WarmingStats, error) {
+	args := m.Called(ctx, platform, startTime, endTime)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WarmingStats), args.Error(1)
+}
+
+// MockMessaging is a mock implementation of the broker
 type MockMessaging struct {
 	mock.Mock
-	PublishedMessages []struct {
-		Exchange   string
-		RoutingKey string
-		Message    []byte
-	}
 }
 
-func (m *MockMessaging) Publish(exchange, routingKey string, message []byte) error {
-	m.PublishedMessages = append(m.PublishedMessages, struct {
-		Exchange   string
-		RoutingKey string
-		Message    []byte
-	}{exchange, routingKey, message})
+func (m *MockMessaging) Publish(exchange, routingKey string, message interface{}) error {
 	args := m.Called(exchange, routingKey, message)
 	return args.Error(0)
 }
 
+func (m *MockMessaging) PublishWithOptions(exchange, routingKey string, message interface{}, opts messaging.PublishOptions) error {
+	args := m.Called(exchange, routingKey, message, opts)
+	return args.Error(0)
+}
+
+func (m *MockMessaging) ConsumeWithHandler(ctx context.Context, queueName, consumerName string, handler func([]byte) error) error {
+	args := m.Called(ctx, queueName, consumerName, handler)
+	return args.Error(0)
+}
+
 // MockLogger is a mock implementation of Logger
 type MockLogger struct {
 	mock.Mock
 }
 
-func (m *MockLogger) Info(format string, args ...interface{})  {}
-func (m *MockLogger) Error(format string, args ...interface{}) {}
-func (m *MockLogger) Warn(format string, args ...interface{})  {}
-func (m *MockLogger) Debug(format string, args ...interface{}) {}
+func (m *MockLogger) Debug(msg string, args ...any)                         {}
+func (m *MockLogger) Info(msg string, args ...any)                          {}
+func (m *MockLogger) Warn(msg string, args ...any)                          {}
+func (m *MockLogger) Error(msg string, args ...any)                         {}
+func (m *MockLogger) Fatal(msg string, args ...any)                         {}
+func (m *MockLogger) Debugf(format string, args ...any)                     {}
+func (m *MockLogger) Infof(format string, args ...any)                      {}
+func (m *MockLogger) Warnf(format string, args ...any)                      {}
+func (m *MockLogger) Errorf(format string, args ...any)                     {}
+func (m *MockLogger) Fatalf(format string, args ...any)                     {}
+func (m *MockLogger) WithContext(ctx context.Context) logger.Logger         { return m }
+func (m *MockLogger) WithField(key string, value interface{}) logger.Logger { return m }
+func (m *MockLogger) WithFields(fields logger.Fields) logger.Logger         { return m }
+func (m *MockLogger) WithError(err error) logger.Logger                     { return m }
+func (m *MockLogger) Slog() *slog.Logger                                    { return slog.New(slog.NewTextHandler(io.Discard, nil)) }
+
+// testMetrics is shared by the suite, promauto registers the collectors only once per process
+var testMetrics = NewMetrics()
 
 // WarmingServiceTestSuite is the test suite for WarmingService
 type WarmingServiceTestSuite struct {
@@ -191,6 +299,7 @@ type WarmingServiceTestSuite struct {
 	statsRepo    *MockStatsRepository
 	messaging    *MockMessaging
 	logger       *MockLogger
+	service      *warmingService
 }
 
 func (s *WarmingServiceTestSuite) SetupTest() {
@@ -200,6 +309,30 @@ func (s *WarmingServiceTestSuite) SetupTest() {
 	s.statsRepo = new(MockStatsRepository)
 	s.messaging = new(MockMessaging)
 	s.logger = new(MockLogger)
+
+	cfg := &config.Config{
+		WarmingConfig: config.WarmingConfig{
+			BehaviorSimulation: config.BehaviorSimulationConfig{
+				EnableRandomDelays: true,
+				DelayMinSeconds:    30,
+				DelayMaxSeconds:    300,
+				ActiveHoursStart:   8,
+				ActiveHoursEnd:     22,
+			},
+		},
+	}
+
+	s.service = &warmingService{
+		taskRepo:     s.taskRepo,
+		scenarioRepo: s.scenarioRepo,
+		statsRepo:    s.statsRepo,
+		messaging:    s.messaging,
+		config:       cfg,
+		logger:       s.logger,
+		metrics:      testMetrics,
+	}
+	s.service.scheduler = NewScheduler(s.service, nil, s.statsRepo, cfg, s.logger)
+	s.service.capacity = NewCapacityModel(cfg.WarmingConfig.Capacity, s.service.scheduler)
 }
 
 func (s *WarmingServiceTestSuite) TearDownTest() {
@@ -214,15 +347,20 @@ func TestWarmingServiceTestSuite(t *testing.T) {
 func (s *WarmingServiceTestSuite) TestStartWarming_Success() {
 	accountID := primitive.NewObjectID()
 	platform := "vk"
-	scenarioType := "basic"
-	durationDays := 14
 
 	s.taskRepo.On("GetByAccountAndPlatform", s.ctx, accountID, platform).Return(nil, nil)
 	s.taskRepo.On("Create", s.ctx, mock.AnythingOfType("*models.WarmingTask")).Return(nil)
-	s.messaging.On("Publish", "warming.commands", "start", mock.AnythingOfType("[]uint8")).Return(nil)
+	s.messaging.On("Publish", "warming.commands", "start", mock.Anything).Return(nil)
+	s.messaging.On("Publish", "warming.events", "warming.task.started.vk", mock.Anything).Return(nil)
 
-	// Verify expectations
+	task, err := s.service.StartWarming(s.ctx, accountID, platform, "basic", nil, 14, nil)
+
+	s.Require().NoError(err)
+	s.Equal(accountID, task.AccountID)
+	s.Equal(string(models.TaskStatusScheduled), task.Status)
+	s.Equal(14, task.DurationDays)
 	s.taskRepo.AssertExpectations(s.T())
+	s.messaging.AssertExpectations(s.T())
 }
 
 // Test StartWarming - task already exists
@@ -239,8 +377,11 @@ func (s *WarmingServiceTestSuite) TestStartWarming_TaskAlreadyExists() {
 
 	s.taskRepo.On("GetByAccountAndPlatform", s.ctx, accountID, platform).Return(existingTask, nil)
 
-	// Should return error when task already exists
+	_, err := s.service.StartWarming(s.ctx, accountID, platform, "basic", nil, 14, nil)
+
+	s.Error(err)
 	s.taskRepo.AssertExpectations(s.T())
+	s.taskRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 }
 
 // Test StartWarming - invalid duration (too short)
@@ -248,8 +389,12 @@ func (s *WarmingServiceTestSuite) TestStartWarming_InvalidDurationTooShort() {
 	accountID := primitive.NewObjectID()
 	durationDays := 7 // Less than 14
 
-	// Duration should be between 14 and 60
-	s.True(durationDays < 14)
+	s.taskRepo.On("GetByAccountAndPlatform", s.ctx, accountID, "vk").Return(nil, nil)
+
+	_, err := s.service.StartWarming(s.ctx, accountID, "vk", "basic", nil, durationDays, nil)
+
+	s.ErrorContains(err, "invalid duration")
+	s.taskRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 }
 
 // Test StartWarming - invalid duration (too long)
@@ -257,8 +402,12 @@ func (s *WarmingServiceTestSuite) TestStartWarming_InvalidDurationTooLong() {
 	accountID := primitive.NewObjectID()
 	durationDays := 90 // More than 60
 
-	// Duration should be between 14 and 60
-	s.True(durationDays > 60)
+	s.taskRepo.On("GetByAccountAndPlatform", s.ctx, accountID, "vk").Return(nil, nil)
+
+	_, err := s.service.StartWarming(s.ctx, accountID, "vk", "basic", nil, durationDays, nil)
+
+	s.ErrorContains(err, "invalid duration")
+	s.taskRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 }
 
 // Test PauseWarming - successful pause
@@ -274,9 +423,14 @@ func (s *WarmingServiceTestSuite) TestPauseWarming_Success() {
 
 	s.taskRepo.On("GetByID", s.ctx, taskID).Return(task, nil)
 	s.taskRepo.On("UpdateStatus", s.ctx, taskID, string(models.TaskStatusPaused)).Return(nil)
-	s.messaging.On("Publish", "warming.events", mock.AnythingOfType("string"), mock.AnythingOfType("[]uint8")).Return(nil)
+	s.messaging.On("Publish", "warming.events", "warming.task.paused.vk", mock.Anything).Return(nil)
+
+	paused, err := s.service.PauseWarming(s.ctx, taskID)
 
+	s.Require().NoError(err)
+	s.Equal(string(models.TaskStatusPaused), paused.Status)
 	s.taskRepo.AssertExpectations(s.T())
+	s.messaging.AssertExpectations(s.T())
 }
 
 // Test PauseWarming - task not in progress
@@ -290,8 +444,11 @@ func (s *WarmingServiceTestSuite) TestPauseWarming_TaskNotInProgress() {
 
 	s.taskRepo.On("GetByID", s.ctx, taskID).Return(task, nil)
 
-	// Should return error when task is not in progress
+	_, err := s.service.PauseWarming(s.ctx, taskID)
+
+	s.Error(err)
 	s.taskRepo.AssertExpectations(s.T())
+	s.taskRepo.AssertNotCalled(s.T(), "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Test ResumeWarming - successful resume
@@ -309,9 +466,17 @@ func (s *WarmingServiceTestSuite) TestResumeWarming_Success() {
 
 	s.taskRepo.On("GetByID", s.ctx, taskID).Return(task, nil)
 	s.taskRepo.On("Update", s.ctx, taskID, mock.AnythingOfType("models.TaskUpdate")).Return(nil)
-	s.messaging.On("Publish", "warming.events", mock.AnythingOfType("string"), mock.AnythingOfType("[]uint8")).Return(nil)
+	s.messaging.On("PublishWithOptions", "warming.commands", "execute_action", mock.AnythingOfType("service.executeActionCommand"), mock.Anything).Return(nil)
+	s.messaging.On("Publish", "warming.events", "warming.task.resumed.vk", mock.Anything).Return(nil)
+
+	resumed, err := s.service.ResumeWarming(s.ctx, taskID)
 
+	s.Require().NoError(err)
+	s.Equal(string(models.TaskStatusInProgress), resumed.Status)
+	s.Require().NotNil(resumed.NextActionAt)
+	s.True(resumed.NextActionAt.After(time.Now().Add(-time.Minute)))
 	s.taskRepo.AssertExpectations(s.T())
+	s.messaging.AssertExpectations(s.T())
 }
 
 // Test ResumeWarming - task not paused
@@ -325,8 +490,11 @@ func (s *WarmingServiceTestSuite) TestResumeWarming_TaskNotPaused() {
 
 	s.taskRepo.On("GetByID", s.ctx, taskID).Return(task, nil)
 
-	// Should return error when task is not paused
+	_, err := s.service.ResumeWarming(s.ctx, taskID)
+
+	s.Error(err)
 	s.taskRepo.AssertExpectations(s.T())
+	s.taskRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Test StopWarming - successful stop
@@ -344,9 +512,15 @@ func (s *WarmingServiceTestSuite) TestStopWarming_Success() {
 
 	s.taskRepo.On("GetByID", s.ctx, taskID).Return(task, nil)
 	s.taskRepo.On("Update", s.ctx, taskID, mock.AnythingOfType("models.TaskUpdate")).Return(nil)
-	s.messaging.On("Publish", "warming.events", mock.AnythingOfType("string"), mock.AnythingOfType("[]uint8")).Return(nil)
+	s.messaging.On("Publish", "warming.events", "warming.task.completed.vk", mock.Anything).Return(nil)
 
+	stopped, err := s.service.StopWarming(s.ctx, taskID)
+
+	s.Require().NoError(err)
+	s.Equal(string(models.TaskStatusCompleted), stopped.Status)
+	s.NotNil(stopped.CompletedAt)
 	s.taskRepo.AssertExpectations(s.T())
+	s.messaging.AssertExpectations(s.T())
 }
 
 // Test GetWarmingStatus
@@ -365,75 +539,85 @@ func (s *WarmingServiceTestSuite) TestGetWarmingStatus_Success() {
 
 	s.taskRepo.On("GetByID", s.ctx, taskID).Return(task, nil)
 
+	status, err := s.service.GetWarmingStatus(s.ctx, taskID)
+
+	s.Require().NoError(err)
+	s.Equal(task, status)
 	s.taskRepo.AssertExpectations(s.T())
 }
 
 // Test GetWarmingStatistics
 func (s *WarmingServiceTestSuite) TestGetWarmingStatistics_Success() {
 	platform := "vk"
-	startDate := time.Now().AddDate(0, -1, 0)
-	endDate := time.Now()
-
-	stats := &models.AggregatedStats{
-		TotalTasks:       100,
-		CompletedTasks:   80,
-		FailedTasks:      5,
-		InProgressTasks:  15,
-		TotalActions:     5000,
-		SuccessfulActions: 4800,
-	}
-
-	topActions := []models.ActionStat{
-		{ActionType: "like_post", Count: 1000},
-		{ActionType: "view_feed", Count: 800},
-	}
-
-	commonErrors := []models.ErrorStat{
-		{ErrorType: "captcha", Count: 50},
-		{ErrorType: "timeout", Count: 30},
-	}
-
-	s.statsRepo.On("GetAggregatedStats", s.ctx, platform, startDate, endDate).Return(stats, nil)
-	s.statsRepo.On("GetTopActions", s.ctx, platform, 10).Return(topActions, nil)
-	s.statsRepo.On("GetCommonErrors", s.ctx, platform, 10).Return(commonErrors, nil)
-
+	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 0, 6)
+
+	daily := []*models.WarmingStats{
+		{
+			Platform:        platform,
+			Date:            startDate,
+			TotalTasks:      100,
+			CompletedTasks:  80,
+			FailedTasks:     5,
+			InProgressTasks: 15,
+			TotalActions:    5000,
+			ByActionType:    map[string]int64{"like_post": 1000, "view_feed": 800},
+			ErrorTypes:      map[string]int64{"captcha": 50, "timeout": 30},
+		},
+	}
+
+	// The range lies in the past, so no live aggregation of today's action logs
+	s.statsRepo.On("GetDailyStats", s.ctx, platform, startDate, endDate).Return(daily, nil)
+
+	stats, err := s.service.GetWarmingStatistics(s.ctx, platform, startDate, endDate)
+
+	s.Require().NoError(err)
+	s.Equal(int64(100), stats.TotalTasks)
+	s.Equal(int64(80), stats.CompletedTasks)
+	s.Require().NotEmpty(stats.TopActions)
+	s.Equal("like_post", stats.TopActions[0].ActionType)
+	s.Require().NotEmpty(stats.CommonErrors)
+	s.Equal("captcha", stats.CommonErrors[0].ErrorType)
 	s.statsRepo.AssertExpectations(s.T())
+	s.statsRepo.AssertNotCalled(s.T(), "AggregateActionLogs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Test CreateCustomScenario - successful creation
 func (s *WarmingServiceTestSuite) TestCreateCustomScenario_Success() {
-	scenario := &models.WarmingScenario{
-		Name:        "custom-scenario",
-		Platform:    "vk",
-		Description: "Custom warming scenario",
-	}
+	scenario := testScenario()
+	scenario.Name = "custom-scenario"
+	scenario.Description = "Custom warming scenario"
 
 	s.scenarioRepo.On("GetByName", s.ctx, "vk", "custom-scenario").Return(nil, nil)
 	s.scenarioRepo.On("Create", s.ctx, scenario).Return(nil)
 
+	created, err := s.service.CreateCustomScenario(s.ctx, scenario)
+
+	s.Require().NoError(err)
+	s.Equal("custom-scenario", created.Name)
 	s.scenarioRepo.AssertExpectations(s.T())
 }
 
 // Test CreateCustomScenario - missing required fields
 func (s *WarmingServiceTestSuite) TestCreateCustomScenario_MissingName() {
-	scenario := &models.WarmingScenario{
-		Name:     "",
-		Platform: "vk",
-	}
+	scenario := testScenario()
+	scenario.Name = ""
 
-	// Should return error when name is missing
-	s.Empty(scenario.Name)
+	_, err := s.service.CreateCustomScenario(s.ctx, scenario)
+
+	s.ErrorIs(err, ErrInvalidScenario)
+	s.scenarioRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 }
 
 // Test CreateCustomScenario - missing platform
 func (s *WarmingServiceTestSuite) TestCreateCustomScenario_MissingPlatform() {
-	scenario := &models.WarmingScenario{
-		Name:     "custom-scenario",
-		Platform: "",
-	}
+	scenario := testScenario()
+	scenario.Platform = ""
+
+	_, err := s.service.CreateCustomScenario(s.ctx, scenario)
 
-	// Should return error when platform is missing
-	s.Empty(scenario.Platform)
+	s.ErrorIs(err, ErrInvalidScenario)
+	s.scenarioRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 }
 
 // Test CreateCustomScenario - duplicate name
@@ -444,31 +628,40 @@ func (s *WarmingServiceTestSuite) TestCreateCustomScenario_DuplicateName() {
 		Platform: "vk",
 	}
 
+	scenario := testScenario()
+	scenario.Name = "custom-scenario"
+
 	s.scenarioRepo.On("GetByName", s.ctx, "vk", "custom-scenario").Return(existingScenario, nil)
 
-	// Should return error when scenario with same name exists
+	_, err := s.service.CreateCustomScenario(s.ctx, scenario)
+
+	s.ErrorContains(err, "already exists")
 	s.scenarioRepo.AssertExpectations(s.T())
+	s.scenarioRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 }
 
 // Test UpdateCustomScenario
 func (s *WarmingServiceTestSuite) TestUpdateCustomScenario_Success() {
 	scenarioID := primitive.NewObjectID()
 
-	existingScenario := &models.WarmingScenario{
-		ID:          scenarioID,
-		Name:        "existing-scenario",
-		Platform:    "vk",
-		Description: "Original description",
-	}
+	existingScenario := testScenario()
+	existingScenario.ID = scenarioID
+	existingScenario.Name = "existing-scenario"
+	existingScenario.Description = "Original description"
 
-	updatedScenario := &models.WarmingScenario{
-		Name:        "updated-scenario",
-		Description: "Updated description",
-	}
+	updatedScenario := testScenario()
+	updatedScenario.Name = "updated-scenario"
+	updatedScenario.Description = "Updated description"
 
 	s.scenarioRepo.On("GetByID", s.ctx, scenarioID).Return(existingScenario, nil)
 	s.scenarioRepo.On("Update", s.ctx, scenarioID, mock.AnythingOfType("*models.WarmingScenario")).Return(nil)
 
+	result, err := s.service.UpdateCustomScenario(s.ctx, scenarioID, updatedScenario)
+
+	s.Require().NoError(err)
+	s.Equal(scenarioID, result.ID)
+	s.Equal("updated-scenario", result.Name)
+	s.Equal("Updated description", result.Description)
 	s.scenarioRepo.AssertExpectations(s.T())
 }
 
@@ -484,6 +677,10 @@ func (s *WarmingServiceTestSuite) TestListScenarios_Success() {
 
 	s.scenarioRepo.On("List", s.ctx, platform).Return(scenarios, nil)
 
+	result, err := s.service.ListScenarios(s.ctx, platform)
+
+	s.Require().NoError(err)
+	s.Len(result, 3)
 	s.scenarioRepo.AssertExpectations(s.T())
 }
 
@@ -501,6 +698,10 @@ func (s *WarmingServiceTestSuite) TestListTasks_Success() {
 
 	s.taskRepo.On("List", s.ctx, filter).Return(tasks, nil)
 
+	result, err := s.service.ListTasks(s.ctx, filter)
+
+	s.Require().NoError(err)
+	s.Equal(tasks, result)
 	s.taskRepo.AssertExpectations(s.T())
 }
 
@@ -508,8 +709,8 @@ func (s *WarmingServiceTestSuite) TestListTasks_Success() {
 func TestTaskStatusTransitions(t *testing.T) {
 	tests := []struct {
 		name        string
-		fromStatus  models.TaskStatus
-		toStatus    models.TaskStatus
+		fromStatus  models.WarmingTaskStatus
+		toStatus    models.WarmingTaskStatus
 		shouldAllow bool
 	}{
 		{"scheduled to in_progress", models.TaskStatusScheduled, models.TaskStatusInProgress, true},
@@ -570,26 +771,19 @@ func TestWarmingScenarioModel(t *testing.T) {
 // Test AggregatedStats model
 func TestAggregatedStatsModel(t *testing.T) {
 	stats := &models.AggregatedStats{
-		TotalTasks:        100,
-		CompletedTasks:    80,
-		FailedTasks:       5,
-		InProgressTasks:   15,
-		TotalActions:      5000,
-		SuccessfulActions: 4800,
-		FailedActions:     200,
-	}
-
-	assert.Equal(t, 100, stats.TotalTasks)
-	assert.Equal(t, 80, stats.CompletedTasks)
-	assert.Equal(t, 5, stats.FailedTasks)
-	assert.Equal(t, 15, stats.InProgressTasks)
-	assert.Equal(t, 5000, stats.TotalActions)
-	assert.Equal(t, 4800, stats.SuccessfulActions)
-	assert.Equal(t, 200, stats.FailedActions)
+		TotalTasks:      100,
+		CompletedTasks:  80,
+		FailedTasks:     5,
+		InProgressTasks: 15,
+	}
+
+	assert.Equal(t, int64(100), stats.TotalTasks)
+	assert.Equal(t, int64(80), stats.CompletedTasks)
+	assert.Equal(t, int64(5), stats.FailedTasks)
+	assert.Equal(t, int64(15), stats.InProgressTasks)
 
 	// Verify consistency
 	assert.Equal(t, stats.TotalTasks, stats.CompletedTasks+stats.FailedTasks+stats.InProgressTasks)
-	assert.Equal(t, stats.TotalActions, stats.SuccessfulActions+stats.FailedActions)
 }
 
 // Benchmark tests
@@ -613,12 +807,10 @@ func BenchmarkWarmingTaskJSON(b *testing.B) {
 
 func BenchmarkAggregatedStatsJSON(b *testing.B) {
 	stats := &models.AggregatedStats{
-		TotalTasks:        100,
-		CompletedTasks:    80,
-		FailedTasks:       5,
-		InProgressTasks:   15,
-		TotalActions:      5000,
-		SuccessfulActions: 4800,
+		TotalTasks:      100,
+		CompletedTasks:  80,
+		FailedTasks:     5,
+		InProgressTasks: 15,
 	}
 
 	b.ResetTimer()
@@ -626,4 +818,3 @@ func BenchmarkAggregatedStatsJSON(b *testing.B) {
 		_, _ = json.Marshal(stats)
 	}
 }
-
@@ -3,23 +3,29 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/grigta/conveer/pkg/messaging"
 	"github.com/grigta/conveer/services/warming-service/internal/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// executeActionCommand is published for every planned action. ScheduledAt is the planned time in
+// unix milliseconds, it lets the executor drop commands of a plan that was replaced since.
+type executeActionCommand struct {
+	TaskID      string `json:"task_id"`
+	AccountID   string `json:"account_id"`
+	Platform    string `json:"platform"`
+	Day         int    `json:"day"`
+	ScheduledAt int64  `json:"scheduled_at"`
+}
+
 func (s *warmingService) runActionExecutorWorker(ctx context.Context) {
 	// Consumer for execute_action commands
-	err := s.messaging.ConsumeQueue(ctx, "warming.execute_action", func(msg []byte) error {
-		var command struct {
-			TaskID    string `json:"task_id"`
-			AccountID string `json:"account_id"`
-			Platform  string `json:"platform"`
-			Day       int    `json:"day"`
-		}
-
+	err := s.messaging.ConsumeWithHandler(ctx, "warming.execute_action", "warming-action-executor", func(msg []byte) error {
+		var command executeActionCommand
 		if err := json.Unmarshal(msg, &command); err != nil {
 			return fmt.Errorf("failed to unmarshal command: %w", err)
 		}
@@ -28,7 +34,7 @@ func (s *warmingService) runActionExecutorWorker(ctx context.Context) {
 		accountID, _ := primitive.ObjectIDFromHex(command.AccountID)
 
 		// Execute action
-		return s.executeTaskAction(ctx, taskID, accountID, command.Platform, command.Day)
+		return s.executeTaskAction(ctx, taskID, accountID, command.Platform, command.Day, command.ScheduledAt)
 	})
 
 	if err != nil {
@@ -36,7 +42,24 @@ func (s *warmingService) runActionExecutorWorker(ctx context.Context) {
 	}
 }
 
-func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountID primitive.ObjectID, platform string, day int) error {
+// scheduleAction publishes the execute_action command for nextTime. RabbitMQ holds it back until
+// then, so no in-process timer is needed.
+func (s *warmingService) scheduleAction(task *models.WarmingTask, nextTime time.Time) {
+	command := executeActionCommand{
+		TaskID:      task.ID.Hex(),
+		AccountID:   task.AccountID.Hex(),
+		Platform:    task.Platform,
+		Day:         task.CurrentDay,
+		ScheduledAt: nextTime.UnixMilli(),
+	}
+
+	opts := messaging.PublishOptions{Delay: time.Until(nextTime)}
+	if err := s.messaging.PublishWithOptions("warming.commands", "execute_action", command, opts); err != nil {
+		s.logger.Error("Failed to schedule action for task %s: %v", task.ID.Hex(), err)
+	}
+}
+
+func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountID primitive.ObjectID, platform string, day int, scheduledAt int64) error {
 	// Get task details
 	task, err := s.taskRepo.GetByID(ctx, taskID)
 	if err != nil {
@@ -49,6 +72,17 @@ func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountI
 		return nil
 	}
 
+	// The task was rescheduled after this command had been published
+	if scheduledAt != 0 && task.NextActionAt != nil && task.NextActionAt.UnixMilli() != scheduledAt {
+		s.logger.Info("Skipping stale action command for task %s", taskID.Hex())
+		return nil
+	}
+
+	// Accounts back from quarantine are re-checked before they are allowed to act
+	if task.ScenarioType == string(models.ScenarioResurrection) && !s.runResurrectionCheckpoint(ctx, task) {
+		return nil
+	}
+
 	// Get scenario configuration
 	scenarioConfig := s.scheduler.getScenarioConfig(task)
 	if scenarioConfig == nil {
@@ -70,8 +104,12 @@ func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountI
 	if s.scheduler.ShouldSkipAction(time.Now()) {
 		s.logger.Info("Skipping action due to behavior simulation")
 		// Schedule next action
-		nextTime := s.scheduler.CalculateNextActionTime(time.Now(), task.CurrentDay, task.DurationDays)
-		return s.taskRepo.UpdateNextActionTime(ctx, taskID, nextTime)
+		nextTime := s.scheduler.CalculateNextActionTimeForTask(task, time.Now())
+		if err := s.taskRepo.UpdateNextActionTime(ctx, taskID, nextTime); err != nil {
+			return err
+		}
+		s.scheduleAction(task, nextTime)
+		return nil
 	}
 
 	// Get today's action count
@@ -92,17 +130,17 @@ func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountI
 		TotalDays:    task.DurationDays,
 		ActionsToday: actionsToday,
 	}
-
-	// Get platform executor
-	executor, ok := s.platformExecs[platform]
-	if !ok {
-		return fmt.Errorf("executor not found for platform %s", platform)
+	execCtx.CaptureEvidence = s.sampleForEvidence()
+	if niche, ok := task.Metadata["niche"].(string); ok {
+		execCtx.Niche = niche
 	}
 
-	// Execute action
+	// Execute action with its plugin, built-in actions run on the platform executor
 	start := time.Now()
-	err = executor.ExecuteAction(ctx, task, actionType, execCtx)
-	duration := time.Since(start).Milliseconds()
+	err = s.actions.Execute(ctx, platform, task, actionType, execCtx)
+	elapsed := time.Since(start)
+	duration := elapsed.Milliseconds()
+	s.capacity.ObserveAction(platform, elapsed)
 
 	// Log action
 	actionLog := &models.WarmingActionLog{
@@ -113,7 +151,9 @@ func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountI
 		Day:        day,
 		DurationMs: duration,
 		Timestamp:  time.Now(),
+		Attempts:   execCtx.Attempts,
 	}
+	actionLog.PlatformLatencyMs = execCtx.PlatformLatencyMs
 
 	if err != nil {
 		actionLog.Status = "failed"
@@ -121,7 +161,9 @@ func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountI
 		actionLog.ErrorType = categorizeError(err)
 
 		// Check if error requires special handling
-		if actionErr, ok := err.(*ActionExecutionError); ok {
+		var actionErr *ActionExecutionError
+		if errors.As(err, &actionErr) {
+			actionLog.ErrorType = actionErr.Type
 			if actionErr.ShouldPause {
 				s.pauseTask(ctx, taskID, actionErr.Message)
 			} else if actionErr.ShouldStop {
@@ -138,6 +180,10 @@ func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountI
 		// Increment completed counter
 		s.taskRepo.IncrementCounters(ctx, taskID, 1, 0)
 		s.metrics.IncrementActionsTotal(platform, actionType, "success")
+
+		if execCtx.CaptureEvidence {
+			s.recordEvidence(ctx, task, actionType, execCtx)
+		}
 	}
 
 	// Save action log
@@ -151,17 +197,21 @@ func (s *warmingService) executeTaskAction(ctx context.Context, taskID, accountI
 	}
 
 	// Schedule next action
-	nextTime := s.scheduler.CalculateNextActionTime(time.Now(), task.CurrentDay, task.DurationDays)
+	nextTime := s.scheduler.CalculateNextActionTimeForTask(task, time.Now())
 	if err := s.taskRepo.UpdateNextActionTime(ctx, taskID, nextTime); err != nil {
 		s.logger.Error("Failed to update next action time: %v", err)
+	} else {
+		s.scheduleAction(task, nextTime)
 	}
 
 	// Publish action executed event
 	s.publishEvent("warming.action.executed", platform, map[string]interface{}{
-		"task_id":     taskID.Hex(),
-		"action_type": actionType,
-		"status":      actionLog.Status,
-		"duration_ms": duration,
+		"task_id":             taskID.Hex(),
+		"action_type":         actionType,
+		"status":              actionLog.Status,
+		"duration_ms":         duration,
+		"attempts":            execCtx.Attempts,
+		"platform_latency_ms": execCtx.PlatformLatencyMs,
 	})
 
 	return nil
@@ -238,7 +288,7 @@ func (s *warmingService) handleStuckTasks(ctx context.Context) {
 		s.logger.Warn("Found stuck task %s, attempting recovery", task.ID.Hex())
 
 		// Try to resume task
-		nextTime := s.scheduler.CalculateNextActionTime(time.Now(), task.CurrentDay, task.DurationDays)
+		nextTime := s.scheduler.CalculateNextActionTimeForTask(task, time.Now())
 		update := models.TaskUpdate{
 			Status:       stringPtr(string(models.TaskStatusInProgress)),
 			NextActionAt: &nextTime,
@@ -251,6 +301,7 @@ func (s *warmingService) handleStuckTasks(ctx context.Context) {
 			// Mark as failed if recovery fails
 			s.taskRepo.UpdateStatus(ctx, task.ID, string(models.TaskStatusFailed))
 		} else {
+			s.scheduleAction(task, nextTime)
 			s.logger.Info("Successfully recovered stuck task %s", task.ID.Hex())
 		}
 	}
@@ -258,7 +309,7 @@ func (s *warmingService) handleStuckTasks(ctx context.Context) {
 
 func (s *warmingService) runAutoStartConsumer(ctx context.Context) {
 	// Consumer for account creation events
-	err := s.messaging.ConsumeQueue(ctx, "warming.auto_start", func(msg []byte) error {
+	err := s.messaging.ConsumeWithHandler(ctx, "warming.auto_start", "warming-auto-start", func(msg []byte) error {
 		var event struct {
 			AccountID string `json:"account_id"`
 			Platform  string `json:"platform"`
@@ -270,8 +321,15 @@ func (s *warmingService) runAutoStartConsumer(ctx context.Context) {
 
 		accountID, _ := primitive.ObjectIDFromHex(event.AccountID)
 
-		// Auto-start warming with basic scenario (14-30 days)
-		task, err := s.StartWarming(ctx, accountID, event.Platform, "basic", nil, 21)
+		// Auto-start warming with basic scenario (14-30 days) unless a running experiment assigns a variant
+		scenarioType, durationDays := "basic", 21
+		var scenarioID *primitive.ObjectID
+		variant, assignment := s.assignExperiment(ctx, accountID, event.Platform)
+		if variant != nil {
+			scenarioType, scenarioID, durationDays = variant.ScenarioType, variant.ScenarioID, variant.DurationDays
+		}
+
+		task, err := s.startWarming(ctx, accountID, event.Platform, scenarioType, scenarioID, durationDays, assignment, nil)
 		if err != nil {
 			s.logger.Error("Failed to auto-start warming for account %s: %v", event.AccountID, err)
 			return err
@@ -292,6 +350,9 @@ func (s *warmingService) runStatsAggregator(ctx context.Context) {
 	ticker := time.NewTicker(1 * time.Hour)
 	defer ticker.Stop()
 
+	// Catch up on days that ended while the service was down
+	s.rollupDailyStats(ctx)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -303,9 +364,7 @@ func (s *warmingService) runStatsAggregator(ctx context.Context) {
 }
 
 func (s *warmingService) aggregateStats(ctx context.Context) {
-	platforms := []string{"vk", "telegram", "mail", "max"}
-
-	for _, platform := range platforms {
+	for _, platform := range statsPlatforms {
 		// Count tasks by status
 		stats := &models.WarmingStats{
 			Platform: platform,
@@ -338,8 +397,11 @@ func (s *warmingService) aggregateStats(ctx context.Context) {
 		}
 	}
 
-	// Cleanup old logs (older than 90 days)
-	if err := s.statsRepo.CleanupOldLogs(ctx, 90); err != nil {
+	s.rollupDailyStats(ctx)
+	s.updateVerificationRates(ctx)
+
+	// Cleanup old logs, finished days are already rolled up
+	if err := s.statsRepo.CleanupOldLogs(ctx, actionLogRetentionDays); err != nil {
 		s.logger.Error("Failed to cleanup old logs: %v", err)
 	}
 }
@@ -351,11 +413,21 @@ func (s *warmingService) updateTaskProgress(ctx context.Context, task *models.Wa
 		// New day, increment current day
 		currentDay := task.CurrentDay + 1
 
+		s.notifyMilestone(task, models.MilestoneDayCompleted, currentDay, "")
+		if crossedHalfway(task.CurrentDay, currentDay, task.DurationDays) {
+			s.notifyMilestone(task, models.MilestoneHalfway, currentDay, "")
+		}
+
 		// Check if warming is complete
 		if currentDay >= task.DurationDays {
 			return s.completeTask(ctx, task.ID)
 		}
 
+		// Accounts that are ready early stop warming
+		if s.graduateIfReady(ctx, task, currentDay) {
+			return nil
+		}
+
 		update := models.TaskUpdate{
 			CurrentDay: &currentDay,
 		}
@@ -409,6 +481,8 @@ func (s *warmingService) stopTask(ctx context.Context, taskID primitive.ObjectID
 		"reason":  reason,
 	})
 
+	s.notifyMilestoneByID(ctx, taskID, models.MilestoneFailed, reason)
+
 	return nil
 }
 
@@ -432,17 +506,27 @@ func (s *warmingService) completeTask(ctx context.Context, taskID primitive.Obje
 	s.updateAccountStatus(ctx, task.AccountID, task.Platform, "ready")
 
 	// Publish completion event
-	s.publishEvent("warming.task.completed", task.Platform, map[string]interface{}{
+	completed := map[string]interface{}{
 		"task_id":           taskID.Hex(),
 		"account_id":        task.AccountID.Hex(),
 		"duration_days":     task.DurationDays,
 		"actions_completed": task.ActionsCompleted,
-	})
+	}
+	ready := map[string]interface{}{
+		"account_id": task.AccountID.Hex(),
+	}
+	if task.Readiness != nil && task.Readiness.Ready {
+		completed["days_warmed"] = task.Readiness.Day
+		completed["readiness_score"] = task.Readiness.Score
+		ready["readiness_score"] = task.Readiness.Score
+		ready["graduated"] = true
+	}
+	s.publishEvent("warming.task.completed", task.Platform, completed)
 
 	// Publish account ready event
-	s.publishEvent("warming.account.ready", task.Platform, map[string]interface{}{
-		"account_id": task.AccountID.Hex(),
-	})
+	s.publishEvent("warming.account.ready", task.Platform, ready)
+
+	s.notifyMilestone(task, models.MilestoneCompleted, task.CurrentDay, "")
 
 	s.metrics.IncrementAccountsReady(task.Platform)
 
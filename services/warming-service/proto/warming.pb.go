@@ -23,14 +23,16 @@ const (
 )
 
 type StartWarmingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`                              // "vk", "telegram", "mail", "max"
-	ScenarioType  string                 `protobuf:"bytes,3,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"`  // "basic", "advanced", "custom"
-	ScenarioId    string                 `protobuf:"bytes,4,opt,name=scenario_id,json=scenarioId,proto3" json:"scenario_id,omitempty"`        // optional, for custom scenarios
-	DurationDays  int32                  `protobuf:"varint,5,opt,name=duration_days,json=durationDays,proto3" json:"duration_days,omitempty"` // 14-30 or 30-60
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Platform       string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`                                   // "vk", "telegram", "mail", "max"
+	ScenarioType   string                 `protobuf:"bytes,3,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"`       // "basic", "advanced", "custom"
+	ScenarioId     string                 `protobuf:"bytes,4,opt,name=scenario_id,json=scenarioId,proto3" json:"scenario_id,omitempty"`             // optional, for custom scenarios
+	DurationDays   int32                  `protobuf:"varint,5,opt,name=duration_days,json=durationDays,proto3" json:"duration_days,omitempty"`      // 14-30 or 30-60
+	CallbackUrls   []string               `protobuf:"bytes,6,rep,name=callback_urls,json=callbackUrls,proto3" json:"callback_urls,omitempty"`       // optional, milestone webhooks of the task
+	CallbackSecret string                 `protobuf:"bytes,7,opt,name=callback_secret,json=callbackSecret,proto3" json:"callback_secret,omitempty"` // optional, signs the webhooks instead of the service secret
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *StartWarmingRequest) Reset() {
@@ -98,6 +100,20 @@ func (x *StartWarmingRequest) GetDurationDays() int32 {
 	return 0
 }
 
+func (x *StartWarmingRequest) GetCallbackUrls() []string {
+	if x != nil {
+		return x.CallbackUrls
+	}
+	return nil
+}
+
+func (x *StartWarmingRequest) GetCallbackSecret() string {
+	if x != nil {
+		return x.CallbackSecret
+	}
+	return ""
+}
+
 type TaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -159,6 +175,9 @@ type WarmingTask struct {
 	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	CompletedAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Calendar         *ActivityCalendar      `protobuf:"bytes,16,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	ReadinessScore   float64                `protobuf:"fixed64,17,opt,name=readiness_score,json=readinessScore,proto3" json:"readiness_score,omitempty"` // latest daily readiness score 0-100, 0 before the first evaluation
+	CallbackUrls     []string               `protobuf:"bytes,18,rep,name=callback_urls,json=callbackUrls,proto3" json:"callback_urls,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -298,6 +317,27 @@ func (x *WarmingTask) GetCompletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *WarmingTask) GetCalendar() *ActivityCalendar {
+	if x != nil {
+		return x.Calendar
+	}
+	return nil
+}
+
+func (x *WarmingTask) GetReadinessScore() float64 {
+	if x != nil {
+		return x.ReadinessScore
+	}
+	return 0
+}
+
+func (x *WarmingTask) GetCallbackUrls() []string {
+	if x != nil {
+		return x.CallbackUrls
+	}
+	return nil
+}
+
 type StatisticsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
@@ -946,27 +986,31 @@ func (x *WarmingScenario) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type ListScenariosRequest struct {
+type ValidateScenarioRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	ActionsJson   string                 `protobuf:"bytes,3,opt,name=actions_json,json=actionsJson,proto3" json:"actions_json,omitempty"`
+	ScheduleJson  string                 `protobuf:"bytes,4,opt,name=schedule_json,json=scheduleJson,proto3" json:"schedule_json,omitempty"`
+	DurationDays  int32                  `protobuf:"varint,5,opt,name=duration_days,json=durationDays,proto3" json:"duration_days,omitempty"` // timeline length to simulate, 14-60
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListScenariosRequest) Reset() {
-	*x = ListScenariosRequest{}
+func (x *ValidateScenarioRequest) Reset() {
+	*x = ValidateScenarioRequest{}
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListScenariosRequest) String() string {
+func (x *ValidateScenarioRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListScenariosRequest) ProtoMessage() {}
+func (*ValidateScenarioRequest) ProtoMessage() {}
 
-func (x *ListScenariosRequest) ProtoReflect() protoreflect.Message {
+func (x *ValidateScenarioRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -978,39 +1022,74 @@ func (x *ListScenariosRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListScenariosRequest.ProtoReflect.Descriptor instead.
-func (*ListScenariosRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateScenarioRequest.ProtoReflect.Descriptor instead.
+func (*ValidateScenarioRequest) Descriptor() ([]byte, []int) {
 	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ListScenariosRequest) GetPlatform() string {
+func (x *ValidateScenarioRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ValidateScenarioRequest) GetPlatform() string {
 	if x != nil {
 		return x.Platform
 	}
 	return ""
 }
 
-type ListScenariosResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Scenarios     []*WarmingScenario     `protobuf:"bytes,1,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ValidateScenarioRequest) GetActionsJson() string {
+	if x != nil {
+		return x.ActionsJson
+	}
+	return ""
 }
 
-func (x *ListScenariosResponse) Reset() {
-	*x = ListScenariosResponse{}
+func (x *ValidateScenarioRequest) GetScheduleJson() string {
+	if x != nil {
+		return x.ScheduleJson
+	}
+	return ""
+}
+
+func (x *ValidateScenarioRequest) GetDurationDays() int32 {
+	if x != nil {
+		return x.DurationDays
+	}
+	return 0
+}
+
+type ScenarioTimelineStage struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Stage            string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"` // "days_1_7", "days_8_14", "days_15_30", "days_31_60"
+	FromDay          int32                  `protobuf:"varint,2,opt,name=from_day,json=fromDay,proto3" json:"from_day,omitempty"`
+	ToDay            int32                  `protobuf:"varint,3,opt,name=to_day,json=toDay,proto3" json:"to_day,omitempty"`
+	MinActionsPerDay int32                  `protobuf:"varint,4,opt,name=min_actions_per_day,json=minActionsPerDay,proto3" json:"min_actions_per_day,omitempty"`
+	MaxActionsPerDay int32                  `protobuf:"varint,5,opt,name=max_actions_per_day,json=maxActionsPerDay,proto3" json:"max_actions_per_day,omitempty"`
+	MinActions       int32                  `protobuf:"varint,6,opt,name=min_actions,json=minActions,proto3" json:"min_actions,omitempty"`
+	MaxActions       int32                  `protobuf:"varint,7,opt,name=max_actions,json=maxActions,proto3" json:"max_actions,omitempty"`
+	ActionShares     map[string]float64     `protobuf:"bytes,8,rep,name=action_shares,json=actionShares,proto3" json:"action_shares,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // action type -> share of the stage's actions
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ScenarioTimelineStage) Reset() {
+	*x = ScenarioTimelineStage{}
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListScenariosResponse) String() string {
+func (x *ScenarioTimelineStage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListScenariosResponse) ProtoMessage() {}
+func (*ScenarioTimelineStage) ProtoMessage() {}
 
-func (x *ListScenariosResponse) ProtoReflect() protoreflect.Message {
+func (x *ScenarioTimelineStage) ProtoReflect() protoreflect.Message {
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1022,43 +1101,93 @@ func (x *ListScenariosResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListScenariosResponse.ProtoReflect.Descriptor instead.
-func (*ListScenariosResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScenarioTimelineStage.ProtoReflect.Descriptor instead.
+func (*ScenarioTimelineStage) Descriptor() ([]byte, []int) {
 	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ListScenariosResponse) GetScenarios() []*WarmingScenario {
+func (x *ScenarioTimelineStage) GetStage() string {
 	if x != nil {
-		return x.Scenarios
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *ScenarioTimelineStage) GetFromDay() int32 {
+	if x != nil {
+		return x.FromDay
+	}
+	return 0
+}
+
+func (x *ScenarioTimelineStage) GetToDay() int32 {
+	if x != nil {
+		return x.ToDay
+	}
+	return 0
+}
+
+func (x *ScenarioTimelineStage) GetMinActionsPerDay() int32 {
+	if x != nil {
+		return x.MinActionsPerDay
+	}
+	return 0
+}
+
+func (x *ScenarioTimelineStage) GetMaxActionsPerDay() int32 {
+	if x != nil {
+		return x.MaxActionsPerDay
+	}
+	return 0
+}
+
+func (x *ScenarioTimelineStage) GetMinActions() int32 {
+	if x != nil {
+		return x.MinActions
+	}
+	return 0
+}
+
+func (x *ScenarioTimelineStage) GetMaxActions() int32 {
+	if x != nil {
+		return x.MaxActions
+	}
+	return 0
+}
+
+func (x *ScenarioTimelineStage) GetActionShares() map[string]float64 {
+	if x != nil {
+		return x.ActionShares
 	}
 	return nil
 }
 
-type ListTasksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ValidateScenarioResponse struct {
+	state           protoimpl.MessageState   `protogen:"open.v1"`
+	Valid           bool                     `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors          []string                 `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	DurationDays    int32                    `protobuf:"varint,3,opt,name=duration_days,json=durationDays,proto3" json:"duration_days,omitempty"`
+	Stages          []*ScenarioTimelineStage `protobuf:"bytes,4,rep,name=stages,proto3" json:"stages,omitempty"`
+	TotalMinActions int32                    `protobuf:"varint,5,opt,name=total_min_actions,json=totalMinActions,proto3" json:"total_min_actions,omitempty"`
+	TotalMaxActions int32                    `protobuf:"varint,6,opt,name=total_max_actions,json=totalMaxActions,proto3" json:"total_max_actions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ListTasksRequest) Reset() {
-	*x = ListTasksRequest{}
+func (x *ValidateScenarioResponse) Reset() {
+	*x = ValidateScenarioResponse{}
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksRequest) String() string {
+func (x *ValidateScenarioResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksRequest) ProtoMessage() {}
+func (*ValidateScenarioResponse) ProtoMessage() {}
 
-func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+func (x *ValidateScenarioResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1070,68 +1199,81 @@ func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
-func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateScenarioResponse.ProtoReflect.Descriptor instead.
+func (*ValidateScenarioResponse) Descriptor() ([]byte, []int) {
 	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListTasksRequest) GetPlatform() string {
+func (x *ValidateScenarioResponse) GetValid() bool {
 	if x != nil {
-		return x.Platform
+		return x.Valid
 	}
-	return ""
+	return false
 }
 
-func (x *ListTasksRequest) GetStatus() string {
+func (x *ValidateScenarioResponse) GetErrors() []string {
 	if x != nil {
-		return x.Status
+		return x.Errors
 	}
-	return ""
+	return nil
 }
 
-func (x *ListTasksRequest) GetAccountId() string {
+func (x *ValidateScenarioResponse) GetDurationDays() int32 {
 	if x != nil {
-		return x.AccountId
+		return x.DurationDays
 	}
-	return ""
+	return 0
 }
 
-func (x *ListTasksRequest) GetLimit() int32 {
+func (x *ValidateScenarioResponse) GetStages() []*ScenarioTimelineStage {
 	if x != nil {
-		return x.Limit
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *ValidateScenarioResponse) GetTotalMinActions() int32 {
+	if x != nil {
+		return x.TotalMinActions
 	}
 	return 0
 }
 
-func (x *ListTasksRequest) GetOffset() int32 {
+func (x *ValidateScenarioResponse) GetTotalMaxActions() int32 {
 	if x != nil {
-		return x.Offset
+		return x.TotalMaxActions
 	}
 	return 0
 }
 
-type ListTasksResponse struct {
+type SimulateScenarioRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*WarmingTask         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	ActionsJson   string                 `protobuf:"bytes,3,opt,name=actions_json,json=actionsJson,proto3" json:"actions_json,omitempty"`
+	ScheduleJson  string                 `protobuf:"bytes,4,opt,name=schedule_json,json=scheduleJson,proto3" json:"schedule_json,omitempty"`
+	DurationDays  int32                  `protobuf:"varint,5,opt,name=duration_days,json=durationDays,proto3" json:"duration_days,omitempty"` // 14-60
+	Accounts      int32                  `protobuf:"varint,6,opt,name=accounts,proto3" json:"accounts,omitempty"`                             // simulated fleet size, 1-1000
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`                 // first warming day, tomorrow when not set
+	Seed          int64                  `protobuf:"varint,8,opt,name=seed,proto3" json:"seed,omitempty"`                                     // same seed and request give the same timeline, random when 0
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksResponse) Reset() {
-	*x = ListTasksResponse{}
+func (x *SimulateScenarioRequest) Reset() {
+	*x = SimulateScenarioRequest{}
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksResponse) String() string {
+func (x *SimulateScenarioRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksResponse) ProtoMessage() {}
+func (*SimulateScenarioRequest) ProtoMessage() {}
 
-func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+func (x *SimulateScenarioRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1143,47 +1285,91 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
-func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SimulateScenarioRequest.ProtoReflect.Descriptor instead.
+func (*SimulateScenarioRequest) Descriptor() ([]byte, []int) {
 	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListTasksResponse) GetTasks() []*WarmingTask {
+func (x *SimulateScenarioRequest) GetName() string {
 	if x != nil {
-		return x.Tasks
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SimulateScenarioRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *SimulateScenarioRequest) GetActionsJson() string {
+	if x != nil {
+		return x.ActionsJson
+	}
+	return ""
+}
+
+func (x *SimulateScenarioRequest) GetScheduleJson() string {
+	if x != nil {
+		return x.ScheduleJson
+	}
+	return ""
+}
+
+func (x *SimulateScenarioRequest) GetDurationDays() int32 {
+	if x != nil {
+		return x.DurationDays
+	}
+	return 0
+}
+
+func (x *SimulateScenarioRequest) GetAccounts() int32 {
+	if x != nil {
+		return x.Accounts
+	}
+	return 0
+}
+
+func (x *SimulateScenarioRequest) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
 	}
 	return nil
 }
 
-func (x *ListTasksResponse) GetTotalCount() int64 {
+func (x *SimulateScenarioRequest) GetSeed() int64 {
 	if x != nil {
-		return x.TotalCount
+		return x.Seed
 	}
 	return 0
 }
 
-type ScenarioStatisticsRequest struct {
+type SimulatedAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
-	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"` // Number of days to look back (default 7)
+	Account       int32                  `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"` // 1-based index of the simulated account
+	Day           int32                  `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	ActionType    string                 `protobuf:"bytes,4,opt,name=action_type,json=actionType,proto3" json:"action_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ScenarioStatisticsRequest) Reset() {
-	*x = ScenarioStatisticsRequest{}
+func (x *SimulatedAction) Reset() {
+	*x = SimulatedAction{}
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScenarioStatisticsRequest) String() string {
+func (x *SimulatedAction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScenarioStatisticsRequest) ProtoMessage() {}
+func (*SimulatedAction) ProtoMessage() {}
 
-func (x *ScenarioStatisticsRequest) ProtoReflect() protoreflect.Message {
+func (x *SimulatedAction) ProtoReflect() protoreflect.Message {
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1195,46 +1381,68 @@ func (x *ScenarioStatisticsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScenarioStatisticsRequest.ProtoReflect.Descriptor instead.
-func (*ScenarioStatisticsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SimulatedAction.ProtoReflect.Descriptor instead.
+func (*SimulatedAction) Descriptor() ([]byte, []int) {
 	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ScenarioStatisticsRequest) GetPlatform() string {
+func (x *SimulatedAction) GetAccount() int32 {
 	if x != nil {
-		return x.Platform
+		return x.Account
 	}
-	return ""
+	return 0
 }
 
-func (x *ScenarioStatisticsRequest) GetDays() int32 {
+func (x *SimulatedAction) GetDay() int32 {
 	if x != nil {
-		return x.Days
+		return x.Day
 	}
 	return 0
 }
 
-type ScenarioStatisticsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ScenarioStats []*ScenarioStats       `protobuf:"bytes,1,rep,name=scenario_stats,json=scenarioStats,proto3" json:"scenario_stats,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SimulatedAction) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
 }
 
-func (x *ScenarioStatisticsResponse) Reset() {
-	*x = ScenarioStatisticsResponse{}
+func (x *SimulatedAction) GetActionType() string {
+	if x != nil {
+		return x.ActionType
+	}
+	return ""
+}
+
+type SimulatedDayVolume struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Day             int32                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Date            string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Stage           string                 `protobuf:"bytes,3,opt,name=stage,proto3" json:"stage,omitempty"`
+	Actions         int32                  `protobuf:"varint,4,opt,name=actions,proto3" json:"actions,omitempty"`
+	ActiveAccounts  int32                  `protobuf:"varint,5,opt,name=active_accounts,json=activeAccounts,proto3" json:"active_accounts,omitempty"` // accounts not on a day off
+	MinPerAccount   int32                  `protobuf:"varint,6,opt,name=min_per_account,json=minPerAccount,proto3" json:"min_per_account,omitempty"`
+	MaxPerAccount   int32                  `protobuf:"varint,7,opt,name=max_per_account,json=maxPerAccount,proto3" json:"max_per_account,omitempty"`
+	PeakHourActions int32                  `protobuf:"varint,8,opt,name=peak_hour_actions,json=peakHourActions,proto3" json:"peak_hour_actions,omitempty"`
+	ActionsByType   map[string]int32       `protobuf:"bytes,9,rep,name=actions_by_type,json=actionsByType,proto3" json:"actions_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SimulatedDayVolume) Reset() {
+	*x = SimulatedDayVolume{}
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScenarioStatisticsResponse) String() string {
+func (x *SimulatedDayVolume) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScenarioStatisticsResponse) ProtoMessage() {}
+func (*SimulatedDayVolume) ProtoMessage() {}
 
-func (x *ScenarioStatisticsResponse) ProtoReflect() protoreflect.Message {
+func (x *SimulatedDayVolume) ProtoReflect() protoreflect.Message {
 	mi := &file_services_warming_service_proto_warming_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1246,23 +1454,623 @@ func (x *ScenarioStatisticsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScenarioStatisticsResponse.ProtoReflect.Descriptor instead.
-func (*ScenarioStatisticsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SimulatedDayVolume.ProtoReflect.Descriptor instead.
+func (*SimulatedDayVolume) Descriptor() ([]byte, []int) {
 	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ScenarioStatisticsResponse) GetScenarioStats() []*ScenarioStats {
+func (x *SimulatedDayVolume) GetDay() int32 {
 	if x != nil {
-		return x.ScenarioStats
+		return x.Day
 	}
-	return nil
+	return 0
 }
 
-type ScenarioStats struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ScenarioType    string                 `protobuf:"bytes,1,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"` // "basic", "advanced", "custom"
-	SuccessRate     float64                `protobuf:"fixed64,2,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
-	AvgDurationDays float64                `protobuf:"fixed64,3,opt,name=avg_duration_days,json=avgDurationDays,proto3" json:"avg_duration_days,omitempty"`
+func (x *SimulatedDayVolume) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *SimulatedDayVolume) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *SimulatedDayVolume) GetActions() int32 {
+	if x != nil {
+		return x.Actions
+	}
+	return 0
+}
+
+func (x *SimulatedDayVolume) GetActiveAccounts() int32 {
+	if x != nil {
+		return x.ActiveAccounts
+	}
+	return 0
+}
+
+func (x *SimulatedDayVolume) GetMinPerAccount() int32 {
+	if x != nil {
+		return x.MinPerAccount
+	}
+	return 0
+}
+
+func (x *SimulatedDayVolume) GetMaxPerAccount() int32 {
+	if x != nil {
+		return x.MaxPerAccount
+	}
+	return 0
+}
+
+func (x *SimulatedDayVolume) GetPeakHourActions() int32 {
+	if x != nil {
+		return x.PeakHourActions
+	}
+	return 0
+}
+
+func (x *SimulatedDayVolume) GetActionsByType() map[string]int32 {
+	if x != nil {
+		return x.ActionsByType
+	}
+	return nil
+}
+
+type SimulationCollision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`        // "max_per_day", "capacity"
+	Account       int32                  `protobuf:"varint,2,opt,name=account,proto3" json:"account,omitempty"` // 0 for fleet-wide collisions
+	Day           int32                  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`
+	ActionType    string                 `protobuf:"bytes,5,opt,name=action_type,json=actionType,proto3" json:"action_type,omitempty"`
+	Count         int32                  `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulationCollision) Reset() {
+	*x = SimulationCollision{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationCollision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationCollision) ProtoMessage() {}
+
+func (x *SimulationCollision) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationCollision.ProtoReflect.Descriptor instead.
+func (*SimulationCollision) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SimulationCollision) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SimulationCollision) GetAccount() int32 {
+	if x != nil {
+		return x.Account
+	}
+	return 0
+}
+
+func (x *SimulationCollision) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *SimulationCollision) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *SimulationCollision) GetActionType() string {
+	if x != nil {
+		return x.ActionType
+	}
+	return ""
+}
+
+func (x *SimulationCollision) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SimulationCollision) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SimulateScenarioResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Valid             bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors            []string               `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	Accounts          int32                  `protobuf:"varint,3,opt,name=accounts,proto3" json:"accounts,omitempty"`
+	DurationDays      int32                  `protobuf:"varint,4,opt,name=duration_days,json=durationDays,proto3" json:"duration_days,omitempty"`
+	StartAt           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	Seed              int64                  `protobuf:"varint,6,opt,name=seed,proto3" json:"seed,omitempty"`
+	TotalActions      int32                  `protobuf:"varint,7,opt,name=total_actions,json=totalActions,proto3" json:"total_actions,omitempty"`
+	CapacityPerHour   float64                `protobuf:"fixed64,8,opt,name=capacity_per_hour,json=capacityPerHour,proto3" json:"capacity_per_hour,omitempty"` // 0 when no limits are configured
+	DailyVolumes      []*SimulatedDayVolume  `protobuf:"bytes,9,rep,name=daily_volumes,json=dailyVolumes,proto3" json:"daily_volumes,omitempty"`
+	Timeline          []*SimulatedAction     `protobuf:"bytes,10,rep,name=timeline,proto3" json:"timeline,omitempty"` // first 2000 actions
+	TimelineTruncated bool                   `protobuf:"varint,11,opt,name=timeline_truncated,json=timelineTruncated,proto3" json:"timeline_truncated,omitempty"`
+	Collisions        []*SimulationCollision `protobuf:"bytes,12,rep,name=collisions,proto3" json:"collisions,omitempty"`                                                                                                             // first 200 collisions
+	CollisionCounts   map[string]int32       `protobuf:"bytes,13,rep,name=collision_counts,json=collisionCounts,proto3" json:"collision_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // collision type -> total
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SimulateScenarioResponse) Reset() {
+	*x = SimulateScenarioResponse{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateScenarioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateScenarioResponse) ProtoMessage() {}
+
+func (x *SimulateScenarioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateScenarioResponse.ProtoReflect.Descriptor instead.
+func (*SimulateScenarioResponse) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SimulateScenarioResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *SimulateScenarioResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *SimulateScenarioResponse) GetAccounts() int32 {
+	if x != nil {
+		return x.Accounts
+	}
+	return 0
+}
+
+func (x *SimulateScenarioResponse) GetDurationDays() int32 {
+	if x != nil {
+		return x.DurationDays
+	}
+	return 0
+}
+
+func (x *SimulateScenarioResponse) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *SimulateScenarioResponse) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *SimulateScenarioResponse) GetTotalActions() int32 {
+	if x != nil {
+		return x.TotalActions
+	}
+	return 0
+}
+
+func (x *SimulateScenarioResponse) GetCapacityPerHour() float64 {
+	if x != nil {
+		return x.CapacityPerHour
+	}
+	return 0
+}
+
+func (x *SimulateScenarioResponse) GetDailyVolumes() []*SimulatedDayVolume {
+	if x != nil {
+		return x.DailyVolumes
+	}
+	return nil
+}
+
+func (x *SimulateScenarioResponse) GetTimeline() []*SimulatedAction {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
+}
+
+func (x *SimulateScenarioResponse) GetTimelineTruncated() bool {
+	if x != nil {
+		return x.TimelineTruncated
+	}
+	return false
+}
+
+func (x *SimulateScenarioResponse) GetCollisions() []*SimulationCollision {
+	if x != nil {
+		return x.Collisions
+	}
+	return nil
+}
+
+func (x *SimulateScenarioResponse) GetCollisionCounts() map[string]int32 {
+	if x != nil {
+		return x.CollisionCounts
+	}
+	return nil
+}
+
+type ListScenariosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScenariosRequest) Reset() {
+	*x = ListScenariosRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScenariosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScenariosRequest) ProtoMessage() {}
+
+func (x *ListScenariosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScenariosRequest.ProtoReflect.Descriptor instead.
+func (*ListScenariosRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListScenariosRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type ListScenariosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scenarios     []*WarmingScenario     `protobuf:"bytes,1,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScenariosResponse) Reset() {
+	*x = ListScenariosResponse{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScenariosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScenariosResponse) ProtoMessage() {}
+
+func (x *ListScenariosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScenariosResponse.ProtoReflect.Descriptor instead.
+func (*ListScenariosResponse) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListScenariosResponse) GetScenarios() []*WarmingScenario {
+	if x != nil {
+		return x.Scenarios
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListTasksRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*WarmingTask         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTasksResponse) GetTasks() []*WarmingTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ScenarioStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"` // Number of days to look back (default 7)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScenarioStatisticsRequest) Reset() {
+	*x = ScenarioStatisticsRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScenarioStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScenarioStatisticsRequest) ProtoMessage() {}
+
+func (x *ScenarioStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScenarioStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*ScenarioStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ScenarioStatisticsRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ScenarioStatisticsRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type ScenarioStatisticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScenarioStats []*ScenarioStats       `protobuf:"bytes,1,rep,name=scenario_stats,json=scenarioStats,proto3" json:"scenario_stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScenarioStatisticsResponse) Reset() {
+	*x = ScenarioStatisticsResponse{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScenarioStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScenarioStatisticsResponse) ProtoMessage() {}
+
+func (x *ScenarioStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScenarioStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*ScenarioStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ScenarioStatisticsResponse) GetScenarioStats() []*ScenarioStats {
+	if x != nil {
+		return x.ScenarioStats
+	}
+	return nil
+}
+
+type ScenarioStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ScenarioType    string                 `protobuf:"bytes,1,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"` // "basic", "advanced", "custom"
+	SuccessRate     float64                `protobuf:"fixed64,2,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	AvgDurationDays float64                `protobuf:"fixed64,3,opt,name=avg_duration_days,json=avgDurationDays,proto3" json:"avg_duration_days,omitempty"`
 	CompletedTasks  int64                  `protobuf:"varint,4,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
 	FailedTasks     int64                  `protobuf:"varint,5,opt,name=failed_tasks,json=failedTasks,proto3" json:"failed_tasks,omitempty"`
 	TotalTasks      int64                  `protobuf:"varint,6,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
@@ -1271,21 +2079,1026 @@ type ScenarioStats struct {
 	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ScenarioStats) Reset() {
-	*x = ScenarioStats{}
-	mi := &file_services_warming_service_proto_warming_proto_msgTypes[17]
+func (x *ScenarioStats) Reset() {
+	*x = ScenarioStats{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScenarioStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScenarioStats) ProtoMessage() {}
+
+func (x *ScenarioStats) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScenarioStats.ProtoReflect.Descriptor instead.
+func (*ScenarioStats) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ScenarioStats) GetScenarioType() string {
+	if x != nil {
+		return x.ScenarioType
+	}
+	return ""
+}
+
+func (x *ScenarioStats) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *ScenarioStats) GetAvgDurationDays() float64 {
+	if x != nil {
+		return x.AvgDurationDays
+	}
+	return 0
+}
+
+func (x *ScenarioStats) GetCompletedTasks() int64 {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return 0
+}
+
+func (x *ScenarioStats) GetFailedTasks() int64 {
+	if x != nil {
+		return x.FailedTasks
+	}
+	return 0
+}
+
+func (x *ScenarioStats) GetTotalTasks() int64 {
+	if x != nil {
+		return x.TotalTasks
+	}
+	return 0
+}
+
+func (x *ScenarioStats) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type ActivityCalendar struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Timezone          string                 `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone,omitempty"` // IANA name, e.g. "Europe/Moscow"
+	Persona           string                 `protobuf:"bytes,2,opt,name=persona,proto3" json:"persona,omitempty"`   // "early_bird", "office", "night_owl"
+	WakeHour          int32                  `protobuf:"varint,3,opt,name=wake_hour,json=wakeHour,proto3" json:"wake_hour,omitempty"`
+	SleepHour         int32                  `protobuf:"varint,4,opt,name=sleep_hour,json=sleepHour,proto3" json:"sleep_hour,omitempty"`
+	WeekendWakeHour   int32                  `protobuf:"varint,5,opt,name=weekend_wake_hour,json=weekendWakeHour,proto3" json:"weekend_wake_hour,omitempty"`
+	WeekendSleepHour  int32                  `protobuf:"varint,6,opt,name=weekend_sleep_hour,json=weekendSleepHour,proto3" json:"weekend_sleep_hour,omitempty"`
+	DayOffProbability float64                `protobuf:"fixed64,7,opt,name=day_off_probability,json=dayOffProbability,proto3" json:"day_off_probability,omitempty"`
+	DaysOff           []string               `protobuf:"bytes,8,rep,name=days_off,json=daysOff,proto3" json:"days_off,omitempty"` // YYYY-MM-DD
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ActivityCalendar) Reset() {
+	*x = ActivityCalendar{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityCalendar) ProtoMessage() {}
+
+func (x *ActivityCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityCalendar.ProtoReflect.Descriptor instead.
+func (*ActivityCalendar) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ActivityCalendar) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ActivityCalendar) GetPersona() string {
+	if x != nil {
+		return x.Persona
+	}
+	return ""
+}
+
+func (x *ActivityCalendar) GetWakeHour() int32 {
+	if x != nil {
+		return x.WakeHour
+	}
+	return 0
+}
+
+func (x *ActivityCalendar) GetSleepHour() int32 {
+	if x != nil {
+		return x.SleepHour
+	}
+	return 0
+}
+
+func (x *ActivityCalendar) GetWeekendWakeHour() int32 {
+	if x != nil {
+		return x.WeekendWakeHour
+	}
+	return 0
+}
+
+func (x *ActivityCalendar) GetWeekendSleepHour() int32 {
+	if x != nil {
+		return x.WeekendSleepHour
+	}
+	return 0
+}
+
+func (x *ActivityCalendar) GetDayOffProbability() float64 {
+	if x != nil {
+		return x.DayOffProbability
+	}
+	return 0
+}
+
+func (x *ActivityCalendar) GetDaysOff() []string {
+	if x != nil {
+		return x.DaysOff
+	}
+	return nil
+}
+
+type UpdateActivityCalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Calendar      *ActivityCalendar      `protobuf:"bytes,2,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateActivityCalendarRequest) Reset() {
+	*x = UpdateActivityCalendarRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateActivityCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateActivityCalendarRequest) ProtoMessage() {}
+
+func (x *UpdateActivityCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateActivityCalendarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateActivityCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateActivityCalendarRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UpdateActivityCalendarRequest) GetCalendar() *ActivityCalendar {
+	if x != nil {
+		return x.Calendar
+	}
+	return nil
+}
+
+type CapacityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"` // empty for all platforms
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapacityRequest) Reset() {
+	*x = CapacityRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityRequest) ProtoMessage() {}
+
+func (x *CapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacityRequest.ProtoReflect.Descriptor instead.
+func (*CapacityRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CapacityRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type PlatformCapacity struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Platform            string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	CapacityPerHour     float64                `protobuf:"fixed64,2,opt,name=capacity_per_hour,json=capacityPerHour,proto3" json:"capacity_per_hour,omitempty"` // 0 when no limits are configured
+	DemandPerHour       float64                `protobuf:"fixed64,3,opt,name=demand_per_hour,json=demandPerHour,proto3" json:"demand_per_hour,omitempty"`
+	Utilization         float64                `protobuf:"fixed64,4,opt,name=utilization,proto3" json:"utilization,omitempty"`
+	BrowserLimitPerHour float64                `protobuf:"fixed64,5,opt,name=browser_limit_per_hour,json=browserLimitPerHour,proto3" json:"browser_limit_per_hour,omitempty"`
+	ProxyLimitPerHour   float64                `protobuf:"fixed64,6,opt,name=proxy_limit_per_hour,json=proxyLimitPerHour,proto3" json:"proxy_limit_per_hour,omitempty"`
+	PlatformCapPerHour  float64                `protobuf:"fixed64,7,opt,name=platform_cap_per_hour,json=platformCapPerHour,proto3" json:"platform_cap_per_hour,omitempty"`
+	Bottleneck          string                 `protobuf:"bytes,8,opt,name=bottleneck,proto3" json:"bottleneck,omitempty"` // "browsers", "proxies", "platform_cap"
+	AvgActionSeconds    float64                `protobuf:"fixed64,9,opt,name=avg_action_seconds,json=avgActionSeconds,proto3" json:"avg_action_seconds,omitempty"`
+	ExpectedWaitSeconds float64                `protobuf:"fixed64,10,opt,name=expected_wait_seconds,json=expectedWaitSeconds,proto3" json:"expected_wait_seconds,omitempty"` // -1 when the browser pool is saturated
+	ActiveTasks         int32                  `protobuf:"varint,11,opt,name=active_tasks,json=activeTasks,proto3" json:"active_tasks,omitempty"`
+	QueuedTasks         int32                  `protobuf:"varint,12,opt,name=queued_tasks,json=queuedTasks,proto3" json:"queued_tasks,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PlatformCapacity) Reset() {
+	*x = PlatformCapacity{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlatformCapacity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlatformCapacity) ProtoMessage() {}
+
+func (x *PlatformCapacity) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlatformCapacity.ProtoReflect.Descriptor instead.
+func (*PlatformCapacity) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PlatformCapacity) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *PlatformCapacity) GetCapacityPerHour() float64 {
+	if x != nil {
+		return x.CapacityPerHour
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetDemandPerHour() float64 {
+	if x != nil {
+		return x.DemandPerHour
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetBrowserLimitPerHour() float64 {
+	if x != nil {
+		return x.BrowserLimitPerHour
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetProxyLimitPerHour() float64 {
+	if x != nil {
+		return x.ProxyLimitPerHour
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetPlatformCapPerHour() float64 {
+	if x != nil {
+		return x.PlatformCapPerHour
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetBottleneck() string {
+	if x != nil {
+		return x.Bottleneck
+	}
+	return ""
+}
+
+func (x *PlatformCapacity) GetAvgActionSeconds() float64 {
+	if x != nil {
+		return x.AvgActionSeconds
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetExpectedWaitSeconds() float64 {
+	if x != nil {
+		return x.ExpectedWaitSeconds
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetActiveTasks() int32 {
+	if x != nil {
+		return x.ActiveTasks
+	}
+	return 0
+}
+
+func (x *PlatformCapacity) GetQueuedTasks() int32 {
+	if x != nil {
+		return x.QueuedTasks
+	}
+	return 0
+}
+
+type CapacityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platforms     []*PlatformCapacity    `protobuf:"bytes,1,rep,name=platforms,proto3" json:"platforms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapacityResponse) Reset() {
+	*x = CapacityResponse{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityResponse) ProtoMessage() {}
+
+func (x *CapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacityResponse.ProtoReflect.Descriptor instead.
+func (*CapacityResponse) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CapacityResponse) GetPlatforms() []*PlatformCapacity {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+type ExperimentVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ScenarioType  string                 `protobuf:"bytes,2,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"` // "basic", "advanced", "custom"
+	ScenarioId    string                 `protobuf:"bytes,3,opt,name=scenario_id,json=scenarioId,proto3" json:"scenario_id,omitempty"`       // required for custom scenarios
+	DurationDays  int32                  `protobuf:"varint,4,opt,name=duration_days,json=durationDays,proto3" json:"duration_days,omitempty"`
+	Weight        int32                  `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExperimentVariant) Reset() {
+	*x = ExperimentVariant{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExperimentVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentVariant) ProtoMessage() {}
+
+func (x *ExperimentVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentVariant.ProtoReflect.Descriptor instead.
+func (*ExperimentVariant) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExperimentVariant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExperimentVariant) GetScenarioType() string {
+	if x != nil {
+		return x.ScenarioType
+	}
+	return ""
+}
+
+func (x *ExperimentVariant) GetScenarioId() string {
+	if x != nil {
+		return x.ScenarioId
+	}
+	return ""
+}
+
+func (x *ExperimentVariant) GetDurationDays() int32 {
+	if x != nil {
+		return x.DurationDays
+	}
+	return 0
+}
+
+func (x *ExperimentVariant) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type Experiment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Platform      string                 `protobuf:"bytes,4,opt,name=platform,proto3" json:"platform,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`     // "running", "stopped"
+	Variants      []*ExperimentVariant   `protobuf:"bytes,6,rep,name=variants,proto3" json:"variants,omitempty"` // the first variant is the control
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	StoppedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=stopped_at,json=stoppedAt,proto3" json:"stopped_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Experiment) Reset() {
+	*x = Experiment{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Experiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Experiment) ProtoMessage() {}
+
+func (x *Experiment) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Experiment.ProtoReflect.Descriptor instead.
+func (*Experiment) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Experiment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Experiment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Experiment) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Experiment) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Experiment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Experiment) GetVariants() []*ExperimentVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Experiment) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Experiment) GetStoppedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StoppedAt
+	}
+	return nil
+}
+
+func (x *Experiment) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type CreateExperimentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Platform      string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	Variants      []*ExperimentVariant   `protobuf:"bytes,4,rep,name=variants,proto3" json:"variants,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExperimentRequest) Reset() {
+	*x = CreateExperimentRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExperimentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExperimentRequest) ProtoMessage() {}
+
+func (x *CreateExperimentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExperimentRequest.ProtoReflect.Descriptor instead.
+func (*CreateExperimentRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateExperimentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateExperimentRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateExperimentRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *CreateExperimentRequest) GetVariants() []*ExperimentVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *CreateExperimentRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type ExperimentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExperimentId  string                 `protobuf:"bytes,1,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExperimentRequest) Reset() {
+	*x = ExperimentRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExperimentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentRequest) ProtoMessage() {}
+
+func (x *ExperimentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentRequest.ProtoReflect.Descriptor instead.
+func (*ExperimentRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExperimentRequest) GetExperimentId() string {
+	if x != nil {
+		return x.ExperimentId
+	}
+	return ""
+}
+
+type ListExperimentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExperimentsRequest) Reset() {
+	*x = ListExperimentsRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExperimentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExperimentsRequest) ProtoMessage() {}
+
+func (x *ListExperimentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExperimentsRequest.ProtoReflect.Descriptor instead.
+func (*ListExperimentsRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListExperimentsRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ListExperimentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListExperimentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Experiments   []*Experiment          `protobuf:"bytes,1,rep,name=experiments,proto3" json:"experiments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExperimentsResponse) Reset() {
+	*x = ListExperimentsResponse{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExperimentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExperimentsResponse) ProtoMessage() {}
+
+func (x *ListExperimentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExperimentsResponse.ProtoReflect.Descriptor instead.
+func (*ListExperimentsResponse) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListExperimentsResponse) GetExperiments() []*Experiment {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+type VariantResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ScenarioType  string                 `protobuf:"bytes,2,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"`
+	DurationDays  int32                  `protobuf:"varint,3,opt,name=duration_days,json=durationDays,proto3" json:"duration_days,omitempty"`
+	Assigned      int64                  `protobuf:"varint,4,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	InProgress    int64                  `protobuf:"varint,5,opt,name=in_progress,json=inProgress,proto3" json:"in_progress,omitempty"`
+	Ready         int64                  `protobuf:"varint,6,opt,name=ready,proto3" json:"ready,omitempty"`
+	Banned        int64                  `protobuf:"varint,7,opt,name=banned,proto3" json:"banned,omitempty"`
+	Failed        int64                  `protobuf:"varint,8,opt,name=failed,proto3" json:"failed,omitempty"`
+	ReadyRate     float64                `protobuf:"fixed64,9,opt,name=ready_rate,json=readyRate,proto3" json:"ready_rate,omitempty"` // percent of finished tasks
+	BanRate       float64                `protobuf:"fixed64,10,opt,name=ban_rate,json=banRate,proto3" json:"ban_rate,omitempty"`      // percent of assigned accounts
+	SurvivalRate  float64                `protobuf:"fixed64,11,opt,name=survival_rate,json=survivalRate,proto3" json:"survival_rate,omitempty"`
+	PValue        float64                `protobuf:"fixed64,12,opt,name=p_value,json=pValue,proto3" json:"p_value,omitempty"` // ready rate compared with the control
+	Significant   bool                   `protobuf:"varint,13,opt,name=significant,proto3" json:"significant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariantResult) Reset() {
+	*x = VariantResult{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantResult) ProtoMessage() {}
+
+func (x *VariantResult) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantResult.ProtoReflect.Descriptor instead.
+func (*VariantResult) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VariantResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VariantResult) GetScenarioType() string {
+	if x != nil {
+		return x.ScenarioType
+	}
+	return ""
+}
+
+func (x *VariantResult) GetDurationDays() int32 {
+	if x != nil {
+		return x.DurationDays
+	}
+	return 0
+}
+
+func (x *VariantResult) GetAssigned() int64 {
+	if x != nil {
+		return x.Assigned
+	}
+	return 0
+}
+
+func (x *VariantResult) GetInProgress() int64 {
+	if x != nil {
+		return x.InProgress
+	}
+	return 0
+}
+
+func (x *VariantResult) GetReady() int64 {
+	if x != nil {
+		return x.Ready
+	}
+	return 0
+}
+
+func (x *VariantResult) GetBanned() int64 {
+	if x != nil {
+		return x.Banned
+	}
+	return 0
+}
+
+func (x *VariantResult) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *VariantResult) GetReadyRate() float64 {
+	if x != nil {
+		return x.ReadyRate
+	}
+	return 0
+}
+
+func (x *VariantResult) GetBanRate() float64 {
+	if x != nil {
+		return x.BanRate
+	}
+	return 0
+}
+
+func (x *VariantResult) GetSurvivalRate() float64 {
+	if x != nil {
+		return x.SurvivalRate
+	}
+	return 0
+}
+
+func (x *VariantResult) GetPValue() float64 {
+	if x != nil {
+		return x.PValue
+	}
+	return 0
+}
+
+func (x *VariantResult) GetSignificant() bool {
+	if x != nil {
+		return x.Significant
+	}
+	return false
+}
+
+type ExperimentResults struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExperimentId  string                 `protobuf:"bytes,1,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Platform      string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Control       string                 `protobuf:"bytes,5,opt,name=control,proto3" json:"control,omitempty"`
+	Variants      []*VariantResult       `protobuf:"bytes,6,rep,name=variants,proto3" json:"variants,omitempty"`
+	Winner        string                 `protobuf:"bytes,7,opt,name=winner,proto3" json:"winner,omitempty"` // empty until a variant differs significantly
+	ComputedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExperimentResults) Reset() {
+	*x = ExperimentResults{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScenarioStats) String() string {
+func (x *ExperimentResults) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScenarioStats) ProtoMessage() {}
+func (*ExperimentResults) ProtoMessage() {}
 
-func (x *ScenarioStats) ProtoReflect() protoreflect.Message {
-	mi := &file_services_warming_service_proto_warming_proto_msgTypes[17]
+func (x *ExperimentResults) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1296,65 +3109,248 @@ func (x *ScenarioStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScenarioStats.ProtoReflect.Descriptor instead.
-func (*ScenarioStats) Descriptor() ([]byte, []int) {
-	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ExperimentResults.ProtoReflect.Descriptor instead.
+func (*ExperimentResults) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ScenarioStats) GetScenarioType() string {
+func (x *ExperimentResults) GetExperimentId() string {
 	if x != nil {
-		return x.ScenarioType
+		return x.ExperimentId
 	}
 	return ""
 }
 
-func (x *ScenarioStats) GetSuccessRate() float64 {
+func (x *ExperimentResults) GetName() string {
 	if x != nil {
-		return x.SuccessRate
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ScenarioStats) GetAvgDurationDays() float64 {
+func (x *ExperimentResults) GetPlatform() string {
 	if x != nil {
-		return x.AvgDurationDays
+		return x.Platform
 	}
-	return 0
+	return ""
 }
 
-func (x *ScenarioStats) GetCompletedTasks() int64 {
+func (x *ExperimentResults) GetStatus() string {
 	if x != nil {
-		return x.CompletedTasks
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *ScenarioStats) GetFailedTasks() int64 {
+func (x *ExperimentResults) GetControl() string {
 	if x != nil {
-		return x.FailedTasks
+		return x.Control
 	}
-	return 0
+	return ""
 }
 
-func (x *ScenarioStats) GetTotalTasks() int64 {
+func (x *ExperimentResults) GetVariants() []*VariantResult {
 	if x != nil {
-		return x.TotalTasks
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *ExperimentResults) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *ExperimentResults) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
+type ActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // echoed in the result, unique per attempt
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ActionType    string                 `protobuf:"bytes,4,opt,name=action_type,json=actionType,proto3" json:"action_type,omitempty"`
+	Attempt       int32                  `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"` // 1 for the first attempt
+	Params        map[string]string      `protobuf:"bytes,6,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionRequest) Reset() {
+	*x = ActionRequest{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionRequest) ProtoMessage() {}
+
+func (x *ActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionRequest.ProtoReflect.Descriptor instead.
+func (*ActionRequest) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ActionRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ActionRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ActionRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ActionRequest) GetActionType() string {
+	if x != nil {
+		return x.ActionType
+	}
+	return ""
+}
+
+func (x *ActionRequest) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
 	}
 	return 0
 }
 
-func (x *ScenarioStats) GetPlatform() string {
+func (x *ActionRequest) GetParams() map[string]string {
 	if x != nil {
-		return x.Platform
+		return x.Params
+	}
+	return nil
+}
+
+type ActionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorType     string                 `protobuf:"bytes,3,opt,name=error_type,json=errorType,proto3" json:"error_type,omitempty"` // error type of the warming executor, "unsupported" for unknown actions
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Retryable     bool                   `protobuf:"varint,5,opt,name=retryable,proto3" json:"retryable,omitempty"`                                                                      // the same action may succeed when sent again right away
+	LatencyMs     int64                  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`                                                     // time the platform service spent on the action
+	Details       map[string]string      `protobuf:"bytes,7,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // e.g. message_id, channel of the action
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionResult) Reset() {
+	*x = ActionResult{}
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionResult) ProtoMessage() {}
+
+func (x *ActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_services_warming_service_proto_warming_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionResult.ProtoReflect.Descriptor instead.
+func (*ActionResult) Descriptor() ([]byte, []int) {
+	return file_services_warming_service_proto_warming_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ActionResult) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ActionResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ActionResult) GetErrorType() string {
+	if x != nil {
+		return x.ErrorType
+	}
+	return ""
+}
+
+func (x *ActionResult) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
+func (x *ActionResult) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+func (x *ActionResult) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ActionResult) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
 var File_services_warming_service_proto_warming_proto protoreflect.FileDescriptor
 
 const file_services_warming_service_proto_warming_proto_rawDesc = "" +
 	"\n" +
-	",services/warming-service/proto/warming.proto\x12\awarming\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbb\x01\n" +
+	",services/warming-service/proto/warming.proto\x12\awarming\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x02\n" +
 	"\x13StartWarmingRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
@@ -1362,9 +3358,11 @@ const file_services_warming_service_proto_warming_proto_rawDesc = "" +
 	"\rscenario_type\x18\x03 \x01(\tR\fscenarioType\x12\x1f\n" +
 	"\vscenario_id\x18\x04 \x01(\tR\n" +
 	"scenarioId\x12#\n" +
-	"\rduration_days\x18\x05 \x01(\x05R\fdurationDays\"&\n" +
+	"\rduration_days\x18\x05 \x01(\x05R\fdurationDays\x12#\n" +
+	"\rcallback_urls\x18\x06 \x03(\tR\fcallbackUrls\x12'\n" +
+	"\x0fcallback_secret\x18\a \x01(\tR\x0ecallbackSecret\"&\n" +
 	"\vTaskRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xe6\x04\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xeb\x05\n" +
 	"\vWarmingTask\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1387,7 +3385,10 @@ const file_services_warming_service_proto_warming_proto_rawDesc = "" +
 	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
-	"\fcompleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xa1\x01\n" +
+	"\fcompleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x125\n" +
+	"\bcalendar\x18\x10 \x01(\v2\x19.warming.ActivityCalendarR\bcalendar\x12'\n" +
+	"\x0freadiness_score\x18\x11 \x01(\x01R\x0ereadinessScore\x12#\n" +
+	"\rcallback_urls\x18\x12 \x03(\tR\fcallbackUrls\"\xa1\x01\n" +
 	"\x11StatisticsRequest\x12\x1a\n" +
 	"\bplatform\x18\x01 \x01(\tR\bplatform\x129\n" +
 	"\n" +
@@ -1460,7 +3461,91 @@ const file_services_warming_service_proto_warming_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"2\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb6\x01\n" +
+	"\x17ValidateScenarioRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12!\n" +
+	"\factions_json\x18\x03 \x01(\tR\vactionsJson\x12#\n" +
+	"\rschedule_json\x18\x04 \x01(\tR\fscheduleJson\x12#\n" +
+	"\rduration_days\x18\x05 \x01(\x05R\fdurationDays\"\x97\x03\n" +
+	"\x15ScenarioTimelineStage\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x19\n" +
+	"\bfrom_day\x18\x02 \x01(\x05R\afromDay\x12\x15\n" +
+	"\x06to_day\x18\x03 \x01(\x05R\x05toDay\x12-\n" +
+	"\x13min_actions_per_day\x18\x04 \x01(\x05R\x10minActionsPerDay\x12-\n" +
+	"\x13max_actions_per_day\x18\x05 \x01(\x05R\x10maxActionsPerDay\x12\x1f\n" +
+	"\vmin_actions\x18\x06 \x01(\x05R\n" +
+	"minActions\x12\x1f\n" +
+	"\vmax_actions\x18\a \x01(\x05R\n" +
+	"maxActions\x12U\n" +
+	"\raction_shares\x18\b \x03(\v20.warming.ScenarioTimelineStage.ActionSharesEntryR\factionShares\x1a?\n" +
+	"\x11ActionSharesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xfd\x01\n" +
+	"\x18ValidateScenarioResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x16\n" +
+	"\x06errors\x18\x02 \x03(\tR\x06errors\x12#\n" +
+	"\rduration_days\x18\x03 \x01(\x05R\fdurationDays\x126\n" +
+	"\x06stages\x18\x04 \x03(\v2\x1e.warming.ScenarioTimelineStageR\x06stages\x12*\n" +
+	"\x11total_min_actions\x18\x05 \x01(\x05R\x0ftotalMinActions\x12*\n" +
+	"\x11total_max_actions\x18\x06 \x01(\x05R\x0ftotalMaxActions\"\x9d\x02\n" +
+	"\x17SimulateScenarioRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12!\n" +
+	"\factions_json\x18\x03 \x01(\tR\vactionsJson\x12#\n" +
+	"\rschedule_json\x18\x04 \x01(\tR\fscheduleJson\x12#\n" +
+	"\rduration_days\x18\x05 \x01(\x05R\fdurationDays\x12\x1a\n" +
+	"\baccounts\x18\x06 \x01(\x05R\baccounts\x125\n" +
+	"\bstart_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x12\x12\n" +
+	"\x04seed\x18\b \x01(\x03R\x04seed\"\x8a\x01\n" +
+	"\x0fSimulatedAction\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\x05R\aaccount\x12\x10\n" +
+	"\x03day\x18\x02 \x01(\x05R\x03day\x12*\n" +
+	"\x02at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x1f\n" +
+	"\vaction_type\x18\x04 \x01(\tR\n" +
+	"actionType\"\xa9\x03\n" +
+	"\x12SimulatedDayVolume\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x05R\x03day\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x14\n" +
+	"\x05stage\x18\x03 \x01(\tR\x05stage\x12\x18\n" +
+	"\aactions\x18\x04 \x01(\x05R\aactions\x12'\n" +
+	"\x0factive_accounts\x18\x05 \x01(\x05R\x0eactiveAccounts\x12&\n" +
+	"\x0fmin_per_account\x18\x06 \x01(\x05R\rminPerAccount\x12&\n" +
+	"\x0fmax_per_account\x18\a \x01(\x05R\rmaxPerAccount\x12*\n" +
+	"\x11peak_hour_actions\x18\b \x01(\x05R\x0fpeakHourActions\x12V\n" +
+	"\x0factions_by_type\x18\t \x03(\v2..warming.SimulatedDayVolume.ActionsByTypeEntryR\ractionsByType\x1a@\n" +
+	"\x12ActionsByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xce\x01\n" +
+	"\x13SimulationCollision\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\x05R\aaccount\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\x05R\x03day\x12*\n" +
+	"\x02at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x1f\n" +
+	"\vaction_type\x18\x05 \x01(\tR\n" +
+	"actionType\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x05R\x05count\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"\xb1\x05\n" +
+	"\x18SimulateScenarioResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x16\n" +
+	"\x06errors\x18\x02 \x03(\tR\x06errors\x12\x1a\n" +
+	"\baccounts\x18\x03 \x01(\x05R\baccounts\x12#\n" +
+	"\rduration_days\x18\x04 \x01(\x05R\fdurationDays\x125\n" +
+	"\bstart_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x12\x12\n" +
+	"\x04seed\x18\x06 \x01(\x03R\x04seed\x12#\n" +
+	"\rtotal_actions\x18\a \x01(\x05R\ftotalActions\x12*\n" +
+	"\x11capacity_per_hour\x18\b \x01(\x01R\x0fcapacityPerHour\x12@\n" +
+	"\rdaily_volumes\x18\t \x03(\v2\x1b.warming.SimulatedDayVolumeR\fdailyVolumes\x124\n" +
+	"\btimeline\x18\n" +
+	" \x03(\v2\x18.warming.SimulatedActionR\btimeline\x12-\n" +
+	"\x12timeline_truncated\x18\v \x01(\bR\x11timelineTruncated\x12<\n" +
+	"\n" +
+	"collisions\x18\f \x03(\v2\x1c.warming.SimulationCollisionR\n" +
+	"collisions\x12a\n" +
+	"\x10collision_counts\x18\r \x03(\v26.warming.SimulateScenarioResponse.CollisionCountsEntryR\x0fcollisionCounts\x1aB\n" +
+	"\x14CollisionCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"2\n" +
 	"\x14ListScenariosRequest\x12\x1a\n" +
 	"\bplatform\x18\x01 \x01(\tR\bplatform\"O\n" +
 	"\x15ListScenariosResponse\x126\n" +
@@ -1489,7 +3574,129 @@ const file_services_warming_service_proto_warming_proto_rawDesc = "" +
 	"\ffailed_tasks\x18\x05 \x01(\x03R\vfailedTasks\x12\x1f\n" +
 	"\vtotal_tasks\x18\x06 \x01(\x03R\n" +
 	"totalTasks\x12\x1a\n" +
-	"\bplatform\x18\a \x01(\tR\bplatform2\xb2\x06\n" +
+	"\bplatform\x18\a \x01(\tR\bplatform\"\xa9\x02\n" +
+	"\x10ActivityCalendar\x12\x1a\n" +
+	"\btimezone\x18\x01 \x01(\tR\btimezone\x12\x18\n" +
+	"\apersona\x18\x02 \x01(\tR\apersona\x12\x1b\n" +
+	"\twake_hour\x18\x03 \x01(\x05R\bwakeHour\x12\x1d\n" +
+	"\n" +
+	"sleep_hour\x18\x04 \x01(\x05R\tsleepHour\x12*\n" +
+	"\x11weekend_wake_hour\x18\x05 \x01(\x05R\x0fweekendWakeHour\x12,\n" +
+	"\x12weekend_sleep_hour\x18\x06 \x01(\x05R\x10weekendSleepHour\x12.\n" +
+	"\x13day_off_probability\x18\a \x01(\x01R\x11dayOffProbability\x12\x19\n" +
+	"\bdays_off\x18\b \x03(\tR\adaysOff\"o\n" +
+	"\x1dUpdateActivityCalendarRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x125\n" +
+	"\bcalendar\x18\x02 \x01(\v2\x19.warming.ActivityCalendarR\bcalendar\"-\n" +
+	"\x0fCapacityRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\"\x85\x04\n" +
+	"\x10PlatformCapacity\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12*\n" +
+	"\x11capacity_per_hour\x18\x02 \x01(\x01R\x0fcapacityPerHour\x12&\n" +
+	"\x0fdemand_per_hour\x18\x03 \x01(\x01R\rdemandPerHour\x12 \n" +
+	"\vutilization\x18\x04 \x01(\x01R\vutilization\x123\n" +
+	"\x16browser_limit_per_hour\x18\x05 \x01(\x01R\x13browserLimitPerHour\x12/\n" +
+	"\x14proxy_limit_per_hour\x18\x06 \x01(\x01R\x11proxyLimitPerHour\x121\n" +
+	"\x15platform_cap_per_hour\x18\a \x01(\x01R\x12platformCapPerHour\x12\x1e\n" +
+	"\n" +
+	"bottleneck\x18\b \x01(\tR\n" +
+	"bottleneck\x12,\n" +
+	"\x12avg_action_seconds\x18\t \x01(\x01R\x10avgActionSeconds\x122\n" +
+	"\x15expected_wait_seconds\x18\n" +
+	" \x01(\x01R\x13expectedWaitSeconds\x12!\n" +
+	"\factive_tasks\x18\v \x01(\x05R\vactiveTasks\x12!\n" +
+	"\fqueued_tasks\x18\f \x01(\x05R\vqueuedTasks\"K\n" +
+	"\x10CapacityResponse\x127\n" +
+	"\tplatforms\x18\x01 \x03(\v2\x19.warming.PlatformCapacityR\tplatforms\"\xaa\x01\n" +
+	"\x11ExperimentVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rscenario_type\x18\x02 \x01(\tR\fscenarioType\x12\x1f\n" +
+	"\vscenario_id\x18\x03 \x01(\tR\n" +
+	"scenarioId\x12#\n" +
+	"\rduration_days\x18\x04 \x01(\x05R\fdurationDays\x12\x16\n" +
+	"\x06weight\x18\x05 \x01(\x05R\x06weight\"\xd3\x02\n" +
+	"\n" +
+	"Experiment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bplatform\x18\x04 \x01(\tR\bplatform\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x126\n" +
+	"\bvariants\x18\x06 \x03(\v2\x1a.warming.ExperimentVariantR\bvariants\x129\n" +
+	"\n" +
+	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x129\n" +
+	"\n" +
+	"stopped_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstoppedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\"\xc2\x01\n" +
+	"\x17CreateExperimentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bplatform\x18\x03 \x01(\tR\bplatform\x126\n" +
+	"\bvariants\x18\x04 \x03(\v2\x1a.warming.ExperimentVariantR\bvariants\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\"8\n" +
+	"\x11ExperimentRequest\x12#\n" +
+	"\rexperiment_id\x18\x01 \x01(\tR\fexperimentId\"L\n" +
+	"\x16ListExperimentsRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"P\n" +
+	"\x17ListExperimentsResponse\x125\n" +
+	"\vexperiments\x18\x01 \x03(\v2\x13.warming.ExperimentR\vexperiments\"\x8a\x03\n" +
+	"\rVariantResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rscenario_type\x18\x02 \x01(\tR\fscenarioType\x12#\n" +
+	"\rduration_days\x18\x03 \x01(\x05R\fdurationDays\x12\x1a\n" +
+	"\bassigned\x18\x04 \x01(\x03R\bassigned\x12\x1f\n" +
+	"\vin_progress\x18\x05 \x01(\x03R\n" +
+	"inProgress\x12\x14\n" +
+	"\x05ready\x18\x06 \x01(\x03R\x05ready\x12\x16\n" +
+	"\x06banned\x18\a \x01(\x03R\x06banned\x12\x16\n" +
+	"\x06failed\x18\b \x01(\x03R\x06failed\x12\x1d\n" +
+	"\n" +
+	"ready_rate\x18\t \x01(\x01R\treadyRate\x12\x19\n" +
+	"\bban_rate\x18\n" +
+	" \x01(\x01R\abanRate\x12#\n" +
+	"\rsurvival_rate\x18\v \x01(\x01R\fsurvivalRate\x12\x17\n" +
+	"\ap_value\x18\f \x01(\x01R\x06pValue\x12 \n" +
+	"\vsignificant\x18\r \x01(\bR\vsignificant\"\xa3\x02\n" +
+	"\x11ExperimentResults\x12#\n" +
+	"\rexperiment_id\x18\x01 \x01(\tR\fexperimentId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bplatform\x18\x03 \x01(\tR\bplatform\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\acontrol\x18\x05 \x01(\tR\acontrol\x122\n" +
+	"\bvariants\x18\x06 \x03(\v2\x16.warming.VariantResultR\bvariants\x12\x16\n" +
+	"\x06winner\x18\a \x01(\tR\x06winner\x12;\n" +
+	"\vcomputed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAt\"\x98\x02\n" +
+	"\rActionRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\tR\x06taskId\x12\x1f\n" +
+	"\vaction_type\x18\x04 \x01(\tR\n" +
+	"actionType\x12\x18\n" +
+	"\aattempt\x18\x05 \x01(\x05R\aattempt\x12:\n" +
+	"\x06params\x18\x06 \x03(\v2\".warming.ActionRequest.ParamsEntryR\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x02\n" +
+	"\fActionResult\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"error_type\x18\x03 \x01(\tR\terrorType\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1c\n" +
+	"\tretryable\x18\x05 \x01(\bR\tretryable\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x06 \x01(\x03R\tlatencyMs\x12<\n" +
+	"\adetails\x18\a \x03(\v2\".warming.ActionResult.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xb4\v\n" +
 	"\x0eWarmingService\x12B\n" +
 	"\fStartWarming\x12\x1c.warming.StartWarmingRequest\x1a\x14.warming.WarmingTask\x12:\n" +
 	"\fPauseWarming\x12\x14.warming.TaskRequest\x1a\x14.warming.WarmingTask\x12;\n" +
@@ -1499,9 +3706,19 @@ const file_services_warming_service_proto_warming_proto_rawDesc = "" +
 	"\x14GetWarmingStatistics\x12\x1a.warming.StatisticsRequest\x1a\x1a.warming.WarmingStatistics\x12`\n" +
 	"\x15GetScenarioStatistics\x12\".warming.ScenarioStatisticsRequest\x1a#.warming.ScenarioStatisticsResponse\x12P\n" +
 	"\x14CreateCustomScenario\x12\x1e.warming.CreateScenarioRequest\x1a\x18.warming.WarmingScenario\x12P\n" +
-	"\x14UpdateCustomScenario\x12\x1e.warming.UpdateScenarioRequest\x1a\x18.warming.WarmingScenario\x12N\n" +
+	"\x14UpdateCustomScenario\x12\x1e.warming.UpdateScenarioRequest\x1a\x18.warming.WarmingScenario\x12W\n" +
+	"\x10ValidateScenario\x12 .warming.ValidateScenarioRequest\x1a!.warming.ValidateScenarioResponse\x12W\n" +
+	"\x10SimulateScenario\x12 .warming.SimulateScenarioRequest\x1a!.warming.SimulateScenarioResponse\x12N\n" +
 	"\rListScenarios\x12\x1d.warming.ListScenariosRequest\x1a\x1e.warming.ListScenariosResponse\x12B\n" +
-	"\tListTasks\x12\x19.warming.ListTasksRequest\x1a\x1a.warming.ListTasksResponseB:Z8github.com/grigta/conveer/services/warming-service/protob\x06proto3"
+	"\tListTasks\x12\x19.warming.ListTasksRequest\x1a\x1a.warming.ListTasksResponse\x12V\n" +
+	"\x16UpdateActivityCalendar\x12&.warming.UpdateActivityCalendarRequest\x1a\x14.warming.WarmingTask\x12B\n" +
+	"\vGetCapacity\x12\x18.warming.CapacityRequest\x1a\x19.warming.CapacityResponse\x12I\n" +
+	"\x10CreateExperiment\x12 .warming.CreateExperimentRequest\x1a\x13.warming.Experiment\x12A\n" +
+	"\x0eStopExperiment\x12\x1a.warming.ExperimentRequest\x1a\x13.warming.Experiment\x12T\n" +
+	"\x0fListExperiments\x12\x1f.warming.ListExperimentsRequest\x1a .warming.ListExperimentsResponse\x12N\n" +
+	"\x14GetExperimentResults\x12\x1a.warming.ExperimentRequest\x1a\x1a.warming.ExperimentResults2U\n" +
+	"\x0eActionExecutor\x12C\n" +
+	"\x0eExecuteActions\x12\x16.warming.ActionRequest\x1a\x15.warming.ActionResult(\x010\x01B:Z8github.com/grigta/conveer/services/warming-service/protob\x06proto3"
 
 var (
 	file_services_warming_service_proto_warming_proto_rawDescOnce sync.Once
@@ -1515,75 +3732,144 @@ func file_services_warming_service_proto_warming_proto_rawDescGZIP() []byte {
 	return file_services_warming_service_proto_warming_proto_rawDescData
 }
 
-var file_services_warming_service_proto_warming_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_services_warming_service_proto_warming_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_services_warming_service_proto_warming_proto_goTypes = []any{
-	(*StartWarmingRequest)(nil),        // 0: warming.StartWarmingRequest
-	(*TaskRequest)(nil),                // 1: warming.TaskRequest
-	(*WarmingTask)(nil),                // 2: warming.WarmingTask
-	(*StatisticsRequest)(nil),          // 3: warming.StatisticsRequest
-	(*WarmingStatistics)(nil),          // 4: warming.WarmingStatistics
-	(*ActionStatistic)(nil),            // 5: warming.ActionStatistic
-	(*ErrorStatistic)(nil),             // 6: warming.ErrorStatistic
-	(*DailyStatistic)(nil),             // 7: warming.DailyStatistic
-	(*CreateScenarioRequest)(nil),      // 8: warming.CreateScenarioRequest
-	(*UpdateScenarioRequest)(nil),      // 9: warming.UpdateScenarioRequest
-	(*WarmingScenario)(nil),            // 10: warming.WarmingScenario
-	(*ListScenariosRequest)(nil),       // 11: warming.ListScenariosRequest
-	(*ListScenariosResponse)(nil),      // 12: warming.ListScenariosResponse
-	(*ListTasksRequest)(nil),           // 13: warming.ListTasksRequest
-	(*ListTasksResponse)(nil),          // 14: warming.ListTasksResponse
-	(*ScenarioStatisticsRequest)(nil),  // 15: warming.ScenarioStatisticsRequest
-	(*ScenarioStatisticsResponse)(nil), // 16: warming.ScenarioStatisticsResponse
-	(*ScenarioStats)(nil),              // 17: warming.ScenarioStats
-	nil,                                // 18: warming.WarmingStatistics.ByPlatformEntry
-	nil,                                // 19: warming.WarmingStatistics.ByScenarioEntry
-	(*timestamppb.Timestamp)(nil),      // 20: google.protobuf.Timestamp
+	(*StartWarmingRequest)(nil),           // 0: warming.StartWarmingRequest
+	(*TaskRequest)(nil),                   // 1: warming.TaskRequest
+	(*WarmingTask)(nil),                   // 2: warming.WarmingTask
+	(*StatisticsRequest)(nil),             // 3: warming.StatisticsRequest
+	(*WarmingStatistics)(nil),             // 4: warming.WarmingStatistics
+	(*ActionStatistic)(nil),               // 5: warming.ActionStatistic
+	(*ErrorStatistic)(nil),                // 6: warming.ErrorStatistic
+	(*DailyStatistic)(nil),                // 7: warming.DailyStatistic
+	(*CreateScenarioRequest)(nil),         // 8: warming.CreateScenarioRequest
+	(*UpdateScenarioRequest)(nil),         // 9: warming.UpdateScenarioRequest
+	(*WarmingScenario)(nil),               // 10: warming.WarmingScenario
+	(*ValidateScenarioRequest)(nil),       // 11: warming.ValidateScenarioRequest
+	(*ScenarioTimelineStage)(nil),         // 12: warming.ScenarioTimelineStage
+	(*ValidateScenarioResponse)(nil),      // 13: warming.ValidateScenarioResponse
+	(*SimulateScenarioRequest)(nil),       // 14: warming.SimulateScenarioRequest
+	(*SimulatedAction)(nil),               // 15: warming.SimulatedAction
+	(*SimulatedDayVolume)(nil),            // 16: warming.SimulatedDayVolume
+	(*SimulationCollision)(nil),           // 17: warming.SimulationCollision
+	(*SimulateScenarioResponse)(nil),      // 18: warming.SimulateScenarioResponse
+	(*ListScenariosRequest)(nil),          // 19: warming.ListScenariosRequest
+	(*ListScenariosResponse)(nil),         // 20: warming.ListScenariosResponse
+	(*ListTasksRequest)(nil),              // 21: warming.ListTasksRequest
+	(*ListTasksResponse)(nil),             // 22: warming.ListTasksResponse
+	(*ScenarioStatisticsRequest)(nil),     // 23: warming.ScenarioStatisticsRequest
+	(*ScenarioStatisticsResponse)(nil),    // 24: warming.ScenarioStatisticsResponse
+	(*ScenarioStats)(nil),                 // 25: warming.ScenarioStats
+	(*ActivityCalendar)(nil),              // 26: warming.ActivityCalendar
+	(*UpdateActivityCalendarRequest)(nil), // 27: warming.UpdateActivityCalendarRequest
+	(*CapacityRequest)(nil),               // 28: warming.CapacityRequest
+	(*PlatformCapacity)(nil),              // 29: warming.PlatformCapacity
+	(*CapacityResponse)(nil),              // 30: warming.CapacityResponse
+	(*ExperimentVariant)(nil),             // 31: warming.ExperimentVariant
+	(*Experiment)(nil),                    // 32: warming.Experiment
+	(*CreateExperimentRequest)(nil),       // 33: warming.CreateExperimentRequest
+	(*ExperimentRequest)(nil),             // 34: warming.ExperimentRequest
+	(*ListExperimentsRequest)(nil),        // 35: warming.ListExperimentsRequest
+	(*ListExperimentsResponse)(nil),       // 36: warming.ListExperimentsResponse
+	(*VariantResult)(nil),                 // 37: warming.VariantResult
+	(*ExperimentResults)(nil),             // 38: warming.ExperimentResults
+	(*ActionRequest)(nil),                 // 39: warming.ActionRequest
+	(*ActionResult)(nil),                  // 40: warming.ActionResult
+	nil,                                   // 41: warming.WarmingStatistics.ByPlatformEntry
+	nil,                                   // 42: warming.WarmingStatistics.ByScenarioEntry
+	nil,                                   // 43: warming.ScenarioTimelineStage.ActionSharesEntry
+	nil,                                   // 44: warming.SimulatedDayVolume.ActionsByTypeEntry
+	nil,                                   // 45: warming.SimulateScenarioResponse.CollisionCountsEntry
+	nil,                                   // 46: warming.ActionRequest.ParamsEntry
+	nil,                                   // 47: warming.ActionResult.DetailsEntry
+	(*timestamppb.Timestamp)(nil),         // 48: google.protobuf.Timestamp
 }
 var file_services_warming_service_proto_warming_proto_depIdxs = []int32{
-	20, // 0: warming.WarmingTask.next_action_at:type_name -> google.protobuf.Timestamp
-	20, // 1: warming.WarmingTask.created_at:type_name -> google.protobuf.Timestamp
-	20, // 2: warming.WarmingTask.updated_at:type_name -> google.protobuf.Timestamp
-	20, // 3: warming.WarmingTask.completed_at:type_name -> google.protobuf.Timestamp
-	20, // 4: warming.StatisticsRequest.start_date:type_name -> google.protobuf.Timestamp
-	20, // 5: warming.StatisticsRequest.end_date:type_name -> google.protobuf.Timestamp
-	18, // 6: warming.WarmingStatistics.by_platform:type_name -> warming.WarmingStatistics.ByPlatformEntry
-	19, // 7: warming.WarmingStatistics.by_scenario:type_name -> warming.WarmingStatistics.ByScenarioEntry
-	5,  // 8: warming.WarmingStatistics.top_actions:type_name -> warming.ActionStatistic
-	6,  // 9: warming.WarmingStatistics.common_errors:type_name -> warming.ErrorStatistic
-	7,  // 10: warming.WarmingStatistics.daily_breakdown:type_name -> warming.DailyStatistic
-	20, // 11: warming.DailyStatistic.date:type_name -> google.protobuf.Timestamp
-	20, // 12: warming.WarmingScenario.created_at:type_name -> google.protobuf.Timestamp
-	20, // 13: warming.WarmingScenario.updated_at:type_name -> google.protobuf.Timestamp
-	10, // 14: warming.ListScenariosResponse.scenarios:type_name -> warming.WarmingScenario
-	2,  // 15: warming.ListTasksResponse.tasks:type_name -> warming.WarmingTask
-	17, // 16: warming.ScenarioStatisticsResponse.scenario_stats:type_name -> warming.ScenarioStats
-	0,  // 17: warming.WarmingService.StartWarming:input_type -> warming.StartWarmingRequest
-	1,  // 18: warming.WarmingService.PauseWarming:input_type -> warming.TaskRequest
-	1,  // 19: warming.WarmingService.ResumeWarming:input_type -> warming.TaskRequest
-	1,  // 20: warming.WarmingService.StopWarming:input_type -> warming.TaskRequest
-	1,  // 21: warming.WarmingService.GetWarmingStatus:input_type -> warming.TaskRequest
-	3,  // 22: warming.WarmingService.GetWarmingStatistics:input_type -> warming.StatisticsRequest
-	15, // 23: warming.WarmingService.GetScenarioStatistics:input_type -> warming.ScenarioStatisticsRequest
-	8,  // 24: warming.WarmingService.CreateCustomScenario:input_type -> warming.CreateScenarioRequest
-	9,  // 25: warming.WarmingService.UpdateCustomScenario:input_type -> warming.UpdateScenarioRequest
-	11, // 26: warming.WarmingService.ListScenarios:input_type -> warming.ListScenariosRequest
-	13, // 27: warming.WarmingService.ListTasks:input_type -> warming.ListTasksRequest
-	2,  // 28: warming.WarmingService.StartWarming:output_type -> warming.WarmingTask
-	2,  // 29: warming.WarmingService.PauseWarming:output_type -> warming.WarmingTask
-	2,  // 30: warming.WarmingService.ResumeWarming:output_type -> warming.WarmingTask
-	2,  // 31: warming.WarmingService.StopWarming:output_type -> warming.WarmingTask
-	2,  // 32: warming.WarmingService.GetWarmingStatus:output_type -> warming.WarmingTask
-	4,  // 33: warming.WarmingService.GetWarmingStatistics:output_type -> warming.WarmingStatistics
-	16, // 34: warming.WarmingService.GetScenarioStatistics:output_type -> warming.ScenarioStatisticsResponse
-	10, // 35: warming.WarmingService.CreateCustomScenario:output_type -> warming.WarmingScenario
-	10, // 36: warming.WarmingService.UpdateCustomScenario:output_type -> warming.WarmingScenario
-	12, // 37: warming.WarmingService.ListScenarios:output_type -> warming.ListScenariosResponse
-	14, // 38: warming.WarmingService.ListTasks:output_type -> warming.ListTasksResponse
-	28, // [28:39] is the sub-list for method output_type
-	17, // [17:28] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	48, // 0: warming.WarmingTask.next_action_at:type_name -> google.protobuf.Timestamp
+	48, // 1: warming.WarmingTask.created_at:type_name -> google.protobuf.Timestamp
+	48, // 2: warming.WarmingTask.updated_at:type_name -> google.protobuf.Timestamp
+	48, // 3: warming.WarmingTask.completed_at:type_name -> google.protobuf.Timestamp
+	26, // 4: warming.WarmingTask.calendar:type_name -> warming.ActivityCalendar
+	48, // 5: warming.StatisticsRequest.start_date:type_name -> google.protobuf.Timestamp
+	48, // 6: warming.StatisticsRequest.end_date:type_name -> google.protobuf.Timestamp
+	41, // 7: warming.WarmingStatistics.by_platform:type_name -> warming.WarmingStatistics.ByPlatformEntry
+	42, // 8: warming.WarmingStatistics.by_scenario:type_name -> warming.WarmingStatistics.ByScenarioEntry
+	5,  // 9: warming.WarmingStatistics.top_actions:type_name -> warming.ActionStatistic
+	6,  // 10: warming.WarmingStatistics.common_errors:type_name -> warming.ErrorStatistic
+	7,  // 11: warming.WarmingStatistics.daily_breakdown:type_name -> warming.DailyStatistic
+	48, // 12: warming.DailyStatistic.date:type_name -> google.protobuf.Timestamp
+	48, // 13: warming.WarmingScenario.created_at:type_name -> google.protobuf.Timestamp
+	48, // 14: warming.WarmingScenario.updated_at:type_name -> google.protobuf.Timestamp
+	43, // 15: warming.ScenarioTimelineStage.action_shares:type_name -> warming.ScenarioTimelineStage.ActionSharesEntry
+	12, // 16: warming.ValidateScenarioResponse.stages:type_name -> warming.ScenarioTimelineStage
+	48, // 17: warming.SimulateScenarioRequest.start_at:type_name -> google.protobuf.Timestamp
+	48, // 18: warming.SimulatedAction.at:type_name -> google.protobuf.Timestamp
+	44, // 19: warming.SimulatedDayVolume.actions_by_type:type_name -> warming.SimulatedDayVolume.ActionsByTypeEntry
+	48, // 20: warming.SimulationCollision.at:type_name -> google.protobuf.Timestamp
+	48, // 21: warming.SimulateScenarioResponse.start_at:type_name -> google.protobuf.Timestamp
+	16, // 22: warming.SimulateScenarioResponse.daily_volumes:type_name -> warming.SimulatedDayVolume
+	15, // 23: warming.SimulateScenarioResponse.timeline:type_name -> warming.SimulatedAction
+	17, // 24: warming.SimulateScenarioResponse.collisions:type_name -> warming.SimulationCollision
+	45, // 25: warming.SimulateScenarioResponse.collision_counts:type_name -> warming.SimulateScenarioResponse.CollisionCountsEntry
+	10, // 26: warming.ListScenariosResponse.scenarios:type_name -> warming.WarmingScenario
+	2,  // 27: warming.ListTasksResponse.tasks:type_name -> warming.WarmingTask
+	25, // 28: warming.ScenarioStatisticsResponse.scenario_stats:type_name -> warming.ScenarioStats
+	26, // 29: warming.UpdateActivityCalendarRequest.calendar:type_name -> warming.ActivityCalendar
+	29, // 30: warming.CapacityResponse.platforms:type_name -> warming.PlatformCapacity
+	31, // 31: warming.Experiment.variants:type_name -> warming.ExperimentVariant
+	48, // 32: warming.Experiment.started_at:type_name -> google.protobuf.Timestamp
+	48, // 33: warming.Experiment.stopped_at:type_name -> google.protobuf.Timestamp
+	31, // 34: warming.CreateExperimentRequest.variants:type_name -> warming.ExperimentVariant
+	32, // 35: warming.ListExperimentsResponse.experiments:type_name -> warming.Experiment
+	37, // 36: warming.ExperimentResults.variants:type_name -> warming.VariantResult
+	48, // 37: warming.ExperimentResults.computed_at:type_name -> google.protobuf.Timestamp
+	46, // 38: warming.ActionRequest.params:type_name -> warming.ActionRequest.ParamsEntry
+	47, // 39: warming.ActionResult.details:type_name -> warming.ActionResult.DetailsEntry
+	0,  // 40: warming.WarmingService.StartWarming:input_type -> warming.StartWarmingRequest
+	1,  // 41: warming.WarmingService.PauseWarming:input_type -> warming.TaskRequest
+	1,  // 42: warming.WarmingService.ResumeWarming:input_type -> warming.TaskRequest
+	1,  // 43: warming.WarmingService.StopWarming:input_type -> warming.TaskRequest
+	1,  // 44: warming.WarmingService.GetWarmingStatus:input_type -> warming.TaskRequest
+	3,  // 45: warming.WarmingService.GetWarmingStatistics:input_type -> warming.StatisticsRequest
+	23, // 46: warming.WarmingService.GetScenarioStatistics:input_type -> warming.ScenarioStatisticsRequest
+	8,  // 47: warming.WarmingService.CreateCustomScenario:input_type -> warming.CreateScenarioRequest
+	9,  // 48: warming.WarmingService.UpdateCustomScenario:input_type -> warming.UpdateScenarioRequest
+	11, // 49: warming.WarmingService.ValidateScenario:input_type -> warming.ValidateScenarioRequest
+	14, // 50: warming.WarmingService.SimulateScenario:input_type -> warming.SimulateScenarioRequest
+	19, // 51: warming.WarmingService.ListScenarios:input_type -> warming.ListScenariosRequest
+	21, // 52: warming.WarmingService.ListTasks:input_type -> warming.ListTasksRequest
+	27, // 53: warming.WarmingService.UpdateActivityCalendar:input_type -> warming.UpdateActivityCalendarRequest
+	28, // 54: warming.WarmingService.GetCapacity:input_type -> warming.CapacityRequest
+	33, // 55: warming.WarmingService.CreateExperiment:input_type -> warming.CreateExperimentRequest
+	34, // 56: warming.WarmingService.StopExperiment:input_type -> warming.ExperimentRequest
+	35, // 57: warming.WarmingService.ListExperiments:input_type -> warming.ListExperimentsRequest
+	34, // 58: warming.WarmingService.GetExperimentResults:input_type -> warming.ExperimentRequest
+	39, // 59: warming.ActionExecutor.ExecuteActions:input_type -> warming.ActionRequest
+	2,  // 60: warming.WarmingService.StartWarming:output_type -> warming.WarmingTask
+	2,  // 61: warming.WarmingService.PauseWarming:output_type -> warming.WarmingTask
+	2,  // 62: warming.WarmingService.ResumeWarming:output_type -> warming.WarmingTask
+	2,  // 63: warming.WarmingService.StopWarming:output_type -> warming.WarmingTask
+	2,  // 64: warming.WarmingService.GetWarmingStatus:output_type -> warming.WarmingTask
+	4,  // 65: warming.WarmingService.GetWarmingStatistics:output_type -> warming.WarmingStatistics
+	24, // 66: warming.WarmingService.GetScenarioStatistics:output_type -> warming.ScenarioStatisticsResponse
+	10, // 67: warming.WarmingService.CreateCustomScenario:output_type -> warming.WarmingScenario
+	10, // 68: warming.WarmingService.UpdateCustomScenario:output_type -> warming.WarmingScenario
+	13, // 69: warming.WarmingService.ValidateScenario:output_type -> warming.ValidateScenarioResponse
+	18, // 70: warming.WarmingService.SimulateScenario:output_type -> warming.SimulateScenarioResponse
+	20, // 71: warming.WarmingService.ListScenarios:output_type -> warming.ListScenariosResponse
+	22, // 72: warming.WarmingService.ListTasks:output_type -> warming.ListTasksResponse
+	2,  // 73: warming.WarmingService.UpdateActivityCalendar:output_type -> warming.WarmingTask
+	30, // 74: warming.WarmingService.GetCapacity:output_type -> warming.CapacityResponse
+	32, // 75: warming.WarmingService.CreateExperiment:output_type -> warming.Experiment
+	32, // 76: warming.WarmingService.StopExperiment:output_type -> warming.Experiment
+	36, // 77: warming.WarmingService.ListExperiments:output_type -> warming.ListExperimentsResponse
+	38, // 78: warming.WarmingService.GetExperimentResults:output_type -> warming.ExperimentResults
+	40, // 79: warming.ActionExecutor.ExecuteActions:output_type -> warming.ActionResult
+	60, // [60:80] is the sub-list for method output_type
+	40, // [40:60] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_services_warming_service_proto_warming_proto_init() }
@@ -1597,9 +3883,9 @@ func file_services_warming_service_proto_warming_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_warming_service_proto_warming_proto_rawDesc), len(file_services_warming_service_proto_warming_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   48,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_services_warming_service_proto_warming_proto_goTypes,
 		DependencyIndexes: file_services_warming_service_proto_warming_proto_depIdxs,
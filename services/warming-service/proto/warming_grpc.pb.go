@@ -19,17 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WarmingService_StartWarming_FullMethodName          = "/warming.WarmingService/StartWarming"
-	WarmingService_PauseWarming_FullMethodName          = "/warming.WarmingService/PauseWarming"
-	WarmingService_ResumeWarming_FullMethodName         = "/warming.WarmingService/ResumeWarming"
-	WarmingService_StopWarming_FullMethodName           = "/warming.WarmingService/StopWarming"
-	WarmingService_GetWarmingStatus_FullMethodName      = "/warming.WarmingService/GetWarmingStatus"
-	WarmingService_GetWarmingStatistics_FullMethodName  = "/warming.WarmingService/GetWarmingStatistics"
-	WarmingService_GetScenarioStatistics_FullMethodName = "/warming.WarmingService/GetScenarioStatistics"
-	WarmingService_CreateCustomScenario_FullMethodName  = "/warming.WarmingService/CreateCustomScenario"
-	WarmingService_UpdateCustomScenario_FullMethodName  = "/warming.WarmingService/UpdateCustomScenario"
-	WarmingService_ListScenarios_FullMethodName         = "/warming.WarmingService/ListScenarios"
-	WarmingService_ListTasks_FullMethodName             = "/warming.WarmingService/ListTasks"
+	WarmingService_StartWarming_FullMethodName           = "/warming.WarmingService/StartWarming"
+	WarmingService_PauseWarming_FullMethodName           = "/warming.WarmingService/PauseWarming"
+	WarmingService_ResumeWarming_FullMethodName          = "/warming.WarmingService/ResumeWarming"
+	WarmingService_StopWarming_FullMethodName            = "/warming.WarmingService/StopWarming"
+	WarmingService_GetWarmingStatus_FullMethodName       = "/warming.WarmingService/GetWarmingStatus"
+	WarmingService_GetWarmingStatistics_FullMethodName   = "/warming.WarmingService/GetWarmingStatistics"
+	WarmingService_GetScenarioStatistics_FullMethodName  = "/warming.WarmingService/GetScenarioStatistics"
+	WarmingService_CreateCustomScenario_FullMethodName   = "/warming.WarmingService/CreateCustomScenario"
+	WarmingService_UpdateCustomScenario_FullMethodName   = "/warming.WarmingService/UpdateCustomScenario"
+	WarmingService_ValidateScenario_FullMethodName       = "/warming.WarmingService/ValidateScenario"
+	WarmingService_SimulateScenario_FullMethodName       = "/warming.WarmingService/SimulateScenario"
+	WarmingService_ListScenarios_FullMethodName          = "/warming.WarmingService/ListScenarios"
+	WarmingService_ListTasks_FullMethodName              = "/warming.WarmingService/ListTasks"
+	WarmingService_UpdateActivityCalendar_FullMethodName = "/warming.WarmingService/UpdateActivityCalendar"
+	WarmingService_GetCapacity_FullMethodName            = "/warming.WarmingService/GetCapacity"
+	WarmingService_CreateExperiment_FullMethodName       = "/warming.WarmingService/CreateExperiment"
+	WarmingService_StopExperiment_FullMethodName         = "/warming.WarmingService/StopExperiment"
+	WarmingService_ListExperiments_FullMethodName        = "/warming.WarmingService/ListExperiments"
+	WarmingService_GetExperimentResults_FullMethodName   = "/warming.WarmingService/GetExperimentResults"
 )
 
 // WarmingServiceClient is the client API for WarmingService service.
@@ -45,8 +53,16 @@ type WarmingServiceClient interface {
 	GetScenarioStatistics(ctx context.Context, in *ScenarioStatisticsRequest, opts ...grpc.CallOption) (*ScenarioStatisticsResponse, error)
 	CreateCustomScenario(ctx context.Context, in *CreateScenarioRequest, opts ...grpc.CallOption) (*WarmingScenario, error)
 	UpdateCustomScenario(ctx context.Context, in *UpdateScenarioRequest, opts ...grpc.CallOption) (*WarmingScenario, error)
+	ValidateScenario(ctx context.Context, in *ValidateScenarioRequest, opts ...grpc.CallOption) (*ValidateScenarioResponse, error)
+	SimulateScenario(ctx context.Context, in *SimulateScenarioRequest, opts ...grpc.CallOption) (*SimulateScenarioResponse, error)
 	ListScenarios(ctx context.Context, in *ListScenariosRequest, opts ...grpc.CallOption) (*ListScenariosResponse, error)
 	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	UpdateActivityCalendar(ctx context.Context, in *UpdateActivityCalendarRequest, opts ...grpc.CallOption) (*WarmingTask, error)
+	GetCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResponse, error)
+	CreateExperiment(ctx context.Context, in *CreateExperimentRequest, opts ...grpc.CallOption) (*Experiment, error)
+	StopExperiment(ctx context.Context, in *ExperimentRequest, opts ...grpc.CallOption) (*Experiment, error)
+	ListExperiments(ctx context.Context, in *ListExperimentsRequest, opts ...grpc.CallOption) (*ListExperimentsResponse, error)
+	GetExperimentResults(ctx context.Context, in *ExperimentRequest, opts ...grpc.CallOption) (*ExperimentResults, error)
 }
 
 type warmingServiceClient struct {
@@ -147,6 +163,26 @@ func (c *warmingServiceClient) UpdateCustomScenario(ctx context.Context, in *Upd
 	return out, nil
 }
 
+func (c *warmingServiceClient) ValidateScenario(ctx context.Context, in *ValidateScenarioRequest, opts ...grpc.CallOption) (*ValidateScenarioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateScenarioResponse)
+	err := c.cc.Invoke(ctx, WarmingService_ValidateScenario_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warmingServiceClient) SimulateScenario(ctx context.Context, in *SimulateScenarioRequest, opts ...grpc.CallOption) (*SimulateScenarioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimulateScenarioResponse)
+	err := c.cc.Invoke(ctx, WarmingService_SimulateScenario_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *warmingServiceClient) ListScenarios(ctx context.Context, in *ListScenariosRequest, opts ...grpc.CallOption) (*ListScenariosResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListScenariosResponse)
@@ -167,6 +203,66 @@ func (c *warmingServiceClient) ListTasks(ctx context.Context, in *ListTasksReque
 	return out, nil
 }
 
+func (c *warmingServiceClient) UpdateActivityCalendar(ctx context.Context, in *UpdateActivityCalendarRequest, opts ...grpc.CallOption) (*WarmingTask, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WarmingTask)
+	err := c.cc.Invoke(ctx, WarmingService_UpdateActivityCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warmingServiceClient) GetCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CapacityResponse)
+	err := c.cc.Invoke(ctx, WarmingService_GetCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warmingServiceClient) CreateExperiment(ctx context.Context, in *CreateExperimentRequest, opts ...grpc.CallOption) (*Experiment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Experiment)
+	err := c.cc.Invoke(ctx, WarmingService_CreateExperiment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warmingServiceClient) StopExperiment(ctx context.Context, in *ExperimentRequest, opts ...grpc.CallOption) (*Experiment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Experiment)
+	err := c.cc.Invoke(ctx, WarmingService_StopExperiment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warmingServiceClient) ListExperiments(ctx context.Context, in *ListExperimentsRequest, opts ...grpc.CallOption) (*ListExperimentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExperimentsResponse)
+	err := c.cc.Invoke(ctx, WarmingService_ListExperiments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warmingServiceClient) GetExperimentResults(ctx context.Context, in *ExperimentRequest, opts ...grpc.CallOption) (*ExperimentResults, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExperimentResults)
+	err := c.cc.Invoke(ctx, WarmingService_GetExperimentResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WarmingServiceServer is the server API for WarmingService service.
 // All implementations must embed UnimplementedWarmingServiceServer
 // for forward compatibility.
@@ -180,8 +276,16 @@ type WarmingServiceServer interface {
 	GetScenarioStatistics(context.Context, *ScenarioStatisticsRequest) (*ScenarioStatisticsResponse, error)
 	CreateCustomScenario(context.Context, *CreateScenarioRequest) (*WarmingScenario, error)
 	UpdateCustomScenario(context.Context, *UpdateScenarioRequest) (*WarmingScenario, error)
+	ValidateScenario(context.Context, *ValidateScenarioRequest) (*ValidateScenarioResponse, error)
+	SimulateScenario(context.Context, *SimulateScenarioRequest) (*SimulateScenarioResponse, error)
 	ListScenarios(context.Context, *ListScenariosRequest) (*ListScenariosResponse, error)
 	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	UpdateActivityCalendar(context.Context, *UpdateActivityCalendarRequest) (*WarmingTask, error)
+	GetCapacity(context.Context, *CapacityRequest) (*CapacityResponse, error)
+	CreateExperiment(context.Context, *CreateExperimentRequest) (*Experiment, error)
+	StopExperiment(context.Context, *ExperimentRequest) (*Experiment, error)
+	ListExperiments(context.Context, *ListExperimentsRequest) (*ListExperimentsResponse, error)
+	GetExperimentResults(context.Context, *ExperimentRequest) (*ExperimentResults, error)
 	mustEmbedUnimplementedWarmingServiceServer()
 }
 
@@ -219,12 +323,36 @@ func (UnimplementedWarmingServiceServer) CreateCustomScenario(context.Context, *
 func (UnimplementedWarmingServiceServer) UpdateCustomScenario(context.Context, *UpdateScenarioRequest) (*WarmingScenario, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateCustomScenario not implemented")
 }
+func (UnimplementedWarmingServiceServer) ValidateScenario(context.Context, *ValidateScenarioRequest) (*ValidateScenarioResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateScenario not implemented")
+}
+func (UnimplementedWarmingServiceServer) SimulateScenario(context.Context, *SimulateScenarioRequest) (*SimulateScenarioResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SimulateScenario not implemented")
+}
 func (UnimplementedWarmingServiceServer) ListScenarios(context.Context, *ListScenariosRequest) (*ListScenariosResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListScenarios not implemented")
 }
 func (UnimplementedWarmingServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListTasks not implemented")
 }
+func (UnimplementedWarmingServiceServer) UpdateActivityCalendar(context.Context, *UpdateActivityCalendarRequest) (*WarmingTask, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateActivityCalendar not implemented")
+}
+func (UnimplementedWarmingServiceServer) GetCapacity(context.Context, *CapacityRequest) (*CapacityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCapacity not implemented")
+}
+func (UnimplementedWarmingServiceServer) CreateExperiment(context.Context, *CreateExperimentRequest) (*Experiment, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateExperiment not implemented")
+}
+func (UnimplementedWarmingServiceServer) StopExperiment(context.Context, *ExperimentRequest) (*Experiment, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopExperiment not implemented")
+}
+func (UnimplementedWarmingServiceServer) ListExperiments(context.Context, *ListExperimentsRequest) (*ListExperimentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListExperiments not implemented")
+}
+func (UnimplementedWarmingServiceServer) GetExperimentResults(context.Context, *ExperimentRequest) (*ExperimentResults, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetExperimentResults not implemented")
+}
 func (UnimplementedWarmingServiceServer) mustEmbedUnimplementedWarmingServiceServer() {}
 func (UnimplementedWarmingServiceServer) testEmbeddedByValue()                        {}
 
@@ -408,6 +536,42 @@ func _WarmingService_UpdateCustomScenario_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WarmingService_ValidateScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarmingServiceServer).ValidateScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarmingService_ValidateScenario_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarmingServiceServer).ValidateScenario(ctx, req.(*ValidateScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarmingService_SimulateScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarmingServiceServer).SimulateScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarmingService_SimulateScenario_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarmingServiceServer).SimulateScenario(ctx, req.(*SimulateScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WarmingService_ListScenarios_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListScenariosRequest)
 	if err := dec(in); err != nil {
@@ -444,6 +608,114 @@ func _WarmingService_ListTasks_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WarmingService_UpdateActivityCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateActivityCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarmingServiceServer).UpdateActivityCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarmingService_UpdateActivityCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarmingServiceServer).UpdateActivityCalendar(ctx, req.(*UpdateActivityCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarmingService_GetCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarmingServiceServer).GetCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarmingService_GetCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarmingServiceServer).GetCapacity(ctx, req.(*CapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarmingService_CreateExperiment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExperimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarmingServiceServer).CreateExperiment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarmingService_CreateExperiment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarmingServiceServer).CreateExperiment(ctx, req.(*CreateExperimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarmingService_StopExperiment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExperimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarmingServiceServer).StopExperiment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarmingService_StopExperiment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarmingServiceServer).StopExperiment(ctx, req.(*ExperimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarmingService_ListExperiments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExperimentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarmingServiceServer).ListExperiments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarmingService_ListExperiments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarmingServiceServer).ListExperiments(ctx, req.(*ListExperimentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarmingService_GetExperimentResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExperimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarmingServiceServer).GetExperimentResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarmingService_GetExperimentResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarmingServiceServer).GetExperimentResults(ctx, req.(*ExperimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WarmingService_ServiceDesc is the grpc.ServiceDesc for WarmingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -487,6 +759,14 @@ var WarmingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCustomScenario",
 			Handler:    _WarmingService_UpdateCustomScenario_Handler,
 		},
+		{
+			MethodName: "ValidateScenario",
+			Handler:    _WarmingService_ValidateScenario_Handler,
+		},
+		{
+			MethodName: "SimulateScenario",
+			Handler:    _WarmingService_SimulateScenario_Handler,
+		},
 		{
 			MethodName: "ListScenarios",
 			Handler:    _WarmingService_ListScenarios_Handler,
@@ -495,7 +775,133 @@ var WarmingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTasks",
 			Handler:    _WarmingService_ListTasks_Handler,
 		},
+		{
+			MethodName: "UpdateActivityCalendar",
+			Handler:    _WarmingService_UpdateActivityCalendar_Handler,
+		},
+		{
+			MethodName: "GetCapacity",
+			Handler:    _WarmingService_GetCapacity_Handler,
+		},
+		{
+			MethodName: "CreateExperiment",
+			Handler:    _WarmingService_CreateExperiment_Handler,
+		},
+		{
+			MethodName: "StopExperiment",
+			Handler:    _WarmingService_StopExperiment_Handler,
+		},
+		{
+			MethodName: "ListExperiments",
+			Handler:    _WarmingService_ListExperiments_Handler,
+		},
+		{
+			MethodName: "GetExperimentResults",
+			Handler:    _WarmingService_GetExperimentResults_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/warming-service/proto/warming.proto",
 }
+
+const (
+	ActionExecutor_ExecuteActions_FullMethodName = "/warming.ActionExecutor/ExecuteActions"
+)
+
+// ActionExecutorClient is the client API for ActionExecutor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ActionExecutor is served by platform services. The warming executor keeps one stream open per
+// platform, sends actions as they come due and gets a result for each one as soon as it finishes.
+type ActionExecutorClient interface {
+	ExecuteActions(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ActionRequest, ActionResult], error)
+}
+
+type actionExecutorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewActionExecutorClient(cc grpc.ClientConnInterface) ActionExecutorClient {
+	return &actionExecutorClient{cc}
+}
+
+func (c *actionExecutorClient) ExecuteActions(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ActionRequest, ActionResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ActionExecutor_ServiceDesc.Streams[0], ActionExecutor_ExecuteActions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ActionRequest, ActionResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ActionExecutor_ExecuteActionsClient = grpc.BidiStreamingClient[ActionRequest, ActionResult]
+
+// ActionExecutorServer is the server API for ActionExecutor service.
+// All implementations must embed UnimplementedActionExecutorServer
+// for forward compatibility.
+//
+// ActionExecutor is served by platform services. The warming executor keeps one stream open per
+// platform, sends actions as they come due and gets a result for each one as soon as it finishes.
+type ActionExecutorServer interface {
+	ExecuteActions(grpc.BidiStreamingServer[ActionRequest, ActionResult]) error
+	mustEmbedUnimplementedActionExecutorServer()
+}
+
+// UnimplementedActionExecutorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedActionExecutorServer struct{}
+
+func (UnimplementedActionExecutorServer) ExecuteActions(grpc.BidiStreamingServer[ActionRequest, ActionResult]) error {
+	return status.Error(codes.Unimplemented, "method ExecuteActions not implemented")
+}
+func (UnimplementedActionExecutorServer) mustEmbedUnimplementedActionExecutorServer() {}
+func (UnimplementedActionExecutorServer) testEmbeddedByValue()                        {}
+
+// UnsafeActionExecutorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ActionExecutorServer will
+// result in compilation errors.
+type UnsafeActionExecutorServer interface {
+	mustEmbedUnimplementedActionExecutorServer()
+}
+
+func RegisterActionExecutorServer(s grpc.ServiceRegistrar, srv ActionExecutorServer) {
+	// If the following call panics, it indicates UnimplementedActionExecutorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ActionExecutor_ServiceDesc, srv)
+}
+
+func _ActionExecutor_ExecuteActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ActionExecutorServer).ExecuteActions(&grpc.GenericServerStream[ActionRequest, ActionResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ActionExecutor_ExecuteActionsServer = grpc.BidiStreamingServer[ActionRequest, ActionResult]
+
+// ActionExecutor_ServiceDesc is the grpc.ServiceDesc for ActionExecutor service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ActionExecutor_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "warming.ActionExecutor",
+	HandlerType: (*ActionExecutorServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExecuteActions",
+			Handler:       _ActionExecutor_ExecuteActions_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "services/warming-service/proto/warming.proto",
+}